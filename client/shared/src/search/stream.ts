@@ -179,6 +179,8 @@ interface Alert {
     title: string
     description?: string | null
     proposedQueries: ProposedQuery[] | null
+    /** Less important alerts which also apply to the search. */
+    notices?: Omit<Alert, 'notices'>[]
 }
 
 interface ProposedQuery {
@@ -0,0 +1,29 @@
+import React, { FunctionComponent } from 'react'
+
+import { LsifIndexFields } from '../../../graphql-operations'
+
+export interface CodeIntelIndexInferenceProps {
+    node: LsifIndexFields
+}
+
+export const CodeIntelIndexInference: FunctionComponent<CodeIntelIndexInferenceProps> = ({ node }) =>
+    node.inference ? (
+        <div className="card mb-3">
+            <div className="card-body">
+                <h3 className="card-title">
+                    Inferred from the repository structure with{' '}
+                    {Math.round(node.inference.confidence * 100)}% confidence
+                </h3>
+                <ul className="card-text mb-2">
+                    {node.inference.reasons.map(reason => (
+                        <li key={reason}>{reason}</li>
+                    ))}
+                </ul>
+                <small className="text-muted">
+                    If this job is incorrect, configure the index jobs of this repository in the index configuration.
+                </small>
+            </div>
+        </div>
+    ) : (
+        <></>
+    )
@@ -164,6 +164,10 @@ const fetch = (
         inputCommit: '9ea5e9f0e0344f8197622df6b36faf48ccd02570',
         inputRoot: 'web/',
         inputIndexer: 'sourcegraph/lsif-go:latest',
+        inference: {
+            confidence: 0.9,
+            reasons: ['found go.mod at web'],
+        },
         ...index,
     })
 
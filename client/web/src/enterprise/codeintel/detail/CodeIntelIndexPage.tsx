@@ -18,6 +18,7 @@ import { CodeIntelStateBanner } from '../shared/CodeIntelStateBanner'
 
 import { deleteLsifIndex, fetchLsifIndex as defaultFetchLsifIndex } from './backend'
 import { CodeIntelAssociatedUpload } from './CodeIntelAssociatedUpload'
+import { CodeIntelIndexInference } from './CodeIntelIndexInference'
 import { CodeIntelIndexMeta } from './CodeIntelIndexMeta'
 import { CodeIntelIndexTimeline } from './CodeIntelIndexTimeline'
 
@@ -121,6 +122,7 @@ export const CodeIntelIndexPage: FunctionComponent<CodeIntelIndexPageProps> = ({
                         className={classNamesByState.get(indexOrError.state)}
                     />
                     <CodeIntelIndexMeta node={indexOrError} now={now} />
+                    <CodeIntelIndexInference node={indexOrError} />
                     <CodeIntelAssociatedUpload node={indexOrError} now={now} />
 
                     <h3>Timeline</h3>
@@ -70,7 +70,7 @@ add('Page', () => {
 const fetch = (
     ...indexes: Omit<
         LsifIndexFields,
-        '__typename' | 'projectRoot' | 'inputCommit' | 'inputRoot' | 'inputIndexer' | 'steps'
+        '__typename' | 'projectRoot' | 'inputCommit' | 'inputRoot' | 'inputIndexer' | 'steps' | 'inference'
     >[]
 ): (() => Observable<IndexConnection>) => () =>
     of({
@@ -106,6 +106,7 @@ const fetch = (
                 upload: executionLog,
                 teardown: [executionLog],
             },
+            inference: null,
             ...index,
         })),
         totalCount: 10,
@@ -75,6 +75,10 @@ export const lsifIndexFieldsFragment = gql`
             finishedAt
             placeInQueue
         }
+        inference {
+            confidence
+            reasons
+        }
     }
     fragment LsifIndexStepsFields on IndexSteps {
         setup {
@@ -26,7 +26,7 @@ export const GlobalAlert: React.FunctionComponent<{ alert: GQL.IAlert; className
     return <div className={className}>{content}</div>
 }
 
-function alertClassForType(type: AlertType): string {
+export function alertClassForType(type: AlertType): string {
     switch (type) {
         case AlertType.INFO:
             return 'info'
@@ -4,6 +4,7 @@ import * as React from 'react'
 import { Subscription } from 'rxjs'
 
 import { Markdown } from '@sourcegraph/shared/src/components/Markdown'
+import * as GQL from '@sourcegraph/shared/src/graphql/schema'
 import { isSettingsValid, SettingsCascadeProps } from '@sourcegraph/shared/src/settings/settings'
 import { renderMarkdown } from '@sourcegraph/shared/src/util/markdown'
 
@@ -11,14 +12,14 @@ import { AuthenticatedUser } from '../auth'
 import { DismissibleAlert } from '../components/DismissibleAlert'
 import { Settings } from '../schema/settings.schema'
 import { SiteFlags } from '../site'
-import { siteFlags } from '../site/backend'
+import { fetchAnnouncements, siteFlags } from '../site/backend'
 import { CodeHostScopeAlerts, GitLabScopeAlert } from '../site/CodeHostScopeAlerts/CodeHostScopeAlerts'
 import { DockerForMacAlert } from '../site/DockerForMacAlert'
 import { FreeUsersExceededAlert } from '../site/FreeUsersExceededAlert'
 import { LicenseExpirationAlert } from '../site/LicenseExpirationAlert'
 import { NeedsRepositoryConfigurationAlert } from '../site/NeedsRepositoryConfigurationAlert'
 
-import { GlobalAlert } from './GlobalAlert'
+import { alertClassForType, GlobalAlert } from './GlobalAlert'
 import { Notices } from './Notices'
 
 interface Props extends SettingsCascadeProps {
@@ -27,6 +28,7 @@ interface Props extends SettingsCascadeProps {
 
 interface State {
     siteFlags?: SiteFlags
+    announcements?: GQL.IAnnouncement[]
 }
 
 /**
@@ -39,6 +41,12 @@ export class GlobalAlerts extends React.PureComponent<Props, State> {
 
     public componentDidMount(): void {
         this.subscriptions.add(siteFlags.subscribe(siteFlags => this.setState({ siteFlags })))
+        this.subscriptions.add(
+            fetchAnnouncements().subscribe(
+                announcements => this.setState({ announcements }),
+                error => console.error(error)
+            )
+        )
     }
 
     public componentWillUnmount(): void {
@@ -87,6 +95,15 @@ export class GlobalAlerts extends React.PureComponent<Props, State> {
                             })()}
                     </>
                 )}
+                {this.state.announcements?.map(announcement => (
+                    <DismissibleAlert
+                        key={announcement.id}
+                        partialStorageKey={`announcement.${announcement.id}.${announcement.updatedAt}`}
+                        className={`alert-${alertClassForType(announcement.severity)} global-alerts__alert`}
+                    >
+                        <Markdown dangerousInnerHTML={renderMarkdown(announcement.message)} />
+                    </DismissibleAlert>
+                ))}
                 {isSettingsValid<Settings>(this.props.settingsCascade) &&
                     this.props.settingsCascade.final.motd &&
                     Array.isArray(this.props.settingsCascade.final.motd) &&
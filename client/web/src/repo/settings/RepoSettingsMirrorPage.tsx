@@ -89,7 +89,7 @@ class UpdateMirrorRepositoryActionContainer extends React.PureComponent<UpdateMi
                     )}
                     {this.props.repo.mirrorInfo.updateQueue && !this.props.repo.mirrorInfo.updateQueue.updating && (
                         <div>
-                            Queued for update (position {this.props.repo.mirrorInfo.updateQueue.index + 1} out of{' '}
+                            Queued for update (position {this.props.repo.mirrorInfo.updateQueue.position + 1} out of{' '}
                             {this.props.repo.mirrorInfo.updateQueue.total} in the queue)
                         </div>
                     )}
@@ -33,6 +33,7 @@ export const settingsAreaRepositoryFragment = gql`
                 updating
                 index
                 total
+                position
             }
         }
         externalServices {
@@ -286,6 +286,15 @@ export const StreamingSearchResults: React.FunctionComponent<StreamingSearchResu
                         versionContext={versionContext}
                     />
                 )}
+                {results?.alert?.notices?.map(notice => (
+                    <SearchAlert
+                        key={notice.title}
+                        alert={notice}
+                        caseSensitive={caseSensitive}
+                        patternType={patternType}
+                        versionContext={versionContext}
+                    />
+                ))}
 
                 {showSignUpCta && (
                     <div className="card my-2 mr-3 d-flex p-3 flex-row align-items-center">
@@ -4,7 +4,7 @@ import React from 'react'
 import { Observable, of } from 'rxjs'
 
 import { WebStory } from '../components/WebStory'
-import { OutOfBandMigrationFields } from '../graphql-operations'
+import { OutOfBandMigrationFields, SchemaMigrationFields } from '../graphql-operations'
 
 import { SiteAdminMigrationsPage } from './SiteAdminMigrationsPage'
 
@@ -23,6 +23,7 @@ add('3.23.2', () => (
             <SiteAdminMigrationsPage
                 {...props}
                 fetchAllMigrations={(): Observable<OutOfBandMigrationFields[]> => of(migrations)}
+                fetchSchemaMigrations={(): Observable<SchemaMigrationFields[]> => of(schemaMigrations)}
                 fetchSiteUpdateCheck={() => of({ productVersion: '3.23.2' })}
                 history={H.createMemoryHistory()}
                 now={now}
@@ -38,6 +39,7 @@ add('3.24.2', () => (
             <SiteAdminMigrationsPage
                 {...props}
                 fetchAllMigrations={(): Observable<OutOfBandMigrationFields[]> => of(migrations)}
+                fetchSchemaMigrations={(): Observable<SchemaMigrationFields[]> => of(schemaMigrations)}
                 fetchSiteUpdateCheck={() => of({ productVersion: '3.24.2' })}
                 history={H.createMemoryHistory()}
                 now={now}
@@ -53,6 +55,7 @@ add('3.25.2', () => (
             <SiteAdminMigrationsPage
                 {...props}
                 fetchAllMigrations={(): Observable<OutOfBandMigrationFields[]> => of(migrations)}
+                fetchSchemaMigrations={(): Observable<SchemaMigrationFields[]> => of(schemaMigrations)}
                 fetchSiteUpdateCheck={() => of({ productVersion: '3.25.2' })}
                 history={H.createMemoryHistory()}
                 now={now}
@@ -68,6 +71,7 @@ add('3.26.2', () => (
             <SiteAdminMigrationsPage
                 {...props}
                 fetchAllMigrations={(): Observable<OutOfBandMigrationFields[]> => of(migrations)}
+                fetchSchemaMigrations={(): Observable<SchemaMigrationFields[]> => of(schemaMigrations)}
                 fetchSiteUpdateCheck={() => of({ productVersion: '3.26.2' })}
                 history={H.createMemoryHistory()}
                 now={now}
@@ -83,6 +87,7 @@ add('3.27.2', () => (
             <SiteAdminMigrationsPage
                 {...props}
                 fetchAllMigrations={(): Observable<OutOfBandMigrationFields[]> => of(migrations)}
+                fetchSchemaMigrations={(): Observable<SchemaMigrationFields[]> => of(schemaMigrations)}
                 fetchSiteUpdateCheck={() => of({ productVersion: '3.27.2' })}
                 history={H.createMemoryHistory()}
                 now={now}
@@ -169,4 +174,31 @@ const migrations = [
     },
 ]
 
+const schemaMigrations: SchemaMigrationFields[] = [
+    {
+        database: 'frontend',
+        version: '1528395872',
+        name: 'repo_name_lower_not_null',
+        state: 'RUNNING',
+        step: 'backfilling repo.name_lower',
+        progress: 0.42,
+        message: null,
+        startedAt: '2021-03-05T11:50:00+00:00',
+        updatedAt: '2021-03-05T11:59:45+00:00',
+        finishedAt: null,
+    },
+    {
+        database: 'frontend',
+        version: '1528395871',
+        name: 'schema_migration_progress',
+        state: 'COMPLETED',
+        step: 'done',
+        progress: null,
+        message: null,
+        startedAt: '2021-03-05T11:49:00+00:00',
+        updatedAt: '2021-03-05T11:49:01+00:00',
+        finishedAt: '2021-03-05T11:49:01+00:00',
+    },
+]
+
 const now = () => new Date('2021-03-05T12:00:00+00:00')
@@ -18,15 +18,17 @@ import { Collapsible } from '../components/Collapsible'
 import { FilteredConnection, FilteredConnectionFilter, Connection } from '../components/FilteredConnection'
 import { PageTitle } from '../components/PageTitle'
 import { Timestamp } from '../components/time/Timestamp'
-import { OutOfBandMigrationFields } from '../graphql-operations'
+import { OutOfBandMigrationFields, SchemaMigrationFields, SchemaMigrationState } from '../graphql-operations'
 
 import {
     fetchAllOutOfBandMigrations as defaultFetchAllMigrations,
+    fetchSchemaMigrations as defaultFetchSchemaMigrations,
     fetchSiteUpdateCheck as defaultFetchSiteUpdateCheck,
 } from './backend'
 
 export interface SiteAdminMigrationsPageProps extends RouteComponentProps<{}>, TelemetryProps {
     fetchAllMigrations?: typeof defaultFetchAllMigrations
+    fetchSchemaMigrations?: typeof defaultFetchSchemaMigrations
     fetchSiteUpdateCheck?: () => Observable<{ productVersion: string }>
     now?: () => Date
 }
@@ -70,6 +72,7 @@ const DOWNGRADE_RANGE = 1
 
 export const SiteAdminMigrationsPage: React.FunctionComponent<SiteAdminMigrationsPageProps> = ({
     fetchAllMigrations = defaultFetchAllMigrations,
+    fetchSchemaMigrations = defaultFetchSchemaMigrations,
     fetchSiteUpdateCheck = defaultFetchSiteUpdateCheck,
     now,
     telemetryService,
@@ -150,12 +153,122 @@ export const SiteAdminMigrationsPage: React.FunctionComponent<SiteAdminMigration
                             defaultFilter="All"
                         />
                     </div>
+
+                    <SchemaMigrations fetchSchemaMigrations={fetchSchemaMigrations} now={now} />
                 </>
             )}
         </div>
     )
 }
 
+interface SchemaMigrationsProps {
+    fetchSchemaMigrations: typeof defaultFetchSchemaMigrations
+    now?: () => Date
+}
+
+const SchemaMigrations: React.FunctionComponent<SchemaMigrationsProps> = ({ fetchSchemaMigrations, now }) => {
+    const migrationsOrError = useObservable(
+        useMemo(
+            () =>
+                timer(0, REFRESH_INTERVAL_MS, undefined).pipe(
+                    concatMap(() =>
+                        fetchSchemaMigrations().pipe(catchError((error): [ErrorLike] => [asError(error)]))
+                    )
+                ),
+            [fetchSchemaMigrations]
+        )
+    )
+
+    return (
+        <>
+            <h2>Schema migrations</h2>
+
+            <p>
+                Schema migrations change the database schema when Sourcegraph starts up. Migrations that would rewrite
+                or scan large tables while holding a lock are refused during the peak hours configured with{' '}
+                <code>MIGRATION_PEAK_HOURS</code> unless <code>MIGRATION_ALLOW_DANGEROUS</code> is set.
+            </p>
+
+            {isErrorLike(migrationsOrError) ? (
+                <ErrorAlert prefix="Error loading schema migrations" error={migrationsOrError} />
+            ) : migrationsOrError === undefined ? (
+                <LoadingSpinner className="icon-inline" />
+            ) : migrationsOrError.length === 0 ? (
+                <p className="text-muted">No schema migrations have been recorded.</p>
+            ) : (
+                <table className="table">
+                    <thead>
+                        <tr>
+                            <th>Migration</th>
+                            <th>State</th>
+                            <th>Step</th>
+                            <th>Updated</th>
+                        </tr>
+                    </thead>
+                    <tbody>
+                        {migrationsOrError.map(migration => (
+                            <SchemaMigrationNode
+                                key={`${migration.database}-${migration.version}`}
+                                node={migration}
+                                now={now}
+                            />
+                        ))}
+                    </tbody>
+                </table>
+            )}
+        </>
+    )
+}
+
+const schemaMigrationStateClassNames: Record<SchemaMigrationState, string> = {
+    RUNNING: 'badge-primary',
+    BLOCKED: 'badge-warning',
+    FAILED: 'badge-danger',
+    COMPLETED: 'badge-success',
+}
+
+interface SchemaMigrationNodeProps {
+    node: SchemaMigrationFields
+    now?: () => Date
+}
+
+const SchemaMigrationNode: React.FunctionComponent<SchemaMigrationNodeProps> = ({ node, now }) => (
+    <tr>
+        <td>
+            <strong>{node.name}</strong>
+            <div className="text-muted">
+                {node.database} {node.version}
+            </div>
+        </td>
+        <td>
+            <span className={`badge ${schemaMigrationStateClassNames[node.state]}`}>{node.state.toLowerCase()}</span>
+        </td>
+        <td>
+            {node.step}
+            {node.progress !== null && node.state === 'RUNNING' && (
+                <div>
+                    <meter
+                        min={0}
+                        max={1}
+                        value={node.progress}
+                        data-tooltip={`${Math.floor(node.progress * 100)}%`}
+                        aria-label="schema migration progress"
+                        data-placement="bottom"
+                    />
+                </div>
+            )}
+            {node.message && (
+                <div>
+                    <code>{node.message}</code>
+                </div>
+            )}
+        </td>
+        <td className="text-nowrap">
+            <Timestamp date={node.finishedAt || node.updatedAt} now={now} noAbout={true} />
+        </td>
+    </tr>
+)
+
 interface MigrationBannersProps {
     migrations: OutOfBandMigrationFields[]
     fetchSiteUpdateCheck?: () => Observable<{ productVersion: string }>
@@ -52,6 +52,9 @@ import {
     OutOfBandMigrationFields,
     OutOfBandMigrationsResult,
     OutOfBandMigrationsVariables,
+    SchemaMigrationFields,
+    SchemaMigrationsResult,
+    SchemaMigrationsVariables,
 } from '../graphql-operations'
 
 /**
@@ -869,3 +872,34 @@ export function fetchAllOutOfBandMigrations(): Observable<OutOfBandMigrationFiel
         map(data => data.outOfBandMigrations)
     )
 }
+
+/**
+ * Fetches the progress of schema migrations.
+ */
+export function fetchSchemaMigrations(): Observable<SchemaMigrationFields[]> {
+    return requestGraphQL<SchemaMigrationsResult, SchemaMigrationsVariables>(
+        gql`
+            query SchemaMigrations {
+                schemaMigrations {
+                    ...SchemaMigrationFields
+                }
+            }
+
+            fragment SchemaMigrationFields on SchemaMigration {
+                database
+                version
+                name
+                state
+                step
+                progress
+                message
+                startedAt
+                updatedAt
+                finishedAt
+            }
+        `
+    ).pipe(
+        map(dataOrThrowErrors),
+        map(data => data.schemaMigrations)
+    )
+}
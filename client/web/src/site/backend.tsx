@@ -1,7 +1,8 @@
 import { Observable, ReplaySubject } from 'rxjs'
-import { filter, mergeMap, take, tap } from 'rxjs/operators'
+import { filter, map, mergeMap, take, tap } from 'rxjs/operators'
 
 import { gql } from '@sourcegraph/shared/src/graphql/graphql'
+import * as GQL from '@sourcegraph/shared/src/graphql/schema'
 import { createAggregateError } from '@sourcegraph/shared/src/util/errors'
 
 import { authRequired } from '../auth'
@@ -72,6 +73,29 @@ export function refreshSiteFlags(): Observable<never> {
     )
 }
 
+/**
+ * Fetches the announcements currently displayed to the viewer.
+ */
+export function fetchAnnouncements(): Observable<GQL.IAnnouncement[]> {
+    return queryGraphQL(gql`
+        query Announcements {
+            announcements {
+                id
+                message
+                severity
+                updatedAt
+            }
+        }
+    `).pipe(
+        map(({ data, errors }) => {
+            if (!data || !data.announcements) {
+                throw createAggregateError(errors)
+            }
+            return data.announcements
+        })
+    )
+}
+
 refreshSiteFlags()
     .toPromise()
     .then(
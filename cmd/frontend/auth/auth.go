@@ -34,8 +34,10 @@ func RegisterMiddlewares(m ...*Middleware) {
 // AuthMiddleware returns the authentication middleware that combines all authentication middlewares
 // that have been registered.
 func AuthMiddleware() *Middleware {
-	m := make([]*Middleware, 0, 1+len(extraAuthMiddlewares))
-	m = append(m, RequireAuthMiddleware)
+	m := make([]*Middleware, 0, 2+len(extraAuthMiddlewares))
+	// 🚨 SECURITY: networkPolicyAnonymousMiddleware is applied first so that it runs last, after
+	// the actor has been determined.
+	m = append(m, networkPolicyAnonymousMiddleware, RequireAuthMiddleware)
 	m = append(m, extraAuthMiddlewares...)
 	return composeMiddleware(m...)
 }
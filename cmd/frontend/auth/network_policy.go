@@ -0,0 +1,301 @@
+package auth
+
+import (
+	"fmt"
+	"net"
+	"net/http"
+	"net/url"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func init() {
+	conf.ContributeValidator(validateNetworkPolicy)
+}
+
+// NetworkPolicyMiddleware rejects requests from client IP addresses that are not allowed by the
+// networkAccessPolicy site configuration, either for the whole site or for the path rule that
+// matches the request.
+//
+// 🚨 SECURITY: This must wrap all handlers that are accessible to external clients.
+func NetworkPolicyMiddleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		p := currentNetworkPolicy()
+		if p == nil {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		ip := p.clientIP(r)
+		if !p.allows(ip) {
+			const msg = "Access to Sourcegraph from your network address is forbidden by the site configuration."
+			http.Error(w, msg, http.StatusForbidden)
+			return
+		}
+		if rule := p.ruleFor(r); rule != nil && !rule.allows(ip) {
+			const msg = "Access to this path from your network address is forbidden by the site configuration."
+			http.Error(w, msg, http.StatusForbidden)
+			return
+		}
+
+		next.ServeHTTP(w, r)
+	})
+}
+
+// networkPolicyAnonymousMiddleware rejects requests from anonymous users to paths whose
+// networkAccessPolicy path rule sets denyAnonymous. It runs after the other auth middlewares so
+// that the actor is known.
+//
+// 🚨 SECURITY: Any change to this function could introduce security exploits.
+var networkPolicyAnonymousMiddleware = &Middleware{
+	API: func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if denyAnonymousRequest(r) {
+				http.Error(w, "Access to this path requires authentication.", http.StatusUnauthorized)
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	},
+	App: func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if denyAnonymousRequest(r) {
+				q := url.Values{}
+				q.Set("returnTo", r.URL.String())
+				http.Redirect(w, r, "/sign-in?"+q.Encode(), anonymousStatusCode(r, http.StatusFound))
+				return
+			}
+
+			next.ServeHTTP(w, r)
+		})
+	},
+}
+
+// denyAnonymousRequest reports whether req is from an anonymous user and matches a path rule that
+// denies anonymous access. Requests that are needed to sign in are never denied.
+func denyAnonymousRequest(req *http.Request) bool {
+	if actor.FromContext(req.Context()).IsAuthenticated() {
+		return false
+	}
+
+	p := currentNetworkPolicy()
+	if p == nil {
+		return false
+	}
+	if rule := p.ruleFor(req); rule == nil || !rule.denyAnonymous {
+		return false
+	}
+
+	return !isSignInRequest(req)
+}
+
+// isSignInRequest reports whether req is needed by anonymous users to sign in: the sign-in pages,
+// the auth provider endpoints and static assets.
+func isSignInRequest(req *http.Request) bool {
+	if strings.HasPrefix(req.URL.Path, "/.assets/") || strings.HasPrefix(req.URL.Path, AuthURLPrefix+"/") {
+		return true
+	}
+	return isAnonymousAccessibleRoute(req)
+}
+
+// networkPolicy is the parsed form of the networkAccessPolicy site configuration.
+type networkPolicy struct {
+	trustedProxies ipSet
+	allow          ipSet
+	deny           ipSet
+	rules          []*networkPathRule
+}
+
+type networkPathRule struct {
+	pathPrefix    string
+	allow         ipSet
+	deny          ipSet
+	denyAnonymous bool
+}
+
+var networkPolicyCache struct {
+	sync.Mutex
+	config *schema.NetworkAccessPolicy
+	policy *networkPolicy
+}
+
+// currentNetworkPolicy returns the parsed networkAccessPolicy from the current site configuration,
+// or nil if none is set. The parsed policy is reused until the site configuration changes.
+func currentNetworkPolicy() *networkPolicy {
+	config := conf.Get().NetworkAccessPolicy
+	if config == nil {
+		return nil
+	}
+
+	networkPolicyCache.Lock()
+	defer networkPolicyCache.Unlock()
+
+	if networkPolicyCache.config != config {
+		policy, errs := parseNetworkPolicy(config)
+		for _, err := range errs {
+			log15.Error("Ignoring invalid entry in networkAccessPolicy.", "error", err)
+		}
+
+		networkPolicyCache.config = config
+		networkPolicyCache.policy = policy
+	}
+
+	return networkPolicyCache.policy
+}
+
+// parseNetworkPolicy parses config. Invalid addresses are skipped and returned as errors.
+func parseNetworkPolicy(config *schema.NetworkAccessPolicy) (*networkPolicy, []error) {
+	var errs []error
+	parse := func(field string, values []string) ipSet {
+		set, setErrs := parseIPSet(field, values)
+		errs = append(errs, setErrs...)
+		return set
+	}
+
+	p := &networkPolicy{
+		trustedProxies: parse("networkAccessPolicy.trustedProxies", config.TrustedProxies),
+		allow:          parse("networkAccessPolicy.allow", config.Allow),
+		deny:           parse("networkAccessPolicy.deny", config.Deny),
+	}
+	for i, rule := range config.PathRules {
+		field := fmt.Sprintf("networkAccessPolicy.pathRules[%d]", i)
+		p.rules = append(p.rules, &networkPathRule{
+			pathPrefix:    rule.PathPrefix,
+			allow:         parse(field+".allow", rule.Allow),
+			deny:          parse(field+".deny", rule.Deny),
+			denyAnonymous: rule.DenyAnonymous,
+		})
+	}
+
+	return p, errs
+}
+
+// clientIP returns the IP address of the client that sent r. The X-Forwarded-For header is only
+// consulted for requests from trusted proxies, from the closest hop backwards, so that clients
+// cannot choose their own address by setting the header. It returns nil if the address cannot be
+// determined.
+func (p *networkPolicy) clientIP(r *http.Request) net.IP {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		host = r.RemoteAddr
+	}
+	ip := net.ParseIP(host)
+	if ip == nil || !p.trustedProxies.contains(ip) {
+		return ip
+	}
+
+	var hops []string
+	for _, value := range r.Header.Values("X-Forwarded-For") {
+		hops = append(hops, strings.Split(value, ",")...)
+	}
+	for i := len(hops) - 1; i >= 0; i-- {
+		ip = net.ParseIP(strings.TrimSpace(hops[i]))
+		if ip == nil || !p.trustedProxies.contains(ip) {
+			return ip
+		}
+	}
+
+	return ip
+}
+
+func (p *networkPolicy) allows(ip net.IP) bool {
+	return allowsIP(p.allow, p.deny, ip)
+}
+
+// ruleFor returns the first path rule matching the path of r, or nil if there is none.
+func (p *networkPolicy) ruleFor(r *http.Request) *networkPathRule {
+	requestPath := cleanRequestPath(r.URL.Path)
+	for _, rule := range p.rules {
+		if strings.HasPrefix(requestPath, rule.pathPrefix) {
+			return rule
+		}
+	}
+	return nil
+}
+
+func (r *networkPathRule) allows(ip net.IP) bool {
+	return allowsIP(r.allow, r.deny, ip)
+}
+
+// allowsIP reports whether ip is not in deny and, if allow is non-empty, is in allow. A client
+// whose address is unknown is only allowed if both lists are empty.
+func allowsIP(allow, deny ipSet, ip net.IP) bool {
+	if len(allow) == 0 && len(deny) == 0 {
+		return true
+	}
+	if ip == nil || deny.contains(ip) {
+		return false
+	}
+	return len(allow) == 0 || allow.contains(ip)
+}
+
+// cleanRequestPath resolves "." and ".." elements and repeated slashes in p so that path rules
+// cannot be bypassed with an equivalent path. A trailing slash is kept.
+func cleanRequestPath(p string) string {
+	cleaned := path.Clean("/" + p)
+	if strings.HasSuffix(p, "/") && cleaned != "/" {
+		cleaned += "/"
+	}
+	return cleaned
+}
+
+// ipSet is a list of IP networks. Single IP addresses are stored as networks of one address.
+type ipSet []*net.IPNet
+
+func parseIPSet(field string, values []string) (set ipSet, errs []error) {
+	for _, value := range values {
+		network, err := parseIPNet(value)
+		if err != nil {
+			errs = append(errs, errors.Errorf("%s: %q is not a valid IP address or CIDR range", field, value))
+			continue
+		}
+		set = append(set, network)
+	}
+	return set, errs
+}
+
+func parseIPNet(value string) (*net.IPNet, error) {
+	if strings.Contains(value, "/") {
+		_, network, err := net.ParseCIDR(value)
+		return network, err
+	}
+
+	ip := net.ParseIP(value)
+	if ip == nil {
+		return nil, errors.Errorf("invalid IP address: %s", value)
+	}
+	if ip4 := ip.To4(); ip4 != nil {
+		return &net.IPNet{IP: ip4, Mask: net.CIDRMask(32, 32)}, nil
+	}
+	return &net.IPNet{IP: ip, Mask: net.CIDRMask(128, 128)}, nil
+}
+
+func (s ipSet) contains(ip net.IP) bool {
+	for _, network := range s {
+		if network.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+func validateNetworkPolicy(c conf.Unified) (problems conf.Problems) {
+	if c.NetworkAccessPolicy == nil {
+		return nil
+	}
+
+	_, errs := parseNetworkPolicy(c.NetworkAccessPolicy)
+	for _, err := range errs {
+		problems = append(problems, conf.NewSiteProblem(err.Error()))
+	}
+	return problems
+}
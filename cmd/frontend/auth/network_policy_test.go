@@ -0,0 +1,145 @@
+package auth_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/app/ui"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestNetworkPolicyMiddleware(t *testing.T) {
+	handler := auth.NetworkPolicyMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "hello")
+	}))
+
+	policy := &schema.NetworkAccessPolicy{
+		TrustedProxies: []string{"10.0.0.1"},
+		Allow:          []string{"203.0.113.0/24", "2001:db8::/32"},
+		Deny:           []string{"203.0.113.7"},
+		PathRules: []*schema.NetworkAccessPathRule{
+			{PathPrefix: "/site-admin", Allow: []string{"203.0.113.10"}},
+		},
+	}
+
+	testCases := []struct {
+		name          string
+		policy        *schema.NetworkAccessPolicy
+		path          string
+		remoteAddr    string
+		xForwardedFor string
+		wantStatus    int
+	}{
+		{name: "no policy", path: "/", remoteAddr: "198.51.100.1:1234", wantStatus: http.StatusOK},
+		{name: "allowed", policy: policy, path: "/", remoteAddr: "203.0.113.1:1234", wantStatus: http.StatusOK},
+		{name: "allowed ipv6", policy: policy, path: "/", remoteAddr: "[2001:db8::1]:1234", wantStatus: http.StatusOK},
+		{name: "not allowed", policy: policy, path: "/", remoteAddr: "198.51.100.1:1234", wantStatus: http.StatusForbidden},
+		{name: "denied", policy: policy, path: "/", remoteAddr: "203.0.113.7:1234", wantStatus: http.StatusForbidden},
+		{name: "untrusted forwarded", policy: policy, path: "/", remoteAddr: "198.51.100.1:1234", xForwardedFor: "203.0.113.1", wantStatus: http.StatusForbidden},
+		{name: "trusted forwarded", policy: policy, path: "/", remoteAddr: "10.0.0.1:1234", xForwardedFor: "203.0.113.1", wantStatus: http.StatusOK},
+		{name: "trusted forwarded spoofed", policy: policy, path: "/", remoteAddr: "10.0.0.1:1234", xForwardedFor: "203.0.113.1, 198.51.100.1", wantStatus: http.StatusForbidden},
+		{name: "path rule allowed", policy: policy, path: "/site-admin/users", remoteAddr: "203.0.113.10:1234", wantStatus: http.StatusOK},
+		{name: "path rule not allowed", policy: policy, path: "/site-admin/users", remoteAddr: "203.0.113.1:1234", wantStatus: http.StatusForbidden},
+		{name: "path rule not allowed unclean path", policy: policy, path: "//foo/../site-admin", remoteAddr: "203.0.113.1:1234", wantStatus: http.StatusForbidden},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{NetworkAccessPolicy: testCase.policy}})
+			defer conf.Mock(nil)
+
+			req := httptest.NewRequest("GET", testCase.path, nil)
+			req.RemoteAddr = testCase.remoteAddr
+			if testCase.xForwardedFor != "" {
+				req.Header.Set("X-Forwarded-For", testCase.xForwardedFor)
+			}
+
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+			if rr.Code != testCase.wantStatus {
+				t.Errorf("got %d, want %d", rr.Code, testCase.wantStatus)
+			}
+		})
+	}
+}
+
+func TestNetworkPolicyDenyAnonymous(t *testing.T) {
+	db := new(dbtesting.MockDB)
+	ui.InitRouter(db, nil)
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		AuthProviders: []schema.AuthProviders{{Builtin: &schema.BuiltinAuthProvider{}}},
+		NetworkAccessPolicy: &schema.NetworkAccessPolicy{
+			PathRules: []*schema.NetworkAccessPathRule{
+				{PathPrefix: "/.api/gitlab-webhooks"},
+				{PathPrefix: "/.api/", DenyAnonymous: true},
+				{PathPrefix: "/", DenyAnonymous: true},
+			},
+		},
+	}})
+	defer conf.Mock(nil)
+
+	next := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "hello")
+	})
+	apiHandler := auth.AuthMiddleware().API(next)
+	appHandler := auth.AuthMiddleware().App(next)
+
+	withAuth := func(r *http.Request) *http.Request {
+		return r.WithContext(actor.WithActor(context.Background(), &actor.Actor{UID: 1}))
+	}
+
+	testCases := []struct {
+		name       string
+		handler    http.Handler
+		req        *http.Request
+		wantStatus int
+	}{
+		{name: "anonymous api", handler: apiHandler, req: httptest.NewRequest("POST", "/.api/github-webhooks", nil), wantStatus: http.StatusUnauthorized},
+		{name: "authenticated api", handler: apiHandler, req: withAuth(httptest.NewRequest("POST", "/.api/github-webhooks", nil)), wantStatus: http.StatusOK},
+		{name: "anonymous api earlier rule", handler: apiHandler, req: httptest.NewRequest("POST", "/.api/gitlab-webhooks", nil), wantStatus: http.StatusOK},
+		{name: "anonymous sign in", handler: appHandler, req: httptest.NewRequest("GET", "/sign-in", nil), wantStatus: http.StatusOK},
+		{name: "anonymous assets", handler: appHandler, req: httptest.NewRequest("GET", "/.assets/app.js", nil), wantStatus: http.StatusOK},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			rr := httptest.NewRecorder()
+			testCase.handler.ServeHTTP(rr, testCase.req)
+			if rr.Code != testCase.wantStatus {
+				t.Errorf("got %d, want %d", rr.Code, testCase.wantStatus)
+			}
+		})
+	}
+}
+
+func TestValidateNetworkPolicy(t *testing.T) {
+	problems, err := conf.Validate(conftypes.RawUnified{Site: `{
+		"auth.providers": [{"type": "builtin"}],
+		"networkAccessPolicy": {
+			"allow": ["203.0.113.0/24", "203.0.113.0/33"],
+			"pathRules": [{"pathPrefix": "/", "deny": ["not-an-ip"]}]
+		}
+	}`})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []string{
+		`networkAccessPolicy.allow: "203.0.113.0/33" is not a valid IP address or CIDR range`,
+		`networkAccessPolicy.pathRules[0].deny: "not-an-ip" is not a valid IP address or CIDR range`,
+	}
+	if diff := cmp.Diff(want, problems.Messages()); diff != "" {
+		t.Errorf("unexpected problems (-want +got):\n%s", diff)
+	}
+}
@@ -134,6 +134,12 @@ func AllowAnonymousRequest(req *http.Request) bool {
 		return true
 	}
 
+	return isAnonymousAccessibleRoute(req)
+}
+
+// isAnonymousAccessibleRoute reports whether req matches one of the routes that anonymous users
+// need in order to sign in, sign up or reset their password.
+func isAnonymousAccessibleRoute(req *http.Request) bool {
 	apiRouteName := matchedRouteName(req, router.Router())
 	if apiRouteName == router.UI {
 		// Test against UI router. (Some of its handlers inject private data into the title or meta tags.)
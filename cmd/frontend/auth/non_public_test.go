@@ -17,7 +17,7 @@ import (
 
 func TestAllowAnonymousRequest(t *testing.T) {
 	db := new(dbtesting.MockDB)
-	ui.InitRouter(db)
+	ui.InitRouter(db, nil)
 	// Ensure auth.public is false (be robust against some other tests having side effects that
 	// change it, or changed defaults).
 	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{AuthPublic: false, AuthProviders: []schema.AuthProviders{{Builtin: &schema.BuiltinAuthProvider{}}}}})
@@ -57,7 +57,7 @@ func TestAllowAnonymousRequest(t *testing.T) {
 func TestNewUserRequiredAuthzMiddleware(t *testing.T) {
 	db := new(dbtesting.MockDB)
 
-	ui.InitRouter(db)
+	ui.InitRouter(db, nil)
 	// Ensure auth.public is false (be robust against some other tests having side effects that
 	// change it, or changed defaults).
 	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{AuthPublic: false, AuthProviders: []schema.AuthProviders{{Builtin: &schema.BuiltinAuthProvider{}}}}})
@@ -3,8 +3,11 @@ package auth
 import (
 	"context"
 	"fmt"
+	"sync"
 
 	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
@@ -12,6 +15,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
 )
 
 var MockGetAndSaveUser func(ctx context.Context, op GetAndSaveUserOp) (userID int32, safeErrMsg string, err error)
@@ -22,6 +27,7 @@ type GetAndSaveUserOp struct {
 	ExternalAccountData extsvc.AccountData
 	CreateIfNotExist    bool
 	LookUpByUsername    bool
+	SkipEmailLookUp     bool
 }
 
 // GetAndSaveUser accepts authentication information associated with a given user, validates and applies
@@ -34,6 +40,7 @@ type GetAndSaveUserOp struct {
 //    c. If the email specified in op.UserProps is verified, Look up the user by verified email.
 //       If op.LookUpByUsername is true, look up by username instead of verified email.
 //       (Note: most clients should look up by email, as username is typically insecure.)
+//       If op.SkipEmailLookUp is true, skip this step.
 //    d. If op.CreateIfNotExist is true, attempt to create a new user with the properties
 //       specified in op.UserProps. This may fail if the desired username is already taken.
 //    e. If a new user is successfully created, attempt to grant pending permissions.
@@ -59,7 +66,7 @@ func GetAndSaveUser(ctx context.Context, db dbutil.DB, op GetAndSaveUserOp) (use
 		return MockGetAndSaveUser(ctx, op)
 	}
 
-	extacc := database.ExternalAccounts(db)
+	extacc := observedExternalAccounts(db)
 
 	userID, userSaved, extAcctSaved, safeErrMsg, err := func() (int32, bool, bool, string, error) {
 		if actor := actor.FromContext(ctx); actor.IsAuthenticated() {
@@ -85,7 +92,7 @@ func GetAndSaveUser(ctx context.Context, db dbutil.DB, op GetAndSaveUserOp) (use
 			if !op.CreateIfNotExist {
 				return 0, false, false, fmt.Sprintf("User account with username %q does not exist. Ask a site admin to create your account.", op.UserProps.Username), getByUsernameErr
 			}
-		} else if op.UserProps.EmailIsVerified {
+		} else if op.UserProps.EmailIsVerified && !op.SkipEmailLookUp {
 			user, getByVerifiedEmailErr := database.GlobalUsers.GetByVerifiedEmail(ctx, op.UserProps.Email)
 			if getByVerifiedEmailErr == nil {
 				return user.ID, false, false, "", nil
@@ -166,3 +173,21 @@ func GetAndSaveUser(ctx context.Context, db dbutil.DB, op GetAndSaveUserOp) (use
 
 	return userID, "", nil
 }
+
+var (
+	externalAccountsOperationsOnce sync.Once
+	externalAccountsOperations     *database.ObservedUserExternalAccountsStoreOperations
+)
+
+// observedExternalAccounts returns an external accounts store that records
+// metrics and traces for the queries run when users sign in.
+func observedExternalAccounts(db dbutil.DB) *database.ObservedUserExternalAccountsStore {
+	externalAccountsOperationsOnce.Do(func() {
+		externalAccountsOperations = database.NewObservedUserExternalAccountsStoreOperations(&observation.Context{
+			Logger:     log15.Root(),
+			Tracer:     &trace.Tracer{Tracer: opentracing.GlobalTracer()},
+			Registerer: prometheus.DefaultRegisterer,
+		})
+	})
+	return database.NewObservedUserExternalAccountsStore(database.ExternalAccounts(db), externalAccountsOperations)
+}
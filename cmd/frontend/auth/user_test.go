@@ -281,6 +281,27 @@ func TestGetAndSaveUser(t *testing.T) {
 				},
 				expCalledGrantPendingPermissions: true,
 			},
+			{
+				description: "ext acct doesn't exist, user with email exists, skipEmailLookUp=true",
+				op: GetAndSaveUserOp{
+					ExternalAccount: ext("st1", "s-new", "c1", "s-new/u1"),
+					UserProps:       userProps("u1", "u1@example.com", true),
+					SkipEmailLookUp: true,
+				},
+				expSafeErr: "It looks like this is your first time signing in with this external identity. Sourcegraph couldn't link it to an existing user, because no verified email was provided. Ask your site admin to configure the auth provider to include the user's verified email on sign-in.",
+				expErr:     &errcode.Mock{IsNotFound: true},
+			},
+			{
+				description: "ext acct exists, belongs to another user, authenticated",
+				actorUID:    1,
+				op: GetAndSaveUserOp{
+					ExternalAccount: ext("st1", "s1", "c1", "s1/u2"),
+					UserProps:       userProps("u2", "u2@example.com", true),
+				},
+				createIfNotExistIrrelevant: true,
+				expSafeErr:                 "Unexpected error associating the external account with your Sourcegraph user. The most likely cause for this problem is that another Sourcegraph user is already linked with this external account. A site admin or the other user can unlink the account to fix this problem.",
+				expErr:                     &database.ExternalAccountAssociatedWithOtherUserError{UserID: 2, RequestedUserID: 1},
+			},
 		},
 	}
 	errorCases := []outerCase{
@@ -654,7 +675,7 @@ func (m *mocks) AssociateUserAndSave(userID int32, spec extsvc.AccountSpec, data
 	for _, u := range m.userInfos {
 		for _, a := range u.extAccts {
 			if a == spec && u.user.ID != userID {
-				return errors.Errorf("unable to change association of external account from user %d to user %d (delete the external account and then try again)", u.user.ID, userID)
+				return &database.ExternalAccountAssociatedWithOtherUserError{UserID: u.user.ID, RequestedUserID: userID}
 			}
 		}
 	}
@@ -15,6 +15,9 @@ var ErrNotAuthenticated = errors.New("not authenticated")
 // CheckOrgAccessOrSiteAdmin returns an error if the user is NEITHER (1) a site
 // admin NOR (2) a member of the organization with the specified ID.
 //
+// Members of any team of the organization are members of the organization,
+// see checkUserIsOrgMember.
+//
 // It is used when an action on a user can be performed by site admins and the
 // organization's members, but nobody else.
 func CheckOrgAccessOrSiteAdmin(ctx context.Context, db dbutil.DB, orgID int32) error {
@@ -24,6 +27,9 @@ func CheckOrgAccessOrSiteAdmin(ctx context.Context, db dbutil.DB, orgID int32) e
 // CheckOrgAccess returns an error if the user is not a member of the
 // organization with the specified ID.
 //
+// Members of any team of the organization are members of the organization,
+// see checkUserIsOrgMember.
+//
 // It is used when an action on a user can be performed by the organization's
 // members, but nobody else.
 func CheckOrgAccess(ctx context.Context, db dbutil.DB, orgID int32) error {
@@ -51,11 +57,18 @@ func checkOrgAccess(ctx context.Context, db dbutil.DB, orgID int32, allowAdmin b
 
 var ErrNotAnOrgMember = errors.New("current user is not an org member")
 
+// checkUserIsOrgMember returns ErrNotAnOrgMember unless the user is a member
+// of the organization, either directly or through a team of the organization.
+//
+// 🚨 SECURITY: Team membership deliberately grants the same access as direct
+// membership, so that access follows the team structure synced from the code
+// host. Adding a user to any team of an organization, e.g. by syncing the
+// team's members from a GitHub team, gives them access to the organization.
 func checkUserIsOrgMember(ctx context.Context, db dbutil.DB, userID, orgID int32) error {
 	resp, err := database.OrgMembers(db).GetByOrgIDAndUserID(ctx, orgID, userID)
 	if err != nil {
 		if errcode.IsNotFound(err) {
-			return ErrNotAnOrgMember
+			return checkUserIsOrgTeamMember(ctx, db, userID, orgID)
 		}
 		return err
 	}
@@ -66,3 +79,16 @@ func checkUserIsOrgMember(ctx context.Context, db dbutil.DB, userID, orgID int32
 	}
 	return nil
 }
+
+// checkUserIsOrgTeamMember returns ErrNotAnOrgMember unless the user is a
+// member of a team of the organization.
+func checkUserIsOrgTeamMember(ctx context.Context, db dbutil.DB, userID, orgID int32) error {
+	ok, err := database.OrgTeams(db).IsOrgMember(ctx, orgID, userID)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrNotAnOrgMember
+	}
+	return nil
+}
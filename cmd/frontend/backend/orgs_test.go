@@ -0,0 +1,51 @@
+package backend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestCheckOrgAccess(t *testing.T) {
+	database.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		uid := actor.FromContext(ctx).UID
+		if uid == 0 {
+			return nil, database.ErrNoCurrentUser
+		}
+		return &types.User{ID: uid, SiteAdmin: uid == 4}, nil
+	}
+	// User 1 is a member of the organization, and user 2 a member of one of
+	// its teams.
+	database.Mocks.OrgMembers.GetByOrgIDAndUserID = func(ctx context.Context, orgID, userID int32) (*types.OrgMembership, error) {
+		if userID == 1 {
+			return &types.OrgMembership{OrgID: orgID, UserID: userID}, nil
+		}
+		return nil, &database.ErrOrgMemberNotFound{}
+	}
+	database.Mocks.OrgTeams.IsOrgMember = func(ctx context.Context, orgID, userID int32) (bool, error) {
+		return userID == 2, nil
+	}
+	defer func() { database.Mocks = database.MockStores{} }()
+
+	tests := []struct {
+		uid        int32
+		allowAdmin bool
+		wantErr    error
+	}{
+		{uid: 0, wantErr: ErrNotAuthenticated},
+		{uid: 1},
+		{uid: 2},
+		{uid: 3, wantErr: ErrNotAnOrgMember},
+		{uid: 4, wantErr: ErrNotAnOrgMember},
+		{uid: 4, allowAdmin: true},
+	}
+	for _, test := range tests {
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: test.uid})
+		if err := checkOrgAccess(ctx, nil, 1, test.allowAdmin); err != test.wantErr {
+			t.Errorf("user %d, allowAdmin %v: got error %v, want %v", test.uid, test.allowAdmin, err, test.wantErr)
+		}
+	}
+}
@@ -60,10 +60,12 @@ func (s *repos) Get(ctx context.Context, repo api.RepoID) (_ *types.Repo, err er
 	return s.store.Get(ctx, repo)
 }
 
-// GetByName retrieves the repository with the given name. On sourcegraph.com,
-// if the name refers to a repository on a github.com or gitlab.com that is not
-// yet present in the database, it will automatically look up the
-// repository externally and add it to the database before returning it.
+// GetByName retrieves the repository with the given name. If no repository has
+// the name, but a repository was renamed or moved on its code host from it,
+// that repository is returned instead. On sourcegraph.com, if the name refers
+// to a repository on a github.com or gitlab.com that is not yet present in the
+// database, it will automatically look up the repository externally and add it
+// to the database before returning it.
 func (s *repos) GetByName(ctx context.Context, name api.RepoName) (_ *types.Repo, err error) {
 	if Mocks.Repos.GetByName != nil {
 		return Mocks.Repos.GetByName(ctx, name)
@@ -73,6 +75,13 @@ func (s *repos) GetByName(ctx context.Context, name api.RepoName) (_ *types.Repo
 	defer done()
 
 	switch repo, err := s.store.GetByName(ctx, name); {
+	case err == nil:
+		return repo, nil
+	case !errcode.IsNotFound(err):
+		return nil, err
+	}
+
+	switch repo, err := s.store.GetByPreviousName(ctx, name); {
 	case err == nil:
 		return repo, nil
 	case !errcode.IsNotFound(err):
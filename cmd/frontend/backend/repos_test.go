@@ -2,6 +2,7 @@ package backend
 
 import (
 	"bytes"
+	"context"
 	"flag"
 	"fmt"
 	"io"
@@ -15,6 +16,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 	"github.com/sourcegraph/sourcegraph/internal/inventory"
 	"github.com/sourcegraph/sourcegraph/internal/rcache"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
@@ -45,6 +47,39 @@ func TestReposService_Get(t *testing.T) {
 	}
 }
 
+func TestReposService_GetByName_renamed(t *testing.T) {
+	var s repos
+	ctx := testContext()
+
+	wantRepo := &types.Repo{ID: 1, Name: "github.com/new/name"}
+
+	database.Mocks.Repos.GetByName = func(ctx context.Context, name api.RepoName) (*types.Repo, error) {
+		return nil, &database.RepoNotFoundErr{Name: name}
+	}
+	database.Mocks.Repos.GetByPreviousName = func(ctx context.Context, name api.RepoName) (*types.Repo, error) {
+		if name != "github.com/old/name" {
+			return nil, &database.RepoNotFoundErr{Name: name}
+		}
+		return wantRepo, nil
+	}
+	defer func() {
+		database.Mocks.Repos.GetByName = nil
+		database.Mocks.Repos.GetByPreviousName = nil
+	}()
+
+	repo, err := s.GetByName(ctx, "github.com/old/name")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(repo, wantRepo) {
+		t.Errorf("got %+v, want %+v", repo, wantRepo)
+	}
+
+	if _, err := s.GetByName(ctx, "example.com/other/name"); !errcode.IsNotFound(err) {
+		t.Errorf("got error %v, want not found", err)
+	}
+}
+
 func TestReposService_List(t *testing.T) {
 	var s repos
 	ctx := testContext()
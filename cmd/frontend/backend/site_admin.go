@@ -13,10 +13,55 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
-var ErrMustBeSiteAdmin = errors.New("must be site admin")
+var (
+	ErrMustBeSiteAdmin   = errors.New("must be site admin")
+	ErrMustBeSiteAuditor = errors.New("must be site admin or site auditor")
+)
 
-// CheckCurrentUserIsSiteAdmin returns an error if the current user is NOT a site admin.
-func CheckCurrentUserIsSiteAdmin(ctx context.Context, db dbutil.DB) error {
+// SiteRole is a built-in role of a user on the site. Each role can do everything that the
+// roles before it can.
+type SiteRole int
+
+const (
+	// SiteRoleUser is the role of regular users.
+	SiteRoleUser SiteRole = iota
+	// SiteRoleAuditor is the role of users who can view, but not change, site administration
+	// data such as the site configuration (with secrets redacted), background job status, the
+	// audit log and usage statistics.
+	SiteRoleAuditor
+	// SiteRoleAdmin is the role of site admins, who can view and change everything.
+	SiteRoleAdmin
+)
+
+// UserSiteRole returns the site role of the user.
+func UserSiteRole(user *types.User) SiteRole {
+	switch {
+	case user.SiteAdmin:
+		return SiteRoleAdmin
+	case user.SiteAuditor:
+		return SiteRoleAuditor
+	default:
+		return SiteRoleUser
+	}
+}
+
+// checkSiteRole returns an error if the user does NOT have at least the given site role.
+func checkSiteRole(user *types.User, role SiteRole) error {
+	if user == nil {
+		return ErrNotAuthenticated
+	}
+	if UserSiteRole(user) >= role {
+		return nil
+	}
+	if role == SiteRoleAuditor {
+		return ErrMustBeSiteAuditor
+	}
+	return ErrMustBeSiteAdmin
+}
+
+// CheckCurrentUserHasSiteRole returns an error if the current user does NOT have at least the
+// given site role.
+func CheckCurrentUserHasSiteRole(ctx context.Context, db dbutil.DB, role SiteRole) error {
 	if hasAuthzBypass(ctx) {
 		return nil
 	}
@@ -24,13 +69,18 @@ func CheckCurrentUserIsSiteAdmin(ctx context.Context, db dbutil.DB) error {
 	if err != nil {
 		return err
 	}
-	if user == nil {
-		return ErrNotAuthenticated
-	}
-	if !user.SiteAdmin {
-		return ErrMustBeSiteAdmin
-	}
-	return nil
+	return checkSiteRole(user, role)
+}
+
+// CheckCurrentUserIsSiteAdmin returns an error if the current user is NOT a site admin.
+func CheckCurrentUserIsSiteAdmin(ctx context.Context, db dbutil.DB) error {
+	return CheckCurrentUserHasSiteRole(ctx, db, SiteRoleAdmin)
+}
+
+// CheckCurrentUserIsSiteAuditor returns an error if the current user is NEITHER a site admin
+// NOR a site auditor. It is used for read-only access to site administration data.
+func CheckCurrentUserIsSiteAuditor(ctx context.Context, db dbutil.DB) error {
+	return CheckCurrentUserHasSiteRole(ctx, db, SiteRoleAuditor)
 }
 
 // CheckUserIsSiteAdmin returns an error if the user is NOT a site admin.
@@ -42,13 +92,7 @@ func CheckUserIsSiteAdmin(ctx context.Context, db dbutil.DB, userID int32) error
 	if err != nil {
 		return err
 	}
-	if user == nil {
-		return ErrNotAuthenticated
-	}
-	if !user.SiteAdmin {
-		return ErrMustBeSiteAdmin
-	}
-	return nil
+	return checkSiteRole(user, SiteRoleAdmin)
 }
 
 // InsufficientAuthorizationError is an error that occurs when the authentication is technically valid
@@ -0,0 +1,47 @@
+package backend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestCheckCurrentUserHasSiteRole(t *testing.T) {
+	users := map[int32]*types.User{
+		1: {ID: 1},
+		2: {ID: 2, SiteAuditor: true},
+		3: {ID: 3, SiteAdmin: true},
+	}
+	database.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		user, ok := users[actor.FromContext(ctx).UID]
+		if !ok {
+			return nil, database.ErrNoCurrentUser
+		}
+		return user, nil
+	}
+	defer func() { database.Mocks.Users = database.MockUsers{} }()
+
+	tests := []struct {
+		uid     int32
+		role    SiteRole
+		wantErr error
+	}{
+		{uid: 0, role: SiteRoleAuditor, wantErr: ErrNotAuthenticated},
+		{uid: 1, role: SiteRoleUser},
+		{uid: 1, role: SiteRoleAuditor, wantErr: ErrMustBeSiteAuditor},
+		{uid: 1, role: SiteRoleAdmin, wantErr: ErrMustBeSiteAdmin},
+		{uid: 2, role: SiteRoleAuditor},
+		{uid: 2, role: SiteRoleAdmin, wantErr: ErrMustBeSiteAdmin},
+		{uid: 3, role: SiteRoleAuditor},
+		{uid: 3, role: SiteRoleAdmin},
+	}
+	for _, test := range tests {
+		ctx := actor.WithActor(context.Background(), &actor.Actor{UID: test.uid})
+		if err := CheckCurrentUserHasSiteRole(ctx, nil, test.role); err != test.wantErr {
+			t.Errorf("user %d, role %d: got error %v, want %v", test.uid, test.role, err, test.wantErr)
+		}
+	}
+}
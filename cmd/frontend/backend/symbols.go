@@ -3,6 +3,9 @@ package backend
 import (
 	"context"
 
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/result"
 	symbolsclient "github.com/sourcegraph/sourcegraph/internal/symbols"
@@ -11,10 +14,35 @@ import (
 // Symbols backend.
 var Symbols = &symbols{}
 
+// PreciseSymbolsSource provides symbols extracted from precise code intelligence data.
+type PreciseSymbolsSource interface {
+	// ReposWithPreciseSymbols returns the subset of the given repositories for which
+	// precise symbols may be available.
+	ReposWithPreciseSymbols(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID]struct{}, error)
+
+	// ListPreciseSymbols returns the symbols matching the given parameters. The returned
+	// flag is false if there is no precise data for the given repository and commit.
+	ListPreciseSymbols(ctx context.Context, args search.SymbolsParameters) (result.Symbols, bool, error)
+}
+
+// PreciseSymbols is consulted by ListTags before falling back to ctags. It is set by the
+// enterprise code intelligence backend and is nil otherwise.
+var PreciseSymbols PreciseSymbolsSource
+
 type symbols struct{}
 
-// ListTags returns symbols in a repository from ctags.
+// ListTags returns symbols in a repository from precise code intelligence data if
+// available, and from ctags otherwise.
 func (symbols) ListTags(ctx context.Context, args search.SymbolsParameters) (result.Symbols, error) {
+	if PreciseSymbols != nil {
+		symbols, ok, err := PreciseSymbols.ListPreciseSymbols(ctx, args)
+		if err != nil {
+			log15.Warn("Failed to list precise symbols, falling back to ctags.", "repo", args.Repo, "commitID", args.CommitID, "err", err)
+		} else if ok {
+			return symbols, nil
+		}
+	}
+
 	result, err := symbolsclient.DefaultClient.Search(ctx, args)
 	if result == nil {
 		return nil, err
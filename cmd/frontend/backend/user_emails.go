@@ -176,7 +176,11 @@ func SendUserEmailVerificationEmail(ctx context.Context, username, email, code s
 	})
 }
 
-var verifyEmailTemplates = txemail.MustValidate(txtypes.Templates{
+var verifyEmailTemplates = txemail.MustRegister("verifyEmail", map[string]string{
+	"Username": "alice",
+	"URL":      "https://sourcegraph.example.com/-/verify-email?code=example",
+	"Host":     "sourcegraph.example.com",
+}, txtypes.Templates{
 	Subject: `Verify your email on Sourcegraph ({{.Host}})`,
 	Text: `Hi {{.Username}},
 
@@ -223,7 +227,12 @@ func (userEmails) SendUserEmailOnFieldUpdate(ctx context.Context, id int32, chan
 	})
 }
 
-var updateAccountEmailTemplate = txemail.MustValidate(txtypes.Templates{
+var updateAccountEmailTemplate = txemail.MustRegister("updateAccount", map[string]string{
+	"Email":    "alice@example.com",
+	"Change":   "updated the password",
+	"Username": "alice",
+	"Host":     "sourcegraph.example.com",
+}, txtypes.Templates{
 	Subject: `Update to your Sourcegraph account ({{.Host}})`,
 	Text: `
 Somebody (likely you) {{.Change}} for the user {{.Username}} on Sourcegraph ({{.Host}}).
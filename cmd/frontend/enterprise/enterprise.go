@@ -1,12 +1,14 @@
 package enterprise
 
 import (
+	"context"
 	"fmt"
 	"net/http"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/webhooks"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
 )
 
 // Services is a bag of HTTP handlers and factory functions that are registered by the
@@ -17,6 +19,7 @@ type Services struct {
 	BitbucketServerWebhook    http.Handler
 	NewCodeIntelUploadHandler NewCodeIntelUploadHandler
 	NewExecutorProxyHandler   NewExecutorProxyHandler
+	CodeIntelCoverage         CodeIntelCoverage
 	AuthzResolver             graphqlbackend.AuthzResolver
 	BatchChangesResolver      graphqlbackend.BatchChangesResolver
 	CodeIntelResolver         graphqlbackend.CodeIntelResolver
@@ -35,6 +38,10 @@ type NewCodeIntelUploadHandler func(internal bool) http.Handler
 // via a shared username and password.
 type NewExecutorProxyHandler func() http.Handler
 
+// CodeIntelCoverage returns the indexers of the uploads which provide precise code
+// intelligence at the tip of the default branch of a repository.
+type CodeIntelCoverage func(ctx context.Context, repoID api.RepoID) ([]string, error)
+
 // DefaultServices creates a new Services value that has default implementations for all services.
 func DefaultServices() Services {
 	return Services{
@@ -43,6 +50,7 @@ func DefaultServices() Services {
 		BitbucketServerWebhook:    makeNotFoundHandler("bitbucket server webhook"),
 		NewCodeIntelUploadHandler: func(_ bool) http.Handler { return makeNotFoundHandler("code intel upload") },
 		NewExecutorProxyHandler:   func() http.Handler { return makeNotFoundHandler("executor proxy") },
+		CodeIntelCoverage:         func(context.Context, api.RepoID) ([]string, error) { return nil, nil },
 	}
 }
 
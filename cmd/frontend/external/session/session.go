@@ -5,9 +5,10 @@ package session
 import "github.com/sourcegraph/sourcegraph/cmd/frontend/internal/session"
 
 var (
-	ResetMockSessionStore  = session.ResetMockSessionStore
-	SetActor               = session.SetActor
-	SetData                = session.SetData
-	GetData                = session.GetData
-	InvalidateSessionsByID = session.InvalidateSessionsByID
+	ResetMockSessionStore       = session.ResetMockSessionStore
+	SetActor                    = session.SetActor
+	SetActorFromExternalAccount = session.SetActorFromExternalAccount
+	SetData                     = session.SetData
+	GetData                     = session.GetData
+	InvalidateSessionsByID      = session.InvalidateSessionsByID
 )
@@ -0,0 +1,226 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// Announcements returns the announcements displayed to the viewer, or all announcements if
+// includeInactive is set.
+func (r *schemaResolver) Announcements(ctx context.Context, args *struct{ IncludeInactive bool }) ([]*announcementResolver, error) {
+	var opts database.AnnouncementsListOptions
+	if args.IncludeInactive {
+		// 🚨 SECURITY: Only site admins and site auditors may view all announcements.
+		if err := backend.CheckCurrentUserIsSiteAuditor(ctx, r.db); err != nil {
+			return nil, err
+		}
+	} else {
+		audiences, err := viewerAnnouncementAudiences(ctx, r.db)
+		if err != nil {
+			return nil, err
+		}
+		opts = database.AnnouncementsListOptions{OnlyActive: true, Audiences: audiences}
+	}
+
+	announcements, err := database.Announcements(r.db).List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*announcementResolver, 0, len(announcements))
+	for _, a := range announcements {
+		resolvers = append(resolvers, &announcementResolver{db: r.db, announcement: a})
+	}
+	return resolvers, nil
+}
+
+// viewerAnnouncementAudiences returns the announcement audiences the viewer belongs to.
+func viewerAnnouncementAudiences(ctx context.Context, db dbutil.DB) ([]types.AnnouncementAudience, error) {
+	audiences := []types.AnnouncementAudience{types.AnnouncementAudienceEveryone}
+	if !actor.FromContext(ctx).IsAuthenticated() {
+		return audiences, nil
+	}
+
+	user, err := backend.CurrentUser(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	if user == nil {
+		return audiences, nil
+	}
+	audiences = append(audiences, types.AnnouncementAudienceAuthenticatedUsers)
+	if user.SiteAdmin {
+		audiences = append(audiences, types.AnnouncementAudienceSiteAdmins)
+	}
+	return audiences, nil
+}
+
+func announcementByID(ctx context.Context, db dbutil.DB, id graphql.ID) (*announcementResolver, error) {
+	announcementID, err := unmarshalAnnouncementID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site admins and site auditors may view announcements by ID, because
+	// the announcement may not be for the viewer's audience or not be active.
+	if err := backend.CheckCurrentUserIsSiteAuditor(ctx, db); err != nil {
+		return nil, err
+	}
+
+	announcement, err := database.Announcements(db).GetByID(ctx, announcementID)
+	if err != nil {
+		return nil, err
+	}
+	return &announcementResolver{db: db, announcement: announcement}, nil
+}
+
+type announcementArgs struct {
+	Message  string
+	Severity string
+	Audience string
+	StartsAt *DateTime
+	EndsAt   *DateTime
+}
+
+func (args *announcementArgs) apply(a *types.Announcement) {
+	a.Message = args.Message
+	a.Severity = types.AnnouncementSeverity(args.Severity)
+	a.Audience = types.AnnouncementAudience(args.Audience)
+	a.StartsAt, a.EndsAt = time.Time{}, time.Time{}
+	if args.StartsAt != nil {
+		a.StartsAt = args.StartsAt.Time
+	}
+	if args.EndsAt != nil {
+		a.EndsAt = args.EndsAt.Time
+	}
+}
+
+func (r *schemaResolver) CreateAnnouncement(ctx context.Context, args *announcementArgs) (*announcementResolver, error) {
+	// 🚨 SECURITY: Only site admins may create announcements.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+	if err := validateAnnouncementArgs(args); err != nil {
+		return nil, err
+	}
+
+	announcement := &types.Announcement{CreatorUserID: actor.FromContext(ctx).UID}
+	args.apply(announcement)
+	created, err := database.Announcements(r.db).Create(ctx, announcement)
+	if err != nil {
+		return nil, err
+	}
+	return &announcementResolver{db: r.db, announcement: created}, nil
+}
+
+func (r *schemaResolver) UpdateAnnouncement(ctx context.Context, args *struct {
+	ID graphql.ID
+	announcementArgs
+}) (*announcementResolver, error) {
+	// 🚨 SECURITY: Only site admins may update announcements.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+	if err := validateAnnouncementArgs(&args.announcementArgs); err != nil {
+		return nil, err
+	}
+	id, err := unmarshalAnnouncementID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	announcement := &types.Announcement{ID: id}
+	args.apply(announcement)
+	updated, err := database.Announcements(r.db).Update(ctx, announcement)
+	if err != nil {
+		return nil, err
+	}
+	return &announcementResolver{db: r.db, announcement: updated}, nil
+}
+
+func (r *schemaResolver) DeleteAnnouncement(ctx context.Context, args *struct{ ID graphql.ID }) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may delete announcements.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+	id, err := unmarshalAnnouncementID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+	if err := database.Announcements(r.db).Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+func validateAnnouncementArgs(args *announcementArgs) error {
+	if strings.TrimSpace(args.Message) == "" {
+		return errors.New("the announcement message must not be empty")
+	}
+	if args.StartsAt != nil && args.EndsAt != nil && !args.EndsAt.After(args.StartsAt.Time) {
+		return errors.New("the announcement must end after it starts")
+	}
+	return nil
+}
+
+func marshalAnnouncementID(id int64) graphql.ID { return relay.MarshalID("Announcement", id) }
+
+func unmarshalAnnouncementID(id graphql.ID) (announcementID int64, err error) {
+	err = relay.UnmarshalSpec(id, &announcementID)
+	return
+}
+
+type announcementResolver struct {
+	db           dbutil.DB
+	announcement *types.Announcement
+}
+
+func (r *announcementResolver) ID() graphql.ID { return marshalAnnouncementID(r.announcement.ID) }
+
+func (r *announcementResolver) Message() string { return r.announcement.Message }
+
+func (r *announcementResolver) Severity() string { return string(r.announcement.Severity) }
+
+func (r *announcementResolver) Audience() string { return string(r.announcement.Audience) }
+
+func (r *announcementResolver) StartsAt() *DateTime {
+	if r.announcement.StartsAt.IsZero() {
+		return nil
+	}
+	return &DateTime{Time: r.announcement.StartsAt}
+}
+
+func (r *announcementResolver) EndsAt() *DateTime {
+	if r.announcement.EndsAt.IsZero() {
+		return nil
+	}
+	return &DateTime{Time: r.announcement.EndsAt}
+}
+
+func (r *announcementResolver) IsActive() bool { return r.announcement.IsActive(time.Now()) }
+
+func (r *announcementResolver) Creator(ctx context.Context) (*UserResolver, error) {
+	if r.announcement.CreatorUserID == 0 {
+		return nil, nil
+	}
+	user, err := UserByIDInt32(ctx, r.db, r.announcement.CreatorUserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *announcementResolver) CreatedAt() DateTime { return DateTime{Time: r.announcement.CreatedAt} }
+
+func (r *announcementResolver) UpdatedAt() DateTime { return DateTime{Time: r.announcement.UpdatedAt} }
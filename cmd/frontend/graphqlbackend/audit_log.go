@@ -0,0 +1,130 @@
+package graphqlbackend
+
+import (
+	"context"
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+func (r *siteResolver) AuditLog(ctx context.Context, args *struct {
+	graphqlutil.ConnectionArgs
+	TableName *string
+	RowID     *string
+}) (*auditLogConnectionResolver, error) {
+	// 🚨 SECURITY: Only site admins and auditors can view the audit log.
+	if err := backend.CheckCurrentUserIsSiteAuditor(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	var opt database.AuditLogListOptions
+	if args.TableName != nil {
+		opt.TableName = *args.TableName
+	}
+	if args.RowID != nil {
+		opt.RowID = *args.RowID
+	}
+	args.ConnectionArgs.Set(&opt.LimitOffset)
+	return &auditLogConnectionResolver{db: r.db, opt: opt}, nil
+}
+
+// auditLogConnectionResolver resolves a list of audit log entries.
+//
+// 🚨 SECURITY: When instantiating an auditLogConnectionResolver value, the caller MUST check
+// permissions.
+type auditLogConnectionResolver struct {
+	opt database.AuditLogListOptions
+
+	// cache results because they are used by multiple fields
+	once    sync.Once
+	entries []*database.AuditLogEntry
+	err     error
+	db      dbutil.DB
+}
+
+func (r *auditLogConnectionResolver) compute(ctx context.Context) ([]*database.AuditLogEntry, error) {
+	r.once.Do(func() {
+		opt2 := r.opt
+		if opt2.LimitOffset != nil {
+			tmp := *opt2.LimitOffset
+			opt2.LimitOffset = &tmp
+			opt2.Limit++ // so we can detect if there is a next page
+		}
+
+		r.entries, r.err = database.AuditLog(r.db).List(ctx, opt2)
+	})
+	return r.entries, r.err
+}
+
+func (r *auditLogConnectionResolver) Nodes(ctx context.Context) ([]*auditLogEntryResolver, error) {
+	entries, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.opt.LimitOffset != nil && len(entries) > r.opt.LimitOffset.Limit {
+		entries = entries[:r.opt.LimitOffset.Limit]
+	}
+
+	l := make([]*auditLogEntryResolver, 0, len(entries))
+	for _, entry := range entries {
+		l = append(l, &auditLogEntryResolver{db: r.db, entry: entry})
+	}
+	return l, nil
+}
+
+func (r *auditLogConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	count, err := database.AuditLog(r.db).Count(ctx, r.opt)
+	return int32(count), err
+}
+
+func (r *auditLogConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	entries, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return graphqlutil.HasNextPage(r.opt.LimitOffset != nil && len(entries) > r.opt.Limit), nil
+}
+
+type auditLogEntryResolver struct {
+	db    dbutil.DB
+	entry *database.AuditLogEntry
+}
+
+func (r *auditLogEntryResolver) TableName() string { return r.entry.TableName }
+
+func (r *auditLogEntryResolver) RowID() string { return r.entry.RowID }
+
+func (r *auditLogEntryResolver) Operation() string { return r.entry.Operation }
+
+func (r *auditLogEntryResolver) Actor(ctx context.Context) (*UserResolver, error) {
+	if r.entry.ActorUserID == 0 {
+		return nil, nil
+	}
+	user, err := UserByIDInt32(ctx, r.db, r.entry.ActorUserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *auditLogEntryResolver) ChangedColumns() []string { return r.entry.ChangedColumns }
+
+func (r *auditLogEntryResolver) OldData() *JSONValue {
+	if r.entry.OldData == nil {
+		return nil
+	}
+	return &JSONValue{r.entry.OldData}
+}
+
+func (r *auditLogEntryResolver) NewData() *JSONValue {
+	if r.entry.NewData == nil {
+		return nil
+	}
+	return &JSONValue{r.entry.NewData}
+}
+
+func (r *auditLogEntryResolver) CreatedAt() DateTime { return DateTime{Time: r.entry.CreatedAt} }
@@ -11,11 +11,15 @@ type AuthzResolver interface {
 	SetRepositoryPermissionsForUsers(ctx context.Context, args *RepoPermsArgs) (*EmptyResponse, error)
 	ScheduleRepositoryPermissionsSync(ctx context.Context, args *RepositoryIDArgs) (*EmptyResponse, error)
 	ScheduleUserPermissionsSync(ctx context.Context, args *UserIDArgs) (*EmptyResponse, error)
+	CreateRepositoryPermissionOverride(ctx context.Context, args *CreateRepositoryPermissionOverrideArgs) (RepositoryPermissionOverrideResolver, error)
+	UpdateRepositoryPermissionOverride(ctx context.Context, args *UpdateRepositoryPermissionOverrideArgs) (RepositoryPermissionOverrideResolver, error)
+	DeleteRepositoryPermissionOverride(ctx context.Context, args *DeleteRepositoryPermissionOverrideArgs) (*EmptyResponse, error)
 
 	// Queries
 	AuthorizedUserRepositories(ctx context.Context, args *AuthorizedRepoArgs) (RepositoryConnectionResolver, error)
 	UsersWithPendingPermissions(ctx context.Context) ([]string, error)
 	AuthorizedUsers(ctx context.Context, args *RepoAuthorizedUserArgs) (UserConnectionResolver, error)
+	RepositoryPermissionOverrides(ctx context.Context, args *RepositoryPermissionOverridesArgs) ([]RepositoryPermissionOverrideResolver, error)
 
 	// Helpers
 	RepositoryPermissionsInfo(ctx context.Context, repoID graphql.ID) (PermissionsInfoResolver, error)
@@ -51,3 +55,42 @@ type PermissionsInfoResolver interface {
 	SyncedAt() *DateTime
 	UpdatedAt() DateTime
 }
+
+type CreateRepositoryPermissionOverrideArgs struct {
+	Repository graphql.ID
+	User       graphql.ID
+	Effect     string
+	Reason     string
+	ExpiresAt  *DateTime
+}
+
+type UpdateRepositoryPermissionOverrideArgs struct {
+	ID        graphql.ID
+	Effect    string
+	Reason    string
+	ExpiresAt *DateTime
+}
+
+type DeleteRepositoryPermissionOverrideArgs struct {
+	ID graphql.ID
+}
+
+type RepositoryPermissionOverridesArgs struct {
+	Repository     *graphql.ID
+	User           *graphql.ID
+	IncludeExpired bool
+}
+
+type RepositoryPermissionOverrideResolver interface {
+	ID() graphql.ID
+	Repository(ctx context.Context) (*RepositoryResolver, error)
+	User(ctx context.Context) (*UserResolver, error)
+	Permission() string
+	Effect() string
+	Reason() string
+	ExpiresAt() *DateTime
+	Expired() bool
+	CreatedBy(ctx context.Context) (*UserResolver, error)
+	CreatedAt() DateTime
+	UpdatedAt() DateTime
+}
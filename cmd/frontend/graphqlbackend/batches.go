@@ -220,6 +220,7 @@ type ListBatchChangeBulkOperationArgs struct {
 	First        int32
 	After        *string
 	CreatedAfter *DateTime
+	State        *[]string
 }
 
 type CreateChangesetCommentsArgs struct {
@@ -237,7 +238,51 @@ type MergeChangesetsArgs struct {
 }
 
 type CreateBatchSpecExecutionArgs struct {
-	Spec string
+	Spec      string
+	Namespace *graphql.ID
+}
+
+type BatchChangeTemplateParameterInput struct {
+	Name        string
+	Description string
+	Default     *string
+}
+
+type CreateBatchChangeTemplateArgs struct {
+	Namespace   graphql.ID
+	Name        string
+	Description string
+	Spec        string
+	Parameters  []BatchChangeTemplateParameterInput
+}
+
+type UpdateBatchChangeTemplateArgs struct {
+	BatchChangeTemplate graphql.ID
+	Name                string
+	Description         string
+	Spec                string
+	Parameters          []BatchChangeTemplateParameterInput
+}
+
+type DeleteBatchChangeTemplateArgs struct {
+	BatchChangeTemplate graphql.ID
+}
+
+type BatchChangeTemplateParameterValueInput struct {
+	Name  string
+	Value string
+}
+
+type InstantiateBatchChangeTemplateArgs struct {
+	BatchChangeTemplate graphql.ID
+	Namespace           graphql.ID
+	Parameters          []BatchChangeTemplateParameterValueInput
+}
+
+type ListBatchChangeTemplatesArgs struct {
+	First     int32
+	After     *string
+	Namespace *graphql.ID
 }
 
 type CloseChangesetsArgs struct {
@@ -282,6 +327,10 @@ type BatchChangesResolver interface {
 	CreateBatchSpecExecution(ctx context.Context, args *CreateBatchSpecExecutionArgs) (BatchSpecExecutionResolver, error)
 	CloseChangesets(ctx context.Context, args *CloseChangesetsArgs) (BulkOperationResolver, error)
 	PublishChangesets(ctx context.Context, args *PublishChangesetsArgs) (BulkOperationResolver, error)
+	CreateBatchChangeTemplate(ctx context.Context, args *CreateBatchChangeTemplateArgs) (BatchChangeTemplateResolver, error)
+	UpdateBatchChangeTemplate(ctx context.Context, args *UpdateBatchChangeTemplateArgs) (BatchChangeTemplateResolver, error)
+	DeleteBatchChangeTemplate(ctx context.Context, args *DeleteBatchChangeTemplateArgs) (*EmptyResponse, error)
+	InstantiateBatchChangeTemplate(ctx context.Context, args *InstantiateBatchChangeTemplateArgs) (BatchSpecResolver, error)
 
 	// Queries
 
@@ -296,6 +345,7 @@ type BatchChangesResolver interface {
 	BatchChangesCodeHosts(ctx context.Context, args *ListBatchChangesCodeHostsArgs) (BatchChangesCodeHostConnectionResolver, error)
 	RepoChangesetsStats(ctx context.Context, repo *graphql.ID) (RepoChangesetsStatsResolver, error)
 	RepoDiffStat(ctx context.Context, repo *graphql.ID) (*DiffStat, error)
+	BatchChangeTemplates(ctx context.Context, args *ListBatchChangeTemplatesArgs) (BatchChangeTemplateConnectionResolver, error)
 
 	NodeResolvers() map[string]NodeByIDFunc
 }
@@ -345,6 +395,8 @@ type BatchSpecResolver interface {
 
 	DiffStat(ctx context.Context) (*DiffStat, error)
 
+	Estimate(ctx context.Context) (BatchSpecEstimateResolver, error)
+
 	AppliesToBatchChange(ctx context.Context) (BatchChangeResolver, error)
 
 	SupersedingBatchSpec(context.Context) (BatchSpecResolver, error)
@@ -362,6 +414,13 @@ type BatchSpecResolver interface {
 	ActAsCampaignSpec() bool
 }
 
+type BatchSpecEstimateResolver interface {
+	Repositories() int32
+	Files() int32
+	Owners() []string
+	CIPipelines() int32
+}
+
 type BatchChangeDescriptionResolver interface {
 	Name() string
 	Description() string
@@ -737,12 +796,37 @@ type BatchSpecExecutionResolver interface {
 	FinishedAt() *DateTime
 	Failure() *string
 	Steps() BatchSpecExecutionStepsResolver
-	PlaceInQueue() *int32
+	PlaceInQueue(ctx context.Context) (*int32, error)
 	BatchSpec(ctx context.Context) (BatchSpecResolver, error)
 	Initiator(ctx context.Context) (*UserResolver, error)
 	Namespace(ctx context.Context) (*NamespaceResolver, error)
 }
 
+type BatchChangeTemplateResolver interface {
+	ID() graphql.ID
+	Name() string
+	Description() string
+	Spec() string
+	Parameters() []BatchChangeTemplateParameterResolver
+	Namespace(ctx context.Context) (*NamespaceResolver, error)
+	Creator(ctx context.Context) (*UserResolver, error)
+	CreatedAt() DateTime
+	UpdatedAt() DateTime
+	ViewerCanAdminister(ctx context.Context) (bool, error)
+}
+
+type BatchChangeTemplateParameterResolver interface {
+	Name() string
+	Description() string
+	Default() *string
+}
+
+type BatchChangeTemplateConnectionResolver interface {
+	Nodes(ctx context.Context) ([]BatchChangeTemplateResolver, error)
+	TotalCount(ctx context.Context) (int32, error)
+	PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error)
+}
+
 type BatchSpecExecutionStepsResolver interface {
 	Setup() []ExecutionLogEntryResolver
 	SrcPreview() ExecutionLogEntryResolver
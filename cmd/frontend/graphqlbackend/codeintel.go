@@ -23,6 +23,9 @@ type CodeIntelResolver interface {
 	UpdateRepositoryIndexConfiguration(ctx context.Context, args *UpdateRepositoryIndexConfigurationArgs) (*EmptyResponse, error)
 	CommitGraph(ctx context.Context, id graphql.ID) (CodeIntelligenceCommitGraphResolver, error)
 	QueueAutoIndexJobForRepo(ctx context.Context, args *struct{ Repository graphql.ID }) (*EmptyResponse, error)
+	LSIFUploadTokens(ctx context.Context, repositoryID graphql.ID) ([]LSIFUploadTokenResolver, error)
+	CreateLSIFUploadToken(ctx context.Context, args *CreateLSIFUploadTokenArgs) (CreateLSIFUploadTokenResultResolver, error)
+	DeleteLSIFUploadToken(ctx context.Context, args *struct{ ID graphql.ID }) (*EmptyResponse, error)
 	GitBlobLSIFData(ctx context.Context, args *GitBlobLSIFDataArgs) (GitBlobLSIFDataResolver, error)
 
 	NodeResolvers() map[string]NodeByIDFunc
@@ -55,6 +58,20 @@ type LSIFUploadResolver interface {
 	PlaceInQueue() *int32
 	AssociatedIndex(ctx context.Context) (LSIFIndexResolver, error)
 	ProjectRoot(ctx context.Context) (*GitTreeEntryResolver, error)
+	ProcessingTrace(ctx context.Context) (LSIFUploadProcessingTraceResolver, error)
+}
+
+type LSIFUploadProcessingTraceResolver interface {
+	Phases() []LSIFUploadProcessingPhaseResolver
+	DurationMilliseconds() int32
+	PeakMemoryBytes() BigInt
+	RecordedAt() DateTime
+}
+
+type LSIFUploadProcessingPhaseResolver interface {
+	Name() string
+	StartMilliseconds() int32
+	DurationMilliseconds() int32
 }
 
 type LSIFUploadConnectionResolver interface {
@@ -89,6 +106,12 @@ type LSIFIndexResolver interface {
 	PlaceInQueue() *int32
 	AssociatedUpload(ctx context.Context) (LSIFUploadResolver, error)
 	ProjectRoot(ctx context.Context) (*GitTreeEntryResolver, error)
+	Inference() IndexJobInferenceResolver
+}
+
+type IndexJobInferenceResolver interface {
+	Confidence() float64
+	Reasons() []string
 }
 
 type IndexStepsResolver interface {
@@ -131,6 +154,24 @@ type QueueAutoIndexJobArgs struct {
 	Repository graphql.ID
 }
 
+type LSIFUploadTokenResolver interface {
+	ID() graphql.ID
+	Note() string
+	Creator(ctx context.Context) (*UserResolver, error)
+	CreatedAt() DateTime
+	LastUsedAt() *DateTime
+}
+
+type CreateLSIFUploadTokenArgs struct {
+	Repository graphql.ID
+	Note       *string
+}
+
+type CreateLSIFUploadTokenResultResolver interface {
+	ID() graphql.ID
+	Token() string
+}
+
 type GitTreeLSIFDataResolver interface {
 	Diagnostics(ctx context.Context, args *LSIFDiagnosticsArgs) (DiagnosticConnectionResolver, error)
 	DocumentationPage(ctx context.Context, args *LSIFDocumentationPageArgs) (DocumentationPageResolver, error)
@@ -192,6 +233,8 @@ type LSIFPagedQueryDocumentationArgs struct {
 
 type LSIFDiagnosticsArgs struct {
 	graphqlutil.ConnectionArgs
+	After      *string
+	Severities *[]string
 }
 
 type CodeIntelligenceRangeConnectionResolver interface {
@@ -66,6 +66,18 @@ func (r *CommitSearchResultResolver) DiffPreview() *highlightedStringResolver {
 	return &highlightedStringResolver{*r.CommitMatch.DiffPreview}
 }
 
+func (r *CommitSearchResultResolver) DiffFiles() *[]*commitSearchResultDiffFileResolver {
+	files := r.CommitMatch.DiffFiles()
+	if files == nil {
+		return nil
+	}
+	resolvers := make([]*commitSearchResultDiffFileResolver, 0, len(files))
+	for _, f := range files {
+		resolvers = append(resolvers, &commitSearchResultDiffFileResolver{f})
+	}
+	return &resolvers
+}
+
 func (r *CommitSearchResultResolver) Label() Markdown {
 	return Markdown(r.CommitMatch.Label())
 }
@@ -97,3 +109,81 @@ func (r *CommitSearchResultResolver) ToCommitSearchResult() (*CommitSearchResult
 func (r *CommitSearchResultResolver) ResultCount() int32 {
 	return 1
 }
+
+type commitSearchResultDiffFileResolver struct {
+	file result.DiffFile
+}
+
+func (r *commitSearchResultDiffFileResolver) OldPath() *string {
+	return nonEmptyStrptr(r.file.OrigName)
+}
+
+func (r *commitSearchResultDiffFileResolver) NewPath() *string {
+	return nonEmptyStrptr(r.file.NewName)
+}
+
+func (r *commitSearchResultDiffFileResolver) Hunks() []*commitSearchResultDiffHunkResolver {
+	resolvers := make([]*commitSearchResultDiffHunkResolver, 0, len(r.file.Hunks))
+	for _, h := range r.file.Hunks {
+		resolvers = append(resolvers, &commitSearchResultDiffHunkResolver{h})
+	}
+	return resolvers
+}
+
+type commitSearchResultDiffHunkResolver struct {
+	hunk result.DiffHunk
+}
+
+func (r *commitSearchResultDiffHunkResolver) OldRange() *DiffHunkRange {
+	return NewDiffHunkRange(r.hunk.OldStart, r.hunk.OldLines)
+}
+
+func (r *commitSearchResultDiffHunkResolver) NewRange() *DiffHunkRange {
+	return NewDiffHunkRange(r.hunk.NewStart, r.hunk.NewLines)
+}
+
+func (r *commitSearchResultDiffHunkResolver) Section() *string { return nonEmptyStrptr(r.hunk.Section) }
+
+func (r *commitSearchResultDiffHunkResolver) Lines() []*commitSearchResultDiffLineResolver {
+	resolvers := make([]*commitSearchResultDiffLineResolver, 0, len(r.hunk.Lines))
+	for _, l := range r.hunk.Lines {
+		resolvers = append(resolvers, &commitSearchResultDiffLineResolver{l})
+	}
+	return resolvers
+}
+
+type commitSearchResultDiffLineResolver struct {
+	line result.DiffLine
+}
+
+func (r *commitSearchResultDiffLineResolver) Kind() string  { return string(r.line.Kind) }
+func (r *commitSearchResultDiffLineResolver) Value() string { return r.line.Value }
+
+func (r *commitSearchResultDiffLineResolver) OldLine() *int32 {
+	if r.line.OldLine == 0 {
+		return nil
+	}
+	return &r.line.OldLine
+}
+
+func (r *commitSearchResultDiffLineResolver) NewLine() *int32 {
+	if r.line.NewLine == 0 {
+		return nil
+	}
+	return &r.line.NewLine
+}
+
+func (r *commitSearchResultDiffLineResolver) Highlights() []highlightedRangeResolver {
+	res := make([]highlightedRangeResolver, len(r.line.Highlights))
+	for i, hl := range r.line.Highlights {
+		res[i] = highlightedRangeResolver{hl}
+	}
+	return res
+}
+
+func nonEmptyStrptr(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
@@ -0,0 +1,50 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/jordan-wright/email"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/txemail"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type previewEmailTemplateArgs struct {
+	Type    string
+	Subject *string
+	Text    *string
+	HTML    *string
+}
+
+func (r *schemaResolver) PreviewEmailTemplate(ctx context.Context, args *previewEmailTemplateArgs) (*emailTemplatePreviewResolver, error) {
+	// 🚨 SECURITY: Only site admins may preview email templates.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	var custom schema.EmailTemplate
+	if args.Subject != nil {
+		custom.Subject = *args.Subject
+	}
+	if args.Text != nil {
+		custom.Text = *args.Text
+	}
+	if args.HTML != nil {
+		custom.Html = *args.HTML
+	}
+
+	m, err := txemail.Preview(args.Type, custom)
+	if err != nil {
+		return nil, err
+	}
+	return &emailTemplatePreviewResolver{m: m}, nil
+}
+
+type emailTemplatePreviewResolver struct {
+	m *email.Email
+}
+
+func (r *emailTemplatePreviewResolver) Subject() string { return r.m.Subject }
+func (r *emailTemplatePreviewResolver) Text() string    { return string(r.m.Text) }
+func (r *emailTemplatePreviewResolver) HTML() string    { return string(r.m.HTML) }
@@ -80,11 +80,12 @@ type ExtensionRegistryUpdateExtensionArgs struct {
 }
 
 type ExtensionRegistryPublishExtensionArgs struct {
-	ExtensionID string
-	Manifest    string
-	Bundle      *string
-	SourceMap   *string
-	Force       bool
+	ExtensionID     string
+	Manifest        string
+	Bundle          *string
+	BundleSignature *string
+	SourceMap       *string
+	Force           bool
 }
 
 type ExtensionRegistryDeleteExtensionArgs struct {
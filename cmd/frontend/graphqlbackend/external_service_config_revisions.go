@@ -0,0 +1,218 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sergi/go-diff/diffmatchpatch"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+const externalServiceConfigRevisionIDKind = "ExternalServiceConfigRevision"
+
+func marshalExternalServiceConfigRevisionID(id int64) graphql.ID {
+	return relay.MarshalID(externalServiceConfigRevisionIDKind, id)
+}
+
+func unmarshalExternalServiceConfigRevisionID(id graphql.ID) (revisionID int64, err error) {
+	if kind := relay.UnmarshalKind(id); kind != externalServiceConfigRevisionIDKind {
+		err = errors.Errorf("expected graphql ID to have kind %q; got %q", externalServiceConfigRevisionIDKind, kind)
+		return
+	}
+	err = relay.UnmarshalSpec(id, &revisionID)
+	return
+}
+
+type externalServiceConfigHistoryArgs struct {
+	First *int32
+}
+
+func (r *externalServiceResolver) ConfigHistory(ctx context.Context, args *externalServiceConfigHistoryArgs) (*externalServiceConfigRevisionConnectionResolver, error) {
+	revisions, err := database.ExternalServices(r.db).ListConfigRevisions(ctx, r.externalService.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*externalServiceConfigRevisionResolver, 0, len(revisions))
+	for i, revision := range revisions {
+		// Revisions are ordered most recent first, so the previous revision is the next one.
+		var previous *types.ExternalServiceConfigRevision
+		if i+1 < len(revisions) {
+			previous = revisions[i+1]
+		}
+
+		resolvers = append(resolvers, &externalServiceConfigRevisionResolver{
+			db:       r.db,
+			kind:     r.externalService.Kind,
+			revision: revision,
+			previous: previous,
+		})
+	}
+
+	return &externalServiceConfigRevisionConnectionResolver{revisions: resolvers, first: args.First}, nil
+}
+
+type externalServiceConfigRevisionConnectionResolver struct {
+	revisions []*externalServiceConfigRevisionResolver
+	first     *int32
+}
+
+func (r *externalServiceConfigRevisionConnectionResolver) Nodes() []*externalServiceConfigRevisionResolver {
+	if r.first != nil && int(*r.first) < len(r.revisions) {
+		return r.revisions[:*r.first]
+	}
+	return r.revisions
+}
+
+func (r *externalServiceConfigRevisionConnectionResolver) TotalCount() int32 {
+	return int32(len(r.revisions))
+}
+
+func (r *externalServiceConfigRevisionConnectionResolver) PageInfo() *graphqlutil.PageInfo {
+	return graphqlutil.HasNextPage(r.first != nil && int(*r.first) < len(r.revisions))
+}
+
+type externalServiceConfigRevisionResolver struct {
+	db       dbutil.DB
+	kind     string
+	revision *types.ExternalServiceConfigRevision
+	previous *types.ExternalServiceConfigRevision
+}
+
+func (r *externalServiceConfigRevisionResolver) ID() graphql.ID {
+	return marshalExternalServiceConfigRevisionID(r.revision.ID)
+}
+
+func (r *externalServiceConfigRevisionResolver) Author(ctx context.Context) (*UserResolver, error) {
+	if r.revision.AuthorUserID == 0 {
+		return nil, nil
+	}
+	user, err := UserByIDInt32(ctx, r.db, r.revision.AuthorUserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *externalServiceConfigRevisionResolver) CreatedAt() DateTime {
+	return DateTime{Time: r.revision.CreatedAt}
+}
+
+func (r *externalServiceConfigRevisionResolver) Config() (JSONCString, error) {
+	config, err := redactConfig(r.kind, r.revision)
+	if err != nil {
+		return "", err
+	}
+	return JSONCString(config), nil
+}
+
+func (r *externalServiceConfigRevisionResolver) Diff() (string, error) {
+	config, err := redactConfig(r.kind, r.revision)
+	if err != nil {
+		return "", err
+	}
+	previousConfig, err := redactConfig(r.kind, r.previous)
+	if err != nil {
+		return "", err
+	}
+	return lineDiff(previousConfig, config), nil
+}
+
+// redactConfig returns the config of the given revision with its secrets redacted, or the
+// empty string if there is no revision.
+func redactConfig(kind string, revision *types.ExternalServiceConfigRevision) (string, error) {
+	if revision == nil {
+		return "", nil
+	}
+	es := &types.ExternalService{Kind: kind, Config: revision.Config}
+	if err := es.RedactConfigSecrets(); err != nil {
+		return "", err
+	}
+	return es.Config, nil
+}
+
+// lineDiff returns a line-based diff of the given texts, in which every line is prefixed
+// with "-" if it was removed, "+" if it was added, or " " if it is unchanged.
+func lineDiff(old, new string) string {
+	dmp := diffmatchpatch.New()
+	oldChars, newChars, lines := dmp.DiffLinesToChars(old, new)
+	diffs := dmp.DiffCharsToLines(dmp.DiffMain(oldChars, newChars, false), lines)
+
+	var b strings.Builder
+	for _, diff := range diffs {
+		prefix := " "
+		switch diff.Type {
+		case diffmatchpatch.DiffDelete:
+			prefix = "-"
+		case diffmatchpatch.DiffInsert:
+			prefix = "+"
+		}
+
+		for _, line := range strings.SplitAfter(diff.Text, "\n") {
+			if line == "" {
+				continue
+			}
+			b.WriteString(prefix)
+			b.WriteString(line)
+			if !strings.HasSuffix(line, "\n") {
+				b.WriteString("\n")
+			}
+		}
+	}
+	return b.String()
+}
+
+type revertExternalServiceConfigArgs struct {
+	ExternalService graphql.ID
+	Revision        graphql.ID
+}
+
+// RevertExternalServiceConfig updates the config of an external service to the config of
+// one of its previous revisions. This creates a new revision.
+func (r *schemaResolver) RevertExternalServiceConfig(ctx context.Context, args *revertExternalServiceConfigArgs) (*externalServiceResolver, error) {
+	id, err := unmarshalExternalServiceID(args.ExternalService)
+	if err != nil {
+		return nil, err
+	}
+	revisionID, err := unmarshalExternalServiceConfigRevisionID(args.Revision)
+	if err != nil {
+		return nil, err
+	}
+
+	es, err := database.ExternalServices(r.db).GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Site admins can only revert site level external services.
+	// Otherwise, the current user can only revert their own external services.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		if es.NamespaceUserID == 0 {
+			return nil, err
+		} else if actor.FromContext(ctx).UID != es.NamespaceUserID {
+			return nil, errNoAccessExternalService
+		}
+	}
+
+	revision, err := database.ExternalServices(r.db).GetConfigRevision(ctx, id, revisionID)
+	if err != nil {
+		return nil, err
+	}
+
+	return r.UpdateExternalService(ctx, &updateExternalServiceArgs{
+		Input: updateExternalServiceInput{
+			ID:     args.ExternalService,
+			Config: &revision.Config,
+		},
+	})
+}
@@ -0,0 +1,68 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRevertExternalServiceConfig(t *testing.T) {
+	db := new(dbtesting.MockDB)
+
+	database.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+		return &types.User{ID: 1}, nil
+	}
+	database.Mocks.ExternalServices.GetByID = func(id int64) (*types.ExternalService, error) {
+		return &types.ExternalService{ID: id}, nil
+	}
+	defer func() {
+		database.Mocks.Users = database.MockUsers{}
+		database.Mocks.ExternalServices = database.MockExternalServices{}
+	}()
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	result, err := newSchemaResolver(db).RevertExternalServiceConfig(ctx, &revertExternalServiceConfigArgs{
+		ExternalService: marshalExternalServiceID(4),
+		Revision:        marshalExternalServiceConfigRevisionID(2),
+	})
+	if want := backend.ErrMustBeSiteAdmin; err != want {
+		t.Errorf("err: want %q but got %v", want, err)
+	}
+	if result != nil {
+		t.Errorf("result: want nil but got %v", result)
+	}
+}
+
+func TestLineDiff(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		old, new string
+		want     string
+	}{
+		{
+			name: "first revision",
+			old:  "",
+			new:  "{\n  \"url\": \"https://github.com\"\n}",
+			want: "+{\n+  \"url\": \"https://github.com\"\n+}\n",
+		},
+		{
+			name: "changed line",
+			old:  "{\n  \"repositoryQuery\": [\"none\"],\n  \"url\": \"https://github.com\"\n}\n",
+			new:  "{\n  \"repositoryQuery\": [\"affiliated\"],\n  \"url\": \"https://github.com\"\n}\n",
+			want: " {\n-  \"repositoryQuery\": [\"none\"],\n+  \"repositoryQuery\": [\"affiliated\"],\n   \"url\": \"https://github.com\"\n }\n",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if diff := cmp.Diff(tc.want, lineDiff(tc.old, tc.new)); diff != "" {
+				t.Errorf("unexpected diff (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,105 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func (r *schemaResolver) SyncExternalServiceDryRun(ctx context.Context, args *struct {
+	ID     graphql.ID
+	Config *string
+}) (*externalServiceSyncDryRunResolver, error) {
+	id, err := unmarshalExternalServiceID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	es, err := database.ExternalServices(r.db).GetByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Same as for updating the external service, because the dry run shows which
+	// repos its configuration gives access to.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		if es.NamespaceUserID == 0 {
+			return nil, err
+		} else if actor.FromContext(ctx).UID != es.NamespaceUserID {
+			return nil, errNoAccessExternalService
+		}
+	}
+
+	if args.Config != nil {
+		if strings.TrimSpace(*args.Config) == "" {
+			return nil, errors.New("blank external service configuration is invalid (must be valid JSONC)")
+		}
+
+		// The configuration may contain the redacted secrets of the stored configuration, just
+		// like when updating the external service.
+		newSvc := types.ExternalService{Kind: es.Kind, Config: *args.Config}
+		if err := newSvc.UnredactConfig(es); err != nil {
+			return nil, errors.Wrapf(err, "error unredacting config")
+		}
+		if _, err := database.ExternalServices(r.db).ValidateConfig(ctx, database.ValidateExternalServiceConfigOptions{
+			ExternalServiceID: es.ID,
+			Kind:              es.Kind,
+			Config:            newSvc.Config,
+			AuthProviders:     conf.Get().AuthProviders,
+			NamespaceUserID:   es.NamespaceUserID,
+		}); err != nil {
+			return nil, err
+		}
+		es.Config = newSvc.Config
+	}
+
+	result, err := r.repoupdaterClient.SyncExternalServiceDryRun(ctx, api.ExternalService{
+		ID:              es.ID,
+		Kind:            es.Kind,
+		DisplayName:     es.DisplayName,
+		Config:          es.Config,
+		NamespaceUserID: es.NamespaceUserID,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &externalServiceSyncDryRunResolver{result: result}, nil
+}
+
+type externalServiceSyncDryRunResolver struct {
+	result *protocol.ExternalServiceSyncDryRunResult
+}
+
+func (r *externalServiceSyncDryRunResolver) Added() []string {
+	return repoNamesToStrings(r.result.Added)
+}
+
+func (r *externalServiceSyncDryRunResolver) Removed() []string {
+	return repoNamesToStrings(r.result.Removed)
+}
+
+func (r *externalServiceSyncDryRunResolver) Renamed() []*externalServiceSyncRenameResolver {
+	renames := make([]*externalServiceSyncRenameResolver, 0, len(r.result.Renamed))
+	for _, rename := range r.result.Renamed {
+		renames = append(renames, &externalServiceSyncRenameResolver{rename: rename})
+	}
+	return renames
+}
+
+type externalServiceSyncRenameResolver struct {
+	rename protocol.RepoRename
+}
+
+func (r *externalServiceSyncRenameResolver) From() string { return string(r.rename.From) }
+
+func (r *externalServiceSyncRenameResolver) To() string { return string(r.rename.To) }
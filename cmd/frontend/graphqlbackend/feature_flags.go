@@ -132,6 +132,15 @@ func (r *schemaResolver) ViewerFeatureFlags(ctx context.Context) []*EvaluatedFea
 	return evaluatedFlagsToResolvers(f)
 }
 
+func (r *schemaResolver) EvaluateFeatureFlag(ctx context.Context, args *struct {
+	FlagName string
+}) *bool {
+	if value, ok := featureflag.Evaluate(ctx, args.FlagName); ok {
+		return &value
+	}
+	return nil
+}
+
 func evaluatedFlagsToResolvers(input map[string]bool) []*EvaluatedFeatureFlagResolver {
 	res := make([]*EvaluatedFeatureFlagResolver, 0, len(input))
 	for k, v := range input {
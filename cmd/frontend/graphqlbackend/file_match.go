@@ -75,6 +75,10 @@ func (fm *FileMatchResolver) LimitHit() bool {
 	return fm.FileMatch.LimitHit
 }
 
+func (fm *FileMatchResolver) DuplicateForks() int32 {
+	return int32(fm.FileMatch.DuplicateForks)
+}
+
 func (fm *FileMatchResolver) ToRepository() (*RepositoryResolver, bool) { return nil, false }
 func (fm *FileMatchResolver) ToFileMatch() (*FileMatchResolver, bool)   { return fm, true }
 func (fm *FileMatchResolver) ToCommitSearchResult() (*CommitSearchResultResolver, bool) {
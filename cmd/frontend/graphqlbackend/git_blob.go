@@ -2,6 +2,7 @@ package graphqlbackend
 
 import (
 	"context"
+	"sort"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
@@ -12,23 +13,26 @@ func (r *GitTreeEntryResolver) Blame(ctx context.Context,
 		StartLine int32
 		EndLine   int32
 	}) ([]*hunkResolver, error) {
-	hunks, err := git.BlameFile(ctx, r.commit.repoResolver.RepoName(), r.Path(), &git.BlameOptions{
+	var hunksResolver []*hunkResolver
+	err := git.StreamBlameFile(ctx, r.commit.repoResolver.RepoName(), r.Path(), &git.BlameOptions{
 		NewestCommit: api.CommitID(r.commit.OID()),
 		StartLine:    int(args.StartLine),
 		EndLine:      int(args.EndLine),
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	var hunksResolver []*hunkResolver
-	for _, hunk := range hunks {
+	}, func(hunk *git.Hunk) error {
 		hunksResolver = append(hunksResolver, &hunkResolver{
 			db:   r.db,
 			repo: r.commit.repoResolver,
 			hunk: hunk,
 		})
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
+	// The hunks are streamed in the order in which they were blamed.
+	sort.Slice(hunksResolver, func(i, j int) bool {
+		return hunksResolver[i].hunk.StartLine < hunksResolver[j].hunk.StartLine
+	})
 	return hunksResolver, nil
 }
@@ -451,6 +451,15 @@ func newSchemaResolver(db dbutil.DB) *schemaResolver {
 		"OrganizationInvitation": func(ctx context.Context, id graphql.ID) (Node, error) {
 			return orgInvitationByID(ctx, db, id)
 		},
+		"OrgTeam": func(ctx context.Context, id graphql.ID) (Node, error) {
+			return orgTeamByID(ctx, db, id)
+		},
+		"Announcement": func(ctx context.Context, id graphql.ID) (Node, error) {
+			return announcementByID(ctx, db, id)
+		},
+		"SearchJob": func(ctx context.Context, id graphql.ID) (Node, error) {
+			return searchJobByID(ctx, db, id)
+		},
 		"GitCommit": func(ctx context.Context, id graphql.ID) (Node, error) {
 			return r.gitCommitByID(ctx, id)
 		},
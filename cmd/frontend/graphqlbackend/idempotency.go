@@ -0,0 +1,63 @@
+package graphqlbackend
+
+import (
+	"github.com/cockroachdb/errors"
+	"github.com/graphql-go/graphql/language/ast"
+)
+
+// idempotentMutations are the mutations that can be retried with an
+// Idempotency-Key header, so that retrying a request after a network failure
+// doesn't create duplicates. The value is true for mutations whose response
+// contains a secret, which must not be stored for replaying.
+var idempotentMutations = map[string]bool{
+	"createAccessToken": true,
+	"createBatchChange": false,
+	"applyBatchChange":  false,
+	"createCampaign":    false,
+	"applyCampaign":     false,
+}
+
+// ErrIdempotencyKeyNotSupported is returned by IsIdempotentMutation for
+// mutations that do not support idempotency keys.
+var ErrIdempotencyKeyNotSupported = errors.New("the Idempotency-Key header is not supported by this mutation")
+
+// IdempotentMutation describes a GraphQL mutation that supports idempotency
+// keys.
+type IdempotentMutation struct {
+	// ReturnsSecrets is true if the response of the mutation contains a
+	// secret, like the token of a new access token.
+	ReturnsSecrets bool
+}
+
+// IsIdempotentMutation returns true if the operation operationName of the
+// GraphQL document query is a mutation which only selects fields that support
+// idempotency keys. It returns false for other operations, which are
+// idempotent anyway, and for documents that cannot be parsed, which fail when
+// they are executed.
+//
+// It returns ErrIdempotencyKeyNotSupported if the operation is a mutation that
+// selects other fields.
+func IsIdempotentMutation(query, operationName string) (*IdempotentMutation, bool, error) {
+	op := findOperation(query, operationName)
+	if op == nil || op.Operation != ast.OperationTypeMutation {
+		return nil, false, nil
+	}
+	if op.SelectionSet == nil {
+		return nil, false, ErrIdempotencyKeyNotSupported
+	}
+
+	var m IdempotentMutation
+	for _, sel := range op.SelectionSet.Selections {
+		// Fragments are not checked, so they are not allowed in mutations.
+		field, ok := sel.(*ast.Field)
+		if !ok || field.Name == nil {
+			return nil, false, ErrIdempotencyKeyNotSupported
+		}
+		secret, ok := idempotentMutations[field.Name.Value]
+		if !ok {
+			return nil, false, ErrIdempotencyKeyNotSupported
+		}
+		m.ReturnsSecrets = m.ReturnsSecrets || secret
+	}
+	return &m, true, nil
+}
@@ -0,0 +1,66 @@
+package graphqlbackend
+
+import "testing"
+
+func TestIsIdempotentMutation(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		operationName string
+		want          bool
+		wantSecrets   bool
+		wantErr       error
+	}{
+		{
+			name:  "query",
+			query: `query { currentUser { username } }`,
+			want:  false,
+		},
+		{
+			name:  "idempotent mutation",
+			query: `mutation { applyBatchChange(batchSpec: "QmF0Y2hTcGVjOjE=") { id } }`,
+			want:  true,
+		},
+		{
+			name:        "idempotent mutation returning secrets",
+			query:       `mutation { createAccessToken(user: "VXNlcjox", scopes: ["user:all"], note: "n") { id token } }`,
+			want:        true,
+			wantSecrets: true,
+		},
+		{
+			name:    "other mutation",
+			query:   `mutation { deleteUser(user: "VXNlcjox") { alwaysNil } }`,
+			wantErr: ErrIdempotencyKeyNotSupported,
+		},
+		{
+			name:    "idempotent and other mutation",
+			query:   `mutation { applyBatchChange(batchSpec: "QmF0Y2hTcGVjOjE=") { id } deleteUser(user: "VXNlcjox") { alwaysNil } }`,
+			wantErr: ErrIdempotencyKeyNotSupported,
+		},
+		{
+			name:          "selected mutation",
+			query:         `query A { currentUser { username } } mutation B { applyBatchChange(batchSpec: "QmF0Y2hTcGVjOjE=") { id } }`,
+			operationName: "B",
+			want:          true,
+		},
+		{
+			name:  "parse error",
+			query: `mutation {`,
+			want:  false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			m, got, err := IsIdempotentMutation(test.query, test.operationName)
+			if err != test.wantErr {
+				t.Fatalf("got error %v, want %v", err, test.wantErr)
+			}
+			if got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+			if got && m.ReturnsSecrets != test.wantSecrets {
+				t.Errorf("got ReturnsSecrets %v, want %v", m.ReturnsSecrets, test.wantSecrets)
+			}
+		})
+	}
+}
@@ -0,0 +1,191 @@
+package graphqlbackend
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/session"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// ImpersonationEventArgs is the argument of impersonation security events.
+// It records both the site admin and the impersonated user.
+type ImpersonationEventArgs struct {
+	ImpersonatorUserID int32     `json:"impersonatorUserID"`
+	UserID             int32     `json:"userID"`
+	ExpiresAt          time.Time `json:"expiresAt,omitempty"`
+	RequestMethod      string    `json:"requestMethod,omitempty"`
+	RequestPath        string    `json:"requestPath,omitempty"`
+	RequestName        string    `json:"requestName,omitempty"`
+	OperationName      string    `json:"operationName,omitempty"`
+}
+
+// LogImpersonationEvent records an impersonation event in the security event
+// log. Unlike other security events, impersonation events are always logged
+// since they form the audit log of impersonation sessions.
+func LogImpersonationEvent(ctx context.Context, db dbutil.DB, name database.SecurityEventName, eventArgs *ImpersonationEventArgs) {
+	args, err := json.Marshal(eventArgs)
+	if err != nil {
+		log15.Error("LogImpersonationEvent: failed to marshal JSON", "eventArgs", eventArgs)
+	}
+
+	event := &database.SecurityEvent{
+		Name:      name,
+		UserID:    uint32(eventArgs.ImpersonatorUserID),
+		Argument:  args,
+		Source:    "BACKEND",
+		Timestamp: time.Now(),
+	}
+
+	if err := database.SecurityEventLogs(db).Insert(ctx, event); err != nil {
+		log15.Error(string(name), "err", err)
+	}
+}
+
+func (r *schemaResolver) ImpersonateUser(ctx context.Context, args *struct {
+	User graphql.ID
+}) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may impersonate users. Note that an
+	// impersonating site admin acts as the impersonated user, so this also
+	// prevents nested impersonation.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+	if !conf.AuthImpersonationEnabled() {
+		return nil, errors.New("impersonation is disabled, set auth.impersonation.enabled in the site configuration to enable it")
+	}
+
+	a := actor.FromContext(ctx)
+	if !a.FromSessionCookie {
+		return nil, errors.New("impersonation requires a session, it cannot be started with an access token")
+	}
+
+	userID, err := UnmarshalUserID(args.User)
+	if err != nil {
+		return nil, err
+	}
+	if userID == a.UID {
+		return nil, errors.New("refusing to impersonate the current user")
+	}
+
+	user, err := database.Users(r.db).GetByID(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Site admins may not be impersonated, since that would
+	// grant access to the site admin area.
+	if user.SiteAdmin {
+		return nil, errors.New("refusing to impersonate a site admin")
+	}
+
+	expiresAt, err := session.StartImpersonation(a.UID, user.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	LogImpersonationEvent(ctx, r.db, database.SecurityEventNameImpersonationStarted, &ImpersonationEventArgs{
+		ImpersonatorUserID: a.UID,
+		UserID:             user.ID,
+		ExpiresAt:          expiresAt,
+	})
+	return &EmptyResponse{}, nil
+}
+
+func (r *schemaResolver) StopImpersonation(ctx context.Context) (*EmptyResponse, error) {
+	a := actor.FromContext(ctx)
+	if !a.IsImpersonated() {
+		return nil, errors.New("not impersonating a user")
+	}
+
+	session.StopImpersonation(a.ImpersonatorUID)
+
+	LogImpersonationEvent(ctx, r.db, database.SecurityEventNameImpersonationStopped, &ImpersonationEventArgs{
+		ImpersonatorUserID: a.ImpersonatorUID,
+		UserID:             a.UID,
+	})
+	return &EmptyResponse{}, nil
+}
+
+func (r *UserResolver) Impersonator(ctx context.Context) (*UserResolver, error) {
+	// 🚨 SECURITY: Only reveal the impersonator to the impersonation session
+	// itself.
+	a := actor.FromContext(ctx)
+	if !a.IsImpersonated() || a.UID != r.user.ID {
+		return nil, nil
+	}
+	return UserByIDInt32(ctx, r.db, a.ImpersonatorUID)
+}
+
+// IsReadOnlyOperation returns true if the operation operationName of the
+// GraphQL document query is not a mutation, or is a mutation which only
+// selects fields in allowedMutations. An empty operationName selects the only
+// operation of the document.
+//
+// It returns false if query cannot be parsed or the operation is not found,
+// so that callers fail closed.
+func IsReadOnlyOperation(query, operationName string, allowedMutations ...string) bool {
+	op := findOperation(query, operationName)
+	if op == nil {
+		return false
+	}
+	if op.Operation != ast.OperationTypeMutation {
+		return true
+	}
+	if op.SelectionSet == nil {
+		return false
+	}
+
+	allowed := make(map[string]struct{}, len(allowedMutations))
+	for _, name := range allowedMutations {
+		allowed[name] = struct{}{}
+	}
+	for _, sel := range op.SelectionSet.Selections {
+		// Fragments are not checked, so they are not allowed in mutations.
+		field, ok := sel.(*ast.Field)
+		if !ok || field.Name == nil {
+			return false
+		}
+		if _, ok := allowed[field.Name.Value]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// findOperation returns the operation operationName of the GraphQL document
+// query, or the only operation of the document if operationName is empty. It
+// returns nil if query cannot be parsed or the operation is not found.
+func findOperation(query, operationName string) *ast.OperationDefinition {
+	doc, err := parser.Parse(parser.ParseParams{
+		Source: query,
+	})
+	if err != nil {
+		return nil
+	}
+
+	var operations []*ast.OperationDefinition
+	for _, def := range doc.Definitions {
+		op, ok := def.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		if operationName == "" || (op.Name != nil && op.Name.Value == operationName) {
+			operations = append(operations, op)
+		}
+	}
+	if len(operations) != 1 {
+		return nil
+	}
+	return operations[0]
+}
@@ -0,0 +1,67 @@
+package graphqlbackend
+
+import "testing"
+
+func TestIsReadOnlyOperation(t *testing.T) {
+	tests := []struct {
+		name          string
+		query         string
+		operationName string
+		want          bool
+	}{
+		{
+			name:  "query",
+			query: `query { currentUser { username } }`,
+			want:  true,
+		},
+		{
+			name:  "shorthand query",
+			query: `{ currentUser { username } }`,
+			want:  true,
+		},
+		{
+			name:  "mutation",
+			query: `mutation { deleteUser(user: "VXNlcjox") { alwaysNil } }`,
+			want:  false,
+		},
+		{
+			name:  "allowed mutation",
+			query: `mutation { stopImpersonation { alwaysNil } }`,
+			want:  true,
+		},
+		{
+			name:  "allowed and disallowed mutation",
+			query: `mutation { stopImpersonation { alwaysNil } deleteUser(user: "VXNlcjox") { alwaysNil } }`,
+			want:  false,
+		},
+		{
+			name:          "selected query",
+			query:         `query A { currentUser { username } } mutation B { deleteUser(user: "VXNlcjox") { alwaysNil } }`,
+			operationName: "A",
+			want:          true,
+		},
+		{
+			name:          "selected mutation",
+			query:         `query A { currentUser { username } } mutation B { deleteUser(user: "VXNlcjox") { alwaysNil } }`,
+			operationName: "B",
+			want:          false,
+		},
+		{
+			name:  "ambiguous operation",
+			query: `query A { currentUser { username } } query B { currentUser { username } }`,
+			want:  false,
+		},
+		{
+			name:  "parse error",
+			query: `query {`,
+			want:  false,
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if got := IsReadOnlyOperation(test.query, test.operationName, "stopImpersonation"); got != test.want {
+				t.Errorf("got %v, want %v", got, test.want)
+			}
+		})
+	}
+}
@@ -13,24 +13,31 @@ type LocationResolver interface {
 	Range() *rangeResolver
 	URL(ctx context.Context) (string, error)
 	CanonicalURL() string
+	Precision() *string
 }
 
 type locationResolver struct {
 	resource *GitTreeEntryResolver
 	lspRange *lsp.Range
+	// precision is the CodeIntelPrecision of a code intelligence result, or
+	// nil for other locations.
+	precision *string
 }
 
 var _ LocationResolver = &locationResolver{}
 
-func NewLocationResolver(resource *GitTreeEntryResolver, lspRange *lsp.Range) LocationResolver {
+func NewLocationResolver(resource *GitTreeEntryResolver, lspRange *lsp.Range, precision *string) LocationResolver {
 	return &locationResolver{
-		resource: resource,
-		lspRange: lspRange,
+		resource:  resource,
+		lspRange:  lspRange,
+		precision: precision,
 	}
 }
 
 func (r *locationResolver) Resource() *GitTreeEntryResolver { return r.resource }
 
+func (r *locationResolver) Precision() *string { return r.precision }
+
 func (r *locationResolver) Range() *rangeResolver {
 	if r.lspRange == nil {
 		return nil
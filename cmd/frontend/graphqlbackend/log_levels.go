@@ -0,0 +1,81 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/logging"
+)
+
+type setLogLevelArgs struct {
+	Component  string
+	Level      *string
+	TTLSeconds int32
+}
+
+// SetLogLevel overrides the log level of a component of this frontend instance until the
+// override expires, or removes the override if no level is given.
+func (r *schemaResolver) SetLogLevel(ctx context.Context, args *setLogLevelArgs) ([]*logLevelOverrideResolver, error) {
+	// 🚨 SECURITY: Only site admins may change log levels.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	if args.Level == nil {
+		logging.ResetLevelOverride(args.Component)
+	} else {
+		lvl, err := log15.LvlFromString(strings.ToLower(*args.Level))
+		if err != nil {
+			return nil, err
+		}
+		if _, err := logging.SetLevelOverride(args.Component, lvl, time.Duration(args.TTLSeconds)*time.Second); err != nil {
+			return nil, err
+		}
+	}
+	return logLevelOverrides(), nil
+}
+
+// LogLevelOverrides returns the log level overrides of this frontend instance.
+func (r *schemaResolver) LogLevelOverrides(ctx context.Context) ([]*logLevelOverrideResolver, error) {
+	// 🚨 SECURITY: Only site admins and site auditors may view log level overrides.
+	if err := backend.CheckCurrentUserIsSiteAuditor(ctx, r.db); err != nil {
+		return nil, err
+	}
+	return logLevelOverrides(), nil
+}
+
+func logLevelOverrides() []*logLevelOverrideResolver {
+	overrides := logging.LevelOverrides()
+	resolvers := make([]*logLevelOverrideResolver, 0, len(overrides))
+	for _, o := range overrides {
+		resolvers = append(resolvers, &logLevelOverrideResolver{override: o})
+	}
+	return resolvers
+}
+
+type logLevelOverrideResolver struct {
+	override logging.LevelOverride
+}
+
+func (r *logLevelOverrideResolver) Component() string { return r.override.Component }
+
+func (r *logLevelOverrideResolver) Level() string {
+	switch r.override.Level {
+	case log15.LvlDebug:
+		return "DEBUG"
+	case log15.LvlInfo:
+		return "INFO"
+	case log15.LvlWarn:
+		return "WARN"
+	case log15.LvlError:
+		return "ERROR"
+	default:
+		return "CRIT"
+	}
+}
+
+func (r *logLevelOverrideResolver) ExpiresAt() DateTime { return DateTime{Time: r.override.ExpiresAt} }
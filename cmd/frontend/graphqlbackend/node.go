@@ -6,6 +6,9 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
 )
 
 // Node must be implemented by any resolver that implements the Node interface in
@@ -17,12 +20,7 @@ type Node interface {
 }
 
 func (r *schemaResolver) Node(ctx context.Context, args *struct{ ID graphql.ID }) (*NodeResolver, error) {
-	kind := relay.UnmarshalKind(args.ID)
-	nodeRes, ok := r.nodeByIDFns[kind]
-	if !ok {
-		return nil, errors.New("invalid id")
-	}
-	n, err := nodeRes(ctx, args.ID)
+	n, err := r.nodeByID(ctx, args.ID)
 	if err != nil {
 		return nil, err
 	}
@@ -34,13 +32,43 @@ func (r *schemaResolver) Node(ctx context.Context, args *struct{ ID graphql.ID }
 
 type NodeByIDFunc = func(ctx context.Context, id graphql.ID) (Node, error)
 
+// nodeByID resolves the node with the given ID. If the node does not exist,
+// the ID is looked up in the node ID redirects, so that references to nodes
+// that were replaced (such as re-created repositories) keep resolving.
 func (r *schemaResolver) nodeByID(ctx context.Context, id graphql.ID) (Node, error) {
 	kind := relay.UnmarshalKind(id)
 	nodeRes, ok := r.nodeByIDFns[kind]
 	if !ok {
 		return nil, errors.New("invalid id")
 	}
-	return nodeRes(ctx, id)
+	n, err := nodeRes(ctx, id)
+	if (err != nil && !errcode.IsNotFound(err)) || (err == nil && n != nil) {
+		return n, err
+	}
+
+	newID, redirected, redirectErr := r.redirectedNodeID(ctx, id)
+	if redirectErr != nil {
+		return nil, redirectErr
+	}
+	if !redirected {
+		return n, err
+	}
+	return nodeRes(ctx, newID)
+}
+
+// redirectedNodeID returns the ID that the given node ID is redirected to, if
+// any. Only IDs with an integer spec can be redirected.
+func (r *schemaResolver) redirectedNodeID(ctx context.Context, id graphql.ID) (graphql.ID, bool, error) {
+	kind := relay.UnmarshalKind(id)
+	var oldID int64
+	if err := relay.UnmarshalSpec(id, &oldID); err != nil {
+		return "", false, nil
+	}
+	newID, redirected, err := database.NodeIDRedirects(r.db).Resolve(ctx, kind, oldID)
+	if err != nil || !redirected {
+		return "", false, err
+	}
+	return relay.MarshalID(kind, newID), true, nil
 }
 
 type NodeResolver struct {
@@ -67,6 +95,16 @@ func (r *NodeResolver) ToMonitorEmail() (MonitorEmailResolver, bool) {
 	return n, ok
 }
 
+func (r *NodeResolver) ToMonitorWebhook() (MonitorWebhookResolver, bool) {
+	n, ok := r.Node.(MonitorWebhookResolver)
+	return n, ok
+}
+
+func (r *NodeResolver) ToMonitorSlackWebhook() (MonitorSlackWebhookResolver, bool) {
+	n, ok := r.Node.(MonitorSlackWebhookResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToMonitorActionEvent() (MonitorActionEventResolver, bool) {
 	n, ok := r.Node.(MonitorActionEventResolver)
 	return n, ok
@@ -192,11 +230,26 @@ func (r *NodeResolver) ToUser() (*UserResolver, bool) {
 	return n, ok
 }
 
+func (r *NodeResolver) ToAnnouncement() (*announcementResolver, bool) {
+	n, ok := r.Node.(*announcementResolver)
+	return n, ok
+}
+
+func (r *NodeResolver) ToSearchJob() (*searchJobResolver, bool) {
+	n, ok := r.Node.(*searchJobResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToOrg() (*OrgResolver, bool) {
 	n, ok := r.Node.(*OrgResolver)
 	return n, ok
 }
 
+func (r *NodeResolver) ToOrgTeam() (*orgTeamResolver, bool) {
+	n, ok := r.Node.(*orgTeamResolver)
+	return n, ok
+}
+
 func (r *NodeResolver) ToOrganizationInvitation() (*organizationInvitationResolver, bool) {
 	n, ok := r.Node.(*organizationInvitationResolver)
 	return n, ok
@@ -253,3 +306,8 @@ func (r *NodeResolver) ToBatchSpecExecution() (BatchSpecExecutionResolver, bool)
 	n, ok := r.Node.(BatchSpecExecutionResolver)
 	return n, ok
 }
+
+func (r *NodeResolver) ToBatchChangeTemplate() (BatchChangeTemplateResolver, bool) {
+	n, ok := r.Node.(BatchChangeTemplateResolver)
+	return n, ok
+}
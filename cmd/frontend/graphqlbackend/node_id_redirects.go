@@ -0,0 +1,134 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+type nodeIDRedirectResolver struct {
+	schema   *schemaResolver
+	redirect *database.NodeIDRedirect
+}
+
+func (r *nodeIDRedirectResolver) Kind() string { return r.redirect.Kind }
+
+func (r *nodeIDRedirectResolver) From() graphql.ID {
+	return relay.MarshalID(r.redirect.Kind, r.redirect.OldID)
+}
+
+func (r *nodeIDRedirectResolver) To() graphql.ID {
+	return relay.MarshalID(r.redirect.Kind, r.redirect.NewID)
+}
+
+func (r *nodeIDRedirectResolver) Target(ctx context.Context) (*NodeResolver, error) {
+	n, err := r.schema.nodeByID(ctx, r.To())
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	if n == nil {
+		return nil, nil
+	}
+	return &NodeResolver{n}, nil
+}
+
+func (r *nodeIDRedirectResolver) CreatedAt() DateTime {
+	return DateTime{Time: r.redirect.CreatedAt}
+}
+
+func (r *schemaResolver) NodeIDRedirects(ctx context.Context, args *struct{ Kind *string }) ([]*nodeIDRedirectResolver, error) {
+	// 🚨 SECURITY: Only site admins may list node ID redirects.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	var kind string
+	if args.Kind != nil {
+		kind = *args.Kind
+	}
+	redirects, err := database.NodeIDRedirects(r.db).List(ctx, kind)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*nodeIDRedirectResolver, 0, len(redirects))
+	for _, redirect := range redirects {
+		resolvers = append(resolvers, &nodeIDRedirectResolver{schema: r, redirect: redirect})
+	}
+	return resolvers, nil
+}
+
+func (r *schemaResolver) RedirectNodeID(ctx context.Context, args *struct {
+	From graphql.ID
+	To   graphql.ID
+}) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may redirect node IDs.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	kind, oldID, err := r.unmarshalRedirectableNodeID(args.From)
+	if err != nil {
+		return nil, err
+	}
+	toKind, newID, err := r.unmarshalRedirectableNodeID(args.To)
+	if err != nil {
+		return nil, err
+	}
+	if kind != toKind {
+		return nil, errors.Errorf("cannot redirect a %s ID to a %s ID", kind, toKind)
+	}
+
+	// Redirects are only followed for IDs of nodes that do not exist, so a
+	// redirect of an existing node would have no effect.
+	if _, err := r.nodeByIDFns[kind](ctx, args.From); err == nil {
+		return nil, errors.Errorf("node %s still exists", args.From)
+	} else if !errcode.IsNotFound(err) {
+		return nil, err
+	}
+	if _, err := r.nodeByID(ctx, args.To); err != nil {
+		return nil, err
+	}
+
+	if err := database.NodeIDRedirects(r.db).Create(ctx, kind, oldID, newID); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+func (r *schemaResolver) DeleteNodeIDRedirect(ctx context.Context, args *struct{ From graphql.ID }) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may delete node ID redirects.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	kind, oldID, err := r.unmarshalRedirectableNodeID(args.From)
+	if err != nil {
+		return nil, err
+	}
+	if err := database.NodeIDRedirects(r.db).Delete(ctx, kind, oldID); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+// unmarshalRedirectableNodeID returns the kind and integer spec of a node ID.
+// Only IDs with an integer spec can be redirected.
+func (r *schemaResolver) unmarshalRedirectableNodeID(id graphql.ID) (kind string, spec int64, err error) {
+	kind = relay.UnmarshalKind(id)
+	if _, ok := r.nodeByIDFns[kind]; !ok {
+		return "", 0, errors.New("invalid id")
+	}
+	if err := relay.UnmarshalSpec(id, &spec); err != nil {
+		return "", 0, errors.Errorf("IDs of %s nodes cannot be redirected", kind)
+	}
+	return kind, spec, nil
+}
@@ -12,8 +12,8 @@ import (
 
 // OutOfBandMigrationByID resolves a single out-of-band migration by its identifier.
 func (r *schemaResolver) OutOfBandMigrationByID(ctx context.Context, id graphql.ID) (*outOfBandMigrationResolver, error) {
-	// 🚨 SECURITY: Only site admins may view out-of-band migrations
-	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// 🚨 SECURITY: Only site admins and auditors may view out-of-band migrations
+	if err := backend.CheckCurrentUserIsSiteAuditor(ctx, r.db); err != nil {
 		return nil, err
 	}
 
@@ -32,8 +32,8 @@ func (r *schemaResolver) OutOfBandMigrationByID(ctx context.Context, id graphql.
 
 // OutOfBandMigrations resolves all registered single out-of-band migrations.
 func (r *schemaResolver) OutOfBandMigrations(ctx context.Context) ([]*outOfBandMigrationResolver, error) {
-	// 🚨 SECURITY: Only site admins may view out-of-band migrations
-	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// 🚨 SECURITY: Only site admins and auditors may view out-of-band migrations
+	if err := backend.CheckCurrentUserIsSiteAuditor(ctx, r.db); err != nil {
 		return nil, err
 	}
 
@@ -262,7 +262,11 @@ func sendOrgInvitationNotification(ctx context.Context, db dbutil.DB, org *types
 	})
 }
 
-var emailTemplates = txemail.MustValidate(txtypes.Templates{
+var emailTemplates = txemail.MustRegister("orgInvitation", map[string]string{
+	"FromName": "alice",
+	"OrgName":  "acme",
+	"URL":      "https://sourcegraph.example.com/organizations/acme/invitation",
+}, txtypes.Templates{
 	Subject: `{{.FromName}} invited you to join {{.OrgName}} on Sourcegraph`,
 	Text: `
 {{.FromName}} invited you to join the {{.OrgName}} organization on Sourcegraph.
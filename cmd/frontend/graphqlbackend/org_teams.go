@@ -0,0 +1,273 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func MarshalOrgTeamID(id int64) graphql.ID { return relay.MarshalID("OrgTeam", id) }
+
+func UnmarshalOrgTeamID(id graphql.ID) (teamID int64, err error) {
+	err = relay.UnmarshalSpec(id, &teamID)
+	return
+}
+
+func orgTeamByID(ctx context.Context, db dbutil.DB, id graphql.ID) (*orgTeamResolver, error) {
+	teamID, err := UnmarshalOrgTeamID(id)
+	if err != nil {
+		return nil, err
+	}
+	return orgTeamByIDInt64(ctx, db, teamID)
+}
+
+func orgTeamByIDInt64(ctx context.Context, db dbutil.DB, teamID int64) (*orgTeamResolver, error) {
+	team, err := database.OrgTeams(db).GetByID(ctx, teamID)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Only org members and site admins can view the teams of an org.
+	if err := backend.CheckOrgAccessOrSiteAdmin(ctx, db, team.OrgID); err != nil {
+		return nil, err
+	}
+	return &orgTeamResolver{db: db, team: team}, nil
+}
+
+type orgTeamResolver struct {
+	db   dbutil.DB
+	team *types.OrgTeam
+}
+
+func (r *orgTeamResolver) ID() graphql.ID { return MarshalOrgTeamID(r.team.ID) }
+
+func (r *orgTeamResolver) Name() string { return r.team.Name }
+
+func (r *orgTeamResolver) DisplayName() *string { return r.team.DisplayName }
+
+func (r *orgTeamResolver) Organization(ctx context.Context) (*OrgResolver, error) {
+	return OrgByIDInt32(ctx, r.db, r.team.OrgID)
+}
+
+func (r *orgTeamResolver) ParentTeam(ctx context.Context) (*orgTeamResolver, error) {
+	if r.team.ParentTeamID == 0 {
+		return nil, nil
+	}
+	return orgTeamByIDInt64(ctx, r.db, r.team.ParentTeamID)
+}
+
+func (r *orgTeamResolver) ChildTeams(ctx context.Context) ([]*orgTeamResolver, error) {
+	teams, err := database.OrgTeams(r.db).List(ctx, database.ListOrgTeamsOptions{ParentTeamID: r.team.ID})
+	if err != nil {
+		return nil, err
+	}
+	return toOrgTeamResolvers(r.db, teams), nil
+}
+
+func (r *orgTeamResolver) Members(ctx context.Context) (*staticUserConnectionResolver, error) {
+	userIDs, err := database.OrgTeams(r.db).ListMemberIDs(ctx, r.team.ID)
+	if err != nil {
+		return nil, err
+	}
+	users := make([]*types.User, 0, len(userIDs))
+	for _, id := range userIDs {
+		user, err := database.Users(r.db).GetByID(ctx, id)
+		if err != nil {
+			return nil, err
+		}
+		users = append(users, user)
+	}
+	return &staticUserConnectionResolver{db: r.db, users: users}, nil
+}
+
+func (r *orgTeamResolver) Repositories(ctx context.Context) ([]*RepositoryResolver, error) {
+	repoIDs, err := database.OrgTeams(r.db).ListRepoIDs(ctx, r.team.ID)
+	if err != nil || len(repoIDs) == 0 {
+		return nil, err
+	}
+	// 🚨 SECURITY: Repos.GetByIDs only returns repositories the current user
+	// has access to.
+	repos, err := database.Repos(r.db).GetByIDs(ctx, repoIDs...)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*RepositoryResolver, 0, len(repos))
+	for _, repo := range repos {
+		resolvers = append(resolvers, NewRepositoryResolver(r.db, repo))
+	}
+	return resolvers, nil
+}
+
+func (r *orgTeamResolver) ExternalTeam() *string {
+	if r.team.ExternalID == "" {
+		return nil
+	}
+	return &r.team.ExternalID
+}
+
+func (r *orgTeamResolver) SyncedAt() *DateTime {
+	if r.team.SyncedAt.IsZero() {
+		return nil
+	}
+	return &DateTime{Time: r.team.SyncedAt}
+}
+
+func (r *orgTeamResolver) SearchContextSpec(ctx context.Context) (string, error) {
+	org, err := database.Orgs(r.db).GetByID(ctx, r.team.OrgID)
+	if err != nil {
+		return "", err
+	}
+	return searchcontexts.GetSearchContextSpec(searchcontexts.GetTeamSearchContext(org, r.team)), nil
+}
+
+func toOrgTeamResolvers(db dbutil.DB, teams []*types.OrgTeam) []*orgTeamResolver {
+	resolvers := make([]*orgTeamResolver, 0, len(teams))
+	for _, team := range teams {
+		resolvers = append(resolvers, &orgTeamResolver{db: db, team: team})
+	}
+	return resolvers
+}
+
+func (o *OrgResolver) Teams(ctx context.Context) ([]*orgTeamResolver, error) {
+	// 🚨 SECURITY: Only org members and site admins can view the teams of an org.
+	if err := backend.CheckOrgAccessOrSiteAdmin(ctx, o.db, o.org.ID); err != nil {
+		return nil, err
+	}
+	teams, err := database.OrgTeams(o.db).List(ctx, database.ListOrgTeamsOptions{OrgID: o.org.ID, OnlyTopLevel: true})
+	if err != nil {
+		return nil, err
+	}
+	return toOrgTeamResolvers(o.db, teams), nil
+}
+
+type createOrgTeamArgs struct {
+	Organization    graphql.ID
+	Name            string
+	DisplayName     *string
+	ParentTeam      *graphql.ID
+	ExternalService *graphql.ID
+	ExternalTeam    *string
+}
+
+func (r *schemaResolver) CreateOrgTeam(ctx context.Context, args *createOrgTeamArgs) (*orgTeamResolver, error) {
+	orgID, err := UnmarshalOrgID(args.Organization)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only org members and site admins can create teams.
+	if err := backend.CheckOrgAccessOrSiteAdmin(ctx, r.db, orgID); err != nil {
+		return nil, err
+	}
+
+	team := &types.OrgTeam{
+		OrgID:       orgID,
+		Name:        args.Name,
+		DisplayName: args.DisplayName,
+	}
+	if args.ParentTeam != nil {
+		if team.ParentTeamID, err = UnmarshalOrgTeamID(*args.ParentTeam); err != nil {
+			return nil, err
+		}
+	}
+	if args.ExternalService != nil && args.ExternalTeam != nil {
+		// 🚨 SECURITY: Only site admins can sync teams from a code host, since
+		// the sync uses the token of the code host connection.
+		if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+			return nil, err
+		}
+		if team.ExternalServiceID, err = unmarshalExternalServiceID(*args.ExternalService); err != nil {
+			return nil, err
+		}
+		team.ExternalID = *args.ExternalTeam
+	}
+
+	team, err = database.OrgTeams(r.db).Create(ctx, team)
+	if err != nil {
+		return nil, err
+	}
+	return &orgTeamResolver{db: r.db, team: team}, nil
+}
+
+func (r *schemaResolver) DeleteOrgTeam(ctx context.Context, args *struct{ Team graphql.ID }) (*EmptyResponse, error) {
+	team, err := orgTeamByID(ctx, r.db, args.Team)
+	if err != nil {
+		return nil, err
+	}
+	if err := database.OrgTeams(r.db).Delete(ctx, team.team.ID); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+type orgTeamMemberArgs struct {
+	Team graphql.ID
+	User graphql.ID
+}
+
+func (r *schemaResolver) AddUserToOrgTeam(ctx context.Context, args *orgTeamMemberArgs) (*EmptyResponse, error) {
+	team, err := orgTeamByID(ctx, r.db, args.Team)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := UnmarshalUserID(args.User)
+	if err != nil {
+		return nil, err
+	}
+	// 🚨 SECURITY: Team members are treated as org members, so only existing
+	// members of the org can be added to a team. Otherwise this would bypass
+	// the org invitation.
+	if _, err := database.OrgMembers(r.db).GetByOrgIDAndUserID(ctx, team.team.OrgID, userID); err != nil {
+		return nil, err
+	}
+	if err := database.OrgTeams(r.db).AddMember(ctx, team.team.ID, userID); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+func (r *schemaResolver) RemoveUserFromOrgTeam(ctx context.Context, args *orgTeamMemberArgs) (*EmptyResponse, error) {
+	team, err := orgTeamByID(ctx, r.db, args.Team)
+	if err != nil {
+		return nil, err
+	}
+	userID, err := UnmarshalUserID(args.User)
+	if err != nil {
+		return nil, err
+	}
+	if err := database.OrgTeams(r.db).RemoveMember(ctx, team.team.ID, userID); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+func (r *schemaResolver) SetOrgTeamRepositories(ctx context.Context, args *struct {
+	Team         graphql.ID
+	Repositories []graphql.ID
+}) (*EmptyResponse, error) {
+	team, err := orgTeamByID(ctx, r.db, args.Team)
+	if err != nil {
+		return nil, err
+	}
+
+	repoIDs := make([]api.RepoID, 0, len(args.Repositories))
+	for _, id := range args.Repositories {
+		repoID, err := UnmarshalRepositoryID(id)
+		if err != nil {
+			return nil, err
+		}
+		repoIDs = append(repoIDs, repoID)
+	}
+
+	if err := database.OrgTeams(r.db).SetRepos(ctx, team.team.ID, repoIDs); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
@@ -362,7 +362,11 @@ func shouldCheckParam(p visitor.VisitFuncParams) bool {
 }
 
 type LimiterArgs struct {
-	IsIP          bool
+	IsIP bool
+	// UID is the ID of the user the rate limit key belongs to, if any. Overrides
+	// configured for the user also apply to keys other than the user ID, e.g. the
+	// keys of the user's access tokens.
+	UID           string
 	Anonymous     bool
 	RequestName   string
 	RequestSource trace.SourceType
@@ -376,6 +380,23 @@ type LimitWatcher interface {
 	Get() (Limiter, bool)
 }
 
+// NewLimitWatchers returns a LimitWatcher that returns the limiter of the first
+// of the given watchers that is enabled.
+func NewLimitWatchers(watchers ...LimitWatcher) LimitWatcher {
+	return limitWatchers(watchers)
+}
+
+type limitWatchers []LimitWatcher
+
+func (ws limitWatchers) Get() (Limiter, bool) {
+	for _, w := range ws {
+		if l, enabled := w.Get(); enabled {
+			return l, true
+		}
+	}
+	return nil, false
+}
+
 func NewBasicLimitWatcher(store throttled.GCRAStore) *BasicLimitWatcher {
 	basic := &BasicLimitWatcher{
 		store: store,
@@ -558,6 +579,9 @@ func (rl *RateLimiter) RateLimit(uid string, cost int, args LimiterArgs) (bool,
 	if r, ok := rl.overrides[uid]; ok {
 		return r.RateLimit(uid, cost)
 	}
+	if r, ok := rl.overrides[args.UID]; ok && args.UID != "" {
+		return r.RateLimit(uid, cost)
+	}
 	if args.IsIP {
 		return rl.ipLimiter.RateLimit(uid, cost)
 	}
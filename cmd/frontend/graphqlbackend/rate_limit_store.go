@@ -0,0 +1,64 @@
+package graphqlbackend
+
+import (
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/throttled/throttled/v2"
+)
+
+var rateLimitStoreFallbacks = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "src_graphql_rate_limit_store_fallbacks_total",
+	Help: "The number of rate limit store operations served by the in-memory store because the shared store failed.",
+})
+
+// FallbackGCRAStore is a throttled.GCRAStore that stores the rate limit state in
+// a shared store, e.g. Redis, so that all frontend instances enforce the same
+// limits. When the shared store fails, it uses a store local to the instance
+// instead, so that limits are still enforced per instance rather than not at all.
+type FallbackGCRAStore struct {
+	shared throttled.GCRAStore
+	local  throttled.GCRAStore
+}
+
+// NewFallbackGCRAStore creates a store that falls back to the local store when
+// the shared store fails.
+func NewFallbackGCRAStore(shared, local throttled.GCRAStore) *FallbackGCRAStore {
+	return &FallbackGCRAStore{shared: shared, local: local}
+}
+
+var _ throttled.GCRAStore = &FallbackGCRAStore{}
+
+func (s *FallbackGCRAStore) GetWithTime(key string) (int64, time.Time, error) {
+	value, now, err := s.shared.GetWithTime(key)
+	if err != nil {
+		s.fallback("GetWithTime", err)
+		return s.local.GetWithTime(key)
+	}
+	return value, now, nil
+}
+
+func (s *FallbackGCRAStore) SetIfNotExistsWithTTL(key string, value int64, ttl time.Duration) (bool, error) {
+	set, err := s.shared.SetIfNotExistsWithTTL(key, value, ttl)
+	if err != nil {
+		s.fallback("SetIfNotExistsWithTTL", err)
+		return s.local.SetIfNotExistsWithTTL(key, value, ttl)
+	}
+	return set, nil
+}
+
+func (s *FallbackGCRAStore) CompareAndSwapWithTTL(key string, old, new int64, ttl time.Duration) (bool, error) {
+	swapped, err := s.shared.CompareAndSwapWithTTL(key, old, new, ttl)
+	if err != nil {
+		s.fallback("CompareAndSwapWithTTL", err)
+		return s.local.CompareAndSwapWithTTL(key, old, new, ttl)
+	}
+	return swapped, nil
+}
+
+func (s *FallbackGCRAStore) fallback(op string, err error) {
+	rateLimitStoreFallbacks.Inc()
+	log15.Debug("rate limit store failed, using in-memory store", "op", op, "error", err)
+}
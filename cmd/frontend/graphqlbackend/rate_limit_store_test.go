@@ -0,0 +1,73 @@
+package graphqlbackend
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/throttled/throttled/v2"
+	"github.com/throttled/throttled/v2/store/memstore"
+)
+
+type failingGCRAStore struct{}
+
+func (failingGCRAStore) GetWithTime(string) (int64, time.Time, error) {
+	return 0, time.Time{}, errors.New("connection refused")
+}
+
+func (failingGCRAStore) SetIfNotExistsWithTTL(string, int64, time.Duration) (bool, error) {
+	return false, errors.New("connection refused")
+}
+
+func (failingGCRAStore) CompareAndSwapWithTTL(string, int64, int64, time.Duration) (bool, error) {
+	return false, errors.New("connection refused")
+}
+
+func TestFallbackGCRAStore(t *testing.T) {
+	local, err := memstore.New(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	store := NewFallbackGCRAStore(failingGCRAStore{}, local)
+
+	rl, err := throttled.NewGCRARateLimiter(store, throttled.RateQuota{MaxRate: throttled.PerHour(1)})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The requests are limited by the local store although the shared store is
+	// failing.
+	for i, want := range []bool{false, true} {
+		limited, _, err := rl.RateLimit("1", 1)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if limited != want {
+			t.Fatalf("request %d: got limited %t, want %t", i, limited, want)
+		}
+	}
+}
+
+func TestLimitWatchers(t *testing.T) {
+	store, err := memstore.New(10)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	disabled := &BasicLimitWatcher{store: store}
+	disabled.updateFromConfig(0)
+	enabled := &BasicLimitWatcher{store: store}
+	enabled.updateFromConfig(10)
+
+	if _, ok := NewLimitWatchers(disabled).Get(); ok {
+		t.Fatal("expected rate limiting to be disabled")
+	}
+
+	l, ok := NewLimitWatchers(disabled, enabled).Get()
+	if !ok {
+		t.Fatal("expected rate limiting to be enabled")
+	}
+	if want, _ := enabled.Get(); l != want {
+		t.Fatal("expected the limiter of the enabled watcher")
+	}
+}
@@ -19,16 +19,17 @@ import (
 
 type repositoryArgs struct {
 	graphqlutil.ConnectionArgs
-	Query       *string
-	Names       *[]string
-	Cloned      bool
-	NotCloned   bool
-	Indexed     bool
-	NotIndexed  bool
-	FailedFetch bool
-	OrderBy     string
-	Descending  bool
-	After       *string
+	Query        *string
+	Names        *[]string
+	Cloned       bool
+	NotCloned    bool
+	Indexed      bool
+	NotIndexed   bool
+	FailedFetch  bool
+	CloneSkipped bool
+	OrderBy      string
+	Descending   bool
+	After        *string
 }
 
 func (r *schemaResolver) Repositories(args *repositoryArgs) (*repositoryConnectionResolver, error) {
@@ -63,16 +64,18 @@ func (r *schemaResolver) Repositories(args *repositoryArgs) (*repositoryConnecti
 	}
 
 	opt.FailedFetch = args.FailedFetch
+	opt.CloneSkipped = args.CloneSkipped
 	args.ConnectionArgs.Set(&opt.LimitOffset)
 
 	return &repositoryConnectionResolver{
-		db:          r.db,
-		opt:         opt,
-		cloned:      args.Cloned,
-		notCloned:   args.NotCloned,
-		indexed:     args.Indexed,
-		notIndexed:  args.NotIndexed,
-		failedFetch: args.FailedFetch,
+		db:           r.db,
+		opt:          opt,
+		cloned:       args.Cloned,
+		notCloned:    args.NotCloned,
+		indexed:      args.Indexed,
+		notIndexed:   args.NotIndexed,
+		failedFetch:  args.FailedFetch,
+		cloneSkipped: args.CloneSkipped,
 	}, nil
 }
 
@@ -89,13 +92,14 @@ type RepositoryConnectionResolver interface {
 var _ RepositoryConnectionResolver = &repositoryConnectionResolver{}
 
 type repositoryConnectionResolver struct {
-	db          dbutil.DB
-	opt         database.ReposListOptions
-	cloned      bool
-	notCloned   bool
-	indexed     bool
-	notIndexed  bool
-	failedFetch bool
+	db           dbutil.DB
+	opt          database.ReposListOptions
+	cloned       bool
+	notCloned    bool
+	indexed      bool
+	notIndexed   bool
+	failedFetch  bool
+	cloneSkipped bool
 
 	// cache results because they are used by multiple fields
 	once  sync.Once
@@ -149,6 +153,7 @@ func (r *repositoryConnectionResolver) compute(ctx context.Context) ([]*types.Re
 			opt2.OnlyCloned = true
 		}
 		opt2.FailedFetch = r.failedFetch
+		opt2.CloneSkipped = r.cloneSkipped
 
 		for {
 			// Cursor-based pagination requires that we fetch limit+1 records, so
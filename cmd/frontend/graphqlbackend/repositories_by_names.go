@@ -0,0 +1,57 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+// maxRepositoriesByNames is the maximum number of names that can be looked up
+// with a single repositoriesByNames query.
+const maxRepositoriesByNames = 1000
+
+type repositoryByNameResultResolver struct {
+	name string
+	repo *RepositoryResolver
+}
+
+func (r *repositoryByNameResultResolver) Name() string                    { return r.name }
+func (r *repositoryByNameResultResolver) Repository() *RepositoryResolver { return r.repo }
+
+func (r *schemaResolver) RepositoriesByNames(ctx context.Context, args *struct {
+	Names []string
+}) ([]*repositoryByNameResultResolver, error) {
+	if len(args.Names) > maxRepositoriesByNames {
+		return nil, errors.Errorf("at most %d repositories can be looked up at once, got %d", maxRepositoriesByNames, len(args.Names))
+	}
+
+	results := make([]*repositoryByNameResultResolver, 0, len(args.Names))
+	if len(args.Names) == 0 {
+		return results, nil
+	}
+
+	// 🚨 SECURITY: The repositories are listed with the permissions of the
+	// current user in a single query, so that repositories the user cannot
+	// access are indistinguishable from repositories that don't exist.
+	repos, err := backend.Repos.List(ctx, database.ReposListOptions{Names: args.Names})
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make(map[api.RepoName]*RepositoryResolver, len(repos))
+	for _, repo := range repos {
+		resolvers[repo.Name] = NewRepositoryResolver(r.db, repo)
+	}
+
+	for _, name := range args.Names {
+		results = append(results, &repositoryByNameResultResolver{
+			name: name,
+			repo: resolvers[api.RepoName(name)],
+		})
+	}
+	return results, nil
+}
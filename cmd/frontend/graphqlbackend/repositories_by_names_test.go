@@ -0,0 +1,67 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRepositoriesByNames(t *testing.T) {
+	resetMocks()
+	calls := 0
+	database.Mocks.Repos.List = func(ctx context.Context, opt database.ReposListOptions) ([]*types.Repo, error) {
+		calls++
+		if diff := cmp.Diff([]string{"github.com/a/b", "github.com/missing/repo", "github.com/c/d"}, opt.Names); diff != "" {
+			t.Errorf("unexpected names (-want +got):\n%s", diff)
+		}
+		return []*types.Repo{
+			{ID: 2, Name: "github.com/c/d"},
+			{ID: 1, Name: "github.com/a/b"},
+		}, nil
+	}
+	defer resetMocks()
+
+	RunTests(t, []*Test{
+		{
+			Schema: mustParseGraphQLSchema(t),
+			Query: `
+				{
+					repositoriesByNames(names: ["github.com/a/b", "github.com/missing/repo", "github.com/c/d"]) {
+						name
+						repository { name }
+					}
+				}
+			`,
+			ExpectedResult: `
+				{
+					"repositoriesByNames": [
+						{ "name": "github.com/a/b", "repository": { "name": "github.com/a/b" } },
+						{ "name": "github.com/missing/repo", "repository": null },
+						{ "name": "github.com/c/d", "repository": { "name": "github.com/c/d" } }
+					]
+				}
+			`,
+		},
+	})
+
+	if calls != 1 {
+		t.Errorf("expected repositories to be listed once, got %d calls", calls)
+	}
+}
+
+func TestRepositoriesByNamesTooMany(t *testing.T) {
+	names := make([]string, maxRepositoriesByNames+1)
+	for i := range names {
+		names[i] = fmt.Sprintf("repo%d", i)
+	}
+
+	r := &schemaResolver{}
+	if _, err := r.RepositoriesByNames(context.Background(), &struct{ Names []string }{Names: names}); err == nil {
+		t.Fatal("expected error for too many names")
+	}
+}
@@ -361,6 +361,10 @@ func (r *RepositoryResolver) IndexConfiguration(ctx context.Context) (IndexConfi
 	return EnterpriseResolvers.codeIntelResolver.IndexConfiguration(ctx, r.ID())
 }
 
+func (r *RepositoryResolver) LSIFUploadTokens(ctx context.Context) ([]LSIFUploadTokenResolver, error) {
+	return EnterpriseResolvers.codeIntelResolver.LSIFUploadTokens(ctx, r.ID())
+}
+
 func (r *RepositoryResolver) CodeIntelligenceCommitGraph(ctx context.Context) (CodeIntelligenceCommitGraphResolver, error) {
 	return EnterpriseResolvers.codeIntelResolver.CommitGraph(ctx, r.ID())
 }
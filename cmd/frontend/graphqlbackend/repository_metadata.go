@@ -0,0 +1,73 @@
+package graphqlbackend
+
+import (
+	"context"
+	"sort"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+type repositoryMetadataResolver struct {
+	key, value string
+}
+
+func (r *repositoryMetadataResolver) Key() string   { return r.key }
+func (r *repositoryMetadataResolver) Value() string { return r.value }
+
+func (r *RepositoryResolver) Metadata(ctx context.Context) ([]*repositoryMetadataResolver, error) {
+	meta, err := database.RepoMetadata(r.db).Get(ctx, r.IDInt32())
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*repositoryMetadataResolver, 0, len(meta))
+	for key, value := range meta {
+		resolvers = append(resolvers, &repositoryMetadataResolver{key: key, value: value})
+	}
+	sort.Slice(resolvers, func(i, j int) bool { return resolvers[i].key < resolvers[j].key })
+	return resolvers, nil
+}
+
+func (r *schemaResolver) SetRepositoryMetadata(ctx context.Context, args *struct {
+	Repository graphql.ID
+	Key        string
+	Value      *string
+}) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins can set repository metadata, since it is
+	// used to filter repositories in search and may be relied upon by automation.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+	repoID, err := UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return nil, err
+	}
+	var value string
+	if args.Value != nil {
+		value = *args.Value
+	}
+	if err := database.RepoMetadata(r.db).Set(ctx, repoID, args.Key, value); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
+func (r *schemaResolver) DeleteRepositoryMetadata(ctx context.Context, args *struct {
+	Repository graphql.ID
+	Key        string
+}) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins can delete repository metadata.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+	repoID, err := UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return nil, err
+	}
+	if err := database.RepoMetadata(r.db).Delete(ctx, repoID, args.Key); err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
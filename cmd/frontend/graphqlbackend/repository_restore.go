@@ -0,0 +1,41 @@
+package graphqlbackend
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/repos"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+)
+
+func (r *schemaResolver) RestoreRepository(ctx context.Context, args *struct {
+	Repository graphql.ID
+}) (*RepositoryResolver, error) {
+	// 🚨 SECURITY: Only site admins can restore deleted repositories.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	repoID, err := UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	repo, err := repos.NewStore(r.db, sql.TxOptions{}).RestoreRepo(ctx, repoID, conf.RepoRestoreWindow())
+	if err != nil {
+		return nil, err
+	}
+
+	// The restored repository is updated right away rather than on its next
+	// scheduled update, in case its clone was removed in the meantime.
+	if _, err := repoupdater.DefaultClient.EnqueueRepoUpdate(ctx, repo.Name); err != nil {
+		log15.Warn("Failed to enqueue update of restored repository", "repo", repo.Name, "error", err)
+	}
+
+	return NewRepositoryResolver(r.db, repo), nil
+}
@@ -0,0 +1,217 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/google/zoekt"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+type repositoryStatisticsArgs struct {
+	graphqlutil.ConnectionArgs
+	After        *string
+	Query        *string
+	Cloned       bool
+	NotCloned    bool
+	Indexed      bool
+	NotIndexed   bool
+	FailedFetch  bool
+	MinSizeBytes *BigInt
+	MaxSizeBytes *BigInt
+	OrderBy      string
+	Descending   bool
+}
+
+// Repositories returns the statistics of the repositories of the site. Access
+// is checked by schemaResolver.RepositoryStats.
+func (r *repositoryStatsResolver) Repositories(args *repositoryStatisticsArgs) (*repositoryStatisticsConnectionResolver, error) {
+	opt := database.ListRepoGitserverStatusOptions{
+		OnlyCloned:  !args.NotCloned,
+		NoCloned:    !args.Cloned,
+		FailedFetch: args.FailedFetch,
+		Descending:  args.Descending,
+	}
+	if args.Query != nil {
+		opt.Query = *args.Query
+	}
+	if args.MinSizeBytes != nil {
+		opt.MinSizeBytes = args.MinSizeBytes.Int
+	}
+	if args.MaxSizeBytes != nil {
+		opt.MaxSizeBytes = args.MaxSizeBytes.Int
+	}
+	switch args.OrderBy {
+	case "SIZE":
+		opt.OrderBy = database.RepoGitserverStatusOrderBySize
+	case "LAST_FETCHED":
+		opt.OrderBy = database.RepoGitserverStatusOrderByLastFetched
+	default:
+		opt.OrderBy = database.RepoGitserverStatusOrderByName
+	}
+
+	args.ConnectionArgs.Set(&opt.LimitOffset)
+	if args.After != nil {
+		offset, err := strconv.Atoi(*args.After)
+		if err != nil {
+			return nil, err
+		}
+		if opt.LimitOffset == nil {
+			opt.LimitOffset = &database.LimitOffset{}
+		}
+		opt.Offset = offset
+	}
+
+	return &repositoryStatisticsConnectionResolver{
+		db:         r.db,
+		opt:        opt,
+		indexed:    args.Indexed,
+		notIndexed: args.NotIndexed,
+	}, nil
+}
+
+type repositoryStatisticsConnectionResolver struct {
+	db         dbutil.DB
+	opt        database.ListRepoGitserverStatusOptions
+	indexed    bool
+	notIndexed bool
+
+	// cache results because they are used by multiple fields
+	once       sync.Once
+	indexedSet map[string]*zoekt.Repository
+	statuses   []types.RepoGitserverStatus
+	count      int
+	err        error
+}
+
+func (r *repositoryStatisticsConnectionResolver) compute(ctx context.Context) ([]types.RepoGitserverStatus, int, error) {
+	r.once.Do(func() {
+		opt := r.opt
+
+		if search.Indexed().Enabled() {
+			listCtx, cancel := context.WithTimeout(ctx, 5*time.Second)
+			defer cancel()
+			r.indexedSet, r.err = search.Indexed().ListAll(listCtx)
+			if r.err != nil {
+				return
+			}
+			if !r.indexed || !r.notIndexed {
+				opt.IndexedNames = make([]string, 0, len(r.indexedSet))
+				for name := range r.indexedSet {
+					opt.IndexedNames = append(opt.IndexedNames, name)
+				}
+				opt.OnlyIndexed = !r.notIndexed
+				opt.NoIndexed = !r.indexed
+			}
+		} else if !r.indexed {
+			// All repositories count as indexed if indexed search is disabled.
+			return
+		}
+
+		store := database.GitserverRepos(r.db)
+		if r.statuses, r.err = store.ListRepoGitserverStatus(ctx, opt); r.err != nil {
+			return
+		}
+		r.count, r.err = store.CountRepoGitserverStatus(ctx, opt)
+	})
+	return r.statuses, r.count, r.err
+}
+
+func (r *repositoryStatisticsConnectionResolver) Nodes(ctx context.Context) ([]*repositoryStatisticsResolver, error) {
+	statuses, _, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*repositoryStatisticsResolver, 0, len(statuses))
+	for _, status := range statuses {
+		indexed := true
+		if r.indexedSet != nil {
+			_, indexed = r.indexedSet[string(status.Name)]
+		}
+		resolvers = append(resolvers, &repositoryStatisticsResolver{
+			db:      r.db,
+			status:  status,
+			indexed: indexed,
+		})
+	}
+	return resolvers, nil
+}
+
+func (r *repositoryStatisticsConnectionResolver) TotalCount(ctx context.Context) (int32, error) {
+	_, count, err := r.compute(ctx)
+	return int32(count), err
+}
+
+func (r *repositoryStatisticsConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
+	statuses, count, err := r.compute(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if r.opt.LimitOffset == nil {
+		return graphqlutil.HasNextPage(false), nil
+	}
+	next := r.opt.Offset + len(statuses)
+	if len(statuses) == 0 || next >= count {
+		return graphqlutil.HasNextPage(false), nil
+	}
+	return graphqlutil.NextPageCursor(strconv.Itoa(next)), nil
+}
+
+type repositoryStatisticsResolver struct {
+	db      dbutil.DB
+	status  types.RepoGitserverStatus
+	indexed bool
+}
+
+func (r *repositoryStatisticsResolver) Repository() *RepositoryResolver {
+	return NewRepositoryResolver(r.db, &types.Repo{ID: r.status.ID, Name: r.status.Name})
+}
+
+func (r *repositoryStatisticsResolver) CloneStatus() string {
+	if r.status.GitserverRepo == nil {
+		return "UNKNOWN"
+	}
+	switch r.status.CloneStatus {
+	case types.CloneStatusNotCloned:
+		return "NOT_CLONED"
+	case types.CloneStatusCloning:
+		return "CLONING"
+	case types.CloneStatusCloned:
+		return "CLONED"
+	default:
+		return "UNKNOWN"
+	}
+}
+
+func (r *repositoryStatisticsResolver) Indexed() bool {
+	return r.indexed
+}
+
+func (r *repositoryStatisticsResolver) SizeBytes() *BigInt {
+	if r.status.GitserverRepo == nil || r.status.LastFetched.IsZero() {
+		return nil
+	}
+	return &BigInt{Int: r.status.RepoSizeBytes}
+}
+
+func (r *repositoryStatisticsResolver) LastFetched() *DateTime {
+	if r.status.GitserverRepo == nil || r.status.LastFetched.IsZero() {
+		return nil
+	}
+	return &DateTime{Time: r.status.LastFetched}
+}
+
+func (r *repositoryStatisticsResolver) LastError() *string {
+	if r.status.GitserverRepo == nil || r.status.LastError == "" {
+		return nil
+	}
+	return &r.status.LastError
+}
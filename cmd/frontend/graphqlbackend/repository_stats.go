@@ -2,37 +2,65 @@ package graphqlbackend
 
 import (
 	"context"
+	"sync"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	searchbackend "github.com/sourcegraph/sourcegraph/internal/search/backend"
 	"github.com/sourcegraph/sourcegraph/internal/usagestats"
 )
 
 type repositoryStatsResolver struct {
-	gitDirBytes       uint64
-	indexedLinesCount uint64
+	db dbutil.DB
+
+	// The totals are only computed if they are requested, because doing so
+	// queries all gitservers and the text search index.
+	once  sync.Once
+	stats *usagestats.Repositories
+	err   error
 }
 
-func (r *repositoryStatsResolver) GitDirBytes() BigInt {
-	return BigInt{Int: int64(r.gitDirBytes)}
+func (r *repositoryStatsResolver) compute(ctx context.Context) (*usagestats.Repositories, error) {
+	r.once.Do(func() {
+		r.stats, r.err = usagestats.GetRepositories(ctx)
+	})
+	return r.stats, r.err
 }
 
-func (r *repositoryStatsResolver) IndexedLinesCount() BigInt {
-	return BigInt{Int: int64(r.indexedLinesCount)}
+func (r *repositoryStatsResolver) GitDirBytes(ctx context.Context) (BigInt, error) {
+	stats, err := r.compute(ctx)
+	if err != nil {
+		return BigInt{}, err
+	}
+	return BigInt{Int: int64(stats.GitDirBytes)}, nil
 }
 
-func (r *schemaResolver) RepositoryStats(ctx context.Context) (*repositoryStatsResolver, error) {
-	// 🚨 SECURITY: Only site admins may query repository statistics for the site.
-	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
-		return nil, err
+func (r *repositoryStatsResolver) IndexedLinesCount(ctx context.Context) (BigInt, error) {
+	stats, err := r.compute(ctx)
+	if err != nil {
+		return BigInt{}, err
 	}
+	return BigInt{Int: int64(stats.DefaultBranchNewLinesCount + stats.OtherBranchesNewLinesCount)}, nil
+}
 
-	stats, err := usagestats.GetRepositories(ctx)
+func (r *repositoryStatsResolver) TextSearchIndexCorruptRepositoriesCount() (int32, error) {
+	corrupt, err := searchbackend.CorruptShards.List()
 	if err != nil {
+		return 0, err
+	}
+	return int32(len(corrupt)), nil
+}
+
+func (r *repositoryStatsResolver) TextSearchIndexShardCrashesCount() (int32, error) {
+	crashes, err := searchbackend.CorruptShards.Crashes()
+	return int32(crashes), err
+}
+
+func (r *schemaResolver) RepositoryStats(ctx context.Context) (*repositoryStatsResolver, error) {
+	// 🚨 SECURITY: Only site admins may query repository statistics for the site.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
 		return nil, err
 	}
 
-	return &repositoryStatsResolver{
-		gitDirBytes:       stats.GitDirBytes,
-		indexedLinesCount: stats.DefaultBranchNewLinesCount + stats.OtherBranchesNewLinesCount,
-	}, nil
+	return &repositoryStatsResolver{db: r.db}, nil
 }
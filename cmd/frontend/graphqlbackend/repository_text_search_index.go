@@ -9,6 +9,7 @@ import (
 	zoektquery "github.com/google/zoekt/query"
 
 	"github.com/sourcegraph/sourcegraph/internal/search"
+	searchbackend "github.com/sourcegraph/sourcegraph/internal/search/backend"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
@@ -67,6 +68,17 @@ func (r *repositoryTextSearchIndexResolver) Status(ctx context.Context) (*reposi
 	return &repositoryTextSearchIndexStatus{entry: *entry}, nil
 }
 
+func (r *repositoryTextSearchIndexResolver) CorruptSince() (*DateTime, error) {
+	corrupt, err := searchbackend.CorruptShards.List()
+	if err != nil {
+		return nil, err
+	}
+	if since, ok := corrupt[r.repo.IDInt32()]; ok {
+		return &DateTime{Time: since}, nil
+	}
+	return nil, nil
+}
+
 type repositoryTextSearchIndexStatus struct {
 	entry zoekt.RepoListEntry
 }
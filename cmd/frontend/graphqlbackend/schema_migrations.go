@@ -0,0 +1,68 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbconn"
+)
+
+// SchemaMigrations resolves the recorded progress of schema migrations.
+func (r *schemaResolver) SchemaMigrations(ctx context.Context) ([]*schemaMigrationResolver, error) {
+	// 🚨 SECURITY: Only site admins and auditors may view schema migrations
+	if err := backend.CheckCurrentUserIsSiteAuditor(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	progress, err := dbconn.ListMigrationProgress(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*schemaMigrationResolver, 0, len(progress))
+	for _, p := range progress {
+		resolvers = append(resolvers, &schemaMigrationResolver{p})
+	}
+
+	return resolvers, nil
+}
+
+// schemaMigrationResolver implements the GraphQL type SchemaMigration.
+type schemaMigrationResolver struct {
+	p *dbconn.MigrationProgress
+}
+
+func (r *schemaMigrationResolver) Database() string      { return r.p.Database }
+func (r *schemaMigrationResolver) Version() string       { return strconv.FormatInt(r.p.Version, 10) }
+func (r *schemaMigrationResolver) Name() string          { return r.p.Name }
+func (r *schemaMigrationResolver) State() string         { return strings.ToUpper(r.p.State) }
+func (r *schemaMigrationResolver) Step() string          { return r.p.Step }
+func (r *schemaMigrationResolver) StartedAt() DateTime   { return DateTime{r.p.StartedAt} }
+func (r *schemaMigrationResolver) UpdatedAt() DateTime   { return DateTime{r.p.UpdatedAt} }
+func (r *schemaMigrationResolver) FinishedAt() *DateTime { return DateTimeOrNil(r.p.FinishedAt) }
+
+func (r *schemaMigrationResolver) Progress() *float64 {
+	if r.p.RowsTotal == nil {
+		return nil
+	}
+	if *r.p.RowsTotal == 0 {
+		progress := 1.0
+		return &progress
+	}
+
+	progress := float64(r.p.RowsDone) / float64(*r.p.RowsTotal)
+	if progress > 1 {
+		// Rows written during a backfill are backfilled too.
+		progress = 1
+	}
+	return &progress
+}
+
+func (r *schemaMigrationResolver) Message() *string {
+	if r.p.Message == "" {
+		return nil
+	}
+	return &r.p.Message
+}
@@ -5,6 +5,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	otlog "github.com/opentracing/opentracing-go/log"
@@ -45,6 +46,11 @@ type SearchArgs struct {
 	// to make it visible in the browser.
 	Stream streaming.Sender
 
+	// Exhaustive if true searches for all results of the query without the
+	// limits and timeouts of interactive searches. It is set by search jobs,
+	// which run in the background.
+	Exhaustive bool
+
 	// For tests
 	Settings *schema.Settings
 }
@@ -88,23 +94,31 @@ func NewSearchImplementer(ctx context.Context, db dbutil.DB, args *SearchArgs) (
 	var plan query.Plan
 	globbing := getBoolPtr(settings.SearchGlobbing, false)
 	tr.LogFields(otlog.Bool("globbing", globbing))
+	maxUserTimeout := search.MaxUserTimeout(conf.Get())
 	plan, err = query.Pipeline(
 		query.Init(args.Query, searchType),
 		query.With(globbing, query.Globbing),
+		query.With(maxUserTimeout > 0 && !args.Exhaustive, query.MaxTimeout(maxUserTimeout)),
 	)
 	if err != nil {
-		return alertForQuery(args.Query, err).wrapSearchImplementer(db), nil
+		return alertForQuery(args.Query, err).localize(alertLocale(ctx)).wrapSearchImplementer(db), nil
+	}
+	if args.Exhaustive {
+		plan = exhaustivePlan(plan)
 	}
 	tr.LazyPrintf("parsing done")
 
 	defaultLimit := defaultMaxSearchResults
-	if args.Stream != nil {
+	if group, _ := plan.ToParseTree().StringValue(query.FieldGroup); args.Stream != nil || group != "" {
 		defaultLimit = defaultMaxSearchResultsStreaming
 	}
 	if searchType == query.SearchTypeStructural {
 		// Set a lower max result count until structural search supports true streaming.
 		defaultLimit = defaultMaxSearchResults
 	}
+	if args.Exhaustive {
+		defaultLimit = exhaustiveSearchCount
+	}
 
 	return &searchResolver{
 		db: db,
@@ -116,6 +130,8 @@ func NewSearchImplementer(ctx context.Context, db dbutil.DB, args *SearchArgs) (
 			UserSettings:   settings,
 			PatternType:    searchType,
 			DefaultLimit:   defaultLimit,
+			Locale:         alertLocale(ctx),
+			Exhaustive:     args.Exhaustive,
 		},
 
 		stream: args.Stream,
@@ -231,6 +247,35 @@ func (r *searchResolver) protocol() search.Protocol {
 const defaultMaxSearchResults = 30
 const defaultMaxSearchResultsStreaming = 500
 
+// exhaustiveSearchCount is the count of exhaustive searches that don't set
+// count:. It is the same as count:all.
+const exhaustiveSearchCount = 99999999
+
+// exhaustiveSearchTimeout bounds exhaustive searches, which are otherwise
+// not subject to the timeouts of interactive searches.
+const exhaustiveSearchTimeout = 24 * time.Hour
+
+// exhaustivePlan returns plan with count:all added to the queries which don't
+// set count:, so that all of their results are searched for.
+func exhaustivePlan(plan query.Plan) query.Plan {
+	exhaustive := make(query.Plan, 0, len(plan))
+	for _, b := range plan {
+		if b.GetCount() == "" {
+			b = b.AddCount(exhaustiveSearchCount)
+		}
+		exhaustive = append(exhaustive, b)
+	}
+	return exhaustive
+}
+
+// timeout returns the timeout of the basic query b.
+func (r *searchResolver) timeout(b query.Basic) time.Duration {
+	if r.Exhaustive {
+		return exhaustiveSearchTimeout
+	}
+	return search.TimeoutDuration(b)
+}
+
 var mockDecodedViewerFinalSettings *schema.Settings
 
 func decodedViewerFinalSettings(ctx context.Context, db dbutil.DB) (_ *schema.Settings, err error) {
@@ -0,0 +1,64 @@
+package graphqlbackend
+
+import (
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+)
+
+type searchAggregationsArgs struct {
+	By    string
+	Limit int32
+}
+
+// Aggregations counts all results of the search grouped by a dimension. Like
+// RepositoryGroups, it is not limited to the first page of results.
+func (sr *SearchResultsResolver) Aggregations(args *searchAggregationsArgs) (*searchAggregationResolver, error) {
+	if args.Limit < 0 {
+		return nil, errors.New("limit must be non-negative")
+	}
+
+	aggregation := streaming.Aggregation{Dimension: streaming.AggregationDimension(args.By)}
+	aggregation.Update(streaming.SearchEvent{
+		Results: sr.Matches,
+		Stats:   sr.Stats,
+	})
+
+	return &searchAggregationResolver{
+		dimension: args.By,
+		result:    aggregation.Compute(int(args.Limit)),
+	}, nil
+}
+
+type searchAggregationResolver struct {
+	dimension string
+	result    streaming.AggregationResult
+}
+
+func (r *searchAggregationResolver) Dimension() string { return r.dimension }
+
+func (r *searchAggregationResolver) Groups() []*searchAggregationGroupResolver {
+	resolvers := make([]*searchAggregationGroupResolver, 0, len(r.result.Groups))
+	for _, g := range r.result.Groups {
+		resolvers = append(resolvers, &searchAggregationGroupResolver{group: g})
+	}
+	return resolvers
+}
+
+func (r *searchAggregationResolver) OtherGroupCount() int32 { return int32(r.result.OtherGroupCount) }
+
+func (r *searchAggregationResolver) OtherCount() int32 { return int32(r.result.OtherCount) }
+
+func (r *searchAggregationResolver) UnattributedCount() int32 {
+	return int32(r.result.UnattributedCount)
+}
+
+func (r *searchAggregationResolver) IsExact() bool { return r.result.IsExact }
+
+type searchAggregationGroupResolver struct {
+	group streaming.AggregationGroup
+}
+
+func (r *searchAggregationGroupResolver) Label() string { return r.group.Label }
+
+func (r *searchAggregationGroupResolver) Count() int32 { return int32(r.group.Count) }
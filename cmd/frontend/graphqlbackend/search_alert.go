@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/comby"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
@@ -36,6 +37,12 @@ type searchAlert struct {
 	proposedQueries []*searchQueryDescription
 	// The higher the priority the more important is the alert.
 	priority int
+	// params are the values interpolated into the title and description,
+	// which translations in search.alertMessages can refer to.
+	params map[string]interface{}
+	// dismissKey, if set, is the key with which users dismiss the alert in
+	// the search.dismissedAlerts setting.
+	dismissKey string
 }
 
 func (a searchAlert) PrometheusType() string { return a.prometheusType }
@@ -56,6 +63,13 @@ func (a searchAlert) ProposedQueries() *[]*searchQueryDescription {
 	return &a.proposedQueries
 }
 
+func (a searchAlert) IsDismissibleWithKey() *string {
+	if a.dismissKey == "" {
+		return nil
+	}
+	return &a.dismissKey
+}
+
 func alertForCappedAndExpression() *searchAlert {
 	return &searchAlert{
 		prometheusType: "exceed_and_expression_search_limit",
@@ -73,26 +87,53 @@ func alertForQuery(queryString string, err error) *searchAlert {
 			description:    `I'm having trouble understanding that query. Your query contains "and" or "or" operators that make me think they apply to filters like "repo:" or "file:". We only support "and" or "or" operators on search patterns for file contents currently. You can help me by putting parentheses around the search pattern.`,
 		}
 	}
+	var timeoutErr *query.TimeoutExceededError
+	if errors.As(err, &timeoutErr) {
+		return &searchAlert{
+			prometheusType: "timeout_exceeds_maximum",
+			title:          "Timeout too long",
+			description:    fmt.Sprintf("The timeout:%s in your query exceeds the maximum timeout of %s allowed on this instance. Use a timeout of at most %s.", timeoutErr.Timeout, timeoutErr.Max, timeoutErr.Max),
+			params:         map[string]interface{}{"Timeout": timeoutErr.Timeout, "Max": timeoutErr.Max},
+		}
+	}
 	return &searchAlert{
 		prometheusType: "generic_invalid_query",
 		title:          "Unable To Process Query",
 		description:    capFirst(err.Error()),
+		params:         map[string]interface{}{"Error": capFirst(err.Error())},
 	}
 }
 
 func alertForTimeout(usedTime time.Duration, suggestTime time.Duration, r *searchResolver) *searchAlert {
+	// Never suggest a timeout that users are not allowed to set.
+	if maxUserTimeout := search.MaxUserTimeout(conf.Get()); maxUserTimeout > 0 {
+		if suggestTime > maxUserTimeout {
+			suggestTime = maxUserTimeout
+		}
+		if suggestTime <= usedTime.Round(time.Second) {
+			return &searchAlert{
+				prometheusType: "timed_out__max_timeout",
+				title:          "Timed out while searching",
+				description:    fmt.Sprintf("We weren't able to find any results in %s, and the maximum timeout allowed on this instance is %s. Try narrowing your query with filters like \"repo:\" or \"file:\".", usedTime.Round(time.Second), maxUserTimeout),
+				params:         map[string]interface{}{"Duration": usedTime.Round(time.Second), "Max": maxUserTimeout},
+			}
+		}
+	}
+
 	q, err := query.ParseLiteral(r.rawQuery()) // Invariant: query is already validated; guard against error anyway.
 	if err != nil {
 		return &searchAlert{
 			prometheusType: "timed_out",
 			title:          "Timed out while searching",
 			description:    fmt.Sprintf("We weren't able to find any results in %s. Try adding timeout: with a higher value.", usedTime.Round(time.Second)),
+			params:         map[string]interface{}{"Duration": usedTime.Round(time.Second)},
 		}
 	}
 	return &searchAlert{
 		prometheusType: "timed_out",
 		title:          "Timed out while searching",
 		description:    fmt.Sprintf("We weren't able to find any results in %s.", usedTime.Round(time.Second)),
+		params:         map[string]interface{}{"Duration": usedTime.Round(time.Second), "SuggestedTimeout": suggestTime},
 		proposedQueries: []*searchQueryDescription{
 			{
 				description: "query with longer timeout",
@@ -146,6 +187,7 @@ func (r *searchResolver) alertForNoResolvedRepos(ctx context.Context, q query.Q)
 			prometheusType: "no_resolved_repos__repogroup_empty",
 			title:          fmt.Sprintf("Add repositories to repogroup:%s to see results", repoGroupFilters[0]),
 			description:    fmt.Sprintf("The repository group %q is empty. See the documentation for configuration and troubleshooting.", repoGroupFilters[0]),
+			params:         map[string]interface{}{"RepoGroup": repoGroupFilters[0]},
 		}
 	}
 	if len(repoFilters) == 0 && len(repoGroupFilters) > 1 {
@@ -167,25 +209,12 @@ func (r *searchResolver) alertForNoResolvedRepos(ctx context.Context, q query.Q)
 			prometheusType:  "no_resolved_repos__context_none_in_common",
 			title:           fmt.Sprintf("No repositories found for your query within the context %s", contextFilters[0]),
 			proposedQueries: proposedQueries,
+			params:          map[string]interface{}{"Context": contextFilters[0]},
 		}
 	}
 
-	isSiteAdmin := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db) == nil
-	if !envvar.SourcegraphDotComMode() {
-		if needsRepoConfig, err := needsRepositoryConfiguration(ctx, r.db); err == nil && needsRepoConfig {
-			if isSiteAdmin {
-				return &searchAlert{
-					title:       "No repositories or code hosts configured",
-					description: "To start searching code, first go to site admin to configure repositories and code hosts.",
-				}
-
-			} else {
-				return &searchAlert{
-					title:       "No repositories or code hosts configured",
-					description: "To start searching code, ask the site admin to configure and enable repositories.",
-				}
-			}
-		}
+	if alert := r.noRepositoriesAlert(ctx); alert != nil {
+		return alert
 	}
 
 	if globbing {
@@ -430,6 +459,7 @@ func alertForMissingRepoRevs(missingRepoRevs []*search.RepositoryRevisions) *sea
 		prometheusType: "missing_repo_revs",
 		title:          "Some repositories could not be searched",
 		description:    description,
+		params:         map[string]interface{}{"Count": len(missingRepoRevs)},
 	}
 }
 
@@ -528,6 +558,7 @@ func alertForError(err error) *searchAlert {
 			prometheusType: "exceeded_diff_commit_search_limit",
 			title:          fmt.Sprintf("Too many matching repositories for %s search to handle", rErr.ResultType),
 			description:    fmt.Sprintf(`%s search can currently only handle searching across %d repositories at a time. Try using the "repo:" filter to narrow down which repositories to search, or using 'after:"1 week ago"'.`, strings.Title(rErr.ResultType), rErr.Max),
+			params:         map[string]interface{}{"ResultType": rErr.ResultType, "Max": rErr.Max},
 			priority:       2,
 		}
 	} else if errors.As(err, &tErr) {
@@ -535,6 +566,7 @@ func alertForError(err error) *searchAlert {
 			prometheusType: "exceeded_diff_commit_with_time_search_limit",
 			title:          fmt.Sprintf("Too many matching repositories for %s search to handle", tErr.ResultType),
 			description:    fmt.Sprintf(`%s search can currently only handle searching across %d repositories at a time. Try using the "repo:" filter to narrow down which repositories to search.`, strings.Title(tErr.ResultType), tErr.Max),
+			params:         map[string]interface{}{"ResultType": tErr.ResultType, "Max": tErr.Max},
 			priority:       1,
 		}
 	}
@@ -637,6 +669,10 @@ type alertObserver struct {
 	mu    sync.Mutex
 	alert *searchAlert
 	err   error
+
+	// notices are the alerts which lost against alert, but are still
+	// worth showing to the user.
+	notices []*searchAlert
 }
 
 func (o *alertObserver) Error(ctx context.Context, err error) {
@@ -661,24 +697,60 @@ func (o *alertObserver) Error(ctx context.Context, err error) {
 	o.err = multierror.Append(o.err, err)
 }
 
-// update to alert if it is more important than our current alert.
+// update to alert if it is more important than our current alert. The less
+// important alert is kept as a notice.
 func (o *alertObserver) update(alert *searchAlert) {
-	if o.alert == nil || alert.priority > o.alert.priority {
+	if o.alert == nil {
 		o.alert = alert
+		return
+	}
+	if alert.priority > o.alert.priority {
+		o.alert, alert = alert, o.alert
 	}
+	o.notices = append(o.notices, alert)
 }
 
-//  Done returns the highest priority alert and a multierror.Error containing
-//  all errors that could not be converted to alerts.
-func (o *alertObserver) Done(stats *streaming.Stats) (*searchAlert, error) {
+//  Done returns the highest priority alert, the remaining alerts as notices
+//  and a multierror.Error containing all errors that could not be converted
+//  to alerts.
+func (o *alertObserver) Done(stats *streaming.Stats) (*searchAlert, []*searchAlert, error) {
 	if !o.hasResults && o.Inputs.PatternType != query.SearchTypeStructural && comby.MatchHoleRegexp.MatchString(o.Inputs.OriginalQuery) {
 		o.update(alertForStructuralSearchNotSet(o.Inputs.OriginalQuery))
 	}
 
+	notices := dedupeNotices(o.alert, o.notices)
+
 	if o.hasResults && o.err != nil {
 		log15.Error("Errors during search", "error", o.err)
-		return o.alert, nil
+		return o.alert, notices, nil
+	}
+
+	return o.alert, notices, o.err
+}
+
+// dedupeNotices returns notices without the alerts that are equal to alert or
+// to a preceding notice, ordered by descending priority. The same alert is
+// often raised for many repositories.
+func dedupeNotices(alert *searchAlert, notices []*searchAlert) []*searchAlert {
+	if alert == nil || len(notices) == 0 {
+		return nil
+	}
+
+	key := func(a *searchAlert) [3]string {
+		return [3]string{a.prometheusType, a.title, a.description}
 	}
 
-	return o.alert, o.err
+	seen := map[[3]string]bool{key(alert): true}
+	deduped := make([]*searchAlert, 0, len(notices))
+	for _, n := range notices {
+		if k := key(n); !seen[k] {
+			seen[k] = true
+			deduped = append(deduped, n)
+		}
+	}
+
+	sort.SliceStable(deduped, func(i, j int) bool {
+		return deduped[i].priority > deduped[j].priority
+	})
+	return deduped
 }
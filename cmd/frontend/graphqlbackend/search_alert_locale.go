@@ -0,0 +1,149 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"text/template"
+
+	"github.com/inconshreveable/log15"
+	"golang.org/x/text/language"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type acceptLanguageKey struct{}
+
+// WithAcceptLanguage returns a context holding the Accept-Language header of
+// a request, which is used to choose the locale of search alerts.
+func WithAcceptLanguage(ctx context.Context, acceptLanguage string) context.Context {
+	return context.WithValue(ctx, acceptLanguageKey{}, acceptLanguage)
+}
+
+// alertLocale returns the locale in which search alerts are shown for the
+// request of ctx: the configured locale in search.alertMessages that best
+// matches its Accept-Language header. It returns the empty string if alerts
+// should be shown in English.
+func alertLocale(ctx context.Context) string {
+	acceptLanguage, _ := ctx.Value(acceptLanguageKey{}).(string)
+	if acceptLanguage == "" {
+		return ""
+	}
+	return matchAlertLocale(acceptLanguage, conf.Get().SearchAlertMessages)
+}
+
+func matchAlertLocale(acceptLanguage string, catalog map[string]map[string]schema.SearchAlertMessage) string {
+	if len(catalog) == 0 {
+		return ""
+	}
+	prefs, _, err := language.ParseAcceptLanguage(acceptLanguage)
+	if err != nil || len(prefs) == 0 {
+		return ""
+	}
+
+	// The built-in English messages come first, so that they are chosen if
+	// no configured locale matches.
+	locales := []string{""}
+	supported := []language.Tag{language.English}
+	for locale := range catalog {
+		tag, err := language.Parse(locale)
+		if err != nil {
+			continue
+		}
+		locales = append(locales, locale)
+		supported = append(supported, tag)
+	}
+
+	_, index, confidence := language.NewMatcher(supported).Match(prefs...)
+	if confidence == language.No {
+		return ""
+	}
+	return locales[index]
+}
+
+// localize returns a copy of the alert with its title, description and
+// proposed query descriptions translated to the given locale, as configured
+// in search.alertMessages. Texts without a valid translation are left in
+// English.
+func (a *searchAlert) localize(locale string) *searchAlert {
+	if a == nil || locale == "" || a.prometheusType == "" {
+		return a
+	}
+	msg, ok := conf.Get().SearchAlertMessages[locale][a.prometheusType]
+	if !ok {
+		return a
+	}
+
+	localized := *a
+	if msg.Title != "" {
+		localized.title = a.execMessage(msg.Title, a.title)
+	}
+	if msg.Description != "" {
+		localized.description = a.execMessage(msg.Description, a.description)
+	}
+	if len(msg.ProposedQueries) > 0 {
+		localized.proposedQueries = make([]*searchQueryDescription, 0, len(a.proposedQueries))
+		for _, pq := range a.proposedQueries {
+			if translation, ok := msg.ProposedQueries[pq.description]; ok && translation != "" {
+				localizedQuery := *pq
+				localizedQuery.description = a.execMessage(translation, pq.description)
+				pq = &localizedQuery
+			}
+			localized.proposedQueries = append(localized.proposedQueries, pq)
+		}
+	}
+	return &localized
+}
+
+// execMessage executes a translated message template with the parameters of
+// the alert. It returns fallback if the template is invalid.
+func (a *searchAlert) execMessage(text, fallback string) string {
+	tmpl, err := template.New("").Option("missingkey=error").Parse(text)
+	if err != nil {
+		log15.Warn("invalid search alert translation, using built-in message", "type", a.prometheusType, "error", err)
+		return fallback
+	}
+	var b strings.Builder
+	if err := tmpl.Execute(&b, a.params); err != nil {
+		log15.Warn("invalid search alert translation, using built-in message", "type", a.prometheusType, "error", err)
+		return fallback
+	}
+	return b.String()
+}
+
+// localizeResults translates the alert and notices of the search results to
+// the given locale.
+func localizeResults(results *SearchResults, locale string) {
+	if locale == "" || results.Alert == nil {
+		return
+	}
+	results.Alert = results.Alert.localize(locale)
+	for i, notice := range results.Notices {
+		results.Notices[i] = notice.localize(locale)
+	}
+}
+
+func init() {
+	conf.ContributeValidator(func(c conf.Unified) (problems conf.Problems) {
+		for locale, messages := range c.SearchAlertMessages {
+			if _, err := language.Parse(locale); err != nil {
+				problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("search.alertMessages: invalid locale %q: %s", locale, err)))
+				continue
+			}
+			for alertType, msg := range messages {
+				for _, text := range []string{msg.Title, msg.Description} {
+					if _, err := template.New("").Parse(text); err != nil {
+						problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("search.alertMessages: invalid message for alert type %q in locale %q: %s", alertType, locale, err)))
+					}
+				}
+				for _, text := range msg.ProposedQueries {
+					if _, err := template.New("").Parse(text); err != nil {
+						problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("search.alertMessages: invalid proposed query description for alert type %q in locale %q: %s", alertType, locale, err)))
+					}
+				}
+			}
+		}
+		return problems
+	})
+}
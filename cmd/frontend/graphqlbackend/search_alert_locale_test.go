@@ -0,0 +1,112 @@
+package graphqlbackend
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestMatchAlertLocale(t *testing.T) {
+	catalog := map[string]map[string]schema.SearchAlertMessage{
+		"de":    {},
+		"pt-BR": {},
+	}
+
+	for _, tc := range []struct {
+		acceptLanguage string
+		want           string
+	}{
+		{acceptLanguage: "", want: ""},
+		{acceptLanguage: "de-DE,de;q=0.9,en;q=0.8", want: "de"},
+		{acceptLanguage: "pt-BR", want: "pt-BR"},
+		{acceptLanguage: "en-US,de;q=0.5", want: ""},
+		{acceptLanguage: "fr", want: ""},
+		{acceptLanguage: "!!invalid", want: ""},
+	} {
+		if got := matchAlertLocale(tc.acceptLanguage, catalog); got != tc.want {
+			t.Errorf("matchAlertLocale(%q) = %q, want %q", tc.acceptLanguage, got, tc.want)
+		}
+	}
+
+	if got := matchAlertLocale("de", nil); got != "" {
+		t.Errorf("expected no locale without configured translations, got %q", got)
+	}
+}
+
+func TestSearchAlertLocalize(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		SearchAlertMessages: map[string]map[string]schema.SearchAlertMessage{
+			"de": {
+				"timed_out": {
+					Title:       "Zeitüberschreitung bei der Suche",
+					Description: "In {{.Duration}} wurden keine Ergebnisse gefunden.",
+					ProposedQueries: map[string]string{
+						"query with longer timeout": "Suche mit Timeout {{.SuggestedTimeout}}",
+					},
+				},
+				"generic_invalid_query": {
+					Title:       "Ungültige Suche",
+					Description: "{{.Missing}}",
+				},
+			},
+		},
+	}})
+	defer conf.Mock(nil)
+
+	alert := &searchAlert{
+		prometheusType: "timed_out",
+		title:          "Timed out while searching",
+		description:    "We weren't able to find any results in 10s.",
+		proposedQueries: []*searchQueryDescription{
+			{description: "query with longer timeout", query: "timeout:1m0s foo"},
+			{description: "untranslated", query: "bar"},
+		},
+		params: map[string]interface{}{"Duration": 10 * time.Second, "SuggestedTimeout": time.Minute},
+	}
+
+	t.Run("translated", func(t *testing.T) {
+		got := alert.localize("de")
+		if diff := cmp.Diff("Zeitüberschreitung bei der Suche", got.title); diff != "" {
+			t.Errorf("title mismatch (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff("In 10s wurden keine Ergebnisse gefunden.", got.description); diff != "" {
+			t.Errorf("description mismatch (-want +got):\n%s", diff)
+		}
+		var descriptions []string
+		for _, pq := range got.proposedQueries {
+			descriptions = append(descriptions, pq.description)
+		}
+		if diff := cmp.Diff([]string{"Suche mit Timeout 1m0s", "untranslated"}, descriptions); diff != "" {
+			t.Errorf("proposed query descriptions mismatch (-want +got):\n%s", diff)
+		}
+
+		// The original alert is left untouched.
+		if alert.title != "Timed out while searching" || alert.proposedQueries[0].description != "query with longer timeout" {
+			t.Errorf("localize modified the original alert: %+v", alert)
+		}
+	})
+
+	t.Run("unknown locale", func(t *testing.T) {
+		if got := alert.localize("fr"); got != alert {
+			t.Errorf("expected the original alert, got %+v", got)
+		}
+	})
+
+	t.Run("invalid translation", func(t *testing.T) {
+		invalid := alertForQuery("foo(", errTestInvalidQuery{}).localize("de")
+		if diff := cmp.Diff("Ungültige Suche", invalid.title); diff != "" {
+			t.Errorf("title mismatch (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff("Invalid query", invalid.description); diff != "" {
+			t.Errorf("description mismatch (-want +got):\n%s", diff)
+		}
+	})
+}
+
+type errTestInvalidQuery struct{}
+
+func (errTestInvalidQuery) Error() string { return "invalid query" }
@@ -0,0 +1,159 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/google/zoekt"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// Onboarding alerts are informational alerts that are resolved from the state
+// of the instance rather than from errors of the query. Users may dismiss them
+// by adding their key to the search.dismissedAlerts setting.
+const (
+	onboardingAlertNoRepositories       = "no-repositories-configured"
+	onboardingAlertIndexingInProgress   = "indexing-in-progress"
+	onboardingAlertDefaultBranchMissing = "default-branch-missing"
+)
+
+// onboardingAlertPriority is lower than the priority of any alert for an
+// error, so that onboarding alerts are only ever shown first if the search
+// raised no other alerts.
+const onboardingAlertPriority = -1
+
+// emptyShardCommit is the commit zoekt-sourcegraph-indexserver indexes for
+// repositories without a default branch.
+const emptyShardCommit = "404aaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaaa"
+
+func alertForNoRepositoriesConfigured(isSiteAdmin bool) *searchAlert {
+	description := "To start searching code, ask the site admin to configure and enable repositories."
+	if isSiteAdmin {
+		description = "To start searching code, first go to site admin to configure repositories and code hosts."
+	}
+	return &searchAlert{
+		prometheusType: "onboarding__no_repositories_configured",
+		title:          "No repositories or code hosts configured",
+		description:    description,
+		priority:       onboardingAlertPriority,
+		dismissKey:     onboardingAlertNoRepositories,
+	}
+}
+
+func alertForIndexingInProgress(unindexed, total int) *searchAlert {
+	return &searchAlert{
+		prometheusType: "onboarding__indexing_in_progress",
+		title:          "Indexing in progress",
+		description:    fmt.Sprintf("%d of the %d repositories you searched are still being indexed. Searches over them are slower and may not return all results until indexing finishes.", unindexed, total),
+		params:         map[string]interface{}{"Unindexed": unindexed, "Total": total},
+		priority:       onboardingAlertPriority,
+		dismissKey:     onboardingAlertIndexingInProgress,
+	}
+}
+
+func alertForDefaultBranchMissing(missing []string) *searchAlert {
+	description := fmt.Sprintf("The repository %s has no default branch, so it was not searched. This happens when a repository is empty or its HEAD does not point to a branch.", missing[0])
+	if len(missing) > 1 {
+		description = fmt.Sprintf("%d repositories, including %s, have no default branch, so they were not searched. This happens when a repository is empty or its HEAD does not point to a branch.", len(missing), missing[0])
+	}
+	return &searchAlert{
+		prometheusType: "onboarding__default_branch_missing",
+		title:          "Default branch missing",
+		description:    description,
+		params:         map[string]interface{}{"Count": len(missing), "Repository": missing[0]},
+		priority:       onboardingAlertPriority,
+		dismissKey:     onboardingAlertDefaultBranchMissing,
+	}
+}
+
+// isAlertDismissed returns true if the user dismissed the alert with the
+// given key.
+func isAlertDismissed(settings *schema.Settings, key string) bool {
+	if settings == nil {
+		return false
+	}
+	for _, dismissed := range settings.SearchDismissedAlerts {
+		if dismissed == key {
+			return true
+		}
+	}
+	return false
+}
+
+// noRepositoriesAlert returns the onboarding alert for instances without any
+// code hosts, or nil if code hosts are configured or the user dismissed the
+// alert.
+func (r *searchResolver) noRepositoriesAlert(ctx context.Context) *searchAlert {
+	if envvar.SourcegraphDotComMode() || isAlertDismissed(r.UserSettings, onboardingAlertNoRepositories) {
+		return nil
+	}
+	if needsRepoConfig, err := needsRepositoryConfiguration(ctx, r.db); err != nil || !needsRepoConfig {
+		return nil
+	}
+	return alertForNoRepositoriesConfigured(backend.CheckCurrentUserIsSiteAdmin(ctx, r.db) == nil)
+}
+
+// onboardingAlerts returns the onboarding alerts for the default branches of
+// the resolved repositories which the user has not dismissed. They are
+// resolved from the repositories known to indexed search, so none are
+// returned if indexed search is disabled or not used by the search.
+func (r *searchResolver) onboardingAlerts(ctx context.Context, args *search.TextParameters, repoRevs []*search.RepositoryRevisions) []*searchAlert {
+	if envvar.SourcegraphDotComMode() || r.zoekt == nil || !r.zoekt.Enabled() || args.PatternInfo.Index == query.No {
+		return nil
+	}
+	indexingDismissed := isAlertDismissed(r.UserSettings, onboardingAlertIndexingInProgress)
+	defaultBranchDismissed := isAlertDismissed(r.UserSettings, onboardingAlertDefaultBranchMissing)
+	if indexingDismissed && defaultBranchDismissed {
+		return nil
+	}
+
+	indexed, err := r.zoekt.ListAll(ctx)
+	if err != nil {
+		return nil
+	}
+
+	var alerts []*searchAlert
+	unindexed, total, missing := defaultBranchIndexStatus(indexed, repoRevs)
+	if unindexed > 0 && !indexingDismissed {
+		alerts = append(alerts, alertForIndexingInProgress(unindexed, total))
+	}
+	if len(missing) > 0 && !defaultBranchDismissed {
+		alerts = append(alerts, alertForDefaultBranchMissing(missing))
+	}
+	return alerts
+}
+
+// defaultBranchIndexStatus returns how many of the repositories searched at
+// their default branch are not indexed yet, out of how many, and the names
+// of those that were indexed without a default branch.
+func defaultBranchIndexStatus(indexed map[string]*zoekt.Repository, repoRevs []*search.RepositoryRevisions) (unindexed, total int, missing []string) {
+	for _, repoRev := range repoRevs {
+		if !searchesDefaultBranch(repoRev) {
+			continue
+		}
+		total++
+
+		repo, ok := indexed[string(repoRev.Repo.Name)]
+		if !ok {
+			unindexed++
+			continue
+		}
+		for _, branch := range repo.Branches {
+			if branch.Name == "HEAD" && branch.Version == emptyShardCommit {
+				missing = append(missing, string(repoRev.Repo.Name))
+			}
+		}
+	}
+	return unindexed, total, missing
+}
+
+// searchesDefaultBranch returns true if the only revision searched in the
+// repository is its default branch.
+func searchesDefaultBranch(repoRev *search.RepositoryRevisions) bool {
+	return len(repoRev.Revs) == 0 || len(repoRev.Revs) == 1 && repoRev.Revs[0].RevSpec == "" && !repoRev.Revs[0].IsRefPattern()
+}
@@ -6,13 +6,16 @@ import (
 	"reflect"
 	"strconv"
 	"testing"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/google/go-cmp/cmp"
+	"github.com/google/zoekt"
 	"github.com/hashicorp/go-multierror"
 	"github.com/stretchr/testify/require"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
 	"github.com/sourcegraph/sourcegraph/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/search/query"
@@ -402,6 +405,42 @@ func TestCapFirst(t *testing.T) {
 	}
 }
 
+func TestAlertObserverNotices(t *testing.T) {
+	ctx := context.Background()
+	ao := alertObserver{
+		Inputs:     &run.SearchInputs{OriginalQuery: "foo", PatternType: query.SearchTypeLiteral},
+		hasResults: true,
+	}
+
+	// The same diff search limit is hit by multiple searches and should only
+	// be reported once.
+	ao.Error(ctx, &run.TimeLimitError{ResultType: "diff", Max: 10000})
+	ao.Error(ctx, errors.New("Worker_oomed"))
+	ao.Error(ctx, &run.TimeLimitError{ResultType: "diff", Max: 10000})
+	ao.Error(ctx, &missingRepoRevsError{Missing: []*search.RepositoryRevisions{{
+		Repo: types.RepoName{Name: "r"},
+		Revs: []search.RevisionSpecifier{{RevSpec: "missing"}},
+	}}})
+
+	alert, notices, err := ao.Done(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if have, want := alert.prometheusType, "missing_repo_revs"; have != want {
+		t.Fatalf("wrong alert: want %q, have %q", want, have)
+	}
+
+	var have []string
+	for _, n := range notices {
+		have = append(have, n.prometheusType)
+	}
+	want := []string{"structural_search_needs_more_memory", "exceeded_diff_commit_with_time_search_limit"}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("notices mismatch (-want +have):\n%s", diff)
+	}
+}
+
 func TestAlertForNoResolvedReposWithNonGlobalSearchContext(t *testing.T) {
 	db := new(dbtesting.MockDB)
 
@@ -425,6 +464,7 @@ func TestAlertForNoResolvedReposWithNonGlobalSearchContext(t *testing.T) {
 			query:       "context:global repo:r1 foo",
 			patternType: query.SearchTypeRegex,
 		}},
+		params: map[string]interface{}{"Context": "@user"},
 	}
 
 	q, err := query.ParseLiteral(searchQuery)
@@ -444,3 +484,76 @@ func TestAlertForNoResolvedReposWithNonGlobalSearchContext(t *testing.T) {
 	require.NoError(t, err)
 	require.Equal(t, wantAlert, alert)
 }
+
+func TestAlertForTimeout_maxUserTimeout(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{SearchMaxUserTimeout: "1m"}})
+	defer conf.Mock(nil)
+
+	r := &searchResolver{SearchInputs: &run.SearchInputs{OriginalQuery: "timeout:30s foo", PatternType: query.SearchTypeLiteral}}
+
+	t.Run("suggested timeout is capped", func(t *testing.T) {
+		alert := alertForTimeout(30*time.Second, 2*time.Minute, r)
+		if alert.prometheusType != "timed_out" {
+			t.Fatalf("unexpected alert type %q", alert.prometheusType)
+		}
+		if diff := cmp.Diff("timeout:1m0s foo", alert.proposedQueries[0].query); diff != "" {
+			t.Errorf("unexpected proposed query (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("no longer timeout can be suggested", func(t *testing.T) {
+		alert := alertForTimeout(time.Minute, 2*time.Minute, r)
+		if alert.prometheusType != "timed_out__max_timeout" {
+			t.Fatalf("unexpected alert type %q", alert.prometheusType)
+		}
+		if len(alert.proposedQueries) != 0 {
+			t.Errorf("expected no proposed queries, got %d", len(alert.proposedQueries))
+		}
+	})
+}
+
+func TestAlertForQuery_timeoutExceeded(t *testing.T) {
+	alert := alertForQuery("timeout:10m foo", &query.TimeoutExceededError{Timeout: 10 * time.Minute, Max: time.Minute})
+	if alert.prometheusType != "timeout_exceeds_maximum" {
+		t.Fatalf("unexpected alert type %q", alert.prometheusType)
+	}
+	want := "The timeout:10m0s in your query exceeds the maximum timeout of 1m0s allowed on this instance. Use a timeout of at most 1m0s."
+	if diff := cmp.Diff(want, alert.description); diff != "" {
+		t.Errorf("unexpected description (-want +got):\n%s", diff)
+	}
+}
+
+func TestDefaultBranchIndexStatus(t *testing.T) {
+	indexed := map[string]*zoekt.Repository{
+		"indexed": {Name: "indexed", Branches: []zoekt.RepositoryBranch{{Name: "HEAD", Version: "deadbeef"}}},
+		"empty":   {Name: "empty", Branches: []zoekt.RepositoryBranch{{Name: "HEAD", Version: emptyShardCommit}}},
+	}
+	repoRevs := []*search.RepositoryRevisions{
+		{Repo: types.RepoName{Name: "indexed"}},
+		{Repo: types.RepoName{Name: "empty"}, Revs: []search.RevisionSpecifier{{RevSpec: ""}}},
+		{Repo: types.RepoName{Name: "unindexed"}},
+		// Searches at other revisions are never indexed and do not count.
+		{Repo: types.RepoName{Name: "unindexed-rev"}, Revs: []search.RevisionSpecifier{{RevSpec: "feature"}}},
+	}
+
+	unindexed, total, missing := defaultBranchIndexStatus(indexed, repoRevs)
+	if unindexed != 1 || total != 3 {
+		t.Errorf("got %d of %d unindexed, want 1 of 3", unindexed, total)
+	}
+	if diff := cmp.Diff([]string{"empty"}, missing); diff != "" {
+		t.Errorf("missing mismatch (-want +have):\n%s", diff)
+	}
+}
+
+func TestIsAlertDismissed(t *testing.T) {
+	settings := &schema.Settings{SearchDismissedAlerts: []string{onboardingAlertIndexingInProgress}}
+	if !isAlertDismissed(settings, onboardingAlertIndexingInProgress) {
+		t.Error("want indexing alert dismissed")
+	}
+	if isAlertDismissed(settings, onboardingAlertDefaultBranchMissing) {
+		t.Error("want default branch alert not dismissed")
+	}
+	if isAlertDismissed(nil, onboardingAlertNoRepositories) {
+		t.Error("want alert not dismissed without settings")
+	}
+}
@@ -0,0 +1,68 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// dedupeForks groups identical file matches found in forks of the same
+// repository (dedupe:forks). Fork relationships are determined from the code
+// host metadata of the repositories.
+func dedupeForks(ctx context.Context, db dbutil.DB, matches []result.Match) ([]result.Match, error) {
+	seen := make(map[api.RepoID]struct{})
+	var ids []api.RepoID
+	for _, m := range matches {
+		if fm, ok := m.(*result.FileMatch); ok {
+			if _, ok := seen[fm.Repo.ID]; !ok {
+				seen[fm.Repo.ID] = struct{}{}
+				ids = append(ids, fm.Repo.ID)
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return matches, nil
+	}
+
+	repos, err := database.Repos(db).GetByIDs(ctx, ids...)
+	if err != nil {
+		return nil, err
+	}
+	origins := make(map[api.RepoID]result.RepoOrigin, len(repos))
+	for _, repo := range repos {
+		origins[repo.ID] = repoOrigin(repo)
+	}
+
+	return result.DedupeForks(matches, func(r types.RepoName) result.RepoOrigin {
+		if o, ok := origins[r.ID]; ok {
+			return o
+		}
+		return result.RepoOrigin{Key: string(r.Name)}
+	}), nil
+}
+
+// repoOrigin returns the origin of repo, as reported by its code host.
+func repoOrigin(repo *types.Repo) result.RepoOrigin {
+	serviceID := repo.ExternalRepo.ServiceID
+	switch m := repo.Metadata.(type) {
+	case *github.Repository:
+		// Forks of forks share the root repository of their fork network.
+		if source, ok := m.SourceNameWithOwner(); ok {
+			return result.RepoOrigin{Key: serviceID + strings.ToLower(source), IsFork: true}
+		}
+		return result.RepoOrigin{Key: serviceID + strings.ToLower(m.NameWithOwner)}
+	case *gitlab.Project:
+		if m.ForkedFromProject != nil {
+			return result.RepoOrigin{Key: serviceID + m.ForkedFromProject.PathWithNamespace, IsFork: true}
+		}
+		return result.RepoOrigin{Key: serviceID + m.PathWithNamespace}
+	}
+	return result.RepoOrigin{Key: string(repo.Name)}
+}
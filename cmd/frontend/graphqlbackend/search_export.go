@@ -0,0 +1,187 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// SearchExportArgs describes a search, as run from the search results page, that is exported to a
+// saved search or a code monitor.
+type SearchExportArgs struct {
+	// Query is the query that was run.
+	Query string
+	// PatternType is the pattern type the query was run with, if the query has no patternType:
+	// filter.
+	PatternType *string
+	// ProposedQuery, if set, is a query proposed by an alert for Query that is exported instead.
+	ProposedQuery *string
+}
+
+// exportedSearchQuery validates the query described by args the same way as when the query is
+// run, and returns the query text to store. A patternType: filter is added to the query if it
+// has none, so that the stored query does not depend on the defaults of the search page.
+//
+// Invalid queries are reported with the description of the alert that is shown when running
+// them.
+func exportedSearchQuery(ctx context.Context, r *schemaResolver, args SearchExportArgs) (string, query.Plan, error) {
+	queryString := args.Query
+	if args.ProposedQuery != nil && *args.ProposedQuery != "" {
+		queryString = *args.ProposedQuery
+	}
+	if queryString == "" {
+		return "", nil, errors.New("the search query is empty")
+	}
+
+	settings, err := decodedViewerFinalSettings(ctx, r.db)
+	if err != nil {
+		return "", nil, err
+	}
+
+	searchType, err := detectSearchType("V2", args.PatternType)
+	if err != nil {
+		return "", nil, err
+	}
+	searchType = overrideSearchType(queryString, searchType)
+
+	maxUserTimeout := search.MaxUserTimeout(conf.Get())
+	plan, err := query.Pipeline(
+		query.Init(queryString, searchType),
+		query.With(getBoolPtr(settings.SearchGlobbing, false), query.Globbing),
+		query.With(maxUserTimeout > 0, query.MaxTimeout(maxUserTimeout)),
+	)
+	if err != nil {
+		alert := alertForQuery(queryString, err).localize(alertLocale(ctx))
+		return "", nil, errors.Errorf("invalid search query: %s", alert.description)
+	}
+
+	if !queryHasPatternType(queryString) {
+		queryString += " patternType:" + patternTypeFilterValue(searchType)
+	}
+	return queryString, plan, nil
+}
+
+func patternTypeFilterValue(searchType query.SearchType) string {
+	if searchType == query.SearchTypeRegex {
+		return "regexp"
+	}
+	return searchType.String()
+}
+
+func (r *schemaResolver) CreateSavedSearchFromSearch(ctx context.Context, args *struct {
+	SearchExportArgs
+	Description string
+	NotifyOwner bool
+	NotifySlack bool
+	OrgID       *graphql.ID
+	UserID      *graphql.ID
+}) (*savedSearchResolver, error) {
+	queryString, _, err := exportedSearchQuery(ctx, r, args.SearchExportArgs)
+	if err != nil {
+		return nil, err
+	}
+
+	userID := args.UserID
+	if userID == nil && args.OrgID == nil {
+		a := actor.FromContext(ctx)
+		if !a.IsAuthenticated() {
+			return nil, backend.ErrNotAuthenticated
+		}
+		id := MarshalUserID(a.UID)
+		userID = &id
+	}
+
+	// 🚨 SECURITY: CreateSavedSearch checks that the current user may create a saved search for
+	// the user or org.
+	return r.CreateSavedSearch(ctx, &struct {
+		Description string
+		Query       string
+		NotifyOwner bool
+		NotifySlack bool
+		OrgID       *graphql.ID
+		UserID      *graphql.ID
+	}{
+		Description: args.Description,
+		Query:       queryString,
+		NotifyOwner: args.NotifyOwner,
+		NotifySlack: args.NotifySlack,
+		OrgID:       args.OrgID,
+		UserID:      userID,
+	})
+}
+
+func (r *schemaResolver) CreateCodeMonitorFromSearch(ctx context.Context, args *struct {
+	SearchExportArgs
+	Namespace   *graphql.ID
+	Description string
+	Enabled     bool
+	Actions     *[]*CreateActionArgs
+}) (MonitorResolver, error) {
+	queryString, plan, err := exportedSearchQuery(ctx, r, args.SearchExportArgs)
+	if err != nil {
+		return nil, err
+	}
+	if err := validateCodeMonitorQuery(plan); err != nil {
+		return nil, err
+	}
+
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	namespace := MarshalUserID(a.UID)
+	if args.Namespace != nil {
+		namespace = *args.Namespace
+	}
+
+	var actions []*CreateActionArgs
+	if args.Actions != nil {
+		actions = *args.Actions
+	}
+	if len(actions) == 0 {
+		actions = []*CreateActionArgs{{
+			Email: &CreateActionEmailArgs{
+				Enabled:    true,
+				Priority:   "NORMAL",
+				Recipients: []graphql.ID{MarshalUserID(a.UID)},
+			},
+		}}
+	}
+
+	// 🚨 SECURITY: CreateCodeMonitor checks that the current user may create a code monitor in the
+	// namespace.
+	return r.CodeMonitorsResolver.CreateCodeMonitor(ctx, &CreateCodeMonitorArgs{
+		Monitor: &CreateMonitorArgs{
+			Namespace:   namespace,
+			Description: args.Description,
+			Enabled:     args.Enabled,
+		},
+		Trigger: &CreateTriggerArgs{Query: queryString},
+		Actions: actions,
+	})
+}
+
+// validateCodeMonitorQuery returns an error if the query cannot be run by a code monitor. Code
+// monitors only search new commits and diffs.
+func validateCodeMonitorQuery(plan query.Plan) error {
+	for _, basic := range plan {
+		hasDiffOrCommit := false
+		basic.VisitParameter(query.FieldType, func(value string, negated bool, _ query.Annotation) {
+			if !negated && (value == "diff" || value == "commit") {
+				hasDiffOrCommit = true
+			}
+		})
+		if !hasDiffOrCommit {
+			return errors.New("code monitor queries must contain a type:diff or type:commit filter")
+		}
+	}
+	return nil
+}
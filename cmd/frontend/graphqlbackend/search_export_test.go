@@ -0,0 +1,148 @@
+package graphqlbackend
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestExportedSearchQuery(t *testing.T) {
+	mockDecodedViewerFinalSettings = &schema.Settings{}
+	defer func() { mockDecodedViewerFinalSettings = nil }()
+
+	strPtr := func(s string) *string { return &s }
+
+	tests := []struct {
+		name    string
+		args    SearchExportArgs
+		want    string
+		wantErr string
+	}{
+		{
+			name: "adds default pattern type",
+			args: SearchExportArgs{Query: "foo type:diff"},
+			want: "foo type:diff patternType:literal",
+		},
+		{
+			name: "adds pattern type of search",
+			args: SearchExportArgs{Query: "fo+ type:diff", PatternType: strPtr("regexp")},
+			want: "fo+ type:diff patternType:regexp",
+		},
+		{
+			name: "keeps pattern type of query",
+			args: SearchExportArgs{Query: "fo+ patternType:regexp", PatternType: strPtr("literal")},
+			want: "fo+ patternType:regexp",
+		},
+		{
+			name: "uses proposed query",
+			args: SearchExportArgs{Query: "foo timeout:1s", ProposedQuery: strPtr("timeout:10s foo")},
+			want: "timeout:10s foo patternType:literal",
+		},
+		{
+			name:    "invalid query",
+			args:    SearchExportArgs{Query: "foo count:abc"},
+			wantErr: "invalid search query: Field count has value abc, abc is not a number",
+		},
+		{
+			name:    "empty query",
+			args:    SearchExportArgs{Query: ""},
+			wantErr: "the search query is empty",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got, _, err := exportedSearchQuery(context.Background(), &schemaResolver{}, test.args)
+			if test.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), test.wantErr) {
+					t.Fatalf("got error %v, want %q", err, test.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
+
+func TestValidateCodeMonitorQuery(t *testing.T) {
+	tests := []struct {
+		query       string
+		patternType query.SearchType
+		wantErr     bool
+	}{
+		{query: "foo type:diff", patternType: query.SearchTypeLiteral},
+		{query: "foo type:commit", patternType: query.SearchTypeLiteral},
+		{query: "foo type:diff or bar type:commit", patternType: query.SearchTypeLiteral},
+		{query: "foo", patternType: query.SearchTypeLiteral, wantErr: true},
+		{query: "foo type:file", patternType: query.SearchTypeLiteral, wantErr: true},
+		{query: "foo(:[x])", patternType: query.SearchTypeStructural, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.query, func(t *testing.T) {
+			plan, err := query.Pipeline(query.Init(test.query, test.patternType))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err := validateCodeMonitorQuery(plan); (err != nil) != test.wantErr {
+				t.Errorf("got error %v, want error: %v", err, test.wantErr)
+			}
+		})
+	}
+}
+
+func TestCreateSavedSearchFromSearch(t *testing.T) {
+	defer resetMocks()
+	mockDecodedViewerFinalSettings = &schema.Settings{}
+	defer func() { mockDecodedViewerFinalSettings = nil }()
+
+	db := new(dbtesting.MockDB)
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+
+	database.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+		return &types.User{ID: 1}, nil
+	}
+	var created *types.SavedSearch
+	database.Mocks.SavedSearches.Create = func(_ context.Context, newSavedSearch *types.SavedSearch) (*types.SavedSearch, error) {
+		created = newSavedSearch
+		return newSavedSearch, nil
+	}
+
+	patternType := "regexp"
+	_, err := (&schemaResolver{db: db}).CreateSavedSearchFromSearch(ctx, &struct {
+		SearchExportArgs
+		Description string
+		NotifyOwner bool
+		NotifySlack bool
+		OrgID       *graphql.ID
+		UserID      *graphql.ID
+	}{
+		SearchExportArgs: SearchExportArgs{Query: "fo+ type:diff", PatternType: &patternType},
+		Description:      "test query",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if created == nil {
+		t.Fatal("Database method database.SavedSearches.Create not called")
+	}
+	if want := "fo+ type:diff patternType:regexp"; created.Query != want {
+		t.Errorf("got query %q, want %q", created.Query, want)
+	}
+	if created.UserID == nil || *created.UserID != 1 {
+		t.Errorf("got user ID %v, want 1", created.UserID)
+	}
+}
@@ -0,0 +1,45 @@
+package graphqlbackend
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+type repositoryGroupsArgs struct {
+	PerRepositoryLimit int32
+}
+
+// RepositoryGroups groups all results of the search by repository, if the
+// query contains group:repo. Unlike Results, it is not limited to the first
+// page of results, so that the counts of each group are complete.
+func (sr *SearchResultsResolver) RepositoryGroups(args *repositoryGroupsArgs) *[]*repositoryResultGroupResolver {
+	if !sr.groupByRepo {
+		return nil
+	}
+
+	groups := result.GroupByRepo(sr.Matches, int(args.PerRepositoryLimit))
+	resolvers := make([]*repositoryResultGroupResolver, 0, len(groups))
+	for _, g := range groups {
+		resolvers = append(resolvers, &repositoryResultGroupResolver{db: sr.db, group: g})
+	}
+	return &resolvers
+}
+
+type repositoryResultGroupResolver struct {
+	db    dbutil.DB
+	group *result.RepoGroup
+}
+
+func (r *repositoryResultGroupResolver) Repository() *RepositoryResolver {
+	return NewRepositoryResolver(r.db, r.group.Repo.ToRepo())
+}
+
+func (r *repositoryResultGroupResolver) Results() []SearchResultResolver {
+	return matchesToResolvers(r.db, r.group.Matches)
+}
+
+func (r *repositoryResultGroupResolver) ResultCount() int32 { return int32(r.group.ResultCount) }
+
+func (r *repositoryResultGroupResolver) MatchCount() int32 { return int32(r.group.MatchCount) }
+
+func (r *repositoryResultGroupResolver) LimitHit() bool { return r.group.LimitHit() }
@@ -0,0 +1,159 @@
+package graphqlbackend
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// SearchJobs returns the search jobs created by the viewer.
+func (r *schemaResolver) SearchJobs(ctx context.Context, args *struct{ First int32 }) ([]*searchJobResolver, error) {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	jobs, err := database.SearchJobs(r.db).List(ctx, database.SearchJobsListOptions{
+		UserID:      a.UID,
+		LimitOffset: &database.LimitOffset{Limit: int(args.First)},
+	})
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*searchJobResolver, 0, len(jobs))
+	for _, j := range jobs {
+		resolvers = append(resolvers, &searchJobResolver{db: r.db, job: j})
+	}
+	return resolvers, nil
+}
+
+func searchJobByID(ctx context.Context, db dbutil.DB, id graphql.ID) (*searchJobResolver, error) {
+	jobID, err := unmarshalSearchJobID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := database.SearchJobs(db).GetByID(ctx, jobID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only the user who created the search job and site admins may
+	// view it, since its query may reveal what the user is looking for.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, db, job.UserID); err != nil {
+		return nil, err
+	}
+	return &searchJobResolver{db: db, job: job}, nil
+}
+
+func (r *schemaResolver) CreateSearchJob(ctx context.Context, args *struct {
+	Query       string
+	PatternType *string
+}) (*searchJobResolver, error) {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return nil, backend.ErrNotAuthenticated
+	}
+
+	queryString, _, err := exportedSearchQuery(ctx, r, SearchExportArgs{
+		Query:       args.Query,
+		PatternType: args.PatternType,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	job, err := database.SearchJobs(r.db).Create(ctx, a.UID, queryString)
+	if err != nil {
+		return nil, err
+	}
+	return &searchJobResolver{db: r.db, job: job}, nil
+}
+
+func (r *schemaResolver) CancelSearchJob(ctx context.Context, args *struct{ ID graphql.ID }) (*searchJobResolver, error) {
+	// 🚨 SECURITY: searchJobByID checks that the current user may view the
+	// search job, which are the users who may cancel it.
+	job, err := searchJobByID(ctx, r.db, args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	store := database.SearchJobs(r.db)
+	if err := store.Cancel(ctx, job.job.ID); err != nil {
+		return nil, err
+	}
+	canceled, err := store.GetByID(ctx, job.job.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &searchJobResolver{db: r.db, job: canceled}, nil
+}
+
+func marshalSearchJobID(id int64) graphql.ID { return relay.MarshalID("SearchJob", id) }
+
+func unmarshalSearchJobID(id graphql.ID) (searchJobID int64, err error) {
+	err = relay.UnmarshalSpec(id, &searchJobID)
+	return
+}
+
+type searchJobResolver struct {
+	db  dbutil.DB
+	job *types.SearchJob
+}
+
+func (r *searchJobResolver) ID() graphql.ID { return marshalSearchJobID(r.job.ID) }
+
+func (r *searchJobResolver) Query() string { return r.job.Query }
+
+func (r *searchJobResolver) State() string { return strings.ToUpper(r.job.State) }
+
+func (r *searchJobResolver) Creator(ctx context.Context) (*UserResolver, error) {
+	user, err := UserByIDInt32(ctx, r.db, r.job.UserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *searchJobResolver) MatchCount() int32 { return int32(r.job.MatchCount) }
+
+func (r *searchJobResolver) FailureMessage() *string {
+	if r.job.FailureMessage == "" {
+		return nil
+	}
+	return &r.job.FailureMessage
+}
+
+func (r *searchJobResolver) ResultsURL() *string {
+	if r.job.State != "completed" {
+		return nil
+	}
+	u := fmt.Sprintf("/.api/search/jobs/%d/results", r.job.ID)
+	return &u
+}
+
+func (r *searchJobResolver) CreatedAt() DateTime { return DateTime{Time: r.job.CreatedAt} }
+
+func (r *searchJobResolver) StartedAt() *DateTime {
+	if r.job.StartedAt.IsZero() {
+		return nil
+	}
+	return &DateTime{Time: r.job.StartedAt}
+}
+
+func (r *searchJobResolver) FinishedAt() *DateTime {
+	if r.job.FinishedAt.IsZero() {
+		return nil
+	}
+	return &DateTime{Time: r.job.FinishedAt}
+}
@@ -0,0 +1,165 @@
+package graphqlbackend
+
+import (
+	"context"
+	"os"
+	"sync"
+
+	"github.com/inconshreveable/log15"
+	"github.com/neelance/parallel"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/internal/search/linguist"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// gitattributesCache caches the contents of the .gitattributes file at the
+// root of a repository, keyed by repository and commit.
+var gitattributesCache = rcache.NewWithTTL("search_gitattributes", 24*60*60)
+
+// maxGitattributesBytes is the maximum size of a .gitattributes file that is
+// read. The rest of a larger file is ignored.
+const maxGitattributesBytes = 256 * 1024
+
+type repoCommit struct {
+	repo   api.RepoName
+	commit api.CommitID
+}
+
+// linguistFilters returns the values of the generated: and vendored: filters
+// of q. ok is false if q has neither filter.
+func linguistFilters(q query.Q) (generated, vendored query.YesNoOnly, ok bool) {
+	generated, vendored = query.Yes, query.Yes
+	if v := q.Generated(); v != nil {
+		generated, ok = *v, true
+	}
+	if v := q.Vendored(); v != nil {
+		vendored, ok = *v, true
+	}
+	return generated, vendored, ok
+}
+
+// filterLinguist applies the generated: and vendored: filters of q to the
+// file matches, and moves file matches of generated or vendored files behind
+// all other matches. Files are classified by the linguist overrides in the
+// .gitattributes file of their repository, falling back to linguist's path
+// heuristics. The .gitattributes files are only read if q has a generated: or
+// vendored: filter; otherwise only the path heuristics are used for ranking.
+func filterLinguist(ctx context.Context, q query.Q, matches []result.Match) []result.Match {
+	generated, vendored, ok := linguistFilters(q)
+	if !ok {
+		return filterLinguistMatches(matches, generated, vendored, nil)
+	}
+	return filterLinguistMatches(matches, generated, vendored, linguistAttributes(ctx, matches))
+}
+
+// withLinguistFilter returns a child Stream of parent that applies the
+// generated: and vendored: filters of q to each event. If q has neither
+// filter, parent is returned.
+func withLinguistFilter(ctx context.Context, parent streaming.Sender, q query.Q) streaming.Sender {
+	generated, vendored, ok := linguistFilters(q)
+	if !ok {
+		return parent
+	}
+
+	return streaming.StreamFunc(func(e streaming.SearchEvent) {
+		if len(e.Results) > 0 {
+			e.Results = filterLinguistMatches(e.Results, generated, vendored, linguistAttributes(ctx, e.Results))
+		}
+		parent.Send(e)
+	})
+}
+
+func filterLinguistMatches(matches []result.Match, generated, vendored query.YesNoOnly, attrs map[repoCommit]*linguist.Attributes) []result.Match {
+	kept := matches[:0:0]
+	var downRanked []result.Match
+	for _, m := range matches {
+		fm, ok := m.(*result.FileMatch)
+		if !ok {
+			kept = append(kept, m)
+			continue
+		}
+
+		a := attrs[repoCommit{repo: fm.Repo.Name, commit: fm.CommitID}]
+		isGenerated, isVendored := a.IsGenerated(fm.Path), a.IsVendored(fm.Path)
+		if !yesNoOnlyAllows(generated, isGenerated) || !yesNoOnlyAllows(vendored, isVendored) {
+			continue
+		}
+		if isGenerated || isVendored {
+			downRanked = append(downRanked, m)
+		} else {
+			kept = append(kept, m)
+		}
+	}
+	return append(kept, downRanked...)
+}
+
+func yesNoOnlyAllows(v query.YesNoOnly, is bool) bool {
+	switch v {
+	case query.No:
+		return !is
+	case query.Only:
+		return is
+	}
+	return true
+}
+
+// linguistAttributes returns the linguist overrides of the repositories and
+// commits of the file matches. Overrides that can't be read are left out, so
+// that the path heuristics are used instead.
+func linguistAttributes(ctx context.Context, matches []result.Match) map[repoCommit]*linguist.Attributes {
+	var (
+		mu    sync.Mutex
+		attrs = make(map[repoCommit]*linguist.Attributes)
+		run   = parallel.NewRun(8)
+	)
+	seen := make(map[repoCommit]struct{})
+	for _, m := range matches {
+		fm, ok := m.(*result.FileMatch)
+		if !ok {
+			continue
+		}
+		key := repoCommit{repo: fm.Repo.Name, commit: fm.CommitID}
+		if _, ok := seen[key]; ok {
+			continue
+		}
+		seen[key] = struct{}{}
+
+		run.Acquire()
+		goroutine.Go(func() {
+			defer run.Release()
+
+			data, err := readGitattributes(ctx, key)
+			if err != nil {
+				log15.Warn("failed to read .gitattributes", "repo", key.repo, "commit", key.commit, "error", err)
+				return
+			}
+			a := linguist.Parse(data)
+			mu.Lock()
+			attrs[key] = a
+			mu.Unlock()
+		})
+	}
+	_ = run.Wait()
+	return attrs
+}
+
+func readGitattributes(ctx context.Context, key repoCommit) ([]byte, error) {
+	cacheKey := string(key.repo) + "@" + string(key.commit)
+	if data, ok := gitattributesCache.Get(cacheKey); ok {
+		return data, nil
+	}
+
+	data, err := git.ReadFile(ctx, key.repo, key.commit, ".gitattributes", maxGitattributesBytes)
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	// Commits are immutable, so a missing file is cached as well.
+	gitattributesCache.Set(cacheKey, data)
+	return data, nil
+}
@@ -0,0 +1,132 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/search/linguist"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestFilterLinguistMatches(t *testing.T) {
+	attrs := map[repoCommit]*linguist.Attributes{
+		{repo: "github.com/org/repo", commit: "c1"}: linguist.Parse([]byte("*.pb.go linguist-generated\nvendor/** -linguist-vendored\n")),
+	}
+	fileMatch := func(repo api.RepoName, path string) *result.FileMatch {
+		return &result.FileMatch{File: result.File{Repo: types.RepoName{Name: repo}, CommitID: "c1", Path: path}}
+	}
+	matches := []result.Match{
+		fileMatch("github.com/org/repo", "api.pb.go"),
+		fileMatch("github.com/org/other", "vendor/lib/lib.go"),
+		&result.RepoMatch{Name: "github.com/org/repo"},
+		fileMatch("github.com/org/repo", "vendor/lib/lib.go"),
+		fileMatch("github.com/org/repo", "main.go"),
+	}
+	name := func(m result.Match) string {
+		switch v := m.(type) {
+		case *result.FileMatch:
+			return string(v.Repo.Name) + "/" + v.Path
+		case *result.RepoMatch:
+			return string(v.Name)
+		}
+		return ""
+	}
+
+	cases := []struct {
+		name                string
+		generated, vendored query.YesNoOnly
+		want                []string
+	}{
+		{
+			name:      "down-rank by default",
+			generated: query.Yes,
+			vendored:  query.Yes,
+			want: []string{
+				"github.com/org/repo",
+				"github.com/org/repo/vendor/lib/lib.go",
+				"github.com/org/repo/main.go",
+				"github.com/org/repo/api.pb.go",
+				"github.com/org/other/vendor/lib/lib.go",
+			},
+		},
+		{
+			name:      "exclude generated and vendored",
+			generated: query.No,
+			vendored:  query.No,
+			want: []string{
+				"github.com/org/repo",
+				"github.com/org/repo/vendor/lib/lib.go",
+				"github.com/org/repo/main.go",
+			},
+		},
+		{
+			name:      "only vendored",
+			generated: query.Yes,
+			vendored:  query.Only,
+			want: []string{
+				"github.com/org/repo",
+				"github.com/org/other/vendor/lib/lib.go",
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			var have []string
+			for _, m := range filterLinguistMatches(matches, tc.generated, tc.vendored, attrs) {
+				have = append(have, name(m))
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("unexpected matches (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestWithLinguistFilter(t *testing.T) {
+	reads := 0
+	git.Mocks.ReadFile = func(commit api.CommitID, name string) ([]byte, error) {
+		reads++
+		return []byte("*.pb.go linguist-generated\n"), nil
+	}
+	t.Cleanup(func() { git.Mocks.ReadFile = nil })
+
+	search := func(t *testing.T, q string) []string {
+		t.Helper()
+		plan, err := query.ParseLiteral(q)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var have []string
+		stream := withLinguistFilter(context.Background(), streaming.StreamFunc(func(e streaming.SearchEvent) {
+			for _, m := range e.Results {
+				have = append(have, m.(*result.FileMatch).Path)
+			}
+		}), plan)
+		stream.Send(streaming.SearchEvent{Results: []result.Match{
+			&result.FileMatch{File: result.File{Repo: types.RepoName{Name: "github.com/org/linguist-stream"}, CommitID: "c1", Path: "main.go"}},
+			&result.FileMatch{File: result.File{Repo: types.RepoName{Name: "github.com/org/linguist-stream"}, CommitID: "c1", Path: "api.pb.go"}},
+		}})
+		return have
+	}
+
+	// Without generated: or vendored: filters .gitattributes must not be
+	// read.
+	if diff := cmp.Diff([]string{"main.go", "api.pb.go"}, search(t, "foo")); diff != "" {
+		t.Fatalf("unexpected matches (-want +got):\n%s", diff)
+	}
+	if reads != 0 {
+		t.Fatalf("expected no .gitattributes reads, got %d", reads)
+	}
+
+	if diff := cmp.Diff([]string{"main.go"}, search(t, "foo generated:no")); diff != "" {
+		t.Fatalf("unexpected matches (-want +got):\n%s", diff)
+	}
+}
@@ -0,0 +1,105 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+)
+
+// searchQueryLintInvalidQuery is the kind of the warning that is returned
+// when the query can't be parsed or validated.
+const searchQueryLintInvalidQuery = "INVALID_QUERY"
+
+type LintSearchQueryArgs struct {
+	Version     string
+	PatternType *string
+	Query       string
+}
+
+func (r *schemaResolver) LintSearchQuery(ctx context.Context, args *LintSearchQueryArgs) ([]*searchQueryLintWarningResolver, error) {
+	searchType, err := detectSearchType(args.Version, args.PatternType)
+	if err != nil {
+		return nil, err
+	}
+	searchType = overrideSearchType(args.Query, searchType)
+
+	warnings, err := query.Lint(args.Query, searchType)
+	if err != nil {
+		return []*searchQueryLintWarningResolver{invalidQueryLintWarning(args.Query, err)}, nil
+	}
+
+	resolvers := make([]*searchQueryLintWarningResolver, 0, len(warnings))
+	for _, w := range warnings {
+		resolvers = append(resolvers, &searchQueryLintWarningResolver{query: args.Query, warning: w, hasRange: true})
+	}
+
+	// Lint doesn't validate the query. Only report validation errors when
+	// none of the warnings already explains them.
+	if len(resolvers) == 0 {
+		if _, err := query.Pipeline(query.Init(args.Query, searchType)); err != nil {
+			resolvers = append(resolvers, invalidQueryLintWarning(args.Query, err))
+		}
+	}
+
+	return resolvers, nil
+}
+
+// invalidQueryLintWarning describes err the same way as the alert that is
+// shown when running the query.
+func invalidQueryLintWarning(queryString string, err error) *searchQueryLintWarningResolver {
+	alert := alertForQuery(queryString, err)
+	return &searchQueryLintWarningResolver{
+		query: queryString,
+		warning: query.LintWarning{
+			Kind:    searchQueryLintInvalidQuery,
+			Message: alert.description,
+		},
+	}
+}
+
+type searchQueryLintWarningResolver struct {
+	query    string
+	warning  query.LintWarning
+	hasRange bool
+}
+
+func (r *searchQueryLintWarningResolver) Kind() string { return string(r.warning.Kind) }
+
+func (r *searchQueryLintWarningResolver) Message() string { return r.warning.Message }
+
+func (r *searchQueryLintWarningResolver) Range() RangeResolver {
+	if !r.hasRange {
+		return nil
+	}
+	return lintRangeResolver(r.warning.Range)
+}
+
+func (r *searchQueryLintWarningResolver) Fixes() []*searchQueryLintFixResolver {
+	fixes := make([]*searchQueryLintFixResolver, 0, len(r.warning.Fixes))
+	for _, f := range r.warning.Fixes {
+		fixes = append(fixes, &searchQueryLintFixResolver{query: r.query, fix: f})
+	}
+	return fixes
+}
+
+type searchQueryLintFixResolver struct {
+	query string
+	fix   query.LintFix
+}
+
+func (r *searchQueryLintFixResolver) Description() string { return r.fix.Description }
+
+func (r *searchQueryLintFixResolver) Range() RangeResolver { return lintRangeResolver(r.fix.Range) }
+
+func (r *searchQueryLintFixResolver) Replacement() string { return r.fix.Replacement }
+
+func (r *searchQueryLintFixResolver) Query() string { return r.fix.Apply(r.query) }
+
+func lintRangeResolver(r query.Range) RangeResolver {
+	return NewRangeResolver(lsp.Range{
+		Start: lsp.Position{Line: r.Start.Line, Character: r.Start.Column},
+		End:   lsp.Position{Line: r.End.Line, Character: r.End.Column},
+	})
+}
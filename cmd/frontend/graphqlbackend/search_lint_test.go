@@ -0,0 +1,48 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+)
+
+func TestLintSearchQuery(t *testing.T) {
+	cases := []struct {
+		query     string
+		wantKinds []string
+	}{
+		{query: `repo:foo bar`, wantKinds: []string{}},
+		{query: `fork:only fork:no bar`, wantKinds: []string{"IMPOSSIBLE_FILTERS"}},
+		{query: `case:maybe bar`, wantKinds: []string{searchQueryLintInvalidQuery}},
+		{query: `repo:foo (bar`, wantKinds: []string{}},
+		{query: `foo (repo:bar baz)`, wantKinds: []string{"LITERAL_PATTERN"}},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			warnings, err := (&schemaResolver{}).LintSearchQuery(context.Background(), &LintSearchQueryArgs{
+				Version: "V2",
+				Query:   tc.query,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			haveKinds := make([]string, 0, len(warnings))
+			for _, w := range warnings {
+				haveKinds = append(haveKinds, w.Kind())
+				if w.Kind() == searchQueryLintInvalidQuery && w.Range() != nil {
+					t.Fatal("expected no range for invalid query warning")
+				}
+			}
+
+			if len(haveKinds) != len(tc.wantKinds) {
+				t.Fatalf("have kinds %v, want %v", haveKinds, tc.wantKinds)
+			}
+			for i := range haveKinds {
+				if haveKinds[i] != tc.wantKinds[i] {
+					t.Fatalf("have kinds %v, want %v", haveKinds, tc.wantKinds)
+				}
+			}
+		})
+	}
+}
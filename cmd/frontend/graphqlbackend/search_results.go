@@ -24,10 +24,9 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
 	searchlogs "github.com/sourcegraph/sourcegraph/cmd/frontend/internal/search/logs"
-	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
-	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/honey"
 	"github.com/sourcegraph/sourcegraph/internal/rcache"
@@ -110,12 +109,18 @@ type SearchResultsResolver struct {
 	// cache for user settings. Ideally this should be set just once in the code path
 	// by an upstream resolver
 	UserSettings *schema.Settings
+
+	// groupByRepo is true if the query contains group:repo.
+	groupByRepo bool
 }
 
 type SearchResults struct {
 	Matches []result.Match
 	Stats   streaming.Stats
 	Alert   *searchAlert
+
+	// Notices are alerts of lower priority than Alert.
+	Notices []*searchAlert
 }
 
 // Results are the results found by the search. It respects the limits set. To
@@ -187,6 +192,13 @@ func (sr *SearchResultsResolver) ApproximateResultCount() string {
 
 func (sr *SearchResultsResolver) Alert() *searchAlert { return sr.SearchResults.Alert }
 
+func (sr *SearchResultsResolver) Notices() []*searchAlert {
+	if sr.SearchResults.Alert == nil || sr.SearchResults.Notices == nil {
+		return []*searchAlert{}
+	}
+	return sr.SearchResults.Notices
+}
+
 func (sr *SearchResultsResolver) ElapsedMilliseconds() int32 {
 	return int32(sr.elapsed.Milliseconds())
 }
@@ -440,18 +452,13 @@ func LogSearchLatency(ctx context.Context, db dbutil.DB, si *run.SearchInputs, d
 
 	// Only log the time if we successfully resolved one search type.
 	if len(types) == 1 {
-		a := actor.FromContext(ctx)
-		if a.IsAuthenticated() {
-			value := fmt.Sprintf(`{"durationMs": %d}`, durationMs)
-			eventName := fmt.Sprintf("search.latencies.%s", types[0])
-			featureFlags := featureflag.FromContext(ctx)
-			go func() {
-				err := usagestats.LogBackendEvent(db, a.UID, eventName, json.RawMessage(value), featureFlags, nil)
-				if err != nil {
-					log15.Warn("Could not log search latency", "err", err)
-				}
-			}()
-		}
+		eventName := fmt.Sprintf("search.latencies.%s", types[0])
+		go func() {
+			err := usagestats.LogActorEvent(ctx, db, eventName, map[string]int32{"durationMs": durationMs})
+			if err != nil {
+				log15.Warn("Could not log search latency", "err", err)
+			}
+		}()
 	}
 }
 
@@ -471,11 +478,14 @@ func (r *searchResolver) toRepoOptions(q query.Q, opts resolveRepositoriesOpts)
 		settingArchived = *v
 	}
 
+	dedupe, _ := q.StringValue(query.FieldDedupe)
+
 	fork := query.No
-	if searchrepos.ExactlyOneRepo(repoFilters) || settingForks {
+	if searchrepos.ExactlyOneRepo(repoFilters) || settingForks || dedupe == query.DedupeForks {
 		// fork defaults to No unless either of:
 		// (1) exactly one repo is being searched, or
-		// (2) user/org/global setting includes forks
+		// (2) user/org/global setting includes forks, or
+		// (3) results in forks are deduplicated with dedupe:forks
 		fork = query.Yes
 	}
 	if setFork := q.Fork(); setFork != nil {
@@ -497,6 +507,7 @@ func (r *searchResolver) toRepoOptions(q query.Q, opts resolveRepositoriesOpts)
 	visibility := query.ParseVisibility(visibilityStr)
 
 	commitAfter, _ := q.StringValue(query.FieldRepoHasCommitAfter)
+	hasMeta, _ := q.StringValues(query.FieldRepoHasMeta)
 	searchContextSpec, _ := q.StringValue(query.FieldContext)
 
 	var versionContextName string
@@ -524,6 +535,7 @@ func (r *searchResolver) toRepoOptions(q query.Q, opts resolveRepositoriesOpts)
 		OnlyPrivate:        visibility == query.Private,
 		OnlyPublic:         visibility == query.Public,
 		CommitAfter:        commitAfter,
+		HasMeta:            hasMeta,
 		Query:              q,
 		Ranked:             true,
 		Limit:              opts.limit,
@@ -577,10 +589,11 @@ func (r *searchResolver) toTextParameters(q query.Q) (*search.TextParameters, er
 	args := search.TextParameters{
 		PatternInfo: p,
 		Query:       q,
-		Timeout:     search.TimeoutDuration(b),
+		Timeout:     r.timeout(b),
 
-		// UseFullDeadline if timeout: set or we are streaming.
-		UseFullDeadline: q.Timeout() != nil || q.Count() != nil || r.stream != nil,
+		// UseFullDeadline if timeout: set, we are streaming or the search is
+		// exhaustive.
+		UseFullDeadline: q.Timeout() != nil || q.Count() != nil || r.stream != nil || r.Exhaustive,
 
 		Zoekt:        r.zoekt,
 		SearcherURLs: r.searcherURLs,
@@ -665,7 +678,7 @@ func (r *searchResolver) evaluateAnd(ctx context.Context, q query.Basic) (*Searc
 	maxTryCount := 40000
 
 	// Set an overall timeout in addition to the timeouts that are set for leaf-requests.
-	ctx, cancel := context.WithTimeout(ctx, search.TimeoutDuration(q))
+	ctx, cancel := context.WithTimeout(ctx, r.timeout(q))
 	defer cancel()
 
 	if count := q.GetCount(); count != "" {
@@ -921,6 +934,9 @@ func (r *searchResolver) logBatch(ctx context.Context, srr *SearchResultsResolve
 func (r *searchResolver) resultsBatch(ctx context.Context) (*SearchResultsResolver, error) {
 	start := time.Now()
 	sr, err := r.resultsRecursive(ctx, r.Plan)
+	if dedupe, _ := r.Plan.ToParseTree().StringValue(query.FieldDedupe); dedupe == query.DedupeForks && sr != nil && err == nil {
+		sr.Matches, err = dedupeForks(ctx, r.db, sr.Matches)
+	}
 	srr := r.resultsToResolver(sr)
 	r.logBatch(ctx, srr, start, err)
 	return srr, err
@@ -956,11 +972,14 @@ func (r *searchResolver) resultsToResolver(results *SearchResults) *SearchResult
 	if results == nil {
 		results = &SearchResults{}
 	}
+	localizeResults(results, r.Locale)
+	group, _ := r.Plan.ToParseTree().StringValue(query.FieldGroup)
 	return &SearchResultsResolver{
 		SearchResults: results,
 		limit:         r.MaxResults(),
 		db:            r.db,
 		UserSettings:  r.UserSettings,
+		groupByRepo:   group == query.GroupRepo,
 	}
 }
 
@@ -1124,7 +1143,13 @@ func (r *searchResolver) resultsWithTimeoutSuggestion(ctx context.Context, args
 	if shouldShowAlert {
 		usedTime := time.Since(start)
 		suggestTime := longer(2, usedTime)
-		return alertForTimeout(usedTime, suggestTime, r).wrapResults(), nil
+		timeoutResults := alertForTimeout(usedTime, suggestTime, r).wrapResults()
+		// Keep the alerts of the timed out search, e.g. about missing
+		// revisions, which still apply to the query.
+		if rr != nil && rr.Alert != nil {
+			timeoutResults.Notices = append([]*searchAlert{rr.Alert}, rr.Notices...)
+		}
+		return timeoutResults, nil
 	}
 	return rr, err
 }
@@ -1416,6 +1441,14 @@ func (r *searchResolver) doResults(ctx context.Context, args *search.TextParamet
 		var cancelOnLimit context.CancelFunc
 		ctx, stream, cancelOnLimit = streaming.WithLimit(ctx, stream, limit)
 		defer cancelOnLimit()
+
+		// 🚨 SECURITY: Filter results by sub-repository permissions before
+		// they are counted or sent to the client.
+		stream = streaming.WithSubRepoPermissionsFilter(ctx, stream, authz.DefaultSubRepoPermsChecker)
+
+		// Apply the generated: and vendored: filters before results are
+		// counted against the limit.
+		stream = withLinguistFilter(ctx, stream, args.Query)
 	}
 
 	agg := run.NewAggregator(r.db, stream)
@@ -1562,6 +1595,12 @@ func (r *searchResolver) doResults(ctx context.Context, args *search.TextParamet
 	// collecting from the streams.
 	matches, common, aggErrs := agg.Get()
 
+	// 🚨 SECURITY: Filter batch results by sub-repository permissions.
+	matches, err = streaming.FilterSubRepoPermissions(ctx, authz.DefaultSubRepoPermsChecker, matches)
+	if err != nil {
+		return nil, errors.Wrap(err, "filtering by sub-repository permissions")
+	}
+
 	ao := alertObserver{
 		Inputs:     r.SearchInputs,
 		hasResults: len(matches) > 0,
@@ -1569,16 +1608,21 @@ func (r *searchResolver) doResults(ctx context.Context, args *search.TextParamet
 	for _, err := range aggErrs.Errors {
 		ao.Error(ctx, err)
 	}
-	alert, err := ao.Done(&common)
+	for _, alert := range r.onboardingAlerts(ctx, args, resolved.RepoRevs) {
+		ao.update(alert)
+	}
+	alert, notices, err := ao.Done(&common)
 
 	tr.LazyPrintf("matches=%d %s", len(matches), &common)
 
 	r.sortResults(matches)
+	matches = filterLinguist(ctx, args.Query, matches)
 
 	return &SearchResults{
 		Matches: matches,
 		Stats:   common,
 		Alert:   alert,
+		Notices: notices,
 	}, err
 }
 
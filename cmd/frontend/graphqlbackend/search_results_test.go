@@ -684,6 +684,71 @@ func Test_SearchResultsResolver_ApproximateResultCount(t *testing.T) {
 	}
 }
 
+func TestSearchResultsResolver_RepositoryGroups(t *testing.T) {
+	db := new(dbtesting.MockDB)
+	matches := []result.Match{
+		&result.FileMatch{File: result.File{Repo: types.RepoName{ID: 1, Name: "a"}, Path: "1.go"}},
+		&result.FileMatch{File: result.File{Repo: types.RepoName{ID: 2, Name: "b"}, Path: "1.go"}},
+		&result.FileMatch{File: result.File{Repo: types.RepoName{ID: 1, Name: "a"}, Path: "2.go"}},
+	}
+
+	sr := &SearchResultsResolver{db: db, SearchResults: &SearchResults{Matches: matches}}
+	if groups := sr.RepositoryGroups(&repositoryGroupsArgs{PerRepositoryLimit: 1}); groups != nil {
+		t.Fatalf("want no groups without group:repo, have %d", len(*groups))
+	}
+
+	sr.groupByRepo = true
+	groups := sr.RepositoryGroups(&repositoryGroupsArgs{PerRepositoryLimit: 1})
+	if groups == nil || len(*groups) != 2 {
+		t.Fatalf("want 2 groups, have %v", groups)
+	}
+	a := (*groups)[0]
+	if have, want := a.Repository().Name(), "a"; have != want {
+		t.Errorf("wrong repository: want %q, have %q", want, have)
+	}
+	if len(a.Results()) != 1 || a.ResultCount() != 2 || !a.LimitHit() {
+		t.Errorf("want 1 of 2 results with limit hit, have %d of %d", len(a.Results()), a.ResultCount())
+	}
+}
+
+func TestSearchResultsResolver_Aggregations(t *testing.T) {
+	db := new(dbtesting.MockDB)
+	matches := []result.Match{
+		&result.FileMatch{File: result.File{Repo: types.RepoName{ID: 1, Name: "a"}, Path: "1.go"}},
+		&result.FileMatch{File: result.File{Repo: types.RepoName{ID: 2, Name: "b"}, Path: "1.go"}},
+		&result.FileMatch{File: result.File{Repo: types.RepoName{ID: 1, Name: "a"}, Path: "README"}},
+		&result.RepoMatch{Name: "c", ID: 3},
+	}
+
+	sr := &SearchResultsResolver{db: db, SearchResults: &SearchResults{Matches: matches}}
+	if _, err := sr.Aggregations(&searchAggregationsArgs{By: "REPO", Limit: -1}); err == nil {
+		t.Fatalf("want error for negative limit")
+	}
+
+	a, err := sr.Aggregations(&searchAggregationsArgs{By: "LANGUAGE", Limit: 50})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Groups()) != 1 || a.Groups()[0].Label() != "Go" || a.Groups()[0].Count() != 2 {
+		t.Errorf("want 2 Go matches, have %+v", a.result.Groups)
+	}
+	if a.UnattributedCount() != 2 || !a.IsExact() {
+		t.Errorf("want 2 exact unattributed matches, have %d (exact=%v)", a.UnattributedCount(), a.IsExact())
+	}
+
+	sr.Stats.IsLimitHit = true
+	a, err = sr.Aggregations(&searchAggregationsArgs{By: "REPO", Limit: 1})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(a.Groups()) != 1 || a.Groups()[0].Label() != "a" || a.Groups()[0].Count() != 2 {
+		t.Errorf("want 2 matches in repository a, have %+v", a.result.Groups)
+	}
+	if a.OtherGroupCount() != 2 || a.OtherCount() != 2 || a.IsExact() {
+		t.Errorf("want 2 inexact matches in 2 other groups, have %d in %d (exact=%v)", a.OtherCount(), a.OtherGroupCount(), a.IsExact())
+	}
+}
+
 func TestGetExactFilePatterns(t *testing.T) {
 	tests := []struct {
 		in   string
@@ -305,6 +305,31 @@ func TestDetectSearchType(t *testing.T) {
 	}
 }
 
+func TestExhaustivePlan(t *testing.T) {
+	for _, tc := range []struct {
+		input string
+		want  []string
+	}{
+		{"foo", []string{"99999999"}},
+		{"foo count:10", []string{"10"}},
+		{"(foo count:10) or (bar repo:baz)", []string{"10", "99999999"}},
+	} {
+		t.Run(tc.input, func(t *testing.T) {
+			plan, err := query.Pipeline(query.Init(tc.input, query.SearchTypeLiteral))
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, b := range exhaustivePlan(plan) {
+				got = append(got, b.GetCount())
+			}
+			if diff := cmp.Diff(tc.want, got); diff != "" {
+				t.Fatalf("unexpected counts (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
 func TestExactlyOneRepo(t *testing.T) {
 	cases := []struct {
 		repoFilters []string
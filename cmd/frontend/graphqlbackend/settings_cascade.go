@@ -4,8 +4,10 @@ import (
 	"context"
 	"encoding/json"
 	"sort"
+	"strconv"
 
 	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
@@ -82,11 +84,74 @@ func viewerFinalSettings(ctx context.Context, db dbutil.DB) (*configurationResol
 }
 
 func (r *settingsCascade) Final(ctx context.Context) (string, error) {
-	subjects, err := r.Subjects(ctx)
+	allSettings, err := r.cachedSettings(ctx)
 	if err != nil {
 		return "", err
 	}
 
+	final, err := mergeSettings(allSettings)
+	return string(final), err
+}
+
+// cachedSettings returns the settings of the subjects, like settings, but reads
+// the settings stored in the database from database.SettingsCascadeCache. The
+// default settings are derived from the site configuration and are not cached.
+func (r *settingsCascade) cachedSettings(ctx context.Context) ([]string, error) {
+	key := r.cacheKey()
+	if key == "" || database.SettingsCascadeCache == nil {
+		return r.settings(ctx)
+	}
+
+	var cached []string
+	generation, ok, err := database.SettingsCascadeCache.GetJSON(ctx, key, &cached)
+	if err != nil {
+		log15.Warn("reading settings cascade cache", "key", key, "error", err)
+	}
+	if ok {
+		defaultSettings, err := (&defaultSettingsResolver{db: r.db}).LatestSettings(ctx)
+		if err != nil {
+			return nil, err
+		}
+		return append([]string{defaultSettings.settings.Contents}, cached...), nil
+	}
+
+	allSettings, err := r.settings(ctx)
+	if err != nil {
+		return nil, err
+	}
+	// The first subject is always the default settings.
+	if err := database.SettingsCascadeCache.SetJSON(ctx, generation, key, allSettings[1:]); err != nil {
+		log15.Warn("writing settings cascade cache", "key", key, "error", err)
+	}
+	return allSettings, nil
+}
+
+// cacheKey returns the key of the cascade in database.SettingsCascadeCache, or
+// the empty string if the cascade is not cached.
+func (r *settingsCascade) cacheKey() string {
+	switch {
+	case mockSettingsCascadeSubjects != nil:
+		return ""
+	case r.unauthenticatedActor:
+		return "anonymous"
+	case r.subject.site != nil:
+		return "site"
+	case r.subject.org != nil:
+		return "org:" + strconv.Itoa(int(r.subject.org.org.ID))
+	case r.subject.user != nil:
+		return "user:" + strconv.Itoa(int(r.subject.user.user.ID))
+	}
+	return ""
+}
+
+// settings returns the contents of the latest settings of each subject, in the
+// order of the subjects.
+func (r *settingsCascade) settings(ctx context.Context) ([]string, error) {
+	subjects, err := r.Subjects(ctx)
+	if err != nil {
+		return nil, err
+	}
+
 	// Each LatestSettings is a roundtrip to the database. So we do the
 	// requests concurrently. If the subject has no settings, then
 	// allSettings[i] will be the empty string. mergeSettings ignores empty
@@ -108,11 +173,9 @@ func (r *settingsCascade) Final(ctx context.Context) (string, error) {
 	}
 
 	if err := bounded.Wait(); err != nil {
-		return "", err
+		return nil, err
 	}
-
-	final, err := mergeSettings(allSettings)
-	return string(final), err
+	return allSettings, nil
 }
 
 // Deprecated: in the GraphQL API
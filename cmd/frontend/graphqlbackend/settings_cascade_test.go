@@ -4,9 +4,15 @@ import (
 	"context"
 	"encoding/json"
 	"reflect"
+	"strconv"
 	"testing"
+	"time"
 
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/cache"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 )
 
 func TestMergeSettings(t *testing.T) {
@@ -148,6 +154,59 @@ func TestSubjects(t *testing.T) {
 	})
 }
 
+// builtinExtensionRegistry is an extension registry that only provides the
+// builtin extensions, which the default settings are computed from.
+type builtinExtensionRegistry struct{ ExtensionRegistryResolver }
+
+func (builtinExtensionRegistry) FilterRemoteExtensions(ids []string) []string { return ids }
+
+func TestSettingsCascadeCache(t *testing.T) {
+	database.SettingsCascadeCache = cache.NewVersioned(cache.NewMemory(), time.Minute)
+	ExtensionRegistry = func(dbutil.DB) ExtensionRegistryResolver { return builtinExtensionRegistry{} }
+	t.Cleanup(func() {
+		database.SettingsCascadeCache = nil
+		ExtensionRegistry = nil
+		database.Mocks.Settings = database.MockSettings{}
+	})
+
+	calls := 0
+	contents := `{"a": 1}`
+	database.Mocks.Settings.GetLatest = func(ctx context.Context, subject api.SettingsSubject) (*api.Settings, error) {
+		calls++
+		return &api.Settings{Subject: subject, Contents: contents}, nil
+	}
+
+	ctx := context.Background()
+	cascade := &settingsCascade{db: new(dbtesting.MockDB), unauthenticatedActor: true}
+	final := func() string {
+		t.Helper()
+		s, err := cascade.Final(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		var v struct{ A int }
+		if err := json.Unmarshal([]byte(s), &v); err != nil {
+			t.Fatal(err)
+		}
+		return strconv.Itoa(v.A)
+	}
+
+	if have, want := final()+final(), "11"; have != want {
+		t.Fatalf("got %q, want %q", have, want)
+	}
+	if calls != 1 {
+		t.Fatalf("got %d calls of GetLatest, want 1", calls)
+	}
+
+	contents = `{"a": 2}`
+	if err := database.SettingsCascadeCache.Invalidate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if have, want := final(), "2"; have != want {
+		t.Fatalf("got %q after invalidation, want %q", have, want)
+	}
+}
+
 func jsonDeepEqual(a, b string) bool {
 	var va, vb interface{}
 	if err := json.Unmarshal([]byte(a), &va); err != nil {
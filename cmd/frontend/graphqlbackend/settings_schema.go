@@ -0,0 +1,324 @@
+package graphqlbackend
+
+import (
+	"context"
+	"encoding/json"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/inconshreveable/log15"
+	"github.com/sourcegraph/go-langserver/pkg/lsp"
+	"github.com/sourcegraph/jsonx"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// ViewerSettingsSchema returns the JSON Schema of settings, extended with the settings
+// contributed by the extensions enabled in the viewer's settings cascade.
+func (r *schemaResolver) ViewerSettingsSchema(ctx context.Context) (*settingsSchemaResolver, error) {
+	final, err := viewerFinalSettings(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+
+	var settings schema.Settings
+	if err := jsonc.Unmarshal(final.contents, &settings); err != nil {
+		return nil, err
+	}
+
+	var extensionIDs []string
+	for id, enabled := range settings.Extensions {
+		if enabled {
+			extensionIDs = append(extensionIDs, id)
+		}
+	}
+	sort.Strings(extensionIDs)
+
+	contributions := r.extensionSettingsContributions(ctx, extensionIDs)
+	return newSettingsSchemaResolver(schema.SettingsSchemaJSON, contributions)
+}
+
+// extensionSettingsContributions returns the JSON Schemas of the settings properties
+// contributed by the manifests of the given extensions, in the order of the extensions.
+// Extensions that cannot be fetched are skipped, as they do not prevent editing settings.
+func (r *schemaResolver) extensionSettingsContributions(ctx context.Context, extensionIDs []string) []map[string]json.RawMessage {
+	if len(extensionIDs) == 0 || ExtensionRegistry == nil || conf.Extensions() == nil {
+		return nil
+	}
+	registry := ExtensionRegistry(r.db)
+
+	contributions := make([]map[string]json.RawMessage, len(extensionIDs))
+	bounded := goroutine.NewBounded(8)
+	for i, id := range extensionIDs {
+		i, id := i, id
+		bounded.Go(func() error {
+			properties, err := extensionSettingsProperties(ctx, registry, id)
+			if err != nil {
+				log15.Warn("fetching settings contributed by extension", "extension", id, "error", err)
+				return nil
+			}
+			contributions[i] = properties
+			return nil
+		})
+	}
+	_ = bounded.Wait()
+	return contributions
+}
+
+func extensionSettingsProperties(ctx context.Context, registry ExtensionRegistryResolver, extensionID string) (map[string]json.RawMessage, error) {
+	extension, err := registry.Extension(ctx, &ExtensionRegistryExtensionArgs{ExtensionID: extensionID})
+	if err != nil || extension == nil {
+		return nil, err
+	}
+	manifest, err := extension.Manifest(ctx)
+	if err != nil || manifest == nil {
+		return nil, err
+	}
+
+	var m struct {
+		Contributes struct {
+			Configuration struct {
+				Properties map[string]json.RawMessage `json:"properties"`
+			} `json:"configuration"`
+		} `json:"contributes"`
+	}
+	if err := jsonc.Unmarshal(manifest.Raw(), &m); err != nil {
+		return nil, err
+	}
+	return m.Contributes.Configuration.Properties, nil
+}
+
+type settingsSchemaResolver struct {
+	json   string
+	schema map[string]interface{}
+}
+
+// newSettingsSchemaResolver returns the settings schema base extended with the properties of
+// each of contributions. Properties of base and of earlier contributions take precedence.
+func newSettingsSchemaResolver(base string, contributions []map[string]json.RawMessage) (*settingsSchemaResolver, error) {
+	var s map[string]interface{}
+	if err := json.Unmarshal([]byte(base), &s); err != nil {
+		return nil, err
+	}
+
+	properties, _ := s["properties"].(map[string]interface{})
+	if properties == nil {
+		properties = map[string]interface{}{}
+		s["properties"] = properties
+	}
+	for _, contribution := range contributions {
+		for name, property := range contribution {
+			if _, ok := properties[name]; ok {
+				continue
+			}
+			var v interface{}
+			if err := json.Unmarshal(property, &v); err != nil {
+				continue
+			}
+			properties[name] = v
+		}
+	}
+
+	b, err := json.MarshalIndent(s, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	return &settingsSchemaResolver{json: string(b), schema: s}, nil
+}
+
+func (r *settingsSchemaResolver) JSON() JSONCString { return JSONCString(r.json) }
+
+func (r *settingsSchemaResolver) Validate(args *struct{ Contents string }) ([]*settingsProblemResolver, error) {
+	problems, err := conf.ValidateSettingsWithSchema(args.Contents, r.json)
+	if err != nil {
+		return nil, err
+	}
+
+	text := []rune(args.Contents)
+	resolvers := make([]*settingsProblemResolver, 0, len(problems))
+	for _, p := range problems {
+		resolvers = append(resolvers, &settingsProblemResolver{
+			problem:  p,
+			lspRange: offsetsToRange(text, p.Offset, p.Offset+p.Length),
+		})
+	}
+	return resolvers, nil
+}
+
+type settingsHoverArgs struct {
+	Contents  string
+	Line      int32
+	Character int32
+}
+
+// Hover returns the documentation of the setting at the given position of the settings
+// contents, or nil if there is no documented setting at the position.
+func (r *settingsSchemaResolver) Hover(args *settingsHoverArgs) *settingsHoverResolver {
+	text := []rune(args.Contents)
+	offset := positionToOffset(text, lsp.Position{Line: int(args.Line), Character: int(args.Character)})
+
+	root, _ := jsonx.ParseTree(args.Contents, jsonx.ParseOptions{Comments: true, TrailingCommas: true})
+	node := innermostNode(root, offset)
+	if node == nil {
+		return nil
+	}
+
+	// Collect the keys from the root to the innermost property containing the position.
+	var property *jsonx.Node
+	var keys []string
+	for n := node; n.Parent != nil; n = n.Parent {
+		if property == nil && n.Type == jsonx.Property {
+			property = n
+		}
+		if property == nil {
+			continue
+		}
+
+		switch n.Parent.Type {
+		case jsonx.Object:
+			keys = append(keys, n.Children[0].Value.(string))
+		case jsonx.Array:
+			for i, child := range n.Parent.Children {
+				if child == n {
+					keys = append(keys, strconv.Itoa(i))
+				}
+			}
+		}
+	}
+	if property == nil {
+		return nil
+	}
+	for i, j := 0, len(keys)-1; i < j; i, j = i+1, j-1 {
+		keys[i], keys[j] = keys[j], keys[i]
+	}
+
+	propertySchema := r.schemaAt(keys)
+	if propertySchema == nil {
+		return nil
+	}
+	description, _ := propertySchema["description"].(string)
+	if description == "" {
+		return nil
+	}
+	if deprecated, _ := propertySchema["deprecationMessage"].(string); deprecated != "" {
+		description += "\n\n**Deprecated:** " + deprecated
+	}
+
+	return &settingsHoverResolver{
+		keyPath:       strings.Join(keys, "."),
+		documentation: description,
+		lspRange:      offsetsToRange(text, property.Offset, property.Offset+property.Length),
+	}
+}
+
+// schemaAt returns the schema of the value at the given keys, following references to the
+// definitions of the settings schema.
+func (r *settingsSchemaResolver) schemaAt(keys []string) map[string]interface{} {
+	s := r.schema
+	for _, key := range keys {
+		s = r.resolveRef(s)
+		if properties, ok := s["properties"].(map[string]interface{}); ok && properties[key] != nil {
+			s, _ = properties[key].(map[string]interface{})
+		} else if items, ok := s["items"].(map[string]interface{}); ok {
+			if _, err := strconv.Atoi(key); err != nil {
+				return nil
+			}
+			s = items
+		} else if additional, ok := s["additionalProperties"].(map[string]interface{}); ok {
+			s = additional
+		} else {
+			return nil
+		}
+		if s == nil {
+			return nil
+		}
+	}
+	return r.resolveRef(s)
+}
+
+func (r *settingsSchemaResolver) resolveRef(s map[string]interface{}) map[string]interface{} {
+	ref, _ := s["$ref"].(string)
+	if !strings.HasPrefix(ref, "#/definitions/") {
+		return s
+	}
+	definitions, _ := r.schema["definitions"].(map[string]interface{})
+	if definition, ok := definitions[strings.TrimPrefix(ref, "#/definitions/")].(map[string]interface{}); ok {
+		return definition
+	}
+	return s
+}
+
+// innermostNode returns the innermost node of the parse tree containing the offset.
+func innermostNode(node *jsonx.Node, offset int) *jsonx.Node {
+	if node == nil || offset < node.Offset || offset > node.Offset+node.Length {
+		return nil
+	}
+	for _, child := range node.Children {
+		if n := innermostNode(child, offset); n != nil {
+			return n
+		}
+	}
+	return node
+}
+
+// positionToOffset returns the character offset of the position in text.
+func positionToOffset(text []rune, pos lsp.Position) int {
+	line := 0
+	for i, c := range text {
+		if line == pos.Line {
+			if offset := i + pos.Character; offset < len(text) {
+				return offset
+			}
+			return len(text)
+		}
+		if c == '\n' {
+			line++
+		}
+	}
+	return len(text)
+}
+
+// offsetsToRange returns the range of text between the character offsets start and end.
+func offsetsToRange(text []rune, start, end int) lsp.Range {
+	var r lsp.Range
+	var pos lsp.Position
+	for i := 0; i <= len(text); i++ {
+		if i == start {
+			r.Start = pos
+		}
+		if i == end {
+			r.End = pos
+			break
+		}
+		if i < len(text) && text[i] == '\n' {
+			pos.Line++
+			pos.Character = 0
+		} else {
+			pos.Character++
+		}
+	}
+	return r
+}
+
+type settingsProblemResolver struct {
+	problem  *conf.SettingsProblem
+	lspRange lsp.Range
+}
+
+func (r *settingsProblemResolver) KeyPath() string       { return r.problem.KeyPath }
+func (r *settingsProblemResolver) Description() string   { return r.problem.Description }
+func (r *settingsProblemResolver) Range() *rangeResolver { return &rangeResolver{r.lspRange} }
+
+type settingsHoverResolver struct {
+	keyPath       string
+	documentation string
+	lspRange      lsp.Range
+}
+
+func (r *settingsHoverResolver) KeyPath() string       { return r.keyPath }
+func (r *settingsHoverResolver) Documentation() string { return r.documentation }
+func (r *settingsHoverResolver) Range() *rangeResolver { return &rangeResolver{r.lspRange} }
@@ -0,0 +1,84 @@
+package graphqlbackend
+
+import (
+	"encoding/json"
+	"testing"
+)
+
+func TestSettingsSchema(t *testing.T) {
+	const base = `{
+		"$id": "settings.schema.json#",
+		"type": "object",
+		"properties": {
+			"search.scopes": {
+				"description": "Predefined search scopes.",
+				"type": "array",
+				"items": {"$ref": "#/definitions/SearchScope"}
+			}
+		},
+		"definitions": {
+			"SearchScope": {
+				"type": "object",
+				"properties": {
+					"name": {"description": "The name of the scope.", "type": "string"}
+				}
+			}
+		}
+	}`
+	contributions := []map[string]json.RawMessage{
+		{"codecov.showCoverage": json.RawMessage(`{"description": "Show coverage.", "type": "boolean"}`)},
+		{"codecov.showCoverage": json.RawMessage(`{"description": "Shadowed.", "type": "string"}`)},
+	}
+
+	r, err := newSettingsSchemaResolver(base, contributions)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("validate", func(t *testing.T) {
+		problems, err := r.Validate(&struct{ Contents string }{Contents: "{\n  // Coverage\n  \"codecov.showCoverage\": \"yes\"\n}"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(problems) != 1 {
+			t.Fatalf("want 1 problem, have %d", len(problems))
+		}
+		p := problems[0]
+		if p.KeyPath() != "codecov.showCoverage" {
+			t.Errorf("want problem with codecov.showCoverage, have %q", p.KeyPath())
+		}
+		if start, end := p.Range().Start(), p.Range().End(); start.Line() != 2 || start.Character() != 2 || end.Line() != 2 || end.Character() != 31 {
+			t.Errorf("want problem at 2:2-2:31, have %d:%d-%d:%d", start.Line(), start.Character(), end.Line(), end.Character())
+		}
+	})
+
+	t.Run("hover", func(t *testing.T) {
+		contents := "{\n  \"search.scopes\": [{\"name\": \"x\"}],\n  \"other\": 1\n}"
+		tests := []struct {
+			line, character int32
+			keyPath         string
+			documentation   string
+		}{
+			{line: 1, character: 5, keyPath: "search.scopes", documentation: "Predefined search scopes."},
+			{line: 1, character: 24, keyPath: "search.scopes.0.name", documentation: "The name of the scope."},
+			{line: 2, character: 4},
+			{line: 0, character: 0},
+		}
+		for _, test := range tests {
+			hover := r.Hover(&settingsHoverArgs{Contents: contents, Line: test.line, Character: test.character})
+			if test.keyPath == "" {
+				if hover != nil {
+					t.Errorf("%d:%d: want no hover, have %q", test.line, test.character, hover.KeyPath())
+				}
+				continue
+			}
+			if hover == nil {
+				t.Errorf("%d:%d: want hover for %q, have none", test.line, test.character, test.keyPath)
+				continue
+			}
+			if hover.KeyPath() != test.keyPath || hover.Documentation() != test.documentation {
+				t.Errorf("%d:%d: want %q (%q), have %q (%q)", test.line, test.character, test.keyPath, test.documentation, hover.KeyPath(), hover.Documentation())
+			}
+		}
+	})
+}
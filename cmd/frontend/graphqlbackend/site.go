@@ -9,14 +9,17 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/sourcegraph/jsonx"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/siteid"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/versions"
 	"github.com/sourcegraph/sourcegraph/internal/version"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
@@ -61,8 +64,8 @@ func (r *siteResolver) SiteID() string { return siteid.Get() }
 
 func (r *siteResolver) Configuration(ctx context.Context) (*siteConfigurationResolver, error) {
 	// 🚨 SECURITY: The site configuration contains secret tokens and credentials,
-	// so only admins may view it.
-	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// so only admins and auditors may view it, and auditors only with secrets redacted.
+	if err := backend.CheckCurrentUserIsSiteAuditor(ctx, r.db); err != nil {
 		return nil, err
 	}
 	return &siteConfigurationResolver{db: r.db}, nil
@@ -77,6 +80,15 @@ func (r *siteResolver) ViewerCanAdminister(ctx context.Context) (bool, error) {
 	return true, nil
 }
 
+func (r *siteResolver) ViewerCanAudit(ctx context.Context) (bool, error) {
+	if err := backend.CheckCurrentUserIsSiteAuditor(ctx, r.db); err == backend.ErrMustBeSiteAuditor || err == backend.ErrNotAuthenticated {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 func (r *siteResolver) settingsSubject() api.SettingsSubject {
 	return api.SettingsSubject{Site: true}
 }
@@ -114,6 +126,8 @@ func (r *siteResolver) HasCodeIntelligence() bool {
 	return true
 }
 
+func (r *siteResolver) MaintenanceMode() bool { return conf.MaintenanceModeEnabled() }
+
 func (r *siteResolver) ProductSubscription() *productSubscriptionStatus {
 	return &productSubscriptionStatus{}
 }
@@ -122,14 +136,45 @@ func (r *siteResolver) AllowSiteSettingsEdits() bool {
 	return canUpdateSiteConfiguration()
 }
 
+func (r *siteResolver) CodeHostVersionWarnings(ctx context.Context) ([]*codeHostVersionWarningResolver, error) {
+	// 🚨 SECURITY: The warnings contain the URLs of the configured code hosts, so only
+	// site admins may view them.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	warnings, err := versions.GetWarnings()
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*codeHostVersionWarningResolver, 0, len(warnings))
+	for _, w := range warnings {
+		resolvers = append(resolvers, &codeHostVersionWarningResolver{warning: w})
+	}
+	return resolvers, nil
+}
+
+type codeHostVersionWarningResolver struct {
+	warning *versions.Warning
+}
+
+func (r *codeHostVersionWarningResolver) ExternalServiceKind() string {
+	return r.warning.ExternalServiceKind
+}
+func (r *codeHostVersionWarningResolver) CodeHost() string       { return r.warning.CodeHost }
+func (r *codeHostVersionWarningResolver) Version() string        { return r.warning.Version }
+func (r *codeHostVersionWarningResolver) MinimumVersion() string { return r.warning.MinimumVersion }
+func (r *codeHostVersionWarningResolver) Message() string        { return r.warning.Message() }
+
 type siteConfigurationResolver struct {
 	db dbutil.DB
 }
 
 func (r *siteConfigurationResolver) ID(ctx context.Context) (int32, error) {
 	// 🚨 SECURITY: The site configuration contains secret tokens and credentials,
-	// so only admins may view it.
-	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// so only admins and auditors may view it.
+	if err := backend.CheckCurrentUserIsSiteAuditor(ctx, r.db); err != nil {
 		return 0, err
 	}
 	return 0, nil // TODO(slimsag): future: return the real ID here to prevent races
@@ -137,20 +182,37 @@ func (r *siteConfigurationResolver) ID(ctx context.Context) (int32, error) {
 
 func (r *siteConfigurationResolver) EffectiveContents(ctx context.Context) (JSONCString, error) {
 	// 🚨 SECURITY: The site configuration contains secret tokens and credentials,
-	// so only admins may view it.
-	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+	// so only admins may view it as is, and auditors only with secrets redacted.
+	if err := backend.CheckCurrentUserIsSiteAuditor(ctx, r.db); err != nil {
 		return "", err
 	}
 	siteConfig := globals.ConfigurationServerFrontendOnly.Raw().Site
-	return JSONCString(siteConfig), nil
+	return r.maybeRedactSecrets(ctx, siteConfig)
 }
 
-func (r *siteConfigurationResolver) ValidationMessages(ctx context.Context) ([]string, error) {
-	contents, err := r.EffectiveContents(ctx)
+// maybeRedactSecrets returns the site configuration contents with secrets redacted unless the
+// current user is a site admin.
+func (r *siteConfigurationResolver) maybeRedactSecrets(ctx context.Context, contents string) (JSONCString, error) {
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err == nil {
+		return JSONCString(contents), nil
+	} else if err != backend.ErrMustBeSiteAdmin {
+		return "", err
+	}
+	redacted, err := conf.RedactSecrets(contents)
 	if err != nil {
+		return "", err
+	}
+	return JSONCString(redacted), nil
+}
+
+func (r *siteConfigurationResolver) ValidationMessages(ctx context.Context) ([]string, error) {
+	// 🚨 SECURITY: Validation messages may quote the site configuration, so only admins and
+	// auditors may view them.
+	if err := backend.CheckCurrentUserIsSiteAuditor(ctx, r.db); err != nil {
 		return nil, err
 	}
-	return conf.ValidateSite(string(contents))
+	// Validate the configuration as is, because redacted secrets may be invalid values.
+	return conf.ValidateSite(globals.ConfigurationServerFrontendOnly.Raw().Site)
 }
 
 func (r *schemaResolver) UpdateSiteConfiguration(ctx context.Context, args *struct {
@@ -184,6 +246,30 @@ func (r *schemaResolver) UpdateSiteConfiguration(ctx context.Context, args *stru
 	return globals.ConfigurationServerFrontendOnly.NeedServerRestart(), nil
 }
 
+func (r *schemaResolver) SetMaintenanceMode(ctx context.Context, args *struct {
+	Enabled bool
+}) (*EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may pause background writers.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+	if !canUpdateSiteConfiguration() {
+		return nil, errors.New("updating site configuration not allowed when using SITE_CONFIG_FILE")
+	}
+	if !args.Enabled && conf.MaintenanceModeEnabledByEnv() {
+		return nil, errors.New("maintenance mode is enabled by the SRC_MAINTENANCE_MODE environment variable and can't be disabled")
+	}
+
+	err := globals.ConfigurationServerFrontendOnly.Edit(ctx, func(_ *conf.Unified, raw conftypes.RawUnified) (conf.Edits, error) {
+		edits, _, err := jsonx.ComputePropertyEdit(raw.Site, jsonx.PropertyPath("maintenanceMode"), args.Enabled, nil, conf.FormatOptions)
+		return conf.Edits{Site: edits}, err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &EmptyResponse{}, nil
+}
+
 var siteConfigAllowEdits, _ = strconv.ParseBool(env.Get("SITE_CONFIG_ALLOW_EDITS", "false", "When SITE_CONFIG_FILE is in use, allow edits in the application to be made which will be overwritten on next process restart"))
 
 func canUpdateSiteConfiguration() bool {
@@ -193,6 +193,56 @@ func (r *schemaResolver) SetUserIsSiteAdmin(ctx context.Context, args *struct {
 	return &EmptyResponse{}, nil
 }
 
+func (r *schemaResolver) SetUserIsSiteAuditor(ctx context.Context, args *struct {
+	UserID      graphql.ID
+	SiteAuditor bool
+}) (response *EmptyResponse, err error) {
+	// 🚨 SECURITY: Only site admins can grant (or revoke) the site auditor role, which gives
+	// read-only access to site administration data.
+
+	eventArgs := roleChangeEventArgs{
+		From: "role_user",
+		To:   "role_site_auditor",
+	}
+	if !args.SiteAuditor {
+		eventArgs.From = "role_site_auditor"
+		eventArgs.To = "role_user"
+	}
+
+	affectedUserID, err := UnmarshalUserID(args.UserID)
+	if err != nil {
+		return nil, err
+	}
+	eventArgs.For = affectedUserID
+
+	userResolver, err := CurrentUser(ctx, r.db)
+	if err != nil {
+		return nil, err
+	}
+	if userResolver == nil {
+		return nil, backend.ErrNotAuthenticated
+	}
+	eventArgs.By = userResolver.user.ID
+
+	eventName := database.SecurityEventNameRoleChangeDenied
+	defer logRoleChangeAttempt(ctx, r.db, &eventName, &eventArgs, &err)
+
+	if err = backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	if userResolver.ID() == args.UserID {
+		return nil, errors.New("refusing to set current user site auditor status")
+	}
+
+	if err = database.Users(r.db).SetIsSiteAuditor(ctx, affectedUserID, args.SiteAuditor); err != nil {
+		return nil, err
+	}
+
+	eventName = database.SecurityEventNameRoleChangeGranted
+	return &EmptyResponse{}, nil
+}
+
 func (r *schemaResolver) InvalidateSessionsByID(ctx context.Context, args *struct {
 	UserID graphql.ID
 }) (*EmptyResponse, error) {
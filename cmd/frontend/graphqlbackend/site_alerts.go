@@ -18,6 +18,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/versions"
 	srcprometheus "github.com/sourcegraph/sourcegraph/internal/src-prometheus"
 	"github.com/sourcegraph/sourcegraph/internal/version"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -109,6 +110,12 @@ func init() {
 	// Notify about postgres deprecation
 	AlertFuncs = append(AlertFuncs, deprecationAlert)
 
+	// Remind site admins that background writers are paused.
+	AlertFuncs = append(AlertFuncs, maintenanceModeAlert)
+
+	// Warn site admins about code hosts running versions that are no longer supported.
+	AlertFuncs = append(AlertFuncs, codeHostVersionAlert)
+
 	// Notify admins if critical alerts are firing, if Prometheus is configured.
 	prom, err := srcprometheus.NewClient(srcprometheus.PrometheusURL)
 	if err == nil {
@@ -250,6 +257,17 @@ func deprecationAlert(args AlertFuncArgs) []*Alert {
 	return nil
 }
 
+func maintenanceModeAlert(args AlertFuncArgs) []*Alert {
+	if !args.IsSiteAdmin || !conf.MaintenanceModeEnabled() {
+		return nil
+	}
+
+	return []*Alert{{
+		TypeValue:    AlertTypeWarning,
+		MessageValue: "Sourcegraph is in maintenance mode: repository syncing, batch change reconciliation and code intelligence processing are paused. Disable `maintenanceMode` in the [**site configuration**](/site-admin/configuration) when maintenance is complete.",
+	}}
+}
+
 func determineOutOfDateAlert(isAdmin bool, months int, offline bool) *Alert {
 	if months <= 0 {
 		return nil
@@ -336,3 +354,21 @@ func pluralize(v int, singular, plural string) string {
 	}
 	return fmt.Sprintf("%d %s", v, plural)
 }
+
+func codeHostVersionAlert(args AlertFuncArgs) []*Alert {
+	if !args.IsSiteAdmin {
+		return nil
+	}
+
+	warnings, err := versions.GetWarnings()
+	if err != nil {
+		log15.Warn("failed to get code host version warnings", "error", err)
+		return nil
+	}
+
+	alerts := make([]*Alert, 0, len(warnings))
+	for _, w := range warnings {
+		alerts = append(alerts, &Alert{TypeValue: AlertTypeWarning, MessageValue: w.Message()})
+	}
+	return alerts
+}
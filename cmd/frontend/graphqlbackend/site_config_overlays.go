@@ -0,0 +1,103 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/confdb"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+)
+
+type siteConfigurationOverlayResolver struct {
+	overlay *confdb.SiteOverlay
+
+	// contents is the overlay contents to show, which has secrets redacted for non-admins.
+	contents string
+}
+
+func (r *siteConfigurationOverlayResolver) Name() string { return r.overlay.Name }
+
+func (r *siteConfigurationOverlayResolver) Position() int32 { return r.overlay.Position }
+
+func (r *siteConfigurationOverlayResolver) Contents() JSONCString {
+	return JSONCString(r.contents)
+}
+
+func (r *siteConfigurationOverlayResolver) CreatedAt() DateTime {
+	return DateTime{Time: r.overlay.CreatedAt}
+}
+
+func (r *siteConfigurationOverlayResolver) UpdatedAt() DateTime {
+	return DateTime{Time: r.overlay.UpdatedAt}
+}
+
+func (r *siteConfigurationResolver) Overlays(ctx context.Context) ([]*siteConfigurationOverlayResolver, error) {
+	// 🚨 SECURITY: The site configuration contains secret tokens and credentials,
+	// so only admins may view it as is, and auditors only with secrets redacted.
+	if err := backend.CheckCurrentUserIsSiteAuditor(ctx, r.db); err != nil {
+		return nil, err
+	}
+	overlays, err := confdb.SiteListOverlays(ctx)
+	if err != nil {
+		return nil, err
+	}
+	resolvers := make([]*siteConfigurationOverlayResolver, 0, len(overlays))
+	for _, overlay := range overlays {
+		contents, err := r.maybeRedactSecrets(ctx, overlay.Contents)
+		if err != nil {
+			return nil, err
+		}
+		resolvers = append(resolvers, &siteConfigurationOverlayResolver{overlay: overlay, contents: string(contents)})
+	}
+	return resolvers, nil
+}
+
+func (r *schemaResolver) SaveSiteConfigurationOverlay(ctx context.Context, args *struct {
+	Name     string
+	Position int32
+	Contents string
+}) (*siteConfigurationOverlayResolver, error) {
+	// 🚨 SECURITY: The site configuration contains secret tokens and credentials,
+	// so only admins may modify it.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+	if !canUpdateSiteConfiguration() {
+		return nil, errors.New("updating site configuration overlays not allowed when using SITE_CONFIG_FILE")
+	}
+
+	// Check that the overlay parses into the site configuration before saving it.
+	if _, err := conf.ParseConfig(conftypes.RawUnified{SiteOverlays: []string{args.Contents}}); err != nil {
+		return nil, errors.Errorf("site configuration overlay is invalid: %s", err)
+	}
+
+	overlay, err := confdb.SiteUpsertOverlay(ctx, args.Name, args.Position, args.Contents)
+	if err != nil {
+		return nil, err
+	}
+	globals.ConfigurationServerFrontendOnly.Reload()
+	return &siteConfigurationOverlayResolver{overlay: overlay, contents: overlay.Contents}, nil
+}
+
+func (r *schemaResolver) DeleteSiteConfigurationOverlay(ctx context.Context, args *struct {
+	Name string
+}) (*EmptyResponse, error) {
+	// 🚨 SECURITY: The site configuration contains secret tokens and credentials,
+	// so only admins may modify it.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+	if !canUpdateSiteConfiguration() {
+		return nil, errors.New("updating site configuration overlays not allowed when using SITE_CONFIG_FILE")
+	}
+
+	if err := confdb.SiteDeleteOverlay(ctx, args.Name); err != nil {
+		return nil, err
+	}
+	globals.ConfigurationServerFrontendOnly.Reload()
+	return &EmptyResponse{}, nil
+}
@@ -0,0 +1,70 @@
+package graphqlbackend
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/healthreport"
+)
+
+func (r *siteResolver) HealthReport(ctx context.Context) (*healthReportResolver, error) {
+	// 🚨 SECURITY: Only site admins and auditors may see the health of the services,
+	// which includes their addresses and errors.
+	if err := backend.CheckCurrentUserIsSiteAuditor(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	return &healthReportResolver{report: healthreport.Generate(ctx, r.db)}, nil
+}
+
+type healthReportResolver struct {
+	report *healthreport.Report
+}
+
+func (r *healthReportResolver) GeneratedAt() DateTime {
+	return DateTime{Time: r.report.GeneratedAt}
+}
+
+func (r *healthReportResolver) Services() []*serviceHealthResolver {
+	resolvers := make([]*serviceHealthResolver, 0, len(r.report.Services))
+	for _, s := range r.report.Services {
+		resolvers = append(resolvers, &serviceHealthResolver{service: s})
+	}
+	return resolvers
+}
+
+func (r *healthReportResolver) JSON() (string, error) {
+	b, err := json.MarshalIndent(r.report, "", "  ")
+	return string(b), err
+}
+
+type serviceHealthResolver struct {
+	service *healthreport.Service
+}
+
+func (r *serviceHealthResolver) Name() string { return r.service.Name }
+
+func (r *serviceHealthResolver) Instance() *string { return nonEmptyStrptr(r.service.Instance) }
+
+func (r *serviceHealthResolver) Version() *string { return nonEmptyStrptr(r.service.Version) }
+
+func (r *serviceHealthResolver) Reachable() bool { return r.service.Reachable }
+
+func (r *serviceHealthResolver) Error() *string { return nonEmptyStrptr(r.service.Error) }
+
+func (r *serviceHealthResolver) Metrics() []*healthMetricResolver {
+	resolvers := make([]*healthMetricResolver, 0, len(r.service.Metrics))
+	for _, m := range r.service.Metrics {
+		resolvers = append(resolvers, &healthMetricResolver{metric: m})
+	}
+	return resolvers
+}
+
+type healthMetricResolver struct {
+	metric healthreport.Metric
+}
+
+func (r *healthMetricResolver) Name() string { return r.metric.Name }
+
+func (r *healthMetricResolver) Value() float64 { return r.metric.Value }
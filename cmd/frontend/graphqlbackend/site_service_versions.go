@@ -0,0 +1,50 @@
+package graphqlbackend
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/version"
+	"github.com/sourcegraph/sourcegraph/internal/version/skew"
+)
+
+func (r *siteResolver) ServiceVersions(ctx context.Context) ([]*serviceVersionResolver, error) {
+	// 🚨 SECURITY: Only site admins may see the hostnames of the services.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	instances, err := database.ServiceInstances(r.db).ListActive(ctx, skew.ActiveWithin)
+	if err != nil {
+		return nil, err
+	}
+
+	skewed := make(map[*database.ServiceInstance]bool)
+	for _, i := range skew.Skewed(version.Version(), instances) {
+		skewed[i] = true
+	}
+
+	resolvers := make([]*serviceVersionResolver, 0, len(instances))
+	for _, i := range instances {
+		resolvers = append(resolvers, &serviceVersionResolver{instance: i, skewed: skewed[i]})
+	}
+	return resolvers, nil
+}
+
+type serviceVersionResolver struct {
+	instance *database.ServiceInstance
+	skewed   bool
+}
+
+func (r *serviceVersionResolver) Service() string { return r.instance.Service }
+
+func (r *serviceVersionResolver) Instance() string { return r.instance.Instance }
+
+func (r *serviceVersionResolver) Version() string { return r.instance.Version }
+
+func (r *serviceVersionResolver) StartedAt() DateTime { return DateTime{Time: r.instance.StartedAt} }
+
+func (r *serviceVersionResolver) UpdatedAt() DateTime { return DateTime{Time: r.instance.UpdatedAt} }
+
+func (r *serviceVersionResolver) Skewed() bool { return r.skewed }
@@ -0,0 +1,112 @@
+package graphqlbackend
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+type usageRollupsArgs struct {
+	Period  string
+	Periods int32
+}
+
+// usageRollupPeriods maps the values of the UsageRollupPeriod GraphQL enum to period types.
+var usageRollupPeriods = map[string]database.PeriodType{
+	"DAILY":  database.Daily,
+	"WEEKLY": database.Weekly,
+}
+
+func (args *usageRollupsArgs) parse() (database.PeriodType, int, error) {
+	period, ok := usageRollupPeriods[args.Period]
+	if !ok {
+		return "", 0, errors.Errorf("invalid usage rollup period %q", args.Period)
+	}
+	if args.Periods < 1 {
+		return "", 0, errors.New("periods must be positive")
+	}
+	return period, int(args.Periods), nil
+}
+
+func (r *siteResolver) ActiveUserRollups(ctx context.Context, args *usageRollupsArgs) ([]*activeUserRollupResolver, error) {
+	// 🚨 SECURITY: Only site admins and site auditors may view usage rollups.
+	if err := backend.CheckCurrentUserIsSiteAuditor(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	period, periods, err := args.parse()
+	if err != nil {
+		return nil, err
+	}
+
+	rollups, err := database.UsageRollups(r.db).ListActiveUsers(ctx, period, time.Now().UTC(), periods)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*activeUserRollupResolver, 0, len(rollups))
+	for _, rollup := range rollups {
+		resolvers = append(resolvers, &activeUserRollupResolver{rollup: rollup})
+	}
+	return resolvers, nil
+}
+
+func (r *siteResolver) EventRollups(ctx context.Context, args *struct {
+	usageRollupsArgs
+	EventNames *[]string
+}) ([]*eventRollupResolver, error) {
+	// 🚨 SECURITY: Only site admins and site auditors may view usage rollups.
+	if err := backend.CheckCurrentUserIsSiteAuditor(ctx, r.db); err != nil {
+		return nil, err
+	}
+
+	period, periods, err := args.parse()
+	if err != nil {
+		return nil, err
+	}
+	var eventNames []string
+	if args.EventNames != nil {
+		eventNames = *args.EventNames
+	}
+
+	rollups, err := database.UsageRollups(r.db).ListEvents(ctx, period, time.Now().UTC(), periods, eventNames)
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]*eventRollupResolver, 0, len(rollups))
+	for _, rollup := range rollups {
+		resolvers = append(resolvers, &eventRollupResolver{rollup: rollup})
+	}
+	return resolvers, nil
+}
+
+type activeUserRollupResolver struct {
+	rollup *database.ActiveUserRollup
+}
+
+func (r *activeUserRollupResolver) StartTime() DateTime {
+	return DateTime{Time: r.rollup.PeriodStart}
+}
+
+func (r *activeUserRollupResolver) ActiveUsers() int32 { return int32(r.rollup.ActiveUsers) }
+
+func (r *activeUserRollupResolver) RegisteredUsers() int32 { return int32(r.rollup.RegisteredUsers) }
+
+type eventRollupResolver struct {
+	rollup *database.EventRollup
+}
+
+func (r *eventRollupResolver) StartTime() DateTime {
+	return DateTime{Time: r.rollup.PeriodStart}
+}
+
+func (r *eventRollupResolver) EventName() string { return r.rollup.EventName }
+
+func (r *eventRollupResolver) Events() int32 { return int32(r.rollup.Events) }
+
+func (r *eventRollupResolver) Users() int32 { return int32(r.rollup.Users) }
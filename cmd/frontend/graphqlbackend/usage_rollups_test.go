@@ -0,0 +1,61 @@
+package graphqlbackend
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestUsageRollups_NonAuditor(t *testing.T) {
+	resetMocks()
+	database.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+		return &types.User{}, nil
+	}
+	defer resetMocks()
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	r := &siteResolver{db: new(dbtesting.MockDB)}
+	args := usageRollupsArgs{Period: "DAILY", Periods: 30}
+
+	if _, err := r.ActiveUserRollups(ctx, &args); err != backend.ErrMustBeSiteAuditor {
+		t.Errorf("ActiveUserRollups: got err %v, want %v", err, backend.ErrMustBeSiteAuditor)
+	}
+	if _, err := r.EventRollups(ctx, &struct {
+		usageRollupsArgs
+		EventNames *[]string
+	}{usageRollupsArgs: args}); err != backend.ErrMustBeSiteAuditor {
+		t.Errorf("EventRollups: got err %v, want %v", err, backend.ErrMustBeSiteAuditor)
+	}
+}
+
+func TestUsageRollupsArgs_parse(t *testing.T) {
+	for _, tc := range []struct {
+		args       usageRollupsArgs
+		wantPeriod database.PeriodType
+		wantErr    bool
+	}{
+		{args: usageRollupsArgs{Period: "DAILY", Periods: 7}, wantPeriod: database.Daily},
+		{args: usageRollupsArgs{Period: "WEEKLY", Periods: 4}, wantPeriod: database.Weekly},
+		{args: usageRollupsArgs{Period: "MONTHLY", Periods: 4}, wantErr: true},
+		{args: usageRollupsArgs{Period: "DAILY", Periods: 0}, wantErr: true},
+	} {
+		period, periods, err := tc.args.parse()
+		if tc.wantErr {
+			if err == nil {
+				t.Errorf("%+v: expected error", tc.args)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("%+v: unexpected error: %s", tc.args, err)
+		}
+		if period != tc.wantPeriod || periods != int(tc.args.Periods) {
+			t.Errorf("%+v: got (%s, %d)", tc.args, period, periods)
+		}
+	}
+}
@@ -181,6 +181,15 @@ func (r *UserResolver) SiteAdmin(ctx context.Context) (bool, error) {
 	return r.user.SiteAdmin, nil
 }
 
+func (r *UserResolver) SiteAuditor(ctx context.Context) (bool, error) {
+	// 🚨 SECURITY: Only the user and admins are allowed to determine if the user is a site auditor.
+	if err := backend.CheckSiteAdminOrSameUser(ctx, r.db, r.user.ID); err != nil {
+		return false, err
+	}
+
+	return r.user.SiteAuditor, nil
+}
+
 type updateUserArgs struct {
 	User        graphql.ID
 	Username    *string
@@ -5,6 +5,7 @@ import (
 
 	"github.com/NYTimes/gziphandler"
 
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/auth/userpasswd"
 	registry "github.com/sourcegraph/sourcegraph/cmd/frontend/registry/api"
@@ -22,7 +23,7 @@ import (
 //
 // 🚨 SECURITY: The caller MUST wrap the returned handler in middleware that checks authentication
 // and sets the actor in the request context.
-func NewHandler(db dbutil.DB) http.Handler {
+func NewHandler(db dbutil.DB, codeIntelCoverage enterprise.CodeIntelCoverage) http.Handler {
 	session.SetSessionStore(session.NewRedisStore(func() bool {
 		return globals.ExternalURL().Scheme == "https"
 	}))
@@ -38,6 +39,8 @@ func NewHandler(db dbutil.DB) http.Handler {
 	r.Get(router.OpenSearch).Handler(trace.Route(http.HandlerFunc(openSearch)))
 
 	r.Get(router.RepoBadge).Handler(trace.Route(errorutil.Handler(serveRepoBadge)))
+	r.Get(router.RepoSearchBadge).Handler(trace.Route(errorutil.Handler(serveRepoSearchBadge(db))))
+	r.Get(router.RepoCodeIntelBadge).Handler(trace.Route(errorutil.Handler(serveRepoCodeIntelBadge(codeIntelCoverage))))
 
 	// Redirects
 	r.Get(router.OldToolsRedirect).Handler(trace.Route(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
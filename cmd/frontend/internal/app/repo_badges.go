@@ -0,0 +1,259 @@
+package app
+
+import (
+	"context"
+	"fmt"
+	"html"
+	"net/http"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gorilla/mux"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/routevar"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// badgeCache caches the values of repository badges, so that READMEs which are
+// viewed often don't run a search for each view. Values are only read after
+// checking that the viewer can access the repository.
+var badgeCache = rcache.NewWithTTL("repo-badge", 600)
+
+const (
+	badgeColorBlue  = "#007ec6"
+	badgeColorGreen = "#4c1"
+	badgeColorGrey  = "#9f9f9f"
+)
+
+// serveRepoSearchBadge serves a badge with the number of matches of a search
+// query in a repository, e.g. `/github.com/foo/bar/-/badge/search.svg?q=TODO`.
+func serveRepoSearchBadge(db dbutil.DB) func(http.ResponseWriter, *http.Request) error {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		q := r.URL.Query().Get("q")
+		if q == "" {
+			return &errcode.HTTPErr{Status: http.StatusBadRequest, Err: errors.New("missing query parameter q")}
+		}
+		patternType := r.URL.Query().Get("patternType")
+		if patternType == "" {
+			patternType = "literal"
+		}
+		label := r.URL.Query().Get("label")
+		if label == "" {
+			label = q
+		}
+
+		searchType, err := badgeSearchType(patternType)
+		if err != nil {
+			return &errcode.HTTPErr{Status: http.StatusBadRequest, Err: err}
+		}
+		hasParameters, err := validateBadgeQuery(q, searchType)
+		if err != nil {
+			return &errcode.HTTPErr{Status: http.StatusBadRequest, Err: err}
+		}
+
+		repo, err := badgeRepo(r)
+		if err != nil {
+			return err
+		}
+
+		// The count depends on what the viewer can see, e.g. through
+		// sub-repository permissions, so values are cached per viewer.
+		uid := actor.FromContext(r.Context()).UID
+		key := fmt.Sprintf("search:%d:%d:%s:%s", repo.ID, uid, patternType, q)
+		value, err := cachedBadgeValue(key, func() (string, error) {
+			return searchBadgeValue(r.Context(), db, repo, q, patternType, hasParameters)
+		})
+		if err != nil {
+			return err
+		}
+		return writeBadge(w, label, value, badgeColorBlue)
+	}
+}
+
+// badgeSearchType returns the search type of the patternType parameter of a
+// search badge.
+func badgeSearchType(patternType string) (query.SearchType, error) {
+	switch patternType {
+	case "literal":
+		return query.SearchTypeLiteral, nil
+	case "regexp":
+		return query.SearchTypeRegex, nil
+	case "structural":
+		return query.SearchTypeStructural, nil
+	}
+	return -1, errors.Errorf("unrecognized patternType %q", patternType)
+}
+
+// validateBadgeQuery returns an error if q could search outside of the
+// repository of the badge, i.e. if it selects repositories itself or has a
+// top-level or. It reports whether q has any parameters.
+func validateBadgeQuery(q string, searchType query.SearchType) (hasParameters bool, err error) {
+	nodes, err := query.Parse(q, searchType)
+	if err != nil {
+		return false, err
+	}
+	nodes = query.SubstituteAliases(searchType)(query.LowercaseFieldNames(nodes))
+
+	query.VisitParameter(nodes, func(field, _ string, _ bool, _ query.Annotation) {
+		hasParameters = true
+		switch field {
+		case query.FieldRepo, query.FieldRepoGroup, query.FieldContext:
+			if err == nil {
+				err = errors.Errorf("badge queries can't contain %s: filters", field)
+			}
+		}
+	})
+	if err != nil {
+		return false, err
+	}
+
+	for _, node := range nodes {
+		if operator, ok := node.(query.Operator); ok && operator.Kind == query.Or {
+			return false, errors.New("badge queries can't contain a top-level or")
+		}
+	}
+	return hasParameters, nil
+}
+
+func searchBadgeValue(ctx context.Context, db dbutil.DB, repo *types.Repo, q, patternType string, hasParameters bool) (string, error) {
+	// Queries with parameters are parenthesized, so that they're evaluated
+	// within the repository. Queries with only patterns are not, as
+	// parentheses around patterns are part of a literal pattern.
+	if hasParameters {
+		q = "(" + q + ")"
+	}
+	searchQuery := fmt.Sprintf("repo:^%s$ %s", regexp.QuoteMeta(string(repo.Name)), q)
+	// Badges count all matches, unless the query limits them.
+	if !strings.Contains(q, "count:") {
+		searchQuery += " count:all"
+	}
+	search, err := graphqlbackend.NewSearchImplementer(ctx, db, &graphqlbackend.SearchArgs{
+		Version:     "V2",
+		PatternType: &patternType,
+		Query:       searchQuery,
+	})
+	if err != nil {
+		return "", err
+	}
+	results, err := search.Results(ctx)
+	if err != nil {
+		return "", err
+	}
+
+	value := badgeCountFmt(int(results.MatchCount()))
+	if results.LimitHit() {
+		value += "+"
+	}
+	return value, nil
+}
+
+// serveRepoCodeIntelBadge serves a badge which shows whether a repository has
+// precise code intelligence, and from which indexers.
+func serveRepoCodeIntelBadge(coverage enterprise.CodeIntelCoverage) func(http.ResponseWriter, *http.Request) error {
+	return func(w http.ResponseWriter, r *http.Request) error {
+		repo, err := badgeRepo(r)
+		if err != nil {
+			return err
+		}
+
+		value, err := cachedBadgeValue(fmt.Sprintf("code-intel:%d", repo.ID), func() (string, error) {
+			indexers, err := coverage(r.Context(), repo.ID)
+			if err != nil {
+				return "", err
+			}
+			if len(indexers) == 0 {
+				return "search-based", nil
+			}
+			return "precise (" + strings.Join(indexers, ", ") + ")", nil
+		})
+		if err != nil {
+			return err
+		}
+
+		color := badgeColorGreen
+		if value == "search-based" {
+			color = badgeColorGrey
+		}
+		return writeBadge(w, "code intel", value, color)
+	}
+}
+
+// badgeRepo returns the repository of the request. It returns an error if
+// the repository does not exist or the viewer can't access it.
+func badgeRepo(r *http.Request) (*types.Repo, error) {
+	return backend.Repos.GetByName(r.Context(), routevar.ToRepo(mux.Vars(r)))
+}
+
+func cachedBadgeValue(key string, compute func() (string, error)) (string, error) {
+	if b, ok := badgeCache.Get(key); ok {
+		return string(b), nil
+	}
+	value, err := compute()
+	if err != nil {
+		return "", err
+	}
+	badgeCache.Set(key, []byte(value))
+	return value, nil
+}
+
+// badgeCountFmt formats e.g. 1399 as "1.4k".
+func badgeCountFmt(n int) string {
+	if n >= 1000 {
+		return fmt.Sprintf("%.1fk", float64(n)/1000.0)
+	}
+	return strconv.Itoa(n)
+}
+
+// badgeTextWidth estimates the width in pixels of s in the 11px font of
+// badges. It only needs to be roughly right, as the text is centered.
+func badgeTextWidth(s string) int {
+	return utf8.RuneCountInString(s)*7 + 10
+}
+
+const badgeSVGFmt = `<svg xmlns="http://www.w3.org/2000/svg" width="%[1]d" height="20" role="img" aria-label="%[3]s: %[4]s">
+<title>%[3]s: %[4]s</title>
+<linearGradient id="s" x2="0" y2="100%%"><stop offset="0" stop-color="#bbb" stop-opacity=".1"/><stop offset="1" stop-opacity=".1"/></linearGradient>
+<clipPath id="r"><rect width="%[1]d" height="20" rx="3" fill="#fff"/></clipPath>
+<g clip-path="url(#r)"><rect width="%[2]d" height="20" fill="#555"/><rect x="%[2]d" width="%[5]d" height="20" fill="%[6]s"/><rect width="%[1]d" height="20" fill="url(#s)"/></g>
+<g fill="#fff" text-anchor="middle" font-family="Verdana,Geneva,DejaVu Sans,sans-serif" font-size="11">
+<text x="%[7]d" y="14">%[3]s</text>
+<text x="%[8]d" y="14">%[4]s</text>
+</g>
+</svg>
+`
+
+// renderBadge renders a badge in the style of shields.io, with label on the
+// left and value on a background of the given color on the right.
+func renderBadge(label, value, color string) []byte {
+	labelWidth, valueWidth := badgeTextWidth(label), badgeTextWidth(value)
+	return []byte(fmt.Sprintf(badgeSVGFmt,
+		labelWidth+valueWidth,
+		labelWidth,
+		html.EscapeString(label),
+		html.EscapeString(value),
+		valueWidth,
+		color,
+		labelWidth/2,
+		labelWidth+valueWidth/2,
+	))
+}
+
+func writeBadge(w http.ResponseWriter, label, value, color string) error {
+	w.Header().Set("Content-Type", "image/svg+xml")
+	// Badges depend on the viewer's permissions, so they must not be cached
+	// by shared caches.
+	w.Header().Set("Cache-Control", "private, max-age=300")
+	_, err := w.Write(renderBadge(label, value, color))
+	return err
+}
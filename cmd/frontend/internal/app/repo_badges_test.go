@@ -0,0 +1,112 @@
+package app
+
+import (
+	"context"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestServeRepoCodeIntelBadge(t *testing.T) {
+	rcache.SetupForTest(t)
+
+	backend.Mocks.Repos.GetByName = func(ctx context.Context, name api.RepoName) (*types.Repo, error) {
+		return &types.Repo{ID: 1, Name: name}, nil
+	}
+	defer func() { backend.Mocks.Repos = backend.MockRepos{} }()
+
+	calls := 0
+	coverage := func(ctx context.Context, repoID api.RepoID) ([]string, error) {
+		calls++
+		if repoID != 1 {
+			t.Errorf("got repo ID %d, want 1", repoID)
+		}
+		return []string{"lsif-go", "lsif-node"}, nil
+	}
+
+	for i := 0; i < 2; i++ {
+		req := httptest.NewRequest("GET", "/github.com/foo/bar/-/badge/code-intel.svg", nil)
+		req = mux.SetURLVars(req, map[string]string{"Repo": "github.com/foo/bar"})
+		rec := httptest.NewRecorder()
+		if err := serveRepoCodeIntelBadge(coverage)(rec, req); err != nil {
+			t.Fatal(err)
+		}
+
+		if ct := rec.Header().Get("Content-Type"); ct != "image/svg+xml" {
+			t.Errorf("got content type %q", ct)
+		}
+		if body := rec.Body.String(); !strings.Contains(body, "precise (lsif-go, lsif-node)") {
+			t.Errorf("badge does not contain the indexers:\n%s", body)
+		}
+	}
+
+	// The second badge is served from the cache.
+	if calls != 1 {
+		t.Errorf("got %d calls, want 1", calls)
+	}
+}
+
+func TestRenderBadge(t *testing.T) {
+	svg := string(renderBadge("<TODO>", "1.4k", badgeColorBlue))
+	if !strings.Contains(svg, "&lt;TODO&gt;") {
+		t.Errorf("label is not escaped:\n%s", svg)
+	}
+	if !strings.Contains(svg, `fill="#007ec6"`) {
+		t.Errorf("badge does not have the value color:\n%s", svg)
+	}
+}
+
+func TestBadgeCountFmt(t *testing.T) {
+	for n, want := range map[int]string{
+		0:    "0",
+		999:  "999",
+		1399: "1.4k",
+	} {
+		if got := badgeCountFmt(n); got != want {
+			t.Errorf("badgeCountFmt(%d) = %q, want %q", n, got, want)
+		}
+	}
+}
+
+func TestValidateBadgeQuery(t *testing.T) {
+	for q, wantParameters := range map[string]bool{
+		"TODO":               false,
+		"(TODO)":             false,
+		"TODO lang:go":       true,
+		"foo or bar lang:go": true,
+		"TODO count:10":      true,
+	} {
+		hasParameters, err := validateBadgeQuery(q, query.SearchTypeLiteral)
+		if err != nil {
+			t.Errorf("validateBadgeQuery(%q) returned an error: %s", q, err)
+		}
+		if hasParameters != wantParameters {
+			t.Errorf("validateBadgeQuery(%q) = %v, want %v", q, hasParameters, wantParameters)
+		}
+	}
+
+	for _, q := range []string{
+		"repo:foo TODO",
+		"r:foo TODO",
+		"-repo:foo TODO",
+		"REPO:foo TODO",
+		"repogroup:foo TODO",
+		"g:foo TODO",
+		"context:global TODO",
+		"TODO (lang:go or repo:foo)",
+		"TODO or lang:go",
+		"(TODO lang:go) or (FIXME lang:go)",
+	} {
+		if _, err := validateBadgeQuery(q, query.SearchTypeLiteral); err == nil {
+			t.Errorf("validateBadgeQuery(%q) did not return an error", q)
+		}
+	}
+}
@@ -17,7 +17,9 @@ const (
 
 	OpenSearch = "opensearch"
 
-	RepoBadge = "repo.badge"
+	RepoBadge          = "repo.badge"
+	RepoSearchBadge    = "repo.badge.search"
+	RepoCodeIntelBadge = "repo.badge.code-intel"
 
 	Logout = "logout"
 
@@ -102,6 +104,8 @@ func newRouter() *mux.Router {
 	repoPath := `/` + routevar.Repo
 	repo := base.PathPrefix(repoPath + "/" + routevar.RepoPathDelim + "/").Subrouter()
 	repo.Path("/badge.svg").Methods("GET").Name(RepoBadge)
+	repo.Path("/badge/search.svg").Methods("GET").Name(RepoSearchBadge)
+	repo.Path("/badge/code-intel.svg").Methods("GET").Name(RepoCodeIntelBadge)
 
 	// Must come last
 	base.PathPrefix("/").Name(UI)
@@ -20,7 +20,10 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/handlerutil"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/vfsutil"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
@@ -143,8 +146,40 @@ func serveRaw(w http.ResponseWriter, r *http.Request) (err error) {
 		metricRawDuration.WithLabelValues(contentType, requestType, errorS).Observe(duration.Seconds())
 	}()
 
+	// The response only depends on the resolved commit, the path and the content type, so
+	// clients can revalidate it cheaply. The response for a commit ID never changes.
+	notModified := func() bool {
+		if common.CommitID == "" {
+			return false
+		}
+		cacheControl := "private, no-cache"
+		if strings.TrimPrefix(common.Rev, "@") == string(common.CommitID) {
+			cacheControl = "private, max-age=86400, immutable"
+		}
+		w.Header().Set("Cache-Control", cacheControl)
+		return handlerutil.NotModified(w, r, fmt.Sprintf(`W/"%s-%s"`, common.CommitID, path.Base(contentType)))
+	}
+
 	switch contentType {
 	case applicationZip, applicationXTar:
+		// 🚨 SECURITY: Archives can't be filtered by path, so we refuse to serve
+		// them to users that may only view part of the repository.
+		if a := actor.FromContext(r.Context()); !a.Internal {
+			restricted, err := authz.DefaultSubRepoPermsChecker.EnabledForRepo(r.Context(), a.UID, common.Repo.Name)
+			if err != nil {
+				return err
+			}
+			if restricted {
+				requestType = "404"
+				http.Error(w, "archives are not available for this repository", http.StatusNotFound)
+				return nil // request handled
+			}
+		}
+		if notModified() {
+			requestType = "304"
+			return nil // request handled
+		}
+
 		// Set the proper filename field, so that downloading "/github.com/gorilla/mux/-/raw" gives us a
 		// "mux.zip" file (e.g. when downloading via a browser) or a .tar file depending on the contentType.
 		ext := ".zip"
@@ -240,6 +275,10 @@ func serveRaw(w http.ResponseWriter, r *http.Request) (err error) {
 			}
 			return err
 		}
+		if notModified() {
+			requestType = "304"
+			return nil // request handled
+		}
 
 		if fi.IsDir() {
 			requestType = "dir"
@@ -137,6 +137,8 @@ func Test_serveRawWithContentArchive(t *testing.T) {
 		expectedHeaders := map[string]string{
 			"X-Content-Type-Options": "nosniff",
 			"Content-Type":           "application/zip",
+			"Cache-Control":          "private, no-cache",
+			"ETag":                   `W/"12345-zip"`,
 			"Content-Disposition":    mime.FormatMediaType("Attachment", map[string]string{"filename": "test.zip"}),
 		}
 
@@ -176,6 +178,8 @@ func Test_serveRawWithContentArchive(t *testing.T) {
 		expectedHeaders := map[string]string{
 			"X-Content-Type-Options": "nosniff",
 			"Content-Type":           "application/x-tar",
+			"Cache-Control":          "private, no-cache",
+			"ETag":                   `W/"12345-x-tar"`,
 			"Content-Disposition":    mime.FormatMediaType("Attachment", map[string]string{"filename": "test.tar"}),
 		}
 
@@ -210,13 +214,17 @@ func Test_serveRawWithContentTypePlain(t *testing.T) {
 		mockNewCommon = nil
 	}()
 
-	assertHeaders := func(w http.ResponseWriter) {
+	assertHeaders := func(w http.ResponseWriter, cacheable bool) {
 		t.Helper()
 
 		expectedHeaders := map[string]string{
 			"X-Content-Type-Options": "nosniff",
 			"Content-Type":           "text/plain; charset=utf-8",
 		}
+		if cacheable {
+			expectedHeaders["Cache-Control"] = "private, no-cache"
+			expectedHeaders["ETag"] = `W/"12345-plain"`
+		}
 
 		if len(w.Header()) != len(expectedHeaders) {
 			t.Errorf("Want %d headers but got %d headers", len(w.Header()), len(expectedHeaders))
@@ -250,7 +258,7 @@ func Test_serveRawWithContentTypePlain(t *testing.T) {
 			t.Fatalf("Want %d but got %d", http.StatusOK, w.Code)
 		}
 
-		assertHeaders(w)
+		assertHeaders(w, false)
 	})
 
 	t.Run("success response for existing directory", func(t *testing.T) {
@@ -283,7 +291,7 @@ func Test_serveRawWithContentTypePlain(t *testing.T) {
 			t.Fatalf("Want %d but got %d", http.StatusOK, w.Code)
 		}
 
-		assertHeaders(w)
+		assertHeaders(w, true)
 
 		want := `a/
 b/
@@ -320,7 +328,7 @@ c.go`
 			t.Fatalf("Want %d but got %d", http.StatusOK, w.Code)
 		}
 
-		assertHeaders(w)
+		assertHeaders(w, true)
 
 		want := "this is a test file"
 
@@ -357,7 +365,7 @@ c.go`
 			t.Fatalf("Want %d but got %d", http.StatusOK, w.Code)
 		}
 
-		assertHeaders(w)
+		assertHeaders(w, true)
 
 		want := "this is a test file"
 
@@ -366,4 +374,37 @@ c.go`
 			t.Errorf("Want %q in body, but got %q", want, body)
 		}
 	})
+
+	t.Run("not modified response for matching ETag", func(t *testing.T) {
+		// httptest server will return a 200 OK, so gitserver.DefaultClient.RepoInfo will not return an error.
+		initHTTPTestGitServer(t, http.StatusOK, "{}")
+
+		git.Mocks.Stat = func(commit api.CommitID, name string) (fs.FileInfo, error) {
+			return &util.FileInfo{Mode_: 0}, nil
+		}
+
+		git.Mocks.NewFileReader = func(commit api.CommitID, name string) (io.ReadCloser, error) {
+			t.Fatal("file was read")
+			return nil, nil
+		}
+
+		defer git.ResetMocks()
+
+		req := httptest.NewRequest("GET", "/github.com/sourcegraph/sourcegraph/-/raw/file.go", nil)
+		req.Header.Set("If-None-Match", `W/"12345-plain"`)
+		w := httptest.NewRecorder()
+
+		err := serveRaw(w, req)
+		if err != nil {
+			t.Fatalf("Failed to invoke serveRaw: %v", err)
+		}
+
+		if w.Code != http.StatusNotModified {
+			t.Fatalf("Want %d but got %d", http.StatusNotModified, w.Code)
+		}
+
+		if w.Body.Len() != 0 {
+			t.Errorf("Want empty body, but got %q", w.Body.String())
+		}
+	})
 }
@@ -11,7 +11,6 @@ import (
 	"strconv"
 	"strings"
 
-	"github.com/NYTimes/gziphandler"
 	"github.com/cockroachdb/errors"
 	"github.com/gorilla/mux"
 	"github.com/inconshreveable/log15"
@@ -21,7 +20,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	uirouter "github.com/sourcegraph/sourcegraph/cmd/frontend/internal/app/ui/router"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/handlerutil"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/httpapi"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/routevar"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
@@ -117,9 +119,9 @@ func Router() *mux.Router {
 // InitRouter create the router that serves pages for our web app
 // and assigns it to uirouter.Router.
 // The router can be accessed by calling Router().
-func InitRouter(db dbutil.DB) {
+func InitRouter(db dbutil.DB, rateLimitWatcher graphqlbackend.LimitWatcher) {
 	router := newRouter()
-	initRouter(db, router)
+	initRouter(db, router, rateLimitWatcher)
 }
 
 var mockServeRepo func(w http.ResponseWriter, r *http.Request)
@@ -217,7 +219,7 @@ func brandNameSubtitle(titles ...string) string {
 	return strings.Join(append(titles, globals.Branding().BrandName), " - ")
 }
 
-func initRouter(db dbutil.DB, router *mux.Router) {
+func initRouter(db dbutil.DB, router *mux.Router, rateLimitWatcher graphqlbackend.LimitWatcher) {
 	uirouter.Router = router // make accessible to other packages
 
 	// basic pages with static titles
@@ -293,7 +295,7 @@ func initRouter(db dbutil.DB, router *mux.Router) {
 	}, nil)))
 
 	// streaming search
-	router.Get(routeSearchStream).Handler(search.StreamHandler(db))
+	router.Get(routeSearchStream).Handler(httpapi.RateLimitHandler(rateLimitWatcher, "search.stream", search.StreamHandler(db)))
 
 	// search badge
 	router.Get(routeSearchBadge).Handler(searchBadgeHandler())
@@ -347,7 +349,7 @@ func initRouter(db dbutil.DB, router *mux.Router) {
 	})))
 
 	// raw
-	router.Get(routeRaw).Handler(handler(serveRaw))
+	router.Get(routeRaw).Handler(compressedHandler(handlerutil.CompressionRouteRaw, serveRaw))
 
 	// All other routes that are not found.
 	router.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -407,6 +409,12 @@ func limitString(s string, n int, ellipsis bool) string {
 //  return nil
 //
 func handler(f func(w http.ResponseWriter, r *http.Request) error) http.Handler {
+	return compressedHandler(handlerutil.CompressionRouteDefault, f)
+}
+
+// compressedHandler is like handler, but compresses the responses as configured for the given
+// compression route.
+func compressedHandler(route handlerutil.CompressionRoute, f func(w http.ResponseWriter, r *http.Request) error) http.Handler {
 	h := http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		defer func() {
 			if rec := recover(); rec != nil {
@@ -417,7 +425,7 @@ func handler(f func(w http.ResponseWriter, r *http.Request) error) http.Handler
 			serveError(w, r, err, http.StatusInternalServerError)
 		}
 	})
-	return trace.Route(gziphandler.GzipHandler(h))
+	return trace.Route(handlerutil.Compress(route, h))
 }
 
 type recoverError struct {
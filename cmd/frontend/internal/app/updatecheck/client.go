@@ -87,14 +87,14 @@ func getAndMarshalSiteActivityJSON(ctx context.Context, db dbutil.DB, criticalOn
 	return json.Marshal(siteActivity)
 }
 
-func hasSearchOccurred(ctx context.Context) (_ bool, err error) {
+func hasSearchOccurred(ctx context.Context, db dbutil.DB) (_ bool, err error) {
 	defer recordOperation("hasSearchOccurred")(&err)
-	return usagestats.HasSearchOccurred(ctx)
+	return usagestats.HasSearchOccurred(ctx, db)
 }
 
-func hasFindRefsOccurred(ctx context.Context) (_ bool, err error) {
+func hasFindRefsOccurred(ctx context.Context, db dbutil.DB) (_ bool, err error) {
 	defer recordOperation("hasSearchOccured")(&err)
-	return usagestats.HasFindRefsOccurred(ctx)
+	return usagestats.HasFindRefsOccurred(ctx, db)
 }
 
 func getTotalUsersCount(ctx context.Context) (_ int, err error) {
@@ -373,11 +373,11 @@ func updateBody(ctx context.Context, db dbutil.DB) (io.Reader, error) {
 		}
 		r.HasRepos = totalRepos > 0
 
-		r.EverSearched, err = hasSearchOccurred(ctx)
+		r.EverSearched, err = hasSearchOccurred(ctx, db)
 		if err != nil {
 			logFunc("telemetry: updatecheck.hasSearchOccurred failed", "error", err)
 		}
-		r.EverFindRefs, err = hasFindRefsOccurred(ctx)
+		r.EverFindRefs, err = hasFindRefsOccurred(ctx, db)
 		if err != nil {
 			logFunc("telemetry: updatecheck.hasFindRefsOccurred failed", "error", err)
 		}
@@ -86,7 +86,11 @@ func HandleResetPasswordInit(db dbutil.DB) func(w http.ResponseWriter, r *http.R
 	}
 }
 
-var resetPasswordEmailTemplates = txemail.MustValidate(txtypes.Templates{
+var resetPasswordEmailTemplates = txemail.MustRegister("resetPassword", map[string]string{
+	"Username": "alice",
+	"URL":      "https://sourcegraph.example.com/password-reset?code=example",
+	"Host":     "sourcegraph.example.com",
+}, txtypes.Templates{
 	Subject: `Reset your Sourcegraph password ({{.Host}})`,
 	Text: `
 Somebody (likely you) requested a password reset for the user {{.Username}} on Sourcegraph ({{.Host}}).
@@ -144,7 +148,11 @@ func HandleSetPasswordEmail(ctx context.Context, db dbutil.DB, id int32) (string
 	return rus, nil
 }
 
-var setPasswordEmailTemplates = txemail.MustValidate(txtypes.Templates{
+var setPasswordEmailTemplates = txemail.MustRegister("setPassword", map[string]string{
+	"Username": "alice",
+	"URL":      "https://sourcegraph.example.com/password-reset?code=example",
+	"Host":     "sourcegraph.example.com",
+}, txtypes.Templates{
 	Subject: `Set your Sourcegraph password ({{.Host}})`,
 	Text: `
 Your administrator created an account for you on Sourcegraph ({{.Host}}).
@@ -0,0 +1,63 @@
+package bg
+
+import (
+	"context"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	searchbackend "github.com/sourcegraph/sourcegraph/internal/search/backend"
+)
+
+var (
+	searchTrafficFlushErrors = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_search_traffic_flush_errors_total",
+		Help: "Number of errors storing the counted repository searches in the database.",
+	})
+	searchTrafficRepos = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "src_search_traffic_repos",
+		Help: "Number of repositories with a search traffic score, which are reindexed before the others.",
+	})
+)
+
+// searchTrafficMinScore is the score below which a repository is no longer
+// considered searched.
+const searchTrafficMinScore = 0.01
+
+// FlushSearchTraffic periodically adds the repository searches counted by
+// searchbackend.SearchTraffic to the database, and deletes the scores which
+// have decayed to almost zero.
+func FlushSearchTraffic(ctx context.Context, db dbutil.DB) {
+	for {
+		if searchbackend.SearchTraffic.Enabled() {
+			if err := flushSearchTraffic(ctx, db); err != nil {
+				searchTrafficFlushErrors.Inc()
+				log15.Error("storing search traffic", "error", err)
+			}
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(time.Minute):
+		}
+	}
+}
+
+func flushSearchTraffic(ctx context.Context, db dbutil.DB) error {
+	halfLife := searchbackend.SearchTraffic.HalfLife()
+	store := database.RepoSearchFrequencies(db)
+	if err := store.Add(ctx, searchbackend.SearchTraffic.Take(), halfLife); err != nil {
+		return err
+	}
+	count, err := store.DeleteBelow(ctx, searchTrafficMinScore, halfLife)
+	if err != nil {
+		return err
+	}
+	searchTrafficRepos.Set(float64(count))
+	return nil
+}
@@ -0,0 +1,139 @@
+package bg
+
+import (
+	"context"
+	"fmt"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// NewOrgTeamsSyncer returns a background routine that periodically syncs the
+// members of org teams which are mapped to a team on a code host. Members of
+// the code host team are matched to users by their external accounts, so only
+// users who signed in with the code host become members of the team.
+func NewOrgTeamsSyncer(ctx context.Context, db dbutil.DB) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(ctx, time.Hour, goroutine.NewHandlerWithErrorMessage(
+		"sync org teams",
+		func(ctx context.Context) error {
+			teams, err := database.OrgTeams(db).List(ctx, database.ListOrgTeamsOptions{OnlySynced: true})
+			if err != nil {
+				return errors.Wrap(err, "listing org teams to sync")
+			}
+			for _, team := range teams {
+				if err := syncOrgTeam(ctx, db, team); err != nil {
+					log15.Error("syncing org team members", "team", team.ID, "error", err)
+				}
+			}
+			return nil
+		},
+	))
+}
+
+func syncOrgTeam(ctx context.Context, db dbutil.DB, team *types.OrgTeam) error {
+	svc, err := database.ExternalServices(db).GetByID(ctx, team.ExternalServiceID)
+	if err != nil {
+		return err
+	}
+
+	codeHost, accountIDs, err := listCodeHostTeamMembers(ctx, svc, team.ExternalID)
+	if err != nil {
+		return err
+	}
+
+	accounts, err := database.ExternalAccounts(db).List(ctx, database.ExternalAccountsListOptions{
+		ServiceType: codeHost.ServiceType,
+		ServiceID:   codeHost.ServiceID,
+	})
+	if err != nil {
+		return err
+	}
+
+	userIDs := make([]int32, 0, len(accountIDs))
+	for _, acct := range accounts {
+		if _, ok := accountIDs[acct.AccountID]; ok {
+			userIDs = append(userIDs, acct.UserID)
+		}
+	}
+
+	return database.OrgTeams(db).SetMembers(ctx, team.ID, userIDs)
+}
+
+// listCodeHostTeamMembers returns the account IDs of the members of the team
+// externalID on the code host of svc.
+func listCodeHostTeamMembers(ctx context.Context, svc *types.ExternalService, externalID string) (*extsvc.CodeHost, map[string]struct{}, error) {
+	cfg, err := svc.Configuration()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	accountIDs := make(map[string]struct{})
+	switch c := cfg.(type) {
+	case *schema.GitHubConnection:
+		baseURL, err := url.Parse(c.Url)
+		if err != nil {
+			return nil, nil, err
+		}
+		parts := strings.SplitN(externalID, "/", 2)
+		if len(parts) != 2 {
+			return nil, nil, errors.Errorf("invalid GitHub team %q, expected org/team-slug", externalID)
+		}
+		org, teamSlug := parts[0], parts[1]
+
+		apiURL, _ := github.APIRoot(baseURL)
+		client := github.NewV3Client(apiURL, &auth.OAuthBearerToken{Token: c.Token}, nil)
+		for page := 1; ; page++ {
+			users, hasNextPage, err := client.ListTeamMembers(ctx, org, teamSlug, page)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, u := range users {
+				accountIDs[strconv.FormatInt(u.DatabaseID, 10)] = struct{}{}
+			}
+			if !hasNextPage {
+				break
+			}
+		}
+		return extsvc.NewCodeHost(baseURL, extsvc.TypeGitHub), accountIDs, nil
+
+	case *schema.GitLabConnection:
+		baseURL, err := url.Parse(c.Url)
+		if err != nil {
+			return nil, nil, err
+		}
+
+		client := gitlab.NewClientProvider(baseURL, nil).GetPATClient(c.Token, "")
+		nextURL := fmt.Sprintf("groups/%s/members/all?per_page=100", url.PathEscape(externalID))
+		for {
+			members, next, err := client.ListMembers(ctx, nextURL)
+			if err != nil {
+				return nil, nil, err
+			}
+			for _, m := range members {
+				accountIDs[strconv.Itoa(int(m.ID))] = struct{}{}
+			}
+			if next == nil {
+				break
+			}
+			nextURL = *next
+		}
+		return extsvc.NewCodeHost(baseURL, extsvc.TypeGitLab), accountIDs, nil
+
+	default:
+		return nil, nil, errors.Errorf("syncing team members is not supported for %s code host connections", svc.Kind)
+	}
+}
@@ -0,0 +1,51 @@
+package bg
+
+import (
+	"context"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	searchbackend "github.com/sourcegraph/sourcegraph/internal/search/backend"
+)
+
+// VerifyZoektShards periodically checks for index shards which zoekt failed
+// to load and schedules the affected repositories for reindexing. See
+// searchbackend.ShardVerifier.
+func VerifyZoektShards(ctx context.Context, db dbutil.DB) {
+	if !search.Indexed().Enabled() {
+		return
+	}
+
+	v := &searchbackend.ShardVerifier{
+		Lister:  search.Indexed().Client,
+		Tracker: searchbackend.CorruptShards,
+		Indexable: func(ctx context.Context, ids []api.RepoID) ([]api.RepoID, error) {
+			repos, err := database.Repos(db).List(ctx, database.ReposListOptions{IDs: ids})
+			if err != nil {
+				return nil, err
+			}
+
+			names := make([]string, 0, len(repos))
+			byName := make(map[string]api.RepoID, len(repos))
+			for _, r := range repos {
+				names = append(names, string(r.Name))
+				byName[string(r.Name)] = r.ID
+			}
+			if searchbackend.OnDemand.Enabled() {
+				if names, err = searchbackend.OnDemand.Filter(names); err != nil {
+					return nil, err
+				}
+			}
+
+			indexable := make([]api.RepoID, 0, len(names))
+			for _, name := range names {
+				indexable = append(indexable, byName[name])
+			}
+			return indexable, nil
+		},
+	}
+	v.Run(ctx, 5*time.Minute)
+}
@@ -349,8 +349,17 @@ func (c configurationSource) Read(ctx context.Context) (conftypes.RawUnified, er
 	if err != nil {
 		return conftypes.RawUnified{}, errors.Wrap(err, "confdb.SiteGetLatest")
 	}
+	overlays, err := confdb.SiteListOverlays(ctx)
+	if err != nil {
+		return conftypes.RawUnified{}, errors.Wrap(err, "confdb.SiteListOverlays")
+	}
+	var siteOverlays []string
+	for _, overlay := range overlays {
+		siteOverlays = append(siteOverlays, overlay.Contents)
+	}
 	return conftypes.RawUnified{
 		Site:               site.Contents,
+		SiteOverlays:       siteOverlays,
 		ServiceConnections: serviceConnections(),
 	}, nil
 }
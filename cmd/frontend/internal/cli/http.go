@@ -30,16 +30,19 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	tracepkg "github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
+	"github.com/sourcegraph/sourcegraph/internal/usagestats"
 	"github.com/sourcegraph/sourcegraph/internal/version"
 )
 
 // newExternalHTTPHandler creates and returns the HTTP handler that serves the app and API pages to
 // external clients.
-func newExternalHTTPHandler(db dbutil.DB, schema *graphql.Schema, gitHubWebhook webhooks.Registerer, gitLabWebhook, bitbucketServerWebhook http.Handler, newCodeIntelUploadHandler enterprise.NewCodeIntelUploadHandler, newExecutorProxyHandler enterprise.NewExecutorProxyHandler, rateLimitWatcher graphqlbackend.LimitWatcher) (http.Handler, error) {
+func newExternalHTTPHandler(db dbutil.DB, schema *graphql.Schema, gitHubWebhook webhooks.Registerer, gitLabWebhook, bitbucketServerWebhook http.Handler, newCodeIntelUploadHandler enterprise.NewCodeIntelUploadHandler, newExecutorProxyHandler enterprise.NewExecutorProxyHandler, codeIntelCoverage enterprise.CodeIntelCoverage, rateLimitWatcher graphqlbackend.LimitWatcher) (http.Handler, error) {
 	// Each auth middleware determines on a per-request basis whether it should be enabled (if not, it
 	// immediately delegates the request to the next middleware in the chain).
 	authMiddlewares := auth.AuthMiddleware()
 
+	logFeatureFlagExposure := usagestats.FeatureFlagExposureLogger(db)
+
 	// HTTP API handler, the call order of middleware is LIFO.
 	r := router.New(mux.NewRouter().PathPrefix("/.api/").Subrouter())
 	apiHandler := internalhttpapi.NewHandler(db, r, schema, gitHubWebhook, gitLabWebhook, bitbucketServerWebhook, newCodeIntelUploadHandler, rateLimitWatcher)
@@ -47,27 +50,29 @@ func newExternalHTTPHandler(db dbutil.DB, schema *graphql.Schema, gitHubWebhook
 		// 🚨 SECURITY: These all run after the auth handler so the client is authenticated.
 		apiHandler = hooks.PostAuthMiddleware(apiHandler)
 	}
-	apiHandler = featureflag.Middleware(database.FeatureFlags(db), apiHandler)
-	apiHandler = authMiddlewares.API(apiHandler) // 🚨 SECURITY: auth middleware
+	apiHandler = featureflag.Middleware(database.FeatureFlags(db), logFeatureFlagExposure, apiHandler)
+	apiHandler = internalhttpapi.ImpersonationMiddleware(db, apiHandler) // 🚨 SECURITY: after all auth middlewares
+	apiHandler = authMiddlewares.API(apiHandler)                         // 🚨 SECURITY: auth middleware
 	// 🚨 SECURITY: The HTTP API should not accept cookies as authentication (except those with the
 	// X-Requested-With header). Doing so would open it up to CSRF attacks.
 	apiHandler = session.CookieMiddlewareWithCSRFSafety(apiHandler, corsAllowHeader, isTrustedOrigin) // API accepts cookies with special header
 	apiHandler = internalhttpapi.AccessTokenAuthMiddleware(db, apiHandler)                            // API accepts access tokens
-	apiHandler = gziphandler.GzipHandler(apiHandler)
+	apiHandler = compressAPI(apiHandler)
 
 	// 🚨 SECURITY: This handler implements its own token auth inside enterprise
 	executorProxyHandler := newExecutorProxyHandler()
 
 	// App handler (HTML pages), the call order of middleware is LIFO.
-	appHandler := app.NewHandler(db)
+	appHandler := app.NewHandler(db, codeIntelCoverage)
 	if hooks.PostAuthMiddleware != nil {
 		// 🚨 SECURITY: These all run after the auth handler so the client is authenticated.
 		appHandler = hooks.PostAuthMiddleware(appHandler)
 	}
-	appHandler = featureflag.Middleware(database.FeatureFlags(db), appHandler)
+	appHandler = featureflag.Middleware(database.FeatureFlags(db), logFeatureFlagExposure, appHandler)
 	appHandler = handlerutil.CSRFMiddleware(appHandler, func() bool {
 		return globals.ExternalURL().Scheme == "https"
 	}) // after appAuthMiddleware because SAML IdP posts data to us w/o a CSRF token
+	appHandler = internalhttpapi.ImpersonationMiddleware(db, appHandler)   // 🚨 SECURITY: after all auth middlewares
 	appHandler = authMiddlewares.App(appHandler)                           // 🚨 SECURITY: auth middleware
 	appHandler = session.CookieMiddleware(appHandler)                      // app accepts cookies
 	appHandler = internalhttpapi.AccessTokenAuthMiddleware(db, appHandler) // app accepts access tokens
@@ -89,6 +94,7 @@ func newExternalHTTPHandler(db dbutil.DB, schema *graphql.Schema, gitHubWebhook
 	// change is explicitly made, to enable this token.
 	h = internalauth.OverrideAuthMiddleware(db, h)
 	h = internalauth.ForbidAllRequestsMiddleware(h)
+	h = auth.NetworkPolicyMiddleware(h) // 🚨 SECURITY: client IP allow and deny lists
 	h = tracepkg.HTTPTraceMiddleware(h)
 	h = ot.Middleware(h)
 	h = middleware.SourcegraphComGoGetHandler(h)
@@ -100,6 +106,23 @@ func newExternalHTTPHandler(db dbutil.DB, schema *graphql.Schema, gitHubWebhook
 	return h, nil
 }
 
+// compressAPI compresses the responses of the HTTP API. The GraphQL API and streaming search
+// are configured separately from the other routes, because their responses are the largest.
+func compressAPI(next http.Handler) http.Handler {
+	routes := map[string]http.Handler{
+		"/.api/graphql":       handlerutil.Compress(handlerutil.CompressionRouteGraphQL, next),
+		"/.api/search/stream": handlerutil.Compress(handlerutil.CompressionRouteSearchStream, next),
+	}
+	other := handlerutil.Compress(handlerutil.CompressionRouteDefault, next)
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if h, ok := routes[r.URL.Path]; ok {
+			h.ServeHTTP(w, r)
+			return
+		}
+		other.ServeHTTP(w, r)
+	})
+}
+
 func healthCheckMiddleware(next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		switch r.URL.Path {
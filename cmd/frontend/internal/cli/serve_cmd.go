@@ -3,6 +3,7 @@ package cli
 import (
 	"context"
 	"database/sql"
+	"encoding/json"
 	"fmt"
 	"log"
 	"net/http"
@@ -17,6 +18,7 @@ import (
 	"github.com/graph-gophers/graphql-go"
 	"github.com/inconshreveable/log15"
 	"github.com/keegancsmith/tmpfriend"
+	"github.com/throttled/throttled/v2/store/memstore"
 	"github.com/throttled/throttled/v2/store/redigostore"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
@@ -28,8 +30,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/app/updatecheck"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/bg"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/cli/loghandlers"
+	internalhttpapi "github.com/sourcegraph/sourcegraph/cmd/frontend/internal/httpapi"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/siteid"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/vfsutil"
+	"github.com/sourcegraph/sourcegraph/internal/cache"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbconn"
@@ -38,6 +42,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/healthreport"
 	"github.com/sourcegraph/sourcegraph/internal/httpserver"
 	"github.com/sourcegraph/sourcegraph/internal/logging"
 	"github.com/sourcegraph/sourcegraph/internal/oobmigration"
@@ -47,6 +52,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/tracer"
 	"github.com/sourcegraph/sourcegraph/internal/version"
+	"github.com/sourcegraph/sourcegraph/internal/version/skew"
 )
 
 var (
@@ -135,14 +141,36 @@ func Main(enterpriseSetupHook func(db dbutil.DB, outOfBandMigrationRunner *oobmi
 	}
 
 	ready := make(chan struct{})
-	go debugserver.NewServerRoutine(ready).Start()
+	go debugserver.NewServerRoutine(
+		ready,
+		debugserver.Endpoint{
+			Name: "Health Report",
+			Path: "/health-report",
+			Handler: http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				// wait until we're healthy to respond
+				<-ready
+				w.Header().Set("Content-Type", "application/json")
+				enc := json.NewEncoder(w)
+				enc.SetIndent("", "  ")
+				_ = enc.Encode(healthreport.Generate(r.Context(), dbconn.Global))
+			}),
+		},
+	).Start()
 
 	db, err := InitDB()
 	if err != nil {
 		log.Fatalf("ERROR: %v", err)
 	}
+	if err := skew.Register(ctx, db, "frontend"); err != nil {
+		log.Fatalf("ERROR: %v", err)
+	}
 
-	ui.InitRouter(db)
+	// Share the settings cascades, the users looked up for repository
+	// permissions and the repositories resolved for searches by each replica
+	// with all others.
+	database.SettingsCascadeCache = cache.NewVersioned(cache.NewRedis(redispool.Cache, "settings_cascade"), 10*time.Minute)
+	database.AuthzUserCache = cache.NewRedis(redispool.Cache, "authz_user")
+	database.SearchReposCache = cache.NewVersioned(cache.NewRedis(redispool.Cache, "search_repos"), 30*time.Second)
 
 	// override site config first
 	if err := overrideSiteConfig(ctx); err != nil {
@@ -249,6 +277,8 @@ func Main(enterpriseSetupHook func(db dbutil.DB, outOfBandMigrationRunner *oobmi
 	goroutine.Go(func() { bg.DeleteOldCacheDataInRedis() })
 	goroutine.Go(func() { bg.DeleteOldEventLogsInPostgres(context.Background(), db) })
 	goroutine.Go(func() { bg.DeleteOldSecurityEventLogsInPostgres(context.Background(), db) })
+	goroutine.Go(func() { bg.VerifyZoektShards(context.Background(), db) })
+	goroutine.Go(func() { bg.FlushSearchTraffic(context.Background(), db) })
 	goroutine.Go(func() { updatecheck.Start(db) })
 
 	// Parse GraphQL schema and set up resolvers that depend on dbconn.Global
@@ -267,6 +297,8 @@ func Main(enterpriseSetupHook func(db dbutil.DB, outOfBandMigrationRunner *oobmi
 		return err
 	}
 
+	ui.InitRouter(db, rateLimitWatcher)
+
 	server, err := makeExternalAPI(db, schema, enterprise, rateLimitWatcher)
 	if err != nil {
 		return err
@@ -284,6 +316,8 @@ func Main(enterpriseSetupHook func(db dbutil.DB, outOfBandMigrationRunner *oobmi
 	if internalAPI != nil {
 		routines = append(routines, internalAPI)
 	}
+	routines = append(routines, internalhttpapi.NewIdempotencyKeyJanitor(context.Background(), db))
+	routines = append(routines, bg.NewOrgTeamsSyncer(context.Background(), db))
 
 	if printLogo {
 		fmt.Println(" ")
@@ -299,7 +333,7 @@ func Main(enterpriseSetupHook func(db dbutil.DB, outOfBandMigrationRunner *oobmi
 
 func makeExternalAPI(db dbutil.DB, schema *graphql.Schema, enterprise enterprise.Services, rateLimiter graphqlbackend.LimitWatcher) (goroutine.BackgroundRoutine, error) {
 	// Create the external HTTP handler.
-	externalHandler, err := newExternalHTTPHandler(db, schema, enterprise.GitHubWebhook, enterprise.GitLabWebhook, enterprise.BitbucketServerWebhook, enterprise.NewCodeIntelUploadHandler, enterprise.NewExecutorProxyHandler, rateLimiter)
+	externalHandler, err := newExternalHTTPHandler(db, schema, enterprise.GitHubWebhook, enterprise.GitLabWebhook, enterprise.BitbucketServerWebhook, enterprise.NewCodeIntelUploadHandler, enterprise.NewExecutorProxyHandler, enterprise.CodeIntelCoverage, rateLimiter)
 	if err != nil {
 		return nil, err
 	}
@@ -354,10 +388,23 @@ func isAllowedOrigin(origin string, allowedOrigins []string) bool {
 	return false
 }
 
-func makeRateLimitWatcher() (*graphqlbackend.BasicLimitWatcher, error) {
-	ratelimitStore, err := redigostore.New(redispool.Cache, "gql:rl:", 0)
+func makeRateLimitWatcher() (graphqlbackend.LimitWatcher, error) {
+	sharedStore, err := redigostore.New(redispool.Cache, "gql:rl:", 0)
+	if err != nil {
+		return nil, err
+	}
+	// The in-memory store is only used while Redis is unavailable, so it only
+	// needs to hold the state of the recently active actors.
+	localStore, err := memstore.New(10000)
 	if err != nil {
 		return nil, err
 	}
-	return graphqlbackend.NewBasicLimitWatcher(ratelimitStore), nil
+	ratelimitStore := graphqlbackend.NewFallbackGCRAStore(sharedStore, localStore)
+
+	// The limits configured in api.ratelimit take precedence over the limit of
+	// anonymous requests.
+	return graphqlbackend.NewLimitWatchers(
+		graphqlbackend.NewRateLimiteWatcher(ratelimitStore),
+		graphqlbackend.NewBasicLimitWatcher(ratelimitStore),
+	), nil
 }
@@ -32,6 +32,7 @@ func ReposourceCloneURLToRepoName(ctx context.Context, db dbutil.DB, cloneURL st
 			extsvc.KindGitLab,
 			extsvc.KindBitbucketServer,
 			extsvc.KindAWSCodeCommit,
+			extsvc.KindAzureDevOps,
 			extsvc.KindGitolite,
 			extsvc.KindPhabricator,
 			extsvc.KindOther,
@@ -68,6 +69,9 @@ func ReposourceCloneURLToRepoName(ctx context.Context, db dbutil.DB, cloneURL st
 			case *schema.BitbucketServerConnection:
 				rs = reposource.BitbucketServer{BitbucketServerConnection: c}
 				host = c.Url
+			case *schema.AzureDevOpsConnection:
+				rs = reposource.AzureDevOps{AzureDevOpsConnection: c}
+				host = c.Url
 			case *schema.AWSCodeCommitConnection:
 				rs = reposource.AWS{AWSCodeCommitConnection: c}
 				// AWS type does not have URL
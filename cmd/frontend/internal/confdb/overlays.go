@@ -0,0 +1,121 @@
+package confdb
+
+import (
+	"context"
+	"database/sql"
+	"regexp"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/jsonx"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbconn"
+)
+
+// SiteOverlay is a named site configuration overlay. The overlays are merged on top of the
+// site config in ascending order of Position, and then Name, to yield the effective site
+// config. This lets environment-specific settings (such as "staging-overrides") be layered
+// on top of a shared site config.
+type SiteOverlay struct {
+	ID        int32     // the unique ID of this overlay
+	Name      string    // the unique name of this overlay
+	Position  int32     // the position of this overlay in the merge order
+	Contents  string    // the raw JSON content (with comments and trailing commas allowed)
+	CreatedAt time.Time // the date when this overlay was created
+	UpdatedAt time.Time // the date when this overlay was updated
+}
+
+// ErrSiteOverlayNotFound is returned by SiteDeleteOverlay when no overlay with the given name
+// exists.
+var ErrSiteOverlayNotFound = errors.New("site configuration overlay not found")
+
+var validOverlayName = regexp.MustCompile(`^[a-zA-Z0-9][a-zA-Z0-9._-]{0,99}$`)
+
+// SiteListOverlays returns all site config overlays in the order in which they are merged.
+//
+// 🚨 SECURITY: This method does NOT verify the user is an admin. The caller is
+// responsible for ensuring this or that the response never makes it to a user.
+func SiteListOverlays(ctx context.Context) ([]*SiteOverlay, error) {
+	q := sqlf.Sprintf("SELECT id, name, position, contents, created_at, updated_at FROM site_config_overlays ORDER BY position ASC, name ASC")
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	return parseOverlayRows(rows)
+}
+
+// SiteUpsertOverlay creates the site config overlay with the given name, or replaces the
+// position and contents of the existing overlay with that name.
+//
+// An error is returned if "name" is invalid or "contents" is invalid JSON.
+//
+// 🚨 SECURITY: This method does NOT verify the user is an admin. The caller is
+// responsible for ensuring this or that the response never makes it to a user.
+func SiteUpsertOverlay(ctx context.Context, name string, position int32, contents string) (*SiteOverlay, error) {
+	if !validOverlayName.MatchString(name) {
+		return nil, errors.Errorf("invalid overlay name %q: must be at most 100 letters, digits, dots, dashes or underscores", name)
+	}
+	// Validate JSON syntax before saving.
+	if _, errs := jsonx.Parse(contents, jsonx.ParseOptions{Comments: true, TrailingCommas: true}); len(errs) > 0 {
+		return nil, errors.Errorf("invalid settings JSON: %v", errs)
+	}
+
+	q := sqlf.Sprintf(`
+INSERT INTO site_config_overlays (name, position, contents)
+VALUES (%s, %s, %s)
+ON CONFLICT (name) DO UPDATE SET
+	position = EXCLUDED.position,
+	contents = EXCLUDED.contents,
+	updated_at = now()
+RETURNING id, name, position, contents, created_at, updated_at`, name, position, contents)
+	rows, err := dbconn.Global.QueryContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return nil, err
+	}
+	overlays, err := parseOverlayRows(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(overlays) != 1 {
+		return nil, errors.Errorf("expected 1 overlay, got %d", len(overlays))
+	}
+	return overlays[0], nil
+}
+
+// SiteDeleteOverlay deletes the site config overlay with the given name.
+//
+// 🚨 SECURITY: This method does NOT verify the user is an admin. The caller is
+// responsible for ensuring this or that the response never makes it to a user.
+func SiteDeleteOverlay(ctx context.Context, name string) error {
+	q := sqlf.Sprintf("DELETE FROM site_config_overlays WHERE name = %s", name)
+	res, err := dbconn.Global.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrSiteOverlayNotFound
+	}
+	return nil
+}
+
+func parseOverlayRows(rows *sql.Rows) ([]*SiteOverlay, error) {
+	overlays := []*SiteOverlay{}
+	defer rows.Close()
+	for rows.Next() {
+		o := SiteOverlay{}
+		err := rows.Scan(&o.ID, &o.Name, &o.Position, &o.Contents, &o.CreatedAt, &o.UpdatedAt)
+		if err != nil {
+			return nil, err
+		}
+		overlays = append(overlays, &o)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return overlays, nil
+}
@@ -0,0 +1,67 @@
+package confdb
+
+import (
+	"context"
+	"reflect"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+)
+
+func TestSiteOverlays(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	dbtesting.SetupGlobalTestDB(t)
+	ctx := context.Background()
+
+	for _, o := range []struct {
+		name     string
+		position int32
+		contents string
+	}{
+		{"staging-overrides", 10, `{"externalURL": "https://staging.example.com"}`},
+		{"base", 0, `{"externalURL": "https://example.com"}`},
+		{"a-base", 0, `{}`},
+	} {
+		if _, err := SiteUpsertOverlay(ctx, o.name, o.position, o.contents); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Updating an overlay replaces its position and contents.
+	updated, err := SiteUpsertOverlay(ctx, "base", 5, `{"externalURL": "https://base.example.com"}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if updated.Position != 5 || updated.Contents != `{"externalURL": "https://base.example.com"}` {
+		t.Fatalf("unexpected overlay after update: %+v", updated)
+	}
+
+	overlays, err := SiteListOverlays(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var names []string
+	for _, o := range overlays {
+		names = append(names, o.Name)
+	}
+	if want := []string{"a-base", "base", "staging-overrides"}; !reflect.DeepEqual(names, want) {
+		t.Fatalf("unexpected merge order: want %q, got %q", want, names)
+	}
+
+	if err := SiteDeleteOverlay(ctx, "base"); err != nil {
+		t.Fatal(err)
+	}
+	if err := SiteDeleteOverlay(ctx, "base"); err != ErrSiteOverlayNotFound {
+		t.Fatalf("expected ErrSiteOverlayNotFound, got %v", err)
+	}
+
+	if _, err := SiteUpsertOverlay(ctx, "invalid name", 0, `{}`); err == nil {
+		t.Fatal("expected error for invalid overlay name")
+	}
+	if _, err := SiteUpsertOverlay(ctx, "invalid-json", 0, `{`); err == nil {
+		t.Fatal("expected error for invalid overlay JSON")
+	}
+}
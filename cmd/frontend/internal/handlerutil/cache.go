@@ -0,0 +1,28 @@
+package handlerutil
+
+import (
+	"net/http"
+	"strings"
+)
+
+// NotModified sets the ETag response header to etag, which must be a quoted entity tag such as
+// `W/"v1"`, and reports whether the If-None-Match request header matches it. If it matches, it
+// responds with 304 Not Modified, and the caller must not write a response.
+func NotModified(w http.ResponseWriter, r *http.Request, etag string) bool {
+	w.Header().Set("ETag", etag)
+
+	ifNoneMatch := r.Header.Get("If-None-Match")
+	if ifNoneMatch == "" {
+		return false
+	}
+	// If-None-Match uses the weak comparison, which ignores the W/ prefix of weak entity tags.
+	// This also matches the entity tags of compressed responses, which Compress makes weak.
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == strings.TrimPrefix(etag, "W/") {
+			w.WriteHeader(http.StatusNotModified)
+			return true
+		}
+	}
+	return false
+}
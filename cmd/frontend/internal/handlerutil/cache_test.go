@@ -0,0 +1,36 @@
+package handlerutil
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestNotModified(t *testing.T) {
+	for _, tc := range []struct {
+		ifNoneMatch string
+		want        bool
+	}{
+		{"", false},
+		{`"v0"`, false},
+		{`W/"v1"`, true},
+		{`"v1"`, true},
+		{`"v0", W/"v1"`, true},
+		{"*", true},
+	} {
+		req := httptest.NewRequest("GET", "/", nil)
+		if tc.ifNoneMatch != "" {
+			req.Header.Set("If-None-Match", tc.ifNoneMatch)
+		}
+		rec := httptest.NewRecorder()
+		if got := NotModified(rec, req, `W/"v1"`); got != tc.want {
+			t.Errorf("If-None-Match %q: got %t, want %t", tc.ifNoneMatch, got, tc.want)
+		}
+		if tc.want && rec.Code != http.StatusNotModified {
+			t.Errorf("If-None-Match %q: got status %d, want %d", tc.ifNoneMatch, rec.Code, http.StatusNotModified)
+		}
+		if got := rec.Header().Get("ETag"); got != `W/"v1"` {
+			t.Errorf("If-None-Match %q: got ETag %q", tc.ifNoneMatch, got)
+		}
+	}
+}
@@ -0,0 +1,264 @@
+package handlerutil
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+)
+
+// CompressionRoute is a group of routes whose response compression is configured by the
+// "http.compression" site configuration setting.
+type CompressionRoute string
+
+const (
+	CompressionRouteDefault      CompressionRoute = "default"
+	CompressionRouteGraphQL      CompressionRoute = "graphql"
+	CompressionRouteSearchStream CompressionRoute = "searchStream"
+	CompressionRouteRaw          CompressionRoute = "raw"
+)
+
+// defaultCompressionEncodings are the encodings of the routes that are not configured, in order
+// of preference.
+var defaultCompressionEncodings = []string{"br", "gzip"}
+
+// minCompressSize is the size below which responses are not compressed, because compressing them
+// doesn't save a network packet. Flushed responses are compressed regardless of their size.
+const minCompressSize = 1400
+
+// incompressibleContentTypes are the content types of responses that are already compressed.
+var incompressibleContentTypes = map[string]bool{
+	"application/gzip":   true,
+	"application/x-gzip": true,
+	"application/zip":    true,
+	"image/gif":          true,
+	"image/jpeg":         true,
+	"image/png":          true,
+	"image/webp":         true,
+}
+
+// Compress returns a handler that compresses the responses of next with the encoding that the
+// client prefers among the encodings configured for route. Responses that already have a
+// Content-Encoding, are too small or have an incompressible content type are not compressed.
+func Compress(route CompressionRoute, next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Add("Vary", "Accept-Encoding")
+
+		encoding := negotiateEncoding(r.Header.Get("Accept-Encoding"), compressionEncodings(route))
+		if encoding == "" || r.Method == "HEAD" {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		cw := &compressResponseWriter{ResponseWriter: w, encoding: encoding}
+		defer cw.close()
+		next.ServeHTTP(cw, r)
+	})
+}
+
+// compressionEncodings returns the encodings configured for route, in order of preference.
+func compressionEncodings(route CompressionRoute) []string {
+	c := conf.Get().HttpCompression
+	if c == nil {
+		return defaultCompressionEncodings
+	}
+
+	var encodings []string
+	switch route {
+	case CompressionRouteGraphQL:
+		encodings = c.Graphql
+	case CompressionRouteSearchStream:
+		encodings = c.SearchStream
+	case CompressionRouteRaw:
+		encodings = c.Raw
+	default:
+		encodings = c.Default
+	}
+	if encodings == nil {
+		return defaultCompressionEncodings
+	}
+	return encodings
+}
+
+// negotiateEncoding returns the offered encoding that the Accept-Encoding header value accepts
+// with the highest quality, preferring earlier offers among those of equal quality. It returns
+// "" if no offered encoding is accepted.
+func negotiateEncoding(acceptEncoding string, offers []string) string {
+	qualities := map[string]float64{}
+	for _, spec := range strings.Split(acceptEncoding, ",") {
+		coding, params, _ := mime.ParseMediaType(strings.TrimSpace(spec))
+		if coding == "" {
+			continue
+		}
+		q := 1.0
+		if v, ok := params["q"]; ok {
+			var err error
+			if q, err = strconv.ParseFloat(v, 64); err != nil {
+				continue
+			}
+		}
+		qualities[coding] = q
+	}
+
+	var best string
+	var bestQ float64
+	for _, offer := range offers {
+		q, ok := qualities[offer]
+		if !ok {
+			q = qualities["*"]
+		}
+		if q > bestQ {
+			best, bestQ = offer, q
+		}
+	}
+	return best
+}
+
+// brotliLevel is the brotli compression level. It is lower than brotli.DefaultCompression,
+// which is too slow for responses that are compressed as they are generated.
+const brotliLevel = 4
+
+var (
+	gzipWriters   = sync.Pool{New: func() interface{} { return gzip.NewWriter(nil) }}
+	brotliWriters = sync.Pool{New: func() interface{} { return brotli.NewWriterLevel(nil, brotliLevel) }}
+)
+
+type compressWriter interface {
+	io.WriteCloser
+	Flush() error
+	Reset(io.Writer)
+}
+
+// compressResponseWriter buffers the start of the response until it knows whether to compress
+// the response, which it decides once minCompressSize bytes were written, the response is
+// flushed or the handler returns.
+type compressResponseWriter struct {
+	http.ResponseWriter
+	encoding string
+
+	status  int
+	buf     []byte
+	started bool
+	cw      compressWriter // nil if the response is not compressed
+}
+
+func (w *compressResponseWriter) WriteHeader(status int) {
+	if w.status != 0 {
+		return
+	}
+	w.status = status
+
+	// Responses without a body are passed through.
+	if status < 200 || status == http.StatusNoContent || status == http.StatusNotModified {
+		_ = w.start(false)
+	}
+}
+
+func (w *compressResponseWriter) Write(p []byte) (int, error) {
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+	if !w.started {
+		w.buf = append(w.buf, p...)
+		if len(w.buf) < minCompressSize {
+			return len(p), nil
+		}
+		return len(p), w.start(true)
+	}
+	if w.cw != nil {
+		return w.cw.Write(p)
+	}
+	return w.ResponseWriter.Write(p)
+}
+
+// start writes the response header, compressing the response if compress is set and the
+// response can be compressed, followed by the buffered start of the response.
+func (w *compressResponseWriter) start(compress bool) error {
+	w.started = true
+
+	h := w.Header()
+	if h.Get("Content-Type") == "" && len(w.buf) > 0 {
+		// Sniff the content type from the uncompressed content, like net/http would.
+		h.Set("Content-Type", http.DetectContentType(w.buf))
+	}
+	mediaType, _, _ := mime.ParseMediaType(h.Get("Content-Type"))
+	if compress && h.Get("Content-Encoding") == "" && !incompressibleContentTypes[mediaType] {
+		h.Set("Content-Encoding", w.encoding)
+		h.Del("Content-Length")
+		// The compressed response is not byte-for-byte identical to the uncompressed one.
+		if etag := h.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			h.Set("ETag", "W/"+etag)
+		}
+
+		switch w.encoding {
+		case "br":
+			w.cw = brotliWriters.Get().(*brotli.Writer)
+		default:
+			w.cw = gzipWriters.Get().(*gzip.Writer)
+		}
+		w.cw.Reset(w.ResponseWriter)
+	}
+
+	w.ResponseWriter.WriteHeader(w.status)
+
+	buf := w.buf
+	w.buf = nil
+	if len(buf) == 0 {
+		return nil
+	}
+	var err error
+	if w.cw != nil {
+		_, err = w.cw.Write(buf)
+	} else {
+		_, err = w.ResponseWriter.Write(buf)
+	}
+	return err
+}
+
+// Flush sends the response written so far to the client, compressing it if it can be
+// compressed. It implements http.Flusher, which streaming handlers rely on.
+func (w *compressResponseWriter) Flush() {
+	if !w.started {
+		if w.status == 0 {
+			w.status = http.StatusOK
+		}
+		_ = w.start(true)
+	}
+	if w.cw != nil {
+		_ = w.cw.Flush()
+	}
+	if f, ok := w.ResponseWriter.(http.Flusher); ok {
+		f.Flush()
+	}
+}
+
+// close finishes the response once the handler returned.
+func (w *compressResponseWriter) close() {
+	if !w.started {
+		if w.status == 0 {
+			// Nothing was written, so we leave it to net/http to respond.
+			return
+		}
+		// The response is smaller than minCompressSize.
+		_ = w.start(false)
+	}
+	if w.cw == nil {
+		return
+	}
+
+	_ = w.cw.Close()
+	switch cw := w.cw.(type) {
+	case *brotli.Writer:
+		brotliWriters.Put(cw)
+	case *gzip.Writer:
+		gzipWriters.Put(cw)
+	}
+	w.cw = nil
+}
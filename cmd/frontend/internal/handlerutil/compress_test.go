@@ -0,0 +1,147 @@
+package handlerutil
+
+import (
+	"compress/gzip"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestNegotiateEncoding(t *testing.T) {
+	offers := []string{"br", "gzip"}
+	for _, tc := range []struct {
+		acceptEncoding string
+		want           string
+	}{
+		{"", ""},
+		{"identity", ""},
+		{"gzip", "gzip"},
+		{"gzip, deflate, br", "br"},
+		{"br;q=0.5, gzip", "gzip"},
+		{"br;q=0, gzip;q=0.1", "gzip"},
+		{"*", "br"},
+		{"*;q=0.5, br;q=0", "gzip"},
+		{"GZIP", "gzip"},
+	} {
+		if got := negotiateEncoding(tc.acceptEncoding, offers); got != tc.want {
+			t.Errorf("negotiateEncoding(%q) = %q, want %q", tc.acceptEncoding, got, tc.want)
+		}
+	}
+}
+
+func TestCompress(t *testing.T) {
+	large := strings.Repeat("compressible ", minCompressSize)
+
+	serve := func(route CompressionRoute, acceptEncoding string, h http.HandlerFunc) *httptest.ResponseRecorder {
+		t.Helper()
+		req := httptest.NewRequest("GET", "/", nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		rec := httptest.NewRecorder()
+		Compress(route, h).ServeHTTP(rec, req)
+		return rec
+	}
+	write := func(contentType, body string) http.HandlerFunc {
+		return func(w http.ResponseWriter, r *http.Request) {
+			w.Header().Set("Content-Type", contentType)
+			w.Header().Set("ETag", `"v1"`)
+			_, _ = io.WriteString(w, body)
+		}
+	}
+	decode := func(t *testing.T, rec *httptest.ResponseRecorder) string {
+		t.Helper()
+		var r io.Reader
+		switch encoding := rec.Header().Get("Content-Encoding"); encoding {
+		case "br":
+			r = brotli.NewReader(rec.Body)
+		case "gzip":
+			zr, err := gzip.NewReader(rec.Body)
+			if err != nil {
+				t.Fatal(err)
+			}
+			r = zr
+		default:
+			t.Fatalf("unexpected Content-Encoding %q", encoding)
+		}
+		b, err := io.ReadAll(r)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(b)
+	}
+
+	t.Run("compresses large responses", func(t *testing.T) {
+		for _, encoding := range []string{"br", "gzip"} {
+			rec := serve(CompressionRouteDefault, encoding, write("text/plain", large))
+			if got := decode(t, rec); got != large {
+				t.Errorf("%s: got %d bytes, want %d", encoding, len(got), len(large))
+			}
+			if rec.Body.Len() >= len(large) {
+				t.Errorf("%s: response was not compressed", encoding)
+			}
+			if got, want := rec.Header().Get("ETag"), `W/"v1"`; got != want {
+				t.Errorf("%s: got ETag %q, want %q", encoding, got, want)
+			}
+			if got, want := rec.Header().Get("Vary"), "Accept-Encoding"; got != want {
+				t.Errorf("%s: got Vary %q, want %q", encoding, got, want)
+			}
+		}
+	})
+
+	t.Run("does not compress small or incompressible responses", func(t *testing.T) {
+		for name, rec := range map[string]*httptest.ResponseRecorder{
+			"small":    serve(CompressionRouteDefault, "gzip", write("text/plain", "small")),
+			"zip":      serve(CompressionRouteDefault, "gzip", write("application/zip", large)),
+			"identity": serve(CompressionRouteDefault, "identity", write("text/plain", large)),
+		} {
+			if got := rec.Header().Get("Content-Encoding"); got != "" {
+				t.Errorf("%s: got Content-Encoding %q, want none", name, got)
+			}
+			if got, want := rec.Header().Get("ETag"), `"v1"`; got != want {
+				t.Errorf("%s: got ETag %q, want %q", name, got, want)
+			}
+		}
+	})
+
+	t.Run("compresses flushed responses as they are written", func(t *testing.T) {
+		rec := serve(CompressionRouteSearchStream, "gzip", func(w http.ResponseWriter, r *http.Request) {
+			_, _ = io.WriteString(w, "event: progress\n\n")
+			w.(http.Flusher).Flush()
+			if !recorder(w).Flushed {
+				t.Error("response was not flushed")
+			}
+			_, _ = io.WriteString(w, "event: done\n\n")
+		})
+		if got, want := decode(t, rec), "event: progress\n\nevent: done\n\n"; got != want {
+			t.Errorf("got %q, want %q", got, want)
+		}
+	})
+
+	t.Run("uses the encodings configured for the route", func(t *testing.T) {
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{HttpCompression: &schema.HttpCompression{
+			Raw:          []string{},
+			SearchStream: []string{"gzip"},
+		}}})
+		defer conf.Mock(nil)
+
+		if got := serve(CompressionRouteRaw, "br, gzip", write("text/plain", large)).Header().Get("Content-Encoding"); got != "" {
+			t.Errorf("raw: got Content-Encoding %q, want none", got)
+		}
+		if got := serve(CompressionRouteSearchStream, "br, gzip", write("text/plain", large)).Header().Get("Content-Encoding"); got != "gzip" {
+			t.Errorf("searchStream: got Content-Encoding %q, want gzip", got)
+		}
+		if got := serve(CompressionRouteGraphQL, "br, gzip", write("text/plain", large)).Header().Get("Content-Encoding"); got != "br" {
+			t.Errorf("graphql: got Content-Encoding %q, want br", got)
+		}
+	})
+}
+
+func recorder(w http.ResponseWriter) *httptest.ResponseRecorder {
+	return w.(*compressResponseWriter).ResponseWriter.(*httptest.ResponseRecorder)
+}
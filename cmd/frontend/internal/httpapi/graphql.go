@@ -18,11 +18,14 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/search"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/cookie"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/honey"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/trace/requestid"
 )
 
-func serveGraphQL(schema *graphql.Schema, rlw graphqlbackend.LimitWatcher, isInternal bool) func(w http.ResponseWriter, r *http.Request) (err error) {
+func serveGraphQL(db dbutil.DB, schema *graphql.Schema, rlw graphqlbackend.LimitWatcher, isInternal bool) func(w http.ResponseWriter, r *http.Request) (err error) {
 	return func(w http.ResponseWriter, r *http.Request) (err error) {
 		if r.Method != "POST" {
 			// The URL router should not have routed to this handler if method is not POST, but just in
@@ -41,6 +44,7 @@ func serveGraphQL(schema *graphql.Schema, rlw graphqlbackend.LimitWatcher, isInt
 		// Used by the prometheus tracer
 		r = r.WithContext(trace.WithGraphQLRequestName(r.Context(), requestName))
 		r = r.WithContext(trace.WithRequestSource(r.Context(), requestSource))
+		r = r.WithContext(graphqlbackend.WithAcceptLanguage(r.Context(), r.Header.Get("Accept-Language")))
 
 		if r.Header.Get("Content-Encoding") == "gzip" {
 			gzipReader, err := gzip.NewReader(r.Body)
@@ -63,13 +67,14 @@ func serveGraphQL(schema *graphql.Schema, rlw graphqlbackend.LimitWatcher, isInt
 			isInternal:    isInternal,
 			requestName:   requestName,
 			requestSource: string(requestSource),
+			requestID:     requestid.FromContext(r.Context()),
 		}
 
 		defer func() {
 			traceGraphQL(traceData)
 		}()
 
-		uid, isIP, anonymous := getUID(r)
+		uid, _, anonymous := getUID(r)
 		traceData.uid = uid
 		traceData.anonymous = anonymous
 
@@ -89,36 +94,53 @@ func serveGraphQL(schema *graphql.Schema, rlw graphqlbackend.LimitWatcher, isInt
 			traceData.cost = cost
 
 			if rl, enabled := rlw.Get(); enabled && cost != nil {
-				limited, result, err := rl.RateLimit(uid, cost.FieldCount, graphqlbackend.LimiterArgs{
-					IsIP:          isIP,
-					Anonymous:     anonymous,
-					RequestName:   requestName,
-					RequestSource: requestSource,
-				})
+				limited, result, err := checkRateLimit(w, rl, "graphql", getRateLimitActor(r, requestName), cost.FieldCount)
 				if err != nil {
-					log15.Error("checking GraphQL rate limit", "error", err)
+					trace.Logger(r.Context()).Error("checking GraphQL rate limit", "error", err)
 					traceData.limitError = err
 				} else {
 					traceData.limited = limited
 					traceData.limitResult = result
 					if limited {
-						w.Header().Set("Retry-After", strconv.Itoa(int(result.RetryAfter.Seconds())))
-						w.WriteHeader(http.StatusTooManyRequests)
 						return nil
 					}
 				}
 			}
 		}
 
+		idempotentRequest, done, err := beginIdempotentRequest(w, r, database.IdempotencyKeys(db), params)
+		if err != nil || done {
+			return err
+		}
+		defer idempotentRequest.release()
+
 		traceData.execStart = time.Now()
 		response := schema.Exec(r.Context(), params.Query, params.OperationName, params.Variables)
 		traceData.queryErrors = response.Errors
+		// Include the request ID in errors, so that users can quote it when reporting them.
+		if traceData.requestID != "" {
+			for _, err := range response.Errors {
+				if err.Extensions == nil {
+					err.Extensions = map[string]interface{}{}
+				}
+				err.Extensions["requestID"] = traceData.requestID
+			}
+		}
 		responseJSON, err := json.Marshal(response)
 		if err != nil {
 			return err
 		}
 
+		if idempotentRequest != nil {
+			if err := idempotentRequest.finish(response, responseJSON); err != nil {
+				return err
+			}
+		}
+
 		w.Header().Set("Content-Type", "application/json")
+		// Responses depend on the viewer and change at any time, so they must not be stored by
+		// the browser or by proxies.
+		w.Header().Set("Cache-Control", "no-store")
 		w.Write(responseJSON)
 
 		return nil
@@ -139,6 +161,7 @@ type traceData struct {
 	isInternal    bool
 	requestName   string
 	requestSource string
+	requestID     string
 	queryErrors   []*gqlerrors.QueryError
 
 	cost      *graphqlbackend.QueryCost
@@ -173,6 +196,7 @@ func traceGraphQL(data traceData) {
 	ev.AddField("hasQueryErrors", len(data.queryErrors) > 0)
 	ev.AddField("requestName", data.requestName)
 	ev.AddField("requestSource", data.requestSource)
+	ev.AddField("requestID", data.requestID)
 
 	if data.costError != nil {
 		ev.AddField("hasCostError", true)
@@ -0,0 +1,196 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/graph-gophers/graphql-go"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+// idempotencyKeyHeader is the header with which clients make GraphQL mutations
+// idempotent. A retry of a mutation with the same key returns the response of
+// the first request instead of running the mutation again.
+const idempotencyKeyHeader = "Idempotency-Key"
+
+// maxIdempotencyKeyLength is the maximum length of an idempotency key.
+const maxIdempotencyKeyLength = 255
+
+// idempotencyKeyStore is the subset of database.IdempotencyKeyStore used by
+// serveGraphQL.
+type idempotencyKeyStore interface {
+	Reserve(ctx context.Context, userID int32, key string, requestHash []byte) (*database.IdempotencyKey, bool, error)
+	Heartbeat(ctx context.Context, userID int32, key string, requestHash []byte) error
+	SetResponse(ctx context.Context, userID int32, key string, requestHash, response []byte) error
+	Delete(ctx context.Context, userID int32, key string) error
+}
+
+// idempotentRequest is a GraphQL mutation which reserved an idempotency key.
+type idempotentRequest struct {
+	store          idempotencyKeyStore
+	userID         int32
+	key            string
+	requestHash    []byte
+	returnsSecrets bool
+
+	// stopHeartbeat stops renewing the reservation of the key.
+	stopHeartbeat context.CancelFunc
+}
+
+// secretResponseJSON is stored instead of the response of mutations that
+// return secrets, so that secrets are never written to the database. Retries
+// get an error instead of the secret.
+var secretResponseJSON = []byte(`{"errors":[{"message":"a request with the same Idempotency-Key header already succeeded, but its response contains a secret and is not stored"}]}`)
+
+// beginIdempotentRequest reserves the idempotency key of the GraphQL request
+// r, if it has one. If the key was already used, it writes the response of the
+// earlier request, or an error if the requests differ or the earlier request
+// is still in progress, and returns true.
+//
+// It returns a nil idempotentRequest if the request doesn't have to be made
+// idempotent, e.g. because it is not a mutation.
+func beginIdempotentRequest(w http.ResponseWriter, r *http.Request, store idempotencyKeyStore, params graphQLQueryParams) (_ *idempotentRequest, done bool, _ error) {
+	key := r.Header.Get(idempotencyKeyHeader)
+	if key == "" {
+		return nil, false, nil
+	}
+	if len(key) > maxIdempotencyKeyLength {
+		http.Error(w, "the Idempotency-Key header must be at most 255 characters long", http.StatusBadRequest)
+		return nil, true, nil
+	}
+
+	// Idempotency keys are scoped to users. The mutations that support them
+	// require an authenticated user anyway.
+	a := actor.FromContext(r.Context())
+	if !a.IsAuthenticated() {
+		return nil, false, nil
+	}
+
+	m, mutation, err := graphqlbackend.IsIdempotentMutation(params.Query, params.OperationName)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return nil, true, nil
+	}
+	if !mutation {
+		return nil, false, nil
+	}
+
+	requestHash, err := hashGraphQLRequest(params)
+	if err != nil {
+		return nil, false, err
+	}
+
+	existing, reserved, err := store.Reserve(r.Context(), a.UID, key, requestHash)
+	if err != nil {
+		return nil, false, err
+	}
+	if reserved {
+		req := &idempotentRequest{
+			store:          store,
+			userID:         a.UID,
+			key:            key,
+			requestHash:    requestHash,
+			returnsSecrets: m.ReturnsSecrets,
+		}
+		req.startHeartbeat()
+		return req, false, nil
+	}
+
+	switch {
+	case !bytes.Equal(existing.RequestHash, requestHash):
+		http.Error(w, "the Idempotency-Key header was already used for a different request", http.StatusUnprocessableEntity)
+	case existing.Response == nil:
+		http.Error(w, "a request with the same Idempotency-Key header is still in progress", http.StatusConflict)
+	default:
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		w.Header().Set("Idempotent-Replayed", "true")
+		_, _ = w.Write(existing.Response)
+	}
+	return nil, true, nil
+}
+
+// startHeartbeat renews the reservation of the idempotency key until
+// stopHeartbeat is called, so that retries don't run the mutation again while
+// it is still in progress, however long it takes.
+func (req *idempotentRequest) startHeartbeat() {
+	ctx, cancel := context.WithCancel(context.Background())
+	req.stopHeartbeat = cancel
+
+	goroutine.Go(func() {
+		ticker := time.NewTicker(database.IdempotencyKeyHeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := req.store.Heartbeat(ctx, req.userID, req.key, req.requestHash); err != nil && ctx.Err() == nil {
+					log15.Warn("renewing GraphQL idempotency key", "error", err)
+				}
+			}
+		}
+	})
+}
+
+// release stops renewing the reservation of the idempotency key. It is safe to
+// call on a nil idempotentRequest and more than once.
+func (req *idempotentRequest) release() {
+	if req != nil && req.stopHeartbeat != nil {
+		req.stopHeartbeat()
+	}
+}
+
+// finish stores the response of the mutation so that it is replayed for
+// retries. If the mutation failed without returning data, the idempotency key
+// is released instead, so that a retry runs the mutation again. The responses
+// of mutations that return secrets are replaced by an error.
+func (req *idempotentRequest) finish(response *graphql.Response, responseJSON []byte) error {
+	req.release()
+
+	// The response is stored even if the client went away, since that is
+	// when it retries the request.
+	ctx := context.Background()
+
+	if len(response.Data) == 0 || bytes.Equal(response.Data, []byte("null")) {
+		return req.store.Delete(ctx, req.userID, req.key)
+	}
+	if req.returnsSecrets {
+		responseJSON = secretResponseJSON
+	}
+	return req.store.SetResponse(ctx, req.userID, req.key, req.requestHash, responseJSON)
+}
+
+// hashGraphQLRequest returns a hash of the query, operation name and
+// variables of a GraphQL request.
+func hashGraphQLRequest(params graphQLQueryParams) ([]byte, error) {
+	// Maps are marshalled with sorted keys, so equal variables have the same
+	// encoding.
+	b, err := json.Marshal(params)
+	if err != nil {
+		return nil, err
+	}
+	sum := sha256.Sum256(b)
+	return sum[:], nil
+}
+
+// NewIdempotencyKeyJanitor returns a background routine that deletes the
+// expired idempotency keys of GraphQL mutations.
+func NewIdempotencyKeyJanitor(ctx context.Context, db dbutil.DB) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(ctx, time.Hour, goroutine.NewHandlerWithErrorMessage(
+		"delete expired GraphQL idempotency keys",
+		func(ctx context.Context) error {
+			return database.IdempotencyKeys(db).DeleteExpired(ctx)
+		},
+	))
+}
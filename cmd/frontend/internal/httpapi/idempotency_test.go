@@ -0,0 +1,151 @@
+package httpapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/graph-gophers/graphql-go"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+type fakeIdempotencyKeyStore struct {
+	keys map[string]*database.IdempotencyKey
+}
+
+func (s *fakeIdempotencyKeyStore) Reserve(_ context.Context, userID int32, key string, requestHash []byte) (*database.IdempotencyKey, bool, error) {
+	if k, ok := s.keys[key]; ok {
+		return k, false, nil
+	}
+	s.keys[key] = &database.IdempotencyKey{UserID: userID, Key: key, RequestHash: requestHash}
+	return s.keys[key], true, nil
+}
+
+func (s *fakeIdempotencyKeyStore) Heartbeat(context.Context, int32, string, []byte) error {
+	return nil
+}
+
+func (s *fakeIdempotencyKeyStore) SetResponse(_ context.Context, _ int32, key string, requestHash, response []byte) error {
+	if k := s.keys[key]; k != nil && bytes.Equal(k.RequestHash, requestHash) && k.Response == nil {
+		k.Response = response
+	}
+	return nil
+}
+
+func (s *fakeIdempotencyKeyStore) Delete(_ context.Context, _ int32, key string) error {
+	delete(s.keys, key)
+	return nil
+}
+
+func TestIdempotentRequest(t *testing.T) {
+	const mutation = `mutation { applyBatchChange(batchSpec: "QmF0Y2hTcGVjOjE=") { id } }`
+	store := &fakeIdempotencyKeyStore{keys: map[string]*database.IdempotencyKey{}}
+
+	begin := func(t *testing.T, key string, params graphQLQueryParams) (*idempotentRequest, bool, *httptest.ResponseRecorder) {
+		t.Helper()
+		r := httptest.NewRequest("POST", "/.api/graphql", nil)
+		r = r.WithContext(actor.WithActor(r.Context(), actor.FromUser(1)))
+		if key != "" {
+			r.Header.Set(idempotencyKeyHeader, key)
+		}
+		w := httptest.NewRecorder()
+		req, done, err := beginIdempotentRequest(w, r, store, params)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return req, done, w
+	}
+
+	t.Run("no key", func(t *testing.T) {
+		if req, done, _ := begin(t, "", graphQLQueryParams{Query: mutation}); req != nil || done {
+			t.Fatalf("got request %v and done %v, want neither", req, done)
+		}
+	})
+
+	t.Run("query", func(t *testing.T) {
+		if req, done, _ := begin(t, "query", graphQLQueryParams{Query: `{ currentUser { username } }`}); req != nil || done {
+			t.Fatalf("got request %v and done %v, want neither", req, done)
+		}
+	})
+
+	t.Run("unsupported mutation", func(t *testing.T) {
+		_, done, w := begin(t, "unsupported", graphQLQueryParams{Query: `mutation { deleteUser(user: "VXNlcjox") { alwaysNil } }`})
+		if !done || w.Code != http.StatusBadRequest {
+			t.Fatalf("got done %v and status %d, want %d", done, w.Code, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("replays response", func(t *testing.T) {
+		params := graphQLQueryParams{Query: mutation}
+		req, done, _ := begin(t, "replay", params)
+		if req == nil || done {
+			t.Fatal("expected idempotency key to be reserved")
+		}
+
+		// Retries while the mutation is in progress are rejected.
+		if _, done, w := begin(t, "replay", params); !done || w.Code != http.StatusConflict {
+			t.Fatalf("got done %v and status %d, want %d", done, w.Code, http.StatusConflict)
+		}
+
+		responseJSON := []byte(`{"data":{"applyBatchChange":{"id":"QmF0Y2hDaGFuZ2U6MQ=="}}}`)
+		if err := req.finish(&graphql.Response{Data: json.RawMessage(`{"applyBatchChange":{"id":"QmF0Y2hDaGFuZ2U6MQ=="}}`)}, responseJSON); err != nil {
+			t.Fatal(err)
+		}
+
+		_, done, w := begin(t, "replay", params)
+		if !done || w.Code != http.StatusOK {
+			t.Fatalf("got done %v and status %d, want %d", done, w.Code, http.StatusOK)
+		}
+		if got := w.Body.String(); got != string(responseJSON) {
+			t.Errorf("got response %s, want %s", got, responseJSON)
+		}
+		if got := w.Header().Get("Idempotent-Replayed"); got != "true" {
+			t.Errorf("got Idempotent-Replayed header %q, want true", got)
+		}
+
+		// The key can't be reused for a different request.
+		params.Variables = map[string]interface{}{"a": 1}
+		if _, done, w := begin(t, "replay", params); !done || w.Code != http.StatusUnprocessableEntity {
+			t.Fatalf("got done %v and status %d, want %d", done, w.Code, http.StatusUnprocessableEntity)
+		}
+	})
+
+	t.Run("releases key of failed mutation", func(t *testing.T) {
+		params := graphQLQueryParams{Query: mutation}
+		req, _, _ := begin(t, "failed", params)
+		if req == nil {
+			t.Fatal("expected idempotency key to be reserved")
+		}
+		if err := req.finish(&graphql.Response{Data: json.RawMessage("null")}, []byte(`{"data":null}`)); err != nil {
+			t.Fatal(err)
+		}
+		if req, done, _ := begin(t, "failed", params); req == nil || done {
+			t.Fatal("expected idempotency key to be reserved again")
+		}
+	})
+
+	t.Run("doesn't store secrets", func(t *testing.T) {
+		params := graphQLQueryParams{Query: `mutation { createAccessToken(user: "VXNlcjox", scopes: ["user:all"], note: "n") { id token } }`}
+		req, _, _ := begin(t, "secret", params)
+		if req == nil {
+			t.Fatal("expected idempotency key to be reserved")
+		}
+		data := json.RawMessage(`{"createAccessToken":{"id":"QWNjZXNzVG9rZW46MQ==","token":"s3cr3t"}}`)
+		if err := req.finish(&graphql.Response{Data: data}, []byte(`{"data":`+string(data)+`}`)); err != nil {
+			t.Fatal(err)
+		}
+		if got := store.keys["secret"].Response; string(got) != string(secretResponseJSON) {
+			t.Fatalf("got stored response %s, want %s", got, secretResponseJSON)
+		}
+
+		_, done, w := begin(t, "secret", params)
+		if !done || w.Body.String() != string(secretResponseJSON) {
+			t.Fatalf("got done %v and response %s, want %s", done, w.Body.String(), secretResponseJSON)
+		}
+	})
+}
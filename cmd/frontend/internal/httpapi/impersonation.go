@@ -0,0 +1,93 @@
+package httpapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// ImpersonationMiddleware restricts the requests of impersonation sessions to
+// read operations and records every one of them in the security event log.
+//
+// 🚨 SECURITY: It must run after the middlewares that authenticate the request,
+// since impersonation is determined by the actor of the request.
+func ImpersonationMiddleware(db dbutil.DB, next http.Handler) http.Handler {
+	return impersonationMiddleware(next, func(ctx context.Context, args *graphqlbackend.ImpersonationEventArgs) {
+		graphqlbackend.LogImpersonationEvent(ctx, db, database.SecurityEventNameImpersonatedRequest, args)
+	})
+}
+
+func impersonationMiddleware(next http.Handler, logEvent func(context.Context, *graphqlbackend.ImpersonationEventArgs)) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		a := actor.FromContext(r.Context())
+		if !a.IsImpersonated() {
+			next.ServeHTTP(w, r)
+			return
+		}
+
+		args := &graphqlbackend.ImpersonationEventArgs{
+			ImpersonatorUserID: a.ImpersonatorUID,
+			UserID:             a.UID,
+			RequestMethod:      r.Method,
+			RequestPath:        r.URL.Path,
+		}
+
+		readOnly := false
+		switch {
+		case r.Method == http.MethodGet || r.Method == http.MethodHead || r.Method == http.MethodOptions:
+			readOnly = true
+		case r.Method == http.MethodPost && r.URL.Path == "/.api/graphql":
+			// GraphQL queries are sent as POST requests, so the operation
+			// decides whether the request may modify data.
+			params, err := peekGraphQLParams(r)
+			if err != nil {
+				http.Error(w, "invalid GraphQL request", http.StatusBadRequest)
+				return
+			}
+			args.RequestName = r.URL.RawQuery
+			args.OperationName = params.OperationName
+			readOnly = graphqlbackend.IsReadOnlyOperation(params.Query, params.OperationName, "stopImpersonation")
+		}
+		if !readOnly {
+			http.Error(w, "only read operations are allowed while impersonating a user", http.StatusForbidden)
+			return
+		}
+
+		logEvent(r.Context(), args)
+		next.ServeHTTP(w, r)
+	})
+}
+
+// peekGraphQLParams decodes the parameters of the GraphQL request r, leaving
+// its body intact for the GraphQL handler.
+func peekGraphQLParams(r *http.Request) (*graphQLQueryParams, error) {
+	body, err := io.ReadAll(r.Body)
+	if err != nil {
+		return nil, err
+	}
+	r.Body = io.NopCloser(bytes.NewReader(body))
+
+	var reader io.Reader = bytes.NewReader(body)
+	if r.Header.Get("Content-Encoding") == "gzip" {
+		gzipReader, err := gzip.NewReader(reader)
+		if err != nil {
+			return nil, err
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	}
+
+	var params graphQLQueryParams
+	if err := json.NewDecoder(reader).Decode(&params); err != nil {
+		return nil, err
+	}
+	return &params, nil
+}
@@ -0,0 +1,103 @@
+package httpapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+func TestImpersonationMiddleware(t *testing.T) {
+	var (
+		events []*graphqlbackend.ImpersonationEventArgs
+		bodies []string
+	)
+	handler := impersonationMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(body))
+	}), func(_ context.Context, args *graphqlbackend.ImpersonationEventArgs) {
+		events = append(events, args)
+	})
+
+	impersonated := &actor.Actor{UID: 1, ImpersonatorUID: 2}
+	serve := func(a *actor.Actor, method, target, body string) int {
+		req := httptest.NewRequest(method, target, strings.NewReader(body))
+		req = req.WithContext(actor.WithActor(context.Background(), a))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		return rr.Code
+	}
+
+	t.Run("not impersonated", func(t *testing.T) {
+		events, bodies = nil, nil
+		if code := serve(actor.FromUser(1), "POST", "/.api/lsif/upload", "data"); code != http.StatusOK {
+			t.Fatalf("got status %d, want %d", code, http.StatusOK)
+		}
+		if len(events) != 0 {
+			t.Fatalf("got %d events, want none", len(events))
+		}
+	})
+
+	for _, tc := range []struct {
+		name       string
+		method     string
+		target     string
+		body       string
+		wantStatus int
+	}{
+		{"page", "GET", "/github.com/foo/bar", "", http.StatusOK},
+		{"upload", "POST", "/.api/lsif/upload", "data", http.StatusForbidden},
+		{"delete", "DELETE", "/.api/some/resource", "", http.StatusForbidden},
+		{"graphql query", "POST", "/.api/graphql?CurrentUser", `{"query": "query CurrentUser { currentUser { id } }"}`, http.StatusOK},
+		{"graphql mutation", "POST", "/.api/graphql?DeleteUser", `{"query": "mutation DeleteUser { deleteUser(user: \"x\") { alwaysNil } }"}`, http.StatusForbidden},
+		{"graphql stop impersonation", "POST", "/.api/graphql", `{"query": "mutation { stopImpersonation { alwaysNil } }"}`, http.StatusOK},
+		{"invalid graphql", "POST", "/.api/graphql", `{`, http.StatusBadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			events, bodies = nil, nil
+			if code := serve(impersonated, tc.method, tc.target, tc.body); code != tc.wantStatus {
+				t.Fatalf("got status %d, want %d", code, tc.wantStatus)
+			}
+			if tc.wantStatus != http.StatusOK {
+				if len(events) != 0 || len(bodies) != 0 {
+					t.Fatalf("rejected request was served or logged")
+				}
+				return
+			}
+
+			if len(events) != 1 {
+				t.Fatalf("got %d events, want 1", len(events))
+			}
+			if e := events[0]; e.ImpersonatorUserID != 2 || e.UserID != 1 || e.RequestMethod != tc.method {
+				t.Errorf("unexpected event %+v", e)
+			}
+			// The handler must still be able to read the body.
+			if len(bodies) != 1 || bodies[0] != tc.body {
+				t.Errorf("got bodies %q, want %q", bodies, tc.body)
+			}
+		})
+	}
+
+	t.Run("gzipped graphql mutation", func(t *testing.T) {
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		_, _ = zw.Write([]byte(`{"query": "mutation { deleteUser(user: \"x\") { alwaysNil } }"}`))
+		_ = zw.Close()
+
+		req := httptest.NewRequest("POST", "/.api/graphql", &buf)
+		req.Header.Set("Content-Encoding", "gzip")
+		req = req.WithContext(actor.WithActor(context.Background(), impersonated))
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+		if rr.Code != http.StatusForbidden {
+			t.Fatalf("got status %d, want %d", rr.Code, http.StatusForbidden)
+		}
+	})
+}
@@ -195,6 +195,14 @@ func repoRankFromConfig(siteConfig schema.SiteConfiguration, repoName string) fl
 func serveSearchConfiguration(w http.ResponseWriter, r *http.Request) error {
 	ctx := r.Context()
 	siteConfig := conf.Get().SiteConfiguration
+
+	// Repositories with corrupt shards are reindexed. Failing to find out
+	// which repositories are affected should not stop indexing.
+	corrupt, err := searchbackend.CorruptShards.List()
+	if err != nil {
+		log15.Warn("listing repositories with corrupt shards", "error", err)
+	}
+
 	getRepoIndexOptions := func(repoName string) (*searchbackend.RepoIndexOptions, error) {
 		repo, err := database.GlobalRepos.GetByName(ctx, api.RepoName(repoName))
 		if err != nil {
@@ -213,6 +221,7 @@ func serveSearchConfiguration(w http.ResponseWriter, r *http.Request) error {
 		}
 
 		priority := float64(repo.Stars) + repoRankFromConfig(siteConfig, repoName)
+		_, reindex := corrupt[repo.ID]
 
 		return &searchbackend.RepoIndexOptions{
 			RepoID:     int32(repo.ID),
@@ -220,6 +229,7 @@ func serveSearchConfiguration(w http.ResponseWriter, r *http.Request) error {
 			Priority:   priority,
 			Fork:       repo.Fork,
 			Archived:   repo.Archived,
+			Reindex:    reindex,
 			GetVersion: getVersion,
 		}, nil
 	}
@@ -263,8 +273,40 @@ type reposListServer struct {
 		// Enabled is true if horizontal indexed search is enabled.
 		Enabled() bool
 	}
+
+	// OnDemand is the subset of searchbackend.OnDemandIndex methods we use.
+	// If on-demand indexing is enabled, only the repositories returned by
+	// Filter are indexed. Declared as an interface for testing.
+	OnDemand interface {
+		// Filter returns the subset of repoNames that should be indexed.
+		Filter(repoNames []string) ([]string, error)
+		// Enabled is true if on-demand indexing is enabled.
+		Enabled() bool
+	}
+
+	// SearchTraffic is used to order the repositories by how often they are
+	// searched, so that zoekt reindexes frequently searched repositories
+	// first. Declared as an interface for testing.
+	SearchTraffic interface {
+		// Scores returns the decayed search counts of repositories.
+		Scores(ctx context.Context) (map[api.RepoName]float64, error)
+		// Enabled is true if ordering by search traffic is enabled.
+		Enabled() bool
+	}
+}
+
+// searchTrafficScores reads the search traffic scores of
+// searchbackend.SearchTraffic from the database.
+type searchTrafficScores struct {
+	db dbutil.DB
 }
 
+func (s searchTrafficScores) Scores(ctx context.Context) (map[api.RepoName]float64, error) {
+	return database.RepoSearchFrequencies(s.db).Scores(ctx, searchbackend.SearchTraffic.HalfLife())
+}
+
+func (searchTrafficScores) Enabled() bool { return searchbackend.SearchTraffic.Enabled() }
+
 // serveIndex is used by zoekt to get the list of repositories for it to
 // index.
 func (h *reposListServer) serveIndex(w http.ResponseWriter, r *http.Request) error {
@@ -300,6 +342,14 @@ func (h *reposListServer) serveIndex(w http.ResponseWriter, r *http.Request) err
 		}
 	}
 
+	if h.OnDemand != nil && h.OnDemand.Enabled() {
+		var err error
+		names, err = h.OnDemand.Filter(names)
+		if err != nil {
+			return errors.Wrap(err, "filtering on-demand repos")
+		}
+	}
+
 	if h.Indexers.Enabled() {
 		indexed := make(map[string]struct{}, len(opt.Indexed))
 		for _, name := range opt.Indexed {
@@ -313,6 +363,14 @@ func (h *reposListServer) serveIndex(w http.ResponseWriter, r *http.Request) err
 		}
 	}
 
+	if h.SearchTraffic != nil && h.SearchTraffic.Enabled() {
+		scores, err := h.SearchTraffic.Scores(r.Context())
+		if err != nil {
+			return errors.Wrap(err, "getting search traffic")
+		}
+		searchbackend.SortBySearchTraffic(names, scores)
+	}
+
 	data := struct {
 		RepoNames []string
 	}{
@@ -103,6 +103,30 @@ func TestReposIndex(t *testing.T) {
 		},
 		body: `{"Hostname": "foo"}`,
 		want: []string{"github.com/popular/foo"},
+	}, {
+		name: "on-demand",
+		srv: &reposListServer{
+			Repos: &mockRepos{
+				indexableRepos: indexableRepos,
+				repos:          allRepos,
+			},
+			Indexers: suffixIndexers(false),
+			OnDemand: prefixOnDemand("github.com/popular/"),
+		},
+		body: `{"Hostname": "foo"}`,
+		want: []string{"github.com/popular/foo", "github.com/popular/bar"},
+	}, {
+		name: "search traffic",
+		srv: &reposListServer{
+			Repos: &mockRepos{
+				indexableRepos: indexableRepos,
+				repos:          allRepos,
+			},
+			Indexers:      suffixIndexers(false),
+			SearchTraffic: staticSearchTraffic{"github.com/alice/bar": 3, "github.com/popular/bar": 1},
+		},
+		body: `{"Hostname": "foo"}`,
+		want: []string{"github.com/alice/bar", "github.com/popular/bar", "github.com/popular/foo", "github.com/alice/foo"},
 	}, {
 		name: "none",
 		srv: &reposListServer{
@@ -225,3 +249,31 @@ func TestRepoRankFromConfig(t *testing.T) {
 		}
 	}
 }
+
+// prefixOnDemand mocks OnDemand. Filter will return all repoNames with the
+// prefix.
+type prefixOnDemand string
+
+func (p prefixOnDemand) Filter(repoNames []string) ([]string, error) {
+	var filter []string
+	for _, name := range repoNames {
+		if strings.HasPrefix(name, string(p)) {
+			filter = append(filter, name)
+		}
+	}
+	return filter, nil
+}
+
+func (p prefixOnDemand) Enabled() bool {
+	return true
+}
+
+type staticSearchTraffic map[api.RepoName]float64
+
+func (s staticSearchTraffic) Scores(context.Context) (map[api.RepoName]float64, error) {
+	return s, nil
+}
+
+func (s staticSearchTraffic) Enabled() bool {
+	return true
+}
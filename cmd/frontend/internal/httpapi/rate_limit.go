@@ -0,0 +1,116 @@
+package httpapi
+
+import (
+	"context"
+	"math"
+	"net/http"
+	"strconv"
+
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/throttled/throttled/v2"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/internal/search"
+)
+
+var rateLimitedRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+	Name: "src_frontend_rate_limited_requests_total",
+	Help: "The number of requests rejected because the actor exceeded its rate limit.",
+}, []string{"endpoint", "actor"})
+
+// searchRequestCost is the rate limit cost of a search request, in the unit of
+// the GraphQL query cost (fields).
+const searchRequestCost = 1
+
+type accessTokenKey struct{}
+
+// withAccessToken records that the request was authenticated with an access
+// token, so that limited requests are counted separately in metrics. Requests
+// with a token share the rate limit of the token's user, so that users can't
+// raise their limit by creating more tokens.
+func withAccessToken(ctx context.Context) context.Context {
+	return context.WithValue(ctx, accessTokenKey{}, true)
+}
+
+// rateLimitActor identifies the actor of a request for rate limiting.
+type rateLimitActor struct {
+	// key is the key the actor is rate limited by.
+	key string
+	// kind is one of user, token, anonymous or ip. It is used as a metric label.
+	kind string
+	args graphqlbackend.LimiterArgs
+}
+
+func getRateLimitActor(r *http.Request, requestName string) rateLimitActor {
+	uid, isIP, anonymous := getUID(r)
+	a := rateLimitActor{
+		key: uid,
+		args: graphqlbackend.LimiterArgs{
+			IsIP:          isIP,
+			Anonymous:     anonymous,
+			RequestName:   requestName,
+			RequestSource: search.GuessSource(r),
+		},
+	}
+
+	switch {
+	case isIP:
+		a.kind = "ip"
+	case anonymous:
+		a.kind = "anonymous"
+	default:
+		a.kind = "user"
+		a.args.UID = uid
+		if token, _ := r.Context().Value(accessTokenKey{}).(bool); token {
+			a.kind = "token"
+		}
+	}
+	return a
+}
+
+// checkRateLimit checks whether the actor of a request to the given endpoint
+// has exceeded its rate limit. It sets the RateLimit-Limit, RateLimit-Remaining
+// and RateLimit-Reset headers of the response and, if the request is limited,
+// responds with 429 Too Many Requests. Requests are not limited if the limit
+// can't be checked.
+func checkRateLimit(w http.ResponseWriter, rl graphqlbackend.Limiter, endpoint string, a rateLimitActor, cost int) (bool, throttled.RateLimitResult, error) {
+	limited, result, err := rl.RateLimit(a.key, cost, a.args)
+	if err != nil {
+		return false, result, err
+	}
+
+	if result.Limit > 0 {
+		w.Header().Set("RateLimit-Limit", strconv.Itoa(result.Limit))
+		w.Header().Set("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		w.Header().Set("RateLimit-Reset", strconv.Itoa(int(math.Ceil(result.ResetAfter.Seconds()))))
+	}
+
+	if limited {
+		rateLimitedRequests.WithLabelValues(endpoint, a.kind).Inc()
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(result.RetryAfter.Seconds()))))
+		w.WriteHeader(http.StatusTooManyRequests)
+	}
+	return limited, result, nil
+}
+
+// RateLimitHandler rate limits requests to the search endpoint next by the
+// limits configured for the GraphQL API.
+func RateLimitHandler(rlw graphqlbackend.LimitWatcher, endpoint string, next http.Handler) http.Handler {
+	if rlw == nil {
+		return next
+	}
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if rl, enabled := rlw.Get(); enabled {
+			limited, _, err := checkRateLimit(w, rl, endpoint, getRateLimitActor(r, endpoint), searchRequestCost)
+			if err != nil {
+				log15.Error("checking search rate limit", "endpoint", endpoint, "error", err)
+			} else if limited {
+				return
+			}
+		}
+		next.ServeHTTP(w, r)
+	})
+}
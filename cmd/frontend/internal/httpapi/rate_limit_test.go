@@ -0,0 +1,83 @@
+package httpapi
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/throttled/throttled/v2"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+type mockLimiter struct {
+	keys   []string
+	args   []graphqlbackend.LimiterArgs
+	result throttled.RateLimitResult
+}
+
+func (l *mockLimiter) RateLimit(key string, _ int, args graphqlbackend.LimiterArgs) (bool, throttled.RateLimitResult, error) {
+	l.keys = append(l.keys, key)
+	l.args = append(l.args, args)
+	return l.result.Remaining == 0, l.result, nil
+}
+
+type mockLimitWatcher struct{ limiter *mockLimiter }
+
+func (w mockLimitWatcher) Get() (graphqlbackend.Limiter, bool) { return w.limiter, true }
+
+func TestRateLimitHandler(t *testing.T) {
+	limiter := &mockLimiter{result: throttled.RateLimitResult{
+		Limit:      10,
+		Remaining:  3,
+		ResetAfter: 1500 * time.Millisecond,
+	}}
+	handler := RateLimitHandler(mockLimitWatcher{limiter}, "search.stream", http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	}))
+
+	serve := func(ctx context.Context) *httptest.ResponseRecorder {
+		req := httptest.NewRequest("GET", "/search/stream?q=foo", nil).WithContext(ctx)
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		return rec
+	}
+
+	rec := serve(actor.WithActor(context.Background(), actor.FromUser(1)))
+	if rec.Code != http.StatusOK {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusOK)
+	}
+	for header, want := range map[string]string{
+		"RateLimit-Limit":     "10",
+		"RateLimit-Remaining": "3",
+		"RateLimit-Reset":     "2",
+	} {
+		if have := rec.Header().Get(header); have != want {
+			t.Errorf("%s: got %q, want %q", header, have, want)
+		}
+	}
+
+	// Requests authenticated with an access token share the limit of the
+	// token's user, so that creating more tokens doesn't raise the limit.
+	ctx := withAccessToken(actor.WithActor(context.Background(), actor.FromUser(1)))
+	limiter.result = throttled.RateLimitResult{Limit: 10, RetryAfter: 30 * time.Second}
+	rec = serve(ctx)
+	if rec.Code != http.StatusTooManyRequests {
+		t.Fatalf("got status %d, want %d", rec.Code, http.StatusTooManyRequests)
+	}
+	if have, want := rec.Header().Get("Retry-After"), "30"; have != want {
+		t.Errorf("Retry-After: got %q, want %q", have, want)
+	}
+
+	for _, have := range limiter.keys {
+		if want := "1"; have != want {
+			t.Errorf("got key %q, want %q", have, want)
+		}
+	}
+	if have, want := limiter.args[1].UID, "1"; have != want {
+		t.Errorf("got UID %q, want %q", have, want)
+	}
+}
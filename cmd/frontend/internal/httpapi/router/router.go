@@ -12,6 +12,9 @@ const (
 	GraphQL    = "graphql"
 
 	SearchStream = "search.stream"
+	SearchExport = "search.export"
+
+	SearchJobResults = "search.job-results"
 
 	SrcCliVersion  = "src-cli.version"
 	SrcCliDownload = "src-cli.download"
@@ -73,6 +76,8 @@ func New(base *mux.Router) *mux.Router {
 	base.Path("/bitbucket-server-webhooks").Methods("POST").Name(BitbucketServerWebhooks)
 	base.Path("/lsif/upload").Methods("POST").Name(LSIFUpload)
 	base.Path("/search/stream").Methods("GET").Name(SearchStream)
+	base.Path("/search/export").Methods("GET").Name(SearchExport)
+	base.Path("/search/jobs/{id:[0-9]+}/results").Methods("GET").Name(SearchJobResults)
 	base.Path("/src-cli/version").Methods("GET").Name(SrcCliVersion)
 	base.Path("/src-cli/{rest:.*}").Methods("GET").Name(SrcCliDownload)
 
@@ -0,0 +1,331 @@
+package search
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/csv"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/export"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// ExportHandler is an http handler which exports search results as CSV or
+// JSON lines for use in scripts and spreadsheets.
+//
+// The search is run with the same limits and permissions as a GraphQL
+// search, so use count: in the query to export more results. Rows are
+// streamed as results are found. Results can be paged through with the first
+// and cursor parameters. Since results are streamed in no particular order, a
+// page is the first rows after the cursor in the order of repository, commit,
+// path and line, so a paged export runs the whole search before writing the
+// page. The cursor for the next page is returned in the X-Next-Cursor
+// trailer.
+func ExportHandler(db dbutil.DB) http.Handler {
+	return &exportHandler{
+		db:                db,
+		newSearchResolver: defaultNewSearchResolver,
+	}
+}
+
+type exportHandler struct {
+	db                dbutil.DB
+	newSearchResolver func(context.Context, dbutil.DB, *graphqlbackend.SearchArgs) (searchResolver, error)
+}
+
+type exportFormat string
+
+const (
+	exportFormatCSV   exportFormat = "csv"
+	exportFormatJSONL exportFormat = "jsonl"
+)
+
+type exportArgs struct {
+	args
+
+	Format exportFormat
+	// First is the maximum number of rows to return. If 0 all rows are
+	// returned.
+	First int
+	// After is the last row of the previous page, decoded from the cursor.
+	After *export.Row
+}
+
+func (h *exportHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	a, err := parseExportURLQuery(r.URL.Query())
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	tr, ctx := trace.New(ctx, "search.ServeExport", a.Query,
+		trace.Tag{Key: "format", Value: string(a.Format)},
+	)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	ew := &exportWriter{w: w, format: a.Format, after: a.After, first: a.First, cancel: cancel}
+
+	search, err := h.newSearchResolver(ctx, h.db, &graphqlbackend.SearchArgs{
+		Query:          a.Query,
+		Version:        a.Version,
+		PatternType:    strPtr(a.PatternType),
+		VersionContext: strPtr(a.VersionContext),
+
+		Stream: streaming.StreamFunc(func(event streaming.SearchEvent) {
+			ew.Send(event)
+		}),
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	// Respect the result limit of the query, like the GraphQL API does.
+	ew.limit = search.Inputs().MaxResults()
+
+	_, err = search.Results(ctx)
+	err = ew.finish(err)
+}
+
+// exportWriter writes the rows of streamed search results to the response as
+// they arrive. It stops the search by calling cancel once the limit of the
+// query is reached.
+//
+// If first is set, the rows after the cursor are collected instead and the
+// first of them in sorted order are written once the search is done.
+type exportWriter struct {
+	w      http.ResponseWriter
+	format exportFormat
+	cancel context.CancelFunc
+
+	// limit is the maximum number of matches to export and first the
+	// maximum number of rows to write. Zero means unlimited.
+	limit, first int
+	// after is the last row of the previous page, if any.
+	after *export.Row
+
+	mu      sync.Mutex
+	started bool
+	done    bool         // whether the search was stopped by the writer
+	hasMore bool         // whether rows remain after the page
+	matches int          // the number of matches seen
+	page    []export.Row // the sorted rows of the page, if first is set
+	err     error
+	csv     *csv.Writer
+	json    *json.Encoder
+}
+
+func (ew *exportWriter) Send(event streaming.SearchEvent) {
+	ew.mu.Lock()
+	defer ew.mu.Unlock()
+
+	for _, match := range event.Results {
+		if ew.done || ew.err != nil {
+			return
+		}
+		if ew.limit > 0 && ew.matches >= ew.limit {
+			ew.stop()
+			return
+		}
+		ew.matches++
+
+		for _, row := range export.Rows([]result.Match{match}) {
+			if ew.first > 0 {
+				ew.addToPage(row)
+				continue
+			}
+			if ew.err = ew.write(row); ew.err != nil {
+				// The client went away.
+				ew.cancel()
+				return
+			}
+		}
+	}
+	ew.flush()
+}
+
+// addToPage inserts row into the sorted page if it is after the cursor,
+// dropping the rows that don't fit on the page.
+func (ew *exportWriter) addToPage(row export.Row) {
+	if ew.after != nil && !ew.after.Less(row) {
+		return
+	}
+	i := sort.Search(len(ew.page), func(i int) bool { return row.Less(ew.page[i]) })
+	if i == ew.first {
+		ew.hasMore = true
+		return
+	}
+	ew.page = append(ew.page, export.Row{})
+	copy(ew.page[i+1:], ew.page[i:])
+	ew.page[i] = row
+	if len(ew.page) > ew.first {
+		ew.page = ew.page[:ew.first]
+		ew.hasMore = true
+	}
+}
+
+// stop stops the search because no more rows will be written.
+func (ew *exportWriter) stop() {
+	ew.done = true
+	ew.cancel()
+}
+
+// start writes the response headers and, for CSV, the column names. The
+// cursor of the next page is only known once the page is written, so it is
+// sent as a trailer.
+func (ew *exportWriter) start() error {
+	if ew.started {
+		return nil
+	}
+	ew.started = true
+
+	if ew.first > 0 {
+		ew.w.Header().Set("Trailer", "X-Next-Cursor")
+	}
+	switch ew.format {
+	case exportFormatCSV:
+		ew.w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		ew.csv = csv.NewWriter(ew.w)
+		return ew.csv.Write(export.Columns)
+	default:
+		ew.w.Header().Set("Content-Type", "application/x-ndjson")
+		ew.json = json.NewEncoder(ew.w)
+		return nil
+	}
+}
+
+func (ew *exportWriter) write(row export.Row) error {
+	if err := ew.start(); err != nil {
+		return err
+	}
+	if ew.csv != nil {
+		return ew.csv.Write(row.Record())
+	}
+	return ew.json.Encode(row)
+}
+
+func (ew *exportWriter) flush() {
+	if ew.csv != nil {
+		ew.csv.Flush()
+	}
+	if f, ok := ew.w.(http.Flusher); ok && ew.started {
+		f.Flush()
+	}
+}
+
+// finish completes the response once the search returned with searchErr.
+func (ew *exportWriter) finish(searchErr error) error {
+	ew.mu.Lock()
+	defer ew.mu.Unlock()
+
+	if ew.done {
+		// The error is the cancellation of the search by the writer.
+		searchErr = nil
+	}
+	if searchErr != nil {
+		if !ew.started {
+			http.Error(ew.w, searchErr.Error(), http.StatusInternalServerError)
+		}
+		// Otherwise rows were already sent and the status can't be changed.
+		return searchErr
+	}
+	if ew.err != nil {
+		return ew.err
+	}
+
+	if err := ew.start(); err != nil {
+		return err
+	}
+	for _, row := range ew.page {
+		if err := ew.write(row); err != nil {
+			return err
+		}
+	}
+	if ew.csv != nil {
+		ew.csv.Flush()
+		if err := ew.csv.Error(); err != nil {
+			return err
+		}
+	}
+	if ew.hasMore {
+		ew.w.Header().Set("X-Next-Cursor", encodeExportCursor(ew.page[len(ew.page)-1]))
+	}
+	return nil
+}
+
+func parseExportURLQuery(q url.Values) (*exportArgs, error) {
+	a, err := parseURLQuery(q)
+	if err != nil {
+		return nil, err
+	}
+
+	ea := exportArgs{
+		args:   *a,
+		Format: exportFormat(q.Get("format")),
+	}
+
+	switch ea.Format {
+	case "":
+		ea.Format = exportFormatJSONL
+	case exportFormatCSV, exportFormatJSONL:
+	default:
+		return nil, errors.Errorf("format must be one of csv or jsonl, got %q", ea.Format)
+	}
+
+	if first := q.Get("first"); first != "" {
+		if ea.First, err = strconv.Atoi(first); err != nil || ea.First < 0 {
+			return nil, errors.Errorf("first must be a non-negative integer, got %q", first)
+		}
+	}
+
+	if cursor := q.Get("cursor"); cursor != "" {
+		if ea.After, err = decodeExportCursor(cursor); err != nil {
+			return nil, err
+		}
+	}
+
+	return &ea, nil
+}
+
+// encodeExportCursor returns the cursor to resume a paged export after the
+// row last. Cursors are opaque to clients. Resuming is exact as long as the
+// search returns the same results, regardless of the order in which they are
+// streamed.
+func encodeExportCursor(last export.Row) string {
+	b, _ := json.Marshal(last)
+	return base64.RawURLEncoding.EncodeToString(append([]byte("export:"), b...))
+}
+
+func decodeExportCursor(cursor string) (*export.Row, error) {
+	b, err := base64.RawURLEncoding.DecodeString(cursor)
+	if err != nil {
+		return nil, errors.Errorf("invalid cursor %q", cursor)
+	}
+	s := string(b)
+	if !strings.HasPrefix(s, "export:") {
+		return nil, errors.Errorf("invalid cursor %q", cursor)
+	}
+	var last export.Row
+	if err := json.Unmarshal([]byte(strings.TrimPrefix(s, "export:")), &last); err != nil {
+		return nil, errors.Errorf("invalid cursor %q", cursor)
+	}
+	return &last, nil
+}
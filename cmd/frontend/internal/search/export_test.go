@@ -0,0 +1,186 @@
+package search
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	api2 "github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/run"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// exportSearchResolver streams each match in its own event.
+type exportSearchResolver struct {
+	matches []result.Match
+	inputs  run.SearchInputs
+	stream  streaming.Sender
+
+	// sent is the number of matches sent before the search was canceled.
+	sent int
+}
+
+func (r *exportSearchResolver) Results(ctx context.Context) (*graphqlbackend.SearchResultsResolver, error) {
+	for _, m := range r.matches {
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		r.stream.Send(streaming.SearchEvent{Results: []result.Match{m}})
+		r.sent++
+	}
+	return &graphqlbackend.SearchResultsResolver{
+		UserSettings:  &schema.Settings{},
+		SearchResults: &graphqlbackend.SearchResults{},
+	}, nil
+}
+
+func (r *exportSearchResolver) Inputs() run.SearchInputs {
+	return r.inputs
+}
+
+func TestServeExport(t *testing.T) {
+	matches := []result.Match{
+		&result.FileMatch{
+			File: result.File{
+				Repo:     types.RepoName{ID: 1, Name: "github.com/foo/bar"},
+				CommitID: "deadbeef",
+				Path:     "main.go",
+			},
+			LineMatches: []*result.LineMatch{
+				{Preview: "func main() {", LineNumber: 2},
+				{Preview: `	fmt.Println("a, b")`, LineNumber: 3},
+			},
+		},
+		&result.RepoMatch{ID: 2, Name: api2.RepoName("github.com/foo/baz")},
+	}
+
+	reversed := make([]result.Match, len(matches))
+	for i, m := range matches {
+		reversed[len(matches)-1-i] = m
+	}
+
+	// streamed are the matches streamed by the next search.
+	streamed := matches
+	var resolver *exportSearchResolver
+	newServer := func(t *testing.T) *httptest.Server {
+		ts := httptest.NewServer(&exportHandler{
+			newSearchResolver: func(_ context.Context, _ dbutil.DB, args *graphqlbackend.SearchArgs) (searchResolver, error) {
+				q, err := query.Parse(args.Query, query.Literal)
+				if err != nil {
+					t.Fatal(err)
+				}
+				resolver = &exportSearchResolver{matches: streamed, inputs: run.SearchInputs{Query: q}, stream: args.Stream}
+				return resolver, nil
+			},
+		})
+		t.Cleanup(ts.Close)
+		return ts
+	}
+
+	get := func(t *testing.T, url string) (*http.Response, string) {
+		t.Helper()
+		res, err := http.Get(url)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		b, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res, string(b)
+	}
+
+	t.Run("csv", func(t *testing.T) {
+		ts := newServer(t)
+		res, body := get(t, ts.URL+"?q=main&format=csv")
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("expected status 200, got %d: %s", res.StatusCode, body)
+		}
+		want := `repository,commit,path,line,match
+github.com/foo/bar,deadbeef,main.go,3,func main() {
+github.com/foo/bar,deadbeef,main.go,4,"	fmt.Println(""a, b"")"
+github.com/foo/baz,,,,github.com/foo/baz
+`
+		if diff := cmp.Diff(want, body); diff != "" {
+			t.Fatalf("unexpected body (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("jsonl with cursor", func(t *testing.T) {
+		ts := newServer(t)
+		res, body := get(t, ts.URL+"?q=main&first=2")
+		want := `{"repository":"github.com/foo/bar","commit":"deadbeef","path":"main.go","line":3,"match":"func main() {"}
+{"repository":"github.com/foo/bar","commit":"deadbeef","path":"main.go","line":4,"match":"\tfmt.Println(\"a, b\")"}
+`
+		if diff := cmp.Diff(want, body); diff != "" {
+			t.Fatalf("unexpected first page (-want +got):\n%s", diff)
+		}
+
+		cursor := res.Trailer.Get("X-Next-Cursor")
+		if cursor == "" {
+			t.Fatal("expected a cursor for the next page")
+		}
+
+		res, body = get(t, ts.URL+"?q=main&first=2&cursor="+cursor)
+		want = `{"repository":"github.com/foo/baz","match":"github.com/foo/baz"}
+`
+		if diff := cmp.Diff(want, body); diff != "" {
+			t.Fatalf("unexpected second page (-want +got):\n%s", diff)
+		}
+		if cursor := res.Trailer.Get("X-Next-Cursor"); cursor != "" {
+			t.Fatalf("expected no cursor on the last page, got %q", cursor)
+		}
+	})
+
+	t.Run("pages don't depend on the order of results", func(t *testing.T) {
+		t.Cleanup(func() { streamed = matches })
+		ts := newServer(t)
+
+		streamed = reversed
+		res, body := get(t, ts.URL+"?q=main&first=1")
+		want := `{"repository":"github.com/foo/bar","commit":"deadbeef","path":"main.go","line":3,"match":"func main() {"}
+`
+		if diff := cmp.Diff(want, body); diff != "" {
+			t.Fatalf("unexpected first page (-want +got):\n%s", diff)
+		}
+		cursor := res.Trailer.Get("X-Next-Cursor")
+		if cursor == "" {
+			t.Fatal("expected a cursor for the next page")
+		}
+
+		streamed = matches
+		_, body = get(t, ts.URL+"?q=main&first=1&cursor="+cursor)
+		want = `{"repository":"github.com/foo/bar","commit":"deadbeef","path":"main.go","line":4,"match":"\tfmt.Println(\"a, b\")"}
+`
+		if diff := cmp.Diff(want, body); diff != "" {
+			t.Fatalf("unexpected second page (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("invalid cursor", func(t *testing.T) {
+		ts := newServer(t)
+		res, _ := get(t, ts.URL+"?q=main&first=1&cursor=export:3")
+		if res.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", res.StatusCode)
+		}
+	})
+
+	t.Run("invalid format", func(t *testing.T) {
+		ts := newServer(t)
+		res, _ := get(t, ts.URL+"?q=main&format=xml")
+		if res.StatusCode != http.StatusBadRequest {
+			t.Fatalf("expected status 400, got %d", res.StatusCode)
+		}
+	})
+}
@@ -0,0 +1,181 @@
+package search
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"log"
+	"net/http"
+	"strconv"
+	"sync"
+
+	"github.com/gorilla/mux"
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/search/export"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+)
+
+// searchJobsUploadStoreConfig configures the upload store which holds the
+// results of search jobs. The worker runs search jobs and uploads their
+// results, the frontend only downloads them.
+var searchJobsUploadStoreConfig = &uploadstore.Config{}
+
+func init() {
+	searchJobsUploadStoreConfig.Load()
+}
+
+var (
+	searchJobsUploadStoreOnce sync.Once
+	searchJobsUploadStore     uploadstore.Store
+)
+
+// getSearchJobsUploadStore returns the upload store which holds the results of
+// search jobs, creating it on first use.
+func getSearchJobsUploadStore() uploadstore.Store {
+	searchJobsUploadStoreOnce.Do(func() {
+		if err := searchJobsUploadStoreConfig.Validate(); err != nil {
+			log.Fatalf("Failed to load search jobs upload store config: %s", err)
+		}
+
+		observationContext := &observation.Context{
+			Logger:     log15.Root(),
+			Tracer:     &trace.Tracer{Tracer: opentracing.GlobalTracer()},
+			Registerer: prometheus.DefaultRegisterer,
+		}
+
+		var err error
+		searchJobsUploadStore, err = uploadstore.CreateLazy(context.Background(), searchJobsUploadStoreConfig.SearchJobsConfig(database.SearchJobRetention), observationContext)
+		if err != nil {
+			log.Fatalf("Failed to initialize search jobs upload store: %s", err)
+		}
+	})
+
+	return searchJobsUploadStore
+}
+
+type searchJobStore interface {
+	GetByID(ctx context.Context, id int64) (*types.SearchJob, error)
+}
+
+// SearchJobResultsHandler is an http handler which downloads the results of a
+// completed search job as CSV or JSON lines, depending on the format
+// parameter. Only the user who created the search job and site admins may
+// download its results.
+func SearchJobResultsHandler(db dbutil.DB) http.Handler {
+	return &searchJobResultsHandler{
+		store:       database.SearchJobs(db),
+		uploadStore: getSearchJobsUploadStore(),
+		checkAccess: func(ctx context.Context, userID int32) error {
+			return backend.CheckSiteAdminOrSameUser(ctx, db, userID)
+		},
+	}
+}
+
+type searchJobResultsHandler struct {
+	store       searchJobStore
+	uploadStore uploadstore.Store
+	checkAccess func(ctx context.Context, userID int32) error
+}
+
+func (h *searchJobResultsHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+
+	id, err := strconv.ParseInt(mux.Vars(r)["id"], 10, 64)
+	if err != nil {
+		http.Error(w, "search job not found", http.StatusNotFound)
+		return
+	}
+
+	format := exportFormat(r.URL.Query().Get("format"))
+	switch format {
+	case "":
+		format = exportFormatJSONL
+	case exportFormatCSV, exportFormatJSONL:
+	default:
+		http.Error(w, fmt.Sprintf("format must be one of csv or jsonl, got %q", format), http.StatusBadRequest)
+		return
+	}
+
+	job, err := h.store.GetByID(ctx, id)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			http.Error(w, "search job not found", http.StatusNotFound)
+			return
+		}
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// 🚨 SECURITY: Only the user who created the search job and site admins may
+	// download its results. Other users can't tell whether the job exists.
+	if err := h.checkAccess(ctx, job.UserID); err != nil {
+		http.Error(w, "search job not found", http.StatusNotFound)
+		return
+	}
+
+	if job.State != "completed" {
+		http.Error(w, fmt.Sprintf("search job is %s, its results can be downloaded once it completed", job.State), http.StatusConflict)
+		return
+	}
+
+	rc, err := h.uploadStore.Get(ctx, database.SearchJobResultsKey(id))
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	defer rc.Close()
+	zr, err := gzip.NewReader(rc)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Disposition", fmt.Sprintf(`attachment; filename="search-job-%d.%s"`, id, format))
+	switch format {
+	case exportFormatCSV:
+		w.Header().Set("Content-Type", "text/csv; charset=utf-8")
+		err = writeSearchJobResultsCSV(w, zr)
+	default:
+		w.Header().Set("Content-Type", "application/x-ndjson")
+		_, err = io.Copy(w, zr)
+	}
+	if err != nil {
+		log15.Error("failed to write search job results", "id", id, "err", err)
+	}
+}
+
+// writeSearchJobResultsCSV converts the JSON lines of export.Row read from r
+// to CSV, without loading all of them in memory.
+func writeSearchJobResultsCSV(w io.Writer, r io.Reader) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(export.Columns); err != nil {
+		return err
+	}
+	dec := json.NewDecoder(r)
+	for {
+		var row export.Row
+		if err := dec.Decode(&row); err == io.EOF {
+			break
+		} else if err != nil {
+			return err
+		}
+		if err := cw.Write(row.Record()); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
@@ -0,0 +1,140 @@
+package search
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/gorilla/mux"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search/export"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+)
+
+type fakeSearchJobStore struct {
+	jobs map[int64]*types.SearchJob
+}
+
+func (s *fakeSearchJobStore) GetByID(_ context.Context, id int64) (*types.SearchJob, error) {
+	j, ok := s.jobs[id]
+	if !ok {
+		return nil, &database.ErrSearchJobNotFound{ID: id}
+	}
+	return j, nil
+}
+
+func TestSearchJobResultsHandler(t *testing.T) {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	enc := json.NewEncoder(zw)
+	for _, row := range []export.Row{
+		{Repository: "github.com/foo/bar", Commit: "deadbeef", Path: "main.go", Line: 3, Match: "a, b"},
+		{Repository: "github.com/foo/baz", Match: "github.com/foo/baz"},
+	} {
+		if err := enc.Encode(row); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	store := &fakeSearchJobStore{
+		jobs: map[int64]*types.SearchJob{
+			1: {ID: 1, UserID: 7, State: "completed"},
+			2: {ID: 2, UserID: 7, State: "processing"},
+			3: {ID: 3, UserID: 8, State: "completed"},
+		},
+	}
+	uploadStore := mocks.NewMockStore()
+	uploadStore.GetFunc.SetDefaultHook(func(_ context.Context, key string) (io.ReadCloser, error) {
+		if want := database.SearchJobResultsKey(1); key != want {
+			t.Errorf("got key %q, want %q", key, want)
+		}
+		return io.NopCloser(bytes.NewReader(buf.Bytes())), nil
+	})
+
+	router := mux.NewRouter()
+	router.Path("/search/jobs/{id:[0-9]+}/results").Handler(&searchJobResultsHandler{
+		store:       store,
+		uploadStore: uploadStore,
+		checkAccess: func(_ context.Context, userID int32) error {
+			if userID != 7 {
+				return errors.New("not the creator of the search job")
+			}
+			return nil
+		},
+	})
+	ts := httptest.NewServer(router)
+	t.Cleanup(ts.Close)
+
+	get := func(t *testing.T, path string) (*http.Response, string) {
+		t.Helper()
+		res, err := http.Get(ts.URL + path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer res.Body.Close()
+		b, err := io.ReadAll(res.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return res, string(b)
+	}
+
+	t.Run("jsonl", func(t *testing.T) {
+		res, body := get(t, "/search/jobs/1/results")
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("got status %d: %s", res.StatusCode, body)
+		}
+		want := `{"repository":"github.com/foo/bar","commit":"deadbeef","path":"main.go","line":3,"match":"a, b"}
+{"repository":"github.com/foo/baz","match":"github.com/foo/baz"}
+`
+		if diff := cmp.Diff(want, body); diff != "" {
+			t.Fatalf("unexpected body (-want +got):\n%s", diff)
+		}
+		if got, want := res.Header.Get("Content-Disposition"), `attachment; filename="search-job-1.jsonl"`; got != want {
+			t.Errorf("got Content-Disposition %q, want %q", got, want)
+		}
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		res, body := get(t, "/search/jobs/1/results?format=csv")
+		if res.StatusCode != http.StatusOK {
+			t.Fatalf("got status %d: %s", res.StatusCode, body)
+		}
+		want := `repository,commit,path,line,match
+github.com/foo/bar,deadbeef,main.go,3,"a, b"
+github.com/foo/baz,,,,github.com/foo/baz
+`
+		if diff := cmp.Diff(want, body); diff != "" {
+			t.Fatalf("unexpected body (-want +got):\n%s", diff)
+		}
+	})
+
+	for _, tc := range []struct {
+		name string
+		path string
+		want int
+	}{
+		{"not found", "/search/jobs/4/results", http.StatusNotFound},
+		{"other user", "/search/jobs/3/results", http.StatusNotFound},
+		{"not completed", "/search/jobs/2/results", http.StatusConflict},
+		{"bad format", "/search/jobs/1/results?format=xml", http.StatusBadRequest},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if res, body := get(t, tc.path); res.StatusCode != tc.want {
+				t.Fatalf("got status %d, want %d: %s", res.StatusCode, tc.want, body)
+			}
+		})
+	}
+}
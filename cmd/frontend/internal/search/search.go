@@ -57,6 +57,7 @@ type streamHandler struct {
 func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 	ctx, cancel := context.WithCancel(r.Context())
 	defer cancel()
+	ctx = graphqlbackend.WithAcceptLanguage(ctx, r.Header.Get("Accept-Language"))
 
 	args, err := parseURLQuery(r.URL.Query())
 	if err != nil {
@@ -239,20 +240,37 @@ func (h *streamHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 
 	alert := resultsResolver.Alert()
 	if alert != nil {
-		var pqs []streamhttp.ProposedQuery
-		if proposed := alert.ProposedQueries(); proposed != nil {
-			for _, pq := range *proposed {
-				pqs = append(pqs, streamhttp.ProposedQuery{
-					Description: fromStrPtr(pq.Description()),
-					Query:       pq.Query(),
-				})
+		// The alert is followed by its notices, which have the same type.
+		notices := resultsResolver.Notices()
+		alerts := make([]streamhttp.EventAlert, 0, len(notices)+1)
+		for i := -1; i < len(notices); i++ {
+			a := alert
+			if i >= 0 {
+				a = notices[i]
 			}
+
+			var pqs []streamhttp.ProposedQuery
+			if proposed := a.ProposedQueries(); proposed != nil {
+				for _, pq := range *proposed {
+					pqs = append(pqs, streamhttp.ProposedQuery{
+						Description: fromStrPtr(pq.Description()),
+						Query:       pq.Query(),
+					})
+				}
+			}
+			alerts = append(alerts, streamhttp.EventAlert{
+				Title:           a.Title(),
+				Description:     fromStrPtr(a.Description()),
+				ProposedQueries: pqs,
+				DismissKey:      fromStrPtr(a.IsDismissibleWithKey()),
+			})
 		}
-		_ = eventWriter.Event("alert", streamhttp.EventAlert{
-			Title:           alert.Title(),
-			Description:     fromStrPtr(alert.Description()),
-			ProposedQueries: pqs,
-		})
+
+		ev := alerts[0]
+		if len(alerts) > 1 {
+			ev.Notices = alerts[1:]
+		}
+		_ = eventWriter.Event("alert", ev)
 	}
 
 	_ = eventWriter.Event("progress", progress.Final())
@@ -440,12 +458,13 @@ func fromPathMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Repo) *
 	}
 
 	return &streamhttp.EventPathMatch{
-		Type:       streamhttp.PathMatchType,
-		Path:       fm.Path,
-		Repository: string(fm.Repo.Name),
-		RepoStars:  stars,
-		Branches:   branches,
-		Version:    string(fm.CommitID),
+		Type:           streamhttp.PathMatchType,
+		Path:           fm.Path,
+		Repository:     string(fm.Repo.Name),
+		RepoStars:      stars,
+		Branches:       branches,
+		Version:        string(fm.CommitID),
+		DuplicateForks: fm.DuplicateForks,
 	}
 }
 
@@ -477,6 +496,8 @@ func fromContentMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Repo
 		Branches:    branches,
 		Version:     string(fm.CommitID),
 		LineMatches: lineMatches,
+
+		DuplicateForks: fm.DuplicateForks,
 	}
 }
 
@@ -515,6 +536,8 @@ func fromSymbolMatch(fm *result.FileMatch, repoCache map[api.RepoID]*types.Repo)
 		Branches:   branches,
 		Version:    string(fm.CommitID),
 		Symbols:    symbols,
+
+		DuplicateForks: fm.DuplicateForks,
 	}
 }
 
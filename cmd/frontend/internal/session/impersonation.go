@@ -0,0 +1,77 @@
+package session
+
+import (
+	"encoding/json"
+	"strconv"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// impersonationKeyPrefix is the redis key prefix of active impersonations.
+// Impersonations are keyed by the user ID of the site admin, so they apply to
+// all sessions of the site admin.
+const impersonationKeyPrefix = "impersonation"
+
+// impersonation is an active impersonation of a user by a site admin.
+type impersonation struct {
+	UserID    int32     `json:"userID"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// StartImpersonation makes all sessions of the site admin adminUID act as the
+// user userID until the impersonation expires after the configured
+// auth.impersonation duration or is stopped with StopImpersonation.
+//
+// 🚨 SECURITY: The caller must ensure that adminUID is a site admin that is
+// allowed to impersonate userID.
+func StartImpersonation(adminUID, userID int32) (expiresAt time.Time, err error) {
+	d := conf.AuthImpersonationDuration()
+	expiresAt = time.Now().Add(d)
+
+	data, err := json.Marshal(impersonation{UserID: userID, ExpiresAt: expiresAt})
+	if err != nil {
+		return time.Time{}, err
+	}
+	rcache.NewWithTTL(impersonationKeyPrefix, int(d.Seconds())).Set(strconv.Itoa(int(adminUID)), data)
+	return expiresAt, nil
+}
+
+// StopImpersonation stops the active impersonation of the site admin
+// adminUID, if any.
+func StopImpersonation(adminUID int32) {
+	rcache.New(impersonationKeyPrefix).Delete(strconv.Itoa(int(adminUID)))
+}
+
+// impersonatedActor returns the actor to use for requests of the site admin
+// usr if they are impersonating another user. Otherwise it returns nil.
+//
+// 🚨 SECURITY: Impersonated requests are restricted to read operations by
+// httpapi.ImpersonationMiddleware, which must wrap every handler that accepts
+// session cookies.
+func impersonatedActor(usr *types.User) *actor.Actor {
+	if !usr.SiteAdmin || !conf.AuthImpersonationEnabled() {
+		return nil
+	}
+
+	data, ok := rcache.New(impersonationKeyPrefix).Get(strconv.Itoa(int(usr.ID)))
+	if !ok {
+		return nil
+	}
+
+	var imp impersonation
+	if err := json.Unmarshal(data, &imp); err != nil {
+		log15.Warn("Error reading impersonation.", "uid", usr.ID, "error", err)
+		return nil
+	}
+	if imp.ExpiresAt.Before(time.Now()) {
+		return nil
+	}
+
+	return &actor.Actor{UID: imp.UserID, ImpersonatorUID: usr.ID, FromSessionCookie: true}
+}
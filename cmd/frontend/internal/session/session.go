@@ -15,8 +15,10 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbconn"
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/redispool"
 
 	"github.com/inconshreveable/log15"
@@ -58,6 +60,10 @@ type sessionInfo struct {
 	LastActive    time.Time     `json:"lastActive"`
 	ExpiryPeriod  time.Duration `json:"expiryPeriod"`
 	UserCreatedAt time.Time     `json:"userCreatedAt"`
+
+	// ExternalAccount is the user external account the user signed in with, if any. The session
+	// is only valid as long as the account is neither deleted nor expired.
+	ExternalAccount *extsvc.AccountSpec `json:"externalAccount,omitempty"`
 }
 
 // SetSessionStore sets the backing store used for storing sessions on the server. It should be called exactly once.
@@ -224,6 +230,17 @@ func GetData(r *http.Request, key string, value interface{}) error {
 //
 // If expiryPeriod is 0, the default expiry period is used.
 func SetActor(w http.ResponseWriter, r *http.Request, actor *actor.Actor, expiryPeriod time.Duration, userCreatedAt time.Time) error {
+	return setActor(w, r, actor, nil, expiryPeriod, userCreatedAt)
+}
+
+// SetActorFromExternalAccount is like SetActor, except it is used when the actor signed in with
+// the given user external account. The session is invalidated as soon as the account is deleted
+// or expires.
+func SetActorFromExternalAccount(w http.ResponseWriter, r *http.Request, actor *actor.Actor, account extsvc.AccountSpec, expiryPeriod time.Duration, userCreatedAt time.Time) error {
+	return setActor(w, r, actor, &account, expiryPeriod, userCreatedAt)
+}
+
+func setActor(w http.ResponseWriter, r *http.Request, actor *actor.Actor, account *extsvc.AccountSpec, expiryPeriod time.Duration, userCreatedAt time.Time) error {
 	var value *sessionInfo
 	if actor != nil {
 		if expiryPeriod == 0 {
@@ -233,7 +250,7 @@ func SetActor(w http.ResponseWriter, r *http.Request, actor *actor.Actor, expiry
 				expiryPeriod = defaultExpiryPeriod
 			}
 		}
-		value = &sessionInfo{Actor: actor, ExpiryPeriod: expiryPeriod, LastActive: time.Now(), UserCreatedAt: userCreatedAt}
+		value = &sessionInfo{Actor: actor, ExpiryPeriod: expiryPeriod, LastActive: time.Now(), UserCreatedAt: userCreatedAt, ExternalAccount: account}
 	}
 	return SetData(w, r, "actor", value)
 }
@@ -385,6 +402,21 @@ func authenticateByCookie(r *http.Request, w http.ResponseWriter) context.Contex
 			return r.Context()
 		}
 
+		// Check that the external account the user signed in with, if any, has not been deleted
+		// or expired since.
+		if info.ExternalAccount != nil {
+			active, err := database.ExternalAccounts(dbconn.Global).IsActive(r.Context(), usr.ID, *info.ExternalAccount)
+			if err != nil {
+				// Like above, don't delete the session on a possibly ephemeral DB error.
+				log15.Error("Error looking up external account for session.", "uid", info.Actor.UID, "error", err)
+				return r.Context() // not authenticated
+			}
+			if !active {
+				_ = deleteSession(w, r) // Delete the now invalid session
+				return r.Context()
+			}
+		}
+
 		// If the session does not have the user's creation date, it's an old (valid)
 		// session from before the check was introduced. In that case, we manually
 		// set the user creation date
@@ -412,6 +444,11 @@ func authenticateByCookie(r *http.Request, w http.ResponseWriter) context.Contex
 			}
 		}
 
+		// Site admins may be impersonating another user.
+		if a := impersonatedActor(usr); a != nil {
+			return actor.WithActor(r.Context(), a)
+		}
+
 		info.Actor.FromSessionCookie = true
 		return actor.WithActor(r.Context(), info.Actor)
 	}
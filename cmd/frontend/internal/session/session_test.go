@@ -14,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
@@ -446,3 +447,52 @@ func TestOldUserSessionSucceeds(t *testing.T) {
 		t.Fatal("user creation date was not set")
 	}
 }
+
+func TestExternalAccountSessionInvalidation(t *testing.T) {
+	cleanup := ResetMockSessionStore(t)
+	defer cleanup()
+
+	user := &types.User{ID: 1, CreatedAt: time.Now()}
+	database.Mocks.Users.GetByID = func(ctx context.Context, id int32) (*types.User, error) {
+		return user, nil
+	}
+	spec := extsvc.AccountSpec{ServiceType: "saml", ServiceID: "https://idp.example.com", AccountID: "alice"}
+	active := true
+	database.Mocks.ExternalAccounts.IsActive = func(userID int32, got extsvc.AccountSpec) (bool, error) {
+		if userID != user.ID || got != spec {
+			t.Errorf("unexpected external account lookup for user %d: %+v", userID, got)
+		}
+		return active, nil
+	}
+	defer func() { database.Mocks = database.MockStores{} }()
+
+	// Start a new session for the user, signed in with the external account.
+	w := httptest.NewRecorder()
+	actr := &actor.Actor{UID: 1, FromSessionCookie: true}
+	if err := SetActorFromExternalAccount(w, httptest.NewRequest("GET", "/", nil), actr, spec, time.Hour, user.CreatedAt); err != nil {
+		t.Fatal(err)
+	}
+	var authCookies []*http.Cookie
+	for _, cookie := range w.Result().Cookies() {
+		if cookie.Expires.After(time.Now()) || cookie.MaxAge > 0 {
+			authCookies = append(authCookies, cookie)
+		}
+	}
+	authenticate := func() *actor.Actor {
+		req := httptest.NewRequest("GET", "/", nil)
+		for _, cookie := range authCookies {
+			req.AddCookie(cookie)
+		}
+		return actor.FromContext(authenticateByCookie(req, httptest.NewRecorder()))
+	}
+
+	if got := authenticate(); !reflect.DeepEqual(got, actr) {
+		t.Fatalf("session was not created, got actor %+v", got)
+	}
+
+	// Deleting or expiring the external account invalidates the session.
+	active = false
+	if got := authenticate(); !reflect.DeepEqual(got, &actor.Actor{}) {
+		t.Fatalf("session was not invalidated, got actor %+v", got)
+	}
+}
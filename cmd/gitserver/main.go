@@ -34,6 +34,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 	"github.com/sourcegraph/sourcegraph/internal/tracer"
+	"github.com/sourcegraph/sourcegraph/internal/version/skew"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -76,6 +77,9 @@ func main() {
 	if err != nil {
 		log.Fatalf("failed to initialize database stores: %v", err)
 	}
+	if err := skew.Register(ctx, db, "gitserver"); err != nil {
+		log.Fatal(err)
+	}
 	repoStore := database.Repos(db)
 	externalServiceStore := database.ExternalServices(db)
 
@@ -156,8 +160,65 @@ func main() {
 
 				return &server.JVMPackagesSyncer{Config: &c}, nil
 			}
+
+			// Like GetRemoteURLFunc, use the fetch credential of the first
+			// source for clones over SSH.
+			for _, info := range r.Sources {
+				es, err := externalServiceStore.GetByID(ctx, info.ExternalServiceID())
+				if err != nil {
+					return nil, errors.Wrap(err, "get external service")
+				}
+
+				cred, err := repos.FetchCredentialFor(es.Config, r.Name)
+				if err != nil {
+					return nil, errors.Wrap(err, "get fetch credential")
+				}
+				if cred != nil {
+					return &server.GitRepoSyncer{SSHKey: cred.SSHKey}, nil
+				}
+				break
+			}
 			return &server.GitRepoSyncer{}, nil
 		},
+		GetClonePolicy: func(ctx context.Context, repo api.RepoName) (*server.ClonePolicy, error) {
+			r, err := repoStore.GetByName(ctx, repo)
+			if err != nil {
+				return nil, errors.Wrap(err, "get repository")
+			}
+
+			var p server.ClonePolicy
+			p.Size, _ = repos.RepoSize(r)
+
+			// The smallest maxRepoSize of the code host connections of the repo
+			// applies. LFS objects are fetched if any of them asks for it.
+			for _, info := range r.Sources {
+				es, err := externalServiceStore.GetByID(ctx, info.ExternalServiceID())
+				if err != nil {
+					return nil, errors.Wrap(err, "get external service")
+				}
+
+				var c struct {
+					MaxRepoSize string `json:"maxRepoSize"`
+					GitLFS      string `json:"gitLFS"`
+				}
+				if err := jsonc.Unmarshal(es.Config, &c); err != nil {
+					return nil, errors.Wrap(err, "unmarshal JSON")
+				}
+
+				if c.MaxRepoSize != "" {
+					maxSize, err := repos.ParseRepoSize(c.MaxRepoSize)
+					if err != nil {
+						return nil, err
+					}
+					if p.MaxSize == 0 || maxSize < p.MaxSize {
+						p.MaxSize = maxSize
+					}
+				}
+				p.FetchLFS = p.FetchLFS || c.GitLFS == "fetch"
+			}
+
+			return &p, nil
+		},
 		Hostname: hostname.Get(),
 		DB:       db,
 	}
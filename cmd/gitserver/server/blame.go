@@ -0,0 +1,327 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os/exec"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// handleBlame blames a file at a commit with `git blame --incremental` and
+// streams the hunks as newline-delimited JSON as soon as git attributed them
+// to a commit. Unlike `git blame --porcelain` through /exec, clients don't
+// have to wait for the whole file to be blamed, which can take seconds for
+// files with many lines and a long history.
+//
+// Errors which happen after the first hunk has been written are reported in
+// the X-Blame-Error trailer.
+func (s *Server) handleBlame(w http.ResponseWriter, r *http.Request) {
+	var req protocol.BlameRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Repo == "" || req.Path == "" {
+		http.Error(w, "repo and path must be non-empty", http.StatusBadRequest)
+		return
+	}
+	if req.Commit == "" {
+		req.Commit = "HEAD"
+	}
+	if err := checkSpecArgSafety(string(req.Commit)); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.StartLine < 0 || req.EndLine < 0 || (req.EndLine != 0 && req.EndLine < req.StartLine) {
+		http.Error(w, "invalid line range", http.StatusBadRequest)
+		return
+	}
+
+	req.Repo = protocol.NormalizeRepo(req.Repo)
+	dir := s.dir(req.Repo)
+	if !repoCloned(dir) {
+		cloneProgress, cloneInProgress := s.locker.Status(dir)
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(&protocol.NotFoundPayload{
+			CloneInProgress: cloneInProgress,
+			CloneProgress:   cloneProgress,
+		})
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	tr, ctx := trace.New(ctx, "blame", string(req.Repo))
+	var (
+		hunks    int
+		blameErr error
+	)
+	defer func() {
+		tr.LogFields(
+			otlog.String("commit", string(req.Commit)),
+			otlog.String("path", req.Path),
+			otlog.Int("hunks", hunks),
+		)
+		tr.SetError(blameErr)
+		tr.Finish()
+	}()
+
+	// The byte offsets of the hunks are computed from the lengths of the lines
+	// of the file, since the incremental output doesn't contain the lines.
+	offsets, err := blameLineOffsets(ctx, dir, &req)
+	if err != nil {
+		blameErr = err
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", blameArgs(&req)...)
+	dir.Set(cmd)
+	cmd.Stderr = &limitWriter{W: &stderr, N: 1024}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		blameErr = err
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := cmd.Start(); err != nil {
+		blameErr = err
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Flush writes more aggressively than standard net/http so that clients
+	// can start processing hunks while the blame is still running.
+	if fw := newFlushingResponseWriter(w); fw != nil {
+		w = fw
+		defer fw.Close()
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Trailer", "X-Blame-Error")
+	w.WriteHeader(http.StatusOK)
+
+	enc := json.NewEncoder(w)
+	blameErr = parseIncrementalBlame(stdout, func(h protocol.BlameHunk) error {
+		h.StartByte = offsets.byteOffset(h.StartLine)
+		h.EndByte = offsets.byteOffset(h.EndLine)
+		if err := enc.Encode(h); err != nil {
+			// The client went away.
+			return err
+		}
+		hunks++
+		return nil
+	})
+
+	if blameErr != nil {
+		// Stop git blame, since we stopped reading its output early.
+		cancel()
+	}
+	if err := cmd.Wait(); blameErr == nil && err != nil {
+		blameErr = errors.Errorf("git blame failed: %s (stderr: %q)", err, stderr.String())
+		checkMaybeCorruptRepo(req.Repo, dir, stderr.String())
+	}
+
+	w.Header().Set("X-Blame-Error", errorString(blameErr))
+}
+
+// blameArgs returns the arguments of the git blame command which runs req.
+func blameArgs(req *protocol.BlameRequest) []string {
+	args := []string{"blame", "-w", "--incremental"}
+	if req.StartLine != 0 || req.EndLine != 0 {
+		args = append(args, blameLineRange(req))
+	}
+	return append(args, string(req.Commit), "--", req.Path)
+}
+
+// blameLineRange returns the -L argument of git blame for the line range of req.
+func blameLineRange(req *protocol.BlameRequest) string {
+	start := req.StartLine
+	if start == 0 {
+		start = 1
+	}
+	if req.EndLine == 0 {
+		return "-L" + strconv.Itoa(start) + ","
+	}
+	return "-L" + strconv.Itoa(start) + "," + strconv.Itoa(req.EndLine)
+}
+
+// lineOffsets are the byte offsets of the lines of a blamed file, counted from
+// the first blamed line. Every line counts with a line ending, even the last
+// line of a file without one. This is how the offsets of hunks parsed from
+// `git blame --porcelain` have always been computed.
+type lineOffsets struct {
+	// offsets[i] is the offset of line i+1 of the file.
+	offsets []int
+	// start is the offset of the first blamed line.
+	start int
+}
+
+func (o lineOffsets) byteOffset(line int) int {
+	if line < 1 || len(o.offsets) == 0 {
+		return 0
+	}
+	if line > len(o.offsets) {
+		line = len(o.offsets)
+	}
+	return o.offsets[line-1] - o.start
+}
+
+// blameLineOffsets reads the file blamed by req to compute the byte offsets of
+// its lines.
+func blameLineOffsets(ctx context.Context, dir GitDir, req *protocol.BlameRequest) (lineOffsets, error) {
+	cmd := exec.CommandContext(ctx, "git", "cat-file", "blob", string(req.Commit)+":"+req.Path)
+	dir.Set(cmd)
+	var stderr bytes.Buffer
+	cmd.Stderr = &limitWriter{W: &stderr, N: 1024}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		return lineOffsets{}, err
+	}
+	if err := cmd.Start(); err != nil {
+		return lineOffsets{}, err
+	}
+
+	// The offset after the last line is included, so that hunks which end
+	// after the last line have an offset.
+	offsets := []int{0}
+	offset := 0
+	partial := false
+	br := bufio.NewReader(stdout)
+	for {
+		line, err := br.ReadSlice('\n')
+		if len(line) > 0 {
+			offset += len(line)
+			partial = line[len(line)-1] != '\n'
+			if !partial {
+				offsets = append(offsets, offset)
+			}
+		}
+		if err == bufio.ErrBufferFull {
+			continue
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			_ = cmd.Wait()
+			return lineOffsets{}, err
+		}
+	}
+	if partial {
+		offsets = append(offsets, offset+1)
+	}
+	if err := cmd.Wait(); err != nil {
+		return lineOffsets{}, errors.Errorf("file %q not found at commit %s: %s", req.Path, req.Commit, strings.TrimSpace(stderr.String()))
+	}
+
+	o := lineOffsets{offsets: offsets}
+	o.start = o.byteOffset(req.StartLine)
+	return o, nil
+}
+
+// blameCommit is the information about a commit in the output of git blame
+// --incremental. It is only printed for the first hunk of each commit.
+type blameCommit struct {
+	authorName  string
+	authorEmail string
+	authorDate  time.Time
+	message     string
+}
+
+// parseIncrementalBlame parses the output of git blame --incremental and
+// calls onHunk for each hunk as soon as it was read. If onHunk returns an
+// error, parsing stops and the error is returned.
+func parseIncrementalBlame(r io.Reader, onHunk func(protocol.BlameHunk) error) error {
+	var (
+		commits = map[api.CommitID]*blameCommit{}
+		hunk    *protocol.BlameHunk
+		commit  *blameCommit
+	)
+
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		line := scanner.Text()
+
+		if hunk == nil {
+			// Each hunk starts with "<commit> <original line> <final line> <number of lines>".
+			fields := strings.Fields(line)
+			if len(fields) != 4 {
+				return errors.Errorf("unexpected hunk header in git blame output: %q", line)
+			}
+			startLine, err := strconv.Atoi(fields[2])
+			if err != nil {
+				return errors.Errorf("unexpected hunk header in git blame output: %q", line)
+			}
+			numLines, err := strconv.Atoi(fields[3])
+			if err != nil {
+				return errors.Errorf("unexpected hunk header in git blame output: %q", line)
+			}
+
+			id := api.CommitID(fields[0])
+			hunk = &protocol.BlameHunk{
+				CommitID:  id,
+				StartLine: startLine,
+				EndLine:   startLine + numLines,
+			}
+			if commit = commits[id]; commit == nil {
+				commit = &blameCommit{}
+				commits[id] = commit
+			}
+			continue
+		}
+
+		key, value := line, ""
+		if i := strings.IndexByte(line, ' '); i >= 0 {
+			key, value = line[:i], line[i+1:]
+		}
+		switch key {
+		case "author":
+			commit.authorName = value
+		case "author-mail":
+			commit.authorEmail = strings.TrimSuffix(strings.TrimPrefix(value, "<"), ">")
+		case "author-time":
+			t, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return errors.Errorf("failed to parse author-time %q", value)
+			}
+			commit.authorDate = time.Unix(t, 0).UTC()
+		case "summary":
+			commit.message = value
+		case "filename":
+			// The filename is the last line of each hunk.
+			hunk.AuthorName = commit.authorName
+			hunk.AuthorEmail = commit.authorEmail
+			hunk.AuthorDate = commit.authorDate
+			hunk.Message = commit.message
+			if err := onHunk(*hunk); err != nil {
+				return err
+			}
+			hunk = nil
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return err
+	}
+	if hunk != nil {
+		return errors.New("unexpected end of git blame output")
+	}
+	return nil
+}
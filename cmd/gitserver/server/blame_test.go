@@ -0,0 +1,150 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+func TestServer_handleBlame(t *testing.T) {
+	reposDir := t.TempDir()
+	repoDir := filepath.Join(reposDir, "github.com/foo/bar")
+	if err := os.MkdirAll(repoDir, 0700); err != nil {
+		t.Fatal(err)
+	}
+	cmd := func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, repoDir, name, arg...)
+	}
+	cmd("git", "init", ".")
+	cmd("sh", "-c", "printf 'foo\\nbar\\n' > f.txt")
+	cmd("git", "add", ".")
+	cmd("git", "commit", "-m", "first", "--date", "2006-01-02T15:04:05Z")
+	first := api.CommitID(strings.TrimSpace(cmd("git", "rev-parse", "HEAD")))
+	cmd("sh", "-c", "printf 'foo\\nbaz\\nqux\\n' > f.txt")
+	cmd("git", "commit", "-am", "second", "--author", "b <b@b.com>", "--date", "2007-01-02T15:04:05Z")
+	second := api.CommitID(strings.TrimSpace(cmd("git", "rev-parse", "HEAD")))
+
+	s := makeTestServer(context.Background(), reposDir, "", nil)
+	h := s.Handler()
+
+	blame := func(req protocol.BlameRequest) (hunks []protocol.BlameHunk, resp *http.Response) {
+		t.Helper()
+		body, err := json.Marshal(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("POST", "/blame", bytes.NewReader(body)))
+		resp = w.Result()
+		if resp.StatusCode != http.StatusOK {
+			return nil, resp
+		}
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var h protocol.BlameHunk
+			if err := dec.Decode(&h); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			hunks = append(hunks, h)
+		}
+		// The hunks are streamed in the order in which git blamed them.
+		sort.Slice(hunks, func(i, j int) bool { return hunks[i].StartLine < hunks[j].StartLine })
+		return hunks, resp
+	}
+
+	firstHunk := protocol.BlameHunk{
+		CommitID:    first,
+		AuthorName:  "a",
+		AuthorEmail: "a@a.com",
+		AuthorDate:  time.Date(2006, 1, 2, 15, 4, 5, 0, time.UTC),
+		Message:     "first",
+	}
+	secondHunk := protocol.BlameHunk{
+		CommitID:    second,
+		AuthorName:  "b",
+		AuthorEmail: "b@b.com",
+		AuthorDate:  time.Date(2007, 1, 2, 15, 4, 5, 0, time.UTC),
+		Message:     "second",
+	}
+	withLines := func(h protocol.BlameHunk, startLine, endLine, startByte, endByte int) protocol.BlameHunk {
+		h.StartLine, h.EndLine, h.StartByte, h.EndByte = startLine, endLine, startByte, endByte
+		return h
+	}
+
+	tests := []struct {
+		name      string
+		req       protocol.BlameRequest
+		wantHunks []protocol.BlameHunk
+	}{
+		{
+			name: "whole file",
+			req:  protocol.BlameRequest{Commit: second},
+			wantHunks: []protocol.BlameHunk{
+				withLines(firstHunk, 1, 2, 0, 4),
+				withLines(secondHunk, 2, 4, 4, 12),
+			},
+		},
+		{
+			name: "line range",
+			req:  protocol.BlameRequest{Commit: second, StartLine: 2, EndLine: 3},
+			wantHunks: []protocol.BlameHunk{
+				withLines(secondHunk, 2, 4, 0, 8),
+			},
+		},
+		{
+			name: "older commit",
+			req:  protocol.BlameRequest{Commit: first},
+			wantHunks: []protocol.BlameHunk{
+				withLines(firstHunk, 1, 3, 0, 8),
+			},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.req.Repo = "github.com/foo/bar"
+			test.req.Path = "f.txt"
+
+			hunks, resp := blame(test.req)
+			if resp.StatusCode != http.StatusOK {
+				t.Fatalf("unexpected status code %d", resp.StatusCode)
+			}
+			if diff := cmp.Diff(test.wantHunks, hunks); diff != "" {
+				t.Errorf("unexpected hunks (-want +got):\n%s", diff)
+			}
+			if got := resp.Trailer.Get("X-Blame-Error"); got != "" {
+				t.Errorf("unexpected error %q", got)
+			}
+		})
+	}
+
+	t.Run("missing file", func(t *testing.T) {
+		_, resp := blame(protocol.BlameRequest{Repo: "github.com/foo/bar", Commit: second, Path: "missing.txt"})
+		if resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("got status code %d, want %d", resp.StatusCode, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("missing repo", func(t *testing.T) {
+		_, resp := blame(protocol.BlameRequest{Repo: "github.com/foo/missing", Path: "f.txt"})
+		if resp.StatusCode != http.StatusNotFound {
+			t.Errorf("got status code %d, want %d", resp.StatusCode, http.StatusNotFound)
+		}
+	})
+}
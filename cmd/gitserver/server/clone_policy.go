@@ -0,0 +1,105 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"os/exec"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/vcs"
+)
+
+// ClonePolicy is the policy configured in the code host connections of a
+// repository for cloning and fetching it.
+type ClonePolicy struct {
+	// MaxSize is the size in bytes above which the repository is not cloned.
+	// It is 0 if there is no limit.
+	MaxSize int64
+
+	// Size is the size in bytes of the repository as reported by its code
+	// host. It is 0 if the size is unknown, in which case MaxSize is not
+	// enforced.
+	Size int64
+
+	// FetchLFS is true if the Git LFS objects of the repository are fetched
+	// after it is cloned or fetched.
+	FetchLFS bool
+}
+
+var repoCloneSkippedCounter = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "src_gitserver_repo_clone_skipped_total",
+	Help: "number of clones skipped because of the clone policy of the repository",
+})
+
+// skipReason returns why the repository must not be cloned, or "" if it can
+// be cloned.
+func (p *ClonePolicy) skipReason() string {
+	if p == nil || p.MaxSize <= 0 || p.Size <= p.MaxSize {
+		return ""
+	}
+	return fmt.Sprintf("repository size %s exceeds the maxRepoSize of %s of its code host connection", formatSize(p.Size), formatSize(p.MaxSize))
+}
+
+func formatSize(bytes int64) string {
+	switch {
+	case bytes >= 1<<30:
+		return fmt.Sprintf("%.1f GB", float64(bytes)/(1<<30))
+	case bytes >= 1<<20:
+		return fmt.Sprintf("%.1f MB", float64(bytes)/(1<<20))
+	default:
+		return fmt.Sprintf("%.1f KB", float64(bytes)/(1<<10))
+	}
+}
+
+// getClonePolicy returns the clone policy of repo. It returns nil if there is
+// no policy to enforce.
+func (s *Server) getClonePolicy(ctx context.Context, repo api.RepoName) (*ClonePolicy, error) {
+	if s.GetClonePolicy == nil {
+		return nil, nil
+	}
+
+	p, err := s.GetClonePolicy(ctx, repo)
+	return p, errors.Wrap(err, "GetClonePolicy")
+}
+
+func (s *Server) setCloneSkipReason(ctx context.Context, name api.RepoName, reason string) (err error) {
+	if s.DB == nil {
+		return nil
+	}
+	tx, err := database.Repos(s.DB).Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	repo, err := tx.GetByName(ctx, name)
+	if err != nil {
+		return err
+	}
+	return database.NewGitserverReposWith(tx).SetCloneSkipReason(ctx, repo.ID, reason, s.Hostname)
+}
+
+// setCloneSkipReasonNonFatal is the same as setCloneSkipReason but only logs
+// errors.
+func (s *Server) setCloneSkipReasonNonFatal(ctx context.Context, name api.RepoName, reason string) {
+	if err := s.setCloneSkipReason(ctx, name, reason); err != nil {
+		log15.Warn("Setting clone skip reason in DB", "error", err)
+	}
+}
+
+// fetchLFS fetches the Git LFS objects of all refs of the repository in dir.
+// It requires git-lfs to be installed.
+func fetchLFS(ctx context.Context, remoteURL *vcs.URL, dir GitDir) error {
+	cmd := exec.CommandContext(ctx, "git", "lfs", "fetch", "--all", remoteURL.String())
+	dir.Set(cmd)
+	if output, err := runWithRemoteOpts(ctx, cmd, nil); err != nil {
+		return errors.Wrapf(err, "failed to fetch Git LFS objects with output %q", newURLRedactor(remoteURL).redact(string(output)))
+	}
+	return nil
+}
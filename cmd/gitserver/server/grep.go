@@ -0,0 +1,203 @@
+package server
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"os/exec"
+	"strconv"
+
+	"github.com/cockroachdb/errors"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/pathmatch"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// handleGrep searches the contents of a repository at a commit with `git
+// grep` and streams the matching lines as newline-delimited JSON. Unlike
+// /archive, only the matching lines are sent over the network, which makes
+// it cheap to search large repositories for patterns which match rarely.
+//
+// Errors which happen after the first match has been written are reported in
+// the X-Grep-Error trailer, and X-Grep-Limit-Hit is "true" if more lines than
+// the limit of the request matched.
+func (s *Server) handleGrep(w http.ResponseWriter, r *http.Request) {
+	var req protocol.GrepRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if req.Repo == "" || req.Pattern == "" {
+		http.Error(w, "repo and pattern must be non-empty", http.StatusBadRequest)
+		return
+	}
+	if err := checkSpecArgSafety(string(req.Commit)); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	matchPath, err := pathmatch.CompilePathPatterns(req.IncludePatterns, req.ExcludePattern, pathmatch.CompileOptions{
+		RegExp:        req.PathPatternsAreRegExps,
+		CaseSensitive: req.PathPatternsAreCaseSensitive,
+	})
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req.Repo = protocol.NormalizeRepo(req.Repo)
+	dir := s.dir(req.Repo)
+	if !repoCloned(dir) {
+		cloneProgress, cloneInProgress := s.locker.Status(dir)
+		w.WriteHeader(http.StatusNotFound)
+		_ = json.NewEncoder(w).Encode(&protocol.NotFoundPayload{
+			CloneInProgress: cloneInProgress,
+			CloneProgress:   cloneProgress,
+		})
+		return
+	}
+
+	ctx, cancel := context.WithCancel(r.Context())
+	defer cancel()
+
+	tr, ctx := trace.New(ctx, "grep", string(req.Repo))
+	var (
+		matches  int
+		limitHit bool
+		grepErr  error
+	)
+	defer func() {
+		tr.LogFields(
+			otlog.String("commit", string(req.Commit)),
+			otlog.String("pattern", req.Pattern),
+			otlog.Int("matches", matches),
+			otlog.Bool("limitHit", limitHit),
+		)
+		tr.SetError(grepErr)
+		tr.Finish()
+	}()
+
+	var stderr bytes.Buffer
+	cmd := exec.CommandContext(ctx, "git", grepArgs(&req)...)
+	dir.Set(cmd)
+	cmd.Stderr = &limitWriter{W: &stderr, N: 1024}
+	stdout, err := cmd.StdoutPipe()
+	if err != nil {
+		grepErr = err
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+	if err := cmd.Start(); err != nil {
+		grepErr = err
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+		return
+	}
+
+	// Flush writes more aggressively than standard net/http so that clients
+	// can start processing matches while the search is still running.
+	if fw := newFlushingResponseWriter(w); fw != nil {
+		w = fw
+		defer fw.Close()
+	}
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Trailer", "X-Grep-Error")
+	w.Header().Add("Trailer", "X-Grep-Limit-Hit")
+	w.WriteHeader(http.StatusOK)
+
+	enc := json.NewEncoder(w)
+	br := bufio.NewReader(stdout)
+	prefix := []byte(string(req.Commit) + ":")
+	for {
+		line, err := br.ReadBytes('\n')
+		if len(line) > 0 {
+			m, ok := parseGrepLine(line, prefix)
+			if ok && matchPath.MatchPath(m.Path) {
+				if req.Limit > 0 && matches >= req.Limit {
+					limitHit = true
+					break
+				}
+				if err := enc.Encode(m); err != nil {
+					// The client went away.
+					grepErr = err
+					break
+				}
+				matches++
+			}
+		}
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			grepErr = err
+			break
+		}
+	}
+
+	if limitHit || grepErr != nil {
+		// Stop git grep, since we stopped reading its output early.
+		cancel()
+	}
+	err = cmd.Wait()
+	if grepErr == nil && !limitHit && err != nil {
+		// git grep exits with status 1 if no lines matched.
+		var exitErr *exec.ExitError
+		if !errors.As(err, &exitErr) || exitErr.ExitCode() != 1 || stderr.Len() > 0 {
+			grepErr = errors.Errorf("git grep failed: %s (stderr: %q)", err, stderr.String())
+			checkMaybeCorruptRepo(req.Repo, dir, stderr.String())
+		}
+	}
+
+	w.Header().Set("X-Grep-Error", errorString(grepErr))
+	w.Header().Set("X-Grep-Limit-Hit", strconv.FormatBool(limitHit))
+}
+
+// grepArgs returns the arguments of the git grep command which runs req.
+func grepArgs(req *protocol.GrepRequest) []string {
+	args := []string{
+		"grep",
+		// Separate the path, line number and line with NUL bytes, so that we
+		// can parse paths which contain colons.
+		"--null",
+		"--line-number",
+		"--no-color",
+		// Skip binary files.
+		"-I",
+	}
+	if req.IsRegExp {
+		args = append(args, "--extended-regexp")
+	} else {
+		args = append(args, "--fixed-strings")
+	}
+	if !req.IsCaseSensitive {
+		args = append(args, "--ignore-case")
+	}
+	return append(args, "-e", req.Pattern, string(req.Commit), "--")
+}
+
+// parseGrepLine parses a line of the output of git grep --null --line-number
+// with a commit, which has the form "<commit>:<path>\0<line number>\0<line>".
+func parseGrepLine(line, prefix []byte) (protocol.GrepMatch, bool) {
+	line = bytes.TrimSuffix(line, []byte{'\n'})
+	if !bytes.HasPrefix(line, prefix) {
+		return protocol.GrepMatch{}, false
+	}
+	parts := bytes.SplitN(line[len(prefix):], []byte{0}, 3)
+	if len(parts) != 3 {
+		return protocol.GrepMatch{}, false
+	}
+	lineNumber, err := strconv.Atoi(string(parts[1]))
+	if err != nil {
+		return protocol.GrepMatch{}, false
+	}
+	return protocol.GrepMatch{
+		Path:       string(parts[0]),
+		LineNumber: lineNumber,
+		Line:       string(parts[2]),
+	}, true
+}
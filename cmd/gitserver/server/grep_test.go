@@ -0,0 +1,131 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+)
+
+func TestServer_handleGrep(t *testing.T) {
+	reposDir := t.TempDir()
+	repoDir := filepath.Join(reposDir, "github.com/foo/bar")
+	if err := os.MkdirAll(filepath.Join(repoDir, "cmd"), 0700); err != nil {
+		t.Fatal(err)
+	}
+	cmd := func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, repoDir, name, arg...)
+	}
+	cmd("git", "init", ".")
+	cmd("sh", "-c", "printf 'hello world\\nfoo\\nHello again\\n' > hello.txt")
+	cmd("sh", "-c", "printf 'package main\\n// hello: colon\\n' > cmd/main.go")
+	cmd("sh", "-c", "printf 'hello\\000binary' > hello.bin")
+	cmd("git", "add", ".")
+	cmd("git", "commit", "-m", "hello")
+	commit := strings.TrimSpace(cmd("git", "rev-parse", "HEAD"))
+
+	s := makeTestServer(context.Background(), reposDir, "", nil)
+	h := s.Handler()
+
+	grep := func(req protocol.GrepRequest) (matches []protocol.GrepMatch, trailer http.Header) {
+		t.Helper()
+		body, err := json.Marshal(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		w := httptest.NewRecorder()
+		h.ServeHTTP(w, httptest.NewRequest("POST", "/grep", bytes.NewReader(body)))
+		resp := w.Result()
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("unexpected status code %d", resp.StatusCode)
+		}
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var m protocol.GrepMatch
+			if err := dec.Decode(&m); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			matches = append(matches, m)
+		}
+		return matches, resp.Trailer
+	}
+
+	tests := []struct {
+		name         string
+		req          protocol.GrepRequest
+		wantMatches  []protocol.GrepMatch
+		wantLimitHit string
+	}{
+		{
+			name: "case insensitive",
+			req:  protocol.GrepRequest{Pattern: "hello"},
+			wantMatches: []protocol.GrepMatch{
+				{Path: "cmd/main.go", LineNumber: 2, Line: "// hello: colon"},
+				{Path: "hello.txt", LineNumber: 1, Line: "hello world"},
+				{Path: "hello.txt", LineNumber: 3, Line: "Hello again"},
+			},
+			wantLimitHit: "false",
+		},
+		{
+			name: "case sensitive regexp",
+			req:  protocol.GrepRequest{Pattern: "^H[a-z]+", IsRegExp: true, IsCaseSensitive: true},
+			wantMatches: []protocol.GrepMatch{
+				{Path: "hello.txt", LineNumber: 3, Line: "Hello again"},
+			},
+			wantLimitHit: "false",
+		},
+		{
+			name: "path filters",
+			req:  protocol.GrepRequest{Pattern: "hello", IncludePatterns: []string{`\.txt$`}, PathPatternsAreRegExps: true},
+			wantMatches: []protocol.GrepMatch{
+				{Path: "hello.txt", LineNumber: 1, Line: "hello world"},
+				{Path: "hello.txt", LineNumber: 3, Line: "Hello again"},
+			},
+			wantLimitHit: "false",
+		},
+		{
+			name: "limit",
+			req:  protocol.GrepRequest{Pattern: "hello", Limit: 1},
+			wantMatches: []protocol.GrepMatch{
+				{Path: "cmd/main.go", LineNumber: 2, Line: "// hello: colon"},
+			},
+			wantLimitHit: "true",
+		},
+		{
+			name:         "no matches",
+			req:          protocol.GrepRequest{Pattern: "missing"},
+			wantLimitHit: "false",
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			test.req.Repo = "github.com/foo/bar"
+			test.req.Commit = api.CommitID(commit)
+
+			matches, trailer := grep(test.req)
+			if diff := cmp.Diff(test.wantMatches, matches); diff != "" {
+				t.Errorf("unexpected matches (-want +got):\n%s", diff)
+			}
+			if got := trailer.Get("X-Grep-Error"); got != "" {
+				t.Errorf("unexpected error %q", got)
+			}
+			if got := trailer.Get("X-Grep-Limit-Hit"); got != test.wantLimitHit {
+				t.Errorf("got limit hit %q, want %q", got, test.wantLimitHit)
+			}
+		})
+	}
+}
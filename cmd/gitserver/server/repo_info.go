@@ -13,6 +13,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/version"
 )
 
 func (s *Server) repoInfo(ctx context.Context, repo api.RepoName) (*protocol.RepoInfo, error) {
@@ -113,6 +114,29 @@ func (s *Server) handleReposStats(w http.ResponseWriter, r *http.Request) {
 	_, _ = w.Write(b)
 }
 
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	diskSizer := s.DiskSizer
+	if diskSizer == nil {
+		diskSizer = &StatDiskSizer{}
+	}
+
+	resp := protocol.HealthResponse{Version: version.Version()}
+	var err error
+	if resp.DiskSizeBytes, err = diskSizer.DiskSizeBytes(s.ReposDir); err != nil {
+		http.Error(w, fmt.Sprintf("failed to get disk size: %v", err), http.StatusInternalServerError)
+		return
+	}
+	if resp.DiskFreeBytes, err = diskSizer.BytesFreeOnDisk(s.ReposDir); err != nil {
+		http.Error(w, fmt.Sprintf("failed to get free disk space: %v", err), http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if err := json.NewEncoder(w).Encode(&resp); err != nil {
+		http.Error(w, err.Error(), http.StatusInternalServerError)
+	}
+}
+
 func (s *Server) handleRepoCloneProgress(w http.ResponseWriter, r *http.Request) {
 	var req protocol.RepoCloneProgressRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
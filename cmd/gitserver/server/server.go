@@ -134,6 +134,12 @@ type Server struct {
 	// usually set to return a GitRepoSyncer.
 	GetVCSSyncer func(context.Context, api.RepoName) (VCSSyncer, error)
 
+	// GetClonePolicy is a function which returns the clone policy for a
+	// repository. It is checked before cloning a repository. In production this
+	// will speak to the database to look up the code host connections of the
+	// repository. It may be nil, in which case there is no policy to enforce.
+	GetClonePolicy func(context.Context, api.RepoName) (*ClonePolicy, error)
+
 	// Hostname is how we identify this instance of gitserver. Generally it is the
 	// actual hostname but can also be overridden by the HOSTNAME environment variable.
 	Hostname string
@@ -276,6 +282,8 @@ func (s *Server) Handler() http.Handler {
 	mux := http.NewServeMux()
 	mux.HandleFunc("/archive", s.handleArchive)
 	mux.HandleFunc("/exec", s.handleExec)
+	mux.HandleFunc("/grep", s.handleGrep)
+	mux.HandleFunc("/blame", s.handleBlame)
 	mux.HandleFunc("/p4-exec", s.handleP4Exec)
 	mux.HandleFunc("/list", s.handleList)
 	mux.HandleFunc("/list-gitolite", s.handleListGitolite)
@@ -283,6 +291,7 @@ func (s *Server) Handler() http.Handler {
 	mux.HandleFunc("/is-repo-cloned", s.handleIsRepoCloned)
 	mux.HandleFunc("/repos", s.handleRepoInfo)
 	mux.HandleFunc("/repos-stats", s.handleReposStats)
+	mux.HandleFunc("/health", s.handleHealth)
 	mux.HandleFunc("/repo-clone-progress", s.handleRepoCloneProgress)
 	mux.HandleFunc("/delete", s.handleRepoDelete)
 	mux.HandleFunc("/repo-update", s.handleRepoUpdate)
@@ -1192,6 +1201,30 @@ func (s *Server) setCloneStatusNonFatal(ctx context.Context, name api.RepoName,
 	}
 }
 
+// setLastFetchedNonFatal records in the DB that the repo in dir was just
+// fetched, along with its size. Errors are only logged.
+func (s *Server) setLastFetchedNonFatal(ctx context.Context, name api.RepoName, dir GitDir) {
+	if s.DB == nil {
+		return
+	}
+	err := func() (err error) {
+		tx, err := database.Repos(s.DB).Transact(ctx)
+		if err != nil {
+			return err
+		}
+		defer func() { err = tx.Done(err) }()
+
+		repo, err := tx.GetByName(ctx, name)
+		if err != nil {
+			return err
+		}
+		return database.NewGitserverReposWith(tx).SetLastFetched(ctx, repo.ID, time.Now(), dirSize(dir.Path(".")), s.Hostname)
+	}()
+	if err != nil {
+		log15.Warn("Setting last fetched in DB", "error", err)
+	}
+}
+
 // setGitAttributes writes our global gitattributes to
 // gitDir/info/attributes. This will override .gitattributes inside of
 // repositories. It is used to unset attributes such as export-ignore.
@@ -1257,6 +1290,18 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, opts *cloneOp
 
 	redactor := newURLRedactor(remoteURL)
 
+	policy, err := s.getClonePolicy(ctx, repo)
+	if err != nil {
+		return "", err
+	}
+	if reason := policy.skipReason(); reason != "" {
+		repoCloneSkippedCounter.Inc()
+		s.setCloneSkipReasonNonFatal(ctx, repo, reason)
+		return "", errors.Errorf("error cloning repo: repo %s skipped: %s", repo, reason)
+	} else if policy != nil {
+		s.setCloneSkipReasonNonFatal(ctx, repo, "")
+	}
+
 	// isCloneable causes a network request, so we limit the number that can
 	// run at one time. We use a separate semaphore to cloning since these
 	// checks being blocked by a few slow clones will lead to poor feedback to
@@ -1361,6 +1406,12 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, opts *cloneOp
 			return errors.Wrapf(err, "clone failed. Output: %s", string(output))
 		}
 
+		if policy != nil && policy.FetchLFS && syncer.Type() == "git" {
+			if err := fetchLFS(ctx, remoteURL, tmp); err != nil {
+				log15.Warn("Failed to fetch Git LFS objects", "repo", repo, "error", err)
+			}
+		}
+
 		if testRepoCorrupter != nil {
 			testRepoCorrupter(ctx, tmp)
 		}
@@ -1404,6 +1455,8 @@ func (s *Server) cloneRepo(ctx context.Context, repo api.RepoName, opts *cloneOp
 		log15.Info("repo cloned", "repo", repo)
 		repoClonedCounter.Inc()
 
+		s.setLastFetchedNonFatal(ctx, repo, dir)
+
 		return nil
 	}
 
@@ -1674,6 +1727,14 @@ func (s *Server) doBackgroundRepoUpdate(repo api.RepoName) error {
 		return errors.Wrap(err, "failed to fetch")
 	}
 
+	if policy, err := s.getClonePolicy(ctx, repo); err != nil {
+		log15.Warn("Failed to get clone policy", "repo", repo, "error", err)
+	} else if policy != nil && policy.FetchLFS && syncer.Type() == "git" {
+		if err := fetchLFS(ctx, remoteURL, dir); err != nil {
+			log15.Warn("Failed to fetch Git LFS objects", "repo", repo, "error", err)
+		}
+	}
+
 	removeBadRefs(ctx, dir)
 
 	if err := setHEAD(ctx, dir, syncer, repo, remoteURL); err != nil {
@@ -1690,6 +1751,8 @@ func (s *Server) doBackgroundRepoUpdate(repo api.RepoName) error {
 		log15.Warn("Failed to update last changed time", "repo", repo, "error", err)
 	}
 
+	s.setLastFetchedNonFatal(ctx, repo, dir)
+
 	return nil
 }
 
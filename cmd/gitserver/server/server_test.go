@@ -825,6 +825,44 @@ func TestCloneRepo_EnsureValidity(t *testing.T) {
 	})
 }
 
+func TestCloneRepo_ClonePolicy(t *testing.T) {
+	ctx := context.Background()
+	remote := t.TempDir()
+	_ = makeSingleCommitRepo(func(name string, arg ...string) string {
+		t.Helper()
+		return runCmd(t, remote, name, arg...)
+	})
+
+	for _, tc := range []struct {
+		name       string
+		policy     *ClonePolicy
+		wantCloned bool
+	}{
+		{name: "no policy", wantCloned: true},
+		{name: "unknown size", policy: &ClonePolicy{MaxSize: 1 << 20}, wantCloned: true},
+		{name: "within limit", policy: &ClonePolicy{MaxSize: 1 << 20, Size: 1 << 10}, wantCloned: true},
+		{name: "too large", policy: &ClonePolicy{MaxSize: 1 << 20, Size: 1 << 30}, wantCloned: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			s := makeTestServer(ctx, t.TempDir(), remote, nil)
+			s.GetClonePolicy = func(context.Context, api.RepoName) (*ClonePolicy, error) {
+				return tc.policy, nil
+			}
+
+			_, err := s.cloneRepo(ctx, "example.com/foo/bar", &cloneOptions{Block: true})
+			if tc.wantCloned && err != nil {
+				t.Fatal(err)
+			}
+			if !tc.wantCloned && (err == nil || !strings.Contains(err.Error(), "exceeds the maxRepoSize")) {
+				t.Fatalf("expected the clone to be skipped, got error %v", err)
+			}
+			if cloned := repoCloned(s.dir("example.com/foo/bar")); cloned != tc.wantCloned {
+				t.Fatalf("want cloned %v, got %v", tc.wantCloned, cloned)
+			}
+		})
+	}
+}
+
 func TestHostnameMatch(t *testing.T) {
 	testCases := []struct {
 		hostname    string
@@ -186,7 +186,13 @@ func configureRemoteGitCommand(cmd *exec.Cmd, tlsConf *tlsConfig) {
 	// the command is non-interactive).
 	//
 	// And set a timeout to avoid indefinite hangs if the server is unreachable.
-	cmd.Env = append(cmd.Env, "GIT_SSH_COMMAND=ssh -o BatchMode=yes -o ConnectTimeout=30")
+	sshCommand := "ssh -o BatchMode=yes -o ConnectTimeout=30"
+	if keyFile := envValue(cmd.Env, sshKeyFileEnv); keyFile != "" {
+		// Only offer the key of the repository's fetch credential, not the
+		// keys of the user gitserver runs as.
+		sshCommand += fmt.Sprintf(" -o IdentitiesOnly=yes -i %q", keyFile)
+	}
+	cmd.Env = append(cmd.Env, "GIT_SSH_COMMAND="+sshCommand)
 
 	if tlsConf.SSLNoVerify {
 		cmd.Env = append(cmd.Env, "GIT_SSL_NO_VERIFY=true")
@@ -209,6 +215,17 @@ func configureRemoteGitCommand(cmd *exec.Cmd, tlsConf *tlsConfig) {
 	cmd.Args = append(cmd.Args[:1], append(extraArgs, cmd.Args[1:]...)...)
 }
 
+// envValue returns the value of the last assignment to key in env, or "" if
+// there is none.
+func envValue(env []string, key string) string {
+	for i := len(env) - 1; i >= 0; i-- {
+		if strings.HasPrefix(env[i], key+"=") {
+			return strings.TrimPrefix(env[i], key+"=")
+		}
+	}
+	return ""
+}
+
 // writeTempFile writes data to the TempFile with pattern. Returns the path of
 // the tempfile.
 func writeTempFile(pattern string, data []byte) (path string, err error) {
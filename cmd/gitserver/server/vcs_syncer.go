@@ -2,10 +2,12 @@ package server
 
 import (
 	"context"
+	"crypto/sha256"
 	"os"
 	"os/exec"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/vcs"
@@ -33,7 +35,62 @@ type VCSSyncer interface {
 }
 
 // GitRepoSyncer is a syncer for Git repositories.
-type GitRepoSyncer struct{}
+type GitRepoSyncer struct {
+	// SSHKey, if set, is the PEM-encoded private key that remote commands
+	// authenticate with over SSH instead of the keys of the user gitserver runs
+	// as. It comes from the fetch credential of the repository.
+	SSHKey string
+}
+
+// sshKeyFileEnv is set in the environment of a remote git command to the path
+// of the private key ssh should use, see configureRemoteGitCommand.
+const sshKeyFileEnv = "SRC_GIT_SSH_KEY_FILE"
+
+// configureSSHKey makes the remote git command cmd use s.SSHKey, if set.
+func (s *GitRepoSyncer) configureSSHKey(cmd *exec.Cmd) error {
+	if s.SSHKey == "" {
+		return nil
+	}
+
+	keyFile, err := sshKeyFile(s.SSHKey)
+	if err != nil {
+		return errors.Wrap(err, "writing SSH key")
+	}
+	if cmd.Env == nil {
+		cmd.Env = os.Environ()
+	}
+	cmd.Env = append(cmd.Env, sshKeyFileEnv+"="+keyFile)
+	return nil
+}
+
+var sshKeyFiles = struct {
+	sync.Mutex
+	paths map[[sha256.Size]byte]string
+}{paths: map[[sha256.Size]byte]string{}}
+
+// sshKeyFile returns the path of a file holding key, which ssh only reads from a
+// file. Each distinct key is written once to the temporary directory, which is
+// only readable by gitserver. We don't clean up the files since they have a
+// process life time and the temporary directory is cleared on startup.
+func sshKeyFile(key string) (string, error) {
+	if !strings.HasSuffix(key, "\n") {
+		key += "\n" // ssh rejects keys without a trailing newline
+	}
+	sum := sha256.Sum256([]byte(key))
+
+	sshKeyFiles.Lock()
+	defer sshKeyFiles.Unlock()
+
+	if p, ok := sshKeyFiles.paths[sum]; ok {
+		return p, nil
+	}
+	p, err := writeTempFile("ssh-key-*", []byte(key))
+	if err != nil {
+		return "", err
+	}
+	sshKeyFiles.paths[sum] = p
+	return p, nil
+}
 
 func (s *GitRepoSyncer) Type() string {
 	return "git"
@@ -53,6 +110,9 @@ func (s *GitRepoSyncer) IsCloneable(ctx context.Context, remoteURL *vcs.URL) err
 	defer cancel()
 
 	cmd := exec.CommandContext(ctx, "git", args...)
+	if err := s.configureSSHKey(cmd); err != nil {
+		return err
+	}
 	out, err := runWithRemoteOpts(ctx, cmd, nil)
 	if err != nil {
 		if ctxerr := ctx.Err(); ctxerr != nil {
@@ -78,8 +138,13 @@ func (s *GitRepoSyncer) CloneCommand(ctx context.Context, remoteURL *vcs.URL, tm
 		return nil, errors.Wrapf(err, "clone setup failed")
 	}
 
-	cmd, _ = s.fetchCommand(ctx, remoteURL)
+	cmd, configRemoteOpts := s.fetchCommand(ctx, remoteURL)
 	cmd.Dir = tmpPath
+	if configRemoteOpts {
+		if err := s.configureSSHKey(cmd); err != nil {
+			return nil, err
+		}
+	}
 	return cmd, nil
 }
 
@@ -113,6 +178,11 @@ func (s *GitRepoSyncer) fetchCommand(ctx context.Context, remoteURL *vcs.URL) (c
 func (s *GitRepoSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDir) error {
 	cmd, configRemoteOpts := s.fetchCommand(ctx, remoteURL)
 	dir.Set(cmd)
+	if configRemoteOpts {
+		if err := s.configureSSHKey(cmd); err != nil {
+			return err
+		}
+	}
 	if output, err := runWith(ctx, cmd, configRemoteOpts, nil); err != nil {
 		return errors.Wrapf(err, "failed to update with output %q", newURLRedactor(remoteURL).redact(string(output)))
 	}
@@ -121,7 +191,11 @@ func (s *GitRepoSyncer) Fetch(ctx context.Context, remoteURL *vcs.URL, dir GitDi
 
 // RemoteShowCommand returns the command to be executed for showing remote of a Git repository.
 func (s *GitRepoSyncer) RemoteShowCommand(ctx context.Context, remoteURL *vcs.URL) (cmd *exec.Cmd, err error) {
-	return exec.CommandContext(ctx, "git", "remote", "show", remoteURL.String()), nil
+	cmd = exec.CommandContext(ctx, "git", "remote", "show", remoteURL.String())
+	if err := s.configureSSHKey(cmd); err != nil {
+		return nil, err
+	}
+	return cmd, nil
 }
 
 // PerforceDepotSyncer is a syncer for Perforce depots.
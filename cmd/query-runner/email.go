@@ -71,7 +71,15 @@ func (n *notifier) emailNotify(ctx context.Context) {
 	}()
 }
 
-var newSearchResultsEmailTemplates = txemail.MustValidate(txtypes.Templates{
+var newSearchResultsEmailTemplates = txemail.MustRegister("savedSearchResults", map[string]string{
+	"URL":                    "https://sourcegraph.example.com/search?q=example",
+	"SavedSearchPageURL":     "https://sourcegraph.example.com/users/alice/searches",
+	"Description":            "example saved search",
+	"Query":                  "example",
+	"ApproximateResultCount": "3",
+	"Ownership":              "your",
+	"PluralResults":          "s",
+}, txtypes.Templates{
 	Subject: `[{{.ApproximateResultCount}} new result{{.PluralResults}}] {{.Description}}`,
 	Text: `
 {{.ApproximateResultCount}} new search result{{.PluralResults}} found for {{.Ownership}} saved search:
@@ -150,7 +158,10 @@ func sendEmail(ctx context.Context, userID int32, eventType string, template txt
 	return nil
 }
 
-var notifySubscribedTemplate = txemail.MustValidate(txtypes.Templates{
+var notifySubscribedTemplate = txemail.MustRegister("savedSearchSubscribed", map[string]string{
+	"Ownership":   "your",
+	"Description": "example saved search",
+}, txtypes.Templates{
 	Subject: `[Subscribed] {{.Description}}`,
 	Text: `
 You are now receiving notifications for {{.Ownership}} saved search:
@@ -168,7 +179,10 @@ When new search results become available, we will notify you.
 `,
 })
 
-var notifyUnsubscribedTemplate = txemail.MustValidate(txtypes.Templates{
+var notifyUnsubscribedTemplate = txemail.MustRegister("savedSearchUnsubscribed", map[string]string{
+	"Ownership":   "your",
+	"Description": "example saved search",
+}, txtypes.Templates{
 	Subject: `[Unsubscribed] {{.Description}}`,
 	Text: `
 You will no longer receive notifications for {{.Ownership}} saved search:
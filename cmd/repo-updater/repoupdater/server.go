@@ -3,6 +3,7 @@ package repoupdater
 
 import (
 	"context"
+	"database/sql"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -26,6 +27,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/version"
 )
 
 // Server is a repoupdater server.
@@ -43,8 +45,9 @@ type Server struct {
 		GetRepo(ctx context.Context, artifactName string) (*types.Repo, error)
 	}
 	Scheduler interface {
-		UpdateOnce(id api.RepoID, name api.RepoName)
+		UpdateOnce(id api.RepoID, name api.RepoName) (deduplicated bool)
 		ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedulerInfoResult
+		Stats() (scheduled, queued int)
 	}
 	GitserverClient interface {
 		ListCloned(context.Context) ([]string, error)
@@ -75,10 +78,12 @@ func (s *Server) Handler() http.Handler {
 	mux.HandleFunc("/healthz", func(w http.ResponseWriter, _ *http.Request) {
 		w.WriteHeader(http.StatusOK)
 	})
+	mux.HandleFunc("/health", s.handleHealth)
 	mux.HandleFunc("/repo-update-scheduler-info", s.handleRepoUpdateSchedulerInfo)
 	mux.HandleFunc("/repo-lookup", s.handleRepoLookup)
 	mux.HandleFunc("/enqueue-repo-update", s.handleEnqueueRepoUpdate)
 	mux.HandleFunc("/sync-external-service", s.handleExternalServiceSync)
+	mux.HandleFunc("/sync-external-service-dry-run", s.handleExternalServiceSyncDryRun)
 	mux.HandleFunc("/enqueue-changeset-sync", s.handleEnqueueChangesetSync)
 	mux.HandleFunc("/schedule-perms-sync", s.handleSchedulePermsSync)
 	return mux
@@ -109,6 +114,12 @@ func respond(w http.ResponseWriter, code int, v interface{}) {
 	}
 }
 
+func (s *Server) handleHealth(w http.ResponseWriter, r *http.Request) {
+	result := protocol.HealthResult{Version: version.Version()}
+	result.ScheduledRepos, result.QueuedUpdates = s.Scheduler.Stats()
+	respond(w, http.StatusOK, &result)
+}
+
 func (s *Server) handleRepoUpdateSchedulerInfo(w http.ResponseWriter, r *http.Request) {
 	var args protocol.RepoUpdateSchedulerInfoArgs
 	if err := json.NewDecoder(r.Body).Decode(&args); err != nil {
@@ -171,6 +182,8 @@ func (s *Server) enqueueRepoUpdate(ctx context.Context, req *protocol.RepoUpdate
 				otlog.Int32("resp.id", int32(resp.ID)),
 				otlog.String("resp.name", resp.Name),
 				otlog.String("resp.url", resp.URL),
+				otlog.Bool("resp.deduplicated", resp.Deduplicated),
+				otlog.Int("resp.queuePosition", resp.QueuePosition),
 			)
 		}
 		tr.SetError(err)
@@ -188,12 +201,31 @@ func (s *Server) enqueueRepoUpdate(ctx context.Context, req *protocol.RepoUpdate
 
 	repo := rs[0]
 
-	s.Scheduler.UpdateOnce(repo.ID, repo.Name)
+	resp = &protocol.RepoUpdateResponse{
+		ID:           repo.ID,
+		Name:         string(repo.Name),
+		Deduplicated: s.Scheduler.UpdateOnce(repo.ID, repo.Name),
+	}
 
-	return &protocol.RepoUpdateResponse{
-		ID:   repo.ID,
-		Name: string(repo.Name),
-	}, http.StatusOK, nil
+	info := s.Scheduler.ScheduleInfo(repo.ID)
+	if info.Queue != nil {
+		resp.Updating = info.Queue.Updating
+		resp.QueuePosition = info.Queue.Position
+	}
+	if info.Schedule != nil {
+		due := info.Schedule.Due
+		resp.NextScheduledFetch = &due
+	}
+
+	// The last error is purely diagnostic, so we don't fail the request if we can't read it.
+	gitserverRepo, err := database.NewGitserverReposWith(s.Store).GetByID(ctx, repo.ID)
+	if err == nil {
+		resp.LastError = gitserverRepo.LastError
+	} else if !errors.Is(err, sql.ErrNoRows) {
+		log15.Warn("enqueueRepoUpdate: failed to read last error", "repo", repo.Name, "error", err)
+	}
+
+	return resp, http.StatusOK, nil
 }
 
 func (s *Server) handleExternalServiceSync(w http.ResponseWriter, r *http.Request) {
@@ -255,6 +287,45 @@ func (s *Server) handleExternalServiceSync(w http.ResponseWriter, r *http.Reques
 	})
 }
 
+func (s *Server) handleExternalServiceSyncDryRun(w http.ResponseWriter, r *http.Request) {
+	var req protocol.ExternalServiceSyncDryRunRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	dryRun, err := s.Syncer.SyncExternalServiceDryRun(r.Context(), &types.ExternalService{
+		ID:              req.ExternalService.ID,
+		Kind:            req.ExternalService.Kind,
+		DisplayName:     req.ExternalService.DisplayName,
+		Config:          req.ExternalService.Config,
+		NamespaceUserID: req.ExternalService.NamespaceUserID,
+	})
+	if err != nil {
+		log15.Error("server.external-service-sync-dry-run", "kind", req.ExternalService.Kind, "error", err)
+		respond(w, http.StatusInternalServerError, err)
+		return
+	}
+
+	result := &protocol.ExternalServiceSyncDryRunResult{
+		Added:   repoNames(dryRun.Added),
+		Removed: repoNames(dryRun.Removed),
+		Renamed: make([]protocol.RepoRename, 0, len(dryRun.Renamed)),
+	}
+	for _, rename := range dryRun.Renamed {
+		result.Renamed = append(result.Renamed, protocol.RepoRename{From: rename.From, To: rename.To})
+	}
+	respond(w, http.StatusOK, result)
+}
+
+func repoNames(rs types.Repos) []api.RepoName {
+	names := make([]api.RepoName, 0, len(rs))
+	for _, r := range rs {
+		names = append(names, r.Name)
+	}
+	return names
+}
+
 func externalServiceValidate(ctx context.Context, req protocol.ExternalServiceSyncRequest, src repos.Source) error {
 	if !req.ExternalService.DeletedAt.IsZero() {
 		// We don't need to check deleted services.
@@ -745,10 +745,11 @@ func (s *fakeRepoSource) GetRepo(context.Context, string) (*types.Repo, error) {
 
 type fakeScheduler struct{}
 
-func (s *fakeScheduler) UpdateOnce(_ api.RepoID, _ api.RepoName) {}
+func (s *fakeScheduler) UpdateOnce(_ api.RepoID, _ api.RepoName) bool { return false }
 func (s *fakeScheduler) ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedulerInfoResult {
 	return &protocol.RepoUpdateSchedulerInfoResult{}
 }
+func (s *fakeScheduler) Stats() (scheduled, queued int) { return 0, 0 }
 
 type fakePermsSyncer struct{}
 
@@ -26,6 +26,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/cache"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbconn"
@@ -40,11 +41,13 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/logging"
 	"github.com/sourcegraph/sourcegraph/internal/profiler"
 	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
 	"github.com/sourcegraph/sourcegraph/internal/repos"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 	"github.com/sourcegraph/sourcegraph/internal/tracer"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/version/skew"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
 
@@ -141,6 +144,9 @@ func Main(enterpriseInit EnterpriseInit) {
 	if err != nil {
 		log.Fatalf("failed to initialize database store: %v", err)
 	}
+	if err := skew.Register(ctx, db, "repo-updater"); err != nil {
+		log.Fatal(err)
+	}
 	// Generally we'll mark the service as ready sometime after the database
 	// has been connected; migrations may take a while and we don't want to
 	// start accepting traffic until we've fully constructed the server we'll
@@ -239,6 +245,10 @@ func Main(enterpriseInit EnterpriseInit) {
 		}
 	}
 
+	// The repositories resolved for searches are cached by the frontend and
+	// invalidated when the syncer changes repositories.
+	database.SearchReposCache = cache.NewVersioned(cache.NewRedis(redispool.Cache, "search_repos"), 30*time.Second)
+
 	syncer := &repos.Syncer{
 		Sourcer: src,
 		Store:   store,
@@ -441,6 +451,7 @@ func watchSyncer(ctx context.Context, syncer *repos.Syncer, sched scheduler, gps
 			if !conf.Get().DisableAutoGitUpdates {
 				sched.UpdateFromDiff(diff)
 			}
+			invalidateSearchRepos(ctx, diff)
 			if gps == nil {
 				continue
 			}
@@ -454,10 +465,19 @@ func watchSyncer(ctx context.Context, syncer *repos.Syncer, sched scheduler, gps
 			if !conf.Get().DisableAutoGitUpdates {
 				sched.UpdateFromDiff(diff)
 			}
+			invalidateSearchRepos(ctx, diff)
 		}
 	}
 }
 
+// invalidateSearchRepos invalidates the repositories resolved for searches if
+// the diff added, modified or deleted any repositories.
+func invalidateSearchRepos(ctx context.Context, diff repos.Diff) {
+	if len(diff.Added)+len(diff.Modified)+len(diff.Deleted) > 0 {
+		database.InvalidateSearchRepos(ctx)
+	}
+}
+
 // syncScheduler will periodically list the cloned repositories on gitserver and
 // update the scheduler with the list. It also ensures that if any of our default
 // repos are missing from the cloned list they will be added for cloning ASAP.
@@ -22,6 +22,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/env"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/logging"
+	"github.com/sourcegraph/sourcegraph/internal/rcache"
 	"github.com/sourcegraph/sourcegraph/internal/store"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
@@ -30,6 +31,9 @@ import (
 
 var cacheDir = env.Get("CACHE_DIR", "/tmp", "directory to store cached archives.")
 var cacheSizeMB = env.Get("SEARCHER_CACHE_SIZE_MB", "100000", "maximum size of the on disk cache in megabytes")
+var minDiskFreeMB = env.Get("SEARCHER_MIN_DISK_FREE_MB", "5000", "free disk space in megabytes below which the on disk cache is evicted and concurrent fetches are reduced (0 disables)")
+var structuralCacheTTL = env.Get("SEARCHER_STRUCTURAL_CACHE_TTL", "24h", "how long the results of structural searches are cached in Redis (0 disables the cache)")
+var disableGitserverGrep, _ = strconv.ParseBool(env.Get("SEARCHER_DISABLE_GITSERVER_GREP", "false", "disables searching file contents with git grep on gitserver instead of fetching archives"))
 
 const port = "3181"
 
@@ -53,6 +57,13 @@ func main() {
 		cacheSizeBytes = i * 1000 * 1000
 	}
 
+	var minDiskFreeBytes int64
+	if i, err := strconv.ParseInt(minDiskFreeMB, 10, 64); err != nil {
+		log.Fatalf("invalid int %q for SEARCHER_MIN_DISK_FREE_MB: %s", minDiskFreeMB, err)
+	} else {
+		minDiskFreeBytes = i * 1000 * 1000
+	}
+
 	service := &search.Service{
 		Store: &store.Store{
 			FetchTar: func(ctx context.Context, repo api.RepoName, commit api.CommitID) (io.ReadCloser, error) {
@@ -61,9 +72,18 @@ func main() {
 			FilterTar:         search.NewFilter,
 			Path:              filepath.Join(cacheDir, "searcher-archives"),
 			MaxCacheSizeBytes: cacheSizeBytes,
+			MinDiskFreeBytes:  minDiskFreeBytes,
 		},
 		Log: log15.Root(),
 	}
+	if !disableGitserverGrep {
+		service.GitserverGrep = gitserver.DefaultClient.Grep
+	}
+	if ttl, err := time.ParseDuration(structuralCacheTTL); err != nil {
+		log.Fatalf("invalid duration %q for SEARCHER_STRUCTURAL_CACHE_TTL: %s", structuralCacheTTL, err)
+	} else if ttl > 0 {
+		service.StructuralCache = rcache.NewWithTTL("searcher-structural", int(ttl.Seconds()))
+	}
 	service.Store.Start()
 	handler := ot.Middleware(service)
 
@@ -53,6 +53,15 @@ const (
 type Service struct {
 	Store *store.Store
 	Log   log15.Logger
+
+	// GitserverGrep, if set, is used to search the content of files for
+	// patterns which contain a fixed string, instead of fetching an archive
+	// of the repository. See gitserverGrepLiteral.
+	GitserverGrep GrepFunc
+
+	// StructuralCache, if set, caches the results of unindexed structural
+	// searches by pattern and repository commit.
+	StructuralCache StructuralCache
 }
 
 var decoder = schema.NewDecoder()
@@ -207,6 +216,26 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender *limit
 		}
 	}
 
+	if s.GitserverGrep != nil {
+		if literal, ok := gitserverGrepLiteral(p, rg); ok {
+			tr.LazyPrintf("gitserver grep %q", literal)
+			span.SetTag("gitserverGrep", true)
+			return false, gitserverGrepSearch(ctx, s.GitserverGrep, p, rg, literal, sender)
+		}
+	}
+
+	var cacheKey string
+	if p.IsStructuralPat && s.StructuralCache != nil {
+		if cacheKey, err = structuralCacheKey(p); err != nil {
+			return false, err
+		}
+		if getCachedStructuralSearch(s.StructuralCache, cacheKey, sender) {
+			tr.LazyPrintf("structural cache hit")
+			span.SetTag("structuralCacheHit", true)
+			return false, nil
+		}
+	}
+
 	if p.FetchTimeout == "" {
 		p.FetchTimeout = "500ms"
 	}
@@ -242,7 +271,11 @@ func (s *Service) search(ctx context.Context, p *protocol.Request, sender *limit
 	archiveSize.Observe(float64(bytes))
 
 	if p.IsStructuralPat {
-		return false, filteredStructuralSearch(ctx, zipPath, zf, &p.PatternInfo, p.Repo, sender)
+		err = filteredStructuralSearch(ctx, zipPath, zf, &p.PatternInfo, p.Repo, sender)
+		if err == nil && cacheKey != "" {
+			setCachedStructuralSearch(ctx, s.StructuralCache, cacheKey, sender)
+		}
+		return false, err
 	} else {
 		return false, regexSearch(ctx, rg, zf, p.Limit, p.PatternMatchesContent, p.PatternMatchesPath, p.IsNegated, sender)
 	}
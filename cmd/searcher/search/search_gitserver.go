@@ -0,0 +1,158 @@
+package search
+
+import (
+	"context"
+	"regexp/syntax"
+	"strings"
+
+	"github.com/opentracing/opentracing-go/ext"
+	otlog "github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+	gitprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
+)
+
+// GrepFunc runs a `git grep` on gitserver, calling onMatch for each matching
+// line. See (*gitserver.Client).Grep.
+type GrepFunc func(ctx context.Context, req *gitprotocol.GrepRequest, onMatch func(gitprotocol.GrepMatch) error) (limitHit bool, err error)
+
+// gitserverGrepLiteral returns the fixed string which is contained in every
+// line matched by rg. It returns false if the search for p can't be offloaded
+// to gitserver's grep, because it matches file paths or matches could span
+// multiple lines.
+//
+// Searching with gitserver is worth it for content searches which find a
+// fixed string, since gitserver then only sends us the lines containing it
+// instead of an archive of the whole repository.
+func gitserverGrepLiteral(p *protocol.Request, rg *readerGrep) (string, bool) {
+	if p.IsStructuralPat || p.IsNegated || p.PatternMatchesPath || !p.PatternMatchesContent || rg.re == nil {
+		return "", false
+	}
+
+	literal, _ := rg.re.LiteralPrefix()
+	if literal == "" {
+		literal = string(rg.literalSubstring)
+	}
+	if literal == "" || strings.Contains(literal, "\n") {
+		return "", false
+	}
+
+	ast, err := syntax.Parse(rg.re.String(), syntax.Perl)
+	if err != nil || spansLines(ast) {
+		return "", false
+	}
+	return literal, true
+}
+
+// spansLines reports whether re may match text which spans multiple lines, or
+// depends on the position of the match in the file rather than in the line.
+func spansLines(re *syntax.Regexp) bool {
+	switch re.Op {
+	case syntax.OpAnyChar, syntax.OpBeginText, syntax.OpEndText:
+		return true
+	case syntax.OpLiteral:
+		for _, r := range re.Rune {
+			if r == '\n' {
+				return true
+			}
+		}
+	case syntax.OpCharClass:
+		for i := 0; i+1 < len(re.Rune); i += 2 {
+			if re.Rune[i] <= '\n' && '\n' <= re.Rune[i+1] {
+				return true
+			}
+		}
+	}
+	for _, sub := range re.Sub {
+		if spansLines(sub) {
+			return true
+		}
+	}
+	return false
+}
+
+// gitserverGrepSearch searches the content of the files of p.Repo for rg with
+// gitserver's grep. gitserver finds the lines which contain literal, which we
+// then match with rg to find the same matches as regexSearch.
+//
+// Unlike archives, gitserver doesn't skip large files, so it may find matches
+// in files which would otherwise not be searched.
+func gitserverGrepSearch(ctx context.Context, grep GrepFunc, p *protocol.Request, rg *readerGrep, literal string, sender *limitedStreamCollector) (err error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "GitserverGrepSearch")
+	ext.Component.Set(span, "gitserver_grep_search")
+	span.SetTag("literal", literal)
+	var lines int
+	defer func() {
+		span.LogFields(otlog.Int("lines", lines))
+		if err != nil {
+			ext.Error.Set(span, true)
+			span.SetTag("err", err.Error())
+		}
+		span.Finish()
+	}()
+
+	ig, err := newIgnoreMatcher(ctx, p.Repo, p.Commit)
+	if err != nil {
+		return err
+	}
+
+	var (
+		fm           protocol.FileMatch
+		transformBuf []byte
+	)
+	send := func() {
+		if len(fm.LineMatches) > 0 {
+			fm.MatchCount = len(fm.LineMatches)
+			sender.Send(fm)
+		}
+	}
+
+	_, err = grep(ctx, &gitprotocol.GrepRequest{
+		Repo:                         p.Repo,
+		Commit:                       p.Commit,
+		Pattern:                      literal,
+		IsCaseSensitive:              p.IsCaseSensitive,
+		IncludePatterns:              p.IncludePatterns,
+		ExcludePattern:               p.ExcludePattern,
+		PathPatternsAreRegExps:       p.PathPatternsAreRegExps,
+		PathPatternsAreCaseSensitive: p.PathPatternsAreCaseSensitive,
+	}, func(m gitprotocol.GrepMatch) error {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		lines++
+		if ig.Match(m.Path) {
+			return nil
+		}
+		if m.Path != fm.Path {
+			send()
+			fm = protocol.FileMatch{Path: m.Path}
+		}
+
+		// As in readerGrep.Find, we match the lowercased line if we are
+		// ignoring case, since compile lowercased the pattern.
+		lineBuf := []byte(m.Line)
+		matchBuf := lineBuf
+		if rg.ignoreCase {
+			if cap(transformBuf) < len(lineBuf) {
+				transformBuf = make([]byte, len(lineBuf))
+			}
+			matchBuf = transformBuf[:len(lineBuf)]
+			bytesToLowerASCII(matchBuf, lineBuf)
+		}
+		for _, loc := range rg.re.FindAllIndex(matchBuf, -1) {
+			fm.LineMatches = appendMatches(fm.LineMatches, lineBuf, matchBuf, m.LineNumber-1, loc[0], loc[1])
+		}
+		return nil
+	})
+	if err != nil {
+		if sender.LimitHit() {
+			// We canceled the search since we found enough matches.
+			return nil
+		}
+		return err
+	}
+	send()
+	return nil
+}
@@ -0,0 +1,232 @@
+package search
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"os"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	gitprotocol "github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/pathmatch"
+	"github.com/sourcegraph/sourcegraph/internal/store"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestGitserverGrepLiteral(t *testing.T) {
+	tests := []struct {
+		name        string
+		p           protocol.PatternInfo
+		wantLiteral string
+		wantOK      bool
+	}{
+		{
+			name:        "literal",
+			p:           protocol.PatternInfo{Pattern: "Foo", IsCaseSensitive: true},
+			wantLiteral: "Foo",
+			wantOK:      true,
+		},
+		{
+			name:        "literal ignoring case",
+			p:           protocol.PatternInfo{Pattern: "Foo"},
+			wantLiteral: "foo",
+			wantOK:      true,
+		},
+		{
+			name:        "word match",
+			p:           protocol.PatternInfo{Pattern: "foo", IsWordMatch: true, IsCaseSensitive: true},
+			wantLiteral: "foo",
+			wantOK:      true,
+		},
+		{
+			name:        "regexp",
+			p:           protocol.PatternInfo{Pattern: `^func \w+Handler`, IsRegExp: true, IsCaseSensitive: true},
+			wantLiteral: "Handler",
+			wantOK:      true,
+		},
+		{
+			name: "regexp without literal",
+			p:    protocol.PatternInfo{Pattern: `[a-z]+`, IsRegExp: true},
+		},
+		{
+			name: "regexp matching newlines",
+			p:    protocol.PatternInfo{Pattern: `foo\nbar`, IsRegExp: true},
+		},
+		{
+			name: "regexp matching any character",
+			p:    protocol.PatternInfo{Pattern: `(?s)foo.*bar`, IsRegExp: true},
+		},
+		{
+			name: "regexp with whitespace class",
+			p:    protocol.PatternInfo{Pattern: `foo\s+bar`, IsRegExp: true},
+		},
+		{
+			name: "regexp with negated class",
+			p:    protocol.PatternInfo{Pattern: `foo[^x]bar`, IsRegExp: true},
+		},
+		{
+			name: "regexp anchored to file",
+			p:    protocol.PatternInfo{Pattern: `\Afoo`, IsRegExp: true},
+		},
+		{
+			name: "negated",
+			p:    protocol.PatternInfo{Pattern: "foo", IsNegated: true},
+		},
+		{
+			name: "matches paths",
+			p:    protocol.PatternInfo{Pattern: "foo", PatternMatchesPath: true},
+		},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if !test.p.PatternMatchesPath {
+				test.p.PatternMatchesContent = true
+			}
+			rg, err := compile(&test.p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			literal, ok := gitserverGrepLiteral(&protocol.Request{PatternInfo: test.p}, rg)
+			if literal != test.wantLiteral || ok != test.wantOK {
+				t.Errorf("got (%q, %v), want (%q, %v)", literal, ok, test.wantLiteral, test.wantOK)
+			}
+		})
+	}
+}
+
+func TestGitserverGrepSearch(t *testing.T) {
+	files := map[string]string{
+		"README.md":           "# Foo\n\nfoo is a library to foo bars.\n",
+		"main.go":             "package main\n\nfunc main() {\n\tfoo.Bar()\n\tfooBar()\n}\n",
+		"ignored/a.go":        "package ignored // foo\n",
+		"empty.txt":           "nothing to see here\n",
+		".sourcegraph/ignore": "ignored/\n",
+	}
+
+	git.Mocks.ReadFile = func(commit api.CommitID, name string) ([]byte, error) {
+		if data, ok := files[name]; ok {
+			return []byte(data), nil
+		}
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	defer git.ResetMocks()
+
+	// grep behaves like gitserver's grep for a fixed string.
+	grep := func(ctx context.Context, req *gitprotocol.GrepRequest, onMatch func(gitprotocol.GrepMatch) error) (bool, error) {
+		matchPath, err := pathmatch.CompilePathPatterns(req.IncludePatterns, req.ExcludePattern, pathmatch.CompileOptions{
+			RegExp:        req.PathPatternsAreRegExps,
+			CaseSensitive: req.PathPatternsAreCaseSensitive,
+		})
+		if err != nil {
+			return false, err
+		}
+		var paths []string
+		for path := range files {
+			if matchPath.MatchPath(path) {
+				paths = append(paths, path)
+			}
+		}
+		sort.Strings(paths)
+		for _, path := range paths {
+			for i, line := range strings.Split(files[path], "\n") {
+				if req.IsCaseSensitive && !strings.Contains(line, req.Pattern) ||
+					!req.IsCaseSensitive && !strings.Contains(strings.ToLower(line), strings.ToLower(req.Pattern)) {
+					continue
+				}
+				if err := onMatch(gitprotocol.GrepMatch{Path: path, LineNumber: i + 1, Line: line}); err != nil {
+					return false, err
+				}
+			}
+		}
+		return false, nil
+	}
+
+	// The archive which regexSearch would search, without the ignored files.
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for path, data := range files {
+		if strings.HasPrefix(path, "ignored/") {
+			continue
+		}
+		w, err := zw.CreateHeader(&zip.FileHeader{Name: path, Method: zip.Store})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if _, err := w.Write([]byte(data)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := zw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	zf, err := store.MockZipFile(buf.Bytes())
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for _, p := range []protocol.PatternInfo{
+		{Pattern: "foo"},
+		{Pattern: "Foo", IsCaseSensitive: true},
+		{Pattern: "foo", IsWordMatch: true},
+		{Pattern: `foo\.?bar`, IsRegExp: true},
+		{Pattern: "foo", IncludePatterns: []string{`\.go$`}, PathPatternsAreRegExps: true},
+		{Pattern: "foo", Limit: 3},
+	} {
+		t.Run(p.String(), func(t *testing.T) {
+			p.PatternMatchesContent = true
+			limit := p.Limit
+			if limit == 0 {
+				limit = maxLimit
+			}
+			rg, err := compile(&p)
+			if err != nil {
+				t.Fatal(err)
+			}
+			req := &protocol.Request{Repo: "foo", Commit: "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef", PatternInfo: p}
+			literal, ok := gitserverGrepLiteral(req, rg)
+			if !ok {
+				t.Fatal("expected search to be offloaded to gitserver")
+			}
+
+			ctx, cancel, sender := newLimitedStreamCollector(context.Background(), limit)
+			defer cancel()
+			if err := gitserverGrepSearch(ctx, grep, req, rg, literal, sender); err != nil {
+				t.Fatal(err)
+			}
+			got := sender.Collected()
+
+			want, wantLimitHit, err := regexSearchBatch(context.Background(), rg, zf, limit, true, false, false)
+			if err != nil {
+				t.Fatal(err)
+			}
+			sort.Slice(want, func(i, j int) bool { return want[i].Path < want[j].Path })
+
+			// regexSearch searches files concurrently, so which files are
+			// searched before the limit is hit differs between runs.
+			if p.Limit > 0 {
+				if len(got) == 0 || countMatches(got) != p.Limit {
+					t.Errorf("got %d matches, want %d", countMatches(got), p.Limit)
+				}
+			} else if diff := cmp.Diff(want, got); diff != "" {
+				t.Errorf("unexpected matches (-want +got):\n%s", diff)
+			}
+			if sender.LimitHit() != wantLimitHit {
+				t.Errorf("got limit hit %v, want %v", sender.LimitHit(), wantLimitHit)
+			}
+		})
+	}
+}
+
+func countMatches(fms []protocol.FileMatch) int {
+	n := 0
+	for _, fm := range fms {
+		n += fm.MatchCount
+	}
+	return n
+}
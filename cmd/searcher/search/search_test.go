@@ -402,6 +402,46 @@ func TestSearch_badrequest(t *testing.T) {
 	}
 }
 
+func TestSearch_structuralCacheHit(t *testing.T) {
+	d, err := os.MkdirTemp("", "search_test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(d)
+
+	// The archive must not be fetched when the results are cached.
+	ts := httptest.NewServer(&search.Service{
+		Store: &store.Store{
+			FetchTar: func(ctx context.Context, repo api.RepoName, commit api.CommitID) (io.ReadCloser, error) {
+				return nil, errors.New("unexpected archive fetch")
+			},
+			Path: d,
+		},
+		StructuralCache: staticCache(`[{"Path":"main.go","LineMatches":[{"Preview":"foo(x)","LineNumber":0,"OffsetAndLengths":[[0,6]]}],"MatchCount":1}]`),
+	})
+	defer ts.Close()
+
+	m, err := doSearch(ts.URL, &protocol.Request{
+		Repo:        "foo",
+		URL:         "u",
+		Commit:      "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+		PatternInfo: protocol.PatternInfo{Pattern: "foo(:[args])", IsStructuralPat: true, PatternMatchesContent: true},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got, want := toString(m), "main.go:1:foo(x)\n"; got != want {
+		t.Fatalf("got %q, want %q", got, want)
+	}
+}
+
+// staticCache is a search.StructuralCache which has the same results cached
+// for every key.
+type staticCache string
+
+func (c staticCache) Get(string) ([]byte, bool) { return []byte(c), true }
+func (c staticCache) Set(string, []byte)        {}
+
 func doSearch(u string, p *protocol.Request) ([]protocol.FileMatch, error) {
 	form := url.Values{
 		"Repo":            []string{string(p.Repo)},
@@ -0,0 +1,99 @@
+package search
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/json"
+	"fmt"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+)
+
+// StructuralCache stores the results of structural searches, so that repeated
+// structural queries don't run comby over the same inputs again. It is
+// implemented by rcache.Cache.
+type StructuralCache interface {
+	Get(key string) ([]byte, bool)
+	Set(key string, b []byte)
+}
+
+// maxStructuralCacheEntryBytes is the size of the largest encoded result set we
+// cache. Larger result sets are recomputed on every search.
+var maxStructuralCacheEntryBytes = 1 << 20
+
+// structuralCacheKey returns the cache key of the results of the structural
+// search p. Since p.Commit is a resolved commit, the results for a key never
+// change: searching a new commit of the repository uses a new key, and the
+// entries of old commits expire.
+func structuralCacheKey(p *protocol.Request) (string, error) {
+	b, err := json.Marshal(p.PatternInfo)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("v1:%s@%s:%x", p.Repo, p.Commit, sha256.Sum256(b)), nil
+}
+
+// getCachedStructuralSearch sends the cached results for key to sender. It
+// returns false if there are no cached results.
+func getCachedStructuralSearch(cache StructuralCache, key string, sender *limitedStreamCollector) bool {
+	b, ok := cache.Get(key)
+	if !ok {
+		structuralCacheRequests.WithLabelValues("miss").Inc()
+		return false
+	}
+
+	var matches []protocol.FileMatch
+	if err := json.Unmarshal(b, &matches); err != nil {
+		structuralCacheRequests.WithLabelValues("miss").Inc()
+		return false
+	}
+	structuralCacheRequests.WithLabelValues("hit").Inc()
+	for _, m := range matches {
+		sender.Send(m)
+	}
+	return true
+}
+
+// setCachedStructuralSearch caches the results sent to sender by a structural
+// search which ran to completion. Results which hit the limit or the deadline
+// are not cached, since the search was cancelled before it completed.
+func setCachedStructuralSearch(ctx context.Context, cache StructuralCache, key string, sender *limitedStreamCollector) {
+	if sender.LimitHit() {
+		structuralCacheSkipped.WithLabelValues("limit_hit").Inc()
+		return
+	}
+	if ctx.Err() != nil {
+		structuralCacheSkipped.WithLabelValues("cancelled").Inc()
+		return
+	}
+
+	b, err := json.Marshal(sender.Collected())
+	if err != nil {
+		return
+	}
+	if len(b) > maxStructuralCacheEntryBytes {
+		structuralCacheSkipped.WithLabelValues("too_large").Inc()
+		return
+	}
+	structuralCacheEntrySize.Observe(float64(len(b)))
+	cache.Set(key, b)
+}
+
+var (
+	structuralCacheRequests = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "searcher_structural_cache_requests_total",
+		Help: "Number of structural searches looked up in the result cache, by result (hit or miss).",
+	}, []string{"result"})
+	structuralCacheSkipped = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "searcher_structural_cache_skipped_total",
+		Help: "Number of structural search results which were not cached, by reason.",
+	}, []string{"reason"})
+	structuralCacheEntrySize = promauto.NewHistogram(prometheus.HistogramOpts{
+		Name:    "searcher_structural_cache_entry_bytes",
+		Help:    "Size of the structural search results stored in the result cache.",
+		Buckets: prometheus.ExponentialBuckets(1000, 4, 8),
+	})
+)
@@ -0,0 +1,114 @@
+package search
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/searcher/protocol"
+)
+
+type mapCache map[string][]byte
+
+func (c mapCache) Get(key string) ([]byte, bool) {
+	b, ok := c[key]
+	return b, ok
+}
+
+func (c mapCache) Set(key string, b []byte) { c[key] = b }
+
+func TestStructuralCacheKey(t *testing.T) {
+	req := protocol.Request{
+		Repo:        "foo",
+		Commit:      "deadbeefdeadbeefdeadbeefdeadbeefdeadbeef",
+		PatternInfo: protocol.PatternInfo{Pattern: "foo(:[args])", IsStructuralPat: true, Limit: 10},
+	}
+	key := func(modify func(p *protocol.Request)) string {
+		p := req
+		modify(&p)
+		k, err := structuralCacheKey(&p)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return k
+	}
+
+	want := key(func(p *protocol.Request) {})
+	if got := key(func(p *protocol.Request) { p.FetchTimeout = "1s" }); got != want {
+		t.Errorf("key changed with fetch timeout: got %q, want %q", got, want)
+	}
+	for name, modify := range map[string]func(p *protocol.Request){
+		"repo":      func(p *protocol.Request) { p.Repo = "bar" },
+		"commit":    func(p *protocol.Request) { p.Commit = "cafebabecafebabecafebabecafebabecafebabe" },
+		"pattern":   func(p *protocol.Request) { p.Pattern = "bar(:[args])" },
+		"rule":      func(p *protocol.Request) { p.CombyRule = `where :[args] == "x"` },
+		"languages": func(p *protocol.Request) { p.Languages = []string{"go"} },
+		"limit":     func(p *protocol.Request) { p.Limit = 20 },
+	} {
+		if got := key(modify); got == want {
+			t.Errorf("%s: key did not change", name)
+		}
+	}
+}
+
+func TestStructuralCache(t *testing.T) {
+	matches := []protocol.FileMatch{
+		{Path: "a.go", LineMatches: []protocol.LineMatch{{Preview: "foo()", OffsetAndLengths: [][2]int{{0, 5}}}}, MatchCount: 1},
+		{Path: "b.go", LineMatches: []protocol.LineMatch{{Preview: "foo(x)", LineNumber: 2, OffsetAndLengths: [][2]int{{0, 6}}}}, MatchCount: 1},
+	}
+	send := func(ctx context.Context, limit int) (context.Context, *limitedStreamCollector) {
+		ctx, cancel, sender := newLimitedStreamCollector(ctx, limit)
+		t.Cleanup(cancel)
+		for _, m := range matches {
+			sender.Send(m)
+		}
+		return ctx, sender
+	}
+
+	t.Run("caches complete results", func(t *testing.T) {
+		cache := mapCache{}
+		_, _, sender := newLimitedStreamCollector(context.Background(), 10)
+		if getCachedStructuralSearch(cache, "k", sender) {
+			t.Fatal("unexpected cache hit")
+		}
+
+		ctx, sender := send(context.Background(), 10)
+		setCachedStructuralSearch(ctx, cache, "k", sender)
+
+		_, _, sender = newLimitedStreamCollector(context.Background(), 10)
+		if !getCachedStructuralSearch(cache, "k", sender) {
+			t.Fatal("expected cache hit")
+		}
+		if diff := cmp.Diff(matches, sender.Collected()); diff != "" {
+			t.Fatalf("unexpected cached matches (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("does not cache incomplete results", func(t *testing.T) {
+		cache := mapCache{}
+		ctx, sender := send(context.Background(), 1)
+		setCachedStructuralSearch(ctx, cache, "limit", sender)
+
+		cancelled, cancel := context.WithCancel(context.Background())
+		cancel()
+		ctx, sender = send(cancelled, 10)
+		setCachedStructuralSearch(ctx, cache, "cancelled", sender)
+
+		if len(cache) != 0 {
+			t.Fatalf("unexpected cache entries: %v", cache)
+		}
+	})
+
+	t.Run("does not cache large results", func(t *testing.T) {
+		defer func(orig int) { maxStructuralCacheEntryBytes = orig }(maxStructuralCacheEntryBytes)
+		maxStructuralCacheEntryBytes = 10
+
+		cache := mapCache{}
+		ctx, sender := send(context.Background(), 10)
+		setCachedStructuralSearch(ctx, cache, "k", sender)
+		if len(cache) != 0 {
+			t.Fatalf("unexpected cache entries: %v", cache)
+		}
+	})
+}
@@ -3,9 +3,14 @@ package main
 import (
 	"github.com/sourcegraph/sourcegraph/cmd/worker/shared"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/search/searchjobs"
+	"github.com/sourcegraph/sourcegraph/internal/usagestats"
 )
 
 func main() {
 	authz.SetProviders(true, []authz.Provider{})
-	shared.Start(nil)
+	shared.Start(map[string]shared.Job{
+		"usage-rollups": usagestats.NewRollupJob(),
+		"search-jobs":   searchjobs.NewSearchJobsJob(),
+	})
 }
@@ -2,6 +2,7 @@ package shared
 
 import (
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 
@@ -17,6 +18,9 @@ type Config struct {
 
 	JobAllowlist []string
 	JobBlocklist []string
+
+	DatabaseStartupAttempts int
+	DatabaseStartupBackoff  time.Duration
 }
 
 var config = &Config{}
@@ -35,6 +39,9 @@ func (c *Config) Load() {
 		"",
 		"A comma-seprated list of names of jobs that should not be enabled. Values in this list take precedence over the allowlist.",
 	), ",")
+
+	c.DatabaseStartupAttempts = c.GetInt("WORKER_DB_STARTUP_ATTEMPTS", "10", "The number of times to try connecting to the frontend database before exiting.")
+	c.DatabaseStartupBackoff = c.GetInterval("WORKER_DB_STARTUP_BACKOFF", "2s", "The time to wait before the first retry of a failed frontend database connection. Doubles after each failed attempt, up to one minute.")
 }
 
 // Validate returns an error indicating if there was an invalid environment read
@@ -43,6 +50,16 @@ func (c *Config) Load() {
 //
 // This method assumes that the name field has been set externally.
 func (c *Config) Validate() error {
+	if err := c.BaseConfig.Validate(); err != nil {
+		return err
+	}
+	if c.DatabaseStartupAttempts < 1 {
+		return errors.Errorf("invalid value %d for WORKER_DB_STARTUP_ATTEMPTS: must be positive", c.DatabaseStartupAttempts)
+	}
+	if c.DatabaseStartupBackoff <= 0 {
+		return errors.Errorf("invalid value %s for WORKER_DB_STARTUP_BACKOFF: must be positive", c.DatabaseStartupBackoff)
+	}
+
 	allowlist := map[string]struct{}{}
 	for _, name := range c.names {
 		allowlist[name] = struct{}{}
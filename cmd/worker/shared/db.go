@@ -1,12 +1,17 @@
 package shared
 
 import (
+	"context"
 	"database/sql"
+	"fmt"
+	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/version/skew"
 )
 
 // InitDatabase initializes and returns a connection to the frontend database.
@@ -25,9 +30,48 @@ var initDatabaseMemo = NewMemoizedConstructor(func() (interface{}, error) {
 	})
 
 	opts := dbconn.Opts{DSN: postgresDSN, DBName: "frontend", AppName: "worker"}
-	if err := dbconn.SetupGlobalConnection(opts); err != nil {
+	if err := connectWithRetry(opts, config.DatabaseStartupAttempts, config.DatabaseStartupBackoff); err != nil {
 		return nil, errors.Errorf("failed to connect to frontend database: %s", err)
 	}
+	if err := skew.Register(context.Background(), dbconn.Global, "worker"); err != nil {
+		return nil, err
+	}
 
 	return dbconn.Global, nil
 })
+
+// maxDatabaseStartupBackoff is the longest delay between attempts to connect to the database.
+const maxDatabaseStartupBackoff = time.Minute
+
+// connectWithRetry attempts to set up the global database connection up to the given number
+// of times. The delay between attempts starts at the given backoff and doubles after each
+// failure, up to maxDatabaseStartupBackoff. The error of the last attempt is returned once
+// the retry budget is exhausted.
+func connectWithRetry(opts dbconn.Opts, maxAttempts int, backoff time.Duration) error {
+	for attempt := 1; ; attempt++ {
+		status.set(fmt.Sprintf("waiting for database (attempt %d of %d)", attempt, maxAttempts))
+
+		err := dbconn.SetupGlobalConnection(opts)
+		if err == nil {
+			status.set("initializing jobs")
+			return nil
+		}
+		if attempt >= maxAttempts {
+			return errors.Wrapf(err, "giving up after %d attempts", attempt)
+		}
+
+		log15.Warn(
+			"Failed to connect to frontend database",
+			"attempt", attempt,
+			"maxAttempts", maxAttempts,
+			"retryIn", backoff,
+			"error", err,
+		)
+
+		time.Sleep(backoff)
+		backoff *= 2
+		if backoff > maxDatabaseStartupBackoff {
+			backoff = maxDatabaseStartupBackoff
+		}
+	}
+}
@@ -51,7 +51,11 @@ func Start(additionalJobs map[string]Job) {
 
 	// Start debug server
 	ready := make(chan struct{})
-	go debugserver.NewServerRoutine(ready).Start()
+	go debugserver.NewServerRoutine(ready, debugserver.Endpoint{
+		Name:    "Startup status",
+		Path:    "/startup",
+		Handler: status,
+	}).Start()
 
 	// Validate environment variables
 	mustValidateConfigs(jobs)
@@ -62,8 +66,9 @@ func Start(additionalJobs map[string]Job) {
 
 	// Create the background routines that the worker will monitor for its
 	// lifetime. There may be a non-trivial startup time on this step as we
-	// connect to external databases, wait for migrations, etc.
-	allRoutines := mustCreateBackgroundRoutines(jobs)
+	// connect to external databases, wait for migrations, etc. The routines
+	// of every job are instrumented with the same set of run metrics.
+	allRoutines := mustCreateBackgroundRoutines(jobs, newJobMetrics(prometheus.DefaultRegisterer))
 
 	// Initialize health server
 	server := httpserver.NewFromAddr(addr, &http.Server{
@@ -75,6 +80,7 @@ func Start(additionalJobs map[string]Job) {
 
 	// We're all set up now
 	// Respond positively to ready checks
+	status.markReady()
 	close(ready)
 
 	goroutine.MonitorBackgroundRoutines(context.Background(), allRoutines...)
@@ -158,8 +164,8 @@ func emitJobCountMetrics(jobs map[string]Job) {
 
 // mustCreateBackgroundRoutines runs the Routines function of each of the given jobs concurrently.
 // If an error occurs from any of them, a fatal log message will be emitted. Otherwise, the set
-// of background routines from each job will be returned.
-func mustCreateBackgroundRoutines(jobs map[string]Job) []goroutine.BackgroundRoutine {
+// of background routines from each job will be instrumented and returned.
+func mustCreateBackgroundRoutines(jobs map[string]Job, metrics *jobMetrics) []goroutine.BackgroundRoutine {
 	var (
 		allRoutines  []goroutine.BackgroundRoutine
 		descriptions []string
@@ -167,6 +173,7 @@ func mustCreateBackgroundRoutines(jobs map[string]Job) []goroutine.BackgroundRou
 
 	for result := range runRoutinesConcurrently(jobs) {
 		if result.err == nil {
+			metrics.instrument(result.name, result.routines)
 			allRoutines = append(allRoutines, result.routines...)
 		} else {
 			descriptions = append(descriptions, fmt.Sprintf("  - %s: %s", result.name, result.err))
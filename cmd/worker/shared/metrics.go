@@ -0,0 +1,69 @@
+package shared
+
+import (
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+// jobMetrics are the standard metrics recorded for every job run by the worker. A run is
+// a single unit of work performed by one of the job's background routines, such as an
+// invocation of a periodic handler or the processing of a dequeued record. Only routines
+// implementing goroutine.RunObservable report runs.
+type jobMetrics struct {
+	duration    *prometheus.HistogramVec
+	errors      *prometheus.CounterVec
+	lastSuccess *prometheus.GaugeVec
+}
+
+func newJobMetrics(r prometheus.Registerer) *jobMetrics {
+	duration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "src_worker_job_run_duration_seconds",
+		Help:    "Time spent performing a single run of a worker job.",
+		Buckets: []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300, 600, 1800},
+	}, []string{"job_name"})
+	r.MustRegister(duration)
+
+	errors := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_worker_job_run_errors_total",
+		Help: "Total number of worker job runs that returned an error.",
+	}, []string{"job_name"})
+	r.MustRegister(errors)
+
+	lastSuccess := prometheus.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "src_worker_job_last_success_timestamp_seconds",
+		Help: "Unix timestamp of the last worker job run that completed without an error.",
+	}, []string{"job_name"})
+	r.MustRegister(lastSuccess)
+
+	return &jobMetrics{
+		duration:    duration,
+		errors:      errors,
+		lastSuccess: lastSuccess,
+	}
+}
+
+// instrument registers an observer recording the runs of the given routines of the job
+// with the given name. This must be called before the routines are started.
+func (m *jobMetrics) instrument(name string, routines []goroutine.BackgroundRoutine) {
+	// Initialize the counter so that rate queries see the job before its first error
+	m.errors.WithLabelValues(name)
+
+	observer := func(duration time.Duration, err error) {
+		m.duration.WithLabelValues(name).Observe(duration.Seconds())
+
+		if err != nil {
+			m.errors.WithLabelValues(name).Inc()
+		} else {
+			m.lastSuccess.WithLabelValues(name).SetToCurrentTime()
+		}
+	}
+
+	for _, routine := range routines {
+		if observable, ok := routine.(goroutine.RunObservable); ok {
+			observable.AddRunObserver(observer)
+		}
+	}
+}
@@ -0,0 +1,48 @@
+package shared
+
+import (
+	"net/http"
+	"sync"
+)
+
+// startupStatus describes what the worker is currently waiting on during startup.
+// Once the worker has finished initializing its jobs, the status is cleared.
+type startupStatus struct {
+	mu      sync.RWMutex
+	message string
+	ready   bool
+}
+
+var status = &startupStatus{message: "initializing jobs"}
+
+// set updates the message reported by the startup status endpoint.
+func (s *startupStatus) set(message string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.message = message
+}
+
+// markReady clears the current message and reports the worker as ready.
+func (s *startupStatus) markReady() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.message = ""
+	s.ready = true
+}
+
+// ServeHTTP responds with 200 OK once the worker is ready, and a 503 Service
+// Unavailable containing the current startup message otherwise.
+func (s *startupStatus) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	s.mu.RLock()
+	message, ready := s.message, s.ready
+	s.mu.RUnlock()
+
+	if ready {
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte("ready\n"))
+		return
+	}
+
+	w.WriteHeader(http.StatusServiceUnavailable)
+	_, _ = w.Write([]byte(message + "\n"))
+}
@@ -0,0 +1,381 @@
+package main
+
+// observedgen generates an observed decorator for a store type. The decorator
+// wraps every method that takes a context as its first parameter and returns an
+// error as its last result in an observation.Operation, which records a duration
+// histogram, an invocation counter and an error counter labeled by method name,
+// as well as a trace span. All other methods are forwarded to the wrapped store
+// as they are.
+//
+// Both interfaces and struct types are supported. The decorator of a struct type
+// has the same exported method set as a pointer to the struct, so it can be used
+// anywhere an interface satisfied by the store is accepted. Methods that return
+// the store type itself, like Transact, return a decorator of the returned store
+// instead, so that stores derived from an observed store are observed as well.
+//
+// Usage:
+//
+//     go run ./dev/observedgen -p <import path> -t <type name> -n <operation name> -o <output file>
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"go/format"
+	"go/importer"
+	"go/token"
+	"go/types"
+	"os"
+	"sort"
+	"strings"
+	"unicode"
+
+	"github.com/cockroachdb/errors"
+)
+
+var (
+	pkgPath  = flag.String("p", "", "The import path of the package declaring the store type.")
+	typeName = flag.String("t", "", "The name of the store type.")
+	opName   = flag.String("n", "", "The dot-separated name of the operations, e.g. batches.store. Metric names are derived from it.")
+	output   = flag.String("o", "", "The output file, written to the package declaring the store type.")
+)
+
+func main() {
+	flag.Parse()
+	if *pkgPath == "" || *typeName == "" || *opName == "" || *output == "" {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	if err := run(); err != nil {
+		fmt.Fprintf(os.Stderr, "observedgen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run() error {
+	wd, err := os.Getwd()
+	if err != nil {
+		return err
+	}
+
+	// The source importer type-checks the package and its dependencies from
+	// source, so the generated code always matches the current tree.
+	imp := importer.ForCompiler(token.NewFileSet(), "source", nil).(types.ImporterFrom)
+	pkg, err := imp.ImportFrom(*pkgPath, wd, 0)
+	if err != nil {
+		return errors.Wrapf(err, "loading package %s", *pkgPath)
+	}
+
+	obj, ok := pkg.Scope().Lookup(*typeName).(*types.TypeName)
+	if !ok {
+		return errors.Errorf("type %s not found in package %s", *typeName, *pkgPath)
+	}
+
+	src, err := generate(pkg, obj)
+	if err != nil {
+		return err
+	}
+	return os.WriteFile(*output, src, 0644)
+}
+
+type method struct {
+	name     string
+	field    string
+	params   []param
+	results  []string
+	variadic bool
+	observed bool
+	// wraps is true if the first result is the store type, which is wrapped
+	// in a decorator.
+	wraps bool
+}
+
+type param struct {
+	name string
+	typ  string
+}
+
+func generate(pkg *types.Package, obj *types.TypeName) ([]byte, error) {
+	imports := newImportSet(pkg)
+	imports.add("fmt", "fmt")
+	imports.add("github.com/sourcegraph/sourcegraph/internal/metrics", "metrics")
+	imports.add("github.com/sourcegraph/sourcegraph/internal/observation", "observation")
+
+	var (
+		inner     = obj.Name()
+		methodSet *types.MethodSet
+	)
+	if types.IsInterface(obj.Type()) {
+		methodSet = types.NewMethodSet(obj.Type())
+	} else {
+		inner = "*" + inner
+		methodSet = types.NewMethodSet(types.NewPointer(obj.Type()))
+	}
+
+	var methods []method
+	for i := 0; i < methodSet.Len(); i++ {
+		fn := methodSet.At(i).Obj()
+		if !fn.Exported() {
+			continue
+		}
+		methods = append(methods, newMethod(fn.Name(), fn.Type().(*types.Signature), inner, imports))
+	}
+	if len(methods) == 0 {
+		return nil, errors.Errorf("type %s has no exported methods", obj.Name())
+	}
+	sort.Slice(methods, func(i, j int) bool { return methods[i].name < methods[j].name })
+
+	// Parameters must not shadow the receiver, the observation variables, or the
+	// packages referenced by the signatures.
+	reserved := map[string]struct{}{"s": {}, "err": {}, "endObservation": {}, "inner": {}}
+	for _, name := range imports.names {
+		reserved[name] = struct{}{}
+	}
+	for i := range methods {
+		nameParams(&methods[i], reserved)
+	}
+
+	var (
+		buf            bytes.Buffer
+		observed       = "Observed" + obj.Name()
+		operations     = observed + "Operations"
+		metricPrefix   = strings.ReplaceAll(*opName, ".", "_")
+		observedMethod = 0
+	)
+	p := func(format string, args ...interface{}) { fmt.Fprintf(&buf, format, args...) }
+
+	p("// Code generated by observedgen; DO NOT EDIT.\n\n")
+	p("package %s\n\n", pkg.Name())
+	p("import (\n")
+	for i, group := range imports.groups() {
+		if i > 0 {
+			p("\n")
+		}
+		for _, path := range group {
+			if name := imports.byPath[path]; name != imports.pkgNames[path] {
+				p("\t%s %q\n", name, path)
+			} else {
+				p("\t%q\n", path)
+			}
+		}
+	}
+	p(")\n\n")
+
+	p("// %s is an observed decorator of %s.\n", observed, obj.Name())
+	p("// Its methods record a duration histogram, an invocation counter and an\n")
+	p("// error counter, labeled by method name.\n")
+	p("type %s struct {\n\tinner %s\n\toperations *%s\n}\n\n", observed, inner, operations)
+
+	p("// New%s wraps the given store with the given operations. The operations\n", observed)
+	p("// should be created once and shared by all decorators.\n")
+	p("func New%s(inner %s, operations *%s) *%s {\n", observed, inner, operations, observed)
+	p("\treturn &%s{inner: inner, operations: operations}\n}\n\n", observed)
+
+	p("// %s holds the operations of the observed methods of %s.\n", operations, obj.Name())
+	p("type %s struct {\n", operations)
+	for _, m := range methods {
+		if m.observed {
+			p("\t%s *observation.Operation\n", m.field)
+			observedMethod++
+		}
+	}
+	p("}\n\n")
+	if observedMethod == 0 {
+		return nil, errors.Errorf("type %s has no methods that can be observed", obj.Name())
+	}
+
+	p("// New%s creates the operations of %s and registers their metrics.\n", operations, observed)
+	p("func New%s(observationContext *observation.Context) *%s {\n", operations, operations)
+	p("\tmetrics := metrics.NewOperationMetrics(\n\t\tobservationContext.Registerer,\n\t\t%q,\n", metricPrefix)
+	p("\t\tmetrics.WithLabels(\"op\"),\n\t\tmetrics.WithCountHelp(\"Total number of method invocations.\"),\n\t)\n\n")
+	p("\top := func(name string) *observation.Operation {\n")
+	p("\t\treturn observationContext.Operation(observation.Op{\n")
+	p("\t\t\tName:         fmt.Sprintf(\"%s.%%s\", name),\n", *opName)
+	p("\t\t\tMetricLabels: []string{name},\n\t\t\tMetrics:      metrics,\n\t\t})\n\t}\n\n")
+	p("\treturn &%s{\n", operations)
+	for _, m := range methods {
+		if m.observed {
+			p("\t\t%s: op(%q),\n", m.field, m.name)
+		}
+	}
+	p("\t}\n}\n")
+
+	for _, m := range methods {
+		p("\n")
+		writeMethod(&buf, observed, m)
+	}
+
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, errors.Wrap(err, "formatting generated code")
+	}
+	return src, nil
+}
+
+func newMethod(name string, sig *types.Signature, inner string, imports *importSet) method {
+	m := method{name: name, field: operationField(name), variadic: sig.Variadic()}
+
+	for i := 0; i < sig.Params().Len(); i++ {
+		v := sig.Params().At(i)
+		typ := types.TypeString(v.Type(), imports.qualifier)
+		if m.variadic && i == sig.Params().Len()-1 {
+			typ = "..." + types.TypeString(v.Type().(*types.Slice).Elem(), imports.qualifier)
+		}
+		m.params = append(m.params, param{name: v.Name(), typ: typ})
+	}
+	for i := 0; i < sig.Results().Len(); i++ {
+		m.results = append(m.results, types.TypeString(sig.Results().At(i).Type(), imports.qualifier))
+	}
+
+	m.observed = sig.Params().Len() > 0 && isNamed(sig.Params().At(0).Type(), "context", "Context") &&
+		sig.Results().Len() > 0 && isNamed(sig.Results().At(sig.Results().Len()-1).Type(), "", "error")
+
+	// Only (T) and (T, error) results are wrapped, as the wrapped store is
+	// only valid if there's no error.
+	m.wraps = len(m.results) > 0 && m.results[0] == inner &&
+		(len(m.results) == 1 || len(m.results) == 2 && m.results[1] == "error")
+	return m
+}
+
+// nameParams gives every parameter of m a unique name that does not collide
+// with the reserved names.
+func nameParams(m *method, reserved map[string]struct{}) {
+	seen := map[string]struct{}{}
+	for i := range m.params {
+		name := m.params[i].name
+		_, isReserved := reserved[name]
+		_, isSeen := seen[name]
+		if name == "" || name == "_" || isReserved || isSeen || token.IsKeyword(name) {
+			name = fmt.Sprintf("p%d", i)
+		}
+		seen[name] = struct{}{}
+		m.params[i].name = name
+	}
+}
+
+func writeMethod(buf *bytes.Buffer, observed string, m method) {
+	params := make([]string, 0, len(m.params))
+	args := make([]string, 0, len(m.params))
+	for i, p := range m.params {
+		params = append(params, p.name+" "+p.typ)
+		arg := p.name
+		if m.variadic && i == len(m.params)-1 {
+			arg += "..."
+		}
+		args = append(args, arg)
+	}
+
+	resultTypes := m.results
+	if m.wraps {
+		resultTypes = append([]string{"*" + observed}, m.results[1:]...)
+	}
+
+	var results string
+	switch {
+	case m.observed:
+		named := make([]string, 0, len(resultTypes))
+		for i, r := range resultTypes[:len(resultTypes)-1] {
+			named = append(named, fmt.Sprintf("r%d %s", i, r))
+		}
+		results = " (" + strings.Join(append(named, "err error"), ", ") + ")"
+	case len(resultTypes) == 1:
+		results = " " + resultTypes[0]
+	case len(resultTypes) > 1:
+		results = " (" + strings.Join(resultTypes, ", ") + ")"
+	}
+
+	call := fmt.Sprintf("s.inner.%s(%s)", m.name, strings.Join(args, ", "))
+
+	fmt.Fprintf(buf, "func (s *%s) %s(%s)%s {\n", observed, m.name, strings.Join(params, ", "), results)
+	if m.observed {
+		fmt.Fprintf(buf, "\t%s, endObservation := s.operations.%s.With(%s, &err, observation.Args{})\n", m.params[0].name, m.field, m.params[0].name)
+		fmt.Fprintf(buf, "\tdefer endObservation(1, observation.Args{})\n\n")
+	}
+	switch {
+	case m.wraps && len(m.results) == 2:
+		fmt.Fprintf(buf, "\tinner, err := %s\n\tif err != nil {\n\t\treturn nil, err\n\t}\n", call)
+		fmt.Fprintf(buf, "\treturn New%s(inner, s.operations), nil\n}\n", observed)
+	case m.wraps:
+		fmt.Fprintf(buf, "\treturn New%s(%s, s.operations)\n}\n", observed, call)
+	case len(m.results) > 0:
+		fmt.Fprintf(buf, "\treturn %s\n}\n", call)
+	default:
+		fmt.Fprintf(buf, "\t%s\n}\n", call)
+	}
+}
+
+// operationField returns the name of the operations field of the given method.
+func operationField(name string) string {
+	runes := []rune(name)
+	runes[0] = unicode.ToLower(runes[0])
+	field := string(runes)
+	if token.IsKeyword(field) {
+		field += "Op"
+	}
+	return field
+}
+
+func isNamed(t types.Type, pkgPath, name string) bool {
+	named, ok := t.(*types.Named)
+	if !ok || named.Obj().Name() != name {
+		return false
+	}
+	if named.Obj().Pkg() == nil {
+		return pkgPath == ""
+	}
+	return named.Obj().Pkg().Path() == pkgPath
+}
+
+// importSet tracks the packages referenced by the generated code and the names
+// they are imported as.
+type importSet struct {
+	pkg      *types.Package
+	byPath   map[string]string
+	pkgNames map[string]string
+	names    map[string]string
+}
+
+func newImportSet(pkg *types.Package) *importSet {
+	return &importSet{pkg: pkg, byPath: map[string]string{}, pkgNames: map[string]string{}, names: map[string]string{}}
+}
+
+func (s *importSet) add(path, name string) string {
+	if existing, ok := s.byPath[path]; ok {
+		return existing
+	}
+	unique := name
+	for i := 2; ; i++ {
+		if _, ok := s.names[unique]; !ok {
+			break
+		}
+		unique = fmt.Sprintf("%s%d", name, i)
+	}
+	s.byPath[path] = unique
+	s.pkgNames[path] = name
+	s.names[unique] = unique
+	return unique
+}
+
+func (s *importSet) qualifier(pkg *types.Package) string {
+	if pkg.Path() == s.pkg.Path() {
+		return ""
+	}
+	return s.add(pkg.Path(), pkg.Name())
+}
+
+// groups returns the sorted import paths of the standard library and of all
+// other packages.
+func (s *importSet) groups() [][]string {
+	var std, other []string
+	for path := range s.byPath {
+		if strings.Contains(strings.SplitN(path, "/", 2)[0], ".") {
+			other = append(other, path)
+		} else {
+			std = append(std, path)
+		}
+	}
+	sort.Strings(std)
+	sort.Strings(other)
+	return [][]string{std, other}
+}
@@ -0,0 +1,74 @@
+package main
+
+import (
+	"go/ast"
+	"go/importer"
+	"go/parser"
+	"go/token"
+	"go/types"
+	"strings"
+	"testing"
+)
+
+const testSource = `package store
+
+import "context"
+
+type Store struct{}
+
+func (s *Store) Get(ctx context.Context, id int) (string, error) { return "", nil }
+func (s *Store) Upsert(ctx context.Context, names ...string) error   { return nil }
+func (s *Store) Select(_ context.Context, s2 string) error             { return nil }
+func (s *Store) Handle() int                                          { return 0 }
+func (s *Store) Transact(ctx context.Context) (*Store, error)           { return s, nil }
+func (s *Store) With(other int) *Store                                 { return s }
+func (s *Store) unexported(ctx context.Context) error                 { return nil }
+`
+
+func TestGenerate(t *testing.T) {
+	fset := token.NewFileSet()
+	file, err := parser.ParseFile(fset, "store.go", testSource, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	pkg, err := (&types.Config{Importer: importer.Default()}).Check("example.com/store", fset, []*ast.File{file}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	*opName = "example.store"
+	src, err := generate(pkg, pkg.Scope().Lookup("Store").(*types.TypeName))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err := parser.ParseFile(fset, "observed_store.go", src, 0); err != nil {
+		t.Fatalf("failed to parse generated code: %s\n%s", err, src)
+	}
+
+	for _, want := range []string{
+		"type ObservedStore struct",
+		"inner      *Store",
+		`op("Get")`,
+		`op("Select")`,
+		"selectOp *observation.Operation",
+		"func (s *ObservedStore) Get(ctx context.Context, id int) (r0 string, err error) {",
+		"ctx, endObservation := s.operations.get.With(ctx, &err, observation.Args{})",
+		"func (s *ObservedStore) Upsert(ctx context.Context, names ...string) (err error) {",
+		"return s.inner.Upsert(ctx, names...)",
+		"func (s *ObservedStore) Select(p0 context.Context, s2 string) (err error) {",
+		"func (s *ObservedStore) Handle() int {\n\treturn s.inner.Handle()\n}",
+		"func (s *ObservedStore) Transact(ctx context.Context) (r0 *ObservedStore, err error) {",
+		"inner, err := s.inner.Transact(ctx)\n\tif err != nil {\n\t\treturn nil, err\n\t}\n\treturn NewObservedStore(inner, s.operations), nil",
+		"func (s *ObservedStore) With(other int) *ObservedStore {\n\treturn NewObservedStore(s.inner.With(other), s.operations)\n}",
+		`metrics.NewOperationMetrics(`,
+		`"example_store",`,
+	} {
+		if !strings.Contains(string(src), want) {
+			t.Errorf("expected generated code to contain %q\n%s", want, src)
+		}
+	}
+	if strings.Contains(string(src), "unexported") {
+		t.Errorf("expected unexported methods to be skipped\n%s", src)
+	}
+}
@@ -6,6 +6,7 @@ import (
 
 	apiserver "github.com/sourcegraph/sourcegraph/enterprise/cmd/executor-queue/internal/server"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/background"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	apiclient "github.com/sourcegraph/sourcegraph/enterprise/internal/executor"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
@@ -15,8 +16,9 @@ import (
 )
 
 func QueueOptions(db dbutil.DB, config *Config, observationContext *observation.Context) apiserver.QueueOptions {
+	batchesStore := store.New(db, nil)
 	recordTransformer := func(ctx context.Context, record workerutil.Record) (apiclient.Job, error) {
-		return transformRecord(ctx, db, record.(*btypes.BatchSpecExecution), config)
+		return transformRecord(ctx, db, batchesStore, record.(*btypes.BatchSpecExecution), config)
 	}
 
 	return apiserver.QueueOptions{
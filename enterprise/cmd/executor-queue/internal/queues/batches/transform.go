@@ -12,8 +12,13 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 )
 
+// BatchesStore is the subset of the batches store used by transformRecord.
+type BatchesStore interface {
+	SetBatchSpecExecutionAccessToken(ctx context.Context, id, tokenID int64) error
+}
+
 // transformRecord transforms a *btypes.BatchSpecExecution into an apiclient.Job.
-func transformRecord(ctx context.Context, db dbutil.DB, exec *btypes.BatchSpecExecution, config *Config) (apiclient.Job, error) {
+func transformRecord(ctx context.Context, db dbutil.DB, s BatchesStore, exec *btypes.BatchSpecExecution, config *Config) (apiclient.Job, error) {
 	// TODO: createAccessToken is a bit of technical debt until we figure out a
 	// better solution. The problem is that src-cli needs to make requests to
 	// the Sourcegraph instance *on behalf of the user*.
@@ -24,15 +29,21 @@ func transformRecord(ctx context.Context, db dbutil.DB, exec *btypes.BatchSpecEx
 	// * valid only for the duration of the batch spec execution
 	// * and cleaned up after batch spec is executed
 	//
-	// Until then we create a fresh access token every time.
+	// Until then we create a fresh access token every time and remember its
+	// ID on the execution, so that it can be deleted once the execution
+	// finished.
 	//
 	// GetOrCreate doesn't work because once an access token has been created
 	// in the database Sourcegraph can't access the plain-text token anymore.
 	// Only a hash for verification is kept in the database.
-	token, err := createAccessToken(ctx, db, exec.UserID)
+	tokenID, token, err := createAccessToken(ctx, db, exec.UserID)
 	if err != nil {
 		return apiclient.Job{}, err
 	}
+	if err := s.SetBatchSpecExecutionAccessToken(ctx, exec.ID, tokenID); err != nil {
+		return apiclient.Job{}, err
+	}
+	exec.AccessTokenID = tokenID
 
 	srcEndpoint, err := makeURL(config.Shared.FrontendURL, config.Shared.FrontendUsername, config.Shared.FrontendPassword)
 	if err != nil {
@@ -110,12 +121,12 @@ const (
 	accessTokenScope = "user:all"
 )
 
-func createAccessToken(ctx context.Context, db dbutil.DB, userID int32) (string, error) {
-	_, token, err := database.AccessTokens(db).Create(ctx, userID, []string{accessTokenScope}, accessTokenNote, userID)
+func createAccessToken(ctx context.Context, db dbutil.DB, userID int32) (int64, string, error) {
+	id, token, err := database.AccessTokens(db).Create(ctx, userID, []string{accessTokenScope}, accessTokenNote, userID)
 	if err != nil {
-		return "", err
+		return 0, "", err
 	}
-	return token, err
+	return id, token, err
 }
 
 func makeURL(base, username, password string) (string, error) {
@@ -54,11 +54,19 @@ func TestTransformRecord(t *testing.T) {
 		database.Mocks.Users.GetByID = nil
 	})
 
-	job, err := transformRecord(context.Background(), &dbtesting.MockDB{}, index, config)
+	s := &mockBatchesStore{}
+	job, err := transformRecord(context.Background(), &dbtesting.MockDB{}, s, index, config)
 	if err != nil {
 		t.Fatalf("unexpected error transforming record: %s", err)
 	}
 
+	if have, want := s.accessTokens[index.ID], int64(1234); have != want {
+		t.Fatalf("wrong access token stored for execution. want=%d, have=%d", want, have)
+	}
+	if have, want := index.AccessTokenID, int64(1234); have != want {
+		t.Fatalf("wrong access token ID on execution. want=%d, have=%d", want, have)
+	}
+
 	expected := apiclient.Job{
 		ID:                  42,
 		VirtualMachineFiles: map[string]string{"spec.yml": testBatchSpec},
@@ -91,3 +99,15 @@ func TestTransformRecord(t *testing.T) {
 		t.Errorf("unexpected job (-want +got):\n%s", diff)
 	}
 }
+
+type mockBatchesStore struct {
+	accessTokens map[int64]int64
+}
+
+func (s *mockBatchesStore) SetBatchSpecExecutionAccessToken(ctx context.Context, id, tokenID int64) error {
+	if s.accessTokens == nil {
+		s.accessTokens = make(map[int64]int64)
+	}
+	s.accessTokens[id] = tokenID
+	return nil
+}
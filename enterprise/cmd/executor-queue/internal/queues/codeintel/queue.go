@@ -43,14 +43,15 @@ func QueueOptions(db dbutil.DB, config *Config, observationContext *observation.
 func newWorkerStore(db dbutil.DB, observationContext *observation.Context) dbworkerstore.Store {
 	handle := basestore.NewHandleWithDB(db, sql.TxOptions{})
 	options := dbworkerstore.Options{
-		Name:              "precise_code_intel_index_worker_store",
-		TableName:         "lsif_indexes",
-		ViewName:          "lsif_indexes_with_repository_name u",
-		ColumnExpressions: store.IndexColumnsWithNullRank,
-		Scan:              store.ScanFirstIndexRecord,
-		OrderByExpression: sqlf.Sprintf("u.queued_at, u.id"),
-		StalledMaxAge:     StalledJobMaximumAge,
-		MaxNumResets:      MaximumNumResets,
+		Name:                  "precise_code_intel_index_worker_store",
+		TableName:             "lsif_indexes",
+		ViewName:              "lsif_indexes_with_repository_name u",
+		ColumnExpressions:     store.IndexColumnsWithNullRank,
+		Scan:                  store.ScanFirstIndexRecord,
+		OrderByExpression:     sqlf.Sprintf("u.queued_at, u.id"),
+		FairnessKeyExpression: sqlf.Sprintf("u.repository_id"),
+		StalledMaxAge:         StalledJobMaximumAge,
+		MaxNumResets:          MaximumNumResets,
 	}
 
 	return dbworkerstore.NewWithMetrics(handle, options, observationContext)
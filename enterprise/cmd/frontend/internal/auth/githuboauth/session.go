@@ -36,7 +36,7 @@ type sessionIssuerHelper struct {
 	allowOrgs   []string
 }
 
-func (s *sessionIssuerHelper) GetOrCreateUser(ctx context.Context, token *oauth2.Token, anonymousUserID, firstSourceURL string) (actr *actor.Actor, safeErrMsg string, err error) {
+func (s *sessionIssuerHelper) GetOrCreateUser(ctx context.Context, token *oauth2.Token, anonymousUserID, firstSourceURL string) (actr *actor.Actor, account *extsvc.AccountSpec, safeErrMsg string, err error) {
 	ghUser, err := github.UserFromContext(ctx)
 	if ghUser == nil {
 		if err != nil {
@@ -44,12 +44,12 @@ func (s *sessionIssuerHelper) GetOrCreateUser(ctx context.Context, token *oauth2
 		} else {
 			err = errors.New("could not read user from context")
 		}
-		return nil, "Could not read GitHub user from callback request.", err
+		return nil, nil, "Could not read GitHub user from callback request.", err
 	}
 
 	login, err := auth.NormalizeUsername(deref(ghUser.Login))
 	if err != nil {
-		return nil, fmt.Sprintf("Error normalizing the username %q. See https://docs.sourcegraph.com/admin/auth/#username-normalization.", login), err
+		return nil, nil, fmt.Sprintf("Error normalizing the username %q. See https://docs.sourcegraph.com/admin/auth/#username-normalization.", login), err
 	}
 
 	ghClient := s.newClient(token.AccessToken)
@@ -57,17 +57,23 @@ func (s *sessionIssuerHelper) GetOrCreateUser(ctx context.Context, token *oauth2
 	// 🚨 SECURITY: Ensure that the user email is verified
 	verifiedEmails := getVerifiedEmails(ctx, ghClient)
 	if len(verifiedEmails) == 0 {
-		return nil, "Could not get verified email for GitHub user. Check that your GitHub account has a verified email that matches one of your Sourcegraph verified emails.", errors.New("no verified email")
+		return nil, nil, "Could not get verified email for GitHub user. Check that your GitHub account has a verified email that matches one of your Sourcegraph verified emails.", errors.New("no verified email")
 	}
 
 	// 🚨 SECURITY: Ensure that the user is part of one of the white listed orgs, if any.
 	if !s.verifyUserOrgs(ctx, ghClient) {
-		return nil, "Could not verify user is part of the allowed GitHub organizations.", errors.New("couldn't verify user is part of allowed GitHub organizations")
+		return nil, nil, "Could not verify user is part of the allowed GitHub organizations.", errors.New("couldn't verify user is part of allowed GitHub organizations")
 	}
 
 	// Try every verified email in succession until the first that succeeds
 	var data extsvc.AccountData
 	githubsvc.SetExternalAccountData(&data, ghUser, token)
+	account = &extsvc.AccountSpec{
+		ServiceType: s.ServiceType,
+		ServiceID:   s.ServiceID,
+		ClientID:    s.clientID,
+		AccountID:   strconv.FormatInt(derefInt64(ghUser.ID), 10),
+	}
 	var (
 		firstSafeErrMsg string
 		firstErr        error
@@ -81,12 +87,7 @@ func (s *sessionIssuerHelper) GetOrCreateUser(ctx context.Context, token *oauth2
 				DisplayName:     deref(ghUser.Name),
 				AvatarURL:       deref(ghUser.AvatarURL),
 			},
-			ExternalAccount: extsvc.AccountSpec{
-				ServiceType: s.ServiceType,
-				ServiceID:   s.ServiceID,
-				ClientID:    s.clientID,
-				AccountID:   strconv.FormatInt(derefInt64(ghUser.ID), 10),
-			},
+			ExternalAccount:     *account,
 			ExternalAccountData: data,
 			CreateIfNotExist:    s.allowSignup,
 		})
@@ -95,14 +96,14 @@ func (s *sessionIssuerHelper) GetOrCreateUser(ctx context.Context, token *oauth2
 				AnonymousUserID: anonymousUserID,
 				FirstSourceURL:  firstSourceURL,
 			})
-			return actor.FromUser(userID), "", nil // success
+			return actor.FromUser(userID), account, "", nil // success
 		}
 		if i == 0 {
 			firstSafeErrMsg, firstErr = safeErrMsg, err
 		}
 	}
 	// On failure, return the first error
-	return nil, fmt.Sprintf("No user exists matching any of the verified emails: %s.\n\nFirst error was: %s", strings.Join(verifiedEmails, ", "), firstSafeErrMsg), firstErr
+	return nil, nil, fmt.Sprintf("No user exists matching any of the verified emails: %s.\n\nFirst error was: %s", strings.Join(verifiedEmails, ", "), firstSafeErrMsg), firstErr
 }
 
 func (s *sessionIssuerHelper) CreateCodeHostConnection(ctx context.Context, token *oauth2.Token, providerID string) (safeErrMsg string, err error) {
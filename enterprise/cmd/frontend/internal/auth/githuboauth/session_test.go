@@ -230,10 +230,13 @@ func TestSessionIssuerHelper_GetOrCreateUser(t *testing.T) {
 					allowOrgs:   ci.allowOrgs,
 				}
 				tok := &oauth2.Token{AccessToken: "dummy-value-that-isnt-relevant-to-unit-correctness"}
-				actr, _, err := s.GetOrCreateUser(ctx, tok, "", "")
+				actr, account, _, err := s.GetOrCreateUser(ctx, tok, "", "")
 				if got, exp := actr, c.expActor; !reflect.DeepEqual(got, exp) {
 					t.Errorf("expected actor %v, got %v", exp, got)
 				}
+				if actr != nil && (account == nil || *account != gotAuthUserOp.ExternalAccount) {
+					t.Errorf("expected external account %v, got %v", gotAuthUserOp.ExternalAccount, account)
+				}
 				if c.expErr && err == nil {
 					t.Errorf("expected err %v, but was nil", c.expErr)
 				} else if !c.expErr && err != nil {
@@ -30,15 +30,15 @@ type sessionIssuerHelper struct {
 	db       dbutil.DB
 }
 
-func (s *sessionIssuerHelper) GetOrCreateUser(ctx context.Context, token *oauth2.Token, anonymousUserID, firstSourceURL string) (actr *actor.Actor, safeErrMsg string, err error) {
+func (s *sessionIssuerHelper) GetOrCreateUser(ctx context.Context, token *oauth2.Token, anonymousUserID, firstSourceURL string) (actr *actor.Actor, account *extsvc.AccountSpec, safeErrMsg string, err error) {
 	gUser, err := UserFromContext(ctx)
 	if err != nil {
-		return nil, "Could not read GitLab user from callback request.", errors.Wrap(err, "could not read user from context")
+		return nil, nil, "Could not read GitLab user from callback request.", errors.Wrap(err, "could not read user from context")
 	}
 
 	login, err := auth.NormalizeUsername(gUser.Username)
 	if err != nil {
-		return nil, fmt.Sprintf("Error normalizing the username %q. See https://docs.sourcegraph.com/admin/auth/#username-normalization.", login), err
+		return nil, nil, fmt.Sprintf("Error normalizing the username %q. See https://docs.sourcegraph.com/admin/auth/#username-normalization.", login), err
 	}
 
 	var data extsvc.AccountData
@@ -47,6 +47,12 @@ func (s *sessionIssuerHelper) GetOrCreateUser(ctx context.Context, token *oauth2
 	// Unlike with GitHub, we can *only* use the primary email to resolve the user's identity,
 	// because the GitLab API does not return whether an email has been verified. The user's primary
 	// email on GitLab is always verified, so we use that.
+	account = &extsvc.AccountSpec{
+		ServiceType: s.ServiceType,
+		ServiceID:   s.ServiceID,
+		ClientID:    s.clientID,
+		AccountID:   strconv.FormatInt(int64(gUser.ID), 10),
+	}
 	userID, safeErrMsg, err := auth.GetAndSaveUser(ctx, s.db, auth.GetAndSaveUserOp{
 		UserProps: database.NewUser{
 			Username:        login,
@@ -55,17 +61,12 @@ func (s *sessionIssuerHelper) GetOrCreateUser(ctx context.Context, token *oauth2
 			DisplayName:     gUser.Name,
 			AvatarURL:       gUser.AvatarURL,
 		},
-		ExternalAccount: extsvc.AccountSpec{
-			ServiceType: s.ServiceType,
-			ServiceID:   s.ServiceID,
-			ClientID:    s.clientID,
-			AccountID:   strconv.FormatInt(int64(gUser.ID), 10),
-		},
+		ExternalAccount:     *account,
 		ExternalAccountData: data,
 		CreateIfNotExist:    true,
 	})
 	if err != nil {
-		return nil, safeErrMsg, err
+		return nil, nil, safeErrMsg, err
 	}
 
 	// There is no need to send record if we know email is empty as it's a primary property
@@ -75,7 +76,7 @@ func (s *sessionIssuerHelper) GetOrCreateUser(ctx context.Context, token *oauth2
 			FirstSourceURL:  firstSourceURL,
 		})
 	}
-	return actor.FromUser(userID), "", nil
+	return actor.FromUser(userID), account, "", nil
 }
 
 func (s *sessionIssuerHelper) CreateCodeHostConnection(ctx context.Context, token *oauth2.Token, providerID string) (safeErrMsg string, err error) {
@@ -21,6 +21,7 @@ import (
 func Init(db dbutil.DB) {
 	githuboauth.Init(db)
 	gitlaboauth.Init(db)
+	openidconnect.Init(db)
 
 	// Register enterprise auth middleware
 	auth.RegisterMiddlewares(
@@ -61,13 +61,19 @@ func validateConfig(c conf.Unified) (problems conf.Problems) {
 		}
 	}
 
-	seen := map[schema.OpenIDConnectAuthProvider]int{}
+	// Providers are compared by their JSON encoding because the config has slice fields and so
+	// is not comparable.
+	seen := map[string]int{}
 	for i, p := range c.AuthProviders {
 		if p.Openidconnect != nil {
-			if j, ok := seen[*p.Openidconnect]; ok {
+			data, err := json.Marshal(p.Openidconnect)
+			if err != nil {
+				panic(err)
+			}
+			if j, ok := seen[string(data)]; ok {
 				problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("OpenID Connect auth provider at index %d is duplicate of index %d, ignoring", i, j)))
 			} else {
-				seen[*p.Openidconnect] = i
+				seen[string(data)] = i
 			}
 		}
 	}
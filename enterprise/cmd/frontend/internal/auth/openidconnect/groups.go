@@ -0,0 +1,206 @@
+package openidconnect
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/coreos/go-oidc"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// groupMappingsInterval is how often the group mappings are re-applied to all users of the
+// providers that configure them, so that changes to the mappings take effect without users
+// having to sign in again.
+const groupMappingsInterval = time.Hour
+
+// groupsFromClaims returns the groups in the claim with the given name. The claim may be a
+// list of strings or, for users in a single group, a string. Claims of the ID token take
+// precedence over claims of the userinfo response.
+func groupsFromClaims(name string, claims ...map[string]interface{}) []string {
+	for _, c := range claims {
+		switch v := c[name].(type) {
+		case string:
+			return []string{v}
+		case []interface{}:
+			groups := make([]string, 0, len(v))
+			for _, g := range v {
+				if s, ok := g.(string); ok {
+					groups = append(groups, s)
+				}
+			}
+			return groups
+		}
+	}
+	return nil
+}
+
+// groupsFromIDTokenAndUserInfo returns the groups in the claim with the given name of the ID
+// token or, if the ID token doesn't have the claim, the userinfo response.
+func groupsFromIDTokenAndUserInfo(idToken *oidc.IDToken, userInfo *oidc.UserInfo, name string) []string {
+	var tokenClaims, userInfoClaims map[string]interface{}
+	if err := idToken.Claims(&tokenClaims); err != nil {
+		log15.Warn("OpenID Connect auth: could not parse ID token claims.", "error", err)
+	}
+	if err := userInfo.Claims(&userInfoClaims); err != nil {
+		log15.Warn("OpenID Connect auth: could not parse userInfo claims.", "error", err)
+	}
+	groups := groupsFromClaims(name, tokenClaims, userInfoClaims)
+	if groups == nil {
+		// Distinguish users without groups from users who signed in before the groups claim
+		// was configured.
+		groups = []string{}
+	}
+	return groups
+}
+
+// groupAssignments are the organization memberships and site admin role that the group
+// mappings of a provider assign to a user.
+type groupAssignments struct {
+	// orgs maps the names of all organizations managed by the mappings to whether the user
+	// should be a member.
+	orgs map[string]bool
+	// siteAdmin is whether the user should be a site admin. It is nil if the mappings don't
+	// manage the site admin role.
+	siteAdmin *bool
+}
+
+// evaluateGroupMappings returns the assignments of the mappings to a user in the given groups.
+func evaluateGroupMappings(mappings []*schema.OpenIDConnectGroupMapping, groups []string) groupAssignments {
+	inGroup := make(map[string]bool, len(groups))
+	for _, g := range groups {
+		inGroup[g] = true
+	}
+
+	a := groupAssignments{orgs: map[string]bool{}}
+	for _, m := range mappings {
+		member := inGroup[m.Group]
+		for _, org := range m.Orgs {
+			a.orgs[org] = a.orgs[org] || member
+		}
+		if m.SiteAdmin {
+			if a.siteAdmin == nil {
+				a.siteAdmin = new(bool)
+			}
+			*a.siteAdmin = *a.siteAdmin || member
+		}
+	}
+	return a
+}
+
+// applyGroupMappings adds the user to and removes the user from the organizations managed by
+// the group mappings of the provider, and grants or revokes the site admin role if the mappings
+// manage it.
+//
+// 🚨 SECURITY: The caller must ensure that groups are the groups of the user as reported by
+// the provider.
+func applyGroupMappings(ctx context.Context, db dbutil.DB, cfg *schema.OpenIDConnectAuthProvider, userID int32, groups []string) error {
+	if len(cfg.GroupMappings) == 0 {
+		return nil
+	}
+	a := evaluateGroupMappings(cfg.GroupMappings, groups)
+
+	memberships, err := database.OrgMembers(db).GetByUserID(ctx, userID)
+	if err != nil {
+		return err
+	}
+	isMember := make(map[int32]bool, len(memberships))
+	for _, m := range memberships {
+		isMember[m.OrgID] = true
+	}
+
+	names := make([]string, 0, len(a.orgs))
+	for name := range a.orgs {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		org, err := database.Orgs(db).GetByName(ctx, name)
+		if errcode.IsNotFound(err) {
+			log15.Warn("OpenID Connect group mappings: organization does not exist.", "org", name)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+
+		switch want := a.orgs[name]; {
+		case want && !isMember[org.ID]:
+			if _, err := database.OrgMembers(db).Create(ctx, org.ID, userID); err != nil {
+				return errors.Wrapf(err, "adding user to organization %q", name)
+			}
+		case !want && isMember[org.ID]:
+			if err := database.OrgMembers(db).Remove(ctx, org.ID, userID); err != nil {
+				return errors.Wrapf(err, "removing user from organization %q", name)
+			}
+		}
+	}
+
+	if a.siteAdmin != nil {
+		user, err := database.Users(db).GetByID(ctx, userID)
+		if err != nil {
+			return err
+		}
+		if user.SiteAdmin != *a.siteAdmin {
+			if err := database.Users(db).SetIsSiteAdmin(ctx, userID, *a.siteAdmin); err != nil {
+				return errors.Wrap(err, "updating site admin role")
+			}
+		}
+	}
+	return nil
+}
+
+// reapplyGroupMappings applies the group mappings of all providers that configure them to
+// their users, with the groups recorded at each user's last sign-in.
+func reapplyGroupMappings(ctx context.Context, db dbutil.DB) {
+	for _, p := range conf.Get().AuthProviders {
+		cfg := p.Openidconnect
+		if cfg == nil || cfg.GroupsClaim == "" || len(cfg.GroupMappings) == 0 {
+			continue
+		}
+
+		accounts, err := database.ExternalAccounts(db).List(ctx, database.ExternalAccountsListOptions{
+			ServiceType: providerType,
+			ServiceID:   cfg.Issuer,
+			ClientID:    cfg.ClientID,
+		})
+		if err != nil {
+			log15.Error("OpenID Connect group mappings: listing external accounts failed.", "issuer", cfg.Issuer, "error", err)
+			continue
+		}
+
+		for _, acct := range accounts {
+			var data accountData
+			if err := acct.AccountData.GetAccountData(&data); err != nil {
+				log15.Warn("OpenID Connect group mappings: reading external account data failed.", "accountID", acct.ID, "error", err)
+				continue
+			}
+			if data.Groups == nil {
+				// The user hasn't signed in since the groups claim was configured.
+				continue
+			}
+			if err := applyGroupMappings(ctx, db, cfg, acct.UserID, data.Groups); err != nil {
+				log15.Error("OpenID Connect group mappings: applying mappings failed.", "userID", acct.UserID, "error", err)
+			}
+		}
+	}
+}
+
+// Init starts the periodic re-application of group mappings.
+func Init(db dbutil.DB) {
+	go func() {
+		ctx := actor.WithInternalActor(context.Background())
+		for range time.NewTicker(groupMappingsInterval).C {
+			reapplyGroupMappings(ctx, db)
+		}
+	}()
+}
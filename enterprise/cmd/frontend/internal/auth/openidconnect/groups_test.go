@@ -0,0 +1,99 @@
+package openidconnect
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestGroupsFromClaims(t *testing.T) {
+	tests := map[string]struct {
+		claims []map[string]interface{}
+		want   []string
+	}{
+		"list": {
+			claims: []map[string]interface{}{{"groups": []interface{}{"a", "b", 1}}},
+			want:   []string{"a", "b"},
+		},
+		"string": {
+			claims: []map[string]interface{}{{"groups": "a"}},
+			want:   []string{"a"},
+		},
+		"missing": {
+			claims: []map[string]interface{}{{"roles": "a"}, nil},
+			want:   nil,
+		},
+		"first claims take precedence": {
+			claims: []map[string]interface{}{{"groups": []interface{}{"a"}}, {"groups": []interface{}{"b"}}},
+			want:   []string{"a"},
+		},
+		"fall back to later claims": {
+			claims: []map[string]interface{}{{}, {"groups": []interface{}{"b"}}},
+			want:   []string{"b"},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := groupsFromClaims("groups", test.claims...)
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestEvaluateGroupMappings(t *testing.T) {
+	mappings := []*schema.OpenIDConnectGroupMapping{
+		{Group: "eng", Orgs: []string{"engineering", "everyone"}},
+		{Group: "sales", Orgs: []string{"everyone"}},
+		{Group: "admins", SiteAdmin: true},
+	}
+	boolPtr := func(b bool) *bool { return &b }
+
+	tests := map[string]struct {
+		mappings []*schema.OpenIDConnectGroupMapping
+		groups   []string
+		want     groupAssignments
+	}{
+		"no groups": {
+			mappings: mappings,
+			want: groupAssignments{
+				orgs:      map[string]bool{"engineering": false, "everyone": false},
+				siteAdmin: boolPtr(false),
+			},
+		},
+		"org in several mappings": {
+			mappings: mappings,
+			groups:   []string{"sales"},
+			want: groupAssignments{
+				orgs:      map[string]bool{"engineering": false, "everyone": true},
+				siteAdmin: boolPtr(false),
+			},
+		},
+		"site admin": {
+			mappings: mappings,
+			groups:   []string{"eng", "admins", "other"},
+			want: groupAssignments{
+				orgs:      map[string]bool{"engineering": true, "everyone": true},
+				siteAdmin: boolPtr(true),
+			},
+		},
+		"site admin not managed": {
+			mappings: mappings[:2],
+			groups:   []string{"admins"},
+			want: groupAssignments{
+				orgs: map[string]bool{"engineering": false, "everyone": false},
+			},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			got := evaluateGroupMappings(test.mappings, test.groups)
+			if diff := cmp.Diff(test.want, got, cmp.AllowUnexported(groupAssignments{})); diff != "" {
+				t.Errorf("mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
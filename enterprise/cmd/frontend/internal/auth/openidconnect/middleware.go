@@ -227,13 +227,25 @@ func authHandler(db dbutil.DB) func(w http.ResponseWriter, r *http.Request) {
 			if err := userInfo.Claims(&claims); err != nil {
 				log15.Warn("OpenID Connect auth: could not parse userInfo claims.", "error", err)
 			}
-			actr, safeErrMsg, err := getOrCreateUser(ctx, db, p, idToken, userInfo, &claims)
+			var groups []string
+			if p.config.GroupsClaim != "" {
+				groups = groupsFromIDTokenAndUserInfo(idToken, userInfo, p.config.GroupsClaim)
+			}
+			actr, account, safeErrMsg, err := getOrCreateUser(ctx, db, p, idToken, userInfo, &claims, groups)
 			if err != nil {
 				log15.Error("OpenID Connect auth failed: error looking up OpenID-authenticated user.", "error", err, "userErr", safeErrMsg)
 				http.Error(w, safeErrMsg, http.StatusInternalServerError)
 				return
 			}
 
+			if p.config.GroupsClaim != "" {
+				if err := applyGroupMappings(ctx, db, &p.config, actr.UID, groups); err != nil {
+					log15.Error("OpenID Connect auth failed: error applying group mappings.", "error", err)
+					http.Error(w, "Authentication failed. The organization memberships and role of the user could not be updated from their groups.", http.StatusInternalServerError)
+					return
+				}
+			}
+
 			user, err := database.GlobalUsers.GetByID(r.Context(), actr.UID)
 			if err != nil {
 				log15.Error("OpenID Connect auth failed: error retrieving user from database.", "error", err)
@@ -252,7 +264,7 @@ func authHandler(db dbutil.DB) func(w http.ResponseWriter, r *http.Request) {
 			// if !idToken.Expiry.IsZero() {
 			// 	exp = time.Until(idToken.Expiry)
 			// }
-			if err := session.SetActor(w, r, actr, exp, user.CreatedAt); err != nil {
+			if err := session.SetActorFromExternalAccount(w, r, actr, *account, exp, user.CreatedAt); err != nil {
 				log15.Error("OpenID Connect auth failed: could not initiate session.", "error", err)
 				http.Error(w, "Authentication failed. Try signing in again (and clearing cookies for the current site). The error was: could not initiate session.", http.StatusInternalServerError)
 				return
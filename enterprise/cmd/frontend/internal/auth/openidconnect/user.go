@@ -14,22 +14,34 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 )
 
+// accountData is the data stored with the external account of a user authenticated via OpenID
+// Connect.
+type accountData struct {
+	IDToken    *oidc.IDToken  `json:"idToken"`
+	UserInfo   *oidc.UserInfo `json:"userInfo"`
+	UserClaims *userClaims    `json:"userClaims"`
+
+	// Groups are the groups of the user in the provider's groups claim at the last sign-in. It
+	// is nil if the provider has no groups claim configured.
+	Groups []string `json:"groups"`
+}
+
 // getOrCreateUser gets or creates a user account based on the OpenID Connect token. It returns the
 // authenticated actor if successful; otherwise it returns an friendly error message (safeErrMsg)
 // that is safe to display to users, and a non-nil err with lower-level error details.
-func getOrCreateUser(ctx context.Context, db dbutil.DB, p *provider, idToken *oidc.IDToken, userInfo *oidc.UserInfo, claims *userClaims) (_ *actor.Actor, safeErrMsg string, err error) {
+func getOrCreateUser(ctx context.Context, db dbutil.DB, p *provider, idToken *oidc.IDToken, userInfo *oidc.UserInfo, claims *userClaims, groups []string) (_ *actor.Actor, account *extsvc.AccountSpec, safeErrMsg string, err error) {
 	if userInfo.Email == "" {
-		return nil, "Only users with an email address may authenticate to Sourcegraph.", errors.New("no email address in claims")
+		return nil, nil, "Only users with an email address may authenticate to Sourcegraph.", errors.New("no email address in claims")
 	}
 	if unverifiedEmail := claims.EmailVerified != nil && !*claims.EmailVerified; unverifiedEmail {
 		// If the OP explicitly reports `"email_verified": false`, then reject the authentication
 		// attempt. If undefined or true, then it will be allowed.
-		return nil, fmt.Sprintf("Only users with verified email addresses may authenticate to Sourcegraph. The email address %q is not verified on the external authentication provider.", userInfo.Email), errors.Errorf("refusing unverified user email address %q", userInfo.Email)
+		return nil, nil, fmt.Sprintf("Only users with verified email addresses may authenticate to Sourcegraph. The email address %q is not verified on the external authentication provider.", userInfo.Email), errors.Errorf("refusing unverified user email address %q", userInfo.Email)
 	}
 
 	pi, err := p.getCachedInfoAndError()
 	if err != nil {
-		return nil, "", err
+		return nil, nil, "", err
 	}
 
 	login := claims.PreferredUsername
@@ -47,16 +59,18 @@ func getOrCreateUser(ctx context.Context, db dbutil.DB, p *provider, idToken *oi
 	}
 	login, err = auth.NormalizeUsername(login)
 	if err != nil {
-		return nil, fmt.Sprintf("Error normalizing the username %q. See https://docs.sourcegraph.com/admin/auth/#username-normalization.", login), err
+		return nil, nil, fmt.Sprintf("Error normalizing the username %q. See https://docs.sourcegraph.com/admin/auth/#username-normalization.", login), err
 	}
 
 	var data extsvc.AccountData
-	data.SetAccountData(struct {
-		IDToken    *oidc.IDToken  `json:"idToken"`
-		UserInfo   *oidc.UserInfo `json:"userInfo"`
-		UserClaims *userClaims    `json:"userClaims"`
-	}{IDToken: idToken, UserInfo: userInfo, UserClaims: claims})
+	data.SetAccountData(accountData{IDToken: idToken, UserInfo: userInfo, UserClaims: claims, Groups: groups})
 
+	account = &extsvc.AccountSpec{
+		ServiceType: providerType,
+		ServiceID:   pi.ServiceID,
+		ClientID:    pi.ClientID,
+		AccountID:   idToken.Subject,
+	}
 	userID, safeErrMsg, err := auth.GetAndSaveUser(ctx, db, auth.GetAndSaveUserOp{
 		UserProps: database.NewUser{
 			Username:        login,
@@ -65,17 +79,12 @@ func getOrCreateUser(ctx context.Context, db dbutil.DB, p *provider, idToken *oi
 			DisplayName:     displayName,
 			AvatarURL:       claims.Picture,
 		},
-		ExternalAccount: extsvc.AccountSpec{
-			ServiceType: providerType,
-			ServiceID:   pi.ServiceID,
-			ClientID:    pi.ClientID,
-			AccountID:   idToken.Subject,
-		},
+		ExternalAccount:     *account,
 		ExternalAccountData: data,
 		CreateIfNotExist:    true,
 	})
 	if err != nil {
-		return nil, safeErrMsg, err
+		return nil, nil, safeErrMsg, err
 	}
-	return actor.FromUser(userID), "", nil
+	return actor.FromUser(userID), account, "", nil
 }
@@ -12,6 +12,7 @@ import (
 	"strconv"
 	"strings"
 
+	"github.com/beevik/etree"
 	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth/providers"
@@ -46,6 +47,55 @@ func getProvider(pcID string) *provider {
 	return p
 }
 
+// getProviderForIssuer looks up the registered saml auth provider whose SAML Identity Provider has
+// the given issuer. It is used for IdP-initiated logins, whose RelayState doesn't contain the
+// provider ID, when there are multiple SAML auth providers. It returns nil if there is only a
+// single SAML auth provider or if not exactly one provider matches.
+func getProviderForIssuer(ctx context.Context, issuer string) *provider {
+	var ps []*provider
+	for _, ap := range providers.Providers() {
+		if p, ok := ap.(*provider); ok {
+			ps = append(ps, p)
+		}
+	}
+	if len(ps) < 2 || issuer == "" {
+		return nil
+	}
+
+	var found *provider
+	for _, p := range ps {
+		if err := p.Refresh(ctx); err != nil {
+			log15.Error("Error getting SAML auth provider", "id", p.ConfigID(), "error", err)
+			continue
+		}
+		if p.CachedInfo().ServiceID == issuer {
+			if found != nil {
+				log15.Error("Multiple SAML auth providers use the same SAML Identity Provider. IdP-initiated login is not supported for them.", "issuer", issuer)
+				return nil
+			}
+			found = p
+		}
+	}
+	return found
+}
+
+// responseIssuer returns the issuer of the base64-encoded SAML response, or the empty string if it
+// can't be determined. The response is not validated.
+func responseIssuer(encodedResp string) string {
+	raw, err := base64.StdEncoding.DecodeString(encodedResp)
+	if err != nil {
+		return ""
+	}
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(raw); err != nil || doc.Root() == nil {
+		return ""
+	}
+	if issuer := doc.Root().FindElement("./Issuer"); issuer != nil {
+		return strings.TrimSpace(issuer.Text())
+	}
+	return ""
+}
+
 func handleGetProvider(ctx context.Context, w http.ResponseWriter, pcID string) (p *provider, handled bool) {
 	handled = true // safer default
 
@@ -76,13 +126,19 @@ func validateConfig(c conf.Unified) (problems conf.Problems) {
 		}
 	}
 
-	seen := map[schema.SAMLAuthProvider]int{}
+	// Providers are compared by their JSON encoding because the config has pointer fields, which
+	// would be compared by address.
+	seen := map[string]int{}
 	for i, p := range c.AuthProviders {
 		if p.Saml != nil {
-			if j, ok := seen[*p.Saml]; ok {
+			data, err := json.Marshal(p.Saml)
+			if err != nil {
+				panic(err)
+			}
+			if j, ok := seen[string(data)]; ok {
 				problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("SAML auth provider at index %d is duplicate of index %d, ignoring", i, j)))
 			} else {
-				seen[*p.Saml] = i
+				seen[string(data)] = i
 			}
 		}
 	}
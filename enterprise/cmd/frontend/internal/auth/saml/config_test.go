@@ -1,6 +1,7 @@
 package saml
 
 import (
+	"encoding/base64"
 	"testing"
 
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -38,3 +39,22 @@ func TestProviderConfigID(t *testing.T) {
 		t.Errorf("id1 (%q) != id2 (%q)", id1, id2)
 	}
 }
+
+func TestResponseIssuer(t *testing.T) {
+	tests := map[string]struct {
+		encodedResp string
+		want        string
+	}{
+		"response":       {encodedResp: base64.StdEncoding.EncodeToString([]byte(testAuthnResponse)), want: "http://localhost:3220/auth/realms/master"},
+		"invalid base64": {encodedResp: "%", want: ""},
+		"invalid XML":    {encodedResp: base64.StdEncoding.EncodeToString([]byte("<")), want: ""},
+		"no issuer":      {encodedResp: base64.StdEncoding.EncodeToString([]byte("<Response/>")), want: ""},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			if got := responseIssuer(test.encodedResp); got != test.want {
+				t.Errorf("got %q, want %q", got, test.want)
+			}
+		})
+	}
+}
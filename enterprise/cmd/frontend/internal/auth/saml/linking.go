@@ -0,0 +1,146 @@
+package saml
+
+import (
+	"html/template"
+	"net/http"
+	"path"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/external/session"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// matchVerifiedEmail reports whether SAML identities that are not yet linked to a user are linked
+// to the user with the same verified email address.
+func matchVerifiedEmail(pc *schema.SAMLAuthProvider) bool {
+	return pc.AccountLinking == nil || pc.AccountLinking.MatchVerifiedEmail == nil || *pc.AccountLinking.MatchVerifiedEmail
+}
+
+// promptOnConflict reports whether a signed-in user who signs in with a SAML identity that is
+// linked to another user is asked which user to continue as, instead of the sign-in failing.
+func promptOnConflict(pc *schema.SAMLAuthProvider) bool {
+	return pc.AccountLinking == nil || pc.AccountLinking.OnConflict != "error"
+}
+
+// linkConflictSessionKey is the session key of the pending linkConflict.
+const linkConflictSessionKey = "samlLinkConflict"
+
+// linkConflictExpiry is how long the user has to resolve a linkConflict.
+const linkConflictExpiry = 10 * time.Minute
+
+// linkConflict is a sign-in with a SAML identity by a signed-in user that is pending because the
+// identity is linked to another user.
+type linkConflict struct {
+	CurrentUserID int32              // the signed-in user
+	LinkedUserID  int32              // the user the SAML identity is linked to
+	Account       extsvc.AccountSpec // the SAML identity
+	ReturnToURL   string             // where to redirect to once the conflict is resolved
+	ExpiresAt     time.Time          // when the conflict can no longer be resolved
+}
+
+// promptLinkConflict records the pending conflict in the session and redirects to the page that
+// asks the user how to resolve it.
+//
+// 🚨 SECURITY: The caller must ensure that the signed-in user has just authenticated with a SAML
+// identity (account) that is linked to the user with ID linkedUserID.
+func promptLinkConflict(w http.ResponseWriter, r *http.Request, linkedUserID int32, account extsvc.AccountSpec, returnToURL string) {
+	c := linkConflict{
+		CurrentUserID: actor.FromContext(r.Context()).UID,
+		LinkedUserID:  linkedUserID,
+		Account:       account,
+		ReturnToURL:   auth.SafeRedirectURL(returnToURL),
+		ExpiresAt:     time.Now().Add(linkConflictExpiry),
+	}
+	if err := session.SetData(w, r, linkConflictSessionKey, c); err != nil {
+		log15.Error("Error storing SAML account linking conflict in session.", "err", err)
+		http.Error(w, "Error signing in with SAML. Try signing in again.", http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, path.Join(authPrefix, "conflict"), http.StatusFound)
+}
+
+var linkConflictTemplate = template.Must(template.New("").Parse(`<!DOCTYPE html>
+<html>
+<head><title>Sourcegraph</title></head>
+<body>
+<p>The identity you signed in with is linked to the Sourcegraph user <strong>{{.LinkedUsername}}</strong>, but you are signed in as <strong>{{.CurrentUsername}}</strong>.</p>
+<p>To link the identity to {{.CurrentUsername}} instead, a site admin or {{.LinkedUsername}} must first remove it from {{.LinkedUsername}}.</p>
+<form method="POST">
+<button type="submit" name="action" value="switch">Continue as {{.LinkedUsername}}</button>
+<button type="submit" name="action" value="cancel">Stay signed in as {{.CurrentUsername}}</button>
+</form>
+</body>
+</html>
+`))
+
+// serveLinkConflict serves the page that asks the user how to resolve the pending linkConflict,
+// and resolves it when the form on that page is submitted.
+func serveLinkConflict(w http.ResponseWriter, r *http.Request) {
+	var c linkConflict
+	if err := session.GetData(r, linkConflictSessionKey, &c); err != nil {
+		log15.Error("Error reading SAML account linking conflict from session.", "err", err)
+		http.Error(w, "Error signing in with SAML. Try signing in again.", http.StatusInternalServerError)
+		return
+	}
+	// 🚨 SECURITY: The conflict must have been recorded for the signed-in user.
+	if c.LinkedUserID == 0 || c.CurrentUserID != actor.FromContext(r.Context()).UID || time.Now().After(c.ExpiresAt) {
+		http.Error(w, "There is no pending SAML sign-in. Try signing in again.", http.StatusBadRequest)
+		return
+	}
+
+	switch r.Method {
+	case "GET":
+		current, err := database.GlobalUsers.GetByID(r.Context(), c.CurrentUserID)
+		if err != nil {
+			log15.Error("Error retrieving signed-in user from database.", "error", err)
+			http.Error(w, "Failed to retrieve user: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		linked, err := database.GlobalUsers.GetByID(r.Context(), c.LinkedUserID)
+		if err != nil {
+			log15.Error("Error retrieving SAML-linked user from database.", "error", err)
+			http.Error(w, "Failed to retrieve user: "+err.Error(), http.StatusInternalServerError)
+			return
+		}
+		w.Header().Set("Content-Type", "text/html; charset=utf-8")
+		if err := linkConflictTemplate.Execute(w, map[string]string{
+			"CurrentUsername": current.Username,
+			"LinkedUsername":  linked.Username,
+		}); err != nil {
+			log15.Error("Error rendering SAML account linking conflict page.", "err", err)
+		}
+
+	case "POST":
+		if err := session.SetData(w, r, linkConflictSessionKey, nil); err != nil {
+			log15.Error("Error clearing SAML account linking conflict from session.", "err", err)
+			http.Error(w, "Error signing in with SAML. Try signing in again.", http.StatusInternalServerError)
+			return
+		}
+
+		if r.FormValue("action") == "switch" {
+			user, err := database.GlobalUsers.GetByID(r.Context(), c.LinkedUserID)
+			if err != nil {
+				log15.Error("Error retrieving SAML-linked user from database.", "error", err)
+				http.Error(w, "Failed to retrieve user: "+err.Error(), http.StatusInternalServerError)
+				return
+			}
+			if err := session.SetActorFromExternalAccount(w, r, actor.FromUser(user.ID), c.Account, 0, user.CreatedAt); err != nil {
+				log15.Error("Error setting SAML-authenticated actor in session.", "err", err)
+				http.Error(w, "Error starting SAML-authenticated session. Try signing in again.", http.StatusInternalServerError)
+				return
+			}
+		}
+
+		// 🚨 SECURITY: Call auth.SafeRedirectURL to avoid an open-redirect vuln.
+		http.Redirect(w, r, auth.SafeRedirectURL(c.ReturnToURL), http.StatusFound)
+
+	default:
+		http.Error(w, "", http.StatusMethodNotAllowed)
+	}
+}
@@ -0,0 +1,85 @@
+package saml
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/external/session"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestLinkConflict(t *testing.T) {
+	cleanup := session.ResetMockSessionStore(t)
+	defer cleanup()
+
+	database.Mocks.Users.GetByID = func(ctx context.Context, id int32) (*types.User, error) {
+		return &types.User{ID: id, Username: map[int32]string{1: "alice", 2: "bob"}[id], CreatedAt: time.Now()}, nil
+	}
+	defer func() { database.Mocks = database.MockStores{} }()
+
+	doRequest := func(method string, uid int32, cookies []*http.Cookie, form url.Values) *http.Response {
+		req := httptest.NewRequest(method, authPrefix+"/conflict", strings.NewReader(form.Encode()))
+		if form != nil {
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+		}
+		for _, cookie := range cookies {
+			req.AddCookie(cookie)
+		}
+		req = req.WithContext(actor.WithActor(context.Background(), &actor.Actor{UID: uid}))
+		w := httptest.NewRecorder()
+		if method == "" {
+			promptLinkConflict(w, req, 2, extsvc.AccountSpec{ServiceType: "saml", AccountID: "bob"}, "/page")
+		} else {
+			serveLinkConflict(w, req)
+		}
+		return w.Result()
+	}
+
+	// Signed in as alice, the SAML identity is linked to bob.
+	resp := doRequest("", 1, nil, nil)
+	if want := authPrefix + "/conflict"; resp.StatusCode != http.StatusFound || resp.Header.Get("Location") != want {
+		t.Fatalf("got %d redirect to %q, want redirect to %q", resp.StatusCode, resp.Header.Get("Location"), want)
+	}
+	cookies := resp.Cookies()
+
+	t.Run("prompt", func(t *testing.T) {
+		resp := doRequest("GET", 1, cookies, nil)
+		if resp.StatusCode != http.StatusOK {
+			t.Fatalf("got status %d, want %d", resp.StatusCode, http.StatusOK)
+		}
+		body, err := io.ReadAll(resp.Body)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(string(body), "Continue as bob") || !strings.Contains(string(body), "Stay signed in as alice") {
+			t.Errorf("unexpected page:\n%s", body)
+		}
+	})
+
+	t.Run("other user", func(t *testing.T) {
+		if resp := doRequest("GET", 3, cookies, nil); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("got status %d, want %d", resp.StatusCode, http.StatusBadRequest)
+		}
+	})
+
+	t.Run("switch", func(t *testing.T) {
+		resp := doRequest("POST", 1, cookies, url.Values{"action": {"switch"}})
+		if resp.StatusCode != http.StatusFound || resp.Header.Get("Location") != "/page" {
+			t.Fatalf("got %d redirect to %q, want redirect to /page", resp.StatusCode, resp.Header.Get("Location"))
+		}
+
+		// The conflict is resolved.
+		if resp := doRequest("GET", 2, resp.Cookies(), nil); resp.StatusCode != http.StatusBadRequest {
+			t.Errorf("got status %d, want %d", resp.StatusCode, http.StatusBadRequest)
+		}
+	})
+}
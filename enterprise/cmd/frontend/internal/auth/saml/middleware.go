@@ -9,6 +9,7 @@ import (
 	"strings"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/auth"
@@ -77,6 +78,12 @@ func samlSPHandler(db dbutil.DB) func(w http.ResponseWriter, r *http.Request) {
 	return func(w http.ResponseWriter, r *http.Request) {
 		requestPath := strings.TrimPrefix(r.URL.Path, authPrefix)
 
+		// The account linking conflict page is not specific to a provider.
+		if requestPath == "/conflict" {
+			serveLinkConflict(w, r)
+			return
+		}
+
 		// Handle GET endpoints.
 		if r.Method == "GET" {
 			// All of these endpoints expect the provider ID in the URL query.
@@ -127,7 +134,15 @@ func samlSPHandler(db dbutil.DB) func(w http.ResponseWriter, r *http.Request) {
 		var relayState relayState
 		relayState.decode(r.FormValue("RelayState"))
 
-		p, handled := handleGetProvider(r.Context(), w, relayState.ProviderID)
+		pcID := relayState.ProviderID
+		if pcID == "" && requestPath == "/acs" {
+			// IdP-initiated logins have no provider ID in the RelayState, so find the provider by
+			// the issuer of the response. The provider then validates the response.
+			if p := getProviderForIssuer(r.Context(), responseIssuer(r.FormValue("SAMLResponse"))); p != nil {
+				pcID = p.ConfigID().ID
+			}
+		}
+		p, handled := handleGetProvider(r.Context(), w, pcID)
 		if handled {
 			return
 		}
@@ -142,8 +157,12 @@ func samlSPHandler(db dbutil.DB) func(w http.ResponseWriter, r *http.Request) {
 				return
 			}
 
-			allowSignup := p.config.AllowSignup == nil || *p.config.AllowSignup
-			actor, safeErrMsg, err := getOrCreateUser(r.Context(), db, allowSignup, info)
+			actor, safeErrMsg, err := getOrCreateUser(r.Context(), db, &p.config, info)
+			var conflict *database.ExternalAccountAssociatedWithOtherUserError
+			if errors.As(err, &conflict) && promptOnConflict(&p.config) {
+				promptLinkConflict(w, r, conflict.UserID, info.spec, relayState.ReturnToURL)
+				return
+			}
 			if err != nil {
 				log15.Error("Error looking up SAML-authenticated user.", "err", err, "userErr", safeErrMsg)
 				http.Error(w, safeErrMsg, http.StatusInternalServerError)
@@ -168,7 +187,7 @@ func samlSPHandler(db dbutil.DB) func(w http.ResponseWriter, r *http.Request) {
 			// if info.SessionNotOnOrAfter != nil {
 			// 	exp = time.Until(*info.SessionNotOnOrAfter)
 			// }
-			if err := session.SetActor(w, r, actor, exp, user.CreatedAt); err != nil {
+			if err := session.SetActorFromExternalAccount(w, r, actor, info.spec, exp, user.CreatedAt); err != nil {
 				log15.Error("Error setting SAML-authenticated actor in session.", "err", err)
 				http.Error(w, "Error starting SAML-authenticated session. Try signing in again.", http.StatusInternalServerError)
 				return
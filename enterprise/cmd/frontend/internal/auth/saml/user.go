@@ -14,6 +14,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 type authnResponseInfo struct {
@@ -86,7 +87,10 @@ func readAuthnResponse(p *provider, encodedResp string) (*authnResponseInfo, err
 // getOrCreateUser gets or creates a user account based on the SAML claims. It returns the
 // authenticated actor if successful; otherwise it returns an friendly error message (safeErrMsg)
 // that is safe to display to users, and a non-nil err with lower-level error details.
-func getOrCreateUser(ctx context.Context, db dbutil.DB, allowSignup bool, info *authnResponseInfo) (_ *actor.Actor, safeErrMsg string, err error) {
+//
+// If the actor of ctx is authenticated and the SAML identity is linked to another user, the err
+// is a *database.ExternalAccountAssociatedWithOtherUserError.
+func getOrCreateUser(ctx context.Context, db dbutil.DB, pc *schema.SAMLAuthProvider, info *authnResponseInfo) (_ *actor.Actor, safeErrMsg string, err error) {
 	var data extsvc.AccountData
 	data.SetAccountData(info.accountData)
 
@@ -105,7 +109,8 @@ func getOrCreateUser(ctx context.Context, db dbutil.DB, allowSignup bool, info *
 		},
 		ExternalAccount:     info.spec,
 		ExternalAccountData: data,
-		CreateIfNotExist:    allowSignup,
+		CreateIfNotExist:    pc.AllowSignup == nil || *pc.AllowSignup,
+		SkipEmailLookUp:     !matchVerifiedEmail(pc),
 	})
 	if err != nil {
 		return nil, safeErrMsg, err
@@ -33,6 +33,7 @@ var clock = timeutil.Now
 func Init(ctx context.Context, db dbutil.DB, outOfBandMigrationRunner *oobmigration.Runner, enterpriseServices *enterprise.Services) error {
 	database.ExternalServices = edb.NewExternalServicesStore
 	database.GlobalAuthz = edb.NewAuthzStore(db, clock)
+	authz.DefaultSubRepoPermsChecker = authz.NewSubRepoPermsClient(edb.SubRepoPerms(db))
 
 	extsvcStore := database.ExternalServices(db)
 
@@ -0,0 +1,271 @@
+package resolvers
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+const repositoryPermissionOverrideIDKind = "RepositoryPermissionOverride"
+
+func marshalRepositoryPermissionOverrideID(id int64) graphql.ID {
+	return relay.MarshalID(repositoryPermissionOverrideIDKind, id)
+}
+
+func unmarshalRepositoryPermissionOverrideID(id graphql.ID) (overrideID int64, err error) {
+	if kind := relay.UnmarshalKind(id); kind != repositoryPermissionOverrideIDKind {
+		return 0, errors.Errorf("expected graphql ID to have kind %q; got %q", repositoryPermissionOverrideIDKind, kind)
+	}
+	err = relay.UnmarshalSpec(id, &overrideID)
+	return overrideID, err
+}
+
+// parseOverride returns the effect of an override from its GraphQL enum value
+// and validates its expiry.
+func (r *Resolver) parseOverride(effect string, expiresAt *graphqlbackend.DateTime) (string, *time.Time, error) {
+	var e string
+	switch effect {
+	case "GRANT":
+		e = edb.RepoPermOverrideGrant
+	case "DENY":
+		e = edb.RepoPermOverrideDeny
+	default:
+		return "", nil, errors.Errorf("unrecognized repository permission override effect %q", effect)
+	}
+
+	if expiresAt == nil {
+		return e, nil, nil
+	}
+	if !expiresAt.After(r.clock()) {
+		return "", nil, errors.New("expiresAt must be in the future")
+	}
+	return e, &expiresAt.Time, nil
+}
+
+func (r *Resolver) CreateRepositoryPermissionOverride(ctx context.Context, args *graphqlbackend.CreateRepositoryPermissionOverrideArgs) (graphqlbackend.RepositoryPermissionOverrideResolver, error) {
+	if envvar.SourcegraphDotComMode() {
+		return nil, errDisabledSourcegraphDotCom
+	}
+
+	if err := r.checkLicense(); err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site admins can mutate repository permissions.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.store.Handle().DB()); err != nil {
+		return nil, err
+	}
+
+	repoID, err := graphqlbackend.UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return nil, err
+	}
+	// Make sure the repo ID is valid.
+	if _, err = database.GlobalRepos.Get(ctx, repoID); err != nil {
+		return nil, err
+	}
+
+	userID, err := graphqlbackend.UnmarshalUserID(args.User)
+	if err != nil {
+		return nil, err
+	}
+	// Make sure the user ID is valid and not soft-deleted.
+	if _, err = database.GlobalUsers.GetByID(ctx, userID); err != nil {
+		return nil, err
+	}
+
+	effect, expiresAt, err := r.parseOverride(args.Effect, args.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	o := &edb.RepoPermOverride{
+		RepoID:          repoID,
+		UserID:          userID,
+		Effect:          effect,
+		Reason:          strings.TrimSpace(args.Reason),
+		ExpiresAt:       expiresAt,
+		CreatedByUserID: actor.FromContext(ctx).UID,
+	}
+	if err := r.overrides.Create(ctx, o); err != nil {
+		return nil, err
+	}
+	return &repositoryPermissionOverrideResolver{db: r.store.Handle().DB(), override: o, now: r.clock()}, nil
+}
+
+func (r *Resolver) UpdateRepositoryPermissionOverride(ctx context.Context, args *graphqlbackend.UpdateRepositoryPermissionOverrideArgs) (graphqlbackend.RepositoryPermissionOverrideResolver, error) {
+	if envvar.SourcegraphDotComMode() {
+		return nil, errDisabledSourcegraphDotCom
+	}
+
+	if err := r.checkLicense(); err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only site admins can mutate repository permissions.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.store.Handle().DB()); err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalRepositoryPermissionOverrideID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	effect, expiresAt, err := r.parseOverride(args.Effect, args.ExpiresAt)
+	if err != nil {
+		return nil, err
+	}
+
+	o := &edb.RepoPermOverride{
+		ID:        id,
+		Effect:    effect,
+		Reason:    strings.TrimSpace(args.Reason),
+		ExpiresAt: expiresAt,
+	}
+	if err := r.overrides.Update(ctx, o); err != nil {
+		return nil, err
+	}
+	return &repositoryPermissionOverrideResolver{db: r.store.Handle().DB(), override: o, now: r.clock()}, nil
+}
+
+func (r *Resolver) DeleteRepositoryPermissionOverride(ctx context.Context, args *graphqlbackend.DeleteRepositoryPermissionOverrideArgs) (*graphqlbackend.EmptyResponse, error) {
+	if envvar.SourcegraphDotComMode() {
+		return nil, errDisabledSourcegraphDotCom
+	}
+
+	// The license is not checked, so that overrides can be deleted after the ACLs
+	// feature is no longer licensed.
+
+	// 🚨 SECURITY: Only site admins can mutate repository permissions.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.store.Handle().DB()); err != nil {
+		return nil, err
+	}
+
+	id, err := unmarshalRepositoryPermissionOverrideID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.overrides.Delete(ctx, id); err != nil {
+		return nil, err
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) RepositoryPermissionOverrides(ctx context.Context, args *graphqlbackend.RepositoryPermissionOverridesArgs) ([]graphqlbackend.RepositoryPermissionOverrideResolver, error) {
+	if envvar.SourcegraphDotComMode() {
+		return nil, errDisabledSourcegraphDotCom
+	}
+
+	// 🚨 SECURITY: Only site admins can query repository permissions.
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, r.store.Handle().DB()); err != nil {
+		return nil, err
+	}
+
+	opts := edb.RepoPermOverridesListOpts{IncludeExpired: args.IncludeExpired}
+	if args.Repository != nil {
+		repoID, err := graphqlbackend.UnmarshalRepositoryID(*args.Repository)
+		if err != nil {
+			return nil, err
+		}
+		opts.RepoID = repoID
+	}
+	if args.User != nil {
+		userID, err := graphqlbackend.UnmarshalUserID(*args.User)
+		if err != nil {
+			return nil, err
+		}
+		opts.UserID = userID
+	}
+
+	overrides, err := r.overrides.List(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	now := r.clock()
+	resolvers := make([]graphqlbackend.RepositoryPermissionOverrideResolver, 0, len(overrides))
+	for _, o := range overrides {
+		resolvers = append(resolvers, &repositoryPermissionOverrideResolver{db: r.store.Handle().DB(), override: o, now: now})
+	}
+	return resolvers, nil
+}
+
+type repositoryPermissionOverrideResolver struct {
+	db       dbutil.DB
+	override *edb.RepoPermOverride
+	now      time.Time
+}
+
+func (r *repositoryPermissionOverrideResolver) ID() graphql.ID {
+	return marshalRepositoryPermissionOverrideID(r.override.ID)
+}
+
+func (r *repositoryPermissionOverrideResolver) Repository(ctx context.Context) (*graphqlbackend.RepositoryResolver, error) {
+	repo, err := database.Repos(r.db).Get(ctx, r.override.RepoID)
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return graphqlbackend.NewRepositoryResolver(r.db, repo), nil
+}
+
+func (r *repositoryPermissionOverrideResolver) User(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	return graphqlbackend.UserByIDInt32(ctx, r.db, r.override.UserID)
+}
+
+func (r *repositoryPermissionOverrideResolver) Permission() string {
+	return strings.ToUpper(authz.Read.String()) // Note: We currently only support read for repository permissions.
+}
+
+func (r *repositoryPermissionOverrideResolver) Effect() string {
+	return strings.ToUpper(r.override.Effect)
+}
+
+func (r *repositoryPermissionOverrideResolver) Reason() string {
+	return r.override.Reason
+}
+
+func (r *repositoryPermissionOverrideResolver) ExpiresAt() *graphqlbackend.DateTime {
+	return graphqlbackend.DateTimeOrNil(r.override.ExpiresAt)
+}
+
+func (r *repositoryPermissionOverrideResolver) Expired() bool {
+	return r.override.Expired(r.now)
+}
+
+func (r *repositoryPermissionOverrideResolver) CreatedBy(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	if r.override.CreatedByUserID == 0 {
+		return nil, nil
+	}
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.db, r.override.CreatedByUserID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *repositoryPermissionOverrideResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.override.CreatedAt}
+}
+
+func (r *repositoryPermissionOverrideResolver) UpdatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.override.UpdatedAt}
+}
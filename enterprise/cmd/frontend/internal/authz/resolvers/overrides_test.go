@@ -0,0 +1,87 @@
+package resolvers
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	edb "github.com/sourcegraph/sourcegraph/enterprise/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestResolver_RepositoryPermissionOverrides_nonAdmin(t *testing.T) {
+	database.Mocks.Users.GetByCurrentAuthUser = func(context.Context) (*types.User, error) {
+		return &types.User{}, nil
+	}
+	t.Cleanup(func() {
+		database.Mocks.Users = database.MockUsers{}
+	})
+
+	ctx := actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+	r := &Resolver{store: &edb.PermsStore{Store: basestore.NewWithDB(nil, sql.TxOptions{})}, clock: clock}
+
+	calls := map[string]func() error{
+		"create": func() error {
+			_, err := r.CreateRepositoryPermissionOverride(ctx, &graphqlbackend.CreateRepositoryPermissionOverrideArgs{})
+			return err
+		},
+		"update": func() error {
+			_, err := r.UpdateRepositoryPermissionOverride(ctx, &graphqlbackend.UpdateRepositoryPermissionOverrideArgs{})
+			return err
+		},
+		"delete": func() error {
+			_, err := r.DeleteRepositoryPermissionOverride(ctx, &graphqlbackend.DeleteRepositoryPermissionOverrideArgs{})
+			return err
+		},
+		"list": func() error {
+			_, err := r.RepositoryPermissionOverrides(ctx, &graphqlbackend.RepositoryPermissionOverridesArgs{})
+			return err
+		},
+	}
+	for name, call := range calls {
+		t.Run(name, func(t *testing.T) {
+			if err, want := call(), backend.ErrMustBeSiteAdmin; err != want {
+				t.Errorf("err: want %q but got %v", want, err)
+			}
+		})
+	}
+}
+
+func TestResolver_parseOverride(t *testing.T) {
+	r := &Resolver{clock: clock}
+	future := &graphqlbackend.DateTime{Time: clock().Add(time.Hour)}
+	past := &graphqlbackend.DateTime{Time: clock().Add(-time.Hour)}
+
+	tests := []struct {
+		name       string
+		effect     string
+		expiresAt  *graphqlbackend.DateTime
+		wantEffect string
+		wantErr    bool
+	}{
+		{name: "grant", effect: "GRANT", wantEffect: edb.RepoPermOverrideGrant},
+		{name: "deny with expiry", effect: "DENY", expiresAt: future, wantEffect: edb.RepoPermOverrideDeny},
+		{name: "unknown effect", effect: "ALLOW", wantErr: true},
+		{name: "expired", effect: "GRANT", expiresAt: past, wantErr: true},
+	}
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			effect, expiresAt, err := r.parseOverride(test.effect, test.expiresAt)
+			if gotErr := err != nil; gotErr != test.wantErr {
+				t.Fatalf("err: want error %v but got %v", test.wantErr, err)
+			}
+			if effect != test.wantEffect {
+				t.Errorf("effect: want %q but got %q", test.wantEffect, effect)
+			}
+			if test.expiresAt != nil && !test.wantErr && !expiresAt.Equal(test.expiresAt.Time) {
+				t.Errorf("expiresAt: want %v but got %v", test.expiresAt.Time, expiresAt)
+			}
+		})
+	}
+}
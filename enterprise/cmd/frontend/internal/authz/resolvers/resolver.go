@@ -31,9 +31,11 @@ var errDisabledSourcegraphDotCom = errors.New("not enabled on sourcegraph.com")
 
 type Resolver struct {
 	store             *edb.PermsStore
+	overrides         *edb.RepoPermOverridesStore
 	repoupdaterClient interface {
 		SchedulePermsSync(ctx context.Context, args protocol.PermsSyncRequest) error
 	}
+	clock func() time.Time
 }
 
 // checkLicense returns a user-facing error if the ACLs feature is not purchased
@@ -58,7 +60,9 @@ func (r *Resolver) checkLicense() error {
 func NewResolver(db dbutil.DB, clock func() time.Time) graphqlbackend.AuthzResolver {
 	return &Resolver{
 		store:             edb.Perms(db, clock),
+		overrides:         edb.RepoPermOverrides(db),
 		repoupdaterClient: repoupdater.DefaultClient,
+		clock:             clock,
 	}
 }
 
@@ -3,15 +3,17 @@ package codeintel
 import (
 	"time"
 
-	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore"
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
 )
 
 type Config struct {
 	env.BaseConfig
 
 	UploadStoreConfig                         *uploadstore.Config
+	BundleRestoreLatencyBudget                time.Duration
 	HunkCacheSize                             int
+	LSIFDataCacheSize                         int
 	DiagnosticsCountMigrationBatchSize        int
 	DiagnosticsCountMigrationBatchInterval    time.Duration
 	DefinitionsCountMigrationBatchSize        int
@@ -31,7 +33,9 @@ func init() {
 	uploadStoreConfig.Load()
 	config.UploadStoreConfig = uploadStoreConfig
 
+	config.BundleRestoreLatencyBudget = config.GetInterval("PRECISE_CODE_INTEL_BUNDLE_RESTORE_LATENCY_BUDGET", "5s", "The maximum time a code intelligence query waits for evicted data to be restored from object storage.")
 	config.HunkCacheSize = config.GetInt("PRECISE_CODE_INTEL_HUNK_CACHE_SIZE", "1000", "The capacity of the git diff hunk cache.")
+	config.LSIFDataCacheSize = config.GetInt("PRECISE_CODE_INTEL_LSIF_DATA_CACHE_SIZE", "10000", "The capacity of the hover and definitions result cache.")
 	config.DiagnosticsCountMigrationBatchSize = config.GetInt("PRECISE_CODE_INTEL_DIAGNOSTICS_COUNT_MIGRATION_BATCH_SIZE", "1000", "The maximum number of document records to migrate at a time.")
 	config.DiagnosticsCountMigrationBatchInterval = config.GetInterval("PRECISE_CODE_INTEL_DIAGNOSTICS_COUNT_MIGRATION_BATCH_INTERVAL", "1s", "The timeout between processing migration batches.")
 	config.DefinitionsCountMigrationBatchSize = config.GetInt("PRECISE_CODE_INTEL_DEFINITIONS_COUNT_MIGRATION_BATCH_SIZE", "1000", "The maximum number of definition records to migrate at once.")
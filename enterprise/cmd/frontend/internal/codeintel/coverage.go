@@ -0,0 +1,36 @@
+package codeintel
+
+import (
+	"context"
+	"sort"
+
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+// coverage returns the distinct indexers of the completed uploads visible at the
+// tip of the default branch of the given repository.
+func coverage(ctx context.Context, repoID api.RepoID) ([]string, error) {
+	uploads, _, err := services.dbStore.GetUploads(ctx, store.GetUploadsOptions{
+		RepositoryID: int(repoID),
+		State:        "completed",
+		VisibleAtTip: true,
+		Limit:        100,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	seen := map[string]struct{}{}
+	indexers := []string{}
+	for _, upload := range uploads {
+		if _, ok := seen[upload.Indexer]; ok {
+			continue
+		}
+		seen[upload.Indexer] = struct{}{}
+		indexers = append(indexers, upload.Indexer)
+	}
+	sort.Strings(indexers)
+
+	return indexers, nil
+}
@@ -0,0 +1,44 @@
+package codeintel
+
+import (
+	"context"
+
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/healthreport"
+)
+
+// checkHealth reports the depth of the code intelligence upload and index
+// queues to the health report.
+func checkHealth(ctx context.Context, _ dbutil.DB) []*healthreport.Service {
+	s := &healthreport.Service{Name: "codeintel"}
+
+	// Count the records of all repositories, regardless of who requested the
+	// report.
+	ctx = actor.WithInternalActor(ctx)
+
+	for _, queue := range []struct {
+		name  string
+		count func() (int, error)
+	}{
+		{"queued_uploads", func() (int, error) {
+			_, count, err := services.dbStore.GetUploads(ctx, store.GetUploadsOptions{State: "queued", Limit: 1})
+			return count, err
+		}},
+		{"queued_indexes", func() (int, error) {
+			_, count, err := services.dbStore.GetIndexes(ctx, store.GetIndexesOptions{State: "queued", Limit: 1})
+			return count, err
+		}},
+	} {
+		count, err := queue.count()
+		if err != nil {
+			s.Error = err.Error()
+			return []*healthreport.Service{s}
+		}
+		s.Metrics = append(s.Metrics, healthreport.Metric{Name: queue.name, Value: float64(count)})
+	}
+
+	s.Reachable = true
+	return []*healthreport.Service{s}
+}
@@ -25,6 +25,22 @@ func isSiteAdmin(ctx context.Context) bool {
 	return user != nil && user.SiteAdmin
 }
 
+// enforceUploadTokenAuth verifies the repository-scoped upload token supplied with the request.
+func (h *UploadHandler) enforceUploadTokenAuth(ctx context.Context, w http.ResponseWriter, r *http.Request, repoName string) bool {
+	verified, err := h.dbStore.VerifyUploadToken(ctx, repoName, getQuery(r, "upload_token"))
+	if err != nil {
+		log15.Error("precise-code-intel proxy: failed to verify upload token", "error", err)
+		http.Error(w, "failed to verify upload token", http.StatusInternalServerError)
+		return false
+	}
+	if !verified {
+		http.Error(w, "upload token is not valid for this repository", http.StatusUnauthorized)
+		return false
+	}
+
+	return true
+}
+
 func enforceAuth(ctx context.Context, w http.ResponseWriter, r *http.Request, repoName string) bool {
 	validatorByCodeHost := map[string]func(context.Context, http.ResponseWriter, *http.Request, string) (int, error){
 		"github.com": enforceAuthGithub,
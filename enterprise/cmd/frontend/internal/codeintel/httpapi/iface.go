@@ -15,6 +15,7 @@ type DBStore interface {
 	AddUploadPart(ctx context.Context, uploadID, partIndex int) error
 	MarkQueued(ctx context.Context, id int, uploadSize *int64) error
 	MarkFailed(ctx context.Context, id int, reason string) error
+	VerifyUploadToken(ctx context.Context, repositoryName, token string) (bool, error)
 }
 
 type DBStoreShim struct {
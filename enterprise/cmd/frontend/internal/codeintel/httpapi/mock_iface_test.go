@@ -35,6 +35,9 @@ type MockDBStore struct {
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
 	TransactFunc *DBStoreTransactFunc
+	// VerifyUploadTokenFunc is an instance of a mock function object
+	// controlling the behavior of the method VerifyUploadToken.
+	VerifyUploadTokenFunc *DBStoreVerifyUploadTokenFunc
 }
 
 // NewMockDBStore creates a new mock of the DBStore interface. All methods
@@ -76,6 +79,11 @@ func NewMockDBStore() *MockDBStore {
 				return nil, nil
 			},
 		},
+		VerifyUploadTokenFunc: &DBStoreVerifyUploadTokenFunc{
+			defaultHook: func(context.Context, string, string) (bool, error) {
+				return false, nil
+			},
+		},
 	}
 }
 
@@ -104,6 +112,9 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		TransactFunc: &DBStoreTransactFunc{
 			defaultHook: i.Transact,
 		},
+		VerifyUploadTokenFunc: &DBStoreVerifyUploadTokenFunc{
+			defaultHook: i.VerifyUploadToken,
+		},
 	}
 }
 
@@ -859,3 +870,115 @@ func (c DBStoreTransactFuncCall) Args() []interface{} {
 func (c DBStoreTransactFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
+
+// DBStoreVerifyUploadTokenFunc describes the behavior when the
+// VerifyUploadToken method of the parent MockDBStore instance is invoked.
+type DBStoreVerifyUploadTokenFunc struct {
+	defaultHook func(context.Context, string, string) (bool, error)
+	hooks       []func(context.Context, string, string) (bool, error)
+	history     []DBStoreVerifyUploadTokenFuncCall
+	mutex       sync.Mutex
+}
+
+// VerifyUploadToken delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) VerifyUploadToken(v0 context.Context, v1 string, v2 string) (bool, error) {
+	r0, r1 := m.VerifyUploadTokenFunc.nextHook()(v0, v1, v2)
+	m.VerifyUploadTokenFunc.appendCall(DBStoreVerifyUploadTokenFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the VerifyUploadToken
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreVerifyUploadTokenFunc) SetDefaultHook(hook func(context.Context, string, string) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// VerifyUploadToken method of the parent MockDBStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *DBStoreVerifyUploadTokenFunc) PushHook(hook func(context.Context, string, string) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreVerifyUploadTokenFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreVerifyUploadTokenFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, string, string) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreVerifyUploadTokenFunc) nextHook() func(context.Context, string, string) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreVerifyUploadTokenFunc) appendCall(r0 DBStoreVerifyUploadTokenFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreVerifyUploadTokenFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreVerifyUploadTokenFunc) History() []DBStoreVerifyUploadTokenFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreVerifyUploadTokenFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreVerifyUploadTokenFuncCall is an object that describes an
+// invocation of method VerifyUploadToken on an instance of MockDBStore.
+type DBStoreVerifyUploadTokenFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreVerifyUploadTokenFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreVerifyUploadTokenFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
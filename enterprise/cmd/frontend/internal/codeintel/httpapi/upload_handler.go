@@ -15,7 +15,6 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
-	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -23,6 +22,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
 	"github.com/sourcegraph/sourcegraph/internal/vcs"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/upload"
 )
@@ -60,10 +60,17 @@ func (h *UploadHandler) handleEnqueue(w http.ResponseWriter, r *http.Request) {
 		}
 
 		// 🚨 SECURITY: Ensure we return before proxying to the precise-code-intel-api-server upload
-		// endpoint. This endpoint is unprotected, so we need to make sure the user provides a valid
-		// token proving contributor access to the repository.
-		if !h.internal && conf.Get().LsifEnforceAuth && !isSiteAdmin(ctx) && !enforceAuth(ctx, w, r, repoName) {
-			return
+		// endpoint. This endpoint is unprotected, so we need to make sure the user provides either an
+		// upload token minted for the repository or a valid token proving contributor access to the
+		// repository.
+		if !h.internal && conf.Get().LsifEnforceAuth && !isSiteAdmin(ctx) {
+			if hasQuery(r, "upload_token") {
+				if !h.enforceUploadTokenAuth(ctx, w, r, repoName) {
+					return
+				}
+			} else if !enforceAuth(ctx, w, r, repoName) {
+				return
+			}
 		}
 
 		// 🚨 SECURITY: It is critical to ensure if repository and commit exists after
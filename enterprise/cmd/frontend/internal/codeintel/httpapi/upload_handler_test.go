@@ -18,9 +18,12 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
-	uploadstoremocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore/mocks"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	uploadstoremocks "github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func TestMain(m *testing.M) {
@@ -421,6 +424,80 @@ func TestHandleEnqueueMultipartFinalizeIncompleteUpload(t *testing.T) {
 	}
 }
 
+func TestHandleEnqueueUploadToken(t *testing.T) {
+	setupRepoMocks(t)
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{LsifEnforceAuth: true}})
+	database.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		return nil, database.ErrNoCurrentUser
+	}
+	t.Cleanup(func() {
+		conf.Mock(nil)
+		database.Mocks.Users.GetByCurrentAuthUser = nil
+	})
+
+	for _, testCase := range []struct {
+		name         string
+		verified     bool
+		expectedCode int
+	}{
+		{name: "valid token", verified: true, expectedCode: http.StatusAccepted},
+		{name: "invalid token", verified: false, expectedCode: http.StatusUnauthorized},
+	} {
+		t.Run(testCase.name, func(t *testing.T) {
+			mockDBStore := NewMockDBStore()
+			mockUploadStore := uploadstoremocks.NewMockStore()
+
+			mockDBStore.TransactFunc.SetDefaultReturn(mockDBStore, nil)
+			mockDBStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+			mockDBStore.InsertUploadFunc.SetDefaultReturn(42, nil)
+			mockDBStore.VerifyUploadTokenFunc.SetDefaultReturn(testCase.verified, nil)
+
+			testURL, err := url.Parse("http://test.com/upload")
+			if err != nil {
+				t.Fatalf("unexpected error constructing url: %s", err)
+			}
+			testURL.RawQuery = (url.Values{
+				"commit":       []string{testCommit},
+				"root":         []string{"proj/"},
+				"repository":   []string{"github.com/test/test"},
+				"indexerName":  []string{"lsif-go"},
+				"upload_token": []string{"deadbeef"},
+			}).Encode()
+
+			w := httptest.NewRecorder()
+			r, err := http.NewRequest("POST", testURL.String(), bytes.NewReader([]byte("payload")))
+			if err != nil {
+				t.Fatalf("unexpected error constructing request: %s", err)
+			}
+
+			h := &UploadHandler{
+				dbStore:     mockDBStore,
+				uploadStore: mockUploadStore,
+			}
+			h.handleEnqueue(w, r)
+
+			if w.Code != testCase.expectedCode {
+				t.Errorf("unexpected status code. want=%d have=%d", testCase.expectedCode, w.Code)
+			}
+
+			if history := mockDBStore.VerifyUploadTokenFunc.History(); len(history) != 1 {
+				t.Errorf("unexpected number of VerifyUploadToken calls. want=%d have=%d", 1, len(history))
+			} else if history[0].Arg1 != "github.com/test/test" || history[0].Arg2 != "deadbeef" {
+				t.Errorf("unexpected VerifyUploadToken arguments: %v", history[0].Args())
+			}
+
+			expectedInserts := 0
+			if testCase.verified {
+				expectedInserts = 1
+			}
+			if len(mockDBStore.InsertUploadFunc.History()) != expectedInserts {
+				t.Errorf("unexpected number of InsertUpload calls. want=%d have=%d", expectedInserts, len(mockDBStore.InsertUploadFunc.History()))
+			}
+		})
+	}
+}
+
 func setupRepoMocks(t testing.TB) {
 	t.Cleanup(func() {
 		backend.Mocks.Repos.GetByName = nil
@@ -9,11 +9,13 @@ import (
 	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
 
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	codeintelresolvers "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
 	codeintelgqlresolvers "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers/graphql"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/healthreport"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/oobmigration"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
@@ -46,6 +48,9 @@ func Init(ctx context.Context, db dbutil.DB, outOfBandMigrationRunner *oobmigrat
 
 	enterpriseServices.CodeIntelResolver = resolver
 	enterpriseServices.NewCodeIntelUploadHandler = uploadHandler
+	enterpriseServices.CodeIntelCoverage = coverage
+	backend.PreciseSymbols = &preciseSymbolsSource{db: db}
+	healthreport.Checkers = append(healthreport.Checkers, checkHealth)
 	return nil
 }
 
@@ -55,9 +60,14 @@ func newResolver(ctx context.Context, db dbutil.DB, observationContext *observat
 		return nil, errors.Errorf("failed to initialize hunk cache: %s", err)
 	}
 
+	lsifDataCache, err := codeintelresolvers.NewLSIFDataCache(config.LSIFDataCacheSize)
+	if err != nil {
+		return nil, errors.Errorf("failed to initialize LSIF data cache: %s", err)
+	}
+
 	innerResolver := codeintelresolvers.NewResolver(
-		services.dbStore,
-		services.lsifStore,
+		services.queryDBStore,
+		codeintelresolvers.NewCachingLSIFStore(services.lsifStore, lsifDataCache, observationContext),
 		services.gitserverClient,
 		services.indexEnqueuer,
 		hunkCache,
@@ -46,6 +46,14 @@ var severities = map[int]string{
 	4: "HINT",
 }
 
+var severityValues = func() map[string]int {
+	values := make(map[string]int, len(severities))
+	for value, name := range severities {
+		values[name] = value
+	}
+	return values
+}()
+
 func toSeverity(val int) (*string, error) {
 	severity, ok := severities[val]
 	if !ok {
@@ -54,3 +62,22 @@ func toSeverity(val int) (*string, error) {
 
 	return &severity, nil
 }
+
+// fromSeverities converts the given GraphQL severity names into diagnostic severity values.
+func fromSeverities(names *[]string) ([]int, error) {
+	if names == nil {
+		return nil, nil
+	}
+
+	values := make([]int, 0, len(*names))
+	for _, name := range *names {
+		value, ok := severityValues[name]
+		if !ok {
+			return nil, errors.Errorf("unknown diagnostic severity %q", name)
+		}
+
+		values = append(values, value)
+	}
+
+	return values, nil
+}
@@ -11,13 +11,15 @@ import (
 type DiagnosticConnectionResolver struct {
 	diagnostics      []resolvers.AdjustedDiagnostic
 	totalCount       int
+	offset           int
 	locationResolver *CachedLocationResolver
 }
 
-func NewDiagnosticConnectionResolver(diagnostics []resolvers.AdjustedDiagnostic, totalCount int, locationResolver *CachedLocationResolver) gql.DiagnosticConnectionResolver {
+func NewDiagnosticConnectionResolver(diagnostics []resolvers.AdjustedDiagnostic, totalCount, offset int, locationResolver *CachedLocationResolver) gql.DiagnosticConnectionResolver {
 	return &DiagnosticConnectionResolver{
 		diagnostics:      diagnostics,
 		totalCount:       totalCount,
+		offset:           offset,
 		locationResolver: locationResolver,
 	}
 }
@@ -35,5 +37,10 @@ func (r *DiagnosticConnectionResolver) TotalCount(ctx context.Context) (int32, e
 }
 
 func (r *DiagnosticConnectionResolver) PageInfo(ctx context.Context) (*graphqlutil.PageInfo, error) {
-	return graphqlutil.HasNextPage(len(r.diagnostics) < r.totalCount), nil
+	nextOffset := r.offset + len(r.diagnostics)
+	if nextOffset >= r.totalCount {
+		return encodeIntCursor(nil), nil
+	}
+
+	return encodeIntCursor(toInt32(&nextOffset)), nil
 }
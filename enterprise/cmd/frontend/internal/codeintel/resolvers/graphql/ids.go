@@ -3,6 +3,7 @@ package graphql
 import (
 	"strconv"
 
+	"github.com/cockroachdb/errors"
 	"github.com/graph-gophers/graphql-go"
 	"github.com/graph-gophers/graphql-go/relay"
 )
@@ -40,3 +41,20 @@ func unmarshalLSIFIndexGQLID(id graphql.ID) (indexID int64, err error) {
 	err = relay.UnmarshalSpec(id, &indexID)
 	return indexID, err
 }
+
+//
+//
+
+const lsifUploadTokenIDKind = "LSIFUploadToken"
+
+func marshalLSIFUploadTokenGQLID(tokenID int64) graphql.ID {
+	return relay.MarshalID(lsifUploadTokenIDKind, tokenID)
+}
+
+func unmarshalLSIFUploadTokenGQLID(id graphql.ID) (tokenID int64, err error) {
+	if kind := relay.UnmarshalKind(id); kind != lsifUploadTokenIDKind {
+		return 0, errors.Errorf("expected graphql ID to have kind %q; got %q", lsifUploadTokenIDKind, kind)
+	}
+	err = relay.UnmarshalSpec(id, &tokenID)
+	return tokenID, err
+}
@@ -40,3 +40,18 @@ func TestIndexID(t *testing.T) {
 		t.Errorf("unexpected id. have=%d want=%d", expected, value)
 	}
 }
+
+func TestUploadTokenID(t *testing.T) {
+	expected := int64(42)
+	value, err := unmarshalLSIFUploadTokenGQLID(marshalLSIFUploadTokenGQLID(expected))
+	if err != nil {
+		t.Fatalf("unexpected error marshalling id: %s", err)
+	}
+	if value != expected {
+		t.Errorf("unexpected id. have=%d want=%d", expected, value)
+	}
+
+	if _, err := unmarshalLSIFUploadTokenGQLID(marshalLSIFUploadGQLID(expected)); err == nil {
+		t.Error("expected an error unmarshalling an upload id")
+	}
+}
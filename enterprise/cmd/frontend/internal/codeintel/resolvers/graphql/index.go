@@ -44,6 +44,14 @@ func (r *IndexResolver) FinishedAt() *gql.DateTime     { return gql.DateTimeOrNi
 func (r *IndexResolver) Steps() gql.IndexStepsResolver { return &indexStepsResolver{index: r.index} }
 func (r *IndexResolver) PlaceInQueue() *int32          { return toInt32(r.index.Rank) }
 
+func (r *IndexResolver) Inference() gql.IndexJobInferenceResolver {
+	if r.index.InferenceConfidence == nil {
+		return nil
+	}
+
+	return &indexJobInferenceResolver{confidence: *r.index.InferenceConfidence, reasons: r.index.InferenceReasons}
+}
+
 func (r *IndexResolver) State() string {
 	state := strings.ToUpper(r.index.State)
 	if state == "FAILED" {
@@ -69,3 +77,17 @@ func (r *IndexResolver) AssociatedUpload(ctx context.Context) (gql.LSIFUploadRes
 func (r *IndexResolver) ProjectRoot(ctx context.Context) (*gql.GitTreeEntryResolver, error) {
 	return r.locationResolver.Path(ctx, api.RepoID(r.index.RepositoryID), r.index.Commit, r.index.Root)
 }
+
+type indexJobInferenceResolver struct {
+	confidence float64
+	reasons    []string
+}
+
+func (r *indexJobInferenceResolver) Confidence() float64 { return r.confidence }
+
+func (r *indexJobInferenceResolver) Reasons() []string {
+	if r.reasons == nil {
+		return []string{}
+	}
+	return r.reasons
+}
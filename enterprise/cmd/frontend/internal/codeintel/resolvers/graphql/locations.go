@@ -271,5 +271,5 @@ func resolveLocation(ctx context.Context, locationResolver *CachedLocationResolv
 	}
 
 	lspRange := convertRange(location.AdjustedRange)
-	return gql.NewLocationResolver(treeResolver, &lspRange), nil
+	return gql.NewLocationResolver(treeResolver, &lspRange, strPtr(string(location.Precision))), nil
 }
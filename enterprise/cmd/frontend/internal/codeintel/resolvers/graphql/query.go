@@ -4,9 +4,12 @@ import (
 	"context"
 
 	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
 
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/usagestats"
 )
 
 // DefaultReferencesPageSize is the reference result page size when no limit is supplied.
@@ -26,6 +29,7 @@ var ErrIllegalBounds = errors.New("illegal bounds")
 // All code intel-specific behavior is delegated to the underlying resolver instance, which is defined
 // in the parent package.
 type QueryResolver struct {
+	db               dbutil.DB
 	resolver         resolvers.QueryResolver
 	locationResolver *CachedLocationResolver
 }
@@ -33,8 +37,9 @@ type QueryResolver struct {
 // NewQueryResolver creates a new QueryResolver with the given resolver that defines all code intel-specific
 // behavior. A cached location resolver instance is also given to the query resolver, which should be used
 // to resolve all location-related values.
-func NewQueryResolver(resolver resolvers.QueryResolver, locationResolver *CachedLocationResolver) gql.GitBlobLSIFDataResolver {
+func NewQueryResolver(db dbutil.DB, resolver resolvers.QueryResolver, locationResolver *CachedLocationResolver) gql.GitBlobLSIFDataResolver {
 	return &QueryResolver{
+		db:               db,
 		resolver:         resolver,
 		locationResolver: locationResolver,
 	}
@@ -83,6 +88,12 @@ func (r *QueryResolver) References(ctx context.Context, args *gql.LSIFPagedQuery
 		return nil, err
 	}
 
+	go func() {
+		if err := usagestats.LogActorEvent(ctx, r.db, "CodeIntelPreciseReferencesQueried", nil); err != nil {
+			log15.Warn("Could not log precise references query", "error", err)
+		}
+	}()
+
 	return NewLocationConnectionResolver(locations, strPtr(cursor), r.locationResolver), nil
 }
 
@@ -100,13 +111,21 @@ func (r *QueryResolver) Diagnostics(ctx context.Context, args *gql.LSIFDiagnosti
 	if limit <= 0 {
 		return nil, ErrIllegalLimit
 	}
+	offset, err := decodeIntCursor(args.After)
+	if err != nil {
+		return nil, err
+	}
+	severities, err := fromSeverities(args.Severities)
+	if err != nil {
+		return nil, err
+	}
 
-	diagnostics, totalCount, err := r.resolver.Diagnostics(ctx, limit)
+	diagnostics, totalCount, err := r.resolver.Diagnostics(ctx, severities, limit, offset)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewDiagnosticConnectionResolver(diagnostics, totalCount, r.locationResolver), nil
+	return NewDiagnosticConnectionResolver(diagnostics, totalCount, offset, r.locationResolver), nil
 }
 
 func (r *QueryResolver) Documentation(ctx context.Context, args *gql.LSIFQueryPositionArgs) (gql.DocumentationResolver, error) {
@@ -5,8 +5,11 @@ import (
 	"encoding/base64"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
+	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
 	resolvermocks "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers/mocks"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
@@ -16,7 +19,7 @@ func TestRanges(t *testing.T) {
 	db := new(dbtesting.MockDB)
 
 	mockResolver := resolvermocks.NewMockQueryResolver()
-	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver(db))
+	resolver := NewQueryResolver(db, mockResolver, NewCachedLocationResolver(db))
 
 	args := &gql.LSIFRangesArgs{StartLine: 10, EndLine: 20}
 	if _, err := resolver.Ranges(context.Background(), args); err != nil {
@@ -38,7 +41,7 @@ func TestDefinitions(t *testing.T) {
 	db := new(dbtesting.MockDB)
 
 	mockResolver := resolvermocks.NewMockQueryResolver()
-	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver(db))
+	resolver := NewQueryResolver(db, mockResolver, NewCachedLocationResolver(db))
 
 	args := &gql.LSIFQueryPositionArgs{Line: 10, Character: 15}
 	if _, err := resolver.Definitions(context.Background(), args); err != nil {
@@ -60,7 +63,7 @@ func TestReferences(t *testing.T) {
 	db := new(dbtesting.MockDB)
 
 	mockResolver := resolvermocks.NewMockQueryResolver()
-	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver(db))
+	resolver := NewQueryResolver(db, mockResolver, NewCachedLocationResolver(db))
 
 	offset := int32(25)
 	cursor := base64.StdEncoding.EncodeToString([]byte("test-cursor"))
@@ -99,7 +102,7 @@ func TestReferencesDefaultLimit(t *testing.T) {
 	db := new(dbtesting.MockDB)
 
 	mockResolver := resolvermocks.NewMockQueryResolver()
-	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver(db))
+	resolver := NewQueryResolver(db, mockResolver, NewCachedLocationResolver(db))
 
 	args := &gql.LSIFPagedQueryPositionArgs{
 		LSIFQueryPositionArgs: gql.LSIFQueryPositionArgs{
@@ -125,7 +128,7 @@ func TestReferencesDefaultIllegalLimit(t *testing.T) {
 	db := new(dbtesting.MockDB)
 
 	mockResolver := resolvermocks.NewMockQueryResolver()
-	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver(db))
+	resolver := NewQueryResolver(db, mockResolver, NewCachedLocationResolver(db))
 
 	offset := int32(-1)
 	args := &gql.LSIFPagedQueryPositionArgs{
@@ -146,7 +149,7 @@ func TestHover(t *testing.T) {
 
 	mockResolver := resolvermocks.NewMockQueryResolver()
 	mockResolver.HoverFunc.SetDefaultReturn("text", lsifstore.Range{}, true, nil)
-	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver(db))
+	resolver := NewQueryResolver(db, mockResolver, NewCachedLocationResolver(db))
 
 	args := &gql.LSIFQueryPositionArgs{Line: 10, Character: 15}
 	if _, err := resolver.Hover(context.Background(), args); err != nil {
@@ -168,7 +171,7 @@ func TestDiagnostics(t *testing.T) {
 	db := new(dbtesting.MockDB)
 
 	mockResolver := resolvermocks.NewMockQueryResolver()
-	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver(db))
+	resolver := NewQueryResolver(db, mockResolver, NewCachedLocationResolver(db))
 
 	offset := int32(25)
 	args := &gql.LSIFDiagnosticsArgs{
@@ -182,7 +185,7 @@ func TestDiagnostics(t *testing.T) {
 	if len(mockResolver.DiagnosticsFunc.History()) != 1 {
 		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockResolver.DiagnosticsFunc.History()))
 	}
-	if val := mockResolver.DiagnosticsFunc.History()[0].Arg1; val != 25 {
+	if val := mockResolver.DiagnosticsFunc.History()[0].Arg2; val != 25 {
 		t.Fatalf("unexpected limit. want=%d have=%d", 25, val)
 	}
 }
@@ -191,7 +194,7 @@ func TestDiagnosticsDefaultLimit(t *testing.T) {
 	db := new(dbtesting.MockDB)
 
 	mockResolver := resolvermocks.NewMockQueryResolver()
-	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver(db))
+	resolver := NewQueryResolver(db, mockResolver, NewCachedLocationResolver(db))
 
 	args := &gql.LSIFDiagnosticsArgs{
 		ConnectionArgs: graphqlutil.ConnectionArgs{},
@@ -204,16 +207,74 @@ func TestDiagnosticsDefaultLimit(t *testing.T) {
 	if len(mockResolver.DiagnosticsFunc.History()) != 1 {
 		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockResolver.DiagnosticsFunc.History()))
 	}
-	if val := mockResolver.DiagnosticsFunc.History()[0].Arg1; val != DefaultDiagnosticsPageSize {
+	if val := mockResolver.DiagnosticsFunc.History()[0].Arg2; val != DefaultDiagnosticsPageSize {
 		t.Fatalf("unexpected limit. want=%d have=%d", DefaultDiagnosticsPageSize, val)
 	}
 }
 
+func TestDiagnosticsSeveritiesAndCursor(t *testing.T) {
+	db := new(dbtesting.MockDB)
+
+	mockResolver := resolvermocks.NewMockQueryResolver()
+	mockResolver.DiagnosticsFunc.SetDefaultReturn(make([]resolvers.AdjustedDiagnostic, 10), 35, nil)
+	resolver := NewQueryResolver(db, mockResolver, NewCachedLocationResolver(db))
+
+	limit := int32(10)
+	cursor := base64.StdEncoding.EncodeToString([]byte("20"))
+	severities := []string{"ERROR", "WARNING"}
+	args := &gql.LSIFDiagnosticsArgs{
+		ConnectionArgs: graphqlutil.ConnectionArgs{First: &limit},
+		After:          &cursor,
+		Severities:     &severities,
+	}
+
+	connection, err := resolver.Diagnostics(context.Background(), args)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockResolver.DiagnosticsFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockResolver.DiagnosticsFunc.History()))
+	}
+	call := mockResolver.DiagnosticsFunc.History()[0]
+	if diff := cmp.Diff([]int{1, 2}, call.Arg1); diff != "" {
+		t.Errorf("unexpected severities (-want +got):\n%s", diff)
+	}
+	if call.Arg3 != 20 {
+		t.Errorf("unexpected offset. want=%d have=%d", 20, call.Arg3)
+	}
+
+	pageInfo, err := connection.PageInfo(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if offset, err := decodeIntCursor(pageInfo.EndCursor()); err != nil || offset != 30 {
+		t.Errorf("unexpected next offset. want=%d have=%d (err=%v)", 30, offset, err)
+	}
+}
+
+func TestDiagnosticsUnknownSeverity(t *testing.T) {
+	db := new(dbtesting.MockDB)
+
+	mockResolver := resolvermocks.NewMockQueryResolver()
+	resolver := NewQueryResolver(db, mockResolver, NewCachedLocationResolver(db))
+
+	severities := []string{"FATAL"}
+	args := &gql.LSIFDiagnosticsArgs{Severities: &severities}
+
+	if _, err := resolver.Diagnostics(context.Background(), args); err == nil {
+		t.Fatalf("expected error for unknown severity")
+	}
+	if len(mockResolver.DiagnosticsFunc.History()) != 0 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 0, len(mockResolver.DiagnosticsFunc.History()))
+	}
+}
+
 func TestDiagnosticsDefaultIllegalLimit(t *testing.T) {
 	db := new(dbtesting.MockDB)
 
 	mockResolver := resolvermocks.NewMockQueryResolver()
-	resolver := NewQueryResolver(mockResolver, NewCachedLocationResolver(db))
+	resolver := NewQueryResolver(db, mockResolver, NewCachedLocationResolver(db))
 
 	offset := int32(-1)
 	args := &gql.LSIFDiagnosticsArgs{
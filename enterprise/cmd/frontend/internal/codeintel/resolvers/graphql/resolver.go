@@ -11,6 +11,7 @@ import (
 	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 	"github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
 	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbconn"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
@@ -28,6 +29,7 @@ var errAutoIndexingNotEnabled = errors.New("precise code intelligence auto index
 // All code intel-specific behavior is delegated to the underlying resolver instance, which is defined
 // in the parent package.
 type Resolver struct {
+	db               dbutil.DB
 	resolver         resolvers.Resolver
 	locationResolver *CachedLocationResolver
 }
@@ -35,6 +37,7 @@ type Resolver struct {
 // NewResolver creates a new Resolver with the given resolver that defines all code intel-specific behavior.
 func NewResolver(db dbutil.DB, resolver resolvers.Resolver) gql.CodeIntelResolver {
 	return &Resolver{
+		db:               db,
 		resolver:         resolver,
 		locationResolver: NewCachedLocationResolver(db),
 	}
@@ -259,13 +262,74 @@ func (r *Resolver) QueueAutoIndexJobForRepo(ctx context.Context, args *struct{ R
 	return &gql.EmptyResponse{}, r.resolver.QueueAutoIndexJobForRepo(ctx, int(repositoryID))
 }
 
+func (r *Resolver) LSIFUploadTokens(ctx context.Context, id graphql.ID) ([]gql.LSIFUploadTokenResolver, error) {
+	// 🚨 SECURITY: Only site admins may list upload tokens
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, dbconn.Global); err != nil {
+		return nil, err
+	}
+
+	repositoryID, err := gql.UnmarshalRepositoryID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	tokens, err := r.resolver.GetUploadTokens(ctx, int(repositoryID))
+	if err != nil {
+		return nil, err
+	}
+
+	resolvers := make([]gql.LSIFUploadTokenResolver, 0, len(tokens))
+	for _, token := range tokens {
+		resolvers = append(resolvers, &uploadTokenResolver{db: r.db, token: token})
+	}
+
+	return resolvers, nil
+}
+
+func (r *Resolver) CreateLSIFUploadToken(ctx context.Context, args *gql.CreateLSIFUploadTokenArgs) (gql.CreateLSIFUploadTokenResultResolver, error) {
+	// 🚨 SECURITY: Only site admins may create upload tokens
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, dbconn.Global); err != nil {
+		return nil, err
+	}
+
+	repositoryID, err := gql.UnmarshalRepositoryID(args.Repository)
+	if err != nil {
+		return nil, err
+	}
+
+	id, token, err := r.resolver.CreateUploadToken(ctx, int(repositoryID), int(actor.FromContext(ctx).UID), derefString(args.Note, ""))
+	if err != nil {
+		return nil, err
+	}
+
+	return &createUploadTokenResultResolver{id: id, token: token}, nil
+}
+
+func (r *Resolver) DeleteLSIFUploadToken(ctx context.Context, args *struct{ ID graphql.ID }) (*gql.EmptyResponse, error) {
+	// 🚨 SECURITY: Only site admins may revoke upload tokens
+	if err := backend.CheckCurrentUserIsSiteAdmin(ctx, dbconn.Global); err != nil {
+		return nil, err
+	}
+
+	tokenID, err := unmarshalLSIFUploadTokenGQLID(args.ID)
+	if err != nil {
+		return nil, err
+	}
+
+	if err := r.resolver.DeleteUploadToken(ctx, int(tokenID)); err != nil {
+		return nil, err
+	}
+
+	return &gql.EmptyResponse{}, nil
+}
+
 func (r *Resolver) GitBlobLSIFData(ctx context.Context, args *gql.GitBlobLSIFDataArgs) (gql.GitBlobLSIFDataResolver, error) {
 	resolver, err := r.resolver.QueryResolver(ctx, args)
 	if err != nil || resolver == nil {
 		return nil, err
 	}
 
-	return NewQueryResolver(resolver, r.locationResolver), nil
+	return NewQueryResolver(r.db, resolver, r.locationResolver), nil
 }
 
 // makeGetUploadsOptions translates the given GraphQL arguments into options defined by the
@@ -13,6 +13,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend/graphqlutil"
 	resolvermocks "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers/mocks"
 	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
@@ -95,6 +96,83 @@ func TestDeleteLSIFIndexUnauthenticated(t *testing.T) {
 	}
 }
 
+func TestCreateLSIFUploadToken(t *testing.T) {
+	db := new(dbtesting.MockDB)
+
+	t.Cleanup(func() {
+		database.Mocks.Users.GetByCurrentAuthUser = nil
+	})
+	database.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		return &types.User{ID: 7, SiteAdmin: true}, nil
+	}
+
+	mockResolver := resolvermocks.NewMockResolver()
+	mockResolver.CreateUploadTokenFunc.SetDefaultReturn(3, "secret", nil)
+
+	note := "ci"
+	ctx := actor.WithActor(context.Background(), actor.FromUser(7))
+	result, err := NewResolver(db, mockResolver).CreateLSIFUploadToken(ctx, &gql.CreateLSIFUploadTokenArgs{
+		Repository: graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repository:50"))),
+		Note:       &note,
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if result.Token() != "secret" {
+		t.Errorf("unexpected token. want=%q have=%q", "secret", result.Token())
+	}
+	if id, err := unmarshalLSIFUploadTokenGQLID(result.ID()); err != nil || id != 3 {
+		t.Errorf("unexpected token id. want=%d have=%d (%v)", 3, id, err)
+	}
+
+	if len(mockResolver.CreateUploadTokenFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockResolver.CreateUploadTokenFunc.History()))
+	}
+	call := mockResolver.CreateUploadTokenFunc.History()[0]
+	if call.Arg1 != 50 || call.Arg2 != 7 || call.Arg3 != "ci" {
+		t.Errorf("unexpected arguments: %v", call.Args())
+	}
+}
+
+func TestCreateLSIFUploadTokenUnauthenticated(t *testing.T) {
+	db := new(dbtesting.MockDB)
+
+	mockResolver := resolvermocks.NewMockResolver()
+	args := &gql.CreateLSIFUploadTokenArgs{Repository: graphql.ID(base64.StdEncoding.EncodeToString([]byte("Repository:50")))}
+
+	if _, err := NewResolver(db, mockResolver).CreateLSIFUploadToken(context.Background(), args); err != backend.ErrNotAuthenticated {
+		t.Errorf("unexpected error. want=%q have=%q", backend.ErrNotAuthenticated, err)
+	}
+	if len(mockResolver.CreateUploadTokenFunc.History()) != 0 {
+		t.Errorf("unexpected call count. want=%d have=%d", 0, len(mockResolver.CreateUploadTokenFunc.History()))
+	}
+}
+
+func TestDeleteLSIFUploadToken(t *testing.T) {
+	db := new(dbtesting.MockDB)
+
+	t.Cleanup(func() {
+		database.Mocks.Users.GetByCurrentAuthUser = nil
+	})
+	database.Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+		return &types.User{SiteAdmin: true}, nil
+	}
+
+	id := graphql.ID(base64.StdEncoding.EncodeToString([]byte("LSIFUploadToken:3")))
+	mockResolver := resolvermocks.NewMockResolver()
+
+	if _, err := NewResolver(db, mockResolver).DeleteLSIFUploadToken(context.Background(), &struct{ ID graphql.ID }{id}); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockResolver.DeleteUploadTokenFunc.History()) != 1 {
+		t.Fatalf("unexpected call count. want=%d have=%d", 1, len(mockResolver.DeleteUploadTokenFunc.History()))
+	}
+	if val := mockResolver.DeleteUploadTokenFunc.History()[0].Arg1; val != 3 {
+		t.Fatalf("unexpected token id. want=%d have=%d", 3, val)
+	}
+}
+
 func TestMakeGetUploadsOptions(t *testing.T) {
 	t.Cleanup(func() {
 		database.Mocks.Repos.Get = nil
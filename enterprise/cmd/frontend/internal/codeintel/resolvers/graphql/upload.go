@@ -70,3 +70,12 @@ func (r *UploadResolver) AssociatedIndex(ctx context.Context) (gql.LSIFIndexReso
 func (r *UploadResolver) ProjectRoot(ctx context.Context) (*gql.GitTreeEntryResolver, error) {
 	return r.locationResolver.Path(ctx, api.RepoID(r.upload.RepositoryID), r.upload.Commit, r.upload.Root)
 }
+
+func (r *UploadResolver) ProcessingTrace(ctx context.Context) (gql.LSIFUploadProcessingTraceResolver, error) {
+	trace, exists, err := r.prefetcher.resolver.GetUploadProcessingTrace(ctx, r.upload.ID)
+	if err != nil || !exists {
+		return nil, err
+	}
+
+	return &uploadProcessingTraceResolver{trace: trace}, nil
+}
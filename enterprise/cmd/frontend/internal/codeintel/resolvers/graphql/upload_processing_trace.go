@@ -0,0 +1,48 @@
+package graphql
+
+import (
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+)
+
+type uploadProcessingTraceResolver struct {
+	trace store.UploadProcessingTrace
+}
+
+var _ gql.LSIFUploadProcessingTraceResolver = &uploadProcessingTraceResolver{}
+
+func (r *uploadProcessingTraceResolver) DurationMilliseconds() int32 {
+	return int32(r.trace.Duration.Milliseconds())
+}
+
+func (r *uploadProcessingTraceResolver) PeakMemoryBytes() gql.BigInt {
+	return gql.BigInt{Int: r.trace.PeakMemoryBytes}
+}
+
+func (r *uploadProcessingTraceResolver) RecordedAt() gql.DateTime {
+	return gql.DateTime{Time: r.trace.RecordedAt}
+}
+
+func (r *uploadProcessingTraceResolver) Phases() []gql.LSIFUploadProcessingPhaseResolver {
+	resolvers := make([]gql.LSIFUploadProcessingPhaseResolver, 0, len(r.trace.Phases))
+	for _, phase := range r.trace.Phases {
+		resolvers = append(resolvers, &uploadProcessingPhaseResolver{phase: phase})
+	}
+	return resolvers
+}
+
+type uploadProcessingPhaseResolver struct {
+	phase store.UploadProcessingPhase
+}
+
+var _ gql.LSIFUploadProcessingPhaseResolver = &uploadProcessingPhaseResolver{}
+
+func (r *uploadProcessingPhaseResolver) Name() string { return r.phase.Name }
+
+func (r *uploadProcessingPhaseResolver) StartMilliseconds() int32 {
+	return int32(r.phase.Start.Milliseconds())
+}
+
+func (r *uploadProcessingPhaseResolver) DurationMilliseconds() int32 {
+	return int32(r.phase.Duration.Milliseconds())
+}
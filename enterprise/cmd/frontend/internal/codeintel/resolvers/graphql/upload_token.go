@@ -0,0 +1,62 @@
+package graphql
+
+import (
+	"context"
+
+	"github.com/graph-gophers/graphql-go"
+
+	gql "github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+type uploadTokenResolver struct {
+	db    dbutil.DB
+	token store.UploadToken
+}
+
+var _ gql.LSIFUploadTokenResolver = &uploadTokenResolver{}
+
+func (r *uploadTokenResolver) ID() graphql.ID {
+	return marshalLSIFUploadTokenGQLID(int64(r.token.ID))
+}
+
+func (r *uploadTokenResolver) Note() string {
+	return r.token.Note
+}
+
+func (r *uploadTokenResolver) Creator(ctx context.Context) (*gql.UserResolver, error) {
+	if r.token.CreatorUserID == nil {
+		return nil, nil
+	}
+
+	user, err := gql.UserByIDInt32(ctx, r.db, int32(*r.token.CreatorUserID))
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *uploadTokenResolver) CreatedAt() gql.DateTime {
+	return gql.DateTime{Time: r.token.CreatedAt}
+}
+
+func (r *uploadTokenResolver) LastUsedAt() *gql.DateTime {
+	return gql.DateTimeOrNil(r.token.LastUsedAt)
+}
+
+type createUploadTokenResultResolver struct {
+	id    int
+	token string
+}
+
+var _ gql.CreateLSIFUploadTokenResultResolver = &createUploadTokenResultResolver{}
+
+func (r *createUploadTokenResultResolver) ID() graphql.ID {
+	return marshalLSIFUploadTokenGQLID(int64(r.id))
+}
+
+func (r *createUploadTokenResultResolver) Token() string {
+	return r.token
+}
@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindex/enqueuer"
@@ -24,6 +25,7 @@ type DBStore interface {
 	GetUploadByID(ctx context.Context, id int) (dbstore.Upload, bool, error)
 	GetUploadsByIDs(ctx context.Context, ids ...int) ([]dbstore.Upload, error)
 	GetUploads(ctx context.Context, opts dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error)
+	GetUploadProcessingTrace(ctx context.Context, uploadID int) (dbstore.UploadProcessingTrace, bool, error)
 	DeleteUploadByID(ctx context.Context, id int) (bool, error)
 	GetDumpsByIDs(ctx context.Context, ids []int) ([]dbstore.Dump, error)
 	FindClosestDumps(ctx context.Context, repositoryID int, commit, path string, rootMustEnclosePath bool, indexer string) ([]dbstore.Dump, error)
@@ -40,6 +42,9 @@ type DBStore interface {
 	DeleteIndexByID(ctx context.Context, id int) (bool, error)
 	GetIndexConfigurationByRepositoryID(ctx context.Context, repositoryID int) (store.IndexConfiguration, bool, error)
 	UpdateIndexConfigurationByRepositoryID(ctx context.Context, repositoryID int, data []byte) error
+	GetUploadTokens(ctx context.Context, repositoryID int) ([]dbstore.UploadToken, error)
+	CreateUploadToken(ctx context.Context, repositoryID, creatorUserID int, note string) (int, string, error)
+	DeleteUploadToken(ctx context.Context, id int) (bool, error)
 }
 
 type LSIFStore interface {
@@ -48,7 +53,7 @@ type LSIFStore interface {
 	Definitions(ctx context.Context, bundleID int, path string, line, character, limit, offset int) ([]lsifstore.Location, int, error)
 	References(ctx context.Context, bundleID int, path string, line, character, limit, offset int) ([]lsifstore.Location, int, error)
 	Hover(ctx context.Context, bundleID int, path string, line, character int) (string, lsifstore.Range, bool, error)
-	Diagnostics(ctx context.Context, bundleID int, prefix string, limit, offset int) ([]lsifstore.Diagnostic, int, error)
+	Diagnostics(ctx context.Context, bundleID int, prefix string, severities []int, limit, offset int) ([]lsifstore.Diagnostic, int, error)
 	MonikersByPosition(ctx context.Context, bundleID int, path string, line, character int) ([][]semantic.MonikerData, error)
 	BulkMonikerResults(ctx context.Context, tableName string, ids []int, args []semantic.MonikerData, limit, offset int) (_ []lsifstore.Location, _ int, err error)
 	PackageInformation(ctx context.Context, bundleID int, path string, packageInformationID string) (semantic.PackageInformationData, bool, error)
@@ -59,6 +64,21 @@ type LSIFStore interface {
 	DocumentationAtPosition(ctx context.Context, bundleID int, path string, line, character int) ([]string, error)
 }
 
+type EvictionDBStore interface {
+	EvictedUploadIDs(ctx context.Context, ids []int) ([]int, error)
+	MarkUploadsQueried(ctx context.Context, ids []int, now time.Time) error
+	UnmarkUploadEvicted(ctx context.Context, id int) error
+}
+
+type EvictionLSIFStore interface {
+	ImportBundle(ctx context.Context, bundleID int, r io.Reader) error
+}
+
+type EvictionUploadStore interface {
+	Get(ctx context.Context, key string) (io.ReadCloser, error)
+	Delete(ctx context.Context, key string) error
+}
+
 type IndexEnqueuer interface {
 	ForceQueueIndexesForRepository(ctx context.Context, repositoryID int) error
 	InferIndexConfiguration(ctx context.Context, repositoryID int) (*config.IndexConfiguration, error)
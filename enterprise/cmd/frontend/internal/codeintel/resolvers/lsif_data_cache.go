@@ -0,0 +1,127 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/dgraph-io/ristretto"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// LSIFDataCache is a LRU cache that holds the results of LSIF store queries.
+type LSIFDataCache interface {
+	// Get returns the value (if any) and a boolean representing whether the value was
+	// found or not.
+	Get(key interface{}) (interface{}, bool)
+
+	// Set attempts to add the key-value item to the cache with the given cost. If it
+	// returns false, then the value as dropped and the item isn't added to the cache.
+	Set(key, value interface{}, cost int64) bool
+}
+
+// NewLSIFDataCache creates a data cache instance with the given maximum number of entries.
+func NewLSIFDataCache(size int) (LSIFDataCache, error) {
+	return ristretto.NewCache(&ristretto.Config{
+		NumCounters: int64(size) * 10,
+		MaxCost:     int64(size),
+		BufferItems: 64,
+	})
+}
+
+type lsifDataCacheKey struct {
+	method    string
+	bundleID  int
+	path      string
+	line      int
+	character int
+	limit     int
+	offset    int
+}
+
+type cachedHover struct {
+	text   string
+	rn     lsifstore.Range
+	exists bool
+}
+
+type cachedDefinitions struct {
+	locations  []lsifstore.Location
+	totalCount int
+}
+
+// cachingLSIFStore is an LSIFStore that caches the results of hover and definitions
+// queries, which are repeated for every hover over the same symbol. The data of an
+// upload does not change once it has been processed, so cached entries are never
+// stale. Upload identifiers are not reused, so entries of deleted uploads are simply
+// evicted over time.
+type cachingLSIFStore struct {
+	LSIFStore
+	cache  LSIFDataCache
+	hits   *prometheus.CounterVec
+	misses *prometheus.CounterVec
+}
+
+// NewCachingLSIFStore wraps the given LSIF store with a cache of hover and definitions results.
+func NewCachingLSIFStore(lsifStore LSIFStore, cache LSIFDataCache, observationContext *observation.Context) LSIFStore {
+	counter := func(name, help string) *prometheus.CounterVec {
+		counter := prometheus.NewCounterVec(prometheus.CounterOpts{
+			Name: name,
+			Help: help,
+		}, []string{"method"})
+
+		observationContext.Registerer.MustRegister(counter)
+		return counter
+	}
+
+	return &cachingLSIFStore{
+		LSIFStore: lsifStore,
+		cache:     cache,
+		hits:      counter("src_codeintel_lsif_data_cache_hits_total", "The number of LSIF store queries answered from the cache."),
+		misses:    counter("src_codeintel_lsif_data_cache_misses_total", "The number of LSIF store queries not found in the cache."),
+	}
+}
+
+func (s *cachingLSIFStore) get(key lsifDataCacheKey) (interface{}, bool) {
+	value, ok := s.cache.Get(key)
+	if ok {
+		s.hits.WithLabelValues(key.method).Inc()
+	} else {
+		s.misses.WithLabelValues(key.method).Inc()
+	}
+	return value, ok
+}
+
+func (s *cachingLSIFStore) Hover(ctx context.Context, bundleID int, path string, line, character int) (string, lsifstore.Range, bool, error) {
+	key := lsifDataCacheKey{method: "hover", bundleID: bundleID, path: path, line: line, character: character}
+	if value, ok := s.get(key); ok {
+		v := value.(cachedHover)
+		return v.text, v.rn, v.exists, nil
+	}
+
+	text, rn, exists, err := s.LSIFStore.Hover(ctx, bundleID, path, line, character)
+	if err != nil {
+		return "", lsifstore.Range{}, false, err
+	}
+
+	s.cache.Set(key, cachedHover{text: text, rn: rn, exists: exists}, 1)
+	return text, rn, exists, nil
+}
+
+func (s *cachingLSIFStore) Definitions(ctx context.Context, bundleID int, path string, line, character, limit, offset int) ([]lsifstore.Location, int, error) {
+	key := lsifDataCacheKey{method: "definitions", bundleID: bundleID, path: path, line: line, character: character, limit: limit, offset: offset}
+	if value, ok := s.get(key); ok {
+		v := value.(cachedDefinitions)
+		// Return a copy so callers can't modify the cached locations
+		return append([]lsifstore.Location(nil), v.locations...), v.totalCount, nil
+	}
+
+	locations, totalCount, err := s.LSIFStore.Definitions(ctx, bundleID, path, line, character, limit, offset)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	s.cache.Set(key, cachedDefinitions{locations: append([]lsifstore.Location(nil), locations...), totalCount: totalCount}, 1)
+	return locations, totalCount, nil
+}
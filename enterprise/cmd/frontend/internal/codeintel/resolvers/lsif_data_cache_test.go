@@ -0,0 +1,77 @@
+package resolvers
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+type mapLSIFDataCache map[interface{}]interface{}
+
+func (c mapLSIFDataCache) Get(key interface{}) (interface{}, bool) {
+	value, ok := c[key]
+	return value, ok
+}
+
+func (c mapLSIFDataCache) Set(key, value interface{}, cost int64) bool {
+	c[key] = value
+	return true
+}
+
+func TestCachingLSIFStoreHover(t *testing.T) {
+	mockLSIFStore := NewMockLSIFStore()
+	mockLSIFStore.HoverFunc.SetDefaultReturn("hover text", lsifstore.Range{Start: lsifstore.Position{Line: 1}}, true, nil)
+	store := NewCachingLSIFStore(mockLSIFStore, mapLSIFDataCache{}, &observation.TestContext)
+
+	for i := 0; i < 3; i++ {
+		text, _, exists, err := store.Hover(context.Background(), 42, "main.go", 10, 20)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !exists || text != "hover text" {
+			t.Errorf("unexpected hover result. want=%q have=%q", "hover text", text)
+		}
+	}
+	if _, _, _, err := store.Hover(context.Background(), 42, "main.go", 10, 21); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if len(mockLSIFStore.HoverFunc.History()) != 2 {
+		t.Errorf("unexpected number of hover calls. want=%d have=%d", 2, len(mockLSIFStore.HoverFunc.History()))
+	}
+}
+
+func TestCachingLSIFStoreDefinitions(t *testing.T) {
+	locations := []lsifstore.Location{{DumpID: 42, Path: "a.go"}, {DumpID: 42, Path: "b.go"}}
+	mockLSIFStore := NewMockLSIFStore()
+	mockLSIFStore.DefinitionsFunc.SetDefaultReturn(locations, 2, nil)
+	store := NewCachingLSIFStore(mockLSIFStore, mapLSIFDataCache{}, &observation.TestContext)
+
+	first, _, err := store.Definitions(context.Background(), 42, "main.go", 10, 20, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	first[0].Path = "modified.go"
+
+	second, totalCount, err := store.Definitions(context.Background(), 42, "main.go", 10, 20, 10, 0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if totalCount != 2 {
+		t.Errorf("unexpected total count. want=%d have=%d", 2, totalCount)
+	}
+	if diff := cmp.Diff([]lsifstore.Location{{DumpID: 42, Path: "a.go"}, {DumpID: 42, Path: "b.go"}}, second); diff != "" {
+		t.Errorf("unexpected locations (-want +got):\n%s", diff)
+	}
+
+	if _, _, err := store.Definitions(context.Background(), 42, "main.go", 10, 20, 10, 10); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+	if len(mockLSIFStore.DefinitionsFunc.History()) != 2 {
+		t.Errorf("unexpected number of definitions calls. want=%d have=%d", 2, len(mockLSIFStore.DefinitionsFunc.History()))
+	}
+}
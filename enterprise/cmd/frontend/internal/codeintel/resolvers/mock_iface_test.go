@@ -4,6 +4,7 @@ package resolvers
 
 import (
 	"context"
+	"io"
 	"regexp"
 	"sync"
 	"time"
@@ -27,6 +28,9 @@ type MockDBStore struct {
 	// CommitGraphMetadataFunc is an instance of a mock function object
 	// controlling the behavior of the method CommitGraphMetadata.
 	CommitGraphMetadataFunc *DBStoreCommitGraphMetadataFunc
+	// CreateUploadTokenFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateUploadToken.
+	CreateUploadTokenFunc *DBStoreCreateUploadTokenFunc
 	// DefinitionDumpsFunc is an instance of a mock function object
 	// controlling the behavior of the method DefinitionDumps.
 	DefinitionDumpsFunc *DBStoreDefinitionDumpsFunc
@@ -36,6 +40,9 @@ type MockDBStore struct {
 	// DeleteUploadByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteUploadByID.
 	DeleteUploadByIDFunc *DBStoreDeleteUploadByIDFunc
+	// DeleteUploadTokenFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteUploadToken.
+	DeleteUploadTokenFunc *DBStoreDeleteUploadTokenFunc
 	// FindClosestDumpsFunc is an instance of a mock function object
 	// controlling the behavior of the method FindClosestDumps.
 	FindClosestDumpsFunc *DBStoreFindClosestDumpsFunc
@@ -62,6 +69,12 @@ type MockDBStore struct {
 	// GetUploadByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadByID.
 	GetUploadByIDFunc *DBStoreGetUploadByIDFunc
+	// GetUploadProcessingTraceFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUploadProcessingTrace.
+	GetUploadProcessingTraceFunc *DBStoreGetUploadProcessingTraceFunc
+	// GetUploadTokensFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUploadTokens.
+	GetUploadTokensFunc *DBStoreGetUploadTokensFunc
 	// GetUploadsFunc is an instance of a mock function object controlling
 	// the behavior of the method GetUploads.
 	GetUploadsFunc *DBStoreGetUploadsFunc
@@ -98,6 +111,11 @@ func NewMockDBStore() *MockDBStore {
 				return false, nil, nil
 			},
 		},
+		CreateUploadTokenFunc: &DBStoreCreateUploadTokenFunc{
+			defaultHook: func(context.Context, int, int, string) (int, string, error) {
+				return 0, "", nil
+			},
+		},
 		DefinitionDumpsFunc: &DBStoreDefinitionDumpsFunc{
 			defaultHook: func(context.Context, []semantic.QualifiedMonikerData) ([]dbstore.Dump, error) {
 				return nil, nil
@@ -113,6 +131,11 @@ func NewMockDBStore() *MockDBStore {
 				return false, nil
 			},
 		},
+		DeleteUploadTokenFunc: &DBStoreDeleteUploadTokenFunc{
+			defaultHook: func(context.Context, int) (bool, error) {
+				return false, nil
+			},
+		},
 		FindClosestDumpsFunc: &DBStoreFindClosestDumpsFunc{
 			defaultHook: func(context.Context, int, string, string, bool, string) ([]dbstore.Dump, error) {
 				return nil, nil
@@ -153,6 +176,16 @@ func NewMockDBStore() *MockDBStore {
 				return dbstore.Upload{}, false, nil
 			},
 		},
+		GetUploadProcessingTraceFunc: &DBStoreGetUploadProcessingTraceFunc{
+			defaultHook: func(context.Context, int) (dbstore.UploadProcessingTrace, bool, error) {
+				return dbstore.UploadProcessingTrace{}, false, nil
+			},
+		},
+		GetUploadTokensFunc: &DBStoreGetUploadTokensFunc{
+			defaultHook: func(context.Context, int) ([]dbstore.UploadToken, error) {
+				return nil, nil
+			},
+		},
 		GetUploadsFunc: &DBStoreGetUploadsFunc{
 			defaultHook: func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error) {
 				return nil, 0, nil
@@ -203,6 +236,9 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		CommitGraphMetadataFunc: &DBStoreCommitGraphMetadataFunc{
 			defaultHook: i.CommitGraphMetadata,
 		},
+		CreateUploadTokenFunc: &DBStoreCreateUploadTokenFunc{
+			defaultHook: i.CreateUploadToken,
+		},
 		DefinitionDumpsFunc: &DBStoreDefinitionDumpsFunc{
 			defaultHook: i.DefinitionDumps,
 		},
@@ -212,6 +248,9 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		DeleteUploadByIDFunc: &DBStoreDeleteUploadByIDFunc{
 			defaultHook: i.DeleteUploadByID,
 		},
+		DeleteUploadTokenFunc: &DBStoreDeleteUploadTokenFunc{
+			defaultHook: i.DeleteUploadToken,
+		},
 		FindClosestDumpsFunc: &DBStoreFindClosestDumpsFunc{
 			defaultHook: i.FindClosestDumps,
 		},
@@ -236,6 +275,12 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		GetUploadByIDFunc: &DBStoreGetUploadByIDFunc{
 			defaultHook: i.GetUploadByID,
 		},
+		GetUploadProcessingTraceFunc: &DBStoreGetUploadProcessingTraceFunc{
+			defaultHook: i.GetUploadProcessingTrace,
+		},
+		GetUploadTokensFunc: &DBStoreGetUploadTokensFunc{
+			defaultHook: i.GetUploadTokens,
+		},
 		GetUploadsFunc: &DBStoreGetUploadsFunc{
 			defaultHook: i.GetUploads,
 		},
@@ -375,6 +420,124 @@ func (c DBStoreCommitGraphMetadataFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// DBStoreCreateUploadTokenFunc describes the behavior when the
+// CreateUploadToken method of the parent MockDBStore instance is invoked.
+type DBStoreCreateUploadTokenFunc struct {
+	defaultHook func(context.Context, int, int, string) (int, string, error)
+	hooks       []func(context.Context, int, int, string) (int, string, error)
+	history     []DBStoreCreateUploadTokenFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateUploadToken delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) CreateUploadToken(v0 context.Context, v1 int, v2 int, v3 string) (int, string, error) {
+	r0, r1, r2 := m.CreateUploadTokenFunc.nextHook()(v0, v1, v2, v3)
+	m.CreateUploadTokenFunc.appendCall(DBStoreCreateUploadTokenFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the CreateUploadToken
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreCreateUploadTokenFunc) SetDefaultHook(hook func(context.Context, int, int, string) (int, string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateUploadToken method of the parent MockDBStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *DBStoreCreateUploadTokenFunc) PushHook(hook func(context.Context, int, int, string) (int, string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreCreateUploadTokenFunc) SetDefaultReturn(r0 int, r1 string, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, int, string) (int, string, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreCreateUploadTokenFunc) PushReturn(r0 int, r1 string, r2 error) {
+	f.PushHook(func(context.Context, int, int, string) (int, string, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *DBStoreCreateUploadTokenFunc) nextHook() func(context.Context, int, int, string) (int, string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreCreateUploadTokenFunc) appendCall(r0 DBStoreCreateUploadTokenFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreCreateUploadTokenFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreCreateUploadTokenFunc) History() []DBStoreCreateUploadTokenFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreCreateUploadTokenFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreCreateUploadTokenFuncCall is an object that describes an
+// invocation of method CreateUploadToken on an instance of MockDBStore.
+type DBStoreCreateUploadTokenFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 string
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreCreateUploadTokenFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreCreateUploadTokenFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // DBStoreDefinitionDumpsFunc describes the behavior when the
 // DefinitionDumps method of the parent MockDBStore instance is invoked.
 type DBStoreDefinitionDumpsFunc struct {
@@ -702,6 +865,115 @@ func (c DBStoreDeleteUploadByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// DBStoreDeleteUploadTokenFunc describes the behavior when the
+// DeleteUploadToken method of the parent MockDBStore instance is invoked.
+type DBStoreDeleteUploadTokenFunc struct {
+	defaultHook func(context.Context, int) (bool, error)
+	hooks       []func(context.Context, int) (bool, error)
+	history     []DBStoreDeleteUploadTokenFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteUploadToken delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) DeleteUploadToken(v0 context.Context, v1 int) (bool, error) {
+	r0, r1 := m.DeleteUploadTokenFunc.nextHook()(v0, v1)
+	m.DeleteUploadTokenFunc.appendCall(DBStoreDeleteUploadTokenFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the DeleteUploadToken
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreDeleteUploadTokenFunc) SetDefaultHook(hook func(context.Context, int) (bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteUploadToken method of the parent MockDBStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *DBStoreDeleteUploadTokenFunc) PushHook(hook func(context.Context, int) (bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreDeleteUploadTokenFunc) SetDefaultReturn(r0 bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) (bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreDeleteUploadTokenFunc) PushReturn(r0 bool, r1 error) {
+	f.PushHook(func(context.Context, int) (bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreDeleteUploadTokenFunc) nextHook() func(context.Context, int) (bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreDeleteUploadTokenFunc) appendCall(r0 DBStoreDeleteUploadTokenFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreDeleteUploadTokenFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreDeleteUploadTokenFunc) History() []DBStoreDeleteUploadTokenFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreDeleteUploadTokenFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreDeleteUploadTokenFuncCall is an object that describes an
+// invocation of method DeleteUploadToken on an instance of MockDBStore.
+type DBStoreDeleteUploadTokenFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreDeleteUploadTokenFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreDeleteUploadTokenFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // DBStoreFindClosestDumpsFunc describes the behavior when the
 // FindClosestDumps method of the parent MockDBStore instance is invoked.
 type DBStoreFindClosestDumpsFunc struct {
@@ -1628,34 +1900,37 @@ func (c DBStoreGetUploadByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// DBStoreGetUploadsFunc describes the behavior when the GetUploads method
-// of the parent MockDBStore instance is invoked.
-type DBStoreGetUploadsFunc struct {
-	defaultHook func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error)
-	hooks       []func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error)
-	history     []DBStoreGetUploadsFuncCall
+// DBStoreGetUploadProcessingTraceFunc describes the behavior when the
+// GetUploadProcessingTrace method of the parent MockDBStore instance is
+// invoked.
+type DBStoreGetUploadProcessingTraceFunc struct {
+	defaultHook func(context.Context, int) (dbstore.UploadProcessingTrace, bool, error)
+	hooks       []func(context.Context, int) (dbstore.UploadProcessingTrace, bool, error)
+	history     []DBStoreGetUploadProcessingTraceFuncCall
 	mutex       sync.Mutex
 }
 
-// GetUploads delegates to the next hook function in the queue and stores
-// the parameter and result values of this invocation.
-func (m *MockDBStore) GetUploads(v0 context.Context, v1 dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error) {
-	r0, r1, r2 := m.GetUploadsFunc.nextHook()(v0, v1)
-	m.GetUploadsFunc.appendCall(DBStoreGetUploadsFuncCall{v0, v1, r0, r1, r2})
+// GetUploadProcessingTrace delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockDBStore) GetUploadProcessingTrace(v0 context.Context, v1 int) (dbstore.UploadProcessingTrace, bool, error) {
+	r0, r1, r2 := m.GetUploadProcessingTraceFunc.nextHook()(v0, v1)
+	m.GetUploadProcessingTraceFunc.appendCall(DBStoreGetUploadProcessingTraceFuncCall{v0, v1, r0, r1, r2})
 	return r0, r1, r2
 }
 
-// SetDefaultHook sets function that is called when the GetUploads method of
-// the parent MockDBStore instance is invoked and the hook queue is empty.
-func (f *DBStoreGetUploadsFunc) SetDefaultHook(hook func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error)) {
+// SetDefaultHook sets function that is called when the
+// GetUploadProcessingTrace method of the parent MockDBStore instance is
+// invoked and the hook queue is empty.
+func (f *DBStoreGetUploadProcessingTraceFunc) SetDefaultHook(hook func(context.Context, int) (dbstore.UploadProcessingTrace, bool, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetUploads method of the parent MockDBStore instance invokes the hook at
-// the front of the queue and discards it. After the queue is empty, the
-// default hook function is invoked for any future action.
-func (f *DBStoreGetUploadsFunc) PushHook(hook func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error)) {
+// GetUploadProcessingTrace method of the parent MockDBStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *DBStoreGetUploadProcessingTraceFunc) PushHook(hook func(context.Context, int) (dbstore.UploadProcessingTrace, bool, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1663,21 +1938,21 @@ func (f *DBStoreGetUploadsFunc) PushHook(hook func(context.Context, dbstore.GetU
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *DBStoreGetUploadsFunc) SetDefaultReturn(r0 []dbstore.Upload, r1 int, r2 error) {
-	f.SetDefaultHook(func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error) {
+func (f *DBStoreGetUploadProcessingTraceFunc) SetDefaultReturn(r0 dbstore.UploadProcessingTrace, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (dbstore.UploadProcessingTrace, bool, error) {
 		return r0, r1, r2
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *DBStoreGetUploadsFunc) PushReturn(r0 []dbstore.Upload, r1 int, r2 error) {
-	f.PushHook(func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error) {
+func (f *DBStoreGetUploadProcessingTraceFunc) PushReturn(r0 dbstore.UploadProcessingTrace, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (dbstore.UploadProcessingTrace, bool, error) {
 		return r0, r1, r2
 	})
 }
 
-func (f *DBStoreGetUploadsFunc) nextHook() func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error) {
+func (f *DBStoreGetUploadProcessingTraceFunc) nextHook() func(context.Context, int) (dbstore.UploadProcessingTrace, bool, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1690,38 +1965,39 @@ func (f *DBStoreGetUploadsFunc) nextHook() func(context.Context, dbstore.GetUplo
 	return hook
 }
 
-func (f *DBStoreGetUploadsFunc) appendCall(r0 DBStoreGetUploadsFuncCall) {
+func (f *DBStoreGetUploadProcessingTraceFunc) appendCall(r0 DBStoreGetUploadProcessingTraceFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of DBStoreGetUploadsFuncCall objects
-// describing the invocations of this function.
-func (f *DBStoreGetUploadsFunc) History() []DBStoreGetUploadsFuncCall {
+// History returns a sequence of DBStoreGetUploadProcessingTraceFuncCall
+// objects describing the invocations of this function.
+func (f *DBStoreGetUploadProcessingTraceFunc) History() []DBStoreGetUploadProcessingTraceFuncCall {
 	f.mutex.Lock()
-	history := make([]DBStoreGetUploadsFuncCall, len(f.history))
+	history := make([]DBStoreGetUploadProcessingTraceFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// DBStoreGetUploadsFuncCall is an object that describes an invocation of
-// method GetUploads on an instance of MockDBStore.
-type DBStoreGetUploadsFuncCall struct {
+// DBStoreGetUploadProcessingTraceFuncCall is an object that describes an
+// invocation of method GetUploadProcessingTrace on an instance of
+// MockDBStore.
+type DBStoreGetUploadProcessingTraceFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 dbstore.GetUploadsOptions
+	Arg1 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 []dbstore.Upload
+	Result0 dbstore.UploadProcessingTrace
 	// Result1 is the value of the 2nd result returned from this method
 	// invocation.
-	Result1 int
+	Result1 bool
 	// Result2 is the value of the 3rd result returned from this method
 	// invocation.
 	Result2 error
@@ -1729,45 +2005,45 @@ type DBStoreGetUploadsFuncCall struct {
 
 // Args returns an interface slice containing the arguments of this
 // invocation.
-func (c DBStoreGetUploadsFuncCall) Args() []interface{} {
+func (c DBStoreGetUploadProcessingTraceFuncCall) Args() []interface{} {
 	return []interface{}{c.Arg0, c.Arg1}
 }
 
 // Results returns an interface slice containing the results of this
 // invocation.
-func (c DBStoreGetUploadsFuncCall) Results() []interface{} {
+func (c DBStoreGetUploadProcessingTraceFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
-// DBStoreGetUploadsByIDsFunc describes the behavior when the
-// GetUploadsByIDs method of the parent MockDBStore instance is invoked.
-type DBStoreGetUploadsByIDsFunc struct {
-	defaultHook func(context.Context, ...int) ([]dbstore.Upload, error)
-	hooks       []func(context.Context, ...int) ([]dbstore.Upload, error)
-	history     []DBStoreGetUploadsByIDsFuncCall
+// DBStoreGetUploadTokensFunc describes the behavior when the
+// GetUploadTokens method of the parent MockDBStore instance is invoked.
+type DBStoreGetUploadTokensFunc struct {
+	defaultHook func(context.Context, int) ([]dbstore.UploadToken, error)
+	hooks       []func(context.Context, int) ([]dbstore.UploadToken, error)
+	history     []DBStoreGetUploadTokensFuncCall
 	mutex       sync.Mutex
 }
 
-// GetUploadsByIDs delegates to the next hook function in the queue and
+// GetUploadTokens delegates to the next hook function in the queue and
 // stores the parameter and result values of this invocation.
-func (m *MockDBStore) GetUploadsByIDs(v0 context.Context, v1 ...int) ([]dbstore.Upload, error) {
-	r0, r1 := m.GetUploadsByIDsFunc.nextHook()(v0, v1...)
-	m.GetUploadsByIDsFunc.appendCall(DBStoreGetUploadsByIDsFuncCall{v0, v1, r0, r1})
+func (m *MockDBStore) GetUploadTokens(v0 context.Context, v1 int) ([]dbstore.UploadToken, error) {
+	r0, r1 := m.GetUploadTokensFunc.nextHook()(v0, v1)
+	m.GetUploadTokensFunc.appendCall(DBStoreGetUploadTokensFuncCall{v0, v1, r0, r1})
 	return r0, r1
 }
 
-// SetDefaultHook sets function that is called when the GetUploadsByIDs
+// SetDefaultHook sets function that is called when the GetUploadTokens
 // method of the parent MockDBStore instance is invoked and the hook queue
 // is empty.
-func (f *DBStoreGetUploadsByIDsFunc) SetDefaultHook(hook func(context.Context, ...int) ([]dbstore.Upload, error)) {
+func (f *DBStoreGetUploadTokensFunc) SetDefaultHook(hook func(context.Context, int) ([]dbstore.UploadToken, error)) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// GetUploadsByIDs method of the parent MockDBStore instance invokes the
+// GetUploadTokens method of the parent MockDBStore instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *DBStoreGetUploadsByIDsFunc) PushHook(hook func(context.Context, ...int) ([]dbstore.Upload, error)) {
+func (f *DBStoreGetUploadTokensFunc) PushHook(hook func(context.Context, int) ([]dbstore.UploadToken, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1775,21 +2051,21 @@ func (f *DBStoreGetUploadsByIDsFunc) PushHook(hook func(context.Context, ...int)
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *DBStoreGetUploadsByIDsFunc) SetDefaultReturn(r0 []dbstore.Upload, r1 error) {
-	f.SetDefaultHook(func(context.Context, ...int) ([]dbstore.Upload, error) {
+func (f *DBStoreGetUploadTokensFunc) SetDefaultReturn(r0 []dbstore.UploadToken, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]dbstore.UploadToken, error) {
 		return r0, r1
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *DBStoreGetUploadsByIDsFunc) PushReturn(r0 []dbstore.Upload, r1 error) {
-	f.PushHook(func(context.Context, ...int) ([]dbstore.Upload, error) {
+func (f *DBStoreGetUploadTokensFunc) PushReturn(r0 []dbstore.UploadToken, r1 error) {
+	f.PushHook(func(context.Context, int) ([]dbstore.UploadToken, error) {
 		return r0, r1
 	})
 }
 
-func (f *DBStoreGetUploadsByIDsFunc) nextHook() func(context.Context, ...int) ([]dbstore.Upload, error) {
+func (f *DBStoreGetUploadTokensFunc) nextHook() func(context.Context, int) ([]dbstore.UploadToken, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1802,13 +2078,233 @@ func (f *DBStoreGetUploadsByIDsFunc) nextHook() func(context.Context, ...int) ([
 	return hook
 }
 
-func (f *DBStoreGetUploadsByIDsFunc) appendCall(r0 DBStoreGetUploadsByIDsFuncCall) {
+func (f *DBStoreGetUploadTokensFunc) appendCall(r0 DBStoreGetUploadTokensFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of DBStoreGetUploadsByIDsFuncCall objects
+// History returns a sequence of DBStoreGetUploadTokensFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreGetUploadTokensFunc) History() []DBStoreGetUploadTokensFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreGetUploadTokensFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreGetUploadTokensFuncCall is an object that describes an invocation
+// of method GetUploadTokens on an instance of MockDBStore.
+type DBStoreGetUploadTokensFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []dbstore.UploadToken
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreGetUploadTokensFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreGetUploadTokensFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// DBStoreGetUploadsFunc describes the behavior when the GetUploads method
+// of the parent MockDBStore instance is invoked.
+type DBStoreGetUploadsFunc struct {
+	defaultHook func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error)
+	hooks       []func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error)
+	history     []DBStoreGetUploadsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploads delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockDBStore) GetUploads(v0 context.Context, v1 dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error) {
+	r0, r1, r2 := m.GetUploadsFunc.nextHook()(v0, v1)
+	m.GetUploadsFunc.appendCall(DBStoreGetUploadsFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the GetUploads method of
+// the parent MockDBStore instance is invoked and the hook queue is empty.
+func (f *DBStoreGetUploadsFunc) SetDefaultHook(hook func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploads method of the parent MockDBStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DBStoreGetUploadsFunc) PushHook(hook func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreGetUploadsFunc) SetDefaultReturn(r0 []dbstore.Upload, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreGetUploadsFunc) PushReturn(r0 []dbstore.Upload, r1 int, r2 error) {
+	f.PushHook(func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *DBStoreGetUploadsFunc) nextHook() func(context.Context, dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreGetUploadsFunc) appendCall(r0 DBStoreGetUploadsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreGetUploadsFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreGetUploadsFunc) History() []DBStoreGetUploadsFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreGetUploadsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreGetUploadsFuncCall is an object that describes an invocation of
+// method GetUploads on an instance of MockDBStore.
+type DBStoreGetUploadsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 dbstore.GetUploadsOptions
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []dbstore.Upload
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreGetUploadsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreGetUploadsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// DBStoreGetUploadsByIDsFunc describes the behavior when the
+// GetUploadsByIDs method of the parent MockDBStore instance is invoked.
+type DBStoreGetUploadsByIDsFunc struct {
+	defaultHook func(context.Context, ...int) ([]dbstore.Upload, error)
+	hooks       []func(context.Context, ...int) ([]dbstore.Upload, error)
+	history     []DBStoreGetUploadsByIDsFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadsByIDs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) GetUploadsByIDs(v0 context.Context, v1 ...int) ([]dbstore.Upload, error) {
+	r0, r1 := m.GetUploadsByIDsFunc.nextHook()(v0, v1...)
+	m.GetUploadsByIDsFunc.appendCall(DBStoreGetUploadsByIDsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetUploadsByIDs
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreGetUploadsByIDsFunc) SetDefaultHook(hook func(context.Context, ...int) ([]dbstore.Upload, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploadsByIDs method of the parent MockDBStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *DBStoreGetUploadsByIDsFunc) PushHook(hook func(context.Context, ...int) ([]dbstore.Upload, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreGetUploadsByIDsFunc) SetDefaultReturn(r0 []dbstore.Upload, r1 error) {
+	f.SetDefaultHook(func(context.Context, ...int) ([]dbstore.Upload, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreGetUploadsByIDsFunc) PushReturn(r0 []dbstore.Upload, r1 error) {
+	f.PushHook(func(context.Context, ...int) ([]dbstore.Upload, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreGetUploadsByIDsFunc) nextHook() func(context.Context, ...int) ([]dbstore.Upload, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreGetUploadsByIDsFunc) appendCall(r0 DBStoreGetUploadsByIDsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreGetUploadsByIDsFuncCall objects
 // describing the invocations of this function.
 func (f *DBStoreGetUploadsByIDsFunc) History() []DBStoreGetUploadsByIDsFuncCall {
 	f.mutex.Lock()
@@ -3532,6 +4028,9 @@ type MockEnqueuerGitserverClient struct {
 	// ResolveRevisionFunc is an instance of a mock function object
 	// controlling the behavior of the method ResolveRevision.
 	ResolveRevisionFunc *EnqueuerGitserverClientResolveRevisionFunc
+	// StatPathsFunc is an instance of a mock function object controlling
+	// the behavior of the method StatPaths.
+	StatPathsFunc *EnqueuerGitserverClientStatPathsFunc
 }
 
 // NewMockEnqueuerGitserverClient creates a new mock of the
@@ -3564,6 +4063,11 @@ func NewMockEnqueuerGitserverClient() *MockEnqueuerGitserverClient {
 				return "", nil
 			},
 		},
+		StatPathsFunc: &EnqueuerGitserverClientStatPathsFunc{
+			defaultHook: func(context.Context, int, string, []string) (map[string]bool, error) {
+				return nil, nil
+			},
+		},
 	}
 }
 
@@ -3587,6 +4091,9 @@ func NewMockEnqueuerGitserverClientFrom(i EnqueuerGitserverClient) *MockEnqueuer
 		ResolveRevisionFunc: &EnqueuerGitserverClientResolveRevisionFunc{
 			defaultHook: i.ResolveRevision,
 		},
+		StatPathsFunc: &EnqueuerGitserverClientStatPathsFunc{
+			defaultHook: i.StatPaths,
+		},
 	}
 }
 
@@ -4172,46 +4679,958 @@ func (c EnqueuerGitserverClientResolveRevisionFuncCall) Results() []interface{}
 	return []interface{}{c.Result0, c.Result1}
 }
 
-// MockGitserverClient is a mock implementation of the GitserverClient
-// interface (from the package
-// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
-// used for unit testing.
-type MockGitserverClient struct {
-	// CommitExistsFunc is an instance of a mock function object controlling
-	// the behavior of the method CommitExists.
-	CommitExistsFunc *GitserverClientCommitExistsFunc
-	// CommitGraphFunc is an instance of a mock function object controlling
-	// the behavior of the method CommitGraph.
-	CommitGraphFunc *GitserverClientCommitGraphFunc
+// EnqueuerGitserverClientStatPathsFunc describes the behavior when the
+// StatPaths method of the parent MockEnqueuerGitserverClient instance is
+// invoked.
+type EnqueuerGitserverClientStatPathsFunc struct {
+	defaultHook func(context.Context, int, string, []string) (map[string]bool, error)
+	hooks       []func(context.Context, int, string, []string) (map[string]bool, error)
+	history     []EnqueuerGitserverClientStatPathsFuncCall
+	mutex       sync.Mutex
 }
 
-// NewMockGitserverClient creates a new mock of the GitserverClient
-// interface. All methods return zero values for all results, unless
-// overwritten.
-func NewMockGitserverClient() *MockGitserverClient {
-	return &MockGitserverClient{
-		CommitExistsFunc: &GitserverClientCommitExistsFunc{
-			defaultHook: func(context.Context, int, string) (bool, error) {
-				return false, nil
-			},
-		},
-		CommitGraphFunc: &GitserverClientCommitGraphFunc{
-			defaultHook: func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error) {
-				return nil, nil
-			},
-		},
-	}
+// StatPaths delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockEnqueuerGitserverClient) StatPaths(v0 context.Context, v1 int, v2 string, v3 []string) (map[string]bool, error) {
+	r0, r1 := m.StatPathsFunc.nextHook()(v0, v1, v2, v3)
+	m.StatPathsFunc.appendCall(EnqueuerGitserverClientStatPathsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
 }
 
-// NewMockGitserverClientFrom creates a new mock of the MockGitserverClient
-// interface. All methods delegate to the given implementation, unless
-// overwritten.
-func NewMockGitserverClientFrom(i GitserverClient) *MockGitserverClient {
-	return &MockGitserverClient{
-		CommitExistsFunc: &GitserverClientCommitExistsFunc{
-			defaultHook: i.CommitExists,
-		},
-		CommitGraphFunc: &GitserverClientCommitGraphFunc{
+// SetDefaultHook sets function that is called when the StatPaths method of
+// the parent MockEnqueuerGitserverClient instance is invoked and the hook
+// queue is empty.
+func (f *EnqueuerGitserverClientStatPathsFunc) SetDefaultHook(hook func(context.Context, int, string, []string) (map[string]bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// StatPaths method of the parent MockEnqueuerGitserverClient instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *EnqueuerGitserverClientStatPathsFunc) PushHook(hook func(context.Context, int, string, []string) (map[string]bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *EnqueuerGitserverClientStatPathsFunc) SetDefaultReturn(r0 map[string]bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, []string) (map[string]bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *EnqueuerGitserverClientStatPathsFunc) PushReturn(r0 map[string]bool, r1 error) {
+	f.PushHook(func(context.Context, int, string, []string) (map[string]bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *EnqueuerGitserverClientStatPathsFunc) nextHook() func(context.Context, int, string, []string) (map[string]bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EnqueuerGitserverClientStatPathsFunc) appendCall(r0 EnqueuerGitserverClientStatPathsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EnqueuerGitserverClientStatPathsFuncCall
+// objects describing the invocations of this function.
+func (f *EnqueuerGitserverClientStatPathsFunc) History() []EnqueuerGitserverClientStatPathsFuncCall {
+	f.mutex.Lock()
+	history := make([]EnqueuerGitserverClientStatPathsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EnqueuerGitserverClientStatPathsFuncCall is an object that describes an
+// invocation of method StatPaths on an instance of
+// MockEnqueuerGitserverClient.
+type EnqueuerGitserverClientStatPathsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EnqueuerGitserverClientStatPathsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EnqueuerGitserverClientStatPathsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// MockEvictionDBStore is a mock implementation of the EvictionDBStore
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
+// used for unit testing.
+type MockEvictionDBStore struct {
+	// EvictedUploadIDsFunc is an instance of a mock function object
+	// controlling the behavior of the method EvictedUploadIDs.
+	EvictedUploadIDsFunc *EvictionDBStoreEvictedUploadIDsFunc
+	// MarkUploadsQueriedFunc is an instance of a mock function object
+	// controlling the behavior of the method MarkUploadsQueried.
+	MarkUploadsQueriedFunc *EvictionDBStoreMarkUploadsQueriedFunc
+	// UnmarkUploadEvictedFunc is an instance of a mock function object
+	// controlling the behavior of the method UnmarkUploadEvicted.
+	UnmarkUploadEvictedFunc *EvictionDBStoreUnmarkUploadEvictedFunc
+}
+
+// NewMockEvictionDBStore creates a new mock of the EvictionDBStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockEvictionDBStore() *MockEvictionDBStore {
+	return &MockEvictionDBStore{
+		EvictedUploadIDsFunc: &EvictionDBStoreEvictedUploadIDsFunc{
+			defaultHook: func(context.Context, []int) ([]int, error) {
+				return nil, nil
+			},
+		},
+		MarkUploadsQueriedFunc: &EvictionDBStoreMarkUploadsQueriedFunc{
+			defaultHook: func(context.Context, []int, time.Time) error {
+				return nil
+			},
+		},
+		UnmarkUploadEvictedFunc: &EvictionDBStoreUnmarkUploadEvictedFunc{
+			defaultHook: func(context.Context, int) error {
+				return nil
+			},
+		},
+	}
+}
+
+// NewMockEvictionDBStoreFrom creates a new mock of the MockEvictionDBStore
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockEvictionDBStoreFrom(i EvictionDBStore) *MockEvictionDBStore {
+	return &MockEvictionDBStore{
+		EvictedUploadIDsFunc: &EvictionDBStoreEvictedUploadIDsFunc{
+			defaultHook: i.EvictedUploadIDs,
+		},
+		MarkUploadsQueriedFunc: &EvictionDBStoreMarkUploadsQueriedFunc{
+			defaultHook: i.MarkUploadsQueried,
+		},
+		UnmarkUploadEvictedFunc: &EvictionDBStoreUnmarkUploadEvictedFunc{
+			defaultHook: i.UnmarkUploadEvicted,
+		},
+	}
+}
+
+// EvictionDBStoreEvictedUploadIDsFunc describes the behavior when the
+// EvictedUploadIDs method of the parent MockEvictionDBStore instance is
+// invoked.
+type EvictionDBStoreEvictedUploadIDsFunc struct {
+	defaultHook func(context.Context, []int) ([]int, error)
+	hooks       []func(context.Context, []int) ([]int, error)
+	history     []EvictionDBStoreEvictedUploadIDsFuncCall
+	mutex       sync.Mutex
+}
+
+// EvictedUploadIDs delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockEvictionDBStore) EvictedUploadIDs(v0 context.Context, v1 []int) ([]int, error) {
+	r0, r1 := m.EvictedUploadIDsFunc.nextHook()(v0, v1)
+	m.EvictedUploadIDsFunc.appendCall(EvictionDBStoreEvictedUploadIDsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the EvictedUploadIDs
+// method of the parent MockEvictionDBStore instance is invoked and the hook
+// queue is empty.
+func (f *EvictionDBStoreEvictedUploadIDsFunc) SetDefaultHook(hook func(context.Context, []int) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// EvictedUploadIDs method of the parent MockEvictionDBStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *EvictionDBStoreEvictedUploadIDsFunc) PushHook(hook func(context.Context, []int) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *EvictionDBStoreEvictedUploadIDsFunc) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context, []int) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *EvictionDBStoreEvictedUploadIDsFunc) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context, []int) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *EvictionDBStoreEvictedUploadIDsFunc) nextHook() func(context.Context, []int) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EvictionDBStoreEvictedUploadIDsFunc) appendCall(r0 EvictionDBStoreEvictedUploadIDsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EvictionDBStoreEvictedUploadIDsFuncCall
+// objects describing the invocations of this function.
+func (f *EvictionDBStoreEvictedUploadIDsFunc) History() []EvictionDBStoreEvictedUploadIDsFuncCall {
+	f.mutex.Lock()
+	history := make([]EvictionDBStoreEvictedUploadIDsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EvictionDBStoreEvictedUploadIDsFuncCall is an object that describes an
+// invocation of method EvictedUploadIDs on an instance of
+// MockEvictionDBStore.
+type EvictionDBStoreEvictedUploadIDsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EvictionDBStoreEvictedUploadIDsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EvictionDBStoreEvictedUploadIDsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// EvictionDBStoreMarkUploadsQueriedFunc describes the behavior when the
+// MarkUploadsQueried method of the parent MockEvictionDBStore instance is
+// invoked.
+type EvictionDBStoreMarkUploadsQueriedFunc struct {
+	defaultHook func(context.Context, []int, time.Time) error
+	hooks       []func(context.Context, []int, time.Time) error
+	history     []EvictionDBStoreMarkUploadsQueriedFuncCall
+	mutex       sync.Mutex
+}
+
+// MarkUploadsQueried delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockEvictionDBStore) MarkUploadsQueried(v0 context.Context, v1 []int, v2 time.Time) error {
+	r0 := m.MarkUploadsQueriedFunc.nextHook()(v0, v1, v2)
+	m.MarkUploadsQueriedFunc.appendCall(EvictionDBStoreMarkUploadsQueriedFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the MarkUploadsQueried
+// method of the parent MockEvictionDBStore instance is invoked and the hook
+// queue is empty.
+func (f *EvictionDBStoreMarkUploadsQueriedFunc) SetDefaultHook(hook func(context.Context, []int, time.Time) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// MarkUploadsQueried method of the parent MockEvictionDBStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *EvictionDBStoreMarkUploadsQueriedFunc) PushHook(hook func(context.Context, []int, time.Time) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *EvictionDBStoreMarkUploadsQueriedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, []int, time.Time) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *EvictionDBStoreMarkUploadsQueriedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, []int, time.Time) error {
+		return r0
+	})
+}
+
+func (f *EvictionDBStoreMarkUploadsQueriedFunc) nextHook() func(context.Context, []int, time.Time) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EvictionDBStoreMarkUploadsQueriedFunc) appendCall(r0 EvictionDBStoreMarkUploadsQueriedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EvictionDBStoreMarkUploadsQueriedFuncCall
+// objects describing the invocations of this function.
+func (f *EvictionDBStoreMarkUploadsQueriedFunc) History() []EvictionDBStoreMarkUploadsQueriedFuncCall {
+	f.mutex.Lock()
+	history := make([]EvictionDBStoreMarkUploadsQueriedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EvictionDBStoreMarkUploadsQueriedFuncCall is an object that describes an
+// invocation of method MarkUploadsQueried on an instance of
+// MockEvictionDBStore.
+type EvictionDBStoreMarkUploadsQueriedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 []int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EvictionDBStoreMarkUploadsQueriedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EvictionDBStoreMarkUploadsQueriedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// EvictionDBStoreUnmarkUploadEvictedFunc describes the behavior when the
+// UnmarkUploadEvicted method of the parent MockEvictionDBStore instance is
+// invoked.
+type EvictionDBStoreUnmarkUploadEvictedFunc struct {
+	defaultHook func(context.Context, int) error
+	hooks       []func(context.Context, int) error
+	history     []EvictionDBStoreUnmarkUploadEvictedFuncCall
+	mutex       sync.Mutex
+}
+
+// UnmarkUploadEvicted delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockEvictionDBStore) UnmarkUploadEvicted(v0 context.Context, v1 int) error {
+	r0 := m.UnmarkUploadEvictedFunc.nextHook()(v0, v1)
+	m.UnmarkUploadEvictedFunc.appendCall(EvictionDBStoreUnmarkUploadEvictedFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the UnmarkUploadEvicted
+// method of the parent MockEvictionDBStore instance is invoked and the hook
+// queue is empty.
+func (f *EvictionDBStoreUnmarkUploadEvictedFunc) SetDefaultHook(hook func(context.Context, int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UnmarkUploadEvicted method of the parent MockEvictionDBStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *EvictionDBStoreUnmarkUploadEvictedFunc) PushHook(hook func(context.Context, int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *EvictionDBStoreUnmarkUploadEvictedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *EvictionDBStoreUnmarkUploadEvictedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+func (f *EvictionDBStoreUnmarkUploadEvictedFunc) nextHook() func(context.Context, int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EvictionDBStoreUnmarkUploadEvictedFunc) appendCall(r0 EvictionDBStoreUnmarkUploadEvictedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EvictionDBStoreUnmarkUploadEvictedFuncCall
+// objects describing the invocations of this function.
+func (f *EvictionDBStoreUnmarkUploadEvictedFunc) History() []EvictionDBStoreUnmarkUploadEvictedFuncCall {
+	f.mutex.Lock()
+	history := make([]EvictionDBStoreUnmarkUploadEvictedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EvictionDBStoreUnmarkUploadEvictedFuncCall is an object that describes an
+// invocation of method UnmarkUploadEvicted on an instance of
+// MockEvictionDBStore.
+type EvictionDBStoreUnmarkUploadEvictedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EvictionDBStoreUnmarkUploadEvictedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EvictionDBStoreUnmarkUploadEvictedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// MockEvictionLSIFStore is a mock implementation of the EvictionLSIFStore
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
+// used for unit testing.
+type MockEvictionLSIFStore struct {
+	// ImportBundleFunc is an instance of a mock function object controlling
+	// the behavior of the method ImportBundle.
+	ImportBundleFunc *EvictionLSIFStoreImportBundleFunc
+}
+
+// NewMockEvictionLSIFStore creates a new mock of the EvictionLSIFStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockEvictionLSIFStore() *MockEvictionLSIFStore {
+	return &MockEvictionLSIFStore{
+		ImportBundleFunc: &EvictionLSIFStoreImportBundleFunc{
+			defaultHook: func(context.Context, int, io.Reader) error {
+				return nil
+			},
+		},
+	}
+}
+
+// NewMockEvictionLSIFStoreFrom creates a new mock of the
+// MockEvictionLSIFStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockEvictionLSIFStoreFrom(i EvictionLSIFStore) *MockEvictionLSIFStore {
+	return &MockEvictionLSIFStore{
+		ImportBundleFunc: &EvictionLSIFStoreImportBundleFunc{
+			defaultHook: i.ImportBundle,
+		},
+	}
+}
+
+// EvictionLSIFStoreImportBundleFunc describes the behavior when the
+// ImportBundle method of the parent MockEvictionLSIFStore instance is
+// invoked.
+type EvictionLSIFStoreImportBundleFunc struct {
+	defaultHook func(context.Context, int, io.Reader) error
+	hooks       []func(context.Context, int, io.Reader) error
+	history     []EvictionLSIFStoreImportBundleFuncCall
+	mutex       sync.Mutex
+}
+
+// ImportBundle delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockEvictionLSIFStore) ImportBundle(v0 context.Context, v1 int, v2 io.Reader) error {
+	r0 := m.ImportBundleFunc.nextHook()(v0, v1, v2)
+	m.ImportBundleFunc.appendCall(EvictionLSIFStoreImportBundleFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ImportBundle method
+// of the parent MockEvictionLSIFStore instance is invoked and the hook
+// queue is empty.
+func (f *EvictionLSIFStoreImportBundleFunc) SetDefaultHook(hook func(context.Context, int, io.Reader) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ImportBundle method of the parent MockEvictionLSIFStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *EvictionLSIFStoreImportBundleFunc) PushHook(hook func(context.Context, int, io.Reader) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *EvictionLSIFStoreImportBundleFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, io.Reader) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *EvictionLSIFStoreImportBundleFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, io.Reader) error {
+		return r0
+	})
+}
+
+func (f *EvictionLSIFStoreImportBundleFunc) nextHook() func(context.Context, int, io.Reader) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EvictionLSIFStoreImportBundleFunc) appendCall(r0 EvictionLSIFStoreImportBundleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EvictionLSIFStoreImportBundleFuncCall
+// objects describing the invocations of this function.
+func (f *EvictionLSIFStoreImportBundleFunc) History() []EvictionLSIFStoreImportBundleFuncCall {
+	f.mutex.Lock()
+	history := make([]EvictionLSIFStoreImportBundleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EvictionLSIFStoreImportBundleFuncCall is an object that describes an
+// invocation of method ImportBundle on an instance of
+// MockEvictionLSIFStore.
+type EvictionLSIFStoreImportBundleFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 io.Reader
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EvictionLSIFStoreImportBundleFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EvictionLSIFStoreImportBundleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// MockEvictionUploadStore is a mock implementation of the
+// EvictionUploadStore interface (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
+// used for unit testing.
+type MockEvictionUploadStore struct {
+	// DeleteFunc is an instance of a mock function object controlling the
+	// behavior of the method Delete.
+	DeleteFunc *EvictionUploadStoreDeleteFunc
+	// GetFunc is an instance of a mock function object controlling the
+	// behavior of the method Get.
+	GetFunc *EvictionUploadStoreGetFunc
+}
+
+// NewMockEvictionUploadStore creates a new mock of the EvictionUploadStore
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockEvictionUploadStore() *MockEvictionUploadStore {
+	return &MockEvictionUploadStore{
+		DeleteFunc: &EvictionUploadStoreDeleteFunc{
+			defaultHook: func(context.Context, string) error {
+				return nil
+			},
+		},
+		GetFunc: &EvictionUploadStoreGetFunc{
+			defaultHook: func(context.Context, string) (io.ReadCloser, error) {
+				return nil, nil
+			},
+		},
+	}
+}
+
+// NewMockEvictionUploadStoreFrom creates a new mock of the
+// MockEvictionUploadStore interface. All methods delegate to the given
+// implementation, unless overwritten.
+func NewMockEvictionUploadStoreFrom(i EvictionUploadStore) *MockEvictionUploadStore {
+	return &MockEvictionUploadStore{
+		DeleteFunc: &EvictionUploadStoreDeleteFunc{
+			defaultHook: i.Delete,
+		},
+		GetFunc: &EvictionUploadStoreGetFunc{
+			defaultHook: i.Get,
+		},
+	}
+}
+
+// EvictionUploadStoreDeleteFunc describes the behavior when the Delete
+// method of the parent MockEvictionUploadStore instance is invoked.
+type EvictionUploadStoreDeleteFunc struct {
+	defaultHook func(context.Context, string) error
+	hooks       []func(context.Context, string) error
+	history     []EvictionUploadStoreDeleteFuncCall
+	mutex       sync.Mutex
+}
+
+// Delete delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockEvictionUploadStore) Delete(v0 context.Context, v1 string) error {
+	r0 := m.DeleteFunc.nextHook()(v0, v1)
+	m.DeleteFunc.appendCall(EvictionUploadStoreDeleteFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Delete method of the
+// parent MockEvictionUploadStore instance is invoked and the hook queue is
+// empty.
+func (f *EvictionUploadStoreDeleteFunc) SetDefaultHook(hook func(context.Context, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Delete method of the parent MockEvictionUploadStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *EvictionUploadStoreDeleteFunc) PushHook(hook func(context.Context, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *EvictionUploadStoreDeleteFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *EvictionUploadStoreDeleteFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string) error {
+		return r0
+	})
+}
+
+func (f *EvictionUploadStoreDeleteFunc) nextHook() func(context.Context, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EvictionUploadStoreDeleteFunc) appendCall(r0 EvictionUploadStoreDeleteFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EvictionUploadStoreDeleteFuncCall objects
+// describing the invocations of this function.
+func (f *EvictionUploadStoreDeleteFunc) History() []EvictionUploadStoreDeleteFuncCall {
+	f.mutex.Lock()
+	history := make([]EvictionUploadStoreDeleteFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EvictionUploadStoreDeleteFuncCall is an object that describes an
+// invocation of method Delete on an instance of MockEvictionUploadStore.
+type EvictionUploadStoreDeleteFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EvictionUploadStoreDeleteFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EvictionUploadStoreDeleteFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// EvictionUploadStoreGetFunc describes the behavior when the Get method of
+// the parent MockEvictionUploadStore instance is invoked.
+type EvictionUploadStoreGetFunc struct {
+	defaultHook func(context.Context, string) (io.ReadCloser, error)
+	hooks       []func(context.Context, string) (io.ReadCloser, error)
+	history     []EvictionUploadStoreGetFuncCall
+	mutex       sync.Mutex
+}
+
+// Get delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockEvictionUploadStore) Get(v0 context.Context, v1 string) (io.ReadCloser, error) {
+	r0, r1 := m.GetFunc.nextHook()(v0, v1)
+	m.GetFunc.appendCall(EvictionUploadStoreGetFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Get method of the
+// parent MockEvictionUploadStore instance is invoked and the hook queue is
+// empty.
+func (f *EvictionUploadStoreGetFunc) SetDefaultHook(hook func(context.Context, string) (io.ReadCloser, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Get method of the parent MockEvictionUploadStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *EvictionUploadStoreGetFunc) PushHook(hook func(context.Context, string) (io.ReadCloser, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *EvictionUploadStoreGetFunc) SetDefaultReturn(r0 io.ReadCloser, r1 error) {
+	f.SetDefaultHook(func(context.Context, string) (io.ReadCloser, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *EvictionUploadStoreGetFunc) PushReturn(r0 io.ReadCloser, r1 error) {
+	f.PushHook(func(context.Context, string) (io.ReadCloser, error) {
+		return r0, r1
+	})
+}
+
+func (f *EvictionUploadStoreGetFunc) nextHook() func(context.Context, string) (io.ReadCloser, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *EvictionUploadStoreGetFunc) appendCall(r0 EvictionUploadStoreGetFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of EvictionUploadStoreGetFuncCall objects
+// describing the invocations of this function.
+func (f *EvictionUploadStoreGetFunc) History() []EvictionUploadStoreGetFuncCall {
+	f.mutex.Lock()
+	history := make([]EvictionUploadStoreGetFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// EvictionUploadStoreGetFuncCall is an object that describes an invocation
+// of method Get on an instance of MockEvictionUploadStore.
+type EvictionUploadStoreGetFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 io.ReadCloser
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c EvictionUploadStoreGetFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c EvictionUploadStoreGetFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
+// MockGitserverClient is a mock implementation of the GitserverClient
+// interface (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers)
+// used for unit testing.
+type MockGitserverClient struct {
+	// CommitExistsFunc is an instance of a mock function object controlling
+	// the behavior of the method CommitExists.
+	CommitExistsFunc *GitserverClientCommitExistsFunc
+	// CommitGraphFunc is an instance of a mock function object controlling
+	// the behavior of the method CommitGraph.
+	CommitGraphFunc *GitserverClientCommitGraphFunc
+}
+
+// NewMockGitserverClient creates a new mock of the GitserverClient
+// interface. All methods return zero values for all results, unless
+// overwritten.
+func NewMockGitserverClient() *MockGitserverClient {
+	return &MockGitserverClient{
+		CommitExistsFunc: &GitserverClientCommitExistsFunc{
+			defaultHook: func(context.Context, int, string) (bool, error) {
+				return false, nil
+			},
+		},
+		CommitGraphFunc: &GitserverClientCommitGraphFunc{
+			defaultHook: func(context.Context, int, gitserver.CommitGraphOptions) (*gitserver.CommitGraph, error) {
+				return nil, nil
+			},
+		},
+	}
+}
+
+// NewMockGitserverClientFrom creates a new mock of the MockGitserverClient
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockGitserverClientFrom(i GitserverClient) *MockGitserverClient {
+	return &MockGitserverClient{
+		CommitExistsFunc: &GitserverClientCommitExistsFunc{
+			defaultHook: i.CommitExists,
+		},
+		CommitGraphFunc: &GitserverClientCommitGraphFunc{
 			defaultHook: i.CommitGraph,
 		},
 	}
@@ -4774,7 +6193,7 @@ func NewMockLSIFStore() *MockLSIFStore {
 			},
 		},
 		DiagnosticsFunc: &LSIFStoreDiagnosticsFunc{
-			defaultHook: func(context.Context, int, string, int, int) ([]lsifstore.Diagnostic, int, error) {
+			defaultHook: func(context.Context, int, string, []int, int, int) ([]lsifstore.Diagnostic, int, error) {
 				return nil, 0, nil
 			},
 		},
@@ -5140,24 +6559,24 @@ func (c LSIFStoreDefinitionsFuncCall) Results() []interface{} {
 // LSIFStoreDiagnosticsFunc describes the behavior when the Diagnostics
 // method of the parent MockLSIFStore instance is invoked.
 type LSIFStoreDiagnosticsFunc struct {
-	defaultHook func(context.Context, int, string, int, int) ([]lsifstore.Diagnostic, int, error)
-	hooks       []func(context.Context, int, string, int, int) ([]lsifstore.Diagnostic, int, error)
+	defaultHook func(context.Context, int, string, []int, int, int) ([]lsifstore.Diagnostic, int, error)
+	hooks       []func(context.Context, int, string, []int, int, int) ([]lsifstore.Diagnostic, int, error)
 	history     []LSIFStoreDiagnosticsFuncCall
 	mutex       sync.Mutex
 }
 
 // Diagnostics delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockLSIFStore) Diagnostics(v0 context.Context, v1 int, v2 string, v3 int, v4 int) ([]lsifstore.Diagnostic, int, error) {
-	r0, r1, r2 := m.DiagnosticsFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.DiagnosticsFunc.appendCall(LSIFStoreDiagnosticsFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+func (m *MockLSIFStore) Diagnostics(v0 context.Context, v1 int, v2 string, v3 []int, v4 int, v5 int) ([]lsifstore.Diagnostic, int, error) {
+	r0, r1, r2 := m.DiagnosticsFunc.nextHook()(v0, v1, v2, v3, v4, v5)
+	m.DiagnosticsFunc.appendCall(LSIFStoreDiagnosticsFuncCall{v0, v1, v2, v3, v4, v5, r0, r1, r2})
 	return r0, r1, r2
 }
 
 // SetDefaultHook sets function that is called when the Diagnostics method
 // of the parent MockLSIFStore instance is invoked and the hook queue is
 // empty.
-func (f *LSIFStoreDiagnosticsFunc) SetDefaultHook(hook func(context.Context, int, string, int, int) ([]lsifstore.Diagnostic, int, error)) {
+func (f *LSIFStoreDiagnosticsFunc) SetDefaultHook(hook func(context.Context, int, string, []int, int, int) ([]lsifstore.Diagnostic, int, error)) {
 	f.defaultHook = hook
 }
 
@@ -5165,7 +6584,7 @@ func (f *LSIFStoreDiagnosticsFunc) SetDefaultHook(hook func(context.Context, int
 // Diagnostics method of the parent MockLSIFStore instance invokes the hook
 // at the front of the queue and discards it. After the queue is empty, the
 // default hook function is invoked for any future action.
-func (f *LSIFStoreDiagnosticsFunc) PushHook(hook func(context.Context, int, string, int, int) ([]lsifstore.Diagnostic, int, error)) {
+func (f *LSIFStoreDiagnosticsFunc) PushHook(hook func(context.Context, int, string, []int, int, int) ([]lsifstore.Diagnostic, int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -5174,7 +6593,7 @@ func (f *LSIFStoreDiagnosticsFunc) PushHook(hook func(context.Context, int, stri
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
 func (f *LSIFStoreDiagnosticsFunc) SetDefaultReturn(r0 []lsifstore.Diagnostic, r1 int, r2 error) {
-	f.SetDefaultHook(func(context.Context, int, string, int, int) ([]lsifstore.Diagnostic, int, error) {
+	f.SetDefaultHook(func(context.Context, int, string, []int, int, int) ([]lsifstore.Diagnostic, int, error) {
 		return r0, r1, r2
 	})
 }
@@ -5182,12 +6601,12 @@ func (f *LSIFStoreDiagnosticsFunc) SetDefaultReturn(r0 []lsifstore.Diagnostic, r
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
 func (f *LSIFStoreDiagnosticsFunc) PushReturn(r0 []lsifstore.Diagnostic, r1 int, r2 error) {
-	f.PushHook(func(context.Context, int, string, int, int) ([]lsifstore.Diagnostic, int, error) {
+	f.PushHook(func(context.Context, int, string, []int, int, int) ([]lsifstore.Diagnostic, int, error) {
 		return r0, r1, r2
 	})
 }
 
-func (f *LSIFStoreDiagnosticsFunc) nextHook() func(context.Context, int, string, int, int) ([]lsifstore.Diagnostic, int, error) {
+func (f *LSIFStoreDiagnosticsFunc) nextHook() func(context.Context, int, string, []int, int, int) ([]lsifstore.Diagnostic, int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -5231,10 +6650,13 @@ type LSIFStoreDiagnosticsFuncCall struct {
 	Arg2 string
 	// Arg3 is the value of the 4th argument passed to this method
 	// invocation.
-	Arg3 int
+	Arg3 []int
 	// Arg4 is the value of the 5th argument passed to this method
 	// invocation.
 	Arg4 int
+	// Arg5 is the value of the 6th argument passed to this method
+	// invocation.
+	Arg5 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 []lsifstore.Diagnostic
@@ -5249,7 +6671,7 @@ type LSIFStoreDiagnosticsFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c LSIFStoreDiagnosticsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3, c.Arg4, c.Arg5}
 }
 
 // Results returns an interface slice containing the results of this
@@ -58,7 +58,7 @@ func NewMockQueryResolver() *MockQueryResolver {
 			},
 		},
 		DiagnosticsFunc: &QueryResolverDiagnosticsFunc{
-			defaultHook: func(context.Context, int) ([]resolvers.AdjustedDiagnostic, int, error) {
+			defaultHook: func(context.Context, []int, int, int) ([]resolvers.AdjustedDiagnostic, int, error) {
 				return nil, 0, nil
 			},
 		},
@@ -258,24 +258,24 @@ func (c QueryResolverDefinitionsFuncCall) Results() []interface{} {
 // QueryResolverDiagnosticsFunc describes the behavior when the Diagnostics
 // method of the parent MockQueryResolver instance is invoked.
 type QueryResolverDiagnosticsFunc struct {
-	defaultHook func(context.Context, int) ([]resolvers.AdjustedDiagnostic, int, error)
-	hooks       []func(context.Context, int) ([]resolvers.AdjustedDiagnostic, int, error)
+	defaultHook func(context.Context, []int, int, int) ([]resolvers.AdjustedDiagnostic, int, error)
+	hooks       []func(context.Context, []int, int, int) ([]resolvers.AdjustedDiagnostic, int, error)
 	history     []QueryResolverDiagnosticsFuncCall
 	mutex       sync.Mutex
 }
 
 // Diagnostics delegates to the next hook function in the queue and stores
 // the parameter and result values of this invocation.
-func (m *MockQueryResolver) Diagnostics(v0 context.Context, v1 int) ([]resolvers.AdjustedDiagnostic, int, error) {
-	r0, r1, r2 := m.DiagnosticsFunc.nextHook()(v0, v1)
-	m.DiagnosticsFunc.appendCall(QueryResolverDiagnosticsFuncCall{v0, v1, r0, r1, r2})
+func (m *MockQueryResolver) Diagnostics(v0 context.Context, v1 []int, v2 int, v3 int) ([]resolvers.AdjustedDiagnostic, int, error) {
+	r0, r1, r2 := m.DiagnosticsFunc.nextHook()(v0, v1, v2, v3)
+	m.DiagnosticsFunc.appendCall(QueryResolverDiagnosticsFuncCall{v0, v1, v2, v3, r0, r1, r2})
 	return r0, r1, r2
 }
 
 // SetDefaultHook sets function that is called when the Diagnostics method
 // of the parent MockQueryResolver instance is invoked and the hook queue is
 // empty.
-func (f *QueryResolverDiagnosticsFunc) SetDefaultHook(hook func(context.Context, int) ([]resolvers.AdjustedDiagnostic, int, error)) {
+func (f *QueryResolverDiagnosticsFunc) SetDefaultHook(hook func(context.Context, []int, int, int) ([]resolvers.AdjustedDiagnostic, int, error)) {
 	f.defaultHook = hook
 }
 
@@ -283,7 +283,7 @@ func (f *QueryResolverDiagnosticsFunc) SetDefaultHook(hook func(context.Context,
 // Diagnostics method of the parent MockQueryResolver instance invokes the
 // hook at the front of the queue and discards it. After the queue is empty,
 // the default hook function is invoked for any future action.
-func (f *QueryResolverDiagnosticsFunc) PushHook(hook func(context.Context, int) ([]resolvers.AdjustedDiagnostic, int, error)) {
+func (f *QueryResolverDiagnosticsFunc) PushHook(hook func(context.Context, []int, int, int) ([]resolvers.AdjustedDiagnostic, int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -292,7 +292,7 @@ func (f *QueryResolverDiagnosticsFunc) PushHook(hook func(context.Context, int)
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
 func (f *QueryResolverDiagnosticsFunc) SetDefaultReturn(r0 []resolvers.AdjustedDiagnostic, r1 int, r2 error) {
-	f.SetDefaultHook(func(context.Context, int) ([]resolvers.AdjustedDiagnostic, int, error) {
+	f.SetDefaultHook(func(context.Context, []int, int, int) ([]resolvers.AdjustedDiagnostic, int, error) {
 		return r0, r1, r2
 	})
 }
@@ -300,12 +300,12 @@ func (f *QueryResolverDiagnosticsFunc) SetDefaultReturn(r0 []resolvers.AdjustedD
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
 func (f *QueryResolverDiagnosticsFunc) PushReturn(r0 []resolvers.AdjustedDiagnostic, r1 int, r2 error) {
-	f.PushHook(func(context.Context, int) ([]resolvers.AdjustedDiagnostic, int, error) {
+	f.PushHook(func(context.Context, []int, int, int) ([]resolvers.AdjustedDiagnostic, int, error) {
 		return r0, r1, r2
 	})
 }
 
-func (f *QueryResolverDiagnosticsFunc) nextHook() func(context.Context, int) ([]resolvers.AdjustedDiagnostic, int, error) {
+func (f *QueryResolverDiagnosticsFunc) nextHook() func(context.Context, []int, int, int) ([]resolvers.AdjustedDiagnostic, int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -343,7 +343,13 @@ type QueryResolverDiagnosticsFuncCall struct {
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 int
+	Arg1 []int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 int
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 []resolvers.AdjustedDiagnostic
@@ -358,7 +364,7 @@ type QueryResolverDiagnosticsFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c QueryResolverDiagnosticsFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
 }
 
 // Results returns an interface slice containing the results of this
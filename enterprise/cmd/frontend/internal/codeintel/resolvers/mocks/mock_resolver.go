@@ -19,12 +19,18 @@ type MockResolver struct {
 	// CommitGraphFunc is an instance of a mock function object controlling
 	// the behavior of the method CommitGraph.
 	CommitGraphFunc *ResolverCommitGraphFunc
+	// CreateUploadTokenFunc is an instance of a mock function object
+	// controlling the behavior of the method CreateUploadToken.
+	CreateUploadTokenFunc *ResolverCreateUploadTokenFunc
 	// DeleteIndexByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteIndexByID.
 	DeleteIndexByIDFunc *ResolverDeleteIndexByIDFunc
 	// DeleteUploadByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method DeleteUploadByID.
 	DeleteUploadByIDFunc *ResolverDeleteUploadByIDFunc
+	// DeleteUploadTokenFunc is an instance of a mock function object
+	// controlling the behavior of the method DeleteUploadToken.
+	DeleteUploadTokenFunc *ResolverDeleteUploadTokenFunc
 	// GetIndexByIDFunc is an instance of a mock function object controlling
 	// the behavior of the method GetIndexByID.
 	GetIndexByIDFunc *ResolverGetIndexByIDFunc
@@ -34,6 +40,12 @@ type MockResolver struct {
 	// GetUploadByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadByID.
 	GetUploadByIDFunc *ResolverGetUploadByIDFunc
+	// GetUploadProcessingTraceFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUploadProcessingTrace.
+	GetUploadProcessingTraceFunc *ResolverGetUploadProcessingTraceFunc
+	// GetUploadTokensFunc is an instance of a mock function object
+	// controlling the behavior of the method GetUploadTokens.
+	GetUploadTokensFunc *ResolverGetUploadTokensFunc
 	// GetUploadsByIDsFunc is an instance of a mock function object
 	// controlling the behavior of the method GetUploadsByIDs.
 	GetUploadsByIDsFunc *ResolverGetUploadsByIDsFunc
@@ -67,6 +79,11 @@ func NewMockResolver() *MockResolver {
 				return nil, nil
 			},
 		},
+		CreateUploadTokenFunc: &ResolverCreateUploadTokenFunc{
+			defaultHook: func(context.Context, int, int, string) (int, string, error) {
+				return 0, "", nil
+			},
+		},
 		DeleteIndexByIDFunc: &ResolverDeleteIndexByIDFunc{
 			defaultHook: func(context.Context, int) error {
 				return nil
@@ -77,6 +94,11 @@ func NewMockResolver() *MockResolver {
 				return nil
 			},
 		},
+		DeleteUploadTokenFunc: &ResolverDeleteUploadTokenFunc{
+			defaultHook: func(context.Context, int) error {
+				return nil
+			},
+		},
 		GetIndexByIDFunc: &ResolverGetIndexByIDFunc{
 			defaultHook: func(context.Context, int) (dbstore.Index, bool, error) {
 				return dbstore.Index{}, false, nil
@@ -92,6 +114,16 @@ func NewMockResolver() *MockResolver {
 				return dbstore.Upload{}, false, nil
 			},
 		},
+		GetUploadProcessingTraceFunc: &ResolverGetUploadProcessingTraceFunc{
+			defaultHook: func(context.Context, int) (dbstore.UploadProcessingTrace, bool, error) {
+				return dbstore.UploadProcessingTrace{}, false, nil
+			},
+		},
+		GetUploadTokensFunc: &ResolverGetUploadTokensFunc{
+			defaultHook: func(context.Context, int) ([]dbstore.UploadToken, error) {
+				return nil, nil
+			},
+		},
 		GetUploadsByIDsFunc: &ResolverGetUploadsByIDsFunc{
 			defaultHook: func(context.Context, ...int) ([]dbstore.Upload, error) {
 				return nil, nil
@@ -137,12 +169,18 @@ func NewMockResolverFrom(i resolvers.Resolver) *MockResolver {
 		CommitGraphFunc: &ResolverCommitGraphFunc{
 			defaultHook: i.CommitGraph,
 		},
+		CreateUploadTokenFunc: &ResolverCreateUploadTokenFunc{
+			defaultHook: i.CreateUploadToken,
+		},
 		DeleteIndexByIDFunc: &ResolverDeleteIndexByIDFunc{
 			defaultHook: i.DeleteIndexByID,
 		},
 		DeleteUploadByIDFunc: &ResolverDeleteUploadByIDFunc{
 			defaultHook: i.DeleteUploadByID,
 		},
+		DeleteUploadTokenFunc: &ResolverDeleteUploadTokenFunc{
+			defaultHook: i.DeleteUploadToken,
+		},
 		GetIndexByIDFunc: &ResolverGetIndexByIDFunc{
 			defaultHook: i.GetIndexByID,
 		},
@@ -152,6 +190,12 @@ func NewMockResolverFrom(i resolvers.Resolver) *MockResolver {
 		GetUploadByIDFunc: &ResolverGetUploadByIDFunc{
 			defaultHook: i.GetUploadByID,
 		},
+		GetUploadProcessingTraceFunc: &ResolverGetUploadProcessingTraceFunc{
+			defaultHook: i.GetUploadProcessingTrace,
+		},
+		GetUploadTokensFunc: &ResolverGetUploadTokensFunc{
+			defaultHook: i.GetUploadTokens,
+		},
 		GetUploadsByIDsFunc: &ResolverGetUploadsByIDsFunc{
 			defaultHook: i.GetUploadsByIDs,
 		},
@@ -285,6 +329,124 @@ func (c ResolverCommitGraphFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// ResolverCreateUploadTokenFunc describes the behavior when the
+// CreateUploadToken method of the parent MockResolver instance is invoked.
+type ResolverCreateUploadTokenFunc struct {
+	defaultHook func(context.Context, int, int, string) (int, string, error)
+	hooks       []func(context.Context, int, int, string) (int, string, error)
+	history     []ResolverCreateUploadTokenFuncCall
+	mutex       sync.Mutex
+}
+
+// CreateUploadToken delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockResolver) CreateUploadToken(v0 context.Context, v1 int, v2 int, v3 string) (int, string, error) {
+	r0, r1, r2 := m.CreateUploadTokenFunc.nextHook()(v0, v1, v2, v3)
+	m.CreateUploadTokenFunc.appendCall(ResolverCreateUploadTokenFuncCall{v0, v1, v2, v3, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the CreateUploadToken
+// method of the parent MockResolver instance is invoked and the hook queue
+// is empty.
+func (f *ResolverCreateUploadTokenFunc) SetDefaultHook(hook func(context.Context, int, int, string) (int, string, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// CreateUploadToken method of the parent MockResolver instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ResolverCreateUploadTokenFunc) PushHook(hook func(context.Context, int, int, string) (int, string, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverCreateUploadTokenFunc) SetDefaultReturn(r0 int, r1 string, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, int, string) (int, string, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverCreateUploadTokenFunc) PushReturn(r0 int, r1 string, r2 error) {
+	f.PushHook(func(context.Context, int, int, string) (int, string, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *ResolverCreateUploadTokenFunc) nextHook() func(context.Context, int, int, string) (int, string, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverCreateUploadTokenFunc) appendCall(r0 ResolverCreateUploadTokenFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverCreateUploadTokenFuncCall objects
+// describing the invocations of this function.
+func (f *ResolverCreateUploadTokenFunc) History() []ResolverCreateUploadTokenFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverCreateUploadTokenFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverCreateUploadTokenFuncCall is an object that describes an
+// invocation of method CreateUploadToken on an instance of MockResolver.
+type ResolverCreateUploadTokenFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 string
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverCreateUploadTokenFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverCreateUploadTokenFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
 // ResolverDeleteIndexByIDFunc describes the behavior when the
 // DeleteIndexByID method of the parent MockResolver instance is invoked.
 type ResolverDeleteIndexByIDFunc struct {
@@ -497,6 +659,112 @@ func (c ResolverDeleteUploadByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// ResolverDeleteUploadTokenFunc describes the behavior when the
+// DeleteUploadToken method of the parent MockResolver instance is invoked.
+type ResolverDeleteUploadTokenFunc struct {
+	defaultHook func(context.Context, int) error
+	hooks       []func(context.Context, int) error
+	history     []ResolverDeleteUploadTokenFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteUploadToken delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockResolver) DeleteUploadToken(v0 context.Context, v1 int) error {
+	r0 := m.DeleteUploadTokenFunc.nextHook()(v0, v1)
+	m.DeleteUploadTokenFunc.appendCall(ResolverDeleteUploadTokenFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteUploadToken
+// method of the parent MockResolver instance is invoked and the hook queue
+// is empty.
+func (f *ResolverDeleteUploadTokenFunc) SetDefaultHook(hook func(context.Context, int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteUploadToken method of the parent MockResolver instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ResolverDeleteUploadTokenFunc) PushHook(hook func(context.Context, int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverDeleteUploadTokenFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverDeleteUploadTokenFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+func (f *ResolverDeleteUploadTokenFunc) nextHook() func(context.Context, int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverDeleteUploadTokenFunc) appendCall(r0 ResolverDeleteUploadTokenFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverDeleteUploadTokenFuncCall objects
+// describing the invocations of this function.
+func (f *ResolverDeleteUploadTokenFunc) History() []ResolverDeleteUploadTokenFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverDeleteUploadTokenFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverDeleteUploadTokenFuncCall is an object that describes an
+// invocation of method DeleteUploadToken on an instance of MockResolver.
+type ResolverDeleteUploadTokenFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverDeleteUploadTokenFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverDeleteUploadTokenFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // ResolverGetIndexByIDFunc describes the behavior when the GetIndexByID
 // method of the parent MockResolver instance is invoked.
 type ResolverGetIndexByIDFunc struct {
@@ -837,6 +1105,230 @@ func (c ResolverGetUploadByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1, c.Result2}
 }
 
+// ResolverGetUploadProcessingTraceFunc describes the behavior when the
+// GetUploadProcessingTrace method of the parent MockResolver instance is
+// invoked.
+type ResolverGetUploadProcessingTraceFunc struct {
+	defaultHook func(context.Context, int) (dbstore.UploadProcessingTrace, bool, error)
+	hooks       []func(context.Context, int) (dbstore.UploadProcessingTrace, bool, error)
+	history     []ResolverGetUploadProcessingTraceFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadProcessingTrace delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockResolver) GetUploadProcessingTrace(v0 context.Context, v1 int) (dbstore.UploadProcessingTrace, bool, error) {
+	r0, r1, r2 := m.GetUploadProcessingTraceFunc.nextHook()(v0, v1)
+	m.GetUploadProcessingTraceFunc.appendCall(ResolverGetUploadProcessingTraceFuncCall{v0, v1, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// GetUploadProcessingTrace method of the parent MockResolver instance is
+// invoked and the hook queue is empty.
+func (f *ResolverGetUploadProcessingTraceFunc) SetDefaultHook(hook func(context.Context, int) (dbstore.UploadProcessingTrace, bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploadProcessingTrace method of the parent MockResolver instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *ResolverGetUploadProcessingTraceFunc) PushHook(hook func(context.Context, int) (dbstore.UploadProcessingTrace, bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverGetUploadProcessingTraceFunc) SetDefaultReturn(r0 dbstore.UploadProcessingTrace, r1 bool, r2 error) {
+	f.SetDefaultHook(func(context.Context, int) (dbstore.UploadProcessingTrace, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverGetUploadProcessingTraceFunc) PushReturn(r0 dbstore.UploadProcessingTrace, r1 bool, r2 error) {
+	f.PushHook(func(context.Context, int) (dbstore.UploadProcessingTrace, bool, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *ResolverGetUploadProcessingTraceFunc) nextHook() func(context.Context, int) (dbstore.UploadProcessingTrace, bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverGetUploadProcessingTraceFunc) appendCall(r0 ResolverGetUploadProcessingTraceFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverGetUploadProcessingTraceFuncCall
+// objects describing the invocations of this function.
+func (f *ResolverGetUploadProcessingTraceFunc) History() []ResolverGetUploadProcessingTraceFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverGetUploadProcessingTraceFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverGetUploadProcessingTraceFuncCall is an object that describes an
+// invocation of method GetUploadProcessingTrace on an instance of
+// MockResolver.
+type ResolverGetUploadProcessingTraceFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 dbstore.UploadProcessingTrace
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 bool
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverGetUploadProcessingTraceFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverGetUploadProcessingTraceFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1, c.Result2}
+}
+
+// ResolverGetUploadTokensFunc describes the behavior when the
+// GetUploadTokens method of the parent MockResolver instance is invoked.
+type ResolverGetUploadTokensFunc struct {
+	defaultHook func(context.Context, int) ([]dbstore.UploadToken, error)
+	hooks       []func(context.Context, int) ([]dbstore.UploadToken, error)
+	history     []ResolverGetUploadTokensFuncCall
+	mutex       sync.Mutex
+}
+
+// GetUploadTokens delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockResolver) GetUploadTokens(v0 context.Context, v1 int) ([]dbstore.UploadToken, error) {
+	r0, r1 := m.GetUploadTokensFunc.nextHook()(v0, v1)
+	m.GetUploadTokensFunc.appendCall(ResolverGetUploadTokensFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the GetUploadTokens
+// method of the parent MockResolver instance is invoked and the hook queue
+// is empty.
+func (f *ResolverGetUploadTokensFunc) SetDefaultHook(hook func(context.Context, int) ([]dbstore.UploadToken, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// GetUploadTokens method of the parent MockResolver instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *ResolverGetUploadTokensFunc) PushHook(hook func(context.Context, int) ([]dbstore.UploadToken, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *ResolverGetUploadTokensFunc) SetDefaultReturn(r0 []dbstore.UploadToken, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]dbstore.UploadToken, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *ResolverGetUploadTokensFunc) PushReturn(r0 []dbstore.UploadToken, r1 error) {
+	f.PushHook(func(context.Context, int) ([]dbstore.UploadToken, error) {
+		return r0, r1
+	})
+}
+
+func (f *ResolverGetUploadTokensFunc) nextHook() func(context.Context, int) ([]dbstore.UploadToken, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *ResolverGetUploadTokensFunc) appendCall(r0 ResolverGetUploadTokensFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of ResolverGetUploadTokensFuncCall objects
+// describing the invocations of this function.
+func (f *ResolverGetUploadTokensFunc) History() []ResolverGetUploadTokensFuncCall {
+	f.mutex.Lock()
+	history := make([]ResolverGetUploadTokensFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// ResolverGetUploadTokensFuncCall is an object that describes an invocation
+// of method GetUploadTokens on an instance of MockResolver.
+type ResolverGetUploadTokensFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []dbstore.UploadToken
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c ResolverGetUploadTokensFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c ResolverGetUploadTokensFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // ResolverGetUploadsByIDsFunc describes the behavior when the
 // GetUploadsByIDs method of the parent MockResolver instance is invoked.
 type ResolverGetUploadsByIDsFunc struct {
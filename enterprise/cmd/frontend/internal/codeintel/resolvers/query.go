@@ -8,6 +8,23 @@ import (
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
 )
 
+// Precision describes where a code intelligence result comes from, so that clients can tell results
+// of different quality apart. The values match the CodeIntelPrecision GraphQL enum.
+type Precision string
+
+const (
+	// PrecisionPrecise denotes a result from an upload for the commit of the result.
+	PrecisionPrecise Precision = "PRECISE"
+
+	// PrecisionFuzzy denotes a result from an upload for another commit, whose range was adjusted
+	// to the requested commit with the diff between the commits.
+	PrecisionFuzzy Precision = "FUZZY"
+
+	// PrecisionSearchBased denotes a result from a text search. Search-based results are not
+	// produced by these resolvers, but by the clients falling back to search-based code intel.
+	PrecisionSearchBased Precision = "SEARCH_BASED"
+)
+
 // AdjustedLocation is a path and range pair from within a particular upload. The adjusted commit
 // denotes the target commit for which the location was adjusted (the originally requested commit).
 type AdjustedLocation struct {
@@ -15,6 +32,7 @@ type AdjustedLocation struct {
 	Path           string
 	AdjustedCommit string
 	AdjustedRange  lsifstore.Range
+	Precision      Precision
 }
 
 // AdjustedDiagnostic is a diagnostic from within a particular upload. The adjusted commit denotes
@@ -53,7 +71,7 @@ type QueryResolver interface {
 	Definitions(ctx context.Context, line, character int) ([]AdjustedLocation, error)
 	References(ctx context.Context, line, character, limit int, rawCursor string) ([]AdjustedLocation, string, error)
 	Hover(ctx context.Context, line, character int) (string, lsifstore.Range, bool, error)
-	Diagnostics(ctx context.Context, limit int) ([]AdjustedDiagnostic, int, error)
+	Diagnostics(ctx context.Context, severities []int, limit, offset int) ([]AdjustedDiagnostic, int, error)
 	DocumentationPage(ctx context.Context, pathID string) (*semantic.DocumentationPageData, error)
 	DocumentationPathInfo(ctx context.Context, pathID string) (*semantic.DocumentationPathInfoData, error)
 	Documentation(ctx context.Context, line int, character int) ([]*Documentation, error)
@@ -14,8 +14,10 @@ import (
 
 const slowDiagnosticsRequestThreshold = time.Second
 
-// Diagnostics returns the diagnostics for documents with the given path prefix.
-func (r *queryResolver) Diagnostics(ctx context.Context, limit int) (adjustedDiagnostics []AdjustedDiagnostic, _ int, err error) {
+// Diagnostics returns the diagnostics for documents with the given path prefix. If any severities
+// are given, only diagnostics with one of those severities are returned. The offset skips that many
+// diagnostics of the result set formed by the diagnostics of all visible uploads, in order.
+func (r *queryResolver) Diagnostics(ctx context.Context, severities []int, limit, offset int) (adjustedDiagnostics []AdjustedDiagnostic, _ int, err error) {
 	ctx, traceLog, endObservation := observeResolver(ctx, &err, "Diagnostics", r.operations.diagnostics, slowDiagnosticsRequestThreshold, observation.Args{
 		LogFields: []log.Field{
 			log.Int("repositoryID", r.repositoryID),
@@ -23,7 +25,9 @@ func (r *queryResolver) Diagnostics(ctx context.Context, limit int) (adjustedDia
 			log.String("path", r.path),
 			log.Int("numUploads", len(r.uploads)),
 			log.String("uploads", uploadIDsToString(r.uploads)),
+			log.String("severities", intsToString(severities)),
 			log.Int("limit", limit),
+			log.Int("offset", offset),
 		},
 	})
 	defer endObservation()
@@ -38,12 +42,20 @@ func (r *queryResolver) Diagnostics(ctx context.Context, limit int) (adjustedDia
 	for i := range adjustedUploads {
 		traceLog(log.Int("uploadID", adjustedUploads[i].Upload.ID))
 
+		// The offset applies to the concatenated result sets of all uploads, so the
+		// diagnostics of the preceding uploads count against it.
+		uploadOffset := offset - totalCount
+		if uploadOffset < 0 {
+			uploadOffset = 0
+		}
+
 		diagnostics, count, err := r.lsifStore.Diagnostics(
 			ctx,
 			adjustedUploads[i].Upload.ID,
 			adjustedUploads[i].AdjustedPathInBundle,
+			severities,
 			limit-len(adjustedDiagnostics),
-			0,
+			uploadOffset,
 		)
 		if err != nil {
 			return nil, 0, errors.Wrap(err, "lsifStore.Diagnostics")
@@ -47,7 +47,7 @@ func TestDiagnostics(t *testing.T) {
 		uploads,
 		newOperations(&observation.TestContext),
 	)
-	adjustedDiagnostics, totalCount, err := resolver.Diagnostics(context.Background(), 5)
+	adjustedDiagnostics, totalCount, err := resolver.Diagnostics(context.Background(), nil, 5, 0)
 	if err != nil {
 		t.Fatalf("unexpected error querying diagnostics: %s", err)
 	}
@@ -69,9 +69,62 @@ func TestDiagnostics(t *testing.T) {
 
 	var limits []int
 	for _, call := range mockLSIFStore.DiagnosticsFunc.History() {
-		limits = append(limits, call.Arg3)
+		limits = append(limits, call.Arg4)
 	}
 	if diff := cmp.Diff([]int{5, 4, 1, 0}, limits); diff != "" {
 		t.Errorf("unexpected limits (-want +got):\n%s", diff)
 	}
 }
+
+func TestDiagnosticsOffset(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+	mockPositionAdjuster := noopPositionAdjuster()
+
+	diagnostics := []lsifstore.Diagnostic{
+		{DiagnosticData: semantic.DiagnosticData{Code: "c3", Severity: 1}},
+		{DiagnosticData: semantic.DiagnosticData{Code: "c4", Severity: 1}},
+	}
+
+	mockLSIFStore.DiagnosticsFunc.PushReturn(nil, 2, nil)
+	mockLSIFStore.DiagnosticsFunc.PushReturn(diagnostics, 3, nil)
+
+	uploads := []dbstore.Dump{
+		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
+		{ID: 51, Commit: "deadbeef", Root: "sub2/"},
+	}
+	resolver := newQueryResolver(
+		mockDBStore,
+		mockLSIFStore,
+		newCachedCommitChecker(mockGitserverClient),
+		mockPositionAdjuster,
+		42,
+		"deadbeef",
+		"s1/main.go",
+		uploads,
+		newOperations(&observation.TestContext),
+	)
+	adjustedDiagnostics, totalCount, err := resolver.Diagnostics(context.Background(), []int{1}, 5, 3)
+	if err != nil {
+		t.Fatalf("unexpected error querying diagnostics: %s", err)
+	}
+
+	if totalCount != 5 {
+		t.Errorf("unexpected count. want=%d have=%d", 5, totalCount)
+	}
+	if len(adjustedDiagnostics) != 2 {
+		t.Errorf("unexpected number of diagnostics. want=%d have=%d", 2, len(adjustedDiagnostics))
+	}
+
+	var offsets []int
+	for _, call := range mockLSIFStore.DiagnosticsFunc.History() {
+		if diff := cmp.Diff([]int{1}, call.Arg3); diff != "" {
+			t.Errorf("unexpected severities (-want +got):\n%s", diff)
+		}
+		offsets = append(offsets, call.Arg5)
+	}
+	if diff := cmp.Diff([]int{3, 1}, offsets); diff != "" {
+		t.Errorf("unexpected offsets (-want +got):\n%s", diff)
+	}
+}
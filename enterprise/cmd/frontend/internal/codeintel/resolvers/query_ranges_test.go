@@ -60,14 +60,14 @@ func TestRanges(t *testing.T) {
 		t.Fatalf("unexpected error querying ranges: %s", err)
 	}
 
-	adjustedLocation1 := AdjustedLocation{Dump: uploads[0], Path: "sub1/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1}
-	adjustedLocation2 := AdjustedLocation{Dump: uploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange2}
-	adjustedLocation3 := AdjustedLocation{Dump: uploads[1], Path: "sub2/c.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1}
-	adjustedLocation4 := AdjustedLocation{Dump: uploads[1], Path: "sub2/d.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange2}
-	adjustedLocation5 := AdjustedLocation{Dump: uploads[1], Path: "sub2/e.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1}
-	adjustedLocation6 := AdjustedLocation{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange2}
-	adjustedLocation7 := AdjustedLocation{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange3}
-	adjustedLocation8 := AdjustedLocation{Dump: uploads[2], Path: "sub3/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange4}
+	adjustedLocation1 := AdjustedLocation{Dump: uploads[0], Path: "sub1/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1, Precision: PrecisionPrecise}
+	adjustedLocation2 := AdjustedLocation{Dump: uploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange2, Precision: PrecisionPrecise}
+	adjustedLocation3 := AdjustedLocation{Dump: uploads[1], Path: "sub2/c.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1, Precision: PrecisionPrecise}
+	adjustedLocation4 := AdjustedLocation{Dump: uploads[1], Path: "sub2/d.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange2, Precision: PrecisionPrecise}
+	adjustedLocation5 := AdjustedLocation{Dump: uploads[1], Path: "sub2/e.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1, Precision: PrecisionPrecise}
+	adjustedLocation6 := AdjustedLocation{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange2, Precision: PrecisionPrecise}
+	adjustedLocation7 := AdjustedLocation{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange3, Precision: PrecisionPrecise}
+	adjustedLocation8 := AdjustedLocation{Dump: uploads[2], Path: "sub3/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange4, Precision: PrecisionPrecise}
 
 	expectedRanges := []AdjustedCodeIntelligenceRange{
 		{Range: testRange1, HoverText: "text1", Definitions: []AdjustedLocation{}, References: []AdjustedLocation{adjustedLocation1}},
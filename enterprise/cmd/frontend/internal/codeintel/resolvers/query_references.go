@@ -325,9 +325,13 @@ func (r *queryResolver) pageLocalReferences(ctx context.Context, adjustedUploads
 }
 
 // maximumIndexesPerMonikerSearch configures the maximum number of reference upload identifiers
-// that can be passed to a single moniker search query.
+// that are tested against the target monikers' bloom filters at once.
 const maximumIndexesPerMonikerSearch = 50
 
+// maximumConcurrentMonikerSearches configures the maximum number of uploads that are searched
+// for moniker references concurrently while filling a single page of remote references.
+const maximumConcurrentMonikerSearches = 4
+
 // pageRemoteReferences returns a slice of the (remote) result set denoted by the given cursor fulfilled by
 // performing a moniker search over a group of indexes. The given cursor will be adjusted to reflect the
 // offsets required to resolve the next page of results. If there are no more pages left in the result set,
@@ -354,8 +358,16 @@ func (r *queryResolver) pageRemoteReferences(ctx context.Context, adjustedUpload
 		}
 	}
 
+	// Search the next few uploads of the batch concurrently. Bounding the number of uploads searched
+	// at once keeps the cost of a page independent of the size of the batch, which can be large for
+	// popular symbols.
+	uploadIDs := cursor.BatchIDs
+	if len(uploadIDs) > maximumConcurrentMonikerSearches {
+		uploadIDs = uploadIDs[:maximumConcurrentMonikerSearches]
+	}
+
 	// Fetch the upload records we don't currently have hydrated and insert them into the map
-	monikerSearchUploads, err := r.uploadsByIDs(ctx, cursor.BatchIDs, uploadsByID)
+	monikerSearchUploads, err := r.uploadsByIDs(ctx, uploadIDs, uploadsByID)
 	if err != nil {
 		return nil, false, err
 	}
@@ -363,17 +375,39 @@ func (r *queryResolver) pageRemoteReferences(ctx context.Context, adjustedUpload
 		uploadsByID[monikerSearchUploads[i].ID] = monikerSearchUploads[i]
 	}
 
-	// Perform the moniker search
-	locations, totalCount, err := r.monikerLocations(ctx, monikerSearchUploads, orderedMonikers, "references", limit, cursor.RemoteOffset)
+	// Perform the moniker search. Only the first upload of the batch may have been partially
+	// searched by a previous page, so it's the only one searched from a non-zero offset.
+	results, err := r.monikerLocationsByUpload(ctx, uploadIDs, uploadsByID, orderedMonikers, "references", limit, cursor.RemoteUploadOffset)
 	if err != nil {
 		return nil, false, err
 	}
 
-	cursor.RemoteOffset += len(locations)
+	var locations []lsifstore.Location
+	batchIDs := cursor.BatchIDs
+	for i, result := range results {
+		offset := 0
+		if i == 0 {
+			offset = cursor.RemoteUploadOffset
+		}
 
-	if cursor.RemoteOffset >= totalCount {
-		// Require a new batch on next page
-		cursor.RemoteOffset = 0
+		n := len(result.locations)
+		if remaining := limit - len(locations); n > remaining {
+			n = remaining
+		}
+		locations = append(locations, result.locations[:n]...)
+
+		if offset+n < result.totalCount {
+			// Resume from this upload on the next page
+			cursor.BatchIDs = batchIDs[i:]
+			cursor.RemoteUploadOffset = offset + n
+			break
+		}
+
+		// Require a new upload (or a new batch) on the next page
+		cursor.BatchIDs = batchIDs[i+1:]
+		cursor.RemoteUploadOffset = 0
+	}
+	if len(cursor.BatchIDs) == 0 {
 		cursor.BatchIDs = nil
 	}
 
@@ -10,6 +10,10 @@ import (
 
 // referencesCursor stores (enough of) the state of a previous References request used to
 // calculate the offset into the result set to be returned by the current request.
+//
+// During the remote phase, BatchIDs holds the uploads of the current batch which have not
+// been searched completely, in search order, and RemoteUploadOffset is the number of locations
+// already returned from the first of them.
 type referencesCursor struct {
 	AdjustedUploads           []cursorAdjustedUpload          `json:"adjustedUploads"`
 	DefinitionUploadIDs       []int                           `json:"definitionUploadIDs"`
@@ -19,7 +23,7 @@ type referencesCursor struct {
 	LocalOffset               int                             `json:"localOffset"`
 	LocalBatchOffset          int                             `json:"localBatchOffset"`
 	BatchIDs                  []int                           `json:"batchIDs"`
-	RemoteOffset              int                             `json:"remoteOffset"`
+	RemoteUploadOffset        int                             `json:"remoteUploadOffset"`
 	RemoteBatchOffset         int                             `json:"remoteBatchOffset"`
 }
 
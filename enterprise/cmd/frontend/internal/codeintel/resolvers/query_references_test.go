@@ -2,6 +2,7 @@ package resolvers
 
 import (
 	"context"
+	"sort"
 	"testing"
 
 	"github.com/google/go-cmp/cmp"
@@ -56,11 +57,11 @@ func TestReferences(t *testing.T) {
 	}
 
 	expectedLocations := []AdjustedLocation{
-		{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1},
-		{Dump: uploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange2},
-		{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange3},
-		{Dump: uploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange4},
-		{Dump: uploads[1], Path: "sub2/c.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange5},
+		{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1, Precision: PrecisionPrecise},
+		{Dump: uploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange2, Precision: PrecisionPrecise},
+		{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange3, Precision: PrecisionPrecise},
+		{Dump: uploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange4, Precision: PrecisionPrecise},
+		{Dump: uploads[1], Path: "sub2/c.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange5, Precision: PrecisionPrecise},
 	}
 	if diff := cmp.Diff(expectedLocations, adjustedLocations); diff != "" {
 		t.Errorf("unexpected locations (-want +got):\n%s", diff)
@@ -150,9 +151,16 @@ func TestReferencesRemote(t *testing.T) {
 		{DumpID: 53, Path: "b.go", Range: testRange4},
 		{DumpID: 53, Path: "c.go", Range: testRange5},
 	}
-	mockLSIFStore.BulkMonikerResultsFunc.PushReturn(monikerLocations[0:1], 1, nil) // defs
-	mockLSIFStore.BulkMonikerResultsFunc.PushReturn(monikerLocations[1:2], 1, nil) // refs batch 1
-	mockLSIFStore.BulkMonikerResultsFunc.PushReturn(monikerLocations[2:], 3, nil)  // refs batch 2
+	// Uploads are searched concurrently, so results are keyed by upload rather than by call order
+	monikerLocationsByUpload := map[int][]lsifstore.Location{
+		151: monikerLocations[0:1], // defs
+		251: monikerLocations[1:2], // refs batch 1
+		252: monikerLocations[2:],  // refs batch 2
+	}
+	mockLSIFStore.BulkMonikerResultsFunc.SetDefaultHook(func(ctx context.Context, tableName string, ids []int, args []semantic.MonikerData, limit, offset int) ([]lsifstore.Location, int, error) {
+		locations := monikerLocationsByUpload[ids[0]]
+		return locations, len(locations), nil
+	})
 
 	uploads := []dbstore.Dump{
 		{ID: 50, Commit: "deadbeef", Root: "sub1/"},
@@ -177,16 +185,16 @@ func TestReferencesRemote(t *testing.T) {
 	}
 
 	expectedLocations := []AdjustedLocation{
-		{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1},
-		{Dump: uploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange2},
-		{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange3},
-		{Dump: uploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange4},
-		{Dump: uploads[1], Path: "sub2/c.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange5},
-		{Dump: uploads[3], Path: "sub4/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1},
-		{Dump: uploads[3], Path: "sub4/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange2},
-		{Dump: uploads[3], Path: "sub4/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange3},
-		{Dump: uploads[3], Path: "sub4/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange4},
-		{Dump: uploads[3], Path: "sub4/c.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange5},
+		{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1, Precision: PrecisionPrecise},
+		{Dump: uploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange2, Precision: PrecisionPrecise},
+		{Dump: uploads[1], Path: "sub2/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange3, Precision: PrecisionPrecise},
+		{Dump: uploads[1], Path: "sub2/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange4, Precision: PrecisionPrecise},
+		{Dump: uploads[1], Path: "sub2/c.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange5, Precision: PrecisionPrecise},
+		{Dump: uploads[3], Path: "sub4/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange1, Precision: PrecisionPrecise},
+		{Dump: uploads[3], Path: "sub4/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange2, Precision: PrecisionPrecise},
+		{Dump: uploads[3], Path: "sub4/a.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange3, Precision: PrecisionPrecise},
+		{Dump: uploads[3], Path: "sub4/b.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange4, Precision: PrecisionPrecise},
+		{Dump: uploads[3], Path: "sub4/c.go", AdjustedCommit: "deadbeef", AdjustedRange: testRange5, Precision: PrecisionPrecise},
 	}
 	if diff := cmp.Diff(expectedLocations, adjustedLocations); diff != "" {
 		t.Errorf("unexpected locations (-want +got):\n%s", diff)
@@ -205,34 +213,106 @@ func TestReferencesRemote(t *testing.T) {
 		}
 	}
 
-	if history := mockLSIFStore.BulkMonikerResultsFunc.History(); len(history) != 3 {
-		t.Fatalf("unexpected call count for lsifstore.BulkMonikerResults. want=%d have=%d", 3, len(history))
+	if history := mockLSIFStore.BulkMonikerResultsFunc.History(); len(history) != 6 {
+		t.Fatalf("unexpected call count for lsifstore.BulkMonikerResults. want=%d have=%d", 6, len(history))
 	} else {
-		if diff := cmp.Diff([]int{151, 152, 153}, history[0].Arg2); diff != "" {
-			t.Errorf("unexpected ids (-want +got):\n%s", diff)
-		}
-
 		expectedMonikers := []semantic.MonikerData{
 			monikers[0],
 			monikers[1],
 			monikers[2],
 		}
-		if diff := cmp.Diff(expectedMonikers, history[0].Arg3); diff != "" {
-			t.Errorf("unexpected monikers (-want +got):\n%s", diff)
+
+		var ids []int
+		for _, call := range history {
+			ids = append(ids, call.Arg2...)
+
+			if diff := cmp.Diff(expectedMonikers, call.Arg3); diff != "" {
+				t.Errorf("unexpected monikers (-want +got):\n%s", diff)
+			}
 		}
+		sort.Ints(ids)
 
-		if diff := cmp.Diff([]int{251}, history[1].Arg2); diff != "" {
+		// #250's commit no longer exists, so it's not searched
+		if diff := cmp.Diff([]int{151, 152, 153, 251, 252, 253}, ids); diff != "" {
 			t.Errorf("unexpected ids (-want +got):\n%s", diff)
 		}
-		if diff := cmp.Diff(expectedMonikers, history[1].Arg3); diff != "" {
-			t.Errorf("unexpected monikers (-want +got):\n%s", diff)
+	}
+}
+
+func TestPageRemoteReferences(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockLSIFStore := NewMockLSIFStore()
+	mockGitserverClient := NewMockGitserverClient()
+
+	// Upload #5 has no references and upload #6 is not hydrated
+	locationsByUpload := map[int][]lsifstore.Location{
+		1: {{DumpID: 1, Path: "a.go"}, {DumpID: 1, Path: "b.go"}, {DumpID: 1, Path: "c.go"}},
+		2: {{DumpID: 2, Path: "a.go"}},
+		3: {{DumpID: 3, Path: "a.go"}, {DumpID: 3, Path: "b.go"}},
+		4: {{DumpID: 4, Path: "a.go"}},
+		7: {{DumpID: 7, Path: "a.go"}, {DumpID: 7, Path: "b.go"}},
+	}
+	mockLSIFStore.BulkMonikerResultsFunc.SetDefaultHook(func(ctx context.Context, tableName string, ids []int, args []semantic.MonikerData, limit, offset int) ([]lsifstore.Location, int, error) {
+		locations := locationsByUpload[ids[0]]
+		totalCount := len(locations)
+		if offset > len(locations) {
+			offset = len(locations)
+		}
+		locations = locations[offset:]
+		if len(locations) > limit {
+			locations = locations[:limit]
 		}
+		return locations, totalCount, nil
+	})
 
-		if diff := cmp.Diff([]int{252, 253}, history[2].Arg2); diff != "" {
-			t.Errorf("unexpected ids (-want +got):\n%s", diff)
+	uploadsByID := map[int]dbstore.Dump{}
+	for _, id := range []int{1, 2, 3, 4, 5, 7} {
+		uploadsByID[id] = dbstore.Dump{ID: id}
+	}
+
+	resolver := newQueryResolver(
+		mockDBStore,
+		mockLSIFStore,
+		newCachedCommitChecker(mockGitserverClient),
+		noopPositionAdjuster(),
+		42,
+		"deadbeef",
+		"s1/main.go",
+		nil,
+		newOperations(&observation.TestContext),
+	)
+
+	cursor := referencesCursor{
+		RemotePhase:       true,
+		BatchIDs:          []int{1, 2, 3, 4, 5, 6, 7},
+		RemoteBatchOffset: -1,
+	}
+
+	var pages [][]lsifstore.Location
+	for i := 0; i < 10; i++ {
+		locations, hasMore, err := resolver.pageRemoteReferences(context.Background(), nil, nil, nil, uploadsByID, &cursor, 2)
+		if err != nil {
+			t.Fatalf("unexpected error paging references: %s", err)
 		}
-		if diff := cmp.Diff(expectedMonikers, history[2].Arg3); diff != "" {
-			t.Errorf("unexpected monikers (-want +got):\n%s", diff)
+		pages = append(pages, locations)
+
+		// Round-trip the cursor as a client would
+		if cursor, err = decodeCursor(encodeCursor(cursor)); err != nil {
+			t.Fatalf("unexpected error decoding cursor: %s", err)
 		}
+		if !hasMore {
+			break
+		}
+	}
+
+	expectedPages := [][]lsifstore.Location{
+		{{DumpID: 1, Path: "a.go"}, {DumpID: 1, Path: "b.go"}},
+		{{DumpID: 1, Path: "c.go"}, {DumpID: 2, Path: "a.go"}},
+		{{DumpID: 3, Path: "a.go"}, {DumpID: 3, Path: "b.go"}},
+		{{DumpID: 4, Path: "a.go"}, {DumpID: 7, Path: "a.go"}},
+		{{DumpID: 7, Path: "b.go"}},
+	}
+	if diff := cmp.Diff(expectedPages, pages); diff != "" {
+		t.Errorf("unexpected pages (-want +got):\n%s", diff)
 	}
 }
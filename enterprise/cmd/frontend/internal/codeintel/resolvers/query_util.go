@@ -5,8 +5,10 @@ import (
 	"fmt"
 	"strconv"
 	"strings"
+	"sync"
 
 	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
 	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
@@ -150,6 +152,64 @@ func (r *queryResolver) monikerLocations(ctx context.Context, uploads []dbstore.
 	return locations, totalCount, nil
 }
 
+// uploadMonikerLocations is a page of the locations of a single upload tagged with a moniker.
+type uploadMonikerLocations struct {
+	locations  []lsifstore.Location
+	totalCount int
+}
+
+// monikerLocationsByUpload returns a page of the locations tagged with any of the given monikers for
+// each of the given uploads, in the same order. The uploads are searched concurrently. The first
+// upload is searched from the given offset, and the others from the start. Uploads missing from the
+// given map (e.g. as their commit no longer exists) have no locations.
+func (r *queryResolver) monikerLocationsByUpload(ctx context.Context, uploadIDs []int, uploadsByID map[int]dbstore.Dump, orderedMonikers []semantic.QualifiedMonikerData, tableName string, limit, offset int) ([]uploadMonikerLocations, error) {
+	args := make([]semantic.MonikerData, 0, len(orderedMonikers))
+	for _, moniker := range orderedMonikers {
+		args = append(args, moniker.MonikerData)
+	}
+
+	var (
+		wg      sync.WaitGroup
+		results = make([]uploadMonikerLocations, len(uploadIDs))
+		errs    = make([]error, len(uploadIDs))
+	)
+	for i, id := range uploadIDs {
+		if _, ok := uploadsByID[id]; !ok {
+			continue
+		}
+
+		uploadOffset := 0
+		if i == 0 {
+			uploadOffset = offset
+		}
+
+		wg.Add(1)
+		go func(i, id, uploadOffset int) {
+			defer wg.Done()
+
+			locations, totalCount, err := r.lsifStore.BulkMonikerResults(ctx, tableName, []int{id}, args, limit, uploadOffset)
+			if err != nil {
+				errs[i] = errors.Wrap(err, "lsifStore.BulkMonikerResults")
+				return
+			}
+			results[i] = uploadMonikerLocations{locations: locations, totalCount: totalCount}
+		}(i, id, uploadOffset)
+	}
+	wg.Wait()
+
+	var err error
+	for _, e := range errs {
+		if e != nil {
+			err = multierror.Append(err, e)
+		}
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
 // adjustLocations translates a set of locations into an equivalent set of locations in the requested
 // commit.
 func (r *queryResolver) adjustLocations(ctx context.Context, uploadsByID map[int]dbstore.Dump, locations []lsifstore.Location) ([]AdjustedLocation, error) {
@@ -175,11 +235,19 @@ func (r *queryResolver) adjustLocation(ctx context.Context, dump store.Dump, loc
 		return AdjustedLocation{}, err
 	}
 
+	// Ranges which were translated from the indexed commit into another commit may be off if the
+	// surrounding code changed between the commits.
+	precision := PrecisionPrecise
+	if adjustedCommit != dump.Commit {
+		precision = PrecisionFuzzy
+	}
+
 	return AdjustedLocation{
 		Dump:           dump,
 		Path:           dump.Root + location.Path,
 		AdjustedCommit: adjustedCommit,
 		AdjustedRange:  adjustedRange,
+		Precision:      precision,
 	}, nil
 }
 
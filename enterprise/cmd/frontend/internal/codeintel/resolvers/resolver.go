@@ -24,6 +24,7 @@ type Resolver interface {
 	GetIndexByID(ctx context.Context, id int) (store.Index, bool, error)
 	GetUploadsByIDs(ctx context.Context, ids ...int) ([]store.Upload, error)
 	GetIndexesByIDs(ctx context.Context, ids ...int) ([]store.Index, error)
+	GetUploadProcessingTrace(ctx context.Context, uploadID int) (store.UploadProcessingTrace, bool, error)
 	UploadConnectionResolver(opts store.GetUploadsOptions) *UploadsResolver
 	IndexConnectionResolver(opts store.GetIndexesOptions) *IndexesResolver
 	DeleteUploadByID(ctx context.Context, uploadID int) error
@@ -32,6 +33,9 @@ type Resolver interface {
 	UpdateIndexConfigurationByRepositoryID(ctx context.Context, repositoryID int, configuration string) error
 	CommitGraph(ctx context.Context, repositoryID int) (gql.CodeIntelligenceCommitGraphResolver, error)
 	QueueAutoIndexJobForRepo(ctx context.Context, repositoryID int) error
+	GetUploadTokens(ctx context.Context, repositoryID int) ([]store.UploadToken, error)
+	CreateUploadToken(ctx context.Context, repositoryID, creatorUserID int, note string) (int, string, error)
+	DeleteUploadToken(ctx context.Context, id int) error
 	QueryResolver(ctx context.Context, args *gql.GitBlobLSIFDataArgs) (QueryResolver, error)
 }
 
@@ -78,6 +82,10 @@ func (r *resolver) GetUploadByID(ctx context.Context, id int) (store.Upload, boo
 	return r.dbStore.GetUploadByID(ctx, id)
 }
 
+func (r *resolver) GetUploadProcessingTrace(ctx context.Context, uploadID int) (store.UploadProcessingTrace, bool, error) {
+	return r.dbStore.GetUploadProcessingTrace(ctx, uploadID)
+}
+
 func (r *resolver) GetIndexByID(ctx context.Context, id int) (store.Index, bool, error) {
 	return r.dbStore.GetIndexByID(ctx, id)
 }
@@ -142,6 +150,19 @@ func (r *resolver) UpdateIndexConfigurationByRepositoryID(ctx context.Context, r
 	return r.dbStore.UpdateIndexConfigurationByRepositoryID(ctx, repositoryID, []byte(configuration))
 }
 
+func (r *resolver) GetUploadTokens(ctx context.Context, repositoryID int) ([]store.UploadToken, error) {
+	return r.dbStore.GetUploadTokens(ctx, repositoryID)
+}
+
+func (r *resolver) CreateUploadToken(ctx context.Context, repositoryID, creatorUserID int, note string) (int, string, error) {
+	return r.dbStore.CreateUploadToken(ctx, repositoryID, creatorUserID, note)
+}
+
+func (r *resolver) DeleteUploadToken(ctx context.Context, id int) error {
+	_, err := r.dbStore.DeleteUploadToken(ctx, id)
+	return err
+}
+
 func (r *resolver) CommitGraph(ctx context.Context, repositoryID int) (gql.CodeIntelligenceCommitGraphResolver, error) {
 	stale, updatedAt, err := r.dbStore.CommitGraphMetadata(ctx, repositoryID)
 	if err != nil {
@@ -0,0 +1,329 @@
+package resolvers
+
+import (
+	"compress/gzip"
+	"context"
+	"sync"
+	"time"
+
+	"github.com/inconshreveable/log15"
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
+)
+
+// restoringDBStore is a DBStore that records which dumps are queried and transparently
+// restores the code intelligence data of dumps that were evicted to object storage.
+type restoringDBStore struct {
+	DBStore
+	restorer *bundleRestorer
+}
+
+// NewRestoringDBStore wraps the given store so that the dumps it returns for code intelligence
+// queries have their data in the codeintel database. The data of evicted dumps is restored from
+// object storage. Dumps that cannot be restored within the given latency budget are omitted from
+// the results, and their restore continues in the background.
+func NewRestoringDBStore(
+	dbStore DBStore,
+	evictionStore EvictionDBStore,
+	lsifStore EvictionLSIFStore,
+	uploadStore EvictionUploadStore,
+	latencyBudget time.Duration,
+	observationContext *observation.Context,
+) DBStore {
+	return &restoringDBStore{
+		DBStore:  dbStore,
+		restorer: newBundleRestorer(evictionStore, lsifStore, uploadStore, latencyBudget, newRestoreMetrics(observationContext)),
+	}
+}
+
+func (s *restoringDBStore) GetDumpsByIDs(ctx context.Context, ids []int) ([]store.Dump, error) {
+	dumps, err := s.DBStore.GetDumpsByIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.restorer.restore(ctx, dumps)
+}
+
+func (s *restoringDBStore) FindClosestDumps(ctx context.Context, repositoryID int, commit, path string, rootMustEnclosePath bool, indexer string) ([]store.Dump, error) {
+	dumps, err := s.DBStore.FindClosestDumps(ctx, repositoryID, commit, path, rootMustEnclosePath, indexer)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.restorer.restore(ctx, dumps)
+}
+
+func (s *restoringDBStore) FindClosestDumpsFromGraphFragment(ctx context.Context, repositoryID int, commit, path string, rootMustEnclosePath bool, indexer string, graph *gitserver.CommitGraph) ([]store.Dump, error) {
+	dumps, err := s.DBStore.FindClosestDumpsFromGraphFragment(ctx, repositoryID, commit, path, rootMustEnclosePath, indexer, graph)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.restorer.restore(ctx, dumps)
+}
+
+func (s *restoringDBStore) DefinitionDumps(ctx context.Context, monikers []semantic.QualifiedMonikerData) ([]store.Dump, error) {
+	dumps, err := s.DBStore.DefinitionDumps(ctx, monikers)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.restorer.restore(ctx, dumps)
+}
+
+// restoreTimeout is the maximum duration of a single restore, which may outlive the query
+// that triggered it.
+const restoreTimeout = 10 * time.Minute
+
+// queriedInterval is the minimum duration between updates of the last query time of a dump.
+const queriedInterval = time.Hour
+
+// maxQueriedEntries is the number of dumps whose last query time is tracked in memory before
+// stale entries are pruned.
+const maxQueriedEntries = 10000
+
+type bundleRestorer struct {
+	evictionStore EvictionDBStore
+	lsifStore     EvictionLSIFStore
+	uploadStore   EvictionUploadStore
+	latencyBudget time.Duration
+	metrics       *restoreMetrics
+
+	mu       sync.Mutex
+	inflight map[int]*restoreCall // restores in progress by dump identifier
+	queried  map[int]time.Time    // last recorded query time by dump identifier
+}
+
+// restoreCall is a restore in progress. The done channel is closed once err is set.
+type restoreCall struct {
+	done chan struct{}
+	err  error
+}
+
+func newBundleRestorer(evictionStore EvictionDBStore, lsifStore EvictionLSIFStore, uploadStore EvictionUploadStore, latencyBudget time.Duration, metrics *restoreMetrics) *bundleRestorer {
+	return &bundleRestorer{
+		evictionStore: evictionStore,
+		lsifStore:     lsifStore,
+		uploadStore:   uploadStore,
+		latencyBudget: latencyBudget,
+		metrics:       metrics,
+		inflight:      map[int]*restoreCall{},
+		queried:       map[int]time.Time{},
+	}
+}
+
+// restore records the given dumps as queried and restores the data of the evicted ones. It
+// returns the dumps whose data is available.
+func (r *bundleRestorer) restore(ctx context.Context, dumps []store.Dump) ([]store.Dump, error) {
+	if len(dumps) == 0 {
+		return dumps, nil
+	}
+
+	ids := make([]int, 0, len(dumps))
+	for _, dump := range dumps {
+		ids = append(ids, dump.ID)
+	}
+
+	if err := r.markQueried(ctx, ids); err != nil {
+		// Not worth failing the query over
+		log15.Warn("Failed to record queried uploads", "error", err)
+	}
+
+	evictedIDs, err := r.evictionStore.EvictedUploadIDs(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+	if len(evictedIDs) == 0 {
+		return dumps, nil
+	}
+
+	calls := make(map[int]*restoreCall, len(evictedIDs))
+	for _, id := range evictedIDs {
+		calls[id] = r.start(id)
+	}
+
+	timer := time.NewTimer(r.latencyBudget)
+	defer timer.Stop()
+
+	budgetExceeded := false
+	unavailable := map[int]bool{}
+	for id, call := range calls {
+		if !budgetExceeded {
+			select {
+			case <-call.done:
+			case <-timer.C:
+				budgetExceeded = true
+			case <-ctx.Done():
+				return nil, ctx.Err()
+			}
+		}
+
+		select {
+		case <-call.done:
+			unavailable[id] = call.err != nil
+		default:
+			unavailable[id] = true
+		}
+	}
+	if budgetExceeded {
+		r.metrics.numBudgetExceeded.Inc()
+	}
+
+	filtered := make([]store.Dump, 0, len(dumps))
+	for _, dump := range dumps {
+		if !unavailable[dump.ID] {
+			filtered = append(filtered, dump)
+		}
+	}
+
+	return filtered, nil
+}
+
+// markQueried updates the last query time of the given dumps that have not been recorded
+// as queried recently by this process.
+func (r *bundleRestorer) markQueried(ctx context.Context, ids []int) error {
+	now := time.Now()
+
+	r.mu.Lock()
+	var stale []int
+	for _, id := range ids {
+		if now.Sub(r.queried[id]) >= queriedInterval {
+			stale = append(stale, id)
+			r.queried[id] = now
+		}
+	}
+	if len(r.queried) > maxQueriedEntries {
+		for id, queriedAt := range r.queried {
+			if now.Sub(queriedAt) >= queriedInterval {
+				delete(r.queried, id)
+			}
+		}
+	}
+	r.mu.Unlock()
+
+	if len(stale) == 0 {
+		return nil
+	}
+
+	return r.evictionStore.MarkUploadsQueried(ctx, stale, now)
+}
+
+// start begins restoring the data of the given dump in the background unless a restore of
+// that dump is already in progress, and returns the in-progress restore.
+func (r *bundleRestorer) start(id int) *restoreCall {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if call, ok := r.inflight[id]; ok {
+		return call
+	}
+
+	call := &restoreCall{done: make(chan struct{})}
+	r.inflight[id] = call
+
+	go func() {
+		call.err = r.restoreBundle(id)
+
+		r.mu.Lock()
+		delete(r.inflight, id)
+		r.mu.Unlock()
+		close(call.done)
+	}()
+
+	return call
+}
+
+// restoreBundle imports the evicted data of the given dump from object storage into the
+// codeintel database.
+func (r *bundleRestorer) restoreBundle(id int) (err error) {
+	ctx, cancel := context.WithTimeout(context.Background(), restoreTimeout)
+	defer cancel()
+
+	start := time.Now()
+	defer func() {
+		r.metrics.restoreDuration.Observe(time.Since(start).Seconds())
+
+		if err != nil {
+			r.metrics.numRestoreErrors.Inc()
+			log15.Error("Failed to restore evicted code intelligence data", "id", id, "error", err)
+			return
+		}
+
+		r.metrics.numRestores.Inc()
+		log15.Debug("Restored evicted code intelligence data", "id", id, "duration", time.Since(start))
+	}()
+
+	rc, err := r.uploadStore.Get(ctx, lsifstore.EvictedBundleKey(id))
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+
+	gzipReader, err := gzip.NewReader(rc)
+	if err != nil {
+		return err
+	}
+
+	if err := r.lsifStore.ImportBundle(ctx, id, gzipReader); err != nil {
+		return err
+	}
+
+	if err := r.evictionStore.UnmarkUploadEvicted(ctx, id); err != nil {
+		return err
+	}
+
+	// The upload is no longer marked as evicted, so nothing else removes this object
+	if err := r.uploadStore.Delete(ctx, lsifstore.EvictedBundleKey(id)); err != nil {
+		log15.Warn("Failed to delete restored code intelligence data from object storage", "id", id, "error", err)
+	}
+
+	return nil
+}
+
+type restoreMetrics struct {
+	numRestores       prometheus.Counter
+	numRestoreErrors  prometheus.Counter
+	numBudgetExceeded prometheus.Counter
+	restoreDuration   prometheus.Histogram
+}
+
+func newRestoreMetrics(observationContext *observation.Context) *restoreMetrics {
+	counter := func(name, help string) prometheus.Counter {
+		counter := prometheus.NewCounter(prometheus.CounterOpts{
+			Name: name,
+			Help: help,
+		})
+
+		observationContext.Registerer.MustRegister(counter)
+		return counter
+	}
+
+	restoreDuration := prometheus.NewHistogram(prometheus.HistogramOpts{
+		Name:    "src_codeintel_bundle_restore_duration_seconds",
+		Help:    "The time taken to restore the evicted code intelligence data of an upload.",
+		Buckets: prometheus.ExponentialBuckets(0.1, 2, 12),
+	})
+	observationContext.Registerer.MustRegister(restoreDuration)
+
+	return &restoreMetrics{
+		numRestores: counter(
+			"src_codeintel_bundle_restores_total",
+			"The number of uploads whose evicted code intelligence data was restored from object storage.",
+		),
+		numRestoreErrors: counter(
+			"src_codeintel_bundle_restore_errors_total",
+			"The number of failures to restore evicted code intelligence data from object storage.",
+		),
+		numBudgetExceeded: counter(
+			"src_codeintel_bundle_restore_budget_exceeded_total",
+			"The number of queries that omitted uploads whose data could not be restored within the latency budget.",
+		),
+		restoreDuration: restoreDuration,
+	}
+}
@@ -0,0 +1,121 @@
+package resolvers
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestRestoringDBStore(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockEvictionStore := NewMockEvictionDBStore()
+	mockLSIFStore := NewMockEvictionLSIFStore()
+	mockUploadStore := NewMockEvictionUploadStore()
+
+	mockDBStore.GetDumpsByIDsFunc.SetDefaultReturn([]store.Dump{{ID: 50}, {ID: 51}}, nil)
+	mockEvictionStore.EvictedUploadIDsFunc.PushReturn([]int{51}, nil)
+	mockUploadStore.GetFunc.SetDefaultHook(func(ctx context.Context, key string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(gzipped(t, key))), nil
+	})
+
+	var imported string
+	mockLSIFStore.ImportBundleFunc.SetDefaultHook(func(ctx context.Context, bundleID int, r io.Reader) error {
+		contents, err := io.ReadAll(r)
+		imported = string(contents)
+		return err
+	})
+
+	dbStore := NewRestoringDBStore(mockDBStore, mockEvictionStore, mockLSIFStore, mockUploadStore, time.Minute, &observation.TestContext)
+	dumps, err := dbStore.GetDumpsByIDs(context.Background(), []int{50, 51})
+	if err != nil {
+		t.Fatalf("unexpected error getting dumps: %s", err)
+	}
+	if diff := cmp.Diff([]store.Dump{{ID: 50}, {ID: 51}}, dumps); diff != "" {
+		t.Errorf("unexpected dumps (-want +got):\n%s", diff)
+	}
+
+	if imported != "evicted-bundle-51.json.gz" {
+		t.Errorf("unexpected imported data %q", imported)
+	}
+	if calls := mockEvictionStore.UnmarkUploadEvictedFunc.History(); len(calls) != 1 || calls[0].Arg1 != 51 {
+		t.Errorf("unexpected calls to UnmarkUploadEvicted: %v", calls)
+	}
+	if calls := mockUploadStore.DeleteFunc.History(); len(calls) != 1 || calls[0].Arg1 != "evicted-bundle-51.json.gz" {
+		t.Errorf("unexpected calls to Delete: %v", calls)
+	}
+
+	// Dumps are only recorded as queried once an hour
+	if _, err := dbStore.GetDumpsByIDs(context.Background(), []int{50, 51}); err != nil {
+		t.Fatalf("unexpected error getting dumps: %s", err)
+	}
+	if calls := mockEvictionStore.MarkUploadsQueriedFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of MarkUploadsQueried calls. want=%d have=%d", 1, len(calls))
+	} else if diff := cmp.Diff([]int{50, 51}, calls[0].Arg1); diff != "" {
+		t.Errorf("unexpected queried uploads (-want +got):\n%s", diff)
+	}
+}
+
+func TestRestoringDBStoreLatencyBudget(t *testing.T) {
+	mockDBStore := NewMockDBStore()
+	mockEvictionStore := NewMockEvictionDBStore()
+	mockLSIFStore := NewMockEvictionLSIFStore()
+	mockUploadStore := NewMockEvictionUploadStore()
+
+	mockDBStore.FindClosestDumpsFunc.SetDefaultReturn([]store.Dump{{ID: 50}, {ID: 51}}, nil)
+	mockEvictionStore.EvictedUploadIDsFunc.SetDefaultReturn([]int{51}, nil)
+	mockUploadStore.GetFunc.SetDefaultHook(func(ctx context.Context, key string) (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(gzipped(t, key))), nil
+	})
+
+	unblock := make(chan struct{})
+	mockLSIFStore.ImportBundleFunc.SetDefaultHook(func(ctx context.Context, bundleID int, r io.Reader) error {
+		<-unblock
+		return nil
+	})
+
+	dbStore := NewRestoringDBStore(mockDBStore, mockEvictionStore, mockLSIFStore, mockUploadStore, time.Millisecond, &observation.TestContext)
+
+	// Both queries exceed the budget and share a single restore
+	for i := 0; i < 2; i++ {
+		dumps, err := dbStore.FindClosestDumps(context.Background(), 42, "deadbeef", "main.go", true, "")
+		if err != nil {
+			t.Fatalf("unexpected error finding dumps: %s", err)
+		}
+		if diff := cmp.Diff([]store.Dump{{ID: 50}}, dumps); diff != "" {
+			t.Errorf("unexpected dumps (-want +got):\n%s", diff)
+		}
+	}
+
+	// The restore continues in the background
+	close(unblock)
+	for i := 0; len(mockEvictionStore.UnmarkUploadEvictedFunc.History()) == 0; i++ {
+		if i > 1000 {
+			t.Fatalf("restore did not complete")
+		}
+		time.Sleep(time.Millisecond)
+	}
+
+	if calls := mockLSIFStore.ImportBundleFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of ImportBundle calls. want=%d have=%d", 1, len(calls))
+	}
+}
+
+func gzipped(t *testing.T, contents string) []byte {
+	var buf bytes.Buffer
+	gzipWriter := gzip.NewWriter(&buf)
+	if _, err := io.WriteString(gzipWriter, contents); err != nil {
+		t.Fatalf("unexpected error compressing data: %s", err)
+	}
+	if err := gzipWriter.Close(); err != nil {
+		t.Fatalf("unexpected error compressing data: %s", err)
+	}
+	return buf.Bytes()
+}
@@ -11,11 +11,11 @@ import (
 	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
 
+	codeintelresolvers "github.com/sourcegraph/sourcegraph/enterprise/cmd/frontend/internal/codeintel/resolvers"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/autoindex/enqueuer"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
 	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
-	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbconn"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
@@ -23,10 +23,12 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
 )
 
 var services struct {
 	dbStore         *store.Store
+	queryDBStore    codeintelresolvers.DBStore
 	locker          *locker.Locker
 	lsifStore       *lsifstore.Store
 	uploadStore     uploadstore.Store
@@ -62,6 +64,13 @@ func initServices(ctx context.Context, db dbutil.DB) error {
 		if err != nil {
 			log.Fatalf("Failed to initialize upload store: %s", err)
 		}
+		evictedBundleStore, err := uploadstore.CreateLazy(context.Background(), config.UploadStoreConfig.EvictionConfig(), observationContext)
+		if err != nil {
+			log.Fatalf("Failed to initialize evicted bundle store: %s", err)
+		}
+
+		// Restores evicted code intelligence data for queries
+		queryDBStore := codeintelresolvers.NewRestoringDBStore(dbStore, dbStore, lsifStore, evictedBundleStore, config.BundleRestoreLatencyBudget, observationContext)
 
 		// Initialize gitserver client
 		gitserverClient := gitserver.New(dbStore, observationContext)
@@ -70,6 +79,7 @@ func initServices(ctx context.Context, db dbutil.DB) error {
 		indexEnqueuer := enqueuer.NewIndexEnqueuer(&enqueuer.DBStoreShim{dbStore}, gitserverClient, repoupdater.DefaultClient, observationContext)
 
 		services.dbStore = dbStore
+		services.queryDBStore = queryDBStore
 		services.locker = locker
 		services.lsifStore = lsifStore
 		services.uploadStore = uploadStore
@@ -0,0 +1,192 @@
+package codeintel
+
+import (
+	"context"
+	"regexp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+)
+
+// maxPreciseSymbols is the maximum number of symbols returned for a single request. This
+// matches the limit enforced by the symbols service.
+const maxPreciseSymbols = 500
+
+// preciseSymbolsSource lists the definitions tagged with symbol metadata in the LSIF
+// uploads visible from a commit.
+type preciseSymbolsSource struct {
+	db dbutil.DB
+}
+
+var _ backend.PreciseSymbolsSource = &preciseSymbolsSource{}
+
+func (s *preciseSymbolsSource) ReposWithPreciseSymbols(ctx context.Context, repoIDs []api.RepoID) (map[api.RepoID]struct{}, error) {
+	ids := make([]int, 0, len(repoIDs))
+	for _, repoID := range repoIDs {
+		ids = append(ids, int(repoID))
+	}
+
+	withUploads, err := services.dbStore.RepositoryIDsWithUploads(ctx, ids)
+	if err != nil {
+		return nil, err
+	}
+
+	repos := make(map[api.RepoID]struct{}, len(withUploads))
+	for _, id := range withUploads {
+		repos[api.RepoID(id)] = struct{}{}
+	}
+
+	return repos, nil
+}
+
+func (s *preciseSymbolsSource) ListPreciseSymbols(ctx context.Context, args search.SymbolsParameters) (result.Symbols, bool, error) {
+	repo, err := database.Repos(s.db).GetByName(ctx, args.Repo)
+	if err != nil {
+		return nil, false, err
+	}
+
+	dumps, err := services.queryDBStore.FindClosestDumps(ctx, int(repo.ID), string(args.CommitID), "", false, "")
+	if err != nil || len(dumps) == 0 {
+		return nil, false, err
+	}
+
+	filter, err := newSymbolFilter(args)
+	if err != nil {
+		return nil, false, err
+	}
+
+	limit := args.First
+	if limit <= 0 || limit > maxPreciseSymbols {
+		limit = maxPreciseSymbols
+	}
+
+	symbols := result.Symbols{}
+	for _, dump := range dumps {
+		if len(symbols) >= limit {
+			break
+		}
+
+		root := dump.Root
+		preciseSymbols, err := services.lsifStore.Symbols(ctx, dump.ID, func(path, name string) bool {
+			return filter(root+path, name)
+		}, limit-len(symbols))
+		if err != nil {
+			return nil, false, err
+		}
+
+		for _, symbol := range preciseSymbols {
+			symbols = append(symbols, toResultSymbol(root, symbol))
+		}
+	}
+
+	return symbols, true, nil
+}
+
+// newSymbolFilter returns a function that determines if a symbol with the given path and
+// name matches the given parameters. The query and path patterns are interpreted as regular
+// expressions, as they are by the symbols service.
+func newSymbolFilter(args search.SymbolsParameters) (func(path, name string) bool, error) {
+	compile := func(pattern string) (*regexp.Regexp, error) {
+		if !args.IsCaseSensitive {
+			pattern = "(?i:" + pattern + ")"
+		}
+		return regexp.Compile(pattern)
+	}
+
+	var nameRegexp, excludeRegexp *regexp.Regexp
+	if args.Query != "" {
+		re, err := compile(args.Query)
+		if err != nil {
+			return nil, err
+		}
+		nameRegexp = re
+	}
+	if args.ExcludePattern != "" {
+		re, err := compile(args.ExcludePattern)
+		if err != nil {
+			return nil, err
+		}
+		excludeRegexp = re
+	}
+
+	includeRegexps := make([]*regexp.Regexp, 0, len(args.IncludePatterns))
+	for _, pattern := range args.IncludePatterns {
+		re, err := compile(pattern)
+		if err != nil {
+			return nil, err
+		}
+		includeRegexps = append(includeRegexps, re)
+	}
+
+	return func(path, name string) bool {
+		if nameRegexp != nil && !nameRegexp.MatchString(name) {
+			return false
+		}
+		if excludeRegexp != nil && excludeRegexp.MatchString(path) {
+			return false
+		}
+		for _, re := range includeRegexps {
+			if !re.MatchString(path) {
+				return false
+			}
+		}
+
+		return true
+	}, nil
+}
+
+// toResultSymbol converts a symbol of a dump with the given root into a search result.
+func toResultSymbol(root string, symbol lsifstore.Symbol) result.Symbol {
+	path := root + symbol.Path
+	language, _ := inventory.GetLanguageByFilename(path)
+
+	return result.Symbol{
+		Name:      symbol.Name,
+		Path:      path,
+		Line:      symbol.Range.Start.Line + 1,
+		Kind:      symbolKindName(symbol.Kind),
+		Language:  language,
+		Signature: symbol.Detail,
+	}
+}
+
+// symbolKindNames maps LSP symbol kinds to the kind names understood by result.Symbol.
+var symbolKindNames = map[protocol.SymbolKind]string{
+	protocol.File:          "file",
+	protocol.Module:        "module",
+	protocol.Namespace:     "namespace",
+	protocol.Package:       "package",
+	protocol.Class:         "class",
+	protocol.Method:        "method",
+	protocol.Property:      "property",
+	protocol.Field:         "field",
+	protocol.Constructor:   "constructor",
+	protocol.Enum:          "enum",
+	protocol.Interface:     "interface",
+	protocol.Function:      "function",
+	protocol.Variable:      "variable",
+	protocol.Constant:      "constant",
+	protocol.String:        "string",
+	protocol.Number:        "number",
+	protocol.Boolean:       "boolean",
+	protocol.Array:         "array",
+	protocol.Object:        "object",
+	protocol.Key:           "key",
+	protocol.Null:          "null",
+	protocol.EnumMember:    "enum member",
+	protocol.Struct:        "struct",
+	protocol.Event:         "event",
+	protocol.Operator:      "operator",
+	protocol.TypeParameter: "type parameter",
+}
+
+func symbolKindName(kind protocol.SymbolKind) string {
+	return symbolKindNames[kind]
+}
@@ -0,0 +1,69 @@
+package codeintel
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+)
+
+func TestNewSymbolFilter(t *testing.T) {
+	testCases := []struct {
+		name     string
+		args     search.SymbolsParameters
+		path     string
+		symbol   string
+		expected bool
+	}{
+		{"empty query", search.SymbolsParameters{}, "main.go", "main", true},
+		{"case insensitive", search.SymbolsParameters{Query: "^newstore$"}, "store.go", "NewStore", true},
+		{"case sensitive", search.SymbolsParameters{Query: "^newstore$", IsCaseSensitive: true}, "store.go", "NewStore", false},
+		{"include pattern", search.SymbolsParameters{Query: "Store", IncludePatterns: []string{`\.go$`, "^internal/"}}, "internal/store.go", "Store", true},
+		{"include pattern mismatch", search.SymbolsParameters{Query: "Store", IncludePatterns: []string{`\.go$`, "^internal/"}}, "cmd/store.go", "Store", false},
+		{"exclude pattern", search.SymbolsParameters{Query: "Store", ExcludePattern: `_test\.go$`}, "store_test.go", "Store", false},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			filter, err := newSymbolFilter(testCase.args)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if matches := filter(testCase.path, testCase.symbol); matches != testCase.expected {
+				t.Errorf("unexpected match. want=%v have=%v", testCase.expected, matches)
+			}
+		})
+	}
+
+	if _, err := newSymbolFilter(search.SymbolsParameters{Query: "("}); err == nil {
+		t.Errorf("expected error for invalid query")
+	}
+}
+
+func TestToResultSymbol(t *testing.T) {
+	symbol := lsifstore.Symbol{
+		DumpID: 42,
+		Path:   "store.go",
+		Name:   "NewStore",
+		Kind:   protocol.Function,
+		Detail: "func NewStore() *Store",
+		Range:  lsifstore.Range{Start: lsifstore.Position{Line: 9, Character: 5}, End: lsifstore.Position{Line: 9, Character: 13}},
+	}
+
+	expected := result.Symbol{
+		Name:      "NewStore",
+		Path:      "internal/store.go",
+		Line:      10,
+		Kind:      "function",
+		Language:  "Go",
+		Signature: "func NewStore() *Store",
+	}
+	if diff := cmp.Diff(expected, toResultSymbol("internal/", symbol)); diff != "" {
+		t.Errorf("unexpected symbol (-want +got):\n%s", diff)
+	}
+}
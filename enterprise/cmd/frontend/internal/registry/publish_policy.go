@@ -0,0 +1,112 @@
+package registry
+
+import (
+	"context"
+	"crypto/ed25519"
+	"encoding/base64"
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	frontendregistry "github.com/sourcegraph/sourcegraph/cmd/frontend/registry/api"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// checkPublishAllowed returns an error if the extension may not be published
+// to the local registry, because extensions.allowRemoteExtensions is set and
+// does not list it.
+func checkPublishAllowed(extensionID string) error {
+	allowedExtensions := getAllowedExtensionsFromSiteConfig()
+	if allowedExtensions == nil {
+		return nil
+	}
+
+	// The allowlist lists extension IDs without the prefix of the local
+	// registry, but allow it for consistency with remote extension IDs.
+	_, publisher, name, err := frontendregistry.SplitExtensionID(extensionID)
+	if err != nil {
+		return err
+	}
+	for _, x := range allowedExtensions {
+		if x == extensionID || x == publisher+"/"+name {
+			return nil
+		}
+	}
+	return errors.Errorf("extension %q may not be published because it is not listed in extensions.allowRemoteExtensions in the site configuration", extensionID)
+}
+
+// verifyBundleSignature checks that signature is a base64-encoded Ed25519
+// signature of bundle made with one of the keys in
+// extensions.bundleSigningKeys, and returns the key. If no keys are
+// configured, bundles need not be signed and the empty string is returned.
+func verifyBundleSignature(bundle, signature *string) (key string, err error) {
+	keys := getBundleSigningKeysFromSiteConfig()
+	if len(keys) == 0 {
+		return "", nil
+	}
+
+	if bundle == nil {
+		return "", errors.New("a bundle is required to publish extensions when extensions.bundleSigningKeys is set")
+	}
+	if signature == nil || *signature == "" {
+		return "", errors.New("a bundle signature is required to publish extensions when extensions.bundleSigningKeys is set")
+	}
+	sig, err := base64.StdEncoding.DecodeString(*signature)
+	if err != nil {
+		return "", errors.Wrap(err, "decoding bundle signature")
+	}
+
+	for _, key := range keys {
+		publicKey, err := base64.StdEncoding.DecodeString(key)
+		if err != nil || len(publicKey) != ed25519.PublicKeySize {
+			log15.Warn("Ignoring invalid key in extensions.bundleSigningKeys", "key", key)
+			continue
+		}
+		if ed25519.Verify(publicKey, []byte(*bundle), sig) {
+			return key, nil
+		}
+	}
+	return "", errors.New("the bundle signature does not match any key in extensions.bundleSigningKeys")
+}
+
+func getBundleSigningKeysFromSiteConfig() []string {
+	if c := conf.Get().Extensions; c != nil {
+		return c.BundleSigningKeys
+	}
+	return nil
+}
+
+// publishEventArgs is the argument of extension publish security events.
+type publishEventArgs struct {
+	ExtensionID string `json:"extensionID"`
+	ReleaseID   int64  `json:"releaseID,omitempty"`
+	SigningKey  string `json:"signingKey,omitempty"`
+	Reason      string `json:"reason,omitempty"`
+}
+
+// logPublishEvent records a publish of an extension, or a rejected attempt,
+// in the security event log. Unlike most security events, it is logged on all
+// instances, since it forms the audit log of private extensions.
+func logPublishEvent(ctx context.Context, db dbutil.DB, name database.SecurityEventName, eventArgs *publishEventArgs) {
+	args, err := json.Marshal(eventArgs)
+	if err != nil {
+		log15.Error("logPublishEvent: failed to marshal JSON", "eventArgs", eventArgs)
+	}
+
+	event := &database.SecurityEvent{
+		Name:      name,
+		UserID:    uint32(actor.FromContext(ctx).UID),
+		Argument:  args,
+		Source:    "BACKEND",
+		Timestamp: time.Now(),
+	}
+
+	if err := database.SecurityEventLogs(db).Insert(ctx, event); err != nil {
+		log15.Error(string(name), "err", err)
+	}
+}
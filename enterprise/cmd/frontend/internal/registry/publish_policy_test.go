@@ -0,0 +1,74 @@
+package registry
+
+import (
+	"crypto/ed25519"
+	"encoding/base64"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestCheckPublishAllowed(t *testing.T) {
+	defer licensing.TestingSkipFeatureChecks()()
+	defer conf.Mock(nil)
+
+	if err := checkPublishAllowed("alice/a"); err != nil {
+		t.Errorf("want %q to be allowed without allowlist, have %v", "alice/a", err)
+	}
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{Extensions: &schema.Extensions{AllowRemoteExtensions: []string{"alice/a"}}}})
+	for id, allowed := range map[string]bool{
+		"alice/a":                   true,
+		"example.com/alice/a":       true,
+		"alice/b":                   false,
+		"example.com/bob/a":         false,
+		"example.com/alice/a-other": false,
+	} {
+		if err := checkPublishAllowed(id); (err == nil) != allowed {
+			t.Errorf("%q: want allowed=%t, have error %v", id, allowed, err)
+		}
+	}
+}
+
+func TestVerifyBundleSignature(t *testing.T) {
+	defer conf.Mock(nil)
+
+	publicKey, privateKey, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherKey, _, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	key := base64.StdEncoding.EncodeToString(publicKey)
+
+	bundle := "console.log('hello')"
+	signature := base64.StdEncoding.EncodeToString(ed25519.Sign(privateKey, []byte(bundle)))
+	tampered := bundle + ";"
+	strptr := func(s string) *string { return &s }
+
+	if have, err := verifyBundleSignature(&bundle, nil); err != nil || have != "" {
+		t.Fatalf("want unsigned bundle to be accepted without keys, have %q, %v", have, err)
+	}
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{Extensions: &schema.Extensions{
+		BundleSigningKeys: []string{"invalid", base64.StdEncoding.EncodeToString(otherKey), key},
+	}}})
+
+	if have, err := verifyBundleSignature(&bundle, &signature); err != nil || have != key {
+		t.Errorf("want signed bundle to be accepted with key %q, have %q, %v", key, have, err)
+	}
+	for name, tc := range map[string]struct{ bundle, signature *string }{
+		"no bundle":         {nil, &signature},
+		"no signature":      {&bundle, nil},
+		"invalid signature": {&bundle, strptr("???")},
+		"tampered bundle":   {&tampered, &signature},
+	} {
+		if _, err := verifyBundleSignature(tc.bundle, tc.signature); err == nil {
+			t.Errorf("%s: want error", name)
+		}
+	}
+}
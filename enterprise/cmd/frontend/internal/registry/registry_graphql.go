@@ -10,6 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/licensing"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
 )
@@ -126,6 +127,18 @@ func extensionRegistryPublishExtension(ctx context.Context, db dbutil.DB, args *
 		return nil, errors.Errorf("unable to publish remote extension %q (publish it directly to the registry on %q)", args.ExtensionID, prefix)
 	}
 
+	// 🚨 SECURITY: Check that the extension is allowed and that its bundle is
+	// signed by a trusted key, if required by the site configuration.
+	if err := checkPublishAllowed(args.ExtensionID); err != nil {
+		logPublishEvent(ctx, db, database.SecurityEventNameExtensionPublishRejected, &publishEventArgs{ExtensionID: args.ExtensionID, Reason: err.Error()})
+		return nil, err
+	}
+	signingKey, err := verifyBundleSignature(args.Bundle, args.BundleSignature)
+	if err != nil {
+		logPublishEvent(ctx, db, database.SecurityEventNameExtensionPublishRejected, &publishEventArgs{ExtensionID: args.ExtensionID, Reason: err.Error()})
+		return nil, err
+	}
+
 	// Get or create the extension to publish.
 	localExtension, _, err := frontendregistry.GetExtensionByExtensionID(ctx, db, args.ExtensionID)
 	if err != nil && !errcode.IsNotFound(err) {
@@ -183,8 +196,10 @@ func extensionRegistryPublishExtension(ctx context.Context, db dbutil.DB, args *
 		Bundle:              args.Bundle,
 		SourceMap:           args.SourceMap,
 	}
-	if _, err := (dbReleases{}).Create(ctx, &release); err != nil {
+	releaseID, err := (dbReleases{}).Create(ctx, &release)
+	if err != nil {
 		return nil, err
 	}
+	logPublishEvent(ctx, db, database.SecurityEventNameExtensionPublished, &publishEventArgs{ExtensionID: args.ExtensionID, ReleaseID: releaseID, SigningKey: signingKey})
 	return &frontendregistry.ExtensionRegistryMutationResult{DB: db, ID: id.LocalID}, nil
 }
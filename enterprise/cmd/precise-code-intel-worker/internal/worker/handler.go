@@ -16,10 +16,10 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
-	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/honey"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
 	"github.com/sourcegraph/sourcegraph/internal/vcs"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
@@ -89,6 +89,15 @@ func (h *handler) handle(ctx context.Context, upload store.Upload) (requeued boo
 		return requeued, err
 	}
 
+	trace := newProcessingTrace(upload.ID, MemorySampleInterval)
+	defer func() {
+		// Record the trace of failed attempts as well so that slow or memory-hungry uploads can
+		// be investigated after the fact.
+		if err := h.dbStore.UpdateUploadProcessingTrace(ctx, trace.stop()); err != nil {
+			log15.Warn("Failed to record upload processing trace", "err", err, "uploadID", upload.ID)
+		}
+	}()
+
 	getChildren := func(ctx context.Context, dirnames []string) (map[string][]string, error) {
 		directoryChildren, err := h.gitserverClient.DirectoryChildren(ctx, upload.RepositoryID, upload.Commit, dirnames)
 		if err != nil {
@@ -98,14 +107,24 @@ func (h *handler) handle(ctx context.Context, upload store.Upload) (requeued boo
 	}
 
 	return false, withUploadData(ctx, h.uploadStore, upload.ID, func(r io.Reader) (err error) {
-		groupedBundleData, err := conversion.Correlate(ctx, r, upload.Root, getChildren)
+		var timings conversion.CorrelationTimings
+		correlateStart := time.Now()
+		groupedBundleData, err := conversion.CorrelateWithTimings(ctx, r, upload.Root, getChildren, &timings)
+		trace.addPhase("parse", correlateStart, timings.Parse)
+		// Correlation does not start if parsing fails
+		if timings.Correlate > 0 {
+			trace.addPhase("correlate", correlateStart.Add(timings.Parse), timings.Correlate)
+		}
 		if err != nil {
 			return errors.Wrap(err, "conversion.Correlate")
 		}
 
 		// Note: this is writing to a different database than the block below, so we need to use a
 		// different transaction context (managed by the writeData function).
-		if err := writeData(ctx, h.lsifStore, upload.ID, groupedBundleData); err != nil {
+		writeStart := time.Now()
+		err = writeData(ctx, h.lsifStore, upload.ID, groupedBundleData)
+		trace.timePhase("write", writeStart)
+		if err != nil {
 			if isUniqueConstraintViolation(err) {
 				// If this is a unique constraint violation, then we've previously processed this same
 				// upload record up to this point, but failed to perform the transaction below. We can
@@ -121,6 +140,9 @@ func (h *handler) handle(ctx context.Context, upload store.Upload) (requeued boo
 		// point fails, we want to update the upload record with an error message but do not want to
 		// alter any other data in the database. Rolling back to this savepoint will allow us to discard
 		// any other changes but still commit the transaction as a whole.
+		commitStart := time.Now()
+		defer trace.timePhase("commit", commitStart)
+
 		return inTransaction(ctx, h.dbStore, func(tx DBStore) error {
 			// Find the date of the commit and store that in the upload record. We do this now as we
 			// will need to find the _oldest_ commit with code intelligence data to efficiently update
@@ -13,9 +13,9 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
-	uploadstoremocks "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore/mocks"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/types"
+	uploadstoremocks "github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
 	"github.com/sourcegraph/sourcegraph/internal/vcs"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/bloomfilter"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
@@ -146,6 +146,29 @@ func TestHandle(t *testing.T) {
 	if len(mockUploadStore.DeleteFunc.History()) != 1 {
 		t.Errorf("unexpected number of Delete calls. want=%d have=%d", 1, len(mockUploadStore.DeleteFunc.History()))
 	}
+
+	if calls := mockDBStore.UpdateUploadProcessingTraceFunc.History(); len(calls) != 1 {
+		t.Errorf("unexpected number of UpdateUploadProcessingTrace calls. want=%d have=%d", 1, len(calls))
+	} else {
+		trace := calls[0].Arg1
+		if trace.UploadID != 42 {
+			t.Errorf("unexpected value for upload id. want=%d have=%d", 42, trace.UploadID)
+		}
+
+		var names []string
+		for _, phase := range trace.Phases {
+			names = append(names, phase.Name)
+			if phase.Start+phase.Duration > trace.Duration {
+				t.Errorf("phase %s ends after processing. end=%s duration=%s", phase.Name, phase.Start+phase.Duration, trace.Duration)
+			}
+		}
+		if diff := cmp.Diff([]string{"parse", "correlate", "write", "commit"}, names); diff != "" {
+			t.Errorf("unexpected phases (-want +got):\n%s", diff)
+		}
+		if trace.PeakMemoryBytes <= 0 {
+			t.Errorf("expected peak memory to be sampled")
+		}
+	}
 }
 
 func TestHandleError(t *testing.T) {
@@ -203,6 +226,10 @@ func TestHandleError(t *testing.T) {
 	if len(mockUploadStore.DeleteFunc.History()) != 0 {
 		t.Errorf("unexpected number of Delete calls. want=%d have=%d", 0, len(mockUploadStore.DeleteFunc.History()))
 	}
+
+	if len(mockDBStore.UpdateUploadProcessingTraceFunc.History()) != 1 {
+		t.Errorf("unexpected number of UpdateUploadProcessingTrace calls. want=%d have=%d", 1, len(mockDBStore.UpdateUploadProcessingTraceFunc.History()))
+	}
 }
 
 func TestHandleCloneInProgress(t *testing.T) {
@@ -252,6 +279,10 @@ func TestHandleCloneInProgress(t *testing.T) {
 	if len(mockWorkerStore.RequeueFunc.History()) != 1 {
 		t.Errorf("unexpected number of Requeue calls. want=%d have=%d", 1, len(mockWorkerStore.RequeueFunc.History()))
 	}
+
+	if len(mockDBStore.UpdateUploadProcessingTraceFunc.History()) != 0 {
+		t.Errorf("unexpected number of UpdateUploadProcessingTrace calls. want=%d have=%d", 0, len(mockDBStore.UpdateUploadProcessingTraceFunc.History()))
+	}
 }
 
 //
@@ -26,6 +26,7 @@ type DBStore interface {
 	DeleteOverlappingDumps(ctx context.Context, repositoryID int, commit, root, indexer string) error
 	InsertDependencyIndexingJob(ctx context.Context, uploadID int) (int, error)
 	UpdateCommitedAt(ctx context.Context, dumpID int, committedAt time.Time) error
+	UpdateUploadProcessingTrace(ctx context.Context, trace dbstore.UploadProcessingTrace) error
 }
 
 type DBStoreShim struct {
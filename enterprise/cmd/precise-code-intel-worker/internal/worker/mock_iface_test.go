@@ -7,6 +7,7 @@ import (
 	"sync"
 	"time"
 
+	dbstore "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
 	api "github.com/sourcegraph/sourcegraph/internal/api"
 	basestore "github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	semantic "github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
@@ -48,6 +49,10 @@ type MockDBStore struct {
 	// UpdatePackagesFunc is an instance of a mock function object
 	// controlling the behavior of the method UpdatePackages.
 	UpdatePackagesFunc *DBStoreUpdatePackagesFunc
+	// UpdateUploadProcessingTraceFunc is an instance of a mock function
+	// object controlling the behavior of the method
+	// UpdateUploadProcessingTrace.
+	UpdateUploadProcessingTraceFunc *DBStoreUpdateUploadProcessingTraceFunc
 	// WithFunc is an instance of a mock function object controlling the
 	// behavior of the method With.
 	WithFunc *DBStoreWithFunc
@@ -107,6 +112,11 @@ func NewMockDBStore() *MockDBStore {
 				return nil
 			},
 		},
+		UpdateUploadProcessingTraceFunc: &DBStoreUpdateUploadProcessingTraceFunc{
+			defaultHook: func(context.Context, dbstore.UploadProcessingTrace) error {
+				return nil
+			},
+		},
 		WithFunc: &DBStoreWithFunc{
 			defaultHook: func(basestore.ShareableStore) DBStore {
 				return nil
@@ -149,6 +159,9 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		UpdatePackagesFunc: &DBStoreUpdatePackagesFunc{
 			defaultHook: i.UpdatePackages,
 		},
+		UpdateUploadProcessingTraceFunc: &DBStoreUpdateUploadProcessingTraceFunc{
+			defaultHook: i.UpdateUploadProcessingTrace,
+		},
 		WithFunc: &DBStoreWithFunc{
 			defaultHook: i.With,
 		},
@@ -1234,6 +1247,115 @@ func (c DBStoreUpdatePackagesFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
+// DBStoreUpdateUploadProcessingTraceFunc describes the behavior when the
+// UpdateUploadProcessingTrace method of the parent MockDBStore instance is
+// invoked.
+type DBStoreUpdateUploadProcessingTraceFunc struct {
+	defaultHook func(context.Context, dbstore.UploadProcessingTrace) error
+	hooks       []func(context.Context, dbstore.UploadProcessingTrace) error
+	history     []DBStoreUpdateUploadProcessingTraceFuncCall
+	mutex       sync.Mutex
+}
+
+// UpdateUploadProcessingTrace delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockDBStore) UpdateUploadProcessingTrace(v0 context.Context, v1 dbstore.UploadProcessingTrace) error {
+	r0 := m.UpdateUploadProcessingTraceFunc.nextHook()(v0, v1)
+	m.UpdateUploadProcessingTraceFunc.appendCall(DBStoreUpdateUploadProcessingTraceFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the
+// UpdateUploadProcessingTrace method of the parent MockDBStore instance is
+// invoked and the hook queue is empty.
+func (f *DBStoreUpdateUploadProcessingTraceFunc) SetDefaultHook(hook func(context.Context, dbstore.UploadProcessingTrace) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UpdateUploadProcessingTrace method of the parent MockDBStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *DBStoreUpdateUploadProcessingTraceFunc) PushHook(hook func(context.Context, dbstore.UploadProcessingTrace) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreUpdateUploadProcessingTraceFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, dbstore.UploadProcessingTrace) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreUpdateUploadProcessingTraceFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, dbstore.UploadProcessingTrace) error {
+		return r0
+	})
+}
+
+func (f *DBStoreUpdateUploadProcessingTraceFunc) nextHook() func(context.Context, dbstore.UploadProcessingTrace) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreUpdateUploadProcessingTraceFunc) appendCall(r0 DBStoreUpdateUploadProcessingTraceFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreUpdateUploadProcessingTraceFuncCall
+// objects describing the invocations of this function.
+func (f *DBStoreUpdateUploadProcessingTraceFunc) History() []DBStoreUpdateUploadProcessingTraceFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreUpdateUploadProcessingTraceFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreUpdateUploadProcessingTraceFuncCall is an object that describes an
+// invocation of method UpdateUploadProcessingTrace on an instance of
+// MockDBStore.
+type DBStoreUpdateUploadProcessingTraceFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 dbstore.UploadProcessingTrace
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreUpdateUploadProcessingTraceFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreUpdateUploadProcessingTraceFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // DBStoreWithFunc describes the behavior when the With method of the parent
 // MockDBStore instance is invoked.
 type DBStoreWithFunc struct {
@@ -0,0 +1,96 @@
+package worker
+
+import (
+	"runtime"
+	"sync"
+	"time"
+
+	store "github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+)
+
+// MemorySampleInterval is the duration between samples of the heap size of the worker while an
+// upload is being processed.
+const MemorySampleInterval = time.Second
+
+// processingTrace records the phases of processing an upload and the peak heap size of the worker
+// while it is processed.
+type processingTrace struct {
+	uploadID int
+	start    time.Time
+	phases   []store.UploadProcessingPhase
+
+	mu         sync.Mutex
+	peakMemory int64
+	done       chan struct{}
+	stopped    chan struct{}
+}
+
+// newProcessingTrace starts a trace of processing the given upload. The caller must call stop.
+func newProcessingTrace(uploadID int, interval time.Duration) *processingTrace {
+	t := &processingTrace{
+		uploadID: uploadID,
+		start:    time.Now(),
+		done:     make(chan struct{}),
+		stopped:  make(chan struct{}),
+	}
+	t.sampleMemory()
+
+	go func() {
+		defer close(t.stopped)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-ticker.C:
+				t.sampleMemory()
+			case <-t.done:
+				return
+			}
+		}
+	}()
+
+	return t
+}
+
+func (t *processingTrace) sampleMemory() {
+	var stats runtime.MemStats
+	runtime.ReadMemStats(&stats)
+
+	t.mu.Lock()
+	if heap := int64(stats.HeapInuse); heap > t.peakMemory {
+		t.peakMemory = heap
+	}
+	t.mu.Unlock()
+}
+
+// addPhase records a phase that started at the given time and took the given duration.
+func (t *processingTrace) addPhase(name string, start time.Time, duration time.Duration) {
+	t.phases = append(t.phases, store.UploadProcessingPhase{
+		Name:     name,
+		Start:    start.Sub(t.start),
+		Duration: duration,
+	})
+}
+
+// timePhase records a phase that started at the given time and ends now.
+func (t *processingTrace) timePhase(name string, start time.Time) {
+	t.addPhase(name, start, time.Since(start))
+}
+
+// stop stops sampling the heap size and returns the trace.
+func (t *processingTrace) stop() store.UploadProcessingTrace {
+	close(t.done)
+	<-t.stopped
+	t.sampleMemory()
+
+	now := time.Now()
+	return store.UploadProcessingTrace{
+		UploadID:        t.uploadID,
+		Phases:          t.phases,
+		Duration:        now.Sub(t.start),
+		PeakMemoryBytes: t.peakMemory,
+		RecordedAt:      now.UTC(),
+	}
+}
@@ -18,7 +18,6 @@ import (
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/gitserver"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
-	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
@@ -32,6 +31,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/tracer"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/version/skew"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	dbworkerstore "github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 )
@@ -77,7 +78,7 @@ func main() {
 	close(ready)
 
 	// Initialize stores
-	dbStore := dbstore.NewWithDB(db, observationContext)
+	dbStore := dbstore.NewWithDB(db, observationContext).WithCopyBatchSize(config.CopyBatchSize)
 	workerStore := dbstore.WorkerutilUploadStore(dbStore, observationContext)
 	lsifStore := lsifstore.NewStore(codeIntelDB, observationContext)
 	gitserverClient := gitserver.New(dbStore, observationContext)
@@ -129,6 +130,9 @@ func mustInitializeDB() *sql.DB {
 	if err := dbconn.SetupGlobalConnection(opts); err != nil {
 		log.Fatalf("Failed to connect to frontend database: %s", err)
 	}
+	if err := skew.Register(context.Background(), dbconn.Global, "precise-code-intel-worker"); err != nil {
+		log.Fatal(err)
+	}
 
 	//
 	// START FLAILING
@@ -184,6 +184,7 @@ func (s *PermsSyncer) syncUserPerms(ctx context.Context, userID int32, noPerms b
 	defer save(requestTypeUser, userID, &err)
 
 	accounts := database.ExternalAccountsWith(s.reposStore)
+	subRepoPerms := edb.SubRepoPerms(s.permsStore.Handle().DB())
 
 	user, err := database.GlobalUsers.GetByID(ctx, userID)
 	if err != nil {
@@ -274,6 +275,13 @@ func (s *PermsSyncer) syncUserPerms(ctx context.Context, userID int32, noPerms b
 
 	var repoSpecs, includePrefixSpecs, excludePrefixSpecs []api.ExternalRepoSpec
 
+	// subRepoPermsRepos holds the external IDs of the repositories with sub-repo
+	// permissions, per authz provider that returned the user's permissions.
+	// Providers that only returned partial results are tracked separately, as
+	// their results can't tell which rules are stale.
+	subRepoPermsRepos := make(map[authz.Provider][]string)
+	partialProviders := make(map[authz.Provider]bool)
+
 	for _, accountOrService := range accountsOrServices {
 		var extIDs *authz.ExternalUserPermissions
 		var provider authz.Provider
@@ -318,6 +326,7 @@ func (s *PermsSyncer) syncUserPerms(ctx context.Context, userID int32, noPerms b
 					return errors.Wrap(err, "fetch user permissions")
 				}
 				log15.Warn("PermsSyncer.syncUserPerms.proceedWithPartialResults", "userID", user.ID, "error", err)
+				partialProviders[provider] = true
 			} else {
 				err = accounts.TouchLastValid(ctx, v.ID)
 				if err != nil {
@@ -360,6 +369,21 @@ func (s *PermsSyncer) syncUserPerms(ctx context.Context, userID int32, noPerms b
 			continue
 		}
 
+		subRepoIDs := subRepoPermsRepos[provider]
+		for repoID, perms := range extIDs.SubRepoPermissions {
+			spec := api.ExternalRepoSpec{
+				ID:          string(repoID),
+				ServiceType: provider.ServiceType(),
+				ServiceID:   provider.ServiceID(),
+			}
+			err = subRepoPerms.UpsertWithSpec(ctx, user.ID, spec, *perms)
+			if err != nil {
+				return errors.Wrapf(err, "upserting sub repo permissions for repo %q", repoID)
+			}
+			subRepoIDs = append(subRepoIDs, string(repoID))
+		}
+		subRepoPermsRepos[provider] = subRepoIDs
+
 		if len(extIDs.Exacts) > 0 {
 			for _, exact := range extIDs.Exacts {
 				repoSpecs = append(repoSpecs,
@@ -395,6 +419,18 @@ func (s *PermsSyncer) syncUserPerms(ctx context.Context, userID int32, noPerms b
 		}
 	}
 
+	// Remove the sub-repo permissions that code hosts no longer report, e.g.
+	// because the user's access to a repository is no longer restricted.
+	for provider, externalIDs := range subRepoPermsRepos {
+		if partialProviders[provider] {
+			continue
+		}
+		err = subRepoPerms.DeleteByUserExcept(ctx, user.ID, provider.ServiceType(), provider.ServiceID(), externalIDs)
+		if err != nil {
+			return errors.Wrapf(err, "deleting stale sub repo permissions for %q", provider.ServiceID())
+		}
+	}
+
 	// Get corresponding internal database IDs
 	var repoNames []types.RepoName
 	if len(repoSpecs) > 0 {
@@ -704,8 +740,9 @@ func (s *PermsSyncer) scheduleReposWithNoPerms(ctx context.Context) ([]scheduled
 }
 
 // scheduleUsersWithOldestPerms returns computed schedules for users who have oldest
-// permissions in database and capped results by the limit.
-func (s *PermsSyncer) scheduleUsersWithOldestPerms(ctx context.Context, limit int) ([]scheduledUser, error) {
+// permissions in database and capped results by the limit. Users whose permissions
+// are still fresh are not scheduled.
+func (s *PermsSyncer) scheduleUsersWithOldestPerms(ctx context.Context, limit int, freshness authz.PermissionsFreshness) ([]scheduledUser, error) {
 	results, err := s.permsStore.UserIDsWithOldestPerms(ctx, limit)
 	if err != nil {
 		return nil, err
@@ -713,8 +750,12 @@ func (s *PermsSyncer) scheduleUsersWithOldestPerms(ctx context.Context, limit in
 
 	users := make([]scheduledUser, 0, len(results))
 	for id, t := range results {
+		p, stale := refreshPriority(freshness, s.clock(), t)
+		if !stale {
+			continue
+		}
 		users = append(users, scheduledUser{
-			priority:   priorityLow,
+			priority:   p,
 			userID:     id,
 			nextSyncAt: t,
 		})
@@ -723,8 +764,9 @@ func (s *PermsSyncer) scheduleUsersWithOldestPerms(ctx context.Context, limit in
 }
 
 // scheduleReposWithOldestPerms returns computed schedules for private repositories that
-// have oldest permissions in database.
-func (s *PermsSyncer) scheduleReposWithOldestPerms(ctx context.Context, limit int) ([]scheduledRepo, error) {
+// have oldest permissions in database. Repositories whose permissions are still fresh
+// are not scheduled.
+func (s *PermsSyncer) scheduleReposWithOldestPerms(ctx context.Context, limit int, freshness authz.PermissionsFreshness) ([]scheduledRepo, error) {
 	results, err := s.permsStore.ReposIDsWithOldestPerms(ctx, limit)
 	if err != nil {
 		return nil, err
@@ -732,8 +774,12 @@ func (s *PermsSyncer) scheduleReposWithOldestPerms(ctx context.Context, limit in
 
 	repos := make([]scheduledRepo, 0, len(results))
 	for id, t := range results {
+		p, stale := refreshPriority(freshness, s.clock(), t)
+		if !stale {
+			continue
+		}
 		repos = append(repos, scheduledRepo{
-			priority:   priorityLow,
+			priority:   p,
 			repoID:     id,
 			nextSyncAt: t,
 		})
@@ -741,6 +787,25 @@ func (s *PermsSyncer) scheduleReposWithOldestPerms(ctx context.Context, limit in
 	return repos, nil
 }
 
+// refreshPriority returns whether permissions last synced at syncedAt are stale, and
+// the priority to refresh them with. Permissions younger than the TTL are fresh and
+// not refreshed. Stale permissions keep being enforced while they are refreshed in
+// the background, unless they are older than the max staleness, in which case they
+// no longer grant access and are refreshed with high priority.
+func refreshPriority(freshness authz.PermissionsFreshness, now, syncedAt time.Time) (priority, bool) {
+	if syncedAt.IsZero() {
+		return priorityLow, true
+	}
+	age := now.Sub(syncedAt)
+	if age < freshness.TTL {
+		return priorityLow, false
+	}
+	if freshness.MaxStaleness > 0 && age >= freshness.MaxStaleness {
+		return priorityHigh, true
+	}
+	return priorityLow, true
+}
+
 // schedule contains information for scheduling users and repositories.
 type schedule struct {
 	Users []scheduledUser
@@ -803,13 +868,19 @@ func (s *PermsSyncer) schedule(ctx context.Context) (*schedule, error) {
 	// TODO(jchen): Use better heuristics for setting NextSyncAt, the initial version
 	// just uses the value of LastUpdatedAt get from the perms tables.
 
-	users, err = s.scheduleUsersWithOldestPerms(ctx, limit)
+	// Permissions of users are synced from all code hosts, and we don't
+	// distinguish the code hosts of repositories here, so the strictest
+	// freshness configured for any code host applies.
+	_, providers := authz.GetProviders()
+	freshness := authz.StrictestPermissionsFreshness(providers)
+
+	users, err = s.scheduleUsersWithOldestPerms(ctx, limit, freshness)
 	if err != nil {
 		return nil, errors.Wrap(err, "load users with oldest permissions")
 	}
 	schedule.Users = append(schedule.Users, users...)
 
-	repos, err = s.scheduleReposWithOldestPerms(ctx, limit)
+	repos, err = s.scheduleReposWithOldestPerms(ctx, limit, freshness)
 	if err != nil {
 		return nil, errors.Wrap(err, "scan repositories with oldest permissions")
 	}
@@ -148,9 +148,18 @@ func TestPermsSyncer_syncUserPerms(t *testing.T) {
 	database.Mocks.ExternalServices.List = func(opt database.ExternalServicesListOptions) ([]*types.ExternalService, error) {
 		return []*types.ExternalService{extService}, nil
 	}
+	var deletedSubRepoPerms bool
+	edb.Mocks.SubRepoPerms.DeleteByUserExcept = func(_ context.Context, userID int32, serviceType, serviceID string, externalIDs []string) error {
+		if serviceID != "https://gitlab.com/" || len(externalIDs) != 0 {
+			return errors.Errorf("unexpected deletion of sub repo permissions for %q except %v", serviceID, externalIDs)
+		}
+		deletedSubRepoPerms = true
+		return nil
+	}
 	defer func() {
 		database.Mocks = database.MockStores{}
 		edb.Mocks.Perms = edb.MockPerms{}
+		edb.Mocks.SubRepoPerms = edb.MockSubRepoPerms{}
 	}()
 
 	permsStore := edb.Perms(nil, timeutil.Now)
@@ -184,10 +193,16 @@ func TestPermsSyncer_syncUserPerms(t *testing.T) {
 				}, test.fetchErr
 			}
 
+			deletedSubRepoPerms = false
 			err := s.syncUserPerms(context.Background(), 1, test.noPerms)
 			if err != nil {
 				t.Fatal(err)
 			}
+
+			// Stale sub-repo permissions can only be told from complete results.
+			if want := test.fetchErr == nil; deletedSubRepoPerms != want {
+				t.Fatalf("deleted sub repo permissions: want %v but got %v", want, deletedSubRepoPerms)
+			}
 		})
 	}
 }
@@ -210,6 +225,9 @@ func TestPermsSyncer_syncUserPerms_tokenExpire(t *testing.T) {
 	database.Mocks.Users.GetByID = func(ctx context.Context, id int32) (*types.User, error) {
 		return &types.User{ID: id}, nil
 	}
+	edb.Mocks.SubRepoPerms.DeleteByUserExcept = func(context.Context, int32, string, string, []string) error {
+		return nil
+	}
 	edb.Mocks.Perms.ListExternalAccounts = func(context.Context, int32) ([]*extsvc.Account, error) {
 		return []*extsvc.Account{&extAccount}, nil
 	}
@@ -231,6 +249,7 @@ func TestPermsSyncer_syncUserPerms_tokenExpire(t *testing.T) {
 	defer func() {
 		database.Mocks = database.MockStores{}
 		edb.Mocks.Perms = edb.MockPerms{}
+		edb.Mocks.SubRepoPerms = edb.MockSubRepoPerms{}
 	}()
 
 	permsStore := edb.Perms(nil, timeutil.Now)
@@ -325,6 +344,9 @@ func TestPermsSyncer_syncUserPerms_prefixSpecs(t *testing.T) {
 	database.Mocks.ExternalAccounts.TouchLastValid = func(ctx context.Context, id int32) error {
 		return nil
 	}
+	edb.Mocks.SubRepoPerms.DeleteByUserExcept = func(context.Context, int32, string, string, []string) error {
+		return nil
+	}
 	edb.Mocks.Perms.ListExternalAccounts = func(context.Context, int32) ([]*extsvc.Account, error) {
 		return []*extsvc.Account{&extAccount}, nil
 	}
@@ -350,6 +372,7 @@ func TestPermsSyncer_syncUserPerms_prefixSpecs(t *testing.T) {
 	defer func() {
 		database.Mocks = database.MockStores{}
 		edb.Mocks.Perms = edb.MockPerms{}
+		edb.Mocks.SubRepoPerms = edb.MockSubRepoPerms{}
 	}()
 
 	permsStore := edb.Perms(nil, timeutil.Now)
@@ -605,6 +628,32 @@ func TestPermsSyncer_waitForRateLimit(t *testing.T) {
 	})
 }
 
+func TestRefreshPriority(t *testing.T) {
+	now := timeutil.Now()
+	freshness := authz.PermissionsFreshness{TTL: time.Hour, MaxStaleness: 24 * time.Hour}
+	for _, tc := range []struct {
+		name      string
+		freshness authz.PermissionsFreshness
+		syncedAt  time.Time
+		want      priority
+		wantStale bool
+	}{
+		{"never synced", freshness, time.Time{}, priorityLow, true},
+		{"fresh", freshness, now.Add(-time.Minute), priorityLow, false},
+		{"stale", freshness, now.Add(-time.Hour), priorityLow, true},
+		{"too stale", freshness, now.Add(-24 * time.Hour), priorityHigh, true},
+		{"no TTL", authz.PermissionsFreshness{}, now.Add(-time.Minute), priorityLow, true},
+		{"no max staleness", authz.PermissionsFreshness{TTL: time.Hour}, now.Add(-72 * time.Hour), priorityLow, true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			got, stale := refreshPriority(tc.freshness, now, tc.syncedAt)
+			if got != tc.want || stale != tc.wantStale {
+				t.Fatalf("got (%v, %t), want (%v, %t)", got, stale, tc.want, tc.wantStale)
+			}
+		})
+	}
+}
+
 func TestPermsSyncer_syncPerms(t *testing.T) {
 	request := &syncRequest{
 		requestMeta: &requestMeta{
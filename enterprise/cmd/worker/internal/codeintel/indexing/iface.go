@@ -42,6 +42,7 @@ type GitserverClient interface {
 	Head(ctx context.Context, repositoryID int) (string, bool, error)
 	ListFiles(ctx context.Context, repositoryID int, commit string, pattern *regexp.Regexp) ([]string, error)
 	FileExists(ctx context.Context, repositoryID int, commit, file string) (bool, error)
+	StatPaths(ctx context.Context, repositoryID int, commit string, paths []string) (map[string]bool, error)
 	RawContents(ctx context.Context, repositoryID int, commit, file string) ([]byte, error)
 	ResolveRevision(ctx context.Context, repositoryID int, versionString string) (api.CommitID, error)
 }
@@ -780,6 +780,9 @@ type MockGitserverClient struct {
 	// ResolveRevisionFunc is an instance of a mock function object
 	// controlling the behavior of the method ResolveRevision.
 	ResolveRevisionFunc *GitserverClientResolveRevisionFunc
+	// StatPathsFunc is an instance of a mock function object controlling the
+	// behavior of the method StatPaths.
+	StatPathsFunc *GitserverClientStatPathsFunc
 }
 
 // NewMockGitserverClient creates a new mock of the GitserverClient
@@ -812,6 +815,11 @@ func NewMockGitserverClient() *MockGitserverClient {
 				return "", nil
 			},
 		},
+		StatPathsFunc: &GitserverClientStatPathsFunc{
+			defaultHook: func(context.Context, int, string, []string) (map[string]bool, error) {
+				return nil, nil
+			},
+		},
 	}
 }
 
@@ -835,6 +843,9 @@ func NewMockGitserverClientFrom(i GitserverClient) *MockGitserverClient {
 		ResolveRevisionFunc: &GitserverClientResolveRevisionFunc{
 			defaultHook: i.ResolveRevision,
 		},
+		StatPathsFunc: &GitserverClientStatPathsFunc{
+			defaultHook: i.StatPaths,
+		},
 	}
 }
 
@@ -1409,6 +1420,117 @@ func (c GitserverClientResolveRevisionFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// GitserverClientStatPathsFunc describes the behavior when the StatPaths
+// method of the parent MockGitserverClient instance is invoked.
+type GitserverClientStatPathsFunc struct {
+	defaultHook func(context.Context, int, string, []string) (map[string]bool, error)
+	hooks       []func(context.Context, int, string, []string) (map[string]bool, error)
+	history     []GitserverClientStatPathsFuncCall
+	mutex       sync.Mutex
+}
+
+// StatPaths delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockGitserverClient) StatPaths(v0 context.Context, v1 int, v2 string, v3 []string) (map[string]bool, error) {
+	r0, r1 := m.StatPathsFunc.nextHook()(v0, v1, v2, v3)
+	m.StatPathsFunc.appendCall(GitserverClientStatPathsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the StatPaths method of
+// the parent MockGitserverClient instance is invoked and the hook queue is
+// empty.
+func (f *GitserverClientStatPathsFunc) SetDefaultHook(hook func(context.Context, int, string, []string) (map[string]bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// StatPaths method of the parent MockGitserverClient instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *GitserverClientStatPathsFunc) PushHook(hook func(context.Context, int, string, []string) (map[string]bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *GitserverClientStatPathsFunc) SetDefaultReturn(r0 map[string]bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, int, string, []string) (map[string]bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *GitserverClientStatPathsFunc) PushReturn(r0 map[string]bool, r1 error) {
+	f.PushHook(func(context.Context, int, string, []string) (map[string]bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitserverClientStatPathsFunc) nextHook() func(context.Context, int, string, []string) (map[string]bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitserverClientStatPathsFunc) appendCall(r0 GitserverClientStatPathsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitserverClientStatPathsFuncCall objects
+// describing the invocations of this function.
+func (f *GitserverClientStatPathsFunc) History() []GitserverClientStatPathsFuncCall {
+	f.mutex.Lock()
+	history := make([]GitserverClientStatPathsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitserverClientStatPathsFuncCall is an object that describes an
+// invocation of method StatPaths on an instance of MockGitserverClient.
+type GitserverClientStatPathsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method invocation.
+	Arg3 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitserverClientStatPathsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitserverClientStatPathsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // MockIndexEnqueuer is a mock implementation of the IndexEnqueuer interface
 // (from the package
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/codeintel/indexing)
@@ -0,0 +1,119 @@
+package janitor
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/lsifstore"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+type bundleEvicter struct {
+	dbStore     DBStore
+	lsifStore   LSIFStore
+	uploadStore UploadStore
+	maxIdle     time.Duration
+	metrics     *metrics
+}
+
+var _ goroutine.Handler = &bundleEvicter{}
+
+// NewBundleEvicter returns a background routine that periodically moves the code intelligence
+// data of uploads that have not been queried for the given duration from the codeintel database
+// to object storage. The data is restored on demand by the frontend when a query needs it. This
+// routine also removes the evicted data of deleted uploads from object storage.
+func NewBundleEvicter(dbStore DBStore, lsifStore LSIFStore, uploadStore UploadStore, maxIdle, interval time.Duration, metrics *metrics) goroutine.BackgroundRoutine {
+	return goroutine.NewPeriodicGoroutine(context.Background(), interval, &bundleEvicter{
+		dbStore:     dbStore,
+		lsifStore:   lsifStore,
+		uploadStore: uploadStore,
+		maxIdle:     maxIdle,
+		metrics:     metrics,
+	})
+}
+
+const evictionBatchSize = 10
+
+func (e *bundleEvicter) Handle(ctx context.Context) error {
+	deletedIDs, err := e.dbStore.DeletedEvictedUploadIDs(ctx, uploadsBatchSize)
+	if err != nil {
+		return errors.Wrap(err, "DeletedEvictedUploadIDs")
+	}
+
+	for _, id := range deletedIDs {
+		if err := e.uploadStore.Delete(ctx, lsifstore.EvictedBundleKey(id)); err != nil {
+			return errors.Wrap(err, "uploadStore.Delete")
+		}
+
+		if err := e.dbStore.UnmarkUploadEvicted(ctx, id); err != nil {
+			return errors.Wrap(err, "UnmarkUploadEvicted")
+		}
+	}
+
+	ids, err := e.dbStore.ColdUploadIDs(ctx, e.maxIdle, evictionBatchSize, time.Now())
+	if err != nil {
+		return errors.Wrap(err, "ColdUploadIDs")
+	}
+
+	for _, id := range ids {
+		size, err := e.evict(ctx, id)
+		if err != nil {
+			return errors.Wrapf(err, "failed to evict upload %d", id)
+		}
+
+		log15.Debug("Evicted code intelligence data of upload", "id", id, "size", size)
+		e.metrics.numBundlesEvicted.Inc()
+		e.metrics.numBytesEvicted.Add(float64(size))
+	}
+
+	return nil
+}
+
+func (e *bundleEvicter) HandleError(err error) {
+	e.metrics.numErrors.Inc()
+	log15.Error("Failed to evict code intelligence data", "error", err)
+}
+
+// evict writes the data of the given upload to object storage, then removes it from the
+// codeintel database. It returns the size of the stored (compressed) data.
+func (e *bundleEvicter) evict(ctx context.Context, id int) (_ int64, err error) {
+	pr, pw := io.Pipe()
+	go func() {
+		gzipWriter := gzip.NewWriter(pw)
+		err := e.lsifStore.ExportBundle(ctx, id, gzipWriter)
+		if closeErr := gzipWriter.Close(); err == nil {
+			err = closeErr
+		}
+		pw.CloseWithError(err)
+	}()
+
+	size, err := e.uploadStore.Upload(ctx, lsifstore.EvictedBundleKey(id), pr)
+	// Unblock the exporting goroutine if the upload did not consume all of its output
+	pr.CloseWithError(errors.New("upload finished"))
+	if err != nil {
+		return 0, errors.Wrap(err, "uploadStore.Upload")
+	}
+
+	tx, err := e.dbStore.Transact(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	// Mark the upload as evicted in the same transaction that deletes its data so that a
+	// failure to delete the data does not leave the upload marked as evicted.
+	if err := tx.MarkUploadEvicted(ctx, id, time.Now()); err != nil {
+		return 0, errors.Wrap(err, "MarkUploadEvicted")
+	}
+
+	if err := e.lsifStore.DeleteBundle(ctx, id); err != nil {
+		return 0, errors.Wrap(err, "DeleteBundle")
+	}
+
+	return size, nil
+}
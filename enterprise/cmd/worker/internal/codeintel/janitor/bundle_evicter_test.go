@@ -0,0 +1,120 @@
+package janitor
+
+import (
+	"compress/gzip"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestBundleEvicter(t *testing.T) {
+	dbStore := NewMockDBStore()
+	dbStore.TransactFunc.SetDefaultReturn(dbStore, nil)
+	dbStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+	dbStore.DeletedEvictedUploadIDsFunc.SetDefaultReturn([]int{7}, nil)
+	dbStore.ColdUploadIDsFunc.SetDefaultReturn([]int{1, 2}, nil)
+
+	lsifStore := NewMockLSIFStore()
+	lsifStore.ExportBundleFunc.SetDefaultHook(func(ctx context.Context, bundleID int, w io.Writer) error {
+		_, err := io.WriteString(w, `{"table":"lsif_data_metadata","row":{}}`+"\n")
+		return err
+	})
+
+	uploaded := map[string]string{}
+	uploadStore := NewMockUploadStore()
+	uploadStore.UploadFunc.SetDefaultHook(func(ctx context.Context, key string, r io.Reader) (int64, error) {
+		gzipReader, err := gzip.NewReader(r)
+		if err != nil {
+			return 0, err
+		}
+		contents, err := io.ReadAll(gzipReader)
+		if err != nil {
+			return 0, err
+		}
+		uploaded[key] = string(contents)
+		return int64(len(contents)), nil
+	})
+
+	evicter := &bundleEvicter{
+		dbStore:     dbStore,
+		lsifStore:   lsifStore,
+		uploadStore: uploadStore,
+		maxIdle:     time.Hour,
+		metrics:     newMetrics(&observation.TestContext),
+	}
+	if err := evicter.Handle(context.Background()); err != nil {
+		t.Fatalf("unexpected error evicting bundles: %s", err)
+	}
+
+	expectedUploads := map[string]string{
+		"evicted-bundle-1.json.gz": `{"table":"lsif_data_metadata","row":{}}` + "\n",
+		"evicted-bundle-2.json.gz": `{"table":"lsif_data_metadata","row":{}}` + "\n",
+	}
+	if diff := cmp.Diff(expectedUploads, uploaded); diff != "" {
+		t.Errorf("unexpected uploads (-want +got):\n%s", diff)
+	}
+
+	var evictedIDs []int
+	for _, call := range dbStore.MarkUploadEvictedFunc.History() {
+		evictedIDs = append(evictedIDs, call.Arg1)
+	}
+	if diff := cmp.Diff([]int{1, 2}, evictedIDs); diff != "" {
+		t.Errorf("unexpected evicted uploads (-want +got):\n%s", diff)
+	}
+
+	var deletedBundleIDs []int
+	for _, call := range lsifStore.DeleteBundleFunc.History() {
+		deletedBundleIDs = append(deletedBundleIDs, call.Arg1)
+	}
+	if diff := cmp.Diff([]int{1, 2}, deletedBundleIDs); diff != "" {
+		t.Errorf("unexpected deleted bundles (-want +got):\n%s", diff)
+	}
+
+	if calls := uploadStore.DeleteFunc.History(); len(calls) != 1 || calls[0].Arg1 != "evicted-bundle-7.json.gz" {
+		t.Errorf("unexpected calls to Delete: %v", calls)
+	}
+	if calls := dbStore.UnmarkUploadEvictedFunc.History(); len(calls) != 1 || calls[0].Arg1 != 7 {
+		t.Errorf("unexpected calls to UnmarkUploadEvicted: %v", calls)
+	}
+}
+
+func TestBundleEvicterUploadError(t *testing.T) {
+	dbStore := NewMockDBStore()
+	dbStore.TransactFunc.SetDefaultReturn(dbStore, nil)
+	dbStore.DoneFunc.SetDefaultHook(func(err error) error { return err })
+	dbStore.ColdUploadIDsFunc.SetDefaultReturn([]int{1}, nil)
+
+	lsifStore := NewMockLSIFStore()
+	lsifStore.ExportBundleFunc.SetDefaultHook(func(ctx context.Context, bundleID int, w io.Writer) error {
+		_, err := w.Write(make([]byte, 1<<20))
+		return err
+	})
+
+	// The upload fails without consuming the export
+	uploadStore := NewMockUploadStore()
+	uploadStore.UploadFunc.SetDefaultReturn(0, errors.New("oops"))
+
+	evicter := &bundleEvicter{
+		dbStore:     dbStore,
+		lsifStore:   lsifStore,
+		uploadStore: uploadStore,
+		maxIdle:     time.Hour,
+		metrics:     newMetrics(&observation.TestContext),
+	}
+	if err := evicter.Handle(context.Background()); err == nil {
+		t.Fatalf("expected error evicting bundles")
+	}
+
+	if calls := dbStore.MarkUploadEvictedFunc.History(); len(calls) != 0 {
+		t.Errorf("unexpected calls to MarkUploadEvicted: %v", calls)
+	}
+	if calls := lsifStore.DeleteBundleFunc.History(); len(calls) != 0 {
+		t.Errorf("unexpected calls to DeleteBundle: %v", calls)
+	}
+}
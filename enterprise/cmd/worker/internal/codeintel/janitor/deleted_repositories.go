@@ -8,6 +8,8 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/inconshreveable/log15"
 
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 )
 
@@ -19,7 +21,8 @@ type deletedRepositoryJanitor struct {
 var _ goroutine.Handler = &deletedRepositoryJanitor{}
 
 // NewDeletedRepositoryJanitor returns a background routine that periodically
-// deletes upload and index records for repositories that have been soft-deleted.
+// deletes upload and index records for repositories that have been soft-deleted
+// and can no longer be restored.
 func NewDeletedRepositoryJanitor(dbStore DBStore, interval time.Duration, metrics *metrics) goroutine.BackgroundRoutine {
 	return goroutine.NewPeriodicGoroutine(context.Background(), interval, &deletedRepositoryJanitor{
 		dbStore: dbStore,
@@ -34,12 +37,18 @@ func (j *deletedRepositoryJanitor) Handle(ctx context.Context) (err error) {
 	}
 	defer func() { err = tx.Done(err) }()
 
-	uploadsCounts, err := tx.DeleteUploadsWithoutRepository(ctx, time.Now())
+	// Keep the records of deleted repositories while they can still be restored
+	gracePeriod := conf.RepoRestoreWindow()
+	if gracePeriod < dbstore.DeletedRepositoryGracePeriod {
+		gracePeriod = dbstore.DeletedRepositoryGracePeriod
+	}
+
+	uploadsCounts, err := tx.DeleteUploadsWithoutRepository(ctx, gracePeriod, time.Now())
 	if err != nil {
 		return errors.Wrap(err, "DeleteUploadsWithoutRepository")
 	}
 
-	indexesCounts, err := tx.DeleteIndexesWithoutRepository(ctx, time.Now())
+	indexesCounts, err := tx.DeleteIndexesWithoutRepository(ctx, gracePeriod, time.Now())
 	if err != nil {
 		return errors.Wrap(err, "DeleteIndexesWithoutRepository")
 	}
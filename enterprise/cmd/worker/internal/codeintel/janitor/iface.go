@@ -2,6 +2,7 @@ package janitor
 
 import (
 	"context"
+	"io"
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/dbstore"
@@ -16,15 +17,19 @@ type DBStore interface {
 	Done(err error) error
 
 	GetUploads(ctx context.Context, opts dbstore.GetUploadsOptions) ([]dbstore.Upload, int, error)
-	DeleteUploadsWithoutRepository(ctx context.Context, now time.Time) (map[int]int, error)
+	DeleteUploadsWithoutRepository(ctx context.Context, gracePeriod time.Duration, now time.Time) (map[int]int, error)
 	HardDeleteUploadByID(ctx context.Context, ids ...int) error
 	SoftDeleteOldUploads(ctx context.Context, maxAge time.Duration, now time.Time) (int, error)
 	DeleteOldIndexes(ctx context.Context, maxAge time.Duration, now time.Time) (int, error)
 	DirtyRepositories(ctx context.Context) (map[int]int, error)
-	DeleteIndexesWithoutRepository(ctx context.Context, now time.Time) (map[int]int, error)
+	DeleteIndexesWithoutRepository(ctx context.Context, gracePeriod time.Duration, now time.Time) (map[int]int, error)
 	DeleteUploadsStuckUploading(ctx context.Context, uploadedBefore time.Time) (int, error)
 	StaleSourcedCommits(ctx context.Context, threshold time.Duration, limit int, now time.Time) ([]dbstore.SourcedCommits, error)
 	RefreshCommitResolvability(ctx context.Context, repositoryID int, commit string, delete bool, now time.Time) (int, int, error)
+	ColdUploadIDs(ctx context.Context, maxIdle time.Duration, limit int, now time.Time) ([]int, error)
+	DeletedEvictedUploadIDs(ctx context.Context, limit int) ([]int, error)
+	MarkUploadEvicted(ctx context.Context, id int, now time.Time) error
+	UnmarkUploadEvicted(ctx context.Context, id int) error
 }
 
 type DBStoreShim struct {
@@ -42,4 +47,11 @@ func (s *DBStoreShim) Transact(ctx context.Context) (DBStore, error) {
 
 type LSIFStore interface {
 	Clear(ctx context.Context, bundleIDs ...int) error
+	ExportBundle(ctx context.Context, bundleID int, w io.Writer) error
+	DeleteBundle(ctx context.Context, bundleID int) error
+}
+
+type UploadStore interface {
+	Upload(ctx context.Context, key string, r io.Reader) (int64, error)
+	Delete(ctx context.Context, key string) error
 }
@@ -4,6 +4,7 @@ package janitor
 
 import (
 	"context"
+	"io"
 	"sync"
 	"time"
 
@@ -16,6 +17,9 @@ import (
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/codeintel/janitor)
 // used for unit testing.
 type MockDBStore struct {
+	// ColdUploadIDsFunc is an instance of a mock function object
+	// controlling the behavior of the method ColdUploadIDs.
+	ColdUploadIDsFunc *DBStoreColdUploadIDsFunc
 	// DeleteIndexesWithoutRepositoryFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// DeleteIndexesWithoutRepository.
@@ -31,6 +35,9 @@ type MockDBStore struct {
 	// object controlling the behavior of the method
 	// DeleteUploadsWithoutRepository.
 	DeleteUploadsWithoutRepositoryFunc *DBStoreDeleteUploadsWithoutRepositoryFunc
+	// DeletedEvictedUploadIDsFunc is an instance of a mock function object
+	// controlling the behavior of the method DeletedEvictedUploadIDs.
+	DeletedEvictedUploadIDsFunc *DBStoreDeletedEvictedUploadIDsFunc
 	// DirtyRepositoriesFunc is an instance of a mock function object
 	// controlling the behavior of the method DirtyRepositories.
 	DirtyRepositoriesFunc *DBStoreDirtyRepositoriesFunc
@@ -46,6 +53,9 @@ type MockDBStore struct {
 	// HardDeleteUploadByIDFunc is an instance of a mock function object
 	// controlling the behavior of the method HardDeleteUploadByID.
 	HardDeleteUploadByIDFunc *DBStoreHardDeleteUploadByIDFunc
+	// MarkUploadEvictedFunc is an instance of a mock function object
+	// controlling the behavior of the method MarkUploadEvicted.
+	MarkUploadEvictedFunc *DBStoreMarkUploadEvictedFunc
 	// RefreshCommitResolvabilityFunc is an instance of a mock function
 	// object controlling the behavior of the method
 	// RefreshCommitResolvability.
@@ -59,14 +69,22 @@ type MockDBStore struct {
 	// TransactFunc is an instance of a mock function object controlling the
 	// behavior of the method Transact.
 	TransactFunc *DBStoreTransactFunc
+	// UnmarkUploadEvictedFunc is an instance of a mock function object
+	// controlling the behavior of the method UnmarkUploadEvicted.
+	UnmarkUploadEvictedFunc *DBStoreUnmarkUploadEvictedFunc
 }
 
 // NewMockDBStore creates a new mock of the DBStore interface. All methods
 // return zero values for all results, unless overwritten.
 func NewMockDBStore() *MockDBStore {
 	return &MockDBStore{
+		ColdUploadIDsFunc: &DBStoreColdUploadIDsFunc{
+			defaultHook: func(context.Context, time.Duration, int, time.Time) ([]int, error) {
+				return nil, nil
+			},
+		},
 		DeleteIndexesWithoutRepositoryFunc: &DBStoreDeleteIndexesWithoutRepositoryFunc{
-			defaultHook: func(context.Context, time.Time) (map[int]int, error) {
+			defaultHook: func(context.Context, time.Duration, time.Time) (map[int]int, error) {
 				return nil, nil
 			},
 		},
@@ -81,7 +99,12 @@ func NewMockDBStore() *MockDBStore {
 			},
 		},
 		DeleteUploadsWithoutRepositoryFunc: &DBStoreDeleteUploadsWithoutRepositoryFunc{
-			defaultHook: func(context.Context, time.Time) (map[int]int, error) {
+			defaultHook: func(context.Context, time.Duration, time.Time) (map[int]int, error) {
+				return nil, nil
+			},
+		},
+		DeletedEvictedUploadIDsFunc: &DBStoreDeletedEvictedUploadIDsFunc{
+			defaultHook: func(context.Context, int) ([]int, error) {
 				return nil, nil
 			},
 		},
@@ -110,6 +133,11 @@ func NewMockDBStore() *MockDBStore {
 				return nil
 			},
 		},
+		MarkUploadEvictedFunc: &DBStoreMarkUploadEvictedFunc{
+			defaultHook: func(context.Context, int, time.Time) error {
+				return nil
+			},
+		},
 		RefreshCommitResolvabilityFunc: &DBStoreRefreshCommitResolvabilityFunc{
 			defaultHook: func(context.Context, int, string, bool, time.Time) (int, int, error) {
 				return 0, 0, nil
@@ -130,6 +158,11 @@ func NewMockDBStore() *MockDBStore {
 				return nil, nil
 			},
 		},
+		UnmarkUploadEvictedFunc: &DBStoreUnmarkUploadEvictedFunc{
+			defaultHook: func(context.Context, int) error {
+				return nil
+			},
+		},
 	}
 }
 
@@ -137,6 +170,9 @@ func NewMockDBStore() *MockDBStore {
 // methods delegate to the given implementation, unless overwritten.
 func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 	return &MockDBStore{
+		ColdUploadIDsFunc: &DBStoreColdUploadIDsFunc{
+			defaultHook: i.ColdUploadIDs,
+		},
 		DeleteIndexesWithoutRepositoryFunc: &DBStoreDeleteIndexesWithoutRepositoryFunc{
 			defaultHook: i.DeleteIndexesWithoutRepository,
 		},
@@ -149,6 +185,9 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		DeleteUploadsWithoutRepositoryFunc: &DBStoreDeleteUploadsWithoutRepositoryFunc{
 			defaultHook: i.DeleteUploadsWithoutRepository,
 		},
+		DeletedEvictedUploadIDsFunc: &DBStoreDeletedEvictedUploadIDsFunc{
+			defaultHook: i.DeletedEvictedUploadIDs,
+		},
 		DirtyRepositoriesFunc: &DBStoreDirtyRepositoriesFunc{
 			defaultHook: i.DirtyRepositories,
 		},
@@ -164,6 +203,9 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		HardDeleteUploadByIDFunc: &DBStoreHardDeleteUploadByIDFunc{
 			defaultHook: i.HardDeleteUploadByID,
 		},
+		MarkUploadEvictedFunc: &DBStoreMarkUploadEvictedFunc{
+			defaultHook: i.MarkUploadEvicted,
+		},
 		RefreshCommitResolvabilityFunc: &DBStoreRefreshCommitResolvabilityFunc{
 			defaultHook: i.RefreshCommitResolvability,
 		},
@@ -176,31 +218,149 @@ func NewMockDBStoreFrom(i DBStore) *MockDBStore {
 		TransactFunc: &DBStoreTransactFunc{
 			defaultHook: i.Transact,
 		},
+		UnmarkUploadEvictedFunc: &DBStoreUnmarkUploadEvictedFunc{
+			defaultHook: i.UnmarkUploadEvicted,
+		},
+	}
+}
+
+// DBStoreColdUploadIDsFunc describes the behavior when the ColdUploadIDs
+// method of the parent MockDBStore instance is invoked.
+type DBStoreColdUploadIDsFunc struct {
+	defaultHook func(context.Context, time.Duration, int, time.Time) ([]int, error)
+	hooks       []func(context.Context, time.Duration, int, time.Time) ([]int, error)
+	history     []DBStoreColdUploadIDsFuncCall
+	mutex       sync.Mutex
+}
+
+// ColdUploadIDs delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockDBStore) ColdUploadIDs(v0 context.Context, v1 time.Duration, v2 int, v3 time.Time) ([]int, error) {
+	r0, r1 := m.ColdUploadIDsFunc.nextHook()(v0, v1, v2, v3)
+	m.ColdUploadIDsFunc.appendCall(DBStoreColdUploadIDsFuncCall{v0, v1, v2, v3, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the ColdUploadIDs method
+// of the parent MockDBStore instance is invoked and the hook queue is
+// empty.
+func (f *DBStoreColdUploadIDsFunc) SetDefaultHook(hook func(context.Context, time.Duration, int, time.Time) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ColdUploadIDs method of the parent MockDBStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *DBStoreColdUploadIDsFunc) PushHook(hook func(context.Context, time.Duration, int, time.Time) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreColdUploadIDsFunc) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context, time.Duration, int, time.Time) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreColdUploadIDsFunc) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context, time.Duration, int, time.Time) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreColdUploadIDsFunc) nextHook() func(context.Context, time.Duration, int, time.Time) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
 	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreColdUploadIDsFunc) appendCall(r0 DBStoreColdUploadIDsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreColdUploadIDsFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreColdUploadIDsFunc) History() []DBStoreColdUploadIDsFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreColdUploadIDsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreColdUploadIDsFuncCall is an object that describes an invocation of
+// method ColdUploadIDs on an instance of MockDBStore.
+type DBStoreColdUploadIDsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 time.Duration
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 int
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreColdUploadIDsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2, c.Arg3}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreColdUploadIDsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
 }
 
 // DBStoreDeleteIndexesWithoutRepositoryFunc describes the behavior when the
 // DeleteIndexesWithoutRepository method of the parent MockDBStore instance
 // is invoked.
 type DBStoreDeleteIndexesWithoutRepositoryFunc struct {
-	defaultHook func(context.Context, time.Time) (map[int]int, error)
-	hooks       []func(context.Context, time.Time) (map[int]int, error)
+	defaultHook func(context.Context, time.Duration, time.Time) (map[int]int, error)
+	hooks       []func(context.Context, time.Duration, time.Time) (map[int]int, error)
 	history     []DBStoreDeleteIndexesWithoutRepositoryFuncCall
 	mutex       sync.Mutex
 }
 
 // DeleteIndexesWithoutRepository delegates to the next hook function in the
 // queue and stores the parameter and result values of this invocation.
-func (m *MockDBStore) DeleteIndexesWithoutRepository(v0 context.Context, v1 time.Time) (map[int]int, error) {
-	r0, r1 := m.DeleteIndexesWithoutRepositoryFunc.nextHook()(v0, v1)
-	m.DeleteIndexesWithoutRepositoryFunc.appendCall(DBStoreDeleteIndexesWithoutRepositoryFuncCall{v0, v1, r0, r1})
+func (m *MockDBStore) DeleteIndexesWithoutRepository(v0 context.Context, v1 time.Duration, v2 time.Time) (map[int]int, error) {
+	r0, r1 := m.DeleteIndexesWithoutRepositoryFunc.nextHook()(v0, v1, v2)
+	m.DeleteIndexesWithoutRepositoryFunc.appendCall(DBStoreDeleteIndexesWithoutRepositoryFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
 // DeleteIndexesWithoutRepository method of the parent MockDBStore instance
 // is invoked and the hook queue is empty.
-func (f *DBStoreDeleteIndexesWithoutRepositoryFunc) SetDefaultHook(hook func(context.Context, time.Time) (map[int]int, error)) {
+func (f *DBStoreDeleteIndexesWithoutRepositoryFunc) SetDefaultHook(hook func(context.Context, time.Duration, time.Time) (map[int]int, error)) {
 	f.defaultHook = hook
 }
 
@@ -209,7 +369,7 @@ func (f *DBStoreDeleteIndexesWithoutRepositoryFunc) SetDefaultHook(hook func(con
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *DBStoreDeleteIndexesWithoutRepositoryFunc) PushHook(hook func(context.Context, time.Time) (map[int]int, error)) {
+func (f *DBStoreDeleteIndexesWithoutRepositoryFunc) PushHook(hook func(context.Context, time.Duration, time.Time) (map[int]int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -218,7 +378,7 @@ func (f *DBStoreDeleteIndexesWithoutRepositoryFunc) PushHook(hook func(context.C
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
 func (f *DBStoreDeleteIndexesWithoutRepositoryFunc) SetDefaultReturn(r0 map[int]int, r1 error) {
-	f.SetDefaultHook(func(context.Context, time.Time) (map[int]int, error) {
+	f.SetDefaultHook(func(context.Context, time.Duration, time.Time) (map[int]int, error) {
 		return r0, r1
 	})
 }
@@ -226,12 +386,12 @@ func (f *DBStoreDeleteIndexesWithoutRepositoryFunc) SetDefaultReturn(r0 map[int]
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
 func (f *DBStoreDeleteIndexesWithoutRepositoryFunc) PushReturn(r0 map[int]int, r1 error) {
-	f.PushHook(func(context.Context, time.Time) (map[int]int, error) {
+	f.PushHook(func(context.Context, time.Duration, time.Time) (map[int]int, error) {
 		return r0, r1
 	})
 }
 
-func (f *DBStoreDeleteIndexesWithoutRepositoryFunc) nextHook() func(context.Context, time.Time) (map[int]int, error) {
+func (f *DBStoreDeleteIndexesWithoutRepositoryFunc) nextHook() func(context.Context, time.Duration, time.Time) (map[int]int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -271,7 +431,10 @@ type DBStoreDeleteIndexesWithoutRepositoryFuncCall struct {
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 time.Time
+	Arg1 time.Duration
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 time.Time
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 map[int]int
@@ -283,7 +446,7 @@ type DBStoreDeleteIndexesWithoutRepositoryFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c DBStoreDeleteIndexesWithoutRepositoryFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
@@ -520,24 +683,24 @@ func (c DBStoreDeleteUploadsStuckUploadingFuncCall) Results() []interface{} {
 // DeleteUploadsWithoutRepository method of the parent MockDBStore instance
 // is invoked.
 type DBStoreDeleteUploadsWithoutRepositoryFunc struct {
-	defaultHook func(context.Context, time.Time) (map[int]int, error)
-	hooks       []func(context.Context, time.Time) (map[int]int, error)
+	defaultHook func(context.Context, time.Duration, time.Time) (map[int]int, error)
+	hooks       []func(context.Context, time.Duration, time.Time) (map[int]int, error)
 	history     []DBStoreDeleteUploadsWithoutRepositoryFuncCall
 	mutex       sync.Mutex
 }
 
 // DeleteUploadsWithoutRepository delegates to the next hook function in the
 // queue and stores the parameter and result values of this invocation.
-func (m *MockDBStore) DeleteUploadsWithoutRepository(v0 context.Context, v1 time.Time) (map[int]int, error) {
-	r0, r1 := m.DeleteUploadsWithoutRepositoryFunc.nextHook()(v0, v1)
-	m.DeleteUploadsWithoutRepositoryFunc.appendCall(DBStoreDeleteUploadsWithoutRepositoryFuncCall{v0, v1, r0, r1})
+func (m *MockDBStore) DeleteUploadsWithoutRepository(v0 context.Context, v1 time.Duration, v2 time.Time) (map[int]int, error) {
+	r0, r1 := m.DeleteUploadsWithoutRepositoryFunc.nextHook()(v0, v1, v2)
+	m.DeleteUploadsWithoutRepositoryFunc.appendCall(DBStoreDeleteUploadsWithoutRepositoryFuncCall{v0, v1, v2, r0, r1})
 	return r0, r1
 }
 
 // SetDefaultHook sets function that is called when the
 // DeleteUploadsWithoutRepository method of the parent MockDBStore instance
 // is invoked and the hook queue is empty.
-func (f *DBStoreDeleteUploadsWithoutRepositoryFunc) SetDefaultHook(hook func(context.Context, time.Time) (map[int]int, error)) {
+func (f *DBStoreDeleteUploadsWithoutRepositoryFunc) SetDefaultHook(hook func(context.Context, time.Duration, time.Time) (map[int]int, error)) {
 	f.defaultHook = hook
 }
 
@@ -546,7 +709,7 @@ func (f *DBStoreDeleteUploadsWithoutRepositoryFunc) SetDefaultHook(hook func(con
 // invokes the hook at the front of the queue and discards it. After the
 // queue is empty, the default hook function is invoked for any future
 // action.
-func (f *DBStoreDeleteUploadsWithoutRepositoryFunc) PushHook(hook func(context.Context, time.Time) (map[int]int, error)) {
+func (f *DBStoreDeleteUploadsWithoutRepositoryFunc) PushHook(hook func(context.Context, time.Duration, time.Time) (map[int]int, error)) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -555,7 +718,7 @@ func (f *DBStoreDeleteUploadsWithoutRepositoryFunc) PushHook(hook func(context.C
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
 func (f *DBStoreDeleteUploadsWithoutRepositoryFunc) SetDefaultReturn(r0 map[int]int, r1 error) {
-	f.SetDefaultHook(func(context.Context, time.Time) (map[int]int, error) {
+	f.SetDefaultHook(func(context.Context, time.Duration, time.Time) (map[int]int, error) {
 		return r0, r1
 	})
 }
@@ -563,12 +726,12 @@ func (f *DBStoreDeleteUploadsWithoutRepositoryFunc) SetDefaultReturn(r0 map[int]
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
 func (f *DBStoreDeleteUploadsWithoutRepositoryFunc) PushReturn(r0 map[int]int, r1 error) {
-	f.PushHook(func(context.Context, time.Time) (map[int]int, error) {
+	f.PushHook(func(context.Context, time.Duration, time.Time) (map[int]int, error) {
 		return r0, r1
 	})
 }
 
-func (f *DBStoreDeleteUploadsWithoutRepositoryFunc) nextHook() func(context.Context, time.Time) (map[int]int, error) {
+func (f *DBStoreDeleteUploadsWithoutRepositoryFunc) nextHook() func(context.Context, time.Duration, time.Time) (map[int]int, error) {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -608,7 +771,10 @@ type DBStoreDeleteUploadsWithoutRepositoryFuncCall struct {
 	Arg0 context.Context
 	// Arg1 is the value of the 2nd argument passed to this method
 	// invocation.
-	Arg1 time.Time
+	Arg1 time.Duration
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 time.Time
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
 	Result0 map[int]int
@@ -620,7 +786,7 @@ type DBStoreDeleteUploadsWithoutRepositoryFuncCall struct {
 // Args returns an interface slice containing the arguments of this
 // invocation.
 func (c DBStoreDeleteUploadsWithoutRepositoryFuncCall) Args() []interface{} {
-	return []interface{}{c.Arg0, c.Arg1}
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
 }
 
 // Results returns an interface slice containing the results of this
@@ -629,6 +795,117 @@ func (c DBStoreDeleteUploadsWithoutRepositoryFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// DBStoreDeletedEvictedUploadIDsFunc describes the behavior when the
+// DeletedEvictedUploadIDs method of the parent MockDBStore instance is
+// invoked.
+type DBStoreDeletedEvictedUploadIDsFunc struct {
+	defaultHook func(context.Context, int) ([]int, error)
+	hooks       []func(context.Context, int) ([]int, error)
+	history     []DBStoreDeletedEvictedUploadIDsFuncCall
+	mutex       sync.Mutex
+}
+
+// DeletedEvictedUploadIDs delegates to the next hook function in the queue
+// and stores the parameter and result values of this invocation.
+func (m *MockDBStore) DeletedEvictedUploadIDs(v0 context.Context, v1 int) ([]int, error) {
+	r0, r1 := m.DeletedEvictedUploadIDsFunc.nextHook()(v0, v1)
+	m.DeletedEvictedUploadIDsFunc.appendCall(DBStoreDeletedEvictedUploadIDsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the
+// DeletedEvictedUploadIDs method of the parent MockDBStore instance is
+// invoked and the hook queue is empty.
+func (f *DBStoreDeletedEvictedUploadIDsFunc) SetDefaultHook(hook func(context.Context, int) ([]int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeletedEvictedUploadIDs method of the parent MockDBStore instance invokes
+// the hook at the front of the queue and discards it. After the queue is
+// empty, the default hook function is invoked for any future action.
+func (f *DBStoreDeletedEvictedUploadIDsFunc) PushHook(hook func(context.Context, int) ([]int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreDeletedEvictedUploadIDsFunc) SetDefaultReturn(r0 []int, r1 error) {
+	f.SetDefaultHook(func(context.Context, int) ([]int, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreDeletedEvictedUploadIDsFunc) PushReturn(r0 []int, r1 error) {
+	f.PushHook(func(context.Context, int) ([]int, error) {
+		return r0, r1
+	})
+}
+
+func (f *DBStoreDeletedEvictedUploadIDsFunc) nextHook() func(context.Context, int) ([]int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreDeletedEvictedUploadIDsFunc) appendCall(r0 DBStoreDeletedEvictedUploadIDsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreDeletedEvictedUploadIDsFuncCall
+// objects describing the invocations of this function.
+func (f *DBStoreDeletedEvictedUploadIDsFunc) History() []DBStoreDeletedEvictedUploadIDsFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreDeletedEvictedUploadIDsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreDeletedEvictedUploadIDsFuncCall is an object that describes an
+// invocation of method DeletedEvictedUploadIDs on an instance of
+// MockDBStore.
+type DBStoreDeletedEvictedUploadIDsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 []int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreDeletedEvictedUploadIDsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreDeletedEvictedUploadIDsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
+
 // DBStoreDirtyRepositoriesFunc describes the behavior when the
 // DirtyRepositories method of the parent MockDBStore instance is invoked.
 type DBStoreDirtyRepositoriesFunc struct {
@@ -1161,37 +1438,35 @@ func (c DBStoreHardDeleteUploadByIDFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
 
-// DBStoreRefreshCommitResolvabilityFunc describes the behavior when the
-// RefreshCommitResolvability method of the parent MockDBStore instance is
-// invoked.
-type DBStoreRefreshCommitResolvabilityFunc struct {
-	defaultHook func(context.Context, int, string, bool, time.Time) (int, int, error)
-	hooks       []func(context.Context, int, string, bool, time.Time) (int, int, error)
-	history     []DBStoreRefreshCommitResolvabilityFuncCall
+// DBStoreMarkUploadEvictedFunc describes the behavior when the
+// MarkUploadEvicted method of the parent MockDBStore instance is invoked.
+type DBStoreMarkUploadEvictedFunc struct {
+	defaultHook func(context.Context, int, time.Time) error
+	hooks       []func(context.Context, int, time.Time) error
+	history     []DBStoreMarkUploadEvictedFuncCall
 	mutex       sync.Mutex
 }
 
-// RefreshCommitResolvability delegates to the next hook function in the
-// queue and stores the parameter and result values of this invocation.
-func (m *MockDBStore) RefreshCommitResolvability(v0 context.Context, v1 int, v2 string, v3 bool, v4 time.Time) (int, int, error) {
-	r0, r1, r2 := m.RefreshCommitResolvabilityFunc.nextHook()(v0, v1, v2, v3, v4)
-	m.RefreshCommitResolvabilityFunc.appendCall(DBStoreRefreshCommitResolvabilityFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
-	return r0, r1, r2
+// MarkUploadEvicted delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) MarkUploadEvicted(v0 context.Context, v1 int, v2 time.Time) error {
+	r0 := m.MarkUploadEvictedFunc.nextHook()(v0, v1, v2)
+	m.MarkUploadEvictedFunc.appendCall(DBStoreMarkUploadEvictedFuncCall{v0, v1, v2, r0})
+	return r0
 }
 
-// SetDefaultHook sets function that is called when the
-// RefreshCommitResolvability method of the parent MockDBStore instance is
-// invoked and the hook queue is empty.
-func (f *DBStoreRefreshCommitResolvabilityFunc) SetDefaultHook(hook func(context.Context, int, string, bool, time.Time) (int, int, error)) {
+// SetDefaultHook sets function that is called when the MarkUploadEvicted
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreMarkUploadEvictedFunc) SetDefaultHook(hook func(context.Context, int, time.Time) error) {
 	f.defaultHook = hook
 }
 
 // PushHook adds a function to the end of hook queue. Each invocation of the
-// RefreshCommitResolvability method of the parent MockDBStore instance
-// invokes the hook at the front of the queue and discards it. After the
-// queue is empty, the default hook function is invoked for any future
-// action.
-func (f *DBStoreRefreshCommitResolvabilityFunc) PushHook(hook func(context.Context, int, string, bool, time.Time) (int, int, error)) {
+// MarkUploadEvicted method of the parent MockDBStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *DBStoreMarkUploadEvictedFunc) PushHook(hook func(context.Context, int, time.Time) error) {
 	f.mutex.Lock()
 	f.hooks = append(f.hooks, hook)
 	f.mutex.Unlock()
@@ -1199,21 +1474,21 @@ func (f *DBStoreRefreshCommitResolvabilityFunc) PushHook(hook func(context.Conte
 
 // SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
 // the given values.
-func (f *DBStoreRefreshCommitResolvabilityFunc) SetDefaultReturn(r0 int, r1 int, r2 error) {
-	f.SetDefaultHook(func(context.Context, int, string, bool, time.Time) (int, int, error) {
-		return r0, r1, r2
+func (f *DBStoreMarkUploadEvictedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, time.Time) error {
+		return r0
 	})
 }
 
 // PushReturn calls PushDefaultHook with a function that returns the given
 // values.
-func (f *DBStoreRefreshCommitResolvabilityFunc) PushReturn(r0 int, r1 int, r2 error) {
-	f.PushHook(func(context.Context, int, string, bool, time.Time) (int, int, error) {
-		return r0, r1, r2
+func (f *DBStoreMarkUploadEvictedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, time.Time) error {
+		return r0
 	})
 }
 
-func (f *DBStoreRefreshCommitResolvabilityFunc) nextHook() func(context.Context, int, string, bool, time.Time) (int, int, error) {
+func (f *DBStoreMarkUploadEvictedFunc) nextHook() func(context.Context, int, time.Time) error {
 	f.mutex.Lock()
 	defer f.mutex.Unlock()
 
@@ -1226,27 +1501,26 @@ func (f *DBStoreRefreshCommitResolvabilityFunc) nextHook() func(context.Context,
 	return hook
 }
 
-func (f *DBStoreRefreshCommitResolvabilityFunc) appendCall(r0 DBStoreRefreshCommitResolvabilityFuncCall) {
+func (f *DBStoreMarkUploadEvictedFunc) appendCall(r0 DBStoreMarkUploadEvictedFuncCall) {
 	f.mutex.Lock()
 	f.history = append(f.history, r0)
 	f.mutex.Unlock()
 }
 
-// History returns a sequence of DBStoreRefreshCommitResolvabilityFuncCall
-// objects describing the invocations of this function.
-func (f *DBStoreRefreshCommitResolvabilityFunc) History() []DBStoreRefreshCommitResolvabilityFuncCall {
+// History returns a sequence of DBStoreMarkUploadEvictedFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreMarkUploadEvictedFunc) History() []DBStoreMarkUploadEvictedFuncCall {
 	f.mutex.Lock()
-	history := make([]DBStoreRefreshCommitResolvabilityFuncCall, len(f.history))
+	history := make([]DBStoreMarkUploadEvictedFuncCall, len(f.history))
 	copy(history, f.history)
 	f.mutex.Unlock()
 
 	return history
 }
 
-// DBStoreRefreshCommitResolvabilityFuncCall is an object that describes an
-// invocation of method RefreshCommitResolvability on an instance of
-// MockDBStore.
-type DBStoreRefreshCommitResolvabilityFuncCall struct {
+// DBStoreMarkUploadEvictedFuncCall is an object that describes an
+// invocation of method MarkUploadEvicted on an instance of MockDBStore.
+type DBStoreMarkUploadEvictedFuncCall struct {
 	// Arg0 is the value of the 1st argument passed to this method
 	// invocation.
 	Arg0 context.Context
@@ -1255,22 +1529,134 @@ type DBStoreRefreshCommitResolvabilityFuncCall struct {
 	Arg1 int
 	// Arg2 is the value of the 3rd argument passed to this method
 	// invocation.
-	Arg2 string
-	// Arg3 is the value of the 4th argument passed to this method
-	// invocation.
-	Arg3 bool
-	// Arg4 is the value of the 5th argument passed to this method
-	// invocation.
-	Arg4 time.Time
+	Arg2 time.Time
 	// Result0 is the value of the 1st result returned from this method
 	// invocation.
-	Result0 int
-	// Result1 is the value of the 2nd result returned from this method
-	// invocation.
-	Result1 int
-	// Result2 is the value of the 3rd result returned from this method
-	// invocation.
-	Result2 error
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreMarkUploadEvictedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreMarkUploadEvictedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// DBStoreRefreshCommitResolvabilityFunc describes the behavior when the
+// RefreshCommitResolvability method of the parent MockDBStore instance is
+// invoked.
+type DBStoreRefreshCommitResolvabilityFunc struct {
+	defaultHook func(context.Context, int, string, bool, time.Time) (int, int, error)
+	hooks       []func(context.Context, int, string, bool, time.Time) (int, int, error)
+	history     []DBStoreRefreshCommitResolvabilityFuncCall
+	mutex       sync.Mutex
+}
+
+// RefreshCommitResolvability delegates to the next hook function in the
+// queue and stores the parameter and result values of this invocation.
+func (m *MockDBStore) RefreshCommitResolvability(v0 context.Context, v1 int, v2 string, v3 bool, v4 time.Time) (int, int, error) {
+	r0, r1, r2 := m.RefreshCommitResolvabilityFunc.nextHook()(v0, v1, v2, v3, v4)
+	m.RefreshCommitResolvabilityFunc.appendCall(DBStoreRefreshCommitResolvabilityFuncCall{v0, v1, v2, v3, v4, r0, r1, r2})
+	return r0, r1, r2
+}
+
+// SetDefaultHook sets function that is called when the
+// RefreshCommitResolvability method of the parent MockDBStore instance is
+// invoked and the hook queue is empty.
+func (f *DBStoreRefreshCommitResolvabilityFunc) SetDefaultHook(hook func(context.Context, int, string, bool, time.Time) (int, int, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// RefreshCommitResolvability method of the parent MockDBStore instance
+// invokes the hook at the front of the queue and discards it. After the
+// queue is empty, the default hook function is invoked for any future
+// action.
+func (f *DBStoreRefreshCommitResolvabilityFunc) PushHook(hook func(context.Context, int, string, bool, time.Time) (int, int, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreRefreshCommitResolvabilityFunc) SetDefaultReturn(r0 int, r1 int, r2 error) {
+	f.SetDefaultHook(func(context.Context, int, string, bool, time.Time) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreRefreshCommitResolvabilityFunc) PushReturn(r0 int, r1 int, r2 error) {
+	f.PushHook(func(context.Context, int, string, bool, time.Time) (int, int, error) {
+		return r0, r1, r2
+	})
+}
+
+func (f *DBStoreRefreshCommitResolvabilityFunc) nextHook() func(context.Context, int, string, bool, time.Time) (int, int, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreRefreshCommitResolvabilityFunc) appendCall(r0 DBStoreRefreshCommitResolvabilityFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreRefreshCommitResolvabilityFuncCall
+// objects describing the invocations of this function.
+func (f *DBStoreRefreshCommitResolvabilityFunc) History() []DBStoreRefreshCommitResolvabilityFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreRefreshCommitResolvabilityFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreRefreshCommitResolvabilityFuncCall is an object that describes an
+// invocation of method RefreshCommitResolvability on an instance of
+// MockDBStore.
+type DBStoreRefreshCommitResolvabilityFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 string
+	// Arg3 is the value of the 4th argument passed to this method
+	// invocation.
+	Arg3 bool
+	// Arg4 is the value of the 5th argument passed to this method
+	// invocation.
+	Arg4 time.Time
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 int
+	// Result2 is the value of the 3rd result returned from this method
+	// invocation.
+	Result2 error
 }
 
 // Args returns an interface slice containing the arguments of this
@@ -1618,6 +2004,112 @@ func (c DBStoreTransactFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
 
+// DBStoreUnmarkUploadEvictedFunc describes the behavior when the
+// UnmarkUploadEvicted method of the parent MockDBStore instance is invoked.
+type DBStoreUnmarkUploadEvictedFunc struct {
+	defaultHook func(context.Context, int) error
+	hooks       []func(context.Context, int) error
+	history     []DBStoreUnmarkUploadEvictedFuncCall
+	mutex       sync.Mutex
+}
+
+// UnmarkUploadEvicted delegates to the next hook function in the queue and
+// stores the parameter and result values of this invocation.
+func (m *MockDBStore) UnmarkUploadEvicted(v0 context.Context, v1 int) error {
+	r0 := m.UnmarkUploadEvictedFunc.nextHook()(v0, v1)
+	m.UnmarkUploadEvictedFunc.appendCall(DBStoreUnmarkUploadEvictedFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the UnmarkUploadEvicted
+// method of the parent MockDBStore instance is invoked and the hook queue
+// is empty.
+func (f *DBStoreUnmarkUploadEvictedFunc) SetDefaultHook(hook func(context.Context, int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// UnmarkUploadEvicted method of the parent MockDBStore instance invokes the
+// hook at the front of the queue and discards it. After the queue is empty,
+// the default hook function is invoked for any future action.
+func (f *DBStoreUnmarkUploadEvictedFunc) PushHook(hook func(context.Context, int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *DBStoreUnmarkUploadEvictedFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *DBStoreUnmarkUploadEvictedFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+func (f *DBStoreUnmarkUploadEvictedFunc) nextHook() func(context.Context, int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *DBStoreUnmarkUploadEvictedFunc) appendCall(r0 DBStoreUnmarkUploadEvictedFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of DBStoreUnmarkUploadEvictedFuncCall objects
+// describing the invocations of this function.
+func (f *DBStoreUnmarkUploadEvictedFunc) History() []DBStoreUnmarkUploadEvictedFuncCall {
+	f.mutex.Lock()
+	history := make([]DBStoreUnmarkUploadEvictedFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// DBStoreUnmarkUploadEvictedFuncCall is an object that describes an
+// invocation of method UnmarkUploadEvicted on an instance of MockDBStore.
+type DBStoreUnmarkUploadEvictedFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c DBStoreUnmarkUploadEvictedFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c DBStoreUnmarkUploadEvictedFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
 // MockLSIFStore is a mock implementation of the LSIFStore interface (from
 // the package
 // github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/codeintel/janitor)
@@ -1626,6 +2118,12 @@ type MockLSIFStore struct {
 	// ClearFunc is an instance of a mock function object controlling the
 	// behavior of the method Clear.
 	ClearFunc *LSIFStoreClearFunc
+	// DeleteBundleFunc is an instance of a mock function object controlling
+	// the behavior of the method DeleteBundle.
+	DeleteBundleFunc *LSIFStoreDeleteBundleFunc
+	// ExportBundleFunc is an instance of a mock function object controlling
+	// the behavior of the method ExportBundle.
+	ExportBundleFunc *LSIFStoreExportBundleFunc
 }
 
 // NewMockLSIFStore creates a new mock of the LSIFStore interface. All
@@ -1637,6 +2135,16 @@ func NewMockLSIFStore() *MockLSIFStore {
 				return nil
 			},
 		},
+		DeleteBundleFunc: &LSIFStoreDeleteBundleFunc{
+			defaultHook: func(context.Context, int) error {
+				return nil
+			},
+		},
+		ExportBundleFunc: &LSIFStoreExportBundleFunc{
+			defaultHook: func(context.Context, int, io.Writer) error {
+				return nil
+			},
+		},
 	}
 }
 
@@ -1647,6 +2155,12 @@ func NewMockLSIFStoreFrom(i LSIFStore) *MockLSIFStore {
 		ClearFunc: &LSIFStoreClearFunc{
 			defaultHook: i.Clear,
 		},
+		DeleteBundleFunc: &LSIFStoreDeleteBundleFunc{
+			defaultHook: i.DeleteBundle,
+		},
+		ExportBundleFunc: &LSIFStoreExportBundleFunc{
+			defaultHook: i.ExportBundle,
+		},
 	}
 }
 
@@ -1761,3 +2275,478 @@ func (c LSIFStoreClearFuncCall) Args() []interface{} {
 func (c LSIFStoreClearFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0}
 }
+
+// LSIFStoreDeleteBundleFunc describes the behavior when the DeleteBundle
+// method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreDeleteBundleFunc struct {
+	defaultHook func(context.Context, int) error
+	hooks       []func(context.Context, int) error
+	history     []LSIFStoreDeleteBundleFuncCall
+	mutex       sync.Mutex
+}
+
+// DeleteBundle delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockLSIFStore) DeleteBundle(v0 context.Context, v1 int) error {
+	r0 := m.DeleteBundleFunc.nextHook()(v0, v1)
+	m.DeleteBundleFunc.appendCall(LSIFStoreDeleteBundleFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the DeleteBundle method
+// of the parent MockLSIFStore instance is invoked and the hook queue is
+// empty.
+func (f *LSIFStoreDeleteBundleFunc) SetDefaultHook(hook func(context.Context, int) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// DeleteBundle method of the parent MockLSIFStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *LSIFStoreDeleteBundleFunc) PushHook(hook func(context.Context, int) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *LSIFStoreDeleteBundleFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *LSIFStoreDeleteBundleFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int) error {
+		return r0
+	})
+}
+
+func (f *LSIFStoreDeleteBundleFunc) nextHook() func(context.Context, int) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreDeleteBundleFunc) appendCall(r0 LSIFStoreDeleteBundleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreDeleteBundleFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreDeleteBundleFunc) History() []LSIFStoreDeleteBundleFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreDeleteBundleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreDeleteBundleFuncCall is an object that describes an invocation
+// of method DeleteBundle on an instance of MockLSIFStore.
+type LSIFStoreDeleteBundleFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreDeleteBundleFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreDeleteBundleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// LSIFStoreExportBundleFunc describes the behavior when the ExportBundle
+// method of the parent MockLSIFStore instance is invoked.
+type LSIFStoreExportBundleFunc struct {
+	defaultHook func(context.Context, int, io.Writer) error
+	hooks       []func(context.Context, int, io.Writer) error
+	history     []LSIFStoreExportBundleFuncCall
+	mutex       sync.Mutex
+}
+
+// ExportBundle delegates to the next hook function in the queue and stores
+// the parameter and result values of this invocation.
+func (m *MockLSIFStore) ExportBundle(v0 context.Context, v1 int, v2 io.Writer) error {
+	r0 := m.ExportBundleFunc.nextHook()(v0, v1, v2)
+	m.ExportBundleFunc.appendCall(LSIFStoreExportBundleFuncCall{v0, v1, v2, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the ExportBundle method
+// of the parent MockLSIFStore instance is invoked and the hook queue is
+// empty.
+func (f *LSIFStoreExportBundleFunc) SetDefaultHook(hook func(context.Context, int, io.Writer) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// ExportBundle method of the parent MockLSIFStore instance invokes the hook
+// at the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *LSIFStoreExportBundleFunc) PushHook(hook func(context.Context, int, io.Writer) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *LSIFStoreExportBundleFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, int, io.Writer) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *LSIFStoreExportBundleFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, int, io.Writer) error {
+		return r0
+	})
+}
+
+func (f *LSIFStoreExportBundleFunc) nextHook() func(context.Context, int, io.Writer) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *LSIFStoreExportBundleFunc) appendCall(r0 LSIFStoreExportBundleFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of LSIFStoreExportBundleFuncCall objects
+// describing the invocations of this function.
+func (f *LSIFStoreExportBundleFunc) History() []LSIFStoreExportBundleFuncCall {
+	f.mutex.Lock()
+	history := make([]LSIFStoreExportBundleFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// LSIFStoreExportBundleFuncCall is an object that describes an invocation
+// of method ExportBundle on an instance of MockLSIFStore.
+type LSIFStoreExportBundleFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 int
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 io.Writer
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c LSIFStoreExportBundleFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c LSIFStoreExportBundleFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// MockUploadStore is a mock implementation of the UploadStore interface
+// (from the package
+// github.com/sourcegraph/sourcegraph/enterprise/cmd/worker/internal/codeintel/janitor)
+// used for unit testing.
+type MockUploadStore struct {
+	// DeleteFunc is an instance of a mock function object controlling the
+	// behavior of the method Delete.
+	DeleteFunc *UploadStoreDeleteFunc
+	// UploadFunc is an instance of a mock function object controlling the
+	// behavior of the method Upload.
+	UploadFunc *UploadStoreUploadFunc
+}
+
+// NewMockUploadStore creates a new mock of the UploadStore interface. All
+// methods return zero values for all results, unless overwritten.
+func NewMockUploadStore() *MockUploadStore {
+	return &MockUploadStore{
+		DeleteFunc: &UploadStoreDeleteFunc{
+			defaultHook: func(context.Context, string) error {
+				return nil
+			},
+		},
+		UploadFunc: &UploadStoreUploadFunc{
+			defaultHook: func(context.Context, string, io.Reader) (int64, error) {
+				return 0, nil
+			},
+		},
+	}
+}
+
+// NewMockUploadStoreFrom creates a new mock of the MockUploadStore
+// interface. All methods delegate to the given implementation, unless
+// overwritten.
+func NewMockUploadStoreFrom(i UploadStore) *MockUploadStore {
+	return &MockUploadStore{
+		DeleteFunc: &UploadStoreDeleteFunc{
+			defaultHook: i.Delete,
+		},
+		UploadFunc: &UploadStoreUploadFunc{
+			defaultHook: i.Upload,
+		},
+	}
+}
+
+// UploadStoreDeleteFunc describes the behavior when the Delete method of
+// the parent MockUploadStore instance is invoked.
+type UploadStoreDeleteFunc struct {
+	defaultHook func(context.Context, string) error
+	hooks       []func(context.Context, string) error
+	history     []UploadStoreDeleteFuncCall
+	mutex       sync.Mutex
+}
+
+// Delete delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUploadStore) Delete(v0 context.Context, v1 string) error {
+	r0 := m.DeleteFunc.nextHook()(v0, v1)
+	m.DeleteFunc.appendCall(UploadStoreDeleteFuncCall{v0, v1, r0})
+	return r0
+}
+
+// SetDefaultHook sets function that is called when the Delete method of the
+// parent MockUploadStore instance is invoked and the hook queue is empty.
+func (f *UploadStoreDeleteFunc) SetDefaultHook(hook func(context.Context, string) error) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Delete method of the parent MockUploadStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *UploadStoreDeleteFunc) PushHook(hook func(context.Context, string) error) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *UploadStoreDeleteFunc) SetDefaultReturn(r0 error) {
+	f.SetDefaultHook(func(context.Context, string) error {
+		return r0
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *UploadStoreDeleteFunc) PushReturn(r0 error) {
+	f.PushHook(func(context.Context, string) error {
+		return r0
+	})
+}
+
+func (f *UploadStoreDeleteFunc) nextHook() func(context.Context, string) error {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadStoreDeleteFunc) appendCall(r0 UploadStoreDeleteFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UploadStoreDeleteFuncCall objects
+// describing the invocations of this function.
+func (f *UploadStoreDeleteFunc) History() []UploadStoreDeleteFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadStoreDeleteFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadStoreDeleteFuncCall is an object that describes an invocation of
+// method Delete on an instance of MockUploadStore.
+type UploadStoreDeleteFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadStoreDeleteFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadStoreDeleteFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0}
+}
+
+// UploadStoreUploadFunc describes the behavior when the Upload method of
+// the parent MockUploadStore instance is invoked.
+type UploadStoreUploadFunc struct {
+	defaultHook func(context.Context, string, io.Reader) (int64, error)
+	hooks       []func(context.Context, string, io.Reader) (int64, error)
+	history     []UploadStoreUploadFuncCall
+	mutex       sync.Mutex
+}
+
+// Upload delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockUploadStore) Upload(v0 context.Context, v1 string, v2 io.Reader) (int64, error) {
+	r0, r1 := m.UploadFunc.nextHook()(v0, v1, v2)
+	m.UploadFunc.appendCall(UploadStoreUploadFuncCall{v0, v1, v2, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the Upload method of the
+// parent MockUploadStore instance is invoked and the hook queue is empty.
+func (f *UploadStoreUploadFunc) SetDefaultHook(hook func(context.Context, string, io.Reader) (int64, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// Upload method of the parent MockUploadStore instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *UploadStoreUploadFunc) PushHook(hook func(context.Context, string, io.Reader) (int64, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *UploadStoreUploadFunc) SetDefaultReturn(r0 int64, r1 error) {
+	f.SetDefaultHook(func(context.Context, string, io.Reader) (int64, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *UploadStoreUploadFunc) PushReturn(r0 int64, r1 error) {
+	f.PushHook(func(context.Context, string, io.Reader) (int64, error) {
+		return r0, r1
+	})
+}
+
+func (f *UploadStoreUploadFunc) nextHook() func(context.Context, string, io.Reader) (int64, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *UploadStoreUploadFunc) appendCall(r0 UploadStoreUploadFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of UploadStoreUploadFuncCall objects
+// describing the invocations of this function.
+func (f *UploadStoreUploadFunc) History() []UploadStoreUploadFuncCall {
+	f.mutex.Lock()
+	history := make([]UploadStoreUploadFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// UploadStoreUploadFuncCall is an object that describes an invocation of
+// method Upload on an instance of MockUploadStore.
+type UploadStoreUploadFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method
+	// invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method
+	// invocation.
+	Arg1 string
+	// Arg2 is the value of the 3rd argument passed to this method
+	// invocation.
+	Arg2 io.Reader
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 int64
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c UploadStoreUploadFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1, c.Arg2}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c UploadStoreUploadFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
@@ -14,6 +14,8 @@ type metrics struct {
 	numUploadResetFailures  prometheus.Counter
 	numIndexResets          prometheus.Counter
 	numIndexResetFailures   prometheus.Counter
+	numBundlesEvicted       prometheus.Counter
+	numBytesEvicted         prometheus.Counter
 	numErrors               prometheus.Counter
 }
 
@@ -58,6 +60,14 @@ func newMetrics(observationContext *observation.Context) *metrics {
 		"src_codeintel_background_index_reset_failures_total",
 		"The number of index reset failures.",
 	)
+	numBundlesEvicted := counter(
+		"src_codeintel_background_bundles_evicted_total",
+		"The number of uploads for which data in the codeintel database was moved to object storage.",
+	)
+	numBytesEvicted := counter(
+		"src_codeintel_background_evicted_bytes_total",
+		"The number of (compressed) bytes of codeintel data moved to object storage.",
+	)
 	numErrors := counter(
 		"src_codeintel_background_errors_total",
 		"The number of errors that occur during a codeintel background job.",
@@ -71,6 +81,8 @@ func newMetrics(observationContext *observation.Context) *metrics {
 		numUploadResetFailures:  numUploadResetFailures,
 		numIndexResets:          numIndexResets,
 		numIndexResetFailures:   numIndexResetFailures,
+		numBundlesEvicted:       numBundlesEvicted,
+		numBytesEvicted:         numBytesEvicted,
 		numErrors:               numErrors,
 	}
 }
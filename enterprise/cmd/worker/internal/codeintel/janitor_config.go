@@ -4,6 +4,7 @@ import (
 	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
 )
 
 type janitorConfig struct {
@@ -15,6 +16,8 @@ type janitorConfig struct {
 	CommitResolverTaskInterval              time.Duration
 	CommitResolverMinimumTimeSinceLastCheck time.Duration
 	CommitResolverBatchSize                 int
+	BundleEvictionMaxIdle                   time.Duration
+	UploadStoreConfig                       *uploadstore.Config
 }
 
 var janitorConfigInst = &janitorConfig{}
@@ -26,4 +29,8 @@ func (c *janitorConfig) Load() {
 	c.CommitResolverTaskInterval = c.GetInterval("PRECISE_CODE_INTEL_COMMIT_RESOLVER_TASK_INTERVAL", "10s", "The frequency with which to run the periodic commit resolver task.")
 	c.CommitResolverMinimumTimeSinceLastCheck = c.GetInterval("PRECISE_CODE_INTEL_COMMIT_RESOLVER_MINIMUM_TIME_SINCE_LAST_CHECK", "24h", "The minimum time the commit resolver will re-check an upload or index record.")
 	c.CommitResolverBatchSize = c.GetInt("PRECISE_CODE_INTEL_COMMIT_RESOLVER_BATCH_SIZE", "100", "The maximum number of unique commits to resolve at a time.")
+	c.BundleEvictionMaxIdle = c.GetInterval("PRECISE_CODE_INTEL_BUNDLE_EVICTION_MAX_IDLE", "0", "The time after which the data of an upload that has not been queried is moved from the codeintel database to object storage. Zero disables eviction.")
+
+	c.UploadStoreConfig = &uploadstore.Config{}
+	c.UploadStoreConfig.Load()
 }
@@ -3,6 +3,8 @@ package codeintel
 import (
 	"context"
 
+	"github.com/cockroachdb/errors"
+
 	"github.com/inconshreveable/log15"
 	"github.com/opentracing/opentracing-go"
 	"github.com/prometheus/client_golang/prometheus"
@@ -14,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
 )
 
 type janitorJob struct{}
@@ -58,5 +61,18 @@ func (j *janitorJob) Routines(ctx context.Context) ([]goroutine.BackgroundRoutin
 		janitor.NewUnknownCommitJanitor(dbStoreShim, janitorConfigInst.CommitResolverMinimumTimeSinceLastCheck, janitorConfigInst.CommitResolverBatchSize, janitorConfigInst.CommitResolverTaskInterval, metrics),
 	}
 
+	if janitorConfigInst.BundleEvictionMaxIdle > 0 {
+		if err := janitorConfigInst.UploadStoreConfig.Validate(); err != nil {
+			return nil, errors.Errorf("failed to load config: %s", err)
+		}
+
+		uploadStore, err := uploadstore.CreateLazy(context.Background(), janitorConfigInst.UploadStoreConfig.EvictionConfig(), observationContext)
+		if err != nil {
+			return nil, err
+		}
+
+		routines = append(routines, janitor.NewBundleEvicter(dbStoreShim, lsifStore, uploadStore, janitorConfigInst.BundleEvictionMaxIdle, janitorConfigInst.CleanupTaskInterval, metrics))
+	}
+
 	return routines, nil
 }
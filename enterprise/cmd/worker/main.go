@@ -16,6 +16,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/versions"
+	"github.com/sourcegraph/sourcegraph/internal/search/searchjobs"
+	"github.com/sourcegraph/sourcegraph/internal/usagestats"
 )
 
 func main() {
@@ -32,6 +34,8 @@ func main() {
 		"codeintel-auto-indexing":  codeintel.NewIndexingJob(),
 		"codehost-version-syncing": versions.NewSyncingJob(),
 		"insights-job":             insights.NewInsightsJob(),
+		"usage-rollups":            usagestats.NewRollupJob(),
+		"search-jobs":              searchjobs.NewSearchJobsJob(),
 	})
 }
 
@@ -3,6 +3,10 @@ package batches
 import (
 	"context"
 
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/background"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/syncer"
@@ -12,6 +16,8 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/encryption"
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
 )
 
 // InitBackgroundJobs starts all jobs required to run batches. Currently, it is called from
@@ -28,8 +34,6 @@ func InitBackgroundJobs(
 	// the registry can start or stop the syncer associated with the service
 	HandleExternalServiceSync(es api.ExternalService)
 } {
-	cstore := store.New(db, key)
-
 	// We use an internal actor so that we can freely load dependencies from
 	// the database without repository permissions being enforced.
 	// We do check for repository permissions consciously in the Rewirer when
@@ -37,6 +41,14 @@ func InitBackgroundJobs(
 	// host, we manually check for BatchChangesCredentials.
 	ctx = actor.WithInternalActor(ctx)
 
+	observationContext := &observation.Context{
+		Logger:     log15.Root(),
+		Tracer:     &trace.Tracer{Tracer: opentracing.GlobalTracer()},
+		Registerer: prometheus.DefaultRegisterer,
+	}
+
+	cstore := store.NewObservedStore(store.New(db, key), store.NewObservedStoreOperations(observationContext))
+
 	syncRegistry := syncer.NewSyncRegistry(ctx, cstore, cf)
 
 	go goroutine.MonitorBackgroundRoutines(ctx, background.Routines(ctx, cstore, cf)...)
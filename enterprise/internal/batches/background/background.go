@@ -17,7 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 )
 
-func Routines(ctx context.Context, batchesStore *store.Store, cf *httpcli.Factory) []goroutine.BackgroundRoutine {
+func Routines(ctx context.Context, batchesStore *store.ObservedStore, cf *httpcli.Factory) []goroutine.BackgroundRoutine {
 	sourcer := sources.NewSourcer(cf)
 	observationContext := &observation.Context{
 		Logger:     log15.Root(),
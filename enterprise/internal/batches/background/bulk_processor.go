@@ -33,7 +33,7 @@ func (e unknownJobTypeErr) NonRetryable() bool {
 }
 
 type bulkProcessor struct {
-	tx      *store.Store
+	tx      *store.ObservedStore
 	sourcer sources.Sourcer
 
 	css  sources.ChangesetSource
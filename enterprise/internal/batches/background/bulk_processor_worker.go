@@ -28,7 +28,7 @@ const bulkProcessorMaxNumResets = 60
 // from the database and passes them to the bulk executor for processing.
 func newBulkOperationWorker(
 	ctx context.Context,
-	s *store.Store,
+	s *store.ObservedStore,
 	sourcer sources.Sourcer,
 	metrics batchChangesMetrics,
 ) *workerutil.Worker {
@@ -50,7 +50,7 @@ func newBulkOperationWorker(
 
 // newBulkOperationWorkerResetter creates a dbworker.Resetter that reenqueues lost jobs
 // for processing.
-func newBulkOperationWorkerResetter(s *store.Store, metrics batchChangesMetrics) *dbworker.Resetter {
+func newBulkOperationWorkerResetter(s *store.ObservedStore, metrics batchChangesMetrics) *dbworker.Resetter {
 	workerStore := createBulkOperationDBWorkerStore(s)
 
 	options := dbworker.ResetterOptions{
@@ -63,7 +63,7 @@ func newBulkOperationWorkerResetter(s *store.Store, metrics batchChangesMetrics)
 	return resetter
 }
 
-func createBulkOperationDBWorkerStore(s *store.Store) dbworkerstore.Store {
+func createBulkOperationDBWorkerStore(s *store.ObservedStore) dbworkerstore.Store {
 	return dbworkerstore.New(s.Handle(), dbworkerstore.Options{
 		Name:              "batches_bulk_worker_store",
 		TableName:         "changeset_jobs",
@@ -89,7 +89,7 @@ func scanFirstChangesetJobRecord(rows *sql.Rows, err error) (workerutil.Record,
 // bulkProcessorWorker is a wrapper for the workerutil handlerfunc to create a
 // bulkProcessor with a source and store.
 type bulkProcessorWorker struct {
-	store   *store.Store
+	store   *store.ObservedStore
 	sourcer sources.Sourcer
 }
 
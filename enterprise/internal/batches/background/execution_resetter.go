@@ -10,7 +10,7 @@ import (
 
 // newBatchSpecExecutionResetter creates a dbworker.Resetter that re-enqueues
 // lost batch_spec_execution jobs for processing.
-func newBatchSpecExecutionResetter(s *store.Store, observationContext *observation.Context, metrics batchChangesMetrics) *dbworker.Resetter {
+func newBatchSpecExecutionResetter(s *store.ObservedStore, observationContext *observation.Context, metrics batchChangesMetrics) *dbworker.Resetter {
 	workerStore := NewExecutorStore(s, observationContext)
 
 	options := dbworker.ResetterOptions{
@@ -14,6 +14,7 @@ import (
 	"github.com/keegancsmith/sqlf"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
@@ -32,13 +33,14 @@ const executorStalledJobMaximumAge = time.Second * 25
 const executorMaximumNumResets = 3
 
 var executorWorkerStoreOptions = dbworkerstore.Options{
-	Name:              "batch_spec_executor_worker_store",
-	TableName:         "batch_spec_executions",
-	ColumnExpressions: store.BatchSpecExecutionColumns,
-	Scan:              scanFirstExecutionRecord,
-	OrderByExpression: sqlf.Sprintf("batch_spec_executions.created_at, batch_spec_executions.id"),
-	StalledMaxAge:     executorStalledJobMaximumAge,
-	MaxNumResets:      executorMaximumNumResets,
+	Name:                  "batch_spec_executor_worker_store",
+	TableName:             "batch_spec_executions",
+	ColumnExpressions:     store.BatchSpecExecutionColumns,
+	Scan:                  scanFirstExecutionRecord,
+	OrderByExpression:     sqlf.Sprintf("batch_spec_executions.created_at, batch_spec_executions.id"),
+	FairnessKeyExpression: sqlf.Sprintf("batch_spec_executions.user_id"),
+	StalledMaxAge:         executorStalledJobMaximumAge,
+	MaxNumResets:          executorMaximumNumResets,
 	// Explicitly disable retries.
 	MaxNumRetries: 0,
 }
@@ -74,11 +76,54 @@ func (s *executorStore) MarkComplete(ctx context.Context, id int) (_ bool, err e
 	batchSpecRandID, err := loadAndExtractBatchSpecRandID(ctx, batchesStore, int64(id))
 	if err != nil {
 		// If we couldn't extract the batch spec rand id, we mark the job as failed
-		return s.Store.MarkFailed(ctx, id, fmt.Sprintf("failed to extract batch spec ID: %s", err))
+		return s.MarkFailed(ctx, id, fmt.Sprintf("failed to extract batch spec ID: %s", err))
 	}
 
 	_, ok, err := basestore.ScanFirstInt(batchesStore.Query(ctx, sqlf.Sprintf(markCompleteQuery, batchSpecRandID, id)))
-	return ok, err
+	if err != nil {
+		return ok, err
+	}
+
+	return ok, deleteAccessToken(ctx, batchesStore, int64(id))
+}
+
+func (s *executorStore) MarkErrored(ctx context.Context, id int, failureMessage string) (bool, error) {
+	ok, err := s.Store.MarkErrored(ctx, id, failureMessage)
+	if err != nil {
+		return ok, err
+	}
+
+	return ok, deleteAccessToken(ctx, store.New(s.Store.Handle().DB(), nil), int64(id))
+}
+
+func (s *executorStore) MarkFailed(ctx context.Context, id int, failureMessage string) (bool, error) {
+	ok, err := s.Store.MarkFailed(ctx, id, failureMessage)
+	if err != nil {
+		return ok, err
+	}
+
+	return ok, deleteAccessToken(ctx, store.New(s.Store.Handle().DB(), nil), int64(id))
+}
+
+// deleteAccessToken deletes the access token that was created for src-cli
+// when the execution was dequeued. The token is only needed while the
+// execution is running.
+func deleteAccessToken(ctx context.Context, s *store.Store, id int64) error {
+	exec, err := s.GetBatchSpecExecution(ctx, store.GetBatchSpecExecutionOpts{ID: id})
+	if err != nil {
+		return err
+	}
+
+	if exec.AccessTokenID == 0 {
+		return nil
+	}
+
+	err = database.AccessTokensWith(s).DeleteByID(ctx, exec.AccessTokenID, exec.UserID)
+	if err != nil && err != database.ErrAccessTokenNotFound {
+		return err
+	}
+
+	return s.SetBatchSpecExecutionAccessToken(ctx, id, 0)
 }
 
 func loadAndExtractBatchSpecRandID(ctx context.Context, s *store.Store, id int64) (string, error) {
@@ -5,8 +5,11 @@ import (
 	"testing"
 	"time"
 
+	"github.com/keegancsmith/sqlf"
+
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
@@ -96,6 +99,63 @@ stdout: {"operation":"CREATING_BATCH_SPEC","timestamp":"2021-07-06T09:38:51.535Z
 	})
 }
 
+func TestExecutorStoreMarkFailedDeletesAccessToken(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.NewDB(t, "")
+	user := ct.CreateTestUser(t, db, true)
+
+	s := store.New(db, nil)
+	workStore := NewExecutorStore(s, &observation.TestContext)
+
+	tokenID, _, err := database.AccessTokens(db).Create(ctx, user.ID, []string{"user:all"}, "batch-spec-execution", user.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	specExec := &btypes.BatchSpecExecution{
+		State:           btypes.BatchSpecExecutionStateProcessing,
+		BatchSpec:       `name: testing`,
+		UserID:          user.ID,
+		NamespaceUserID: user.ID,
+	}
+	if err := s.CreateBatchSpecExecution(ctx, specExec); err != nil {
+		t.Fatal(err)
+	}
+	if err := s.SetBatchSpecExecutionAccessToken(ctx, specExec.ID, tokenID); err != nil {
+		t.Fatal(err)
+	}
+
+	// The execution is created in the queued state, so we need to move it
+	// into processing first.
+	if err := s.Exec(ctx, sqlf.Sprintf("UPDATE batch_spec_executions SET state = 'processing' WHERE id = %s", specExec.ID)); err != nil {
+		t.Fatal(err)
+	}
+
+	ok, err := workStore.MarkFailed(ctx, int(specExec.ID), "boom")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok {
+		t.Fatal("execution not marked as failed")
+	}
+
+	count, err := database.AccessTokens(db).Count(ctx, database.AccessTokensListOptions{SubjectUserID: user.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 0 {
+		t.Fatalf("access token not deleted. have %d tokens", count)
+	}
+
+	have, err := s.GetBatchSpecExecution(ctx, store.GetBatchSpecExecutionOpts{ID: specExec.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have.AccessTokenID != 0 {
+		t.Fatalf("access token ID not reset. have=%d", have.AccessTokenID)
+	}
+}
+
 func TestExtractBatchSpecRandID(t *testing.T) {
 	tests := []struct {
 		name       string
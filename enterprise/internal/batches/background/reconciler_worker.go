@@ -28,7 +28,7 @@ const reconcilerMaxNumResets = 60
 // processing.
 func newReconcilerWorker(
 	ctx context.Context,
-	s *store.Store,
+	s *store.ObservedStore,
 	gitClient reconciler.GitserverClient,
 	sourcer sources.Sourcer,
 	metrics batchChangesMetrics,
@@ -49,7 +49,7 @@ func newReconcilerWorker(
 	return worker
 }
 
-func newReconcilerWorkerResetter(s *store.Store, metrics batchChangesMetrics) *dbworker.Resetter {
+func newReconcilerWorkerResetter(s *store.ObservedStore, metrics batchChangesMetrics) *dbworker.Resetter {
 	workerStore := createReconcilerDBWorkerStore(s)
 
 	options := dbworker.ResetterOptions{
@@ -66,7 +66,7 @@ func scanFirstChangesetRecord(rows *sql.Rows, err error) (workerutil.Record, boo
 	return store.ScanFirstChangeset(rows, err)
 }
 
-func createReconcilerDBWorkerStore(s *store.Store) dbworkerstore.Store {
+func createReconcilerDBWorkerStore(s *store.ObservedStore) dbworkerstore.Store {
 	return dbworkerstore.New(s.Handle(), dbworkerstore.Options{
 		Name:                 "batches_reconciler_worker_store",
 		TableName:            "changesets",
@@ -80,6 +80,10 @@ func createReconcilerDBWorkerStore(s *store.Store) dbworkerstore.Store {
 		// If state is equal, prefer the newer ones.
 		OrderByExpression: sqlf.Sprintf("changesets.reconciler_state = 'errored', changesets.updated_at DESC"),
 
+		// Interleave the changesets of batch changes, so that publishing
+		// a huge batch change doesn't block all others.
+		FairnessKeyExpression: sqlf.Sprintf("changesets.owned_by_batch_change_id"),
+
 		StalledMaxAge: 60 * time.Second,
 		MaxNumResets:  reconcilerMaxNumResets,
 
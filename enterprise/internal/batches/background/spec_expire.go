@@ -10,7 +10,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 )
 
-func newSpecExpireWorker(ctx context.Context, cstore *store.Store) goroutine.BackgroundRoutine {
+func newSpecExpireWorker(ctx context.Context, cstore *store.ObservedStore) goroutine.BackgroundRoutine {
 	expireSpecs := goroutine.NewHandlerWithErrorMessage("expire batch changes specs", func(ctx context.Context) error {
 		// We first need to delete expired ChangesetSpecs...
 		if err := cstore.DeleteExpiredChangesetSpecs(ctx); err != nil {
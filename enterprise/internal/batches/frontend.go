@@ -3,16 +3,23 @@ package batches
 import (
 	"context"
 
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/enterprise"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/background"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/resolvers"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/service"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types/scheduler/window"
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/webhooks"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/encryption/keyring"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/oobmigration"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
 )
 
 // InitFrontend initializes the given enterpriseServices to include the required
@@ -25,15 +32,26 @@ func InitFrontend(ctx context.Context, db dbutil.DB, outOfBandMigrationRunner *o
 			problems = append(problems, conf.NewSiteProblem(err.Error()))
 		}
 
+		if _, err := service.NewRepoExcluder(c.BatchChangesExcludedRepositories); err != nil {
+			problems = append(problems, conf.NewSiteProblem(err.Error()))
+		}
+
 		return
 	})
 
 	cstore := store.New(db, keyring.Default().BatchChangesCredentialKey)
 
-	enterpriseServices.BatchChangesResolver = resolvers.New(cstore)
-	enterpriseServices.GitHubWebhook = webhooks.NewGitHubWebhook(cstore)
-	enterpriseServices.BitbucketServerWebhook = webhooks.NewBitbucketServerWebhook(cstore)
-	enterpriseServices.GitLabWebhook = webhooks.NewGitLabWebhook(cstore)
+	observationContext := &observation.Context{
+		Logger:     log15.Root(),
+		Tracer:     &trace.Tracer{Tracer: opentracing.GlobalTracer()},
+		Registerer: prometheus.DefaultRegisterer,
+	}
+	observedStore := store.NewObservedStore(cstore, store.NewObservedStoreOperations(observationContext))
+
+	enterpriseServices.BatchChangesResolver = resolvers.New(observedStore)
+	enterpriseServices.GitHubWebhook = webhooks.NewGitHubWebhook(observedStore)
+	enterpriseServices.BitbucketServerWebhook = webhooks.NewBitbucketServerWebhook(observedStore)
+	enterpriseServices.GitLabWebhook = webhooks.NewGitLabWebhook(observedStore)
 
 	return background.RegisterMigrations(cstore, outOfBandMigrationRunner)
 }
@@ -22,7 +22,7 @@ import (
 )
 
 // executePlan executes the given reconciler plan.
-func executePlan(ctx context.Context, gitserverClient GitserverClient, sourcer sources.Sourcer, noSleepBeforeSync bool, tx *store.Store, plan *Plan) (err error) {
+func executePlan(ctx context.Context, gitserverClient GitserverClient, sourcer sources.Sourcer, noSleepBeforeSync bool, tx *store.ObservedStore, plan *Plan) (err error) {
 	e := &executor{
 		gitserverClient:   gitserverClient,
 		sourcer:           sourcer,
@@ -39,7 +39,7 @@ type executor struct {
 	gitserverClient   GitserverClient
 	sourcer           sources.Sourcer
 	noSleepBeforeSync bool
-	tx                *store.Store
+	tx                *store.ObservedStore
 	ch                *btypes.Changeset
 	spec              *btypes.ChangesetSpec
 
@@ -343,7 +343,7 @@ func (e *executor) sleep() {
 	}
 }
 
-func loadChangesetSource(ctx context.Context, s *store.Store, sourcer sources.Sourcer, ch *btypes.Changeset, repo *types.Repo) (sources.ChangesetSource, error) {
+func loadChangesetSource(ctx context.Context, s *store.ObservedStore, sourcer sources.Sourcer, ch *btypes.Changeset, repo *types.Repo) (sources.ChangesetSource, error) {
 	// This is a changeset source using the external service config for authentication,
 	// based on our heuristic in the sources package.
 	css, err := sourcer.ForRepo(ctx, s, repo)
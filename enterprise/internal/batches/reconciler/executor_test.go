@@ -39,7 +39,7 @@ func TestExecutor_ExecutePlan(t *testing.T) {
 
 	now := timeutil.Now()
 	clock := func() time.Time { return now }
-	cstore := store.NewWithClock(db, et.TestKey{}, clock)
+	cstore := store.NewObservedForTest(store.NewWithClock(db, et.TestKey{}, clock))
 
 	admin := ct.CreateTestUser(t, db, true)
 
@@ -596,7 +596,7 @@ func TestExecutor_ExecutePlan_PublishedChangesetDuplicateBranch(t *testing.T) {
 	ctx := context.Background()
 	db := dbtest.NewDB(t, "")
 
-	cstore := store.New(db, et.TestKey{})
+	cstore := store.NewObservedForTest(store.New(db, et.TestKey{}))
 
 	rs, _ := ct.CreateTestRepos(t, ctx, db, 1)
 	repo := rs[0]
@@ -639,7 +639,7 @@ func TestLoadChangesetSource(t *testing.T) {
 	db := dbtest.NewDB(t, "")
 	token := &auth.OAuthBearerToken{Token: "abcdef"}
 
-	cstore := store.New(db, et.TestKey{})
+	cstore := store.NewObservedForTest(store.New(db, et.TestKey{}))
 
 	admin := ct.CreateTestUser(t, db, true)
 	user := ct.CreateTestUser(t, db, false)
@@ -799,7 +799,7 @@ func TestExecutor_UserCredentialsForGitserver(t *testing.T) {
 	ctx := backend.WithAuthzBypass(context.Background())
 	db := dbtest.NewDB(t, "")
 
-	cstore := store.New(db, et.TestKey{})
+	cstore := store.NewObservedForTest(store.New(db, et.TestKey{}))
 
 	admin := ct.CreateTestUser(t, db, true)
 	user := ct.CreateTestUser(t, db, false)
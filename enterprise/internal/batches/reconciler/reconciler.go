@@ -23,14 +23,14 @@ type GitserverClient interface {
 type Reconciler struct {
 	gitserverClient GitserverClient
 	sourcer         sources.Sourcer
-	store           *store.Store
+	store           *store.ObservedStore
 
 	// This is used to disable a time.Sleep for operationSleep so that the
 	// tests don't run slower.
 	noSleepBeforeSync bool
 }
 
-func New(gitClient GitserverClient, sourcer sources.Sourcer, store *store.Store) *Reconciler {
+func New(gitClient GitserverClient, sourcer sources.Sourcer, store *store.ObservedStore) *Reconciler {
 	return &Reconciler{
 		gitserverClient: gitClient,
 		sourcer:         sourcer,
@@ -74,7 +74,7 @@ func (r *Reconciler) HandlerFunc() workerutil.HandlerFunc {
 // If an error is returned, the workerutil.Worker that called this function
 // (through the HandlerFunc) will set the changeset's ReconcilerState to
 // errored and set its FailureMessage to the error.
-func (r *Reconciler) process(ctx context.Context, tx *store.Store, ch *btypes.Changeset) error {
+func (r *Reconciler) process(ctx context.Context, tx *store.ObservedStore, ch *btypes.Changeset) error {
 	// Reset the error message.
 	ch.FailureMessage = nil
 
@@ -100,7 +100,7 @@ func (r *Reconciler) process(ctx context.Context, tx *store.Store, ch *btypes.Ch
 	)
 }
 
-func loadChangesetSpecs(ctx context.Context, tx *store.Store, ch *btypes.Changeset) (prev, curr *btypes.ChangesetSpec, err error) {
+func loadChangesetSpecs(ctx context.Context, tx *store.ObservedStore, ch *btypes.Changeset) (prev, curr *btypes.ChangesetSpec, err error) {
 	if ch.CurrentSpecID != 0 {
 		curr, err = tx.GetChangesetSpecByID(ctx, ch.CurrentSpecID)
 		if err != nil {
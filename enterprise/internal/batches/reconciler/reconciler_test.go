@@ -24,7 +24,7 @@ func TestReconcilerProcess_IntegrationTest(t *testing.T) {
 	ctx := backend.WithAuthzBypass(context.Background())
 	db := dbtest.NewDB(t, "")
 
-	store := store.New(db, nil)
+	store := store.NewObservedForTest(store.New(db, nil))
 
 	admin := ct.CreateTestUser(t, db, true)
 
@@ -21,7 +21,7 @@ import (
 var _ graphqlbackend.BatchChangeResolver = &batchChangeResolver{}
 
 type batchChangeResolver struct {
-	store *store.Store
+	store *store.ObservedStore
 
 	batchChange *btypes.BatchChange
 
@@ -280,6 +280,16 @@ func (r *batchChangeResolver) BulkOperations(
 		opts.CreatedAfter = args.CreatedAfter.Time
 	}
 
+	if args.State != nil {
+		for _, s := range *args.State {
+			bulkState := btypes.BulkOperationState(s)
+			if !bulkState.Valid() {
+				return nil, errors.Errorf("bulk operation state %q not valid", s)
+			}
+			opts.States = append(opts.States, bulkState)
+		}
+	}
+
 	return &bulkOperationConnectionResolver{
 		store:         r.store,
 		batchChangeID: r.batchChange.ID,
@@ -14,7 +14,7 @@ import (
 var _ graphqlbackend.BatchChangesConnectionResolver = &batchChangesConnectionResolver{}
 
 type batchChangesConnectionResolver struct {
-	store *store.Store
+	store *store.ObservedStore
 	opts  store.ListBatchChangesOpts
 
 	// cache results because they are used by multiple fields
@@ -0,0 +1,132 @@
+package resolvers
+
+import (
+	"context"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go"
+	"github.com/graph-gophers/graphql-go/relay"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/backend"
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+const batchChangeTemplateIDKind = "BatchChangeTemplate"
+
+func marshalBatchChangeTemplateID(id int64) graphql.ID {
+	return relay.MarshalID(batchChangeTemplateIDKind, id)
+}
+
+func unmarshalBatchChangeTemplateID(id graphql.ID) (templateID int64, err error) {
+	err = relay.UnmarshalSpec(id, &templateID)
+	return
+}
+
+type batchChangeTemplateResolver struct {
+	store    *store.ObservedStore
+	template *btypes.BatchChangeTemplate
+}
+
+// Type guard.
+var _ graphqlbackend.BatchChangeTemplateResolver = &batchChangeTemplateResolver{}
+
+func (r *batchChangeTemplateResolver) ID() graphql.ID {
+	return marshalBatchChangeTemplateID(r.template.ID)
+}
+
+func (r *batchChangeTemplateResolver) Name() string {
+	return r.template.Name
+}
+
+func (r *batchChangeTemplateResolver) Description() string {
+	return r.template.Description
+}
+
+func (r *batchChangeTemplateResolver) Spec() string {
+	return r.template.Spec
+}
+
+func (r *batchChangeTemplateResolver) Parameters() []graphqlbackend.BatchChangeTemplateParameterResolver {
+	resolvers := make([]graphqlbackend.BatchChangeTemplateParameterResolver, 0, len(r.template.Parameters))
+	for _, p := range r.template.Parameters {
+		resolvers = append(resolvers, &batchChangeTemplateParameterResolver{parameter: p})
+	}
+	return resolvers
+}
+
+func (r *batchChangeTemplateResolver) Namespace(ctx context.Context) (*graphqlbackend.NamespaceResolver, error) {
+	var (
+		namespace graphqlbackend.NamespaceResolver
+		err       error
+	)
+	if r.template.NamespaceUserID != 0 {
+		namespace.Namespace, err = graphqlbackend.UserByIDInt32(ctx, r.store.DB(), r.template.NamespaceUserID)
+	} else {
+		namespace.Namespace, err = graphqlbackend.OrgByIDInt32(ctx, r.store.DB(), r.template.NamespaceOrgID)
+	}
+	if errcode.IsNotFound(err) {
+		return nil, errors.New("namespace of batch change template has been deleted")
+	}
+	return &namespace, err
+}
+
+func (r *batchChangeTemplateResolver) Creator(ctx context.Context) (*graphqlbackend.UserResolver, error) {
+	if r.template.CreatorID == 0 {
+		return nil, nil
+	}
+	user, err := graphqlbackend.UserByIDInt32(ctx, r.store.DB(), r.template.CreatorID)
+	if errcode.IsNotFound(err) {
+		return nil, nil
+	}
+	return user, err
+}
+
+func (r *batchChangeTemplateResolver) CreatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.template.CreatedAt}
+}
+
+func (r *batchChangeTemplateResolver) UpdatedAt() graphqlbackend.DateTime {
+	return graphqlbackend.DateTime{Time: r.template.UpdatedAt}
+}
+
+func (r *batchChangeTemplateResolver) ViewerCanAdminister(ctx context.Context) (bool, error) {
+	// 🚨 SECURITY: Only site admins and users with access to the namespace can
+	// modify a template.
+	var err error
+	if r.template.NamespaceOrgID != 0 {
+		err = backend.CheckOrgAccessOrSiteAdmin(ctx, r.store.DB(), r.template.NamespaceOrgID)
+		if err == backend.ErrNotAnOrgMember || err == backend.ErrNotAuthenticated {
+			return false, nil
+		}
+	} else {
+		err = backend.CheckSiteAdminOrSameUser(ctx, r.store.DB(), r.template.NamespaceUserID)
+	}
+	if err != nil {
+		if errors.HasType(err, &backend.InsufficientAuthorizationError{}) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
+
+type batchChangeTemplateParameterResolver struct {
+	parameter btypes.BatchChangeTemplateParameter
+}
+
+var _ graphqlbackend.BatchChangeTemplateParameterResolver = &batchChangeTemplateParameterResolver{}
+
+func (r *batchChangeTemplateParameterResolver) Name() string {
+	return r.parameter.Name
+}
+
+func (r *batchChangeTemplateParameterResolver) Description() string {
+	return r.parameter.Description
+}
+
+func (r *batchChangeTemplateParameterResolver) Default() *string {
+	return r.parameter.Default
+}
@@ -34,7 +34,7 @@ func unmarshalBatchSpecID(id graphql.ID) (batchSpecRandID string, err error) {
 var _ graphqlbackend.BatchSpecResolver = &batchSpecResolver{}
 
 type batchSpecResolver struct {
-	store *store.Store
+	store *store.ObservedStore
 
 	batchSpec          *btypes.BatchSpec
 	preloadedNamespace *graphqlbackend.NamespaceResolver
@@ -242,6 +242,35 @@ func (r *batchSpecResolver) DiffStat(ctx context.Context) (*graphqlbackend.DiffS
 	return totalStat, nil
 }
 
+func (r *batchSpecResolver) Estimate(ctx context.Context) (graphqlbackend.BatchSpecEstimateResolver, error) {
+	svc := service.New(r.store)
+	estimate, err := svc.EstimateBatchSpec(ctx, r.batchSpec.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &batchSpecEstimateResolver{estimate: estimate}, nil
+}
+
+type batchSpecEstimateResolver struct {
+	estimate *service.BatchSpecEstimate
+}
+
+func (r *batchSpecEstimateResolver) Repositories() int32 {
+	return int32(r.estimate.Repositories)
+}
+
+func (r *batchSpecEstimateResolver) Files() int32 {
+	return int32(r.estimate.Files)
+}
+
+func (r *batchSpecEstimateResolver) Owners() []string {
+	return r.estimate.Owners
+}
+
+func (r *batchSpecEstimateResolver) CIPipelines() int32 {
+	return int32(r.estimate.CIPipelines)
+}
+
 // TODO(campaigns-deprecation): This should be removed once we remove campaigns completely.
 func (r *batchSpecResolver) AppliesToCampaign(ctx context.Context) (graphqlbackend.BatchChangeResolver, error) {
 	return r.AppliesToBatchChange(ctx)
@@ -25,7 +25,7 @@ func unmarshalBatchSpecExecutionRandID(id graphql.ID) (batchSpecExecutionID stri
 }
 
 type batchSpecExecutionResolver struct {
-	store *store.Store
+	store *store.ObservedStore
 	exec  *btypes.BatchSpecExecution
 }
 
@@ -73,9 +73,18 @@ func (r *batchSpecExecutionResolver) Steps() graphqlbackend.BatchSpecExecutionSt
 	}
 }
 
-func (r *batchSpecExecutionResolver) PlaceInQueue() *int32 {
-	// TODO(eseliger): Implement this.
-	return nil
+func (r *batchSpecExecutionResolver) PlaceInQueue(ctx context.Context) (*int32, error) {
+	if r.exec.State != btypes.BatchSpecExecutionStateQueued {
+		return nil, nil
+	}
+
+	place, ok, err := r.store.BatchSpecExecutionPlaceInQueue(ctx, r.exec.ID)
+	if err != nil || !ok {
+		return nil, err
+	}
+
+	i32 := int32(place)
+	return &i32, nil
 }
 
 func (r *batchSpecExecutionResolver) BatchSpec(ctx context.Context) (graphqlbackend.BatchSpecResolver, error) {
@@ -115,7 +124,7 @@ func (r *batchSpecExecutionResolver) Namespace(ctx context.Context) (*graphqlbac
 }
 
 type batchSpecExecutionStepsResolver struct {
-	store *store.Store
+	store *store.ObservedStore
 	exec  *btypes.BatchSpecExecution
 }
 
@@ -29,7 +29,7 @@ func TestBatchSpecResolver(t *testing.T) {
 	ctx := backend.WithAuthzBypass(context.Background())
 	db := dbtest.NewDB(t, "")
 
-	cstore := store.New(db, nil)
+	cstore := store.NewObservedForTest(store.New(db, nil))
 	repoStore := database.ReposWith(cstore)
 	esStore := database.ExternalServicesWith(cstore)
 
@@ -26,7 +26,7 @@ func unmarshalBulkOperationID(id graphql.ID) (bulkOperationID string, err error)
 }
 
 type bulkOperationResolver struct {
-	store         *store.Store
+	store         *store.ObservedStore
 	bulkOperation *btypes.BulkOperation
 }
 
@@ -12,7 +12,7 @@ import (
 )
 
 type bulkOperationConnectionResolver struct {
-	store         *store.Store
+	store         *store.ObservedStore
 	batchChangeID int64
 	opts          store.ListBulkOperationsOpts
 
@@ -29,6 +29,7 @@ func (r *bulkOperationConnectionResolver) TotalCount(ctx context.Context) (int32
 	count, err := r.store.CountBulkOperations(ctx, store.CountBulkOperationsOpts{
 		BatchChangeID: r.batchChangeID,
 		CreatedAfter:  r.opts.CreatedAfter,
+		States:        r.opts.States,
 	})
 	if err != nil {
 		return 0, err
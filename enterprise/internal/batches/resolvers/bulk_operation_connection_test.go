@@ -30,7 +30,7 @@ func TestBulkOperationConnectionResolver(t *testing.T) {
 	userID := ct.CreateTestUser(t, db, true).ID
 	now := timeutil.Now()
 	clock := func() time.Time { return now }
-	cstore := store.NewWithClock(db, nil, clock)
+	cstore := store.NewObservedForTest(store.NewWithClock(db, nil, clock))
 
 	batchSpec := ct.CreateBatchSpec(t, ctx, cstore, "test", userID)
 	batchChange := ct.CreateBatchChange(t, ctx, cstore, "test", userID, batchSpec.ID)
@@ -180,13 +180,34 @@ func TestBulkOperationConnectionResolver(t *testing.T) {
 			t.Fatalf("unexpected endCursor existence. want=%t, have=%t", want, have)
 		}
 	}
+
+	t.Run("State filter", func(t *testing.T) {
+		for state, wantCount := range map[btypes.BulkOperationState]int{
+			btypes.BulkOperationStateProcessing: len(nodes),
+			btypes.BulkOperationStateCompleted:  0,
+		} {
+			input := map[string]interface{}{"batchChange": batchChangeAPIID, "first": 10, "state": []string{string(state)}}
+			var response struct {
+				Node apitest.BatchChange
+			}
+			apitest.MustExec(actor.WithActor(context.Background(), actor.FromUser(userID)), t, s, input, &response, queryBulkOperationConnection)
+
+			bulkOperations := response.Node.BulkOperations
+			if have := bulkOperations.TotalCount; have != wantCount {
+				t.Fatalf("unexpected total count for state %s. want=%d, have=%d", state, wantCount, have)
+			}
+			if have := len(bulkOperations.Nodes); have != wantCount {
+				t.Fatalf("unexpected number of nodes for state %s. want=%d, have=%d", state, wantCount, have)
+			}
+		}
+	})
 }
 
 const queryBulkOperationConnection = `
-query($batchChange: ID!, $first: Int, $after: String){
+query($batchChange: ID!, $first: Int, $after: String, $state: [BulkOperationState!]){
     node(id: $batchChange) {
         ... on BatchChange {
-            bulkOperations(first: $first, after: $after) {
+            bulkOperations(first: $first, after: $after, state: $state) {
                 totalCount
                 pageInfo {
                     endCursor
@@ -29,7 +29,7 @@ func TestBulkOperationResolver(t *testing.T) {
 
 	now := timeutil.Now()
 	clock := func() time.Time { return now }
-	cstore := store.NewWithClock(db, nil, clock)
+	cstore := store.NewObservedForTest(store.NewWithClock(db, nil, clock))
 
 	batchSpec := ct.CreateBatchSpec(t, ctx, cstore, "test", userID)
 	batchChange := ct.CreateBatchChange(t, ctx, cstore, "test", userID, batchSpec.ID)
@@ -26,7 +26,7 @@ import (
 )
 
 type changesetResolver struct {
-	store *store.Store
+	store *store.ObservedStore
 
 	changeset *btypes.Changeset
 
@@ -47,14 +47,14 @@ type changesetResolver struct {
 	specErr  error
 }
 
-func NewChangesetResolverWithNextSync(store *store.Store, changeset *btypes.Changeset, repo *types.Repo, nextSyncAt time.Time) *changesetResolver {
+func NewChangesetResolverWithNextSync(store *store.ObservedStore, changeset *btypes.Changeset, repo *types.Repo, nextSyncAt time.Time) *changesetResolver {
 	r := NewChangesetResolver(store, changeset, repo)
 	r.attemptedPreloadNextSyncAt = true
 	r.preloadedNextSyncAt = nextSyncAt
 	return r
 }
 
-func NewChangesetResolver(store *store.Store, changeset *btypes.Changeset, repo *types.Repo) *changesetResolver {
+func NewChangesetResolver(store *store.ObservedStore, changeset *btypes.Changeset, repo *types.Repo) *changesetResolver {
 	return &changesetResolver{
 		store:        store,
 		repo:         repo,
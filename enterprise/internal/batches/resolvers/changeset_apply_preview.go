@@ -17,7 +17,7 @@ import (
 )
 
 type changesetApplyPreviewResolver struct {
-	store *store.Store
+	store *store.ObservedStore
 
 	mapping              *btypes.RewirerMapping
 	preloadedNextSync    time.Time
@@ -57,7 +57,7 @@ func (r *changesetApplyPreviewResolver) ToHiddenChangesetApplyPreview() (graphql
 }
 
 type hiddenChangesetApplyPreviewResolver struct {
-	store *store.Store
+	store *store.ObservedStore
 
 	mapping           *btypes.RewirerMapping
 	preloadedNextSync time.Time
@@ -84,7 +84,7 @@ func (r *hiddenChangesetApplyPreviewResolver) Targets() graphqlbackend.HiddenApp
 }
 
 type hiddenApplyPreviewTargetsResolver struct {
-	store *store.Store
+	store *store.ObservedStore
 
 	mapping           *btypes.RewirerMapping
 	preloadedNextSync time.Time
@@ -129,7 +129,7 @@ func (r *hiddenApplyPreviewTargetsResolver) Changeset(ctx context.Context) (grap
 }
 
 type visibleChangesetApplyPreviewResolver struct {
-	store *store.Store
+	store *store.ObservedStore
 
 	mapping              *btypes.RewirerMapping
 	preloadedNextSync    time.Time
@@ -279,7 +279,7 @@ func (r *visibleChangesetApplyPreviewResolver) computeBatchChange(ctx context.Co
 }
 
 type visibleApplyPreviewTargetsResolver struct {
-	store *store.Store
+	store *store.ObservedStore
 
 	mapping           *btypes.RewirerMapping
 	preloadedNextSync time.Time
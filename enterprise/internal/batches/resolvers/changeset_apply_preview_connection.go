@@ -20,7 +20,7 @@ import (
 var _ graphqlbackend.ChangesetApplyPreviewConnectionResolver = &changesetApplyPreviewConnectionResolver{}
 
 type changesetApplyPreviewConnectionResolver struct {
-	store *store.Store
+	store *store.ObservedStore
 
 	opts        store.GetRewirerMappingsOpts
 	action      *btypes.ReconcilerOperation
@@ -247,7 +247,7 @@ type rewirerMappingsFacade struct {
 
 	// Inputs from outside the resolver that we need to build other resolvers.
 	batchSpecID int64
-	store       *store.Store
+	store       *store.ObservedStore
 
 	// This field is set when ReconcileBatchChange is called.
 	batchChange *btypes.BatchChange
@@ -263,7 +263,7 @@ type rewirerMappingsFacade struct {
 
 // newRewirerMappingsFacade creates a new rewirer mappings object, which
 // includes dry running the batch change reconciliation.
-func newRewirerMappingsFacade(s *store.Store, batchSpecID int64) *rewirerMappingsFacade {
+func newRewirerMappingsFacade(s *store.ObservedStore, batchSpecID int64) *rewirerMappingsFacade {
 	return &rewirerMappingsFacade{
 		batchSpecID: batchSpecID,
 		store:       s,
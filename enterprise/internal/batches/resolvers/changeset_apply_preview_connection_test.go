@@ -30,7 +30,7 @@ func TestChangesetApplyPreviewConnectionResolver(t *testing.T) {
 
 	userID := ct.CreateTestUser(t, db, false).ID
 
-	cstore := store.New(db, nil)
+	cstore := store.NewObservedForTest(store.New(db, nil))
 
 	batchSpec := &btypes.BatchSpec{
 		UserID:          userID,
@@ -394,7 +394,7 @@ func TestRewirerMappings(t *testing.T) {
 			}
 		}
 
-		s := &store.Store{}
+		s := store.NewObservedForTest(&store.Store{})
 		rmf := newRewirerMappingsFacade(s, 1)
 		rmf.batchChange = &btypes.BatchChange{}
 
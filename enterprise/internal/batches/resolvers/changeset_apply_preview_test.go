@@ -28,7 +28,7 @@ func TestChangesetApplyPreviewResolver(t *testing.T) {
 
 	userID := ct.CreateTestUser(t, db, false).ID
 
-	cstore := store.New(db, nil)
+	cstore := store.NewObservedForTest(store.New(db, nil))
 
 	// Create a batch spec for the target batch change.
 	oldBatchSpec := &btypes.BatchSpec{
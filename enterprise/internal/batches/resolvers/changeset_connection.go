@@ -14,7 +14,7 @@ import (
 )
 
 type changesetsConnectionResolver struct {
-	store *store.Store
+	store *store.ObservedStore
 
 	opts store.ListChangesetsOpts
 	// 🚨 SECURITY: If the given opts do not reveal hidden information about a
@@ -10,7 +10,7 @@ import (
 )
 
 type changesetEventResolver struct {
-	store             *store.Store
+	store             *store.ObservedStore
 	changesetResolver *changesetResolver
 	*btypes.ChangesetEvent
 }
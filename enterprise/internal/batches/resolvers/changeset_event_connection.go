@@ -12,7 +12,7 @@ import (
 )
 
 type changesetEventsConnectionResolver struct {
-	store             *store.Store
+	store             *store.ObservedStore
 	changesetResolver *changesetResolver
 	first             int
 	cursor            int64
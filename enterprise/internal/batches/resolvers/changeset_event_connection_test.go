@@ -33,7 +33,7 @@ func TestChangesetEventConnectionResolver(t *testing.T) {
 
 	now := timeutil.Now()
 	clock := func() time.Time { return now }
-	cstore := store.NewWithClock(db, nil, clock)
+	cstore := store.NewObservedForTest(store.NewWithClock(db, nil, clock))
 	repoStore := database.ReposWith(cstore)
 	esStore := database.ExternalServicesWith(cstore)
 
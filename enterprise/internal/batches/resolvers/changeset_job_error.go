@@ -8,7 +8,7 @@ import (
 )
 
 type changesetJobErrorResolver struct {
-	store     *store.Store
+	store     *store.ObservedStore
 	changeset *btypes.Changeset
 	repo      *types.Repo
 	error     string
@@ -29,14 +29,14 @@ func unmarshalChangesetSpecID(id graphql.ID) (changesetSpecRandID string, err er
 var _ graphqlbackend.ChangesetSpecResolver = &changesetSpecResolver{}
 
 type changesetSpecResolver struct {
-	store *store.Store
+	store *store.ObservedStore
 
 	changesetSpec *btypes.ChangesetSpec
 
 	repo *types.Repo
 }
 
-func NewChangesetSpecResolver(ctx context.Context, store *store.Store, changesetSpec *btypes.ChangesetSpec) (*changesetSpecResolver, error) {
+func NewChangesetSpecResolver(ctx context.Context, store *store.ObservedStore, changesetSpec *btypes.ChangesetSpec) (*changesetSpecResolver, error) {
 	resolver := &changesetSpecResolver{
 		store:         store,
 		changesetSpec: changesetSpec,
@@ -59,7 +59,7 @@ func NewChangesetSpecResolver(ctx context.Context, store *store.Store, changeset
 	return resolver, nil
 }
 
-func NewChangesetSpecResolverWithRepo(store *store.Store, repo *types.Repo, changesetSpec *btypes.ChangesetSpec) *changesetSpecResolver {
+func NewChangesetSpecResolverWithRepo(store *store.ObservedStore, repo *types.Repo, changesetSpec *btypes.ChangesetSpec) *changesetSpecResolver {
 	return &changesetSpecResolver{
 		store:         store,
 		repo:          repo,
@@ -120,7 +120,7 @@ var _ graphqlbackend.ChangesetDescription = &changesetDescriptionResolver{}
 // interfaces: ExistingChangesetReferenceResolver and
 // GitBranchChangesetDescriptionResolver.
 type changesetDescriptionResolver struct {
-	store        *store.Store
+	store        *store.ObservedStore
 	repoResolver *graphqlbackend.RepositoryResolver
 	desc         *btypes.ChangesetSpecDescription
 	diffStat     diff.Stat
@@ -187,7 +187,7 @@ func (r *changesetDescriptionResolver) Commits() []graphqlbackend.GitCommitDescr
 var _ graphqlbackend.GitCommitDescriptionResolver = &gitCommitDescriptionResolver{}
 
 type gitCommitDescriptionResolver struct {
-	store       *store.Store
+	store       *store.ObservedStore
 	message     string
 	diff        string
 	authorName  string
@@ -16,7 +16,7 @@ import (
 var _ graphqlbackend.ChangesetSpecConnectionResolver = &changesetSpecConnectionResolver{}
 
 type changesetSpecConnectionResolver struct {
-	store *store.Store
+	store *store.ObservedStore
 
 	opts        store.ListChangesetSpecsOpts
 	batchSpecID int64
@@ -28,7 +28,7 @@ func TestChangesetSpecConnectionResolver(t *testing.T) {
 
 	userID := ct.CreateTestUser(t, db, false).ID
 
-	cstore := store.New(db, nil)
+	cstore := store.NewObservedForTest(store.New(db, nil))
 
 	batchSpec := &btypes.BatchSpec{
 		UserID:          userID,
@@ -30,7 +30,7 @@ func TestChangesetSpecResolver(t *testing.T) {
 
 	userID := ct.CreateTestUser(t, db, false).ID
 
-	cstore := store.New(db, nil)
+	cstore := store.NewObservedForTest(store.New(db, nil))
 	esStore := database.ExternalServicesWith(cstore)
 
 	// Creating user with matching email to the changeset spec author.
@@ -17,7 +17,7 @@ type batchChangesCodeHostConnectionResolver struct {
 	onlyWithoutCredential bool
 	opts                  store.ListCodeHostsOpts
 	limitOffset           database.LimitOffset
-	store                 *store.Store
+	store                 *store.ObservedStore
 
 	once          sync.Once
 	chs           []*btypes.CodeHost
@@ -92,6 +92,16 @@ func (e ErrMatchingBatchChangeExists) Extensions() map[string]interface{} {
 	return map[string]interface{}{"code": "ErrMatchingBatchChangeExists"}
 }
 
+type ErrBatchChangeVersionConflict struct{}
+
+func (e ErrBatchChangeVersionConflict) Error() string {
+	return "the batch change has been modified concurrently, please retry"
+}
+
+func (e ErrBatchChangeVersionConflict) Extensions() map[string]interface{} {
+	return map[string]interface{}{"code": "ErrBatchChangeVersionConflict"}
+}
+
 type ErrDuplicateCredential struct{}
 
 func (e ErrDuplicateCredential) Error() string {
@@ -216,7 +216,7 @@ func mockRepoComparison(t *testing.T, baseRev, headRev, diff string) {
 	t.Cleanup(func() { git.Mocks.MergeBase = nil })
 }
 
-func addChangeset(t *testing.T, ctx context.Context, s *store.Store, c *btypes.Changeset, batchChange int64) {
+func addChangeset(t *testing.T, ctx context.Context, s *store.ObservedStore, c *btypes.Changeset, batchChange int64) {
 	t.Helper()
 
 	c.BatchChanges = append(c.BatchChanges, btypes.BatchChangeAssoc{BatchChangeID: batchChange})
@@ -238,7 +238,7 @@ func pruneUserCredentials(t *testing.T, db dbutil.DB, key encryption.Key) {
 	}
 }
 
-func pruneSiteCredentials(t *testing.T, cstore *store.Store) {
+func pruneSiteCredentials(t *testing.T, cstore *store.ObservedStore) {
 	t.Helper()
 	creds, _, err := cstore.ListSiteCredentials(context.Background(), store.ListSiteCredentialsOpts{})
 	if err != nil {
@@ -39,7 +39,7 @@ func TestPermissionLevels(t *testing.T) {
 	db := dbtest.NewDB(t, "")
 	key := et.TestKey{}
 
-	cstore := store.New(db, key)
+	cstore := store.NewObservedForTest(store.New(db, key))
 	sr := New(cstore)
 	s, err := graphqlbackend.NewSchema(db, sr, nil, nil, nil, nil, nil, nil)
 	if err != nil {
@@ -75,7 +75,7 @@ func TestPermissionLevels(t *testing.T) {
 		t.Fatal(err)
 	}
 
-	createBatchChange := func(t *testing.T, s *store.Store, name string, userID int32, batchSpecID int64) (batchChangeID int64) {
+	createBatchChange := func(t *testing.T, s *store.ObservedStore, name string, userID int32, batchSpecID int64) (batchChangeID int64) {
 		t.Helper()
 
 		c := &btypes.BatchChange{
@@ -104,7 +104,7 @@ func TestPermissionLevels(t *testing.T) {
 		return c.ID
 	}
 
-	createBatchSpec := func(t *testing.T, s *store.Store, userID int32) (randID string, id int64) {
+	createBatchSpec := func(t *testing.T, s *store.ObservedStore, userID int32) (randID string, id int64) {
 		t.Helper()
 
 		cs := &btypes.BatchSpec{UserID: userID, NamespaceUserID: userID}
@@ -115,7 +115,7 @@ func TestPermissionLevels(t *testing.T) {
 		return cs.RandID, cs.ID
 	}
 
-	cleanUpBatchChanges := func(t *testing.T, s *store.Store) {
+	cleanUpBatchChanges := func(t *testing.T, s *store.ObservedStore) {
 		t.Helper()
 
 		batchChanges, next, err := s.ListBatchChanges(ctx, store.ListBatchChangesOpts{LimitOpts: store.LimitOpts{Limit: 1000}})
@@ -945,7 +945,7 @@ func TestRepositoryPermissions(t *testing.T) {
 
 	db := dbtest.NewDB(t, "")
 
-	cstore := store.New(db, nil)
+	cstore := store.NewObservedForTest(store.New(db, nil))
 	sr := &Resolver{store: cstore}
 	s, err := graphqlbackend.NewSchema(db, sr, nil, nil, nil, nil, nil, nil)
 	if err != nil {
@@ -2,7 +2,6 @@ package resolvers
 
 import (
 	"context"
-	"encoding/json"
 	"fmt"
 	"strconv"
 
@@ -27,18 +26,17 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
-	"github.com/sourcegraph/sourcegraph/internal/featureflag"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
 	"github.com/sourcegraph/sourcegraph/internal/usagestats"
 )
 
 // Resolver is the GraphQL resolver of all things related to batch changes.
 type Resolver struct {
-	store *store.Store
+	store *store.ObservedStore
 }
 
 // New returns a new Resolver whose store uses the given database
-func New(store *store.Store) graphqlbackend.BatchChangesResolver {
+func New(store *store.ObservedStore) graphqlbackend.BatchChangesResolver {
 	return &Resolver{store: store}
 }
 
@@ -106,16 +104,6 @@ type batchChangeEventArg struct {
 	BatchChangeID int64 `json:"batch_change_id"`
 }
 
-func logBackendEvent(ctx context.Context, db dbutil.DB, name string, args interface{}) error {
-	actor := actor.FromContext(ctx)
-	jsonArg, err := json.Marshal(args)
-	if err != nil {
-		return err
-	}
-	featureFlags := featureflag.FromContext(ctx)
-	return usagestats.LogBackendEvent(db, actor.UID, name, jsonArg, featureFlags, nil)
-}
-
 func (r *Resolver) NodeResolvers() map[string]graphqlbackend.NodeByIDFunc {
 	return map[string]graphqlbackend.NodeByIDFunc{
 		"Campaign": func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
@@ -148,6 +136,9 @@ func (r *Resolver) NodeResolvers() map[string]graphqlbackend.NodeByIDFunc {
 		batchSpecExecutionIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
 			return r.batchSpecExecutionByID(ctx, id)
 		},
+		batchChangeTemplateIDKind: func(ctx context.Context, id graphql.ID) (graphqlbackend.Node, error) {
+			return r.batchChangeTemplateByID(ctx, id)
+		},
 	}
 }
 
@@ -389,6 +380,30 @@ func (r *Resolver) batchSpecExecutionByID(ctx context.Context, id graphql.ID) (g
 	return &batchSpecExecutionResolver{store: r.store, exec: spec}, nil
 }
 
+func (r *Resolver) batchChangeTemplateByID(ctx context.Context, id graphql.ID) (graphqlbackend.BatchChangeTemplateResolver, error) {
+	if err := batchChangesEnabled(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchChangeTemplateID(id)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, nil
+	}
+
+	template, err := r.store.GetBatchChangeTemplate(ctx, store.GetBatchChangeTemplateOpts{ID: templateID})
+	if err != nil {
+		if err == store.ErrNoResults {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return &batchChangeTemplateResolver{store: r.store, template: template}, nil
+}
+
 func (r *Resolver) CreateBatchChange(ctx context.Context, args *graphqlbackend.CreateBatchChangeArgs) (graphqlbackend.BatchChangeResolver, error) {
 	var err error
 	tr, _ := trace.New(ctx, "Resolver.CreateBatchChange", fmt.Sprintf("BatchSpec %s", args.BatchSpec))
@@ -411,7 +426,7 @@ func (r *Resolver) CreateBatchChange(ctx context.Context, args *graphqlbackend.C
 	}
 
 	arg := &batchChangeEventArg{BatchChangeID: batchChange.ID}
-	err = logBackendEvent(ctx, r.store.DB(), "BatchChangeCreated", arg)
+	err = usagestats.LogActorEvent(ctx, r.store.DB(), "BatchChangeCreated", arg)
 	if err != nil {
 		return nil, err
 	}
@@ -433,7 +448,7 @@ func (r *Resolver) ApplyBatchChange(ctx context.Context, args *graphqlbackend.Ap
 	}
 
 	arg := &batchChangeEventArg{BatchChangeID: batchChange.ID}
-	err = logBackendEvent(ctx, r.store.DB(), "BatchChangeCreatedOrUpdated", arg)
+	err = usagestats.LogActorEvent(ctx, r.store.DB(), "BatchChangeCreatedOrUpdated", arg)
 	if err != nil {
 		return nil, err
 	}
@@ -497,6 +512,8 @@ func (r *Resolver) applyOrCreateBatchChange(ctx context.Context, args *graphqlba
 			return nil, ErrApplyClosedBatchChange{}
 		} else if err == service.ErrMatchingBatchChangeExists {
 			return nil, ErrMatchingBatchChangeExists{}
+		} else if err == store.ErrBatchChangeVersionConflict {
+			return nil, ErrBatchChangeVersionConflict{}
 		}
 		return nil, err
 	}
@@ -552,7 +569,7 @@ func (r *Resolver) CreateBatchSpec(ctx context.Context, args *graphqlbackend.Cre
 	}
 
 	eventArg := &batchSpecCreatedArg{ChangesetSpecsCount: len(opts.ChangesetSpecRandIDs)}
-	if err := logBackendEvent(ctx, r.store.DB(), "BatchSpecCreated", eventArg); err != nil {
+	if err := usagestats.LogActorEvent(ctx, r.store.DB(), "BatchSpecCreated", eventArg); err != nil {
 		return nil, err
 	}
 
@@ -637,6 +654,9 @@ func (r *Resolver) MoveBatchChange(ctx context.Context, args *graphqlbackend.Mov
 	// 🚨 SECURITY: MoveBatchChange checks whether the current user is authorized.
 	batchChange, err := svc.MoveBatchChange(ctx, opts)
 	if err != nil {
+		if err == store.ErrBatchChangeVersionConflict {
+			return nil, ErrBatchChangeVersionConflict{}
+		}
 		return nil, err
 	}
 
@@ -670,7 +690,7 @@ func (r *Resolver) DeleteBatchChange(ctx context.Context, args *graphqlbackend.D
 	}
 
 	arg := &batchChangeEventArg{BatchChangeID: batchChangeID}
-	if err := logBackendEvent(ctx, r.store.DB(), "BatchChangeDeleted", arg); err != nil {
+	if err := usagestats.LogActorEvent(ctx, r.store.DB(), "BatchChangeDeleted", arg); err != nil {
 		return nil, err
 	}
 
@@ -948,11 +968,14 @@ func (r *Resolver) CloseBatchChange(ctx context.Context, args *graphqlbackend.Cl
 	// 🚨 SECURITY: CloseBatchChange checks whether current user is authorized.
 	batchChange, err := svc.CloseBatchChange(ctx, batchChangeID, args.CloseChangesets)
 	if err != nil {
+		if err == store.ErrBatchChangeVersionConflict {
+			return nil, ErrBatchChangeVersionConflict{}
+		}
 		return nil, errors.Wrap(err, "closing batch change")
 	}
 
 	arg := &batchChangeEventArg{BatchChangeID: batchChangeID}
-	if err := logBackendEvent(ctx, r.store.DB(), "BatchChangeClosed", arg); err != nil {
+	if err := usagestats.LogActorEvent(ctx, r.store.DB(), "BatchChangeClosed", arg); err != nil {
 		return nil, err
 	}
 
@@ -1459,21 +1482,217 @@ func (r *Resolver) CreateBatchSpecExecution(ctx context.Context, args *graphqlba
 		return nil, err
 	}
 
-	actor := actor.FromContext(ctx)
-
-	exec := &btypes.BatchSpecExecution{
-		BatchSpec:       args.Spec,
-		UserID:          actor.UID,
-		NamespaceUserID: actor.UID,
+	opts := service.CreateBatchSpecExecutionOpts{Spec: args.Spec}
+	if args.Namespace != nil {
+		err := graphqlbackend.UnmarshalNamespaceID(*args.Namespace, &opts.NamespaceUserID, &opts.NamespaceOrgID)
+		if err != nil {
+			return nil, err
+		}
 	}
 
-	if err := r.store.CreateBatchSpecExecution(ctx, exec); err != nil {
+	exec, err := service.New(r.store).CreateBatchSpecExecution(ctx, opts)
+	if err != nil {
 		return nil, err
 	}
 
 	return r.batchSpecExecutionByID(ctx, marshalBatchSpecExecutionRandID(exec.RandID))
 }
 
+func (r *Resolver) CreateBatchChangeTemplate(ctx context.Context, args *graphqlbackend.CreateBatchChangeTemplateArgs) (_ graphqlbackend.BatchChangeTemplateResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.CreateBatchChangeTemplate", fmt.Sprintf("Namespace %s, Name %q", args.Namespace, args.Name))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := batchChangesEnabled(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	if err := batchChangesCreateAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	opts := service.CreateBatchChangeTemplateOpts{
+		Name:        args.Name,
+		Description: args.Description,
+		Spec:        args.Spec,
+		Parameters:  parseBatchChangeTemplateParameters(args.Parameters),
+	}
+	err = graphqlbackend.UnmarshalNamespaceID(args.Namespace, &opts.NamespaceUserID, &opts.NamespaceOrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: CreateBatchChangeTemplate checks whether current user has
+	// access to the namespace.
+	template, err := service.New(r.store).CreateBatchChangeTemplate(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	return &batchChangeTemplateResolver{store: r.store, template: template}, nil
+}
+
+func (r *Resolver) UpdateBatchChangeTemplate(ctx context.Context, args *graphqlbackend.UpdateBatchChangeTemplateArgs) (_ graphqlbackend.BatchChangeTemplateResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.UpdateBatchChangeTemplate", fmt.Sprintf("BatchChangeTemplate: %q", args.BatchChangeTemplate))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := batchChangesEnabled(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchChangeTemplateID(args.BatchChangeTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	// 🚨 SECURITY: UpdateBatchChangeTemplate checks whether current user is
+	// authorized.
+	template, err := service.New(r.store).UpdateBatchChangeTemplate(ctx, service.UpdateBatchChangeTemplateOpts{
+		ID:          templateID,
+		Name:        args.Name,
+		Description: args.Description,
+		Spec:        args.Spec,
+		Parameters:  parseBatchChangeTemplateParameters(args.Parameters),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &batchChangeTemplateResolver{store: r.store, template: template}, nil
+}
+
+func (r *Resolver) DeleteBatchChangeTemplate(ctx context.Context, args *graphqlbackend.DeleteBatchChangeTemplateArgs) (_ *graphqlbackend.EmptyResponse, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.DeleteBatchChangeTemplate", fmt.Sprintf("BatchChangeTemplate: %q", args.BatchChangeTemplate))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := batchChangesEnabled(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchChangeTemplateID(args.BatchChangeTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	// 🚨 SECURITY: DeleteBatchChangeTemplate checks whether current user is
+	// authorized.
+	if err := service.New(r.store).DeleteBatchChangeTemplate(ctx, templateID); err != nil {
+		return nil, err
+	}
+	return &graphqlbackend.EmptyResponse{}, nil
+}
+
+func (r *Resolver) InstantiateBatchChangeTemplate(ctx context.Context, args *graphqlbackend.InstantiateBatchChangeTemplateArgs) (_ graphqlbackend.BatchSpecResolver, err error) {
+	tr, ctx := trace.New(ctx, "Resolver.InstantiateBatchChangeTemplate", fmt.Sprintf("BatchChangeTemplate: %q, Namespace %s", args.BatchChangeTemplate, args.Namespace))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	if err := batchChangesEnabled(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	if err := batchChangesCreateAccess(ctx); err != nil {
+		return nil, err
+	}
+
+	templateID, err := unmarshalBatchChangeTemplateID(args.BatchChangeTemplate)
+	if err != nil {
+		return nil, err
+	}
+
+	if templateID == 0 {
+		return nil, ErrIDIsZero{}
+	}
+
+	opts := service.InstantiateBatchChangeTemplateOpts{
+		TemplateID: templateID,
+		Values:     make(map[string]string, len(args.Parameters)),
+	}
+	for _, p := range args.Parameters {
+		if _, ok := opts.Values[p.Name]; ok {
+			return nil, errors.Errorf("duplicate value for parameter %q", p.Name)
+		}
+		opts.Values[p.Name] = p.Value
+	}
+	err = graphqlbackend.UnmarshalNamespaceID(args.Namespace, &opts.NamespaceUserID, &opts.NamespaceOrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: InstantiateBatchChangeTemplate checks whether current user
+	// has access to the namespace.
+	batchSpec, err := service.New(r.store).InstantiateBatchChangeTemplate(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	eventArg := &batchSpecCreatedArg{}
+	if err := usagestats.LogActorEvent(ctx, r.store.DB(), "BatchSpecCreated", eventArg); err != nil {
+		return nil, err
+	}
+
+	return &batchSpecResolver{store: r.store, batchSpec: batchSpec}, nil
+}
+
+func (r *Resolver) BatchChangeTemplates(ctx context.Context, args *graphqlbackend.ListBatchChangeTemplatesArgs) (graphqlbackend.BatchChangeTemplateConnectionResolver, error) {
+	if err := batchChangesEnabled(ctx, r.store.DB()); err != nil {
+		return nil, err
+	}
+
+	opts := store.ListBatchChangeTemplatesOpts{}
+	if err := validateFirstParamDefaults(args.First); err != nil {
+		return nil, err
+	}
+	opts.Limit = int(args.First)
+	if args.After != nil {
+		cursor, err := strconv.ParseInt(*args.After, 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		opts.Cursor = cursor
+	}
+
+	if args.Namespace != nil {
+		err := graphqlbackend.UnmarshalNamespaceID(*args.Namespace, &opts.NamespaceUserID, &opts.NamespaceOrgID)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return &batchChangeTemplateConnectionResolver{
+		store: r.store,
+		opts:  opts,
+	}, nil
+}
+
+func parseBatchChangeTemplateParameters(inputs []graphqlbackend.BatchChangeTemplateParameterInput) []btypes.BatchChangeTemplateParameter {
+	params := make([]btypes.BatchChangeTemplateParameter, 0, len(inputs))
+	for _, p := range inputs {
+		params = append(params, btypes.BatchChangeTemplateParameter{
+			Name:        p.Name,
+			Description: p.Description,
+			Default:     p.Default,
+		})
+	}
+	return params
+}
+
 func parseBatchChangeState(s *string) (btypes.BatchChangeState, error) {
 	if s == nil {
 		return btypes.BatchChangeStateAny, nil
@@ -37,7 +37,7 @@ func TestNullIDResilience(t *testing.T) {
 	ct.MockRSAKeygen(t)
 
 	db := dbtest.NewDB(t, "")
-	sr := New(store.New(db, nil))
+	sr := New(store.NewObservedForTest(store.New(db, nil)))
 
 	s, err := graphqlbackend.NewSchema(db, sr, nil, nil, nil, nil, nil, nil)
 	if err != nil {
@@ -115,7 +115,7 @@ func TestCreateBatchSpec(t *testing.T) {
 	user := ct.CreateTestUser(t, db, true)
 	userID := user.ID
 
-	cstore := store.New(db, nil)
+	cstore := store.NewObservedForTest(store.New(db, nil))
 	repoStore := database.ReposWith(cstore)
 	esStore := database.ExternalServicesWith(cstore)
 
@@ -295,7 +295,7 @@ func TestCreateChangesetSpec(t *testing.T) {
 
 	userID := ct.CreateTestUser(t, db, true).ID
 
-	cstore := store.New(db, nil)
+	cstore := store.NewObservedForTest(store.New(db, nil))
 	repoStore := database.ReposWith(cstore)
 	esStore := database.ExternalServicesWith(cstore)
 
@@ -374,7 +374,7 @@ func TestApplyBatchChange(t *testing.T) {
 
 	now := timeutil.Now()
 	clock := func() time.Time { return now }
-	cstore := store.NewWithClock(db, nil, clock)
+	cstore := store.NewObservedForTest(store.NewWithClock(db, nil, clock))
 	repoStore := database.ReposWith(cstore)
 	esStore := database.ExternalServicesWith(cstore)
 
@@ -534,7 +534,7 @@ func TestCreateBatchChange(t *testing.T) {
 
 	userID := ct.CreateTestUser(t, db, true).ID
 
-	cstore := store.New(db, nil)
+	cstore := store.NewObservedForTest(store.New(db, nil))
 
 	batchSpec := &btypes.BatchSpec{
 		RawSpec: ct.TestRawBatchSpec,
@@ -611,7 +611,7 @@ func TestApplyOrCreateBatchSpecWithPublicationStates(t *testing.T) {
 
 	now := timeutil.Now()
 	clock := func() time.Time { return now }
-	cstore := store.NewWithClock(db, nil, clock)
+	cstore := store.NewObservedForTest(store.NewWithClock(db, nil, clock))
 	repoStore := database.ReposWith(cstore)
 	esStore := database.ExternalServicesWith(cstore)
 
@@ -789,7 +789,7 @@ func TestMoveBatchChange(t *testing.T) {
 	orgName := "move-batch-change-test"
 	orgID := ct.InsertTestOrg(t, db, orgName)
 
-	cstore := store.New(db, nil)
+	cstore := store.NewObservedForTest(store.New(db, nil))
 
 	batchSpec := &btypes.BatchSpec{
 		RawSpec:         ct.TestRawBatchSpec,
@@ -1064,7 +1064,7 @@ func TestCreateBatchChangesCredential(t *testing.T) {
 
 	userID := ct.CreateTestUser(t, db, true).ID
 
-	cstore := store.New(db, nil)
+	cstore := store.NewObservedForTest(store.New(db, nil))
 
 	r := &Resolver{store: cstore}
 	s, err := graphqlbackend.NewSchema(db, r, nil, nil, nil, nil, nil, nil)
@@ -1194,7 +1194,7 @@ func TestDeleteBatchChangesCredential(t *testing.T) {
 
 	userID := ct.CreateTestUser(t, db, true).ID
 
-	cstore := store.New(db, nil)
+	cstore := store.NewObservedForTest(store.New(db, nil))
 
 	authenticator := &auth.OAuthBearerToken{Token: "SOSECRET"}
 	userCred, err := cstore.UserCredentials().Create(ctx, database.UserCredentialScope{
@@ -1278,7 +1278,7 @@ func TestCreateChangesetComments(t *testing.T) {
 
 	ctx := context.Background()
 	db := dbtest.NewDB(t, "")
-	cstore := store.New(db, nil)
+	cstore := store.NewObservedForTest(store.New(db, nil))
 
 	userID := ct.CreateTestUser(t, db, true).ID
 	batchSpec := ct.CreateBatchSpec(t, ctx, cstore, "test-comments", userID)
@@ -1379,7 +1379,7 @@ func TestReenqueueChangesets(t *testing.T) {
 
 	ctx := context.Background()
 	db := dbtest.NewDB(t, "")
-	cstore := store.New(db, nil)
+	cstore := store.NewObservedForTest(store.New(db, nil))
 
 	userID := ct.CreateTestUser(t, db, true).ID
 	batchSpec := ct.CreateBatchSpec(t, ctx, cstore, "test-reenqueue", userID)
@@ -1487,7 +1487,7 @@ func TestMergeChangesets(t *testing.T) {
 
 	ctx := context.Background()
 	db := dbtest.NewDB(t, "")
-	cstore := store.New(db, nil)
+	cstore := store.NewObservedForTest(store.New(db, nil))
 
 	userID := ct.CreateTestUser(t, db, true).ID
 	batchSpec := ct.CreateBatchSpec(t, ctx, cstore, "test-merge", userID)
@@ -1599,7 +1599,7 @@ func TestResolver_CreateBatchSpecExecution(t *testing.T) {
 	ctx := context.Background()
 	db := dbtest.NewDB(t, "")
 	now := time.Now().UTC().Truncate(time.Millisecond)
-	cstore := store.NewWithClock(db, nil, func() time.Time { return now })
+	cstore := store.NewObservedForTest(store.NewWithClock(db, nil, func() time.Time { return now }))
 
 	userID := ct.CreateTestUser(t, db, true).ID
 	userCtx := actor.WithActor(ctx, actor.FromUser(userID))
@@ -1609,7 +1609,7 @@ func TestResolver_CreateBatchSpecExecution(t *testing.T) {
 	if err != nil {
 		t.Fatal(err)
 	}
-	testSpec := `testSpec: yeah`
+	testSpec := ct.TestRawBatchSpecYAML
 	input := map[string]interface{}{
 		"spec": testSpec,
 	}
@@ -1631,16 +1631,27 @@ func TestResolver_CreateBatchSpecExecution(t *testing.T) {
 		Namespace: apitest.UserOrg{
 			ID: string(graphqlbackend.MarshalUserID(userID)),
 		},
-		CreatedAt: graphqlbackend.DateTime{Time: now.Truncate(time.Second)},
+		CreatedAt:    graphqlbackend.DateTime{Time: now.Truncate(time.Second)},
+		PlaceInQueue: 1,
 	}
 	if diff := cmp.Diff(want, response.CreateBatchSpecExecution); diff != "" {
 		t.Fatalf("invalid execution returned, diff=%s", diff)
 	}
+
+	t.Run("invalid spec", func(t *testing.T) {
+		input := map[string]interface{}{
+			"spec": `testSpec: yeah`,
+		}
+		errs := apitest.Exec(userCtx, t, s, input, &response, mutationCreateBatchSpecExecution)
+		if len(errs) == 0 {
+			t.Fatal("expected error for invalid spec but got none")
+		}
+	})
 }
 
 const mutationCreateBatchSpecExecution = `
-mutation($spec: String!) {
-    createBatchSpecExecution(spec: $spec) {
+mutation($spec: String!, $namespace: ID) {
+    createBatchSpecExecution(spec: $spec, namespace: $namespace) {
 		id
 		inputSpec
 		state
@@ -1663,7 +1674,7 @@ func TestCloseChangesets(t *testing.T) {
 
 	ctx := context.Background()
 	db := dbtest.NewDB(t, "")
-	cstore := store.New(db, nil)
+	cstore := store.NewObservedForTest(store.New(db, nil))
 
 	userID := ct.CreateTestUser(t, db, true).ID
 	batchSpec := ct.CreateBatchSpec(t, ctx, cstore, "test-close", userID)
@@ -1774,7 +1785,7 @@ func TestPublishChangesets(t *testing.T) {
 
 	ctx := context.Background()
 	db := dbtest.NewDB(t, "")
-	cstore := store.New(db, nil)
+	cstore := store.NewObservedForTest(store.New(db, nil))
 
 	userID := ct.CreateTestUser(t, db, true).ID
 	batchSpec := ct.CreateBatchSpec(t, ctx, cstore, "test-close", userID)
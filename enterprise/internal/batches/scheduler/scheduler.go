@@ -17,12 +17,12 @@ import (
 type Scheduler struct {
 	ctx   context.Context
 	done  chan struct{}
-	store *store.Store
+	store *store.ObservedStore
 }
 
 var _ goroutine.BackgroundRoutine = &Scheduler{}
 
-func NewScheduler(ctx context.Context, bstore *store.Store) *Scheduler {
+func NewScheduler(ctx context.Context, bstore *store.ObservedStore) *Scheduler {
 	return &Scheduler{
 		ctx:   ctx,
 		done:  make(chan struct{}),
@@ -0,0 +1,213 @@
+package service
+
+import (
+	"context"
+	"io"
+	"os"
+	"path"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/neelance/parallel"
+	"github.com/sourcegraph/go-diff/diff"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/codeowners"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// BatchSpecEstimate estimates the impact of publishing the changesets of a
+// batch spec.
+type BatchSpecEstimate struct {
+	// Repositories is the number of repositories which are changed.
+	Repositories int
+	// Files is the number of files which are changed, across all changesets.
+	Files int
+	// Owners are the owners of the changed files, according to the
+	// CODEOWNERS files of the repositories, sorted by name.
+	Owners []string
+	// CIPipelines is the number of CI pipelines configured in the changed
+	// repositories, each of which is likely to run once per changeset.
+	CIPipelines int
+}
+
+// ciConfigPaths are the paths of the configuration files of common CI
+// services, each of which defines one pipeline.
+var ciConfigPaths = []string{
+	".gitlab-ci.yml",
+	".travis.yml",
+	".circleci/config.yml",
+	".buildkite/pipeline.yml",
+	"azure-pipelines.yml",
+	"bitbucket-pipelines.yml",
+	"Jenkinsfile",
+}
+
+// githubWorkflowsDir is the directory of GitHub Actions workflows, each of
+// which defines one pipeline.
+const githubWorkflowsDir = ".github/workflows"
+
+// maxCodeownersBytes is the maximum size of CODEOWNERS files supported by
+// GitHub.
+const maxCodeownersBytes = 3 * 1024 * 1024
+
+// EstimateBatchSpec estimates the impact of publishing the changesets of the
+// given batch spec, based on their diffs and the contents of the repositories
+// at the base revisions of the changesets. Changesets in repositories the
+// current user can't access and changesets which import existing changesets
+// are left out.
+func (s *Service) EstimateBatchSpec(ctx context.Context, batchSpecID int64) (_ *BatchSpecEstimate, err error) {
+	tr, ctx := trace.New(ctx, "Service.EstimateBatchSpec", "")
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	specs, _, err := s.store.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{BatchSpecID: batchSpecID})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: database.Repos.GetReposSetByIDs uses the authzFilter under the hood and
+	// filters out repositories that the user doesn't have access to.
+	reposByID, err := s.store.Repos().GetReposSetByIDs(ctx, specs.RepoIDs()...)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		mu     sync.Mutex
+		est    = &BatchSpecEstimate{}
+		repos  = map[api.RepoID]struct{}{}
+		owners = map[string]struct{}{}
+		run    = parallel.NewRun(8)
+	)
+	for _, spec := range specs {
+		repo, ok := reposByID[spec.RepoID]
+		if !ok || !spec.Spec.IsBranch() {
+			continue
+		}
+
+		run.Acquire()
+		go func(spec *btypes.ChangesetSpec, repo api.RepoName) {
+			defer run.Release()
+
+			files, err := changedFiles(spec)
+			if err != nil {
+				run.Error(errors.Wrapf(err, "parsing diff of changeset spec %s", spec.RandID))
+				return
+			}
+
+			commit := api.CommitID(spec.Spec.BaseRev)
+			rules, err := readCodeowners(ctx, repo, commit)
+			if err != nil {
+				run.Error(errors.Wrapf(err, "reading CODEOWNERS of %s", repo))
+				return
+			}
+			pipelines, err := countCIPipelines(ctx, repo, commit)
+			if err != nil {
+				run.Error(errors.Wrapf(err, "counting CI pipelines of %s", repo))
+				return
+			}
+
+			mu.Lock()
+			defer mu.Unlock()
+			repos[spec.RepoID] = struct{}{}
+			est.Files += len(files)
+			est.CIPipelines += pipelines
+			for _, f := range files {
+				for _, o := range rules.Match(f) {
+					owners[o] = struct{}{}
+				}
+			}
+		}(spec, repo.Name)
+	}
+	if err := run.Wait(); err != nil {
+		return nil, err
+	}
+
+	est.Repositories = len(repos)
+	est.Owners = make([]string, 0, len(owners))
+	for o := range owners {
+		est.Owners = append(est.Owners, o)
+	}
+	sort.Strings(est.Owners)
+
+	return est, nil
+}
+
+// changedFiles returns the paths of the files changed by the diff of the
+// changeset spec. Renamed files are counted once, by their new path.
+func changedFiles(spec *btypes.ChangesetSpec) ([]string, error) {
+	d, err := spec.Spec.Diff()
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	r := diff.NewMultiFileDiffReader(strings.NewReader(d))
+	for {
+		fd, err := r.ReadFile()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		name := fd.NewName
+		if name == "/dev/null" {
+			name = fd.OrigName
+		}
+		files = append(files, strings.TrimPrefix(strings.TrimPrefix(name, "a/"), "b/"))
+	}
+	return files, nil
+}
+
+// readCodeowners returns the rules of the first CODEOWNERS file found in the
+// repository at commit. Repositories without a CODEOWNERS file have no rules.
+func readCodeowners(ctx context.Context, repo api.RepoName, commit api.CommitID) (*codeowners.Ruleset, error) {
+	for _, p := range codeowners.Paths {
+		data, err := git.ReadFile(ctx, repo, commit, p, maxCodeownersBytes)
+		if os.IsNotExist(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		return codeowners.Parse(data)
+	}
+	return codeowners.Parse(nil)
+}
+
+// countCIPipelines returns the number of CI pipelines configured in the
+// repository at commit.
+func countCIPipelines(ctx context.Context, repo api.RepoName, commit api.CommitID) (int, error) {
+	count := 0
+	for _, p := range ciConfigPaths {
+		if _, err := git.Stat(ctx, repo, commit, p); err != nil {
+			if os.IsNotExist(err) {
+				continue
+			}
+			return 0, err
+		}
+		count++
+	}
+
+	workflows, err := git.ReadDir(ctx, repo, commit, githubWorkflowsDir, false)
+	if err != nil && !os.IsNotExist(err) {
+		return 0, err
+	}
+	for _, fi := range workflows {
+		if ext := path.Ext(fi.Name()); !fi.IsDir() && (ext == ".yml" || ext == ".yaml") {
+			count++
+		}
+	}
+
+	return count, nil
+}
@@ -0,0 +1,98 @@
+package service
+
+import (
+	"context"
+	"io/fs"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/util"
+)
+
+const estimateTestDiff = `diff --git a/README.md b/README.md
+index 671e50a..851b23a 100644
+--- a/README.md
++++ b/README.md
+@@ -1 +1 @@
+-# README
++# Readme
+diff --git a/docs/old.md b/docs/old.md
+deleted file mode 100644
+index 671e50a..0000000
+--- a/docs/old.md
++++ /dev/null
+@@ -1 +0,0 @@
+-# Old
+diff --git a/cmd/main.go b/cmd/main.go
+new file mode 100644
+index 0000000..851b23a
+--- /dev/null
++++ b/cmd/main.go
+@@ -0,0 +1 @@
++package main
+`
+
+func TestChangedFiles(t *testing.T) {
+	spec := &btypes.ChangesetSpec{Spec: &btypes.ChangesetSpecDescription{
+		Commits: []btypes.GitCommitDescription{{Diff: estimateTestDiff}},
+	}}
+
+	files, err := changedFiles(spec)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"README.md", "docs/old.md", "cmd/main.go"}, files); diff != "" {
+		t.Fatalf("unexpected files (-want +got):\n%s", diff)
+	}
+}
+
+func TestReadCodeowners(t *testing.T) {
+	git.Mocks.ReadFile = func(commit api.CommitID, name string) ([]byte, error) {
+		if name == ".github/CODEOWNERS" {
+			return []byte("docs/ @org/docs\n"), nil
+		}
+		return nil, &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	defer git.ResetMocks()
+
+	rules, err := readCodeowners(context.Background(), "github.com/foo/bar", "deadbeef")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"@org/docs"}, rules.Match("docs/old.md")); diff != "" {
+		t.Fatalf("unexpected owners (-want +got):\n%s", diff)
+	}
+}
+
+func TestCountCIPipelines(t *testing.T) {
+	git.Mocks.Stat = func(commit api.CommitID, name string) (fs.FileInfo, error) {
+		if name == ".gitlab-ci.yml" {
+			return &util.FileInfo{Name_: name}, nil
+		}
+		return nil, &os.PathError{Op: "lstat", Path: name, Err: os.ErrNotExist}
+	}
+	git.Mocks.ReadDir = func(commit api.CommitID, name string, recurse bool) ([]fs.FileInfo, error) {
+		if name != githubWorkflowsDir {
+			t.Fatalf("unexpected directory %q", name)
+		}
+		return []fs.FileInfo{
+			&util.FileInfo{Name_: ".github/workflows/build.yml"},
+			&util.FileInfo{Name_: ".github/workflows/lint.yaml"},
+			&util.FileInfo{Name_: ".github/workflows/README.md"},
+		}, nil
+	}
+	defer git.ResetMocks()
+
+	count, err := countCIPipelines(context.Background(), "github.com/foo/bar", "deadbeef")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 3 {
+		t.Fatalf("got %d pipelines, want 3", count)
+	}
+}
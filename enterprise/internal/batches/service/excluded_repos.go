@@ -0,0 +1,139 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gobwas/glob"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// RepoExcluder matches repository names against the
+// batchChanges.excludedRepositories site configuration.
+type RepoExcluder struct {
+	rules []excludedRepoRule
+}
+
+type excludedRepoRule struct {
+	*schema.BatchChangesExcludedRepository
+
+	glob  glob.Glob
+	regex *regexp.Regexp
+}
+
+// NewRepoExcluder compiles the given excluded repository rules.
+func NewRepoExcluder(rules []*schema.BatchChangesExcludedRepository) (*RepoExcluder, error) {
+	e := &RepoExcluder{}
+	for _, r := range rules {
+		rule := excludedRepoRule{BatchChangesExcludedRepository: r}
+
+		if r.Glob != "" {
+			g, err := glob.Compile(r.Glob, '/')
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid glob %q in batchChanges.excludedRepositories", r.Glob)
+			}
+			rule.glob = g
+		}
+
+		if r.Regex != "" {
+			re, err := regexp.Compile(r.Regex)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid regex %q in batchChanges.excludedRepositories", r.Regex)
+			}
+			rule.regex = re
+		}
+
+		e.rules = append(e.rules, rule)
+	}
+	return e, nil
+}
+
+// Excluded returns the first rule that excludes the repository with the given
+// name, or nil if batch changes may publish changesets to it.
+func (e *RepoExcluder) Excluded(name api.RepoName) *schema.BatchChangesExcludedRepository {
+	for _, r := range e.rules {
+		if r.Name != "" && strings.EqualFold(r.Name, string(name)) {
+			return r.BatchChangesExcludedRepository
+		}
+		if r.glob != nil && r.glob.Match(string(name)) {
+			return r.BatchChangesExcludedRepository
+		}
+		if r.regex != nil && r.regex.MatchString(string(name)) {
+			return r.BatchChangesExcludedRepository
+		}
+	}
+	return nil
+}
+
+// repoExcluderFromConf returns a RepoExcluder for the current site
+// configuration.
+func repoExcluderFromConf() (*RepoExcluder, error) {
+	return NewRepoExcluder(conf.Get().BatchChangesExcludedRepositories)
+}
+
+// RepoExcludedError is returned by CreateBatchSpec and ApplyBatchChange when a
+// batch spec would publish a changeset to a repository that is excluded by
+// the site configuration.
+type RepoExcludedError struct {
+	RepoName api.RepoName
+	Reason   string
+}
+
+func (e *RepoExcludedError) Error() string {
+	msg := fmt.Sprintf("batch changes may not publish changesets to repository %q, which is excluded by the site configuration", e.RepoName)
+	if e.Reason != "" {
+		msg += ": " + e.Reason
+	}
+	return msg
+}
+
+// checkExcludedRepos returns a RepoExcludedError if one of the given changeset
+// specs would publish a changeset to a repository excluded by the site
+// configuration. Changeset specs that import existing changesets are allowed,
+// since tracking a changeset doesn't publish anything.
+func (s *Service) checkExcludedRepos(ctx context.Context, specs btypes.ChangesetSpecs) error {
+	excluder, err := repoExcluderFromConf()
+	if err != nil {
+		return err
+	}
+	if len(excluder.rules) == 0 {
+		return nil
+	}
+
+	var repoIDs []api.RepoID
+	for _, spec := range specs {
+		if spec.Spec != nil && spec.Spec.IsImportingExisting() {
+			continue
+		}
+		repoIDs = append(repoIDs, spec.RepoID)
+	}
+	if len(repoIDs) == 0 {
+		return nil
+	}
+
+	// The exclusions apply regardless of the repositories the user can read,
+	// so we look up the repositories as the internal actor.
+	reposByID, err := s.store.Repos().GetReposSetByIDs(actor.WithInternalActor(ctx), repoIDs...)
+	if err != nil {
+		return err
+	}
+
+	for _, id := range repoIDs {
+		repo, ok := reposByID[id]
+		if !ok {
+			continue
+		}
+		if rule := excluder.Excluded(repo.Name); rule != nil {
+			return &RepoExcludedError{RepoName: repo.Name, Reason: rule.Reason}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package service
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestRepoExcluder(t *testing.T) {
+	excluder, err := NewRepoExcluder([]*schema.BatchChangesExcludedRepository{
+		{Name: "github.com/sourcegraph/Frozen", Reason: "name"},
+		{Glob: "github.com/compliance/*", Reason: "glob"},
+		{Regex: `^gitlab\.com/.*-audit$`, Reason: "regex"},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, want := range map[api.RepoName]string{
+		"github.com/sourcegraph/frozen":       "name",
+		"github.com/sourcegraph/frozen2":      "",
+		"github.com/compliance/payments":      "glob",
+		"github.com/compliance/payments/fork": "",
+		"gitlab.com/finance/ledger-audit":     "regex",
+		"gitlab.com/finance/ledger":           "",
+	} {
+		var have string
+		if rule := excluder.Excluded(name); rule != nil {
+			have = rule.Reason
+		}
+		if have != want {
+			t.Errorf("wrong excluding rule for %q. want=%q, have=%q", name, want, have)
+		}
+	}
+
+	for _, rule := range []*schema.BatchChangesExcludedRepository{
+		{Glob: "github.com/[a"},
+		{Regex: "("},
+	} {
+		if _, err := NewRepoExcluder([]*schema.BatchChangesExcludedRepository{rule}); err == nil {
+			t.Errorf("expected error for invalid rule %+v", rule)
+		}
+	}
+}
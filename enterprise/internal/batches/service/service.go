@@ -24,20 +24,20 @@ import (
 )
 
 // New returns a Service.
-func New(store *store.Store) *Service {
+func New(store *store.ObservedStore) *Service {
 	return NewWithClock(store, store.Clock())
 }
 
 // NewWithClock returns a Service the given clock used
 // to generate timestamps.
-func NewWithClock(store *store.Store, clock func() time.Time) *Service {
+func NewWithClock(store *store.ObservedStore, clock func() time.Time) *Service {
 	svc := &Service{store: store, sourcer: sources.NewSourcer(httpcli.NewExternalHTTPClientFactory()), clock: clock}
 
 	return svc
 }
 
 type Service struct {
-	store *store.Store
+	store *store.ObservedStore
 
 	sourcer sources.Sourcer
 
@@ -46,7 +46,7 @@ type Service struct {
 
 // WithStore returns a copy of the Service with its store attribute set to the
 // given Store.
-func (s *Service) WithStore(store *store.Store) *Service {
+func (s *Service) WithStore(store *store.ObservedStore) *Service {
 	return &Service{store: store, sourcer: s.sourcer, clock: s.clock}
 }
 
@@ -116,6 +116,12 @@ func (s *Service) CreateBatchSpec(ctx context.Context, opts CreateBatchSpecOpts)
 		}
 	}
 
+	// Site admins can exclude repositories from batch changes, independent of
+	// the repositories users have access to.
+	if err := s.checkExcludedRepos(ctx, cs); err != nil {
+		return nil, err
+	}
+
 	tx, err := s.store.Transact(ctx)
 	if err != nil {
 		return nil, err
@@ -137,6 +143,50 @@ func (s *Service) CreateBatchSpec(ctx context.Context, opts CreateBatchSpecOpts)
 	return spec, nil
 }
 
+type CreateBatchSpecExecutionOpts struct {
+	Spec string `json:"spec"`
+
+	NamespaceUserID int32 `json:"namespace_user_id"`
+	NamespaceOrgID  int32 `json:"namespace_org_id"`
+}
+
+// CreateBatchSpecExecution validates the given raw batch spec and creates a
+// BatchSpecExecution, which is then picked up by an executor. If no namespace
+// is given, the execution runs in the namespace of the current user.
+func (s *Service) CreateBatchSpecExecution(ctx context.Context, opts CreateBatchSpecExecutionOpts) (exec *btypes.BatchSpecExecution, err error) {
+	actor := actor.FromContext(ctx)
+	tr, ctx := trace.New(ctx, "Service.CreateBatchSpecExecution", fmt.Sprintf("Actor %s", actor))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// Validate the spec before queueing it, so that the user doesn't have to
+	// wait for an executor to tell them that the input is invalid.
+	if _, err := btypes.NewBatchSpecFromRaw(opts.Spec); err != nil {
+		return nil, err
+	}
+
+	if opts.NamespaceUserID == 0 && opts.NamespaceOrgID == 0 {
+		opts.NamespaceUserID = actor.UID
+	}
+
+	// Check whether the current user has access to either one of the namespaces.
+	err = checkNamespaceAccess(ctx, s.store.DB(), opts.NamespaceUserID, opts.NamespaceOrgID)
+	if err != nil {
+		return nil, err
+	}
+
+	exec = &btypes.BatchSpecExecution{
+		BatchSpec:       opts.Spec,
+		UserID:          actor.UID,
+		NamespaceUserID: opts.NamespaceUserID,
+		NamespaceOrgID:  opts.NamespaceOrgID,
+	}
+
+	return exec, s.store.CreateBatchSpecExecution(ctx, exec)
+}
+
 // CreateChangesetSpec validates the given raw spec input and creates the ChangesetSpec.
 func (s *Service) CreateChangesetSpec(ctx context.Context, rawSpec string, userID int32) (spec *btypes.ChangesetSpec, err error) {
 	tr, ctx := trace.New(ctx, "Service.CreateChangesetSpec", fmt.Sprintf("User %d", userID))
@@ -183,7 +233,7 @@ func (e *changesetSpecNotFoundErr) NotFound() bool { return true }
 // GetBatchChangeMatchingBatchSpec returns the batch change that the BatchSpec
 // applies to, if that BatchChange already exists.
 // If it doesn't exist yet, both return values are nil.
-// It accepts a *store.Store so that it can be used inside a transaction.
+// It accepts a *store.ObservedStore so that it can be used inside a transaction.
 func (s *Service) GetBatchChangeMatchingBatchSpec(ctx context.Context, spec *btypes.BatchSpec) (*btypes.BatchChange, error) {
 	opts := store.GetBatchChangeOpts{
 		Name:            spec.Spec.Name,
@@ -203,7 +253,7 @@ func (s *Service) GetBatchChangeMatchingBatchSpec(ctx context.Context, spec *bty
 
 // GetNewestBatchSpec returns the newest batch spec that matches the given
 // spec's namespace and name and is owned by the given user, or nil if none is found.
-func (s *Service) GetNewestBatchSpec(ctx context.Context, tx *store.Store, spec *btypes.BatchSpec, userID int32) (*btypes.BatchSpec, error) {
+func (s *Service) GetNewestBatchSpec(ctx context.Context, tx *store.ObservedStore, spec *btypes.BatchSpec, userID int32) (*btypes.BatchSpec, error) {
 	opts := store.GetNewestBatchSpecOpts{
 		UserID:          userID,
 		NamespaceUserID: spec.NamespaceUserID,
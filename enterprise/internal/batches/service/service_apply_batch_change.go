@@ -84,6 +84,16 @@ func (s *Service) ApplyBatchChange(ctx context.Context, opts ApplyBatchChangeOpt
 		return nil, ErrApplyClosedBatchChange
 	}
 
+	// The site configuration might have excluded repositories of the batch
+	// spec since it was created.
+	specs, _, err := s.store.ListChangesetSpecs(ctx, store.ListChangesetSpecsOpts{BatchSpecID: batchSpec.ID})
+	if err != nil {
+		return nil, err
+	}
+	if err := s.checkExcludedRepos(ctx, specs); err != nil {
+		return nil, err
+	}
+
 	if previousSpecID == batchSpec.ID {
 		return batchChange, nil
 	}
@@ -158,6 +168,12 @@ func (s *Service) ApplyBatchChange(ctx context.Context, opts ApplyBatchChangeOpt
 			changeset.UiPublicationState = state
 		}
 
+		// The changesets created by the batch change aggregate their checks
+		// according to the rules of its spec.
+		if changeset.OwnedByBatchChangeID == batchChange.ID {
+			changeset.CheckRules = batchSpec.Spec.Checks
+		}
+
 		if err := tx.UpsertChangeset(ctx, changeset); err != nil {
 			return nil, err
 		}
@@ -37,7 +37,7 @@ func TestServiceApplyBatchChange(t *testing.T) {
 
 	now := timeutil.Now()
 	clock := func() time.Time { return now }
-	store := store.NewWithClock(db, nil, clock)
+	store := store.NewObservedForTest(store.NewWithClock(db, nil, clock))
 	svc := New(store)
 
 	t.Run("BatchSpec without changesetSpecs", func(t *testing.T) {
@@ -63,6 +63,7 @@ func TestServiceApplyBatchChange(t *testing.T) {
 				LastAppliedAt:    now,
 				NamespaceUserID:  batchSpec.NamespaceUserID,
 				BatchSpecID:      batchSpec.ID,
+				Version:          1,
 
 				// Ignore these fields
 				ID:        batchChange.ID,
@@ -0,0 +1,192 @@
+package service
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+)
+
+// ErrBatchChangeTemplateNameTaken is returned by CreateBatchChangeTemplate and
+// UpdateBatchChangeTemplate if the namespace already has a template with the
+// given name.
+var ErrBatchChangeTemplateNameTaken = errors.New("a batch change template with the given name already exists in the namespace")
+
+type CreateBatchChangeTemplateOpts struct {
+	Name        string
+	Description string
+	Spec        string
+	Parameters  []btypes.BatchChangeTemplateParameter
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+}
+
+// CreateBatchChangeTemplate creates a BatchChangeTemplate in the given
+// namespace after validating it.
+func (s *Service) CreateBatchChangeTemplate(ctx context.Context, opts CreateBatchChangeTemplateOpts) (template *btypes.BatchChangeTemplate, err error) {
+	actor := actor.FromContext(ctx)
+	tr, ctx := trace.New(ctx, "Service.CreateBatchChangeTemplate", fmt.Sprintf("Actor %s", actor))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Only users with access to the namespace can add templates to it.
+	if err := checkNamespaceAccess(ctx, s.store.DB(), opts.NamespaceUserID, opts.NamespaceOrgID); err != nil {
+		return nil, err
+	}
+
+	template = &btypes.BatchChangeTemplate{
+		Name:            opts.Name,
+		Description:     opts.Description,
+		Spec:            opts.Spec,
+		Parameters:      opts.Parameters,
+		NamespaceUserID: opts.NamespaceUserID,
+		NamespaceOrgID:  opts.NamespaceOrgID,
+		CreatorID:       actor.UID,
+	}
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := checkBatchChangeTemplateNameAvailable(ctx, tx, template); err != nil {
+		return nil, err
+	}
+
+	return template, tx.CreateBatchChangeTemplate(ctx, template)
+}
+
+type UpdateBatchChangeTemplateOpts struct {
+	ID          int64
+	Name        string
+	Description string
+	Spec        string
+	Parameters  []btypes.BatchChangeTemplateParameter
+}
+
+// UpdateBatchChangeTemplate replaces the name, description, spec and
+// parameters of the BatchChangeTemplate with the given ID.
+func (s *Service) UpdateBatchChangeTemplate(ctx context.Context, opts UpdateBatchChangeTemplateOpts) (template *btypes.BatchChangeTemplate, err error) {
+	tr, ctx := trace.New(ctx, "Service.UpdateBatchChangeTemplate", fmt.Sprintf("BatchChangeTemplate: %d", opts.ID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	tx, err := s.store.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	template, err = tx.GetBatchChangeTemplate(ctx, store.GetBatchChangeTemplateOpts{ID: opts.ID})
+	if err != nil {
+		return nil, err
+	}
+
+	// 🚨 SECURITY: Only users with access to the namespace can modify its templates.
+	if err := checkNamespaceAccess(ctx, s.store.DB(), template.NamespaceUserID, template.NamespaceOrgID); err != nil {
+		return nil, err
+	}
+
+	renamed := template.Name != opts.Name
+	template.Name = opts.Name
+	template.Description = opts.Description
+	template.Spec = opts.Spec
+	template.Parameters = opts.Parameters
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+
+	if renamed {
+		if err := checkBatchChangeTemplateNameAvailable(ctx, tx, template); err != nil {
+			return nil, err
+		}
+	}
+
+	return template, tx.UpdateBatchChangeTemplate(ctx, template)
+}
+
+// DeleteBatchChangeTemplate deletes the BatchChangeTemplate with the given ID.
+func (s *Service) DeleteBatchChangeTemplate(ctx context.Context, id int64) (err error) {
+	tr, ctx := trace.New(ctx, "Service.DeleteBatchChangeTemplate", fmt.Sprintf("BatchChangeTemplate: %d", id))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	template, err := s.store.GetBatchChangeTemplate(ctx, store.GetBatchChangeTemplateOpts{ID: id})
+	if err != nil {
+		return err
+	}
+
+	// 🚨 SECURITY: Only users with access to the namespace can delete its templates.
+	if err := checkNamespaceAccess(ctx, s.store.DB(), template.NamespaceUserID, template.NamespaceOrgID); err != nil {
+		return err
+	}
+
+	return s.store.DeleteBatchChangeTemplate(ctx, id)
+}
+
+type InstantiateBatchChangeTemplateOpts struct {
+	TemplateID int64
+	Values     map[string]string
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+}
+
+// InstantiateBatchChangeTemplate renders the BatchChangeTemplate with the
+// given values and creates a BatchSpec from the result in the given
+// namespace. Templates can be instantiated by any user.
+func (s *Service) InstantiateBatchChangeTemplate(ctx context.Context, opts InstantiateBatchChangeTemplateOpts) (spec *btypes.BatchSpec, err error) {
+	tr, ctx := trace.New(ctx, "Service.InstantiateBatchChangeTemplate", fmt.Sprintf("BatchChangeTemplate: %d", opts.TemplateID))
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	template, err := s.store.GetBatchChangeTemplate(ctx, store.GetBatchChangeTemplateOpts{ID: opts.TemplateID})
+	if err != nil {
+		return nil, err
+	}
+
+	rawSpec, err := template.Render(opts.Values)
+	if err != nil {
+		return nil, err
+	}
+
+	// CreateBatchSpec checks that the user has access to the target namespace.
+	return s.CreateBatchSpec(ctx, CreateBatchSpecOpts{
+		RawSpec:         rawSpec,
+		NamespaceUserID: opts.NamespaceUserID,
+		NamespaceOrgID:  opts.NamespaceOrgID,
+	})
+}
+
+func checkBatchChangeTemplateNameAvailable(ctx context.Context, tx *store.ObservedStore, template *btypes.BatchChangeTemplate) error {
+	_, err := tx.GetBatchChangeTemplate(ctx, store.GetBatchChangeTemplateOpts{
+		NamespaceUserID: template.NamespaceUserID,
+		NamespaceOrgID:  template.NamespaceOrgID,
+		Name:            template.Name,
+	})
+	if err == nil {
+		return ErrBatchChangeTemplateNameTaken
+	}
+	if err != store.ErrNoResults {
+		return err
+	}
+	return nil
+}
@@ -0,0 +1,149 @@
+package service
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store"
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+)
+
+const testBatchChangeTemplateSpec = `name: bump-${{ parameters.image }}
+on:
+  - repositoriesMatchingQuery: file:Dockerfile FROM ${{ parameters.image }}
+steps:
+  - run: echo ${{ parameters.tag }}
+    container: alpine:3
+`
+
+func TestServiceBatchChangeTemplates(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	db := dbtest.NewDB(t, "")
+
+	admin := ct.CreateTestUser(t, db, true)
+	adminCtx := actor.WithActor(context.Background(), actor.FromUser(admin.ID))
+
+	user := ct.CreateTestUser(t, db, false)
+	userCtx := actor.WithActor(context.Background(), actor.FromUser(user.ID))
+
+	svc := New(store.NewObservedForTest(store.New(db, nil)))
+
+	latest := "latest"
+	opts := CreateBatchChangeTemplateOpts{
+		Name:        "bump-base-image",
+		Description: "Bumps the base image",
+		Spec:        testBatchChangeTemplateSpec,
+		Parameters: []btypes.BatchChangeTemplateParameter{
+			{Name: "image"},
+			{Name: "tag", Default: &latest},
+		},
+		NamespaceUserID: admin.ID,
+	}
+
+	t.Run("CreateBatchChangeTemplate", func(t *testing.T) {
+		t.Run("namespace user is not admin and not creator", func(t *testing.T) {
+			_, err := svc.CreateBatchChangeTemplate(userCtx, opts)
+			if !errcode.IsUnauthorized(err) {
+				t.Fatalf("expected unauthorized error but got %s", err)
+			}
+		})
+
+		t.Run("invalid template", func(t *testing.T) {
+			invalid := opts
+			invalid.Parameters = nil
+			if _, err := svc.CreateBatchChangeTemplate(adminCtx, invalid); err == nil {
+				t.Fatal("expected error but got none")
+			}
+		})
+	})
+
+	template, err := svc.CreateBatchChangeTemplate(adminCtx, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := template.CreatorID, admin.ID; have != want {
+		t.Fatalf("have creator %d, want %d", have, want)
+	}
+
+	t.Run("name taken", func(t *testing.T) {
+		if _, err := svc.CreateBatchChangeTemplate(adminCtx, opts); err != ErrBatchChangeTemplateNameTaken {
+			t.Fatalf("have err %v, want %v", err, ErrBatchChangeTemplateNameTaken)
+		}
+	})
+
+	t.Run("UpdateBatchChangeTemplate", func(t *testing.T) {
+		update := UpdateBatchChangeTemplateOpts{
+			ID:          template.ID,
+			Name:        template.Name,
+			Description: "updated",
+			Spec:        template.Spec,
+			Parameters:  template.Parameters,
+		}
+
+		if _, err := svc.UpdateBatchChangeTemplate(userCtx, update); !errcode.IsUnauthorized(err) {
+			t.Fatalf("expected unauthorized error but got %s", err)
+		}
+
+		updated, err := svc.UpdateBatchChangeTemplate(adminCtx, update)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated.Description != "updated" {
+			t.Fatalf("have description %q, want %q", updated.Description, "updated")
+		}
+	})
+
+	t.Run("InstantiateBatchChangeTemplate", func(t *testing.T) {
+		instantiate := InstantiateBatchChangeTemplateOpts{
+			TemplateID:      template.ID,
+			Values:          map[string]string{"image": "alpine"},
+			NamespaceUserID: user.ID,
+		}
+
+		t.Run("success", func(t *testing.T) {
+			spec, err := svc.InstantiateBatchChangeTemplate(userCtx, instantiate)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have, want := spec.Spec.Name, "bump-alpine"; have != want {
+				t.Fatalf("have name %q, want %q", have, want)
+			}
+			if have, want := spec.NamespaceUserID, user.ID; have != want {
+				t.Fatalf("have namespace %d, want %d", have, want)
+			}
+		})
+
+		t.Run("missing required parameter", func(t *testing.T) {
+			missing := instantiate
+			missing.Values = nil
+			if _, err := svc.InstantiateBatchChangeTemplate(userCtx, missing); err == nil {
+				t.Fatal("expected error but got none")
+			}
+		})
+
+		t.Run("namespace user is not admin and not creator", func(t *testing.T) {
+			other := instantiate
+			other.NamespaceUserID = admin.ID
+			if _, err := svc.InstantiateBatchChangeTemplate(userCtx, other); !errcode.IsUnauthorized(err) {
+				t.Fatalf("expected unauthorized error but got %s", err)
+			}
+		})
+	})
+
+	t.Run("DeleteBatchChangeTemplate", func(t *testing.T) {
+		if err := svc.DeleteBatchChangeTemplate(userCtx, template.ID); !errcode.IsUnauthorized(err) {
+			t.Fatalf("expected unauthorized error but got %s", err)
+		}
+
+		if err := svc.DeleteBatchChangeTemplate(adminCtx, template.ID); err != nil {
+			t.Fatal(err)
+		}
+	})
+}
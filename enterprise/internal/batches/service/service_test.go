@@ -18,6 +18,7 @@ import (
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
@@ -26,6 +27,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 func TestServicePermissionLevels(t *testing.T) {
@@ -36,7 +38,7 @@ func TestServicePermissionLevels(t *testing.T) {
 	ctx := backend.WithAuthzBypass(context.Background())
 	db := dbtest.NewDB(t, "")
 
-	s := store.New(db, nil)
+	s := store.NewObservedForTest(store.New(db, nil))
 	svc := New(s)
 
 	admin := ct.CreateTestUser(t, db, true)
@@ -45,7 +47,7 @@ func TestServicePermissionLevels(t *testing.T) {
 
 	rs, _ := ct.CreateTestRepos(t, ctx, db, 1)
 
-	createTestData := func(t *testing.T, s *store.Store, svc *Service, author int32) (*btypes.BatchChange, *btypes.Changeset, *btypes.BatchSpec) {
+	createTestData := func(t *testing.T, s *store.ObservedStore, svc *Service, author int32) (*btypes.BatchChange, *btypes.Changeset, *btypes.BatchSpec) {
 		spec := testBatchSpec(author)
 		if err := s.CreateBatchSpec(ctx, spec); err != nil {
 			t.Fatal(err)
@@ -186,7 +188,7 @@ func TestService(t *testing.T) {
 	now := timeutil.Now()
 	clock := func() time.Time { return now }
 
-	s := store.NewWithClock(db, nil, clock)
+	s := store.NewObservedForTest(store.NewWithClock(db, nil, clock))
 	rs, _ := ct.CreateTestRepos(t, ctx, db, 4)
 
 	fakeSource := &sources.FakeChangesetSource{}
@@ -479,6 +481,30 @@ func TestService(t *testing.T) {
 			}
 		})
 
+		t.Run("excluded repository", func(t *testing.T) {
+			conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				BatchChangesExcludedRepositories: []*schema.BatchChangesExcludedRepository{
+					{Name: string(rs[1].Name), Reason: "frozen"},
+				},
+			}})
+			defer conf.Mock(nil)
+
+			opts := CreateBatchSpecOpts{
+				NamespaceUserID:      admin.ID,
+				RawSpec:              ct.TestRawBatchSpec,
+				ChangesetSpecRandIDs: changesetSpecRandIDs,
+			}
+
+			_, err := svc.CreateBatchSpec(adminCtx, opts)
+			var excludedErr *RepoExcludedError
+			if !errors.As(err, &excludedErr) {
+				t.Fatalf("expected excluded repository error but got %s", err)
+			}
+			if have, want := excludedErr.RepoName, rs[1].Name; have != want {
+				t.Fatalf("wrong excluded repository. want=%s, have=%s", want, have)
+			}
+		})
+
 		t.Run("namespace user is not admin and not creator", func(t *testing.T) {
 			opts := CreateBatchSpecOpts{
 				NamespaceUserID: admin.ID,
@@ -550,6 +576,49 @@ func TestService(t *testing.T) {
 		})
 	})
 
+	t.Run("CreateBatchSpecExecution", func(t *testing.T) {
+		t.Run("success", func(t *testing.T) {
+			opts := CreateBatchSpecExecutionOpts{Spec: ct.TestRawBatchSpecYAML}
+
+			exec, err := svc.CreateBatchSpecExecution(adminCtx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if exec.ID == 0 {
+				t.Fatalf("BatchSpecExecution ID is 0")
+			}
+
+			if have, want := exec.UserID, admin.ID; have != want {
+				t.Fatalf("UserID is %d, want %d", have, want)
+			}
+
+			if have, want := exec.NamespaceUserID, admin.ID; have != want {
+				t.Fatalf("NamespaceUserID is %d, want %d", have, want)
+			}
+		})
+
+		t.Run("invalid spec", func(t *testing.T) {
+			opts := CreateBatchSpecExecutionOpts{Spec: `{"name": "no spaces allowed"}`}
+
+			if _, err := svc.CreateBatchSpecExecution(adminCtx, opts); err == nil {
+				t.Fatal("expected error but got none")
+			}
+		})
+
+		t.Run("namespace user is not admin and not creator", func(t *testing.T) {
+			opts := CreateBatchSpecExecutionOpts{
+				NamespaceUserID: admin.ID,
+				Spec:            ct.TestRawBatchSpecYAML,
+			}
+
+			_, err := svc.CreateBatchSpecExecution(userCtx, opts)
+			if !errcode.IsUnauthorized(err) {
+				t.Fatalf("expected unauthorized error but got %s", err)
+			}
+		})
+	})
+
 	t.Run("CreateChangesetSpec", func(t *testing.T) {
 		repo := rs[0]
 		rawSpec := ct.NewRawChangesetSpecGitBranch(graphqlbackend.MarshalRepositoryID(repo.ID), "d34db33f")
@@ -0,0 +1,281 @@
+package sources
+
+import (
+	"context"
+	"net/url"
+	"strconv"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type AzureDevOpsSource struct {
+	client *azuredevops.Client
+	au     auth.Authenticator
+
+	// org is used to validate authenticators, since Azure DevOps has no
+	// organization independent endpoint to do so.
+	org string
+}
+
+// NewAzureDevOpsSource returns a new AzureDevOpsSource from the given external service.
+func NewAzureDevOpsSource(svc *types.ExternalService, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
+	var c schema.AzureDevOpsConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return newAzureDevOpsSource(&c, cf)
+}
+
+func newAzureDevOpsSource(c *schema.AzureDevOpsConnection, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
+	if c.Url == "" {
+		c.Url = "https://dev.azure.com"
+	}
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+	baseURL = extsvc.NormalizeBaseURL(baseURL)
+
+	if cf == nil {
+		cf = httpcli.NewExternalHTTPClientFactory()
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	client := azuredevops.NewClient(baseURL, cli)
+	client.Username = c.Username
+	client.Token = c.Token
+
+	var org string
+	if len(c.Orgs) > 0 {
+		org = c.Orgs[0]
+	} else if len(c.Projects) > 0 {
+		org = strings.SplitN(c.Projects[0], "/", 2)[0]
+	}
+
+	return &AzureDevOpsSource{
+		client: client,
+		au:     &auth.BasicAuth{Username: c.Username, Password: c.Token},
+		org:    org,
+	}, nil
+}
+
+func (s AzureDevOpsSource) GitserverPushConfig(ctx context.Context, store *database.ExternalServiceStore, repo *types.Repo) (*protocol.PushConfig, error) {
+	return gitserverPushConfig(ctx, store, repo, s.au)
+}
+
+func (s AzureDevOpsSource) WithAuthenticator(a auth.Authenticator) (ChangesetSource, error) {
+	// Azure DevOps personal access tokens are passed as the password of basic
+	// auth, the username is ignored.
+	client := *s.client
+	switch av := a.(type) {
+	case *auth.OAuthBearerToken:
+		client.Token = av.Token
+	case *auth.OAuthBearerTokenWithSSH:
+		client.Token = av.Token
+	case *auth.BasicAuth:
+		client.Username, client.Token = av.Username, av.Password
+	case *auth.BasicAuthWithSSH:
+		client.Username, client.Token = av.Username, av.Password
+	default:
+		return nil, newUnsupportedAuthenticatorError("AzureDevOpsSource", a)
+	}
+
+	return &AzureDevOpsSource{
+		client: &client,
+		au:     a,
+		org:    s.org,
+	}, nil
+}
+
+func (s AzureDevOpsSource) ValidateAuthenticator(ctx context.Context) error {
+	_, err := s.client.AuthenticatedUser(ctx, s.org)
+	return err
+}
+
+// CreateChangeset creates the given *Changeset in the code host.
+func (s AzureDevOpsSource) CreateChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	return s.createChangeset(ctx, c, false)
+}
+
+// CreateDraftChangeset creates the given *Changeset in the code host as a
+// draft pull request.
+func (s AzureDevOpsSource) CreateDraftChangeset(ctx context.Context, c *Changeset) (bool, error) {
+	exists, err := s.createChangeset(ctx, c, true)
+	if err != nil {
+		return exists, err
+	}
+
+	pr, ok := c.Changeset.Metadata.(*azuredevops.PullRequest)
+	if !ok {
+		return false, errors.New("Changeset is not an Azure DevOps pull request")
+	}
+
+	// If it already exists, but is not a draft, we need to convert it back.
+	if exists && !pr.IsDraft {
+		isDraft := true
+		if err := s.updatePullRequest(ctx, c, pr, &azuredevops.UpdatePullRequestInput{IsDraft: &isDraft}); err != nil {
+			return exists, err
+		}
+	}
+	return exists, nil
+}
+
+func (s AzureDevOpsSource) createChangeset(ctx context.Context, c *Changeset, draft bool) (bool, error) {
+	var exists bool
+
+	repo := c.Repo.Metadata.(*azuredevops.Repository)
+
+	pr, err := s.client.CreatePullRequest(ctx, repo, &azuredevops.CreatePullRequestInput{
+		SourceRefName: git.EnsureRefPrefix(c.HeadRef),
+		TargetRefName: git.EnsureRefPrefix(c.BaseRef),
+		Title:         c.Title,
+		Description:   c.Body,
+		IsDraft:       draft,
+	})
+	if err != nil {
+		var e azuredevops.ErrPullRequestAlreadyExists
+		if !errors.As(err, &e) {
+			return exists, errors.Wrap(err, "creating Azure DevOps pull request")
+		}
+		pr = e.Existing
+		exists = true
+	}
+
+	pr.Repository = repo
+	if err := c.SetMetadata(pr); err != nil {
+		return false, errors.Wrap(err, "setting changeset metadata")
+	}
+
+	return exists, nil
+}
+
+// UndraftChangeset marks the pull request as published.
+func (s AzureDevOpsSource) UndraftChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*azuredevops.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an Azure DevOps pull request")
+	}
+
+	isDraft := false
+	return s.updatePullRequest(ctx, c, pr, &azuredevops.UpdatePullRequestInput{IsDraft: &isDraft})
+}
+
+// CloseChangeset abandons the pull request on Azure DevOps.
+func (s AzureDevOpsSource) CloseChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*azuredevops.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an Azure DevOps pull request")
+	}
+
+	return s.updatePullRequest(ctx, c, pr, &azuredevops.UpdatePullRequestInput{
+		Status: azuredevops.PullRequestStatusAbandoned,
+	})
+}
+
+// LoadChangeset loads the latest state of the given Changeset from the codehost.
+func (s AzureDevOpsSource) LoadChangeset(ctx context.Context, cs *Changeset) error {
+	repo := cs.Repo.Metadata.(*azuredevops.Repository)
+
+	id, err := strconv.Atoi(cs.ExternalID)
+	if err != nil {
+		return errors.Wrap(err, "parsing changeset external ID")
+	}
+
+	pr, err := s.client.LoadPullRequest(ctx, repo, id)
+	if err != nil {
+		if errors.Is(err, azuredevops.ErrPullRequestNotFound) {
+			return ChangesetNotFoundError{Changeset: cs}
+		}
+		return errors.Wrap(err, "loading Azure DevOps pull request")
+	}
+
+	pr.Repository = repo
+	return cs.SetMetadata(pr)
+}
+
+// ReopenChangeset reactivates an abandoned pull request on Azure DevOps.
+func (s AzureDevOpsSource) ReopenChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*azuredevops.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an Azure DevOps pull request")
+	}
+
+	return s.updatePullRequest(ctx, c, pr, &azuredevops.UpdatePullRequestInput{
+		Status: azuredevops.PullRequestStatusActive,
+	})
+}
+
+// UpdateChangeset updates the pull request on Azure DevOps to reflect the
+// local state of the Changeset.
+func (s AzureDevOpsSource) UpdateChangeset(ctx context.Context, c *Changeset) error {
+	pr, ok := c.Changeset.Metadata.(*azuredevops.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an Azure DevOps pull request")
+	}
+
+	return s.updatePullRequest(ctx, c, pr, &azuredevops.UpdatePullRequestInput{
+		Title:         c.Title,
+		Description:   c.Body,
+		TargetRefName: git.EnsureRefPrefix(c.BaseRef),
+	})
+}
+
+// CreateComment posts a comment on the Changeset.
+func (s AzureDevOpsSource) CreateComment(ctx context.Context, c *Changeset, text string) error {
+	pr, ok := c.Changeset.Metadata.(*azuredevops.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an Azure DevOps pull request")
+	}
+	repo := c.Repo.Metadata.(*azuredevops.Repository)
+
+	return s.client.CreatePullRequestComment(ctx, repo, pr.ID, text)
+}
+
+// MergeChangeset completes the pull request on Azure DevOps, if in a
+// mergeable state. If squash is true, the commits are squashed.
+func (s AzureDevOpsSource) MergeChangeset(ctx context.Context, c *Changeset, squash bool) error {
+	pr, ok := c.Changeset.Metadata.(*azuredevops.PullRequest)
+	if !ok {
+		return errors.New("Changeset is not an Azure DevOps pull request")
+	}
+	repo := c.Repo.Metadata.(*azuredevops.Repository)
+
+	updated, err := s.client.CompletePullRequest(ctx, repo, pr, squash)
+	if err != nil {
+		if errors.Is(err, azuredevops.ErrNotMergeable) {
+			return ChangesetNotMergeableError{ErrorMsg: err.Error()}
+		}
+		return errors.Wrap(err, "completing Azure DevOps pull request")
+	}
+
+	updated.Repository = repo
+	return c.Changeset.SetMetadata(updated)
+}
+
+func (s AzureDevOpsSource) updatePullRequest(ctx context.Context, c *Changeset, pr *azuredevops.PullRequest, in *azuredevops.UpdatePullRequestInput) error {
+	repo := c.Repo.Metadata.(*azuredevops.Repository)
+
+	updated, err := s.client.UpdatePullRequest(ctx, repo, pr.ID, in)
+	if err != nil {
+		return errors.Wrap(err, "updating Azure DevOps pull request")
+	}
+
+	updated.Repository = repo
+	return c.Changeset.SetMetadata(updated)
+}
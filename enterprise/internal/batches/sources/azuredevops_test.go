@@ -0,0 +1,128 @@
+package sources
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func newAzureDevOpsTestSource(t *testing.T, h http.HandlerFunc) *AzureDevOpsSource {
+	t.Helper()
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	src, err := newAzureDevOpsSource(&schema.AzureDevOpsConnection{
+		Url:      srv.URL,
+		Username: "alice",
+		Token:    "secret",
+		Orgs:     []string{"myorg"},
+	}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return src
+}
+
+func newAzureDevOpsTestChangeset() *Changeset {
+	return &Changeset{
+		Title:   "Title",
+		Body:    "Body",
+		HeadRef: "refs/heads/feature",
+		BaseRef: "refs/heads/main",
+		Repo: &types.Repo{Metadata: &azuredevops.Repository{
+			ID:      "repo-id",
+			Project: azuredevops.Project{ID: "project-id"},
+			WebURL:  "https://dev.azure.com/myorg/myproject/_git/myrepo",
+		}},
+		Changeset: &btypes.Changeset{},
+	}
+}
+
+func TestAzureDevOpsSource_CreateChangeset(t *testing.T) {
+	src := newAzureDevOpsTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			w.WriteHeader(http.StatusConflict)
+			return
+		}
+		io.WriteString(w, `{"value": [{"pullRequestId": 7, "status": "active", "sourceRefName": "refs/heads/feature"}]}`)
+	})
+
+	cs := newAzureDevOpsTestChangeset()
+	exists, err := src.CreateChangeset(context.Background(), cs)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !exists {
+		t.Error("want existing pull request to be reported")
+	}
+	if have, want := cs.Changeset.ExternalID, "7"; have != want {
+		t.Errorf("wrong external ID: want %q, have %q", want, have)
+	}
+	if have, want := cs.Changeset.ExternalBranch, "refs/heads/feature"; have != want {
+		t.Errorf("wrong external branch: want %q, have %q", want, have)
+	}
+	if have, err := cs.Changeset.URL(); err != nil {
+		t.Fatal(err)
+	} else if want := "https://dev.azure.com/myorg/myproject/_git/myrepo/pullrequest/7"; have != want {
+		t.Errorf("wrong URL: want %q, have %q", want, have)
+	}
+}
+
+func TestAzureDevOpsSource_LoadChangeset(t *testing.T) {
+	src := newAzureDevOpsTestSource(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+
+	cs := newAzureDevOpsTestChangeset()
+	cs.Changeset.ExternalID = "42"
+	err := src.LoadChangeset(context.Background(), cs)
+	if !errors.HasType(err, ChangesetNotFoundError{}) {
+		t.Fatalf("want ChangesetNotFoundError, have %v", err)
+	}
+}
+
+func TestAzureDevOpsSource_WithAuthenticator(t *testing.T) {
+	src, err := newAzureDevOpsSource(&schema.AzureDevOpsConnection{Username: "alice", Token: "secret"}, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for name, tc := range map[string]struct {
+		au      auth.Authenticator
+		wantErr bool
+	}{
+		"OAuthBearerToken": {au: &auth.OAuthBearerToken{Token: "token"}},
+		"BasicAuth":        {au: &auth.BasicAuth{Username: "bob", Password: "token"}},
+		"OAuthClient":      {au: &auth.OAuthClient{}, wantErr: true},
+	} {
+		t.Run(name, func(t *testing.T) {
+			have, err := src.WithAuthenticator(tc.au)
+			if tc.wantErr {
+				if !errors.HasType(err, UnsupportedAuthenticatorError{}) {
+					t.Fatalf("want UnsupportedAuthenticatorError, have %v", err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if as := have.(*AzureDevOpsSource); as.client.Token != "token" {
+				t.Errorf("client not authenticated with token: %+v", as.client)
+			}
+			if src.client.Token != "secret" {
+				t.Error("original source was modified")
+			}
+		})
+	}
+}
@@ -16,6 +16,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -94,14 +95,16 @@ func SetDerivedState(ctx context.Context, repoStore *database.RepoStore, c *btyp
 
 // computeCheckState computes the overall check state based on the current
 // synced check state and any webhook events that have arrived after the most
-// recent sync.
+// recent sync. Only the checks required by the check rules of the changeset
+// count toward the overall state. GitLab combines the jobs of a pipeline
+// itself, so the rules don't apply to merge requests.
 func computeCheckState(c *btypes.Changeset, events ChangesetEvents) btypes.ChangesetCheckState {
 	switch m := c.Metadata.(type) {
 	case *github.PullRequest:
-		return computeGitHubCheckState(c.UpdatedAt, m, events)
+		return computeGitHubCheckState(c.UpdatedAt, m, events, c.CheckRules)
 
 	case *bitbucketserver.PullRequest:
-		return computeBitbucketBuildStatus(c.UpdatedAt, m, events)
+		return computeBitbucketBuildStatus(c.UpdatedAt, m, events, c.CheckRules)
 
 	case *gitlab.MergeRequest:
 		return computeGitLabCheckState(c.UpdatedAt, m, events)
@@ -146,7 +149,7 @@ func computeReviewState(c *btypes.Changeset, history []changesetStatesAtTime) (b
 	return newestDataPoint.reviewState, nil
 }
 
-func computeBitbucketBuildStatus(lastSynced time.Time, pr *bitbucketserver.PullRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
+func computeBitbucketBuildStatus(lastSynced time.Time, pr *bitbucketserver.PullRequest, events []*btypes.ChangesetEvent, rules btypes.ChangesetCheckRules) btypes.ChangesetCheckState {
 	var latestCommit bitbucketserver.Commit
 	for _, c := range pr.Commits {
 		if latestCommit.CommitterTimestamp <= c.CommitterTimestamp {
@@ -155,10 +158,12 @@ func computeBitbucketBuildStatus(lastSynced time.Time, pr *bitbucketserver.PullR
 	}
 
 	stateMap := make(map[string]btypes.ChangesetCheckState)
+	nameMap := make(map[string]string)
 
 	// States from last sync
 	for _, status := range pr.CommitStatus {
 		stateMap[status.Key()] = parseBitbucketBuildState(status.Status.State)
+		nameMap[status.Key()] = bitbucketBuildName(status)
 	}
 
 	// Add any events we've received since our last sync
@@ -173,17 +178,29 @@ func computeBitbucketBuildStatus(lastSynced time.Time, pr *bitbucketserver.PullR
 				continue
 			}
 			stateMap[m.Key()] = parseBitbucketBuildState(m.Status.State)
+			nameMap[m.Key()] = bitbucketBuildName(m)
 		}
 	}
 
 	states := make([]btypes.ChangesetCheckState, 0, len(stateMap))
-	for _, v := range stateMap {
-		states = append(states, v)
+	for k, v := range stateMap {
+		if rules.Required(nameMap[k]) {
+			states = append(states, v)
+		}
 	}
 
 	return combineCheckStates(states)
 }
 
+// bitbucketBuildName returns the name check rules match against for the build
+// status, falling back to its key if the build has no name.
+func bitbucketBuildName(s *bitbucketserver.CommitStatus) string {
+	if s.Status.Name != "" {
+		return s.Status.Name
+	}
+	return s.Status.Key
+}
+
 func parseBitbucketBuildState(s string) btypes.ChangesetCheckState {
 	switch s {
 	case "FAILED":
@@ -197,7 +214,7 @@ func parseBitbucketBuildState(s string) btypes.ChangesetCheckState {
 	}
 }
 
-func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, events []*btypes.ChangesetEvent) btypes.ChangesetCheckState {
+func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, events []*btypes.ChangesetEvent, rules btypes.ChangesetCheckRules) btypes.ChangesetCheckState {
 	// We should only consider the latest commit. This could be from a sync or a webhook that
 	// has occurred later
 	var latestCommitTime time.Time
@@ -205,6 +222,7 @@ func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, event
 	statusPerContext := make(map[string]btypes.ChangesetCheckState)
 	statusPerCheckSuite := make(map[string]btypes.ChangesetCheckState)
 	statusPerCheckRun := make(map[string]btypes.ChangesetCheckState)
+	checkRunNames := make(map[string]string)
 
 	if len(pr.Commits.Nodes) > 0 {
 		// We only request the most recent commit
@@ -225,6 +243,7 @@ func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, event
 			statusPerCheckSuite[c.ID] = parseGithubCheckSuiteState(c.Status, c.Conclusion)
 			for _, r := range c.CheckRuns.Nodes {
 				statusPerCheckRun[r.ID] = parseGithubCheckSuiteState(r.Status, r.Conclusion)
+				checkRunNames[r.ID] = r.Name
 			}
 		}
 	}
@@ -258,6 +277,7 @@ func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, event
 		case *github.CheckRun:
 			if m.ReceivedAt.After(lastSynced) {
 				statusPerCheckRun[m.ID] = parseGithubCheckSuiteState(m.Status, m.Conclusion)
+				checkRunNames[m.ID] = m.Name
 			}
 		}
 	}
@@ -276,13 +296,21 @@ func computeGitHubCheckState(lastSynced time.Time, pr *github.PullRequest, event
 	}
 	finalStates := make([]btypes.ChangesetCheckState, 0, len(statusPerContext))
 	for k := range statusPerContext {
-		finalStates = append(finalStates, statusPerContext[k])
+		if rules.Required(k) {
+			finalStates = append(finalStates, statusPerContext[k])
+		}
 	}
-	for k := range statusPerCheckSuite {
-		finalStates = append(finalStates, statusPerCheckSuite[k])
+	// A check suite combines the states of its check runs, so it only counts
+	// when there are no rules that could exempt some of its check runs.
+	if len(rules) == 0 {
+		for k := range statusPerCheckSuite {
+			finalStates = append(finalStates, statusPerCheckSuite[k])
+		}
 	}
 	for k := range statusPerCheckRun {
-		finalStates = append(finalStates, statusPerCheckRun[k])
+		if rules.Required(checkRunNames[k]) {
+			finalStates = append(finalStates, statusPerCheckRun[k])
+		}
 	}
 	return combineCheckStates(finalStates)
 }
@@ -450,6 +478,21 @@ func computeSingleChangesetExternalState(c *btypes.Changeset) (s btypes.Changese
 		default:
 			return "", errors.Errorf("unknown GitLab merge request state: %s", m.State)
 		}
+	case *azuredevops.PullRequest:
+		switch m.Status {
+		case azuredevops.PullRequestStatusAbandoned:
+			s = btypes.ChangesetExternalStateClosed
+		case azuredevops.PullRequestStatusCompleted:
+			s = btypes.ChangesetExternalStateMerged
+		case azuredevops.PullRequestStatusActive:
+			if m.IsDraft {
+				s = btypes.ChangesetExternalStateDraft
+			} else {
+				s = btypes.ChangesetExternalStateOpen
+			}
+		default:
+			return "", errors.Errorf("unknown Azure DevOps pull request status: %s", m.Status)
+		}
 	default:
 		return "", errors.New("unknown changeset type")
 	}
@@ -507,6 +550,18 @@ func computeSingleChangesetReviewState(c *btypes.Changeset) (s btypes.ChangesetR
 		}
 		return btypes.ChangesetReviewStatePending, nil
 
+	case *azuredevops.PullRequest:
+		for _, r := range m.Reviewers {
+			switch {
+			case r.Vote > azuredevops.VoteNone:
+				states[btypes.ChangesetReviewStateApproved] = true
+			case r.Vote < azuredevops.VoteNone:
+				states[btypes.ChangesetReviewStateChangesRequested] = true
+			default:
+				states[btypes.ChangesetReviewStatePending] = true
+			}
+		}
+
 	default:
 		return "", errors.New("unknown changeset type")
 	}
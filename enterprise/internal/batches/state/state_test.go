@@ -34,6 +34,7 @@ func TestComputeGithubCheckState(t *testing.T) {
 	checkRun := func(id, status, conclusion string) github.CheckRun {
 		return github.CheckRun{
 			ID:         id,
+			Name:       id,
 			Status:     status,
 			Conclusion: conclusion,
 		}
@@ -52,13 +53,27 @@ func TestComputeGithubCheckState(t *testing.T) {
 		}
 		return event
 	}
+	checkRunEvent := func(minutesSinceSync int, run github.CheckRun) *btypes.ChangesetEvent {
+		run.ReceivedAt = now.Add(time.Duration(minutesSinceSync) * time.Minute)
+		return &btypes.ChangesetEvent{
+			Kind:     btypes.ChangesetEventKindCheckRun,
+			Metadata: &run,
+		}
+	}
 
 	lastSynced := now.Add(-1 * time.Minute)
 	pr := &github.PullRequest{}
 
+	informational := false
+	rules := btypes.ChangesetCheckRules{
+		{Name: "lint/required"},
+		{Name: "lint/*", Required: &informational},
+	}
+
 	tests := []struct {
 		name   string
 		events []*btypes.ChangesetEvent
+		rules  btypes.ChangesetCheckRules
 		want   btypes.ChangesetCheckState
 	}{
 		{
@@ -151,11 +166,47 @@ func TestComputeGithubCheckState(t *testing.T) {
 			},
 			want: btypes.ChangesetCheckStateFailed,
 		},
+		{
+			name: "informational statuses are ignored",
+			events: []*btypes.ChangesetEvent{
+				commitEvent(1, "ctx1", "SUCCESS"),
+				commitEvent(1, "lint/style", "ERROR"),
+				commitEvent(1, "lint/docs", "PENDING"),
+			},
+			rules: rules,
+			want:  btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name: "first matching rule applies",
+			events: []*btypes.ChangesetEvent{
+				commitEvent(1, "ctx1", "SUCCESS"),
+				commitEvent(1, "lint/required", "ERROR"),
+			},
+			rules: rules,
+			want:  btypes.ChangesetCheckStateFailed,
+		},
+		{
+			name: "informational check runs are ignored",
+			events: []*btypes.ChangesetEvent{
+				checkRunEvent(1, checkRun("build", "COMPLETED", "SUCCESS")),
+				checkRunEvent(1, checkRun("lint/style", "COMPLETED", "FAILURE")),
+			},
+			rules: rules,
+			want:  btypes.ChangesetCheckStatePassed,
+		},
+		{
+			name: "only informational checks",
+			events: []*btypes.ChangesetEvent{
+				commitEvent(1, "lint/style", "ERROR"),
+			},
+			rules: rules,
+			want:  btypes.ChangesetCheckStateUnknown,
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			got := computeGitHubCheckState(lastSynced, pr, tc.events)
+			got := computeGitHubCheckState(lastSynced, pr, tc.events, tc.rules)
 			if diff := cmp.Diff(tc.want, got); diff != "" {
 				t.Fatalf(diff)
 			}
@@ -193,9 +244,16 @@ func TestComputeBitbucketBuildStatus(t *testing.T) {
 		},
 	}
 
+	informational := false
+	rules := btypes.ChangesetCheckRules{
+		{Name: "lint/required"},
+		{Name: "lint/*", Required: &informational},
+	}
+
 	tests := []struct {
 		name   string
 		events []*btypes.ChangesetEvent
+		rules  btypes.ChangesetCheckRules
 		want   btypes.ChangesetCheckState
 	}{
 		{
@@ -264,11 +322,20 @@ func TestComputeBitbucketBuildStatus(t *testing.T) {
 			},
 			want: btypes.ChangesetCheckStatePassed,
 		},
+		{
+			name: "informational builds are ignored",
+			events: []*btypes.ChangesetEvent{
+				statusEvent(1, "ctx1", "SUCCESSFUL"),
+				statusEvent(1, "lint/style", "FAILED"),
+			},
+			rules: rules,
+			want:  btypes.ChangesetCheckStatePassed,
+		},
 	}
 
 	for _, tc := range tests {
 		t.Run(tc.name, func(t *testing.T) {
-			have := computeBitbucketBuildStatus(lastSynced, pr, tc.events)
+			have := computeBitbucketBuildStatus(lastSynced, pr, tc.events, tc.rules)
 			if diff := cmp.Diff(tc.want, have); diff != "" {
 				t.Fatalf(diff)
 			}
@@ -0,0 +1,319 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/cockroachdb/errors"
+	"github.com/keegancsmith/sqlf"
+
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// batchChangeTemplateColumns are used by the batch change template related
+// Store methods to query batch change templates.
+var batchChangeTemplateColumns = []*sqlf.Query{
+	sqlf.Sprintf("batch_change_templates.id"),
+	sqlf.Sprintf("batch_change_templates.name"),
+	sqlf.Sprintf("batch_change_templates.description"),
+	sqlf.Sprintf("batch_change_templates.spec"),
+	sqlf.Sprintf("batch_change_templates.parameters"),
+	sqlf.Sprintf("batch_change_templates.namespace_user_id"),
+	sqlf.Sprintf("batch_change_templates.namespace_org_id"),
+	sqlf.Sprintf("batch_change_templates.creator_id"),
+	sqlf.Sprintf("batch_change_templates.created_at"),
+	sqlf.Sprintf("batch_change_templates.updated_at"),
+}
+
+// batchChangeTemplateInsertColumns is the list of batch_change_templates
+// columns that are modified when updating/inserting batch change templates.
+var batchChangeTemplateInsertColumns = []*sqlf.Query{
+	sqlf.Sprintf("name"),
+	sqlf.Sprintf("description"),
+	sqlf.Sprintf("spec"),
+	sqlf.Sprintf("parameters"),
+	sqlf.Sprintf("namespace_user_id"),
+	sqlf.Sprintf("namespace_org_id"),
+	sqlf.Sprintf("creator_id"),
+	sqlf.Sprintf("created_at"),
+	sqlf.Sprintf("updated_at"),
+}
+
+const batchChangeTemplateInsertColsFmt = `(%s, %s, %s, %s, %s, %s, %s, %s, %s)`
+
+// CreateBatchChangeTemplate creates the given BatchChangeTemplate.
+func (s *Store) CreateBatchChangeTemplate(ctx context.Context, t *btypes.BatchChangeTemplate) error {
+	if t.CreatedAt.IsZero() {
+		t.CreatedAt = s.now()
+	}
+
+	if t.UpdatedAt.IsZero() {
+		t.UpdatedAt = t.CreatedAt
+	}
+
+	q, err := batchChangeTemplateWriteQuery(createBatchChangeTemplateQueryFmtstr, t)
+	if err != nil {
+		return err
+	}
+	return s.query(ctx, q, func(sc scanner) error { return scanBatchChangeTemplate(t, sc) })
+}
+
+var createBatchChangeTemplateQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_templates.go:CreateBatchChangeTemplate
+INSERT INTO batch_change_templates (%s)
+VALUES ` + batchChangeTemplateInsertColsFmt + `
+RETURNING %s`
+
+// UpdateBatchChangeTemplate updates the given BatchChangeTemplate.
+func (s *Store) UpdateBatchChangeTemplate(ctx context.Context, t *btypes.BatchChangeTemplate) error {
+	t.UpdatedAt = s.now()
+
+	q, err := batchChangeTemplateWriteQuery(updateBatchChangeTemplateQueryFmtstr, t)
+	if err != nil {
+		return err
+	}
+
+	updated := &btypes.BatchChangeTemplate{}
+	if err := s.query(ctx, q, func(sc scanner) error { return scanBatchChangeTemplate(updated, sc) }); err != nil {
+		return err
+	}
+	if updated.ID == 0 {
+		return ErrNoResults
+	}
+	*t = *updated
+	return nil
+}
+
+var updateBatchChangeTemplateQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_templates.go:UpdateBatchChangeTemplate
+UPDATE batch_change_templates
+SET (%s) = ` + batchChangeTemplateInsertColsFmt + `
+WHERE id = %s
+RETURNING %s`
+
+func batchChangeTemplateWriteQuery(fmtstr string, t *btypes.BatchChangeTemplate) (*sqlf.Query, error) {
+	params := t.Parameters
+	if params == nil {
+		params = []btypes.BatchChangeTemplateParameter{}
+	}
+	parameters, err := jsonbColumn(params)
+	if err != nil {
+		return nil, err
+	}
+
+	args := []interface{}{
+		sqlf.Join(batchChangeTemplateInsertColumns, ", "),
+		t.Name,
+		t.Description,
+		t.Spec,
+		parameters,
+		nullInt32Column(t.NamespaceUserID),
+		nullInt32Column(t.NamespaceOrgID),
+		nullInt32Column(t.CreatorID),
+		t.CreatedAt,
+		t.UpdatedAt,
+	}
+	if t.ID != 0 {
+		args = append(args, t.ID)
+	}
+	args = append(args, sqlf.Join(batchChangeTemplateColumns, ", "))
+
+	return sqlf.Sprintf(fmtstr, args...), nil
+}
+
+// DeleteBatchChangeTemplate deletes the BatchChangeTemplate with the given ID.
+func (s *Store) DeleteBatchChangeTemplate(ctx context.Context, id int64) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(deleteBatchChangeTemplateQueryFmtstr, id))
+	if err != nil {
+		return err
+	}
+
+	if rows, err := res.RowsAffected(); err != nil {
+		return err
+	} else if rows == 0 {
+		return ErrNoResults
+	}
+	return nil
+}
+
+var deleteBatchChangeTemplateQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_templates.go:DeleteBatchChangeTemplate
+DELETE FROM batch_change_templates WHERE id = %s
+`
+
+// GetBatchChangeTemplateOpts captures the query options needed for getting a
+// BatchChangeTemplate.
+type GetBatchChangeTemplateOpts struct {
+	ID int64
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+	Name            string
+}
+
+// GetBatchChangeTemplate gets a BatchChangeTemplate matching the given options.
+func (s *Store) GetBatchChangeTemplate(ctx context.Context, opts GetBatchChangeTemplateOpts) (*btypes.BatchChangeTemplate, error) {
+	q := getBatchChangeTemplateQuery(&opts)
+
+	var t btypes.BatchChangeTemplate
+	err := s.query(ctx, q, func(sc scanner) error { return scanBatchChangeTemplate(&t, sc) })
+	if err != nil {
+		return nil, err
+	}
+
+	if t.ID == 0 {
+		return nil, ErrNoResults
+	}
+
+	return &t, nil
+}
+
+var getBatchChangeTemplateQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_templates.go:GetBatchChangeTemplate
+SELECT %s FROM batch_change_templates
+WHERE %s
+LIMIT 1
+`
+
+func getBatchChangeTemplateQuery(opts *GetBatchChangeTemplateOpts) *sqlf.Query {
+	var preds []*sqlf.Query
+	if opts.ID != 0 {
+		preds = append(preds, sqlf.Sprintf("id = %s", opts.ID))
+	}
+
+	if opts.NamespaceUserID != 0 {
+		preds = append(preds, sqlf.Sprintf("namespace_user_id = %s", opts.NamespaceUserID))
+	}
+
+	if opts.NamespaceOrgID != 0 {
+		preds = append(preds, sqlf.Sprintf("namespace_org_id = %s", opts.NamespaceOrgID))
+	}
+
+	if opts.Name != "" {
+		preds = append(preds, sqlf.Sprintf("name = %s", opts.Name))
+	}
+
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+
+	return sqlf.Sprintf(
+		getBatchChangeTemplateQueryFmtstr,
+		sqlf.Join(batchChangeTemplateColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// ListBatchChangeTemplatesOpts captures the query options needed for listing
+// batch change templates.
+type ListBatchChangeTemplatesOpts struct {
+	LimitOpts
+	Cursor int64
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+}
+
+// ListBatchChangeTemplates lists BatchChangeTemplates with the given filters.
+func (s *Store) ListBatchChangeTemplates(ctx context.Context, opts ListBatchChangeTemplatesOpts) (ts []*btypes.BatchChangeTemplate, next int64, err error) {
+	q := listBatchChangeTemplatesQuery(&opts)
+
+	ts = make([]*btypes.BatchChangeTemplate, 0, opts.DBLimit())
+	err = s.query(ctx, q, func(sc scanner) error {
+		var t btypes.BatchChangeTemplate
+		if err := scanBatchChangeTemplate(&t, sc); err != nil {
+			return err
+		}
+		ts = append(ts, &t)
+		return nil
+	})
+
+	if opts.Limit != 0 && len(ts) == opts.DBLimit() {
+		next = ts[len(ts)-1].ID
+		ts = ts[:len(ts)-1]
+	}
+
+	return ts, next, err
+}
+
+var listBatchChangeTemplatesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_templates.go:ListBatchChangeTemplates
+SELECT %s FROM batch_change_templates
+WHERE %s
+ORDER BY id ASC
+`
+
+func listBatchChangeTemplatesQuery(opts *ListBatchChangeTemplatesOpts) *sqlf.Query {
+	preds := append(
+		batchChangeTemplateNamespacePreds(opts.NamespaceUserID, opts.NamespaceOrgID),
+		sqlf.Sprintf("id >= %s", opts.Cursor),
+	)
+
+	return sqlf.Sprintf(
+		listBatchChangeTemplatesQueryFmtstr+opts.LimitOpts.ToDB(),
+		sqlf.Join(batchChangeTemplateColumns, ", "),
+		sqlf.Join(preds, "\n AND "),
+	)
+}
+
+// CountBatchChangeTemplatesOpts captures the query options needed for
+// counting batch change templates.
+type CountBatchChangeTemplatesOpts struct {
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+}
+
+// CountBatchChangeTemplates returns the number of batch change templates in
+// the database.
+func (s *Store) CountBatchChangeTemplates(ctx context.Context, opts CountBatchChangeTemplatesOpts) (int, error) {
+	preds := batchChangeTemplateNamespacePreds(opts.NamespaceUserID, opts.NamespaceOrgID)
+	if len(preds) == 0 {
+		preds = append(preds, sqlf.Sprintf("TRUE"))
+	}
+	return s.queryCount(ctx, sqlf.Sprintf(countBatchChangeTemplatesQueryFmtstr, sqlf.Join(preds, "\n AND ")))
+}
+
+var countBatchChangeTemplatesQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_change_templates.go:CountBatchChangeTemplates
+SELECT COUNT(id)
+FROM batch_change_templates
+WHERE %s
+`
+
+func batchChangeTemplateNamespacePreds(namespaceUserID, namespaceOrgID int32) []*sqlf.Query {
+	var preds []*sqlf.Query
+	if namespaceUserID != 0 {
+		preds = append(preds, sqlf.Sprintf("namespace_user_id = %s", namespaceUserID))
+	}
+	if namespaceOrgID != 0 {
+		preds = append(preds, sqlf.Sprintf("namespace_org_id = %s", namespaceOrgID))
+	}
+	return preds
+}
+
+func scanBatchChangeTemplate(t *btypes.BatchChangeTemplate, s scanner) error {
+	var parameters json.RawMessage
+
+	err := s.Scan(
+		&t.ID,
+		&t.Name,
+		&t.Description,
+		&t.Spec,
+		&parameters,
+		&dbutil.NullInt32{N: &t.NamespaceUserID},
+		&dbutil.NullInt32{N: &t.NamespaceOrgID},
+		&dbutil.NullInt32{N: &t.CreatorID},
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+	if err != nil {
+		return errors.Wrap(err, "scanning batch change template")
+	}
+
+	if err = json.Unmarshal(parameters, &t.Parameters); err != nil {
+		return errors.Wrap(err, "scanBatchChangeTemplate: failed to unmarshal parameters")
+	}
+
+	return nil
+}
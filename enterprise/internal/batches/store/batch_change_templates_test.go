@@ -0,0 +1,208 @@
+package store
+
+import (
+	"context"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	ct "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/testing"
+	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+)
+
+func testStoreBatchChangeTemplates(t *testing.T, ctx context.Context, s *Store, clock ct.Clock) {
+	defaultTag := "latest"
+
+	templates := make([]*btypes.BatchChangeTemplate, 0, 3)
+	for i := 0; i < cap(templates); i++ {
+		c := &btypes.BatchChangeTemplate{
+			Name:        "template-" + strconv.Itoa(i),
+			Description: "the template",
+			Spec:        "name: ${{ parameters.name }}",
+			Parameters: []btypes.BatchChangeTemplateParameter{
+				{Name: "name"},
+				{Name: "tag", Description: "the tag", Default: &defaultTag},
+			},
+			CreatorID: 123,
+		}
+
+		if i == cap(templates)-1 {
+			c.NamespaceOrgID = 23
+		} else {
+			c.NamespaceUserID = int32(i + 345)
+		}
+
+		templates = append(templates, c)
+	}
+
+	t.Run("Create", func(t *testing.T) {
+		for _, tmpl := range templates {
+			want := *tmpl
+			if err := s.CreateBatchChangeTemplate(ctx, tmpl); err != nil {
+				t.Fatal(err)
+			}
+
+			have := tmpl
+			if have.ID == 0 {
+				t.Fatal("ID should not be zero")
+			}
+
+			want.ID = have.ID
+			want.CreatedAt = clock.Now()
+			want.UpdatedAt = clock.Now()
+
+			if diff := cmp.Diff(have, &want); diff != "" {
+				t.Fatal(diff)
+			}
+		}
+	})
+
+	t.Run("Count", func(t *testing.T) {
+		count, err := s.CountBatchChangeTemplates(ctx, CountBatchChangeTemplatesOpts{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := count, len(templates); have != want {
+			t.Fatalf("have count: %d, want: %d", have, want)
+		}
+
+		count, err = s.CountBatchChangeTemplates(ctx, CountBatchChangeTemplatesOpts{NamespaceOrgID: 23})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have, want := count, 1; have != want {
+			t.Fatalf("have count: %d, want: %d", have, want)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		t.Run("All", func(t *testing.T) {
+			have, next, err := s.ListBatchChangeTemplates(ctx, ListBatchChangeTemplatesOpts{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if next != 0 {
+				t.Fatalf("have next %d, want 0", next)
+			}
+			if diff := cmp.Diff(have, templates); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("WithLimit", func(t *testing.T) {
+			for i := 1; i <= len(templates); i++ {
+				have, next, err := s.ListBatchChangeTemplates(ctx, ListBatchChangeTemplatesOpts{LimitOpts: LimitOpts{Limit: i}})
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var wantNext int64
+				if i < len(templates) {
+					wantNext = templates[i].ID
+				}
+				if next != wantNext {
+					t.Fatalf("limit %d: have next %d, want %d", i, next, wantNext)
+				}
+				if diff := cmp.Diff(have, templates[:i]); diff != "" {
+					t.Fatalf("limit %d: %s", i, diff)
+				}
+			}
+		})
+
+		t.Run("ByNamespace", func(t *testing.T) {
+			have, _, err := s.ListBatchChangeTemplates(ctx, ListBatchChangeTemplatesOpts{NamespaceUserID: templates[1].NamespaceUserID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, templates[1:2]); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	})
+
+	t.Run("Get", func(t *testing.T) {
+		t.Run("ByID", func(t *testing.T) {
+			for i, tmpl := range templates {
+				t.Run(strconv.Itoa(i), func(t *testing.T) {
+					have, err := s.GetBatchChangeTemplate(ctx, GetBatchChangeTemplateOpts{ID: tmpl.ID})
+					if err != nil {
+						t.Fatal(err)
+					}
+					if diff := cmp.Diff(have, tmpl); diff != "" {
+						t.Fatal(diff)
+					}
+				})
+			}
+		})
+
+		t.Run("ByName", func(t *testing.T) {
+			tmpl := templates[2]
+			have, err := s.GetBatchChangeTemplate(ctx, GetBatchChangeTemplateOpts{NamespaceOrgID: tmpl.NamespaceOrgID, Name: tmpl.Name})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, tmpl); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+
+		t.Run("NoResults", func(t *testing.T) {
+			_, have := s.GetBatchChangeTemplate(ctx, GetBatchChangeTemplateOpts{ID: 0xdeadbeef})
+			want := ErrNoResults
+			if have != want {
+				t.Fatalf("have err %v, want %v", have, want)
+			}
+		})
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		clock.Add(1 * time.Second)
+
+		tmpl := templates[0]
+		tmpl.Description = "updated"
+		tmpl.Parameters = nil
+		tmpl.Spec = "name: fixed"
+
+		if err := s.UpdateBatchChangeTemplate(ctx, tmpl); err != nil {
+			t.Fatal(err)
+		}
+		if have, want := tmpl.UpdatedAt, clock.Now(); !have.Equal(want) {
+			t.Fatalf("have updated at %v, want %v", have, want)
+		}
+
+		have, err := s.GetBatchChangeTemplate(ctx, GetBatchChangeTemplateOpts{ID: tmpl.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(have.Parameters, []btypes.BatchChangeTemplateParameter{}); diff != "" {
+			t.Fatal(diff)
+		}
+		if have.Description != "updated" {
+			t.Fatalf("have description %q, want %q", have.Description, "updated")
+		}
+
+		missing := *tmpl
+		missing.ID = 0xdeadbeef
+		if err := s.UpdateBatchChangeTemplate(ctx, &missing); err != ErrNoResults {
+			t.Fatalf("have err %v, want %v", err, ErrNoResults)
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		for _, tmpl := range templates {
+			if err := s.DeleteBatchChangeTemplate(ctx, tmpl.ID); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := s.GetBatchChangeTemplate(ctx, GetBatchChangeTemplateOpts{ID: tmpl.ID}); err != ErrNoResults {
+				t.Fatalf("have err %v, want %v", err, ErrNoResults)
+			}
+		}
+
+		if err := s.DeleteBatchChangeTemplate(ctx, templates[0].ID); err != ErrNoResults {
+			t.Fatalf("have err %v, want %v", err, ErrNoResults)
+		}
+	})
+}
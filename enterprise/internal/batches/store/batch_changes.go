@@ -29,6 +29,7 @@ var batchChangeColumns = []*sqlf.Query{
 	sqlf.Sprintf("batch_changes.updated_at"),
 	sqlf.Sprintf("batch_changes.closed_at"),
 	sqlf.Sprintf("batch_changes.batch_spec_id"),
+	sqlf.Sprintf("batch_changes.version"),
 }
 
 // batchChangeInsertColumns is the list of batch changes columns that are
@@ -91,18 +92,35 @@ func (s *Store) createBatchChangeQuery(c *btypes.BatchChange) *sqlf.Query {
 	)
 }
 
-// UpdateBatchChange updates the given bach change.
+// ErrBatchChangeVersionConflict is returned by UpdateBatchChange when the batch
+// change has been updated (or deleted) since it was read.
+var ErrBatchChangeVersionConflict = errors.New("batch change has been modified concurrently")
+
+// UpdateBatchChange updates the given batch change if its version still matches
+// the version in the database, and increments the version. Otherwise it returns
+// ErrBatchChangeVersionConflict.
 func (s *Store) UpdateBatchChange(ctx context.Context, c *btypes.BatchChange) error {
 	q := s.updateBatchChangeQuery(c)
 
-	return s.query(ctx, q, func(sc scanner) (err error) { return scanBatchChange(c, sc) })
+	var updated bool
+	err := s.query(ctx, q, func(sc scanner) (err error) {
+		updated = true
+		return scanBatchChange(c, sc)
+	})
+	if err != nil {
+		return err
+	}
+	if !updated {
+		return ErrBatchChangeVersionConflict
+	}
+	return nil
 }
 
 var updateBatchChangeQueryFmtstr = `
 -- source: enterprise/internal/batches/store.go:UpdateBatchChange
 UPDATE batch_changes
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
-WHERE id = %s
+SET (%s, version) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, version + 1)
+WHERE id = %s AND version = %s
 RETURNING %s
 `
 
@@ -124,6 +142,7 @@ func (s *Store) updateBatchChangeQuery(c *btypes.BatchChange) *sqlf.Query {
 		nullTimeColumn(c.ClosedAt),
 		c.BatchSpecID,
 		c.ID,
+		c.Version,
 		sqlf.Join(batchChangeColumns, ", "),
 	)
 }
@@ -485,5 +504,6 @@ func scanBatchChange(c *btypes.BatchChange, s scanner) error {
 		&c.UpdatedAt,
 		&dbutil.NullTime{Time: &c.ClosedAt},
 		&c.BatchSpecID,
+		&c.Version,
 	)
 }
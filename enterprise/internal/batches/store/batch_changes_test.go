@@ -62,6 +62,7 @@ func testStoreBatchChanges(t *testing.T, ctx context.Context, s *Store, clock ct
 			want.ID = have.ID
 			want.CreatedAt = clock.Now()
 			want.UpdatedAt = clock.Now()
+			want.Version = 1
 
 			if diff := cmp.Diff(have, want); diff != "" {
 				t.Fatal(diff)
@@ -446,11 +447,30 @@ func testStoreBatchChanges(t *testing.T, ctx context.Context, s *Store, clock ct
 			if err := s.UpdateBatchChange(ctx, have); err != nil {
 				t.Fatal(err)
 			}
+			want.Version++
 
 			if diff := cmp.Diff(have, want); diff != "" {
 				t.Fatal(diff)
 			}
 		}
+
+		t.Run("VersionConflict", func(t *testing.T) {
+			stale := cs[0].Clone()
+			stale.Version--
+			stale.Name += "-stale"
+
+			if err := s.UpdateBatchChange(ctx, stale); err != ErrBatchChangeVersionConflict {
+				t.Fatalf("unexpected error: want=%v have=%v", ErrBatchChangeVersionConflict, err)
+			}
+
+			have, err := s.GetBatchChange(ctx, GetBatchChangeOpts{ID: cs[0].ID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(have, cs[0]); diff != "" {
+				t.Fatal(diff)
+			}
+		})
 	})
 
 	t.Run("Get", func(t *testing.T) {
@@ -34,6 +34,7 @@ var BatchSpecExecutionColumns = []*sqlf.Query{
 	sqlf.Sprintf(`batch_spec_executions.user_id`),
 	sqlf.Sprintf(`batch_spec_executions.namespace_user_id`),
 	sqlf.Sprintf(`batch_spec_executions.namespace_org_id`),
+	sqlf.Sprintf(`batch_spec_executions.access_token_id`),
 }
 
 var batchSpecExecutionInsertColumns = []*sqlf.Query{
@@ -148,6 +149,38 @@ func getBatchSpecExecutionQuery(opts *GetBatchSpecExecutionOpts) (*sqlf.Query, e
 	), nil
 }
 
+// SetBatchSpecExecutionAccessToken sets the ID of the access token that was
+// created for the given BatchSpecExecution.
+func (s *Store) SetBatchSpecExecutionAccessToken(ctx context.Context, id, tokenID int64) error {
+	return s.Exec(ctx, sqlf.Sprintf(setBatchSpecExecutionAccessTokenQueryFmtstr, nullInt64Column(tokenID), s.now(), id))
+}
+
+var setBatchSpecExecutionAccessTokenQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_executions.go:SetBatchSpecExecutionAccessToken
+UPDATE batch_spec_executions
+SET access_token_id = %s, updated_at = %s
+WHERE id = %s
+`
+
+// BatchSpecExecutionPlaceInQueue returns the 1-indexed position of the given
+// BatchSpecExecution in the queue. If the execution is not queued, false is
+// returned.
+func (s *Store) BatchSpecExecutionPlaceInQueue(ctx context.Context, id int64) (int, bool, error) {
+	return basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(batchSpecExecutionPlaceInQueueQueryFmtstr, id)))
+}
+
+var batchSpecExecutionPlaceInQueueQueryFmtstr = `
+-- source: enterprise/internal/batches/store/batch_spec_executions.go:BatchSpecExecutionPlaceInQueue
+SELECT place_in_queue FROM (
+  SELECT
+    id,
+    ROW_NUMBER() OVER (ORDER BY created_at, id) AS place_in_queue
+  FROM batch_spec_executions
+  WHERE state = 'queued'
+) AS queue
+WHERE id = %s
+`
+
 func scanBatchSpecExecution(b *btypes.BatchSpecExecution, sc scanner) error {
 	var executionLogs []dbworkerstore.ExecutionLogEntry
 
@@ -170,6 +203,7 @@ func scanBatchSpecExecution(b *btypes.BatchSpecExecution, sc scanner) error {
 		&b.UserID,
 		&dbutil.NullInt32{N: &b.NamespaceUserID},
 		&dbutil.NullInt32{N: &b.NamespaceOrgID},
+		&dbutil.NullInt64{N: &b.AccessTokenID},
 	); err != nil {
 		return err
 	}
@@ -100,4 +100,43 @@ func testStoreChangesetSpecExecutions(t *testing.T, ctx context.Context, s *Stor
 			}
 		})
 	})
+
+	t.Run("SetBatchSpecExecutionAccessToken", func(t *testing.T) {
+		exec := execs[0]
+		if err := s.SetBatchSpecExecutionAccessToken(ctx, exec.ID, 0); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.GetBatchSpecExecution(ctx, GetBatchSpecExecutionOpts{ID: exec.ID})
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if have.AccessTokenID != 0 {
+			t.Fatalf("have access token ID %d, want 0", have.AccessTokenID)
+		}
+	})
+
+	t.Run("BatchSpecExecutionPlaceInQueue", func(t *testing.T) {
+		for i, exec := range execs {
+			have, ok, err := s.BatchSpecExecutionPlaceInQueue(ctx, exec.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !ok {
+				t.Fatalf("execution %d not found in queue", exec.ID)
+			}
+			if want := i + 1; have != want {
+				t.Fatalf("have place in queue %d, want %d", have, want)
+			}
+		}
+
+		_, ok, err := s.BatchSpecExecutionPlaceInQueue(ctx, 0xdeadbeef)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if ok {
+			t.Fatal("unexpected place in queue for missing execution")
+		}
+	})
 }
@@ -10,24 +10,29 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 )
 
+// bulkOperationStateExpression computes the state of a bulk operation from
+// the states of its changeset jobs. It has to be used in a query grouped by
+// bulk_group.
+var bulkOperationStateExpression = sqlf.Sprintf(
+	`CASE
+	WHEN COUNT(*) FILTER (WHERE changeset_jobs.state IN (%s, %s, %s)) > 0 THEN %s
+	WHEN COUNT(*) FILTER (WHERE changeset_jobs.state = %s) > 0 THEN %s
+	ELSE %s
+END`,
+	btypes.ChangesetJobStateProcessing.ToDB(),
+	btypes.ChangesetJobStateQueued.ToDB(),
+	btypes.ChangesetJobStateErrored.ToDB(),
+	btypes.BulkOperationStateProcessing,
+	btypes.ChangesetJobStateFailed.ToDB(),
+	btypes.BulkOperationStateFailed,
+	btypes.BulkOperationStateCompleted,
+)
+
 var bulkOperationColumns = []*sqlf.Query{
 	sqlf.Sprintf("changeset_jobs.bulk_group AS id"),
 	sqlf.Sprintf("MIN(changeset_jobs.id) AS db_id"),
 	sqlf.Sprintf("changeset_jobs.job_type AS type"),
-	sqlf.Sprintf(
-		`CASE
-	WHEN COUNT(*) FILTER (WHERE changeset_jobs.state IN (%s, %s, %s)) > 0 THEN %s
-	WHEN COUNT(*) FILTER (WHERE changeset_jobs.state = %s) > 0 THEN %s
-	ELSE %s
-END AS state`,
-		btypes.ChangesetJobStateProcessing.ToDB(),
-		btypes.ChangesetJobStateQueued.ToDB(),
-		btypes.ChangesetJobStateErrored.ToDB(),
-		btypes.BulkOperationStateProcessing,
-		btypes.ChangesetJobStateFailed.ToDB(),
-		btypes.BulkOperationStateFailed,
-		btypes.BulkOperationStateCompleted,
-	),
+	sqlf.Sprintf("%s AS state", bulkOperationStateExpression),
 	sqlf.Sprintf(
 		"CAST(COUNT(*) FILTER (WHERE changeset_jobs.state IN (%s, %s)) AS float) / CAST(COUNT(*) AS float) AS progress",
 		btypes.ChangesetJobStateCompleted.ToDB(),
@@ -99,6 +104,7 @@ type ListBulkOperationsOpts struct {
 	LimitOpts
 	Cursor       int64
 	CreatedAfter time.Time
+	States       []btypes.BulkOperationState
 
 	BatchChangeID int64
 }
@@ -145,27 +151,32 @@ func listBulkOperationsQuery(opts *ListBulkOperationsOpts) *sqlf.Query {
 		sqlf.Sprintf("repo.deleted_at IS NULL"),
 		sqlf.Sprintf("changeset_jobs.batch_change_id = %s", opts.BatchChangeID),
 	}
-	having := sqlf.Sprintf("")
+	var havingPreds []*sqlf.Query
 
 	if opts.Cursor > 0 {
 		preds = append(preds, sqlf.Sprintf("changeset_jobs.id <= %s", opts.Cursor))
 	}
 
 	if !opts.CreatedAfter.IsZero() {
-		having = sqlf.Sprintf("HAVING MIN(changeset_jobs.created_at) >= %s", opts.CreatedAfter)
+		havingPreds = append(havingPreds, sqlf.Sprintf("MIN(changeset_jobs.created_at) >= %s", opts.CreatedAfter))
+	}
+
+	if len(opts.States) > 0 {
+		havingPreds = append(havingPreds, bulkOperationStatesPredicate(opts.States))
 	}
 
 	return sqlf.Sprintf(
 		listBulkOperationsQueryFmtstr+opts.LimitOpts.ToDB(),
 		sqlf.Join(bulkOperationColumns, ","),
 		sqlf.Join(preds, "\n AND "),
-		having,
+		bulkOperationsHavingClause(havingPreds),
 	)
 }
 
 // CountBulkOperationsOpts captures the query options needed when counting BulkOperations.
 type CountBulkOperationsOpts struct {
 	CreatedAfter  time.Time
+	States        []btypes.BulkOperationState
 	BatchChangeID int64
 }
 
@@ -176,13 +187,18 @@ func (s *Store) CountBulkOperations(ctx context.Context, opts CountBulkOperation
 
 var countBulkOperationsQueryFmtstr = `
 -- source: enterprise/internal/batches/store/bulk_operations.go:CountBulkOperations
-SELECT
-	COUNT(DISTINCT(changeset_jobs.bulk_group))
-FROM changeset_jobs
-INNER JOIN changesets ON changesets.id = changeset_jobs.changeset_id
-INNER JOIN repo ON repo.id = changesets.repo_id
-WHERE
-    %s
+SELECT COUNT(*) FROM (
+	SELECT
+		changeset_jobs.bulk_group
+	FROM changeset_jobs
+	INNER JOIN changesets ON changesets.id = changeset_jobs.changeset_id
+	INNER JOIN repo ON repo.id = changesets.repo_id
+	WHERE
+		%s
+	GROUP BY
+		changeset_jobs.bulk_group
+	%s
+) AS bulk_operations
 `
 
 func countBulkOperationsQuery(opts *CountBulkOperationsOpts) *sqlf.Query {
@@ -195,12 +211,33 @@ func countBulkOperationsQuery(opts *CountBulkOperationsOpts) *sqlf.Query {
 		preds = append(preds, sqlf.Sprintf("changeset_jobs.created_at >= %s", opts.CreatedAfter))
 	}
 
+	var havingPreds []*sqlf.Query
+	if len(opts.States) > 0 {
+		havingPreds = append(havingPreds, bulkOperationStatesPredicate(opts.States))
+	}
+
 	return sqlf.Sprintf(
 		countBulkOperationsQueryFmtstr,
 		sqlf.Join(preds, "\n AND "),
+		bulkOperationsHavingClause(havingPreds),
 	)
 }
 
+func bulkOperationStatesPredicate(states []btypes.BulkOperationState) *sqlf.Query {
+	values := make([]*sqlf.Query, 0, len(states))
+	for _, state := range states {
+		values = append(values, sqlf.Sprintf("%s", state))
+	}
+	return sqlf.Sprintf("%s IN (%s)", bulkOperationStateExpression, sqlf.Join(values, ","))
+}
+
+func bulkOperationsHavingClause(preds []*sqlf.Query) *sqlf.Query {
+	if len(preds) == 0 {
+		return sqlf.Sprintf("")
+	}
+	return sqlf.Sprintf("HAVING %s", sqlf.Join(preds, "\n AND "))
+}
+
 // ListBulkOperationErrorsOpts captures the query options needed for getting a list of
 // BulkOperationErrors.
 type ListBulkOperationErrorsOpts struct {
@@ -124,6 +124,21 @@ func testStoreBulkOperations(t *testing.T, ctx context.Context, s *Store, clock
 			}
 		})
 
+		t.Run("WithStates", func(t *testing.T) {
+			opts := CountBulkOperationsOpts{
+				BatchChangeID: batchChangeID,
+				States:        []btypes.BulkOperationState{btypes.BulkOperationStateProcessing},
+			}
+			have, err := s.CountBulkOperations(ctx, opts)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if want := 1; have != want {
+				t.Fatalf("have count %d, want %d", have, want)
+			}
+		})
+
 		t.Run("NoResults", func(t *testing.T) {
 			opts := CountBulkOperationsOpts{BatchChangeID: -1}
 
@@ -205,6 +220,27 @@ func testStoreBulkOperations(t *testing.T, ctx context.Context, s *Store, clock
 			}
 		})
 
+		t.Run("WithStates", func(t *testing.T) {
+			for _, state := range []btypes.BulkOperationState{btypes.BulkOperationStateProcessing, btypes.BulkOperationStateFailed} {
+				opts := ListBulkOperationsOpts{BatchChangeID: batchChangeID, States: []btypes.BulkOperationState{state}}
+				have, _, err := s.ListBulkOperations(ctx, opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				var want []*btypes.BulkOperation
+				for _, op := range reverseBulkOperations {
+					if op.State == state {
+						want = append(want, op)
+					}
+				}
+
+				if diff := cmp.Diff(have, want); diff != "" {
+					t.Fatalf("opts: %+v, diff: %s", opts, diff)
+				}
+			}
+		})
+
 		t.Run("WithLimitAndCursor", func(t *testing.T) {
 			var cursor int64
 			for i := 1; i <= len(reverseBulkOperations); i++ {
@@ -19,6 +19,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/gitlab"
@@ -60,6 +61,7 @@ var ChangesetColumns = []*sqlf.Query{
 	sqlf.Sprintf("changesets.num_failures"),
 	sqlf.Sprintf("changesets.closing"),
 	sqlf.Sprintf("changesets.syncer_error"),
+	sqlf.Sprintf("changesets.check_rules"),
 }
 
 // changesetInsertColumns is the list of changeset columns that are modified in
@@ -96,6 +98,7 @@ var changesetInsertColumns = []*sqlf.Query{
 	sqlf.Sprintf("num_failures"),
 	sqlf.Sprintf("closing"),
 	sqlf.Sprintf("syncer_error"),
+	sqlf.Sprintf("check_rules"),
 	// We additionally store the result of changeset.Title() in a column, so
 	// the business logic for determining it is in one place and the field is
 	// indexable for searching.
@@ -144,6 +147,15 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		return nil, err
 	}
 
+	checkRules := c.CheckRules
+	if checkRules == nil {
+		checkRules = btypes.ChangesetCheckRules{}
+	}
+	checkRulesJSON, err := json.Marshal(checkRules)
+	if err != nil {
+		return nil, err
+	}
+
 	// Not being able to find a title is fine, we just have a NULL in the database then.
 	title, _ := c.Title()
 
@@ -185,6 +197,7 @@ func (s *Store) changesetWriteQuery(q string, includeID bool, c *btypes.Changese
 		c.NumFailures,
 		c.Closing,
 		c.SyncErrorMessage,
+		checkRulesJSON,
 		nullStringColumn(title),
 	}
 
@@ -226,7 +239,7 @@ func (s *Store) CreateChangeset(ctx context.Context, c *btypes.Changeset) error
 var createChangesetQueryFmtstr = `
 -- source: enterprise/internal/batches/store.go:CreateChangeset
 INSERT INTO changesets (%s)
-VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING %s
 `
 
@@ -440,6 +453,17 @@ func (s *Store) ListChangesetSyncData(ctx context.Context, opts ListChangesetSyn
 	if err != nil {
 		return nil, err
 	}
+	if len(results) == 0 {
+		return results, nil
+	}
+
+	webhooks, err := s.listCodeHostsWithWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, h := range results {
+		h.HasWebhooks = webhooks[h.RepoExternalServiceID]
+	}
 	return results, nil
 }
 
@@ -704,7 +728,7 @@ func (s *Store) UpdateChangeset(ctx context.Context, cs *btypes.Changeset) error
 var updateChangesetQueryFmtstr = `
 -- source: enterprise/internal/batches/store_changesets.go:UpdateChangeset
 UPDATE changesets
-SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+SET (%s) = (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 WHERE id = %s
 RETURNING
   %s
@@ -1012,7 +1036,7 @@ func (n jsonBatchChangeChangesetSet) Value() (driver.Value, error) {
 }
 
 func scanChangeset(t *btypes.Changeset, s scanner) error {
-	var metadata, syncState json.RawMessage
+	var metadata, syncState, checkRules json.RawMessage
 
 	var (
 		externalState       string
@@ -1055,6 +1079,7 @@ func scanChangeset(t *btypes.Changeset, s scanner) error {
 		&t.NumFailures,
 		&t.Closing,
 		&dbutil.NullString{S: &syncErrorMessage},
+		&checkRules,
 	)
 	if err != nil {
 		return errors.Wrap(err, "scanning changeset")
@@ -1078,6 +1103,8 @@ func scanChangeset(t *btypes.Changeset, s scanner) error {
 		t.Metadata = new(bitbucketserver.PullRequest)
 	case extsvc.TypeGitLab:
 		t.Metadata = new(gitlab.MergeRequest)
+	case extsvc.TypeAzureDevOps:
+		t.Metadata = new(azuredevops.PullRequest)
 	default:
 		return errors.New("unknown external service type")
 	}
@@ -1088,6 +1115,13 @@ func scanChangeset(t *btypes.Changeset, s scanner) error {
 	if err = json.Unmarshal(syncState, &t.SyncState); err != nil {
 		return errors.Wrapf(err, "scanChangeset: failed to unmarshal sync state: %s", syncState)
 	}
+	var rules btypes.ChangesetCheckRules
+	if err = json.Unmarshal(checkRules, &rules); err != nil {
+		return errors.Wrapf(err, "scanChangeset: failed to unmarshal check rules: %s", checkRules)
+	}
+	if len(rules) > 0 {
+		t.CheckRules = rules
+	}
 
 	return nil
 }
@@ -2,11 +2,15 @@ package store
 
 import (
 	"context"
+	"net/url"
 
 	"github.com/keegancsmith/sqlf"
 
 	btypes "github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/schema"
 )
 
 type ListCodeHostsOpts struct {
@@ -25,8 +29,68 @@ func (s *Store) ListCodeHosts(ctx context.Context, opts ListCodeHostsOpts) ([]*b
 		cs = append(cs, &c)
 		return nil
 	})
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks, err := s.listCodeHostsWithWebhooks(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, c := range cs {
+		c.HasWebhooks = webhooks[c.ExternalServiceID]
+	}
+
+	return cs, nil
+}
 
-	return cs, err
+// listCodeHostsWithWebhooks returns the set of external service IDs (the
+// normalized code host URLs, as in the repo table) of the code hosts for which
+// an external service configures webhooks.
+func (s *Store) listCodeHostsWithWebhooks(ctx context.Context) (map[string]bool, error) {
+	kinds := make([]string, 0, len(btypes.SupportedExternalServices))
+	for extSvcType := range btypes.SupportedExternalServices {
+		kinds = append(kinds, extsvc.TypeToKind(extSvcType))
+	}
+	es, err := s.ExternalServices().List(ctx, database.ExternalServicesListOptions{Kinds: kinds})
+	if err != nil {
+		return nil, err
+	}
+
+	webhooks := make(map[string]bool)
+	for _, e := range es {
+		c, err := e.Configuration()
+		if err != nil {
+			// An invalid configuration doesn't configure webhooks.
+			continue
+		}
+
+		var rawURL string
+		switch c := c.(type) {
+		case *schema.GitHubConnection:
+			if len(c.Webhooks) > 0 {
+				rawURL = c.Url
+			}
+		case *schema.GitLabConnection:
+			if len(c.Webhooks) > 0 {
+				rawURL = c.Url
+			}
+		case *schema.BitbucketServerConnection:
+			if c.WebhookSecret() != "" {
+				rawURL = c.Url
+			}
+		}
+		if rawURL == "" {
+			continue
+		}
+
+		u, err := url.Parse(rawURL)
+		if err != nil {
+			continue
+		}
+		webhooks[extsvc.NormalizeBaseURL(u).String()] = true
+	}
+	return webhooks, nil
 }
 
 var listCodeHostsQueryFmtstr = `
@@ -80,6 +80,42 @@ func testStoreCodeHost(t *testing.T, ctx context.Context, s *Store, clock ct.Clo
 			}
 
 		})
+		t.Run("With webhooks", func(t *testing.T) {
+			svc := &types.ExternalService{
+				Kind:        extsvc.KindGitLab,
+				DisplayName: "GitLab with webhooks",
+				Config:      `{"url": "https://gitlab.com", "token": "abc", "projectQuery": ["none"], "webhooks": [{"secret": "secret"}]}`,
+				CreatedAt:   clock.Now(),
+				UpdatedAt:   clock.Now(),
+			}
+			if err := es.Upsert(ctx, svc); err != nil {
+				t.Fatal(err)
+			}
+			defer func() {
+				if err := es.Delete(ctx, svc.ID); err != nil {
+					t.Fatal(err)
+				}
+			}()
+
+			have, err := s.ListCodeHosts(ctx, ListCodeHostsOpts{RepoIDs: []api.RepoID{repo.ID, gitlabRepo.ID}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			want := []*btypes.CodeHost{
+				{
+					ExternalServiceType: extsvc.TypeGitHub,
+					ExternalServiceID:   "https://github.com/",
+				},
+				{
+					ExternalServiceType: extsvc.TypeGitLab,
+					ExternalServiceID:   "https://gitlab.com/",
+					HasWebhooks:         true,
+				},
+			}
+			if diff := cmp.Diff(have, want); diff != "" {
+				t.Fatalf("Invalid code hosts returned. %s", diff)
+			}
+		})
 	})
 
 	t.Run("GetExternalServiceIDs", func(t *testing.T) {
@@ -0,0 +1,3 @@
+package store
+
+//go:generate go run ../../../../dev/observedgen -p github.com/sourcegraph/sourcegraph/enterprise/internal/batches/store -t Store -n batches.store -o observed_store.go
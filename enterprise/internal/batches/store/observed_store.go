@@ -0,0 +1,774 @@
+// Code generated by observedgen; DO NOT EDIT.
+
+package store
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/sourcegraph/go-diff/diff"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/batches/types"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/auth"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// ObservedStore is an observed decorator of Store.
+// Its methods record a duration histogram, an invocation counter and an
+// error counter, labeled by method name.
+type ObservedStore struct {
+	inner      *Store
+	operations *ObservedStoreOperations
+}
+
+// NewObservedStore wraps the given store with the given operations. The operations
+// should be created once and shared by all decorators.
+func NewObservedStore(inner *Store, operations *ObservedStoreOperations) *ObservedStore {
+	return &ObservedStore{inner: inner, operations: operations}
+}
+
+// ObservedStoreOperations holds the operations of the observed methods of Store.
+type ObservedStoreOperations struct {
+	batchSpecExecutionPlaceInQueue    *observation.Operation
+	cancelQueuedBatchChangeChangesets *observation.Operation
+	countBatchChangeTemplates         *observation.Operation
+	countBatchChanges                 *observation.Operation
+	countBatchSpecs                   *observation.Operation
+	countBulkOperations               *observation.Operation
+	countChangesetEvents              *observation.Operation
+	countChangesetSpecs               *observation.Operation
+	countChangesets                   *observation.Operation
+	createBatchChange                 *observation.Operation
+	createBatchChangeTemplate         *observation.Operation
+	createBatchSpec                   *observation.Operation
+	createBatchSpecExecution          *observation.Operation
+	createChangeset                   *observation.Operation
+	createChangesetJob                *observation.Operation
+	createChangesetSpec               *observation.Operation
+	createSiteCredential              *observation.Operation
+	deleteBatchChange                 *observation.Operation
+	deleteBatchChangeTemplate         *observation.Operation
+	deleteBatchSpec                   *observation.Operation
+	deleteChangeset                   *observation.Operation
+	deleteChangesetSpec               *observation.Operation
+	deleteExpiredBatchSpecs           *observation.Operation
+	deleteExpiredChangesetSpecs       *observation.Operation
+	deleteSiteCredential              *observation.Operation
+	enqueueChangeset                  *observation.Operation
+	enqueueChangesetsToClose          *observation.Operation
+	enqueueNextScheduledChangeset     *observation.Operation
+	exec                              *observation.Operation
+	execResult                        *observation.Operation
+	getBatchChange                    *observation.Operation
+	getBatchChangeDiffStat            *observation.Operation
+	getBatchChangeTemplate            *observation.Operation
+	getBatchSpec                      *observation.Operation
+	getBatchSpecExecution             *observation.Operation
+	getBulkOperation                  *observation.Operation
+	getChangeset                      *observation.Operation
+	getChangesetByID                  *observation.Operation
+	getChangesetEvent                 *observation.Operation
+	getChangesetExternalIDs           *observation.Operation
+	getChangesetJob                   *observation.Operation
+	getChangesetPlaceInSchedulerQueue *observation.Operation
+	getChangesetSpec                  *observation.Operation
+	getChangesetSpecByID              *observation.Operation
+	getChangesetsStats                *observation.Operation
+	getExternalServiceIDs             *observation.Operation
+	getNewestBatchSpec                *observation.Operation
+	getRepoChangesetsStats            *observation.Operation
+	getRepoDiffStat                   *observation.Operation
+	getRewirerMappings                *observation.Operation
+	getSiteCredential                 *observation.Operation
+	listBatchChangeTemplates          *observation.Operation
+	listBatchChanges                  *observation.Operation
+	listBatchSpecs                    *observation.Operation
+	listBulkOperationErrors           *observation.Operation
+	listBulkOperations                *observation.Operation
+	listChangesetEvents               *observation.Operation
+	listChangesetSpecs                *observation.Operation
+	listChangesetSyncData             *observation.Operation
+	listChangesets                    *observation.Operation
+	listCodeHosts                     *observation.Operation
+	listSiteCredentials               *observation.Operation
+	query                             *observation.Operation
+	setBatchSpecExecutionAccessToken  *observation.Operation
+	transact                          *observation.Operation
+	updateBatchChange                 *observation.Operation
+	updateBatchChangeTemplate         *observation.Operation
+	updateBatchSpec                   *observation.Operation
+	updateChangeset                   *observation.Operation
+	updateChangesetCodeHostState      *observation.Operation
+	updateChangesetSpec               *observation.Operation
+	updateSiteCredential              *observation.Operation
+	upsertChangeset                   *observation.Operation
+	upsertChangesetEvents             *observation.Operation
+}
+
+// NewObservedStoreOperations creates the operations of ObservedStore and registers their metrics.
+func NewObservedStoreOperations(observationContext *observation.Context) *ObservedStoreOperations {
+	metrics := metrics.NewOperationMetrics(
+		observationContext.Registerer,
+		"batches_store",
+		metrics.WithLabels("op"),
+		metrics.WithCountHelp("Total number of method invocations."),
+	)
+
+	op := func(name string) *observation.Operation {
+		return observationContext.Operation(observation.Op{
+			Name:         fmt.Sprintf("batches.store.%s", name),
+			MetricLabels: []string{name},
+			Metrics:      metrics,
+		})
+	}
+
+	return &ObservedStoreOperations{
+		batchSpecExecutionPlaceInQueue:    op("BatchSpecExecutionPlaceInQueue"),
+		cancelQueuedBatchChangeChangesets: op("CancelQueuedBatchChangeChangesets"),
+		countBatchChangeTemplates:         op("CountBatchChangeTemplates"),
+		countBatchChanges:                 op("CountBatchChanges"),
+		countBatchSpecs:                   op("CountBatchSpecs"),
+		countBulkOperations:               op("CountBulkOperations"),
+		countChangesetEvents:              op("CountChangesetEvents"),
+		countChangesetSpecs:               op("CountChangesetSpecs"),
+		countChangesets:                   op("CountChangesets"),
+		createBatchChange:                 op("CreateBatchChange"),
+		createBatchChangeTemplate:         op("CreateBatchChangeTemplate"),
+		createBatchSpec:                   op("CreateBatchSpec"),
+		createBatchSpecExecution:          op("CreateBatchSpecExecution"),
+		createChangeset:                   op("CreateChangeset"),
+		createChangesetJob:                op("CreateChangesetJob"),
+		createChangesetSpec:               op("CreateChangesetSpec"),
+		createSiteCredential:              op("CreateSiteCredential"),
+		deleteBatchChange:                 op("DeleteBatchChange"),
+		deleteBatchChangeTemplate:         op("DeleteBatchChangeTemplate"),
+		deleteBatchSpec:                   op("DeleteBatchSpec"),
+		deleteChangeset:                   op("DeleteChangeset"),
+		deleteChangesetSpec:               op("DeleteChangesetSpec"),
+		deleteExpiredBatchSpecs:           op("DeleteExpiredBatchSpecs"),
+		deleteExpiredChangesetSpecs:       op("DeleteExpiredChangesetSpecs"),
+		deleteSiteCredential:              op("DeleteSiteCredential"),
+		enqueueChangeset:                  op("EnqueueChangeset"),
+		enqueueChangesetsToClose:          op("EnqueueChangesetsToClose"),
+		enqueueNextScheduledChangeset:     op("EnqueueNextScheduledChangeset"),
+		exec:                              op("Exec"),
+		execResult:                        op("ExecResult"),
+		getBatchChange:                    op("GetBatchChange"),
+		getBatchChangeDiffStat:            op("GetBatchChangeDiffStat"),
+		getBatchChangeTemplate:            op("GetBatchChangeTemplate"),
+		getBatchSpec:                      op("GetBatchSpec"),
+		getBatchSpecExecution:             op("GetBatchSpecExecution"),
+		getBulkOperation:                  op("GetBulkOperation"),
+		getChangeset:                      op("GetChangeset"),
+		getChangesetByID:                  op("GetChangesetByID"),
+		getChangesetEvent:                 op("GetChangesetEvent"),
+		getChangesetExternalIDs:           op("GetChangesetExternalIDs"),
+		getChangesetJob:                   op("GetChangesetJob"),
+		getChangesetPlaceInSchedulerQueue: op("GetChangesetPlaceInSchedulerQueue"),
+		getChangesetSpec:                  op("GetChangesetSpec"),
+		getChangesetSpecByID:              op("GetChangesetSpecByID"),
+		getChangesetsStats:                op("GetChangesetsStats"),
+		getExternalServiceIDs:             op("GetExternalServiceIDs"),
+		getNewestBatchSpec:                op("GetNewestBatchSpec"),
+		getRepoChangesetsStats:            op("GetRepoChangesetsStats"),
+		getRepoDiffStat:                   op("GetRepoDiffStat"),
+		getRewirerMappings:                op("GetRewirerMappings"),
+		getSiteCredential:                 op("GetSiteCredential"),
+		listBatchChangeTemplates:          op("ListBatchChangeTemplates"),
+		listBatchChanges:                  op("ListBatchChanges"),
+		listBatchSpecs:                    op("ListBatchSpecs"),
+		listBulkOperationErrors:           op("ListBulkOperationErrors"),
+		listBulkOperations:                op("ListBulkOperations"),
+		listChangesetEvents:               op("ListChangesetEvents"),
+		listChangesetSpecs:                op("ListChangesetSpecs"),
+		listChangesetSyncData:             op("ListChangesetSyncData"),
+		listChangesets:                    op("ListChangesets"),
+		listCodeHosts:                     op("ListCodeHosts"),
+		listSiteCredentials:               op("ListSiteCredentials"),
+		query:                             op("Query"),
+		setBatchSpecExecutionAccessToken:  op("SetBatchSpecExecutionAccessToken"),
+		transact:                          op("Transact"),
+		updateBatchChange:                 op("UpdateBatchChange"),
+		updateBatchChangeTemplate:         op("UpdateBatchChangeTemplate"),
+		updateBatchSpec:                   op("UpdateBatchSpec"),
+		updateChangeset:                   op("UpdateChangeset"),
+		updateChangesetCodeHostState:      op("UpdateChangesetCodeHostState"),
+		updateChangesetSpec:               op("UpdateChangesetSpec"),
+		updateSiteCredential:              op("UpdateSiteCredential"),
+		upsertChangeset:                   op("UpsertChangeset"),
+		upsertChangesetEvents:             op("UpsertChangesetEvents"),
+	}
+}
+
+func (s *ObservedStore) AfterCommit(f func()) {
+	s.inner.AfterCommit(f)
+}
+
+func (s *ObservedStore) BatchSpecExecutionPlaceInQueue(ctx context.Context, id int64) (r0 int, r1 bool, err error) {
+	ctx, endObservation := s.operations.batchSpecExecutionPlaceInQueue.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.BatchSpecExecutionPlaceInQueue(ctx, id)
+}
+
+func (s *ObservedStore) CancelQueuedBatchChangeChangesets(ctx context.Context, batchChangeID int64) (err error) {
+	ctx, endObservation := s.operations.cancelQueuedBatchChangeChangesets.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.CancelQueuedBatchChangeChangesets(ctx, batchChangeID)
+}
+
+func (s *ObservedStore) Clock() func() time.Time {
+	return s.inner.Clock()
+}
+
+func (s *ObservedStore) CountBatchChangeTemplates(ctx context.Context, opts CountBatchChangeTemplatesOpts) (r0 int, err error) {
+	ctx, endObservation := s.operations.countBatchChangeTemplates.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.CountBatchChangeTemplates(ctx, opts)
+}
+
+func (s *ObservedStore) CountBatchChanges(ctx context.Context, opts CountBatchChangesOpts) (r0 int, err error) {
+	ctx, endObservation := s.operations.countBatchChanges.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.CountBatchChanges(ctx, opts)
+}
+
+func (s *ObservedStore) CountBatchSpecs(ctx context.Context) (r0 int, err error) {
+	ctx, endObservation := s.operations.countBatchSpecs.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.CountBatchSpecs(ctx)
+}
+
+func (s *ObservedStore) CountBulkOperations(ctx context.Context, opts CountBulkOperationsOpts) (r0 int, err error) {
+	ctx, endObservation := s.operations.countBulkOperations.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.CountBulkOperations(ctx, opts)
+}
+
+func (s *ObservedStore) CountChangesetEvents(ctx context.Context, opts CountChangesetEventsOpts) (r0 int, err error) {
+	ctx, endObservation := s.operations.countChangesetEvents.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.CountChangesetEvents(ctx, opts)
+}
+
+func (s *ObservedStore) CountChangesetSpecs(ctx context.Context, opts CountChangesetSpecsOpts) (r0 int, err error) {
+	ctx, endObservation := s.operations.countChangesetSpecs.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.CountChangesetSpecs(ctx, opts)
+}
+
+func (s *ObservedStore) CountChangesets(ctx context.Context, opts CountChangesetsOpts) (r0 int, err error) {
+	ctx, endObservation := s.operations.countChangesets.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.CountChangesets(ctx, opts)
+}
+
+func (s *ObservedStore) CreateBatchChange(ctx context.Context, c *types.BatchChange) (err error) {
+	ctx, endObservation := s.operations.createBatchChange.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.CreateBatchChange(ctx, c)
+}
+
+func (s *ObservedStore) CreateBatchChangeTemplate(ctx context.Context, t *types.BatchChangeTemplate) (err error) {
+	ctx, endObservation := s.operations.createBatchChangeTemplate.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.CreateBatchChangeTemplate(ctx, t)
+}
+
+func (s *ObservedStore) CreateBatchSpec(ctx context.Context, c *types.BatchSpec) (err error) {
+	ctx, endObservation := s.operations.createBatchSpec.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.CreateBatchSpec(ctx, c)
+}
+
+func (s *ObservedStore) CreateBatchSpecExecution(ctx context.Context, b *types.BatchSpecExecution) (err error) {
+	ctx, endObservation := s.operations.createBatchSpecExecution.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.CreateBatchSpecExecution(ctx, b)
+}
+
+func (s *ObservedStore) CreateChangeset(ctx context.Context, c *types.Changeset) (err error) {
+	ctx, endObservation := s.operations.createChangeset.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.CreateChangeset(ctx, c)
+}
+
+func (s *ObservedStore) CreateChangesetJob(ctx context.Context, cs ...*types.ChangesetJob) (err error) {
+	ctx, endObservation := s.operations.createChangesetJob.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.CreateChangesetJob(ctx, cs...)
+}
+
+func (s *ObservedStore) CreateChangesetSpec(ctx context.Context, c *types.ChangesetSpec) (err error) {
+	ctx, endObservation := s.operations.createChangesetSpec.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.CreateChangesetSpec(ctx, c)
+}
+
+func (s *ObservedStore) CreateSiteCredential(ctx context.Context, c *types.SiteCredential, credential auth.Authenticator) (err error) {
+	ctx, endObservation := s.operations.createSiteCredential.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.CreateSiteCredential(ctx, c, credential)
+}
+
+func (s *ObservedStore) DB() dbutil.DB {
+	return s.inner.DB()
+}
+
+func (s *ObservedStore) DeleteBatchChange(ctx context.Context, id int64) (err error) {
+	ctx, endObservation := s.operations.deleteBatchChange.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.DeleteBatchChange(ctx, id)
+}
+
+func (s *ObservedStore) DeleteBatchChangeTemplate(ctx context.Context, id int64) (err error) {
+	ctx, endObservation := s.operations.deleteBatchChangeTemplate.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.DeleteBatchChangeTemplate(ctx, id)
+}
+
+func (s *ObservedStore) DeleteBatchSpec(ctx context.Context, id int64) (err error) {
+	ctx, endObservation := s.operations.deleteBatchSpec.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.DeleteBatchSpec(ctx, id)
+}
+
+func (s *ObservedStore) DeleteChangeset(ctx context.Context, id int64) (err error) {
+	ctx, endObservation := s.operations.deleteChangeset.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.DeleteChangeset(ctx, id)
+}
+
+func (s *ObservedStore) DeleteChangesetSpec(ctx context.Context, id int64) (err error) {
+	ctx, endObservation := s.operations.deleteChangesetSpec.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.DeleteChangesetSpec(ctx, id)
+}
+
+func (s *ObservedStore) DeleteExpiredBatchSpecs(ctx context.Context) (err error) {
+	ctx, endObservation := s.operations.deleteExpiredBatchSpecs.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.DeleteExpiredBatchSpecs(ctx)
+}
+
+func (s *ObservedStore) DeleteExpiredChangesetSpecs(ctx context.Context) (err error) {
+	ctx, endObservation := s.operations.deleteExpiredChangesetSpecs.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.DeleteExpiredChangesetSpecs(ctx)
+}
+
+func (s *ObservedStore) DeleteSiteCredential(ctx context.Context, id int64) (err error) {
+	ctx, endObservation := s.operations.deleteSiteCredential.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.DeleteSiteCredential(ctx, id)
+}
+
+func (s *ObservedStore) Done(p0 error) error {
+	return s.inner.Done(p0)
+}
+
+func (s *ObservedStore) EnqueueChangeset(ctx context.Context, cs *types.Changeset, resetState types.ReconcilerState, currentState types.ReconcilerState) (err error) {
+	ctx, endObservation := s.operations.enqueueChangeset.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.EnqueueChangeset(ctx, cs, resetState, currentState)
+}
+
+func (s *ObservedStore) EnqueueChangesetsToClose(ctx context.Context, batchChangeID int64) (err error) {
+	ctx, endObservation := s.operations.enqueueChangesetsToClose.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.EnqueueChangesetsToClose(ctx, batchChangeID)
+}
+
+func (s *ObservedStore) EnqueueNextScheduledChangeset(ctx context.Context) (r0 *types.Changeset, err error) {
+	ctx, endObservation := s.operations.enqueueNextScheduledChangeset.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.EnqueueNextScheduledChangeset(ctx)
+}
+
+func (s *ObservedStore) Exec(ctx context.Context, query *sqlf.Query) (err error) {
+	ctx, endObservation := s.operations.exec.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.Exec(ctx, query)
+}
+
+func (s *ObservedStore) ExecResult(ctx context.Context, query *sqlf.Query) (r0 sql.Result, err error) {
+	ctx, endObservation := s.operations.execResult.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.ExecResult(ctx, query)
+}
+
+func (s *ObservedStore) ExternalServices() *database.ExternalServiceStore {
+	return s.inner.ExternalServices()
+}
+
+func (s *ObservedStore) GetBatchChange(ctx context.Context, opts GetBatchChangeOpts) (r0 *types.BatchChange, err error) {
+	ctx, endObservation := s.operations.getBatchChange.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetBatchChange(ctx, opts)
+}
+
+func (s *ObservedStore) GetBatchChangeDiffStat(ctx context.Context, opts GetBatchChangeDiffStatOpts) (r0 *diff.Stat, err error) {
+	ctx, endObservation := s.operations.getBatchChangeDiffStat.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetBatchChangeDiffStat(ctx, opts)
+}
+
+func (s *ObservedStore) GetBatchChangeTemplate(ctx context.Context, opts GetBatchChangeTemplateOpts) (r0 *types.BatchChangeTemplate, err error) {
+	ctx, endObservation := s.operations.getBatchChangeTemplate.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetBatchChangeTemplate(ctx, opts)
+}
+
+func (s *ObservedStore) GetBatchSpec(ctx context.Context, opts GetBatchSpecOpts) (r0 *types.BatchSpec, err error) {
+	ctx, endObservation := s.operations.getBatchSpec.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetBatchSpec(ctx, opts)
+}
+
+func (s *ObservedStore) GetBatchSpecExecution(ctx context.Context, opts GetBatchSpecExecutionOpts) (r0 *types.BatchSpecExecution, err error) {
+	ctx, endObservation := s.operations.getBatchSpecExecution.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetBatchSpecExecution(ctx, opts)
+}
+
+func (s *ObservedStore) GetBulkOperation(ctx context.Context, opts GetBulkOperationOpts) (r0 *types.BulkOperation, err error) {
+	ctx, endObservation := s.operations.getBulkOperation.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetBulkOperation(ctx, opts)
+}
+
+func (s *ObservedStore) GetChangeset(ctx context.Context, opts GetChangesetOpts) (r0 *types.Changeset, err error) {
+	ctx, endObservation := s.operations.getChangeset.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetChangeset(ctx, opts)
+}
+
+func (s *ObservedStore) GetChangesetByID(ctx context.Context, id int64) (r0 *types.Changeset, err error) {
+	ctx, endObservation := s.operations.getChangesetByID.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetChangesetByID(ctx, id)
+}
+
+func (s *ObservedStore) GetChangesetEvent(ctx context.Context, opts GetChangesetEventOpts) (r0 *types.ChangesetEvent, err error) {
+	ctx, endObservation := s.operations.getChangesetEvent.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetChangesetEvent(ctx, opts)
+}
+
+func (s *ObservedStore) GetChangesetExternalIDs(ctx context.Context, spec api.ExternalRepoSpec, refs []string) (r0 []string, err error) {
+	ctx, endObservation := s.operations.getChangesetExternalIDs.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetChangesetExternalIDs(ctx, spec, refs)
+}
+
+func (s *ObservedStore) GetChangesetJob(ctx context.Context, opts GetChangesetJobOpts) (r0 *types.ChangesetJob, err error) {
+	ctx, endObservation := s.operations.getChangesetJob.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetChangesetJob(ctx, opts)
+}
+
+func (s *ObservedStore) GetChangesetPlaceInSchedulerQueue(ctx context.Context, id int64) (r0 int, err error) {
+	ctx, endObservation := s.operations.getChangesetPlaceInSchedulerQueue.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetChangesetPlaceInSchedulerQueue(ctx, id)
+}
+
+func (s *ObservedStore) GetChangesetSpec(ctx context.Context, opts GetChangesetSpecOpts) (r0 *types.ChangesetSpec, err error) {
+	ctx, endObservation := s.operations.getChangesetSpec.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetChangesetSpec(ctx, opts)
+}
+
+func (s *ObservedStore) GetChangesetSpecByID(ctx context.Context, id int64) (r0 *types.ChangesetSpec, err error) {
+	ctx, endObservation := s.operations.getChangesetSpecByID.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetChangesetSpecByID(ctx, id)
+}
+
+func (s *ObservedStore) GetChangesetsStats(ctx context.Context, batchChangeID int64) (r0 types.ChangesetsStats, err error) {
+	ctx, endObservation := s.operations.getChangesetsStats.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetChangesetsStats(ctx, batchChangeID)
+}
+
+func (s *ObservedStore) GetExternalServiceIDs(ctx context.Context, opts GetExternalServiceIDsOpts) (r0 []int64, err error) {
+	ctx, endObservation := s.operations.getExternalServiceIDs.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetExternalServiceIDs(ctx, opts)
+}
+
+func (s *ObservedStore) GetNewestBatchSpec(ctx context.Context, opts GetNewestBatchSpecOpts) (r0 *types.BatchSpec, err error) {
+	ctx, endObservation := s.operations.getNewestBatchSpec.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetNewestBatchSpec(ctx, opts)
+}
+
+func (s *ObservedStore) GetRepoChangesetsStats(ctx context.Context, repoID api.RepoID) (r0 *types.RepoChangesetsStats, err error) {
+	ctx, endObservation := s.operations.getRepoChangesetsStats.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetRepoChangesetsStats(ctx, repoID)
+}
+
+func (s *ObservedStore) GetRepoDiffStat(ctx context.Context, repoID api.RepoID) (r0 *diff.Stat, err error) {
+	ctx, endObservation := s.operations.getRepoDiffStat.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetRepoDiffStat(ctx, repoID)
+}
+
+func (s *ObservedStore) GetRewirerMappings(ctx context.Context, opts GetRewirerMappingsOpts) (r0 types.RewirerMappings, err error) {
+	ctx, endObservation := s.operations.getRewirerMappings.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetRewirerMappings(ctx, opts)
+}
+
+func (s *ObservedStore) GetSiteCredential(ctx context.Context, opts GetSiteCredentialOpts) (r0 *types.SiteCredential, err error) {
+	ctx, endObservation := s.operations.getSiteCredential.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.GetSiteCredential(ctx, opts)
+}
+
+func (s *ObservedStore) Handle() *basestore.TransactableHandle {
+	return s.inner.Handle()
+}
+
+func (s *ObservedStore) InTransaction() bool {
+	return s.inner.InTransaction()
+}
+
+func (s *ObservedStore) ListBatchChangeTemplates(ctx context.Context, opts ListBatchChangeTemplatesOpts) (r0 []*types.BatchChangeTemplate, r1 int64, err error) {
+	ctx, endObservation := s.operations.listBatchChangeTemplates.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.ListBatchChangeTemplates(ctx, opts)
+}
+
+func (s *ObservedStore) ListBatchChanges(ctx context.Context, opts ListBatchChangesOpts) (r0 []*types.BatchChange, r1 int64, err error) {
+	ctx, endObservation := s.operations.listBatchChanges.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.ListBatchChanges(ctx, opts)
+}
+
+func (s *ObservedStore) ListBatchSpecs(ctx context.Context, opts ListBatchSpecsOpts) (r0 []*types.BatchSpec, r1 int64, err error) {
+	ctx, endObservation := s.operations.listBatchSpecs.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.ListBatchSpecs(ctx, opts)
+}
+
+func (s *ObservedStore) ListBulkOperationErrors(ctx context.Context, opts ListBulkOperationErrorsOpts) (r0 []*types.BulkOperationError, err error) {
+	ctx, endObservation := s.operations.listBulkOperationErrors.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.ListBulkOperationErrors(ctx, opts)
+}
+
+func (s *ObservedStore) ListBulkOperations(ctx context.Context, opts ListBulkOperationsOpts) (r0 []*types.BulkOperation, r1 int64, err error) {
+	ctx, endObservation := s.operations.listBulkOperations.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.ListBulkOperations(ctx, opts)
+}
+
+func (s *ObservedStore) ListChangesetEvents(ctx context.Context, opts ListChangesetEventsOpts) (r0 []*types.ChangesetEvent, r1 int64, err error) {
+	ctx, endObservation := s.operations.listChangesetEvents.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.ListChangesetEvents(ctx, opts)
+}
+
+func (s *ObservedStore) ListChangesetSpecs(ctx context.Context, opts ListChangesetSpecsOpts) (r0 types.ChangesetSpecs, r1 int64, err error) {
+	ctx, endObservation := s.operations.listChangesetSpecs.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.ListChangesetSpecs(ctx, opts)
+}
+
+func (s *ObservedStore) ListChangesetSyncData(ctx context.Context, opts ListChangesetSyncDataOpts) (r0 []*types.ChangesetSyncData, err error) {
+	ctx, endObservation := s.operations.listChangesetSyncData.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.ListChangesetSyncData(ctx, opts)
+}
+
+func (s *ObservedStore) ListChangesets(ctx context.Context, opts ListChangesetsOpts) (r0 types.Changesets, r1 int64, err error) {
+	ctx, endObservation := s.operations.listChangesets.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.ListChangesets(ctx, opts)
+}
+
+func (s *ObservedStore) ListCodeHosts(ctx context.Context, opts ListCodeHostsOpts) (r0 []*types.CodeHost, err error) {
+	ctx, endObservation := s.operations.listCodeHosts.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.ListCodeHosts(ctx, opts)
+}
+
+func (s *ObservedStore) ListSiteCredentials(ctx context.Context, opts ListSiteCredentialsOpts) (r0 []*types.SiteCredential, r1 int64, err error) {
+	ctx, endObservation := s.operations.listSiteCredentials.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.ListSiteCredentials(ctx, opts)
+}
+
+func (s *ObservedStore) Query(ctx context.Context, query *sqlf.Query) (r0 *sql.Rows, err error) {
+	ctx, endObservation := s.operations.query.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.Query(ctx, query)
+}
+
+func (s *ObservedStore) QueryRow(ctx context.Context, query *sqlf.Query) *sql.Row {
+	return s.inner.QueryRow(ctx, query)
+}
+
+func (s *ObservedStore) Repos() *database.RepoStore {
+	return s.inner.Repos()
+}
+
+func (s *ObservedStore) SetBatchSpecExecutionAccessToken(ctx context.Context, id int64, tokenID int64) (err error) {
+	ctx, endObservation := s.operations.setBatchSpecExecutionAccessToken.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.SetBatchSpecExecutionAccessToken(ctx, id, tokenID)
+}
+
+func (s *ObservedStore) Transact(ctx context.Context) (r0 *ObservedStore, err error) {
+	ctx, endObservation := s.operations.transact.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	inner, err := s.inner.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return NewObservedStore(inner, s.operations), nil
+}
+
+func (s *ObservedStore) UpdateBatchChange(ctx context.Context, c *types.BatchChange) (err error) {
+	ctx, endObservation := s.operations.updateBatchChange.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.UpdateBatchChange(ctx, c)
+}
+
+func (s *ObservedStore) UpdateBatchChangeTemplate(ctx context.Context, t *types.BatchChangeTemplate) (err error) {
+	ctx, endObservation := s.operations.updateBatchChangeTemplate.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.UpdateBatchChangeTemplate(ctx, t)
+}
+
+func (s *ObservedStore) UpdateBatchSpec(ctx context.Context, c *types.BatchSpec) (err error) {
+	ctx, endObservation := s.operations.updateBatchSpec.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.UpdateBatchSpec(ctx, c)
+}
+
+func (s *ObservedStore) UpdateChangeset(ctx context.Context, cs *types.Changeset) (err error) {
+	ctx, endObservation := s.operations.updateChangeset.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.UpdateChangeset(ctx, cs)
+}
+
+func (s *ObservedStore) UpdateChangesetCodeHostState(ctx context.Context, cs *types.Changeset) (err error) {
+	ctx, endObservation := s.operations.updateChangesetCodeHostState.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.UpdateChangesetCodeHostState(ctx, cs)
+}
+
+func (s *ObservedStore) UpdateChangesetSpec(ctx context.Context, c *types.ChangesetSpec) (err error) {
+	ctx, endObservation := s.operations.updateChangesetSpec.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.UpdateChangesetSpec(ctx, c)
+}
+
+func (s *ObservedStore) UpdateSiteCredential(ctx context.Context, c *types.SiteCredential) (err error) {
+	ctx, endObservation := s.operations.updateSiteCredential.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.UpdateSiteCredential(ctx, c)
+}
+
+func (s *ObservedStore) UpsertChangeset(ctx context.Context, c *types.Changeset) (err error) {
+	ctx, endObservation := s.operations.upsertChangeset.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.UpsertChangeset(ctx, c)
+}
+
+func (s *ObservedStore) UpsertChangesetEvents(ctx context.Context, cs ...*types.ChangesetEvent) (err error) {
+	ctx, endObservation := s.operations.upsertChangesetEvents.With(ctx, &err, observation.Args{})
+	defer endObservation(1, observation.Args{})
+
+	return s.inner.UpsertChangesetEvents(ctx, cs...)
+}
+
+func (s *ObservedStore) UserCredentials() *database.UserCredentialsStore {
+	return s.inner.UserCredentials()
+}
+
+func (s *ObservedStore) With(other basestore.ShareableStore) *ObservedStore {
+	return NewObservedStore(s.inner.With(other), s.operations)
+}
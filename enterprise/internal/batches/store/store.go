@@ -17,6 +17,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 	"github.com/sourcegraph/sourcegraph/internal/encryption"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/internal/timeutil"
 )
 
@@ -67,6 +68,12 @@ func NewWithClock(db dbutil.DB, key encryption.Key, clock func() time.Time) *Sto
 	}
 }
 
+// NewObservedForTest wraps the given Store in an ObservedStore whose operations
+// don't record any metrics, for tests of code that takes an ObservedStore.
+func NewObservedForTest(s *Store) *ObservedStore {
+	return NewObservedStore(s, NewObservedStoreOperations(&observation.TestContext))
+}
+
 // Clock returns the clock used by the Store.
 func (s *Store) Clock() func() time.Time { return s.now }
 
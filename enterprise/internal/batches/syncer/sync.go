@@ -9,12 +9,23 @@ import (
 var (
 	minSyncDelay = 2 * time.Minute
 	maxSyncDelay = 8 * time.Hour
+
+	// When webhooks are configured for the code host, changes are applied as the
+	// webhooks arrive and syncing is only a fallback that reconciles the changes
+	// of webhooks that were missed, so it happens much less often.
+	minWebhookSyncDelay = 1 * time.Hour
+	maxWebhookSyncDelay = 24 * time.Hour
 )
 
 // NextSync computes the time we want the next sync to happen.
 func NextSync(clock func() time.Time, h *btypes.ChangesetSyncData) time.Time {
 	lastSync := h.UpdatedAt
 
+	minDelay, maxDelay := minSyncDelay, maxSyncDelay
+	if h.HasWebhooks {
+		minDelay, maxDelay = minWebhookSyncDelay, maxWebhookSyncDelay
+	}
+
 	if lastSync.IsZero() {
 		// Edge case where we've never synced
 		return clock()
@@ -35,18 +46,18 @@ func NextSync(clock func() time.Time, h *btypes.ChangesetSyncData) time.Time {
 	diff := lastSync.Sub(lastChange)
 
 	// If the last change has happened AFTER our last sync this indicates a webhook
-	// has arrived. In this case, we should check again in minSyncDelay after
+	// has arrived. In this case, we should check again in minDelay after
 	// the hook arrived. If multiple webhooks arrive in close succession this will
-	// cause us to wait for a quiet period of at least minSyncDelay
+	// cause us to wait for a quiet period of at least minDelay
 	if diff < 0 {
-		return lastChange.Add(minSyncDelay)
+		return lastChange.Add(minDelay)
 	}
 
-	if diff > maxSyncDelay {
-		diff = maxSyncDelay
+	if diff > maxDelay {
+		diff = maxDelay
 	}
-	if diff < minSyncDelay {
-		diff = minSyncDelay
+	if diff < minDelay {
+		diff = minDelay
 	}
 	return lastSync.Add(diff)
 }
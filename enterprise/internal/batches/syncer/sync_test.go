@@ -73,6 +73,48 @@ func TestNextSync(t *testing.T) {
 			h:    &btypes.ChangesetSyncData{},
 			want: clock(),
 		},
+		{
+			name: "Webhooks linear backoff",
+			h: &btypes.ChangesetSyncData{
+				UpdatedAt:         clock(),
+				ExternalUpdatedAt: clock().Add(-2 * time.Hour),
+				HasWebhooks:       true,
+			},
+			want: clock().Add(2 * time.Hour),
+		},
+		{
+			name: "Webhooks diff max is capped",
+			h: &btypes.ChangesetSyncData{
+				UpdatedAt:         clock(),
+				ExternalUpdatedAt: clock().Add(-2 * maxWebhookSyncDelay),
+				HasWebhooks:       true,
+			},
+			want: clock().Add(maxWebhookSyncDelay),
+		},
+		{
+			name: "Webhooks diff min is capped",
+			h: &btypes.ChangesetSyncData{
+				UpdatedAt:         clock(),
+				ExternalUpdatedAt: clock().Add(-1 * minSyncDelay),
+				HasWebhooks:       true,
+			},
+			want: clock().Add(minWebhookSyncDelay),
+		},
+		{
+			name: "Webhooks event arrives after sync",
+			h: &btypes.ChangesetSyncData{
+				UpdatedAt:         clock(),
+				ExternalUpdatedAt: clock().Add(-1 * maxSyncDelay / 2),
+				LatestEvent:       clock().Add(10 * time.Minute),
+				HasWebhooks:       true,
+			},
+			want: clock().Add(10 * time.Minute).Add(minWebhookSyncDelay),
+		},
+		{
+			name: "Webhooks never synced",
+			h:    &btypes.ChangesetSyncData{HasWebhooks: true},
+			want: clock(),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -44,7 +44,7 @@ type SyncStore interface {
 	UpdateChangesetCodeHostState(ctx context.Context, cs *btypes.Changeset) error
 	UpsertChangesetEvents(ctx context.Context, cs ...*btypes.ChangesetEvent) error
 	GetSiteCredential(ctx context.Context, opts store.GetSiteCredentialOpts) (*btypes.SiteCredential, error)
-	Transact(context.Context) (*store.Store, error)
+	Transact(context.Context) (*store.ObservedStore, error)
 	Repos() *database.RepoStore
 	ExternalServices() *database.ExternalServiceStore
 	Clock() func() time.Time
@@ -341,7 +341,7 @@ type MockSyncStore struct {
 	upsertChangesetEvents        func(context.Context, ...*btypes.ChangesetEvent) error
 	getSiteCredential            func(ctx context.Context, opts store.GetSiteCredentialOpts) (*btypes.SiteCredential, error)
 	getExternalServiceIDs        func(ctx context.Context, opts store.GetExternalServiceIDsOpts) ([]int64, error)
-	transact                     func(context.Context) (*store.Store, error)
+	transact                     func(context.Context) (*store.ObservedStore, error)
 }
 
 func (m MockSyncStore) ListChangesetSyncData(ctx context.Context, opts store.ListChangesetSyncDataOpts) ([]*btypes.ChangesetSyncData, error) {
@@ -368,7 +368,7 @@ func (m MockSyncStore) GetExternalServiceIDs(ctx context.Context, opts store.Get
 	return m.getExternalServiceIDs(ctx, opts)
 }
 
-func (m MockSyncStore) Transact(ctx context.Context) (*store.Store, error) {
+func (m MockSyncStore) Transact(ctx context.Context) (*store.ObservedStore, error) {
 	return m.transact(ctx)
 }
 
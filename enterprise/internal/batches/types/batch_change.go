@@ -30,6 +30,10 @@ type BatchChange struct {
 
 	CreatedAt time.Time
 	UpdatedAt time.Time
+
+	// Version is incremented on every update and is used to detect
+	// concurrent updates.
+	Version int32
 }
 
 // Clone returns a clone of a BatchChange.
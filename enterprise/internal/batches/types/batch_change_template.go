@@ -0,0 +1,137 @@
+package types
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/hashicorp/go-multierror"
+)
+
+// BatchChangeTemplate is a batch spec with parameters that is offered to all
+// users of the instance. Users instantiate a template by providing values for
+// its parameters, which renders it into a batch spec in their namespace.
+//
+// Parameters are referenced in the spec as ${{ parameters.name }}. Other
+// ${{ }} expressions are left untouched, since they are evaluated when the
+// batch spec is executed.
+type BatchChangeTemplate struct {
+	ID          int64
+	Name        string
+	Description string
+	Spec        string
+	Parameters  []BatchChangeTemplateParameter
+
+	NamespaceUserID int32
+	NamespaceOrgID  int32
+
+	CreatorID int32
+
+	CreatedAt time.Time
+	UpdatedAt time.Time
+}
+
+// BatchChangeTemplateParameter is a parameter of a BatchChangeTemplate. A
+// parameter without a default value is required.
+type BatchChangeTemplateParameter struct {
+	Name        string  `json:"name"`
+	Description string  `json:"description,omitempty"`
+	Default     *string `json:"default,omitempty"`
+}
+
+var (
+	batchChangeTemplateParameterName = regexp.MustCompile(`^[A-Za-z_][A-Za-z0-9_]*$`)
+	batchChangeTemplateParameterRef  = regexp.MustCompile(`\$\{\{\s*parameters\.([A-Za-z0-9_]+)\s*\}\}`)
+)
+
+// Validate checks that the parameters of the template are well-formed and
+// that every parameter referenced in the spec is declared. It also checks
+// that the spec is a valid batch spec when rendered with placeholder values.
+func (t *BatchChangeTemplate) Validate() error {
+	var errs *multierror.Error
+
+	if t.Name == "" {
+		errs = multierror.Append(errs, errors.New("name must not be blank"))
+	}
+
+	declared := make(map[string]struct{}, len(t.Parameters))
+	for _, p := range t.Parameters {
+		if !batchChangeTemplateParameterName.MatchString(p.Name) {
+			errs = multierror.Append(errs, errors.Errorf("invalid parameter name %q", p.Name))
+		}
+		if _, ok := declared[p.Name]; ok {
+			errs = multierror.Append(errs, errors.Errorf("duplicate parameter %q", p.Name))
+		}
+		declared[p.Name] = struct{}{}
+	}
+
+	for _, name := range t.referencedParameters() {
+		if _, ok := declared[name]; !ok {
+			errs = multierror.Append(errs, errors.Errorf("undeclared parameter %q", name))
+		}
+	}
+
+	if errs.ErrorOrNil() != nil {
+		return errs
+	}
+
+	// Required parameters don't have a value to check the spec with, so we
+	// use their name instead.
+	placeholders := make(map[string]string, len(t.Parameters))
+	for _, p := range t.Parameters {
+		if p.Default == nil {
+			placeholders[p.Name] = p.Name
+		}
+	}
+	if _, err := t.Render(placeholders); err != nil {
+		return errors.Wrap(err, "invalid spec")
+	}
+	return nil
+}
+
+// Render substitutes the parameters of the template with the given values,
+// or their defaults, and returns the resulting batch spec. An error is
+// returned if a value is given for an unknown parameter, a required parameter
+// has no value or the rendered spec is not a valid batch spec.
+func (t *BatchChangeTemplate) Render(values map[string]string) (string, error) {
+	resolved := make(map[string]string, len(t.Parameters))
+	for _, p := range t.Parameters {
+		if v, ok := values[p.Name]; ok {
+			resolved[p.Name] = v
+		} else if p.Default != nil {
+			resolved[p.Name] = *p.Default
+		} else {
+			return "", errors.Errorf("missing value for required parameter %q", p.Name)
+		}
+	}
+	for name := range values {
+		if _, ok := resolved[name]; !ok {
+			return "", errors.Errorf("unknown parameter %q", name)
+		}
+	}
+
+	rendered := batchChangeTemplateParameterRef.ReplaceAllStringFunc(t.Spec, func(ref string) string {
+		name := batchChangeTemplateParameterRef.FindStringSubmatch(ref)[1]
+		if v, ok := resolved[name]; ok {
+			return v
+		}
+		return ref
+	})
+
+	if _, err := NewBatchSpecFromRaw(rendered); err != nil {
+		return "", err
+	}
+	return rendered, nil
+}
+
+func (t *BatchChangeTemplate) referencedParameters() []string {
+	var names []string
+	seen := map[string]struct{}{}
+	for _, m := range batchChangeTemplateParameterRef.FindAllStringSubmatch(t.Spec, -1) {
+		if _, ok := seen[m[1]]; !ok {
+			seen[m[1]] = struct{}{}
+			names = append(names, m[1])
+		}
+	}
+	return names
+}
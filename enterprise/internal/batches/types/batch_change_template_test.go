@@ -0,0 +1,136 @@
+package types
+
+import (
+	"strings"
+	"testing"
+)
+
+const testBatchChangeTemplateSpec = `name: bump-${{ parameters.image }}
+on:
+  - repositoriesMatchingQuery: file:Dockerfile FROM ${{ parameters.image }}
+steps:
+  - run: sed -i 's/FROM ${{ parameters.image }}:.*/FROM ${{ parameters.image }}:${{ parameters.tag }}/' Dockerfile
+    container: alpine:3
+changesetTemplate:
+  title: Bump ${{ parameters.image }} in ${{ repository.name }}
+  body: Bump the base image
+  branch: bump-base-image
+  commit:
+    message: Bump base image
+  published: false
+`
+
+func TestBatchChangeTemplateRender(t *testing.T) {
+	latest := "latest"
+	tmpl := &BatchChangeTemplate{
+		Name: "bump-base-image",
+		Spec: testBatchChangeTemplateSpec,
+		Parameters: []BatchChangeTemplateParameter{
+			{Name: "image"},
+			{Name: "tag", Default: &latest},
+		},
+	}
+
+	if err := tmpl.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("defaults", func(t *testing.T) {
+		have, err := tmpl.Render(map[string]string{"image": "alpine"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, want := range []string{
+			"name: bump-alpine\n",
+			"FROM alpine:latest/",
+			// Expressions evaluated at execution time are kept.
+			"Bump alpine in ${{ repository.name }}",
+		} {
+			if !strings.Contains(have, want) {
+				t.Errorf("rendered spec does not contain %q:\n%s", want, have)
+			}
+		}
+	})
+
+	t.Run("values", func(t *testing.T) {
+		have, err := tmpl.Render(map[string]string{"image": "alpine", "tag": "3.14"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !strings.Contains(have, "FROM alpine:3.14/") {
+			t.Errorf("value not substituted:\n%s", have)
+		}
+	})
+
+	for name, tc := range map[string]struct {
+		values map[string]string
+		err    string
+	}{
+		"missing required": {
+			values: map[string]string{"tag": "3.14"},
+			err:    `missing value for required parameter "image"`,
+		},
+		"unknown": {
+			values: map[string]string{"image": "alpine", "foo": "bar"},
+			err:    `unknown parameter "foo"`,
+		},
+		"invalid spec": {
+			values: map[string]string{"image": "not a name"},
+			err:    "name",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := tmpl.Render(tc.values)
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
+
+func TestBatchChangeTemplateValidate(t *testing.T) {
+	for name, tc := range map[string]struct {
+		tmpl BatchChangeTemplate
+		err  string
+	}{
+		"blank name": {
+			tmpl: BatchChangeTemplate{Spec: testBatchChangeTemplateSpec},
+			err:  "name must not be blank",
+		},
+		"undeclared parameter": {
+			tmpl: BatchChangeTemplate{
+				Name:       "t",
+				Spec:       testBatchChangeTemplateSpec,
+				Parameters: []BatchChangeTemplateParameter{{Name: "image"}},
+			},
+			err: `undeclared parameter "tag"`,
+		},
+		"duplicate parameter": {
+			tmpl: BatchChangeTemplate{
+				Name:       "t",
+				Spec:       testBatchChangeTemplateSpec,
+				Parameters: []BatchChangeTemplateParameter{{Name: "image"}, {Name: "tag"}, {Name: "tag"}},
+			},
+			err: `duplicate parameter "tag"`,
+		},
+		"invalid parameter name": {
+			tmpl: BatchChangeTemplate{
+				Name:       "t",
+				Spec:       testBatchChangeTemplateSpec,
+				Parameters: []BatchChangeTemplateParameter{{Name: "image"}, {Name: "tag"}, {Name: "a-b"}},
+			},
+			err: `invalid parameter name "a-b"`,
+		},
+		"invalid spec": {
+			tmpl: BatchChangeTemplate{Name: "t", Spec: "name: [foo"},
+			err:  "invalid spec",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			err := tc.tmpl.Validate()
+			if err == nil || !strings.Contains(err.Error(), tc.err) {
+				t.Fatalf("expected error containing %q, got %v", tc.err, err)
+			}
+		})
+	}
+}
@@ -3,6 +3,8 @@ package types
 import (
 	"time"
 
+	"github.com/cockroachdb/errors"
+	"github.com/gobwas/glob"
 	"github.com/sourcegraph/batch-change-utils/env"
 	"github.com/sourcegraph/batch-change-utils/overridable"
 	"github.com/sourcegraph/batch-change-utils/yaml"
@@ -41,7 +43,17 @@ func (cs *BatchSpec) Clone() *BatchSpec {
 // UnmarshalValidate unmarshals the RawSpec into Spec and validates it against
 // the BatchSpec schema and does additional semantic validation.
 func (cs *BatchSpec) UnmarshalValidate() error {
-	return yaml.UnmarshalValidate(schema.BatchSpecSchemaJSON, []byte(cs.RawSpec), &cs.Spec)
+	if err := yaml.UnmarshalValidate(schema.BatchSpecSchemaJSON, []byte(cs.RawSpec), &cs.Spec); err != nil {
+		return err
+	}
+
+	for _, rule := range cs.Spec.Checks {
+		if _, err := glob.Compile(rule.Name); err != nil {
+			return errors.Wrapf(err, "invalid check name pattern %q", rule.Name)
+		}
+	}
+
+	return nil
 }
 
 // BatchSpecTTL specifies the TTL of BatchSpecs that haven't been applied
@@ -61,6 +73,7 @@ type BatchSpecFields struct {
 	Steps             []BatchSpecStep              `json:"steps,omitempty" yaml:"steps,omitempty"`
 	ImportChangeset   []BatchChangeImportChangeset `json:"importChangesets,omitempty" yaml:"importChangesets,omitempty"`
 	ChangesetTemplate ChangesetTemplate            `json:"changesetTemplate,omitempty" yaml:"changesetTemplate,omitempty"`
+	Checks            ChangesetCheckRules          `json:"checks,omitempty" yaml:"checks,omitempty"`
 }
 
 type BatchSpecOn struct {
@@ -90,3 +103,34 @@ type ChangesetTemplate struct {
 type CommitTemplate struct {
 	Message string `json:"message,omitempty" yaml:"message,omitempty"`
 }
+
+// ChangesetCheckRule determines whether the checks whose names match the glob
+// pattern Name count toward the check state of a changeset.
+type ChangesetCheckRule struct {
+	Name string `json:"name" yaml:"name"`
+	// Required is false for informational checks, which are reported by the
+	// code host but never turn the check state of a changeset pending or
+	// failed. If omitted, matching checks are required.
+	Required *bool `json:"required,omitempty" yaml:"required,omitempty"`
+}
+
+// ChangesetCheckRules are the rules for the checks of the changesets created by
+// a batch change, in order of precedence.
+type ChangesetCheckRules []ChangesetCheckRule
+
+// Required returns whether the check with the given name counts toward the
+// check state of a changeset. The first rule whose pattern matches the name
+// applies, and checks that match no rule are required.
+func (rs ChangesetCheckRules) Required(name string) bool {
+	for _, r := range rs {
+		g, err := glob.Compile(r.Name)
+		if err != nil {
+			continue
+		}
+		if g.Match(name) {
+			return r.Required == nil || *r.Required
+		}
+	}
+
+	return true
+}
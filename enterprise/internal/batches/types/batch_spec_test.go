@@ -94,6 +94,25 @@ changesetTemplate:
 			}`,
 			err: "1 error occurred:\n\t* name: Does not match pattern '^[\\w.-]+$'\n\n",
 		},
+		{
+			name: "valid checks",
+			rawSpec: `
+name: my-unique-name
+checks:
+- name: ci/build
+- name: "lint/*"
+  required: false
+`,
+		},
+		{
+			name: "invalid check name pattern",
+			rawSpec: `
+name: my-unique-name
+checks:
+- name: "lint/[*"
+`,
+			err: `invalid check name pattern "lint/[*": unexpected end of input`,
+		},
 	}
 
 	for _, tc := range tests {
@@ -109,3 +128,25 @@ changesetTemplate:
 		})
 	}
 }
+
+func TestChangesetCheckRulesRequired(t *testing.T) {
+	informational := false
+	rules := ChangesetCheckRules{
+		{Name: "lint/required"},
+		{Name: "lint/*", Required: &informational},
+	}
+
+	for name, want := range map[string]bool{
+		"ci/build":      true,
+		"lint/required": true,
+		"lint/style":    false,
+	} {
+		if have := rules.Required(name); have != want {
+			t.Errorf("unexpected result for %q. want=%v have=%v", name, want, have)
+		}
+	}
+
+	if !ChangesetCheckRules(nil).Required("lint/style") {
+		t.Error("expected checks to be required without rules")
+	}
+}
@@ -7,6 +7,9 @@ type CodeHost struct {
 	ExternalServiceType string
 	ExternalServiceID   string
 	RequiresSSH         bool
+	// HasWebhooks is true if an external service for the code host configures
+	// webhooks to send changeset updates to Sourcegraph.
+	HasWebhooks bool
 }
 
 // IsSupported returns true, when this code host is supported by
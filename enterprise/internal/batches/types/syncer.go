@@ -28,4 +28,8 @@ type ChangesetSyncData struct {
 	// RepoExternalServiceID is the external_service_id in the repo table, usually
 	// represented by the code host URL
 	RepoExternalServiceID string
+	// HasWebhooks is true if webhooks are configured for the code host of the
+	// changeset, in which case changes are received as they happen and syncing
+	// only reconciles missed webhooks
+	HasWebhooks bool
 }
@@ -23,7 +23,7 @@ type BitbucketServerWebhook struct {
 	*Webhook
 }
 
-func NewBitbucketServerWebhook(store *store.Store) *BitbucketServerWebhook {
+func NewBitbucketServerWebhook(store *store.ObservedStore) *BitbucketServerWebhook {
 	return &BitbucketServerWebhook{
 		Webhook: &Webhook{store, extsvc.TypeBitbucketServer},
 	}
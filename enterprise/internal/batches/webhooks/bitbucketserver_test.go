@@ -92,7 +92,7 @@ func testBitbucketWebhook(db *sql.DB, userID int32) func(*testing.T) {
 			t.Fatal(err)
 		}
 
-		s := store.NewWithClock(db, nil, clock)
+		s := store.NewObservedForTest(store.NewWithClock(db, nil, clock))
 		sourcer := sources.NewSourcer(cf)
 
 		spec := &btypes.BatchSpec{
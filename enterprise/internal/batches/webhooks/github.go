@@ -39,7 +39,7 @@ type GitHubWebhook struct {
 	*Webhook
 }
 
-func NewGitHubWebhook(store *store.Store) *GitHubWebhook {
+func NewGitHubWebhook(store *store.ObservedStore) *GitHubWebhook {
 	return &GitHubWebhook{&Webhook{store, extsvc.TypeGitHub}}
 }
 
@@ -589,6 +589,7 @@ func (h *GitHubWebhook) checkSuiteEvent(cs *gh.CheckSuite) *github.CheckSuite {
 func (h *GitHubWebhook) checkRunEvent(cr *gh.CheckRun) *github.CheckRun {
 	return &github.CheckRun{
 		ID:         cr.GetNodeID(),
+		Name:       cr.GetName(),
 		Status:     cr.GetStatus(),
 		Conclusion: cr.GetConclusion(),
 		ReceivedAt: h.Store.Clock()(),
@@ -25,7 +25,7 @@ type GitLabWebhook struct {
 	*Webhook
 }
 
-func NewGitLabWebhook(store *store.Store) *GitLabWebhook {
+func NewGitLabWebhook(store *store.ObservedStore) *GitLabWebhook {
 	return &GitLabWebhook{&Webhook{store, extsvc.TypeGitLab}}
 }
 
@@ -248,7 +248,7 @@ func (h *GitLabWebhook) handlePipelineEvent(ctx context.Context, esID string, ev
 	return nil
 }
 
-func (h *GitLabWebhook) getChangesetForPR(ctx context.Context, tx *store.Store, pr *PR, repo *types.Repo) (*btypes.Changeset, error) {
+func (h *GitLabWebhook) getChangesetForPR(ctx context.Context, tx *store.ObservedStore, pr *PR, repo *types.Repo) (*btypes.Changeset, error) {
 	return tx.GetChangeset(ctx, store.GetChangesetOpts{
 		RepoID:              repo.ID,
 		ExternalID:          strconv.FormatInt(pr.ID, 10),
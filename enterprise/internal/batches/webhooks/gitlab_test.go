@@ -479,7 +479,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 			// We can induce an error with a broken database connection.
 			s := gitLabTestSetup(t, db)
 			h := NewGitLabWebhook(s)
-			h.Store = store.NewWithClock(&brokenDB{errors.New("foo")}, nil, s.Clock())
+			h.Store = store.NewObservedForTest(store.NewWithClock(&brokenDB{errors.New("foo")}, nil, s.Clock()))
 
 			es, err := h.getExternalServiceFromRawID(ctx, "12345")
 			if es != nil {
@@ -539,7 +539,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 				}
 
 				// We can induce an error with a broken database connection.
-				h.Store = store.NewWithClock(&brokenDB{errors.New("foo")}, nil, s.Clock())
+				h.Store = store.NewObservedForTest(store.NewWithClock(&brokenDB{errors.New("foo")}, nil, s.Clock()))
 
 				err := h.handleEvent(ctx, es, event)
 				if err == nil {
@@ -559,7 +559,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 				}
 
 				// We can induce an error with a broken database connection.
-				h.Store = store.NewWithClock(&brokenDB{errors.New("foo")}, nil, s.Clock())
+				h.Store = store.NewObservedForTest(store.NewWithClock(&brokenDB{errors.New("foo")}, nil, s.Clock()))
 
 				err := h.handleEvent(ctx, es, event)
 				if err == nil {
@@ -669,7 +669,7 @@ func testGitLabWebhook(db *sql.DB, userID int32) func(*testing.T) {
 			// Again, we're going to set up a poisoned store database that will
 			// error if a transaction is started.
 			s := gitLabTestSetup(t, db)
-			store := store.NewWithClock(&noNestingTx{s.DB()}, nil, s.Clock())
+			store := store.NewObservedForTest(store.NewWithClock(&noNestingTx{s.DB()}, nil, s.Clock()))
 			h := NewGitLabWebhook(store)
 
 			t.Run("missing merge request", func(t *testing.T) {
@@ -827,13 +827,13 @@ func (nntx *noNestingTx) BeginTx(ctx context.Context, opts *sql.TxOptions) error
 // gitLabTestSetup instantiates the stores and a clock for use within tests.
 // Any changes made to the stores will be rolled back after the test is
 // complete.
-func gitLabTestSetup(t *testing.T, db *sql.DB) *store.Store {
+func gitLabTestSetup(t *testing.T, db *sql.DB) *store.ObservedStore {
 	c := &ct.TestClock{Time: timeutil.Now()}
 	tx := dbtest.NewTx(t, db)
 
 	// Note that tx is wrapped in nestedTx to effectively neuter further use of
 	// transactions within the test.
-	return store.NewWithClock(&nestedTx{tx}, nil, c.Now)
+	return store.NewObservedForTest(store.NewWithClock(&nestedTx{tx}, nil, c.Now))
 }
 
 // assertBodyIncludes checks for a specific substring within the given response
@@ -852,7 +852,7 @@ func assertBodyIncludes(t *testing.T, r io.Reader, want string) {
 // assertChangesetEventForChangeset checks that one (and only one) changeset
 // event has been created on the given changeset, and that it is of the given
 // kind.
-func assertChangesetEventForChangeset(t *testing.T, ctx context.Context, tx *store.Store, changeset *btypes.Changeset, want btypes.ChangesetEventKind) {
+func assertChangesetEventForChangeset(t *testing.T, ctx context.Context, tx *store.ObservedStore, changeset *btypes.Changeset, want btypes.ChangesetEventKind) {
 	ces, _, err := tx.ListChangesetEvents(ctx, store.ListChangesetEventsOpts{
 		ChangesetIDs: []int64{changeset.ID},
 		LimitOpts:    store.LimitOpts{Limit: 100},
@@ -917,7 +917,7 @@ func createGitLabRepo(t *testing.T, ctx context.Context, rstore *database.RepoSt
 }
 
 // createGitLabChangeset creates a mock GitLab changeset.
-func createGitLabChangeset(t *testing.T, ctx context.Context, store *store.Store, repo *types.Repo) *btypes.Changeset {
+func createGitLabChangeset(t *testing.T, ctx context.Context, store *store.ObservedStore, repo *types.Repo) *btypes.Changeset {
 	c := &btypes.Changeset{
 		RepoID:              repo.ID,
 		ExternalID:          "1",
@@ -22,7 +22,7 @@ import (
 )
 
 type Webhook struct {
-	Store *store.Store
+	Store *store.ObservedStore
 
 	// ServiceType corresponds to api.ExternalRepoSpec.ServiceType
 	// Example values: extsvc.TypeBitbucketServer, extsvc.TypeGitHub
@@ -36,7 +36,7 @@ type PR struct {
 
 func (h Webhook) getRepoForPR(
 	ctx context.Context,
-	tx *store.Store,
+	tx *store.ObservedStore,
 	pr PR,
 	externalServiceID string,
 ) (*types.Repo, error) {
@@ -97,7 +97,7 @@ func (h Webhook) upsertChangesetEvent(
 	pr PR,
 	ev keyer,
 ) (err error) {
-	var tx *store.Store
+	var tx *store.ObservedStore
 	if tx, err = h.Store.Transact(ctx); err != nil {
 		return err
 	}
@@ -103,6 +103,12 @@ func (s *IndexEnqueuer) QueueIndexesForPackage(ctx context.Context, pkg semantic
 
 		return errors.Wrap(err, "repoUpdater.EnqueueRepoUpdate")
 	}
+	traceLog(
+		log.Bool("deduplicated", resp.Deduplicated),
+		log.Bool("updating", resp.Updating),
+		log.Int("queuePosition", resp.QueuePosition),
+		log.String("lastError", resp.LastError),
+	)
 
 	commit, err := s.gitserverClient.ResolveRevision(ctx, int(resp.ID), revision)
 	if err != nil {
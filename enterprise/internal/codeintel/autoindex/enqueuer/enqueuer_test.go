@@ -426,6 +426,7 @@ func TestQueueIndexesForPackage(t *testing.T) {
 			indexes = append(indexes, call.Arg1)
 		}
 
+		confidence := 0.9
 		expectedIndexes := []store.Index{
 			{
 				RepositoryID: 42,
@@ -437,8 +438,10 @@ func TestQueueIndexesForPackage(t *testing.T) {
 						Commands: []string{"go mod download"},
 					},
 				},
-				Indexer:     "sourcegraph/lsif-go:latest",
-				IndexerArgs: []string{"lsif-go", "--no-animation"},
+				Indexer:             "sourcegraph/lsif-go:latest",
+				IndexerArgs:         []string{"lsif-go", "--no-animation"},
+				InferenceConfidence: &confidence,
+				InferenceReasons:    []string{"found go.mod at the repository root"},
 			},
 		}
 		if diff := cmp.Diff(expectedIndexes, indexes); diff != "" {
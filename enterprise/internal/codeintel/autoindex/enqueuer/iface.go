@@ -46,6 +46,7 @@ type GitserverClient interface {
 	Head(ctx context.Context, repositoryID int) (string, bool, error)
 	ListFiles(ctx context.Context, repositoryID int, commit string, pattern *regexp.Regexp) ([]string, error)
 	FileExists(ctx context.Context, repositoryID int, commit, file string) (bool, error)
+	StatPaths(ctx context.Context, repositoryID int, commit string, paths []string) (map[string]bool, error)
 	RawContents(ctx context.Context, repositoryID int, commit, file string) ([]byte, error)
 	ResolveRevision(ctx context.Context, repositoryID int, versionString string) (api.CommitID, error)
 }
@@ -72,6 +73,10 @@ func (c gitClient) FileExists(ctx context.Context, file string) (bool, error) {
 	return c.client.FileExists(ctx, c.repositoryID, c.commit, file)
 }
 
+func (c gitClient) StatPaths(ctx context.Context, paths []string) (map[string]bool, error) {
+	return c.client.StatPaths(ctx, c.repositoryID, c.commit, paths)
+}
+
 func (c gitClient) RawContents(ctx context.Context, file string) ([]byte, error) {
 	return c.client.RawContents(ctx, c.repositoryID, c.commit, file)
 }
@@ -147,16 +147,20 @@ func convertInferredConfiguration(repositoryID int, commit string, indexJobs []c
 			})
 		}
 
+		confidence := indexJob.Confidence
+
 		indexes = append(indexes, store.Index{
-			RepositoryID: repositoryID,
-			Commit:       commit,
-			State:        "queued",
-			DockerSteps:  dockerSteps,
-			LocalSteps:   indexJob.LocalSteps,
-			Root:         indexJob.Root,
-			Indexer:      indexJob.Indexer,
-			IndexerArgs:  indexJob.IndexerArgs,
-			Outfile:      indexJob.Outfile,
+			RepositoryID:        repositoryID,
+			Commit:              commit,
+			State:               "queued",
+			DockerSteps:         dockerSteps,
+			LocalSteps:          indexJob.LocalSteps,
+			Root:                indexJob.Root,
+			Indexer:             indexJob.Indexer,
+			IndexerArgs:         indexJob.IndexerArgs,
+			Outfile:             indexJob.Outfile,
+			InferenceConfidence: &confidence,
+			InferenceReasons:    indexJob.Reasons,
 		})
 	}
 
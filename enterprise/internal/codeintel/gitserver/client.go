@@ -4,7 +4,6 @@ import (
 	"bytes"
 	"context"
 	"fmt"
-	"os"
 	"regexp"
 	"strings"
 	"time"
@@ -325,24 +324,36 @@ func (c *Client) FileExists(ctx context.Context, repositoryID int, commit, file
 	}})
 	defer endObservation(1, observation.Args{})
 
-	repo, err := c.repositoryIDToRepo(ctx, repositoryID)
+	tree, err := c.tree(ctx, repositoryID, commit)
 	if err != nil {
 		return false, err
 	}
 
-	if _, err := git.ResolveRevision(ctx, repo, commit, git.ResolveRevisionOptions{}); err != nil {
-		return false, errors.Wrap(err, "git.ResolveRevision")
-	}
+	return tree.exists(file), nil
+}
 
-	if _, err := git.Stat(ctx, repo, api.CommitID(commit), file); err != nil {
-		if os.IsNotExist(err) {
-			return false, nil
-		}
+// StatPaths determines whether each of the given paths exists as a file or directory in a particular
+// commit of a repository. The keys of the resulting map are the input paths. This requires at most one
+// git invocation regardless of the number of paths, as the tree listing of each commit is cached.
+func (c *Client) StatPaths(ctx context.Context, repositoryID int, commit string, paths []string) (_ map[string]bool, err error) {
+	ctx, endObservation := c.operations.statPaths.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.String("commit", commit),
+		log.Int("numPaths", len(paths)),
+	}})
+	defer endObservation(1, observation.Args{})
 
-		return false, err
+	tree, err := c.tree(ctx, repositoryID, commit)
+	if err != nil {
+		return nil, err
+	}
+
+	exists := make(map[string]bool, len(paths))
+	for _, path := range paths {
+		exists[path] = tree.exists(path)
 	}
 
-	return true, nil
+	return exists, nil
 }
 
 // ListFiles returns a list of root-relative file paths matching the given pattern in a particular
@@ -355,13 +366,13 @@ func (c *Client) ListFiles(ctx context.Context, repositoryID int, commit string,
 	}})
 	defer endObservation(1, observation.Args{})
 
-	out, err := c.execResolveRevGitCommand(ctx, repositoryID, commit, "ls-tree", "--name-only", "-r", commit, "--")
+	tree, err := c.tree(ctx, repositoryID, commit)
 	if err != nil {
 		return nil, err
 	}
 
 	var matching []string
-	for _, path := range strings.Split(out, "\n") {
+	for _, path := range tree.files {
 		if pattern.MatchString(path) {
 			matching = append(matching, path)
 		}
@@ -21,6 +21,7 @@ type operations struct {
 	rawContents       *observation.Operation
 	refDescriptions   *observation.Operation
 	resolveRevision   *observation.Operation
+	statPaths         *observation.Operation
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -53,5 +54,6 @@ func newOperations(observationContext *observation.Context) *operations {
 		rawContents:       op("RawContents"),
 		refDescriptions:   op("RefDescriptions"),
 		resolveRevision:   op("ResolveRevision"),
+		statPaths:         op("StatPaths"),
 	}
 }
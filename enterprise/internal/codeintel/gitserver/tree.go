@@ -0,0 +1,88 @@
+package gitserver
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"sync"
+
+	"github.com/golang/groupcache/lru"
+
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+// repositoryTree is the recursive tree listing of a particular commit of a repository.
+type repositoryTree struct {
+	// files is the list of root-relative file paths in the tree.
+	files []string
+
+	// paths is the set of files in the tree along with all of their parent directories.
+	paths map[string]struct{}
+}
+
+func newRepositoryTree(files []string) *repositoryTree {
+	paths := make(map[string]struct{}, len(files))
+	for _, file := range files {
+		for p := file; p != "." && p != "/"; p = path.Dir(p) {
+			if _, ok := paths[p]; ok && p != file {
+				// Parent directories of p have already been added
+				break
+			}
+			paths[p] = struct{}{}
+		}
+	}
+
+	return &repositoryTree{files: files, paths: paths}
+}
+
+// exists returns true if the given root-relative path is a file or directory in the tree.
+func (t *repositoryTree) exists(p string) bool {
+	_, ok := t.paths[strings.Trim(path.Clean(p), "/")]
+	return ok
+}
+
+// treeCache caches the recursive tree listing of recently requested commits. Inference of auto-index
+// jobs lists and stats many paths of the same commit, which would otherwise require a git invocation
+// per path, and each invocation can take multiple seconds for large monorepos. Only absolute commits are
+// cached, as the tree of a commit never changes.
+var (
+	treeCacheMu sync.Mutex
+	treeCache   = lru.New(16)
+)
+
+// tree returns the recursive tree listing of the given commit of a repository.
+func (c *Client) tree(ctx context.Context, repositoryID int, commit string) (*repositoryTree, error) {
+	key := fmt.Sprintf("%d:%s", repositoryID, commit)
+	cacheable := git.IsAbsoluteRevision(commit)
+
+	if cacheable {
+		treeCacheMu.Lock()
+		v, ok := treeCache.Get(key)
+		treeCacheMu.Unlock()
+		if ok {
+			return v.(*repositoryTree), nil
+		}
+	}
+
+	out, err := c.execResolveRevGitCommand(ctx, repositoryID, commit, "ls-tree", "-z", "--name-only", "-r", commit, "--")
+	if err != nil {
+		return nil, err
+	}
+
+	var files []string
+	for _, file := range strings.Split(out, "\x00") {
+		if file != "" {
+			files = append(files, file)
+		}
+	}
+	t := newRepositoryTree(files)
+
+	if cacheable {
+		treeCacheMu.Lock()
+		treeCache.Add(key, t)
+		treeCacheMu.Unlock()
+	}
+
+	return t, nil
+}
@@ -0,0 +1,48 @@
+package gitserver
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRepositoryTreeExists(t *testing.T) {
+	tree := newRepositoryTree([]string{
+		"go.mod",
+		"cmd/server/main.go",
+		"cmd/worker/main.go",
+		"lib/go.mod",
+	})
+
+	exists := map[string]bool{}
+	for _, path := range []string{
+		"go.mod",
+		"/go.mod",
+		"cmd",
+		"cmd/",
+		"cmd/server",
+		"cmd/server/main.go",
+		"cmd/server/main_test.go",
+		"lib/go.mod",
+		"lib/go.sum",
+		"missing",
+	} {
+		exists[path] = tree.exists(path)
+	}
+
+	expected := map[string]bool{
+		"go.mod":                  true,
+		"/go.mod":                 true,
+		"cmd":                     true,
+		"cmd/":                    true,
+		"cmd/server":              true,
+		"cmd/server/main.go":      true,
+		"cmd/server/main_test.go": false,
+		"lib/go.mod":              true,
+		"lib/go.sum":              false,
+		"missing":                 false,
+	}
+	if diff := cmp.Diff(expected, exists); diff != "" {
+		t.Errorf("unexpected path existence (-want +got):\n%s", diff)
+	}
+}
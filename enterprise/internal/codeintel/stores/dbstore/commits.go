@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 	"github.com/opentracing/opentracing-go/log"
 
 	"github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/commitgraph"
@@ -58,6 +59,25 @@ const hasRepositoryQuery = `
 SELECT COUNT(*) FROM lsif_uploads WHERE state NOT IN ('deleted', 'deleting') AND repository_id = %s LIMIT 1
 `
 
+// RepositoryIDsWithUploads returns the subset of the given repository identifiers that have LSIF data.
+func (s *Store) RepositoryIDsWithUploads(ctx context.Context, repositoryIDs []int) (_ []int, err error) {
+	ctx, endObservation := s.operations.repositoryIDsWithUploads.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("numRepositoryIDs", len(repositoryIDs)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(repositoryIDs) == 0 {
+		return nil, nil
+	}
+
+	return basestore.ScanInts(s.Store.Query(ctx, sqlf.Sprintf(repositoryIDsWithUploadsQuery, pq.Array(repositoryIDs))))
+}
+
+const repositoryIDsWithUploadsQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/commits.go:RepositoryIDsWithUploads
+SELECT DISTINCT repository_id FROM lsif_uploads WHERE state NOT IN ('deleted', 'deleting') AND repository_id = ANY(%s)
+`
+
 // HasCommit determines if the given commit is known for the given repository.
 func (s *Store) HasCommit(ctx context.Context, repositoryID int, commit string) (_ bool, err error) {
 	ctx, endObservation := s.operations.hasCommit.With(ctx, &err, observation.Args{LogFields: []log.Field{
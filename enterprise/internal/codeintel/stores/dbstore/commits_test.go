@@ -56,6 +56,31 @@ func TestHasRepository(t *testing.T) {
 	}
 }
 
+func TestRepositoryIDsWithUploads(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+
+	insertUploads(t, db,
+		Upload{ID: 1, RepositoryID: 50},
+		Upload{ID: 2, RepositoryID: 50},
+		Upload{ID: 3, RepositoryID: 51, State: "deleted"},
+		Upload{ID: 4, RepositoryID: 53},
+	)
+
+	repositoryIDs, err := store.RepositoryIDsWithUploads(context.Background(), []int{50, 51, 52, 53})
+	if err != nil {
+		t.Fatalf("unexpected error getting repositories with uploads: %s", err)
+	}
+	sort.Ints(repositoryIDs)
+
+	if diff := cmp.Diff([]int{50, 53}, repositoryIDs); diff != "" {
+		t.Errorf("unexpected repository identifiers (-want +got):\n%s", diff)
+	}
+}
+
 func TestHasCommit(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
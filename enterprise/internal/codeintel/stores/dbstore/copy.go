@@ -0,0 +1,106 @@
+package dbstore
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/lib/pq"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/batch"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbconn"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// DefaultCopyBatchSize is the maximum number of rows written by a single COPY
+// statement when the store is not configured with a batch size.
+const DefaultCopyBatchSize = 10000
+
+// copyTx is a transaction on which COPY statements can be prepared. COPY is bound
+// to a single connection, so it cannot be issued through a connection pool.
+type copyTx interface {
+	dbutil.Tx
+	PrepareContext(ctx context.Context, query string) (*sql.Stmt, error)
+}
+
+// copyValues writes the rows read from the given channel into the given table with
+// one COPY statement per batch of rows. This is much cheaper than the equivalent
+// INSERT statements for large uploads. The progress of the write is reported to
+// the copy metrics after each batch. Outside of a transaction, the rows are
+// written with multi-row INSERT statements instead.
+func (s *Store) copyValues(ctx context.Context, tableName string, columnNames []string, values <-chan []interface{}) (err error) {
+	tx, ok := s.Handle().DB().(copyTx)
+	if !ok {
+		return batch.InsertValues(ctx, s.Handle().DB(), tableName, columnNames, values)
+	}
+
+	ctx, traceLog, endObservation := s.operations.copyValues.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("tableName", tableName),
+		log.Int("batchSize", s.copyBatchSize),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	// Do not log every row written by the COPY statement
+	ctx = dbconn.WithBulkInsertion(ctx, true)
+
+	numRows := 0
+	for {
+		rows := readCopyBatch(values, s.copyBatchSize)
+		if len(rows) == 0 {
+			return nil
+		}
+
+		start := time.Now()
+		if err := copyBatch(ctx, tx, tableName, columnNames, rows); err != nil {
+			return err
+		}
+		numRows += len(rows)
+
+		s.operations.copyRows.WithLabelValues(tableName).Add(float64(len(rows)))
+		s.operations.copyBatchDuration.WithLabelValues(tableName).Observe(time.Since(start).Seconds())
+		traceLog(log.Int("numRows", numRows))
+	}
+}
+
+// readCopyBatch reads at most batchSize rows from the given channel. An empty
+// batch is returned once the channel is closed and drained.
+func readCopyBatch(values <-chan []interface{}, batchSize int) [][]interface{} {
+	if batchSize <= 0 {
+		batchSize = DefaultCopyBatchSize
+	}
+
+	rows := make([][]interface{}, 0, batchSize)
+	for row := range values {
+		rows = append(rows, row)
+		if len(rows) >= batchSize {
+			break
+		}
+	}
+
+	return rows
+}
+
+// copyBatch writes the given rows into the given table with a single COPY statement.
+func copyBatch(ctx context.Context, tx copyTx, tableName string, columnNames []string, rows [][]interface{}) (err error) {
+	stmt, err := tx.PrepareContext(ctx, pq.CopyIn(tableName, columnNames...))
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if closeErr := stmt.Close(); err == nil {
+			err = closeErr
+		}
+	}()
+
+	for _, row := range rows {
+		if _, err := stmt.ExecContext(ctx, row...); err != nil {
+			return err
+		}
+	}
+
+	// Flush the buffered rows to the database
+	_, err = stmt.ExecContext(ctx)
+	return err
+}
@@ -0,0 +1,33 @@
+package dbstore
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestReadCopyBatch(t *testing.T) {
+	values := make(chan []interface{}, 5)
+	for i := 0; i < 5; i++ {
+		values <- []interface{}{i}
+	}
+	close(values)
+
+	var batches [][][]interface{}
+	for {
+		rows := readCopyBatch(values, 2)
+		if len(rows) == 0 {
+			break
+		}
+		batches = append(batches, rows)
+	}
+
+	expected := [][][]interface{}{
+		{{0}, {1}},
+		{{2}, {3}},
+		{{4}},
+	}
+	if diff := cmp.Diff(expected, batches); diff != "" {
+		t.Errorf("unexpected batches (-want +got):\n%s", diff)
+	}
+}
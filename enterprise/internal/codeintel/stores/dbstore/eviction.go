@@ -0,0 +1,165 @@
+package dbstore
+
+import (
+	"context"
+	"sort"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// ColdUploadIDs returns the identifiers of completed uploads whose code intelligence data is
+// in the codeintel database and that have not been queried (or, if never queried, finished
+// processing) within the given duration. Uploads that have been idle the longest come first.
+func (s *Store) ColdUploadIDs(ctx context.Context, maxIdle time.Duration, limit int, now time.Time) (_ []int, err error) {
+	ctx, traceLog, endObservation := s.operations.coldUploadIDs.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("maxIdle", maxIdle.String()),
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	ids, err := basestore.ScanInts(s.Store.Query(ctx, sqlf.Sprintf(coldUploadIDsQuery, now.UTC(), int(maxIdle/time.Second), limit)))
+	if err != nil {
+		return nil, err
+	}
+	traceLog(log.Int("numIDs", len(ids)))
+
+	return ids, nil
+}
+
+const coldUploadIDsQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/eviction.go:ColdUploadIDs
+SELECT u.id
+FROM lsif_uploads u
+WHERE
+	u.state = 'completed' AND
+	u.evicted_at IS NULL AND
+	%s - COALESCE(u.last_queried_at, u.finished_at, u.uploaded_at) > (%s * '1 second'::interval)
+ORDER BY COALESCE(u.last_queried_at, u.finished_at, u.uploaded_at), u.id
+LIMIT %s
+`
+
+// DeletedEvictedUploadIDs returns the identifiers of deleted uploads whose code intelligence
+// data is still in object storage.
+func (s *Store) DeletedEvictedUploadIDs(ctx context.Context, limit int) (_ []int, err error) {
+	ctx, traceLog, endObservation := s.operations.deletedEvictedUploadIDs.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	ids, err := basestore.ScanInts(s.Store.Query(ctx, sqlf.Sprintf(deletedEvictedUploadIDsQuery, limit)))
+	if err != nil {
+		return nil, err
+	}
+	traceLog(log.Int("numIDs", len(ids)))
+
+	return ids, nil
+}
+
+const deletedEvictedUploadIDsQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/eviction.go:DeletedEvictedUploadIDs
+SELECT u.id FROM lsif_uploads u WHERE u.state = 'deleted' AND u.evicted_at IS NOT NULL ORDER BY u.id LIMIT %s
+`
+
+// EvictedUploadIDs returns the subset of the given upload identifiers whose code intelligence
+// data has been moved to object storage.
+func (s *Store) EvictedUploadIDs(ctx context.Context, ids []int) (_ []int, err error) {
+	ctx, endObservation := s.operations.evictedUploadIDs.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("numIDs", len(ids)),
+		log.String("ids", intsToString(ids)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(ids) == 0 {
+		return nil, nil
+	}
+
+	var idQueries []*sqlf.Query
+	for _, id := range ids {
+		idQueries = append(idQueries, sqlf.Sprintf("%s", id))
+	}
+
+	return basestore.ScanInts(s.Store.Query(ctx, sqlf.Sprintf(evictedUploadIDsQuery, sqlf.Join(idQueries, ", "))))
+}
+
+const evictedUploadIDsQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/eviction.go:EvictedUploadIDs
+SELECT u.id FROM lsif_uploads u WHERE u.id IN (%s) AND u.evicted_at IS NOT NULL ORDER BY u.id
+`
+
+// MarkUploadEvicted records that the code intelligence data of the given upload has been
+// moved to object storage.
+func (s *Store) MarkUploadEvicted(ctx context.Context, id int, now time.Time) (err error) {
+	ctx, endObservation := s.operations.markUploadEvicted.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("id", id),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Store.Exec(ctx, sqlf.Sprintf(markUploadEvictedQuery, now.UTC(), id))
+}
+
+const markUploadEvictedQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/eviction.go:MarkUploadEvicted
+UPDATE lsif_uploads SET evicted_at = %s WHERE id = %s
+`
+
+// UnmarkUploadEvicted records that the code intelligence data of the given upload is no
+// longer in object storage.
+func (s *Store) UnmarkUploadEvicted(ctx context.Context, id int) (err error) {
+	ctx, endObservation := s.operations.unmarkUploadEvicted.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("id", id),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return s.Store.Exec(ctx, sqlf.Sprintf(unmarkUploadEvictedQuery, id))
+}
+
+const unmarkUploadEvictedQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/eviction.go:UnmarkUploadEvicted
+UPDATE lsif_uploads SET evicted_at = NULL WHERE id = %s
+`
+
+// MarkUploadsQueried bumps the last query time of the given uploads. To limit write traffic,
+// uploads queried within the last hour are not updated.
+func (s *Store) MarkUploadsQueried(ctx context.Context, ids []int, now time.Time) (err error) {
+	ctx, endObservation := s.operations.markUploadsQueried.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("numIDs", len(ids)),
+		log.String("ids", intsToString(ids)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	if len(ids) == 0 {
+		return nil
+	}
+
+	// Ensure ids are sorted so that we take row locks during the
+	// UPDATE query in a determinstic order. This should prevent
+	// deadlocks with other queries that mass update lsif_uploads.
+	sort.Ints(ids)
+
+	var idQueries []*sqlf.Query
+	for _, id := range ids {
+		idQueries = append(idQueries, sqlf.Sprintf("%s", id))
+	}
+
+	now = now.UTC()
+	return s.Store.Exec(ctx, sqlf.Sprintf(markUploadsQueriedQuery, sqlf.Join(idQueries, ", "), now, now))
+}
+
+const markUploadsQueriedQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/eviction.go:MarkUploadsQueried
+WITH candidates AS (
+	SELECT u.id
+	FROM lsif_uploads u
+	WHERE u.id IN (%s) AND (u.last_queried_at IS NULL OR u.last_queried_at < %s - '1 hour'::interval)
+
+	-- Lock these rows in a deterministic order so that we don't
+	-- deadlock with other processes updating the lsif_uploads table.
+	ORDER BY u.id FOR UPDATE
+)
+UPDATE lsif_uploads u SET last_queried_at = %s WHERE u.id IN (SELECT id FROM candidates)
+`
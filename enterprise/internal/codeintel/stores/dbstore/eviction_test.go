@@ -0,0 +1,133 @@
+package dbstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+)
+
+func TestColdUploadIDs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+	ctx := context.Background()
+
+	now := time.Unix(1587396557, 0).UTC()
+	t1 := now.Add(-time.Hour * 24 * 40)
+	t2 := now.Add(-time.Hour * 24 * 35)
+	t3 := now.Add(-time.Hour * 24 * 5)
+
+	insertUploads(t, db,
+		Upload{ID: 1, FinishedAt: &t1},
+		Upload{ID: 2, FinishedAt: &t2},
+		Upload{ID: 3, FinishedAt: &t3},
+		Upload{ID: 4, FinishedAt: &t1, State: "errored"},
+		Upload{ID: 5, FinishedAt: &t1},
+		Upload{ID: 6, FinishedAt: &t1, State: "deleted"},
+	)
+
+	// Upload 5 was queried recently
+	if err := store.MarkUploadsQueried(ctx, []int{5}, now.Add(-time.Hour)); err != nil {
+		t.Fatalf("unexpected error marking uploads as queried: %s", err)
+	}
+
+	ids, err := store.ColdUploadIDs(ctx, time.Hour*24*30, 10, now)
+	if err != nil {
+		t.Fatalf("unexpected error getting cold uploads: %s", err)
+	}
+	if diff := cmp.Diff([]int{1, 2}, ids); diff != "" {
+		t.Errorf("unexpected cold uploads (-want +got):\n%s", diff)
+	}
+
+	for _, id := range []int{1, 6} {
+		if err := store.MarkUploadEvicted(ctx, id, now); err != nil {
+			t.Fatalf("unexpected error marking upload as evicted: %s", err)
+		}
+	}
+
+	ids, err = store.ColdUploadIDs(ctx, time.Hour*24*30, 10, now)
+	if err != nil {
+		t.Fatalf("unexpected error getting cold uploads: %s", err)
+	}
+	if diff := cmp.Diff([]int{2}, ids); diff != "" {
+		t.Errorf("unexpected cold uploads (-want +got):\n%s", diff)
+	}
+
+	ids, err = store.EvictedUploadIDs(ctx, []int{1, 2, 3, 6})
+	if err != nil {
+		t.Fatalf("unexpected error getting evicted uploads: %s", err)
+	}
+	if diff := cmp.Diff([]int{1, 6}, ids); diff != "" {
+		t.Errorf("unexpected evicted uploads (-want +got):\n%s", diff)
+	}
+
+	ids, err = store.DeletedEvictedUploadIDs(ctx, 10)
+	if err != nil {
+		t.Fatalf("unexpected error getting deleted evicted uploads: %s", err)
+	}
+	if diff := cmp.Diff([]int{6}, ids); diff != "" {
+		t.Errorf("unexpected deleted evicted uploads (-want +got):\n%s", diff)
+	}
+
+	if err := store.UnmarkUploadEvicted(ctx, 1); err != nil {
+		t.Fatalf("unexpected error unmarking upload as evicted: %s", err)
+	}
+
+	ids, err = store.EvictedUploadIDs(ctx, []int{1, 2, 3, 6})
+	if err != nil {
+		t.Fatalf("unexpected error getting evicted uploads: %s", err)
+	}
+	if diff := cmp.Diff([]int{6}, ids); diff != "" {
+		t.Errorf("unexpected evicted uploads (-want +got):\n%s", diff)
+	}
+}
+
+func TestMarkUploadsQueried(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+	ctx := context.Background()
+
+	now := time.Unix(1587396557, 0).UTC()
+	insertUploads(t, db, Upload{ID: 1}, Upload{ID: 2})
+
+	if err := store.MarkUploadsQueried(ctx, []int{1, 2}, now); err != nil {
+		t.Fatalf("unexpected error marking uploads as queried: %s", err)
+	}
+	// Within the hour: not updated
+	if err := store.MarkUploadsQueried(ctx, []int{1}, now.Add(time.Minute*30)); err != nil {
+		t.Fatalf("unexpected error marking uploads as queried: %s", err)
+	}
+	// After the hour: updated
+	if err := store.MarkUploadsQueried(ctx, []int{2}, now.Add(time.Minute*90)); err != nil {
+		t.Fatalf("unexpected error marking uploads as queried: %s", err)
+	}
+
+	lastQueriedAt := map[int]time.Time{}
+	rows, err := db.Query("SELECT id, last_queried_at FROM lsif_uploads ORDER BY id")
+	if err != nil {
+		t.Fatalf("unexpected error querying uploads: %s", err)
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var id int
+		var queriedAt time.Time
+		if err := rows.Scan(&id, &queriedAt); err != nil {
+			t.Fatalf("unexpected error scanning uploads: %s", err)
+		}
+		lastQueriedAt[id] = queriedAt.UTC()
+	}
+
+	expected := map[int]time.Time{1: now, 2: now.Add(time.Minute * 90)}
+	if diff := cmp.Diff(expected, lastQueriedAt); diff != "" {
+		t.Errorf("unexpected last queried times (-want +got):\n%s", diff)
+	}
+}
@@ -41,6 +41,11 @@ type Index struct {
 	ExecutionLogs      []workerutil.ExecutionLogEntry `json:"execution_logs"`
 	Rank               *int                           `json:"placeInQueue"`
 	AssociatedUploadID *int                           `json:"associatedUpload"`
+
+	// InferenceConfidence and InferenceReasons explain index jobs inferred from the
+	// repository structure. They are unset for explicitly configured index jobs.
+	InferenceConfidence *float64 `json:"inferenceConfidence"`
+	InferenceReasons    []string `json:"inferenceReasons"`
 }
 
 func (i Index) RecordID() int {
@@ -80,6 +85,8 @@ func scanIndexes(rows *sql.Rows, queryErr error) (_ []Index, err error) {
 			pq.Array(&executionLogs),
 			&index.Rank,
 			pq.Array(&index.LocalSteps),
+			&index.InferenceConfidence,
+			pq.Array(&index.InferenceReasons),
 			&index.AssociatedUploadID,
 		); err != nil {
 			return nil, err
@@ -163,6 +170,8 @@ SELECT
 	u.execution_logs,
 	s.rank,
 	u.local_steps,
+	u.inference_confidence,
+	u.inference_reasons,
 	` + indexAssociatedUploadIDQueryFragment + `
 FROM lsif_indexes_with_repository_name u
 LEFT JOIN (` + indexRankQueryFragment + `) s
@@ -219,6 +228,8 @@ SELECT
 	u.execution_logs,
 	s.rank,
 	u.local_steps,
+	u.inference_confidence,
+	u.inference_reasons,
 	` + indexAssociatedUploadIDQueryFragment + `
 FROM lsif_indexes_with_repository_name u
 LEFT JOIN (` + indexRankQueryFragment + `) s
@@ -318,6 +329,8 @@ SELECT
 	u.execution_logs,
 	s.rank,
 	u.local_steps,
+	u.inference_confidence,
+	u.inference_reasons,
 	` + indexAssociatedUploadIDQueryFragment + `
 FROM lsif_indexes_with_repository_name u
 LEFT JOIN (` + indexRankQueryFragment + `) s
@@ -399,6 +412,8 @@ func (s *Store) InsertIndex(ctx context.Context, index Index) (id int, err error
 			pq.Array(index.IndexerArgs),
 			index.Outfile,
 			pq.Array(dbworkerstore.ExecutionLogEntries(index.ExecutionLogs)),
+			index.InferenceConfidence,
+			pq.Array(index.InferenceReasons),
 		),
 	))
 
@@ -417,8 +432,10 @@ INSERT INTO lsif_indexes (
 	indexer,
 	indexer_args,
 	outfile,
-	execution_logs
-) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
+	execution_logs,
+	inference_confidence,
+	inference_reasons
+) VALUES (%s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s, %s)
 RETURNING id
 `
 
@@ -443,6 +460,8 @@ var indexColumnsWithNullRank = []*sqlf.Query{
 	sqlf.Sprintf(`u.execution_logs`),
 	sqlf.Sprintf("NULL"),
 	sqlf.Sprintf(`u.local_steps`),
+	sqlf.Sprintf(`u.inference_confidence`),
+	sqlf.Sprintf(`u.inference_reasons`),
 	sqlf.Sprintf(indexAssociatedUploadIDQueryFragment),
 }
 
@@ -471,16 +490,16 @@ DELETE FROM lsif_indexes WHERE id = %s RETURNING repository_id
 `
 
 // DeleteIndexesWithoutRepository deletes indexes associated with repositories that were deleted at least
-// DeletedRepositoryGracePeriod ago. This returns the repository identifier mapped to the number of indexes
+// the given grace period ago. This returns the repository identifier mapped to the number of indexes
 // that were removed for that repository.
-func (s *Store) DeleteIndexesWithoutRepository(ctx context.Context, now time.Time) (_ map[int]int, err error) {
+func (s *Store) DeleteIndexesWithoutRepository(ctx context.Context, gracePeriod time.Duration, now time.Time) (_ map[int]int, err error) {
 	ctx, traceLog, endObservation := s.operations.deleteIndexesWithoutRepository.WithAndLogger(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
 
 	// TODO(efritz) - this would benefit from an index on repository_id. We currently have
 	// a similar one on this index, but only for uploads that are completed or visible at tip.
 
-	repositories, err := scanCounts(s.Store.Query(ctx, sqlf.Sprintf(deleteIndexesWithoutRepositoryQuery, now.UTC(), DeletedRepositoryGracePeriod/time.Second)))
+	repositories, err := scanCounts(s.Store.Query(ctx, sqlf.Sprintf(deleteIndexesWithoutRepositoryQuery, now.UTC(), gracePeriod/time.Second)))
 	if err != nil {
 		return nil, err
 	}
@@ -379,6 +379,8 @@ func TestInsertIndex(t *testing.T) {
 
 	insertRepo(t, db, 50, "")
 
+	confidence := 0.8
+
 	id, err := store.InsertIndex(context.Background(), Index{
 		State:        "queued",
 		Commit:       makeCommit(1),
@@ -398,6 +400,8 @@ func TestInsertIndex(t *testing.T) {
 			{Command: []string{"op", "1"}, Out: "Indexing\nUploading\nDone with 1.\n"},
 			{Command: []string{"op", "2"}, Out: "Indexing\nUploading\nDone with 2.\n"},
 		},
+		InferenceConfidence: &confidence,
+		InferenceReasons:    []string{"found tsconfig.json at /foo/bar"},
 	})
 	if err != nil {
 		t.Fatalf("unexpected error enqueueing index: %s", err)
@@ -429,7 +433,9 @@ func TestInsertIndex(t *testing.T) {
 			{Command: []string{"op", "1"}, Out: "Indexing\nUploading\nDone with 1.\n"},
 			{Command: []string{"op", "2"}, Out: "Indexing\nUploading\nDone with 2.\n"},
 		},
-		Rank: &rank,
+		Rank:                &rank,
+		InferenceConfidence: &confidence,
+		InferenceReasons:    []string{"found tsconfig.json at /foo/bar"},
 	}
 
 	if index, exists, err := store.GetIndexByID(context.Background(), id); err != nil {
@@ -517,7 +523,7 @@ func TestDeleteIndexesWithoutRepository(t *testing.T) {
 		}
 	}
 
-	ids, err := store.DeleteIndexesWithoutRepository(context.Background(), t1)
+	ids, err := store.DeleteIndexesWithoutRepository(context.Background(), DeletedRepositoryGracePeriod, t1)
 	if err != nil {
 		t.Fatalf("unexpected error deleting indexes: %s", err)
 	}
@@ -3,6 +3,8 @@ package dbstore
 import (
 	"fmt"
 
+	"github.com/prometheus/client_golang/prometheus"
+
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
@@ -10,18 +12,23 @@ import (
 type operations struct {
 	addUploadPart                          *observation.Operation
 	calculateVisibleUploads                *observation.Operation
+	coldUploadIDs                          *observation.Operation
 	commitGraphMetadata                    *observation.Operation
+	createUploadToken                      *observation.Operation
 	definitionDumps                        *observation.Operation
+	deletedEvictedUploadIDs                *observation.Operation
 	deleteIndexByID                        *observation.Operation
 	deleteIndexesWithoutRepository         *observation.Operation
 	deleteOldIndexes                       *observation.Operation
 	deleteOverlappingDumps                 *observation.Operation
 	deleteUploadByID                       *observation.Operation
+	deleteUploadToken                      *observation.Operation
 	deleteUploadsStuckUploading            *observation.Operation
 	deleteUploadsWithoutRepository         *observation.Operation
 	dequeue                                *observation.Operation
 	dequeueIndex                           *observation.Operation
 	dirtyRepositories                      *observation.Operation
+	evictedUploadIDs                       *observation.Operation
 	findClosestDumps                       *observation.Operation
 	findClosestDumpsFromGraphFragment      *observation.Operation
 	getAutoindexDisabledRepositories       *observation.Operation
@@ -33,7 +40,9 @@ type operations struct {
 	getOldestCommitDate                    *observation.Operation
 	getRepositoriesWithIndexConfiguration  *observation.Operation
 	getUploadByID                          *observation.Operation
+	getUploadProcessingTrace               *observation.Operation
 	getUploads                             *observation.Operation
+	getUploadTokens                        *observation.Operation
 	getUploadsByIDs                        *observation.Operation
 	hardDeleteUploadByID                   *observation.Operation
 	hasCommit                              *observation.Operation
@@ -50,24 +59,34 @@ type operations struct {
 	markIndexErrored                       *observation.Operation
 	markQueued                             *observation.Operation
 	markRepositoryAsDirty                  *observation.Operation
+	markUploadEvicted                      *observation.Operation
+	markUploadsQueried                     *observation.Operation
 	queueSize                              *observation.Operation
 	referenceIDsAndFilters                 *observation.Operation
 	referencesForUpload                    *observation.Operation
 	refreshCommitResolvability             *observation.Operation
 	repoName                               *observation.Operation
+	repositoryIDsWithUploads               *observation.Operation
 	requeue                                *observation.Operation
 	requeueIndex                           *observation.Operation
 	softDeleteOldUploads                   *observation.Operation
 	staleSourcedCommits                    *observation.Operation
+	unmarkUploadEvicted                    *observation.Operation
 	updateCommitedAt                       *observation.Operation
 	updateIndexConfigurationByRepositoryID *observation.Operation
 	updatePackageReferences                *observation.Operation
 	updatePackages                         *observation.Operation
+	updateUploadProcessingTrace            *observation.Operation
+	verifyUploadToken                      *observation.Operation
 
 	writeVisibleUploads        *observation.Operation
 	persistNearestUploads      *observation.Operation
 	persistNearestUploadsLinks *observation.Operation
 	persistUploadsVisibleAtTip *observation.Operation
+	copyValues                 *observation.Operation
+
+	copyRows          *prometheus.CounterVec
+	copyBatchDuration *prometheus.HistogramVec
 }
 
 func newOperations(observationContext *observation.Context) *operations {
@@ -95,21 +114,39 @@ func newOperations(observationContext *observation.Context) *operations {
 		})
 	}
 
+	copyRows := prometheus.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_codeintel_dbstore_copy_rows_total",
+		Help: "Total number of rows written with COPY statements.",
+	}, []string{"table"})
+	observationContext.Registerer.MustRegister(copyRows)
+
+	copyBatchDuration := prometheus.NewHistogramVec(prometheus.HistogramOpts{
+		Name:    "src_codeintel_dbstore_copy_batch_duration_seconds",
+		Help:    "Time spent writing a batch of rows with a COPY statement.",
+		Buckets: prometheus.DefBuckets,
+	}, []string{"table"})
+	observationContext.Registerer.MustRegister(copyBatchDuration)
+
 	return &operations{
 		addUploadPart:                          op("AddUploadPart"),
 		calculateVisibleUploads:                op("CalculateVisibleUploads"),
+		coldUploadIDs:                          op("ColdUploadIDs"),
 		commitGraphMetadata:                    op("CommitGraphMetadata"),
+		createUploadToken:                      op("CreateUploadToken"),
 		definitionDumps:                        op("DefinitionDumps"),
+		deletedEvictedUploadIDs:                op("DeletedEvictedUploadIDs"),
 		deleteIndexByID:                        op("DeleteIndexByID"),
 		deleteIndexesWithoutRepository:         op("DeleteIndexesWithoutRepository"),
 		deleteOldIndexes:                       op("DeleteOldIndexes"),
 		deleteOverlappingDumps:                 op("DeleteOverlappingDumps"),
 		deleteUploadByID:                       op("DeleteUploadByID"),
+		deleteUploadToken:                      op("DeleteUploadToken"),
 		deleteUploadsStuckUploading:            op("DeleteUploadsStuckUploading"),
 		deleteUploadsWithoutRepository:         op("DeleteUploadsWithoutRepository"),
 		dequeue:                                op("Dequeue"),
 		dequeueIndex:                           op("DequeueIndex"),
 		dirtyRepositories:                      op("DirtyRepositories"),
+		evictedUploadIDs:                       op("EvictedUploadIDs"),
 		findClosestDumps:                       op("FindClosestDumps"),
 		findClosestDumpsFromGraphFragment:      op("FindClosestDumpsFromGraphFragment"),
 		getAutoindexDisabledRepositories:       op("getAutoindexDisabledRepositories"),
@@ -121,7 +158,9 @@ func newOperations(observationContext *observation.Context) *operations {
 		getOldestCommitDate:                    op("GetOldestCommitDate"),
 		getRepositoriesWithIndexConfiguration:  op("GetRepositoriesWithIndexConfiguration"),
 		getUploadByID:                          op("GetUploadByID"),
+		getUploadProcessingTrace:               op("GetUploadProcessingTrace"),
 		getUploads:                             op("GetUploads"),
+		getUploadTokens:                        op("GetUploadTokens"),
 		getUploadsByIDs:                        op("GetUploadsByIDs"),
 		hardDeleteUploadByID:                   op("HardDeleteUploadByID"),
 		hasCommit:                              op("HasCommit"),
@@ -138,23 +177,33 @@ func newOperations(observationContext *observation.Context) *operations {
 		markIndexErrored:                       op("MarkIndexErrored"),
 		markQueued:                             op("MarkQueued"),
 		markRepositoryAsDirty:                  op("MarkRepositoryAsDirty"),
+		markUploadEvicted:                      op("MarkUploadEvicted"),
+		markUploadsQueried:                     op("MarkUploadsQueried"),
 		queueSize:                              op("QueueSize"),
 		referenceIDsAndFilters:                 op("ReferenceIDsAndFilters"),
 		referencesForUpload:                    op("ReferencesForUpload"),
 		refreshCommitResolvability:             op("RefreshCommitResolvability"),
 		repoName:                               op("RepoName"),
+		repositoryIDsWithUploads:               op("RepositoryIDsWithUploads"),
 		requeue:                                op("Requeue"),
 		requeueIndex:                           op("RequeueIndex"),
 		softDeleteOldUploads:                   op("SoftDeleteOldUploads"),
 		staleSourcedCommits:                    op("StaleSourcedCommits"),
+		unmarkUploadEvicted:                    op("UnmarkUploadEvicted"),
 		updateCommitedAt:                       op("UpdateCommitedAt"),
 		updateIndexConfigurationByRepositoryID: op("UpdateIndexConfigurationByRepositoryID"),
 		updatePackageReferences:                op("UpdatePackageReferences"),
 		updatePackages:                         op("UpdatePackages"),
+		updateUploadProcessingTrace:            op("UpdateUploadProcessingTrace"),
+		verifyUploadToken:                      op("VerifyUploadToken"),
 
 		writeVisibleUploads:        subOp("writeVisibleUploads"),
 		persistNearestUploads:      subOp("persistNearestUploads"),
 		persistNearestUploadsLinks: subOp("persistNearestUploadsLinks"),
 		persistUploadsVisibleAtTip: subOp("persistUploadsVisibleAtTip"),
+		copyValues:                 subOp("copyValues"),
+
+		copyRows:          copyRows,
+		copyBatchDuration: copyBatchDuration,
 	}
 }
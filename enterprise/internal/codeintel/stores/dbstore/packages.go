@@ -6,7 +6,6 @@ import (
 	"github.com/keegancsmith/sqlf"
 	"github.com/opentracing/opentracing-go/log"
 
-	"github.com/sourcegraph/sourcegraph/internal/database/batch"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
 )
@@ -34,9 +33,8 @@ func (s *Store) UpdatePackages(ctx context.Context, dumpID int, packages []seman
 	}
 
 	// Bulk insert all the unique column values into the temporary table
-	if err := batch.InsertValues(
+	if err := tx.copyValues(
 		ctx,
-		tx.Handle().DB(),
 		"t_lsif_packages",
 		[]string{"scheme", "name", "version"},
 		loadPackagesChannel(packages),
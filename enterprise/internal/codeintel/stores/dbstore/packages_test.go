@@ -2,6 +2,7 @@ package dbstore
 
 import (
 	"context"
+	"fmt"
 	"testing"
 
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
@@ -43,6 +44,33 @@ func TestUpdatePackages(t *testing.T) {
 	}
 }
 
+func TestUpdatePackagesMultipleCopyBatches(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db).WithCopyBatchSize(3)
+
+	// for foreign key relation
+	insertUploads(t, db, Upload{ID: 42})
+
+	var packages []semantic.Package
+	for i := 0; i < 10; i++ {
+		packages = append(packages, semantic.Package{Scheme: fmt.Sprintf("s%d", i), Name: fmt.Sprintf("n%d", i), Version: fmt.Sprintf("v%d", i)})
+	}
+	if err := store.UpdatePackages(context.Background(), 42, packages); err != nil {
+		t.Fatalf("unexpected error updating packages: %s", err)
+	}
+
+	count, _, err := basestore.ScanFirstInt(db.Query("SELECT COUNT(*) FROM lsif_packages"))
+	if err != nil {
+		t.Fatalf("unexpected error checking package count: %s", err)
+	}
+	if count != 10 {
+		t.Errorf("unexpected package count. want=%d have=%d", 10, count)
+	}
+}
+
 func TestUpdatePackagesEmpty(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -0,0 +1,148 @@
+package dbstore
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// UploadProcessingTrace is a breakdown of the time and memory the precise code intel worker spent
+// on the last processing attempt of an upload.
+type UploadProcessingTrace struct {
+	UploadID int
+	// Phases are the phases of processing that were reached, in the order they started.
+	Phases []UploadProcessingPhase
+	// Duration is the total time spent processing the upload.
+	Duration time.Duration
+	// PeakMemoryBytes is the highest heap size of the worker sampled while processing the
+	// upload. Uploads processed concurrently by the same worker contribute to the same heap.
+	PeakMemoryBytes int64
+	RecordedAt      time.Time
+}
+
+// UploadProcessingPhase is a phase of processing an upload, such as parsing the raw LSIF data.
+type UploadProcessingPhase struct {
+	Name string
+	// Start is the offset of the start of the phase from the start of processing.
+	Start    time.Duration
+	Duration time.Duration
+}
+
+// uploadProcessingPhaseJSON is the serialized form of an UploadProcessingPhase.
+type uploadProcessingPhaseJSON struct {
+	Name       string `json:"name"`
+	StartMs    int64  `json:"start_ms"`
+	DurationMs int64  `json:"duration_ms"`
+}
+
+// scanUploadProcessingTraces scans a slice of upload processing traces from the return value of `*Store.query`.
+func scanUploadProcessingTraces(rows *sql.Rows, queryErr error) (_ []UploadProcessingTrace, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var traces []UploadProcessingTrace
+	for rows.Next() {
+		var (
+			trace        UploadProcessingTrace
+			rawPhases    []byte
+			durationMs   int64
+			serialPhases []uploadProcessingPhaseJSON
+		)
+		if err := rows.Scan(
+			&trace.UploadID,
+			&rawPhases,
+			&durationMs,
+			&trace.PeakMemoryBytes,
+			&trace.RecordedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		if err := json.Unmarshal(rawPhases, &serialPhases); err != nil {
+			return nil, err
+		}
+		for _, phase := range serialPhases {
+			trace.Phases = append(trace.Phases, UploadProcessingPhase{
+				Name:     phase.Name,
+				Start:    time.Duration(phase.StartMs) * time.Millisecond,
+				Duration: time.Duration(phase.DurationMs) * time.Millisecond,
+			})
+		}
+		trace.Duration = time.Duration(durationMs) * time.Millisecond
+
+		traces = append(traces, trace)
+	}
+
+	return traces, nil
+}
+
+// GetUploadProcessingTrace returns the processing trace of the upload with the given identifier and
+// a boolean flag indicating its existence.
+func (s *Store) GetUploadProcessingTrace(ctx context.Context, uploadID int) (_ UploadProcessingTrace, _ bool, err error) {
+	ctx, endObservation := s.operations.getUploadProcessingTrace.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("uploadID", uploadID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	traces, err := scanUploadProcessingTraces(s.Store.Query(ctx, sqlf.Sprintf(getUploadProcessingTraceQuery, uploadID)))
+	if err != nil || len(traces) == 0 {
+		return UploadProcessingTrace{}, false, err
+	}
+	return traces[0], true, nil
+}
+
+const getUploadProcessingTraceQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/processing_traces.go:GetUploadProcessingTrace
+SELECT upload_id, phases, duration_ms, peak_memory_bytes, recorded_at FROM lsif_upload_processing_traces WHERE upload_id = %s
+`
+
+// UpdateUploadProcessingTrace replaces the processing trace of an upload.
+func (s *Store) UpdateUploadProcessingTrace(ctx context.Context, trace UploadProcessingTrace) (err error) {
+	ctx, endObservation := s.operations.updateUploadProcessingTrace.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("uploadID", trace.UploadID),
+		log.Int("numPhases", len(trace.Phases)),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	serialPhases := make([]uploadProcessingPhaseJSON, 0, len(trace.Phases))
+	for _, phase := range trace.Phases {
+		serialPhases = append(serialPhases, uploadProcessingPhaseJSON{
+			Name:       phase.Name,
+			StartMs:    phase.Start.Milliseconds(),
+			DurationMs: phase.Duration.Milliseconds(),
+		})
+	}
+	rawPhases, err := json.Marshal(serialPhases)
+	if err != nil {
+		return err
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(
+		updateUploadProcessingTraceQuery,
+		trace.UploadID,
+		rawPhases,
+		trace.Duration.Milliseconds(),
+		trace.PeakMemoryBytes,
+		trace.RecordedAt,
+	))
+}
+
+const updateUploadProcessingTraceQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/processing_traces.go:UpdateUploadProcessingTrace
+INSERT INTO lsif_upload_processing_traces (upload_id, phases, duration_ms, peak_memory_bytes, recorded_at)
+VALUES (%s, %s, %s, %s, %s)
+ON CONFLICT (upload_id) DO UPDATE SET
+	phases = EXCLUDED.phases,
+	duration_ms = EXCLUDED.duration_ms,
+	peak_memory_bytes = EXCLUDED.peak_memory_bytes,
+	recorded_at = EXCLUDED.recorded_at
+`
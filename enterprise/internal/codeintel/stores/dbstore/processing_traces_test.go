@@ -0,0 +1,69 @@
+package dbstore
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+)
+
+func TestUploadProcessingTrace(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+	ctx := context.Background()
+
+	insertUploads(t, db, Upload{ID: 1}, Upload{ID: 2})
+
+	if _, exists, err := store.GetUploadProcessingTrace(ctx, 1); err != nil {
+		t.Fatalf("unexpected error getting processing trace: %s", err)
+	} else if exists {
+		t.Fatal("unexpected processing trace")
+	}
+
+	now := time.Unix(1587396557, 0).UTC()
+	for _, trace := range []UploadProcessingTrace{
+		{UploadID: 1, Duration: time.Minute, PeakMemoryBytes: 1024, RecordedAt: now.Add(-time.Hour)},
+		{
+			UploadID: 1,
+			Phases: []UploadProcessingPhase{
+				{Name: "parse", Start: 0, Duration: 3 * time.Second},
+				{Name: "correlate", Start: 3 * time.Second, Duration: time.Second},
+			},
+			Duration:        5 * time.Second,
+			PeakMemoryBytes: 2048,
+			RecordedAt:      now,
+		},
+	} {
+		if err := store.UpdateUploadProcessingTrace(ctx, trace); err != nil {
+			t.Fatalf("unexpected error updating processing trace: %s", err)
+		}
+	}
+
+	trace, exists, err := store.GetUploadProcessingTrace(ctx, 1)
+	if err != nil {
+		t.Fatalf("unexpected error getting processing trace: %s", err)
+	}
+	if !exists {
+		t.Fatal("expected processing trace to exist")
+	}
+
+	expected := UploadProcessingTrace{
+		UploadID: 1,
+		Phases: []UploadProcessingPhase{
+			{Name: "parse", Start: 0, Duration: 3 * time.Second},
+			{Name: "correlate", Start: 3 * time.Second, Duration: time.Second},
+		},
+		Duration:        5 * time.Second,
+		PeakMemoryBytes: 2048,
+		RecordedAt:      now,
+	}
+	if diff := cmp.Diff(expected, trace); diff != "" {
+		t.Errorf("unexpected processing trace (-want +got):\n%s", diff)
+	}
+}
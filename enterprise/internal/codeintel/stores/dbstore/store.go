@@ -13,20 +13,37 @@ import (
 
 type Store struct {
 	*basestore.Store
-	operations *operations
+	operations    *operations
+	copyBatchSize int
 }
 
 func NewWithDB(db dbutil.DB, observationContext *observation.Context) *Store {
 	return &Store{
-		Store:      basestore.NewWithDB(db, sql.TxOptions{}),
-		operations: newOperations(observationContext),
+		Store:         basestore.NewWithDB(db, sql.TxOptions{}),
+		operations:    newOperations(observationContext),
+		copyBatchSize: DefaultCopyBatchSize,
 	}
 }
 
 func (s *Store) With(other basestore.ShareableStore) *Store {
 	return &Store{
-		Store:      s.Store.With(other),
-		operations: s.operations,
+		Store:         s.Store.With(other),
+		operations:    s.operations,
+		copyBatchSize: s.copyBatchSize,
+	}
+}
+
+// WithCopyBatchSize returns a copy of the store that writes package and reference
+// rows with COPY statements of at most the given number of rows.
+func (s *Store) WithCopyBatchSize(batchSize int) *Store {
+	if batchSize <= 0 {
+		batchSize = DefaultCopyBatchSize
+	}
+
+	return &Store{
+		Store:         s.Store,
+		operations:    s.operations,
+		copyBatchSize: batchSize,
 	}
 }
 
@@ -41,8 +58,9 @@ func (s *Store) transact(ctx context.Context) (*Store, error) {
 	}
 
 	return &Store{
-		Store:      txBase,
-		operations: s.operations,
+		Store:         txBase,
+		operations:    s.operations,
+		copyBatchSize: s.copyBatchSize,
 	}, nil
 }
 
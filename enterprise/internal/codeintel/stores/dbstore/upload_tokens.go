@@ -0,0 +1,160 @@
+package dbstore
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"database/sql"
+	"encoding/hex"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// UploadToken is a token that permits uploading LSIF data for a single repository.
+// The secret value of the token is never stored, only its SHA-256 hash.
+type UploadToken struct {
+	ID            int
+	RepositoryID  int
+	Note          string
+	CreatorUserID *int
+	CreatedAt     time.Time
+	LastUsedAt    *time.Time
+}
+
+// scanUploadTokens scans a slice of upload tokens from the return value of `*Store.query`.
+func scanUploadTokens(rows *sql.Rows, queryErr error) (_ []UploadToken, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var tokens []UploadToken
+	for rows.Next() {
+		var token UploadToken
+		if err := rows.Scan(
+			&token.ID,
+			&token.RepositoryID,
+			&token.Note,
+			&token.CreatorUserID,
+			&token.CreatedAt,
+			&token.LastUsedAt,
+		); err != nil {
+			return nil, err
+		}
+
+		tokens = append(tokens, token)
+	}
+
+	return tokens, nil
+}
+
+// CreateUploadToken creates a new upload token for the given repository and returns its identifier
+// along with the secret token value. The caller is responsible for presenting the token value to the
+// user, as only a hash of it is retained.
+func (s *Store) CreateUploadToken(ctx context.Context, repositoryID, creatorUserID int, note string) (_ int, _ string, err error) {
+	ctx, endObservation := s.operations.createUploadToken.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+		log.Int("creatorUserID", creatorUserID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	var b [20]byte
+	if _, err := rand.Read(b[:]); err != nil {
+		return 0, "", err
+	}
+
+	id, _, err := basestore.ScanFirstInt(s.Store.Query(ctx, sqlf.Sprintf(createUploadTokenQuery, repositoryID, hashUploadToken(b[:]), note, creatorUserID)))
+	if err != nil {
+		return 0, "", err
+	}
+
+	return id, hex.EncodeToString(b[:]), nil
+}
+
+const createUploadTokenQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/upload_tokens.go:CreateUploadToken
+INSERT INTO lsif_upload_tokens (repository_id, value_sha256, note, creator_user_id)
+VALUES (%s, %s, %s, NULLIF(%s, 0))
+RETURNING id
+`
+
+// GetUploadTokens returns the live upload tokens for the given repository.
+func (s *Store) GetUploadTokens(ctx context.Context, repositoryID int) (_ []UploadToken, err error) {
+	ctx, endObservation := s.operations.getUploadTokens.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("repositoryID", repositoryID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	return scanUploadTokens(s.Store.Query(ctx, sqlf.Sprintf(getUploadTokensQuery, repositoryID)))
+}
+
+const getUploadTokensQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/upload_tokens.go:GetUploadTokens
+SELECT t.id, t.repository_id, t.note, t.creator_user_id, t.created_at, t.last_used_at
+FROM lsif_upload_tokens t
+WHERE t.repository_id = %s AND t.deleted_at IS NULL
+ORDER BY t.id
+`
+
+// DeleteUploadToken revokes the upload token with the given identifier. This method returns
+// a boolean flag indicating whether a live token existed.
+func (s *Store) DeleteUploadToken(ctx context.Context, id int) (_ bool, err error) {
+	ctx, endObservation := s.operations.deleteUploadToken.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("id", id),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	_, exists, err := basestore.ScanFirstInt(s.Store.Query(ctx, sqlf.Sprintf(deleteUploadTokenQuery, id)))
+	return exists, err
+}
+
+const deleteUploadTokenQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/upload_tokens.go:DeleteUploadToken
+UPDATE lsif_upload_tokens SET deleted_at = NOW()
+WHERE id = %s AND deleted_at IS NULL
+RETURNING id
+`
+
+// VerifyUploadToken returns true if the given token value belongs to a live upload token for the
+// repository with the given name. A successful verification updates the token's last-used date.
+//
+// 🚨 SECURITY: This returns true if and only if the token was minted for the given repository. It
+// returns false without distinguishing unknown tokens, revoked tokens, and tokens minted for other
+// repositories so that it cannot be used to probe for the existence of repositories.
+func (s *Store) VerifyUploadToken(ctx context.Context, repositoryName, token string) (_ bool, err error) {
+	ctx, endObservation := s.operations.verifyUploadToken.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.String("repositoryName", repositoryName),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	b, err := hex.DecodeString(token)
+	if err != nil {
+		return false, nil
+	}
+
+	_, exists, err := basestore.ScanFirstInt(s.Store.Query(ctx, sqlf.Sprintf(verifyUploadTokenQuery, hashUploadToken(b), repositoryName)))
+	return exists, err
+}
+
+const verifyUploadTokenQuery = `
+-- source: enterprise/internal/codeintel/stores/dbstore/upload_tokens.go:VerifyUploadToken
+UPDATE lsif_upload_tokens t SET last_used_at = NOW()
+FROM repo r
+WHERE
+	t.value_sha256 = %s AND
+	t.deleted_at IS NULL AND
+	r.id = t.repository_id AND
+	r.name = %s AND
+	r.deleted_at IS NULL
+RETURNING t.id
+`
+
+func hashUploadToken(value []byte) []byte {
+	b := sha256.Sum256(value)
+	return b[:]
+}
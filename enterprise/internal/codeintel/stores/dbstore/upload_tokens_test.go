@@ -0,0 +1,73 @@
+package dbstore
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+)
+
+func TestUploadTokens(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := testStore(db)
+	ctx := context.Background()
+
+	insertRepo(t, db, 50, "github.com/test/test")
+	insertRepo(t, db, 51, "github.com/test/other")
+
+	id, token, err := store.CreateUploadToken(ctx, 50, 0, "ci")
+	if err != nil {
+		t.Fatalf("unexpected error creating upload token: %s", err)
+	}
+	if len(token) != 40 {
+		t.Fatalf("unexpected token length. want=%d have=%d", 40, len(token))
+	}
+
+	for _, testCase := range []struct {
+		repositoryName string
+		token          string
+		expected       bool
+	}{
+		{"github.com/test/test", token, true},
+		{"github.com/test/other", token, false},
+		{"github.com/test/test", "deadbeef", false},
+		{"github.com/test/test", "not hex", false},
+	} {
+		if verified, err := store.VerifyUploadToken(ctx, testCase.repositoryName, testCase.token); err != nil {
+			t.Fatalf("unexpected error verifying upload token: %s", err)
+		} else if verified != testCase.expected {
+			t.Errorf("unexpected verification result for %s/%q. want=%v have=%v", testCase.repositoryName, testCase.token, testCase.expected, verified)
+		}
+	}
+
+	tokens, err := store.GetUploadTokens(ctx, 50)
+	if err != nil {
+		t.Fatalf("unexpected error getting upload tokens: %s", err)
+	}
+	if len(tokens) != 1 || tokens[0].ID != id || tokens[0].Note != "ci" || tokens[0].CreatorUserID != nil {
+		t.Fatalf("unexpected upload tokens: %+v", tokens)
+	}
+	if tokens[0].LastUsedAt == nil {
+		t.Errorf("expected last used date to be set")
+	}
+
+	if deleted, err := store.DeleteUploadToken(ctx, id); err != nil {
+		t.Fatalf("unexpected error deleting upload token: %s", err)
+	} else if !deleted {
+		t.Fatalf("expected upload token to be deleted")
+	}
+	if deleted, err := store.DeleteUploadToken(ctx, id); err != nil {
+		t.Fatalf("unexpected error deleting upload token: %s", err)
+	} else if deleted {
+		t.Fatalf("unexpected second deletion of upload token")
+	}
+
+	if verified, err := store.VerifyUploadToken(ctx, "github.com/test/test", token); err != nil {
+		t.Fatalf("unexpected error verifying upload token: %s", err)
+	} else if verified {
+		t.Errorf("unexpected verification of revoked upload token")
+	}
+}
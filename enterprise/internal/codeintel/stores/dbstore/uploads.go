@@ -583,20 +583,21 @@ UPDATE lsif_uploads u SET state = CASE WHEN u.state = 'completed' THEN 'deleting
 `
 
 // DeletedRepositoryGracePeriod is the minimum allowable duration between a repo deletion
-// and the upload and index records for that repository being deleted.
+// and the upload and index records for that repository being deleted. The records are kept
+// for longer if the site is configured with a longer restore window for deleted repositories.
 const DeletedRepositoryGracePeriod = time.Minute * 30
 
 // DeleteUploadsWithoutRepository deletes uploads associated with repositories that were deleted at least
-// DeletedRepositoryGracePeriod ago. This returns the repository identifier mapped to the number of uploads
+// the given grace period ago. This returns the repository identifier mapped to the number of uploads
 // that were removed for that repository.
-func (s *Store) DeleteUploadsWithoutRepository(ctx context.Context, now time.Time) (_ map[int]int, err error) {
+func (s *Store) DeleteUploadsWithoutRepository(ctx context.Context, gracePeriod time.Duration, now time.Time) (_ map[int]int, err error) {
 	ctx, traceLog, endObservation := s.operations.deleteUploadsWithoutRepository.WithAndLogger(ctx, &err, observation.Args{})
 	defer endObservation(1, observation.Args{})
 
 	// TODO(efritz) - this would benefit from an index on repository_id. We currently have
 	// a similar one on this index, but only for uploads that are completed or visible at tip.
 
-	repositories, err := scanCounts(s.Store.Query(ctx, sqlf.Sprintf(deleteUploadsWithoutRepositoryQuery, now.UTC(), DeletedRepositoryGracePeriod/time.Second)))
+	repositories, err := scanCounts(s.Store.Query(ctx, sqlf.Sprintf(deleteUploadsWithoutRepositoryQuery, now.UTC(), gracePeriod/time.Second)))
 	if err != nil {
 		return nil, err
 	}
@@ -794,7 +794,7 @@ func TestDeleteUploadsWithoutRepository(t *testing.T) {
 		}
 	}
 
-	deletedCounts, err := store.DeleteUploadsWithoutRepository(context.Background(), t1)
+	deletedCounts, err := store.DeleteUploadsWithoutRepository(context.Background(), DeletedRepositoryGracePeriod, t1)
 	if err != nil {
 		t.Fatalf("unexpected error deleting uploads: %s", err)
 	}
@@ -22,14 +22,15 @@ const StalledUploadMaxAge = time.Second * 5
 const UploadMaxNumResets = 3
 
 var uploadWorkerStoreOptions = dbworkerstore.Options{
-	Name:              "precise_code_intel_upload_worker_store",
-	TableName:         "lsif_uploads",
-	ViewName:          "lsif_uploads_with_repository_name u",
-	ColumnExpressions: uploadColumnsWithNullRank,
-	Scan:              scanFirstUploadRecord,
-	OrderByExpression: sqlf.Sprintf("u.uploaded_at, u.id"),
-	StalledMaxAge:     StalledUploadMaxAge,
-	MaxNumResets:      UploadMaxNumResets,
+	Name:                  "precise_code_intel_upload_worker_store",
+	TableName:             "lsif_uploads",
+	ViewName:              "lsif_uploads_with_repository_name u",
+	ColumnExpressions:     uploadColumnsWithNullRank,
+	Scan:                  scanFirstUploadRecord,
+	OrderByExpression:     sqlf.Sprintf("u.uploaded_at, u.id"),
+	FairnessKeyExpression: sqlf.Sprintf("u.repository_id"),
+	StalledMaxAge:         StalledUploadMaxAge,
+	MaxNumResets:          UploadMaxNumResets,
 }
 
 func WorkerutilUploadStore(s basestore.ShareableStore, observationContext *observation.Context) dbworkerstore.Store {
@@ -48,14 +49,15 @@ const StalledIndexMaxAge = time.Second * 5
 const IndexMaxNumResets = 3
 
 var indexWorkerStoreOptions = dbworkerstore.Options{
-	Name:              "precise_code_intel_index_worker_store",
-	TableName:         "lsif_indexes",
-	ViewName:          "lsif_indexes_with_repository_name u",
-	ColumnExpressions: indexColumnsWithNullRank,
-	Scan:              scanFirstIndexRecord,
-	OrderByExpression: sqlf.Sprintf("u.queued_at, u.id"),
-	StalledMaxAge:     StalledIndexMaxAge,
-	MaxNumResets:      IndexMaxNumResets,
+	Name:                  "precise_code_intel_index_worker_store",
+	TableName:             "lsif_indexes",
+	ViewName:              "lsif_indexes_with_repository_name u",
+	ColumnExpressions:     indexColumnsWithNullRank,
+	Scan:                  scanFirstIndexRecord,
+	OrderByExpression:     sqlf.Sprintf("u.queued_at, u.id"),
+	FairnessKeyExpression: sqlf.Sprintf("u.repository_id"),
+	StalledMaxAge:         StalledIndexMaxAge,
+	MaxNumResets:          IndexMaxNumResets,
 }
 
 func WorkerutilIndexStore(s basestore.ShareableStore, observationContext *observation.Context) dbworkerstore.Store {
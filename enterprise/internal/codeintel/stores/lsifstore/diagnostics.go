@@ -7,14 +7,17 @@ import (
 	"github.com/opentracing/opentracing-go/log"
 
 	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
 )
 
-// Diagnostics returns the diagnostics for the documents that have the given path prefix. This method
-// also returns the size of the complete result set to aid in pagination.
-func (s *Store) Diagnostics(ctx context.Context, bundleID int, prefix string, limit, offset int) (_ []Diagnostic, _ int, err error) {
+// Diagnostics returns the diagnostics for the documents that have the given path prefix. If any
+// severities are given, only diagnostics with one of those severities are returned. This method
+// also returns the size of the complete (filtered) result set to aid in pagination.
+func (s *Store) Diagnostics(ctx context.Context, bundleID int, prefix string, severities []int, limit, offset int) (_ []Diagnostic, _ int, err error) {
 	ctx, traceLog, endObservation := s.operations.diagnostics.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
 		log.Int("bundleID", bundleID),
 		log.String("prefix", prefix),
+		log.String("severities", intsToString(severities)),
 		log.Int("limit", limit),
 		log.Int("offset", offset),
 	}})
@@ -27,14 +30,14 @@ func (s *Store) Diagnostics(ctx context.Context, bundleID int, prefix string, li
 	traceLog(log.Int("numDocuments", len(documentData)))
 
 	totalCount := 0
-	for _, documentData := range documentData {
-		totalCount += len(documentData.Document.Diagnostics)
-	}
-	traceLog(log.Int("totalCount", totalCount))
-
 	diagnostics := make([]Diagnostic, 0, limit)
 	for _, documentData := range documentData {
 		for _, diagnostic := range documentData.Document.Diagnostics {
+			if !matchesSeverity(diagnostic, severities) {
+				continue
+			}
+
+			totalCount++
 			offset--
 
 			if offset < 0 && len(diagnostics) < limit {
@@ -46,10 +49,27 @@ func (s *Store) Diagnostics(ctx context.Context, bundleID int, prefix string, li
 			}
 		}
 	}
+	traceLog(log.Int("totalCount", totalCount))
 
 	return diagnostics, totalCount, nil
 }
 
+// matchesSeverity returns true if the diagnostic has one of the given severities, or if no
+// severities are given.
+func matchesSeverity(diagnostic semantic.DiagnosticData, severities []int) bool {
+	if len(severities) == 0 {
+		return true
+	}
+
+	for _, severity := range severities {
+		if diagnostic.Severity == severity {
+			return true
+		}
+	}
+
+	return false
+}
+
 const diagnosticsQuery = `
 -- source: enterprise/internal/codeintel/stores/lsifstore/diagnostics.go:Diagnostics
 SELECT
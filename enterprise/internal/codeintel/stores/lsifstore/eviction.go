@@ -0,0 +1,218 @@
+package lsifstore
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+// evictableTableNames are the tables holding the data of a bundle that is moved to
+// object storage when the bundle is evicted.
+var evictableTableNames = append(append([]string{}, tableNames...),
+	"lsif_data_documentation_pages",
+	"lsif_data_documentation_path_info",
+	"lsif_data_documentation_mappings",
+)
+
+// EvictedBundleKey returns the object storage key of the exported data of the given bundle.
+func EvictedBundleKey(bundleID int) string {
+	return fmt.Sprintf("evicted-bundle-%d.json.gz", bundleID)
+}
+
+// bundleRecord is a single row of an exported bundle.
+type bundleRecord struct {
+	Table string          `json:"table"`
+	Row   json.RawMessage `json:"row"`
+}
+
+// ExportBundle writes all rows of the given bundle to w as newline-delimited JSON records.
+// The output can be restored with ImportBundle.
+func (s *Store) ExportBundle(ctx context.Context, bundleID int, w io.Writer) (err error) {
+	ctx, traceLog, endObservation := s.operations.exportBundle.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tx, err := s.Store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	encoder := json.NewEncoder(w)
+	for _, tableName := range evictableTableNames {
+		numRows, err := exportTable(ctx, tx, tableName, bundleID, encoder)
+		if err != nil {
+			return err
+		}
+		traceLog(log.String("tableName", tableName), log.Int("numRows", numRows))
+	}
+
+	return nil
+}
+
+// exportTable streams the rows of the given bundle in the given table to the encoder.
+func exportTable(ctx context.Context, tx *basestore.Store, tableName string, bundleID int, encoder *json.Encoder) (numRows int, err error) {
+	rows, err := tx.Query(ctx, sqlf.Sprintf(exportBundleQuery, sqlf.Sprintf(tableName), bundleID))
+	if err != nil {
+		return 0, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	for rows.Next() {
+		var row string
+		if err := rows.Scan(&row); err != nil {
+			return 0, err
+		}
+
+		if err := encoder.Encode(bundleRecord{Table: tableName, Row: json.RawMessage(row)}); err != nil {
+			return 0, err
+		}
+		numRows++
+	}
+
+	return numRows, nil
+}
+
+const exportBundleQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/eviction.go:ExportBundle
+SELECT row_to_json(t)::text FROM %s t WHERE t.dump_id = %s
+`
+
+// importBundleBatchSize is the maximum number of rows inserted by a single query in ImportBundle.
+const importBundleBatchSize = 500
+
+// ImportBundle restores the rows of the given bundle written by ExportBundle. Concurrent
+// imports of the same bundle are serialized, and the import is skipped if the bundle
+// already has data in the database.
+func (s *Store) ImportBundle(ctx context.Context, bundleID int, r io.Reader) (err error) {
+	ctx, traceLog, endObservation := s.operations.importBundle.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tx, err := s.Store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(importBundleLockQuery, bundleID)); err != nil {
+		return err
+	}
+
+	count, _, err := basestore.ScanFirstInt(tx.Query(ctx, sqlf.Sprintf(importBundleExistsQuery, bundleID)))
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		traceLog(log.Bool("alreadyImported", true))
+		return nil
+	}
+
+	isEvictable := make(map[string]bool, len(evictableTableNames))
+	for _, tableName := range evictableTableNames {
+		isEvictable[tableName] = true
+	}
+
+	var (
+		tableName string
+		batch     []string
+		numRows   int
+	)
+
+	flush := func() error {
+		if len(batch) == 0 {
+			return nil
+		}
+
+		rows := "[" + strings.Join(batch, ",") + "]"
+		batch = batch[:0]
+		return tx.Exec(ctx, sqlf.Sprintf(importBundleQuery, sqlf.Sprintf(tableName), sqlf.Sprintf(tableName), rows, bundleID))
+	}
+
+	decoder := json.NewDecoder(r)
+	for {
+		var record bundleRecord
+		if err := decoder.Decode(&record); err != nil {
+			if err == io.EOF {
+				break
+			}
+
+			return errors.Wrap(err, "failed to decode bundle record")
+		}
+
+		// 🚨 SECURITY: The table name is interpolated into the query.
+		if !isEvictable[record.Table] {
+			return errors.Errorf("unexpected table %q in bundle data", record.Table)
+		}
+
+		if record.Table != tableName || len(batch) >= importBundleBatchSize {
+			if err := flush(); err != nil {
+				return err
+			}
+			tableName = record.Table
+		}
+
+		batch = append(batch, string(record.Row))
+		numRows++
+	}
+
+	if err := flush(); err != nil {
+		return err
+	}
+	traceLog(log.Int("numRows", numRows))
+
+	return nil
+}
+
+const importBundleLockQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/eviction.go:ImportBundle
+SELECT pg_advisory_xact_lock(hashtext('lsif_data_import'), %s)
+`
+
+const importBundleExistsQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/eviction.go:ImportBundle
+SELECT COUNT(*) FROM lsif_data_metadata WHERE dump_id = %s
+`
+
+const importBundleQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/eviction.go:ImportBundle
+INSERT INTO %s SELECT r.* FROM json_populate_recordset(NULL::%s, %s::json) r WHERE r.dump_id = %s
+`
+
+// DeleteBundle removes all rows of the given bundle, including its documentation data.
+func (s *Store) DeleteBundle(ctx context.Context, bundleID int) (err error) {
+	ctx, endObservation := s.operations.deleteBundle.With(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	tx, err := s.Store.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	for _, tableName := range evictableTableNames {
+		if err := tx.Exec(ctx, sqlf.Sprintf(deleteBundleQuery, sqlf.Sprintf(tableName), bundleID)); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+const deleteBundleQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/eviction.go:DeleteBundle
+DELETE FROM %s WHERE dump_id = %s
+`
@@ -0,0 +1,86 @@
+package lsifstore
+
+import (
+	"bytes"
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtesting"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+)
+
+func TestExportImportBundle(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	populateTestStore(t)
+	store := NewStore(db, &observation.TestContext)
+	ctx := context.Background()
+
+	countRows := func() map[string]int {
+		counts := map[string]int{}
+		for _, tableName := range evictableTableNames {
+			query := sqlf.Sprintf("SELECT COUNT(*) FROM %s WHERE dump_id = %s", sqlf.Sprintf(tableName), testBundleID)
+			count, _, err := basestore.ScanFirstInt(db.Query(query.Query(sqlf.PostgresBindVar), query.Args()...))
+			if err != nil {
+				t.Fatalf("unexpected error counting rows: %s", err)
+			}
+			counts[tableName] = count
+		}
+		return counts
+	}
+	before := countRows()
+
+	var buf bytes.Buffer
+	if err := store.ExportBundle(ctx, testBundleID, &buf); err != nil {
+		t.Fatalf("unexpected error exporting bundle: %s", err)
+	}
+	exported := buf.Bytes()
+
+	if err := store.DeleteBundle(ctx, testBundleID); err != nil {
+		t.Fatalf("unexpected error deleting bundle: %s", err)
+	}
+	if exists, err := store.Exists(ctx, testBundleID, "cmd/lsif-go/main.go"); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	} else if exists {
+		t.Fatalf("expected bundle data to be deleted")
+	}
+
+	if err := store.ImportBundle(ctx, testBundleID, bytes.NewReader(exported)); err != nil {
+		t.Fatalf("unexpected error importing bundle: %s", err)
+	}
+	if diff := cmp.Diff(before, countRows()); diff != "" {
+		t.Errorf("unexpected row counts after import (-want +got):\n%s", diff)
+	}
+	if exists, err := store.Exists(ctx, testBundleID, "cmd/lsif-go/main.go"); err != nil {
+		t.Fatalf("unexpected error %s", err)
+	} else if !exists {
+		t.Errorf("expected bundle data to be restored")
+	}
+
+	// Importing a bundle that has data is a no-op
+	if err := store.ImportBundle(ctx, testBundleID, bytes.NewReader(exported)); err != nil {
+		t.Fatalf("unexpected error re-importing bundle: %s", err)
+	}
+	if diff := cmp.Diff(before, countRows()); diff != "" {
+		t.Errorf("unexpected row counts after re-import (-want +got):\n%s", diff)
+	}
+}
+
+func TestImportBundleUnexpectedTable(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtesting.GetDB(t)
+	store := NewStore(db, &observation.TestContext)
+
+	data := `{"table":"lsif_uploads","row":{"dump_id":1}}` + "\n"
+	if err := store.ImportBundle(context.Background(), 1, bytes.NewReader([]byte(data))); err == nil {
+		t.Fatalf("expected error importing rows of an unexpected table")
+	}
+}
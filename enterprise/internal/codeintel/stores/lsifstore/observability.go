@@ -11,14 +11,18 @@ type operations struct {
 	bulkMonikerResults         *observation.Operation
 	clear                      *observation.Operation
 	definitions                *observation.Operation
+	deleteBundle               *observation.Operation
 	diagnostics                *observation.Operation
 	exists                     *observation.Operation
+	exportBundle               *observation.Operation
 	hover                      *observation.Operation
+	importBundle               *observation.Operation
 	monikerResults             *observation.Operation
 	monikersByPosition         *observation.Operation
 	packageInformation         *observation.Operation
 	ranges                     *observation.Operation
 	references                 *observation.Operation
+	symbols                    *observation.Operation
 	documentationPage          *observation.Operation
 	documentationPathInfo      *observation.Operation
 	documentationIDsToPathIDs  *observation.Operation
@@ -68,14 +72,18 @@ func newOperations(observationContext *observation.Context) *operations {
 		bulkMonikerResults:         op("BulkMonikerResults"),
 		clear:                      op("Clear"),
 		definitions:                op("Definitions"),
+		deleteBundle:               op("DeleteBundle"),
 		diagnostics:                op("Diagnostics"),
 		exists:                     op("Exists"),
+		exportBundle:               op("ExportBundle"),
 		hover:                      op("Hover"),
+		importBundle:               op("ImportBundle"),
 		monikerResults:             op("MonikerResults"),
 		monikersByPosition:         op("MonikersByPosition"),
 		packageInformation:         op("PackageInformation"),
 		ranges:                     op("Ranges"),
 		references:                 op("References"),
+		symbols:                    op("Symbols"),
 		documentationPage:          op("DocumentationPage"),
 		documentationPathInfo:      op("DocumentationPathInfo"),
 		documentationIDsToPathIDs:  op("DocumentationIDsToPathIDs"),
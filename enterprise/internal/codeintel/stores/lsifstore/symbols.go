@@ -0,0 +1,88 @@
+package lsifstore
+
+import (
+	"context"
+	"sort"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
+)
+
+// Symbols returns the definitions of the given bundle that were tagged with symbol metadata by the
+// indexer and for which the given filter function returns true. The filter is called with the path
+// of the containing document and the name of the symbol. At most limit symbols are returned.
+func (s *Store) Symbols(ctx context.Context, bundleID int, filter func(path, name string) bool, limit int) (_ []Symbol, err error) {
+	ctx, traceLog, endObservation := s.operations.symbols.WithAndLogger(ctx, &err, observation.Args{LogFields: []log.Field{
+		log.Int("bundleID", bundleID),
+		log.Int("limit", limit),
+	}})
+	defer endObservation(1, observation.Args{})
+
+	var symbols []Symbol
+	visitDocuments := s.makeDocumentVisitor(func(path string, document semantic.DocumentData) {
+		if len(symbols) >= limit {
+			return
+		}
+
+		symbols = append(symbols, extractSymbols(bundleID, path, document, filter)...)
+	})
+	if err := visitDocuments(s.Store.Query(ctx, sqlf.Sprintf(symbolsQuery, bundleID))); err != nil {
+		return nil, err
+	}
+	traceLog(log.Int("numSymbols", len(symbols)))
+
+	sort.Slice(symbols, func(i, j int) bool {
+		if symbols[i].Path != symbols[j].Path {
+			return symbols[i].Path < symbols[j].Path
+		}
+		return compareBundleRanges(symbols[i].Range, symbols[j].Range)
+	})
+	if len(symbols) > limit {
+		symbols = symbols[:limit]
+	}
+
+	return symbols, nil
+}
+
+const symbolsQuery = `
+-- source: enterprise/internal/codeintel/stores/lsifstore/symbols.go:Symbols
+SELECT
+	dump_id,
+	path,
+	data,
+	ranges,
+	NULL AS hovers,
+	NULL AS monikers,
+	NULL AS packages,
+	NULL AS diagnostics
+FROM
+	lsif_data_documents
+WHERE
+	dump_id = %s
+ORDER BY path
+`
+
+// extractSymbols returns a symbol for each tagged definition range of the given document
+// that passes the given filter.
+func extractSymbols(bundleID int, path string, document semantic.DocumentData, filter func(path, name string) bool) []Symbol {
+	var symbols []Symbol
+	for _, r := range document.Ranges {
+		if r.Tag == nil || r.Tag.Text == "" || !filter(path, r.Tag.Text) {
+			continue
+		}
+
+		symbols = append(symbols, Symbol{
+			DumpID: bundleID,
+			Path:   path,
+			Name:   r.Tag.Text,
+			Kind:   r.Tag.Kind,
+			Detail: r.Tag.Detail,
+			Range:  newRange(r.StartLine, r.StartCharacter, r.EndLine, r.EndCharacter),
+		})
+	}
+
+	return symbols
+}
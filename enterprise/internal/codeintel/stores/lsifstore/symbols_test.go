@@ -0,0 +1,35 @@
+package lsifstore
+
+import (
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
+)
+
+func TestExtractSymbols(t *testing.T) {
+	document := semantic.DocumentData{
+		Ranges: map[semantic.ID]semantic.RangeData{
+			"1": {StartLine: 3, StartCharacter: 5, EndLine: 3, EndCharacter: 12, Tag: &protocol.RangeTag{Type: "definition", Text: "NewStore", Kind: protocol.Function, Detail: "func NewStore() *Store"}},
+			"2": {StartLine: 7, StartCharacter: 1, EndLine: 7, EndCharacter: 6, Tag: &protocol.RangeTag{Type: "definition", Text: "Store", Kind: protocol.Struct}},
+			"3": {StartLine: 9, StartCharacter: 2, EndLine: 9, EndCharacter: 7},
+			"4": {StartLine: 12, StartCharacter: 0, EndLine: 12, EndCharacter: 8, Tag: &protocol.RangeTag{Type: "definition", Text: "unrelated", Kind: protocol.Variable}},
+		},
+	}
+
+	filter := func(path, name string) bool { return strings.Contains(name, "Store") }
+	symbols := extractSymbols(42, "store.go", document, filter)
+	sort.Slice(symbols, func(i, j int) bool { return symbols[i].Range.Start.Line < symbols[j].Range.Start.Line })
+
+	expected := []Symbol{
+		{DumpID: 42, Path: "store.go", Name: "NewStore", Kind: protocol.Function, Detail: "func NewStore() *Store", Range: newRange(3, 5, 3, 12)},
+		{DumpID: 42, Path: "store.go", Name: "Store", Kind: protocol.Struct, Range: newRange(7, 1, 7, 6)},
+	}
+	if diff := cmp.Diff(expected, symbols); diff != "" {
+		t.Errorf("unexpected symbols (-want +got):\n%s", diff)
+	}
+}
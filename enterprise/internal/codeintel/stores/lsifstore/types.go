@@ -1,6 +1,7 @@
 package lsifstore
 
 import (
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
 )
 
@@ -45,6 +46,17 @@ type Diagnostic struct {
 	semantic.DiagnosticData
 }
 
+// Symbol is a definition within a particular dump that the indexer tagged with
+// symbol metadata.
+type Symbol struct {
+	DumpID int
+	Path   string
+	Name   string
+	Kind   protocol.SymbolKind
+	Detail string
+	Range  Range
+}
+
 // CodeIntelligenceRange pairs a range with its definitions, reference, hover text, and documentation.
 type CodeIntelligenceRange struct {
 	Range               Range
@@ -1,3 +0,0 @@
-package mocks
-
-//go:generate ../../../../../../dev/mockgen.sh github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore -i Store -o mock_store.go
@@ -148,6 +148,23 @@ AND id > %s
 LIMIT %s;
 `
 
+const listActionEmailsFmtStr = `
+SELECT %s
+FROM cm_emails
+WHERE monitor = %s
+ORDER BY id ASC
+`
+
+// ListActionEmails returns all email actions of a monitor.
+func (s *Store) ListActionEmails(ctx context.Context, monitorID int64) ([]*MonitorEmail, error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(listActionEmailsFmtStr, sqlf.Join(EmailsColumns, ", "), monitorID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return ScanEmails(rows)
+}
+
 func (s *Store) ReadActionEmailQuery(ctx context.Context, monitorID int64, args *graphqlbackend.ListActionArgs) (*sqlf.Query, error) {
 	after, err := unmarshalAfter(args.After)
 	if err != nil {
@@ -15,9 +15,13 @@ import (
 
 type ActionJob struct {
 	Id           int
-	Email        int64
 	TriggerEvent int
 
+	// Exactly one of the actions is set.
+	Email        *int64
+	Webhook      *int64
+	SlackWebhook *int64
+
 	// Fields demanded by any dbworker.
 	State          string
 	FailureMessage *string
@@ -45,6 +49,8 @@ type ActionJobMetadata struct {
 var ActionJobsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_action_jobs.id"),
 	sqlf.Sprintf("cm_action_jobs.email"),
+	sqlf.Sprintf("cm_action_jobs.webhook"),
+	sqlf.Sprintf("cm_action_jobs.slack_webhook"),
 	sqlf.Sprintf("cm_action_jobs.trigger_event"),
 	sqlf.Sprintf("cm_action_jobs.state"),
 	sqlf.Sprintf("cm_action_jobs.failure_message"),
@@ -56,8 +62,8 @@ var ActionJobsColumns = []*sqlf.Query{
 	sqlf.Sprintf("cm_action_jobs.log_contents"),
 }
 
-const readActionEmailEventsFmtStr = `
-SELECT id, email, trigger_event, state, failure_message, started_at, finished_at, process_after, num_resets, num_failures, log_contents
+const readActionEventsFmtStr = `
+SELECT %s
 FROM cm_action_jobs
 WHERE %s
 AND id > %s
@@ -66,18 +72,24 @@ LIMIT %s;
 `
 
 func (s *Store) ReadActionEmailEvents(ctx context.Context, emailID int64, triggerEventID *int, args *graphqlbackend.ListEventsArgs) (js []*ActionJob, err error) {
-	var where *sqlf.Query
-	if triggerEventID == nil {
-		where = sqlf.Sprintf("email = %s", emailID)
-	} else {
-		where = sqlf.Sprintf("email = %s AND trigger_event = %s", emailID, *triggerEventID)
-	}
+	return s.readActionEvents(ctx, actionEventsWhere("email", emailID, triggerEventID), args)
+}
+
+func (s *Store) ReadActionWebhookEvents(ctx context.Context, webhookID int64, triggerEventID *int, args *graphqlbackend.ListEventsArgs) (js []*ActionJob, err error) {
+	return s.readActionEvents(ctx, actionEventsWhere("webhook", webhookID, triggerEventID), args)
+}
+
+func (s *Store) ReadActionSlackWebhookEvents(ctx context.Context, slackWebhookID int64, triggerEventID *int, args *graphqlbackend.ListEventsArgs) (js []*ActionJob, err error) {
+	return s.readActionEvents(ctx, actionEventsWhere("slack_webhook", slackWebhookID, triggerEventID), args)
+}
+
+func (s *Store) readActionEvents(ctx context.Context, where *sqlf.Query, args *graphqlbackend.ListEventsArgs) (js []*ActionJob, err error) {
 	var rows *sql.Rows
 	after, err := unmarshalAfter(args.After)
 	if err != nil {
 		return nil, err
 	}
-	rows, err = s.Query(ctx, sqlf.Sprintf(readActionEmailEventsFmtStr, where, after, args.First))
+	rows, err = s.Query(ctx, sqlf.Sprintf(readActionEventsFmtStr, sqlf.Join(ActionJobsColumns, ", "), where, after, args.First))
 	if err != nil {
 		return nil, err
 	}
@@ -85,43 +97,81 @@ func (s *Store) ReadActionEmailEvents(ctx context.Context, emailID int64, trigge
 	return scanActionJobs(rows, err)
 }
 
-const totalActionEmailEventsFmtStr = `
+const totalActionEventsFmtStr = `
 SELECT COUNT(*)
 FROM cm_action_jobs
 WHERE %s
 `
 
 func (s *Store) TotalActionEmailEvents(ctx context.Context, emailID int64, triggerEventID *int) (totalCount int32, err error) {
-	var where *sqlf.Query
-	if triggerEventID == nil {
-		where = sqlf.Sprintf("email = %s", emailID)
-	} else {
-		where = sqlf.Sprintf("email = %s AND trigger_event = %s", emailID, *triggerEventID)
-	}
-	err = s.QueryRow(ctx, sqlf.Sprintf(totalActionEmailEventsFmtStr, where)).Scan(&totalCount)
+	return s.totalActionEvents(ctx, actionEventsWhere("email", emailID, triggerEventID))
+}
+
+func (s *Store) TotalActionWebhookEvents(ctx context.Context, webhookID int64, triggerEventID *int) (totalCount int32, err error) {
+	return s.totalActionEvents(ctx, actionEventsWhere("webhook", webhookID, triggerEventID))
+}
+
+func (s *Store) TotalActionSlackWebhookEvents(ctx context.Context, slackWebhookID int64, triggerEventID *int) (totalCount int32, err error) {
+	return s.totalActionEvents(ctx, actionEventsWhere("slack_webhook", slackWebhookID, triggerEventID))
+}
+
+func (s *Store) totalActionEvents(ctx context.Context, where *sqlf.Query) (totalCount int32, err error) {
+	err = s.QueryRow(ctx, sqlf.Sprintf(totalActionEventsFmtStr, where)).Scan(&totalCount)
 	if err != nil {
 		return -1, err
 	}
 	return totalCount, nil
 }
 
-const enqueueActionEmailFmtStr = `
+// actionEventsWhere returns the condition for the jobs of the action with the
+// given ID in column. If triggerEventID is not nil, only the jobs of that
+// trigger event match.
+func actionEventsWhere(column string, actionID int64, triggerEventID *int) *sqlf.Query {
+	if triggerEventID == nil {
+		return sqlf.Sprintf(column+" = %s", actionID)
+	}
+	return sqlf.Sprintf(column+" = %s AND trigger_event = %s", actionID, *triggerEventID)
+}
+
+const enqueueActionFmtStr = `
 WITH due AS (
-	SELECT e.id, e.monitor, e.enabled, e.priority, e.header, e.created_by, e.created_at, e.changed_by, e.changed_at
-	FROM cm_emails e INNER JOIN cm_queries q ON e.monitor = q.monitor
-	WHERE q.id = %s AND e.enabled = true
+	SELECT a.id
+	FROM %s a INNER JOIN cm_queries q ON a.monitor = q.monitor
+	WHERE q.id = %s AND a.enabled = true
 ),
 busy AS (
-    SELECT DISTINCT email as id FROM cm_action_jobs
+    SELECT DISTINCT %s as id FROM cm_action_jobs
     WHERE state = 'queued'
     OR state = 'processing'
 )
-INSERT INTO cm_action_jobs (email, trigger_event)
+INSERT INTO cm_action_jobs (%s, trigger_event)
 SELECT id, %s::integer from due EXCEPT SELECT id, %s::integer from busy ORDER BY id
 `
 
 func (s *Store) EnqueueActionEmailsForQueryIDInt64(ctx context.Context, queryID int64, triggerEventID int) (err error) {
-	return s.Store.Exec(ctx, sqlf.Sprintf(enqueueActionEmailFmtStr, queryID, triggerEventID, triggerEventID))
+	return s.enqueueActions(ctx, "cm_emails", "email", queryID, triggerEventID)
+}
+
+func (s *Store) EnqueueActionWebhooksForQueryIDInt64(ctx context.Context, queryID int64, triggerEventID int) (err error) {
+	return s.enqueueActions(ctx, "cm_webhooks", "webhook", queryID, triggerEventID)
+}
+
+func (s *Store) EnqueueActionSlackWebhooksForQueryIDInt64(ctx context.Context, queryID int64, triggerEventID int) (err error) {
+	return s.enqueueActions(ctx, "cm_slack_webhooks", "slack_webhook", queryID, triggerEventID)
+}
+
+// enqueueActions enqueues a job for each enabled action in table of the
+// monitor of the query, unless the action already has a pending job.
+func (s *Store) enqueueActions(ctx context.Context, table, column string, queryID int64, triggerEventID int) error {
+	return s.Store.Exec(ctx, sqlf.Sprintf(
+		enqueueActionFmtStr,
+		sqlf.Sprintf(table),
+		queryID,
+		sqlf.Sprintf(column),
+		sqlf.Sprintf(column),
+		triggerEventID,
+		triggerEventID,
+	))
 }
 
 const getActionJobMetadataFmtStr = `
@@ -144,13 +194,13 @@ func (s *Store) GetActionJobMetadata(ctx context.Context, recordID int) (m *Acti
 }
 
 const actionJobForIDFmtStr = `
-SELECT id, email, trigger_event, state, failure_message, started_at, finished_at, process_after, num_resets, num_failures, log_contents
+SELECT %s
 FROM cm_action_jobs
 WHERE id = %s
 `
 
 func (s *Store) ActionJobForIDInt(ctx context.Context, recordID int) (*ActionJob, error) {
-	return s.runActionJobQuery(ctx, sqlf.Sprintf(actionJobForIDFmtStr, recordID))
+	return s.runActionJobQuery(ctx, sqlf.Sprintf(actionJobForIDFmtStr, sqlf.Join(ActionJobsColumns, ", "), recordID))
 }
 
 func (s *Store) runActionJobQuery(ctx context.Context, q *sqlf.Query) (ajs *ActionJob, err error) {
@@ -190,6 +240,8 @@ func scanActionJobs(rows *sql.Rows, err error) ([]*ActionJob, error) {
 		if err := rows.Scan(
 			&aj.Id,
 			&aj.Email,
+			&aj.Webhook,
+			&aj.SlackWebhook,
 			&aj.TriggerEvent,
 			&aj.State,
 			&aj.FailureMessage,
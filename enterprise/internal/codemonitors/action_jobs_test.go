@@ -7,6 +7,8 @@ import (
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
 )
 
 func TestEnqueueActionEmailsForQueryIDInt64QueryByRecordID(t *testing.T) {
@@ -35,9 +37,10 @@ func TestEnqueueActionEmailsForQueryIDInt64QueryByRecordID(t *testing.T) {
 		t.Fatal(err)
 	}
 
+	emailID := int64(1)
 	want := &ActionJob{
 		Id:             1,
-		Email:          1,
+		Email:          &emailID,
 		TriggerEvent:   1,
 		State:          "queued",
 		FailureMessage: nil,
@@ -53,6 +56,42 @@ func TestEnqueueActionEmailsForQueryIDInt64QueryByRecordID(t *testing.T) {
 	}
 }
 
+func TestEnqueueActionWebhooksForQueryIDInt64(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	ctx, s := newTestStore(t)
+	_, _, _, userCTX := newTestUser(ctx, t)
+	m, err := s.insertTestMonitor(userCTX, t)
+	if err != nil {
+		t.Fatal(err)
+	}
+	w, err := s.CreateActionWebhook(userCTX, m.ID, &graphqlbackend.CreateActionWebhookArgs{
+		Enabled: true,
+		URL:     "https://example.com/webhook",
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.EnqueueTriggerQueries(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = s.EnqueueActionWebhooksForQueryIDInt64(ctx, 1, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := s.ActionJobForIDInt(ctx, 1)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got.Webhook == nil || *got.Webhook != w.Id || got.Email != nil || got.SlackWebhook != nil {
+		t.Fatalf("want job for webhook %d, got %+v", w.Id, got)
+	}
+}
+
 func TestGetActionJobMetadata(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
@@ -124,7 +163,7 @@ func TestScanActionJobs(t *testing.T) {
 		t.Fatal(err)
 	}
 	var rows *sql.Rows
-	rows, err = s.Query(ctx, sqlf.Sprintf(actionJobForIDFmtStr, testRecordID))
+	rows, err = s.Query(ctx, sqlf.Sprintf(actionJobForIDFmtStr, sqlf.Join(ActionJobsColumns, ", "), testRecordID))
 	record, _, err := ScanActionJobs(rows, err)
 	if err != nil {
 		t.Fatal(err)
@@ -0,0 +1,174 @@
+package codemonitors
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+)
+
+// MonitorSlackWebhook is an action which posts a message about new results of a
+// code monitor to a Slack incoming webhook.
+type MonitorSlackWebhook struct {
+	Id        int64
+	Monitor   int64
+	Enabled   bool
+	URL       string
+	CreatedBy int32
+	CreatedAt time.Time
+	ChangedBy int32
+	ChangedAt time.Time
+}
+
+const createActionSlackWebhookFmtStr = `
+INSERT INTO cm_slack_webhooks
+(monitor, enabled, url, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s)
+RETURNING %s;
+`
+
+func (s *Store) CreateActionSlackWebhook(ctx context.Context, monitorID int64, args *graphqlbackend.CreateActionSlackWebhookArgs) (*MonitorSlackWebhook, error) {
+	if err := ValidateWebhookURL(args.URL); err != nil {
+		return nil, err
+	}
+	now := s.Now()
+	a := actor.FromContext(ctx)
+	return s.runSlackWebhookQuery(ctx, sqlf.Sprintf(
+		createActionSlackWebhookFmtStr,
+		monitorID,
+		args.Enabled,
+		args.URL,
+		a.UID,
+		now,
+		a.UID,
+		now,
+		sqlf.Join(slackWebhooksColumns, ", "),
+	))
+}
+
+const updateActionSlackWebhookFmtStr = `
+UPDATE cm_slack_webhooks
+SET enabled = %s,
+	url = %s,
+	changed_by = %s,
+	changed_at = %s
+WHERE id = %s
+AND monitor = %s
+RETURNING %s;
+`
+
+func (s *Store) UpdateActionSlackWebhook(ctx context.Context, monitorID int64, args *graphqlbackend.EditActionSlackWebhookArgs) (*MonitorSlackWebhook, error) {
+	if args.Id == nil {
+		return nil, errors.Errorf("nil is not a valid action ID")
+	}
+	var actionID int64
+	if err := relay.UnmarshalSpec(*args.Id, &actionID); err != nil {
+		return nil, err
+	}
+	if err := ValidateWebhookURL(args.Update.URL); err != nil {
+		return nil, err
+	}
+	a := actor.FromContext(ctx)
+	return s.runSlackWebhookQuery(ctx, sqlf.Sprintf(
+		updateActionSlackWebhookFmtStr,
+		args.Update.Enabled,
+		args.Update.URL,
+		a.UID,
+		s.Now(),
+		actionID,
+		monitorID,
+		sqlf.Join(slackWebhooksColumns, ", "),
+	))
+}
+
+const deleteActionSlackWebhooksFmtStr = `DELETE FROM cm_slack_webhooks WHERE id = ANY(%s) AND monitor = %s`
+
+func (s *Store) DeleteActionSlackWebhooksInt64(ctx context.Context, actionIDs []int64, monitorID int64) error {
+	if len(actionIDs) == 0 {
+		return nil
+	}
+	return s.Exec(ctx, sqlf.Sprintf(deleteActionSlackWebhooksFmtStr, pq.Array(actionIDs), monitorID))
+}
+
+const actionSlackWebhookByIDFmtStr = `
+SELECT %s
+FROM cm_slack_webhooks
+WHERE id = %s
+`
+
+func (s *Store) ActionSlackWebhookByIDInt64(ctx context.Context, webhookID int64) (*MonitorSlackWebhook, error) {
+	return s.runSlackWebhookQuery(ctx, sqlf.Sprintf(actionSlackWebhookByIDFmtStr, sqlf.Join(slackWebhooksColumns, ", "), webhookID))
+}
+
+const listActionSlackWebhooksFmtStr = `
+SELECT %s
+FROM cm_slack_webhooks
+WHERE monitor = %s
+ORDER BY id ASC
+`
+
+// ListActionSlackWebhooks returns all Slack webhook actions of a monitor.
+func (s *Store) ListActionSlackWebhooks(ctx context.Context, monitorID int64) ([]*MonitorSlackWebhook, error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(listActionSlackWebhooksFmtStr, sqlf.Join(slackWebhooksColumns, ", "), monitorID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanSlackWebhooks(rows)
+}
+
+func (s *Store) runSlackWebhookQuery(ctx context.Context, q *sqlf.Query) (*MonitorSlackWebhook, error) {
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ws, err := scanSlackWebhooks(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(ws) == 0 {
+		return nil, errors.Errorf("operation failed. Query should have returned 1 row")
+	}
+	return ws[0], nil
+}
+
+var slackWebhooksColumns = []*sqlf.Query{
+	sqlf.Sprintf("cm_slack_webhooks.id"),
+	sqlf.Sprintf("cm_slack_webhooks.monitor"),
+	sqlf.Sprintf("cm_slack_webhooks.enabled"),
+	sqlf.Sprintf("cm_slack_webhooks.url"),
+	sqlf.Sprintf("cm_slack_webhooks.created_by"),
+	sqlf.Sprintf("cm_slack_webhooks.created_at"),
+	sqlf.Sprintf("cm_slack_webhooks.changed_by"),
+	sqlf.Sprintf("cm_slack_webhooks.changed_at"),
+}
+
+func scanSlackWebhooks(rows *sql.Rows) (ws []*MonitorSlackWebhook, err error) {
+	defer func() { err = basestore.CloseRows(rows, err) }()
+	for rows.Next() {
+		w := &MonitorSlackWebhook{}
+		if err := rows.Scan(
+			&w.Id,
+			&w.Monitor,
+			&w.Enabled,
+			&w.URL,
+			&w.CreatedBy,
+			&w.CreatedAt,
+			&w.ChangedBy,
+			&w.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		ws = append(ws, w)
+	}
+	return ws, nil
+}
@@ -0,0 +1,204 @@
+package codemonitors
+
+import (
+	"context"
+	"database/sql"
+	"net"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/graph-gophers/graphql-go/relay"
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+)
+
+// MonitorWebhook is an action which posts new results of a code monitor to a
+// URL as JSON.
+type MonitorWebhook struct {
+	Id        int64
+	Monitor   int64
+	Enabled   bool
+	URL       string
+	CreatedBy int32
+	CreatedAt time.Time
+	ChangedBy int32
+	ChangedAt time.Time
+}
+
+// ValidateWebhookURL returns an error if url can't be used as the URL of a
+// webhook or Slack webhook action. The URL must be an http or https URL of a
+// host on the internet, since code monitors must not be able to reach
+// services on the network of the Sourcegraph instance. Host names are checked
+// again when the webhook is posted, after they are resolved.
+func ValidateWebhookURL(rawURL string) error {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return errors.Wrap(err, "invalid webhook URL")
+	}
+	if u.Scheme != "http" && u.Scheme != "https" {
+		return errors.Errorf("invalid webhook URL %q: the scheme must be http or https", rawURL)
+	}
+	host := strings.ToLower(u.Hostname())
+	if host == "" {
+		return errors.Errorf("invalid webhook URL %q: the URL must have a host", rawURL)
+	}
+	if host == "localhost" || strings.HasSuffix(host, ".localhost") {
+		return errors.Errorf("invalid webhook URL %q: the host must not be localhost", rawURL)
+	}
+	if ip := net.ParseIP(host); ip != nil && httpcli.IsPrivateAddress(ip) {
+		return errors.Errorf("invalid webhook URL %q: the host must not be a private address", rawURL)
+	}
+	return nil
+}
+
+const createActionWebhookFmtStr = `
+INSERT INTO cm_webhooks
+(monitor, enabled, url, created_by, created_at, changed_by, changed_at)
+VALUES (%s,%s,%s,%s,%s,%s,%s)
+RETURNING %s;
+`
+
+func (s *Store) CreateActionWebhook(ctx context.Context, monitorID int64, args *graphqlbackend.CreateActionWebhookArgs) (*MonitorWebhook, error) {
+	if err := ValidateWebhookURL(args.URL); err != nil {
+		return nil, err
+	}
+	now := s.Now()
+	a := actor.FromContext(ctx)
+	return s.runWebhookQuery(ctx, sqlf.Sprintf(
+		createActionWebhookFmtStr,
+		monitorID,
+		args.Enabled,
+		args.URL,
+		a.UID,
+		now,
+		a.UID,
+		now,
+		sqlf.Join(webhooksColumns, ", "),
+	))
+}
+
+const updateActionWebhookFmtStr = `
+UPDATE cm_webhooks
+SET enabled = %s,
+	url = %s,
+	changed_by = %s,
+	changed_at = %s
+WHERE id = %s
+AND monitor = %s
+RETURNING %s;
+`
+
+func (s *Store) UpdateActionWebhook(ctx context.Context, monitorID int64, args *graphqlbackend.EditActionWebhookArgs) (*MonitorWebhook, error) {
+	if args.Id == nil {
+		return nil, errors.Errorf("nil is not a valid action ID")
+	}
+	var actionID int64
+	if err := relay.UnmarshalSpec(*args.Id, &actionID); err != nil {
+		return nil, err
+	}
+	if err := ValidateWebhookURL(args.Update.URL); err != nil {
+		return nil, err
+	}
+	a := actor.FromContext(ctx)
+	return s.runWebhookQuery(ctx, sqlf.Sprintf(
+		updateActionWebhookFmtStr,
+		args.Update.Enabled,
+		args.Update.URL,
+		a.UID,
+		s.Now(),
+		actionID,
+		monitorID,
+		sqlf.Join(webhooksColumns, ", "),
+	))
+}
+
+const deleteActionWebhooksFmtStr = `DELETE FROM cm_webhooks WHERE id = ANY(%s) AND monitor = %s`
+
+func (s *Store) DeleteActionWebhooksInt64(ctx context.Context, actionIDs []int64, monitorID int64) error {
+	if len(actionIDs) == 0 {
+		return nil
+	}
+	return s.Exec(ctx, sqlf.Sprintf(deleteActionWebhooksFmtStr, pq.Array(actionIDs), monitorID))
+}
+
+const actionWebhookByIDFmtStr = `
+SELECT %s
+FROM cm_webhooks
+WHERE id = %s
+`
+
+func (s *Store) ActionWebhookByIDInt64(ctx context.Context, webhookID int64) (*MonitorWebhook, error) {
+	return s.runWebhookQuery(ctx, sqlf.Sprintf(actionWebhookByIDFmtStr, sqlf.Join(webhooksColumns, ", "), webhookID))
+}
+
+const listActionWebhooksFmtStr = `
+SELECT %s
+FROM cm_webhooks
+WHERE monitor = %s
+ORDER BY id ASC
+`
+
+// ListActionWebhooks returns all webhook actions of a monitor.
+func (s *Store) ListActionWebhooks(ctx context.Context, monitorID int64) ([]*MonitorWebhook, error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(listActionWebhooksFmtStr, sqlf.Join(webhooksColumns, ", "), monitorID))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	return scanWebhooks(rows)
+}
+
+func (s *Store) runWebhookQuery(ctx context.Context, q *sqlf.Query) (*MonitorWebhook, error) {
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	ws, err := scanWebhooks(rows)
+	if err != nil {
+		return nil, err
+	}
+	if len(ws) == 0 {
+		return nil, errors.Errorf("operation failed. Query should have returned 1 row")
+	}
+	return ws[0], nil
+}
+
+var webhooksColumns = []*sqlf.Query{
+	sqlf.Sprintf("cm_webhooks.id"),
+	sqlf.Sprintf("cm_webhooks.monitor"),
+	sqlf.Sprintf("cm_webhooks.enabled"),
+	sqlf.Sprintf("cm_webhooks.url"),
+	sqlf.Sprintf("cm_webhooks.created_by"),
+	sqlf.Sprintf("cm_webhooks.created_at"),
+	sqlf.Sprintf("cm_webhooks.changed_by"),
+	sqlf.Sprintf("cm_webhooks.changed_at"),
+}
+
+func scanWebhooks(rows *sql.Rows) (ws []*MonitorWebhook, err error) {
+	defer func() { err = basestore.CloseRows(rows, err) }()
+	for rows.Next() {
+		w := &MonitorWebhook{}
+		if err := rows.Scan(
+			&w.Id,
+			&w.Monitor,
+			&w.Enabled,
+			&w.URL,
+			&w.CreatedBy,
+			&w.CreatedAt,
+			&w.ChangedBy,
+			&w.ChangedAt,
+		); err != nil {
+			return nil, err
+		}
+		ws = append(ws, w)
+	}
+	return ws, nil
+}
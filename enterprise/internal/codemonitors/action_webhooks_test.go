@@ -0,0 +1,26 @@
+package codemonitors
+
+import "testing"
+
+func TestValidateWebhookURL(t *testing.T) {
+	for url, valid := range map[string]bool{
+		"https://example.com/webhook":                     true,
+		"http://example.com:8080/webhook":                 true,
+		"https://hooks.slack.com/services/T00/B00/XXXXXX": true,
+		"https://8.8.8.8/webhook":                         true,
+		"ftp://example.com/webhook":                       false,
+		"file:///etc/passwd":                              false,
+		"https:///webhook":                                false,
+		"http://localhost:3080/webhook":                   false,
+		"http://api.localhost/webhook":                    false,
+		"http://127.0.0.1/webhook":                        false,
+		"http://169.254.169.254/latest/meta-data":         false,
+		"http://10.0.0.1/webhook":                         false,
+		"http://[::1]/webhook":                            false,
+		"://example.com":                                  false,
+	} {
+		if err := ValidateWebhookURL(url); (err == nil) != valid {
+			t.Errorf("ValidateWebhookURL(%q): got error %v, want valid %v", url, err, valid)
+		}
+	}
+}
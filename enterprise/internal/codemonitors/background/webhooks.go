@@ -0,0 +1,145 @@
+package background
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+
+	cm "github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors"
+	"github.com/sourcegraph/sourcegraph/enterprise/internal/codemonitors/email"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/slack"
+)
+
+const (
+	utmSourceWebhook = "code-monitoring-webhook"
+	utmSourceSlack   = "code-monitoring-slack"
+)
+
+var (
+	webhookDoerOnce sync.Once
+	webhookDoer     httpcli.Doer
+	webhookDoerErr  error
+)
+
+// getWebhookDoer returns the client used to post to the URLs of webhook
+// actions. The URLs are provided by users, so it refuses to connect to
+// private addresses.
+func getWebhookDoer() (httpcli.Doer, error) {
+	webhookDoerOnce.Do(func() {
+		webhookDoer, webhookDoerErr = httpcli.NewExternalHTTPClientFactory().Doer(httpcli.DenyPrivateAddressesOpt)
+	})
+	return webhookDoer, webhookDoerErr
+}
+
+// webhookPayload is the JSON body posted to the URL of a webhook action.
+type webhookPayload struct {
+	MonitorDescription string `json:"monitorDescription"`
+	MonitorURL         string `json:"monitorURL"`
+	Query              string `json:"query"`
+	ResultCount        int    `json:"resultCount"`
+	SearchURL          string `json:"searchURL"`
+}
+
+func handleWebhook(ctx context.Context, s *cm.Store, webhookID int64, m *cm.ActionJobMetadata) error {
+	w, err := s.ActionWebhookByIDInt64(ctx, webhookID)
+	if err != nil {
+		return errors.Errorf("store.ActionWebhookByIDInt64: %w", err)
+	}
+
+	payload, err := newWebhookPayload(ctx, m, utmSourceWebhook)
+	if err != nil {
+		return err
+	}
+	doer, err := getWebhookDoer()
+	if err != nil {
+		return err
+	}
+	return postWebhook(ctx, doer, w.URL, payload)
+}
+
+func handleSlackWebhook(ctx context.Context, s *cm.Store, slackWebhookID int64, m *cm.ActionJobMetadata) error {
+	w, err := s.ActionSlackWebhookByIDInt64(ctx, slackWebhookID)
+	if err != nil {
+		return errors.Errorf("store.ActionSlackWebhookByIDInt64: %w", err)
+	}
+
+	payload, err := newWebhookPayload(ctx, m, utmSourceSlack)
+	if err != nil {
+		return err
+	}
+	doer, err := getWebhookDoer()
+	if err != nil {
+		return err
+	}
+	client := slack.New(w.URL)
+	client.HTTPClient = doer
+	return client.Post(ctx, newSlackPayload(payload))
+}
+
+func newWebhookPayload(ctx context.Context, m *cm.ActionJobMetadata, utmSource string) (*webhookPayload, error) {
+	searchURL, err := email.SearchURL(ctx, m.Query, utmSource)
+	if err != nil {
+		return nil, err
+	}
+	monitorURL, err := email.CodeMonitorURL(ctx, m.MonitorID, utmSource)
+	if err != nil {
+		return nil, err
+	}
+	return &webhookPayload{
+		MonitorDescription: m.Description,
+		MonitorURL:         monitorURL,
+		Query:              m.Query,
+		ResultCount:        zeroOrVal(m.NumResults),
+		SearchURL:          searchURL,
+	}, nil
+}
+
+func newSlackPayload(p *webhookPayload) *slack.Payload {
+	plural := "s"
+	if p.ResultCount == 1 {
+		plural = ""
+	}
+	return &slack.Payload{
+		Username:  "code-monitor",
+		IconEmoji: ":mag:",
+		Text: fmt.Sprintf(`*%d* new result%s found for the code monitor <%s|"%s">. <%s|View the results>.`,
+			p.ResultCount,
+			plural,
+			p.MonitorURL,
+			p.MonitorDescription,
+			p.SearchURL,
+		),
+	}
+}
+
+// postWebhook posts payload as JSON to url. Responses with a status other
+// than 2xx are returned as errors, so that the job is retried.
+func postWebhook(ctx context.Context, doer httpcli.Doer, url string, payload *webhookPayload) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := doer.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "posting webhook")
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		b, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return errors.Errorf("webhook responded with %d: %s", resp.StatusCode, b)
+	}
+	return nil
+}
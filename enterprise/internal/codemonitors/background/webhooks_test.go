@@ -0,0 +1,68 @@
+package background
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPostWebhook(t *testing.T) {
+	want := &webhookPayload{
+		MonitorDescription: "test description",
+		MonitorURL:         "https://www.sourcegraph.com/code-monitoring/Q29kZU1vbml0b3I6MQ==",
+		Query:              "test patternType:literal",
+		ResultCount:        2,
+		SearchURL:          "https://www.sourcegraph.com/search?q=test",
+	}
+
+	var status int
+	var got webhookPayload
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/json" {
+			t.Errorf("wrong content type %q", ct)
+		}
+		if err := json.NewDecoder(r.Body).Decode(&got); err != nil {
+			t.Error(err)
+		}
+		w.WriteHeader(status)
+	}))
+	defer srv.Close()
+
+	status = http.StatusNoContent
+	if err := postWebhook(context.Background(), http.DefaultClient, srv.URL, want); err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(want, &got); diff != "" {
+		t.Fatalf("payload mismatch (-want +got):\n%s", diff)
+	}
+
+	// Failed deliveries are returned as errors so that the job is retried.
+	status = http.StatusInternalServerError
+	if err := postWebhook(context.Background(), http.DefaultClient, srv.URL, want); err == nil {
+		t.Fatal("want error for failed delivery")
+	}
+}
+
+func TestNewSlackPayload(t *testing.T) {
+	for _, tc := range []struct {
+		count int
+		want  string
+	}{
+		{count: 1, want: `*1* new result found for the code monitor <https://monitor|"test">. <https://search|View the results>.`},
+		{count: 3, want: `*3* new results found for the code monitor <https://monitor|"test">. <https://search|View the results>.`},
+	} {
+		p := newSlackPayload(&webhookPayload{
+			MonitorDescription: "test",
+			MonitorURL:         "https://monitor",
+			ResultCount:        tc.count,
+			SearchURL:          "https://search",
+		})
+		if p.Text != tc.want {
+			t.Errorf("got %q, want %q", p.Text, tc.want)
+		}
+	}
+}
@@ -169,6 +169,14 @@ func (r *queryRunner) Handle(ctx context.Context, record workerutil.Record) (err
 		if err != nil {
 			return errors.Errorf("store.EnqueueActionEmailsForQueryIDInt64: %w", err)
 		}
+		err = s.EnqueueActionWebhooksForQueryIDInt64(ctx, q.Id, record.RecordID())
+		if err != nil {
+			return errors.Errorf("store.EnqueueActionWebhooksForQueryIDInt64: %w", err)
+		}
+		err = s.EnqueueActionSlackWebhooksForQueryIDInt64(ctx, q.Id, record.RecordID())
+		if err != nil {
+			return errors.Errorf("store.EnqueueActionSlackWebhooksForQueryIDInt64: %w", err)
+		}
 	}
 	// Log next_run and latest_result to table cm_queries.
 	newLatestResult := latestResultTime(q.LatestResult, results, err)
@@ -202,36 +210,40 @@ func (r *actionRunner) Handle(ctx context.Context, record workerutil.Record) (er
 	}
 	defer func() { err = s.Done(err) }()
 
-	var (
-		j    *cm.ActionJob
-		m    *cm.ActionJobMetadata
-		e    *cm.MonitorEmail
-		recs []*cm.Recipient
-		data *email.TemplateDataNewSearchResults
-	)
-
-	var ok bool
-	j, ok = record.(*cm.ActionJob)
+	j, ok := record.(*cm.ActionJob)
 	if !ok {
 		return errors.Errorf("type assertion failed")
 	}
 
-	m, err = s.GetActionJobMetadata(ctx, record.RecordID())
+	m, err := s.GetActionJobMetadata(ctx, record.RecordID())
 	if err != nil {
 		return errors.Errorf("store.GetActionJobMetadata: %w", err)
 	}
 
-	e, err = s.ActionEmailByIDInt64(ctx, j.Email)
+	switch {
+	case j.Email != nil:
+		return handleEmail(ctx, s, *j.Email, m)
+	case j.Webhook != nil:
+		return handleWebhook(ctx, s, *j.Webhook, m)
+	case j.SlackWebhook != nil:
+		return handleSlackWebhook(ctx, s, *j.SlackWebhook, m)
+	default:
+		return errors.Errorf("action job %d has no action", j.Id)
+	}
+}
+
+func handleEmail(ctx context.Context, s *cm.Store, emailID int64, m *cm.ActionJobMetadata) error {
+	e, err := s.ActionEmailByIDInt64(ctx, emailID)
 	if err != nil {
 		return errors.Errorf("store.ActionEmailByIDInt64: %w", err)
 	}
 
-	recs, err = s.AllRecipientsForEmailIDInt64(ctx, j.Email)
+	recs, err := s.AllRecipientsForEmailIDInt64(ctx, emailID)
 	if err != nil {
 		return errors.Errorf("store.AllRecipientsForEmailIDInt64: %w", err)
 	}
 
-	data, err = email.NewTemplateDataForNewSearchResults(ctx, m.Description, m.Query, e, zeroOrVal(m.NumResults))
+	data, err := email.NewTemplateDataForNewSearchResults(ctx, m.Description, m.Query, e, zeroOrVal(m.NumResults))
 	if err != nil {
 		return errors.Errorf("email.NewTemplateDataForNewSearchResults: %w", err)
 	}
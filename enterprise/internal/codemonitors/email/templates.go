@@ -5,7 +5,13 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
 )
 
-var newSearchResultsEmailTemplates = txemail.MustValidate(txtypes.Templates{
+var newSearchResultsEmailTemplates = txemail.MustRegister("codeMonitorResults", TemplateDataNewSearchResults{
+	Priority:                  "New",
+	CodeMonitorURL:            "https://sourcegraph.example.com/code-monitoring/example",
+	SearchURL:                 "https://sourcegraph.example.com/search?q=example",
+	Description:               "example code monitor",
+	NumberOfResultsWithDetail: "There were 3 new search results for your query",
+}, txtypes.Templates{
 	Subject: `{{ if .IsTest }}Test: {{ end }}[{{.Priority}} event] {{.Description}}`,
 	Text: `
 {{ if .IsTest }}This email is a preview. Links are disabled.{{ end }}
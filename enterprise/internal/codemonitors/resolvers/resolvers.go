@@ -170,6 +170,9 @@ func (r *Resolver) UpdateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 	}
 
 	toCreate, toDelete, err := splitActionIDs(ctx, args, actionIDs)
+	if err != nil {
+		return nil, err
+	}
 	if len(toDelete) == len(actionIDs) {
 		return nil, errors.Errorf("you tried to delete all actions, but every monitor must be connected to at least 1 action")
 	}
@@ -182,7 +185,7 @@ func (r *Resolver) UpdateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 	}
 	defer func() { err = tx.store.Done(err) }()
 
-	err = tx.store.DeleteActionsInt64(ctx, toDelete, monitorID)
+	err = tx.deleteActions(ctx, toDelete, monitorID)
 	if err != nil {
 		return nil, err
 	}
@@ -253,91 +256,136 @@ func sendTestEmail(ctx context.Context, recipient graphql.ID, description string
 }
 
 func (r *Resolver) actionIDsForMonitorIDInt64(ctx context.Context, monitorID int64) (actionIDs []graphql.ID, err error) {
-	limit := 50
-	var (
-		ids   []graphql.ID
-		after *string
-		q     *sqlf.Query
-	)
-	// Paging.
-	for {
-		q, err = r.store.ReadActionEmailQuery(ctx, monitorID, &graphqlbackend.ListActionArgs{
-			First: int32(limit),
-			After: after,
-		})
-		if err != nil {
-			return nil, err
-		}
-		es, cur, err := r.actionIDsForMonitorIDINT64SinglePage(ctx, q, limit)
-		if err != nil {
-			return nil, err
-		}
-		ids = append(ids, es...)
-		if cur == nil {
-			break
-		}
-		after = cur
+	actions, err := r.actionsForMonitorIDInt64(ctx, monitorID, nil)
+	if err != nil {
+		return nil, err
+	}
+	ids := make([]graphql.ID, 0, len(actions))
+	for _, a := range actions {
+		ids = append(ids, a.ID())
 	}
 	return ids, nil
 }
 
-func (r *Resolver) actionIDsForMonitorIDINT64SinglePage(ctx context.Context, q *sqlf.Query, limit int) (ids []graphql.ID, cursor *string, err error) {
-	var rows *sql.Rows
-	rows, err = r.store.Query(ctx, q)
+// actionsForMonitorIDInt64 returns all actions of a monitor, ordered by their
+// type and ID.
+func (r *Resolver) actionsForMonitorIDInt64(ctx context.Context, monitorID int64, triggerEventID *int) ([]*action, error) {
+	es, err := r.store.ListActionEmails(ctx, monitorID)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	defer rows.Close()
-
-	var es []*cm.MonitorEmail
-	es, err = cm.ScanEmails(rows)
+	ws, err := r.store.ListActionWebhooks(ctx, monitorID)
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
-	ids = make([]graphql.ID, 0, len(es))
-	for _, e := range es {
-		ids = append(ids, (&monitorEmail{MonitorEmail: e}).ID())
+	sws, err := r.store.ListActionSlackWebhooks(ctx, monitorID)
+	if err != nil {
+		return nil, err
 	}
 
-	// Set the cursor if the result size equals limit.
-	if len(ids) == limit {
-		stringID := string(ids[len(ids)-1])
-		cursor = &stringID
+	actions := make([]*action, 0, len(es)+len(ws)+len(sws))
+	for _, e := range es {
+		actions = append(actions, &action{
+			email: &monitorEmail{
+				Resolver:       r,
+				MonitorEmail:   e,
+				triggerEventID: triggerEventID,
+			},
+		})
 	}
-	return ids, cursor, nil
+	for _, w := range ws {
+		actions = append(actions, &action{
+			webhook: &monitorWebhook{
+				Resolver:       r,
+				MonitorWebhook: w,
+				triggerEventID: triggerEventID,
+			},
+		})
+	}
+	for _, w := range sws {
+		actions = append(actions, &action{
+			slackWebhook: &monitorSlackWebhook{
+				Resolver:            r,
+				MonitorSlackWebhook: w,
+				triggerEventID:      triggerEventID,
+			},
+		})
+	}
+	return actions, nil
 }
 
 // splitActionIDs splits actions into three buckets: create, delete and update.
 // Note: args is mutated. After splitActionIDs, args only contains actions to be updated.
-func splitActionIDs(ctx context.Context, args *graphqlbackend.UpdateCodeMonitorArgs, actionIDs []graphql.ID) (toCreate []*graphqlbackend.CreateActionArgs, toDelete []int64, err error) {
+func splitActionIDs(ctx context.Context, args *graphqlbackend.UpdateCodeMonitorArgs, actionIDs []graphql.ID) (toCreate []*graphqlbackend.CreateActionArgs, toDelete []graphql.ID, err error) {
 	aMap := make(map[graphql.ID]struct{}, len(actionIDs))
 	for _, id := range actionIDs {
 		aMap[id] = struct{}{}
 	}
 	var toUpdateActions []*graphqlbackend.EditActionArgs
 	for _, a := range args.Actions {
-		if a.Email.Id == nil {
-			toCreate = append(toCreate, &graphqlbackend.CreateActionArgs{Email: a.Email.Update})
+		id, kind, create, err := splitEditAction(a)
+		if err != nil {
+			return nil, nil, err
+		}
+		if id == nil {
+			toCreate = append(toCreate, create)
 			continue
 		}
-		if _, ok := aMap[*a.Email.Id]; !ok {
-			return nil, nil, errors.Errorf("unknown ID=%s for action", *a.Email.Id)
+		if _, ok := aMap[*id]; !ok || relay.UnmarshalKind(*id) != kind {
+			return nil, nil, errors.Errorf("unknown ID=%s for action", *id)
 		}
 		toUpdateActions = append(toUpdateActions, a)
-		delete(aMap, *a.Email.Id)
+		delete(aMap, *id)
 	}
-	var actionID int64
 	for k := range aMap {
-		err = relay.UnmarshalSpec(k, &actionID)
-		if err != nil {
-			return nil, nil, err
-		}
-		toDelete = append(toDelete, actionID)
+		toDelete = append(toDelete, k)
 	}
 	args.Actions = toUpdateActions
 	return toCreate, toDelete, nil
 }
 
+// splitEditAction returns the ID and the kind of the action to edit, and the
+// arguments to create the action if the ID is nil.
+func splitEditAction(a *graphqlbackend.EditActionArgs) (id *graphql.ID, kind string, create *graphqlbackend.CreateActionArgs, err error) {
+	switch {
+	case a.Email != nil:
+		return a.Email.Id, monitorActionEmailKind, &graphqlbackend.CreateActionArgs{Email: a.Email.Update}, nil
+	case a.Webhook != nil:
+		return a.Webhook.Id, monitorActionWebhookKind, &graphqlbackend.CreateActionArgs{Webhook: a.Webhook.Update}, nil
+	case a.SlackWebhook != nil:
+		return a.SlackWebhook.Id, monitorActionSlackWebhookKind, &graphqlbackend.CreateActionArgs{SlackWebhook: a.SlackWebhook.Update}, nil
+	default:
+		return nil, "", nil, errors.New("action must be one of email, webhook or Slack webhook")
+	}
+}
+
+func (r *Resolver) deleteActions(ctx context.Context, actionIDs []graphql.ID, monitorID int64) error {
+	var emails, webhooks, slackWebhooks []int64
+	for _, id := range actionIDs {
+		var actionID int64
+		if err := relay.UnmarshalSpec(id, &actionID); err != nil {
+			return err
+		}
+		switch kind := relay.UnmarshalKind(id); kind {
+		case monitorActionEmailKind:
+			emails = append(emails, actionID)
+		case monitorActionWebhookKind:
+			webhooks = append(webhooks, actionID)
+		case monitorActionSlackWebhookKind:
+			slackWebhooks = append(slackWebhooks, actionID)
+		default:
+			return errors.Errorf("unknown action kind %q", kind)
+		}
+	}
+	if err := r.store.DeleteActionsInt64(ctx, emails, monitorID); err != nil {
+		return err
+	}
+	if err := r.store.DeleteActionWebhooksInt64(ctx, webhooks, monitorID); err != nil {
+		return err
+	}
+	return r.store.DeleteActionSlackWebhooksInt64(ctx, slackWebhooks, monitorID)
+}
+
 func (r *Resolver) updateCodeMonitor(ctx context.Context, args *graphqlbackend.UpdateCodeMonitorArgs) (m graphqlbackend.MonitorResolver, err error) {
 	// Update monitor.
 	var mo *cm.Monitor
@@ -360,24 +408,36 @@ func (r *Resolver) updateCodeMonitor(ctx context.Context, args *graphqlbackend.U
 	var emailID int64
 	var e *cm.MonitorEmail
 	for i, action := range args.Actions {
-		if action.Email == nil {
-			return nil, errors.Errorf("missing email object for action %d", i)
-		}
-		err = relay.UnmarshalSpec(*action.Email.Id, &emailID)
-		if err != nil {
-			return nil, err
-		}
-		err = r.store.DeleteRecipients(ctx, emailID)
-		if err != nil {
-			return nil, err
-		}
-		e, err = r.store.UpdateActionEmail(ctx, mo.ID, action)
-		if err != nil {
-			return nil, err
-		}
-		err = r.store.CreateRecipients(ctx, action.Email.Update.Recipients, e.Id)
-		if err != nil {
-			return nil, err
+		switch {
+		case action.Email != nil:
+			err = relay.UnmarshalSpec(*action.Email.Id, &emailID)
+			if err != nil {
+				return nil, err
+			}
+			err = r.store.DeleteRecipients(ctx, emailID)
+			if err != nil {
+				return nil, err
+			}
+			e, err = r.store.UpdateActionEmail(ctx, mo.ID, action)
+			if err != nil {
+				return nil, err
+			}
+			err = r.store.CreateRecipients(ctx, action.Email.Update.Recipients, e.Id)
+			if err != nil {
+				return nil, err
+			}
+		case action.Webhook != nil:
+			_, err = r.store.UpdateActionWebhook(ctx, mo.ID, action.Webhook)
+			if err != nil {
+				return nil, err
+			}
+		case action.SlackWebhook != nil:
+			_, err = r.store.UpdateActionSlackWebhook(ctx, mo.ID, action.SlackWebhook)
+			if err != nil {
+				return nil, err
+			}
+		default:
+			return nil, errors.Errorf("missing action object for action %d", i)
 		}
 	}
 	return &monitor{
@@ -483,9 +543,7 @@ func ownerForID64Query(ctx context.Context, monitorID int64) (*sqlf.Query, error
 	), nil
 }
 
-//
 // MonitorConnection
-//
 type monitorConnection struct {
 	*Resolver
 	monitors    []graphqlbackend.MonitorResolver
@@ -508,9 +566,7 @@ func (m *monitorConnection) PageInfo(ctx context.Context) (*graphqlutil.PageInfo
 	return graphqlutil.NextPageCursor(string(m.monitors[len(m.monitors)-1].ID())), nil
 }
 
-//
 // Monitor
-//
 type monitor struct {
 	*Resolver
 	*cm.Monitor
@@ -521,6 +577,8 @@ const (
 	monitorTriggerQueryKind         = "CodeMonitorTriggerQuery"
 	monitorTriggerEventKind         = "CodeMonitorTriggerEvent"
 	monitorActionEmailKind          = "CodeMonitorActionEmail"
+	monitorActionWebhookKind        = "CodeMonitorActionWebhook"
+	monitorActionSlackWebhookKind   = "CodeMonitorActionSlackWebhook"
 	monitorActionEventKind          = "CodeMonitorActionEmailEvent"
 	monitorActionEmailRecipientKind = "CodeMonitorActionEmailRecipient"
 )
@@ -567,41 +625,35 @@ func (m *monitor) Actions(ctx context.Context, args *graphqlbackend.ListActionAr
 }
 
 func (r *Resolver) actionConnectionResolverWithTriggerID(ctx context.Context, triggerEventID *int, monitorID int64, args *graphqlbackend.ListActionArgs) (graphqlbackend.MonitorActionConnectionResolver, error) {
-	// For now, we only support emails as actions. Once we add other actions such as
-	// webhooks, we have to query those tables here too.
-	q, err := r.store.ReadActionEmailQuery(ctx, monitorID, args)
+	all, err := r.actionsForMonitorIDInt64(ctx, monitorID, triggerEventID)
 	if err != nil {
 		return nil, err
 	}
-	rows, err := r.store.Query(ctx, q)
-	if err != nil {
-		return nil, err
-	}
-	defer rows.Close()
-	es, err := cm.ScanEmails(rows)
-	if err != nil {
-		return nil, err
+
+	// Monitors only have a handful of actions of different types, so we page
+	// through all of them in memory.
+	page := all
+	if args.After != nil {
+		page = nil
+		for i, a := range all {
+			if a.ID() == graphql.ID(*args.After) {
+				page = all[i+1:]
+				break
+			}
+		}
 	}
-	totalCount, err := r.store.TotalCountActionEmails(ctx, monitorID)
-	if err != nil {
-		return nil, err
+	if args.First >= 0 && len(page) > int(args.First) {
+		page = page[:args.First]
 	}
-	actions := make([]graphqlbackend.MonitorAction, 0, len(es))
-	for _, e := range es {
-		actions = append(actions, &action{
-			email: &monitorEmail{
-				Resolver:       r,
-				MonitorEmail:   e,
-				triggerEventID: triggerEventID,
-			},
-		})
+
+	actions := make([]graphqlbackend.MonitorAction, 0, len(page))
+	for _, a := range page {
+		actions = append(actions, a)
 	}
-	return &monitorActionConnection{actions: actions, totalCount: totalCount}, nil
+	return &monitorActionConnection{actions: actions, totalCount: int32(len(all))}, nil
 }
 
-//
 // MonitorTrigger <<UNION>>
-//
 type monitorTrigger struct {
 	query graphqlbackend.MonitorQueryResolver
 }
@@ -610,9 +662,7 @@ func (t *monitorTrigger) ToMonitorQuery() (graphqlbackend.MonitorQueryResolver,
 	return t.query, t.query != nil
 }
 
-//
 // Query
-//
 type monitorQuery struct {
 	*Resolver
 	*cm.MonitorQuery
@@ -646,9 +696,7 @@ func (q *monitorQuery) Events(ctx context.Context, args *graphqlbackend.ListEven
 	return &monitorTriggerEventConnection{Resolver: q.Resolver, events: events, totalCount: totalCount}, nil
 }
 
-//
 // MonitorTriggerEventConnection
-//
 type monitorTriggerEventConnection struct {
 	*Resolver
 	events     []graphqlbackend.MonitorTriggerEventResolver
@@ -670,9 +718,7 @@ func (a *monitorTriggerEventConnection) PageInfo(ctx context.Context) (*graphqlu
 	return graphqlutil.NextPageCursor(string(a.events[len(a.events)-1].ID())), nil
 }
 
-//
 // MonitorTriggerEvent
-//
 type monitorTriggerEvent struct {
 	*Resolver
 	*cm.TriggerJobs
@@ -716,7 +762,6 @@ func (m *monitorTriggerEvent) Actions(ctx context.Context, args *graphqlbackend.
 }
 
 // ActionConnection
-//
 type monitorActionConnection struct {
 	actions    []graphqlbackend.MonitorAction
 	totalCount int32
@@ -734,27 +779,40 @@ func (a *monitorActionConnection) PageInfo(ctx context.Context) (*graphqlutil.Pa
 	if len(a.actions) == 0 {
 		return graphqlutil.HasNextPage(false), nil
 	}
-	last := a.actions[len(a.actions)-1]
-	if email, ok := last.ToMonitorEmail(); ok {
-		return graphqlutil.NextPageCursor(string(email.ID())), nil
-	}
-	return nil, errors.Errorf("we only support email actions for now")
+	return graphqlutil.NextPageCursor(string(a.actions[len(a.actions)-1].(*action).ID())), nil
 }
 
-//
 // Action <<UNION>>
-//
 type action struct {
-	email graphqlbackend.MonitorEmailResolver
+	email        graphqlbackend.MonitorEmailResolver
+	webhook      graphqlbackend.MonitorWebhookResolver
+	slackWebhook graphqlbackend.MonitorSlackWebhookResolver
+}
+
+func (a *action) ID() graphql.ID {
+	switch {
+	case a.email != nil:
+		return a.email.ID()
+	case a.webhook != nil:
+		return a.webhook.ID()
+	default:
+		return a.slackWebhook.ID()
+	}
 }
 
 func (a *action) ToMonitorEmail() (graphqlbackend.MonitorEmailResolver, bool) {
 	return a.email, a.email != nil
 }
 
-//
+func (a *action) ToMonitorWebhook() (graphqlbackend.MonitorWebhookResolver, bool) {
+	return a.webhook, a.webhook != nil
+}
+
+func (a *action) ToMonitorSlackWebhook() (graphqlbackend.MonitorSlackWebhookResolver, bool) {
+	return a.slackWebhook, a.slackWebhook != nil
+}
+
 // Email
-//
 type monitorEmail struct {
 	*Resolver
 	*cm.MonitorEmail
@@ -826,16 +884,86 @@ func (m *monitorEmail) Events(ctx context.Context, args *graphqlbackend.ListEven
 	if err != nil {
 		return nil, err
 	}
+	return m.newActionEventConnection(ajs, totalCount), nil
+}
+
+// Webhook
+type monitorWebhook struct {
+	*Resolver
+	*cm.MonitorWebhook
+
+	// If triggerEventID == nil, all events of this action will be returned.
+	// Otherwise, only those events of this action which are related to the specified
+	// trigger event will be returned.
+	triggerEventID *int
+}
+
+func (m *monitorWebhook) ID() graphql.ID {
+	return relay.MarshalID(monitorActionWebhookKind, m.Id)
+}
+
+func (m *monitorWebhook) Enabled() bool {
+	return m.MonitorWebhook.Enabled
+}
+
+func (m *monitorWebhook) URL() string {
+	return m.MonitorWebhook.URL
+}
+
+func (m *monitorWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	ajs, err := m.store.ReadActionWebhookEvents(ctx, m.Id, m.triggerEventID, args)
+	if err != nil {
+		return nil, err
+	}
+	totalCount, err := m.store.TotalActionWebhookEvents(ctx, m.Id, m.triggerEventID)
+	if err != nil {
+		return nil, err
+	}
+	return m.newActionEventConnection(ajs, totalCount), nil
+}
+
+// SlackWebhook
+type monitorSlackWebhook struct {
+	*Resolver
+	*cm.MonitorSlackWebhook
+
+	// See monitorWebhook.
+	triggerEventID *int
+}
+
+func (m *monitorSlackWebhook) ID() graphql.ID {
+	return relay.MarshalID(monitorActionSlackWebhookKind, m.Id)
+}
+
+func (m *monitorSlackWebhook) Enabled() bool {
+	return m.MonitorSlackWebhook.Enabled
+}
+
+func (m *monitorSlackWebhook) URL() string {
+	return m.MonitorSlackWebhook.URL
+}
+
+func (m *monitorSlackWebhook) Events(ctx context.Context, args *graphqlbackend.ListEventsArgs) (graphqlbackend.MonitorActionEventConnectionResolver, error) {
+	ajs, err := m.store.ReadActionSlackWebhookEvents(ctx, m.Id, m.triggerEventID, args)
+	if err != nil {
+		return nil, err
+	}
+	totalCount, err := m.store.TotalActionSlackWebhookEvents(ctx, m.Id, m.triggerEventID)
+	if err != nil {
+		return nil, err
+	}
+	return m.newActionEventConnection(ajs, totalCount), nil
+}
+
+func (r *Resolver) newActionEventConnection(ajs []*cm.ActionJob, totalCount int32) *monitorActionEventConnection {
 	events := make([]graphqlbackend.MonitorActionEventResolver, len(ajs))
 	for i, aj := range ajs {
-		events[i] = &monitorActionEvent{Resolver: m.Resolver, ActionJob: aj}
+		events[i] = &monitorActionEvent{Resolver: r, ActionJob: aj}
 	}
-	return &monitorActionEventConnection{events: events, totalCount: totalCount}, nil
+	return &monitorActionEventConnection{events: events, totalCount: totalCount}
 }
 
-//
 // MonitorActionEmailRecipientConnection
-//
 type monitorActionEmailRecipientsConnection struct {
 	recipients     []graphqlbackend.NamespaceResolver
 	nextPageCursor string
@@ -857,9 +985,7 @@ func (a *monitorActionEmailRecipientsConnection) PageInfo(ctx context.Context) (
 	return graphqlutil.NextPageCursor(a.nextPageCursor), nil
 }
 
-//
 // MonitorActionEventConnection
-//
 type monitorActionEventConnection struct {
 	events     []graphqlbackend.MonitorActionEventResolver
 	totalCount int32
@@ -880,9 +1006,7 @@ func (a *monitorActionEventConnection) PageInfo(ctx context.Context) (*graphqlut
 	return graphqlutil.NextPageCursor(string(a.events[len(a.events)-1].ID())), nil
 }
 
-//
 // MonitorEvent
-//
 type monitorActionEvent struct {
 	*Resolver
 	*cm.ActionJob
@@ -170,6 +170,15 @@ func (s *PermsStore) SetUserPermissions(ctx context.Context, p *authz.UserPermis
 	ctx, save := s.observe(ctx, "SetUserPermissions", "")
 	defer func() { save(&err, p.TracingFields()...) }()
 
+	// Searches resolve different repositories for the affected users once the
+	// changed permissions are committed.
+	var changed bool
+	defer func() {
+		if err == nil && changed {
+			database.InvalidateSearchRepos(ctx)
+		}
+	}()
+
 	// Open a transaction for update consistency.
 	txs, err := s.Transact(ctx)
 	if err != nil {
@@ -200,6 +209,7 @@ func (s *PermsStore) SetUserPermissions(ctx context.Context, p *authz.UserPermis
 
 	updatedAt := txs.clock()
 	if !added.IsEmpty() || !removed.IsEmpty() {
+		changed = true
 		if q, err := upsertRepoPermissionsBatchQuery(added.ToArray(), removed.ToArray(), []uint32{uint32(p.UserID)}, p.Perm, updatedAt); err != nil {
 			return err
 		} else if err = txs.execute(ctx, q); err != nil {
@@ -289,6 +299,14 @@ func (s *PermsStore) SetRepoPermissions(ctx context.Context, p *authz.RepoPermis
 	ctx, save := s.observe(ctx, "SetRepoPermissions", "")
 	defer func() { save(&err, p.TracingFields()...) }()
 
+	// See SetUserPermissions.
+	var changed bool
+	defer func() {
+		if err == nil && changed {
+			database.InvalidateSearchRepos(ctx)
+		}
+	}()
+
 	var txs *PermsStore
 	if s.InTransaction() {
 		txs = s
@@ -323,6 +341,7 @@ func (s *PermsStore) SetRepoPermissions(ctx context.Context, p *authz.RepoPermis
 
 	updatedAt := txs.clock()
 	if !added.IsEmpty() || !removed.IsEmpty() {
+		changed = true
 		if q, err := upsertUserPermissionsBatchQuery(added.ToArray(), removed.ToArray(), []uint32{uint32(p.RepoID)}, p.Perm, authz.PermRepos, updatedAt); err != nil {
 			return err
 		} else if err = txs.execute(ctx, q); err != nil {
@@ -796,6 +815,14 @@ func (s *PermsStore) GrantPendingPermissions(ctx context.Context, userID int32,
 	ctx, save := s.observe(ctx, "GrantPendingPermissions", "")
 	defer func() { save(&err, append(p.TracingFields(), otlog.Int32("userID", userID))...) }()
 
+	// Granted pending permissions take effect for searches as well.
+	var changed bool
+	defer func() {
+		if err == nil && changed {
+			database.InvalidateSearchRepos(ctx)
+		}
+	}()
+
 	var txs *PermsStore
 	if s.InTransaction() {
 		txs = s
@@ -823,6 +850,7 @@ func (s *PermsStore) GrantPendingPermissions(ctx context.Context, userID int32,
 	if len(repoIDs) == 0 {
 		return nil
 	}
+	changed = true
 
 	updatedAt := txs.clock()
 	if q, err := upsertRepoPermissionsBatchQuery(repoIDs, nil, []uint32{uint32(userID)}, p.Perm, updatedAt); err != nil {
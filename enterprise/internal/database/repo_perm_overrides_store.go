@@ -0,0 +1,244 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// Effects of repository permission overrides.
+const (
+	// RepoPermOverrideGrant gives the user the permission in addition to the
+	// permissions synced from code hosts.
+	RepoPermOverrideGrant = "grant"
+	// RepoPermOverrideDeny revokes the permission from the user regardless of the
+	// permissions synced from code hosts.
+	RepoPermOverrideDeny = "deny"
+)
+
+var (
+	ErrRepoPermOverrideNotFound = errors.New("repository permission override not found")
+	ErrRepoPermOverrideExists   = errors.New("the user already has a permission override on the repository")
+)
+
+// RepoPermOverride is a permission of a user on a repository that is managed
+// by site admins instead of being synced from a code host, e.g. to give a
+// contractor temporary access.
+type RepoPermOverride struct {
+	ID     int64
+	RepoID api.RepoID
+	UserID int32
+	// Effect is either RepoPermOverrideGrant or RepoPermOverrideDeny.
+	Effect string
+	Reason string
+	// ExpiresAt is the time after which the override no longer applies. It is
+	// nil if the override applies until it is deleted.
+	ExpiresAt       *time.Time
+	CreatedByUserID int32
+	CreatedAt       time.Time
+	UpdatedAt       time.Time
+}
+
+// Expired returns true if the override no longer applies at the given time.
+func (o *RepoPermOverride) Expired(now time.Time) bool {
+	return o.ExpiresAt != nil && !o.ExpiresAt.After(now)
+}
+
+// RepoPermOverridesStore is responsible for storing and retrieving repository
+// permission overrides from the 'repo_permission_overrides' table. The
+// overrides are enforced by database.AuthzQueryConds.
+//
+// Changes to overrides invalidate database.SearchReposCache once they are
+// committed.
+type RepoPermOverridesStore struct {
+	*basestore.Store
+}
+
+// RepoPermOverrides returns a new RepoPermOverridesStore with the given parameters.
+func RepoPermOverrides(db dbutil.DB) *RepoPermOverridesStore {
+	return &RepoPermOverridesStore{Store: basestore.NewWithDB(db, sql.TxOptions{})}
+}
+
+// With returns a new RepoPermOverridesStore with the given basestore.Store.
+func (s *RepoPermOverridesStore) With(other basestore.ShareableStore) *RepoPermOverridesStore {
+	return &RepoPermOverridesStore{Store: s.Store.With(other)}
+}
+
+// Transact begins a new transaction and make a new RepoPermOverridesStore over it.
+func (s *RepoPermOverridesStore) Transact(ctx context.Context) (*RepoPermOverridesStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &RepoPermOverridesStore{Store: txBase}, err
+}
+
+// invalidateSearchRepos invalidates the search repositories cache once the
+// transaction of the store is committed, so that changed overrides are
+// enforced in search right away without repositories resolved concurrently
+// from the previous overrides being cached again.
+func (s *RepoPermOverridesStore) invalidateSearchRepos(ctx context.Context) {
+	s.AfterCommit(func() { database.InvalidateSearchRepos(ctx) })
+}
+
+const repoPermOverrideColumns = `id, repo_id, user_id, effect, reason, expires_at, created_by_user_id, created_at, updated_at`
+
+// Create inserts the override of the read permission and sets its ID and
+// timestamps. It returns ErrRepoPermOverrideExists if the user already has an
+// override on the repository.
+func (s *RepoPermOverridesStore) Create(ctx context.Context, o *RepoPermOverride) error {
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/database/repo_perm_overrides_store.go:RepoPermOverridesStore.Create
+INSERT INTO repo_permission_overrides (repo_id, user_id, permission, effect, reason, expires_at, created_by_user_id)
+VALUES (%s, %s, %s, %s, %s, %s, NULLIF(%s, 0))
+RETURNING `+repoPermOverrideColumns,
+		o.RepoID, o.UserID, authz.Read.String(), o.Effect, o.Reason, o.ExpiresAt, o.CreatedByUserID)
+
+	overrides, err := s.scan(s.Query(ctx, q))
+	if err != nil {
+		if dbutil.IsPostgresError(err, "23505") { // unique_violation
+			return ErrRepoPermOverrideExists
+		}
+		return errors.Wrap(err, "creating repository permission override")
+	}
+	*o = *overrides[0]
+	s.invalidateSearchRepos(ctx)
+	return nil
+}
+
+// Update updates the effect, reason and expiry of the override with the ID of
+// the given override and sets its timestamps.
+func (s *RepoPermOverridesStore) Update(ctx context.Context, o *RepoPermOverride) error {
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/database/repo_perm_overrides_store.go:RepoPermOverridesStore.Update
+UPDATE repo_permission_overrides
+SET
+  effect = %s,
+  reason = %s,
+  expires_at = %s,
+  updated_at = now()
+WHERE id = %s
+RETURNING `+repoPermOverrideColumns,
+		o.Effect, o.Reason, o.ExpiresAt, o.ID)
+
+	overrides, err := s.scan(s.Query(ctx, q))
+	if err != nil {
+		return errors.Wrap(err, "updating repository permission override")
+	}
+	if len(overrides) == 0 {
+		return ErrRepoPermOverrideNotFound
+	}
+	*o = *overrides[0]
+	s.invalidateSearchRepos(ctx)
+	return nil
+}
+
+// Delete deletes the override with the given ID.
+func (s *RepoPermOverridesStore) Delete(ctx context.Context, id int64) error {
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/database/repo_perm_overrides_store.go:RepoPermOverridesStore.Delete
+DELETE FROM repo_permission_overrides WHERE id = %s
+`, id)
+
+	res, err := s.ExecResult(ctx, q)
+	if err != nil {
+		return errors.Wrap(err, "deleting repository permission override")
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return ErrRepoPermOverrideNotFound
+	}
+	s.invalidateSearchRepos(ctx)
+	return nil
+}
+
+// GetByID returns the override with the given ID.
+func (s *RepoPermOverridesStore) GetByID(ctx context.Context, id int64) (*RepoPermOverride, error) {
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/database/repo_perm_overrides_store.go:RepoPermOverridesStore.GetByID
+SELECT `+repoPermOverrideColumns+`
+FROM repo_permission_overrides
+WHERE id = %s
+`, id)
+
+	overrides, err := s.scan(s.Query(ctx, q))
+	if err != nil {
+		return nil, errors.Wrap(err, "getting repository permission override")
+	}
+	if len(overrides) == 0 {
+		return nil, ErrRepoPermOverrideNotFound
+	}
+	return overrides[0], nil
+}
+
+// RepoPermOverridesListOpts are the options of RepoPermOverridesStore.List.
+type RepoPermOverridesListOpts struct {
+	// RepoID, if non-zero, restricts the results to overrides of the repository.
+	RepoID api.RepoID
+	// UserID, if non-zero, restricts the results to overrides of the user.
+	UserID int32
+	// IncludeExpired includes overrides that no longer apply.
+	IncludeExpired bool
+}
+
+// List returns the overrides matching the options, most recently created first.
+func (s *RepoPermOverridesStore) List(ctx context.Context, opts RepoPermOverridesListOpts) ([]*RepoPermOverride, error) {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.RepoID != 0 {
+		conds = append(conds, sqlf.Sprintf("repo_id = %s", opts.RepoID))
+	}
+	if opts.UserID != 0 {
+		conds = append(conds, sqlf.Sprintf("user_id = %s", opts.UserID))
+	}
+	if !opts.IncludeExpired {
+		conds = append(conds, sqlf.Sprintf("(expires_at IS NULL OR expires_at > now())"))
+	}
+
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/database/repo_perm_overrides_store.go:RepoPermOverridesStore.List
+SELECT `+repoPermOverrideColumns+`
+FROM repo_permission_overrides
+WHERE %s
+ORDER BY created_at DESC, id DESC
+`, sqlf.Join(conds, "AND"))
+
+	overrides, err := s.scan(s.Query(ctx, q))
+	if err != nil {
+		return nil, errors.Wrap(err, "listing repository permission overrides")
+	}
+	return overrides, nil
+}
+
+func (s *RepoPermOverridesStore) scan(rows *sql.Rows, queryErr error) (_ []*RepoPermOverride, err error) {
+	if queryErr != nil {
+		return nil, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var overrides []*RepoPermOverride
+	for rows.Next() {
+		var o RepoPermOverride
+		if err := rows.Scan(
+			&o.ID,
+			&o.RepoID,
+			&o.UserID,
+			&o.Effect,
+			&o.Reason,
+			&o.ExpiresAt,
+			&dbutil.NullInt32{N: &o.CreatedByUserID},
+			&o.CreatedAt,
+			&o.UpdatedAt,
+		); err != nil {
+			return nil, err
+		}
+		overrides = append(overrides, &o)
+	}
+	return overrides, nil
+}
@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/cache"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestRepoPermOverridesStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+
+	db := dbtest.NewDB(t, *dsn)
+	ctx := context.Background()
+	s := RepoPermOverrides(db)
+
+	qs := []*sqlf.Query{
+		sqlf.Sprintf(`INSERT INTO users(username) VALUES ('admin')`),
+		sqlf.Sprintf(`INSERT INTO users(username) VALUES ('alice')`),
+		sqlf.Sprintf(`INSERT INTO repo(name) VALUES ('github.com/foo/bar')`),
+		sqlf.Sprintf(`INSERT INTO repo(name) VALUES ('github.com/foo/baz')`),
+	}
+	for _, q := range qs {
+		if err := s.Exec(ctx, q); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	expiresAt := time.Now().Add(time.Hour).UTC().Truncate(time.Microsecond)
+	expiredAt := time.Now().Add(-time.Hour).UTC().Truncate(time.Microsecond)
+
+	grant := &RepoPermOverride{RepoID: 1, UserID: 2, Effect: RepoPermOverrideGrant, Reason: "contractor", ExpiresAt: &expiresAt, CreatedByUserID: 1}
+	deny := &RepoPermOverride{RepoID: 2, UserID: 2, Effect: RepoPermOverrideDeny}
+
+	t.Run("Create", func(t *testing.T) {
+		for _, o := range []*RepoPermOverride{grant, deny} {
+			if err := s.Create(ctx, o); err != nil {
+				t.Fatal(err)
+			}
+			if o.ID == 0 || o.CreatedAt.IsZero() {
+				t.Fatalf("want ID and timestamps to be set, got %+v", o)
+			}
+		}
+
+		err := s.Create(ctx, &RepoPermOverride{RepoID: 1, UserID: 2, Effect: RepoPermOverrideDeny})
+		if err != ErrRepoPermOverrideExists {
+			t.Fatalf("err: want %q but got %v", ErrRepoPermOverrideExists, err)
+		}
+	})
+
+	t.Run("GetByID", func(t *testing.T) {
+		have, err := s.GetByID(ctx, grant.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(grant, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		if _, err := s.GetByID(ctx, 1000); err != ErrRepoPermOverrideNotFound {
+			t.Fatalf("err: want %q but got %v", ErrRepoPermOverrideNotFound, err)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		update := &RepoPermOverride{ID: deny.ID, Effect: RepoPermOverrideGrant, Reason: "break-glass", ExpiresAt: &expiredAt}
+		if err := s.Update(ctx, update); err != nil {
+			t.Fatal(err)
+		}
+		if update.RepoID != deny.RepoID || update.Effect != RepoPermOverrideGrant || !update.Expired(time.Now()) {
+			t.Fatalf("unexpected override after update: %+v", update)
+		}
+		*deny = *update
+
+		if err := s.Update(ctx, &RepoPermOverride{ID: 1000, Effect: RepoPermOverrideDeny}); err != ErrRepoPermOverrideNotFound {
+			t.Fatalf("err: want %q but got %v", ErrRepoPermOverrideNotFound, err)
+		}
+	})
+
+	t.Run("List", func(t *testing.T) {
+		for _, test := range []struct {
+			name string
+			opts RepoPermOverridesListOpts
+			want []*RepoPermOverride
+		}{
+			{name: "unexpired", want: []*RepoPermOverride{grant}},
+			{name: "include expired", opts: RepoPermOverridesListOpts{IncludeExpired: true}, want: []*RepoPermOverride{deny, grant}},
+			{name: "by repo", opts: RepoPermOverridesListOpts{RepoID: api.RepoID(2), IncludeExpired: true}, want: []*RepoPermOverride{deny}},
+			{name: "by user", opts: RepoPermOverridesListOpts{UserID: 1, IncludeExpired: true}},
+		} {
+			t.Run(test.name, func(t *testing.T) {
+				have, err := s.List(ctx, test.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(test.want, have); diff != "" {
+					t.Fatal(diff)
+				}
+			})
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := s.Delete(ctx, grant.ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.Delete(ctx, grant.ID); err != ErrRepoPermOverrideNotFound {
+			t.Fatalf("err: want %q but got %v", ErrRepoPermOverrideNotFound, err)
+		}
+	})
+}
+
+func TestRepoPermOverridesStore_InvalidatesSearchRepos(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	db := dbtest.NewDB(t, *dsn)
+	ctx := context.Background()
+	s := RepoPermOverrides(db)
+
+	database.SearchReposCache = cache.NewVersioned(cache.NewMemory(), time.Minute)
+	t.Cleanup(func() { database.SearchReposCache = nil })
+
+	generation := func() cache.Generation {
+		t.Helper()
+		g, _, err := database.SearchReposCache.GetJSON(ctx, "k", new(int))
+		if err != nil {
+			t.Fatal(err)
+		}
+		return g
+	}
+
+	for _, q := range []*sqlf.Query{
+		sqlf.Sprintf(`INSERT INTO users(username) VALUES ('alice')`),
+		sqlf.Sprintf(`INSERT INTO repo(name) VALUES ('github.com/foo/bar')`),
+	} {
+		if err := s.Exec(ctx, q); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	deny := &RepoPermOverride{RepoID: 1, UserID: 1, Effect: RepoPermOverrideDeny}
+	before := generation()
+	if err := s.Create(ctx, deny); err != nil {
+		t.Fatal(err)
+	}
+	if generation() == before {
+		t.Fatal("want search repositories cache to be invalidated after Create")
+	}
+
+	// Within a transaction, the cache is invalidated once it is committed.
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	before = generation()
+	if err := tx.Delete(ctx, deny.ID); err != nil {
+		t.Fatal(err)
+	}
+	if generation() != before {
+		t.Fatal("want search repositories cache to be invalidated only after commit")
+	}
+	if err := tx.Done(nil); err != nil {
+		t.Fatal(err)
+	}
+	if generation() == before {
+		t.Fatal("want search repositories cache to be invalidated after commit")
+	}
+}
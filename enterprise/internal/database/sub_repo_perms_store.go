@@ -0,0 +1,169 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cockroachdb/errors"
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// SubRepoPermsVersion defines the version we are using to encode our include
+// and exclude patterns.
+var SubRepoPermsVersion = 1
+
+// SubRepoPermsStore is responsible for storing and retrieving sub-repository
+// permissions from the 'sub_repo_permissions' table.
+type SubRepoPermsStore struct {
+	*basestore.Store
+}
+
+// SubRepoPerms returns a new SubRepoPermsStore with the given parameters.
+func SubRepoPerms(db dbutil.DB) *SubRepoPermsStore {
+	return &SubRepoPermsStore{Store: basestore.NewWithDB(db, sql.TxOptions{})}
+}
+
+func (s *SubRepoPermsStore) With(other basestore.ShareableStore) *SubRepoPermsStore {
+	return &SubRepoPermsStore{Store: s.Store.With(other)}
+}
+
+// Transact begins a new transaction and make a new SubRepoPermsStore over it.
+func (s *SubRepoPermsStore) Transact(ctx context.Context) (*SubRepoPermsStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &SubRepoPermsStore{Store: txBase}, err
+}
+
+func (s *SubRepoPermsStore) Done(err error) error {
+	return s.Store.Done(err)
+}
+
+// Upsert will upsert sub repo permissions data.
+func (s *SubRepoPermsStore) Upsert(ctx context.Context, userID int32, repoID api.RepoID, perms authz.SubRepoPermissions) error {
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/database/sub_repo_perms_store.go:SubRepoPermsStore.Upsert
+INSERT INTO sub_repo_permissions (user_id, repo_id, path_includes, path_excludes, version, updated_at)
+VALUES (%s, %s, %s, %s, %s, now())
+ON CONFLICT (user_id, repo_id, version)
+DO UPDATE
+SET
+  user_id = EXCLUDED.user_id,
+  repo_id = EXCLUDED.repo_id,
+  path_includes = EXCLUDED.path_includes,
+  path_excludes = EXCLUDED.path_excludes,
+  version = EXCLUDED.version,
+  updated_at = now()
+`, userID, repoID, pq.Array(perms.PathIncludes), pq.Array(perms.PathExcludes), SubRepoPermsVersion)
+	return errors.Wrap(s.Exec(ctx, q), "upserting sub repo permissions")
+}
+
+// UpsertWithSpec will upsert sub repo permissions data using the provided
+// external repo spec to map to our internal repo id. If there is no mapping,
+// nothing is written.
+func (s *SubRepoPermsStore) UpsertWithSpec(ctx context.Context, userID int32, spec api.ExternalRepoSpec, perms authz.SubRepoPermissions) error {
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/database/sub_repo_perms_store.go:SubRepoPermsStore.UpsertWithSpec
+INSERT INTO sub_repo_permissions (user_id, repo_id, path_includes, path_excludes, version, updated_at)
+SELECT %s, id, %s, %s, %s, now()
+FROM repo
+WHERE external_service_id = %s
+  AND external_service_type = %s
+  AND external_id = %s
+ON CONFLICT (user_id, repo_id, version)
+DO UPDATE
+SET
+  user_id = EXCLUDED.user_id,
+  repo_id = EXCLUDED.repo_id,
+  path_includes = EXCLUDED.path_includes,
+  path_excludes = EXCLUDED.path_excludes,
+  version = EXCLUDED.version,
+  updated_at = now()
+`, userID, pq.Array(perms.PathIncludes), pq.Array(perms.PathExcludes), SubRepoPermsVersion, spec.ServiceID, spec.ServiceType, spec.ID)
+	return errors.Wrap(s.Exec(ctx, q), "upserting sub repo permissions with spec")
+}
+
+// DeleteByUserExcept deletes the sub repo permissions of the given user on the
+// repositories of the code host with the given service type and ID, except for
+// the repositories with the given external IDs. It is used to remove rules that
+// the code host no longer reports.
+func (s *SubRepoPermsStore) DeleteByUserExcept(ctx context.Context, userID int32, serviceType, serviceID string, externalIDs []string) error {
+	if Mocks.SubRepoPerms.DeleteByUserExcept != nil {
+		return Mocks.SubRepoPerms.DeleteByUserExcept(ctx, userID, serviceType, serviceID, externalIDs)
+	}
+
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/database/sub_repo_perms_store.go:SubRepoPermsStore.DeleteByUserExcept
+DELETE FROM sub_repo_permissions p
+USING repo r
+WHERE r.id = p.repo_id
+  AND p.user_id = %s
+  AND p.version = %s
+  AND r.external_service_type = %s
+  AND r.external_service_id = %s
+  AND NOT r.external_id = ANY(%s)
+`, userID, SubRepoPermsVersion, serviceType, serviceID, pq.Array(externalIDs))
+	return errors.Wrap(s.Exec(ctx, q), "deleting sub repo permissions")
+}
+
+// Get will fetch sub repo rules for the given repo and user combination.
+func (s *SubRepoPermsStore) Get(ctx context.Context, userID int32, repoID api.RepoID) (_ *authz.SubRepoPermissions, err error) {
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/database/sub_repo_perms_store.go:SubRepoPermsStore.Get
+SELECT path_includes, path_excludes
+FROM sub_repo_permissions
+WHERE repo_id = %s
+  AND user_id = %s
+  AND version = %s
+`, repoID, userID, SubRepoPermsVersion)
+
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting sub repo permissions")
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	perms := new(authz.SubRepoPermissions)
+	for rows.Next() {
+		if err := rows.Scan(pq.Array(&perms.PathIncludes), pq.Array(&perms.PathExcludes)); err != nil {
+			return nil, errors.Wrap(err, "scanning row")
+		}
+	}
+	return perms, rows.Err()
+}
+
+// GetByUser fetches all sub repo perms for a user keyed by repo.
+func (s *SubRepoPermsStore) GetByUser(ctx context.Context, userID int32) (_ map[api.RepoName]authz.SubRepoPermissions, err error) {
+	q := sqlf.Sprintf(`
+-- source: enterprise/internal/database/sub_repo_perms_store.go:SubRepoPermsStore.GetByUser
+SELECT r.name, p.path_includes, p.path_excludes
+FROM sub_repo_permissions p
+JOIN repo r ON r.id = p.repo_id
+WHERE p.user_id = %s
+  AND p.version = %s
+  AND r.deleted_at IS NULL
+`, userID, SubRepoPermsVersion)
+
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting sub repo permissions by user")
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	result := make(map[api.RepoName]authz.SubRepoPermissions)
+	for rows.Next() {
+		var (
+			repoName api.RepoName
+			perms    authz.SubRepoPermissions
+		)
+		if err := rows.Scan(&repoName, pq.Array(&perms.PathIncludes), pq.Array(&perms.PathExcludes)); err != nil {
+			return nil, errors.Wrap(err, "scanning row")
+		}
+		result[repoName] = perms
+	}
+	return result, rows.Err()
+}
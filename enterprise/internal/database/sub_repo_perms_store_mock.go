@@ -0,0 +1,9 @@
+package database
+
+import (
+	"context"
+)
+
+type MockSubRepoPerms struct {
+	DeleteByUserExcept func(ctx context.Context, userID int32, serviceType, serviceID string, externalIDs []string) error
+}
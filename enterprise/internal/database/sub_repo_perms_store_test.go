@@ -0,0 +1,141 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestSubRepoPermsStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+
+	db := dbtest.NewDB(t, *dsn)
+	ctx := context.Background()
+	s := SubRepoPerms(db)
+
+	qs := []*sqlf.Query{
+		sqlf.Sprintf(`INSERT INTO users(username) VALUES ('alice')`),
+		sqlf.Sprintf(`INSERT INTO repo(name, external_id, external_service_type, external_service_id) VALUES ('github.com/foo/bar', 'MDEwOlJlcG9zaXRvcnk0MTI4ODcwOA==', 'github', 'https://github.com/')`),
+		sqlf.Sprintf(`INSERT INTO repo(name) VALUES ('github.com/foo/baz')`),
+	}
+	for _, q := range qs {
+		if err := s.Exec(ctx, q); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	userID := int32(1)
+
+	t.Run("Upsert", func(t *testing.T) {
+		perms := authz.SubRepoPermissions{
+			PathIncludes: []string{"/src/**"},
+			PathExcludes: []string{"/src/secret/**"},
+		}
+		if err := s.Upsert(ctx, userID, api.RepoID(2), perms); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.Get(ctx, userID, api.RepoID(2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(&perms, have); diff != "" {
+			t.Fatal(diff)
+		}
+
+		// Upserting again should overwrite the existing rules.
+		perms.PathExcludes = nil
+		if err := s.Upsert(ctx, userID, api.RepoID(2), perms); err != nil {
+			t.Fatal(err)
+		}
+		have, err = s.Get(ctx, userID, api.RepoID(2))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(&perms, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("UpsertWithSpec", func(t *testing.T) {
+		perms := authz.SubRepoPermissions{
+			PathIncludes: []string{"/docs/**"},
+			PathExcludes: []string{},
+		}
+		spec := api.ExternalRepoSpec{
+			ID:          "MDEwOlJlcG9zaXRvcnk0MTI4ODcwOA==",
+			ServiceType: "github",
+			ServiceID:   "https://github.com/",
+		}
+		if err := s.UpsertWithSpec(ctx, userID, spec, perms); err != nil {
+			t.Fatal(err)
+		}
+
+		have, err := s.Get(ctx, userID, api.RepoID(1))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(&perms, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+
+	t.Run("GetByUser", func(t *testing.T) {
+		have, err := s.GetByUser(ctx, userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := map[api.RepoName]authz.SubRepoPermissions{
+			"github.com/foo/bar": {
+				PathIncludes: []string{"/docs/**"},
+				PathExcludes: []string{},
+			},
+			"github.com/foo/baz": {
+				PathIncludes: []string{"/src/**"},
+			},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+	t.Run("DeleteByUserExcept", func(t *testing.T) {
+		// Keeping the repository retains its rules.
+		if err := s.DeleteByUserExcept(ctx, userID, "github", "https://github.com/", []string{"MDEwOlJlcG9zaXRvcnk0MTI4ODcwOA=="}); err != nil {
+			t.Fatal(err)
+		}
+		have, err := s.GetByUser(ctx, userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(have) != 2 {
+			t.Fatalf("have %d repos with rules, want 2", len(have))
+		}
+
+		// Only the rules of repositories of the code host are deleted.
+		if err := s.DeleteByUserExcept(ctx, userID, "github", "https://github.com/", nil); err != nil {
+			t.Fatal(err)
+		}
+		have, err = s.GetByUser(ctx, userID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := map[api.RepoName]authz.SubRepoPermissions{
+			"github.com/foo/baz": {
+				PathIncludes: []string{"/src/**"},
+			},
+		}
+		if diff := cmp.Diff(want, have); diff != "" {
+			t.Fatal(diff)
+		}
+	})
+}
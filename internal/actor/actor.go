@@ -24,6 +24,11 @@ type Actor struct {
 	// to selectively display a logout link. (If the actor wasn't authenticated with a session
 	// cookie, logout would be ineffective.)
 	FromSessionCookie bool `json:"-"`
+
+	// ImpersonatorUID is the unique ID of the site admin impersonating the user
+	// identified by UID, or 0 if the actor is not impersonated. Impersonated
+	// actors must only be allowed to perform read operations.
+	ImpersonatorUID int32 `json:",omitempty"`
 }
 
 // FromUser returns an actor corresponding to a user
@@ -41,6 +46,12 @@ func (a *Actor) IsAuthenticated() bool {
 	return a != nil && a.UID != 0
 }
 
+// IsImpersonated returns true if a site admin is impersonating the user of
+// the Actor.
+func (a *Actor) IsImpersonated() bool {
+	return a != nil && a.UID != 0 && a.ImpersonatorUID != 0
+}
+
 type key int
 
 const actorKey key = iota
@@ -0,0 +1,75 @@
+package authz
+
+import (
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// PermissionsFreshness configures how long the permissions synced from the
+// code host of a provider are served.
+//
+// Permissions older than TTL are stale: they keep being enforced while they
+// are refreshed in the background. Permissions older than MaxStaleness are no
+// longer enforced, which denies access to the private repositories they
+// grant. A zero TTL means permissions are refreshed continuously, and a zero
+// MaxStaleness means permissions are enforced regardless of their age.
+type PermissionsFreshness struct {
+	TTL          time.Duration
+	MaxStaleness time.Duration
+}
+
+// FreshnessProvider is implemented by providers whose permissions freshness is
+// configurable.
+type FreshnessProvider interface {
+	PermissionsFreshness() PermissionsFreshness
+}
+
+// ParsePermissionsFreshness parses the "ttl" and "maxStaleness" fields of the
+// authorization config of a code host connection. Both are optional.
+func ParsePermissionsFreshness(ttl, maxStaleness string) (PermissionsFreshness, error) {
+	var f PermissionsFreshness
+	var err error
+	if ttl != "" {
+		if f.TTL, err = time.ParseDuration(ttl); err != nil {
+			return f, errors.Wrap(err, "authorization.ttl")
+		}
+		if f.TTL < 0 {
+			return f, errors.Errorf("authorization.ttl must not be negative: %s", ttl)
+		}
+	}
+	if maxStaleness != "" {
+		if f.MaxStaleness, err = time.ParseDuration(maxStaleness); err != nil {
+			return f, errors.Wrap(err, "authorization.maxStaleness")
+		}
+		if f.MaxStaleness <= 0 {
+			return f, errors.Errorf("authorization.maxStaleness must be positive: %s", maxStaleness)
+		}
+		if f.MaxStaleness < f.TTL {
+			return f, errors.Errorf("authorization.maxStaleness (%s) must not be shorter than authorization.ttl (%s)", maxStaleness, ttl)
+		}
+	}
+	return f, nil
+}
+
+// StrictestPermissionsFreshness returns the shortest TTL and max staleness of
+// the given providers. Permissions of users are synced from all providers at
+// once, so they are only as fresh as the strictest provider requires.
+// Providers which don't implement FreshnessProvider refresh permissions
+// continuously.
+func StrictestPermissionsFreshness(providers []Provider) PermissionsFreshness {
+	var strictest PermissionsFreshness
+	for i, p := range providers {
+		var f PermissionsFreshness
+		if fp, ok := p.(FreshnessProvider); ok {
+			f = fp.PermissionsFreshness()
+		}
+		if i == 0 || f.TTL < strictest.TTL {
+			strictest.TTL = f.TTL
+		}
+		if f.MaxStaleness > 0 && (strictest.MaxStaleness == 0 || f.MaxStaleness < strictest.MaxStaleness) {
+			strictest.MaxStaleness = f.MaxStaleness
+		}
+	}
+	return strictest
+}
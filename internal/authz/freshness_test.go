@@ -0,0 +1,62 @@
+package authz
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParsePermissionsFreshness(t *testing.T) {
+	for _, tc := range []struct {
+		ttl, maxStaleness string
+		want              PermissionsFreshness
+		wantErr           bool
+	}{
+		{"", "", PermissionsFreshness{}, false},
+		{"3h", "", PermissionsFreshness{TTL: 3 * time.Hour}, false},
+		{"", "72h", PermissionsFreshness{MaxStaleness: 72 * time.Hour}, false},
+		{"3h", "72h", PermissionsFreshness{TTL: 3 * time.Hour, MaxStaleness: 72 * time.Hour}, false},
+		{"3h", "3h", PermissionsFreshness{TTL: 3 * time.Hour, MaxStaleness: 3 * time.Hour}, false},
+		{"three hours", "", PermissionsFreshness{}, true},
+		{"-1h", "", PermissionsFreshness{}, true},
+		{"", "0s", PermissionsFreshness{}, true},
+		{"3h", "1h", PermissionsFreshness{}, true},
+	} {
+		got, err := ParsePermissionsFreshness(tc.ttl, tc.maxStaleness)
+		if gotErr := err != nil; gotErr != tc.wantErr {
+			t.Errorf("ParsePermissionsFreshness(%q, %q): got error %v, want error %t", tc.ttl, tc.maxStaleness, err, tc.wantErr)
+			continue
+		}
+		if !tc.wantErr && got != tc.want {
+			t.Errorf("ParsePermissionsFreshness(%q, %q): got %+v, want %+v", tc.ttl, tc.maxStaleness, got, tc.want)
+		}
+	}
+}
+
+type freshnessProvider struct {
+	Provider
+	freshness PermissionsFreshness
+}
+
+func (p freshnessProvider) PermissionsFreshness() PermissionsFreshness { return p.freshness }
+
+type plainProvider struct{ Provider }
+
+func TestStrictestPermissionsFreshness(t *testing.T) {
+	hourly := freshnessProvider{freshness: PermissionsFreshness{TTL: time.Hour, MaxStaleness: 72 * time.Hour}}
+	daily := freshnessProvider{freshness: PermissionsFreshness{TTL: 24 * time.Hour, MaxStaleness: 48 * time.Hour}}
+	unlimited := freshnessProvider{freshness: PermissionsFreshness{TTL: 3 * time.Hour}}
+
+	for name, tc := range map[string]struct {
+		providers []Provider
+		want      PermissionsFreshness
+	}{
+		"none":             {nil, PermissionsFreshness{}},
+		"single":           {[]Provider{hourly}, hourly.freshness},
+		"shortest of each": {[]Provider{hourly, daily, unlimited}, PermissionsFreshness{TTL: time.Hour, MaxStaleness: 48 * time.Hour}},
+		"continuous":       {[]Provider{daily, plainProvider{}}, PermissionsFreshness{MaxStaleness: 48 * time.Hour}},
+	} {
+		if got := StrictestPermissionsFreshness(tc.providers); got != tc.want {
+			t.Errorf("%s: got %+v, want %+v", name, got, tc.want)
+		}
+	}
+}
@@ -47,7 +47,14 @@ func newAuthzProvider(urn string, a *schema.GitHubAuthorization, instanceURL, to
 		return nil, errors.Errorf("Could not parse URL for GitHub instance %q: %s", instanceURL, err)
 	}
 
-	return NewProvider(urn, ghURL, token, nil), nil
+	freshness, err := authz.ParsePermissionsFreshness(a.Ttl, a.MaxStaleness)
+	if err != nil {
+		return nil, errors.Errorf("Invalid authorization for GitHub instance %q: %s", instanceURL, err)
+	}
+
+	p := NewProvider(urn, ghURL, token, nil)
+	p.freshness = freshness
+	return p, nil
 }
 
 // ValidateAuthz validates the authorization fields of the given GitHub external
@@ -20,6 +20,8 @@ type Provider struct {
 	urn      string
 	client   client
 	codeHost *extsvc.CodeHost
+
+	freshness authz.PermissionsFreshness
 }
 
 func NewProvider(urn string, githubURL *url.URL, baseToken string, client *github.V3Client) *Provider {
@@ -59,6 +61,11 @@ func (p *Provider) Validate() (problems []string) {
 	return nil
 }
 
+// PermissionsFreshness implements the authz.FreshnessProvider interface.
+func (p *Provider) PermissionsFreshness() authz.PermissionsFreshness {
+	return p.freshness
+}
+
 // FetchUserPermsByToken fetches all the private repo ids that the token can
 // access.
 func (p *Provider) FetchUserPermsByToken(ctx context.Context, token string) (*authz.ExternalUserPermissions, error) {
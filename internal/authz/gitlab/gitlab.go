@@ -50,6 +50,11 @@ func newAuthzProvider(urn string, a *schema.GitLabAuthorization, instanceURL, to
 		return nil, errors.Errorf("Could not parse URL for GitLab instance %q: %s", instanceURL, err)
 	}
 
+	freshness, err := authz.ParsePermissionsFreshness(a.Ttl, a.MaxStaleness)
+	if err != nil {
+		return nil, errors.Errorf("Invalid authorization for GitLab instance %q: %s", instanceURL, err)
+	}
+
 	switch idp := a.IdentityProvider; {
 	case idp.Oauth != nil:
 		// Check that there is a GitLab authn provider corresponding to this GitLab instance
@@ -81,6 +86,7 @@ func newAuthzProvider(urn string, a *schema.GitLabAuthorization, instanceURL, to
 			BaseURL:   glURL,
 			Token:     token,
 			TokenType: tokenType,
+			Freshness: freshness,
 		}), nil
 	case idp.Username != nil:
 		return NewSudoProvider(SudoProviderOp{
@@ -88,6 +94,7 @@ func newAuthzProvider(urn string, a *schema.GitLabAuthorization, instanceURL, to
 			BaseURL:           glURL,
 			SudoToken:         token,
 			UseNativeUsername: true,
+			Freshness:         freshness,
 		}), nil
 	case idp.External != nil:
 		ext := idp.External
@@ -107,6 +114,7 @@ func newAuthzProvider(urn string, a *schema.GitLabAuthorization, instanceURL, to
 					GitLabProvider:    ext.GitlabProvider,
 					SudoToken:         token,
 					UseNativeUsername: false,
+					Freshness:         freshness,
 				}), nil
 			}
 		}
@@ -25,6 +25,7 @@ type OAuthProvider struct {
 	clientProvider *gitlab.ClientProvider
 	clientURL      *url.URL
 	codeHost       *extsvc.CodeHost
+	freshness      authz.PermissionsFreshness
 }
 
 type OAuthProviderOp struct {
@@ -41,6 +42,9 @@ type OAuthProviderOp struct {
 
 	// TokenType is the type of the access token. Default is gitlab.TokenTypePAT.
 	TokenType gitlab.TokenType
+
+	// Freshness configures how long the synced permissions are served.
+	Freshness authz.PermissionsFreshness
 }
 
 func newOAuthProvider(op OAuthProviderOp, cli httpcli.Doer) *OAuthProvider {
@@ -52,6 +56,7 @@ func newOAuthProvider(op OAuthProviderOp, cli httpcli.Doer) *OAuthProvider {
 		clientProvider: gitlab.NewClientProvider(op.BaseURL, cli),
 		clientURL:      op.BaseURL,
 		codeHost:       extsvc.NewCodeHost(op.BaseURL, extsvc.TypeGitLab),
+		freshness:      op.Freshness,
 	}
 }
 
@@ -59,6 +64,11 @@ func (p *OAuthProvider) Validate() (problems []string) {
 	return nil
 }
 
+// PermissionsFreshness implements the authz.FreshnessProvider interface.
+func (p *OAuthProvider) PermissionsFreshness() authz.PermissionsFreshness {
+	return p.freshness
+}
+
 func (p *OAuthProvider) URN() string {
 	return p.urn
 }
@@ -32,6 +32,7 @@ type SudoProvider struct {
 	gitlabProvider    string
 	authnConfigID     providers.ConfigID
 	useNativeUsername bool
+	freshness         authz.PermissionsFreshness
 }
 
 var _ authz.Provider = (*SudoProvider)(nil)
@@ -60,6 +61,9 @@ type SudoProviderOp struct {
 	// instead of the authn provider user ID. This is *very* insecure (Sourcegraph usernames can be
 	// changed at the user's will) and should only be used in development environments.
 	UseNativeUsername bool
+
+	// Freshness configures how long the synced permissions are served.
+	Freshness authz.PermissionsFreshness
 }
 
 func newSudoProvider(op SudoProviderOp, cli httpcli.Doer) *SudoProvider {
@@ -73,6 +77,7 @@ func newSudoProvider(op SudoProviderOp, cli httpcli.Doer) *SudoProvider {
 		authnConfigID:     op.AuthnConfigID,
 		gitlabProvider:    op.GitLabProvider,
 		useNativeUsername: op.UseNativeUsername,
+		freshness:         op.Freshness,
 	}
 }
 
@@ -89,6 +94,11 @@ func (p *SudoProvider) Validate() (problems []string) {
 	return problems
 }
 
+// PermissionsFreshness implements the authz.FreshnessProvider interface.
+func (p *SudoProvider) PermissionsFreshness() authz.PermissionsFreshness {
+	return p.freshness
+}
+
 func (p *SudoProvider) URN() string {
 	return p.urn
 }
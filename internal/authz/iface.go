@@ -19,6 +19,11 @@ type ExternalUserPermissions struct {
 	Exacts          []extsvc.RepoID
 	IncludePrefixes []extsvc.RepoID
 	ExcludePrefixes []extsvc.RepoID
+
+	// SubRepoPermissions contains the path-level access rules of repositories
+	// that the user can only partially view. Repositories that are absent have
+	// no path-level restrictions.
+	SubRepoPermissions map[extsvc.RepoID]*SubRepoPermissions
 }
 
 // Provider defines a source of truth of which repositories a user is authorized to view. The
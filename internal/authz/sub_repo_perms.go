@@ -0,0 +1,352 @@
+package authz
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gobwas/glob"
+	lru "github.com/hashicorp/golang-lru"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+)
+
+// SubRepoPermissions denotes access control rules within a repository's
+// contents.
+//
+// Rules are expressed as Glob syntaxes relative to the repository root:
+//
+//	"/dir/**"        matches every file under "dir"
+//	"/dir/*.go"      matches every Go file directly under "dir"
+//
+// A path is accessible if it matches at least one of PathIncludes and none of
+// PathExcludes. Directories are denoted by a trailing slash and are accessible
+// if they contain any accessible path.
+type SubRepoPermissions struct {
+	PathIncludes []string
+	PathExcludes []string
+}
+
+// RepoContent specifies data existing in a repo. It currently only supports
+// paths but will be extended in future to support other pieces of metadata,
+// for example branch.
+type RepoContent struct {
+	Repo api.RepoName
+	Path string
+}
+
+// SubRepoPermissionChecker is the interface exposed by the SubRepoPermsClient
+// and is exposed to allow consumers to mock out the client.
+type SubRepoPermissionChecker interface {
+	// Permissions takes a userID and repo content and returns the permissions
+	// the user has on that content. A userID of zero indicates an anonymous
+	// user.
+	Permissions(ctx context.Context, userID int32, content RepoContent) (Perms, error)
+
+	// Enabled indicates whether sub-repo permissions are enabled.
+	Enabled() bool
+
+	// EnabledForRepo indicates whether the given user has sub-repo permissions
+	// rules for the given repository, i.e. whether they may only view part of
+	// it.
+	EnabledForRepo(ctx context.Context, userID int32, repo api.RepoName) (bool, error)
+}
+
+// DefaultSubRepoPermsChecker is the default implementation of
+// SubRepoPermissionChecker. It is expected to be replaced during service
+// startup once a database connection is available.
+var DefaultSubRepoPermsChecker SubRepoPermissionChecker = &noopPermsChecker{}
+
+type noopPermsChecker struct{}
+
+func (*noopPermsChecker) Permissions(ctx context.Context, userID int32, content RepoContent) (Perms, error) {
+	return None, nil
+}
+
+func (*noopPermsChecker) Enabled() bool {
+	return false
+}
+
+func (*noopPermsChecker) EnabledForRepo(ctx context.Context, userID int32, repo api.RepoName) (bool, error) {
+	return false, nil
+}
+
+// SubRepoPermissionsGetter allows getting sub-repository permissions.
+type SubRepoPermissionsGetter interface {
+	// GetByUser returns the sub-repository permissions rules known for a user,
+	// keyed by the name of the repository they apply to.
+	GetByUser(ctx context.Context, userID int32) (map[api.RepoName]SubRepoPermissions, error)
+}
+
+// defaultSubRepoPermsCacheTTL is used when no TTL is configured in site
+// configuration.
+const defaultSubRepoPermsCacheTTL = 10 * time.Second
+
+// subRepoPermsCacheSize is the number of users whose rules are cached.
+const subRepoPermsCacheSize = 1000
+
+// SubRepoPermsClient is responsible for checking whether a user has access to
+// data within a repo. Sub-repository permissions enforcement is on top of
+// existing repository permissions, which means the user must already have
+// access to the repository itself. The intention is for this client to be
+// created once at startup and passed in to all places that need to check sub
+// repo permissions.
+//
+// Note that sub-repo permissions are currently opt-in via the
+// experimentalFeatures.subRepoPermissions.enabled setting.
+type SubRepoPermsClient struct {
+	permissionsGetter SubRepoPermissionsGetter
+	clock             func() time.Time
+
+	// cache holds the cachedRules of the most recently active users, keyed
+	// by user ID.
+	cache *lru.Cache
+}
+
+type cachedRules struct {
+	rules     map[api.RepoName]compiledRules
+	timestamp time.Time
+}
+
+type compiledRules struct {
+	includes []glob.Glob
+	excludes []glob.Glob
+
+	// dirIncludes match the directories that may contain paths matched by
+	// includes. See compileDirIncludes.
+	dirIncludes []glob.Glob
+}
+
+// NewSubRepoPermsClient instantiates an instance of authz.SubRepoPermsClient
+// which implements SubRepoPermissionChecker.
+func NewSubRepoPermsClient(permissionsGetter SubRepoPermissionsGetter) *SubRepoPermsClient {
+	// lru.New only fails for a non-positive size.
+	cache, _ := lru.New(subRepoPermsCacheSize)
+	return &SubRepoPermsClient{
+		permissionsGetter: permissionsGetter,
+		clock:             time.Now,
+		cache:             cache,
+	}
+}
+
+// Permissions return the current permissions granted to the given user on the
+// given content. If sub-repo permissions are disabled, it is a no-op that return
+// Read.
+func (s *SubRepoPermsClient) Permissions(ctx context.Context, userID int32, content RepoContent) (Perms, error) {
+	// Are sub-repo permissions enabled at the site level
+	if !s.Enabled() {
+		return Read, nil
+	}
+
+	if s.permissionsGetter == nil {
+		return None, errors.New("permissionsGetter is nil")
+	}
+
+	// An empty path is the repository root, which is visible to anyone that
+	// can see the repository.
+	if content.Path == "" || content.Path == "/" {
+		return Read, nil
+	}
+
+	repoRules, err := s.rulesForUser(ctx, userID)
+	if err != nil {
+		return None, err
+	}
+
+	rules, ok := repoRules[content.Repo]
+	if !ok {
+		// The repository has no sub-repo permissions for this user, in which
+		// case regular repository permissions apply.
+		return Read, nil
+	}
+
+	// Rules are relative to the repository root and always start with a slash.
+	path := content.Path
+	if !strings.HasPrefix(path, "/") {
+		path = "/" + path
+	}
+
+	// Exclusion rules take precedence.
+	for _, g := range rules.excludes {
+		if g.Match(path) {
+			return None, nil
+		}
+	}
+	for _, g := range rules.includes {
+		if g.Match(path) {
+			return Read, nil
+		}
+	}
+
+	// Directories that are ancestors of included paths need to be visible in
+	// order to navigate to those paths.
+	if strings.HasSuffix(path, "/") {
+		for _, g := range rules.dirIncludes {
+			if g.Match(path) {
+				return Read, nil
+			}
+		}
+	}
+
+	// Paths that are not explicitly included are not visible.
+	return None, nil
+}
+
+// rulesForUser returns the compiled sub-repository permission rules of the
+// given user, from cache if they are not yet stale.
+func (s *SubRepoPermsClient) rulesForUser(ctx context.Context, userID int32) (map[api.RepoName]compiledRules, error) {
+	if v, ok := s.cache.Get(userID); ok {
+		if cached := v.(cachedRules); s.clock().Sub(cached.timestamp) < cacheTTL() {
+			return cached.rules, nil
+		}
+	}
+
+	perms, err := s.permissionsGetter.GetByUser(ctx, userID)
+	if err != nil {
+		return nil, errors.Wrap(err, "getting permissions")
+	}
+
+	rules := make(map[api.RepoName]compiledRules, len(perms))
+	for repo, perm := range perms {
+		compiled, err := compileRules(perm)
+		if err != nil {
+			return nil, errors.Wrapf(err, "compiling rules for %q", repo)
+		}
+		rules[repo] = compiled
+	}
+
+	s.cache.Add(userID, cachedRules{rules: rules, timestamp: s.clock()})
+	return rules, nil
+}
+
+func compileRules(perms SubRepoPermissions) (compiledRules, error) {
+	var rules compiledRules
+	for _, pattern := range perms.PathIncludes {
+		g, err := glob.Compile(pattern, '/')
+		if err != nil {
+			return compiledRules{}, errors.Wrap(err, "building include matcher")
+		}
+		rules.includes = append(rules.includes, g)
+		rules.dirIncludes = append(rules.dirIncludes, compileDirIncludes(pattern)...)
+	}
+	for _, pattern := range perms.PathExcludes {
+		g, err := glob.Compile(pattern, '/')
+		if err != nil {
+			return compiledRules{}, errors.Wrap(err, "building exclude matcher")
+		}
+		rules.excludes = append(rules.excludes, g)
+	}
+	return rules, nil
+}
+
+// compileDirIncludes returns globs matching the directories that may contain
+// paths matched by the given include pattern, i.e. one glob per directory
+// prefix of the pattern. For example "/**/*.go" yields "/**/", which matches
+// every directory, and "/src/*/main.go" yields "/src/" and "/src/*/".
+//
+// Prefixes that are not valid patterns on their own, e.g. because they split
+// an alternation like "{a/b,c}", are skipped.
+func compileDirIncludes(pattern string) []glob.Glob {
+	var globs []glob.Glob
+	for i := 1; i < len(pattern); i++ {
+		if pattern[i] != '/' {
+			continue
+		}
+		g, err := glob.Compile(pattern[:i+1], '/')
+		if err != nil {
+			continue
+		}
+		globs = append(globs, g)
+	}
+	return globs
+}
+
+func cacheTTL() time.Duration {
+	c := conf.ExperimentalFeatures().SubRepoPermissions
+	if c == nil || c.UserCacheTTLSeconds <= 0 {
+		return defaultSubRepoPermsCacheTTL
+	}
+	return time.Duration(c.UserCacheTTLSeconds) * time.Second
+}
+
+// Enabled returns true if sub-repo permissions are enabled in site
+// configuration.
+func (s *SubRepoPermsClient) Enabled() bool {
+	c := conf.ExperimentalFeatures().SubRepoPermissions
+	return c != nil && c.Enabled
+}
+
+// EnabledForRepo returns true if sub-repo permissions are enabled and the
+// given user has rules for the given repository.
+func (s *SubRepoPermsClient) EnabledForRepo(ctx context.Context, userID int32, repo api.RepoName) (bool, error) {
+	if !s.Enabled() {
+		return false, nil
+	}
+	if s.permissionsGetter == nil {
+		return false, errors.New("permissionsGetter is nil")
+	}
+
+	repoRules, err := s.rulesForUser(ctx, userID)
+	if err != nil {
+		return false, err
+	}
+	_, ok := repoRules[repo]
+	return ok, nil
+}
+
+// ActorPermissions returns the level of access the given actor has for the
+// requested content.
+//
+// Sub-repo permissions rules are stored per user, so unauthenticated actors
+// have no rules and are granted Read wherever they can see the repository.
+// Internal actors are always granted Read.
+func ActorPermissions(ctx context.Context, s SubRepoPermissionChecker, a *actor.Actor, content RepoContent) (Perms, error) {
+	if s == nil || !s.Enabled() {
+		return Read, nil
+	}
+	if a.Internal {
+		return Read, nil
+	}
+
+	perms, err := s.Permissions(ctx, a.UID, content)
+	if err != nil {
+		return None, errors.Wrapf(err, "getting actor permissions for actor: %d", a.UID)
+	}
+	return perms, nil
+}
+
+// FilterActorPaths will filter the given list of paths for the given actor
+// returning only paths they have read access to.
+func FilterActorPaths(ctx context.Context, checker SubRepoPermissionChecker, a *actor.Actor, repo api.RepoName, paths []string) ([]string, error) {
+	if checker == nil || !checker.Enabled() {
+		return paths, nil
+	}
+
+	filtered := make([]string, 0, len(paths))
+	for _, p := range paths {
+		include, err := FilterActorPath(ctx, checker, a, repo, p)
+		if err != nil {
+			return nil, err
+		}
+		if include {
+			filtered = append(filtered, p)
+		}
+	}
+	return filtered, nil
+}
+
+// FilterActorPath will filter the given path for the given actor
+// returning true if the path is allowed to read.
+func FilterActorPath(ctx context.Context, checker SubRepoPermissionChecker, a *actor.Actor, repo api.RepoName, path string) (bool, error) {
+	perms, err := ActorPermissions(ctx, checker, a, RepoContent{
+		Repo: repo,
+		Path: path,
+	})
+	if err != nil {
+		return false, errors.Wrap(err, "checking sub-repo permissions")
+	}
+	return perms.Include(Read), nil
+}
@@ -0,0 +1,215 @@
+package authz
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type mockSubRepoPermissionsGetter map[api.RepoName]SubRepoPermissions
+
+func (m mockSubRepoPermissionsGetter) GetByUser(ctx context.Context, userID int32) (map[api.RepoName]SubRepoPermissions, error) {
+	return m, nil
+}
+
+func TestSubRepoPermsPermissions(t *testing.T) {
+	conf.Mock(&conf.Unified{
+		SiteConfiguration: schema.SiteConfiguration{
+			ExperimentalFeatures: &schema.ExperimentalFeatures{
+				SubRepoPermissions: &schema.SubRepoPermissions{Enabled: true},
+			},
+		},
+	})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	client := NewSubRepoPermsClient(mockSubRepoPermissionsGetter{
+		"sample": {
+			PathIncludes: []string{"/dev/**", "/README.md"},
+			PathExcludes: []string{"/dev/secret/**"},
+		},
+		"go": {
+			PathIncludes: []string{"/**/*.go"},
+		},
+		"docs": {
+			PathIncludes: []string{"/docs/*/index.md"},
+		},
+	})
+
+	for _, tc := range []struct {
+		name    string
+		content RepoContent
+		want    Perms
+	}{
+		{
+			name:    "repository root",
+			content: RepoContent{Repo: "sample", Path: ""},
+			want:    Read,
+		},
+		{
+			name:    "included file",
+			content: RepoContent{Repo: "sample", Path: "dev/main.go"},
+			want:    Read,
+		},
+		{
+			name:    "included file with leading slash",
+			content: RepoContent{Repo: "sample", Path: "/README.md"},
+			want:    Read,
+		},
+		{
+			name:    "excluded file",
+			content: RepoContent{Repo: "sample", Path: "dev/secret/key.pem"},
+			want:    None,
+		},
+		{
+			name:    "not included",
+			content: RepoContent{Repo: "sample", Path: "prod/main.go"},
+			want:    None,
+		},
+		{
+			name:    "directory containing included paths",
+			content: RepoContent{Repo: "sample", Path: "dev/"},
+			want:    Read,
+		},
+		{
+			name:    "excluded directory",
+			content: RepoContent{Repo: "sample", Path: "dev/secret/"},
+			want:    None,
+		},
+		{
+			name:    "directory without included paths",
+			content: RepoContent{Repo: "sample", Path: "prod/"},
+			want:    None,
+		},
+		{
+			name:    "directory containing paths matched by a wildcard",
+			content: RepoContent{Repo: "go", Path: "src/internal/"},
+			want:    Read,
+		},
+		{
+			name:    "directory matched by a wildcard in the middle of a pattern",
+			content: RepoContent{Repo: "docs", Path: "docs/api/"},
+			want:    Read,
+		},
+		{
+			name:    "directory below the directories of a pattern",
+			content: RepoContent{Repo: "docs", Path: "docs/api/v1/"},
+			want:    None,
+		},
+		{
+			name:    "repository without rules",
+			content: RepoContent{Repo: "other", Path: "prod/main.go"},
+			want:    Read,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			have, err := client.Permissions(context.Background(), 1, tc.content)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != tc.want {
+				t.Fatalf("have %v, want %v", have, tc.want)
+			}
+		})
+	}
+}
+
+func TestSubRepoPermsEnabledForRepo(t *testing.T) {
+	conf.Mock(&conf.Unified{
+		SiteConfiguration: schema.SiteConfiguration{
+			ExperimentalFeatures: &schema.ExperimentalFeatures{
+				SubRepoPermissions: &schema.SubRepoPermissions{Enabled: true},
+			},
+		},
+	})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	client := NewSubRepoPermsClient(mockSubRepoPermissionsGetter{
+		"sample": {PathIncludes: []string{"/**"}},
+	})
+
+	for repo, want := range map[api.RepoName]bool{
+		"sample": true,
+		"other":  false,
+	} {
+		have, err := client.EnabledForRepo(context.Background(), 1, repo)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have != want {
+			t.Errorf("%s: have %v, want %v", repo, have, want)
+		}
+	}
+}
+
+func TestSubRepoPermsCacheIsBounded(t *testing.T) {
+	client := NewSubRepoPermsClient(mockSubRepoPermissionsGetter{
+		"sample": {PathIncludes: []string{"/**"}},
+	})
+
+	for userID := int32(1); userID <= subRepoPermsCacheSize+10; userID++ {
+		if _, err := client.rulesForUser(context.Background(), userID); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if have := client.cache.Len(); have != subRepoPermsCacheSize {
+		t.Fatalf("have %d cached users, want %d", have, subRepoPermsCacheSize)
+	}
+}
+
+func TestSubRepoPermsDisabled(t *testing.T) {
+	conf.Mock(&conf.Unified{})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	client := NewSubRepoPermsClient(mockSubRepoPermissionsGetter{
+		"sample": {PathExcludes: []string{"/**"}},
+	})
+
+	have, err := client.Permissions(context.Background(), 1, RepoContent{Repo: "sample", Path: "main.go"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have != Read {
+		t.Fatalf("have %v, want %v", have, Read)
+	}
+}
+
+func TestFilterActorPaths(t *testing.T) {
+	conf.Mock(&conf.Unified{
+		SiteConfiguration: schema.SiteConfiguration{
+			ExperimentalFeatures: &schema.ExperimentalFeatures{
+				SubRepoPermissions: &schema.SubRepoPermissions{Enabled: true},
+			},
+		},
+	})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	client := NewSubRepoPermsClient(mockSubRepoPermissionsGetter{
+		"sample": {PathIncludes: []string{"/public/**"}},
+	})
+
+	paths := []string{"public/a.go", "private/b.go", "public/c/d.go"}
+
+	have, err := FilterActorPaths(context.Background(), client, actor.FromUser(1), "sample", paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := []string{"public/a.go", "public/c/d.go"}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("unexpected paths (-want +have):\n%s", diff)
+	}
+
+	// Internal actors are never restricted.
+	have, err = FilterActorPaths(context.Background(), client, &actor.Actor{Internal: true}, "sample", paths)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(paths, have); diff != "" {
+		t.Fatalf("unexpected paths (-want +have):\n%s", diff)
+	}
+}
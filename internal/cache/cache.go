@@ -0,0 +1,51 @@
+// Package cache provides caches shared by all replicas of a service.
+//
+// Values computed by one replica are stored in Redis, so that adding replicas
+// does not multiply identical database work. Unlike package rcache, failures
+// are returned to the caller, which is expected to fall back to computing the
+// value, and entries can be invalidated across replicas with a Versioned cache.
+package cache
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Cache is a key-value cache. Implementations must be safe for concurrent use.
+type Cache interface {
+	// Get returns the value of the key and whether it exists.
+	Get(ctx context.Context, key string) ([]byte, bool, error)
+
+	// Set stores the value of the key. The entry expires after the given TTL, or
+	// never if the TTL is zero.
+	Set(ctx context.Context, key string, value []byte, ttl time.Duration) error
+
+	// Delete removes the given keys. Keys that don't exist are ignored.
+	Delete(ctx context.Context, keys ...string) error
+
+	// Incr atomically increments the integer value of the key and returns the
+	// new value. A key that doesn't exist is treated as zero.
+	Incr(ctx context.Context, key string) (int64, error)
+}
+
+// GetJSON decodes the value of the key into v and returns whether it exists.
+func GetJSON(ctx context.Context, c Cache, key string, v interface{}) (bool, error) {
+	data, ok, err := c.Get(ctx, key)
+	if err != nil || !ok {
+		return false, err
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// SetJSON stores the JSON encoding of v as the value of the key.
+func SetJSON(ctx context.Context, c Cache, key string, v interface{}, ttl time.Duration) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	return c.Set(ctx, key, data, ttl)
+}
@@ -0,0 +1,98 @@
+package cache
+
+import (
+	"context"
+	"testing"
+	"time"
+)
+
+func TestMemory(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now()
+	c := NewMemory().(*memoryCache)
+	c.now = func() time.Time { return now }
+
+	if err := c.Set(ctx, "a", []byte("1"), time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	if err := c.Set(ctx, "b", []byte("2"), 0); err != nil {
+		t.Fatal(err)
+	}
+
+	if value, ok, _ := c.Get(ctx, "a"); !ok || string(value) != "1" {
+		t.Fatalf("got %q, %t, want %q, true", value, ok, "1")
+	}
+
+	now = now.Add(time.Minute)
+	if _, ok, _ := c.Get(ctx, "a"); ok {
+		t.Fatal("expected a to expire")
+	}
+	if _, ok, _ := c.Get(ctx, "b"); !ok {
+		t.Fatal("expected b not to expire")
+	}
+
+	if err := c.Delete(ctx, "b", "missing"); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, _ := c.Get(ctx, "b"); ok {
+		t.Fatal("expected b to be deleted")
+	}
+
+	for want := int64(1); want <= 2; want++ {
+		if have, err := c.Incr(ctx, "n"); err != nil || have != want {
+			t.Fatalf("got %d, %v, want %d", have, err, want)
+		}
+	}
+}
+
+func TestVersioned(t *testing.T) {
+	ctx := context.Background()
+	v := NewVersioned(NewMemory(), time.Minute)
+
+	type value struct{ N int }
+
+	var have value
+	generation, ok, err := v.GetJSON(ctx, "key", &have)
+	if err != nil || ok {
+		t.Fatalf("got %t, %v, want a miss", ok, err)
+	}
+	if err := v.SetJSON(ctx, generation, "key", value{N: 1}); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok, err := v.GetJSON(ctx, "key", &have); err != nil || !ok || have.N != 1 {
+		t.Fatalf("got %+v, %t, %v, want a hit", have, ok, err)
+	}
+
+	// A value computed before an invalidation is stored in the old generation
+	// and never read.
+	stale, _, _ := v.GetJSON(ctx, "other", &have)
+	if err := v.Invalidate(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.SetJSON(ctx, stale, "other", value{N: 2}); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, key := range []string{"key", "other"} {
+		if _, ok, err := v.GetJSON(ctx, key, &have); err != nil || ok {
+			t.Fatalf("%s: got %t, %v, want a miss", key, ok, err)
+		}
+	}
+}
+
+func TestVersionedNil(t *testing.T) {
+	ctx := context.Background()
+	var v *Versioned
+
+	if err := v.SetJSON(ctx, "0", "key", 1); err != nil {
+		t.Fatal(err)
+	}
+	if err := v.Invalidate(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	var have int
+	if _, ok, err := v.GetJSON(ctx, "key", &have); err != nil || ok {
+		t.Fatalf("got %t, %v, want a miss", ok, err)
+	}
+}
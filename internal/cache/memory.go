@@ -0,0 +1,89 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"sync"
+	"time"
+)
+
+type memoryEntry struct {
+	value   []byte
+	expires time.Time
+}
+
+type memoryCache struct {
+	mu      sync.Mutex
+	entries map[string]memoryEntry
+	now     func() time.Time
+}
+
+// NewMemory returns a cache that stores its entries in memory. It is not shared
+// by replicas, and is meant for tests and single-process tools.
+func NewMemory() Cache {
+	return &memoryCache{entries: map[string]memoryEntry{}, now: time.Now}
+}
+
+func (c *memoryCache) Get(_ context.Context, key string) ([]byte, bool, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e, ok := c.get(key)
+	if !ok {
+		return nil, false, nil
+	}
+	return append([]byte(nil), e.value...), true, nil
+}
+
+func (c *memoryCache) Set(_ context.Context, key string, value []byte, ttl time.Duration) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	e := memoryEntry{value: append([]byte(nil), value...)}
+	if ttl > 0 {
+		e.expires = c.now().Add(ttl)
+	}
+	c.entries[key] = e
+	return nil
+}
+
+func (c *memoryCache) Delete(_ context.Context, keys ...string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, key := range keys {
+		delete(c.entries, key)
+	}
+	return nil
+}
+
+func (c *memoryCache) Incr(_ context.Context, key string) (int64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var value int64
+	e, ok := c.get(key)
+	if ok {
+		var err error
+		if value, err = strconv.ParseInt(string(e.value), 10, 64); err != nil {
+			return 0, err
+		}
+	}
+	value++
+	e.value = []byte(strconv.FormatInt(value, 10))
+	c.entries[key] = e
+	return value, nil
+}
+
+// get returns the entry of the key unless it expired. The caller must hold the mutex.
+func (c *memoryCache) get(key string) (memoryEntry, bool) {
+	e, ok := c.entries[key]
+	if !ok {
+		return memoryEntry{}, false
+	}
+	if !e.expires.IsZero() && !c.now().Before(e.expires) {
+		delete(c.entries, key)
+		return memoryEntry{}, false
+	}
+	return e, true
+}
@@ -0,0 +1,83 @@
+package cache
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gomodule/redigo/redis"
+)
+
+type redisCache struct {
+	pool   *redis.Pool
+	prefix string
+}
+
+// NewRedis returns a cache that stores its entries in the given Redis pool,
+// usually redispool.Cache. Keys are prefixed with the given prefix, so that
+// caches of different data don't collide.
+func NewRedis(pool *redis.Pool, prefix string) Cache {
+	return &redisCache{pool: pool, prefix: "cache:" + prefix + ":"}
+}
+
+func (c *redisCache) Get(ctx context.Context, key string) ([]byte, bool, error) {
+	var value []byte
+	err := c.do(ctx, func(conn redis.Conn) (err error) {
+		value, err = redis.Bytes(conn.Do("GET", c.prefix+key))
+		return err
+	})
+	if err == redis.ErrNil {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, errors.Wrap(err, "redis GET")
+	}
+	return value, true, nil
+}
+
+func (c *redisCache) Set(ctx context.Context, key string, value []byte, ttl time.Duration) error {
+	err := c.do(ctx, func(conn redis.Conn) (err error) {
+		if ttl > 0 {
+			_, err = conn.Do("SET", c.prefix+key, value, "PX", ttl.Milliseconds())
+		} else {
+			_, err = conn.Do("SET", c.prefix+key, value)
+		}
+		return err
+	})
+	return errors.Wrap(err, "redis SET")
+}
+
+func (c *redisCache) Delete(ctx context.Context, keys ...string) error {
+	if len(keys) == 0 {
+		return nil
+	}
+
+	args := make([]interface{}, 0, len(keys))
+	for _, key := range keys {
+		args = append(args, c.prefix+key)
+	}
+	err := c.do(ctx, func(conn redis.Conn) error {
+		_, err := conn.Do("DEL", args...)
+		return err
+	})
+	return errors.Wrap(err, "redis DEL")
+}
+
+func (c *redisCache) Incr(ctx context.Context, key string) (int64, error) {
+	var value int64
+	err := c.do(ctx, func(conn redis.Conn) (err error) {
+		value, err = redis.Int64(conn.Do("INCR", c.prefix+key))
+		return err
+	})
+	return value, errors.Wrap(err, "redis INCR")
+}
+
+func (c *redisCache) do(ctx context.Context, f func(redis.Conn) error) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	conn := c.pool.Get()
+	defer conn.Close()
+	return f(conn)
+}
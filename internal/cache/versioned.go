@@ -0,0 +1,81 @@
+package cache
+
+import (
+	"context"
+	"strconv"
+	"time"
+)
+
+// Versioned is a cache whose entries can all be invalidated at once, e.g. when
+// data that many entries are derived from changes.
+//
+// The keys of the entries are scoped by a generation number stored in the
+// underlying cache. Invalidate increments the generation, so the entries of
+// previous generations are no longer found by any replica and expire by their
+// TTL.
+//
+// A nil *Versioned is a disabled cache: it misses on every read and ignores
+// writes and invalidations.
+type Versioned struct {
+	cache Cache
+	ttl   time.Duration
+}
+
+// Generation identifies a generation of the entries of a versioned cache.
+type Generation string
+
+const generationKey = "generation"
+
+// NewVersioned returns a versioned cache that stores its entries in the given
+// cache with the given TTL. The TTL must be non-zero, as entries of previous
+// generations are never deleted explicitly.
+func NewVersioned(c Cache, ttl time.Duration) *Versioned {
+	return &Versioned{cache: c, ttl: ttl}
+}
+
+// GetJSON decodes the value of the key in the current generation into value and
+// returns whether it exists. The returned generation must be passed to SetJSON
+// to store a value computed after a miss.
+func (v *Versioned) GetJSON(ctx context.Context, key string, value interface{}) (Generation, bool, error) {
+	if v == nil {
+		return "", false, nil
+	}
+
+	generation, err := v.generation(ctx)
+	if err != nil {
+		return "", false, err
+	}
+	ok, err := GetJSON(ctx, v.cache, string(generation)+":"+key, value)
+	return generation, ok, err
+}
+
+// SetJSON stores the JSON encoding of value as the value of the key in the given
+// generation. Because the generation is read before the value is computed, a value
+// computed from data that changed concurrently is stored in the generation that
+// the change invalidates.
+func (v *Versioned) SetJSON(ctx context.Context, generation Generation, key string, value interface{}) error {
+	if v == nil || generation == "" {
+		return nil
+	}
+	return SetJSON(ctx, v.cache, string(generation)+":"+key, value, v.ttl)
+}
+
+// Invalidate invalidates all entries.
+func (v *Versioned) Invalidate(ctx context.Context) error {
+	if v == nil {
+		return nil
+	}
+	_, err := v.cache.Incr(ctx, generationKey)
+	return err
+}
+
+func (v *Versioned) generation(ctx context.Context) (Generation, error) {
+	data, ok, err := v.cache.Get(ctx, generationKey)
+	if err != nil || !ok {
+		return "0", err
+	}
+	if _, err := strconv.ParseInt(string(data), 10, 64); err != nil {
+		return "", err
+	}
+	return Generation(data), nil
+}
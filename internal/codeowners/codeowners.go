@@ -0,0 +1,111 @@
+// Package codeowners parses CODEOWNERS files, which assign owners to the files
+// of a repository, in the format supported by GitHub and GitLab.
+package codeowners
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gobwas/glob"
+)
+
+// Paths are the paths at which code hosts look for the CODEOWNERS file of a
+// repository, in the order they look for it.
+var Paths = []string{
+	"CODEOWNERS",
+	".github/CODEOWNERS",
+	".gitlab/CODEOWNERS",
+	"docs/CODEOWNERS",
+}
+
+// Ruleset is a parsed CODEOWNERS file.
+type Ruleset struct {
+	rules []rule
+}
+
+type rule struct {
+	globs  []glob.Glob
+	owners []string
+}
+
+// Parse parses the contents of a CODEOWNERS file. Comments, section headers
+// and lines without owners are ignored.
+func Parse(data []byte) (*Ruleset, error) {
+	var rs Ruleset
+
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		line := strings.TrimSpace(s.Text())
+		if line == "" || strings.HasPrefix(line, "#") || strings.HasPrefix(line, "[") {
+			continue
+		}
+		if i := strings.Index(line, " #"); i >= 0 {
+			line = line[:i]
+		}
+
+		fields := strings.Fields(line)
+		if len(fields) < 2 {
+			continue
+		}
+
+		globs, err := compile(fields[0])
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid pattern %q", fields[0])
+		}
+		rs.rules = append(rs.rules, rule{globs: globs, owners: fields[1:]})
+	}
+	return &rs, s.Err()
+}
+
+// compile returns the globs which match the paths matched by a CODEOWNERS
+// pattern, which follows the rules of .gitignore patterns: patterns which
+// contain a slash other than a trailing one are relative to the root of the
+// repository, other patterns match at any depth, and patterns which match a
+// directory match all files in it.
+func compile(pattern string) ([]glob.Glob, error) {
+	dirOnly := strings.HasSuffix(pattern, "/")
+	p := strings.Trim(pattern, "/")
+	if p == "" || p == "*" || p == "**" {
+		p = "**"
+	}
+	anchored := strings.HasPrefix(pattern, "/") || strings.Contains(p, "/")
+
+	var patterns []string
+	if !dirOnly {
+		patterns = append(patterns, p)
+	}
+	patterns = append(patterns, p+"/**")
+	if !anchored {
+		if !dirOnly {
+			patterns = append(patterns, "**/"+p)
+		}
+		patterns = append(patterns, "**/"+p+"/**")
+	}
+
+	globs := make([]glob.Glob, 0, len(patterns))
+	for _, p := range patterns {
+		g, err := glob.Compile(p, '/')
+		if err != nil {
+			return nil, err
+		}
+		globs = append(globs, g)
+	}
+	return globs, nil
+}
+
+// Match returns the owners of the file at path, which is relative to the root
+// of the repository. As on code hosts, the last matching rule takes
+// precedence. It returns nil if no rule matches.
+func (rs *Ruleset) Match(path string) []string {
+	path = strings.TrimPrefix(path, "/")
+	for i := len(rs.rules) - 1; i >= 0; i-- {
+		for _, g := range rs.rules[i].globs {
+			if g.Match(path) {
+				return rs.rules[i].owners
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,59 @@
+package codeowners
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRulesetMatch(t *testing.T) {
+	rs, err := Parse([]byte(`
+# Default owners
+*                   @org/everyone
+
+[Docs]
+docs/               @org/docs # trailing comment
+*.go                @org/go @alice
+/cmd/frontend/      @org/frontend
+internal/**/*_test.go @org/testing
+vendor
+`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for path, want := range map[string][]string{
+		"README.md":                   {"@org/everyone"},
+		"docs/index.md":               {"@org/docs"},
+		"docs/admin/index.md":         {"@org/docs"},
+		"main.go":                     {"@org/go", "@alice"},
+		"internal/conf/conf.go":       {"@org/go", "@alice"},
+		"cmd/frontend/main.go":        {"@org/frontend"},
+		"cmd/frontend/app/app.go":     {"@org/frontend"},
+		"other/cmd/frontend/main.go":  {"@org/go", "@alice"},
+		"internal/conf/conf_test.go":  {"@org/testing"},
+		"internal/a/b/c/some_test.go": {"@org/testing"},
+		"vendor/lib/lib.js":           {"@org/everyone"},
+	} {
+		if diff := cmp.Diff(want, rs.Match(path)); diff != "" {
+			t.Errorf("unexpected owners of %q (-want +got):\n%s", path, diff)
+		}
+	}
+}
+
+func TestRulesetMatchNoRules(t *testing.T) {
+	rs, err := Parse([]byte("docs/ @org/docs\n"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if owners := rs.Match("main.go"); owners != nil {
+		t.Errorf("got owners %v, want none", owners)
+	}
+	// A pattern with a trailing slash only matches directories.
+	if owners := rs.Match("docs"); owners != nil {
+		t.Errorf("got owners %v, want none", owners)
+	}
+	if diff := cmp.Diff([]string{"@org/docs"}, rs.Match("src/docs/index.md")); diff != "" {
+		t.Errorf("unexpected owners (-want +got):\n%s", diff)
+	}
+}
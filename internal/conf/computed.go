@@ -6,6 +6,7 @@ import (
 	"os"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/conf/confdefaults"
@@ -292,6 +293,21 @@ func SearchSymbolsParallelism() int {
 	return val
 }
 
+// MaintenanceModeEnabledByEnv reports whether maintenance mode is enabled by the
+// SRC_MAINTENANCE_MODE environment variable. It cannot be disabled via site
+// configuration in that case.
+func MaintenanceModeEnabledByEnv() bool {
+	v, _ := strconv.ParseBool(os.Getenv("SRC_MAINTENANCE_MODE"))
+	return v
+}
+
+// MaintenanceModeEnabled reports whether background writers should pause. It is
+// enabled by the SRC_MAINTENANCE_MODE environment variable or the site config
+// "maintenanceMode" value.
+func MaintenanceModeEnabled() bool {
+	return MaintenanceModeEnabledByEnv() || Get().MaintenanceMode
+}
+
 func BitbucketServerPluginPerm() bool {
 	val := ExperimentalFeatures().BitbucketServerFastPerm
 	return val == "enabled"
@@ -329,6 +345,23 @@ func ExperimentalFeatures() schema.ExperimentalFeatures {
 	return *val
 }
 
+// AuthImpersonationEnabled returns true if site admins are allowed to
+// impersonate users.
+func AuthImpersonationEnabled() bool {
+	c := Get().AuthImpersonation
+	return c != nil && c.Enabled
+}
+
+// AuthImpersonationDuration returns the duration of impersonation sessions.
+// If not set, it returns the default value of 30 minutes.
+func AuthImpersonationDuration() time.Duration {
+	c := Get().AuthImpersonation
+	if c == nil || c.DurationMinutes <= 0 {
+		return 30 * time.Minute
+	}
+	return time.Duration(c.DurationMinutes) * time.Minute
+}
+
 // AuthMinPasswordLength returns the value of minimum password length requirement.
 // If not set, it returns the default value 12.
 func AuthMinPasswordLength() int {
@@ -413,3 +446,27 @@ func UserReposMaxPerSite() int {
 	}
 	return v
 }
+
+// UserReposMaxExternalServicesPerUser returns the maximum number of external
+// services a user can add. If not set, it returns 0, which means there is no
+// limit.
+func UserReposMaxExternalServicesPerUser() int {
+	return Get().UserReposMaxExternalServicesPerUser
+}
+
+// UserReposMaxCloneSizeBytesPerUser returns the maximum total size of the
+// clones of the repos a user can add. If not set, it returns 0, which means
+// there is no limit.
+func UserReposMaxCloneSizeBytesPerUser() int64 {
+	return int64(Get().UserReposMaxCloneSizeBytesPerUser)
+}
+
+// RepoRestoreWindow returns the duration for which deleted repositories can be
+// restored. If not set, it returns the default value of 72 hours.
+func RepoRestoreWindow() time.Duration {
+	val := Get().RepoRestoreWindow
+	if val <= 0 {
+		return 72 * time.Hour
+	}
+	return time.Duration(val) * time.Hour
+}
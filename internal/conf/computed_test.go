@@ -5,6 +5,7 @@ import (
 	"os"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/conf/confdefaults"
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
@@ -183,3 +184,28 @@ func boolPtr(b bool) *bool {
 func intPtr(i int) *int {
 	return &i
 }
+
+func TestRepoRestoreWindow(t *testing.T) {
+	tests := []struct {
+		name string
+		sc   *Unified
+		want time.Duration
+	}{{
+		name: "not set should return default",
+		sc:   &Unified{},
+		want: 72 * time.Hour,
+	}, {
+		name: "set should return hours",
+		sc:   &Unified{SiteConfiguration: schema.SiteConfiguration{RepoRestoreWindow: 24}},
+		want: 24 * time.Hour,
+	}}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			Mock(test.sc)
+			if got, want := RepoRestoreWindow(), test.want; got != want {
+				t.Fatalf("RepoRestoreWindow() = %v, want %v", got, want)
+			}
+		})
+	}
+}
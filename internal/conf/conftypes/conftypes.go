@@ -28,11 +28,16 @@ type ServiceConnections struct {
 
 // RawUnified is the unparsed variant of conf.Unified.
 type RawUnified struct {
-	Site               string
+	Site string
+
+	// SiteOverlays are the contents of the named site configuration overlays,
+	// in the order in which they are merged on top of Site.
+	SiteOverlays []string
+
 	ServiceConnections ServiceConnections
 }
 
 // Equal tells if the two configurations are equal or not.
 func (r RawUnified) Equal(other RawUnified) bool {
-	return r.Site == other.Site && reflect.DeepEqual(r.ServiceConnections, other.ServiceConnections)
+	return r.Site == other.Site && reflect.DeepEqual(r.SiteOverlays, other.SiteOverlays) && reflect.DeepEqual(r.ServiceConnections, other.ServiceConnections)
 }
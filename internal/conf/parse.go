@@ -3,6 +3,8 @@ package conf
 import (
 	"encoding/json"
 
+	"github.com/cockroachdb/errors"
+
 	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
 	"github.com/sourcegraph/sourcegraph/internal/jsonc"
 	"github.com/sourcegraph/sourcegraph/schema"
@@ -31,6 +33,11 @@ func parseConfigData(data string, cfg interface{}) error {
 }
 
 // ParseConfig parses the raw configuration.
+//
+// The site configuration overlays are merged on top of the site configuration
+// in order. Properties set in an overlay replace the previous value, except
+// for objects with a fixed set of properties (such as experimentalFeatures),
+// which are merged recursively.
 func ParseConfig(data conftypes.RawUnified) (*Unified, error) {
 	cfg := &Unified{
 		ServiceConnections: data.ServiceConnections,
@@ -38,6 +45,11 @@ func ParseConfig(data conftypes.RawUnified) (*Unified, error) {
 	if err := parseConfigData(data.Site, &cfg.SiteConfiguration); err != nil {
 		return nil, err
 	}
+	for i, overlay := range data.SiteOverlays {
+		if err := parseConfigData(overlay, &cfg.SiteConfiguration); err != nil {
+			return nil, errors.Wrapf(err, "site configuration overlay %d", i)
+		}
+	}
 	return cfg, nil
 }
 
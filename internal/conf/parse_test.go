@@ -0,0 +1,42 @@
+package conf
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf/conftypes"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestParseConfig_siteOverlays(t *testing.T) {
+	cfg, err := ParseConfig(conftypes.RawUnified{
+		Site: `{
+			// comment
+			"externalURL": "https://base.example.com",
+			"auth.providers": [{"type": "builtin"}],
+			"experimentalFeatures": {"perforce": "enabled"},
+		}`,
+		SiteOverlays: []string{
+			`{"externalURL": "https://staging.example.com", "experimentalFeatures": {"eventLogging": "disabled"}}`,
+			`{"externalURL": "https://staging2.example.com", "disableAutoGitUpdates": true}`,
+		},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := schema.SiteConfiguration{
+		ExternalURL:           "https://staging2.example.com",
+		AuthProviders:         []schema.AuthProviders{{Builtin: &schema.BuiltinAuthProvider{Type: "builtin"}}},
+		ExperimentalFeatures:  &schema.ExperimentalFeatures{Perforce: "enabled", EventLogging: "disabled"},
+		DisableAutoGitUpdates: true,
+	}
+	if diff := cmp.Diff(want, cfg.SiteConfiguration); diff != "" {
+		t.Errorf("unexpected site configuration (-want +got):\n%s", diff)
+	}
+
+	if _, err := ParseConfig(conftypes.RawUnified{Site: `{}`, SiteOverlays: []string{`{"externalURL": 1}`}}); err == nil {
+		t.Error("expected error for invalid overlay")
+	}
+}
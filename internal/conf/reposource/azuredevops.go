@@ -0,0 +1,48 @@
+package reposource
+
+import (
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type AzureDevOps struct {
+	*schema.AzureDevOpsConnection
+}
+
+var _ RepoSource = AzureDevOps{}
+
+func (c AzureDevOps) CloneURLToRepoName(cloneURL string) (repoName api.RepoName, err error) {
+	parsedCloneURL, baseURL, match, err := parseURLs(cloneURL, c.Url)
+	if err != nil {
+		return "", err
+	}
+	// SSH clone URLs use the ssh subdomain of the Azure DevOps host.
+	if !match && (baseURL == nil || hostname(parsedCloneURL) != "ssh."+hostname(baseURL)) {
+		return "", nil
+	}
+
+	// HTTPS clone URLs have the form /{org}/{project}/_git/{repo}, SSH clone
+	// URLs v3/{org}/{project}/{repo}.
+	path := strings.Trim(strings.TrimSuffix(parsedCloneURL.Path, ".git"), "/")
+	path = strings.TrimPrefix(path, "v3/")
+	parts := strings.Split(strings.Replace(path, "/_git/", "/", 1), "/")
+	if len(parts) != 3 {
+		return "", nil
+	}
+	return AzureDevOpsRepoName(c.RepositoryPathPattern, baseURL.Hostname(), parts[0], parts[1], parts[2]), nil
+}
+
+func AzureDevOpsRepoName(repositoryPathPattern, host, org, project, repo string) api.RepoName {
+	if repositoryPathPattern == "" {
+		repositoryPathPattern = "{host}/{org}/{project}/{repo}"
+	}
+
+	return api.RepoName(strings.NewReplacer(
+		"{host}", host,
+		"{org}", org,
+		"{project}", project,
+		"{repo}", repo,
+	).Replace(repositoryPathPattern))
+}
@@ -74,11 +74,18 @@ func (s *Server) Write(ctx context.Context, input conftypes.RawUnified) error {
 	// Wait for the change to the configuration file to be detected. Otherwise
 	// we would return to the caller earlier than server.Raw() would return the
 	// new configuration.
+	s.Reload()
+
+	return nil
+}
+
+// Reload reads the configuration from the source and waits until it has been
+// applied. It is used after the source was changed other than through Write,
+// such as when a site configuration overlay was changed.
+func (s *Server) Reload() {
 	doneReading := make(chan struct{}, 1)
 	s.fileWrite <- doneReading
 	<-doneReading
-
-	return nil
 }
 
 // Edits describes some JSON edits to apply to site configuration.
@@ -0,0 +1,108 @@
+package conf
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/jsonx"
+	"github.com/xeipuuv/gojsonschema"
+
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+)
+
+// SettingsProblem is a problem with settings found by ValidateSettingsWithSchema.
+type SettingsProblem struct {
+	// KeyPath is the dot-separated key path of the value with the problem, such as
+	// "search.scopes.0.name". It is empty for problems with the whole document and for
+	// syntax errors.
+	KeyPath     string
+	Description string
+
+	// Offset and Length are the range of characters (not bytes) of the input at which
+	// the problem is located.
+	Offset int
+	Length int
+}
+
+// contextDelimiter separates the keys of the gojsonschema error contexts that are
+// resolved in the parse tree of the input. Unlike ".", it does not occur in keys.
+const contextDelimiter = "\x00"
+
+// ValidateSettingsWithSchema is like ValidateSetting, except it validates the input against
+// the given settings schema (such as one extended with the settings contributed by extensions)
+// and locates each problem in the input.
+func ValidateSettingsWithSchema(input, settingsSchema string) ([]*SettingsProblem, error) {
+	options := jsonx.ParseOptions{Comments: true, TrailingCommas: true}
+
+	var problems []*SettingsProblem
+	if _, errs := jsonx.ParseWithDetailedErrors(input, options); len(errs) > 0 {
+		for _, err := range errs {
+			problems = append(problems, &SettingsProblem{
+				Description: "invalid JSON: " + err.Code.String(),
+				Offset:      err.Offset,
+				Length:      err.Length,
+			})
+		}
+		return problems, nil
+	}
+
+	s, err := gojsonschema.NewSchema(jsonLoader{gojsonschema.NewStringLoader(settingsSchema)})
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.Validate(gojsonschema.NewBytesLoader(jsonc.Normalize(input)))
+	if err != nil {
+		return nil, err
+	}
+
+	root, _ := jsonx.ParseTree(input, options)
+	for _, e := range res.Errors() {
+		var keys []string
+		if c := e.Context(); c != nil {
+			keys = strings.Split(c.String(contextDelimiter), contextDelimiter)[1:] // skip "(root)"
+		}
+		// Point at the unexpected property itself rather than at its parent object.
+		if property, ok := e.Details()["property"].(string); ok && e.Type() == "additional_property_not_allowed" {
+			keys = append(keys, property)
+		}
+
+		keyPath, node := locateSettingsKeys(root, keys)
+		problem := &SettingsProblem{KeyPath: keyPath, Description: e.Description()}
+		if node != nil {
+			problem.Offset, problem.Length = node.Offset, node.Length
+		}
+		problems = append(problems, problem)
+	}
+	return problems, nil
+}
+
+// locateSettingsKeys returns the dot-separated key path of keys and the deepest node of the
+// parse tree along it. For object properties, the node is the property, including its name.
+func locateSettingsKeys(root *jsonx.Node, keys []string) (string, *jsonx.Node) {
+	node := root
+	for _, key := range keys {
+		if node == nil {
+			break
+		}
+
+		segment := jsonx.Segment{IsProperty: true, Property: key}
+		if node.Type == jsonx.Array {
+			index, err := strconv.Atoi(key)
+			if err != nil {
+				break
+			}
+			segment = jsonx.Segment{Index: index}
+		}
+
+		value := jsonx.FindNodeAtLocation(node, jsonx.Path{segment})
+		if value == nil {
+			break
+		}
+		node = value
+	}
+
+	if node != nil && node.Parent != nil && node.Parent.Type == jsonx.Property {
+		node = node.Parent
+	}
+	return strings.Join(keys, "."), node
+}
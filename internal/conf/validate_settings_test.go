@@ -0,0 +1,55 @@
+package conf
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValidateSettingsWithSchema(t *testing.T) {
+	const settingsSchema = `{
+		"$id": "settings.schema.json#",
+		"type": "object",
+		"properties": {
+			"a": {"type": "boolean"},
+			"b": {"type": "array", "items": {"type": "string"}},
+			"c": {"type": "object", "additionalProperties": false, "properties": {"d": {"type": "number"}}}
+		}
+	}`
+
+	tests := map[string]struct {
+		input string
+		want  []*SettingsProblem
+	}{
+		"valid": {
+			input: `{"a": true, /* comment */ "b": ["x",],}`,
+		},
+		"syntax error": {
+			input: `{"a": }`,
+			want:  []*SettingsProblem{{Description: "invalid JSON: ValueExpected", Offset: 6, Length: 1}},
+		},
+		"wrong type": {
+			input: `{"a": 1}`,
+			want:  []*SettingsProblem{{KeyPath: "a", Description: "Invalid type. Expected: boolean, given: integer", Offset: 1, Length: 6}},
+		},
+		"array item": {
+			input: "{\n  \"b\": [\"x\", 2]\n}",
+			want:  []*SettingsProblem{{KeyPath: "b.1", Description: "Invalid type. Expected: string, given: integer", Offset: 15, Length: 1}},
+		},
+		"additional property": {
+			input: `{"c": {"d": 1, "é": 2}}`,
+			want:  []*SettingsProblem{{KeyPath: "c.é", Description: "Additional property é is not allowed", Offset: 15, Length: 6}},
+		},
+	}
+	for name, test := range tests {
+		t.Run(name, func(t *testing.T) {
+			problems, err := ValidateSettingsWithSchema(test.input, settingsSchema)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, problems); diff != "" {
+				t.Errorf("unexpected problems (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
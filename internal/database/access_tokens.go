@@ -88,7 +88,17 @@ func (s *AccessTokenStore) Create(ctx context.Context, subjectUserID int32, scop
 		return 0, "", errors.New("access tokens without scopes are not supported")
 	}
 
-	if err := s.Handle().DB().QueryRowContext(ctx,
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return 0, "", err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := setAuditActor(ctx, tx.Handle().DB()); err != nil {
+		return 0, "", err
+	}
+
+	if err := tx.Handle().DB().QueryRowContext(ctx,
 		// Include users table query (with "FOR UPDATE") to ensure that subject/creator users have
 		// not been deleted. If they were deleted, the query will return an error.
 		`
@@ -289,7 +299,7 @@ func (s *AccessTokenStore) delete(ctx context.Context, cond *sqlf.Query) error {
 	conds := []*sqlf.Query{cond, sqlf.Sprintf("deleted_at IS NULL")}
 	q := sqlf.Sprintf("UPDATE access_tokens SET deleted_at=now() WHERE (%s)", sqlf.Join(conds, ") AND ("))
 
-	res, err := s.ExecResult(ctx, q)
+	res, err := execAudited(ctx, s.Store, q)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,196 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// AnnouncementStore provides access to the announcements table, which holds the
+// instance-wide announcements displayed at the top of all clients.
+type AnnouncementStore struct {
+	*basestore.Store
+}
+
+// Announcements instantiates and returns a new AnnouncementStore.
+func Announcements(db dbutil.DB) *AnnouncementStore {
+	return &AnnouncementStore{Store: basestore.NewWithDB(db, sql.TxOptions{})}
+}
+
+// AnnouncementsWith instantiates and returns a new AnnouncementStore using the other store handle.
+func AnnouncementsWith(other basestore.ShareableStore) *AnnouncementStore {
+	return &AnnouncementStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *AnnouncementStore) With(other basestore.ShareableStore) *AnnouncementStore {
+	return &AnnouncementStore{Store: s.Store.With(other)}
+}
+
+func (s *AnnouncementStore) Transact(ctx context.Context) (*AnnouncementStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &AnnouncementStore{Store: txBase}, err
+}
+
+// ErrAnnouncementNotFound is the error that is returned when an announcement is not found.
+type ErrAnnouncementNotFound struct {
+	ID int64
+}
+
+func (err *ErrAnnouncementNotFound) Error() string {
+	return fmt.Sprintf("announcement not found: %d", err.ID)
+}
+
+func (ErrAnnouncementNotFound) NotFound() bool { return true }
+
+// Create creates an announcement and returns it with its ID and timestamps set.
+func (s *AnnouncementStore) Create(ctx context.Context, a *types.Announcement) (*types.Announcement, error) {
+	return scanAnnouncement(s.QueryRow(ctx, sqlf.Sprintf(createAnnouncementQueryFmtstr,
+		a.Message,
+		a.Severity,
+		a.Audience,
+		nullTimeColumn(a.StartsAt),
+		nullTimeColumn(a.EndsAt),
+		nullInt32Column(a.CreatorUserID),
+	)))
+}
+
+const createAnnouncementQueryFmtstr = `
+-- source: internal/database/announcements.go:Create
+INSERT INTO announcements (message, severity, audience, starts_at, ends_at, creator_user_id)
+VALUES (%s, %s, %s, %s, %s, %s)
+RETURNING ` + announcementColumns
+
+// Update updates the message, severity, audience and time window of an announcement.
+func (s *AnnouncementStore) Update(ctx context.Context, a *types.Announcement) (*types.Announcement, error) {
+	updated, err := scanAnnouncement(s.QueryRow(ctx, sqlf.Sprintf(updateAnnouncementQueryFmtstr,
+		a.Message,
+		a.Severity,
+		a.Audience,
+		nullTimeColumn(a.StartsAt),
+		nullTimeColumn(a.EndsAt),
+		a.ID,
+	)))
+	if err == sql.ErrNoRows {
+		return nil, &ErrAnnouncementNotFound{ID: a.ID}
+	}
+	return updated, err
+}
+
+const updateAnnouncementQueryFmtstr = `
+-- source: internal/database/announcements.go:Update
+UPDATE announcements
+SET message = %s, severity = %s, audience = %s, starts_at = %s, ends_at = %s, updated_at = now()
+WHERE id = %s
+RETURNING ` + announcementColumns
+
+// Delete deletes an announcement.
+func (s *AnnouncementStore) Delete(ctx context.Context, id int64) error {
+	res, err := s.ExecResult(ctx, sqlf.Sprintf(`DELETE FROM announcements WHERE id = %s`, id))
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return &ErrAnnouncementNotFound{ID: id}
+	}
+	return nil
+}
+
+// GetByID returns the announcement with the given ID.
+func (s *AnnouncementStore) GetByID(ctx context.Context, id int64) (*types.Announcement, error) {
+	a, err := scanAnnouncement(s.QueryRow(ctx, sqlf.Sprintf(getAnnouncementQueryFmtstr, id)))
+	if err == sql.ErrNoRows {
+		return nil, &ErrAnnouncementNotFound{ID: id}
+	}
+	return a, err
+}
+
+const getAnnouncementQueryFmtstr = `
+-- source: internal/database/announcements.go:GetByID
+SELECT ` + announcementColumns + ` FROM announcements WHERE id = %s
+`
+
+// AnnouncementsListOptions contains options for listing announcements.
+type AnnouncementsListOptions struct {
+	// OnlyActive restricts the announcements to those whose time window includes
+	// the current time.
+	OnlyActive bool
+
+	// Audiences restricts the announcements to those for one of the audiences, if
+	// non-empty.
+	Audiences []types.AnnouncementAudience
+}
+
+// List returns the announcements matching the options, most recently created first.
+//
+// 🚨 SECURITY: List does not check the audience of the announcements, so callers must
+// only pass the audiences the viewer belongs to, unless the viewer is a site admin.
+func (s *AnnouncementStore) List(ctx context.Context, opts AnnouncementsListOptions) ([]*types.Announcement, error) {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.OnlyActive {
+		conds = append(conds,
+			sqlf.Sprintf("(starts_at IS NULL OR starts_at <= now())"),
+			sqlf.Sprintf("(ends_at IS NULL OR ends_at > now())"),
+		)
+	}
+	if len(opts.Audiences) > 0 {
+		audiences := make([]string, 0, len(opts.Audiences))
+		for _, audience := range opts.Audiences {
+			audiences = append(audiences, string(audience))
+		}
+		conds = append(conds, sqlf.Sprintf("audience = ANY(%s)", pq.Array(audiences)))
+	}
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(listAnnouncementsQueryFmtstr, sqlf.Join(conds, "AND")))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var announcements []*types.Announcement
+	for rows.Next() {
+		a, err := scanAnnouncement(rows)
+		if err != nil {
+			return nil, err
+		}
+		announcements = append(announcements, a)
+	}
+	return announcements, rows.Err()
+}
+
+const listAnnouncementsQueryFmtstr = `
+-- source: internal/database/announcements.go:List
+SELECT ` + announcementColumns + `
+FROM announcements
+WHERE %s
+ORDER BY created_at DESC, id DESC
+`
+
+const announcementColumns = `id, message, severity, audience, starts_at, ends_at, creator_user_id, created_at, updated_at`
+
+func scanAnnouncement(sc dbutil.Scanner) (*types.Announcement, error) {
+	var a types.Announcement
+	err := sc.Scan(
+		&a.ID,
+		&a.Message,
+		&a.Severity,
+		&a.Audience,
+		&dbutil.NullTime{Time: &a.StartsAt},
+		&dbutil.NullTime{Time: &a.EndsAt},
+		&dbutil.NullInt32{N: &a.CreatorUserID},
+		&a.CreatedAt,
+		&a.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &a, nil
+}
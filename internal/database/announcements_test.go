@@ -0,0 +1,146 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestAnnouncements(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	db := dbtest.NewDB(t, "")
+	ctx := context.Background()
+	store := Announcements(db)
+
+	user, err := Users(db).Create(ctx, NewUser{Username: "admin"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	create := func(message string, audience types.AnnouncementAudience, startsAt, endsAt time.Time) *types.Announcement {
+		t.Helper()
+		a, err := store.Create(ctx, &types.Announcement{
+			Message:       message,
+			Severity:      types.AnnouncementSeverityInfo,
+			Audience:      audience,
+			StartsAt:      startsAt,
+			EndsAt:        endsAt,
+			CreatorUserID: user.ID,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return a
+	}
+	everyone := create("everyone", types.AnnouncementAudienceEveryone, time.Time{}, time.Time{})
+	admins := create("admins", types.AnnouncementAudienceSiteAdmins, now.Add(-time.Hour), now.Add(time.Hour))
+	scheduled := create("scheduled", types.AnnouncementAudienceEveryone, now.Add(time.Hour), time.Time{})
+	expired := create("expired", types.AnnouncementAudienceAuthenticatedUsers, time.Time{}, now.Add(-time.Hour))
+
+	messages := func(announcements []*types.Announcement) []string {
+		var ms []string
+		for _, a := range announcements {
+			ms = append(ms, a.Message)
+		}
+		return ms
+	}
+
+	t.Run("List", func(t *testing.T) {
+		for _, tc := range []struct {
+			name string
+			opts AnnouncementsListOptions
+			want []string
+		}{
+			{name: "all", want: []string{"expired", "scheduled", "admins", "everyone"}},
+			{name: "active", opts: AnnouncementsListOptions{OnlyActive: true}, want: []string{"admins", "everyone"}},
+			{
+				name: "audiences",
+				opts: AnnouncementsListOptions{Audiences: []types.AnnouncementAudience{types.AnnouncementAudienceEveryone, types.AnnouncementAudienceAuthenticatedUsers}},
+				want: []string{"expired", "scheduled", "everyone"},
+			},
+			{
+				name: "active audience",
+				opts: AnnouncementsListOptions{OnlyActive: true, Audiences: []types.AnnouncementAudience{types.AnnouncementAudienceEveryone}},
+				want: []string{"everyone"},
+			},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				announcements, err := store.List(ctx, tc.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(tc.want, messages(announcements)); diff != "" {
+					t.Fatalf("unexpected announcements (-want +got):\n%s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("GetByID", func(t *testing.T) {
+		a, err := store.GetByID(ctx, admins.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(admins, a); diff != "" {
+			t.Fatalf("unexpected announcement (-want +got):\n%s", diff)
+		}
+		if !a.IsActive(now) || scheduled.IsActive(now) || expired.IsActive(now) {
+			t.Fatal("unexpected active state")
+		}
+
+		if _, err := store.GetByID(ctx, 12345); !errcode.IsNotFound(err) {
+			t.Fatalf("want not found error, got %v", err)
+		}
+	})
+
+	t.Run("Update", func(t *testing.T) {
+		update := *everyone
+		update.Message = "updated"
+		update.Severity = types.AnnouncementSeverityWarning
+		update.EndsAt = now.Add(24 * time.Hour).Truncate(time.Microsecond)
+		updated, err := store.Update(ctx, &update)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if updated.Message != "updated" || updated.Severity != types.AnnouncementSeverityWarning || !updated.EndsAt.Equal(update.EndsAt) {
+			t.Fatalf("unexpected updated announcement: %+v", updated)
+		}
+		if !updated.UpdatedAt.After(everyone.UpdatedAt) {
+			t.Fatalf("updated_at was not bumped: %v", updated.UpdatedAt)
+		}
+
+		update.ID = 12345
+		if _, err := store.Update(ctx, &update); !errcode.IsNotFound(err) {
+			t.Fatalf("want not found error, got %v", err)
+		}
+
+		update.ID = everyone.ID
+		update.StartsAt = update.EndsAt
+		if _, err := store.Update(ctx, &update); err == nil {
+			t.Fatal("want error for an announcement ending before it starts")
+		}
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		if err := store.Delete(ctx, expired.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := store.GetByID(ctx, expired.ID); !errcode.IsNotFound(err) {
+			t.Fatalf("want not found error, got %v", err)
+		}
+		if err := store.Delete(ctx, expired.ID); !errcode.IsNotFound(err) {
+			t.Fatalf("want not found error, got %v", err)
+		}
+	})
+}
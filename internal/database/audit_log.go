@@ -0,0 +1,158 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// AuditLogEntry is a change to a row of an audit-critical table (users, access_tokens,
+// external_services and user_external_accounts), as recorded by the trig_audit_log_*
+// triggers. Secrets such as password hashes and external service configs are not part
+// of OldData and NewData, but are listed in ChangedColumns when they change.
+type AuditLogEntry struct {
+	ID             int64
+	TableName      string
+	RowID          string
+	Operation      string // INSERT, UPDATE or DELETE
+	ActorUserID    int32  // 0 if the change was not made on behalf of a user
+	ChangedColumns []string
+	OldData        json.RawMessage // nil for inserts
+	NewData        json.RawMessage // nil for deletes
+	CreatedAt      time.Time
+}
+
+// setAuditActor attributes the changes made to audit-critical tables in the transaction
+// of tx to the actor of the given context. The setting is scoped to the transaction, so
+// tx must be one; changes made outside of it are recorded without an actor.
+func setAuditActor(ctx context.Context, tx dbutil.DB) error {
+	if _, ok := tx.(dbutil.Tx); !ok {
+		return errors.New("audit actor must be set within a transaction")
+	}
+	_, err := tx.ExecContext(ctx, "SELECT set_config('sourcegraph.audit_actor_id', $1, true)", actorIDSetting(ctx))
+	return err
+}
+
+func actorIDSetting(ctx context.Context) string {
+	if a := actor.FromContext(ctx); a.IsAuthenticated() {
+		return a.UIDString()
+	}
+	return ""
+}
+
+// execAudited executes the given query in a transaction in which changes are attributed
+// to the actor of the given context. If the store is already in a transaction, the actor
+// is set on it through a savepoint. The query is never executed without setting the actor,
+// so it fails if the store's handle cannot start a transaction.
+func execAudited(ctx context.Context, s *basestore.Store, q *sqlf.Query) (_ sql.Result, err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "starting transaction for audited write")
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := setAuditActor(ctx, tx.Handle().DB()); err != nil {
+		return nil, err
+	}
+	return tx.ExecResult(ctx, q)
+}
+
+// AuditLogStore provides read access to the audit_log table. Entries are only ever
+// written by database triggers.
+type AuditLogStore struct {
+	*basestore.Store
+}
+
+// AuditLog instantiates and returns a new AuditLogStore with prepared statements.
+func AuditLog(db dbutil.DB) *AuditLogStore {
+	return &AuditLogStore{Store: basestore.NewWithDB(db, sql.TxOptions{})}
+}
+
+// AuditLogListOptions contains options for listing audit log entries.
+type AuditLogListOptions struct {
+	TableName   string // only entries of this table
+	RowID       string // only entries of this row; requires TableName
+	ActorUserID int32  // only entries made by this user
+	*LimitOffset
+}
+
+func (o AuditLogListOptions) sqlConditions() []*sqlf.Query {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if o.TableName != "" {
+		conds = append(conds, sqlf.Sprintf("table_name = %s", o.TableName))
+		if o.RowID != "" {
+			conds = append(conds, sqlf.Sprintf("row_id = %s", o.RowID))
+		}
+	}
+	if o.ActorUserID != 0 {
+		conds = append(conds, sqlf.Sprintf("actor_user_id = %s", o.ActorUserID))
+	}
+	return conds
+}
+
+// List returns the audit log entries matching the options, most recent first.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+func (s *AuditLogStore) List(ctx context.Context, opt AuditLogListOptions) ([]*AuditLogEntry, error) {
+	q := sqlf.Sprintf(listAuditLogQuery, sqlf.Join(opt.sqlConditions(), "AND"), opt.LimitOffset.SQL())
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var entries []*AuditLogEntry
+	for rows.Next() {
+		var (
+			e           AuditLogEntry
+			actorUserID sql.NullInt32
+			oldData     []byte
+			newData     []byte
+		)
+		if err := rows.Scan(
+			&e.ID,
+			&e.TableName,
+			&e.RowID,
+			&e.Operation,
+			&actorUserID,
+			pq.Array(&e.ChangedColumns),
+			&oldData,
+			&newData,
+			&e.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		e.ActorUserID = actorUserID.Int32
+		e.OldData = oldData
+		e.NewData = newData
+		entries = append(entries, &e)
+	}
+	return entries, rows.Err()
+}
+
+const listAuditLogQuery = `
+-- source: internal/database/audit_log.go:List
+SELECT id, table_name, row_id, operation, actor_user_id, changed_columns, old_data, new_data, created_at
+FROM audit_log
+WHERE %s
+ORDER BY id DESC
+%s
+`
+
+// Count counts the audit log entries matching the options.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+func (s *AuditLogStore) Count(ctx context.Context, opt AuditLogListOptions) (int, error) {
+	q := sqlf.Sprintf("SELECT COUNT(*) FROM audit_log WHERE %s", sqlf.Join(opt.sqlConditions(), "AND"))
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, q))
+	return count, err
+}
@@ -0,0 +1,109 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"strconv"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+func TestAuditLog(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtest.NewDB(t, "")
+	ctx := context.Background()
+
+	admin, err := Users(db).Create(ctx, NewUser{Username: "admin", Password: "p"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	user, err := Users(db).Create(ctx, NewUser{Username: "u", Password: "p"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	adminCtx := actor.WithActor(ctx, actor.FromUser(admin.ID))
+	if err := Users(db).SetIsSiteAdmin(adminCtx, user.ID, true); err != nil {
+		t.Fatal(err)
+	}
+
+	store := AuditLog(db)
+	userID := strconv.Itoa(int(user.ID))
+
+	entries, err := store.List(ctx, AuditLogListOptions{TableName: "users", RowID: userID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(entries) != 2 {
+		t.Fatalf("got %d entries, want 2", len(entries))
+	}
+
+	update, insert := entries[0], entries[1]
+	if insert.Operation != "INSERT" || insert.OldData != nil || insert.NewData == nil {
+		t.Fatalf("unexpected insert entry: %+v", insert)
+	}
+	if update.Operation != "UPDATE" {
+		t.Fatalf("got operation %q, want UPDATE", update.Operation)
+	}
+	if update.ActorUserID != admin.ID {
+		t.Fatalf("got actor %d, want %d", update.ActorUserID, admin.ID)
+	}
+	if diff := cmp.Diff([]string{"site_admin"}, update.ChangedColumns); diff != "" {
+		t.Fatalf("unexpected changed columns (-want +got):\n%s", diff)
+	}
+
+	var newData map[string]interface{}
+	if err := json.Unmarshal(update.NewData, &newData); err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := newData["passwd"]; ok {
+		t.Fatal("password hash was recorded in the audit log")
+	}
+	if newData["site_admin"] != true {
+		t.Fatalf("got site_admin %v, want true", newData["site_admin"])
+	}
+
+	count, err := store.Count(ctx, AuditLogListOptions{ActorUserID: admin.ID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("got count %d, want 1", count)
+	}
+}
+
+// nonTransactableDB is a database handle that cannot start transactions.
+type nonTransactableDB struct {
+	dbutil.DB
+	execs int
+}
+
+func (db *nonTransactableDB) ExecContext(ctx context.Context, query string, args ...interface{}) (sql.Result, error) {
+	db.execs++
+	return nil, nil
+}
+
+func TestExecAudited_NotTransactable(t *testing.T) {
+	db := &nonTransactableDB{}
+	store := basestore.NewWithDB(db, sql.TxOptions{})
+
+	// Audited writes must never happen without attributing them to an actor.
+	_, err := execAudited(actor.WithActor(context.Background(), actor.FromUser(1)), store, sqlf.Sprintf("UPDATE users SET site_admin=true WHERE id=1"))
+	if !errors.Is(err, basestore.ErrNotTransactable) {
+		t.Fatalf("got error %v, want %v", err, basestore.ErrNotTransactable)
+	}
+	if db.execs != 0 {
+		t.Fatalf("got %d queries, want none", db.execs)
+	}
+}
@@ -0,0 +1,50 @@
+package basestore
+
+import (
+	"sync"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// afterCommit holds the functions to call once the transactions begun by
+// TransactableHandle.Transact are committed. It is keyed by transaction rather
+// than by handle, so that functions registered on stores that wrap the
+// connection of a transaction in a new handle, e.g. with NewWithDB, are
+// deferred as well.
+var afterCommit = struct {
+	sync.Mutex
+	funcs map[dbutil.Tx][]func()
+}{funcs: map[dbutil.Tx][]func(){}}
+
+// beginAfterCommit starts collecting the functions to call once tx is
+// committed.
+func beginAfterCommit(tx dbutil.Tx) {
+	afterCommit.Lock()
+	afterCommit.funcs[tx] = nil
+	afterCommit.Unlock()
+}
+
+// addAfterCommit adds f to the functions to call once tx is committed. It
+// returns false if tx was not begun by TransactableHandle.Transact.
+func addAfterCommit(tx dbutil.Tx, f func()) bool {
+	afterCommit.Lock()
+	defer afterCommit.Unlock()
+
+	funcs, ok := afterCommit.funcs[tx]
+	if !ok {
+		return false
+	}
+	afterCommit.funcs[tx] = append(funcs, f)
+	return true
+}
+
+// endAfterCommit returns the functions to call once tx is committed and
+// stops collecting them.
+func endAfterCommit(tx dbutil.Tx) []func() {
+	afterCommit.Lock()
+	defer afterCommit.Unlock()
+
+	funcs := afterCommit.funcs[tx]
+	delete(afterCommit.funcs, tx)
+	return funcs
+}
@@ -62,6 +62,7 @@ func (h *TransactableHandle) Transact(ctx context.Context) (*TransactableHandle,
 	if err != nil {
 		return nil, err
 	}
+	beginAfterCommit(tx)
 
 	return &TransactableHandle{db: tx, txOptions: h.txOptions}, nil
 }
@@ -87,12 +88,32 @@ func (h *TransactableHandle) Done(err error) error {
 		return err
 	}
 
+	funcs := endAfterCommit(tx)
 	if err == nil {
-		return tx.Commit()
+		if err := tx.Commit(); err != nil {
+			return err
+		}
+		for _, f := range funcs {
+			f()
+		}
+		return nil
 	}
 	return combineErrors(err, tx.Rollback())
 }
 
+// AfterCommit calls f once the transaction of the handle is committed, or
+// right away if the handle is not in a transaction begun by Transact. Use it
+// for side effects of changes that must not be observable before the changes
+// are, like invalidating caches. f is not called if the transaction is rolled
+// back, but it is called if only a savepoint in which it was registered is
+// rolled back.
+func (h *TransactableHandle) AfterCommit(f func()) {
+	if tx, ok := h.db.(dbutil.Tx); ok && addAfterCommit(tx, f) {
+		return
+	}
+	f()
+}
+
 // combineErrors returns a multierror containing all fo the non-nil error parameter values.
 // This method should be used over multierror when it is not guaranteed that the original
 // error was non-nil (multierror.Append creates a non-nil error even if it is empty).
@@ -130,3 +130,9 @@ func (s *Store) Transact(ctx context.Context) (*Store, error) {
 func (s *Store) Done(err error) error {
 	return s.handle.Done(err)
 }
+
+// AfterCommit calls f once the transaction of the store is committed, or right
+// away if the store is not in a transaction. See TransactableHandle.AfterCommit.
+func (s *Store) AfterCommit(f func()) {
+	s.handle.AfterCommit(f)
+}
@@ -91,6 +91,65 @@ func TestSavepoints(t *testing.T) {
 	}
 }
 
+func TestAfterCommit(t *testing.T) {
+	db := dbtesting.GetDB(t)
+	setupStoreTest(t, db)
+	store := testStore(db)
+
+	var calls []string
+	after := func(name string) func() {
+		return func() { calls = append(calls, name) }
+	}
+
+	// Outside of a transaction, functions are called right away
+	store.AfterCommit(after("no tx"))
+	if diff := cmp.Diff([]string{"no tx"}, calls); diff != "" {
+		t.Fatalf("unexpected calls (-want +got):\n%s", diff)
+	}
+
+	// Functions registered in a transaction, its savepoints, or stores wrapping
+	// its connection are called once the outer transaction commits
+	calls = nil
+	tx, err := store.Transact(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error creating transaction: %s", err)
+	}
+	tx.AfterCommit(after("tx"))
+	savepoint, err := tx.Transact(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error creating savepoint: %s", err)
+	}
+	savepoint.AfterCommit(after("savepoint"))
+	if err := savepoint.Done(nil); err != nil {
+		t.Fatalf("unexpected error releasing savepoint: %s", err)
+	}
+	testStore(tx.Handle().DB()).AfterCommit(after("wrapped"))
+	if len(calls) != 0 {
+		t.Fatalf("unexpected calls before commit: %v", calls)
+	}
+	if err := tx.Done(nil); err != nil {
+		t.Fatalf("unexpected error committing transaction: %s", err)
+	}
+	if diff := cmp.Diff([]string{"tx", "savepoint", "wrapped"}, calls); diff != "" {
+		t.Fatalf("unexpected calls (-want +got):\n%s", diff)
+	}
+
+	// Functions are not called if the transaction is rolled back
+	calls = nil
+	tx, err = store.Transact(context.Background())
+	if err != nil {
+		t.Fatalf("unexpected error creating transaction: %s", err)
+	}
+	tx.AfterCommit(after("rolled back"))
+	rollbackErr := errors.New("rollback")
+	if err := tx.Done(rollbackErr); err != rollbackErr {
+		t.Fatalf("unexpected error rolling back transaction. want=%q have=%q", rollbackErr, err)
+	}
+	if len(calls) != 0 {
+		t.Fatalf("unexpected calls after rollback: %v", calls)
+	}
+}
+
 func recurSavepoints(t *testing.T, store *Store, index, rollbackAt int) {
 	if index == 0 {
 		return
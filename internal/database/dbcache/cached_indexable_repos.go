@@ -9,7 +9,9 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/inconshreveable/log15"
 
+	sharedcache "github.com/sourcegraph/sourcegraph/internal/cache"
 	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
@@ -34,16 +36,26 @@ func (c *cachedRepos) Repos() ([]types.RepoName, bool) {
 	return append([]types.RepoName{}, c.repos...), time.Since(c.fetched) > indexableReposMaxAge
 }
 
+// sharedIndexableRepos is the list of indexable repos that was most recently
+// fetched by any replica.
+type sharedIndexableRepos struct {
+	Repos   []types.RepoName
+	Fetched time.Time
+}
+
 func NewIndexableReposLister(store *database.RepoStore) *IndexableReposLister {
 	return &IndexableReposLister{
-		store: store,
+		store:  store,
+		shared: sharedcache.NewRedis(redispool.Cache, "indexable_repos"),
 	}
 }
 
 // IndexableReposLister holds the list of indexable repos which are cached for
-// indexableReposMaxAge.
+// indexableReposMaxAge. The list is shared with other replicas through Redis,
+// so that it is only fetched from the database by one of them at a time.
 type IndexableReposLister struct {
-	store *database.RepoStore
+	store  *database.RepoStore
+	shared sharedcache.Cache
 
 	cacheAllRepos    atomic.Value
 	cachePublicRepos atomic.Value
@@ -111,6 +123,21 @@ func (s *IndexableReposLister) refreshCache(ctx context.Context, onlyPublic bool
 		return repos, nil
 	}
 
+	key := "all"
+	if onlyPublic {
+		key = "public"
+	}
+
+	var shared sharedIndexableRepos
+	if ok, err := sharedcache.GetJSON(ctx, s.shared, key, &shared); err != nil {
+		log15.Warn("Reading shared indexable repos cache", "error", err)
+	} else if ok && time.Since(shared.Fetched) <= indexableReposMaxAge {
+		cached := &cachedRepos{repos: shared.Repos, fetched: shared.Fetched}
+		cache.Store(cached)
+		repos, _ := cached.Repos()
+		return repos, nil
+	}
+
 	opts := database.ListIndexableReposOptions{}
 	if !onlyPublic {
 		opts.IncludePrivate = true
@@ -120,10 +147,15 @@ func (s *IndexableReposLister) refreshCache(ctx context.Context, onlyPublic bool
 		return nil, errors.Wrap(err, "querying for indexable repos")
 	}
 
+	fetched := time.Now()
+	if err := sharedcache.SetJSON(ctx, s.shared, key, sharedIndexableRepos{Repos: repos, Fetched: fetched}, indexableReposMaxAge); err != nil {
+		log15.Warn("Writing shared indexable repos cache", "error", err)
+	}
+
 	cache.Store(&cachedRepos{
 		// Copy since repos will be mutated by the caller
 		repos:   append([]types.RepoName{}, repos...),
-		fetched: time.Now(),
+		fetched: fetched,
 	})
 
 	return repos, nil
@@ -8,6 +8,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	sharedcache "github.com/sourcegraph/sourcegraph/internal/cache"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
@@ -76,7 +77,7 @@ func TestListIndexableRepos(t *testing.T) {
 		}
 
 		t.Run("List ALL repos", func(t *testing.T) {
-			repos, err := NewIndexableReposLister(database.Repos(db)).List(ctx)
+			repos, err := newTestIndexableReposLister(db).List(ctx)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -104,7 +105,7 @@ func TestListIndexableRepos(t *testing.T) {
 		})
 
 		t.Run("List only public indexable repos", func(t *testing.T) {
-			repos, err := NewIndexableReposLister(database.Repos(db)).ListPublic(ctx)
+			repos, err := newTestIndexableReposLister(db).ListPublic(ctx)
 			if err != nil {
 				t.Fatal(err)
 			}
@@ -140,9 +141,17 @@ func BenchmarkIndexableRepos_List_Empty(b *testing.B) {
 	}
 	b.ResetTimer()
 	for n := 0; n < b.N; n++ {
-		_, err := NewIndexableReposLister(database.Repos(db)).List(ctx)
+		_, err := newTestIndexableReposLister(db).List(ctx)
 		if err != nil {
 			b.Fatal(err)
 		}
 	}
 }
+
+// newTestIndexableReposLister returns a lister that doesn't share its list
+// through Redis, so that tests don't see lists of other tests.
+func newTestIndexableReposLister(db dbutil.DB) *IndexableReposLister {
+	l := NewIndexableReposLister(database.Repos(db))
+	l.shared = sharedcache.NewMemory()
+	return l
+}
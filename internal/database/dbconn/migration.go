@@ -1,6 +1,7 @@
 package dbconn
 
 import (
+	"context"
 	"database/sql"
 	"fmt"
 	"io/fs"
@@ -54,12 +55,18 @@ var (
 	}
 )
 
+// MigrateDB runs all up migrations of database one at a time, with pre-flight checks of the
+// tables each migration locks. See runMigrations.
 func MigrateDB(db *sql.DB, database *Database) error {
+	opts, err := parseMigrationRunnerOptions()
+	if err != nil {
+		return err
+	}
 	m, err := NewMigrate(db, database)
 	if err != nil {
 		return err
 	}
-	if err := DoMigrate(m); err != nil {
+	if err := runMigrations(context.Background(), db, database, m, opts); err != nil {
 		return errors.Wrap(err, "Failed to migrate the DB. Please contact support@sourcegraph.com for further assistance")
 	}
 	return nil
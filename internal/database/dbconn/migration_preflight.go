@@ -0,0 +1,299 @@
+package dbconn
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+// migrationOperation is a statement of a migration that holds a lock on a table for longer than it
+// takes to change the catalog.
+type migrationOperation struct {
+	// Table is the name of the table the statement locks.
+	Table string
+	// Statement is a short description of the statement, such as "ALTER COLUMN TYPE".
+	Statement string
+	// Lock is the lock the statement holds on the table, such as "ACCESS EXCLUSIVE".
+	Lock string
+	// Rewrite indicates that the statement rewrites every row of the table.
+	Rewrite bool
+	// Scan indicates that the statement reads every row of the table while holding Lock.
+	Scan bool
+}
+
+func (op migrationOperation) String() string {
+	kind := "scans"
+	if op.Rewrite {
+		kind = "rewrites"
+	}
+	return fmt.Sprintf("%s %s table %s under a %s lock", op.Statement, kind, op.Table, op.Lock)
+}
+
+// migrationBackfill is a backfill declared in a migration with a directive of the form
+//
+//	-- backfill: table.column = expression
+//
+// The migration runner sets column to expression in batches for the rows where it is NULL before
+// the migration runs, so that the migration can set the column NOT NULL without rewriting the
+// table under a lock.
+type migrationBackfill struct {
+	Table      string
+	Column     string
+	Expression string
+}
+
+var (
+	backfillDirectivePattern = lazyregexp.New(`(?m)^\s*--\s*backfill:\s*(\S+)\.(\S+)\s*=\s*(.+?)\s*$`)
+	identifierPattern        = lazyregexp.New(`^[a-z_][a-z0-9_]*$`)
+)
+
+// parseBackfills returns the backfill directives of the migration script.
+func parseBackfills(script string) ([]migrationBackfill, error) {
+	var backfills []migrationBackfill
+	for _, match := range backfillDirectivePattern.FindAllStringSubmatch(script, -1) {
+		b := migrationBackfill{Table: match[1], Column: match[2], Expression: match[3]}
+		if !identifierPattern.MatchString(b.Table) || !identifierPattern.MatchString(b.Column) {
+			return nil, errors.Errorf("invalid backfill directive %q: the table and column must be lowercase unquoted identifiers", strings.TrimSpace(match[0]))
+		}
+		backfills = append(backfills, b)
+	}
+	return backfills, nil
+}
+
+var (
+	commentPattern     = lazyregexp.New(`(?m)--.*$`)
+	whitespacePattern  = lazyregexp.New(`\s+`)
+	alterTablePattern  = lazyregexp.New(`(?i)^ALTER TABLE (?:IF EXISTS )?(?:ONLY )?(\S+) (.*)$`)
+	createIndexPattern = lazyregexp.New(`(?i)^CREATE (?:UNIQUE )?INDEX (?:(CONCURRENTLY) )?.*? ON (?:ONLY )?([^\s(]+)`)
+	updatePattern      = lazyregexp.New(`(?i)^UPDATE (?:ONLY )?(\S+) .*$`)
+	clusterPattern     = lazyregexp.New(`(?i)^CLUSTER (?:VERBOSE )?(\S+)`)
+	dollarTagPattern   = lazyregexp.New(`^(?i:[a-z_][a-z0-9_]*)?$`)
+
+	alterTypePattern     = lazyregexp.New(`(?i)^ALTER (?:COLUMN )?\S+ (?:SET DATA )?TYPE `)
+	setNotNullPattern    = lazyregexp.New(`(?i)^ALTER (?:COLUMN )?(\S+) SET NOT NULL$`)
+	addColumnPattern     = lazyregexp.New(`(?i)^ADD (?:COLUMN )?(?:IF NOT EXISTS )?\S+ (.*)$`)
+	volatileDefault      = lazyregexp.New(`(?i)\b(?:small|big)?serial\b|\bDEFAULT\b.*\b(?:random|gen_random_uuid|uuid_generate_v\d|clock_timestamp|nextval|timeofday)\s*\(|\bGENERATED ALWAYS AS\b.*\bSTORED\b`)
+	addConstraintPattern = lazyregexp.New(`(?i)^ADD (?:CONSTRAINT \S+ )?(PRIMARY KEY|UNIQUE|FOREIGN KEY|CHECK)\b`)
+	setStoragePattern    = lazyregexp.New(`(?i)^SET (?:LOGGED|UNLOGGED|TABLESPACE)\b`)
+)
+
+// analyzeMigration returns the operations of the migration script that lock a table for longer than
+// it takes to change the catalog. SET NOT NULL on a column that is backfilled by the runner is not
+// reported, as the runner validates it without blocking writes before the migration runs.
+func analyzeMigration(script string, backfills []migrationBackfill) []migrationOperation {
+	backfilled := map[string]bool{}
+	for _, b := range backfills {
+		backfilled[b.Table+"."+b.Column] = true
+	}
+
+	var ops []migrationOperation
+	for _, stmt := range splitStatements(script) {
+		stmt = strings.TrimSpace(whitespacePattern.ReplaceAllString(commentPattern.ReplaceAllString(stmt, ""), " "))
+
+		if match := alterTablePattern.FindStringSubmatch(stmt); match != nil {
+			table := normalizeTableName(match[1])
+			for _, action := range splitTopLevel(match[2], ',') {
+				op := analyzeAlterTableAction(strings.TrimSpace(action), table, backfilled)
+				if op != nil {
+					ops = append(ops, *op)
+				}
+			}
+			continue
+		}
+
+		if match := createIndexPattern.FindStringSubmatch(stmt); match != nil {
+			if match[1] == "" {
+				ops = append(ops, migrationOperation{Table: normalizeTableName(match[2]), Statement: "CREATE INDEX", Lock: "SHARE", Scan: true})
+			}
+			continue
+		}
+
+		if match := updatePattern.FindStringSubmatch(stmt); match != nil {
+			if !strings.Contains(strings.ToUpper(stmt), " WHERE ") {
+				ops = append(ops, migrationOperation{Table: normalizeTableName(match[1]), Statement: "UPDATE", Lock: "ROW EXCLUSIVE", Rewrite: true})
+			}
+			continue
+		}
+
+		if match := clusterPattern.FindStringSubmatch(stmt); match != nil {
+			ops = append(ops, migrationOperation{Table: normalizeTableName(match[1]), Statement: "CLUSTER", Lock: "ACCESS EXCLUSIVE", Rewrite: true})
+		}
+	}
+	return ops
+}
+
+func analyzeAlterTableAction(action, table string, backfilled map[string]bool) *migrationOperation {
+	op := &migrationOperation{Table: table, Lock: "ACCESS EXCLUSIVE"}
+
+	switch {
+	case alterTypePattern.MatchString(action):
+		op.Statement, op.Rewrite = "ALTER COLUMN TYPE", true
+
+	case setNotNullPattern.MatchString(action):
+		column := normalizeTableName(setNotNullPattern.FindStringSubmatch(action)[1])
+		if backfilled[table+"."+column] {
+			return nil
+		}
+		op.Statement, op.Scan = "SET NOT NULL", true
+
+	// Constraints are matched first, as ADD CONSTRAINT looks like ADD COLUMN.
+	case addConstraintPattern.MatchString(action):
+		if strings.HasSuffix(strings.ToUpper(action), " NOT VALID") || strings.Contains(strings.ToUpper(action), " USING INDEX ") {
+			return nil
+		}
+		kind := strings.ToUpper(addConstraintPattern.FindStringSubmatch(action)[1])
+		op.Statement, op.Scan = "ADD "+kind, true
+		if kind == "FOREIGN KEY" {
+			op.Lock = "SHARE ROW EXCLUSIVE"
+		}
+
+	case addColumnPattern.MatchString(action):
+		if !volatileDefault.MatchString(addColumnPattern.FindStringSubmatch(action)[1]) {
+			return nil
+		}
+		op.Statement, op.Rewrite = "ADD COLUMN with a volatile default", true
+
+	case setStoragePattern.MatchString(action):
+		op.Statement, op.Rewrite = strings.ToUpper(strings.Join(strings.Fields(action)[:2], " ")), true
+
+	default:
+		return nil
+	}
+	return op
+}
+
+// normalizeTableName strips quotes and the public schema from a table or column name.
+func normalizeTableName(name string) string {
+	name = strings.ReplaceAll(name, `"`, "")
+	return strings.TrimPrefix(strings.ToLower(name), "public.")
+}
+
+// splitStatements splits a SQL script into statements at semicolons that are not quoted or part
+// of a dollar-quoted string, such as a function body.
+func splitStatements(script string) []string {
+	var (
+		statements []string
+		start      int
+		quote      string // the quote or dollar-quote tag we are in, if any
+	)
+	for i := 0; i < len(script); i++ {
+		switch c := script[i]; {
+		case quote != "":
+			if strings.HasPrefix(script[i:], quote) {
+				i += len(quote) - 1
+				quote = ""
+			}
+		case c == '\'' || c == '"':
+			quote = string(c)
+		case c == '$':
+			// Dollar-quoted strings start with $$ or $tag$.
+			if end := strings.IndexByte(script[i+1:], '$'); end >= 0 && dollarTagPattern.MatchString(script[i+1:i+1+end]) {
+				quote = script[i : i+end+2]
+				i += end + 1
+			}
+		case c == '-' && strings.HasPrefix(script[i:], "--"):
+			if end := strings.IndexByte(script[i:], '\n'); end >= 0 {
+				i += end
+			} else {
+				i = len(script)
+			}
+		case c == ';':
+			statements = append(statements, script[start:i])
+			start = i + 1
+		}
+	}
+	if strings.TrimSpace(script[start:]) != "" {
+		statements = append(statements, script[start:])
+	}
+	return statements
+}
+
+// splitTopLevel splits s at sep where it is not nested in parentheses.
+func splitTopLevel(s string, sep byte) []string {
+	var (
+		parts []string
+		depth int
+		start int
+	)
+	for i := 0; i < len(s); i++ {
+		switch s[i] {
+		case '(':
+			depth++
+		case ')':
+			depth--
+		case sep:
+			if depth == 0 {
+				parts = append(parts, s[start:i])
+				start = i + 1
+			}
+		}
+	}
+	return append(parts, s[start:])
+}
+
+// tableStats is the estimated size of a table.
+type tableStats struct {
+	Rows  int64
+	Bytes int64
+}
+
+// getTableStats returns the estimated sizes of the given tables that exist.
+func getTableStats(ctx context.Context, db *sql.DB, tables []string) (map[string]tableStats, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT c.relname, GREATEST(c.reltuples, 0)::bigint, pg_total_relation_size(c.oid)
+		FROM pg_class c
+		WHERE c.relname = ANY($1) AND c.relkind IN ('r', 'p', 'm') AND pg_table_is_visible(c.oid)
+	`, pq.Array(tables))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	stats := map[string]tableStats{}
+	for rows.Next() {
+		var (
+			name string
+			s    tableStats
+		)
+		if err := rows.Scan(&name, &s.Rows, &s.Bytes); err != nil {
+			return nil, err
+		}
+		stats[name] = s
+	}
+	return stats, rows.Err()
+}
+
+// dangerousOperations returns the operations that rewrite or scan a table with at least minRows
+// rows, in order of descending table size.
+func dangerousOperations(ops []migrationOperation, stats map[string]tableStats, minRows int64) []migrationOperation {
+	var dangerous []migrationOperation
+	for _, op := range ops {
+		if (op.Rewrite || op.Scan) && stats[op.Table].Rows >= minRows {
+			dangerous = append(dangerous, op)
+		}
+	}
+	sort.SliceStable(dangerous, func(i, j int) bool {
+		return stats[dangerous[i].Table].Rows > stats[dangerous[j].Table].Rows
+	})
+	return dangerous
+}
+
+// tablesOf returns the distinct tables of ops.
+func tablesOf(ops []migrationOperation) []string {
+	seen := map[string]bool{}
+	var tables []string
+	for _, op := range ops {
+		if !seen[op.Table] {
+			seen[op.Table] = true
+			tables = append(tables, op.Table)
+		}
+	}
+	return tables
+}
@@ -0,0 +1,208 @@
+package dbconn
+
+import (
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseBackfills(t *testing.T) {
+	backfills, err := parseBackfills(`
+BEGIN;
+-- backfill: repo.name_lower = lower(name)
+--backfill:lsif_uploads.num_references=0
+ALTER TABLE repo ALTER COLUMN name_lower SET NOT NULL;
+COMMIT;
+`)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []migrationBackfill{
+		{Table: "repo", Column: "name_lower", Expression: "lower(name)"},
+		{Table: "lsif_uploads", Column: "num_references", Expression: "0"},
+	}
+	if diff := cmp.Diff(want, backfills); diff != "" {
+		t.Errorf("unexpected backfills (-want +got):\n%s", diff)
+	}
+
+	if _, err := parseBackfills(`-- backfill: "Repo".name = 'x'`); err == nil {
+		t.Error("expected an error for a quoted identifier")
+	}
+}
+
+func TestAnalyzeMigration(t *testing.T) {
+	tests := []struct {
+		name      string
+		script    string
+		backfills []migrationBackfill
+		want      []migrationOperation
+	}{
+		{
+			name:   "add nullable column",
+			script: `ALTER TABLE repo ADD COLUMN blocked jsonb;`,
+		},
+		{
+			name:   "add column with constant default",
+			script: `ALTER TABLE repo ADD COLUMN IF NOT EXISTS stars integer DEFAULT 0 NOT NULL;`,
+		},
+		{
+			name:   "add column with volatile default",
+			script: `ALTER TABLE repo ADD COLUMN uuid uuid DEFAULT gen_random_uuid();`,
+			want: []migrationOperation{
+				{Table: "repo", Statement: "ADD COLUMN with a volatile default", Lock: "ACCESS EXCLUSIVE", Rewrite: true},
+			},
+		},
+		{
+			name:   "add serial column",
+			script: `ALTER TABLE public.repo ADD COLUMN seq bigserial;`,
+			want: []migrationOperation{
+				{Table: "repo", Statement: "ADD COLUMN with a volatile default", Lock: "ACCESS EXCLUSIVE", Rewrite: true},
+			},
+		},
+		{
+			name:   "alter column type",
+			script: `ALTER TABLE "repo" ALTER COLUMN stars TYPE bigint, ALTER COLUMN name SET NOT NULL;`,
+			want: []migrationOperation{
+				{Table: "repo", Statement: "ALTER COLUMN TYPE", Lock: "ACCESS EXCLUSIVE", Rewrite: true},
+				{Table: "repo", Statement: "SET NOT NULL", Lock: "ACCESS EXCLUSIVE", Scan: true},
+			},
+		},
+		{
+			name:      "backfilled set not null",
+			script:    `ALTER TABLE repo ALTER COLUMN name_lower SET NOT NULL;`,
+			backfills: []migrationBackfill{{Table: "repo", Column: "name_lower", Expression: "lower(name)"}},
+		},
+		{
+			name: "constraints",
+			script: `
+ALTER TABLE lsif_uploads ADD CONSTRAINT lsif_uploads_repo_fk FOREIGN KEY (repository_id) REFERENCES repo(id);
+ALTER TABLE lsif_uploads ADD CONSTRAINT lsif_uploads_check CHECK (num_parts > 0) NOT VALID;
+ALTER TABLE lsif_uploads ADD CONSTRAINT lsif_uploads_pkey PRIMARY KEY USING INDEX lsif_uploads_id;
+`,
+			want: []migrationOperation{
+				{Table: "lsif_uploads", Statement: "ADD FOREIGN KEY", Lock: "SHARE ROW EXCLUSIVE", Scan: true},
+			},
+		},
+		{
+			name: "indexes",
+			script: `
+CREATE INDEX IF NOT EXISTS repo_name_idx ON repo USING btree (name);
+CREATE UNIQUE INDEX CONCURRENTLY repo_uri_idx ON repo(uri);
+`,
+			want: []migrationOperation{
+				{Table: "repo", Statement: "CREATE INDEX", Lock: "SHARE", Scan: true},
+			},
+		},
+		{
+			name: "updates",
+			script: `
+UPDATE repo SET stars = 0;
+UPDATE repo SET stars = 0 WHERE stars IS NULL;
+`,
+			want: []migrationOperation{
+				{Table: "repo", Statement: "UPDATE", Lock: "ROW EXCLUSIVE", Rewrite: true},
+			},
+		},
+		{
+			name: "function body",
+			script: `
+CREATE FUNCTION repo_trigger() RETURNS trigger AS $$
+BEGIN
+    UPDATE repo SET stars = 0;
+    RETURN NEW;
+END;
+$$ LANGUAGE plpgsql;
+`,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			if diff := cmp.Diff(test.want, analyzeMigration(test.script, test.backfills)); diff != "" {
+				t.Errorf("unexpected operations (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestSplitStatements(t *testing.T) {
+	script := `
+INSERT INTO t VALUES ('a;b');
+CREATE FUNCTION f() RETURNS void AS $body$ SELECT 1; $body$ LANGUAGE sql;
+-- a comment; with a semicolon
+SELECT "a;b" FROM t`
+
+	statements := splitStatements(script)
+	if len(statements) != 3 {
+		t.Fatalf("got %d statements, want 3: %q", len(statements), statements)
+	}
+}
+
+func TestDangerousOperations(t *testing.T) {
+	ops := []migrationOperation{
+		{Table: "small", Statement: "UPDATE", Rewrite: true},
+		{Table: "large", Statement: "CREATE INDEX", Scan: true},
+		{Table: "larger", Statement: "ALTER COLUMN TYPE", Rewrite: true},
+	}
+	stats := map[string]tableStats{
+		"small":  {Rows: 10},
+		"large":  {Rows: 1000},
+		"larger": {Rows: 5000},
+	}
+
+	want := []migrationOperation{ops[2], ops[1]}
+	if diff := cmp.Diff(want, dangerousOperations(ops, stats, 1000)); diff != "" {
+		t.Errorf("unexpected operations (-want +got):\n%s", diff)
+	}
+}
+
+func TestPeakHours(t *testing.T) {
+	at := func(hour int) time.Time { return time.Date(2021, 3, 5, hour, 30, 0, 0, time.UTC) }
+
+	tests := []struct {
+		hours    string
+		peak     []int
+		offPeak  []int
+		wantsErr bool
+	}{
+		{hours: "", offPeak: []int{0, 12, 23}},
+		{hours: "8-18", peak: []int{8, 12, 17}, offPeak: []int{7, 18, 23}},
+		{hours: "22-6", peak: []int{22, 23, 0, 5}, offPeak: []int{6, 12, 21}},
+		{hours: "8-24", peak: []int{8, 23}, offPeak: []int{0, 7}},
+		{hours: "0-24", peak: []int{0, 12, 23}},
+		{hours: "24-6", peak: []int{0, 5}, offPeak: []int{6, 23}},
+		{hours: "8-8", wantsErr: true},
+		{hours: "24-0", wantsErr: true},
+		{hours: "8-25", wantsErr: true},
+		{hours: "8", wantsErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.hours, func(t *testing.T) {
+			start, end, err := parsePeakHours(test.hours)
+			if test.wantsErr {
+				if err == nil {
+					t.Fatal("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			opts := &migrationRunnerOptions{PeakStart: start, PeakEnd: end}
+			for _, hour := range test.peak {
+				if !opts.isPeak(at(hour)) {
+					t.Errorf("expected %d:30 to be peak", hour)
+				}
+			}
+			for _, hour := range test.offPeak {
+				if opts.isPeak(at(hour)) {
+					t.Errorf("expected %d:30 to be off-peak", hour)
+				}
+			}
+		})
+	}
+}
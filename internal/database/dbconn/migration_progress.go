@@ -0,0 +1,106 @@
+package dbconn
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// MigrationProgress is the progress of a schema migration run by MigrateDB. It is recorded in the
+// schema_migration_progress table of the migrated database, if the table exists, so that site
+// admins can follow migrations that take long.
+type MigrationProgress struct {
+	// Database is the name of the migrated database, such as "frontend".
+	Database string
+	// Version is the version of the migration.
+	Version int64
+	// Name is the name of the migration.
+	Name string
+	// State is one of the MigrationState constants.
+	State string
+	// Step describes what the migration runner is doing, such as "backfilling repo.name_lower".
+	Step string
+	// RowsDone and RowsTotal are the number of rows of the current step that are done and the total
+	// number of rows of the step, if the step processes rows.
+	RowsDone  int64
+	RowsTotal *int64
+	// Message explains why the migration is blocked or failed.
+	Message    string
+	StartedAt  time.Time
+	UpdatedAt  time.Time
+	FinishedAt *time.Time
+}
+
+const (
+	MigrationStateRunning   = "running"
+	MigrationStateBlocked   = "blocked"
+	MigrationStateFailed    = "failed"
+	MigrationStateCompleted = "completed"
+)
+
+// recordMigrationProgress stores p. Progress is best-effort: the table does not exist before the
+// migration that creates it has run, and errors are only logged.
+func recordMigrationProgress(ctx context.Context, db *sql.DB, p *MigrationProgress) {
+	p.UpdatedAt = time.Now()
+	if p.State == MigrationStateCompleted {
+		p.FinishedAt = &p.UpdatedAt
+	}
+
+	_, err := db.ExecContext(ctx, `
+		INSERT INTO schema_migration_progress (database, version, name, state, step, rows_done, rows_total, message, started_at, updated_at, finished_at)
+		VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11)
+		ON CONFLICT (database, version) DO UPDATE SET
+			name = EXCLUDED.name,
+			state = EXCLUDED.state,
+			step = EXCLUDED.step,
+			rows_done = EXCLUDED.rows_done,
+			rows_total = EXCLUDED.rows_total,
+			message = EXCLUDED.message,
+			started_at = EXCLUDED.started_at,
+			updated_at = EXCLUDED.updated_at,
+			finished_at = EXCLUDED.finished_at
+	`, p.Database, p.Version, p.Name, p.State, p.Step, p.RowsDone, p.RowsTotal, p.Message, p.StartedAt, p.UpdatedAt, p.FinishedAt)
+	if err != nil && !dbutil.IsPostgresError(err, "42P01") { // undefined_table
+		log15.Warn("Failed to record schema migration progress.", "database", p.Database, "version", p.Version, "error", err)
+	}
+}
+
+// ListMigrationProgress returns the recorded progress of schema migrations, most recently started
+// first.
+func ListMigrationProgress(ctx context.Context, db dbutil.DB) ([]*MigrationProgress, error) {
+	rows, err := db.QueryContext(ctx, `
+		SELECT database, version, name, state, step, rows_done, rows_total, message, started_at, updated_at, finished_at
+		FROM schema_migration_progress
+		ORDER BY started_at DESC, version DESC
+	`)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var progress []*MigrationProgress
+	for rows.Next() {
+		var p MigrationProgress
+		if err := rows.Scan(
+			&p.Database,
+			&p.Version,
+			&p.Name,
+			&p.State,
+			&p.Step,
+			&p.RowsDone,
+			&p.RowsTotal,
+			&p.Message,
+			&p.StartedAt,
+			&p.UpdatedAt,
+			&p.FinishedAt,
+		); err != nil {
+			return nil, err
+		}
+		progress = append(progress, &p)
+	}
+	return progress, rows.Err()
+}
@@ -0,0 +1,341 @@
+package dbconn
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"hash/fnv"
+	"io/fs"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/golang-migrate/migrate/v4"
+	"github.com/inconshreveable/log15"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/lazyregexp"
+)
+
+var (
+	migrationPeakHours         = env.Get("MIGRATION_PEAK_HOURS", "", "UTC hours, such as 8-18, during which schema migrations that rewrite or scan large tables under a lock are refused. Empty to never refuse them.")
+	migrationAllowDangerous    = env.Get("MIGRATION_ALLOW_DANGEROUS", "false", "Run schema migrations that rewrite or scan large tables under a lock during MIGRATION_PEAK_HOURS.")
+	migrationLargeTableRows    = env.Get("MIGRATION_LARGE_TABLE_ROWS", "1000000", "The estimated number of rows from which schema migrations consider a table large.")
+	migrationBackfillBatchSize = env.Get("MIGRATION_BACKFILL_BATCH_SIZE", "10000", "The number of rows updated at a time by the backfills of schema migrations.")
+)
+
+// migrationRunnerOptions configures the pre-flight checks and backfills of runMigrations.
+type migrationRunnerOptions struct {
+	// PeakStart and PeakEnd are the UTC hours [PeakStart, PeakEnd) during which dangerous
+	// migrations are refused. The range wraps around midnight if PeakEnd < PeakStart. There are
+	// no peak hours if they are equal.
+	PeakStart, PeakEnd int
+	// AllowDangerous runs dangerous migrations during peak hours.
+	AllowDangerous bool
+	// LargeTableRows is the estimated number of rows from which a migration that rewrites or scans
+	// a table under a lock is dangerous.
+	LargeTableRows int64
+	// BackfillBatchSize is the number of rows updated at a time by backfills.
+	BackfillBatchSize int
+
+	now func() time.Time
+}
+
+func parseMigrationRunnerOptions() (*migrationRunnerOptions, error) {
+	opts := &migrationRunnerOptions{now: time.Now}
+
+	var err error
+	if opts.PeakStart, opts.PeakEnd, err = parsePeakHours(migrationPeakHours); err != nil {
+		return nil, errors.Wrap(err, "MIGRATION_PEAK_HOURS")
+	}
+	if opts.AllowDangerous, err = strconv.ParseBool(migrationAllowDangerous); err != nil {
+		return nil, errors.Wrap(err, "MIGRATION_ALLOW_DANGEROUS")
+	}
+	if opts.LargeTableRows, err = strconv.ParseInt(migrationLargeTableRows, 10, 64); err != nil {
+		return nil, errors.Wrap(err, "MIGRATION_LARGE_TABLE_ROWS")
+	}
+	if opts.BackfillBatchSize, err = strconv.Atoi(migrationBackfillBatchSize); err != nil || opts.BackfillBatchSize <= 0 {
+		return nil, errors.Errorf("MIGRATION_BACKFILL_BATCH_SIZE: invalid batch size %q", migrationBackfillBatchSize)
+	}
+	return opts, nil
+}
+
+var peakHoursPattern = lazyregexp.New(`^(\d{1,2})-(\d{1,2})$`)
+
+// parsePeakHours parses a range of UTC hours such as "8-18". The empty string is no peak hours.
+// The end of the range is kept as 24 rather than wrapped to 0, so that "0-24" is the whole day.
+func parsePeakHours(s string) (start, end int, err error) {
+	if s == "" {
+		return 0, 0, nil
+	}
+
+	match := peakHoursPattern.FindStringSubmatch(strings.TrimSpace(s))
+	if match == nil {
+		return 0, 0, errors.Errorf("invalid range of hours %q, want a range such as 8-18", s)
+	}
+	start, _ = strconv.Atoi(match[1])
+	end, _ = strconv.Atoi(match[2])
+	if start > 24 || end > 24 {
+		return 0, 0, errors.Errorf("invalid range of hours %q, hours must be between 0 and 24", s)
+	}
+	if start == end || start%24 == end {
+		return 0, 0, errors.Errorf("invalid range of hours %q, the range must not be empty", s)
+	}
+	return start % 24, end, nil
+}
+
+func (o *migrationRunnerOptions) isPeak(t time.Time) bool {
+	h := t.UTC().Hour()
+	switch {
+	case o.PeakStart < o.PeakEnd:
+		return o.PeakStart <= h && h < o.PeakEnd
+	case o.PeakStart > o.PeakEnd:
+		return o.PeakStart <= h || h < o.PeakEnd
+	}
+	return false
+}
+
+// migrationFile is an up migration of a database.
+type migrationFile struct {
+	Version int64
+	Name    string
+	Path    string
+}
+
+var upMigrationPattern = lazyregexp.New(`^(\d+)_(.+)\.up\.sql$`)
+
+// listUpMigrations returns the up migrations in fsys ordered by version.
+func listUpMigrations(fsys fs.FS) ([]migrationFile, error) {
+	paths, err := fs.Glob(fsys, "*.up.sql")
+	if err != nil {
+		return nil, err
+	}
+
+	var migrations []migrationFile
+	for _, path := range paths {
+		match := upMigrationPattern.FindStringSubmatch(path)
+		if match == nil {
+			continue
+		}
+		version, err := strconv.ParseInt(match[1], 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		migrations = append(migrations, migrationFile{Version: version, Name: match[2], Path: path})
+	}
+	sort.Slice(migrations, func(i, j int) bool { return migrations[i].Version < migrations[j].Version })
+	return migrations, nil
+}
+
+// runMigrations applies the pending up migrations of database one at a time. Before each migration,
+// it estimates the size of the tables the migration rewrites or scans under a lock, and refuses to
+// run the migration during peak hours if any of them is large. It runs the backfills the migration
+// declares, and records the progress of each step in the schema_migration_progress table.
+//
+// Only one process runs migrations of a database at a time, so that the pre-flight checks apply to
+// the migration that is actually run next.
+func runMigrations(ctx context.Context, db *sql.DB, database *Database, m *migrate.Migrate, opts *migrationRunnerOptions) error {
+	migrations, err := listUpMigrations(database.FS)
+	if err != nil {
+		return errors.Wrap(err, "listing migrations")
+	}
+
+	conn, err := db.Conn(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	lockID := migrationLockID(database)
+	lockCtx, cancel := context.WithTimeout(ctx, m.LockTimeout)
+	defer cancel()
+	if _, err := conn.ExecContext(lockCtx, `SELECT pg_advisory_lock($1)`, lockID); err != nil {
+		return errors.Wrap(err, "acquiring migration lock")
+	}
+	defer func() {
+		if _, err := conn.ExecContext(context.Background(), `SELECT pg_advisory_unlock($1)`, lockID); err != nil {
+			log15.Error("Failed to release migration lock.", "database", database.Name, "error", err)
+		}
+	}()
+
+	for {
+		version, dirty, err := m.Version()
+		if err != nil && err != migrate.ErrNilVersion {
+			return err
+		}
+		if dirty {
+			return migrate.ErrDirty{Version: int(version)}
+		}
+
+		i := sort.Search(len(migrations), func(i int) bool { return migrations[i].Version > int64(version) })
+		if i == len(migrations) {
+			if len(migrations) > 0 && int64(version) > migrations[len(migrations)-1].Version {
+				log15.Warn("WARNING: Detected an old version of Sourcegraph. The database has migrated to a newer version. If you have applied a rollback, this is expected and you can ignore this warning. If not, please contact support@sourcegraph.com for further assistance.", "db_version", version)
+			}
+			return nil
+		}
+
+		if err := runMigration(ctx, db, database, m, migrations[i], opts); err != nil {
+			return err
+		}
+	}
+}
+
+// migrationLockID returns the ID of the advisory lock held while migrating database.
+func migrationLockID(database *Database) int64 {
+	h := fnv.New32a()
+	_, _ = h.Write([]byte("sourcegraph-migration-runner:" + database.MigrationsTable))
+	return int64(h.Sum32())
+}
+
+// runMigration runs the pre-flight checks and backfills of migration and applies it.
+func runMigration(ctx context.Context, db *sql.DB, database *Database, m *migrate.Migrate, migration migrationFile, opts *migrationRunnerOptions) (err error) {
+	p := &MigrationProgress{
+		Database:  database.Name,
+		Version:   migration.Version,
+		Name:      migration.Name,
+		State:     MigrationStateRunning,
+		Step:      "pre-flight checks",
+		StartedAt: opts.now(),
+	}
+	recordMigrationProgress(ctx, db, p)
+	defer func() {
+		if err != nil {
+			if p.State != MigrationStateBlocked {
+				p.State = MigrationStateFailed
+			}
+			p.Message = err.Error()
+			recordMigrationProgress(ctx, db, p)
+		}
+	}()
+
+	script, err := fs.ReadFile(database.FS, migration.Path)
+	if err != nil {
+		return err
+	}
+	backfills, err := parseBackfills(string(script))
+	if err != nil {
+		return errors.Wrapf(err, "migration %d", migration.Version)
+	}
+
+	if ops := analyzeMigration(string(script), backfills); len(ops) > 0 {
+		stats, err := getTableStats(ctx, db, tablesOf(ops))
+		if err != nil {
+			return errors.Wrap(err, "estimating table sizes")
+		}
+
+		if dangerous := dangerousOperations(ops, stats, opts.LargeTableRows); len(dangerous) > 0 {
+			descriptions := make([]string, 0, len(dangerous))
+			for _, op := range dangerous {
+				descriptions = append(descriptions, fmt.Sprintf("%s (about %d rows)", op, stats[op.Table].Rows))
+			}
+
+			if opts.isPeak(opts.now()) && !opts.AllowDangerous {
+				p.State = MigrationStateBlocked
+				return errors.Errorf(
+					"refusing to run migration %d_%s of the %s database during peak hours (%d-%d UTC): %s. Run it outside of peak hours, or set MIGRATION_ALLOW_DANGEROUS=true to run it now",
+					migration.Version, migration.Name, database.Name, opts.PeakStart, opts.PeakEnd, strings.Join(descriptions, "; "),
+				)
+			}
+			log15.Warn("Running a schema migration that locks large tables.", "database", database.Name, "version", migration.Version, "operations", strings.Join(descriptions, "; "))
+		}
+	}
+
+	for _, b := range backfills {
+		defer dropBackfillConstraint(ctx, db, b)
+		if err := runBackfill(ctx, db, b, opts.BackfillBatchSize, p); err != nil {
+			return errors.Wrapf(err, "backfilling %s.%s", b.Table, b.Column)
+		}
+	}
+
+	p.Step, p.RowsDone, p.RowsTotal = "applying migration", 0, nil
+	recordMigrationProgress(ctx, db, p)
+	if err := m.Steps(1); err != nil {
+		return err
+	}
+
+	p.State, p.Step = MigrationStateCompleted, "done"
+	recordMigrationProgress(ctx, db, p)
+	return nil
+}
+
+// runBackfill sets the column of b to its expression in batches for the rows where it is NULL, and
+// then validates a NOT NULL check constraint on the column without blocking writes. With that
+// constraint in place, Postgres does not scan the table when the migration sets the column NOT
+// NULL.
+func runBackfill(ctx context.Context, db *sql.DB, b migrationBackfill, batchSize int, p *MigrationProgress) error {
+	table, column := pq.QuoteIdentifier(b.Table), pq.QuoteIdentifier(b.Column)
+
+	var total int64
+	if err := db.QueryRowContext(ctx, fmt.Sprintf(`SELECT count(*) FROM %s WHERE %s IS NULL`, table, column)).Scan(&total); err != nil {
+		return err
+	}
+	p.Step, p.RowsDone, p.RowsTotal = fmt.Sprintf("backfilling %s.%s", b.Table, b.Column), 0, &total
+	recordMigrationProgress(ctx, db, p)
+
+	if err := backfillBatches(ctx, db, b, batchSize, p); err != nil {
+		return err
+	}
+
+	// New rows are checked as soon as the constraint is added. Backfill the rows written since the
+	// first pass before validating the existing rows.
+	constraint := pq.QuoteIdentifier(backfillConstraintName(b))
+	if _, err := db.ExecContext(ctx, fmt.Sprintf(`ALTER TABLE %[1]s DROP CONSTRAINT IF EXISTS %[2]s, ADD CONSTRAINT %[2]s CHECK (%[3]s IS NOT NULL) NOT VALID`, table, constraint, column)); err != nil {
+		return err
+	}
+	if err := backfillBatches(ctx, db, b, batchSize, p); err != nil {
+		return err
+	}
+	p.Step = fmt.Sprintf("validating %s.%s", b.Table, b.Column)
+	recordMigrationProgress(ctx, db, p)
+	_, err := db.ExecContext(ctx, fmt.Sprintf(`ALTER TABLE %s VALIDATE CONSTRAINT %s`, table, constraint))
+	return err
+}
+
+func backfillBatches(ctx context.Context, db *sql.DB, b migrationBackfill, batchSize int, p *MigrationProgress) error {
+	table, column := pq.QuoteIdentifier(b.Table), pq.QuoteIdentifier(b.Column)
+	q := fmt.Sprintf(`
+		WITH
+		batch AS (SELECT ctid FROM %[1]s WHERE %[2]s IS NULL LIMIT %[4]d),
+		updated AS (UPDATE %[1]s SET %[2]s = %[3]s FROM batch WHERE %[1]s.ctid = batch.ctid RETURNING %[1]s.%[2]s IS NULL AS is_null)
+		SELECT count(*), count(*) FILTER (WHERE is_null) FROM updated
+	`, table, column, b.Expression, batchSize)
+
+	for {
+		var updated, stillNull int64
+		if err := db.QueryRowContext(ctx, q).Scan(&updated, &stillNull); err != nil {
+			return err
+		}
+		if stillNull > 0 {
+			return errors.Errorf("the expression %q is NULL for %d rows", b.Expression, stillNull)
+		}
+		if updated == 0 {
+			return nil
+		}
+
+		p.RowsDone += updated
+		recordMigrationProgress(ctx, db, p)
+	}
+}
+
+// backfillConstraintName returns the name of the check constraint validated by the backfill b,
+// truncated to the maximum length of identifiers.
+func backfillConstraintName(b migrationBackfill) string {
+	name := fmt.Sprintf("%s_%s_backfill_not_null", b.Table, b.Column)
+	if len(name) > 63 {
+		name = name[:63]
+	}
+	return name
+}
+
+// dropBackfillConstraint drops the constraint added by runBackfill, which is redundant once the
+// column is NOT NULL.
+func dropBackfillConstraint(ctx context.Context, db *sql.DB, b migrationBackfill) {
+	q := fmt.Sprintf(`ALTER TABLE %s DROP CONSTRAINT IF EXISTS %s`, pq.QuoteIdentifier(b.Table), pq.QuoteIdentifier(backfillConstraintName(b)))
+	if _, err := db.ExecContext(ctx, q); err != nil {
+		log15.Error("Failed to drop backfill constraint.", "table", b.Table, "column", b.Column, "error", err)
+	}
+}
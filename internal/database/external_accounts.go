@@ -34,6 +34,19 @@ func (err userExternalAccountNotFoundError) NotFound() bool {
 	return true
 }
 
+// ExternalAccountAssociatedWithOtherUserError is the error that is returned when an external
+// account cannot be associated with a user because it is already associated with another user.
+type ExternalAccountAssociatedWithOtherUserError struct {
+	// UserID is the ID of the user the external account is associated with.
+	UserID int32
+	// RequestedUserID is the ID of the user the external account was to be associated with.
+	RequestedUserID int32
+}
+
+func (err *ExternalAccountAssociatedWithOtherUserError) Error() string {
+	return fmt.Sprintf("unable to change association of external account from user %d to user %d (delete the external account and then try again)", err.UserID, err.RequestedUserID)
+}
+
 // UserExternalAccountsStore provides access to the `user_external_accounts` table.
 type UserExternalAccountsStore struct {
 	*basestore.Store
@@ -154,7 +167,7 @@ RETURNING user_id
 // account already exists and is associated with:
 //
 // - the same user: it updates the data and returns a nil error; or
-// - a different user: it performs no update and returns a non-nil error
+// - a different user: it performs no update and returns an *ExternalAccountAssociatedWithOtherUserError
 func (s *UserExternalAccountsStore) AssociateUserAndSave(ctx context.Context, userID int32, spec extsvc.AccountSpec, data extsvc.AccountData) (err error) {
 	if Mocks.ExternalAccounts.AssociateUserAndSave != nil {
 		return Mocks.ExternalAccounts.AssociateUserAndSave(userID, spec, data)
@@ -170,6 +183,10 @@ func (s *UserExternalAccountsStore) AssociateUserAndSave(ctx context.Context, us
 	}
 	defer func() { err = tx.Done(err) }()
 
+	if err := setAuditActor(ctx, tx.Handle().DB()); err != nil {
+		return err
+	}
+
 	// Find whether the account exists and, if so, which user ID the account is associated with.
 	var exists bool
 	var existingID, associatedUserID int32
@@ -192,7 +209,7 @@ AND deleted_at IS NULL
 
 	if exists && associatedUserID != userID {
 		// The account already exists and is associated with another user.
-		return errors.Errorf("unable to change association of external account from user %d to user %d (delete the external account and then try again)", associatedUserID, userID)
+		return &ExternalAccountAssociatedWithOtherUserError{UserID: associatedUserID, RequestedUserID: userID}
 	}
 
 	if !exists {
@@ -264,6 +281,10 @@ func (s *UserExternalAccountsStore) CreateUserAndSave(ctx context.Context, newUs
 	}
 	defer func() { err = tx.Done(err) }()
 
+	if err := setAuditActor(ctx, tx.Handle().DB()); err != nil {
+		return 0, err
+	}
+
 	createdUser, err := UsersWith(tx).create(ctx, newUser)
 	if err != nil {
 		return 0, err
@@ -304,7 +325,8 @@ VALUES (%s, %s, %s, %s, %s, %s, %s, %s)
 `, userID, spec.ServiceType, spec.ServiceID, spec.ClientID, spec.AccountID, data.AuthData, data.Data, keyID))
 }
 
-// TouchExpired sets the given user external account to be expired now.
+// TouchExpired sets the given user external account to be expired now. Sessions authenticated
+// with the account are no longer valid (see IsActive).
 func (s *UserExternalAccountsStore) TouchExpired(ctx context.Context, id int32) error {
 	if Mocks.ExternalAccounts.TouchExpired != nil {
 		return Mocks.ExternalAccounts.TouchExpired(ctx, id)
@@ -338,14 +360,15 @@ WHERE id = $1
 	return err
 }
 
-// Delete deletes a user external account.
+// Delete deletes a user external account. Sessions authenticated with the account are no longer
+// valid (see IsActive).
 func (s *UserExternalAccountsStore) Delete(ctx context.Context, id int32) error {
 	if Mocks.ExternalAccounts.Delete != nil {
 		return Mocks.ExternalAccounts.Delete(id)
 	}
 	s.ensureStore()
 
-	res, err := s.Handle().DB().ExecContext(ctx, "UPDATE user_external_accounts SET deleted_at=now() WHERE id=$1 AND deleted_at IS NULL", id)
+	res, err := execAudited(ctx, s.Store, sqlf.Sprintf("UPDATE user_external_accounts SET deleted_at=now() WHERE id=%s AND deleted_at IS NULL", id))
 	if err != nil {
 		return err
 	}
@@ -359,6 +382,33 @@ func (s *UserExternalAccountsStore) Delete(ctx context.Context, id int32) error
 	return nil
 }
 
+// IsActive reports whether the user has an external account matching spec that is neither deleted
+// nor expired. It is checked on every request authenticated by a session that was started by
+// signing in with the external account, so that deleting or expiring the account invalidates the
+// session immediately.
+func (s *UserExternalAccountsStore) IsActive(ctx context.Context, userID int32, spec extsvc.AccountSpec) (bool, error) {
+	if Mocks.ExternalAccounts.IsActive != nil {
+		return Mocks.ExternalAccounts.IsActive(userID, spec)
+	}
+	s.ensureStore()
+
+	active, _, err := basestore.ScanFirstBool(s.Query(ctx, sqlf.Sprintf(`
+-- source: internal/database/external_accounts.go:UserExternalAccountsStore.IsActive
+SELECT EXISTS (
+	SELECT 1 FROM user_external_accounts
+	WHERE
+		user_id = %s
+	AND service_type = %s
+	AND service_id = %s
+	AND client_id = %s
+	AND account_id = %s
+	AND deleted_at IS NULL
+	AND expired_at IS NULL
+)
+`, userID, spec.ServiceType, spec.ServiceID, spec.ClientID, spec.AccountID)))
+	return active, err
+}
+
 // ExternalAccountsListOptions specifies the options for listing user external accounts.
 type ExternalAccountsListOptions struct {
 	UserID                           int32
@@ -496,6 +546,7 @@ type MockExternalAccounts struct {
 	AssociateUserAndSave func(userID int32, spec extsvc.AccountSpec, data extsvc.AccountData) error
 	CreateUserAndSave    func(NewUser, extsvc.AccountSpec, extsvc.AccountData) (createdUserID int32, err error)
 	Delete               func(id int32) error
+	IsActive             func(userID int32, spec extsvc.AccountSpec) (bool, error)
 	List                 func(ExternalAccountsListOptions) ([]*extsvc.Account, error)
 	Count                func(ExternalAccountsListOptions) (int, error)
 	TouchExpired         func(ctx context.Context, id int32) error
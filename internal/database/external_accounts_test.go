@@ -519,3 +519,64 @@ func TestExternalAccounts_expiredAt(t *testing.T) {
 		}
 	})
 }
+
+func TestExternalAccounts_IsActive(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t, "")
+	ctx := context.Background()
+
+	spec := extsvc.AccountSpec{
+		ServiceType: "xa",
+		ServiceID:   "xb",
+		ClientID:    "xc",
+		AccountID:   "xd",
+	}
+	userID, err := ExternalAccounts(db).CreateUserAndSave(ctx, NewUser{Username: "u"}, spec, extsvc.AccountData{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	accts, err := ExternalAccounts(db).List(ctx, ExternalAccountsListOptions{UserID: userID})
+	if err != nil {
+		t.Fatal(err)
+	} else if len(accts) != 1 {
+		t.Fatalf("Want 1 external accounts but got %d", len(accts))
+	}
+	acct := accts[0]
+
+	isActive := func(userID int32, spec extsvc.AccountSpec) bool {
+		t.Helper()
+		active, err := ExternalAccounts(db).IsActive(ctx, userID, spec)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return active
+	}
+
+	if !isActive(userID, spec) {
+		t.Fatal("Want account to be active")
+	}
+	if isActive(userID+1, spec) {
+		t.Fatal("Want account of other user to be inactive")
+	}
+
+	if err := ExternalAccounts(db).TouchExpired(ctx, acct.ID); err != nil {
+		t.Fatal(err)
+	}
+	if isActive(userID, spec) {
+		t.Fatal("Want expired account to be inactive")
+	}
+
+	if err := ExternalAccounts(db).TouchLastValid(ctx, acct.ID); err != nil {
+		t.Fatal(err)
+	}
+	if err := ExternalAccounts(db).Delete(ctx, acct.ID); err != nil {
+		t.Fatal(err)
+	}
+	if isActive(userID, spec) {
+		t.Fatal("Want deleted account to be inactive")
+	}
+}
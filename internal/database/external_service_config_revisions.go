@@ -0,0 +1,113 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// recordConfigRevision stores the given (possibly encrypted) config as the latest revision
+// of the config of the external service with the given ID. The author of the revision is
+// the actor of the given context, if any.
+func recordConfigRevision(ctx context.Context, db dbutil.DB, id int64, config, keyID string) error {
+	q := sqlf.Sprintf(
+		recordConfigRevisionQuery,
+		id,
+		config,
+		keyID,
+		nullInt32Column(actor.FromContext(ctx).UID),
+	)
+	_, err := db.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	return err
+}
+
+const recordConfigRevisionQuery = `
+-- source: internal/database/external_service_config_revisions.go:recordConfigRevision
+INSERT INTO external_service_config_revisions (external_service_id, config, encryption_key_id, author_user_id)
+VALUES (%s, %s, %s, %s)
+`
+
+// ListConfigRevisions returns the revisions of the config of the external service with
+// the given ID, most recent first. The configs of the revisions are decrypted.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin or owner of the external service.
+func (e *ExternalServiceStore) ListConfigRevisions(ctx context.Context, id int64) ([]*types.ExternalServiceConfigRevision, error) {
+	e.ensureStore()
+
+	return e.listConfigRevisions(ctx, sqlf.Sprintf("external_service_id = %s", id))
+}
+
+// GetConfigRevision returns the revision of the config of the external service with the
+// given ID. The config of the revision is decrypted.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin or owner of the external service.
+func (e *ExternalServiceStore) GetConfigRevision(ctx context.Context, id, revisionID int64) (*types.ExternalServiceConfigRevision, error) {
+	e.ensureStore()
+
+	revisions, err := e.listConfigRevisions(ctx, sqlf.Sprintf("external_service_id = %s AND id = %s", id, revisionID))
+	if err != nil {
+		return nil, err
+	}
+	if len(revisions) == 0 {
+		return nil, externalServiceConfigRevisionNotFoundError{id: revisionID}
+	}
+	return revisions[0], nil
+}
+
+func (e *ExternalServiceStore) listConfigRevisions(ctx context.Context, cond *sqlf.Query) ([]*types.ExternalServiceConfigRevision, error) {
+	rows, err := e.Query(ctx, sqlf.Sprintf(listConfigRevisionsQuery, cond))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var results []*types.ExternalServiceConfigRevision
+	for rows.Next() {
+		var (
+			r            types.ExternalServiceConfigRevision
+			keyID        string
+			authorUserID sql.NullInt32
+		)
+		if err := rows.Scan(&r.ID, &r.ExternalServiceID, &r.Config, &keyID, &authorUserID, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		r.AuthorUserID = authorUserID.Int32
+
+		r.Config, err = e.maybeDecryptConfig(ctx, r.Config, keyID)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, &r)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	return results, nil
+}
+
+const listConfigRevisionsQuery = `
+-- source: internal/database/external_service_config_revisions.go:listConfigRevisions
+SELECT id, external_service_id, config, encryption_key_id, author_user_id, created_at
+FROM external_service_config_revisions
+WHERE %s
+ORDER BY id DESC
+`
+
+type externalServiceConfigRevisionNotFoundError struct {
+	id int64
+}
+
+func (e externalServiceConfigRevisionNotFoundError) Error() string {
+	return fmt.Sprintf("external service config revision not found: %v", e.id)
+}
+
+func (e externalServiceConfigRevisionNotFoundError) NotFound() bool {
+	return true
+}
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestExternalServicesStore_ConfigRevisions(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtest.NewDB(t, "")
+	ctx := context.Background()
+
+	user, err := Users(db).Create(ctx, NewUser{Username: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	confGet := func() *conf.Unified {
+		return &conf.Unified{}
+	}
+	es := &types.ExternalService{
+		Kind:        extsvc.KindGitHub,
+		DisplayName: "GITHUB #1",
+		Config:      `{"url": "https://github.com", "repositoryQuery": ["none"], "token": "abc"}`,
+	}
+	if err := ExternalServices(db).Create(ctx, confGet, es); err != nil {
+		t.Fatal(err)
+	}
+
+	// Updating only the display name does not create a revision
+	if err := ExternalServices(db).Update(ctx, nil, es.ID, &ExternalServiceUpdate{DisplayName: strptr("GITHUB #2")}); err != nil {
+		t.Fatal(err)
+	}
+
+	updatedConfig := `{"url": "https://github.com", "repositoryQuery": ["affiliated"], "token": "abc"}`
+	userCtx := actor.WithActor(ctx, actor.FromUser(user.ID))
+	if err := ExternalServices(db).Update(userCtx, nil, es.ID, &ExternalServiceUpdate{Config: &updatedConfig}); err != nil {
+		t.Fatal(err)
+	}
+
+	revisions, err := ExternalServices(db).ListConfigRevisions(ctx, es.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	type revision struct {
+		Config       string
+		AuthorUserID int32
+	}
+	var have []revision
+	for _, r := range revisions {
+		have = append(have, revision{Config: r.Config, AuthorUserID: r.AuthorUserID})
+	}
+	want := []revision{
+		{Config: updatedConfig, AuthorUserID: user.ID},
+		{Config: es.Config, AuthorUserID: 0},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("unexpected revisions (-want +got):\n%s", diff)
+	}
+
+	got, err := ExternalServices(db).GetConfigRevision(ctx, es.ID, revisions[1].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(revisions[1], got); diff != "" {
+		t.Fatalf("unexpected revision (-want +got):\n%s", diff)
+	}
+
+	// Revisions of other external services are not found
+	if _, err := ExternalServices(db).GetConfigRevision(ctx, es.ID+1, revisions[1].ID); !errcode.IsNotFound(err) {
+		t.Fatalf("expected not found error, got %v", err)
+	}
+}
@@ -119,6 +119,7 @@ func (e *ExternalServiceStore) ensureStore() {
 // external services.
 var ExternalServiceKinds = map[string]ExternalServiceKind{
 	extsvc.KindAWSCodeCommit:   {CodeHost: true, JSONSchema: schema.AWSCodeCommitSchemaJSON},
+	extsvc.KindAzureDevOps:     {CodeHost: true, JSONSchema: schema.AzureDevOpsSchemaJSON},
 	extsvc.KindBitbucketCloud:  {CodeHost: true, JSONSchema: schema.BitbucketCloudSchemaJSON},
 	extsvc.KindBitbucketServer: {CodeHost: true, JSONSchema: schema.BitbucketServerSchemaJSON},
 	extsvc.KindGitHub:          {CodeHost: true, JSONSchema: schema.GitHubSchemaJSON},
@@ -312,6 +313,13 @@ func (e *ExternalServiceStore) ValidateConfig(ctx context.Context, opt ValidateE
 		}
 		err = e.validateBitbucketCloudConnection(ctx, opt.ExternalServiceID, &c)
 
+	case extsvc.KindAzureDevOps:
+		var c schema.AzureDevOpsConnection
+		if err = jsoniter.Unmarshal(normalized, &c); err != nil {
+			return nil, err
+		}
+		err = e.validateAzureDevOpsConnection(ctx, opt.ExternalServiceID, &c)
+
 	case extsvc.KindPerforce:
 		var c schema.PerforceConnection
 		if err = jsoniter.Unmarshal(normalized, &c); err != nil {
@@ -404,6 +412,10 @@ func (e *ExternalServiceStore) validateBitbucketCloudConnection(ctx context.Cont
 	return e.validateDuplicateRateLimits(ctx, id, extsvc.KindBitbucketCloud, c)
 }
 
+func (e *ExternalServiceStore) validateAzureDevOpsConnection(ctx context.Context, id int64, c *schema.AzureDevOpsConnection) error {
+	return e.validateDuplicateRateLimits(ctx, id, extsvc.KindAzureDevOps, c)
+}
+
 func (e *ExternalServiceStore) validatePerforceConnection(ctx context.Context, id int64, c *schema.PerforceConnection) error {
 	err := new(multierror.Error)
 	for _, validate := range e.PerforceValidators {
@@ -536,7 +548,7 @@ func upsertAuthorizationToExternalService(kind, config string) (string, error) {
 // recalculated based on whether "authorization" field is presented in
 // `es.Config`. For Sourcegraph Cloud, the `es.Unrestricted` will always be
 // false (i.e. enforce permissions).
-func (e *ExternalServiceStore) Create(ctx context.Context, confGet func() *conf.Unified, es *types.ExternalService) error {
+func (e *ExternalServiceStore) Create(ctx context.Context, confGet func() *conf.Unified, es *types.ExternalService) (err error) {
 	if Mocks.ExternalServices.Create != nil {
 		return Mocks.ExternalServices.Create(ctx, confGet, es)
 	}
@@ -578,11 +590,26 @@ func (e *ExternalServiceStore) Create(ctx context.Context, confGet func() *conf.
 		return err
 	}
 
-	return e.Store.Handle().DB().QueryRowContext(
+	tx, err := e.Store.Handle().Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := setAuditActor(ctx, tx.DB()); err != nil {
+		return err
+	}
+
+	err = tx.DB().QueryRowContext(
 		ctx,
 		"INSERT INTO external_services(kind, display_name, config, encryption_key_id, created_at, updated_at, namespace_user_id, unrestricted, cloud_default) VALUES($1, $2, $3, $4, $5, $6, $7, $8, $9) RETURNING id",
 		es.Kind, es.DisplayName, config, keyID, es.CreatedAt, es.UpdatedAt, nullInt32Column(es.NamespaceUserID), es.Unrestricted, es.CloudDefault,
 	).Scan(&es.ID)
+	if err != nil {
+		return err
+	}
+
+	return recordConfigRevision(ctx, tx.DB(), es.ID, config, keyID)
 }
 
 // maybeEncryptConfig encrypts and returns externals service config if an encryption.Key is configured
@@ -657,6 +684,10 @@ func (e *ExternalServiceStore) Upsert(ctx context.Context, svcs ...*types.Extern
 	}
 	defer func() { err = tx.Done(err) }()
 
+	if err := setAuditActor(ctx, tx.Handle().DB()); err != nil {
+		return err
+	}
+
 	// Get the list services that are marked as deleted. We don't know at this point
 	// whether they are marked as deleted in the DB too.
 	var deleted []int64
@@ -883,6 +914,10 @@ func (e *ExternalServiceStore) Update(ctx context.Context, ps []schema.AuthProvi
 	}
 	defer func() { err = tx.Done(err) }()
 
+	if err := setAuditActor(ctx, tx.DB()); err != nil {
+		return err
+	}
+
 	if update.DisplayName != nil {
 		if err := execUpdate(ctx, tx.DB(), sqlf.Sprintf("display_name=%s", update.DisplayName)); err != nil {
 			return err
@@ -895,6 +930,9 @@ func (e *ExternalServiceStore) Update(ctx context.Context, ps []schema.AuthProvi
 		if err := execUpdate(ctx, tx.DB(), q); err != nil {
 			return err
 		}
+		if err := recordConfigRevision(ctx, tx.DB(), id, *update.Config, keyID); err != nil {
+			return err
+		}
 	}
 
 	if update.CloudDefault != nil {
@@ -926,12 +964,24 @@ func (e *ExternalServiceStore) Delete(ctx context.Context, id int64) (err error)
 	}
 	e.ensureStore()
 
+	// The repositories of the external service are no longer searchable once
+	// the deletion is committed.
+	defer func() {
+		if err == nil {
+			InvalidateSearchRepos(ctx)
+		}
+	}()
+
 	tx, err := e.Transact(ctx)
 	if err != nil {
 		return err
 	}
 	defer func() { err = tx.Done(err) }()
 
+	if err := setAuditActor(ctx, tx.Handle().DB()); err != nil {
+		return err
+	}
+
 	// Create a temporary table where we'll store repos affected by the deletion of
 	// the external service
 	if err := tx.Exec(ctx, sqlf.Sprintf(`
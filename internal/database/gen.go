@@ -2,3 +2,5 @@ package database
 
 // $PGHOST, $PGUSER, $PGPORT etc. must be set to run this generate script.
 //go:generate env GO111MODULE=on go run schemadoc/main.go
+
+//go:generate go run ../../dev/observedgen -p github.com/sourcegraph/sourcegraph/internal/database -t UserExternalAccountsStore -n database.external_accounts -o observed_external_accounts.go
@@ -4,9 +4,11 @@ import (
 	"context"
 	"database/sql"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
@@ -43,12 +45,13 @@ func (s *GitserverRepoStore) Transact(ctx context.Context) (*GitserverRepoStore,
 func (s *GitserverRepoStore) Upsert(ctx context.Context, repos ...*types.GitserverRepo) error {
 	values := make([]*sqlf.Query, 0, len(repos))
 	for _, gr := range repos {
-		q := sqlf.Sprintf("(%s, %s, %s, %s, %s, now())",
+		q := sqlf.Sprintf("(%s, %s, %s, %s, %s, %s, now())",
 			gr.RepoID,
 			gr.CloneStatus,
 			dbutil.NewNullString(gr.ShardID),
 			dbutil.NewNullInt64(gr.LastExternalService),
 			dbutil.NewNullString(sanitizeToUTF8(gr.LastError)),
+			dbutil.NewNullString(gr.CloneSkipReason),
 		)
 
 		values = append(values, q)
@@ -57,11 +60,11 @@ func (s *GitserverRepoStore) Upsert(ctx context.Context, repos ...*types.Gitserv
 	err := s.Exec(ctx, sqlf.Sprintf(`
 -- source: internal/database/gitserver_repos.go:GitserverRepoStore.Upsert
 INSERT INTO
-    gitserver_repos(repo_id, clone_status, shard_id, last_external_service, last_error, updated_at)
+    gitserver_repos(repo_id, clone_status, shard_id, last_external_service, last_error, clone_skip_reason, updated_at)
     VALUES %s
     ON CONFLICT (repo_id) DO UPDATE
-    SET (clone_status, shard_id, last_external_service, last_error, updated_at) =
-        (EXCLUDED.clone_status, EXCLUDED.shard_id, EXCLUDED.last_external_service, EXCLUDED.last_error, now())
+    SET (clone_status, shard_id, last_external_service, last_error, clone_skip_reason, updated_at) =
+        (EXCLUDED.clone_status, EXCLUDED.shard_id, EXCLUDED.last_external_service, EXCLUDED.last_error, EXCLUDED.clone_skip_reason, now())
 `, sqlf.Join(values, ",")))
 
 	return errors.Wrap(err, "creating GitserverRepo")
@@ -89,6 +92,9 @@ SELECT repo.id,
        gr.shard_id,
        gr.last_external_service,
        gr.last_error,
+       gr.clone_skip_reason,
+       gr.last_fetched,
+       gr.repo_size_bytes,
        gr.updated_at
 FROM repo
     LEFT JOIN gitserver_repos gr ON gr.repo_id = repo.id
@@ -105,31 +111,12 @@ FROM repo
 	defer rows.Close()
 
 	for rows.Next() {
-		var rgs types.RepoGitserverStatus
-		var gr types.GitserverRepo
-		var cloneStatus string
-
-		if err := rows.Scan(
-			&rgs.ID,
-			&rgs.Name,
-			&dbutil.NullString{S: &cloneStatus},
-			&dbutil.NullString{S: &gr.ShardID},
-			&dbutil.NullInt64{N: &gr.LastExternalService},
-			&dbutil.NullString{S: &gr.LastError},
-			&dbutil.NullTime{Time: &gr.UpdatedAt},
-		); err != nil {
-			return errors.Wrap(err, "scanning row")
-		}
-
-		// Clone status will only be null if we don't have a corresponding row in
-		// gitserver_repos
-		if cloneStatus != "" {
-			gr.CloneStatus = types.ParseCloneStatus(cloneStatus)
-			gr.RepoID = rgs.ID
-			rgs.GitserverRepo = &gr
+		rgs, err := scanRepoGitserverStatus(rows)
+		if err != nil {
+			return err
 		}
 
-		err := repoFn(rgs)
+		err = repoFn(rgs)
 		if err != nil {
 			// Abort
 			return errors.Wrap(err, "calling repoFn")
@@ -143,6 +130,174 @@ FROM repo
 	return nil
 }
 
+func scanRepoGitserverStatus(sc dbutil.Scanner) (types.RepoGitserverStatus, error) {
+	var rgs types.RepoGitserverStatus
+	var gr types.GitserverRepo
+	var cloneStatus string
+
+	if err := sc.Scan(
+		&rgs.ID,
+		&rgs.Name,
+		&dbutil.NullString{S: &cloneStatus},
+		&dbutil.NullString{S: &gr.ShardID},
+		&dbutil.NullInt64{N: &gr.LastExternalService},
+		&dbutil.NullString{S: &gr.LastError},
+		&dbutil.NullString{S: &gr.CloneSkipReason},
+		&dbutil.NullTime{Time: &gr.LastFetched},
+		&dbutil.NullInt64{N: &gr.RepoSizeBytes},
+		&dbutil.NullTime{Time: &gr.UpdatedAt},
+	); err != nil {
+		return rgs, errors.Wrap(err, "scanning row")
+	}
+
+	// Clone status will only be null if we don't have a corresponding row in
+	// gitserver_repos
+	if cloneStatus != "" {
+		gr.CloneStatus = types.ParseCloneStatus(cloneStatus)
+		gr.RepoID = rgs.ID
+		rgs.GitserverRepo = &gr
+	}
+	return rgs, nil
+}
+
+// RepoGitserverStatusOrderBy is a column that the status of repos can be
+// ordered by.
+type RepoGitserverStatusOrderBy string
+
+const (
+	RepoGitserverStatusOrderByName        RepoGitserverStatusOrderBy = "repo.name"
+	RepoGitserverStatusOrderBySize        RepoGitserverStatusOrderBy = "gr.repo_size_bytes"
+	RepoGitserverStatusOrderByLastFetched RepoGitserverStatusOrderBy = "gr.last_fetched"
+)
+
+// ListRepoGitserverStatusOptions filters and orders the status of repos
+// returned by ListRepoGitserverStatus.
+type ListRepoGitserverStatusOptions struct {
+	// Query, if set, only includes repos whose names contain it.
+	Query string
+
+	// OnlyCloned excludes repos that are not cloned.
+	OnlyCloned bool
+	// NoCloned excludes repos that are cloned.
+	NoCloned bool
+	// FailedFetch only includes repos that failed to clone or fetch.
+	FailedFetch bool
+
+	// IndexedNames are the names of the repos with a text search index. They
+	// are used by OnlyIndexed and NoIndexed.
+	IndexedNames []string
+	// OnlyIndexed excludes repos that are not in IndexedNames.
+	OnlyIndexed bool
+	// NoIndexed excludes repos that are in IndexedNames.
+	NoIndexed bool
+
+	// MinSizeBytes, if non-zero, excludes repos that are smaller.
+	MinSizeBytes int64
+	// MaxSizeBytes, if non-zero, excludes repos that are larger.
+	MaxSizeBytes int64
+
+	// OrderBy defaults to the name of the repos. Repos whose gitserver status
+	// is not known are ordered last.
+	OrderBy    RepoGitserverStatusOrderBy
+	Descending bool
+
+	*LimitOffset
+}
+
+func (o ListRepoGitserverStatusOptions) sqlConds() *sqlf.Query {
+	conds := []*sqlf.Query{sqlf.Sprintf("repo.deleted_at IS NULL")}
+	if o.Query != "" {
+		conds = append(conds, sqlf.Sprintf("repo.name ILIKE %s", "%"+o.Query+"%"))
+	}
+	if o.OnlyCloned {
+		conds = append(conds, sqlf.Sprintf("gr.clone_status = %s", types.CloneStatusCloned))
+	}
+	if o.NoCloned {
+		conds = append(conds, sqlf.Sprintf("gr.clone_status IS DISTINCT FROM %s", types.CloneStatusCloned))
+	}
+	if o.FailedFetch {
+		conds = append(conds, sqlf.Sprintf("gr.last_error IS NOT NULL"))
+	}
+	if o.OnlyIndexed {
+		conds = append(conds, sqlf.Sprintf("repo.name = ANY(%s)", pq.Array(o.IndexedNames)))
+	}
+	if o.NoIndexed {
+		conds = append(conds, sqlf.Sprintf("NOT (repo.name = ANY(%s))", pq.Array(o.IndexedNames)))
+	}
+	if o.MinSizeBytes != 0 {
+		conds = append(conds, sqlf.Sprintf("gr.repo_size_bytes >= %s", o.MinSizeBytes))
+	}
+	if o.MaxSizeBytes != 0 {
+		conds = append(conds, sqlf.Sprintf("gr.repo_size_bytes <= %s", o.MaxSizeBytes))
+	}
+	return sqlf.Join(conds, "AND")
+}
+
+func (o ListRepoGitserverStatusOptions) sqlOrderBy() *sqlf.Query {
+	column := o.OrderBy
+	if column == "" {
+		column = RepoGitserverStatusOrderByName
+	}
+	direction := "ASC"
+	if o.Descending {
+		direction = "DESC"
+	}
+	// Break ties by ID so that pagination is stable.
+	return sqlf.Sprintf(string(column) + " " + direction + " NULLS LAST, repo.id " + direction)
+}
+
+// ListRepoGitserverStatus returns the gitserver status of the repos matching
+// the options, including repos without a row in gitserver_repos.
+func (s *GitserverRepoStore) ListRepoGitserverStatus(ctx context.Context, opt ListRepoGitserverStatusOptions) ([]types.RepoGitserverStatus, error) {
+	q := sqlf.Sprintf(`
+-- source: internal/database/gitserver_repos.go:GitserverRepoStore.ListRepoGitserverStatus
+SELECT repo.id,
+       repo.name,
+       gr.clone_status,
+       gr.shard_id,
+       gr.last_external_service,
+       gr.last_error,
+       gr.clone_skip_reason,
+       gr.last_fetched,
+       gr.repo_size_bytes,
+       gr.updated_at
+FROM repo
+    LEFT JOIN gitserver_repos gr ON gr.repo_id = repo.id
+WHERE %s
+ORDER BY %s
+%s
+`, opt.sqlConds(), opt.sqlOrderBy(), opt.LimitOffset.SQL())
+
+	rows, err := s.Query(ctx, q)
+	if err != nil {
+		return nil, errors.Wrap(err, "listing gitserver status")
+	}
+	defer rows.Close()
+
+	var statuses []types.RepoGitserverStatus
+	for rows.Next() {
+		rgs, err := scanRepoGitserverStatus(rows)
+		if err != nil {
+			return nil, err
+		}
+		statuses = append(statuses, rgs)
+	}
+	return statuses, errors.Wrap(rows.Err(), "iterating rows")
+}
+
+// CountRepoGitserverStatus returns the number of repos matching the options,
+// ignoring their limit and offset.
+func (s *GitserverRepoStore) CountRepoGitserverStatus(ctx context.Context, opt ListRepoGitserverStatusOptions) (int, error) {
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(`
+-- source: internal/database/gitserver_repos.go:GitserverRepoStore.CountRepoGitserverStatus
+SELECT COUNT(*)
+FROM repo
+    LEFT JOIN gitserver_repos gr ON gr.repo_id = repo.id
+WHERE %s
+`, opt.sqlConds())))
+	return count, errors.Wrap(err, "counting gitserver status")
+}
+
 func (s *GitserverRepoStore) GetByID(ctx context.Context, id api.RepoID) (*types.GitserverRepo, error) {
 	q := `
 -- source: internal/database/gitserver_repos.go:GitserverRepoStore.GetByID
@@ -152,6 +307,9 @@ SELECT
        shard_id,
        last_external_service,
        last_error,
+       clone_skip_reason,
+       last_fetched,
+       repo_size_bytes,
        updated_at
 FROM gitserver_repos
 WHERE repo_id = %s
@@ -169,6 +327,9 @@ WHERE repo_id = %s
 		&gr.ShardID,
 		&dbutil.NullInt64{N: &gr.LastExternalService},
 		&dbutil.NullString{S: &gr.LastError},
+		&dbutil.NullString{S: &gr.CloneSkipReason},
+		&dbutil.NullTime{Time: &gr.LastFetched},
+		&dbutil.NullInt64{N: &gr.RepoSizeBytes},
 		&gr.UpdatedAt,
 	)
 	if err != nil {
@@ -215,6 +376,40 @@ SET (last_error, shard_id, updated_at) =
 	return errors.Wrap(err, "setting last error")
 }
 
+// SetCloneSkipReason will attempt to update ONLY the clone skip reason of a
+// GitServerRepo. An empty reason records that the repo wasn't skipped. If a
+// matching row does not yet exist a new one will be created.
+// If the reason hasn't changed, the row will not be updated.
+func (s *GitserverRepoStore) SetCloneSkipReason(ctx context.Context, id api.RepoID, reason, shardID string) error {
+	err := s.Exec(ctx, sqlf.Sprintf(`
+-- source: internal/database/gitserver_repos.go:GitserverRepoStore.SetCloneSkipReason
+INSERT INTO gitserver_repos(repo_id, clone_skip_reason, shard_id, updated_at)
+VALUES (%s, %s, %s, now())
+ON CONFLICT (repo_id) DO UPDATE
+SET (clone_skip_reason, shard_id, updated_at) =
+    (EXCLUDED.clone_skip_reason, EXCLUDED.shard_id, now())
+    WHERE gitserver_repos.clone_skip_reason IS DISTINCT FROM EXCLUDED.clone_skip_reason
+`, id, dbutil.NewNullString(reason), shardID))
+
+	return errors.Wrap(err, "setting clone skip reason")
+}
+
+// SetLastFetched will attempt to update ONLY the time a GitServerRepo was last
+// fetched and its size afterwards. If a matching row does not yet exist a new
+// one will be created.
+func (s *GitserverRepoStore) SetLastFetched(ctx context.Context, id api.RepoID, lastFetched time.Time, sizeBytes int64, shardID string) error {
+	err := s.Exec(ctx, sqlf.Sprintf(`
+-- source: internal/database/gitserver_repos.go:GitserverRepoStore.SetLastFetched
+INSERT INTO gitserver_repos(repo_id, last_fetched, repo_size_bytes, shard_id, updated_at)
+VALUES (%s, %s, %s, %s, now())
+ON CONFLICT (repo_id) DO UPDATE
+SET (last_fetched, repo_size_bytes, shard_id, updated_at) =
+    (EXCLUDED.last_fetched, EXCLUDED.repo_size_bytes, EXCLUDED.shard_id, now())
+`, id, lastFetched, sizeBytes, shardID))
+
+	return errors.Wrap(err, "setting last fetched")
+}
+
 // sanitizeToUTF8 will remove any null character terminated string. The null character can be
 // represented in one of the following ways in Go:
 //
@@ -3,6 +3,7 @@ package database
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -342,6 +343,177 @@ func TestSetLastError(t *testing.T) {
 	}
 }
 
+func TestSetCloneSkipReason(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	db := dbtest.NewDB(t, "")
+	ctx := context.Background()
+	const shardID = "test"
+
+	repo1 := &types.Repo{
+		Name:         "github.com/sourcegraph/repo1",
+		URI:          "github.com/sourcegraph/repo1",
+		ExternalRepo: api.ExternalRepoSpec{},
+	}
+	if err := Repos(db).Create(ctx, repo1); err != nil {
+		t.Fatal(err)
+	}
+
+	// The row is created if it doesn't exist yet
+	const reason = "repository size 2 GB exceeds the limit of 1 GB"
+	if err := GitserverRepos(db).SetCloneSkipReason(ctx, repo1.ID, reason, shardID); err != nil {
+		t.Fatal(err)
+	}
+
+	fromDB, err := GitserverRepos(db).GetByID(ctx, repo1.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := &types.GitserverRepo{
+		RepoID:          repo1.ID,
+		ShardID:         shardID,
+		CloneStatus:     types.CloneStatusNotCloned,
+		CloneSkipReason: reason,
+	}
+	if diff := cmp.Diff(want, fromDB, cmpopts.IgnoreFields(types.GitserverRepo{}, "UpdatedAt")); diff != "" {
+		t.Fatal(diff)
+	}
+
+	// Skipped repos can be listed
+	skipped, err := Repos(db).List(ctx, ReposListOptions{CloneSkipped: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(skipped) != 1 || skipped[0].ID != repo1.ID {
+		t.Fatalf("want repo %d to be listed as skipped, got %v", repo1.ID, skipped)
+	}
+
+	// Clearing the reason sets the column to null
+	if err := GitserverRepos(db).SetCloneSkipReason(ctx, repo1.ID, "", shardID); err != nil {
+		t.Fatal(err)
+	}
+
+	count, _, err := basestore.ScanFirstInt(db.QueryContext(ctx, "SELECT COUNT(*) FROM gitserver_repos WHERE clone_skip_reason IS NULL"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Fatalf("Want %d, got %d", 1, count)
+	}
+}
+
+func TestListRepoGitserverStatus(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	db := dbtest.NewDB(t, "")
+	ctx := context.Background()
+	const shardID = "test"
+
+	var repos []*types.Repo
+	for _, name := range []api.RepoName{"github.com/sourcegraph/small", "github.com/sourcegraph/large", "github.com/sourcegraph/unknown"} {
+		repos = append(repos, &types.Repo{Name: name, URI: string(name)})
+	}
+	if err := Repos(db).Create(ctx, repos...); err != nil {
+		t.Fatal(err)
+	}
+
+	lastFetched := time.Now().Truncate(time.Microsecond)
+	store := GitserverRepos(db)
+	if err := store.SetLastFetched(ctx, repos[0].ID, lastFetched, 100, shardID); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetCloneStatus(ctx, repos[0].ID, types.CloneStatusCloned, shardID); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetLastFetched(ctx, repos[1].ID, lastFetched, 2000, shardID); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetLastError(ctx, repos[1].ID, "oops", shardID); err != nil {
+		t.Fatal(err)
+	}
+
+	for _, tc := range []struct {
+		name string
+		opt  ListRepoGitserverStatusOptions
+		want []api.RepoName
+	}{
+		{
+			name: "all by name",
+			want: []api.RepoName{"github.com/sourcegraph/large", "github.com/sourcegraph/small", "github.com/sourcegraph/unknown"},
+		},
+		{
+			name: "by size descending",
+			opt:  ListRepoGitserverStatusOptions{OrderBy: RepoGitserverStatusOrderBySize, Descending: true},
+			want: []api.RepoName{"github.com/sourcegraph/large", "github.com/sourcegraph/small", "github.com/sourcegraph/unknown"},
+		},
+		{
+			name: "paginated",
+			opt:  ListRepoGitserverStatusOptions{OrderBy: RepoGitserverStatusOrderBySize, LimitOffset: &LimitOffset{Limit: 1, Offset: 1}},
+			want: []api.RepoName{"github.com/sourcegraph/large"},
+		},
+		{
+			name: "cloned",
+			opt:  ListRepoGitserverStatusOptions{OnlyCloned: true},
+			want: []api.RepoName{"github.com/sourcegraph/small"},
+		},
+		{
+			name: "not cloned",
+			opt:  ListRepoGitserverStatusOptions{NoCloned: true},
+			want: []api.RepoName{"github.com/sourcegraph/large", "github.com/sourcegraph/unknown"},
+		},
+		{
+			name: "failed fetch",
+			opt:  ListRepoGitserverStatusOptions{FailedFetch: true},
+			want: []api.RepoName{"github.com/sourcegraph/large"},
+		},
+		{
+			name: "size range",
+			opt:  ListRepoGitserverStatusOptions{MinSizeBytes: 50, MaxSizeBytes: 1000},
+			want: []api.RepoName{"github.com/sourcegraph/small"},
+		},
+		{
+			name: "not indexed",
+			opt:  ListRepoGitserverStatusOptions{IndexedNames: []string{"github.com/sourcegraph/small"}, NoIndexed: true, Query: "l"},
+			want: []api.RepoName{"github.com/sourcegraph/large"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			statuses, err := store.ListRepoGitserverStatus(ctx, tc.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			var have []api.RepoName
+			for _, s := range statuses {
+				have = append(have, s.Name)
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatal(diff)
+			}
+
+			count, err := store.CountRepoGitserverStatus(ctx, tc.opt)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tc.opt.LimitOffset == nil && count != len(tc.want) {
+				t.Fatalf("want count %d, got %d", len(tc.want), count)
+			}
+		})
+	}
+
+	fromDB, err := store.GetByID(ctx, repos[0].ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !fromDB.LastFetched.Equal(lastFetched) || fromDB.RepoSizeBytes != 100 {
+		t.Fatalf("unexpected last fetch: %v, size %d", fromDB.LastFetched, fromDB.RepoSizeBytes)
+	}
+}
+
 func TestGitserverRepoUpsertNullShard(t *testing.T) {
 	if testing.Short() {
 		t.Skip()
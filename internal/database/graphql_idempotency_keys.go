@@ -0,0 +1,161 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+const (
+	// IdempotencyKeyTTL is how long the response of a GraphQL mutation is
+	// replayed for retries with the same idempotency key.
+	IdempotencyKeyTTL = 24 * time.Hour
+
+	// IdempotencyKeyHeartbeatInterval is how often the request that reserved
+	// an idempotency key renews its reservation while it is in progress.
+	IdempotencyKeyHeartbeatInterval = 30 * time.Second
+
+	// idempotencyKeyInProgressTimeout is how long an idempotency key stays
+	// reserved for a request without a response after its last heartbeat,
+	// e.g. because the frontend was restarted while the request was in
+	// progress.
+	idempotencyKeyInProgressTimeout = 4 * IdempotencyKeyHeartbeatInterval
+)
+
+// IdempotencyKey is the idempotency key of a GraphQL mutation.
+type IdempotencyKey struct {
+	UserID      int32
+	Key         string
+	RequestHash []byte
+	// Response is the response of the mutation, or nil while the mutation is
+	// in progress.
+	Response  []byte
+	CreatedAt time.Time
+	// HeartbeatAt is when the request in progress last renewed its
+	// reservation of the key.
+	HeartbeatAt time.Time
+}
+
+// IdempotencyKeyStore provides access to the graphql_idempotency_keys table.
+type IdempotencyKeyStore struct {
+	*basestore.Store
+
+	now func() time.Time
+}
+
+// IdempotencyKeys instantiates and returns a new IdempotencyKeyStore.
+func IdempotencyKeys(db dbutil.DB) *IdempotencyKeyStore {
+	return &IdempotencyKeyStore{Store: basestore.NewWithDB(db, sql.TxOptions{}), now: time.Now}
+}
+
+// Reserve reserves the idempotency key of the user for the request with the
+// given hash. If the key is already in use, it returns the existing key and
+// false. Keys older than IdempotencyKeyTTL, and keys whose request neither
+// stored a response nor sent a heartbeat in time, are reserved again.
+func (s *IdempotencyKeyStore) Reserve(ctx context.Context, userID int32, key string, requestHash []byte) (*IdempotencyKey, bool, error) {
+	now := s.now()
+	k, err := scanIdempotencyKey(s.QueryRow(ctx, sqlf.Sprintf(
+		reserveIdempotencyKeyQueryFmtstr,
+		userID,
+		key,
+		requestHash,
+		now,
+		now,
+		now.Add(-IdempotencyKeyTTL),
+		now.Add(-idempotencyKeyInProgressTimeout),
+	)))
+	if err == nil {
+		return k, true, nil
+	}
+	if err != sql.ErrNoRows {
+		return nil, false, err
+	}
+
+	k, err = scanIdempotencyKey(s.QueryRow(ctx, sqlf.Sprintf(getIdempotencyKeyQueryFmtstr, userID, key)))
+	if err != nil {
+		return nil, false, err
+	}
+	return k, false, nil
+}
+
+const reserveIdempotencyKeyQueryFmtstr = `
+-- source: internal/database/graphql_idempotency_keys.go:Reserve
+INSERT INTO graphql_idempotency_keys (user_id, key, request_hash, created_at, heartbeat_at)
+VALUES (%s, %s, %s, %s, %s)
+ON CONFLICT (user_id, key) DO UPDATE
+SET
+	request_hash = EXCLUDED.request_hash,
+	response = NULL,
+	created_at = EXCLUDED.created_at,
+	heartbeat_at = EXCLUDED.heartbeat_at
+WHERE
+	graphql_idempotency_keys.created_at < %s OR
+	(graphql_idempotency_keys.response IS NULL AND graphql_idempotency_keys.heartbeat_at < %s)
+RETURNING user_id, key, request_hash, response, created_at, heartbeat_at
+`
+
+const getIdempotencyKeyQueryFmtstr = `
+-- source: internal/database/graphql_idempotency_keys.go:Reserve
+SELECT user_id, key, request_hash, response, created_at, heartbeat_at
+FROM graphql_idempotency_keys
+WHERE user_id = %s AND key = %s
+`
+
+// Heartbeat renews the reservation of the idempotency key of the user by the
+// in-progress request with the given hash.
+func (s *IdempotencyKeyStore) Heartbeat(ctx context.Context, userID int32, key string, requestHash []byte) error {
+	return s.Exec(ctx, sqlf.Sprintf(heartbeatIdempotencyKeyQueryFmtstr, s.now(), userID, key, requestHash))
+}
+
+const heartbeatIdempotencyKeyQueryFmtstr = `
+-- source: internal/database/graphql_idempotency_keys.go:Heartbeat
+UPDATE graphql_idempotency_keys SET heartbeat_at = %s
+WHERE user_id = %s AND key = %s AND request_hash = %s AND response IS NULL
+`
+
+// SetResponse stores the response of the in-progress request with the given
+// hash that reserved the idempotency key of the user. It does nothing if the
+// key was reserved again by another request in the meantime.
+func (s *IdempotencyKeyStore) SetResponse(ctx context.Context, userID int32, key string, requestHash, response []byte) error {
+	return s.Exec(ctx, sqlf.Sprintf(setIdempotencyKeyResponseQueryFmtstr, response, userID, key, requestHash))
+}
+
+const setIdempotencyKeyResponseQueryFmtstr = `
+-- source: internal/database/graphql_idempotency_keys.go:SetResponse
+UPDATE graphql_idempotency_keys SET response = %s
+WHERE user_id = %s AND key = %s AND request_hash = %s AND response IS NULL
+`
+
+// Delete releases the idempotency key of the user, so that a retry of the
+// request runs again.
+func (s *IdempotencyKeyStore) Delete(ctx context.Context, userID int32, key string) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteIdempotencyKeyQueryFmtstr, userID, key))
+}
+
+const deleteIdempotencyKeyQueryFmtstr = `
+-- source: internal/database/graphql_idempotency_keys.go:Delete
+DELETE FROM graphql_idempotency_keys WHERE user_id = %s AND key = %s
+`
+
+// DeleteExpired deletes the idempotency keys older than IdempotencyKeyTTL.
+func (s *IdempotencyKeyStore) DeleteExpired(ctx context.Context) error {
+	return s.Exec(ctx, sqlf.Sprintf(deleteExpiredIdempotencyKeysQueryFmtstr, s.now().Add(-IdempotencyKeyTTL)))
+}
+
+const deleteExpiredIdempotencyKeysQueryFmtstr = `
+-- source: internal/database/graphql_idempotency_keys.go:DeleteExpired
+DELETE FROM graphql_idempotency_keys WHERE created_at < %s
+`
+
+func scanIdempotencyKey(sc dbutil.Scanner) (*IdempotencyKey, error) {
+	var k IdempotencyKey
+	if err := sc.Scan(&k.UserID, &k.Key, &k.RequestHash, &k.Response, &k.CreatedAt, &k.HeartbeatAt); err != nil {
+		return nil, err
+	}
+	return &k, nil
+}
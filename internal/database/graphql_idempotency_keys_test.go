@@ -0,0 +1,95 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestIdempotencyKeys(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	db := dbtest.NewDB(t, "")
+	ctx := context.Background()
+
+	user, err := Users(db).Create(ctx, NewUser{Username: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	store := IdempotencyKeys(db)
+	store.now = func() time.Time { return now }
+
+	if _, reserved, err := store.Reserve(ctx, user.ID, "k", []byte("a")); err != nil || !reserved {
+		t.Fatalf("got reserved %v and error %v, want key to be reserved", reserved, err)
+	}
+
+	// The key is in progress until it has a response.
+	k, reserved, err := store.Reserve(ctx, user.ID, "k", []byte("b"))
+	if err != nil || reserved {
+		t.Fatalf("got reserved %v and error %v, want existing key", reserved, err)
+	}
+	if string(k.RequestHash) != "a" || k.Response != nil {
+		t.Fatalf("unexpected key %+v", k)
+	}
+
+	// Only the request that reserved the key stores its response, once.
+	if err := store.SetResponse(ctx, user.ID, "k", []byte("b"), []byte("other")); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetResponse(ctx, user.ID, "k", []byte("a"), []byte("response")); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.SetResponse(ctx, user.ID, "k", []byte("a"), []byte("again")); err != nil {
+		t.Fatal(err)
+	}
+	if k, _, err := store.Reserve(ctx, user.ID, "k", []byte("a")); err != nil || string(k.Response) != "response" {
+		t.Fatalf("got key %+v and error %v, want stored response", k, err)
+	}
+
+	// Keys whose request didn't store a response are reserved again once it
+	// stops sending heartbeats, and all keys once they expire.
+	if _, reserved, err := store.Reserve(ctx, user.ID, "abandoned", []byte("a")); err != nil || !reserved {
+		t.Fatalf("got reserved %v and error %v, want key to be reserved", reserved, err)
+	}
+	if _, reserved, err := store.Reserve(ctx, user.ID, "long-running", []byte("a")); err != nil || !reserved {
+		t.Fatalf("got reserved %v and error %v, want key to be reserved", reserved, err)
+	}
+	for i := 0; i < 3; i++ {
+		now = now.Add(idempotencyKeyInProgressTimeout - time.Second)
+		if err := store.Heartbeat(ctx, user.ID, "long-running", []byte("a")); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, reserved, err := store.Reserve(ctx, user.ID, "long-running", []byte("a")); err != nil || reserved {
+		t.Fatalf("got reserved %v and error %v, want key of request with heartbeats to stay reserved", reserved, err)
+	}
+	now = now.Add(idempotencyKeyInProgressTimeout + time.Minute)
+	if _, reserved, err := store.Reserve(ctx, user.ID, "abandoned", []byte("a")); err != nil || !reserved {
+		t.Fatalf("got reserved %v and error %v, want abandoned key to be reserved again", reserved, err)
+	}
+	if _, reserved, err := store.Reserve(ctx, user.ID, "k", []byte("a")); err != nil || reserved {
+		t.Fatalf("got reserved %v and error %v, want existing key", reserved, err)
+	}
+
+	if err := store.Delete(ctx, user.ID, "abandoned"); err != nil {
+		t.Fatal(err)
+	}
+	if _, reserved, err := store.Reserve(ctx, user.ID, "abandoned", []byte("a")); err != nil || !reserved {
+		t.Fatalf("got reserved %v and error %v, want deleted key to be reserved again", reserved, err)
+	}
+
+	now = now.Add(IdempotencyKeyTTL)
+	if err := store.DeleteExpired(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if _, reserved, err := store.Reserve(ctx, user.ID, "k", []byte("b")); err != nil || !reserved {
+		t.Fatalf("got reserved %v and error %v, want expired key to be reserved again", reserved, err)
+	}
+}
@@ -10,6 +10,7 @@ type MockStores struct {
 	Namespaces      MockNamespaces
 	Orgs            MockOrgs
 	OrgMembers      MockOrgMembers
+	OrgTeams        MockOrgTeams
 	SavedSearches   MockSavedSearches
 	Settings        MockSettings
 	Users           MockUsers
@@ -0,0 +1,119 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// NodeIDRedirect maps the ID of a GraphQL node that no longer exists to the ID of the node
+// of the same kind that replaced it, such as a repository that was re-created after it was
+// deleted.
+type NodeIDRedirect struct {
+	Kind      string
+	OldID     int64
+	NewID     int64
+	CreatedAt time.Time
+}
+
+// maxNodeIDRedirects is the maximum number of redirects that are followed when resolving an
+// ID, which guards against redirect cycles.
+const maxNodeIDRedirects = 10
+
+// NodeIDRedirectStore provides access to the node_id_redirects table.
+type NodeIDRedirectStore struct {
+	*basestore.Store
+}
+
+// NodeIDRedirects instantiates and returns a new NodeIDRedirectStore with prepared statements.
+func NodeIDRedirects(db dbutil.DB) *NodeIDRedirectStore {
+	return &NodeIDRedirectStore{Store: basestore.NewWithDB(db, sql.TxOptions{})}
+}
+
+// Create redirects the old ID of the given node kind to the new ID, replacing any existing
+// redirect of the old ID.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+func (s *NodeIDRedirectStore) Create(ctx context.Context, kind string, oldID, newID int64) error {
+	if oldID == newID {
+		return errors.New("cannot redirect a node ID to itself")
+	}
+	return s.Exec(ctx, sqlf.Sprintf(createNodeIDRedirectQuery, kind, oldID, newID))
+}
+
+const createNodeIDRedirectQuery = `
+-- source: internal/database/node_id_redirects.go:Create
+INSERT INTO node_id_redirects (kind, old_id, new_id)
+VALUES (%s, %s, %s)
+ON CONFLICT (kind, old_id) DO UPDATE SET new_id = EXCLUDED.new_id, created_at = now()
+`
+
+// Delete removes the redirect of the old ID of the given node kind. It is not an error if
+// there is no such redirect.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+func (s *NodeIDRedirectStore) Delete(ctx context.Context, kind string, oldID int64) error {
+	return s.Exec(ctx, sqlf.Sprintf("DELETE FROM node_id_redirects WHERE kind = %s AND old_id = %s", kind, oldID))
+}
+
+// Resolve follows the redirects of the given ID of a node kind, and returns the ID they end
+// at. It returns false if the ID is not redirected.
+func (s *NodeIDRedirectStore) Resolve(ctx context.Context, kind string, id int64) (_ int64, redirected bool, err error) {
+	seen := map[int64]struct{}{id: {}}
+	for i := 0; i < maxNodeIDRedirects; i++ {
+		next, ok, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf("SELECT new_id FROM node_id_redirects WHERE kind = %s AND old_id = %s", kind, id)))
+		if err != nil {
+			return 0, false, err
+		}
+		if !ok {
+			return id, redirected, nil
+		}
+		newID := int64(next)
+		if _, ok := seen[newID]; ok {
+			return 0, false, errors.Errorf("redirect cycle for %s ID %d", kind, newID)
+		}
+		seen[newID] = struct{}{}
+		id, redirected = newID, true
+	}
+	return 0, false, errors.Errorf("too many redirects for %s ID %d", kind, id)
+}
+
+// List returns the redirects of the given node kind (or of all kinds, if kind is empty),
+// most recent first.
+//
+// 🚨 SECURITY: The caller must ensure that the actor is a site admin.
+func (s *NodeIDRedirectStore) List(ctx context.Context, kind string) ([]*NodeIDRedirect, error) {
+	cond := sqlf.Sprintf("TRUE")
+	if kind != "" {
+		cond = sqlf.Sprintf("kind = %s", kind)
+	}
+	rows, err := s.Query(ctx, sqlf.Sprintf(listNodeIDRedirectsQuery, cond))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var redirects []*NodeIDRedirect
+	for rows.Next() {
+		var r NodeIDRedirect
+		if err := rows.Scan(&r.Kind, &r.OldID, &r.NewID, &r.CreatedAt); err != nil {
+			return nil, err
+		}
+		redirects = append(redirects, &r)
+	}
+	return redirects, rows.Err()
+}
+
+const listNodeIDRedirectsQuery = `
+-- source: internal/database/node_id_redirects.go:List
+SELECT kind, old_id, new_id, created_at
+FROM node_id_redirects
+WHERE %s
+ORDER BY created_at DESC, kind, old_id
+`
@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestNodeIDRedirects(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtest.NewDB(t, "")
+	ctx := context.Background()
+	store := NodeIDRedirects(db)
+
+	if err := store.Create(ctx, "Monitor", 1, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Create(ctx, "Monitor", 2, 3); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Create(ctx, "Monitor", 4, 4); err == nil {
+		t.Fatal("expected error for redirect to itself")
+	}
+
+	assertResolve := func(t *testing.T, kind string, id, wantID int64, wantRedirected bool) {
+		t.Helper()
+		gotID, redirected, err := store.Resolve(ctx, kind, id)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if gotID != wantID || redirected != wantRedirected {
+			t.Fatalf("Resolve(%q, %d) = (%d, %v), want (%d, %v)", kind, id, gotID, redirected, wantID, wantRedirected)
+		}
+	}
+
+	t.Run("chain", func(t *testing.T) {
+		assertResolve(t, "Monitor", 1, 3, true)
+		assertResolve(t, "Monitor", 3, 3, false)
+		assertResolve(t, "User", 1, 1, false)
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		if err := store.Create(ctx, "Org", 1, 2); err != nil {
+			t.Fatal(err)
+		}
+		if err := store.Create(ctx, "Org", 2, 1); err != nil {
+			t.Fatal(err)
+		}
+		if _, _, err := store.Resolve(ctx, "Org", 1); err == nil {
+			t.Fatal("expected error for redirect cycle")
+		}
+	})
+
+	t.Run("list and delete", func(t *testing.T) {
+		redirects, err := store.List(ctx, "Monitor")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(redirects) != 2 {
+			t.Fatalf("expected 2 redirects, got %d", len(redirects))
+		}
+
+		if err := store.Delete(ctx, "Monitor", 2); err != nil {
+			t.Fatal(err)
+		}
+		assertResolve(t, "Monitor", 1, 2, true)
+	})
+
+	t.Run("re-created repository", func(t *testing.T) {
+		var oldID, newID int64
+		if err := db.QueryRowContext(ctx, "INSERT INTO repo (name) VALUES ('github.com/foo/bar') RETURNING id").Scan(&oldID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := db.ExecContext(ctx, "UPDATE repo SET name = soft_deleted_repository_name(name), deleted_at = now() WHERE id = $1", oldID); err != nil {
+			t.Fatal(err)
+		}
+		if err := db.QueryRowContext(ctx, "INSERT INTO repo (name) VALUES ('github.com/foo/bar') RETURNING id").Scan(&newID); err != nil {
+			t.Fatal(err)
+		}
+
+		assertResolve(t, "Repository", oldID, newID, true)
+	})
+}
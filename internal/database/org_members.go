@@ -53,6 +53,7 @@ func (m *OrgMemberStore) Create(ctx context.Context, orgID, userID int32) (*type
 		}
 		return nil, err
 	}
+	m.AfterCommit(func() { invalidateSettingsCascades(ctx) })
 	return &om, nil
 }
 
@@ -69,7 +70,11 @@ func (m *OrgMemberStore) GetByOrgIDAndUserID(ctx context.Context, orgID, userID
 
 func (m *OrgMemberStore) Remove(ctx context.Context, orgID, userID int32) error {
 	_, err := m.Handle().DB().ExecContext(ctx, "DELETE FROM org_members WHERE (org_id=$1 AND user_id=$2)", orgID, userID)
-	return err
+	if err != nil {
+		return err
+	}
+	m.AfterCommit(func() { invalidateSettingsCascades(ctx) })
+	return nil
 }
 
 // GetByOrgID returns a list of all members of a given organization.
@@ -149,6 +154,9 @@ func (m *OrgMemberStore) CreateMembershipInOrgsForAllUsers(ctx context.Context,
 			INSERT INTO org_members(org_id,user_id) SELECT to_join.org_id, to_join.user_id FROM to_join;`,
 		sqlf.Join(orgNameVars, ","))
 
-	err := m.Exec(ctx, sqlQuery)
-	return err
+	if err := m.Exec(ctx, sqlQuery); err != nil {
+		return err
+	}
+	m.AfterCommit(func() { invalidateSettingsCascades(ctx) })
+	return nil
 }
@@ -0,0 +1,415 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+
+	"github.com/cockroachdb/errors"
+	"github.com/jackc/pgconn"
+	"github.com/keegancsmith/sqlf"
+	"github.com/lib/pq"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// OrgTeamStore provides access to the org_teams, org_team_members and
+// org_team_repos tables.
+//
+// Teams are nested like teams on GitHub: the members of a team include the
+// members of its descendant teams, and the repositories of a team include
+// the repositories of its ancestor teams.
+type OrgTeamStore struct {
+	*basestore.Store
+}
+
+// OrgTeams instantiates and returns a new OrgTeamStore.
+func OrgTeams(db dbutil.DB) *OrgTeamStore {
+	return &OrgTeamStore{Store: basestore.NewWithDB(db, sql.TxOptions{})}
+}
+
+// OrgTeamsWith instantiates and returns a new OrgTeamStore using the other store handle.
+func OrgTeamsWith(other basestore.ShareableStore) *OrgTeamStore {
+	return &OrgTeamStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *OrgTeamStore) With(other basestore.ShareableStore) *OrgTeamStore {
+	return &OrgTeamStore{Store: s.Store.With(other)}
+}
+
+func (s *OrgTeamStore) Transact(ctx context.Context) (*OrgTeamStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &OrgTeamStore{Store: txBase}, err
+}
+
+// ErrOrgTeamNotFound is the error that is returned when a team is not found.
+type ErrOrgTeamNotFound struct {
+	args []interface{}
+}
+
+func (err *ErrOrgTeamNotFound) Error() string {
+	return fmt.Sprintf("org team not found: %v", err.args)
+}
+
+func (ErrOrgTeamNotFound) NotFound() bool { return true }
+
+// ErrOrgTeamCycle is returned when setting the parent of a team would make
+// the team its own ancestor.
+var ErrOrgTeamCycle = errors.New("a team cannot be nested in itself or one of its child teams")
+
+// Create creates a team. If team.ParentTeamID is set, the parent team must
+// belong to the same organization.
+func (s *OrgTeamStore) Create(ctx context.Context, team *types.OrgTeam) (_ *types.OrgTeam, err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.checkParent(ctx, team, 0); err != nil {
+		return nil, err
+	}
+
+	q := sqlf.Sprintf(createOrgTeamQueryFmtstr,
+		team.OrgID,
+		dbutil.NewNullInt64(team.ParentTeamID),
+		team.Name,
+		team.DisplayName,
+		dbutil.NewNullInt64(team.ExternalServiceID),
+		dbutil.NewNullString(team.ExternalID),
+	)
+	created, err := scanOrgTeam(tx.QueryRow(ctx, q))
+	if err != nil {
+		var e *pgconn.PgError
+		if errors.As(err, &e) && e.ConstraintName == "org_teams_org_id_name" {
+			return nil, errors.Errorf("a team named %q already exists in the organization", team.Name)
+		}
+		return nil, err
+	}
+	return created, nil
+}
+
+const createOrgTeamQueryFmtstr = `
+-- source: internal/database/org_teams.go:Create
+INSERT INTO org_teams (org_id, parent_team_id, name, display_name, external_service_id, external_id)
+VALUES (%s, %s, %s, %s, %s, %s)
+RETURNING ` + orgTeamColumns
+
+// Update updates the parent, display name and code host mapping of a team.
+func (s *OrgTeamStore) Update(ctx context.Context, team *types.OrgTeam) (err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.checkParent(ctx, team, team.ID); err != nil {
+		return err
+	}
+
+	res, err := tx.ExecResult(ctx, sqlf.Sprintf(updateOrgTeamQueryFmtstr,
+		dbutil.NewNullInt64(team.ParentTeamID),
+		team.DisplayName,
+		dbutil.NewNullInt64(team.ExternalServiceID),
+		dbutil.NewNullString(team.ExternalID),
+		team.ID,
+	))
+	if err != nil {
+		return err
+	}
+	if n, err := res.RowsAffected(); err != nil {
+		return err
+	} else if n == 0 {
+		return &ErrOrgTeamNotFound{[]interface{}{team.ID}}
+	}
+	return nil
+}
+
+const updateOrgTeamQueryFmtstr = `
+-- source: internal/database/org_teams.go:Update
+UPDATE org_teams
+SET parent_team_id = %s, display_name = %s, external_service_id = %s, external_id = %s, updated_at = now()
+WHERE id = %s
+`
+
+// checkParent returns an error if team.ParentTeamID is not a team of the
+// same organization, or if teamID is the parent team or one of its ancestors.
+func (s *OrgTeamStore) checkParent(ctx context.Context, team *types.OrgTeam, teamID int64) error {
+	if team.ParentTeamID == 0 {
+		return nil
+	}
+	if team.ParentTeamID == teamID {
+		return ErrOrgTeamCycle
+	}
+
+	parent, err := s.GetByID(ctx, team.ParentTeamID)
+	if err != nil {
+		return err
+	}
+	if parent.OrgID != team.OrgID {
+		return errors.New("the parent team must belong to the same organization")
+	}
+	if teamID == 0 {
+		return nil
+	}
+
+	ancestors, err := basestore.ScanInts(s.Query(ctx, sqlf.Sprintf(orgTeamAncestorsQueryFmtstr, team.ParentTeamID)))
+	if err != nil {
+		return err
+	}
+	for _, id := range ancestors {
+		if int64(id) == teamID {
+			return ErrOrgTeamCycle
+		}
+	}
+	return nil
+}
+
+const orgTeamAncestorsQueryFmtstr = `
+-- source: internal/database/org_teams.go:checkParent
+WITH RECURSIVE ancestors(id, parent_team_id) AS (
+	SELECT id, parent_team_id FROM org_teams WHERE id = %s
+	UNION
+	SELECT t.id, t.parent_team_id FROM org_teams t JOIN ancestors a ON t.id = a.parent_team_id
+)
+SELECT id FROM ancestors
+`
+
+// Delete deletes a team and its child teams.
+func (s *OrgTeamStore) Delete(ctx context.Context, id int64) error {
+	return s.Exec(ctx, sqlf.Sprintf(`DELETE FROM org_teams WHERE id = %s`, id))
+}
+
+// GetByID returns the team with the given ID.
+func (s *OrgTeamStore) GetByID(ctx context.Context, id int64) (*types.OrgTeam, error) {
+	return s.getOne(ctx, sqlf.Sprintf("id = %s", id), id)
+}
+
+// GetByName returns the team of the organization with the given name.
+func (s *OrgTeamStore) GetByName(ctx context.Context, orgID int32, name string) (*types.OrgTeam, error) {
+	return s.getOne(ctx, sqlf.Sprintf("org_id = %s AND name = %s", orgID, name), orgID, name)
+}
+
+func (s *OrgTeamStore) getOne(ctx context.Context, cond *sqlf.Query, args ...interface{}) (*types.OrgTeam, error) {
+	teams, err := s.list(ctx, cond)
+	if err != nil {
+		return nil, err
+	}
+	if len(teams) != 1 {
+		return nil, &ErrOrgTeamNotFound{args}
+	}
+	return teams[0], nil
+}
+
+// ListOrgTeamsOptions specifies the options for listing teams.
+type ListOrgTeamsOptions struct {
+	// OrgID, if non zero, only lists the teams of the organization.
+	OrgID int32
+	// ParentTeamID, if non zero, only lists the child teams of the team.
+	ParentTeamID int64
+	// OnlyTopLevel only lists teams without a parent team.
+	OnlyTopLevel bool
+	// OnlySynced only lists teams which are mapped to a team on a code host.
+	OnlySynced bool
+}
+
+// List lists the teams matching the options, ordered by name.
+func (s *OrgTeamStore) List(ctx context.Context, opt ListOrgTeamsOptions) ([]*types.OrgTeam, error) {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opt.OrgID != 0 {
+		conds = append(conds, sqlf.Sprintf("org_id = %s", opt.OrgID))
+	}
+	if opt.ParentTeamID != 0 {
+		conds = append(conds, sqlf.Sprintf("parent_team_id = %s", opt.ParentTeamID))
+	}
+	if opt.OnlyTopLevel {
+		conds = append(conds, sqlf.Sprintf("parent_team_id IS NULL"))
+	}
+	if opt.OnlySynced {
+		conds = append(conds, sqlf.Sprintf("external_service_id IS NOT NULL AND external_id IS NOT NULL"))
+	}
+	return s.list(ctx, sqlf.Join(conds, "AND"))
+}
+
+func (s *OrgTeamStore) list(ctx context.Context, cond *sqlf.Query) (_ []*types.OrgTeam, err error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(listOrgTeamsQueryFmtstr, cond))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var teams []*types.OrgTeam
+	for rows.Next() {
+		t, err := scanOrgTeam(rows)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, t)
+	}
+	return teams, nil
+}
+
+const orgTeamColumns = `id, org_id, parent_team_id, name, display_name, external_service_id, external_id, synced_at, created_at, updated_at`
+
+const listOrgTeamsQueryFmtstr = `
+-- source: internal/database/org_teams.go:list
+SELECT ` + orgTeamColumns + `
+FROM org_teams
+WHERE %s
+ORDER BY name ASC, id ASC
+`
+
+func scanOrgTeam(sc dbutil.Scanner) (*types.OrgTeam, error) {
+	var t types.OrgTeam
+	err := sc.Scan(
+		&t.ID,
+		&t.OrgID,
+		&dbutil.NullInt64{N: &t.ParentTeamID},
+		&t.Name,
+		&t.DisplayName,
+		&dbutil.NullInt64{N: &t.ExternalServiceID},
+		&dbutil.NullString{S: &t.ExternalID},
+		&dbutil.NullTime{Time: &t.SyncedAt},
+		&t.CreatedAt,
+		&t.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// AddMember adds the user to the team. Adding an existing member is a no-op.
+func (s *OrgTeamStore) AddMember(ctx context.Context, teamID int64, userID int32) error {
+	return s.Exec(ctx, sqlf.Sprintf(`
+-- source: internal/database/org_teams.go:AddMember
+INSERT INTO org_team_members (team_id, user_id) VALUES (%s, %s)
+ON CONFLICT DO NOTHING
+`, teamID, userID))
+}
+
+// RemoveMember removes the user from the team.
+func (s *OrgTeamStore) RemoveMember(ctx context.Context, teamID int64, userID int32) error {
+	return s.Exec(ctx, sqlf.Sprintf(`DELETE FROM org_team_members WHERE team_id = %s AND user_id = %s`, teamID, userID))
+}
+
+// SetMembers replaces the direct members of the team with userIDs and marks
+// the team as synced. It is used to sync the members of a team from the code
+// host.
+func (s *OrgTeamStore) SetMembers(ctx context.Context, teamID int64, userIDs []int32) (err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(setOrgTeamMembersQueryFmtstr, teamID, pq.Array(userIDs), teamID, pq.Array(userIDs))); err != nil {
+		return err
+	}
+
+	return tx.Exec(ctx, sqlf.Sprintf(`UPDATE org_teams SET synced_at = now() WHERE id = %s`, teamID))
+}
+
+const setOrgTeamMembersQueryFmtstr = `
+-- source: internal/database/org_teams.go:SetMembers
+WITH deleted AS (
+	DELETE FROM org_team_members WHERE team_id = %s AND NOT (user_id = ANY(%s))
+)
+INSERT INTO org_team_members (team_id, user_id)
+SELECT %s, u.id FROM users u
+WHERE u.deleted_at IS NULL AND u.id = ANY(%s)
+ON CONFLICT DO NOTHING
+`
+
+// ListMemberIDs returns the IDs of the members of the team, including the
+// members of its descendant teams.
+func (s *OrgTeamStore) ListMemberIDs(ctx context.Context, teamID int64) ([]int32, error) {
+	return basestore.ScanInt32s(s.Query(ctx, sqlf.Sprintf(listOrgTeamMemberIDsQueryFmtstr, teamID)))
+}
+
+const listOrgTeamMemberIDsQueryFmtstr = `
+-- source: internal/database/org_teams.go:ListMemberIDs
+WITH RECURSIVE descendants(id) AS (
+	SELECT id FROM org_teams WHERE id = %s
+	UNION
+	SELECT t.id FROM org_teams t JOIN descendants d ON t.parent_team_id = d.id
+)
+SELECT DISTINCT m.user_id
+FROM org_team_members m
+JOIN descendants d ON m.team_id = d.id
+JOIN users u ON m.user_id = u.id
+WHERE u.deleted_at IS NULL
+ORDER BY m.user_id
+`
+
+// IsOrgMember returns true if the user is a member of any team of the
+// organization.
+func (s *OrgTeamStore) IsOrgMember(ctx context.Context, orgID, userID int32) (bool, error) {
+	if Mocks.OrgTeams.IsOrgMember != nil {
+		return Mocks.OrgTeams.IsOrgMember(ctx, orgID, userID)
+	}
+	ok, _, err := basestore.ScanFirstBool(s.Query(ctx, sqlf.Sprintf(`
+-- source: internal/database/org_teams.go:IsOrgMember
+SELECT EXISTS (
+	SELECT FROM org_team_members m JOIN org_teams t ON m.team_id = t.id
+	WHERE t.org_id = %s AND m.user_id = %s
+)
+`, orgID, userID)))
+	return ok, err
+}
+
+// SetRepos replaces the repositories mapped to the team.
+func (s *OrgTeamStore) SetRepos(ctx context.Context, teamID int64, repoIDs []api.RepoID) (err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(`DELETE FROM org_team_repos WHERE team_id = %s`, teamID)); err != nil {
+		return err
+	}
+	if len(repoIDs) == 0 {
+		return nil
+	}
+
+	values := make([]*sqlf.Query, 0, len(repoIDs))
+	for _, id := range repoIDs {
+		values = append(values, sqlf.Sprintf("(%s, %s)", teamID, id))
+	}
+	return tx.Exec(ctx, sqlf.Sprintf(`
+-- source: internal/database/org_teams.go:SetRepos
+INSERT INTO org_team_repos (team_id, repo_id) VALUES %s
+ON CONFLICT DO NOTHING
+`, sqlf.Join(values, ",")))
+}
+
+// ListRepoIDs returns the IDs of the repositories of the team, including the
+// repositories of its ancestor teams.
+func (s *OrgTeamStore) ListRepoIDs(ctx context.Context, teamID int64) ([]api.RepoID, error) {
+	ids, err := basestore.ScanInts(s.Query(ctx, sqlf.Sprintf(listOrgTeamRepoIDsQueryFmtstr, teamID)))
+	if err != nil {
+		return nil, err
+	}
+	repoIDs := make([]api.RepoID, 0, len(ids))
+	for _, id := range ids {
+		repoIDs = append(repoIDs, api.RepoID(id))
+	}
+	return repoIDs, nil
+}
+
+const listOrgTeamRepoIDsQueryFmtstr = `
+-- source: internal/database/org_teams.go:ListRepoIDs
+WITH RECURSIVE ancestors(id, parent_team_id) AS (
+	SELECT id, parent_team_id FROM org_teams WHERE id = %s
+	UNION
+	SELECT t.id, t.parent_team_id FROM org_teams t JOIN ancestors a ON t.id = a.parent_team_id
+)
+SELECT DISTINCT r.repo_id
+FROM org_team_repos r
+JOIN ancestors a ON r.team_id = a.id
+ORDER BY r.repo_id
+`
@@ -0,0 +1,7 @@
+package database
+
+import "context"
+
+type MockOrgTeams struct {
+	IsOrgMember func(ctx context.Context, orgID, userID int32) (bool, error)
+}
@@ -0,0 +1,181 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestOrgTeams(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	db := dbtest.NewDB(t, "")
+	ctx := context.Background()
+
+	org, err := Orgs(db).Create(ctx, "org", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	otherOrg, err := Orgs(db).Create(ctx, "other", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var users []*types.User
+	for _, name := range []string{"u1", "u2", "u3"} {
+		u, err := Users(db).Create(ctx, NewUser{Username: name})
+		if err != nil {
+			t.Fatal(err)
+		}
+		users = append(users, u)
+	}
+
+	repo1 := &types.Repo{Name: "github.com/org/repo1"}
+	repo2 := &types.Repo{Name: "github.com/org/repo2"}
+	if err := Repos(db).Create(ctx, repo1, repo2); err != nil {
+		t.Fatal(err)
+	}
+
+	s := OrgTeams(db)
+	eng, err := s.Create(ctx, &types.OrgTeam{OrgID: org.ID, Name: "eng"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	frontend, err := s.Create(ctx, &types.OrgTeam{OrgID: org.ID, ParentTeamID: eng.ID, Name: "frontend"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	web, err := s.Create(ctx, &types.OrgTeam{OrgID: org.ID, ParentTeamID: frontend.ID, Name: "web"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	t.Run("duplicate name", func(t *testing.T) {
+		if _, err := s.Create(ctx, &types.OrgTeam{OrgID: org.ID, Name: "ENG"}); err == nil {
+			t.Fatal("expected error for duplicate team name")
+		}
+	})
+
+	t.Run("parent in other org", func(t *testing.T) {
+		if _, err := s.Create(ctx, &types.OrgTeam{OrgID: otherOrg.ID, ParentTeamID: eng.ID, Name: "eng"}); err == nil {
+			t.Fatal("expected error for parent team of another organization")
+		}
+	})
+
+	t.Run("cycle", func(t *testing.T) {
+		team := *eng
+		team.ParentTeamID = web.ID
+		if err := s.Update(ctx, &team); !errors.Is(err, ErrOrgTeamCycle) {
+			t.Fatalf("expected ErrOrgTeamCycle, got %v", err)
+		}
+	})
+
+	t.Run("get", func(t *testing.T) {
+		team, err := s.GetByName(ctx, org.ID, "frontend")
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(frontend, team); diff != "" {
+			t.Fatalf("unexpected team (-want +got):\n%s", diff)
+		}
+		if _, err := s.GetByName(ctx, otherOrg.ID, "frontend"); !errcode.IsNotFound(err) {
+			t.Fatalf("expected not found error, got %v", err)
+		}
+	})
+
+	t.Run("list", func(t *testing.T) {
+		teams, err := s.List(ctx, ListOrgTeamsOptions{OrgID: org.ID, OnlyTopLevel: true})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]*types.OrgTeam{eng}, teams); diff != "" {
+			t.Fatalf("unexpected teams (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("members", func(t *testing.T) {
+		if err := s.AddMember(ctx, eng.ID, users[0].ID); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.SetMembers(ctx, web.ID, []int32{users[1].ID, users[2].ID}); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.SetMembers(ctx, web.ID, []int32{users[1].ID}); err != nil {
+			t.Fatal(err)
+		}
+
+		for team, want := range map[*types.OrgTeam][]int32{
+			eng:      {users[0].ID, users[1].ID},
+			frontend: {users[1].ID},
+			web:      {users[1].ID},
+		} {
+			have, err := s.ListMemberIDs(ctx, team.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, have); diff != "" {
+				t.Errorf("unexpected members of %s (-want +got):\n%s", team.Name, diff)
+			}
+		}
+
+		for _, tc := range []struct {
+			orgID  int32
+			userID int32
+			want   bool
+		}{
+			{org.ID, users[1].ID, true},
+			{org.ID, users[2].ID, false},
+			{otherOrg.ID, users[1].ID, false},
+		} {
+			have, err := s.IsOrgMember(ctx, tc.orgID, tc.userID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if have != tc.want {
+				t.Errorf("IsOrgMember(%d, %d): want %v, got %v", tc.orgID, tc.userID, tc.want, have)
+			}
+		}
+	})
+
+	t.Run("repos", func(t *testing.T) {
+		if err := s.SetRepos(ctx, eng.ID, []api.RepoID{repo1.ID}); err != nil {
+			t.Fatal(err)
+		}
+		if err := s.SetRepos(ctx, frontend.ID, []api.RepoID{repo2.ID}); err != nil {
+			t.Fatal(err)
+		}
+
+		for team, want := range map[*types.OrgTeam][]api.RepoID{
+			eng:      {repo1.ID},
+			frontend: {repo1.ID, repo2.ID},
+			web:      {repo1.ID, repo2.ID},
+		} {
+			have, err := s.ListRepoIDs(ctx, team.ID)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(want, have); diff != "" {
+				t.Errorf("unexpected repos of %s (-want +got):\n%s", team.Name, diff)
+			}
+		}
+	})
+
+	t.Run("delete", func(t *testing.T) {
+		if err := s.Delete(ctx, frontend.ID); err != nil {
+			t.Fatal(err)
+		}
+		if _, err := s.GetByID(ctx, web.ID); !errcode.IsNotFound(err) {
+			t.Fatalf("expected child team to be deleted, got %v", err)
+		}
+	})
+}
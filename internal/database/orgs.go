@@ -249,6 +249,8 @@ func (o *OrgStore) Delete(ctx context.Context, id int32) (err error) {
 	defer func() {
 		err = tx.Done(err)
 	}()
+	// The settings of the org no longer apply to its members.
+	tx.AfterCommit(func() { invalidateSettingsCascades(ctx) })
 
 	res, err := tx.Handle().DB().ExecContext(ctx, "UPDATE orgs SET deleted_at=now() WHERE id=$1 AND deleted_at IS NULL", id)
 	if err != nil {
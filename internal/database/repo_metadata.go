@@ -0,0 +1,86 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/cockroachdb/errors"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// RepoMetadataStore stores custom key/value metadata of repositories, such
+// as team:payments or tier:1.
+type RepoMetadataStore struct {
+	*basestore.Store
+}
+
+// RepoMetadata instantiates and returns a new RepoMetadataStore.
+func RepoMetadata(db dbutil.DB) *RepoMetadataStore {
+	return &RepoMetadataStore{Store: basestore.NewWithDB(db, sql.TxOptions{})}
+}
+
+// RepoMetadataWith instantiates and returns a new RepoMetadataStore using the other store handle.
+func RepoMetadataWith(other basestore.ShareableStore) *RepoMetadataStore {
+	return &RepoMetadataStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+// RepoMetaFilter matches repositories which have the metadata Key. If Value
+// is non-nil, the metadata must also have the value.
+type RepoMetaFilter struct {
+	Key   string
+	Value *string
+}
+
+// Get returns the metadata of the repository.
+func (s *RepoMetadataStore) Get(ctx context.Context, repoID api.RepoID) (_ map[string]string, err error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(`SELECT key, value FROM repo_metadata WHERE repo_id = %s`, repoID))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	meta := make(map[string]string)
+	for rows.Next() {
+		var key, value string
+		if err := rows.Scan(&key, &value); err != nil {
+			return nil, err
+		}
+		meta[key] = value
+	}
+	return meta, nil
+}
+
+// Set sets the metadata key of the repository to value. An empty value can
+// be used for tags without a value.
+func (s *RepoMetadataStore) Set(ctx context.Context, repoID api.RepoID, key, value string) error {
+	if key == "" {
+		return errors.New("repository metadata key must not be empty")
+	}
+	return s.Exec(ctx, sqlf.Sprintf(`
+-- source: internal/database/repo_metadata.go:Set
+INSERT INTO repo_metadata (repo_id, key, value) VALUES (%s, %s, %s)
+ON CONFLICT (repo_id, key) DO UPDATE SET value = EXCLUDED.value, updated_at = now()
+`, repoID, key, value))
+}
+
+// Delete deletes the metadata key of the repository.
+func (s *RepoMetadataStore) Delete(ctx context.Context, repoID api.RepoID, key string) error {
+	return s.Exec(ctx, sqlf.Sprintf(`DELETE FROM repo_metadata WHERE repo_id = %s AND key = %s`, repoID, key))
+}
+
+// repoMetaFilterConds returns the conditions on the repo table for filters.
+func repoMetaFilterConds(filters []RepoMetaFilter) []*sqlf.Query {
+	conds := make([]*sqlf.Query, 0, len(filters))
+	for _, f := range filters {
+		if f.Value == nil {
+			conds = append(conds, sqlf.Sprintf("EXISTS (SELECT 1 FROM repo_metadata rm WHERE rm.repo_id = repo.id AND rm.key = %s)", f.Key))
+		} else {
+			conds = append(conds, sqlf.Sprintf("EXISTS (SELECT 1 FROM repo_metadata rm WHERE rm.repo_id = repo.id AND rm.key = %s AND rm.value = %s)", f.Key, *f.Value))
+		}
+	}
+	return conds
+}
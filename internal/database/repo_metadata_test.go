@@ -0,0 +1,92 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRepoMetadata(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	db := dbtest.NewDB(t, "")
+	ctx := context.Background()
+
+	payments := &types.Repo{Name: "github.com/org/payments"}
+	web := &types.Repo{Name: "github.com/org/web"}
+	if err := Repos(db).Create(ctx, payments, web); err != nil {
+		t.Fatal(err)
+	}
+
+	s := RepoMetadata(db)
+	for _, m := range []struct {
+		repo       *types.Repo
+		key, value string
+	}{
+		{payments, "team", "payments"},
+		{payments, "tier", "2"},
+		{payments, "tier", "1"},
+		{web, "team", "frontend"},
+		{web, "public", ""},
+	} {
+		if err := s.Set(ctx, m.repo.ID, m.key, m.value); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err := s.Set(ctx, web.ID, "", "x"); err == nil {
+		t.Fatal("expected error for empty key")
+	}
+
+	meta, err := s.Get(ctx, payments.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]string{"team": "payments", "tier": "1"}, meta); diff != "" {
+		t.Fatalf("unexpected metadata (-want +got):\n%s", diff)
+	}
+
+	strPtr := func(s string) *string { return &s }
+	for name, tc := range map[string]struct {
+		filters []RepoMetaFilter
+		want    []string
+	}{
+		"key":           {[]RepoMetaFilter{{Key: "team"}}, []string{"github.com/org/payments", "github.com/org/web"}},
+		"key and value": {[]RepoMetaFilter{{Key: "team", Value: strPtr("payments")}}, []string{"github.com/org/payments"}},
+		"empty value":   {[]RepoMetaFilter{{Key: "public", Value: strPtr("")}}, []string{"github.com/org/web"}},
+		"all filters":   {[]RepoMetaFilter{{Key: "team"}, {Key: "tier", Value: strPtr("1")}}, []string{"github.com/org/payments"}},
+		"no match":      {[]RepoMetaFilter{{Key: "tier", Value: strPtr("2")}}, nil},
+		"unknown key":   {[]RepoMetaFilter{{Key: "owner"}}, nil},
+	} {
+		t.Run(name, func(t *testing.T) {
+			repos, err := Repos(db).ListRepoNames(ctx, ReposListOptions{HasMeta: tc.filters, OrderBy: RepoListOrderBy{{Field: RepoListName}}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var have []string
+			for _, r := range repos {
+				have = append(have, string(r.Name))
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("unexpected repos (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	if err := s.Delete(ctx, payments.ID, "tier"); err != nil {
+		t.Fatal(err)
+	}
+	meta, err = s.Get(ctx, payments.ID)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff(map[string]string{"team": "payments"}, meta); diff != "" {
+		t.Fatalf("unexpected metadata (-want +got):\n%s", diff)
+	}
+}
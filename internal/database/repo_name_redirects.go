@@ -0,0 +1,68 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// RepoNameRedirectStore provides access to the repo_name_redirects table, which maps the
+// previous names of repositories that were renamed or moved on their code host to the
+// repositories. The redirects are recorded by a trigger whenever the syncer changes the name
+// of a repository.
+type RepoNameRedirectStore struct {
+	*basestore.Store
+}
+
+// RepoNameRedirects instantiates and returns a new RepoNameRedirectStore with prepared statements.
+func RepoNameRedirects(db dbutil.DB) *RepoNameRedirectStore {
+	return &RepoNameRedirectStore{Store: basestore.NewWithDB(db, sql.TxOptions{})}
+}
+
+// Resolve returns the ID and current name of the repository that previously had the given
+// name. It returns false if no repository was renamed from the name.
+//
+// 🚨 SECURITY: Resolve does not check that the actor can access the repository, so callers
+// must look the repository up by the returned ID or name before exposing it.
+func (s *RepoNameRedirectStore) Resolve(ctx context.Context, name api.RepoName) (id api.RepoID, current api.RepoName, ok bool, err error) {
+	err = s.QueryRow(ctx, sqlf.Sprintf(resolveRepoNameRedirectQuery, name)).Scan(&id, &current)
+	if err == sql.ErrNoRows {
+		return 0, "", false, nil
+	}
+	if err != nil {
+		return 0, "", false, err
+	}
+	return id, current, true, nil
+}
+
+const resolveRepoNameRedirectQuery = `
+-- source: internal/database/repo_name_redirects.go:Resolve
+SELECT repo.id, repo.name
+FROM repo_name_redirects
+JOIN repo ON repo.id = repo_name_redirects.repo_id
+WHERE repo_name_redirects.name = %s AND repo.deleted_at IS NULL
+`
+
+// ListByRepo returns the previous names of the given repository, most recent first.
+func (s *RepoNameRedirectStore) ListByRepo(ctx context.Context, repoID api.RepoID) ([]api.RepoName, error) {
+	names, err := basestore.ScanStrings(s.Query(ctx, sqlf.Sprintf(listRepoNameRedirectsQuery, repoID)))
+	if err != nil {
+		return nil, err
+	}
+
+	repoNames := make([]api.RepoName, 0, len(names))
+	for _, name := range names {
+		repoNames = append(repoNames, api.RepoName(name))
+	}
+	return repoNames, nil
+}
+
+const listRepoNameRedirectsQuery = `
+-- source: internal/database/repo_name_redirects.go:ListByRepo
+SELECT name FROM repo_name_redirects WHERE repo_id = %s ORDER BY created_at DESC, name
+`
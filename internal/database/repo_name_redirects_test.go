@@ -0,0 +1,75 @@
+package database
+
+import (
+	"context"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestRepoNameRedirects(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	db := dbtest.NewDB(t, "")
+	ctx := context.Background()
+	store := RepoNameRedirects(db)
+
+	rename := func(t *testing.T, id int64, name string) {
+		t.Helper()
+		if _, err := db.ExecContext(ctx, "UPDATE repo SET name = $1 WHERE id = $2", name, id); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	assertResolve := func(t *testing.T, name api.RepoName, wantID int64, wantName api.RepoName, wantOK bool) {
+		t.Helper()
+		id, current, ok, err := store.Resolve(ctx, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if int64(id) != wantID || current != wantName || ok != wantOK {
+			t.Fatalf("Resolve(%q) = (%d, %q, %v), want (%d, %q, %v)", name, id, current, ok, wantID, wantName, wantOK)
+		}
+	}
+
+	var id int64
+	if err := db.QueryRowContext(ctx, "INSERT INTO repo (name) VALUES ('github.com/foo/bar') RETURNING id").Scan(&id); err != nil {
+		t.Fatal(err)
+	}
+	assertResolve(t, "github.com/foo/bar", 0, "", false)
+
+	t.Run("renamed", func(t *testing.T) {
+		rename(t, id, "github.com/foo/baz")
+		rename(t, id, "github.com/qux/baz")
+
+		assertResolve(t, "github.com/foo/bar", id, "github.com/qux/baz", true)
+		assertResolve(t, "GITHUB.COM/FOO/BAZ", id, "github.com/qux/baz", true)
+		assertResolve(t, "github.com/qux/baz", 0, "", false)
+
+		names, err := store.ListByRepo(ctx, api.RepoID(id))
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(names) != 2 {
+			t.Fatalf("expected 2 previous names, got %v", names)
+		}
+	})
+
+	t.Run("name taken by another repository", func(t *testing.T) {
+		if _, err := db.ExecContext(ctx, "INSERT INTO repo (name) VALUES ('github.com/foo/baz')"); err != nil {
+			t.Fatal(err)
+		}
+		assertResolve(t, "github.com/foo/baz", 0, "", false)
+		assertResolve(t, "github.com/foo/bar", id, "github.com/qux/baz", true)
+	})
+
+	t.Run("soft-deleted", func(t *testing.T) {
+		if _, err := db.ExecContext(ctx, "UPDATE repo SET name = soft_deleted_repository_name(name), deleted_at = now() WHERE id = $1", id); err != nil {
+			t.Fatal(err)
+		}
+		assertResolve(t, "github.com/foo/bar", 0, "", false)
+		assertResolve(t, "github.com/qux/baz", 0, "", false)
+	})
+}
@@ -0,0 +1,93 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// RepoSearchFrequencyStore stores decaying counts of how often repositories
+// are searched. Each search counts half as much after each half-life, so
+// that the scores reflect recent search traffic.
+type RepoSearchFrequencyStore struct {
+	*basestore.Store
+}
+
+// RepoSearchFrequencies instantiates and returns a new RepoSearchFrequencyStore.
+func RepoSearchFrequencies(db dbutil.DB) *RepoSearchFrequencyStore {
+	return &RepoSearchFrequencyStore{Store: basestore.NewWithDB(db, sql.TxOptions{})}
+}
+
+// decayedScore is the score of repo_search_frequencies row f decayed to now.
+const decayedScore = "f.score * power(0.5, extract(epoch FROM now() - f.updated_at) / %s)"
+
+// Add adds the number of searches of each repository to its score, after
+// decaying its score with halfLife. Repositories that don't exist are
+// ignored.
+func (s *RepoSearchFrequencyStore) Add(ctx context.Context, searches map[api.RepoID]int, halfLife time.Duration) error {
+	if len(searches) == 0 {
+		return nil
+	}
+
+	values := make([]*sqlf.Query, 0, len(searches))
+	for id, n := range searches {
+		values = append(values, sqlf.Sprintf("(%s::integer, %s::double precision)", id, n))
+	}
+
+	return s.Exec(ctx, sqlf.Sprintf(`
+-- source: internal/database/repo_search_frequencies.go:Add
+INSERT INTO repo_search_frequencies AS f (repo_id, score, updated_at)
+SELECT v.repo_id, v.searches, now()
+FROM (VALUES %s) AS v (repo_id, searches)
+JOIN repo ON repo.id = v.repo_id
+ON CONFLICT (repo_id) DO UPDATE SET
+	score = `+decayedScore+` + EXCLUDED.score,
+	updated_at = EXCLUDED.updated_at
+`, sqlf.Join(values, ", "), halfLife.Seconds()))
+}
+
+// Scores returns the decayed scores of the repositories that were searched,
+// by repository name.
+func (s *RepoSearchFrequencyStore) Scores(ctx context.Context, halfLife time.Duration) (_ map[api.RepoName]float64, err error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(`
+-- source: internal/database/repo_search_frequencies.go:Scores
+SELECT repo.name, `+decayedScore+`
+FROM repo_search_frequencies f
+JOIN repo ON repo.id = f.repo_id
+WHERE repo.deleted_at IS NULL
+`, halfLife.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	scores := make(map[api.RepoName]float64)
+	for rows.Next() {
+		var name api.RepoName
+		var score float64
+		if err := rows.Scan(&name, &score); err != nil {
+			return nil, err
+		}
+		scores[name] = score
+	}
+	return scores, nil
+}
+
+// DeleteBelow deletes the scores which have decayed below min, and returns
+// the number of remaining scores.
+func (s *RepoSearchFrequencyStore) DeleteBelow(ctx context.Context, min float64, halfLife time.Duration) (int, error) {
+	if err := s.Exec(ctx, sqlf.Sprintf(`
+-- source: internal/database/repo_search_frequencies.go:DeleteBelow
+DELETE FROM repo_search_frequencies f WHERE `+decayedScore+` < %s
+`, halfLife.Seconds(), min)); err != nil {
+		return 0, err
+	}
+	count, _, err := basestore.ScanFirstInt(s.Query(ctx, sqlf.Sprintf(`SELECT COUNT(*) FROM repo_search_frequencies`)))
+	return count, err
+}
@@ -0,0 +1,62 @@
+package database
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRepoSearchFrequencies(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	db := dbtest.NewDB(t, "")
+	ctx := context.Background()
+
+	hot := &types.Repo{Name: "github.com/org/hot"}
+	cold := &types.Repo{Name: "github.com/org/cold"}
+	if err := Repos(db).Create(ctx, hot, cold); err != nil {
+		t.Fatal(err)
+	}
+
+	s := RepoSearchFrequencies(db)
+	halfLife := time.Hour
+	for _, searches := range []map[api.RepoID]int{
+		{hot.ID: 2, cold.ID: 1},
+		{hot.ID: 3},
+		{12345: 1}, // missing repos are ignored
+	} {
+		if err := s.Add(ctx, searches, halfLife); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	scores, err := s.Scores(ctx, halfLife)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The scores decay a little between the calls.
+	for name, want := range map[api.RepoName]float64{hot.Name: 5, cold.Name: 1} {
+		if got := scores[name]; math.Abs(got-want) > 0.01 {
+			t.Errorf("score of %s: got %v, want %v", name, got, want)
+		}
+	}
+	if len(scores) != 2 {
+		t.Errorf("got %d scores, want 2", len(scores))
+	}
+
+	count, err := s.DeleteBelow(ctx, 2, halfLife)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("got %d remaining scores, want 1", count)
+	}
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/database/query"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
@@ -175,6 +176,32 @@ func (s *RepoStore) GetByName(ctx context.Context, nameOrURI api.RepoName) (_ *t
 	return repos[0], repos[0].IsBlocked()
 }
 
+// GetByPreviousName returns the repository that was renamed or moved on its code host from
+// the given name. When no repository had the name, or it isn't visible to the current actor, a
+// RepoNotFoundErr is returned.
+func (s *RepoStore) GetByPreviousName(ctx context.Context, name api.RepoName) (_ *types.Repo, err error) {
+	if Mocks.Repos.GetByPreviousName != nil {
+		return Mocks.Repos.GetByPreviousName(ctx, name)
+	}
+	s.ensureStore()
+
+	id, _, ok, err := RepoNameRedirects(s.Handle().DB()).Resolve(ctx, name)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, &RepoNotFoundErr{Name: name}
+	}
+
+	// 🚨 SECURITY: Get only returns the repository if the current actor can access it.
+	repo, err := s.Get(ctx, id)
+	var notFound *RepoNotFoundErr
+	if errors.As(err, &notFound) {
+		return nil, &RepoNotFoundErr{Name: name}
+	}
+	return repo, err
+}
+
 // GetByIDs returns a list of repositories by given IDs. The number of results list could be less
 // than the candidate list due to no repository is associated with some IDs.
 func (s *RepoStore) GetByIDs(ctx context.Context, ids ...api.RepoID) (_ []*types.Repo, err error) {
@@ -358,6 +385,8 @@ func scanRepo(rows *sql.Rows, r *types.Repo) (err error) {
 		r.Metadata = new(bitbucketcloud.Repo)
 	case extsvc.TypeAWSCodeCommit:
 		r.Metadata = new(awscodecommit.Repository)
+	case extsvc.TypeAzureDevOps:
+		r.Metadata = new(azuredevops.Repository)
 	case extsvc.TypeGitolite:
 		r.Metadata = new(gitolite.Repo)
 	case extsvc.TypePerforce:
@@ -467,6 +496,10 @@ type ReposListOptions struct {
 	// OnlyPrivate excludes non-private repositories from the list.
 	OnlyPrivate bool
 
+	// HasMeta, if non-empty, only includes repositories which match all of the
+	// metadata filters.
+	HasMeta []RepoMetaFilter
+
 	// Index when set will only include repositories which should be indexed
 	// if true. If false it will exclude repositories which should be
 	// indexed. An example use case of this is for indexed search only
@@ -497,6 +530,12 @@ type ReposListOptions struct {
 	// last_error value in the gitserver_repos table.
 	FailedFetch bool
 
+	// CloneSkipped, if true, will filter to only repos that gitserver didn't
+	// clone because of the clone policy of their code host connection, e.g.
+	// because they are too large. Specifically, this means that they have a
+	// non-null clone_skip_reason value in the gitserver_repos table.
+	CloneSkipped bool
+
 	// IncludeBlocked, if true, will include blocked repositories in the result set. Repos can be blocked
 	// automatically or manually for different reasons, like being too big or having copyright issues.
 	IncludeBlocked bool
@@ -762,12 +801,16 @@ func (s *RepoStore) listSQL(ctx context.Context, opt ReposListOptions) (*sqlf.Qu
 	if opt.FailedFetch {
 		where = append(where, sqlf.Sprintf("gr.last_error IS NOT NULL"))
 	}
+	if opt.CloneSkipped {
+		where = append(where, sqlf.Sprintf("gr.clone_skip_reason IS NOT NULL"))
+	}
 	if opt.NoPrivate {
 		where = append(where, sqlf.Sprintf("NOT private"))
 	}
 	if opt.OnlyPrivate {
 		where = append(where, sqlf.Sprintf("private"))
 	}
+	where = append(where, repoMetaFilterConds(opt.HasMeta)...)
 
 	if len(opt.Names) > 0 {
 		where = append(where, sqlf.Sprintf("name = ANY (%s)", pq.Array(opt.Names)))
@@ -804,7 +847,7 @@ func (s *RepoStore) listSQL(ctx context.Context, opt ReposListOptions) (*sqlf.Qu
 		where = append(where, sqlf.Sprintf("dscr.search_context_id = %d", opt.SearchContextID))
 	}
 
-	if opt.NoCloned || opt.OnlyCloned || opt.FailedFetch {
+	if opt.NoCloned || opt.OnlyCloned || opt.FailedFetch || opt.CloneSkipped {
 		from = append(from, sqlf.Sprintf("LEFT JOIN gitserver_repos gr ON gr.repo_id = repo.id"))
 	}
 
@@ -1271,6 +1314,7 @@ func (s *RepoStore) Block(ctx context.Context, reason string, ids ...api.RepoID)
 		return errors.Wrap(err, "block")
 	}
 
+	InvalidateSearchRepos(ctx)
 	return nil
 }
 
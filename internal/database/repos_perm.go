@@ -2,13 +2,17 @@ package database
 
 import (
 	"context"
+	"strconv"
+	"time"
 
 	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
 	"github.com/keegancsmith/sqlf"
 
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/globals"
 	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/cache"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
 )
@@ -18,6 +22,13 @@ var errPermissionsUserMappingConflict = errors.New("The permissions user mapping
 // AuthzQueryConds returns a query clause for enforcing repository permissions.
 // It uses `repo` as the table name to filter out repository IDs and should be
 // used as an AND condition in a complete SQL query.
+//
+// Unless authz is bypassed, the repository permission overrides managed by site
+// admins are consulted after the permissions synced from code hosts: an override
+// that denies access takes precedence over any other permission, and an override
+// that grants access is sufficient on its own. Permissions synced from code
+// hosts which are older than the max staleness configured for the authz
+// providers don't grant access.
 func AuthzQueryConds(ctx context.Context, db dbutil.DB) (*sqlf.Query, error) {
 	authzAllowByDefault, authzProviders := authz.GetProviders()
 	usePermissionsUserMapping := globals.PermissionsUserMapping().Enabled
@@ -39,7 +50,7 @@ func AuthzQueryConds(ctx context.Context, db dbutil.DB) (*sqlf.Query, error) {
 	// conf.AuthEnforceForSiteAdmins is set to "true".
 	bypassAuthz := isInternalActor(ctx) || (authzAllowByDefault && len(authzProviders) == 0)
 	if !bypassAuthz && actor.FromContext(ctx).IsAuthenticated() {
-		currentUser, err := Users(db).GetByCurrentAuthUser(ctx)
+		currentUser, err := getAuthzUser(ctx, db)
 		if err != nil {
 			return nil, err
 		}
@@ -50,54 +61,139 @@ func AuthzQueryConds(ctx context.Context, db dbutil.DB) (*sqlf.Query, error) {
 	q := authzQuery(bypassAuthz,
 		usePermissionsUserMapping,
 		authenticatedUserID,
+		authz.StrictestPermissionsFreshness(authzProviders).MaxStaleness,
 		authz.Read, // Note: We currently only support read for repository permissions.
 	)
 	return q, nil
 }
 
-func authzQuery(bypassAuthz, usePermissionsUserMapping bool, authenticatedUserID int32, perms authz.Perms) *sqlf.Query {
+// AuthzUserCache caches the users looked up by AuthzQueryConds, which is called
+// for every query of repositories, keyed by user ID. Entries are deleted when the
+// site admin status of a user changes or the user is deleted, and expire after
+// authzUserCacheTTL to bound the staleness of entries written concurrently with
+// such a change. It is nil, and users are not cached, unless the frontend sets
+// it on startup.
+var AuthzUserCache cache.Cache
+
+const authzUserCacheTTL = time.Minute
+
+// authzUser is the subset of a user that is relevant to repository permissions.
+type authzUser struct {
+	ID        int32
+	SiteAdmin bool
+}
+
+func getAuthzUser(ctx context.Context, db dbutil.DB) (*authzUser, error) {
+	key := actor.FromContext(ctx).UIDString()
+	if AuthzUserCache != nil {
+		var u authzUser
+		ok, err := cache.GetJSON(ctx, AuthzUserCache, key, &u)
+		if err != nil {
+			log15.Warn("reading authz user cache", "error", err)
+		} else if ok {
+			return &u, nil
+		}
+	}
+
+	currentUser, err := Users(db).GetByCurrentAuthUser(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u := &authzUser{ID: currentUser.ID, SiteAdmin: currentUser.SiteAdmin}
+
+	if AuthzUserCache != nil {
+		if err := cache.SetJSON(ctx, AuthzUserCache, key, u, authzUserCacheTTL); err != nil {
+			log15.Warn("writing authz user cache", "error", err)
+		}
+	}
+	return u, nil
+}
+
+// invalidateAuthzUser deletes the user from AuthzUserCache. It must be called
+// after the change to the user is committed.
+func invalidateAuthzUser(ctx context.Context, id int32) {
+	if AuthzUserCache == nil {
+		return
+	}
+	if err := AuthzUserCache.Delete(ctx, strconv.Itoa(int(id))); err != nil {
+		log15.Warn("invalidating authz user cache", "id", id, "error", err)
+	}
+}
+
+func authzQuery(bypassAuthz, usePermissionsUserMapping bool, authenticatedUserID int32, maxStaleness time.Duration, perms authz.Perms) *sqlf.Query {
 	const queryFmtString = `(
     %s                            -- TRUE or FALSE to indicate whether to bypass the check
 OR  (
-	NOT %s                        -- Disregard unrestricted state when permissions user mapping is enabled
+	NOT EXISTS (                  -- Overrides managed by site admins that deny access take precedence
+		SELECT
+		FROM repo_permission_overrides
+		WHERE
+			repo_id = repo.id
+		AND user_id = %s
+		AND permission = %s
+		AND effect = 'deny'
+		AND (expires_at IS NULL OR expires_at > NOW())
+	)
 	AND (
-		NOT repo.private          -- Happy path of non-private repositories
-		OR  EXISTS (              -- Each external service defines if repositories are unrestricted
-			SELECT
-			FROM external_services AS es
-			JOIN external_service_repos AS esr ON (
-					esr.external_service_id = es.id
-				AND esr.repo_id = repo.id
-				AND es.unrestricted = TRUE
-				AND es.deleted_at IS NULL
+		(
+			NOT %s                        -- Disregard unrestricted state when permissions user mapping is enabled
+			AND (
+				NOT repo.private          -- Happy path of non-private repositories
+				OR  EXISTS (              -- Each external service defines if repositories are unrestricted
+					SELECT
+					FROM external_services AS es
+					JOIN external_service_repos AS esr ON (
+							esr.external_service_id = es.id
+						AND esr.repo_id = repo.id
+						AND es.unrestricted = TRUE
+						AND es.deleted_at IS NULL
+					)
+					LIMIT 1
+				)
 			)
-			LIMIT 1
+		)
+		OR EXISTS ( -- We assume that all repos added by the authenticated user should be shown
+		  SELECT 1
+		  FROM external_service_repos
+		  WHERE repo_id = repo.id
+		  AND user_id = %s
+		)
+		OR (                             -- Restricted repositories require checking permissions
+			SELECT object_ids_ints @> INTSET(repo.id)
+			FROM user_permissions
+			WHERE
+				user_id = %s
+			AND permission = %s
+			AND object_type = 'repos'
+			AND (%s = 0 OR updated_at >= NOW() - %s * INTERVAL '1 second') -- Permissions older than the max staleness are not enforced
+		)
+		OR EXISTS (                      -- Overrides managed by site admins that grant access
+			SELECT
+			FROM repo_permission_overrides
+			WHERE
+				repo_id = repo.id
+			AND user_id = %s
+			AND permission = %s
+			AND effect = 'grant'
+			AND (expires_at IS NULL OR expires_at > NOW())
 		)
 	)
 )
-OR EXISTS ( -- We assume that all repos added by the authenticated user should be shown
-  SELECT 1
-  FROM external_service_repos
-  WHERE repo_id = repo.id
-  AND user_id = %s
-)
-OR (                             -- Restricted repositories require checking permissions
-	SELECT object_ids_ints @> INTSET(repo.id)
-	FROM user_permissions
-	WHERE
-		user_id = %s
-	AND permission = %s
-	AND object_type = 'repos'
-)
 )
 `
 
 	return sqlf.Sprintf(queryFmtString,
 		bypassAuthz,
+		authenticatedUserID,
+		perms.String(),
 		usePermissionsUserMapping,
 		authenticatedUserID,
 		authenticatedUserID,
 		perms.String(),
+		int64(maxStaleness/time.Second),
+		int64(maxStaleness/time.Second),
+		authenticatedUserID,
+		perms.String(),
 	)
 }
 
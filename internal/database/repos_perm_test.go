@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -22,8 +23,9 @@ import (
 )
 
 type fakeProvider struct {
-	codeHost *extsvc.CodeHost
-	extAcct  *extsvc.Account
+	codeHost  *extsvc.CodeHost
+	extAcct   *extsvc.Account
+	freshness authz.PermissionsFreshness
 }
 
 func (p *fakeProvider) FetchAccount(context.Context, *types.User, []*extsvc.Account, []string) (mine *extsvc.Account, err error) {
@@ -47,6 +49,8 @@ func (p *fakeProvider) FetchRepoPerms(context.Context, *extsvc.Repository) ([]ex
 	return nil, nil
 }
 
+func (p *fakeProvider) PermissionsFreshness() authz.PermissionsFreshness { return p.freshness }
+
 // 🚨 SECURITY: Tests are necessary to ensure security.
 func TestAuthzQueryConds(t *testing.T) {
 	cmpOpts := cmp.AllowUnexported(sqlf.Query{})
@@ -76,7 +80,7 @@ func TestAuthzQueryConds(t *testing.T) {
 		if err != nil {
 			t.Fatal(err)
 		}
-		want := authzQuery(false, true, int32(0), authz.Read)
+		want := authzQuery(false, true, int32(0), 0, authz.Read)
 		if diff := cmp.Diff(want, got, cmpOpts); diff != "" {
 			t.Fatalf("Mismatch (-want +got):\n%s", diff)
 		}
@@ -89,6 +93,7 @@ func TestAuthzQueryConds(t *testing.T) {
 		name                string
 		setup               func(t *testing.T) context.Context
 		authzAllowByDefault bool
+		authzProviders      []authz.Provider
 		wantQuery           *sqlf.Query
 	}{
 		{
@@ -96,14 +101,14 @@ func TestAuthzQueryConds(t *testing.T) {
 			setup: func(t *testing.T) context.Context {
 				return actor.WithInternalActor(context.Background())
 			},
-			wantQuery: authzQuery(true, false, int32(0), authz.Read),
+			wantQuery: authzQuery(true, false, int32(0), 0, authz.Read),
 		},
 		{
 			name: "no authz provider and not allow by default",
 			setup: func(t *testing.T) context.Context {
 				return context.Background()
 			},
-			wantQuery: authzQuery(false, false, int32(0), authz.Read),
+			wantQuery: authzQuery(false, false, int32(0), 0, authz.Read),
 		},
 		{
 			name: "no authz provider but allow by default",
@@ -111,7 +116,7 @@ func TestAuthzQueryConds(t *testing.T) {
 				return context.Background()
 			},
 			authzAllowByDefault: true,
-			wantQuery:           authzQuery(true, false, int32(0), authz.Read),
+			wantQuery:           authzQuery(true, false, int32(0), 0, authz.Read),
 		},
 		{
 			name: "authenticated user is a site admin",
@@ -124,7 +129,7 @@ func TestAuthzQueryConds(t *testing.T) {
 				})
 				return actor.WithActor(context.Background(), &actor.Actor{UID: 1})
 			},
-			wantQuery: authzQuery(true, false, int32(1), authz.Read),
+			wantQuery: authzQuery(true, false, int32(1), 0, authz.Read),
 		},
 		{
 			name: "authenticated user is a site admin and AuthzEnforceForSiteAdmins is set",
@@ -139,7 +144,7 @@ func TestAuthzQueryConds(t *testing.T) {
 				})
 				return actor.WithActor(context.Background(), &actor.Actor{UID: 1})
 			},
-			wantQuery: authzQuery(false, false, int32(1), authz.Read),
+			wantQuery: authzQuery(false, false, int32(1), 0, authz.Read),
 		},
 		{
 			name: "authenticated user is not a site admin",
@@ -152,13 +157,31 @@ func TestAuthzQueryConds(t *testing.T) {
 				})
 				return actor.WithActor(context.Background(), &actor.Actor{UID: 1})
 			},
-			wantQuery: authzQuery(false, false, int32(1), authz.Read),
+			wantQuery: authzQuery(false, false, int32(1), 0, authz.Read),
+		},
+		{
+			name: "authz providers with max staleness",
+			setup: func(t *testing.T) context.Context {
+				Mocks.Users.GetByCurrentAuthUser = func(ctx context.Context) (*types.User, error) {
+					return &types.User{ID: 1}, nil
+				}
+				t.Cleanup(func() {
+					Mocks.Users = MockUsers{}
+				})
+				return actor.WithActor(context.Background(), &actor.Actor{UID: 1})
+			},
+			authzProviders: []authz.Provider{
+				&fakeProvider{freshness: authz.PermissionsFreshness{TTL: time.Hour, MaxStaleness: 72 * time.Hour}},
+				&fakeProvider{freshness: authz.PermissionsFreshness{MaxStaleness: 24 * time.Hour}},
+				&fakeProvider{},
+			},
+			wantQuery: authzQuery(false, false, int32(1), 24*time.Hour, authz.Read),
 		},
 	}
 
 	for _, test := range tests {
 		t.Run(test.name, func(t *testing.T) {
-			authz.SetProviders(test.authzAllowByDefault, nil)
+			authz.SetProviders(test.authzAllowByDefault, test.authzProviders)
 			defer authz.SetProviders(true, nil)
 
 			q, err := AuthzQueryConds(test.setup(t), db)
@@ -642,3 +665,123 @@ VALUES
 		t.Fatalf("Mismatch (-want +got):\n%s", diff)
 	}
 }
+
+// 🚨 SECURITY: Tests are necessary to ensure security.
+func TestRepos_getReposBySQL_permissionOverrides(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	db := dbtest.NewDB(t, "")
+	ctx := context.Background()
+
+	alice, err := Users(db).Create(ctx, NewUser{Username: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Ensure alice is not a site admin, as it was the first user.
+	err = Users(db).SetIsSiteAdmin(ctx, alice.ID, false)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	internalCtx := actor.WithInternalActor(ctx)
+	publicRepo := mustCreate(internalCtx, t, db,
+		&types.Repo{
+			Name: "public_repo",
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          "public_repo",
+				ServiceType: extsvc.TypeGitHub,
+				ServiceID:   "https://github.com/",
+			},
+		}, types.CloneStatusNotCloned,
+	)[0]
+	syncedRepo := mustCreate(internalCtx, t, db,
+		&types.Repo{
+			Name:    "synced_repo",
+			Private: true,
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          "synced_repo",
+				ServiceType: extsvc.TypeGitHub,
+				ServiceID:   "https://github.com/",
+			},
+		}, types.CloneStatusNotCloned,
+	)[0]
+	grantedRepo := mustCreate(internalCtx, t, db,
+		&types.Repo{
+			Name:    "granted_repo",
+			Private: true,
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          "granted_repo",
+				ServiceType: extsvc.TypeGitHub,
+				ServiceID:   "https://github.com/",
+			},
+		}, types.CloneStatusNotCloned,
+	)[0]
+	expiredRepo := mustCreate(internalCtx, t, db,
+		&types.Repo{
+			Name:    "expired_repo",
+			Private: true,
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          "expired_repo",
+				ServiceType: extsvc.TypeGitHub,
+				ServiceID:   "https://github.com/",
+			},
+		}, types.CloneStatusNotCloned,
+	)[0]
+
+	// Alice has synced permissions to "synced_repo", which are denied by an override,
+	// and is granted access to the other private repositories by overrides, one of
+	// which has expired.
+	q := sqlf.Sprintf(`
+INSERT INTO user_permissions (user_id, permission, object_type, object_ids_ints, updated_at)
+VALUES (%s, 'read', 'repos', %s, NOW())
+`, alice.ID, pq.Array([]int32{int32(syncedRepo.ID)}))
+	_, err = db.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	q = sqlf.Sprintf(`
+INSERT INTO repo_permission_overrides (repo_id, user_id, effect, expires_at)
+VALUES
+	(%s, %s, 'deny', NULL),
+	(%s, %s, 'deny', NULL),
+	(%s, %s, 'grant', NOW() + INTERVAL '1 hour'),
+	(%s, %s, 'grant', NOW() - INTERVAL '1 hour')
+`,
+		publicRepo.ID, alice.ID,
+		syncedRepo.ID, alice.ID,
+		grantedRepo.ID, alice.ID,
+		expiredRepo.ID, alice.ID,
+	)
+	_, err = db.ExecContext(ctx, q.Query(sqlf.PostgresBindVar), q.Args()...)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	authz.SetProviders(false, []authz.Provider{&fakeProvider{}})
+	defer authz.SetProviders(true, nil)
+
+	// Alice should only see "granted_repo": access to "public_repo" and "synced_repo"
+	// is denied, and the grant of "expired_repo" no longer applies.
+	aliceCtx := actor.WithActor(ctx, &actor.Actor{UID: alice.ID})
+	repos, err := Repos(db).List(aliceCtx, ReposListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantRepos := []*types.Repo{grantedRepo}
+	if diff := cmp.Diff(wantRepos, repos); diff != "" {
+		t.Fatalf("Mismatch (-want +got):\n%s", diff)
+	}
+
+	// Overrides of other users do not apply to a random user
+	repos, err = Repos(db).List(ctx, ReposListOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantRepos = []*types.Repo{publicRepo}
+	if diff := cmp.Diff(wantRepos, repos); diff != "" {
+		t.Fatalf("Mismatch (-want +got):\n%s", diff)
+	}
+}
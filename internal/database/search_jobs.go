@@ -0,0 +1,230 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// SearchJobStore provides access to the search_jobs table, which is the queue
+// of the search job worker. The results of completed search jobs are stored in
+// the upload store under SearchJobResultsKey.
+type SearchJobStore struct {
+	*basestore.Store
+}
+
+// SearchJobs instantiates and returns a new SearchJobStore.
+func SearchJobs(db dbutil.DB) *SearchJobStore {
+	return &SearchJobStore{Store: basestore.NewWithDB(db, sql.TxOptions{})}
+}
+
+// SearchJobsWith instantiates and returns a new SearchJobStore using the other store handle.
+func SearchJobsWith(other basestore.ShareableStore) *SearchJobStore {
+	return &SearchJobStore{Store: basestore.NewWithHandle(other.Handle())}
+}
+
+func (s *SearchJobStore) With(other basestore.ShareableStore) *SearchJobStore {
+	return &SearchJobStore{Store: s.Store.With(other)}
+}
+
+func (s *SearchJobStore) Transact(ctx context.Context) (*SearchJobStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &SearchJobStore{Store: txBase}, err
+}
+
+// ErrSearchJobNotFound is the error that is returned when a search job is not
+// found.
+type ErrSearchJobNotFound struct {
+	ID int64
+}
+
+func (err *ErrSearchJobNotFound) Error() string {
+	return fmt.Sprintf("search job not found: %d", err.ID)
+}
+
+func (ErrSearchJobNotFound) NotFound() bool { return true }
+
+// Search job states in addition to those of the dbworker queue.
+const (
+	// SearchJobStateCanceled is the state of a search job that was canceled
+	// before it completed.
+	SearchJobStateCanceled = "canceled"
+)
+
+// Create queues a search job for the query and returns it.
+func (s *SearchJobStore) Create(ctx context.Context, userID int32, query string) (*types.SearchJob, error) {
+	return ScanSearchJob(s.QueryRow(ctx, sqlf.Sprintf(createSearchJobQueryFmtstr, userID, query, sqlf.Join(SearchJobColumns, ", "))))
+}
+
+const createSearchJobQueryFmtstr = `
+-- source: internal/database/search_jobs.go:Create
+INSERT INTO search_jobs (user_id, query)
+VALUES (%s, %s)
+RETURNING %s
+`
+
+// GetByID returns the search job with the given ID.
+func (s *SearchJobStore) GetByID(ctx context.Context, id int64) (*types.SearchJob, error) {
+	j, err := ScanSearchJob(s.QueryRow(ctx, sqlf.Sprintf(getSearchJobQueryFmtstr, sqlf.Join(SearchJobColumns, ", "), id)))
+	if err == sql.ErrNoRows {
+		return nil, &ErrSearchJobNotFound{ID: id}
+	}
+	return j, err
+}
+
+const getSearchJobQueryFmtstr = `
+-- source: internal/database/search_jobs.go:GetByID
+SELECT %s FROM search_jobs WHERE id = %s
+`
+
+// SearchJobsListOptions contains options for listing search jobs.
+type SearchJobsListOptions struct {
+	// UserID restricts the search jobs to those created by the user, if
+	// non-zero.
+	UserID int32
+
+	*LimitOffset
+}
+
+// List returns the search jobs matching the options, most recently created first.
+func (s *SearchJobStore) List(ctx context.Context, opts SearchJobsListOptions) ([]*types.SearchJob, error) {
+	conds := []*sqlf.Query{sqlf.Sprintf("TRUE")}
+	if opts.UserID != 0 {
+		conds = append(conds, sqlf.Sprintf("user_id = %s", opts.UserID))
+	}
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(listSearchJobsQueryFmtstr,
+		sqlf.Join(SearchJobColumns, ", "),
+		sqlf.Join(conds, "AND"),
+		opts.LimitOffset.SQL(),
+	))
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+
+	var jobs []*types.SearchJob
+	for rows.Next() {
+		j, err := ScanSearchJob(rows)
+		if err != nil {
+			return nil, err
+		}
+		jobs = append(jobs, j)
+	}
+	return jobs, rows.Err()
+}
+
+const listSearchJobsQueryFmtstr = `
+-- source: internal/database/search_jobs.go:List
+SELECT %s
+FROM search_jobs
+WHERE %s
+ORDER BY created_at DESC, id DESC
+%s
+`
+
+// Cancel cancels a search job that has not finished yet. Canceling a finished
+// search job has no effect. The worker stops running a canceled search job the
+// next time it reports its progress.
+func (s *SearchJobStore) Cancel(ctx context.Context, id int64) error {
+	return s.Exec(ctx, sqlf.Sprintf(cancelSearchJobQueryFmtstr, SearchJobStateCanceled, id))
+}
+
+const cancelSearchJobQueryFmtstr = `
+-- source: internal/database/search_jobs.go:Cancel
+UPDATE search_jobs
+SET state = %s, finished_at = now(), updated_at = now()
+WHERE id = %s AND state IN ('queued', 'processing')
+`
+
+// UpdateProgress records the number of results a running search job has found
+// so far. It returns the state of the job, which is no longer "processing" if
+// the job was canceled.
+func (s *SearchJobStore) UpdateProgress(ctx context.Context, id int64, matchCount int) (string, error) {
+	state, ok, err := basestore.ScanFirstString(s.Query(ctx, sqlf.Sprintf(updateSearchJobProgressQueryFmtstr, matchCount, id)))
+	if err != nil {
+		return "", err
+	}
+	if !ok {
+		return "", &ErrSearchJobNotFound{ID: id}
+	}
+	return state, nil
+}
+
+const updateSearchJobProgressQueryFmtstr = `
+-- source: internal/database/search_jobs.go:UpdateProgress
+UPDATE search_jobs
+SET match_count = %s, updated_at = now()
+WHERE id = %s
+RETURNING state
+`
+
+// DeleteFinishedBefore deletes the search jobs which finished before the given
+// time, and returns the IDs of the deleted jobs which completed, so that their
+// results can be deleted.
+func (s *SearchJobStore) DeleteFinishedBefore(ctx context.Context, before time.Time) ([]int64, error) {
+	return basestore.ScanInt64s(s.Query(ctx, sqlf.Sprintf(deleteFinishedSearchJobsQueryFmtstr, before)))
+}
+
+const deleteFinishedSearchJobsQueryFmtstr = `
+-- source: internal/database/search_jobs.go:DeleteFinishedBefore
+WITH deleted AS (
+	DELETE FROM search_jobs
+	WHERE finished_at < %s AND state IN ('completed', 'errored', 'failed', 'canceled')
+	RETURNING id, state
+)
+SELECT id FROM deleted WHERE state = 'completed'
+`
+
+// SearchJobRetention is how long search jobs and their results are kept after
+// they finished.
+const SearchJobRetention = 7 * 24 * time.Hour
+
+// SearchJobResultsKey returns the key of the results of the search job in the
+// upload store, where they are stored as gzipped JSON lines.
+func SearchJobResultsKey(id int64) string {
+	return fmt.Sprintf("search-job-%d.jsonl.gz", id)
+}
+
+// SearchJobColumns are the columns of the search_jobs table scanned by
+// ScanSearchJob.
+var SearchJobColumns = []*sqlf.Query{
+	sqlf.Sprintf("search_jobs.id"),
+	sqlf.Sprintf("search_jobs.user_id"),
+	sqlf.Sprintf("search_jobs.query"),
+	sqlf.Sprintf("search_jobs.state"),
+	sqlf.Sprintf("search_jobs.failure_message"),
+	sqlf.Sprintf("search_jobs.match_count"),
+	sqlf.Sprintf("search_jobs.started_at"),
+	sqlf.Sprintf("search_jobs.finished_at"),
+	sqlf.Sprintf("search_jobs.created_at"),
+	sqlf.Sprintf("search_jobs.updated_at"),
+}
+
+// ScanSearchJob scans a search job from the SearchJobColumns.
+func ScanSearchJob(sc dbutil.Scanner) (*types.SearchJob, error) {
+	var j types.SearchJob
+	err := sc.Scan(
+		&j.ID,
+		&j.UserID,
+		&j.Query,
+		&j.State,
+		&dbutil.NullString{S: &j.FailureMessage},
+		&j.MatchCount,
+		&dbutil.NullTime{Time: &j.StartedAt},
+		&dbutil.NullTime{Time: &j.FinishedAt},
+		&j.CreatedAt,
+		&j.UpdatedAt,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &j, nil
+}
@@ -0,0 +1,147 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestSearchJobs(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+
+	t.Parallel()
+	db := dbtest.NewDB(t, "")
+	ctx := context.Background()
+	store := SearchJobs(db)
+
+	alice, err := Users(db).Create(ctx, NewUser{Username: "alice"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	bob, err := Users(db).Create(ctx, NewUser{Username: "bob"})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	create := func(userID int32, query string) *types.SearchJob {
+		t.Helper()
+		j, err := store.Create(ctx, userID, query)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return j
+	}
+	first := create(alice.ID, "foo patternType:literal")
+	second := create(alice.ID, "bar patternType:literal")
+	other := create(bob.ID, "baz patternType:literal")
+
+	// setState simulates the search job worker.
+	setState := func(id int64, state string) {
+		t.Helper()
+		if err := store.Exec(ctx, sqlf.Sprintf("UPDATE search_jobs SET state = %s, finished_at = now() WHERE id = %s", state, id)); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	t.Run("List", func(t *testing.T) {
+		queries := func(jobs []*types.SearchJob) []string {
+			var qs []string
+			for _, j := range jobs {
+				qs = append(qs, j.Query)
+			}
+			return qs
+		}
+		for _, tc := range []struct {
+			name string
+			opts SearchJobsListOptions
+			want []string
+		}{
+			{name: "all", want: []string{other.Query, second.Query, first.Query}},
+			{name: "user", opts: SearchJobsListOptions{UserID: alice.ID}, want: []string{second.Query, first.Query}},
+			{name: "limit", opts: SearchJobsListOptions{UserID: alice.ID, LimitOffset: &LimitOffset{Limit: 1}}, want: []string{second.Query}},
+		} {
+			t.Run(tc.name, func(t *testing.T) {
+				jobs, err := store.List(ctx, tc.opts)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if diff := cmp.Diff(tc.want, queries(jobs)); diff != "" {
+					t.Fatalf("unexpected search jobs (-want +got):\n%s", diff)
+				}
+			})
+		}
+	})
+
+	t.Run("GetByID", func(t *testing.T) {
+		j, err := store.GetByID(ctx, first.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff(first, j); diff != "" {
+			t.Fatalf("unexpected search job (-want +got):\n%s", diff)
+		}
+		if j.State != "queued" {
+			t.Fatalf("got state %q, want queued", j.State)
+		}
+
+		if _, err := store.GetByID(ctx, 12345); !errcode.IsNotFound(err) {
+			t.Fatalf("want not found error, got %v", err)
+		}
+	})
+
+	t.Run("UpdateProgress", func(t *testing.T) {
+		setState(first.ID, "processing")
+		if state, err := store.UpdateProgress(ctx, first.ID, 42); err != nil || state != "processing" {
+			t.Fatalf("got state %q and error %v, want processing", state, err)
+		}
+		setState(first.ID, "completed")
+		if j, err := store.GetByID(ctx, first.ID); err != nil || j.MatchCount != 42 {
+			t.Fatalf("got search job %+v and error %v, want match count 42", j, err)
+		}
+	})
+
+	t.Run("Cancel", func(t *testing.T) {
+		setState(other.ID, "processing")
+		if err := store.Cancel(ctx, other.ID); err != nil {
+			t.Fatal(err)
+		}
+		if state, err := store.UpdateProgress(ctx, other.ID, 1); err != nil || state != SearchJobStateCanceled {
+			t.Fatalf("got state %q and error %v, want canceled", state, err)
+		}
+
+		// Canceling a completed job has no effect.
+		if err := store.Cancel(ctx, first.ID); err != nil {
+			t.Fatal(err)
+		}
+		if j, err := store.GetByID(ctx, first.ID); err != nil || j.State != "completed" {
+			t.Fatalf("got search job %+v and error %v, want completed", j, err)
+		}
+	})
+
+	t.Run("DeleteFinishedBefore", func(t *testing.T) {
+		ids, err := store.DeleteFinishedBefore(ctx, time.Now().Add(time.Hour))
+		if err != nil {
+			t.Fatal(err)
+		}
+		// Only the completed job has results to delete, the other job was canceled.
+		if diff := cmp.Diff([]int64{first.ID}, ids); diff != "" {
+			t.Fatalf("unexpected deleted search jobs (-want +got):\n%s", diff)
+		}
+		jobs, err := store.List(ctx, SearchJobsListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(jobs) != 1 || jobs[0].ID != second.ID {
+			t.Fatalf("unexpected search jobs after deleting finished jobs: %+v", jobs)
+		}
+	})
+}
@@ -0,0 +1,26 @@
+package database
+
+import (
+	"context"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/cache"
+)
+
+// SearchReposCache caches the repositories resolved for searches, keyed by the
+// repository filters of the search and the actor. It is invalidated whenever
+// repositories are added, changed or removed by a sync and whenever repository
+// permissions change. Its entries expire after a short TTL, because some of
+// these changes are only reported before they are committed. It is nil, and
+// resolved repositories are not cached, unless the service sets it on startup.
+var SearchReposCache *cache.Versioned
+
+// InvalidateSearchRepos invalidates SearchReposCache. It should be called after
+// the change is committed, so that repositories resolved concurrently from the
+// previous data are not cached in the new generation.
+func InvalidateSearchRepos(ctx context.Context) {
+	if err := SearchReposCache.Invalidate(ctx); err != nil {
+		log15.Warn("invalidating search repositories cache", "error", err)
+	}
+}
@@ -38,6 +38,13 @@ const (
 
 	SecurityEventNameRoleChangeDenied  SecurityEventName = "RoleChangeDenied"
 	SecurityEventNameRoleChangeGranted SecurityEventName = "RoleChangeGranted"
+
+	SecurityEventNameImpersonationStarted SecurityEventName = "ImpersonationStarted"
+	SecurityEventNameImpersonationStopped SecurityEventName = "ImpersonationStopped"
+	SecurityEventNameImpersonatedRequest  SecurityEventName = "ImpersonatedRequest"
+
+	SecurityEventNameExtensionPublished       SecurityEventName = "ExtensionPublished"
+	SecurityEventNameExtensionPublishRejected SecurityEventName = "ExtensionPublishRejected"
 )
 
 // SecurityEvent contains information needed for logging a security-relevant event.
@@ -0,0 +1,85 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// ServiceInstance is a running instance of a Sourcegraph service, as reported
+// by the instance itself.
+type ServiceInstance struct {
+	Service   string
+	Instance  string
+	Version   string
+	StartedAt time.Time
+	UpdatedAt time.Time
+}
+
+// ServiceInstanceStore stores the running instances of Sourcegraph services
+// and their build version.
+type ServiceInstanceStore struct {
+	*basestore.Store
+}
+
+// ServiceInstances instantiates and returns a new ServiceInstanceStore.
+func ServiceInstances(db dbutil.DB) *ServiceInstanceStore {
+	return &ServiceInstanceStore{Store: basestore.NewWithDB(db, sql.TxOptions{})}
+}
+
+// Start records that the given instance of service started running version.
+func (s *ServiceInstanceStore) Start(ctx context.Context, service, instance, version string) error {
+	return s.Exec(ctx, sqlf.Sprintf(startServiceInstanceQuery, service, instance, version))
+}
+
+const startServiceInstanceQuery = `
+-- source: internal/database/service_instances.go:ServiceInstanceStore.Start
+INSERT INTO service_instances (service, instance, version, started_at, updated_at)
+VALUES (%s, %s, %s, now(), now())
+ON CONFLICT (service, instance) DO UPDATE
+SET version = excluded.version, started_at = excluded.started_at, updated_at = excluded.updated_at
+`
+
+// Heartbeat records that the given instance of service is still running. It
+// is a no-op if the instance never started.
+func (s *ServiceInstanceStore) Heartbeat(ctx context.Context, service, instance string) error {
+	return s.Exec(ctx, sqlf.Sprintf(heartbeatServiceInstanceQuery, service, instance))
+}
+
+const heartbeatServiceInstanceQuery = `
+-- source: internal/database/service_instances.go:ServiceInstanceStore.Heartbeat
+UPDATE service_instances SET updated_at = now() WHERE service = %s AND instance = %s
+`
+
+// ListActive returns the instances which reported that they are running
+// within the given duration, ordered by service and instance.
+func (s *ServiceInstanceStore) ListActive(ctx context.Context, within time.Duration) (_ []*ServiceInstance, err error) {
+	rows, err := s.Query(ctx, sqlf.Sprintf(listActiveServiceInstancesQuery, within.Seconds()))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var instances []*ServiceInstance
+	for rows.Next() {
+		var i ServiceInstance
+		if err := rows.Scan(&i.Service, &i.Instance, &i.Version, &i.StartedAt, &i.UpdatedAt); err != nil {
+			return nil, err
+		}
+		instances = append(instances, &i)
+	}
+	return instances, nil
+}
+
+const listActiveServiceInstancesQuery = `
+-- source: internal/database/service_instances.go:ServiceInstanceStore.ListActive
+SELECT service, instance, version, started_at, updated_at
+FROM service_instances
+WHERE updated_at > now() - (%s * interval '1 second')
+ORDER BY service, instance
+`
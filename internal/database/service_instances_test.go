@@ -0,0 +1,58 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestServiceInstances(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t, "")
+	ctx := context.Background()
+	store := ServiceInstances(db)
+
+	for _, i := range []struct{ service, instance, version string }{
+		{"gitserver", "gitserver-1", "3.31.0"},
+		{"gitserver", "gitserver-0", "3.30.0"},
+		{"frontend", "frontend-0", "3.31.0"},
+	} {
+		if err := store.Start(ctx, i.service, i.instance, i.version); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Restarting an instance replaces its version.
+	if err := store.Start(ctx, "gitserver", "gitserver-0", "3.31.0"); err != nil {
+		t.Fatal(err)
+	}
+
+	// The frontend stopped reporting that it is running.
+	if _, err := db.ExecContext(ctx, "UPDATE service_instances SET updated_at = now() - interval '1 hour' WHERE service = 'frontend'"); err != nil {
+		t.Fatal(err)
+	}
+	if err := store.Heartbeat(ctx, "gitserver", "gitserver-1"); err != nil {
+		t.Fatal(err)
+	}
+
+	instances, err := store.ListActive(ctx, 5*time.Minute)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var have []string
+	for _, i := range instances {
+		have = append(have, i.Service+"/"+i.Instance+"@"+i.Version)
+	}
+	want := []string{"gitserver/gitserver-0@3.31.0", "gitserver/gitserver-1@3.31.0"}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("unexpected instances (-want +got):\n%s", diff)
+	}
+}
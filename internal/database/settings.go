@@ -93,6 +93,8 @@ func (o *SettingStore) CreateIfUpToDate(ctx context.Context, subject api.Setting
 			return nil, err
 		}
 		latestSetting = &s
+		// Invalidate the cached cascades once the new settings are committed.
+		tx.AfterCommit(func() { invalidateSettingsCascades(ctx) })
 	}
 
 	return latestSetting, nil
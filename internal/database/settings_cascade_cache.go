@@ -0,0 +1,25 @@
+package database
+
+import (
+	"context"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/cache"
+)
+
+// SettingsCascadeCache caches the settings of the subjects of settings cascades,
+// keyed by the subject the cascade is computed for. It is invalidated whenever
+// settings or organization memberships change, which can affect the cascades of
+// many users at once. It is nil, and settings cascades are not cached, unless
+// the frontend sets it on startup.
+var SettingsCascadeCache *cache.Versioned
+
+// invalidateSettingsCascades invalidates SettingsCascadeCache. It must be called
+// after the change is committed, so that cascades computed concurrently from the
+// previous data are not cached in the new generation.
+func invalidateSettingsCascades(ctx context.Context) {
+	if err := SettingsCascadeCache.Invalidate(ctx); err != nil {
+		log15.Warn("invalidating settings cascade cache", "error", err)
+	}
+}
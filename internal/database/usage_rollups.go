@@ -0,0 +1,275 @@
+package database
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/keegancsmith/sqlf"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// ActiveUserRollup is the number of users who logged an event in a day or week.
+type ActiveUserRollup struct {
+	Period          PeriodType
+	PeriodStart     time.Time
+	ActiveUsers     int
+	RegisteredUsers int
+}
+
+// EventRollup is the number of times an event was logged in a day or week, and the number of
+// users who logged it.
+type EventRollup struct {
+	Period      PeriodType
+	PeriodStart time.Time
+	EventName   string
+	Events      int64
+	Users       int
+}
+
+// UsageRollupStore stores the daily and weekly usage rollups that the worker aggregates from
+// event_logs, so that usage can be reported without scanning all events.
+type UsageRollupStore struct {
+	*basestore.Store
+}
+
+// UsageRollups instantiates and returns a new UsageRollupStore.
+func UsageRollups(db dbutil.DB) *UsageRollupStore {
+	return &UsageRollupStore{Store: basestore.NewWithDB(db, sql.TxOptions{})}
+}
+
+func (s *UsageRollupStore) With(other basestore.ShareableStore) *UsageRollupStore {
+	return &UsageRollupStore{Store: s.Store.With(other)}
+}
+
+func (s *UsageRollupStore) Transact(ctx context.Context) (*UsageRollupStore, error) {
+	txBase, err := s.Store.Transact(ctx)
+	return &UsageRollupStore{Store: txBase}, err
+}
+
+// RollupPeriodStart returns the start of the daily or weekly period that contains t. Weeks
+// start on Sunday, as they do for CountUniqueUsersPerPeriod.
+func RollupPeriodStart(period PeriodType, t time.Time) (time.Time, error) {
+	if period != Daily && period != Weekly {
+		return time.Time{}, errors.Errorf("period must be \"daily\" or \"weekly\". Got %s", period)
+	}
+	start, _ := calcStartDate(t.UTC(), period, 1)
+	return start, nil
+}
+
+// rollupPeriodExpression returns an expression that truncates the timestamp field of
+// event_logs to the start of its period.
+func rollupPeriodExpression(period PeriodType) *sqlf.Query {
+	return sqlf.Sprintf("(%s)::date", periodByPeriodType[period])
+}
+
+// rollupEventConds are the conditions of the events logged in the given time span that count
+// towards rollups. Anonymous backend events are excluded, as they share a placeholder
+// anonymous user ID rather than belonging to a single user.
+func rollupEventConds(start, end time.Time) *sqlf.Query {
+	return sqlf.Sprintf(
+		"timestamp >= %s AND timestamp < %s AND NOT (user_id = 0 AND anonymous_user_id = %s)",
+		start, end, backendAnonymousUserID,
+	)
+}
+
+// backendAnonymousUserID is the anonymous user ID of events logged by the backend on behalf
+// of unauthenticated users.
+const backendAnonymousUserID = "backend"
+
+// Rollup aggregates the events logged in the periods of the given type that start between
+// start and end into rollups, replacing the existing rollups of those periods. The start is
+// truncated to the start of its period, so that the first period is aggregated in full.
+//
+// Rollups of periods that are no longer covered by event_logs should not be recomputed, as
+// the events of those periods may have been deleted.
+func (s *UsageRollupStore) Rollup(ctx context.Context, period PeriodType, start, end time.Time) (err error) {
+	start, err = RollupPeriodStart(period, start)
+	if err != nil {
+		return err
+	}
+	if !start.Before(end) {
+		return nil
+	}
+
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(
+		rollupActiveUsersQuery,
+		period,
+		rollupPeriodExpression(period),
+		sqlf.Sprintf(userIDQueryFragment),
+		rollupEventConds(start, end),
+	)); err != nil {
+		return errors.Wrap(err, "rolling up active users")
+	}
+
+	if err := tx.Exec(ctx, sqlf.Sprintf(
+		rollupEventsQuery,
+		period,
+		rollupPeriodExpression(period),
+		sqlf.Sprintf(userIDQueryFragment),
+		rollupEventConds(start, end),
+	)); err != nil {
+		return errors.Wrap(err, "rolling up events")
+	}
+
+	return nil
+}
+
+const rollupActiveUsersQuery = `
+-- source: internal/database/usage_rollups.go:UsageRollupStore.Rollup
+INSERT INTO usage_active_user_rollups (period, period_start, active_users, registered_users, updated_at)
+SELECT %s, period_start, COUNT(DISTINCT user_key), COUNT(DISTINCT user_id) FILTER (WHERE user_id > 0), now()
+FROM (
+	SELECT %s AS period_start, %s AS user_key, user_id
+	FROM event_logs
+	WHERE %s
+) events
+GROUP BY period_start
+ON CONFLICT (period, period_start) DO UPDATE SET
+	active_users = EXCLUDED.active_users,
+	registered_users = EXCLUDED.registered_users,
+	updated_at = EXCLUDED.updated_at
+`
+
+const rollupEventsQuery = `
+-- source: internal/database/usage_rollups.go:UsageRollupStore.Rollup
+INSERT INTO usage_event_rollups (period, period_start, event_name, events, users, updated_at)
+SELECT %s, period_start, name, COUNT(*), COUNT(DISTINCT user_key), now()
+FROM (
+	SELECT %s AS period_start, name, %s AS user_key
+	FROM event_logs
+	WHERE %s
+) events
+GROUP BY period_start, name
+ON CONFLICT (period, period_start, event_name) DO UPDATE SET
+	events = EXCLUDED.events,
+	users = EXCLUDED.users,
+	updated_at = EXCLUDED.updated_at
+`
+
+// RollupStart returns the start of the first period of the given type that Rollup should
+// aggregate: the most recent period with rollups, which may have been incomplete when it was
+// aggregated, or the period of the earliest logged event if there are no rollups yet. It
+// returns false if there is nothing to aggregate.
+func (s *UsageRollupStore) RollupStart(ctx context.Context, period PeriodType) (time.Time, bool, error) {
+	var latest sql.NullTime
+	if err := s.QueryRow(ctx, sqlf.Sprintf(`
+-- source: internal/database/usage_rollups.go:UsageRollupStore.RollupStart
+SELECT COALESCE(
+	(SELECT MAX(period_start)::timestamp AT TIME ZONE 'UTC' FROM usage_active_user_rollups WHERE period = %s),
+	(SELECT MIN(timestamp) FROM event_logs)
+)
+`, period)).Scan(&latest); err != nil || !latest.Valid {
+		return time.Time{}, false, err
+	}
+
+	start, err := RollupPeriodStart(period, latest.Time)
+	return start, err == nil, err
+}
+
+// ListActiveUsers returns the active user rollups of the given number of most recent periods
+// of the given type before now, most recent first. Periods without events are omitted.
+func (s *UsageRollupStore) ListActiveUsers(ctx context.Context, period PeriodType, now time.Time, periods int) (_ []*ActiveUserRollup, err error) {
+	startDate, err := rollupsStartDate(period, now, periods)
+	if err != nil {
+		return nil, err
+	}
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(`
+-- source: internal/database/usage_rollups.go:UsageRollupStore.ListActiveUsers
+SELECT period, period_start, active_users, registered_users
+FROM usage_active_user_rollups
+WHERE period = %s AND period_start >= %s
+ORDER BY period_start DESC
+`, period, startDate))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var rollups []*ActiveUserRollup
+	for rows.Next() {
+		var r ActiveUserRollup
+		if err := rows.Scan(&r.Period, &r.PeriodStart, &r.ActiveUsers, &r.RegisteredUsers); err != nil {
+			return nil, err
+		}
+		r.PeriodStart = r.PeriodStart.UTC()
+		rollups = append(rollups, &r)
+	}
+	return rollups, nil
+}
+
+// ListEvents returns the event rollups of the given number of most recent periods of the given
+// type before now, most recent first and then by event name. If eventNames is not empty, only
+// rollups of those events are returned.
+func (s *UsageRollupStore) ListEvents(ctx context.Context, period PeriodType, now time.Time, periods int, eventNames []string) (_ []*EventRollup, err error) {
+	startDate, err := rollupsStartDate(period, now, periods)
+	if err != nil {
+		return nil, err
+	}
+
+	conds := []*sqlf.Query{sqlf.Sprintf("period = %s", period), sqlf.Sprintf("period_start >= %s", startDate)}
+	if len(eventNames) > 0 {
+		items := make([]*sqlf.Query, 0, len(eventNames))
+		for _, name := range eventNames {
+			items = append(items, sqlf.Sprintf("%s", name))
+		}
+		conds = append(conds, sqlf.Sprintf("event_name IN (%s)", sqlf.Join(items, ",")))
+	}
+
+	rows, err := s.Query(ctx, sqlf.Sprintf(`
+-- source: internal/database/usage_rollups.go:UsageRollupStore.ListEvents
+SELECT period, period_start, event_name, events, users
+FROM usage_event_rollups
+WHERE %s
+ORDER BY period_start DESC, event_name
+`, sqlf.Join(conds, "AND")))
+	if err != nil {
+		return nil, err
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	var rollups []*EventRollup
+	for rows.Next() {
+		var r EventRollup
+		if err := rows.Scan(&r.Period, &r.PeriodStart, &r.EventName, &r.Events, &r.Users); err != nil {
+			return nil, err
+		}
+		r.PeriodStart = r.PeriodStart.UTC()
+		rollups = append(rollups, &r)
+	}
+	return rollups, nil
+}
+
+func rollupsStartDate(period PeriodType, now time.Time, periods int) (time.Time, error) {
+	if period != Daily && period != Weekly {
+		return time.Time{}, errors.Errorf("period must be \"daily\" or \"weekly\". Got %s", period)
+	}
+	if periods < 1 {
+		return time.Time{}, errors.Errorf("periods must be positive. Got %d", periods)
+	}
+	startDate, _ := calcStartDate(now.UTC(), period, periods)
+	return startDate, nil
+}
+
+// EventOccurred returns true if an event with the given name has ever been logged. Unlike
+// counting events in event_logs, this also considers rolled up events that may since have been
+// deleted from event_logs.
+func (s *UsageRollupStore) EventOccurred(ctx context.Context, name string) (bool, error) {
+	occurred, _, err := basestore.ScanFirstBool(s.Query(ctx, sqlf.Sprintf(`
+-- source: internal/database/usage_rollups.go:UsageRollupStore.EventOccurred
+SELECT
+	EXISTS (SELECT 1 FROM usage_event_rollups WHERE event_name = %s)
+	OR EXISTS (SELECT 1 FROM event_logs WHERE name = %s)
+`, name, name)))
+	return occurred, err
+}
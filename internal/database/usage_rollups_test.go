@@ -0,0 +1,132 @@
+package database
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbtest"
+)
+
+func TestRollupPeriodStart(t *testing.T) {
+	// Wednesday
+	now := time.Date(2021, 7, 14, 15, 30, 0, 0, time.UTC)
+
+	for _, tc := range []struct {
+		period PeriodType
+		want   time.Time
+	}{
+		{Daily, time.Date(2021, 7, 14, 0, 0, 0, 0, time.UTC)},
+		{Weekly, time.Date(2021, 7, 11, 0, 0, 0, 0, time.UTC)},
+	} {
+		have, err := RollupPeriodStart(tc.period, now)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !have.Equal(tc.want) {
+			t.Errorf("%s: have %s, want %s", tc.period, have, tc.want)
+		}
+	}
+
+	if _, err := RollupPeriodStart(Monthly, now); err == nil {
+		t.Error("expected error for monthly period")
+	}
+}
+
+func TestUsageRollups(t *testing.T) {
+	if testing.Short() {
+		t.Skip()
+	}
+	t.Parallel()
+	db := dbtest.NewDB(t, "")
+	ctx := context.Background()
+	store := UsageRollups(db)
+
+	// There are no events to aggregate yet.
+	if _, ok, err := store.RollupStart(ctx, Daily); err != nil {
+		t.Fatal(err)
+	} else if ok {
+		t.Fatal("expected no rollup start without events")
+	}
+
+	now := time.Now().UTC()
+	today, _ := RollupPeriodStart(Daily, now)
+	yesterday := today.AddDate(0, 0, -1)
+
+	events := []*Event{
+		{Name: "SearchResultsQueried", UserID: 1, Source: "WEB", Timestamp: yesterday.Add(time.Hour)},
+		{Name: "SearchResultsQueried", UserID: 1, Source: "WEB", Timestamp: yesterday.Add(2 * time.Hour)},
+		{Name: "SearchResultsQueried", AnonymousUserID: "anon", Source: "WEB", Timestamp: yesterday.Add(3 * time.Hour)},
+		{Name: "BatchChangeCreated", UserID: 2, AnonymousUserID: "backend", Source: "BACKEND", Timestamp: today.Add(time.Minute)},
+		// Anonymous backend events do not belong to a single user.
+		{Name: "FeatureFlagExposed", AnonymousUserID: "backend", Source: "BACKEND", Timestamp: today.Add(time.Minute)},
+	}
+	for _, e := range events {
+		if err := EventLogs(db).Insert(ctx, e); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	start, ok, err := store.RollupStart(ctx, Daily)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !ok || !start.Equal(yesterday) {
+		t.Fatalf("unexpected rollup start: have %s (%v), want %s", start, ok, yesterday)
+	}
+
+	if err := store.Rollup(ctx, Daily, start, today.AddDate(0, 0, 1)); err != nil {
+		t.Fatal(err)
+	}
+	// Rolling up the same periods again replaces the rollups.
+	if err := store.Rollup(ctx, Daily, start, today.AddDate(0, 0, 1)); err != nil {
+		t.Fatal(err)
+	}
+
+	activeUsers, err := store.ListActiveUsers(ctx, Daily, now, 7)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantActiveUsers := []*ActiveUserRollup{
+		{Period: Daily, PeriodStart: today, ActiveUsers: 1, RegisteredUsers: 1},
+		{Period: Daily, PeriodStart: yesterday, ActiveUsers: 2, RegisteredUsers: 1},
+	}
+	if diff := cmp.Diff(wantActiveUsers, activeUsers); diff != "" {
+		t.Errorf("unexpected active users (-want +got):\n%s", diff)
+	}
+
+	eventRollups, err := store.ListEvents(ctx, Daily, now, 7, []string{"SearchResultsQueried", "BatchChangeCreated"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantEvents := []*EventRollup{
+		{Period: Daily, PeriodStart: today, EventName: "BatchChangeCreated", Events: 1, Users: 1},
+		{Period: Daily, PeriodStart: yesterday, EventName: "SearchResultsQueried", Events: 3, Users: 2},
+	}
+	if diff := cmp.Diff(wantEvents, eventRollups); diff != "" {
+		t.Errorf("unexpected event rollups (-want +got):\n%s", diff)
+	}
+
+	// The next rollup starts at the most recent period with rollups.
+	if start, _, err := store.RollupStart(ctx, Daily); err != nil {
+		t.Fatal(err)
+	} else if !start.Equal(today) {
+		t.Errorf("unexpected rollup start: have %s, want %s", start, today)
+	}
+
+	// Events that have been rolled up are still known to have occurred after they are deleted.
+	if _, err := db.ExecContext(ctx, "DELETE FROM event_logs"); err != nil {
+		t.Fatal(err)
+	}
+	for name, want := range map[string]bool{"SearchResultsQueried": true, "findReferences": false} {
+		have, err := store.EventOccurred(ctx, name)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if have != want {
+			t.Errorf("EventOccurred(%q): have %v, want %v", name, have, want)
+		}
+	}
+}
@@ -197,6 +197,10 @@ func (u *UserStore) Create(ctx context.Context, info NewUser) (newUser *types.Us
 		return nil, err
 	}
 	defer func() { err = tx.Done(err) }()
+
+	if err := setAuditActor(ctx, tx.Handle().DB()); err != nil {
+		return nil, err
+	}
 	newUser, err = tx.create(ctx, info)
 	if err == nil {
 		logAccountCreatedEvent(ctx, u.Handle().DB(), newUser, "")
@@ -444,6 +448,10 @@ func (u *UserStore) Update(ctx context.Context, id int32, update UserUpdate) (er
 	}
 	defer func() { err = tx.Done(err) }()
 
+	if err := setAuditActor(ctx, tx.Handle().DB()); err != nil {
+		return err
+	}
+
 	fieldUpdates := []*sqlf.Query{
 		sqlf.Sprintf("updated_at=now()"), // always update updated_at timestamp
 	}
@@ -502,6 +510,11 @@ func (u *UserStore) Delete(ctx context.Context, id int32) (err error) {
 		return err
 	}
 	defer func() { err = tx.Done(err) }()
+	tx.AfterCommit(func() { invalidateAuthzUser(ctx, id) })
+
+	if err := setAuditActor(ctx, tx.Handle().DB()); err != nil {
+		return err
+	}
 
 	res, err := tx.ExecResult(ctx, sqlf.Sprintf("UPDATE users SET deleted_at=now() WHERE id=%s AND deleted_at IS NULL", id))
 	if err != nil {
@@ -553,6 +566,11 @@ func (u *UserStore) HardDelete(ctx context.Context, id int32) (err error) {
 		return err
 	}
 	defer func() { err = tx.Done(err) }()
+	tx.AfterCommit(func() { invalidateAuthzUser(ctx, id) })
+
+	if err := setAuditActor(ctx, tx.Handle().DB()); err != nil {
+		return err
+	}
 
 	if err := tx.Exec(ctx, sqlf.Sprintf("DELETE FROM names WHERE user_id=%s", id)); err != nil {
 		return err
@@ -651,7 +669,21 @@ func (u *UserStore) SetIsSiteAdmin(ctx context.Context, id int32, isSiteAdmin bo
 		}
 	}
 
-	err := u.Store.Exec(ctx, sqlf.Sprintf("UPDATE users SET site_admin=%s WHERE id=%s", isSiteAdmin, id))
+	if _, err := execAudited(ctx, u.Store, sqlf.Sprintf("UPDATE users SET site_admin=%s WHERE id=%s", isSiteAdmin, id)); err != nil {
+		return err
+	}
+	u.AfterCommit(func() { invalidateAuthzUser(ctx, id) })
+	return nil
+}
+
+// SetIsSiteAuditor sets the the user with given ID to be or not to be a site auditor.
+func (u *UserStore) SetIsSiteAuditor(ctx context.Context, id int32, isSiteAuditor bool) error {
+	if Mocks.Users.SetIsSiteAuditor != nil {
+		return Mocks.Users.SetIsSiteAuditor(id, isSiteAuditor)
+	}
+	u.ensureStore()
+
+	_, err := execAudited(ctx, u.Store, sqlf.Sprintf("UPDATE users SET site_auditor=%s WHERE id=%s", isSiteAuditor, id))
 	return err
 }
 
@@ -758,6 +790,10 @@ func (u *UserStore) InvalidateSessionsByID(ctx context.Context, id int32) (err e
 	}
 	defer func() { err = tx.Done(err) }()
 
+	if err := setAuditActor(ctx, tx.Handle().DB()); err != nil {
+		return err
+	}
+
 	query := sqlf.Sprintf(`
 		UPDATE users
 		SET
@@ -908,7 +944,7 @@ func (u *UserStore) getOneBySQL(ctx context.Context, q *sqlf.Query) (*types.User
 func (u *UserStore) getBySQL(ctx context.Context, query *sqlf.Query) ([]*types.User, error) {
 	u.ensureStore()
 
-	q := sqlf.Sprintf("SELECT u.id, u.username, u.display_name, u.avatar_url, u.created_at, u.updated_at, u.site_admin, u.passwd IS NOT NULL, u.tags, u.invalidated_sessions_at FROM users u %s", query)
+	q := sqlf.Sprintf("SELECT u.id, u.username, u.display_name, u.avatar_url, u.created_at, u.updated_at, u.site_admin, u.site_auditor, u.passwd IS NOT NULL, u.tags, u.invalidated_sessions_at FROM users u %s", query)
 	rows, err := u.Query(ctx, q)
 	if err != nil {
 		return nil, err
@@ -919,7 +955,7 @@ func (u *UserStore) getBySQL(ctx context.Context, query *sqlf.Query) ([]*types.U
 	for rows.Next() {
 		var u types.User
 		var displayName, avatarURL sql.NullString
-		err := rows.Scan(&u.ID, &u.Username, &displayName, &avatarURL, &u.CreatedAt, &u.UpdatedAt, &u.SiteAdmin, &u.BuiltinAuth, pq.Array(&u.Tags), &u.InvalidatedSessionsAt)
+		err := rows.Scan(&u.ID, &u.Username, &displayName, &avatarURL, &u.CreatedAt, &u.UpdatedAt, &u.SiteAdmin, &u.SiteAuditor, &u.BuiltinAuth, pq.Array(&u.Tags), &u.InvalidatedSessionsAt)
 		if err != nil {
 			return nil, err
 		}
@@ -1007,7 +1043,7 @@ func (u *UserStore) SetPassword(ctx context.Context, id int32, resetCode, newPas
 		return false, err
 	}
 	// 🚨 SECURITY: set the new password and clear the reset code and expiry so the same code can't be reused.
-	if err := u.Exec(ctx, sqlf.Sprintf("UPDATE users SET passwd_reset_code=NULL, passwd_reset_time=NULL, passwd=%s WHERE id=%s", passwd, id)); err != nil {
+	if _, err := execAudited(ctx, u.Store, sqlf.Sprintf("UPDATE users SET passwd_reset_code=NULL, passwd_reset_time=NULL, passwd=%s WHERE id=%s", passwd, id)); err != nil {
 		return false, err
 	}
 
@@ -1045,7 +1081,7 @@ func (u *UserStore) UpdatePassword(ctx context.Context, id int32, oldPassword, n
 		return err
 	}
 	// 🚨 SECURITY: Set the new password
-	if err := u.Exec(ctx, sqlf.Sprintf("UPDATE users SET passwd_reset_code=NULL, passwd_reset_time=NULL, passwd=%s WHERE id=%s", passwd, id)); err != nil {
+	if _, err := execAudited(ctx, u.Store, sqlf.Sprintf("UPDATE users SET passwd_reset_code=NULL, passwd_reset_time=NULL, passwd=%s WHERE id=%s", passwd, id)); err != nil {
 		return err
 	}
 
@@ -1068,7 +1104,7 @@ func (u *UserStore) CreatePassword(ctx context.Context, id int32, password strin
 	}
 
 	// 🚨 SECURITY: Create the password
-	res, err := u.ExecResult(ctx, sqlf.Sprintf(`
+	res, err := execAudited(ctx, u.Store, sqlf.Sprintf(`
 UPDATE users
 SET passwd=%s
 WHERE id=%s
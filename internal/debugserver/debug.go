@@ -97,6 +97,7 @@ func NewServerRoutine(ready <-chan struct{}, extra ...Endpoint) goroutine.Backgr
 				<a href="metrics">Metrics</a><br>
 				<a href="debug/requests">Requests</a><br>
 				<a href="debug/events">Events</a><br>
+				<a href="debug/loglevels">Log levels</a><br>
 			`))
 
 			for _, e := range extra {
@@ -124,6 +125,7 @@ func NewServerRoutine(ready <-chan struct{}, extra ...Endpoint) goroutine.Backgr
 		router.Handle("/debug/pprof/trace", http.HandlerFunc(pprof.Trace))
 		router.Handle("/debug/requests", http.HandlerFunc(trace.Traces))
 		router.Handle("/debug/events", http.HandlerFunc(trace.Events))
+		router.Handle("/debug/loglevels", http.HandlerFunc(logLevelsHandler))
 		router.Handle("/metrics", promhttp.Handler())
 
 		// This path acts as a wildcard and should appear after more specific entries.
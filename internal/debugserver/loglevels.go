@@ -0,0 +1,64 @@
+package debugserver
+
+import (
+	"encoding/json"
+	"net/http"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/logging"
+)
+
+// defaultLogLevelTTL is how long a log level override lasts if the request does not
+// specify a duration.
+const defaultLogLevelTTL = 10 * time.Minute
+
+type logLevelOverride struct {
+	Component string    `json:"component"`
+	Level     string    `json:"level"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// logLevelsHandler lists the log level overrides on GET. On POST, it overrides the log
+// level of the component form value with the level form value for the ttl form value
+// (a duration such as "30m"), or removes the override of the component if level is empty.
+func logLevelsHandler(w http.ResponseWriter, r *http.Request) {
+	switch r.Method {
+	case "GET":
+	case "POST":
+		component, level := r.FormValue("component"), r.FormValue("level")
+		if level == "" {
+			logging.ResetLevelOverride(component)
+			break
+		}
+
+		lvl, err := log15.LvlFromString(level)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		ttl := defaultLogLevelTTL
+		if v := r.FormValue("ttl"); v != "" {
+			if ttl, err = time.ParseDuration(v); err != nil {
+				http.Error(w, err.Error(), http.StatusBadRequest)
+				return
+			}
+		}
+		if _, err := logging.SetLevelOverride(component, lvl, ttl); err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "only GET and POST are supported", http.StatusMethodNotAllowed)
+		return
+	}
+
+	overrides := logging.LevelOverrides()
+	resp := make([]logLevelOverride, 0, len(overrides))
+	for _, o := range overrides {
+		resp = append(resp, logLevelOverride{Component: o.Component, Level: o.Level.String(), ExpiresAt: o.ExpiresAt})
+	}
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	_ = json.NewEncoder(w).Encode(resp)
+}
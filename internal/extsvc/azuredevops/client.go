@@ -0,0 +1,267 @@
+// Package azuredevops implements a client for the Azure DevOps Services REST
+// API.
+package azuredevops
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+	"github.com/opentracing-contrib/go-stdlib/nethttp"
+	"golang.org/x/time/rate"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/metrics"
+	"github.com/sourcegraph/sourcegraph/internal/ratelimit"
+	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
+)
+
+var requestCounter = metrics.NewRequestMeter("azure_devops_requests_count", "Total number of requests sent to the Azure DevOps API.")
+
+// apiVersion is the version of the REST API requested by the client.
+const apiVersion = "6.0"
+
+// These fields define the self-imposed Azure DevOps rate limit. Azure DevOps
+// throttles requests based on their cost in "TSTUs" rather than on a request
+// count, so there is no exact number to mirror here.
+//
+// See https://docs.microsoft.com/en-us/azure/devops/integrate/concepts/rate-limits
+const (
+	rateLimitRequestsPerSecond = 2 // 120/min or 7200/hr
+	RateLimitMaxBurstRequests  = 500
+)
+
+// Client accesses Azure DevOps Services via the REST API.
+type Client struct {
+	// HTTP Client used to communicate with the API
+	httpClient httpcli.Doer
+
+	// URL is the base URL of Azure DevOps, e.g. https://dev.azure.com.
+	URL *url.URL
+
+	// The username and personal access token for accessing the API.
+	Username, Token string
+
+	// RateLimit is the self-imposed rate limiter.
+	RateLimit *rate.Limiter
+}
+
+// NewClient creates a new Azure DevOps API client with the given base URL. If
+// a nil httpClient is provided, httpcli.ExternalDoer will be used. Both
+// Username and Token fields are required to be set before calling any APIs.
+func NewClient(apiURL *url.URL, httpClient httpcli.Doer) *Client {
+	if httpClient == nil {
+		httpClient = httpcli.ExternalDoer()
+	}
+
+	httpClient = requestCounter.Doer(httpClient, func(u *url.URL) string {
+		// Paths are of the form /{org}/{project}/_apis/{area}/..., the
+		// area after _apis maps to the type of API request we are making.
+		parts := strings.Split(u.Path, "/")
+		for i, p := range parts {
+			if p == "_apis" && i+1 < len(parts) {
+				return parts[i+1]
+			}
+		}
+		return ""
+	})
+
+	// Normally our registry will return a default infinite limiter when nothing has been
+	// synced from config. However, we always want to ensure there is at least some form of rate
+	// limiting for Azure DevOps.
+	defaultLimiter := rate.NewLimiter(rateLimitRequestsPerSecond, RateLimitMaxBurstRequests)
+	l := ratelimit.DefaultRegistry.GetOrSet(apiURL.String(), defaultLimiter)
+
+	return &Client{
+		httpClient: httpClient,
+		URL:        apiURL,
+		RateLimit:  l,
+	}
+}
+
+// Repos returns the Git repositories of the given organization. If project is
+// not empty, only the repositories of that project are returned.
+func (c *Client) Repos(ctx context.Context, org, project string) ([]*Repository, error) {
+	path := "/" + org
+	if project != "" {
+		path += "/" + project
+	}
+
+	var resp struct {
+		Value []*Repository `json:"value"`
+	}
+	if err := c.get(ctx, path+"/_apis/git/repositories", nil, &resp); err != nil {
+		return nil, err
+	}
+	return resp.Value, nil
+}
+
+// AuthenticatedUser returns the user the client is authenticated as. The
+// connection data endpoint is used because it works with PATs of any scope.
+func (c *Client) AuthenticatedUser(ctx context.Context, org string) (*Identity, error) {
+	var resp struct {
+		AuthenticatedUser Identity `json:"authenticatedUser"`
+	}
+	if err := c.get(ctx, "/"+org+"/_apis/connectionData", nil, &resp); err != nil {
+		return nil, err
+	}
+	return &resp.AuthenticatedUser, nil
+}
+
+func (c *Client) get(ctx context.Context, path string, qry url.Values, result interface{}) error {
+	req, err := c.newRequest("GET", path, qry, nil)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, req, result)
+}
+
+func (c *Client) newRequest(method, path string, qry url.Values, body interface{}) (*http.Request, error) {
+	if qry == nil {
+		qry = make(url.Values)
+	}
+	qry.Set("api-version", apiVersion)
+
+	var r io.Reader
+	if body != nil {
+		bs, err := json.Marshal(body)
+		if err != nil {
+			return nil, err
+		}
+		r = bytes.NewReader(bs)
+	}
+
+	u := url.URL{Path: path, RawQuery: qry.Encode()}
+	return http.NewRequest(method, u.String(), r)
+}
+
+func (c *Client) do(ctx context.Context, req *http.Request, result interface{}) error {
+	req.URL = c.URL.ResolveReference(req.URL)
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	req, ht := nethttp.TraceRequest(ot.GetTracer(ctx),
+		req.WithContext(ctx),
+		nethttp.OperationName("Azure DevOps"),
+		nethttp.ClientTrace(false))
+	defer ht.Finish()
+
+	req.SetBasicAuth(c.Username, c.Token)
+
+	startWait := time.Now()
+	if err := c.RateLimit.Wait(ctx); err != nil {
+		return err
+	}
+
+	if d := time.Since(startWait); d > 200*time.Millisecond {
+		log15.Warn("Azure DevOps self-enforced API rate limit: request delayed longer than expected due to rate limit", "delay", d)
+	}
+
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	// Azure DevOps redirects unauthenticated requests to a sign-in page
+	// rather than responding with 401, which we can only detect by the 203
+	// status code of the non-JSON response.
+	if resp.StatusCode == http.StatusNonAuthoritativeInfo {
+		resp.StatusCode = http.StatusUnauthorized
+	}
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return errors.WithStack(&httpError{
+			URL:        req.URL,
+			StatusCode: resp.StatusCode,
+			Body:       bs,
+		})
+	}
+
+	if result != nil {
+		return json.Unmarshal(bs, result)
+	}
+
+	return nil
+}
+
+// Repository is an Azure DevOps Git repository.
+type Repository struct {
+	ID            string  `json:"id"`
+	Name          string  `json:"name"`
+	URL           string  `json:"url"`
+	Project       Project `json:"project"`
+	DefaultBranch string  `json:"defaultBranch,omitempty"`
+	Size          int64   `json:"size,omitempty"` // in bytes
+	RemoteURL     string  `json:"remoteUrl"`
+	SSHURL        string  `json:"sshUrl"`
+	WebURL        string  `json:"webUrl"`
+	IsDisabled    bool    `json:"isDisabled,omitempty"`
+	IsFork        bool    `json:"isFork,omitempty"`
+}
+
+// Org returns the name of the organization of the repository, which the API
+// only includes in the repository URLs.
+func (r *Repository) Org() string {
+	u, err := url.Parse(r.WebURL)
+	if err != nil {
+		return ""
+	}
+	// The legacy {org}.visualstudio.com URLs have the organization as
+	// subdomain, dev.azure.com URLs as first path component.
+	if strings.HasSuffix(u.Hostname(), ".visualstudio.com") {
+		return strings.SplitN(u.Hostname(), ".", 2)[0]
+	}
+	return strings.SplitN(strings.TrimPrefix(u.Path, "/"), "/", 2)[0]
+}
+
+// NameWithOrg returns the "org/project/repo" name of the repository.
+func (r *Repository) NameWithOrg() string {
+	return r.Org() + "/" + r.Project.Name + "/" + r.Name
+}
+
+// Project is an Azure DevOps project.
+type Project struct {
+	ID         string `json:"id"`
+	Name       string `json:"name"`
+	State      string `json:"state,omitempty"`
+	Visibility string `json:"visibility,omitempty"`
+}
+
+// Identity is an Azure DevOps user or group.
+type Identity struct {
+	ID          string `json:"id"`
+	DisplayName string `json:"displayName"`
+	UniqueName  string `json:"uniqueName,omitempty"`
+}
+
+type httpError struct {
+	StatusCode int
+	URL        *url.URL
+	Body       []byte
+}
+
+func (e *httpError) Error() string {
+	return fmt.Sprintf("Azure DevOps API HTTP error: code=%d url=%q body=%q", e.StatusCode, e.URL, e.Body)
+}
+
+func (e *httpError) Unauthorized() bool {
+	return e.StatusCode == http.StatusUnauthorized
+}
+
+func (e *httpError) NotFound() bool {
+	return e.StatusCode == http.StatusNotFound
+}
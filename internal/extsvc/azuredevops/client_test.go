@@ -0,0 +1,155 @@
+package azuredevops
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
+)
+
+func newTestClient(t *testing.T, h http.HandlerFunc) *Client {
+	t.Helper()
+
+	srv := httptest.NewServer(h)
+	t.Cleanup(srv.Close)
+
+	u, err := url.Parse(srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cli := NewClient(u, srv.Client())
+	cli.Username = "alice"
+	cli.Token = "secret"
+	return cli
+}
+
+func TestClient_Repos(t *testing.T) {
+	cli := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		if user, pass, _ := r.BasicAuth(); user != "alice" || pass != "secret" {
+			w.WriteHeader(http.StatusNonAuthoritativeInfo)
+			return
+		}
+		if have, want := r.URL.Path, "/myorg/My Project/_apis/git/repositories"; have != want {
+			t.Errorf("wrong path: want %q, have %q", want, have)
+		}
+		if have, want := r.URL.Query().Get("api-version"), apiVersion; have != want {
+			t.Errorf("wrong api-version: want %q, have %q", want, have)
+		}
+		io.WriteString(w, `{"count": 1, "value": [{
+			"id": "5febef5a-833d-4e14-b9c0-14cb638f91e6",
+			"name": "myrepo",
+			"project": {"id": "eb6e4656-77fc-42a1-9181-4c6d8e9da5d1", "name": "My Project"},
+			"size": 1024,
+			"remoteUrl": "https://myorg@dev.azure.com/myorg/My%20Project/_git/myrepo",
+			"webUrl": "https://dev.azure.com/myorg/My%20Project/_git/myrepo"
+		}]}`)
+	})
+
+	repos, err := cli.Repos(context.Background(), "myorg", "My Project")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []*Repository{{
+		ID:        "5febef5a-833d-4e14-b9c0-14cb638f91e6",
+		Name:      "myrepo",
+		Project:   Project{ID: "eb6e4656-77fc-42a1-9181-4c6d8e9da5d1", Name: "My Project"},
+		Size:      1024,
+		RemoteURL: "https://myorg@dev.azure.com/myorg/My%20Project/_git/myrepo",
+		WebURL:    "https://dev.azure.com/myorg/My%20Project/_git/myrepo",
+	}}
+	if diff := cmp.Diff(want, repos); diff != "" {
+		t.Fatalf("repos mismatch (-want +have):\n%s", diff)
+	}
+	if have, want := repos[0].NameWithOrg(), "myorg/My Project/myrepo"; have != want {
+		t.Errorf("wrong name: want %q, have %q", want, have)
+	}
+
+	cli.Token = "wrong"
+	_, err = cli.Repos(context.Background(), "myorg", "My Project")
+	var e *httpError
+	if !errors.As(err, &e) || !e.Unauthorized() {
+		t.Fatalf("want unauthorized error, have %v", err)
+	}
+}
+
+func TestRepository_Org(t *testing.T) {
+	for webURL, want := range map[string]string{
+		"https://dev.azure.com/myorg/myproject/_git/myrepo":    "myorg",
+		"https://myorg.visualstudio.com/myproject/_git/myrepo": "myorg",
+		"https://tfs.example.com/myorg/myproject/_git/myrepo":  "myorg",
+	} {
+		if have := (&Repository{WebURL: webURL}).Org(); have != want {
+			t.Errorf("%s: want %q, have %q", webURL, want, have)
+		}
+	}
+}
+
+func TestClient_CreatePullRequest(t *testing.T) {
+	repo := &Repository{
+		ID:      "repo-id",
+		Project: Project{ID: "project-id"},
+		WebURL:  "https://dev.azure.com/myorg/myproject/_git/myrepo",
+	}
+	in := &CreatePullRequestInput{
+		SourceRefName: "refs/heads/feature",
+		TargetRefName: "refs/heads/main",
+		Title:         "Title",
+	}
+
+	t.Run("created", func(t *testing.T) {
+		cli := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method != "POST" || r.URL.Path != "/myorg/project-id/_apis/git/repositories/repo-id/pullrequests" {
+				t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+			}
+			io.WriteString(w, `{"pullRequestId": 42, "status": "active", "title": "Title"}`)
+		})
+
+		pr, err := cli.CreatePullRequest(context.Background(), repo, in)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pr.ID != 42 {
+			t.Fatalf("wrong pull request: %+v", pr)
+		}
+	})
+
+	t.Run("already exists", func(t *testing.T) {
+		cli := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+			if r.Method == "POST" {
+				w.WriteHeader(http.StatusConflict)
+				io.WriteString(w, `{"message": "TF401179: An active pull request for the source and target branch already exists."}`)
+				return
+			}
+			if have, want := r.URL.Query().Get("searchCriteria.sourceRefName"), in.SourceRefName; have != want {
+				t.Errorf("wrong source ref: want %q, have %q", want, have)
+			}
+			io.WriteString(w, `{"value": [{"pullRequestId": 7, "status": "active"}]}`)
+		})
+
+		_, err := cli.CreatePullRequest(context.Background(), repo, in)
+		var e ErrPullRequestAlreadyExists
+		if !errors.As(err, &e) || e.Existing.ID != 7 {
+			t.Fatalf("want ErrPullRequestAlreadyExists, have %v", err)
+		}
+	})
+}
+
+func TestClient_CompletePullRequest(t *testing.T) {
+	repo := &Repository{ID: "repo-id", Project: Project{ID: "project-id"}, WebURL: "https://dev.azure.com/myorg/myproject/_git/myrepo"}
+	pr := &PullRequest{ID: 1, LastMergeSourceCommit: &CommitRef{CommitID: "deadbeef"}}
+
+	cli := newTestClient(t, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusConflict)
+		io.WriteString(w, `{"message": "TF401181: The pull request cannot be completed."}`)
+	})
+
+	if _, err := cli.CompletePullRequest(context.Background(), repo, pr, true); !errors.Is(err, ErrNotMergeable) {
+		t.Fatalf("want ErrNotMergeable, have %v", err)
+	}
+}
@@ -0,0 +1,243 @@
+package azuredevops
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// Pull request statuses.
+const (
+	PullRequestStatusActive    = "active"
+	PullRequestStatusAbandoned = "abandoned"
+	PullRequestStatusCompleted = "completed"
+)
+
+// Reviewer votes. Any positive vote is an approval.
+const (
+	VoteApproved                = 10
+	VoteApprovedWithSuggestions = 5
+	VoteNone                    = 0
+	VoteWaitingForAuthor        = -5
+	VoteRejected                = -10
+)
+
+// PullRequest is an Azure Repos pull request.
+type PullRequest struct {
+	ID                    int         `json:"pullRequestId"`
+	Status                string      `json:"status"`
+	CreatedBy             Identity    `json:"createdBy"`
+	CreationDate          time.Time   `json:"creationDate"`
+	ClosedDate            time.Time   `json:"closedDate,omitempty"`
+	Title                 string      `json:"title"`
+	Description           string      `json:"description"`
+	SourceRefName         string      `json:"sourceRefName"`
+	TargetRefName         string      `json:"targetRefName"`
+	MergeStatus           string      `json:"mergeStatus,omitempty"`
+	IsDraft               bool        `json:"isDraft"`
+	Reviewers             []Reviewer  `json:"reviewers,omitempty"`
+	LastMergeSourceCommit *CommitRef  `json:"lastMergeSourceCommit,omitempty"`
+	LastMergeTargetCommit *CommitRef  `json:"lastMergeTargetCommit,omitempty"`
+	Repository            *Repository `json:"repository,omitempty"`
+}
+
+// WebURL returns the URL of the pull request in the Azure DevOps UI.
+func (pr *PullRequest) WebURL() string {
+	if pr.Repository == nil || pr.Repository.WebURL == "" {
+		return ""
+	}
+	return pr.Repository.WebURL + "/pullrequest/" + strconv.Itoa(pr.ID)
+}
+
+// UpdatedAt returns the last time the pull request was updated as far as it
+// can be determined, since the API doesn't expose it.
+func (pr *PullRequest) UpdatedAt() time.Time {
+	if !pr.ClosedDate.IsZero() {
+		return pr.ClosedDate
+	}
+	return pr.CreationDate
+}
+
+// Reviewer is a reviewer of a pull request and their vote.
+type Reviewer struct {
+	Identity
+	Vote       int  `json:"vote"`
+	IsRequired bool `json:"isRequired,omitempty"`
+}
+
+// CommitRef references a commit.
+type CommitRef struct {
+	CommitID string `json:"commitId"`
+}
+
+// ErrPullRequestNotFound is returned by LoadPullRequest when the pull request
+// doesn't exist.
+var ErrPullRequestNotFound = errors.New("pull request not found")
+
+// ErrNotMergeable is returned by CompletePullRequest when the pull request
+// can't be merged, e.g. because of conflicts or branch policies.
+var ErrNotMergeable = errors.New("pull request cannot be merged")
+
+// ErrPullRequestAlreadyExists is returned by CreatePullRequest when an active
+// pull request for the same source and target branch already exists.
+type ErrPullRequestAlreadyExists struct {
+	Existing *PullRequest
+}
+
+func (e ErrPullRequestAlreadyExists) Error() string {
+	return fmt.Sprintf("pull request already exists: %d", e.Existing.ID)
+}
+
+// CreatePullRequestInput contains the fields of a new pull request.
+type CreatePullRequestInput struct {
+	SourceRefName string `json:"sourceRefName"`
+	TargetRefName string `json:"targetRefName"`
+	Title         string `json:"title"`
+	Description   string `json:"description"`
+	IsDraft       bool   `json:"isDraft"`
+}
+
+// CreatePullRequest creates a pull request in the given repository. If an
+// active pull request for the same branches exists, an
+// ErrPullRequestAlreadyExists containing it is returned.
+func (c *Client) CreatePullRequest(ctx context.Context, repo *Repository, in *CreatePullRequestInput) (*PullRequest, error) {
+	var pr PullRequest
+	err := c.send(ctx, "POST", pullRequestsPath(repo), nil, in, &pr)
+	if err == nil {
+		return &pr, nil
+	}
+
+	var e *httpError
+	if !errors.As(err, &e) || e.StatusCode != http.StatusConflict {
+		return nil, err
+	}
+
+	existing, err := c.activePullRequest(ctx, repo, in.SourceRefName, in.TargetRefName)
+	if err != nil {
+		return nil, errors.Wrap(err, "loading existing pull request")
+	}
+	return nil, ErrPullRequestAlreadyExists{Existing: existing}
+}
+
+func (c *Client) activePullRequest(ctx context.Context, repo *Repository, source, target string) (*PullRequest, error) {
+	qry := url.Values{
+		"searchCriteria.sourceRefName": []string{source},
+		"searchCriteria.targetRefName": []string{target},
+		"searchCriteria.status":        []string{PullRequestStatusActive},
+	}
+
+	var resp struct {
+		Value []*PullRequest `json:"value"`
+	}
+	if err := c.get(ctx, pullRequestsPath(repo), qry, &resp); err != nil {
+		return nil, err
+	}
+	if len(resp.Value) == 0 {
+		return nil, ErrPullRequestNotFound
+	}
+	return resp.Value[0], nil
+}
+
+// LoadPullRequest loads the pull request with the given ID of the repository.
+func (c *Client) LoadPullRequest(ctx context.Context, repo *Repository, id int) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.get(ctx, pullRequestsPath(repo)+"/"+strconv.Itoa(id), nil, &pr); err != nil {
+		var e *httpError
+		if errors.As(err, &e) && e.NotFound() {
+			return nil, ErrPullRequestNotFound
+		}
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// UpdatePullRequestInput contains the fields of a pull request to update.
+// Empty fields are left unchanged.
+type UpdatePullRequestInput struct {
+	Title         string `json:"title,omitempty"`
+	Description   string `json:"description,omitempty"`
+	TargetRefName string `json:"targetRefName,omitempty"`
+	Status        string `json:"status,omitempty"`
+	IsDraft       *bool  `json:"isDraft,omitempty"`
+
+	LastMergeSourceCommit *CommitRef         `json:"lastMergeSourceCommit,omitempty"`
+	CompletionOptions     *CompletionOptions `json:"completionOptions,omitempty"`
+}
+
+// CompletionOptions are the options used when a pull request is completed.
+type CompletionOptions struct {
+	// MergeStrategy is one of noFastForward, squash, rebase and rebaseMerge.
+	MergeStrategy      string `json:"mergeStrategy"`
+	DeleteSourceBranch bool   `json:"deleteSourceBranch"`
+}
+
+// UpdatePullRequest updates the pull request with the given ID of the
+// repository.
+func (c *Client) UpdatePullRequest(ctx context.Context, repo *Repository, id int, in *UpdatePullRequestInput) (*PullRequest, error) {
+	var pr PullRequest
+	if err := c.send(ctx, "PATCH", pullRequestsPath(repo)+"/"+strconv.Itoa(id), nil, in, &pr); err != nil {
+		return nil, err
+	}
+	return &pr, nil
+}
+
+// CompletePullRequest merges the pull request. Completing a pull request
+// whose source branch changed since it was loaded fails, so that unreviewed
+// commits are never merged.
+func (c *Client) CompletePullRequest(ctx context.Context, repo *Repository, pr *PullRequest, squash bool) (*PullRequest, error) {
+	if pr.LastMergeSourceCommit == nil {
+		return nil, ErrNotMergeable
+	}
+
+	opts := &CompletionOptions{MergeStrategy: "noFastForward"}
+	if squash {
+		opts.MergeStrategy = "squash"
+	}
+
+	updated, err := c.UpdatePullRequest(ctx, repo, pr.ID, &UpdatePullRequestInput{
+		Status:                PullRequestStatusCompleted,
+		LastMergeSourceCommit: pr.LastMergeSourceCommit,
+		CompletionOptions:     opts,
+	})
+	if err != nil {
+		var e *httpError
+		if errors.As(err, &e) && (e.StatusCode == http.StatusConflict || e.StatusCode == http.StatusBadRequest) {
+			return nil, errors.Wrap(ErrNotMergeable, string(e.Body))
+		}
+		return nil, err
+	}
+	return updated, nil
+}
+
+// CreatePullRequestComment posts a comment in a new thread of the pull
+// request.
+func (c *Client) CreatePullRequestComment(ctx context.Context, repo *Repository, id int, text string) error {
+	thread := struct {
+		Comments []interface{} `json:"comments"`
+		Status   string        `json:"status"`
+	}{
+		Comments: []interface{}{map[string]interface{}{
+			"content":     text,
+			"commentType": "text",
+		}},
+		Status: "active",
+	}
+	return c.send(ctx, "POST", pullRequestsPath(repo)+"/"+strconv.Itoa(id)+"/threads", nil, thread, nil)
+}
+
+func (c *Client) send(ctx context.Context, method, path string, qry url.Values, body, result interface{}) error {
+	req, err := c.newRequest(method, path, qry, body)
+	if err != nil {
+		return err
+	}
+	return c.do(ctx, req, result)
+}
+
+func pullRequestsPath(repo *Repository) string {
+	return "/" + repo.Org() + "/" + repo.Project.ID + "/_apis/git/repositories/" + repo.ID + "/pullrequests"
+}
@@ -233,6 +233,7 @@ type Repo struct {
 	Description string `json:"description"`
 	Parent      *Repo  `json:"parent"`
 	IsPrivate   bool   `json:"is_private"`
+	Size        int64  `json:"size,omitempty"` // in bytes
 	Links       Links  `json:"links"`
 }
 
@@ -29,6 +29,7 @@ func TestClient_Repos(t *testing.T) {
 			UUID:      "{e1e75436-05e6-4c38-8543-9c36ec26fad1}",
 			SCM:       "git",
 			IsPrivate: true,
+			Size:      473453,
 			Links: Links{
 				Clone: CloneLinks{
 					{"https://Unknwon@bitbucket.org/sglocal/mux.git", "https"},
@@ -44,6 +45,7 @@ func TestClient_Repos(t *testing.T) {
 			UUID:      "{421b93e9-1f00-4054-8156-4d821d4a768b}",
 			SCM:       "git",
 			IsPrivate: false,
+			Size:      885899,
 			Links: Links{
 				Clone: CloneLinks{
 					{"https://Unknwon@bitbucket.org/sglocal/python-langserver.git", "https"},
@@ -89,7 +89,8 @@ func (c *CheckSuite) Key() string {
 
 // CheckRun represents the status of a checkrun
 type CheckRun struct {
-	ID string
+	ID   string
+	Name string
 	// One of COMPLETED, IN_PROGRESS, QUEUED, REQUESTED
 	Status string
 	// One of ACTION_REQUIRED, CANCELLED, FAILURE, NEUTRAL, SUCCESS, TIMED_OUT
@@ -1354,6 +1355,7 @@ fragment commitWithChecks on Commit {
       checkRuns(last: 20) {
         nodes {
           id
+          name
           status
           conclusion
         }
@@ -1644,6 +1646,48 @@ type Repository struct {
 	// Metadata retained for ranking
 	StargazerCount int `json:",omitempty"`
 	ForkCount      int `json:",omitempty"`
+
+	// DiskUsage is the size of the repository on the code host in kilobytes.
+	DiskUsage int `json:",omitempty"`
+
+	// Parent is the repository this repository was forked from. It is nil if
+	// the repository is not a fork or the parent is not accessible.
+	Parent *ParentRepository `json:",omitempty"`
+
+	// Source is the root repository of the fork network of this repository.
+	// Only the REST API populates it, the GraphQL API populates the ancestors
+	// of Parent instead. Use SourceNameWithOwner to get it.
+	Source *ParentRepository `json:",omitempty"`
+}
+
+// ParentRepository is the repository a GitHub repository was forked from.
+type ParentRepository struct {
+	NameWithOwner string // full name of repository ("owner/name")
+
+	// Parent is the repository this repository was forked from, if known. The
+	// GraphQL API populates up to maxParentRepositoryDepth ancestors.
+	Parent *ParentRepository `json:",omitempty"`
+}
+
+// maxParentRepositoryDepth is the number of ancestors of a fork the GraphQL
+// API is queried for. Forks of forks are rare, so this finds the root
+// repository of almost every fork network.
+const maxParentRepositoryDepth = 5
+
+// SourceNameWithOwner returns the full name of the root repository of the fork
+// network of r, or false if r is not a fork or its parent is not accessible.
+func (r *Repository) SourceNameWithOwner() (string, bool) {
+	if r.Source != nil {
+		return r.Source.NameWithOwner, true
+	}
+	if r.Parent == nil {
+		return "", false
+	}
+	p := r.Parent
+	for p.Parent != nil {
+		p = p.Parent
+	}
+	return p.NameWithOwner, true
 }
 
 func ownerNameCacheKey(owner, name string) string       { return "0:" + owner + "/" + name }
@@ -1715,6 +1759,13 @@ type restRepository struct {
 	Permissions restRepositoryPermissions `json:"permissions"`
 	Stars       int                       `json:"stargazers_count"`
 	Forks       int                       `json:"forks_count"`
+	Size        int                       `json:"size"`
+	Parent      *restParentRepository     `json:"parent"`
+	Source      *restParentRepository     `json:"source"`
+}
+
+type restParentRepository struct {
+	FullName string `json:"full_name"`
 }
 
 // getRepositoryFromAPI attempts to fetch a repository from the GitHub API without use of the redis cache.
@@ -1736,6 +1787,13 @@ func (c *V3Client) getRepositoryFromAPI(ctx context.Context, owner, name string)
 // convertRestRepo converts repo information returned by the rest API
 // to a standard format.
 func convertRestRepo(restRepo restRepository) *Repository {
+	var parent, source *ParentRepository
+	if restRepo.Parent != nil {
+		parent = &ParentRepository{NameWithOwner: restRepo.Parent.FullName}
+	}
+	if restRepo.Source != nil {
+		source = &ParentRepository{NameWithOwner: restRepo.Source.FullName}
+	}
 	return &Repository{
 		ID:               restRepo.ID,
 		DatabaseID:       restRepo.DatabaseID,
@@ -1750,6 +1808,9 @@ func convertRestRepo(restRepo restRepository) *Repository {
 		ViewerPermission: convertRestRepoPermissions(restRepo.Permissions),
 		StargazerCount:   restRepo.Stars,
 		ForkCount:        restRepo.Forks,
+		DiskUsage:        restRepo.Size,
+		Parent:           parent,
+		Source:           source,
 	}
 }
 
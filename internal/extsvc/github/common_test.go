@@ -75,6 +75,42 @@ func newTestClientWithAuthenticator(t *testing.T, auth auth.Authenticator, cli h
 }
 
 // TestClient_GetRepository tests the behavior of GetRepository.
+func TestRepository_SourceNameWithOwner(t *testing.T) {
+	for _, tc := range []struct {
+		name     string
+		repo     Repository
+		want     string
+		wantFork bool
+	}{
+		{
+			name: "not a fork",
+			repo: Repository{NameWithOwner: "a/b"},
+		},
+		{
+			name:     "source from the REST API",
+			repo:     Repository{Parent: &ParentRepository{NameWithOwner: "c/b"}, Source: &ParentRepository{NameWithOwner: "d/b"}},
+			want:     "d/b",
+			wantFork: true,
+		},
+		{
+			name: "ancestors from the GraphQL API",
+			repo: Repository{Parent: &ParentRepository{
+				NameWithOwner: "c/b",
+				Parent:        &ParentRepository{NameWithOwner: "d/b"},
+			}},
+			want:     "d/b",
+			wantFork: true,
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			have, fork := tc.repo.SourceNameWithOwner()
+			if have != tc.want || fork != tc.wantFork {
+				t.Errorf("have (%q, %v), want (%q, %v)", have, fork, tc.want, tc.wantFork)
+			}
+		})
+	}
+}
+
 func TestClient_GetRepository(t *testing.T) {
 	mock := mockHTTPResponseBody{
 		responseBody: `
@@ -305,6 +305,18 @@ func (c *V3Client) ListRepositoryCollaborators(ctx context.Context, owner, repo
 	return users, len(users) > 0, nil
 }
 
+// ListTeamMembers lists the GitHub users that are members of the team, including the
+// members of its child teams. The page is the page of results to return, and is 1-indexed
+// (so the first call should be for page 1).
+func (c *V3Client) ListTeamMembers(ctx context.Context, org, teamSlug string, page int) (users []*Collaborator, hasNextPage bool, _ error) {
+	path := fmt.Sprintf("/orgs/%s/teams/%s/members?page=%d&per_page=100", org, teamSlug, page)
+	err := c.requestGet(ctx, path, &users)
+	if err != nil {
+		return nil, false, err
+	}
+	return users, len(users) > 0, nil
+}
+
 // GetRepository gets a repository from GitHub by owner and repository name.
 func (c *V3Client) GetRepository(ctx context.Context, owner, name string) (*Repository, error) {
 	if GetRepositoryMock != nil {
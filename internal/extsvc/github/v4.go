@@ -522,7 +522,7 @@ func (c *V4Client) buildGetReposBatchQuery(ctx context.Context, namesWithOwners
 // Repository struct.
 func (c *V4Client) repositoryFieldsGraphQLFragment(ctx context.Context) string {
 	if c.githubDotCom {
-		return `
+		return fmt.Sprintf(`
 fragment RepositoryFields on Repository {
 	id
 	databaseId
@@ -537,8 +537,10 @@ fragment RepositoryFields on Repository {
 	viewerPermission
 	stargazerCount
 	forkCount
+	diskUsage
+	%s
 }
-	`
+	`, parentRepositoryGraphQLFields())
 	}
 	ghe300Fields := []string{}
 	version := c.determineGitHubVersion(ctx)
@@ -561,7 +563,19 @@ fragment RepositoryFields on Repository {
 	isLocked
 	isDisabled
 	forkCount
+	diskUsage
+	%s
 	%s
 }
-	`, strings.Join(ghe300Fields, "\n	"))
+	`, parentRepositoryGraphQLFields(), strings.Join(ghe300Fields, "\n	"))
+}
+
+// parentRepositoryGraphQLFields returns the GraphQL selection of the parent of
+// a repository and its ancestors, up to maxParentRepositoryDepth levels.
+func parentRepositoryGraphQLFields() string {
+	fields := "parent { nameWithOwner }"
+	for i := 1; i < maxParentRepositoryDepth; i++ {
+		fields = "parent { nameWithOwner " + fields + " }"
+	}
+	return fields
 }
@@ -156,6 +156,18 @@ func TestExtractRateLimitConfig(t *testing.T) {
 				IsDefault:   false,
 			},
 		},
+		{
+			name:        "Azure DevOps non-default",
+			config:      `{"url": "https://dev.azure.com", "rateLimit": {"enabled": true, "requestsPerHour": 3600}}`,
+			kind:        KindAzureDevOps,
+			displayName: "AzureDevOps 1",
+			want: RateLimitConfig{
+				BaseURL:     "https://dev.azure.com/",
+				DisplayName: "AzureDevOps 1",
+				Limit:       1.0,
+				IsDefault:   false,
+			},
+		},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			rlc, err := ExtractRateLimitConfig(tc.config, tc.kind, tc.displayName)
@@ -304,6 +316,12 @@ func TestUniqueCodeHostIdentifier(t *testing.T) {
 			want:   "https://bitbucket.org/",
 		},
 
+		{
+			kind:   KindAzureDevOps,
+			config: `{"url": "https://dev.azure.com"}`,
+			want:   "https://dev.azure.com/",
+		},
+
 		{
 			kind:   KindGitolite,
 			config: `{"host": "ssh://git@gitolite.example.com:2222/"}`,
@@ -0,0 +1,90 @@
+package versions
+
+import (
+	"fmt"
+
+	"github.com/Masterminds/semver"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+// minimumVersions is the compatibility matrix of the oldest code host versions
+// that are supported, keyed by external service kind. Code hosts of other kinds
+// are not checked.
+var minimumVersions = map[string]string{
+	extsvc.KindGitHub: "2.22.0",
+	extsvc.KindGitLab: "12.0.0",
+}
+
+// codeHostNames are the names of the code hosts in minimumVersions used in
+// warning messages.
+var codeHostNames = map[string]string{
+	extsvc.KindGitHub: "GitHub Enterprise",
+	extsvc.KindGitLab: "GitLab",
+}
+
+// Warning describes a code host whose version is older than the minimum
+// supported version of its kind.
+type Warning struct {
+	ExternalServiceKind string `json:"external_service_kind"`
+	CodeHost            string `json:"code_host"`
+	Version             string `json:"version"`
+	MinimumVersion      string `json:"minimum_version"`
+}
+
+// Message returns a human-readable description of the warning.
+func (w *Warning) Message() string {
+	return fmt.Sprintf(
+		"The %s instance at %s runs version %s, which is older than the minimum supported version %s. Some features may not work until it is upgraded.",
+		codeHostNames[w.ExternalServiceKind],
+		w.CodeHost,
+		w.Version,
+		w.MinimumVersion,
+	)
+}
+
+// checkVersion evaluates the version of a code host against the compatibility
+// matrix. It returns nil if the version is supported, or if it cannot be
+// compared because the kind has no minimum or the version is not a valid
+// semantic version (such as the "unknown" version reported by GitHub.com).
+func checkVersion(kind, codeHost, version string) *Warning {
+	minimum, ok := minimumVersions[kind]
+	if !ok {
+		return nil
+	}
+
+	have, err := semver.NewVersion(version)
+	if err != nil {
+		return nil
+	}
+	want := semver.MustParse(minimum)
+
+	// Ignore pre-release suffixes such as GitLab's "-ee", which would otherwise
+	// make e.g. 12.0.0-ee older than 12.0.0.
+	if compareRelease(have, want) >= 0 {
+		return nil
+	}
+
+	return &Warning{
+		ExternalServiceKind: kind,
+		CodeHost:            codeHost,
+		Version:             version,
+		MinimumVersion:      minimum,
+	}
+}
+
+func compareRelease(a, b *semver.Version) int {
+	for _, d := range [][2]int64{
+		{a.Major(), b.Major()},
+		{a.Minor(), b.Minor()},
+		{a.Patch(), b.Patch()},
+	} {
+		if d[0] < d[1] {
+			return -1
+		}
+		if d[0] > d[1] {
+			return 1
+		}
+	}
+	return 0
+}
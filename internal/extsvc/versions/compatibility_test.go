@@ -0,0 +1,34 @@
+package versions
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+)
+
+func TestCheckVersion(t *testing.T) {
+	for _, tc := range []struct {
+		kind    string
+		version string
+		want    bool
+	}{
+		{kind: extsvc.KindGitHub, version: "2.21.9", want: true},
+		{kind: extsvc.KindGitHub, version: "2.22.0", want: false},
+		{kind: extsvc.KindGitHub, version: "3.0.1", want: false},
+		{kind: extsvc.KindGitHub, version: "unknown", want: false},
+		{kind: extsvc.KindGitLab, version: "11.11.8-ee", want: true},
+		{kind: extsvc.KindGitLab, version: "12.0.0-ee", want: false},
+		{kind: extsvc.KindGitLab, version: "13.10.2", want: false},
+		{kind: extsvc.KindBitbucketServer, version: "1.0.0", want: false},
+	} {
+		t.Run(tc.kind+" "+tc.version, func(t *testing.T) {
+			w := checkVersion(tc.kind, "https://example.com/", tc.version)
+			if have := w != nil; have != tc.want {
+				t.Fatalf("wrong warning. want=%t, have=%t", tc.want, have)
+			}
+			if w != nil && w.Message() == "" {
+				t.Fatal("empty warning message")
+			}
+		})
+	}
+}
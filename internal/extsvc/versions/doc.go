@@ -2,5 +2,7 @@
 // versions of code host instances configured in the external services.
 //
 // The main consumer of this package is the updatecheck package that includes
-// the code host versions in ping data requests.
+// the code host versions in ping data requests. The versions are also checked
+// against the minimum supported versions, and the resulting warnings are shown
+// to site admins as site alerts.
 package versions
@@ -10,6 +10,7 @@ import (
 var (
 	pool        = redispool.Store
 	versionsKey = "extsvcversions"
+	warningsKey = "extsvcversionwarnings"
 )
 
 type Version struct {
@@ -46,3 +47,40 @@ func GetVersions() ([]*Version, error) {
 
 	return versions, nil
 }
+
+// storeWarnings stores the warnings separately from the versions, since the
+// versions are sent in pings and must not include the URLs of code hosts.
+func storeWarnings(warnings []*Warning) error {
+	c := pool.Get()
+	defer c.Close()
+
+	payload, err := json.Marshal(warnings)
+	if err != nil {
+		return err
+	}
+
+	return c.Send("SET", warningsKey, payload)
+}
+
+// GetWarnings returns the warnings about code hosts running unsupported
+// versions that were found by the last sync.
+func GetWarnings() ([]*Warning, error) {
+	c := pool.Get()
+	defer c.Close()
+
+	var warnings []*Warning
+
+	raw, err := redis.Bytes(c.Do("GET", warningsKey))
+	if err != nil {
+		if err == redis.ErrNil {
+			return warnings, nil
+		}
+		return warnings, err
+	}
+
+	if err := json.Unmarshal(raw, &warnings); err != nil {
+		return warnings, err
+	}
+
+	return warnings, nil
+}
@@ -45,10 +45,13 @@ func (j *syncingJob) Routines(_ context.Context) ([]goroutine.BackgroundRoutine,
 	sourcer := repos.NewSourcer(cf)
 
 	handler := goroutine.NewHandlerWithErrorMessage("sync versions of external services", func(ctx context.Context) error {
-		versions, err := loadVersions(ctx, db, sourcer)
+		versions, warnings, err := loadVersions(ctx, db, sourcer)
 		if err != nil {
 			return err
 		}
+		if err := storeWarnings(warnings); err != nil {
+			return err
+		}
 		return storeVersions(versions)
 	})
 
@@ -58,12 +61,18 @@ func (j *syncingJob) Routines(_ context.Context) ([]goroutine.BackgroundRoutine,
 	}, nil
 }
 
-func loadVersions(ctx context.Context, db dbutil.DB, sourcer repos.Sourcer) ([]*Version, error) {
-	var versions []*Version
+// loadVersions fetches the versions of the configured code hosts and
+// evaluates them against the compatibility matrix, returning a warning for
+// each code host that runs an unsupported version.
+func loadVersions(ctx context.Context, db dbutil.DB, sourcer repos.Sourcer) ([]*Version, []*Warning, error) {
+	var (
+		versions []*Version
+		warnings []*Warning
+	)
 
 	es, err := database.ExternalServices(db).List(ctx, database.ExternalServicesListOptions{})
 	if err != nil {
-		return versions, err
+		return versions, warnings, err
 	}
 
 	// Group the external services by the code host instance they point at so
@@ -72,7 +81,7 @@ func loadVersions(ctx context.Context, db dbutil.DB, sourcer repos.Sourcer) ([]*
 	for _, svc := range es {
 		ident, err := extsvc.UniqueCodeHostIdentifier(svc.Kind, svc.Config)
 		if err != nil {
-			return versions, err
+			return versions, warnings, err
 		}
 
 		if _, ok := unique[ident]; ok {
@@ -81,10 +90,10 @@ func loadVersions(ctx context.Context, db dbutil.DB, sourcer repos.Sourcer) ([]*
 		unique[ident] = svc
 	}
 
-	for _, svc := range unique {
+	for ident, svc := range unique {
 		sources, err := sourcer(svc)
 		if err != nil {
-			return versions, err
+			return versions, warnings, err
 		}
 		src := sources[0]
 
@@ -104,7 +113,11 @@ func loadVersions(ctx context.Context, db dbutil.DB, sourcer repos.Sourcer) ([]*
 			ExternalServiceKind: svc.Kind,
 			Version:             v,
 		})
+
+		if w := checkVersion(svc.Kind, ident, v); w != nil {
+			warnings = append(warnings, w)
+		}
 	}
 
-	return versions, nil
+	return versions, warnings, nil
 }
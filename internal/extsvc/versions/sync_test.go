@@ -40,7 +40,7 @@ func TestGetAndStoreVersions(t *testing.T) {
 
 		src := &fakeVersionSource{version: "1.2.3.4", err: nil, es: es}
 
-		have, err := loadVersions(context.Background(), nil, newFakeSourcer(src))
+		have, _, err := loadVersions(context.Background(), nil, newFakeSourcer(src))
 		if err != nil {
 			t.Fatal(err)
 		}
@@ -50,13 +50,33 @@ func TestGetAndStoreVersions(t *testing.T) {
 		}
 	})
 
+	t.Run("unsupported version", func(t *testing.T) {
+		mockExternalServices(t, es)
+
+		src := &fakeVersionSource{version: "2.21.3", err: nil, es: es}
+
+		_, warnings, err := loadVersions(context.Background(), nil, newFakeSourcer(src))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if len(warnings) != 6 {
+			t.Errorf("wrong number of warnings returned. want=%d, have=%d", 6, len(warnings))
+		}
+		for _, w := range warnings {
+			if w.MinimumVersion != minimumVersions[extsvc.KindGitHub] {
+				t.Errorf("wrong minimum version. want=%q, have=%q", minimumVersions[extsvc.KindGitHub], w.MinimumVersion)
+			}
+		}
+	})
+
 	t.Run("error fetching version", func(t *testing.T) {
 		mockExternalServices(t, es)
 
 		testErr := errors.Errorf("what is up")
 		src := &fakeVersionSource{version: "1.2.3.4", err: testErr, es: es}
 
-		_, err := loadVersions(context.Background(), nil, newFakeSourcer(src))
+		_, _, err := loadVersions(context.Background(), nil, newFakeSourcer(src))
 		if err != nil {
 			t.Fatal("error returned even though it should be logged and skipped")
 		}
@@ -70,7 +90,7 @@ func TestGetAndStoreVersions(t *testing.T) {
 
 		src := &fakeVersionSource{version: "1.2.3.4", err: nil, es: invalidEs}
 
-		_, err := loadVersions(context.Background(), nil, newFakeSourcer(src))
+		_, _, err := loadVersions(context.Background(), nil, newFakeSourcer(src))
 		if err == nil {
 			t.Fatal("no error, but was expected")
 		}
@@ -0,0 +1,122 @@
+package featureflag
+
+import (
+	"context"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/cookie"
+)
+
+// Exposure records that a feature flag was evaluated to a value for a user, so that analytics
+// can attribute the behavior of the user to the flag.
+type Exposure struct {
+	FlagName string
+	Value    bool
+
+	// Exactly one of UserID and AnonymousUID is set.
+	UserID       int32
+	AnonymousUID string
+}
+
+// ExposureLogger persists an exposure. It is called outside of the request that caused the
+// exposure.
+type ExposureLogger func(Exposure)
+
+type exposureContextKey struct{}
+
+// exposureRecorder collects the feature flags evaluated while handling a single request.
+type exposureRecorder struct {
+	userID       int32
+	anonymousUID string
+
+	mu    sync.Mutex
+	flags map[string]bool
+}
+
+// newExposureRecorder returns a recorder for the user of r, or nil if the request has neither
+// an authenticated actor nor an anonymous user cookie.
+func newExposureRecorder(r *http.Request) *exposureRecorder {
+	if a := actor.FromContext(r.Context()); a.IsAuthenticated() {
+		return &exposureRecorder{userID: a.UID, flags: map[string]bool{}}
+	}
+	if uid, ok := cookie.AnonymousUID(r); ok {
+		return &exposureRecorder{anonymousUID: uid, flags: map[string]bool{}}
+	}
+	return nil
+}
+
+func (r *exposureRecorder) record(flagName string, value bool) {
+	if r == nil {
+		return
+	}
+
+	r.mu.Lock()
+	r.flags[flagName] = value
+	r.mu.Unlock()
+}
+
+// exposures returns the recorded exposures that have not been seen today.
+func (r *exposureRecorder) exposures(now time.Time) []Exposure {
+	if r == nil {
+		return nil
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	exposures := make([]Exposure, 0, len(r.flags))
+	for flagName, value := range r.flags {
+		exposure := Exposure{
+			FlagName:     flagName,
+			Value:        value,
+			UserID:       r.userID,
+			AnonymousUID: r.anonymousUID,
+		}
+		if seenExposures.add(exposure, now) {
+			exposures = append(exposures, exposure)
+		}
+	}
+	return exposures
+}
+
+func recordExposure(ctx context.Context, flagName string, value bool) {
+	if recorder, ok := ctx.Value(exposureContextKey{}).(*exposureRecorder); ok {
+		recorder.record(flagName, value)
+	}
+}
+
+// maxSeenExposures bounds the memory used to deduplicate exposures. When it is reached, further
+// exposures are logged again until the next day.
+const maxSeenExposures = 100000
+
+// seenExposures deduplicates exposures across requests, so that each exposure is logged at most
+// once a day by each frontend instance.
+var seenExposures = &exposureCache{}
+
+type exposureCache struct {
+	mu   sync.Mutex
+	day  time.Time
+	seen map[Exposure]struct{}
+}
+
+// add reports whether exposure has not been seen on the day of now, and marks it as seen.
+func (c *exposureCache) add(exposure Exposure, now time.Time) bool {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if day := now.UTC().Truncate(24 * time.Hour); !day.Equal(c.day) || c.seen == nil {
+		c.day = day
+		c.seen = map[Exposure]struct{}{}
+	}
+
+	if _, ok := c.seen[exposure]; ok {
+		return false
+	}
+	if len(c.seen) < maxSeenExposures {
+		c.seen[exposure] = struct{}{}
+	}
+	return true
+}
@@ -0,0 +1,41 @@
+package featureflag
+
+import "context"
+
+// Flag is a boolean feature flag that is read by code. Declare flags as package-level variables
+// so that the name and default value of each flag is defined in one place:
+//
+//	var newSearchUI = featureflag.Flag{Name: "new-search-ui"}
+//
+//	if newSearchUI.Enabled(ctx) { ... }
+//
+// The value and targeting of a flag (rollout percentage and user and organization overrides) are
+// managed by site admins through the GraphQL API.
+type Flag struct {
+	// Name is the name of the feature flag.
+	Name string
+
+	// Default is the value of the flag when it has not been created by a site admin or when it
+	// cannot be evaluated, such as outside of an HTTP request.
+	Default bool
+}
+
+// Enabled returns the value of the flag for the user of the current request. Evaluations of flags
+// that exist are recorded as exposures.
+func (f Flag) Enabled(ctx context.Context) bool {
+	if value, ok := Evaluate(ctx, f.Name); ok {
+		return value
+	}
+	return f.Default
+}
+
+// Evaluate returns the value of the feature flag with the given name for the user of the current
+// request, and whether the flag exists. Evaluations of flags that exist are recorded as exposures
+// and logged when the request completes.
+func Evaluate(ctx context.Context, flagName string) (value bool, ok bool) {
+	value, ok = FromContext(ctx).GetBool(flagName)
+	if ok {
+		recordExposure(ctx, flagName, value)
+	}
+	return value, ok
+}
@@ -3,6 +3,7 @@ package featureflag
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/cookie"
 
@@ -18,11 +19,31 @@ type Store interface {
 }
 
 // Middleware evaluates the feature flags for the current user and adds the
-// feature flags to the current context.
-func Middleware(ffs Store, next http.Handler) http.Handler {
+// feature flags to the current context. Flags read with Evaluate or Flag.Enabled
+// while handling the request are passed to logExposure after the request
+// completes, at most once a day per user, flag and value. logExposure may be nil.
+func Middleware(ffs Store, logExposure ExposureLogger, next http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		w.Header().Add("Vary", "Cookie")
-		next.ServeHTTP(w, r.WithContext(contextWithFeatureFlags(ffs, r)))
+
+		ctx := contextWithFeatureFlags(ffs, r)
+		recorder := newExposureRecorder(r)
+		if recorder != nil {
+			ctx = context.WithValue(ctx, exposureContextKey{}, recorder)
+		}
+
+		next.ServeHTTP(w, r.WithContext(ctx))
+
+		if logExposure == nil {
+			return
+		}
+		if exposures := recorder.exposures(time.Now()); len(exposures) > 0 {
+			go func() {
+				for _, exposure := range exposures {
+					logExposure(exposure)
+				}
+			}()
+		}
 	})
 }
 
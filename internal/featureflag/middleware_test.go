@@ -0,0 +1,85 @@
+package featureflag
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+)
+
+type testStore map[string]bool
+
+func (s testStore) GetUserFlags(context.Context, int32) (map[string]bool, error) { return s, nil }
+func (s testStore) GetAnonymousUserFlags(context.Context, string) (map[string]bool, error) {
+	return s, nil
+}
+func (s testStore) GetGlobalFeatureFlags(context.Context) (map[string]bool, error) { return s, nil }
+
+func TestMiddlewareExposures(t *testing.T) {
+	seenExposures = &exposureCache{}
+	defer func() { seenExposures = &exposureCache{} }()
+
+	exposures := make(chan Exposure, 10)
+	logExposure := func(exposure Exposure) { exposures <- exposure }
+
+	var values []bool
+	handler := Middleware(testStore{"enabled-flag": true}, logExposure, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		values = append(values,
+			Flag{Name: "enabled-flag"}.Enabled(r.Context()),
+			Flag{Name: "enabled-flag"}.Enabled(r.Context()),
+			Flag{Name: "missing-flag", Default: true}.Enabled(r.Context()),
+		)
+	}))
+
+	serve := func() {
+		req := httptest.NewRequest("GET", "/", nil)
+		req = req.WithContext(actor.WithActor(req.Context(), &actor.Actor{UID: 42}))
+		handler.ServeHTTP(httptest.NewRecorder(), req)
+	}
+
+	serve()
+	select {
+	case exposure := <-exposures:
+		if diff := cmp.Diff(Exposure{FlagName: "enabled-flag", Value: true, UserID: 42}, exposure); diff != "" {
+			t.Errorf("unexpected exposure (-want +got):\n%s", diff)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for exposure")
+	}
+
+	// The same exposure is not logged again on the same day.
+	serve()
+	select {
+	case exposure := <-exposures:
+		t.Errorf("unexpected exposure %+v", exposure)
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	if diff := cmp.Diff([]bool{true, true, true, true, true, true}, values); diff != "" {
+		t.Errorf("unexpected values (-want +got):\n%s", diff)
+	}
+}
+
+func TestExposureCache(t *testing.T) {
+	cache := &exposureCache{}
+	exposure := Exposure{FlagName: "f", Value: true, AnonymousUID: "anon"}
+	now := time.Date(2021, 7, 1, 12, 0, 0, 0, time.UTC)
+
+	if !cache.add(exposure, now) {
+		t.Errorf("expected first exposure to be new")
+	}
+	if cache.add(exposure, now.Add(time.Hour)) {
+		t.Errorf("expected exposure on the same day to be seen")
+	}
+	if !cache.add(Exposure{FlagName: "f", Value: false, AnonymousUID: "anon"}, now) {
+		t.Errorf("expected exposure with a different value to be new")
+	}
+	if !cache.add(exposure, now.Add(24*time.Hour)) {
+		t.Errorf("expected exposure on the next day to be new")
+	}
+}
@@ -285,6 +285,136 @@ func (c *Cmd) sendExec(ctx context.Context) (_ io.ReadCloser, _ http.Header, err
 	}
 }
 
+// Grep searches the contents of a repository at a commit with `git grep` on
+// gitserver. onMatch is called for each matching line as it is streamed back.
+// If onMatch returns an error, the search is stopped and the error returned.
+// limitHit is true if more lines than req.Limit matched.
+func (c *Client) Grep(ctx context.Context, req *protocol.GrepRequest, onMatch func(protocol.GrepMatch) error) (limitHit bool, err error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "Client.Grep")
+	defer func() {
+		if err != nil {
+			ext.Error.Set(span, true)
+			span.SetTag("err", err.Error())
+		}
+		span.Finish()
+	}()
+	span.SetTag("repo", req.Repo)
+	span.SetTag("commit", req.Commit)
+	span.SetTag("pattern", req.Pattern)
+
+	// Check that ctx is not expired.
+	if err := ctx.Err(); err != nil {
+		deadlineExceededCounter.Inc()
+		return false, err
+	}
+
+	repoName := protocol.NormalizeRepo(req.Repo)
+	resp, err := c.httpPost(ctx, repoName, "grep", req)
+	if err != nil {
+		return false, err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var m protocol.GrepMatch
+			if err := dec.Decode(&m); err == io.EOF {
+				break
+			} else if err != nil {
+				return false, err
+			}
+			if err := onMatch(m); err != nil {
+				return false, err
+			}
+		}
+		if errorMsg := resp.Trailer.Get("X-Grep-Error"); errorMsg != "" {
+			return false, errors.New(errorMsg)
+		}
+		return resp.Trailer.Get("X-Grep-Limit-Hit") == "true", nil
+
+	case http.StatusNotFound:
+		var payload protocol.NotFoundPayload
+		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+			return false, err
+		}
+		return false, &vcs.RepoNotExistError{Repo: repoName, CloneInProgress: payload.CloneInProgress, CloneProgress: payload.CloneProgress}
+
+	case http.StatusBadRequest:
+		body, _ := io.ReadAll(resp.Body)
+		return false, badRequestError{errors.New(strings.TrimSpace(string(body)))}
+
+	default:
+		return false, errors.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+}
+
+// Blame blames a file at a commit with `git blame` on gitserver. onHunk is
+// called for each hunk as soon as gitserver attributed it to a commit, which
+// is not in the order of the lines of the file. If onHunk returns an error,
+// the blame is stopped and the error returned.
+func (c *Client) Blame(ctx context.Context, req *protocol.BlameRequest, onHunk func(protocol.BlameHunk) error) (err error) {
+	span, ctx := ot.StartSpanFromContext(ctx, "Client.Blame")
+	defer func() {
+		if err != nil {
+			ext.Error.Set(span, true)
+			span.SetTag("err", err.Error())
+		}
+		span.Finish()
+	}()
+	span.SetTag("repo", req.Repo)
+	span.SetTag("commit", req.Commit)
+	span.SetTag("path", req.Path)
+
+	// Check that ctx is not expired.
+	if err := ctx.Err(); err != nil {
+		deadlineExceededCounter.Inc()
+		return err
+	}
+
+	repoName := protocol.NormalizeRepo(req.Repo)
+	resp, err := c.httpPost(ctx, repoName, "blame", req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	switch resp.StatusCode {
+	case http.StatusOK:
+		dec := json.NewDecoder(resp.Body)
+		for {
+			var h protocol.BlameHunk
+			if err := dec.Decode(&h); err == io.EOF {
+				break
+			} else if err != nil {
+				return err
+			}
+			if err := onHunk(h); err != nil {
+				return err
+			}
+		}
+		if errorMsg := resp.Trailer.Get("X-Blame-Error"); errorMsg != "" {
+			return errors.New(errorMsg)
+		}
+		return nil
+
+	case http.StatusNotFound:
+		var payload protocol.NotFoundPayload
+		if err := json.NewDecoder(resp.Body).Decode(&payload); err != nil {
+			return err
+		}
+		return &vcs.RepoNotExistError{Repo: repoName, CloneInProgress: payload.CloneInProgress, CloneProgress: payload.CloneProgress}
+
+	case http.StatusBadRequest:
+		body, _ := io.ReadAll(resp.Body)
+		return badRequestError{errors.New(strings.TrimSpace(string(body)))}
+
+	default:
+		return errors.Errorf("unexpected status code: %d", resp.StatusCode)
+	}
+}
+
 // P4Exec sends a p4 command with given arguments and returns an io.ReadCloser for the output.
 func (c *Client) P4Exec(ctx context.Context, host, user, password string, args ...string) (_ io.ReadCloser, _ http.Header, errRes error) {
 	span, ctx := ot.StartSpanFromContext(ctx, "Client.P4Exec")
@@ -884,6 +1014,31 @@ func (c *Client) doReposStats(ctx context.Context, addr string) (*protocol.Repos
 	return &stats, nil
 }
 
+// Health returns the health of the gitserver at addr.
+func (c *Client) Health(ctx context.Context, addr string) (*protocol.HealthResponse, error) {
+	req, err := http.NewRequestWithContext(ctx, "GET", "http://"+addr+"/health", nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.HTTPClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		body, _ := io.ReadAll(io.LimitReader(resp.Body, 1024))
+		return nil, errors.Errorf("gitserver health: http status %d: %s", resp.StatusCode, body)
+	}
+
+	var health protocol.HealthResponse
+	if err := json.NewDecoder(resp.Body).Decode(&health); err != nil {
+		return nil, err
+	}
+	return &health, nil
+}
+
 // Remove removes the repository clone from gitserver.
 func (c *Client) Remove(ctx context.Context, repo api.RepoName) error {
 	req := &protocol.RepoDeleteRequest{
@@ -23,6 +23,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/cmd/gitserver/server"
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 )
 
@@ -370,3 +371,44 @@ func TestClient_P4Exec(t *testing.T) {
 		})
 	}
 }
+
+func TestClient_Grep(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/grep" {
+			t.Fatalf("unexpected path %q", r.URL.Path)
+		}
+		w.Header().Set("Trailer", "X-Grep-Error")
+		w.Header().Add("Trailer", "X-Grep-Limit-Hit")
+		w.WriteHeader(http.StatusOK)
+		_, _ = w.Write([]byte(`{"path":"a.go","lineNumber":1,"line":"foo"}` + "\n"))
+		_, _ = w.Write([]byte(`{"path":"b.go","lineNumber":3,"line":"foo bar"}` + "\n"))
+		w.Header().Set("X-Grep-Error", "")
+		w.Header().Set("X-Grep-Limit-Hit", "true")
+	}))
+	defer server.Close()
+
+	cli := gitserver.NewClient(&http.Client{})
+	cli.Addrs = func() []string {
+		u, _ := url.Parse(server.URL)
+		return []string{u.Host}
+	}
+
+	var matches []protocol.GrepMatch
+	limitHit, err := cli.Grep(context.Background(), &protocol.GrepRequest{Repo: "github.com/foo/bar", Pattern: "foo"}, func(m protocol.GrepMatch) error {
+		matches = append(matches, m)
+		return nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !limitHit {
+		t.Error("expected limit to be hit")
+	}
+	want := []protocol.GrepMatch{
+		{Path: "a.go", LineNumber: 1, Line: "foo"},
+		{Path: "b.go", LineNumber: 3, Line: "foo bar"},
+	}
+	if diff := cmp.Diff(want, matches); diff != "" {
+		t.Errorf("unexpected matches (-want +got):\n%s", diff)
+	}
+}
@@ -31,6 +31,76 @@ type P4ExecRequest struct {
 	Args     []string `json:"args"`
 }
 
+// GrepRequest is a request to search the contents of a repository at a commit
+// with `git grep`. The matching lines are streamed back as newline-delimited
+// JSON encoded GrepMatches.
+type GrepRequest struct {
+	Repo   api.RepoName `json:"repo"`
+	Commit api.CommitID `json:"commit"`
+
+	// Pattern is matched against each line of the files. It is a POSIX
+	// extended regular expression if IsRegExp is true, otherwise a fixed
+	// string.
+	Pattern         string `json:"pattern"`
+	IsRegExp        bool   `json:"isRegExp"`
+	IsCaseSensitive bool   `json:"isCaseSensitive"`
+
+	// IncludePatterns must all match the path of a file, and ExcludePattern
+	// must not match it, for the file to be searched. See
+	// pathmatch.CompilePathPatterns.
+	IncludePatterns              []string `json:"includePatterns"`
+	ExcludePattern               string   `json:"excludePattern"`
+	PathPatternsAreRegExps       bool     `json:"pathPatternsAreRegExps"`
+	PathPatternsAreCaseSensitive bool     `json:"pathPatternsAreCaseSensitive"`
+
+	// Limit is the maximum number of matching lines to return. Zero means no
+	// limit.
+	Limit int `json:"limit"`
+}
+
+// GrepMatch is a line matched by a GrepRequest.
+type GrepMatch struct {
+	Path string `json:"path"`
+	// LineNumber is the 1-based number of the line in the file.
+	LineNumber int    `json:"lineNumber"`
+	Line       string `json:"line"`
+}
+
+// BlameRequest is a request to blame a file at a commit with `git blame`. The
+// hunks are streamed back as newline-delimited JSON encoded BlameHunks as soon
+// as git has attributed them to a commit, which is not in the order of their
+// lines.
+type BlameRequest struct {
+	Repo   api.RepoName `json:"repo"`
+	Commit api.CommitID `json:"commit"`
+	Path   string       `json:"path"`
+
+	// StartLine and EndLine restrict the blame to the 1-based, inclusive
+	// range of lines. Zero means the beginning and the end of the file.
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine"`
+}
+
+// BlameHunk is a contiguous range of lines of a blamed file which were last
+// changed by the same commit.
+type BlameHunk struct {
+	// StartLine is the 1-based number of the first line of the hunk, and
+	// EndLine the number of the line after its last line.
+	StartLine int `json:"startLine"`
+	EndLine   int `json:"endLine"`
+	// StartByte and EndByte are the offsets of the hunk, counted without line
+	// endings from the first blamed line.
+	StartByte int `json:"startByte"`
+	EndByte   int `json:"endByte"`
+
+	CommitID    api.CommitID `json:"commitID"`
+	AuthorName  string       `json:"authorName"`
+	AuthorEmail string       `json:"authorEmail"`
+	AuthorDate  time.Time    `json:"authorDate"`
+	// Message is the summary line of the commit message.
+	Message string `json:"message"`
+}
+
 // RemoteOpts configures interactions with a remote repository.
 type RemoteOpts struct {
 	SSH   *SSHConfig   `json:"ssh"`   // SSH configuration for communication with the remote
@@ -145,6 +215,16 @@ type ReposStats struct {
 	GitDirBytes int64
 }
 
+// HealthResponse is the health of a gitserver, returned by its health endpoint.
+type HealthResponse struct {
+	Version string
+
+	// DiskSizeBytes and DiskFreeBytes are the size and free space of the disk
+	// that repositories are stored on.
+	DiskSizeBytes uint64
+	DiskFreeBytes uint64
+}
+
 // RepoCloneProgressRequest is a request for information about the clone progress of multiple
 // repositories on gitserver.
 type RepoCloneProgressRequest struct {
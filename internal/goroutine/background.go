@@ -6,6 +6,7 @@ import (
 	"os/signal"
 	"sync"
 	"syscall"
+	"time"
 )
 
 // StartableRoutine represents a component of a binary that consists of a long
@@ -33,6 +34,17 @@ type BackgroundRoutine interface {
 	Stop()
 }
 
+// RunObserver is called after each unit of work performed by a background routine with
+// the time spent on the unit of work and the error it produced, if any.
+type RunObserver func(duration time.Duration, err error)
+
+// RunObservable is an optional extension of the BackgroundRoutine interface implemented
+// by routines that perform discrete units of work, such as the handler invocations of a
+// periodic goroutine. Observers must be added before the routine is started.
+type RunObservable interface {
+	AddRunObserver(observer RunObserver)
+}
+
 // MonitorBackgroundRoutines will start the given background routines in their own
 // goroutine. If the given context is canceled or a signal is received, the Stop
 // method of each routine will be called. This method blocks until the Stop methods
@@ -25,9 +25,11 @@ type PeriodicGoroutine struct {
 	ctx       context.Context    // root context passed to the handler
 	cancel    context.CancelFunc // cancels the root context
 	finished  chan struct{}      // signals that Start has finished
+	observers []RunObserver      // notified after each handler invocation
 }
 
 var _ BackgroundRoutine = &PeriodicGoroutine{}
+var _ RunObservable = &PeriodicGoroutine{}
 
 // Handler represents the main behavior of a PeriodicGoroutine.
 type Handler interface {
@@ -108,9 +110,16 @@ func (r *PeriodicGoroutine) Start() {
 
 loop:
 	for {
-		if shutdown, err := runPeriodicHandler(r.ctx, r.handler, r.operation); shutdown {
+		start := r.clock.Now()
+		shutdown, err := runPeriodicHandler(r.ctx, r.handler, r.operation)
+		if shutdown {
 			break
-		} else if h, ok := r.handler.(ErrorHandler); ok && err != nil {
+		}
+
+		for _, observer := range r.observers {
+			observer(r.clock.Since(start), err)
+		}
+		if h, ok := r.handler.(ErrorHandler); ok && err != nil {
 			h.HandleError(err)
 		}
 
@@ -126,6 +135,12 @@ loop:
 	}
 }
 
+// AddRunObserver registers an observer that is called after each handler invocation
+// that was not interrupted by a shutdown. This method must be called before Start.
+func (r *PeriodicGoroutine) AddRunObserver(observer RunObserver) {
+	r.observers = append(r.observers, observer)
+}
+
 // Stop will cancel the context passed to the handler function to stop the current
 // iteration of work, then break the loop in the Start method so that no new work
 // is accepted. This method blocks until Start has returned.
@@ -110,3 +110,24 @@ func NewMockHandlerWithFinalizer() *MockHandlerWithFinalizer {
 		MockFinalizer: NewMockFinalizer(),
 	}
 }
+
+func TestPeriodicGoroutineRunObserver(t *testing.T) {
+	clock := glock.NewMockClock()
+	handler := NewMockHandler()
+	handler.HandleFunc.PushReturn(errors.New("oops"))
+
+	var errs []error
+	goroutine := newPeriodicGoroutine(context.Background(), time.Second, handler, nil, clock)
+	goroutine.AddRunObserver(func(duration time.Duration, err error) { errs = append(errs, err) })
+	go goroutine.Start()
+	clock.BlockingAdvance(time.Second)
+	clock.BlockingAdvance(time.Second)
+	goroutine.Stop()
+
+	if len(errs) != 3 {
+		t.Fatalf("unexpected number of observed runs. want=%d have=%d", 3, len(errs))
+	}
+	if errs[0] == nil || errs[1] != nil || errs[2] != nil {
+		t.Errorf("unexpected observed errors: %v", errs)
+	}
+}
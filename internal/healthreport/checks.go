@@ -0,0 +1,81 @@
+package healthreport
+
+import (
+	"context"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/repoupdater"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/version"
+)
+
+func checkFrontend(ctx context.Context, db dbutil.DB) []*Service {
+	s := &Service{Name: "frontend", Version: version.Version()}
+
+	// The frontend is reachable if it can reach its database.
+	schemaVersion, _, err := basestore.ScanFirstInt(db.QueryContext(ctx, "SELECT version FROM schema_migrations"))
+	if err != nil {
+		s.Error = err.Error()
+		return []*Service{s}
+	}
+	s.Reachable = true
+	s.Metrics = []Metric{{Name: "database_schema_version", Value: float64(schemaVersion)}}
+	return []*Service{s}
+}
+
+func checkGitservers(ctx context.Context, _ dbutil.DB) []*Service {
+	addrs := gitserver.DefaultClient.Addrs()
+	services := make([]*Service, len(addrs))
+	for i, addr := range addrs {
+		s := &Service{Name: "gitserver", Instance: addr}
+		services[i] = s
+
+		health, err := gitserver.DefaultClient.Health(ctx, addr)
+		if err != nil {
+			s.Error = err.Error()
+			continue
+		}
+		s.Reachable = true
+		s.Version = health.Version
+		s.Metrics = []Metric{
+			{Name: "disk_size_bytes", Value: float64(health.DiskSizeBytes)},
+			{Name: "disk_free_bytes", Value: float64(health.DiskFreeBytes)},
+		}
+	}
+	return services
+}
+
+func checkRepoUpdater(ctx context.Context, _ dbutil.DB) []*Service {
+	s := &Service{Name: "repo-updater"}
+
+	health, err := repoupdater.DefaultClient.Health(ctx)
+	if err != nil {
+		s.Error = err.Error()
+		return []*Service{s}
+	}
+	s.Reachable = true
+	s.Version = health.Version
+	s.Metrics = []Metric{
+		{Name: "scheduled_repos", Value: float64(health.ScheduledRepos)},
+		{Name: "queued_updates", Value: float64(health.QueuedUpdates)},
+	}
+	return []*Service{s}
+}
+
+func checkZoekt(ctx context.Context, _ dbutil.DB) []*Service {
+	if !search.Indexed().Enabled() {
+		return nil
+	}
+
+	s := &Service{Name: "zoekt"}
+	indexed, err := search.Indexed().ListAll(ctx)
+	if err != nil {
+		s.Error = err.Error()
+		return []*Service{s}
+	}
+	s.Reachable = true
+	s.Metrics = []Metric{{Name: "indexed_repos", Value: float64(len(indexed))}}
+	return []*Service{s}
+}
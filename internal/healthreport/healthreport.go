@@ -0,0 +1,92 @@
+// Package healthreport aggregates the health of the services of a Sourcegraph
+// instance into a single report, e.g. to attach it to a support request.
+package healthreport
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+// Report is the health of all services of the instance at a point in time.
+type Report struct {
+	GeneratedAt time.Time  `json:"generatedAt"`
+	Services    []*Service `json:"services"`
+}
+
+// Service is the health of a single instance of a service.
+type Service struct {
+	// Name is the name of the service, e.g. "gitserver".
+	Name string `json:"name"`
+	// Instance is the address of the instance, if the service has several.
+	Instance string `json:"instance,omitempty"`
+	// Version is the version of the service, if it reports one.
+	Version string `json:"version,omitempty"`
+	// Reachable is whether the instance responded to the health check.
+	Reachable bool `json:"reachable"`
+	// Error is the error that occurred when checking the health of the
+	// instance, if any.
+	Error string `json:"error,omitempty"`
+	// Metrics are the service specific measurements of the instance, such as
+	// queue depths and free disk space.
+	Metrics []Metric `json:"metrics,omitempty"`
+}
+
+// Metric is a named measurement of the health of a service.
+type Metric struct {
+	Name  string  `json:"name"`
+	Value float64 `json:"value"`
+}
+
+// A Checker returns the health of the instances of a service. It should return
+// unreachable instances with an error rather than omitting them.
+type Checker func(ctx context.Context, db dbutil.DB) []*Service
+
+// Checkers are run to generate a report. It may be appended to at init time,
+// e.g. by enterprise services.
+var Checkers = []Checker{
+	checkFrontend,
+	checkGitservers,
+	checkRepoUpdater,
+	checkZoekt,
+}
+
+// checkTimeout is the time a health check may take before the instance is
+// reported as unreachable.
+const checkTimeout = 10 * time.Second
+
+// Generate runs all Checkers concurrently and returns their results, ordered
+// by service and instance.
+func Generate(ctx context.Context, db dbutil.DB) *Report {
+	ctx, cancel := context.WithTimeout(ctx, checkTimeout)
+	defer cancel()
+
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		services []*Service
+	)
+	for _, check := range Checkers {
+		wg.Add(1)
+		go func(check Checker) {
+			defer wg.Done()
+			s := check(ctx, db)
+			mu.Lock()
+			services = append(services, s...)
+			mu.Unlock()
+		}(check)
+	}
+	wg.Wait()
+
+	sort.Slice(services, func(i, j int) bool {
+		if services[i].Name != services[j].Name {
+			return services[i].Name < services[j].Name
+		}
+		return services[i].Instance < services[j].Instance
+	})
+
+	return &Report{GeneratedAt: time.Now().UTC(), Services: services}
+}
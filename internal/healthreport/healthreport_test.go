@@ -0,0 +1,45 @@
+package healthreport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/cockroachdb/errors"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+)
+
+func TestGenerate(t *testing.T) {
+	orig := Checkers
+	t.Cleanup(func() { Checkers = orig })
+
+	Checkers = []Checker{
+		func(context.Context, dbutil.DB) []*Service {
+			return []*Service{
+				{Name: "gitserver", Instance: "gitserver-1", Reachable: true, Metrics: []Metric{{Name: "disk_free_bytes", Value: 42}}},
+				{Name: "gitserver", Instance: "gitserver-0", Error: "connection refused"},
+			}
+		},
+		func(ctx context.Context, _ dbutil.DB) []*Service {
+			if _, ok := ctx.Deadline(); !ok {
+				return []*Service{{Name: "frontend", Error: errors.New("no deadline").Error()}}
+			}
+			return []*Service{{Name: "frontend", Version: "1.2.3", Reachable: true}}
+		},
+	}
+
+	report := Generate(context.Background(), nil)
+	if report.GeneratedAt.IsZero() {
+		t.Error("want generation time to be set")
+	}
+
+	want := []*Service{
+		{Name: "frontend", Version: "1.2.3", Reachable: true},
+		{Name: "gitserver", Instance: "gitserver-0", Error: "connection refused"},
+		{Name: "gitserver", Instance: "gitserver-1", Reachable: true, Metrics: []Metric{{Name: "disk_free_bytes", Value: 42}}},
+	}
+	if diff := cmp.Diff(want, report.Services); diff != "" {
+		t.Fatalf("unexpected services (-want +got):\n%s", diff)
+	}
+}
@@ -10,6 +10,7 @@ import (
 	"encoding/pem"
 	"fmt"
 	"math/big"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"reflect"
@@ -290,3 +291,36 @@ type bogusTransport struct{}
 func (t bogusTransport) RoundTrip(*http.Request) (*http.Response, error) {
 	panic("should not be called")
 }
+
+func TestDenyPrivateAddressesOpt(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {}))
+	defer srv.Close()
+
+	cli, err := NewFactory(nil).Client(DenyPrivateAddressesOpt)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// The test server listens on a loopback address.
+	if _, err := cli.Get(srv.URL); !errors.Is(err, ErrPrivateAddress) {
+		t.Fatalf("got error %v, want %v", err, ErrPrivateAddress)
+	}
+
+	for ip, want := range map[string]bool{
+		"127.0.0.1":       true,
+		"10.1.2.3":        true,
+		"172.20.0.1":      true,
+		"192.168.1.1":     true,
+		"169.254.169.254": true,
+		"::1":             true,
+		"::ffff:10.0.0.1": true,
+		"fd00::1":         true,
+		"fe80::1":         true,
+		"8.8.8.8":         false,
+		"2001:4860::8888": false,
+	} {
+		if got := IsPrivateAddress(net.ParseIP(ip)); got != want {
+			t.Errorf("IsPrivateAddress(%s): got %v, want %v", ip, got, want)
+		}
+	}
+}
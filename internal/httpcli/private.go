@@ -0,0 +1,87 @@
+package httpcli
+
+import (
+	"net"
+	"net/http"
+	"syscall"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// privateNetworks are the networks that requests to user-provided URLs must
+// not reach: loopback, private, shared, link-local and unspecified addresses.
+var privateNetworks = func() []*net.IPNet {
+	var nets []*net.IPNet
+	for _, cidr := range []string{
+		"0.0.0.0/8",
+		"10.0.0.0/8",
+		"100.64.0.0/10",
+		"127.0.0.0/8",
+		"169.254.0.0/16",
+		"172.16.0.0/12",
+		"192.168.0.0/16",
+		"::/128",
+		"::1/128",
+		"fc00::/7",
+		"fe80::/10",
+	} {
+		_, n, err := net.ParseCIDR(cidr)
+		if err != nil {
+			panic(err)
+		}
+		nets = append(nets, n)
+	}
+	return nets
+}()
+
+// IsPrivateAddress returns true if ip is a loopback, private, link-local or
+// unspecified address, i.e. an address of the network of the Sourcegraph
+// instance rather than of the internet.
+func IsPrivateAddress(ip net.IP) bool {
+	if ip4 := ip.To4(); ip4 != nil {
+		ip = ip4
+	}
+	for _, n := range privateNetworks {
+		if n.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
+// ErrPrivateAddress is returned by clients configured with
+// DenyPrivateAddressesOpt for requests to private addresses.
+var ErrPrivateAddress = errors.New("connecting to a private address is not allowed")
+
+// DenyPrivateAddressesOpt is an Opt that makes the http.Client refuse to
+// connect to private addresses (see IsPrivateAddress). Use it for requests
+// to URLs provided by users, so that they can't reach services on the network
+// of the Sourcegraph instance. Addresses are checked after the host name is
+// resolved, so host names resolving to private addresses are refused as well.
+func DenyPrivateAddressesOpt(cli *http.Client) error {
+	tr, err := getTransportForMutation(cli)
+	if err != nil {
+		return errors.Wrap(err, "httpcli.DenyPrivateAddressesOpt")
+	}
+
+	dialer := &net.Dialer{
+		Timeout:   30 * time.Second,
+		KeepAlive: 30 * time.Second,
+		Control: func(network, address string, _ syscall.RawConn) error {
+			host, _, err := net.SplitHostPort(address)
+			if err != nil {
+				return err
+			}
+			if ip := net.ParseIP(host); ip == nil || IsPrivateAddress(ip) {
+				return errors.Wrap(ErrPrivateAddress, host)
+			}
+			return nil
+		},
+	}
+	tr.DialContext = dialer.DialContext
+	// Requests must not be sent through a proxy, which would connect to the
+	// private address on behalf of the client.
+	tr.Proxy = nil
+	return nil
+}
@@ -72,6 +72,21 @@ func Edit(input string, v interface{}, path ...string) (string, error) {
 	return jsonx.ApplyEdits(input, edits...)
 }
 
+// EditPath is like Edit, but path may also contain array indexes (ints).
+func EditPath(input string, v interface{}, path ...interface{}) (string, error) {
+	edits, _, err := jsonx.ComputePropertyEdit(input,
+		jsonx.MakePath(path...),
+		v,
+		nil,
+		DefaultFormatOptions,
+	)
+	if err != nil {
+		return input, err
+	}
+
+	return jsonx.ApplyEdits(input, edits...)
+}
+
 // ReadProperty attempts to read the value of the specified path, ignoring parse errors. it will only error if the path
 // doesn't exist
 func ReadProperty(input, path string) (interface{}, error) {
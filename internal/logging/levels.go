@@ -0,0 +1,122 @@
+package logging
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/inconshreveable/log15"
+)
+
+// MaxLevelOverrideTTL is the longest time a log level override set at runtime lasts
+// before the component reverts to the level configured with SRC_LOG_LEVEL.
+const MaxLevelOverrideTTL = 24 * time.Hour
+
+// modulePrefix is stripped from the package paths of the callers of log records, so that
+// components are named like "internal/repos".
+const modulePrefix = "github.com/sourcegraph/sourcegraph/"
+
+// LevelOverride is a log level set at runtime for the records logged by a component,
+// which is a package path relative to the repository root such as "internal/repos".
+// It applies to the package and its subpackages until it expires.
+type LevelOverride struct {
+	Component string
+	Level     log15.Lvl
+	ExpiresAt time.Time
+}
+
+var levelOverrides = struct {
+	sync.RWMutex
+	m map[string]LevelOverride
+}{m: map[string]LevelOverride{}}
+
+// SetLevelOverride sets the log level of the records logged by component for the given
+// duration, which is capped at MaxLevelOverrideTTL. It replaces any override of the
+// component.
+func SetLevelOverride(component string, lvl log15.Lvl, ttl time.Duration) (LevelOverride, error) {
+	component = strings.Trim(strings.TrimPrefix(component, modulePrefix), "/")
+	if component == "" {
+		return LevelOverride{}, fmt.Errorf("the component must not be empty")
+	}
+	if ttl <= 0 {
+		return LevelOverride{}, fmt.Errorf("the duration of the log level override must be positive")
+	}
+	if ttl > MaxLevelOverrideTTL {
+		ttl = MaxLevelOverrideTTL
+	}
+
+	o := LevelOverride{Component: component, Level: lvl, ExpiresAt: time.Now().Add(ttl)}
+	levelOverrides.Lock()
+	levelOverrides.m[component] = o
+	levelOverrides.Unlock()
+	return o, nil
+}
+
+// ResetLevelOverride removes the log level override of component, if any, and reports
+// whether there was one.
+func ResetLevelOverride(component string) bool {
+	component = strings.Trim(strings.TrimPrefix(component, modulePrefix), "/")
+	levelOverrides.Lock()
+	defer levelOverrides.Unlock()
+	_, ok := levelOverrides.m[component]
+	delete(levelOverrides.m, component)
+	return ok
+}
+
+// LevelOverrides returns the log level overrides that have not expired, sorted by component.
+func LevelOverrides() []LevelOverride {
+	now := time.Now()
+	levelOverrides.Lock()
+	defer levelOverrides.Unlock()
+
+	overrides := make([]LevelOverride, 0, len(levelOverrides.m))
+	for component, o := range levelOverrides.m {
+		if !now.Before(o.ExpiresAt) {
+			delete(levelOverrides.m, component)
+			continue
+		}
+		overrides = append(overrides, o)
+	}
+	sort.Slice(overrides, func(i, j int) bool { return overrides[i].Component < overrides[j].Component })
+	return overrides
+}
+
+// overriddenLevel returns the level of the most specific unexpired override whose component
+// contains the package that logged the record.
+func overriddenLevel(r *log15.Record) (log15.Lvl, bool) {
+	levelOverrides.RLock()
+	defer levelOverrides.RUnlock()
+	if len(levelOverrides.m) == 0 {
+		return 0, false
+	}
+
+	pkg := strings.TrimPrefix(fmt.Sprintf("%+k", r.Call), modulePrefix)
+	now := time.Now()
+	for component := pkg; component != "."; component = parentComponent(component) {
+		if o, ok := levelOverrides.m[component]; ok && now.Before(o.ExpiresAt) {
+			return o.Level, true
+		}
+	}
+	return 0, false
+}
+
+// parentComponent returns the parent package path of component, or "." if it has none.
+func parentComponent(component string) string {
+	if i := strings.LastIndex(component, "/"); i != -1 {
+		return component[:i]
+	}
+	return "."
+}
+
+// lvlFilterHandler returns a handler that only passes on records at or above lvl, or at or
+// above the overridden level of the component that logged them.
+func lvlFilterHandler(lvl log15.Lvl, h log15.Handler) log15.Handler {
+	return log15.FilterHandler(func(r *log15.Record) bool {
+		if overridden, ok := overriddenLevel(r); ok {
+			return r.Lvl <= overridden
+		}
+		return r.Lvl <= lvl
+	}, h)
+}
@@ -0,0 +1,75 @@
+package logging
+
+import (
+	"testing"
+	"time"
+
+	"github.com/inconshreveable/log15"
+)
+
+func TestLvlFilterHandler(t *testing.T) {
+	var logged []string
+	h := lvlFilterHandler(log15.LvlInfo, log15.FuncHandler(func(r *log15.Record) error {
+		logged = append(logged, r.Msg)
+		return nil
+	}))
+	// The records are logged by this test, in the internal/logging package.
+	logger := log15.New()
+	logger.SetHandler(h)
+	log := func(msg string, lvl log15.Lvl) {
+		switch lvl {
+		case log15.LvlDebug:
+			logger.Debug(msg)
+		case log15.LvlInfo:
+			logger.Info(msg)
+		case log15.LvlError:
+			logger.Error(msg)
+		}
+	}
+
+	t.Cleanup(func() {
+		ResetLevelOverride("internal")
+		ResetLevelOverride("internal/logging")
+	})
+
+	log("default debug", log15.LvlDebug)
+	log("default info", log15.LvlInfo)
+
+	if _, err := SetLevelOverride("internal", log15.LvlDebug, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	log("parent debug", log15.LvlDebug)
+
+	if _, err := SetLevelOverride("github.com/sourcegraph/sourcegraph/internal/logging", log15.LvlError, time.Minute); err != nil {
+		t.Fatal(err)
+	}
+	log("package info", log15.LvlInfo)
+	log("package error", log15.LvlError)
+
+	ResetLevelOverride("internal/logging")
+	log("reset debug", log15.LvlDebug)
+
+	want := []string{"default info", "parent debug", "package error", "reset debug"}
+	if len(logged) != len(want) {
+		t.Fatalf("got logged %q, want %q", logged, want)
+	}
+	for i := range want {
+		if logged[i] != want[i] {
+			t.Fatalf("got logged %q, want %q", logged, want)
+		}
+	}
+
+	overrides := LevelOverrides()
+	if len(overrides) != 1 || overrides[0].Component != "internal" || overrides[0].Level != log15.LvlDebug {
+		t.Fatalf("unexpected overrides: %+v", overrides)
+	}
+
+	levelOverrides.Lock()
+	o := levelOverrides.m["internal"]
+	o.ExpiresAt = time.Now().Add(-time.Second)
+	levelOverrides.m["internal"] = o
+	levelOverrides.Unlock()
+	if overrides := LevelOverrides(); len(overrides) != 0 {
+		t.Fatalf("expired override was not removed: %+v", overrides)
+	}
+}
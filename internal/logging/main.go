@@ -122,10 +122,8 @@ func Init(options ...Option) {
 	for _, filter := range opts.filters {
 		handler = log15.FilterHandler(filter, handler)
 	}
-	// Filter log output by level.
-	lvl, err := log15.LvlFromString(env.LogLevel)
-	if err == nil {
-		handler = log15.LvlFilterHandler(lvl, handler)
-	}
-	log15.Root().SetHandler(log15.LvlFilterHandler(lvl, handler))
+	// Filter log output by level, unless the level of the component logging was overridden
+	// at runtime.
+	lvl, _ := log15.LvlFromString(env.LogLevel)
+	log15.Root().SetHandler(lvlFilterHandler(lvl, handler))
 }
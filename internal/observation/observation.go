@@ -49,6 +49,10 @@
 // of an operation (the With function), or after the invocation completes but before the observation
 // has terminated (the endObservation function). Log fields and metric labels are concatenated
 // together in the order they are attached to an operation.
+//
+// Operations on hot paths can set a sample rate so that only a fraction of their invocations
+// are traced. The values of log fields supplied per invocation are tracked, and fields with
+// more distinct values than the limit of the operation are suppressed.
 package observation
 
 import (
@@ -61,6 +65,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/logging"
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 )
 
 // Context carries context about where to send logs, trace spans, and register
@@ -95,6 +100,17 @@ type Op struct {
 	// an unexpected value in metrics and traces but should be handled higher up in
 	// the stack.
 	ErrorFilter func(err error) bool
+	// SampleRate is the fraction of invocations of this operation that are traced,
+	// between 0 and 1. Invocations that are not sampled create no trace span, and
+	// neither do the operations they invoke. Metrics and error logs are emitted for
+	// every invocation. The zero value traces every invocation.
+	SampleRate float64
+	// LogFieldCardinalityLimit is the maximum number of distinct values of a log
+	// field supplied to With or to the finish function. Once a field exceeds the
+	// limit, its values are replaced by a placeholder for the lifetime of the
+	// operation. The zero value uses DefaultLogFieldCardinalityLimit, and a negative
+	// value disables the limit.
+	LogFieldCardinalityLimit int
 }
 
 // Operation combines the state of the parent context to create a new operation. This value
@@ -108,6 +124,8 @@ func (c *Context) Operation(args Op) *Operation {
 		metricLabels: args.MetricLabels,
 		logFields:    args.LogFields,
 		errorFilter:  args.ErrorFilter,
+		sampleRate:   args.SampleRate,
+		cardinality:  newCardinalityGuard(args.LogFieldCardinalityLimit),
 	}
 }
 
@@ -120,6 +138,8 @@ type Operation struct {
 	metricLabels []string
 	logFields    []log.Field
 	errorFilter  func(err error) bool
+	sampleRate   float64
+	cardinality  *cardinalityGuard
 }
 
 // TraceLogger is returned from WithAndLogger and can be used to add timestamped key and
@@ -173,6 +193,7 @@ func (op *Operation) With(ctx context.Context, err *error, args Args) (context.C
 // to the active trace, and a function to be deferred until the end of the operation.
 func (op *Operation) WithAndLogger(ctx context.Context, err *error, args Args) (context.Context, TraceLogger, FinishFunc) {
 	start := time.Now()
+	args.LogFields = op.cardinality.filter(args.LogFields)
 	tr, ctx := op.trace(ctx, args)
 
 	var logFields TraceLogger
@@ -185,7 +206,7 @@ func (op *Operation) WithAndLogger(ctx context.Context, err *error, args Args) (
 	return ctx, logFields, func(count float64, finishArgs Args) {
 		elapsed := time.Since(start).Seconds()
 		defaultFinishFields := []log.Field{log.Float64("count", count), log.Float64("elapsed", elapsed)}
-		logFields := mergeLogFields(defaultFinishFields, finishArgs.LogFields)
+		logFields := mergeLogFields(defaultFinishFields, op.cardinality.filter(finishArgs.LogFields))
 		metricLabels := mergeLabels(op.metricLabels, args.MetricLabels, finishArgs.MetricLabels)
 
 		err = op.applyErrorFilter(err)
@@ -198,10 +219,15 @@ func (op *Operation) WithAndLogger(ctx context.Context, err *error, args Args) (
 // trace creates a new Trace object and returns the wrapped context. If any log fields are
 // attached to the operation or to the args to With, they are emitted immediately. This returns
 // an unmodified context and a nil trace if no tracer was supplied on the observation context.
+// If the invocation is not sampled, this returns a nil trace and a context that disables
+// tracing for the rest of the invocation.
 func (op *Operation) trace(ctx context.Context, args Args) (*trace.Trace, context.Context) {
 	if op.context.Tracer == nil {
 		return nil, ctx
 	}
+	if !sampled(op.sampleRate) {
+		return nil, ot.WithShouldTrace(ctx, false)
+	}
 
 	tr, ctx := op.context.Tracer.New(ctx, op.kebabName, "")
 	tr.LogFields(mergeLogFields(op.logFields, args.LogFields)...)
@@ -0,0 +1,106 @@
+package observation
+
+import (
+	"fmt"
+	"math/rand"
+	"sync"
+
+	"github.com/opentracing/opentracing-go/log"
+)
+
+// DefaultLogFieldCardinalityLimit is the maximum number of distinct values of a
+// log field of an operation that don't set a limit explicitly.
+const DefaultLogFieldCardinalityLimit = 1000
+
+// suppressedLogFieldValue replaces the values of log fields whose cardinality
+// exceeded the limit of their operation.
+const suppressedLogFieldValue = "<suppressed: high cardinality>"
+
+// sampled returns true if an invocation of an operation with the given sample
+// rate should be traced.
+func sampled(rate float64) bool {
+	if rate <= 0 || rate >= 1 {
+		return true
+	}
+
+	return rand.Float64() < rate
+}
+
+// cardinalityGuard tracks the distinct values of the log fields of an operation
+// and suppresses the values of fields that exceed the limit.
+type cardinalityGuard struct {
+	limit int
+
+	mu sync.Mutex
+	// values holds the distinct values seen for each field key. The set of a key is
+	// replaced by nil once the key exceeded the limit.
+	values map[string]map[string]struct{}
+}
+
+func newCardinalityGuard(limit int) *cardinalityGuard {
+	if limit < 0 {
+		return nil
+	}
+	if limit == 0 {
+		limit = DefaultLogFieldCardinalityLimit
+	}
+
+	return &cardinalityGuard{limit: limit, values: map[string]map[string]struct{}{}}
+}
+
+// filter returns the given log fields with the values of high-cardinality fields
+// replaced by a placeholder. The input slice is not modified.
+func (g *cardinalityGuard) filter(fields []log.Field) []log.Field {
+	if g == nil || len(fields) == 0 {
+		return fields
+	}
+
+	g.mu.Lock()
+	defer g.mu.Unlock()
+
+	var filtered []log.Field
+	for i, field := range fields {
+		if g.allow(field.Key(), fmt.Sprint(field.Value())) {
+			if filtered != nil {
+				filtered = append(filtered, field)
+			}
+			continue
+		}
+
+		if filtered == nil {
+			filtered = make([]log.Field, i, len(fields))
+			copy(filtered, fields[:i])
+		}
+		filtered = append(filtered, log.String(field.Key(), suppressedLogFieldValue))
+	}
+	if filtered == nil {
+		return fields
+	}
+
+	return filtered
+}
+
+// allow records the given value of the field key and returns false if the key
+// has exceeded the limit. The caller must hold the mutex.
+func (g *cardinalityGuard) allow(key, value string) bool {
+	seen, ok := g.values[key]
+	if ok && seen == nil {
+		return false
+	}
+	if !ok {
+		seen = map[string]struct{}{}
+		g.values[key] = seen
+	}
+
+	if _, ok := seen[value]; ok {
+		return true
+	}
+	if len(seen) >= g.limit {
+		// Drop the recorded values, the key won't be logged again
+		g.values[key] = nil
+		return false
+	}
+
+	seen[value] = struct{}{}
+	return true
+}
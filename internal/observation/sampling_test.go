@@ -0,0 +1,75 @@
+package observation
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/opentracing/opentracing-go/log"
+
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
+)
+
+func TestSampled(t *testing.T) {
+	for _, rate := range []float64{0, 1, 2} {
+		if !sampled(rate) {
+			t.Errorf("expected rate %v to sample every invocation", rate)
+		}
+	}
+
+	count := 0
+	for i := 0; i < 1000; i++ {
+		if sampled(0.001) {
+			count++
+		}
+	}
+	if count > 100 {
+		t.Errorf("unexpected number of sampled invocations. want<=%d have=%d", 100, count)
+	}
+}
+
+func TestOperationNotSampled(t *testing.T) {
+	observationContext := &Context{Tracer: &trace.Tracer{}}
+	op := observationContext.Operation(Op{Name: "Test.NotSampled", SampleRate: 1e-12})
+
+	ctx, endObservation := op.With(ot.WithShouldTrace(context.Background(), true), nil, Args{})
+	defer endObservation(1, Args{})
+
+	if ot.ShouldTrace(ctx) {
+		t.Errorf("expected tracing to be disabled for an unsampled invocation")
+	}
+}
+
+func TestCardinalityGuard(t *testing.T) {
+	g := newCardinalityGuard(2)
+
+	values := func(fields []log.Field) []string {
+		var vs []string
+		for _, f := range fields {
+			vs = append(vs, fmt.Sprintf("%s=%v", f.Key(), f.Value()))
+		}
+		return vs
+	}
+
+	steps := []struct {
+		fields []log.Field
+		want   []string
+	}{
+		{[]log.Field{log.Int("id", 1), log.String("kind", "a")}, []string{"id=1", "kind=a"}},
+		{[]log.Field{log.Int("id", 2), log.String("kind", "a")}, []string{"id=2", "kind=a"}},
+		{[]log.Field{log.Int("id", 1), log.String("kind", "b")}, []string{"id=1", "kind=b"}},
+		{[]log.Field{log.Int("id", 3), log.String("kind", "a")}, []string{"id=" + suppressedLogFieldValue, "kind=a"}},
+		{[]log.Field{log.Int("id", 1), log.String("kind", "b")}, []string{"id=" + suppressedLogFieldValue, "kind=b"}},
+	}
+	for i, step := range steps {
+		if diff := cmp.Diff(step.want, values(g.filter(step.fields))); diff != "" {
+			t.Errorf("unexpected fields in step %d (-want +got):\n%s", i, diff)
+		}
+	}
+
+	if fields := []log.Field{log.Int("id", 4)}; cmp.Diff(values(fields), values(newCardinalityGuard(-1).filter(fields))) != "" {
+		t.Errorf("expected a negative limit to disable the guard")
+	}
+}
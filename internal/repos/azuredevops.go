@@ -0,0 +1,167 @@
+package repos
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf/reposource"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// An AzureDevOpsSource yields repositories from a single Azure DevOps connection configured
+// in Sourcegraph via the external services configuration.
+type AzureDevOpsSource struct {
+	svc     *types.ExternalService
+	config  *schema.AzureDevOpsConnection
+	baseURL *url.URL
+	exclude excludeFunc
+	client  *azuredevops.Client
+}
+
+// NewAzureDevOpsSource returns a new AzureDevOpsSource from the given external service.
+func NewAzureDevOpsSource(svc *types.ExternalService, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
+	var c schema.AzureDevOpsConnection
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, errors.Errorf("external service id=%d config error: %s", svc.ID, err)
+	}
+	return newAzureDevOpsSource(svc, &c, cf)
+}
+
+func newAzureDevOpsSource(svc *types.ExternalService, c *schema.AzureDevOpsConnection, cf *httpcli.Factory) (*AzureDevOpsSource, error) {
+	if c.Url == "" {
+		c.Url = "https://dev.azure.com"
+	}
+	baseURL, err := url.Parse(c.Url)
+	if err != nil {
+		return nil, err
+	}
+	baseURL = extsvc.NormalizeBaseURL(baseURL)
+
+	if cf == nil {
+		cf = httpcli.NewExternalHTTPClientFactory()
+	}
+
+	cli, err := cf.Doer()
+	if err != nil {
+		return nil, err
+	}
+
+	var eb excludeBuilder
+	for _, r := range c.Exclude {
+		eb.Exact(r.Name)
+		eb.Exact(r.Id)
+		eb.Pattern(r.Pattern)
+	}
+	exclude, err := eb.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	client := azuredevops.NewClient(baseURL, cli)
+	client.Username = c.Username
+	client.Token = c.Token
+
+	return &AzureDevOpsSource{
+		svc:     svc,
+		config:  c,
+		baseURL: baseURL,
+		exclude: exclude,
+		client:  client,
+	}, nil
+}
+
+// ListRepos returns all Azure DevOps repositories of the organizations and
+// projects configured in the connection.
+func (s AzureDevOpsSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	seen := make(map[string]bool)
+
+	list := func(org, project string) {
+		repos, err := s.client.Repos(ctx, org, project)
+		if err != nil {
+			results <- SourceResult{Source: s, Err: errors.Wrapf(err, "azuredevops.repos: org=%q, project=%q", org, project)}
+			return
+		}
+
+		for _, r := range repos {
+			// Disabled repositories can't be cloned.
+			if r.IsDisabled {
+				continue
+			}
+
+			if !seen[r.ID] && !s.excludes(r) {
+				results <- SourceResult{Source: s, Repo: s.makeRepo(r)}
+				seen[r.ID] = true
+			}
+		}
+	}
+
+	for _, org := range s.config.Orgs {
+		list(org, "")
+	}
+
+	for _, p := range s.config.Projects {
+		parts := strings.SplitN(p, "/", 2)
+		if len(parts) != 2 {
+			results <- SourceResult{Source: s, Err: errors.Errorf("invalid project %q, expected \"org/project\"", p)}
+			continue
+		}
+		list(parts[0], parts[1])
+	}
+}
+
+// ExternalServices returns a singleton slice containing the external service.
+func (s AzureDevOpsSource) ExternalServices() types.ExternalServices {
+	return types.ExternalServices{s.svc}
+}
+
+func (s AzureDevOpsSource) makeRepo(r *azuredevops.Repository) *types.Repo {
+	urn := s.svc.URN()
+	cloneURL := r.RemoteURL
+	if s.config.GitURLType == "ssh" {
+		cloneURL = r.SSHURL
+	}
+
+	return &types.Repo{
+		Name: reposource.AzureDevOpsRepoName(
+			s.config.RepositoryPathPattern,
+			s.baseURL.Hostname(),
+			r.Org(),
+			r.Project.Name,
+			r.Name,
+		),
+		URI: string(reposource.AzureDevOpsRepoName(
+			"",
+			s.baseURL.Hostname(),
+			r.Org(),
+			r.Project.Name,
+			r.Name,
+		)),
+		ExternalRepo: api.ExternalRepoSpec{
+			ID:          r.ID,
+			ServiceType: extsvc.TypeAzureDevOps,
+			ServiceID:   s.baseURL.String(),
+		},
+		Fork:    r.IsFork,
+		Private: r.Project.Visibility != "public",
+		Sources: map[string]*types.SourceInfo{
+			urn: {
+				ID:       urn,
+				CloneURL: cloneURL,
+			},
+		},
+		Metadata: r,
+	}
+}
+
+func (s *AzureDevOpsSource) excludes(r *azuredevops.Repository) bool {
+	return s.exclude(r.NameWithOrg()) || s.exclude(r.ID)
+}
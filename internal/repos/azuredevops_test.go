@@ -0,0 +1,75 @@
+package repos
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestAzureDevOpsSource_ListRepos(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		repo := func(id, org, project, name string) string {
+			webURL := "https://dev.azure.com/" + org + "/" + project + "/_git/" + name
+			return `{"id": "` + id + `", "name": "` + name + `", "project": {"id": "p", "name": "` + project + `", "visibility": "private"},
+				"remoteUrl": "` + webURL + `", "webUrl": "` + webURL + `"}`
+		}
+
+		switch r.URL.Path {
+		case "/org/_apis/git/repositories":
+			io.WriteString(w, `{"value": [`+strings.Join([]string{
+				repo("1", "org", "proj", "a"),
+				repo("2", "org", "proj", "b"),
+				repo("3", "org", "other", "c"),
+			}, ",")+`]}`)
+		case "/org/proj/_apis/git/repositories":
+			io.WriteString(w, `{"value": [`+repo("1", "org", "proj", "a")+`]}`)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	svc := &types.ExternalService{
+		ID:   1,
+		Kind: extsvc.KindAzureDevOps,
+		Config: `{
+			"url": "` + srv.URL + `",
+			"username": "alice",
+			"token": "secret",
+			"orgs": ["org"],
+			"projects": ["org/proj"],
+			"exclude": [{"name": "org/proj/b"}],
+			"repositoryPathPattern": "ado/{org}/{project}/{repo}"
+		}`,
+	}
+
+	src, err := NewAzureDevOpsSource(svc, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repos, err := listAll(context.Background(), src)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// Repository "a" is listed by both the org and the project but yielded
+	// only once.
+	if have, want := types.Repos(repos).Names(), []string{"ado/org/proj/a", "ado/org/other/c"}; !cmp.Equal(want, have) {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+	if have, want := repos[0].ExternalRepo.ServiceType, extsvc.TypeAzureDevOps; have != want {
+		t.Errorf("wrong service type: want %q, have %q", want, have)
+	}
+	if !repos[0].Private {
+		t.Error("want private repo")
+	}
+}
@@ -9,6 +9,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/awscodecommit"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketserver"
 	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
@@ -23,18 +24,33 @@ import (
 
 // CloneURL builds a cloneURL for the given repo based on the external service
 // configuration. If authentication information is found in the configuration, it
-// returns an authenticated URL for the selected code host.
+// returns an authenticated URL for the selected code host. A fetch credential of
+// the configuration that applies to the repo takes precedence over the credentials
+// used to discover it.
 func CloneURL(kind, config string, repo *types.Repo) (string, error) {
 	parsed, err := extsvc.ParseConfig(kind, config)
 	if err != nil {
 		return "", errors.Wrap(err, "loading service configuration")
 	}
 
+	cred, err := FetchCredentialFor(config, repo.Name)
+	if err != nil {
+		return "", err
+	}
+	if cred != nil && cred.Token != "" {
+		// Clones over SSH use the SSH key of the credential, see GitRepoSyncer.
+		parsed = withFetchCredential(parsed, cred)
+	}
+
 	switch t := parsed.(type) {
 	case *schema.AWSCodeCommitConnection:
 		if r, ok := repo.Metadata.(*awscodecommit.Repository); ok {
 			return awsCodeCloneURL(r, t), nil
 		}
+	case *schema.AzureDevOpsConnection:
+		if r, ok := repo.Metadata.(*azuredevops.Repository); ok {
+			return azureDevOpsCloneURL(r, t), nil
+		}
 	case *schema.BitbucketServerConnection:
 		if r, ok := repo.Metadata.(*bitbucketserver.Repo); ok {
 			return bitbucketServerCloneURL(r, t), nil
@@ -77,6 +93,36 @@ func CloneURL(kind, config string, repo *types.Repo) (string, error) {
 	return "", errors.Errorf("unknown repo.Metadata type %T for repo %d", repo.Metadata, repo.ID)
 }
 
+// withFetchCredential returns a copy of the code host connection config cfg whose
+// credentials for cloning are replaced by cred.
+func withFetchCredential(cfg interface{}, cred *FetchCredential) interface{} {
+	switch t := cfg.(type) {
+	case *schema.BitbucketServerConnection:
+		c := *t
+		if cred.Username != "" {
+			c.Username = cred.Username
+		}
+		c.Token, c.Password = cred.Token, ""
+		return &c
+	case *schema.BitbucketCloudConnection:
+		c := *t
+		if cred.Username != "" {
+			c.Username = cred.Username
+		}
+		c.AppPassword = cred.Token
+		return &c
+	case *schema.GitHubConnection:
+		c := *t
+		c.Token = cred.Token
+		return &c
+	case *schema.GitLabConnection:
+		c := *t
+		c.Token, c.TokenType = cred.Token, "pat"
+		return &c
+	}
+	return cfg
+}
+
 func awsCodeCloneURL(repo *awscodecommit.Repository, cfg *schema.AWSCodeCommitConnection) string {
 	u, err := url.Parse(repo.HTTPCloneURL)
 	if err != nil {
@@ -91,6 +137,21 @@ func awsCodeCloneURL(repo *awscodecommit.Repository, cfg *schema.AWSCodeCommitCo
 	return u.String()
 }
 
+// azureDevOpsCloneURL returns the repository's Git remote URL with the
+// configured Azure DevOps personal access token inserted in the URL userinfo.
+func azureDevOpsCloneURL(repo *azuredevops.Repository, cfg *schema.AzureDevOpsConnection) string {
+	if cfg.GitURLType == "ssh" {
+		return repo.SSHURL // SSH authentication must be provided out-of-band
+	}
+	u, err := url.Parse(repo.RemoteURL)
+	if err != nil {
+		log15.Warn("Error adding authentication to Azure DevOps repository Git remote URL.", "url", repo.RemoteURL, "error", err)
+		return repo.RemoteURL
+	}
+	u.User = url.UserPassword(cfg.Username, cfg.Token)
+	return u.String()
+}
+
 func bitbucketServerCloneURL(repo *bitbucketserver.Repo, cfg *schema.BitbucketServerConnection) string {
 	var cloneURL string
 	for _, l := range repo.Links.Clone {
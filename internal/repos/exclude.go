@@ -1,8 +1,19 @@
 package repos
 
 import (
+	"context"
 	"regexp"
+	"strconv"
 	"strings"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gobwas/glob"
+
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/azuredevops"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+	"github.com/sourcegraph/sourcegraph/internal/types"
 )
 
 // excludeFunc takes a string and returns true if it should be excluded. In
@@ -13,6 +24,7 @@ type excludeFunc func(string) bool
 type excludeBuilder struct {
 	exact    map[string]struct{}
 	patterns []*regexp.Regexp
+	globs    []glob.Glob
 
 	err error
 }
@@ -42,8 +54,8 @@ func (e *excludeBuilder) Pattern(pattern string) {
 	e.patterns = append(e.patterns, re)
 }
 
-// Build will return an excludeFunc based on the previous calls to Exact and
-// Pattern.
+// Build will return an excludeFunc based on the previous calls to Exact,
+// Pattern and Glob.
 func (e *excludeBuilder) Build() (excludeFunc, error) {
 	return func(name string) bool {
 		if _, ok := e.exact[strings.ToLower(name)]; ok {
@@ -56,6 +68,186 @@ func (e *excludeBuilder) Build() (excludeFunc, error) {
 			}
 		}
 
+		for _, g := range e.globs {
+			if g.Match(name) {
+				return true
+			}
+		}
+
 		return false
 	}, e.err
 }
+
+// Glob will exclude strings matching the glob pattern, where "*" doesn't
+// match "/" and "**" matches any number of path components.
+func (e *excludeBuilder) Glob(pattern string) {
+	if pattern == "" {
+		return
+	}
+
+	g, err := glob.Compile(pattern, '/')
+	if err != nil {
+		e.err = err
+		return
+	}
+	e.globs = append(e.globs, g)
+}
+
+// excludeRule is an item of the "exclude" list of a code host connection.
+// Only the fields handled the same way for every kind of code host are
+// decoded here. Fields specific to a code host, such as a repository ID,
+// are handled by its Source.
+type excludeRule struct {
+	Glob       string `json:"glob"`
+	Regex      string `json:"regex"`
+	Archived   bool   `json:"archived"`
+	Forks      bool   `json:"forks"`
+	LargerThan string `json:"largerThan"`
+}
+
+// repoExcluder excludes sourced repositories by their name on Sourcegraph and
+// by the metadata reported by the code host.
+type repoExcluder struct {
+	name     excludeFunc
+	archived bool
+	forks    bool
+	// maxSize is the size in bytes above which repositories are excluded. It
+	// is 0 if repositories aren't excluded by size.
+	maxSize int64
+}
+
+// newRepoExcluder returns a repoExcluder for the exclude rules in the config
+// of svc, or nil if there are none.
+func newRepoExcluder(svc *types.ExternalService) (*repoExcluder, error) {
+	var c struct {
+		Exclude []excludeRule `json:"exclude"`
+	}
+	if err := jsonc.Unmarshal(svc.Config, &c); err != nil {
+		return nil, err
+	}
+
+	var (
+		eb    excludeBuilder
+		e     repoExcluder
+		found bool
+	)
+	for _, r := range c.Exclude {
+		if r.Glob == "" && r.Regex == "" && !r.Archived && !r.Forks && r.LargerThan == "" {
+			continue
+		}
+		found = true
+
+		eb.Glob(r.Glob)
+		eb.Pattern(r.Regex)
+		e.archived = e.archived || r.Archived
+		e.forks = e.forks || r.Forks
+
+		if r.LargerThan != "" {
+			size, err := ParseRepoSize(r.LargerThan)
+			if err != nil {
+				return nil, err
+			}
+			if e.maxSize == 0 || size < e.maxSize {
+				e.maxSize = size
+			}
+		}
+	}
+
+	if !found {
+		return nil, nil
+	}
+
+	var err error
+	if e.name, err = eb.Build(); err != nil {
+		return nil, err
+	}
+	return &e, nil
+}
+
+// Excluded returns true if r must not be mirrored.
+func (e *repoExcluder) Excluded(r *types.Repo) bool {
+	if e.name(string(r.Name)) {
+		return true
+	}
+	if (e.archived && r.Archived) || (e.forks && r.Fork) {
+		return true
+	}
+	if e.maxSize > 0 {
+		if size, ok := RepoSize(r); ok && size > e.maxSize {
+			return true
+		}
+	}
+	return false
+}
+
+var repoSizeUnits = map[string]int64{
+	"KB": 1 << 10,
+	"MB": 1 << 20,
+	"GB": 1 << 30,
+}
+
+// ParseRepoSize parses a size such as "500MB" into bytes.
+func ParseRepoSize(s string) (int64, error) {
+	s = strings.TrimSpace(s)
+	if len(s) < 2 {
+		return 0, errors.Errorf("invalid repository size %q", s)
+	}
+
+	unit, ok := repoSizeUnits[strings.ToUpper(s[len(s)-2:])]
+	if !ok {
+		return 0, errors.Errorf("invalid repository size %q: unit must be one of KB, MB or GB", s)
+	}
+	n, err := strconv.ParseInt(strings.TrimSpace(s[:len(s)-2]), 10, 64)
+	if err != nil || n < 0 {
+		return 0, errors.Errorf("invalid repository size %q", s)
+	}
+	return n * unit, nil
+}
+
+// RepoSize returns the size in bytes of r as reported by its code host. It
+// returns false if the code host doesn't report it.
+func RepoSize(r *types.Repo) (int64, bool) {
+	switch m := r.Metadata.(type) {
+	case *github.Repository:
+		return int64(m.DiskUsage) << 10, m.DiskUsage > 0
+	case *bitbucketcloud.Repo:
+		return m.Size, m.Size > 0
+	case *azuredevops.Repository:
+		return m.Size, m.Size > 0
+	default:
+		return 0, false
+	}
+}
+
+// excludingSource is a Source that doesn't yield the repositories excluded by
+// a repoExcluder.
+type excludingSource struct {
+	Source
+	excluder *repoExcluder
+}
+
+// withExcludeRules wraps src so that it doesn't yield the repositories
+// excluded by the exclude rules in the config of svc, regardless of whether
+// src supports them. src is returned as is if there are no such rules.
+func withExcludeRules(svc *types.ExternalService, src Source) (Source, error) {
+	e, err := newRepoExcluder(svc)
+	if err != nil || e == nil {
+		return src, err
+	}
+	return &excludingSource{Source: src, excluder: e}, nil
+}
+
+func (s *excludingSource) ListRepos(ctx context.Context, results chan SourceResult) {
+	unfiltered := make(chan SourceResult)
+	go func() {
+		s.Source.ListRepos(ctx, unfiltered)
+		close(unfiltered)
+	}()
+
+	for res := range unfiltered {
+		if res.Err == nil && s.excluder.Excluded(res.Repo) {
+			continue
+		}
+		results <- res
+	}
+}
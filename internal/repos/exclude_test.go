@@ -0,0 +1,145 @@
+package repos
+
+import (
+	"context"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/bitbucketcloud"
+	"github.com/sourcegraph/sourcegraph/internal/extsvc/github"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestRepoExcluder(t *testing.T) {
+	repos := []*types.Repo{
+		{Name: "git.example.com/org/repo"},
+		{Name: "git.example.com/org/sub/repo"},
+		{Name: "git.example.com/org/repo-mirror"},
+		{Name: "git.example.com/other/repo", Archived: true},
+		{Name: "git.example.com/other/fork", Fork: true},
+		{Name: "github.com/org/big", Metadata: &github.Repository{DiskUsage: 2048}},
+		{Name: "github.com/org/small", Metadata: &github.Repository{DiskUsage: 512}},
+		{Name: "bitbucket.org/org/big", Metadata: &bitbucketcloud.Repo{Size: 2 << 20}},
+		{Name: "bitbucket.org/org/unknown", Metadata: &bitbucketcloud.Repo{}},
+	}
+
+	for _, tc := range []struct {
+		name   string
+		config string
+		want   []api.RepoName
+	}{
+		{
+			name:   "no rules",
+			config: `{"exclude": [{"name": "org/repo"}]}`,
+		},
+		{
+			name:   "glob",
+			config: `{"exclude": [{"glob": "git.example.com/org/*"}]}`,
+			want:   []api.RepoName{"git.example.com/org/repo", "git.example.com/org/repo-mirror"},
+		},
+		{
+			name:   "glob matching path components",
+			config: `{"exclude": [{"glob": "git.example.com/org/**"}]}`,
+			want:   []api.RepoName{"git.example.com/org/repo", "git.example.com/org/sub/repo", "git.example.com/org/repo-mirror"},
+		},
+		{
+			name: "regex and flags",
+			// The config is JSONC, so trailing commas are allowed.
+			config: `{"exclude": [
+				{"regex": "-mirror$"},
+				{"archived": true},
+				{"forks": true},
+			]}`,
+			want: []api.RepoName{"git.example.com/org/repo-mirror", "git.example.com/other/repo", "git.example.com/other/fork"},
+		},
+		{
+			name:   "size",
+			config: `{"exclude": [{"largerThan": "1MB"}]}`,
+			want:   []api.RepoName{"github.com/org/big", "bitbucket.org/org/big"},
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			e, err := newRepoExcluder(&types.ExternalService{Kind: extsvc.KindOther, Config: tc.config})
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			if tc.want == nil {
+				if e != nil {
+					t.Fatalf("want no excluder, got %+v", e)
+				}
+				return
+			}
+
+			var have []api.RepoName
+			for _, r := range repos {
+				if e.Excluded(r) {
+					have = append(have, r.Name)
+				}
+			}
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("excluded repos mismatch (-want +have):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("invalid", func(t *testing.T) {
+		for _, config := range []string{
+			`{"exclude": [{"regex": "("}]}`,
+			`{"exclude": [{"glob": "[a"}]}`,
+			`{"exclude": [{"largerThan": "1TB"}]}`,
+		} {
+			if _, err := newRepoExcluder(&types.ExternalService{Config: config}); err == nil {
+				t.Errorf("%s: want error", config)
+			}
+		}
+	})
+}
+
+func TestParseRepoSize(t *testing.T) {
+	for in, want := range map[string]int64{
+		"0KB":    0,
+		"10KB":   10 << 10,
+		"500 MB": 500 << 20,
+		"2gb":    2 << 30,
+	} {
+		have, err := ParseRepoSize(in)
+		if err != nil {
+			t.Errorf("%q: %s", in, err)
+		} else if have != want {
+			t.Errorf("%q: want %d, have %d", in, want, have)
+		}
+	}
+
+	for _, in := range []string{"", "MB", "-1MB", "1.5GB", "100"} {
+		if _, err := ParseRepoSize(in); err == nil {
+			t.Errorf("%q: want error", in)
+		}
+	}
+}
+
+func TestWithExcludeRules(t *testing.T) {
+	svc := &types.ExternalService{
+		ID:     1,
+		Kind:   extsvc.KindOther,
+		Config: `{"url": "https://git.example.com", "repos": ["a", "b"], "exclude": [{"glob": "*/b"}]}`,
+	}
+	src, err := withExcludeRules(svc, NewFakeSource(svc, nil,
+		&types.Repo{Name: "git.example.com/a"},
+		&types.Repo{Name: "git.example.com/b"},
+	))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	repos, err := listAll(context.Background(), src)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if have, want := types.Repos(repos).Names(), []string{"git.example.com/a"}; !cmp.Equal(want, have) {
+		t.Fatalf("want %v, have %v", want, have)
+	}
+}
@@ -0,0 +1,77 @@
+package repos
+
+import (
+	"regexp"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/jsonc"
+)
+
+// FetchCredential is a credential that gitserver uses to clone and fetch a repository instead of
+// the credentials of the code host connection that discovered it. It comes from the
+// fetchCredentials of the GitHub, GitLab, Bitbucket Server and Bitbucket Cloud connections.
+type FetchCredential struct {
+	// Username, if set, replaces the username of the connection.
+	Username string
+	// Token is used for clones over HTTPS.
+	Token string
+	// SSHKey is the PEM-encoded private key used for clones over SSH.
+	SSHKey string
+}
+
+// FetchCredentialFor returns the first of the fetchCredentials in the code host connection config
+// that applies to repo and has a credential for the gitURLType of the connection, or nil if there
+// is none.
+func FetchCredentialFor(config string, repo api.RepoName) (*FetchCredential, error) {
+	var c struct {
+		GitURLType       string `json:"gitURLType"`
+		FetchCredentials []struct {
+			Repos    []string `json:"repos"`
+			Username string   `json:"username"`
+			Token    string   `json:"token"`
+			SSHKey   string   `json:"sshKey"`
+		} `json:"fetchCredentials"`
+	}
+	if err := jsonc.Unmarshal(config, &c); err != nil {
+		return nil, errors.Wrap(err, "loading service configuration")
+	}
+
+	for i, cred := range c.FetchCredentials {
+		if c.GitURLType == "ssh" && cred.SSHKey == "" || c.GitURLType != "ssh" && cred.Token == "" {
+			continue
+		}
+
+		ok, err := matchesAnyPattern(string(repo), cred.Repos)
+		if err != nil {
+			return nil, errors.Wrapf(err, "fetchCredentials[%d]", i)
+		}
+		if ok {
+			return &FetchCredential{
+				Username: cred.Username,
+				Token:    cred.Token,
+				SSHKey:   cred.SSHKey,
+			}, nil
+		}
+	}
+	return nil, nil
+}
+
+// matchesAnyPattern reports whether name matches any of the regular expressions in patterns. An
+// empty list of patterns matches all names.
+func matchesAnyPattern(name string, patterns []string) (bool, error) {
+	if len(patterns) == 0 {
+		return true, nil
+	}
+	for _, pattern := range patterns {
+		re, err := regexp.Compile(pattern)
+		if err != nil {
+			return false, err
+		}
+		if re.MatchString(name) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
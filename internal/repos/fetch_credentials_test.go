@@ -0,0 +1,80 @@
+package repos
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestFetchCredentialFor(t *testing.T) {
+	const config = `{
+		// The discovery token.
+		"token": "discovery",
+		"fetchCredentials": [
+			{"repos": ["^github\\.com/myorg/secret-"], "sshKey": "secret-key"},
+			{"repos": ["^github\\.com/myorg/"], "token": "myorg", "sshKey": "myorg-key"},
+			{"token": "default"}
+		]
+	}`
+
+	tests := []struct {
+		name       string
+		repo       api.RepoName
+		gitURLType string
+		want       *FetchCredential
+	}{
+		{
+			name: "first match",
+			repo: "github.com/myorg/foo",
+			want: &FetchCredential{Token: "myorg", SSHKey: "myorg-key"},
+		},
+		{
+			name: "skips entries without token",
+			repo: "github.com/myorg/secret-foo",
+			want: &FetchCredential{Token: "myorg", SSHKey: "myorg-key"},
+		},
+		{
+			name:       "ssh",
+			repo:       "github.com/myorg/secret-foo",
+			gitURLType: "ssh",
+			want:       &FetchCredential{SSHKey: "secret-key"},
+		},
+		{
+			name: "entry without repos",
+			repo: "github.com/other/foo",
+			want: &FetchCredential{Token: "default"},
+		},
+		{
+			name:       "no match",
+			repo:       "github.com/other/foo",
+			gitURLType: "ssh",
+			want:       nil,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			c := config
+			if test.gitURLType != "" {
+				c = `{"gitURLType": "` + test.gitURLType + `",` + config[1:]
+			}
+
+			got, err := FetchCredentialFor(c, test.repo)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(test.want, got); diff != "" {
+				t.Errorf("unexpected credential (-want +got):\n%s", diff)
+			}
+		})
+	}
+
+	t.Run("invalid pattern", func(t *testing.T) {
+		_, err := FetchCredentialFor(`{"fetchCredentials": [{"repos": ["("], "token": "t"}]}`, "github.com/foo/bar")
+		if err == nil {
+			t.Fatal("expected error")
+		}
+	})
+}
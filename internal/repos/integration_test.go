@@ -40,6 +40,7 @@ func TestIntegration(t *testing.T) {
 		{"DBStore/EnqueueSingleSyncJob", testStoreEnqueueSingleSyncJob},
 		{"DBStore/ListExternalRepoSpecs", testStoreListExternalRepoSpecs},
 		{"DBStore/SetClonedRepos", testStoreSetClonedRepos},
+		{"DBStore/RestoreRepo", testStoreRestoreRepo},
 		{"DBStore/CountNotClonedRepos", testStoreCountNotClonedRepos},
 		{"DBStore/Syncer/SyncWorker", testSyncWorkerPlumbing},
 
@@ -73,11 +74,16 @@ func TestIntegration(t *testing.T) {
 		{"DBStore/Syncer/Batch/NameConflictOnRename", testBatchNameOnConflictOnRename},
 		{"DBStore/Syncer/Streaming/NameConflictOnRename", testStreamingNameOnConflictOnRename},
 
+		{"DBStore/Syncer/Batch/RenameKeepsRepo", testBatchRenameKeepsRepo},
+		{"DBStore/Syncer/Streaming/RenameKeepsRepo", testStreamingRenameKeepsRepo},
+
 		{"DBStore/Syncer/Batch/ConflictingSyncers", testConflictingBatchSyncers},
 		{"DBStore/Syncer/Streaming/ConflictingSyncers", testConflictingStreamingSyncers},
 
 		{"DBStore/Syncer/Batch/SyncRepoMaintainsOtherSources", testBatchSyncRepoMaintainsOtherSources},
 		{"DBStore/Syncer/Streaming/SyncRepoMaintainsOtherSources", testStreamingSyncRepoMaintainsOtherSources},
+
+		{"DBStore/Syncer/SyncExternalServiceDryRun", testSyncExternalServiceDryRun},
 	} {
 		t.Run(tc.name, func(t *testing.T) {
 			db := dbtest.NewDB(t, *dsn)
@@ -89,6 +89,26 @@ var (
 		Name: "src_repoupdater_sched_update_queue_length",
 		Help: "The number of repositories that are currently queued for update",
 	})
+
+	upsertReposChunks = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_repoupdater_store_upsert_repos_chunks_total",
+		Help: "Total number of chunks of repositories written by UpsertRepos",
+	}, []string{tagSuccess})
+
+	upsertReposChunkRetries = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "src_repoupdater_store_upsert_repos_chunk_retries_total",
+		Help: "Total number of retried chunks of repositories in UpsertRepos",
+	})
+
+	upsertedRepos = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_repoupdater_store_upserted_repos_total",
+		Help: "Total number of repositories written by UpsertRepos",
+	}, []string{tagSuccess})
+
+	renamedRepos = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "src_repoupdater_syncer_renamed_repos_total",
+		Help: "Total number of repositories renamed in place by a sync",
+	}, []string{tagFamily})
 )
 
 func MustRegisterMetrics(db dbutil.DB, sourcegraphDotCom bool) {
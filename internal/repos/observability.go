@@ -110,8 +110,10 @@ type StoreMetrics struct {
 	ListExternalRepoSpecs           *metrics.OperationMetrics
 	GetExternalService              *metrics.OperationMetrics
 	SetClonedRepos                  *metrics.OperationMetrics
+	RestoreRepo                     *metrics.OperationMetrics
 	CountNotClonedRepos             *metrics.OperationMetrics
 	CountUserAddedRepos             *metrics.OperationMetrics
+	UserAddedReposSize              *metrics.OperationMetrics
 	EnqueueSyncJobs                 *metrics.OperationMetrics
 }
 
@@ -130,6 +132,8 @@ func (sm StoreMetrics) MustRegister(r prometheus.Registerer) {
 		sm.UpsertSources,
 		sm.GetExternalService,
 		sm.SetClonedRepos,
+		sm.RestoreRepo,
+		sm.UserAddedReposSize,
 	} {
 		r.MustRegister(om.Count)
 		r.MustRegister(om.Duration)
@@ -295,6 +299,20 @@ func NewStoreMetrics() StoreMetrics {
 				Help: "Total number of errors when setting cloned repos",
 			}, []string{}),
 		},
+		RestoreRepo: &metrics.OperationMetrics{
+			Duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Name: "src_repoupdater_store_restore_repo_duration_seconds",
+				Help: "Time spent restoring deleted repos",
+			}, []string{}),
+			Count: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "src_repoupdater_store_restore_repo_total",
+				Help: "Total number of restore repo calls",
+			}, []string{}),
+			Errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "src_repoupdater_store_restore_repo_errors_total",
+				Help: "Total number of errors when restoring deleted repos",
+			}, []string{}),
+		},
 		CountNotClonedRepos: &metrics.OperationMetrics{
 			Duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
 				Name: "src_repoupdater_store_count_not_cloned_repos_duration_seconds",
@@ -323,5 +341,19 @@ func NewStoreMetrics() StoreMetrics {
 				Help: "Total number of errors when counting user added repos",
 			}, []string{}),
 		},
+		UserAddedReposSize: &metrics.OperationMetrics{
+			Duration: prometheus.NewHistogramVec(prometheus.HistogramOpts{
+				Name: "src_repoupdater_store_user_added_repos_size_duration_seconds",
+				Help: "Time spent computing the total clone size of the repos added by a user",
+			}, []string{}),
+			Count: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "src_repoupdater_store_user_added_repos_size_total",
+				Help: "Total number of calls computing the total clone size of the repos added by a user",
+			}, []string{}),
+			Errors: prometheus.NewCounterVec(prometheus.CounterOpts{
+				Name: "src_repoupdater_store_user_added_repos_size_errors_total",
+				Help: "Total number of errors when computing the total clone size of the repos added by a user",
+			}, []string{}),
+		},
 	}
 }
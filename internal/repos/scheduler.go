@@ -103,6 +103,12 @@ const (
 //
 // A worker continuously dequeues repos and sends updates to gitserver, but its concurrency
 // is limited by the gitMaxConcurrentClones site configuration.
+//
+// Repos in the queue are ordered fairly across the external services they
+// belong to, so that the initial sync of a large code host connection does not
+// starve smaller ones. The share of updates and the number of concurrent
+// updates per external service can be configured with the
+// gitExternalServiceUpdateLimits site configuration.
 type updateScheduler struct {
 	updateQueue *updateQueue
 	schedule    *schedule
@@ -114,6 +120,10 @@ type updateScheduler struct {
 type configuredRepo struct {
 	ID   api.RepoID
 	Name api.RepoName
+
+	// ExternalServiceID is the external service the repo is queued under
+	// for fairness in the updateQueue. It is zero if unknown.
+	ExternalServiceID int64
 }
 
 // notifyChanBuffer controls the buffer size of notification channels.
@@ -126,6 +136,8 @@ func NewUpdateScheduler() *updateScheduler {
 	return &updateScheduler{
 		updateQueue: &updateQueue{
 			index:         make(map[api.RepoID]*repoUpdate),
+			lastSeq:       make(map[int64]float64),
+			updating:      make(map[int64]int),
 			notifyEnqueue: make(chan struct{}, notifyChanBuffer),
 		},
 		schedule: &schedule{
@@ -238,6 +250,26 @@ func getCustomInterval(c *conf.Unified, repoName string) time.Duration {
 	return 0
 }
 
+// getExternalServiceUpdateLimit returns the weight and the maximum number of
+// concurrent updates configured for the given external service. A
+// maxConcurrent of 0 means that no per external service limit applies.
+func getExternalServiceUpdateLimit(c *conf.Unified, externalServiceID int64) (weight, maxConcurrent int) {
+	weight = 1
+	if c == nil {
+		return weight, 0
+	}
+	for _, limit := range c.GitExternalServiceUpdateLimits {
+		if int64(limit.ExternalServiceID) != externalServiceID {
+			continue
+		}
+		if limit.Weight > 0 {
+			weight = limit.Weight
+		}
+		return weight, limit.MaxConcurrentUpdates
+	}
+	return weight, 0
+}
+
 // requestRepoUpdate sends a request to gitserver to request an update.
 var requestRepoUpdate = func(ctx context.Context, repo configuredRepo, since time.Duration) (*gitserverprotocol.RepoUpdateResponse, error) {
 	return gitserver.DefaultClient.RequestRepoUpdate(ctx, repo.Name, since)
@@ -362,18 +394,35 @@ func configuredRepoFromRepo(r *types.Repo) configuredRepo {
 		Name: r.Name,
 	}
 
+	// A repo can belong to multiple external services. We pick the one with
+	// the lowest ID so that the repo is consistently queued under the same
+	// external service.
+	for _, id := range r.ExternalServiceIDs() {
+		if repo.ExternalServiceID == 0 || id < repo.ExternalServiceID {
+			repo.ExternalServiceID = id
+		}
+	}
+
 	return repo
 }
 
 // UpdateOnce causes a single update of the given repository.
 // It neither adds nor removes the repo from the schedule.
-func (s *updateScheduler) UpdateOnce(id api.RepoID, name api.RepoName) {
+// It returns true if an update of the repo was already queued or
+// in progress, in which case no additional update is queued.
+func (s *updateScheduler) UpdateOnce(id api.RepoID, name api.RepoName) (deduplicated bool) {
 	repo := configuredRepo{
 		ID:   id,
 		Name: name,
 	}
 	schedManualFetch.Inc()
+
+	s.updateQueue.mu.Lock()
+	_, deduplicated = s.updateQueue.index[id]
+	s.updateQueue.mu.Unlock()
+
 	s.updateQueue.enqueue(repo, priorityHigh)
+	return deduplicated
 }
 
 // DebugDump returns the state of the update scheduler for debugging.
@@ -455,6 +504,7 @@ func (s *updateScheduler) ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedu
 			Index:    update.Index,
 			Total:    len(s.updateQueue.index),
 			Updating: update.Updating,
+			Position: s.updateQueue.position(update),
 		}
 	}
 	s.updateQueue.mu.Unlock()
@@ -462,6 +512,19 @@ func (s *updateScheduler) ScheduleInfo(id api.RepoID) *protocol.RepoUpdateSchedu
 	return &result
 }
 
+// Stats returns the number of repos in the schedule and in the update queue.
+func (s *updateScheduler) Stats() (scheduled, queued int) {
+	s.schedule.mu.Lock()
+	scheduled = len(s.schedule.index)
+	s.schedule.mu.Unlock()
+
+	s.updateQueue.mu.Lock()
+	queued = len(s.updateQueue.index)
+	s.updateQueue.mu.Unlock()
+
+	return scheduled, queued
+}
+
 // updateQueue is a priority queue of repos to update.
 // A repo can't have more than one location in the queue.
 type updateQueue struct {
@@ -470,7 +533,14 @@ type updateQueue struct {
 	heap  []*repoUpdate
 	index map[api.RepoID]*repoUpdate
 
-	seq uint64
+	// virtualTime is the sequence number of the most recently acquired
+	// update. Together with lastSeq it is used to assign sequence numbers
+	// that interleave updates of different external services fairly.
+	virtualTime float64
+	// lastSeq is the last sequence number assigned per external service.
+	lastSeq map[int64]float64
+	// updating is the number of updating repos per external service.
+	updating map[int64]int
 
 	// The queue performs a non-blocking send on this channel
 	// when a new value is enqueued so that the update loop
@@ -489,9 +559,15 @@ const (
 type repoUpdate struct {
 	Repo     configuredRepo
 	Priority priority
-	Seq      uint64 // the sequence number of the update
-	Updating bool   // whether the repo has been acquired for update
-	Index    int    `json:"-"` // the index in the heap
+	Seq      float64 // the fair sequence number of the update
+	Updating bool    // whether the repo has been acquired for update
+	Index    int     `json:"-"` // the index in the heap
+
+	// updatingExternalServiceID is the external service the update is counted
+	// against in updateQueue.updating while it is updating. It is recorded when
+	// the update is acquired so that the count is released correctly even if
+	// the external service of the repo changes in the meantime.
+	updatingExternalServiceID int64
 }
 
 func (q *updateQueue) reset() {
@@ -500,7 +576,9 @@ func (q *updateQueue) reset() {
 
 	q.heap = q.heap[:0]
 	q.index = map[api.RepoID]*repoUpdate{}
-	q.seq = 0
+	q.virtualTime = 0
+	q.lastSeq = map[int64]float64{}
+	q.updating = map[int64]int{}
 	q.notifyEnqueue = make(chan struct{}, notifyChanBuffer)
 
 	schedUpdateQueueLength.Set(0)
@@ -535,6 +613,10 @@ func (q *updateQueue) enqueue(repo configuredRepo, p priority) (updated bool) {
 		return false
 	}
 
+	if repo.ExternalServiceID == 0 {
+		// Keep the repo queued under the external service we already know.
+		repo.ExternalServiceID = update.Repo.ExternalServiceID
+	}
 	update.Repo = repo
 	if p <= update.Priority {
 		// Repo is already in the queue with at least as good priority.
@@ -542,19 +624,61 @@ func (q *updateQueue) enqueue(repo configuredRepo, p priority) (updated bool) {
 	}
 
 	// Repo is in the queue at a lower priority.
-	update.Priority = p      // bump the priority
-	update.Seq = q.nextSeq() // put it after all existing updates with this priority
+	update.Priority = p                            // bump the priority
+	update.Seq = q.nextSeq(repo.ExternalServiceID) // put it after all existing updates of its external service with this priority
 	heap.Fix(q, update.Index)
 	notify(q.notifyEnqueue)
 
 	return true
 }
 
-// nextSeq increments and returns the next sequence number.
+// nextSeq returns the next sequence number for an update of the given
+// external service.
+//
+// Sequence numbers are virtual finish times as used by weighted fair queueing:
+// each external service advances its own sequence by 1/weight per update,
+// starting from the sequence of the most recently acquired update. Updates of
+// an external service with many queued repos are therefore interleaved with
+// those of other external services instead of being processed in discovery
+// order. With a single external service this degrades to a plain counter.
+//
+// The caller must hold the lock on q.mu.
+func (q *updateQueue) nextSeq(externalServiceID int64) float64 {
+	weight, _ := getExternalServiceUpdateLimit(conf.Get(), externalServiceID)
+
+	seq := q.lastSeq[externalServiceID]
+	if seq < q.virtualTime {
+		seq = q.virtualTime
+	}
+	seq += 1 / float64(weight)
+
+	q.lastSeq[externalServiceID] = seq
+	return seq
+}
+
+// atLimit returns true if the given external service has reached its
+// configured maximum number of concurrent updates.
 // The caller must hold the lock on q.mu.
-func (q *updateQueue) nextSeq() uint64 {
-	q.seq++
-	return q.seq
+func (q *updateQueue) atLimit(externalServiceID int64) bool {
+	_, maxConcurrent := getExternalServiceUpdateLimit(conf.Get(), externalServiceID)
+	return maxConcurrent > 0 && q.updating[externalServiceID] >= maxConcurrent
+}
+
+// position returns the number of repos that will be updated before the given
+// update, taking priority and fairness across external services into account.
+// It returns 0 for repos that are updating.
+// The caller must hold the lock on q.mu.
+func (q *updateQueue) position(update *repoUpdate) int {
+	if update.Updating {
+		return 0
+	}
+	n := 0
+	for _, other := range q.heap {
+		if other != update && !other.Updating && other.less(update) {
+			n++
+		}
+	}
+	return n
 }
 
 // remove removes the repo from the queue if the repo.Updating matches the updating argument.
@@ -567,12 +691,27 @@ func (q *updateQueue) remove(repo configuredRepo, updating bool) (removed bool)
 	defer q.mu.Unlock()
 
 	update := q.index[repo.ID]
-	if update != nil && update.Updating == updating {
-		heap.Remove(q, update.Index)
-		return true
+	if update == nil || update.Updating != updating {
+		return false
 	}
 
-	return false
+	heap.Remove(q, update.Index)
+
+	if updating {
+		id := update.updatingExternalServiceID
+		wasAtLimit := q.atLimit(id)
+		q.updating[id]--
+		if q.updating[id] <= 0 {
+			delete(q.updating, id)
+		}
+		if wasAtLimit && len(q.heap) > 0 {
+			// Repos of this external service may have been skipped while it was
+			// at its limit, so wake up the update loop.
+			notify(q.notifyEnqueue)
+		}
+	}
+
+	return true
 }
 
 // acquireNext acquires the next repo for update.
@@ -589,11 +728,50 @@ func (q *updateQueue) acquireNext() (configuredRepo, bool) {
 		// Everything in the queue is already updating.
 		return configuredRepo{}, false
 	}
+	if q.atLimit(update.Repo.ExternalServiceID) {
+		// The external service of the next repo is at its limit of concurrent
+		// updates. Fall back to the best repo of another external service.
+		update = nil
+		for _, candidate := range q.heap {
+			if candidate.Updating || q.atLimit(candidate.Repo.ExternalServiceID) {
+				continue
+			}
+			if update == nil || candidate.less(update) {
+				update = candidate
+			}
+		}
+		if update == nil {
+			return configuredRepo{}, false
+		}
+	}
 	update.Updating = true
+	update.updatingExternalServiceID = update.Repo.ExternalServiceID
+	q.updating[update.updatingExternalServiceID]++
+	if update.Seq > q.virtualTime {
+		q.virtualTime = update.Seq
+	}
 	heap.Fix(q, update.Index)
 	return update.Repo, true
 }
 
+// less returns true if u should be updated before other.
+func (u *repoUpdate) less(other *repoUpdate) bool {
+	if u.Updating != other.Updating {
+		// Repos that are already updating are sorted last.
+		return other.Updating
+	}
+	if u.Priority != other.Priority {
+		// We want Pop to give us the highest, not lowest, priority so we use greater than here.
+		return u.Priority > other.Priority
+	}
+	// Queue semantics for items with the same priority.
+	if u.Seq != other.Seq {
+		return u.Seq < other.Seq
+	}
+	// Updates of different external services can share a sequence number.
+	return u.Repo.ExternalServiceID < other.Repo.ExternalServiceID
+}
+
 // The following methods implement heap.Interface based on the priority queue example:
 // https://golang.org/pkg/container/heap/#example__priorityQueue
 // These methods are not safe for concurrent use. Therefore, it is the caller's
@@ -607,18 +785,7 @@ func (q *updateQueue) Len() int {
 }
 
 func (q *updateQueue) Less(i, j int) bool {
-	qi := q.heap[i]
-	qj := q.heap[j]
-	if qi.Updating != qj.Updating {
-		// Repos that are already updating are sorted last.
-		return qj.Updating
-	}
-	if qi.Priority != qj.Priority {
-		// We want Pop to give us the highest, not lowest, priority so we use greater than here.
-		return qi.Priority > qj.Priority
-	}
-	// Queue semantics for items with the same priority.
-	return qi.Seq < qj.Seq
+	return q.heap[i].less(q.heap[j])
 }
 
 func (q *updateQueue) Swap(i, j int) {
@@ -631,7 +798,7 @@ func (q *updateQueue) Push(x interface{}) {
 	n := len(q.heap)
 	item := x.(*repoUpdate)
 	item.Index = n
-	item.Seq = q.nextSeq()
+	item.Seq = q.nextSeq(item.Repo.ExternalServiceID)
 	q.heap = append(q.heap, item)
 	q.index[item.Repo.ID] = item
 }
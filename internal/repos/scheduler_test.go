@@ -538,6 +538,120 @@ func TestUpdateQueue_acquireNext(t *testing.T) {
 	}
 }
 
+func TestUpdateQueue_fairness(t *testing.T) {
+	a1 := configuredRepo{ID: 1, Name: "a1", ExternalServiceID: 1}
+	a2 := configuredRepo{ID: 2, Name: "a2", ExternalServiceID: 1}
+	a3 := configuredRepo{ID: 3, Name: "a3", ExternalServiceID: 1}
+	b1 := configuredRepo{ID: 4, Name: "b1", ExternalServiceID: 2}
+	b2 := configuredRepo{ID: 5, Name: "b2", ExternalServiceID: 2}
+
+	enqueueAll := func(s *updateScheduler) {
+		for _, repo := range []configuredRepo{a1, a2, a3, b1, b2} {
+			s.updateQueue.enqueue(repo, priorityLow)
+		}
+	}
+
+	acquireAll := func(s *updateScheduler) []api.RepoName {
+		var names []api.RepoName
+		for {
+			repo, ok := s.updateQueue.acquireNext()
+			if !ok {
+				return names
+			}
+			names = append(names, repo.Name)
+		}
+	}
+
+	t.Run("interleaves external services", func(t *testing.T) {
+		_, stop := startRecording()
+		defer stop()
+
+		s := NewUpdateScheduler()
+		enqueueAll(s)
+
+		want := []api.RepoName{"a1", "b1", "a2", "b2", "a3"}
+		if diff := cmp.Diff(want, acquireAll(s)); diff != "" {
+			t.Fatalf("unexpected acquire order (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("weight", func(t *testing.T) {
+		_, stop := startRecording()
+		defer stop()
+
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+			GitExternalServiceUpdateLimits: []*schema.ExternalServiceUpdateLimit{
+				{ExternalServiceID: 1, Weight: 2},
+			},
+		}})
+		defer conf.Mock(nil)
+
+		s := NewUpdateScheduler()
+		enqueueAll(s)
+
+		want := []api.RepoName{"a1", "a2", "b1", "a3", "b2"}
+		if diff := cmp.Diff(want, acquireAll(s)); diff != "" {
+			t.Fatalf("unexpected acquire order (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("max concurrent updates", func(t *testing.T) {
+		r, stop := startRecording()
+		defer stop()
+
+		conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+			GitExternalServiceUpdateLimits: []*schema.ExternalServiceUpdateLimit{
+				{ExternalServiceID: 1, MaxConcurrentUpdates: 1},
+			},
+		}})
+		defer conf.Mock(nil)
+
+		s := NewUpdateScheduler()
+		enqueueAll(s)
+		r.notifications = nil
+
+		want := []api.RepoName{"a1", "b1", "b2"}
+		if diff := cmp.Diff(want, acquireAll(s)); diff != "" {
+			t.Fatalf("unexpected acquire order (-want +got):\n%s", diff)
+		}
+
+		if info := s.ScheduleInfo(a3.ID); info.Queue == nil || info.Queue.Position != 1 {
+			t.Fatalf("unexpected queue state for a3: %+v", info.Queue)
+		}
+
+		// Finishing the update of a1 frees up a slot for external service 1
+		// and wakes up the update loop.
+		s.updateQueue.remove(a1, true)
+		if len(r.notifications) != 1 {
+			t.Fatalf("expected 1 notification, got %d", len(r.notifications))
+		}
+
+		want = []api.RepoName{"a2"}
+		if diff := cmp.Diff(want, acquireAll(s)); diff != "" {
+			t.Fatalf("unexpected acquire order (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("external service changes while updating", func(t *testing.T) {
+		_, stop := startRecording()
+		defer stop()
+
+		s := NewUpdateScheduler()
+		s.updateQueue.enqueue(a1, priorityLow)
+		if _, ok := s.updateQueue.acquireNext(); !ok {
+			t.Fatal("expected to acquire a1")
+		}
+
+		// The repo is moved to another external service while it is updating.
+		s.updateQueue.index[a1.ID].Repo.ExternalServiceID = 2
+		s.updateQueue.remove(a1, true)
+
+		if diff := cmp.Diff(map[int64]int{}, s.updateQueue.updating); diff != "" {
+			t.Fatalf("unexpected updating counts (-want +got):\n%s", diff)
+		}
+	})
+}
+
 func setupInitialQueue(s *updateScheduler, initialQueue []*repoUpdate) {
 	for _, update := range initialQueue {
 		heap.Push(s.updateQueue, update)
@@ -559,6 +673,30 @@ func verifyQueue(t *testing.T, s *updateScheduler, expected []*repoUpdate) {
 	}
 }
 
+func TestUpdateScheduler_UpdateOnce(t *testing.T) {
+	_, stop := startRecording()
+	defer stop()
+
+	s := NewUpdateScheduler()
+
+	if s.UpdateOnce(1, "a") {
+		t.Errorf("expected first update of a to not be deduplicated")
+	}
+	if !s.UpdateOnce(1, "a") {
+		t.Errorf("expected queued update of a to be deduplicated")
+	}
+
+	if _, ok := s.updateQueue.acquireNext(); !ok {
+		t.Fatalf("expected to acquire a")
+	}
+	if !s.UpdateOnce(1, "a") {
+		t.Errorf("expected in-progress update of a to be deduplicated")
+	}
+	if s.UpdateOnce(2, "b") {
+		t.Errorf("expected first update of b to not be deduplicated")
+	}
+}
+
 func Test_updateScheduler_UpdateFromDiff(t *testing.T) {
 	a := configuredRepo{ID: 1, Name: "a"}
 	b := configuredRepo{ID: 2, Name: "b"}
@@ -12,6 +12,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/hashicorp/go-multierror"
 	"github.com/inconshreveable/log15"
+	"github.com/jackc/pgconn"
 	"github.com/keegancsmith/sqlf"
 	"github.com/lib/pq"
 	"github.com/opentracing/opentracing-go"
@@ -39,6 +40,9 @@ type Store struct {
 	RepoStore *database.RepoStore
 	// ExternalServiceStore is a database.ExternalServiceStore using the same database handle.
 	ExternalServiceStore *database.ExternalServiceStore
+	// UpsertReposChunkSize is the number of repos written per transaction by
+	// UpsertRepos. Defaults to 500.
+	UpsertReposChunkSize int
 	// Used to mock calls to certain methods.
 	Mocks MockStore
 
@@ -66,6 +70,7 @@ func (s *Store) With(other basestore.ShareableStore) *Store {
 		Log:                  s.Log,
 		Metrics:              s.Metrics,
 		Tracer:               s.Tracer,
+		UpsertReposChunkSize: s.UpsertReposChunkSize,
 		Mocks:                s.Mocks,
 	}
 }
@@ -96,6 +101,7 @@ func (s *Store) Transact(ctx context.Context) (stx *Store, err error) {
 		Log:                  s.Log,
 		Metrics:              s.Metrics,
 		Tracer:               s.Tracer,
+		UpsertReposChunkSize: s.UpsertReposChunkSize,
 		Mocks:                s.Mocks,
 		txtrace:              tr,
 		txctx:                ctx,
@@ -428,6 +434,37 @@ SELECT COUNT(DISTINCT(repo_id))
 FROM external_service_repos
 WHERE user_id IS NOT NULL`
 
+// UserAddedReposSize returns the total size in bytes of the clones of the repos
+// that have been added by external services owned by the given user. Repos
+// that haven't been cloned yet don't count.
+func (s *Store) UserAddedReposSize(ctx context.Context, userID int32) (size uint64, err error) {
+	tr, ctx := s.trace(ctx, "Store.UserAddedReposSize")
+	defer func(began time.Time) {
+		secs := time.Since(began).Seconds()
+
+		tr.LogFields(otlog.Int32("user-id", userID))
+		s.Metrics.UserAddedReposSize.Observe(secs, 1, &err)
+		logging.Log(s.Log, "store.user-added-repos-size", &err, "size", size, "user-id", userID)
+
+		tr.SetError(err)
+		tr.Finish()
+	}(time.Now())
+
+	err = s.QueryRow(ctx, sqlf.Sprintf(userAddedReposSizeQueryFmtstr, userID)).Scan(&size)
+	return size, err
+}
+
+const userAddedReposSizeQueryFmtstr = `
+-- source: internal/repos/store.go:DBStore.UserAddedReposSize
+SELECT COALESCE(SUM(repo_size_bytes), 0)
+FROM gitserver_repos
+WHERE repo_id IN (
+	SELECT repo_id
+	FROM external_service_repos
+	WHERE user_id = %s
+)
+`
+
 // a paginatedQuery returns a query with the given pagination
 // parameters
 type paginatedQuery func(cursor, limit int64) *sqlf.Query
@@ -783,6 +820,12 @@ RETURNING updated_at
 // each given Repo is set on inserts. The cloned column is not updated by this
 // function. This method does NOT update sources in the external_services_repo
 // table. Use UpsertSources for that purpose.
+//
+// Repos are written in chunks of UpsertReposChunkSize, each in its own
+// transaction (or savepoint, if the store is already in a transaction). A
+// chunk that fails is retried a few times. If chunks still fail, the others
+// are written and an *UpsertReposError describing the failed chunks is
+// returned.
 func (s *Store) UpsertRepos(ctx context.Context, repos ...*types.Repo) (err error) {
 	if s.Mocks.UpsertRepos != nil {
 		return s.Mocks.UpsertRepos(ctx, repos...)
@@ -805,6 +848,140 @@ func (s *Store) UpsertRepos(ctx context.Context, repos ...*types.Repo) (err erro
 		return nil
 	}
 
+	// Deletes run before updates, and updates before inserts, so that names
+	// freed by one chunk can be taken by the repos of a later chunk.
+	var deletes, updates, inserts []*types.Repo
+	for _, r := range repos {
+		switch {
+		case r.IsDeleted():
+			deletes = append(deletes, r)
+		case r.ID != 0:
+			updates = append(updates, r)
+		default:
+			inserts = append(inserts, r)
+		}
+	}
+
+	size := s.UpsertReposChunkSize
+	if size <= 0 {
+		size = defaultUpsertReposChunkSize
+	}
+
+	var chunks [][]*types.Repo
+	for _, rs := range [][]*types.Repo{deletes, updates, inserts} {
+		for len(rs) > 0 {
+			n := size
+			if n > len(rs) {
+				n = len(rs)
+			}
+			chunks = append(chunks, rs[:n])
+			rs = rs[n:]
+		}
+	}
+
+	uerr := &UpsertReposError{Total: len(repos)}
+	for i, chunk := range chunks {
+		if err := s.upsertReposChunkWithRetry(ctx, chunk); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			uerr.Failed = append(uerr.Failed, UpsertReposChunkError{Repos: chunk, Err: err})
+			upsertReposChunks.WithLabelValues("false").Inc()
+			upsertedRepos.WithLabelValues("false").Add(float64(len(chunk)))
+		} else {
+			upsertReposChunks.WithLabelValues("true").Inc()
+			upsertedRepos.WithLabelValues("true").Add(float64(len(chunk)))
+		}
+
+		tr.LogFields(
+			otlog.Int("chunk", i+1),
+			otlog.Int("chunks", len(chunks)),
+			otlog.Int("failed", len(uerr.Failed)),
+		)
+	}
+
+	if len(uerr.Failed) > 0 {
+		return uerr
+	}
+	return nil
+}
+
+const (
+	defaultUpsertReposChunkSize = 500
+	upsertReposChunkAttempts    = 3
+)
+
+// UpsertReposError is returned by UpsertRepos if some chunks of repos could
+// not be written. The repos of all other chunks were written.
+type UpsertReposError struct {
+	// Total is the number of repos passed to UpsertRepos.
+	Total int
+	// Failed are the chunks that could not be written.
+	Failed []UpsertReposChunkError
+}
+
+// UpsertReposChunkError is a chunk of repos that could not be written by
+// UpsertRepos, together with the error of the last attempt.
+type UpsertReposChunkError struct {
+	Repos types.Repos
+	Err   error
+}
+
+func (e *UpsertReposError) Error() string {
+	failed := e.FailedRepos()
+	return fmt.Sprintf(
+		"failed to upsert %d of %d repos in %d chunks: %s",
+		len(failed),
+		e.Total,
+		len(e.Failed),
+		e.Failed[0].Err,
+	)
+}
+
+// FailedRepos returns the repos of all failed chunks.
+func (e *UpsertReposError) FailedRepos() types.Repos {
+	var rs types.Repos
+	for _, c := range e.Failed {
+		rs = append(rs, c.Repos...)
+	}
+	return rs
+}
+
+func (s *Store) upsertReposChunkWithRetry(ctx context.Context, repos []*types.Repo) (err error) {
+	// IDs are assigned to inserted repos as we go, so we restore them if an
+	// attempt fails.
+	ids := make([]api.RepoID, len(repos))
+	for i, r := range repos {
+		ids[i] = r.ID
+	}
+
+	for attempt := 1; attempt <= upsertReposChunkAttempts; attempt++ {
+		if attempt > 1 {
+			upsertReposChunkRetries.Inc()
+			sleep(ctx, time.Duration(attempt-1)*100*time.Millisecond)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		if err = s.upsertReposChunk(ctx, repos); err == nil {
+			return nil
+		}
+
+		for i, r := range repos {
+			r.ID = ids[i]
+		}
+	}
+	return err
+}
+
+func (s *Store) upsertReposChunk(ctx context.Context, repos []*types.Repo) (err error) {
+	tx, err := s.Transact(ctx)
+	if err != nil {
+		return err
+	}
+	defer func() { err = tx.Done(err) }()
+
 	var deletes, updates, inserts []*types.Repo
 	for _, r := range repos {
 		switch {
@@ -839,7 +1016,7 @@ func (s *Store) UpsertRepos(ctx context.Context, repos ...*types.Repo) (err erro
 			return errors.Wrap(err, op.name)
 		}
 
-		rows, err := s.Query(ctx, q)
+		rows, err := tx.Query(ctx, q)
 		if err != nil {
 			return errors.Wrap(err, op.name)
 		}
@@ -881,6 +1058,56 @@ func (s *Store) UpsertRepos(ctx context.Context, repos ...*types.Repo) (err erro
 	return nil
 }
 
+// ErrRepoNotRestorable is returned by RestoreRepo when the repository is not
+// deleted or was deleted longer ago than the restore window.
+var ErrRepoNotRestorable = errors.New("repository is not deleted or can no longer be restored")
+
+// RestoreRepo restores the soft-deleted repo with the given ID if it was
+// deleted within the given restore window, and returns it. The repo gets back
+// the name it had before it was deleted, so restoring fails if another repo
+// took that name in the meantime. The repo is associated again with its
+// external services on their next sync.
+func (s *Store) RestoreRepo(ctx context.Context, id api.RepoID, window time.Duration) (_ *types.Repo, err error) {
+	tr, ctx := s.trace(ctx, "Store.RestoreRepo")
+	tr.LogFields(otlog.Int32("id", int32(id)))
+
+	defer func(began time.Time) {
+		secs := time.Since(began).Seconds()
+
+		s.Metrics.RestoreRepo.Observe(secs, 1, &err)
+		logging.Log(s.Log, "store.restore-repo", &err, "repo-id", id)
+
+		tr.SetError(err)
+		tr.Finish()
+	}(time.Now())
+
+	err = s.QueryRow(ctx, sqlf.Sprintf(restoreRepoQuery, id, window.Seconds())).Scan(&id)
+	if err == sql.ErrNoRows {
+		return nil, ErrRepoNotRestorable
+	}
+	if err != nil {
+		var e *pgconn.PgError
+		if errors.As(err, &e) && e.ConstraintName == "repo_name_unique" {
+			return nil, errors.New("another repository with the same name exists")
+		}
+		return nil, errors.Wrap(err, "failed to restore repo")
+	}
+
+	return s.RepoStore.Get(ctx, id)
+}
+
+// The name of soft-deleted repos is prefixed by soft_deleted_repository_name,
+// which we strip again to restore it.
+const restoreRepoQuery = `
+-- source: internal/repos/store.go:Store.RestoreRepo
+UPDATE repo
+SET name = regexp_replace(name, '^DELETED-[0-9.]+-', ''), deleted_at = NULL
+WHERE id = %s
+AND deleted_at IS NOT NULL
+AND deleted_at > now() - (%s * interval '1 second')
+RETURNING id
+`
+
 // EnqueueSingleSyncJob enqueues a single sync job for the given external
 // service if it is not already queued or processing.
 func (s *Store) EnqueueSingleSyncJob(ctx context.Context, id int64) (err error) {
@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/keegancsmith/sqlf"
@@ -327,6 +328,51 @@ func testStoreUpsertRepos(store *repos.Store) func(*testing.T) {
 				t.Fatalf("ListRepos:\n%s", diff)
 			}
 		}))
+
+		t.Run("chunks with partial failure", transact(ctx, store, func(t testing.TB, tx *repos.Store) {
+			tx.UpsertReposChunkSize = 2
+
+			existing := mkRepos(1, &github)
+			if err := tx.UpsertRepos(ctx, existing...); err != nil {
+				t.Fatalf("UpsertRepos error: %s", err)
+			}
+
+			// The last repo has the name of an existing repo, so it can't be
+			// inserted and fails the second chunk.
+			upserts := mkRepos(3, &gitlab)
+			conflicting := gitlab.Clone()
+			conflicting.Name = existing[0].Name
+			conflicting.ExternalRepo.ID += "-conflicting"
+			upserts = append(upserts, conflicting)
+
+			err := tx.UpsertRepos(ctx, upserts...)
+
+			var upsertErr *repos.UpsertReposError
+			if !errors.As(err, &upsertErr) {
+				t.Fatalf("want UpsertReposError, got %v", err)
+			}
+			if have, want := upsertErr.Total, len(upserts); have != want {
+				t.Errorf("have total %d, want %d", have, want)
+			}
+			if diff := cmp.Diff(upsertErr.FailedRepos().Names(), upserts[2:].Names()); diff != "" {
+				t.Errorf("FailedRepos:\n%s", diff)
+			}
+
+			if noID := upserts[:2].Filter(hasNoID); len(noID) > 0 {
+				t.Errorf("UpsertRepos didn't assign an ID to repos of successful chunk: %v", noID.Names())
+			}
+			if withID := upserts[2:].Filter(func(r *types.Repo) bool { return r.ID != 0 }); len(withID) > 0 {
+				t.Errorf("UpsertRepos assigned an ID to repos of failed chunk: %v", withID.Names())
+			}
+
+			have, err := tx.RepoStore.List(ctx, database.ReposListOptions{})
+			if err != nil {
+				t.Fatalf("ListRepos error: %s", err)
+			}
+			if diff := cmp.Diff(types.Repos(have).Names(), append(existing, upserts[:2]...).Names()); diff != "" {
+				t.Errorf("ListRepos:\n%s", diff)
+			}
+		}))
 	}
 }
 
@@ -641,6 +687,88 @@ func testStoreSetClonedRepos(store *repos.Store) func(*testing.T) {
 	}
 }
 
+func testStoreRestoreRepo(store *repos.Store) func(*testing.T) {
+	return func(t *testing.T) {
+		ctx := context.Background()
+
+		repo := &types.Repo{
+			Name: "github.com/foo/bar",
+			URI:  "github.com/foo/bar",
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          "bar",
+				ServiceType: extsvc.TypeGitHub,
+				ServiceID:   "http://github.com",
+			},
+			Metadata: new(github.Repository),
+		}
+
+		t.Run("within restore window", transact(ctx, store, func(t testing.TB, tx *repos.Store) {
+			r := repo.Clone()
+			if err := tx.RepoStore.Create(ctx, r); err != nil {
+				t.Fatalf("Create error: %s", err)
+			}
+			if err := tx.RepoStore.Delete(ctx, r.ID); err != nil {
+				t.Fatalf("Delete error: %s", err)
+			}
+
+			restored, err := tx.RestoreRepo(ctx, r.ID, time.Hour)
+			if err != nil {
+				t.Fatalf("RestoreRepo error: %s", err)
+			}
+			if restored.Name != repo.Name {
+				t.Fatalf("got name %q, want %q", restored.Name, repo.Name)
+			}
+			if restored.IsDeleted() {
+				t.Fatal("restored repo is still deleted")
+			}
+
+			// The repo is no longer deleted, so it can't be restored again.
+			if _, err := tx.RestoreRepo(ctx, r.ID, time.Hour); err != repos.ErrRepoNotRestorable {
+				t.Fatalf("got error %v, want %v", err, repos.ErrRepoNotRestorable)
+			}
+		}))
+
+		t.Run("after restore window", transact(ctx, store, func(t testing.TB, tx *repos.Store) {
+			r := repo.Clone()
+			if err := tx.RepoStore.Create(ctx, r); err != nil {
+				t.Fatalf("Create error: %s", err)
+			}
+			if err := tx.RepoStore.Delete(ctx, r.ID); err != nil {
+				t.Fatalf("Delete error: %s", err)
+			}
+			q := sqlf.Sprintf("UPDATE repo SET deleted_at = now() - interval '2 hours' WHERE id = %s", r.ID)
+			if err := tx.Exec(ctx, q); err != nil {
+				t.Fatal(err)
+			}
+
+			if _, err := tx.RestoreRepo(ctx, r.ID, time.Hour); err != repos.ErrRepoNotRestorable {
+				t.Fatalf("got error %v, want %v", err, repos.ErrRepoNotRestorable)
+			}
+		}))
+
+		t.Run("name taken", transact(ctx, store, func(t testing.TB, tx *repos.Store) {
+			r := repo.Clone()
+			if err := tx.RepoStore.Create(ctx, r); err != nil {
+				t.Fatalf("Create error: %s", err)
+			}
+			if err := tx.RepoStore.Delete(ctx, r.ID); err != nil {
+				t.Fatalf("Delete error: %s", err)
+			}
+
+			other := repo.Clone()
+			other.ID = 0
+			other.ExternalRepo.ID = "baz"
+			if err := tx.RepoStore.Create(ctx, other); err != nil {
+				t.Fatalf("Create error: %s", err)
+			}
+
+			if _, err := tx.RestoreRepo(ctx, r.ID, time.Hour); err == nil {
+				t.Fatal("want error for repo whose name is taken")
+			}
+		}))
+	}
+}
+
 func testStoreCountNotClonedRepos(store *repos.Store) func(*testing.T) {
 	return func(t *testing.T) {
 		servicesPerKind := createExternalServices(t, store)
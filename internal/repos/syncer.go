@@ -2,6 +2,7 @@ package repos
 
 import (
 	"context"
+	"fmt"
 	"sort"
 	"strconv"
 	"strings"
@@ -54,6 +55,14 @@ type Syncer struct {
 	// If zero, we'll read from config instead.
 	UserReposMaxPerSite int
 
+	// UserReposMaxExternalServicesPerUser can be used to override the value read
+	// from config. If zero, we'll read from config instead.
+	UserReposMaxExternalServicesPerUser int
+
+	// UserReposMaxCloneSizeBytesPerUser can be used to override the value read
+	// from config. If zero, we'll read from config instead.
+	UserReposMaxCloneSizeBytesPerUser int64
+
 	// Streaming, if true, will make the Syncer use the streaming implementations of
 	// SyncExternalService and SyncRepo.
 	Streaming bool
@@ -101,7 +110,7 @@ func (s *Syncer) Run(ctx context.Context, store *Store, opts RunOptions) error {
 	defer resetter.Stop()
 
 	for ctx.Err() == nil {
-		if !conf.Get().DisableAutoCodeHostSyncs {
+		if !conf.Get().DisableAutoCodeHostSyncs && !conf.MaintenanceModeEnabled() {
 			err := store.EnqueueSyncJobs(ctx, opts.IsCloud)
 			if err != nil && s.Logger != nil {
 				s.Logger.Error("Enqueuing sync jobs", "error", err)
@@ -131,7 +140,19 @@ func (s *syncHandler) Handle(ctx context.Context, record workerutil.Record) (err
 		if err != nil {
 			return err
 		}
-		defer func() { err = tx.Done(err) }()
+		defer func() {
+			// When only some repos failed to be upserted we still commit the
+			// rest of the sync, but fail the job so the failed chunks are
+			// reported.
+			var upsertErr *UpsertReposError
+			if errors.As(err, &upsertErr) {
+				if doneErr := tx.Done(nil); doneErr != nil {
+					err = doneErr
+				}
+				return
+			}
+			err = tx.Done(err)
+		}()
 	}
 
 	return s.syncer.SyncExternalService(ctx, tx, sj.ExternalServiceID, s.minSyncInterval())
@@ -202,6 +223,13 @@ func (s *Syncer) SyncExternalService(ctx context.Context, tx *Store, externalSer
 	onSourced := func(*types.Repo) error { return nil } // noop
 
 	if owner == ownerUser {
+		if err := s.checkUserExternalServiceQuota(ctx, tx, svc); err != nil {
+			return err
+		}
+		if err := s.checkUserCloneSizeQuota(ctx, tx, svc.NamespaceUserID); err != nil {
+			return err
+		}
+
 		// If we are over our limit for user added repos we abort the sync
 		totalAllowed := uint64(s.UserReposMaxPerSite)
 		if totalAllowed == 0 {
@@ -319,6 +347,9 @@ func (s *Syncer) SyncExternalService(ctx context.Context, tx *Store, externalSer
 		}
 	}
 
+	// newDiff updates the stored repos in place, so we remember their names to detect renames.
+	storedNames := repoNamesByExternalRepo(storedServiceRepos)
+
 	// Find the diff associated with only the currently syncing external service.
 	diff = newDiff(svc, sourced, storedServiceRepos)
 	resolveNameConflicts(&diff, conflicting)
@@ -333,8 +364,17 @@ func (s *Syncer) SyncExternalService(ctx context.Context, tx *Store, externalSer
 
 	// Next, insert or modify existing repos. This is needed so that the next call
 	// to UpsertSources has valid repo ids
+	var upsertErr *UpsertReposError
 	if err = tx.UpsertRepos(ctx, upserts...); err != nil {
-		return errors.Wrap(err, "syncer.sync.store.upsert-repos")
+		if !errors.As(err, &upsertErr) {
+			return errors.Wrap(err, "syncer.sync.store.upsert-repos")
+		}
+		// The other chunks were written, so we finish the sync without the
+		// failed repos and report them at the end.
+		if s.Logger != nil {
+			s.Logger.Error("Failed to upsert some repos", "externalService", externalServiceID, "error", upsertErr)
+		}
+		diff.drop(upsertErr.FailedRepos())
 	}
 
 	// Only modify added and modified relationships in external_service_repos, deleted was
@@ -345,6 +385,8 @@ func (s *Syncer) SyncExternalService(ctx context.Context, tx *Store, externalSer
 		return errors.Wrap(err, "syncer.sync.store.upsert-sources")
 	}
 
+	s.logRenames("Syncer.SyncExternalService", svc, renames(diff, storedNames))
+
 	now := s.Now()
 	interval := calcSyncInterval(now, svc.LastSyncAt, minSyncInterval, diff)
 	if s.Logger != nil {
@@ -365,6 +407,10 @@ func (s *Syncer) SyncExternalService(ctx context.Context, tx *Store, externalSer
 		}
 	}
 
+	if upsertErr != nil {
+		return errors.Wrap(upsertErr, "syncer.sync.store.upsert-repos")
+	}
+
 	if unauthorized {
 		return &ErrUnauthorized{}
 	}
@@ -378,6 +424,99 @@ func (s *Syncer) SyncExternalService(ctx context.Context, tx *Store, externalSer
 	return nil
 }
 
+// SyncDryRun is the repo-level diff that a sync of an external service would apply.
+type SyncDryRun struct {
+	Added   types.Repos  // repos the sync would add
+	Removed types.Repos  // repos the sync would remove from the external service
+	Renamed []RepoRename // repos the sync would rename
+}
+
+// RepoRename is the rename of a repo that a sync would apply.
+type RepoRename struct {
+	From, To api.RepoName
+}
+
+// SyncExternalServiceDryRun computes the diff that a sync of the given external service would
+// apply, without applying it. The external service doesn't need to be stored, so that a changed
+// configuration can be checked before it is saved. Unlike SyncExternalService, errors from the
+// code host are returned instead of being treated as if no repos were found.
+func (s *Syncer) SyncExternalServiceDryRun(ctx context.Context, svc *types.ExternalService) (_ *SyncDryRun, err error) {
+	var sourced types.Repos
+	if sourced, err = s.sourced(ctx, svc); err != nil {
+		return nil, errors.Wrap(err, "fetching from code host "+svc.DisplayName)
+	}
+
+	// Same as in SyncExternalService: user added external services only sync public code unless
+	// the user is allowed to add private code.
+	if svc.NamespaceUserID > 0 {
+		if mode, err := database.UsersWith(s.Store).UserAllowedExternalServices(ctx, svc.NamespaceUserID); err != nil {
+			return nil, errors.Wrap(err, "checking if user can add private code")
+		} else if mode != conf.ExternalServiceModeAll {
+			sourced = sourced.Filter(func(r *types.Repo) bool { return !r.Private })
+		}
+	}
+
+	var stored types.Repos
+	if svc.ID != 0 {
+		if stored, err = s.Store.RepoStore.List(ctx, database.ReposListOptions{ExternalServiceIDs: []int64{svc.ID}}); err != nil {
+			return nil, errors.Wrap(err, "syncer.sync-dry-run.store.list-repos")
+		}
+	}
+
+	var conflicting types.Repos
+	if len(sourced) > 0 {
+		if conflicting, err = s.Store.RepoStore.List(ctx, database.ReposListOptions{Names: sourced.Names()}); err != nil {
+			return nil, errors.Wrap(err, "syncer.sync-dry-run.store.list-repos")
+		}
+		conflicting = conflicting.Filter(func(r *types.Repo) bool {
+			for _, id := range r.ExternalServiceIDs() {
+				if id == svc.ID {
+					return false
+				}
+			}
+			return true
+		})
+	}
+
+	// newDiff updates the stored repos in place, so we remember their names to detect renames.
+	storedNames := repoNamesByExternalRepo(stored)
+
+	diff := newDiff(svc, sourced, stored)
+	resolveNameConflicts(&diff, conflicting)
+	diff.Sort()
+
+	return &SyncDryRun{Added: diff.Added, Removed: diff.Deleted, Renamed: renames(diff, storedNames)}, nil
+}
+
+// repoNamesByExternalRepo returns the names of the given repos by their external repo spec.
+func repoNamesByExternalRepo(rs types.Repos) map[api.ExternalRepoSpec]api.RepoName {
+	names := make(map[api.ExternalRepoSpec]api.RepoName, len(rs))
+	for _, r := range rs {
+		names[r.ExternalRepo] = r.Name
+	}
+	return names
+}
+
+// renames returns the repos of the diff that were modified with a different name than the
+// one they had before, given by their external repo spec. Repos are matched by external
+// repo spec, so a renamed repo keeps its ID and the store records its previous name.
+func renames(diff Diff, names map[api.ExternalRepoSpec]api.RepoName) (rs []RepoRename) {
+	for _, r := range diff.Modified {
+		if from, ok := names[r.ExternalRepo]; ok && from != r.Name {
+			rs = append(rs, RepoRename{From: from, To: r.Name})
+		}
+	}
+	return rs
+}
+
+// logRenames logs and counts the repos that were renamed in place by a sync.
+func (s *Syncer) logRenames(family string, svc *types.ExternalService, rs []RepoRename) {
+	for _, r := range rs {
+		s.log().Info("Renamed repo", "externalService", svc.ID, "from", r.From, "to", r.To)
+	}
+	renamedRepos.WithLabelValues(family).Add(float64(len(rs)))
+}
+
 type ErrUnauthorized struct{}
 
 func (e ErrUnauthorized) Error() string {
@@ -408,6 +547,23 @@ func (e ErrAccountSuspended) AccountSuspended() bool {
 	return true
 }
 
+// ErrUserQuotaExceeded is returned when syncing an external service owned by a
+// user would exceed one of the quotas of the user configured in the site
+// configuration.
+type ErrUserQuotaExceeded struct {
+	UserID int32
+	// Quota is the human readable name of the exceeded quota.
+	Quota string
+	// Setting is the site configuration setting of the quota.
+	Setting string
+
+	Usage, Limit uint64
+}
+
+func (e *ErrUserQuotaExceeded) Error() string {
+	return fmt.Sprintf("user %d reached the maximum allowed %s of user added code hosts (%d/%d), which is configured by %q in the site configuration", e.UserID, e.Quota, e.Usage, e.Limit, e.Setting)
+}
+
 // We need to resolve name conflicts by deciding whether to keep the newly added repo
 // or the repo that already exists in the database.
 // If the new repo wins, then the old repo is added to the diff.Deleted slice.
@@ -765,6 +921,20 @@ func (d Diff) Len() int {
 	return len(d.Deleted) + len(d.Modified) + len(d.Added) + len(d.Unmodified)
 }
 
+// drop removes the given repos from the added and modified repos of the diff.
+func (d *Diff) drop(rs types.Repos) {
+	dropped := make(map[*types.Repo]struct{}, len(rs))
+	for _, r := range rs {
+		dropped[r] = struct{}{}
+	}
+	keep := func(r *types.Repo) bool {
+		_, ok := dropped[r]
+		return !ok
+	}
+	d.Added = d.Added.Filter(keep)
+	d.Modified = d.Modified.Filter(keep)
+}
+
 // NewDiff returns a diff from the given sourced and stored repos.
 func NewDiff(sourced, stored []*types.Repo) (diff Diff) {
 	return newDiff(nil, sourced, stored)
@@ -847,7 +1017,12 @@ func (s *Syncer) sourced(ctx context.Context, svc *types.ExternalService, onSour
 		return nil, err
 	}
 
-	return listAll(ctx, srcs, onSourced...)
+	src, err := withExcludeRules(svc, srcs)
+	if err != nil {
+		return nil, err
+	}
+
+	return listAll(ctx, src, onSourced...)
 }
 
 // makeNewRepoInserter returns a function that will insert repos.
@@ -978,6 +1153,13 @@ func (s *Syncer) StreamingSyncExternalService(ctx context.Context, tx *Store, ex
 	// only sync public code.
 	allowed := func(*types.Repo) bool { return true }
 	if svc.NamespaceUserID != 0 {
+		if err := s.checkUserExternalServiceQuota(ctx, tx, svc); err != nil {
+			return err
+		}
+		if err := s.checkUserCloneSizeQuota(ctx, tx, svc.NamespaceUserID); err != nil {
+			return err
+		}
+
 		if mode, err := database.UsersWith(tx).UserAllowedExternalServices(ctx, svc.NamespaceUserID); err != nil {
 			return errors.Wrap(err, "checking if user can add private code")
 		} else if mode != conf.ExternalServiceModeAll {
@@ -985,7 +1167,12 @@ func (s *Syncer) StreamingSyncExternalService(ctx context.Context, tx *Store, ex
 		}
 	}
 
-	src, err := s.Sourcer(svc)
+	srcs, err := s.Sourcer(svc)
+	if err != nil {
+		return err
+	}
+
+	src, err := withExcludeRules(svc, srcs)
 	if err != nil {
 		return err
 	}
@@ -1070,6 +1257,76 @@ func (s *Syncer) userReposMaxPerUser() uint64 {
 	return uint64(s.UserReposMaxPerUser)
 }
 
+func (s *Syncer) userReposMaxExternalServicesPerUser() uint64 {
+	if s.UserReposMaxExternalServicesPerUser == 0 {
+		return uint64(conf.UserReposMaxExternalServicesPerUser())
+	}
+	return uint64(s.UserReposMaxExternalServicesPerUser)
+}
+
+func (s *Syncer) userReposMaxCloneSizeBytesPerUser() uint64 {
+	if s.UserReposMaxCloneSizeBytesPerUser == 0 {
+		return uint64(conf.UserReposMaxCloneSizeBytesPerUser())
+	}
+	return uint64(s.UserReposMaxCloneSizeBytesPerUser)
+}
+
+// checkUserExternalServiceQuota returns an ErrUserQuotaExceeded if the user
+// owning svc has more external services than allowed and svc is not one of the
+// oldest ones, which are synced regardless of the external services added
+// later.
+func (s *Syncer) checkUserExternalServiceQuota(ctx context.Context, tx *Store, svc *types.ExternalService) error {
+	limit := s.userReposMaxExternalServicesPerUser()
+	if limit == 0 {
+		return nil
+	}
+
+	// AfterID only includes the external services with a lower ID, i.e. the
+	// ones added before svc.
+	older, err := tx.ExternalServiceStore.Count(ctx, database.ExternalServicesListOptions{
+		NamespaceUserID: svc.NamespaceUserID,
+		AfterID:         svc.ID,
+	})
+	if err != nil {
+		return errors.Wrap(err, "counting user added external services")
+	}
+	if uint64(older) >= limit {
+		return &ErrUserQuotaExceeded{
+			UserID:  svc.NamespaceUserID,
+			Quota:   "code host connections",
+			Setting: "userRepos.maxExternalServicesPerUser",
+			Usage:   uint64(older) + 1,
+			Limit:   limit,
+		}
+	}
+	return nil
+}
+
+// checkUserCloneSizeQuota returns an ErrUserQuotaExceeded if the clones of the
+// repos added by the user take up as much space as allowed, so that no more
+// repos may be added for the user.
+func (s *Syncer) checkUserCloneSizeQuota(ctx context.Context, tx *Store, userID int32) error {
+	limit := s.userReposMaxCloneSizeBytesPerUser()
+	if limit == 0 {
+		return nil
+	}
+
+	size, err := tx.UserAddedReposSize(ctx, userID)
+	if err != nil {
+		return errors.Wrap(err, "computing size of user added repos")
+	}
+	if size >= limit {
+		return &ErrUserQuotaExceeded{
+			UserID:  userID,
+			Quota:   "total clone size in bytes",
+			Setting: "userRepos.maxCloneSizeBytesPerUser",
+			Usage:   size,
+			Limit:   limit,
+		}
+	}
+	return nil
+}
+
 // syncs a sourced repo of a given external service, returning a diff with a single repo.
 func (s *Syncer) sync(ctx context.Context, tx *Store, svc *types.ExternalService, sourced *types.Repo) (d Diff, err error) {
 	if !tx.InTransaction() {
@@ -1114,6 +1371,13 @@ func (s *Syncer) sync(ctx context.Context, tx *Store, svc *types.ExternalService
 		stored = types.Repos{existing}
 		fallthrough
 	case 1: // Existing repo, update.
+		// The repo is matched by its external repo spec, so a repo renamed on the code host
+		// keeps its ID and the store records its previous name.
+		var names map[api.ExternalRepoSpec]api.RepoName
+		if !stored[0].IsDeleted() {
+			names = repoNamesByExternalRepo(stored)
+		}
+
 		if !stored[0].Update(sourced) {
 			d.Unmodified = append(d.Unmodified, stored[0])
 			break
@@ -1124,6 +1388,7 @@ func (s *Syncer) sync(ctx context.Context, tx *Store, svc *types.ExternalService
 		}
 
 		d.Modified = append(d.Modified, stored[0])
+		s.logRenames("Syncer.Sync", svc, renames(d, names))
 	case 0: // New repo, create.
 		if svc.NamespaceUserID != 0 { // enforce user repo limits
 			siteAdded, err := tx.CountUserAddedRepos(ctx)
@@ -1144,6 +1409,10 @@ func (s *Syncer) sync(ctx context.Context, tx *Store, svc *types.ExternalService
 					userAdded, userLimit,
 				)
 			}
+
+			if err := s.checkUserCloneSizeQuota(ctx, tx, svc.NamespaceUserID); err != nil {
+				return Diff{}, err
+			}
 		}
 
 		if err = tx.CreateExternalServiceRepo(ctx, svc, sourced); err != nil {
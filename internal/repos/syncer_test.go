@@ -8,6 +8,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/cockroachdb/errors"
 	"github.com/gitchander/permutation"
 	"github.com/google/go-cmp/cmp"
 	"github.com/google/go-cmp/cmp/cmpopts"
@@ -1894,6 +1895,82 @@ func testUserAddedRepos(store *repos.Store, streaming bool) func(*testing.T) {
 		if err := syncer.SyncExternalService(ctx, store, userService.ID, 10*time.Second); err == nil {
 			t.Fatal("Expected an error, got none")
 		}
+
+		// Attempt to add some repos once the clones of the repos of the user
+		// take up all the allowed space
+		err = store.Exec(ctx, sqlf.Sprintf(`
+INSERT INTO gitserver_repos (repo_id, repo_size_bytes, shard_id)
+SELECT repo_id, 100, 'test' FROM external_service_repos WHERE user_id = %s
+ON CONFLICT (repo_id) DO UPDATE SET repo_size_bytes = EXCLUDED.repo_size_bytes
+`, userID))
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		syncer = &repos.Syncer{
+			Sourcer: func(services ...*types.ExternalService) (repos.Sources, error) {
+				s := repos.NewFakeSource(userService, nil, publicRepo, publicRepo2)
+				return repos.Sources{s}, nil
+			},
+			Now:                               time.Now,
+			Store:                             store,
+			UserReposMaxCloneSizeBytesPerUser: 200,
+			Streaming:                         streaming,
+		}
+		var quotaErr *repos.ErrUserQuotaExceeded
+		if err := syncer.SyncExternalService(ctx, store, userService.ID, 10*time.Second); !errors.As(err, &quotaErr) {
+			t.Fatalf("Expected ErrUserQuotaExceeded, got %v", err)
+		}
+		if quotaErr.Setting != "userRepos.maxCloneSizeBytesPerUser" || quotaErr.Usage != 200 {
+			t.Fatalf("Unexpected quota error: %v", quotaErr)
+		}
+
+		// The clone size quota is checked before sourcing, so the external
+		// service isn't synced even if it only yields repos that were synced
+		// before
+		syncer.Sourcer = func(services ...*types.ExternalService) (repos.Sources, error) {
+			s := repos.NewFakeSource(userService, nil, publicRepo)
+			return repos.Sources{s}, nil
+		}
+		quotaErr = nil
+		if err := syncer.SyncExternalService(ctx, store, userService.ID, 10*time.Second); !errors.As(err, &quotaErr) {
+			t.Fatalf("Expected ErrUserQuotaExceeded, got %v", err)
+		}
+		if quotaErr.Setting != "userRepos.maxCloneSizeBytesPerUser" {
+			t.Fatalf("Unexpected quota error: %v", quotaErr)
+		}
+
+		// Attempt to sync a second external service of the user with a limit
+		// of one external service per user
+		userService2 := userService.Clone()
+		userService2.ID = 0
+		userService2.DisplayName = "Github - User 2"
+		if err := store.ExternalServiceStore.Upsert(ctx, userService2); err != nil {
+			t.Fatal(err)
+		}
+
+		syncer = &repos.Syncer{
+			Sourcer: func(services ...*types.ExternalService) (repos.Sources, error) {
+				s := repos.NewFakeSource(services[0], nil, publicRepo2)
+				return repos.Sources{s}, nil
+			},
+			Now:                                 time.Now,
+			Store:                               store,
+			UserReposMaxExternalServicesPerUser: 1,
+			Streaming:                           streaming,
+		}
+		quotaErr = nil
+		if err := syncer.SyncExternalService(ctx, store, userService2.ID, 10*time.Second); !errors.As(err, &quotaErr) {
+			t.Fatalf("Expected ErrUserQuotaExceeded, got %v", err)
+		}
+		if quotaErr.Setting != "userRepos.maxExternalServicesPerUser" || quotaErr.Usage != 2 || quotaErr.Limit != 1 {
+			t.Fatalf("Unexpected quota error: %v", quotaErr)
+		}
+
+		// The first external service of the user is still synced
+		if err := syncer.SyncExternalService(ctx, store, userService.ID, 10*time.Second); err != nil {
+			t.Fatal(err)
+		}
 	}
 }
 
@@ -2228,6 +2305,114 @@ func testNameOnConflictOnRename(store *repos.Store, streaming bool) func(*testin
 	}
 }
 
+func testBatchRenameKeepsRepo(store *repos.Store) func(*testing.T) {
+	return testRenameKeepsRepo(store, false)
+}
+
+func testStreamingRenameKeepsRepo(store *repos.Store) func(*testing.T) {
+	return testRenameKeepsRepo(store, true)
+}
+
+func testRenameKeepsRepo(store *repos.Store, streaming bool) func(*testing.T) {
+	return func(t *testing.T) {
+		// Test that a repo renamed on the code host is renamed in place, keeping its ID, and that its
+		// previous names keep resolving, even if it was deleted before it showed up with its new name.
+
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel()
+
+		now := time.Now()
+
+		svc1 := &types.ExternalService{
+			Kind:        extsvc.KindGitHub,
+			DisplayName: "Github - Test1",
+			Config:      `{"url": "https://github.com"}`,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		svc2 := &types.ExternalService{
+			Kind:        extsvc.KindGitHub,
+			DisplayName: "Github - Test2",
+			Config:      `{"url": "https://github.com"}`,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+
+		if err := store.ExternalServiceStore.Upsert(ctx, svc1, svc2); err != nil {
+			t.Fatal(err)
+		}
+
+		githubRepo := &types.Repo{
+			Name:     "github.com/org/foo",
+			Metadata: &github.Repository{},
+			ExternalRepo: api.ExternalRepoSpec{
+				ID:          "foo-external-foo",
+				ServiceID:   "https://github.com/",
+				ServiceType: extsvc.TypeGitHub,
+			},
+		}
+
+		sync := func(svc *types.ExternalService, rs ...*types.Repo) {
+			t.Helper()
+			syncer := &repos.Syncer{
+				Sourcer: func(services ...*types.ExternalService) (repos.Sources, error) {
+					return repos.Sources{repos.NewFakeSource(svc, nil, rs...)}, nil
+				},
+				Store:     store,
+				Now:       time.Now,
+				Streaming: streaming,
+			}
+			if err := syncer.SyncExternalService(ctx, store, svc.ID, 10*time.Second); err != nil {
+				t.Fatal(err)
+			}
+		}
+
+		assertRepo := func(name api.RepoName, id api.RepoID, previous ...api.RepoName) {
+			t.Helper()
+			fromDB, err := store.RepoStore.List(ctx, database.ReposListOptions{})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if len(fromDB) != 1 {
+				t.Fatalf("Expected 1 repo, have %d", len(fromDB))
+			}
+			if fromDB[0].Name != name || fromDB[0].ID != id {
+				t.Fatalf("Want repo %q with ID %d, got %q with ID %d", name, id, fromDB[0].Name, fromDB[0].ID)
+			}
+
+			for _, p := range previous {
+				redirectID, current, ok, err := database.RepoNameRedirects(store.Handle().DB()).Resolve(ctx, p)
+				if err != nil {
+					t.Fatal(err)
+				}
+				if !ok || redirectID != id || current != name {
+					t.Fatalf("Want %q to redirect to %q (%d), got %q (%d, %t)", p, name, id, current, redirectID, ok)
+				}
+			}
+		}
+
+		sync(svc1, githubRepo)
+		stored, err := store.RepoStore.List(ctx, database.ReposListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(stored) != 1 {
+			t.Fatalf("Expected 1 repo, have %d", len(stored))
+		}
+		id := stored[0].ID
+
+		// Rename the repo on the code host.
+		sync(svc1, githubRepo.With(func(r *types.Repo) { r.Name = "github.com/org/foo2" }))
+		assertRepo("github.com/org/foo2", id, "github.com/org/foo")
+
+		// Move the repo to the org of the other external service, which deletes it before it's
+		// sourced again with its new name.
+		sync(svc1)
+		sync(svc2, githubRepo.With(func(r *types.Repo) { r.Name = "github.com/other/foo2" }))
+		assertRepo("github.com/other/foo2", id, "github.com/org/foo", "github.com/org/foo2")
+	}
+}
+
 func testBatchDeleteExternalService(store *repos.Store) func(*testing.T) {
 	return testDeleteExternalService(store, false)
 }
@@ -2348,3 +2533,74 @@ func assertDeletedRepoCount(ctx context.Context, t *testing.T, store *repos.Stor
 		t.Fatalf("Expected %d rows, got %d", want, rowCount)
 	}
 }
+
+func testSyncExternalServiceDryRun(store *repos.Store) func(*testing.T) {
+	return func(t *testing.T) {
+		ctx := context.Background()
+		now := time.Now()
+
+		svc := &types.ExternalService{
+			Kind:        extsvc.KindGitHub,
+			DisplayName: "Github - Test",
+			Config:      `{"url": "https://github.com"}`,
+			CreatedAt:   now,
+			UpdatedAt:   now,
+		}
+		if err := store.ExternalServiceStore.Upsert(ctx, svc); err != nil {
+			t.Fatal(err)
+		}
+
+		newRepo := func(name, id string) *types.Repo {
+			return &types.Repo{
+				Name:     api.RepoName(name),
+				Metadata: &github.Repository{},
+				ExternalRepo: api.ExternalRepoSpec{
+					ID:          id,
+					ServiceID:   "https://github.com/",
+					ServiceType: extsvc.TypeGitHub,
+				},
+			}
+		}
+		foo := newRepo("github.com/org/foo", "foo")
+		bar := newRepo("github.com/org/bar", "bar")
+
+		newSyncer := func(sourced ...*types.Repo) *repos.Syncer {
+			return &repos.Syncer{
+				Sourcer: func(services ...*types.ExternalService) (repos.Sources, error) {
+					return repos.Sources{repos.NewFakeSource(svc, nil, sourced...)}, nil
+				},
+				Store: store,
+				Now:   time.Now,
+			}
+		}
+		if err := newSyncer(foo.Clone(), bar.Clone()).SyncExternalService(ctx, store, svc.ID, 10*time.Second); err != nil {
+			t.Fatal(err)
+		}
+
+		renamedFoo := foo.With(func(r *types.Repo) { r.Name = "github.com/org/foo2" })
+		baz := newRepo("github.com/org/baz", "baz")
+		dryRun, err := newSyncer(renamedFoo, baz).SyncExternalServiceDryRun(ctx, svc)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		if diff := cmp.Diff([]string{"github.com/org/baz"}, dryRun.Added.Names()); diff != "" {
+			t.Errorf("added mismatch (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]string{"github.com/org/bar"}, dryRun.Removed.Names()); diff != "" {
+			t.Errorf("removed mismatch (-want +got):\n%s", diff)
+		}
+		if diff := cmp.Diff([]repos.RepoRename{{From: "github.com/org/foo", To: "github.com/org/foo2"}}, dryRun.Renamed); diff != "" {
+			t.Errorf("renamed mismatch (-want +got):\n%s", diff)
+		}
+
+		// The dry run must not change the stored repos.
+		stored, err := store.RepoStore.List(ctx, database.ReposListOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		if diff := cmp.Diff([]string{"github.com/org/bar", "github.com/org/foo"}, types.Repos(stored).Names(), cmpopts.SortSlices(func(a, b string) bool { return a < b })); diff != "" {
+			t.Errorf("stored repos mismatch (-want +got):\n%s", diff)
+		}
+	}
+}
@@ -71,6 +71,23 @@ func (c *Client) RepoUpdateSchedulerInfo(ctx context.Context, args protocol.Repo
 	return result, err
 }
 
+// Health returns the health of repo-updater.
+func (c *Client) Health(ctx context.Context) (*protocol.HealthResult, error) {
+	resp, err := c.httpPost(ctx, "health", nil)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, errors.Errorf("Health: http status %d", resp.StatusCode)
+	}
+
+	var result protocol.HealthResult
+	err = json.NewDecoder(resp.Body).Decode(&result)
+	return &result, err
+}
+
 // MockRepoLookup mocks (*Client).RepoLookup for tests.
 var MockRepoLookup func(protocol.RepoLookupArgs) (*protocol.RepoLookupResult, error)
 
@@ -268,6 +285,30 @@ func (c *Client) SyncExternalService(ctx context.Context, svc api.ExternalServic
 	return &result, nil
 }
 
+// SyncExternalServiceDryRun requests the repo-level diff that a sync of the given external
+// service would apply, without applying it.
+func (c *Client) SyncExternalServiceDryRun(ctx context.Context, svc api.ExternalService) (*protocol.ExternalServiceSyncDryRunResult, error) {
+	req := &protocol.ExternalServiceSyncDryRunRequest{ExternalService: svc}
+	resp, err := c.httpPost(ctx, "sync-external-service-dry-run", req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	bs, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to read response body")
+	}
+
+	var result protocol.ExternalServiceSyncDryRunResult
+	if resp.StatusCode < 200 || resp.StatusCode >= 400 {
+		return nil, errors.New(string(bs))
+	} else if err = json.Unmarshal(bs, &result); err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 // RepoExternalServices requests the external services associated with a
 // repository with the given id.
 func (c *Client) RepoExternalServices(ctx context.Context, id api.RepoID) ([]api.ExternalService, error) {
@@ -32,6 +32,17 @@ type RepoQueueState struct {
 	Index    int
 	Total    int
 	Updating bool
+	// Position is the number of repos that will be updated before this one.
+	Position int
+}
+
+// HealthResult is the health of repo-updater, returned by its health endpoint.
+type HealthResult struct {
+	Version string
+	// ScheduledRepos is the number of repos in the update schedule.
+	ScheduledRepos int
+	// QueuedUpdates is the number of repos waiting to be or being updated.
+	QueuedUpdates int
 }
 
 // RepoExternalServicesRequest is a request for the external services
@@ -140,6 +151,20 @@ type RepoUpdateResponse struct {
 	Name string `json:"name"`
 	// URL of the repo that got an update request.
 	URL string `json:"url"`
+
+	// Deduplicated is true if an update of the repo was already queued or in
+	// progress, in which case no additional update was queued.
+	Deduplicated bool `json:"deduplicated,omitempty"`
+	// Updating is true if the repo is currently being updated.
+	Updating bool `json:"updating,omitempty"`
+	// QueuePosition is the number of repos that will be updated before this one.
+	QueuePosition int `json:"queuePosition,omitempty"`
+	// NextScheduledFetch is the next time the repo will be enqueued for an update
+	// by the regular update schedule, or nil if the repo isn't scheduled.
+	NextScheduledFetch *time.Time `json:"nextScheduledFetch,omitempty"`
+	// LastError is the error of the last clone or fetch of the repo, or empty if
+	// it was successful.
+	LastError string `json:"lastError,omitempty"`
 }
 
 // ChangesetSyncRequest is a request to sync a number of changesets
@@ -177,3 +202,22 @@ type ExternalServiceSyncResult struct {
 	ExternalService api.ExternalService
 	Error           string
 }
+
+// ExternalServiceSyncDryRunRequest is a request to compute the repo-level diff that a sync of an
+// external service would apply, without applying it. The external service doesn't need to be
+// stored (its ID is 0 then), so that a changed configuration can be checked before it is saved.
+type ExternalServiceSyncDryRunRequest struct {
+	ExternalService api.ExternalService
+}
+
+// ExternalServiceSyncDryRunResult is the result of an ExternalServiceSyncDryRunRequest.
+type ExternalServiceSyncDryRunResult struct {
+	Added   []api.RepoName // repos the sync would add
+	Removed []api.RepoName // repos the sync would remove from the external service
+	Renamed []RepoRename   // repos the sync would rename
+}
+
+// RepoRename is the rename of a repo that a sync would apply.
+type RepoRename struct {
+	From, To api.RepoName
+}
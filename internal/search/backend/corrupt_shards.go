@@ -0,0 +1,295 @@
+package backend
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/zoekt"
+	"github.com/google/zoekt/query"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
+)
+
+// CorruptShardsTracker tracks the repositories whose index shards zoekt failed
+// to load, for example because a shard was truncated or its checksum did not
+// match.
+//
+// Repositories recorded here are searched by searcher instead of zoekt, so
+// that searches do not silently return partial results. Their index options
+// are changed slightly (see RepoIndexOptions.Reindex), which makes
+// zoekt-sourcegraph-indexserver schedule them for reindexing ahead of
+// repositories that are up to date. Once zoekt lists a newer index for a
+// repository it is removed again.
+type CorruptShardsTracker struct {
+	// Pool is the redis pool used to store the corrupt repositories. It is
+	// shared by all frontend replicas.
+	Pool *redis.Pool
+
+	// key if non-empty will be used instead of corruptShardsKey. For tests.
+	key string
+
+	// now if non-nil will be used instead of time.Now. For tests.
+	now func() time.Time
+
+	mu    sync.RWMutex
+	repos map[api.RepoID]time.Time
+}
+
+// CorruptShards is the CorruptShardsTracker used by the frontend.
+var CorruptShards = &CorruptShardsTracker{Pool: redispool.Store}
+
+const corruptShardsKey = "search:index:corrupt"
+
+// Mark records that the index shards of the given repositories are corrupt.
+// The time a repository was first marked is kept if it is marked again.
+func (t *CorruptShardsTracker) Mark(ids []api.RepoID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	c := t.Pool.Get()
+	defer c.Close()
+
+	score := t.timeNow().Unix()
+	args := redis.Args{}.Add(t.redisKey(), "NX")
+	for _, id := range ids {
+		args = args.Add(score, int32(id))
+	}
+	if _, err := c.Do("ZADD", args...); err != nil {
+		return errors.Wrap(err, "marking repositories with corrupt shards")
+	}
+	return nil
+}
+
+// Clear removes the given repositories. It is not an error if they are not
+// marked.
+func (t *CorruptShardsTracker) Clear(ids []api.RepoID) error {
+	if len(ids) == 0 {
+		return nil
+	}
+
+	c := t.Pool.Get()
+	defer c.Close()
+
+	args := redis.Args{}.Add(t.redisKey())
+	for _, id := range ids {
+		args = args.Add(int32(id))
+	}
+	if _, err := c.Do("ZREM", args...); err != nil {
+		return errors.Wrap(err, "clearing repositories with corrupt shards")
+	}
+	return nil
+}
+
+// List returns the repositories with corrupt shards and the time they were
+// first marked. It also refreshes the set consulted by IsCorrupt.
+func (t *CorruptShardsTracker) List() (map[api.RepoID]time.Time, error) {
+	c := t.Pool.Get()
+	defer c.Close()
+
+	values, err := redis.Int64s(c.Do("ZRANGE", t.redisKey(), 0, -1, "WITHSCORES"))
+	if err != nil && err != redis.ErrNil {
+		return nil, errors.Wrap(err, "listing repositories with corrupt shards")
+	}
+
+	repos := make(map[api.RepoID]time.Time, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		repos[api.RepoID(values[i])] = time.Unix(values[i+1], 0)
+	}
+
+	t.mu.Lock()
+	t.repos = repos
+	t.mu.Unlock()
+
+	return repos, nil
+}
+
+// IsCorrupt returns true if the repository had corrupt shards the last time
+// List was called. It does not contact redis, so it is cheap enough to call
+// for every search.
+func (t *CorruptShardsTracker) IsCorrupt(id api.RepoID) bool {
+	t.mu.RLock()
+	_, ok := t.repos[id]
+	t.mu.RUnlock()
+	return ok
+}
+
+// Count returns the number of repositories with corrupt shards the last time
+// List was called.
+func (t *CorruptShardsTracker) Count() int {
+	t.mu.RLock()
+	defer t.mu.RUnlock()
+	return len(t.repos)
+}
+
+// SetCrashes records the number of shards which crashed while zoekt listed
+// them during the last verification.
+func (t *CorruptShardsTracker) SetCrashes(n int) error {
+	c := t.Pool.Get()
+	defer c.Close()
+
+	if _, err := c.Do("SET", t.redisKey()+":crashes", n); err != nil {
+		return errors.Wrap(err, "recording shard crashes")
+	}
+	return nil
+}
+
+// Crashes returns the number of shards which crashed while zoekt listed them
+// during the last verification.
+func (t *CorruptShardsTracker) Crashes() (int, error) {
+	c := t.Pool.Get()
+	defer c.Close()
+
+	n, err := redis.Int(c.Do("GET", t.redisKey()+":crashes"))
+	if err == redis.ErrNil {
+		return 0, nil
+	}
+	return n, errors.Wrap(err, "getting shard crashes")
+}
+
+func (t *CorruptShardsTracker) redisKey() string {
+	if t.key != "" {
+		return t.key
+	}
+	return corruptShardsKey
+}
+
+func (t *CorruptShardsTracker) timeNow() time.Time {
+	if t.now != nil {
+		return t.now()
+	}
+	return time.Now()
+}
+
+// shardState is what a ShardVerifier remembers about the index of a
+// repository between verifications.
+type shardState struct {
+	// Shards is the number of shards zoekt loaded for the repository.
+	Shards int
+
+	// IndexTime is the time the loaded index was built.
+	IndexTime time.Time
+}
+
+// ShardVerifier periodically compares the shards zoekt lists with the shards
+// it listed during the previous verification to detect shards that zoekt
+// failed to load.
+//
+// zoekt skips shards it cannot open, so a corrupt shard makes the repository
+// disappear from the list, or be listed with fewer shards for the same
+// index. A verifier only knows about the shards listed since it started, so
+// corruption which happened before is not detected.
+type ShardVerifier struct {
+	// Lister lists the repositories in the index of every zoekt replica.
+	Lister interface {
+		List(ctx context.Context, q query.Q, opts *zoekt.ListOptions) (*zoekt.RepoList, error)
+	}
+
+	// Tracker records the repositories with corrupt shards.
+	Tracker *CorruptShardsTracker
+
+	// Indexable returns the subset of ids which should still be indexed. It
+	// is used to ignore repositories which disappeared from the index
+	// because they were deleted or are no longer indexed.
+	Indexable func(ctx context.Context, ids []api.RepoID) ([]api.RepoID, error)
+
+	prev map[api.RepoID]shardState
+}
+
+// Run verifies the shards every interval until ctx is canceled.
+func (v *ShardVerifier) Run(ctx context.Context, interval time.Duration) {
+	for {
+		if err := v.Verify(ctx); err != nil {
+			log15.Error("verifying zoekt shards", "error", err)
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval):
+		}
+	}
+}
+
+// Verify lists the shards of every indexed repository, marks the
+// repositories whose shards went missing since the last verification and
+// clears the repositories which were reindexed since they were marked.
+func (v *ShardVerifier) Verify(ctx context.Context) error {
+	rl, err := v.Lister.List(ctx, &query.Const{Value: true}, nil)
+	if err != nil {
+		return errors.Wrap(err, "listing zoekt shards")
+	}
+
+	if err := v.Tracker.SetCrashes(rl.Crashes); err != nil {
+		return err
+	}
+
+	cur := make(map[api.RepoID]shardState, len(rl.Repos))
+	for _, e := range rl.Repos {
+		id := api.RepoID(e.Repository.ID)
+		s := cur[id]
+		s.Shards += e.Stats.Shards
+		if e.IndexMetadata.IndexTime.After(s.IndexTime) {
+			s.IndexTime = e.IndexMetadata.IndexTime
+		}
+		cur[id] = s
+	}
+
+	corrupt := detectCorruptShards(v.prev, cur)
+	if len(corrupt) > 0 {
+		corrupt, err = v.Indexable(ctx, corrupt)
+		if err != nil {
+			return errors.Wrap(err, "filtering indexable repositories")
+		}
+		if len(corrupt) > 0 {
+			log15.Warn("zoekt failed to load shards, scheduling repositories for reindexing", "repos", corrupt)
+		}
+		if err := v.Tracker.Mark(corrupt); err != nil {
+			return err
+		}
+	}
+
+	// When a crash prevented zoekt from listing some shards we can't tell
+	// which repositories are affected, so we compare the next list with the
+	// last complete one.
+	if rl.Crashes == 0 {
+		v.prev = cur
+	}
+
+	marked, err := v.Tracker.List()
+	if err != nil {
+		return err
+	}
+	var healed []api.RepoID
+	for id, markedAt := range marked {
+		if s, ok := cur[id]; ok && s.IndexTime.After(markedAt) {
+			healed = append(healed, id)
+		}
+	}
+	if err := v.Tracker.Clear(healed); err != nil {
+		return err
+	}
+	if len(healed) > 0 {
+		_, err = v.Tracker.List()
+	}
+	return err
+}
+
+// detectCorruptShards returns the repositories which were listed in prev but
+// are missing from cur, or which are listed with fewer shards for the same
+// index.
+func detectCorruptShards(prev, cur map[api.RepoID]shardState) []api.RepoID {
+	var corrupt []api.RepoID
+	for id, p := range prev {
+		c, ok := cur[id]
+		if !ok || (c.IndexTime.Equal(p.IndexTime) && c.Shards < p.Shards) {
+			corrupt = append(corrupt, id)
+		}
+	}
+	return corrupt
+}
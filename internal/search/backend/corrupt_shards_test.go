@@ -0,0 +1,159 @@
+package backend
+
+import (
+	"context"
+	"os"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/go-cmp/cmp"
+	"github.com/google/zoekt"
+	"github.com/google/zoekt/query"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+)
+
+func TestDetectCorruptShards(t *testing.T) {
+	t0 := time.Unix(1000, 0)
+	t1 := t0.Add(time.Hour)
+
+	prev := map[api.RepoID]shardState{
+		1: {Shards: 1, IndexTime: t0}, // unchanged
+		2: {Shards: 1, IndexTime: t0}, // missing
+		3: {Shards: 3, IndexTime: t0}, // lost a shard
+		4: {Shards: 3, IndexTime: t0}, // reindexed with fewer shards
+	}
+	cur := map[api.RepoID]shardState{
+		1: {Shards: 1, IndexTime: t0},
+		3: {Shards: 2, IndexTime: t0},
+		4: {Shards: 2, IndexTime: t1},
+		5: {Shards: 1, IndexTime: t1}, // new
+	}
+
+	got := detectCorruptShards(prev, cur)
+	sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+	if diff := cmp.Diff([]api.RepoID{2, 3}, got); diff != "" {
+		t.Fatalf("unexpected corrupt repos (-want +got):\n%s", diff)
+	}
+
+	if got := detectCorruptShards(nil, cur); len(got) != 0 {
+		t.Fatalf("expected no corrupt repos on first verification, got %v", got)
+	}
+}
+
+func TestShardVerifier(t *testing.T) {
+	pool := &redis.Pool{
+		MaxIdle:     3,
+		IdleTimeout: 240 * time.Second,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", "127.0.0.1:6379")
+		},
+	}
+	key := "__test__" + t.Name()
+
+	c := pool.Get()
+	defer c.Close()
+	if _, err := c.Do("PING"); err != nil {
+		// If we are not on CI, skip the test if our redis connection fails.
+		if os.Getenv("CI") == "" {
+			t.Skip("could not connect to redis", err)
+		}
+		t.Fatal(err)
+	}
+	if _, err := c.Do("DEL", key, key+":crashes"); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _, _ = c.Do("DEL", key, key+":crashes") })
+
+	now := time.Unix(1000, 0)
+	tracker := &CorruptShardsTracker{Pool: pool, key: key, now: func() time.Time { return now }}
+
+	entry := func(id uint32, shards int, indexTime time.Time) *zoekt.RepoListEntry {
+		return &zoekt.RepoListEntry{
+			Repository:    zoekt.Repository{ID: id},
+			IndexMetadata: zoekt.IndexMetadata{IndexTime: indexTime},
+			Stats:         zoekt.RepoStats{Shards: shards},
+		}
+	}
+	var list zoekt.RepoList
+	v := &ShardVerifier{
+		Lister:  listerFunc(func() *zoekt.RepoList { return &list }),
+		Tracker: tracker,
+		Indexable: func(_ context.Context, ids []api.RepoID) ([]api.RepoID, error) {
+			// Repository 3 was deleted.
+			subset := ids[:0]
+			for _, id := range ids {
+				if id != 3 {
+					subset = append(subset, id)
+				}
+			}
+			return subset, nil
+		},
+	}
+
+	verify := func(want ...api.RepoID) {
+		t.Helper()
+		if err := v.Verify(context.Background()); err != nil {
+			t.Fatal(err)
+		}
+		marked, err := tracker.List()
+		if err != nil {
+			t.Fatal(err)
+		}
+		got := []api.RepoID{}
+		for id := range marked {
+			got = append(got, id)
+		}
+		sort.Slice(got, func(i, j int) bool { return got[i] < got[j] })
+		if want == nil {
+			want = []api.RepoID{}
+		}
+		if diff := cmp.Diff(want, got); diff != "" {
+			t.Fatalf("unexpected corrupt repos (-want +got):\n%s", diff)
+		}
+		for _, id := range want {
+			if !tracker.IsCorrupt(id) {
+				t.Fatalf("expected IsCorrupt(%d)", id)
+			}
+		}
+	}
+
+	t0 := time.Unix(500, 0)
+	list = zoekt.RepoList{Repos: []*zoekt.RepoListEntry{
+		entry(1, 1, t0),
+		entry(2, 2, t0),
+		entry(3, 1, t0),
+	}}
+	verify()
+
+	// Repository 1 lost its only shard, repository 2 one of its shards and
+	// repository 3 was deleted.
+	list = zoekt.RepoList{Repos: []*zoekt.RepoListEntry{
+		entry(2, 1, t0),
+	}, Crashes: 1}
+	verify(1, 2)
+
+	crashes, err := tracker.Crashes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if crashes != 1 {
+		t.Fatalf("expected 1 crash, got %d", crashes)
+	}
+
+	// Repository 1 was reindexed.
+	now = now.Add(time.Minute)
+	list = zoekt.RepoList{Repos: []*zoekt.RepoListEntry{
+		entry(1, 1, now),
+		entry(2, 1, t0),
+	}}
+	verify(2)
+}
+
+type listerFunc func() *zoekt.RepoList
+
+func (f listerFunc) List(context.Context, query.Q, *zoekt.ListOptions) (*zoekt.RepoList, error) {
+	return f(), nil
+}
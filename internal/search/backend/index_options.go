@@ -3,6 +3,7 @@ package backend
 import (
 	"bytes"
 	"encoding/json"
+	"math"
 	"sort"
 
 	"github.com/google/zoekt"
@@ -66,6 +67,10 @@ type RepoIndexOptions struct {
 	// Archived is true if the repository is archived.
 	Archived bool
 
+	// Reindex is true if the repository should be reindexed even though its
+	// options did not change, for example because its shards are corrupt.
+	Reindex bool
+
 	// GetVersion is used to resolve revisions for a repo. If it fails, the
 	// error is encoded in the body. If the revision is missing, an empty
 	// string should be returned rather than an error.
@@ -123,6 +128,14 @@ func getIndexOptions(
 		Symbols:    getBoolPtr(c.SearchIndexSymbolsEnabled, true),
 	}
 
+	// zoekt-sourcegraph-indexserver reindexes a repository when its options
+	// change, before any repository which is up to date. We nudge the
+	// priority by the smallest possible amount so that ranking is not
+	// affected.
+	if opts.Reindex {
+		o.Priority = math.Nextafter(o.Priority, math.Inf(1))
+	}
+
 	// Set of branch names. Always index HEAD
 	branches := map[string]struct{}{"HEAD": {}}
 
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"encoding/json"
 	"fmt"
+	"math"
 	"strings"
 	"testing"
 
@@ -216,6 +217,18 @@ func TestGetIndexOptions(t *testing.T) {
 			},
 			Priority: 10,
 		},
+	}, {
+		name: "reindex",
+		conf: schema.SiteConfiguration{},
+		repo: "corrupt",
+		want: zoektIndexOptions{
+			RepoID:  8,
+			Symbols: true,
+			Branches: []zoekt.RepositoryBranch{
+				{Name: "HEAD", Version: "!HEAD"},
+			},
+			Priority: math.Nextafter(10, 11),
+		},
 	}}
 
 	{
@@ -245,14 +258,14 @@ func TestGetIndexOptions(t *testing.T) {
 
 	getRepoIndexOptions := func(repo string) (*RepoIndexOptions, error) {
 		repoID := int32(1)
-		for _, r := range []string{"repo", "foo", "not_in_version_context", "priority", "public", "fork", "archived"} {
+		for _, r := range []string{"repo", "foo", "not_in_version_context", "priority", "public", "fork", "archived", "corrupt"} {
 			if r == repo {
 				break
 			}
 			repoID++
 		}
 		var priority float64
-		if repo == "priority" {
+		if repo == "priority" || repo == "corrupt" {
 			priority = 10
 		}
 		return &RepoIndexOptions{
@@ -261,6 +274,7 @@ func TestGetIndexOptions(t *testing.T) {
 			Fork:     repo == "fork",
 			Archived: repo == "archived",
 			Priority: priority,
+			Reindex:  repo == "corrupt",
 			GetVersion: func(branch string) (string, error) {
 				return "!" + branch, nil
 			},
@@ -0,0 +1,172 @@
+package backend
+
+import (
+	"regexp"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/gomodule/redigo/redis"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/redispool"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// defaultOnDemandMaxRepos is the number of repositories indexed on demand if
+// search.index.onDemand.maxRepos is not set.
+const defaultOnDemandMaxRepos = 1000
+
+// OnDemandIndex tracks the repositories which are indexed on demand.
+//
+// When on-demand indexing is enabled, only repositories matching
+// search.index.onDemand.alwaysIndex are continuously indexed. Other
+// repositories are searched by searcher and recorded here when they are
+// searched. The recorded repositories are part of the list of repositories
+// zoekt is asked to index. Once more than maxRepos repositories are recorded,
+// the least recently searched ones are dropped from the list which causes
+// zoekt to delete their shards.
+type OnDemandIndex struct {
+	// Pool is the redis pool used to store the recently searched
+	// repositories. It is shared by all frontend replicas.
+	Pool *redis.Pool
+
+	// key if non-empty will be used instead of onDemandKey. For tests.
+	key string
+
+	// now if non-nil will be used instead of time.Now. For tests.
+	now func() time.Time
+}
+
+// OnDemand is the OnDemandIndex used by the frontend.
+var OnDemand = &OnDemandIndex{Pool: redispool.Store}
+
+const onDemandKey = "search:index:ondemand"
+
+// Enabled is true if on-demand indexing is enabled.
+func (o *OnDemandIndex) Enabled() bool {
+	c := onDemandConfig()
+	return c != nil && c.Enabled
+}
+
+// Enqueue records that the named repositories were searched without an
+// index. They will be part of the list of repositories to index until they
+// are evicted by more recently searched repositories.
+func (o *OnDemandIndex) Enqueue(names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	c := o.Pool.Get()
+	defer c.Close()
+
+	score := o.timeNow().Unix()
+	key := o.redisKey()
+	args := redis.Args{}.Add(key)
+	for _, name := range names {
+		args = args.Add(score, name)
+	}
+	if _, err := c.Do("ZADD", args...); err != nil {
+		return errors.Wrap(err, "recording on-demand repositories")
+	}
+
+	// Evict the least recently searched repositories.
+	if _, err := c.Do("ZREMRANGEBYRANK", key, 0, -(onDemandMaxRepos() + 1)); err != nil {
+		return errors.Wrap(err, "evicting on-demand repositories")
+	}
+	return nil
+}
+
+// Touch records that the named repositories were searched with an index.
+// Repositories indexed on demand are moved to the end of the eviction order,
+// so that repositories are evicted by the time they were last searched rather
+// than by the time they were first indexed. Other repositories are ignored.
+func (o *OnDemandIndex) Touch(names []string) error {
+	if len(names) == 0 {
+		return nil
+	}
+
+	c := o.Pool.Get()
+	defer c.Close()
+
+	score := o.timeNow().Unix()
+	args := redis.Args{}.Add(o.redisKey(), "XX")
+	for _, name := range names {
+		args = args.Add(score, name)
+	}
+	if _, err := c.Do("ZADD", args...); err != nil {
+		return errors.Wrap(err, "touching on-demand repositories")
+	}
+	return nil
+}
+
+// Filter returns the subset of names that should be indexed: the
+// repositories matching alwaysIndex and the repositories which were recently
+// searched.
+//
+// Filter reuses the underlying array of names.
+func (o *OnDemandIndex) Filter(names []string) ([]string, error) {
+	var patterns []*regexp.Regexp
+	if c := onDemandConfig(); c != nil {
+		for _, p := range c.AlwaysIndex {
+			re, err := regexp.Compile(p)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid search.index.onDemand.alwaysIndex pattern %q", p)
+			}
+			patterns = append(patterns, re)
+		}
+	}
+
+	c := o.Pool.Get()
+	defer c.Close()
+
+	searched, err := redis.Strings(c.Do("ZRANGE", o.redisKey(), 0, -1))
+	if err != nil && err != redis.ErrNil {
+		return nil, errors.Wrap(err, "listing on-demand repositories")
+	}
+	set := make(map[string]struct{}, len(searched))
+	for _, name := range searched {
+		set[name] = struct{}{}
+	}
+
+	subset := names[:0]
+	for _, name := range names {
+		if _, ok := set[name]; ok || matchesAny(patterns, name) {
+			subset = append(subset, name)
+		}
+	}
+	return subset, nil
+}
+
+func (o *OnDemandIndex) redisKey() string {
+	if o.key != "" {
+		return o.key
+	}
+	return onDemandKey
+}
+
+func (o *OnDemandIndex) timeNow() time.Time {
+	if o.now != nil {
+		return o.now()
+	}
+	return time.Now()
+}
+
+func matchesAny(patterns []*regexp.Regexp, name string) bool {
+	for _, re := range patterns {
+		if re.MatchString(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func onDemandConfig() *schema.SearchIndexOnDemand {
+	return conf.ExperimentalFeatures().SearchIndexOnDemand
+}
+
+func onDemandMaxRepos() int {
+	if c := onDemandConfig(); c != nil && c.MaxRepos > 0 {
+		return c.MaxRepos
+	}
+	return defaultOnDemandMaxRepos
+}
@@ -0,0 +1,103 @@
+package backend
+
+import (
+	"os"
+	"testing"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestOnDemandIndex(t *testing.T) {
+	pool := &redis.Pool{
+		MaxIdle:     3,
+		IdleTimeout: 240 * time.Second,
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", "127.0.0.1:6379")
+		},
+	}
+	key := "__test__" + t.Name()
+
+	c := pool.Get()
+	defer c.Close()
+	if _, err := c.Do("PING"); err != nil {
+		// If we are not on CI, skip the test if our redis connection fails.
+		if os.Getenv("CI") == "" {
+			t.Skip("could not connect to redis", err)
+		}
+		t.Fatal(err)
+	}
+	if _, err := c.Do("DEL", key); err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { _, _ = c.Do("DEL", key) })
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ExperimentalFeatures: &schema.ExperimentalFeatures{
+			SearchIndexOnDemand: &schema.SearchIndexOnDemand{
+				Enabled:     true,
+				AlwaysIndex: []string{"^hot/"},
+				MaxRepos:    2,
+			},
+		},
+	}})
+	t.Cleanup(func() { conf.Mock(nil) })
+
+	now := time.Unix(1000, 0)
+	o := &OnDemandIndex{Pool: pool, key: key, now: func() time.Time { return now }}
+
+	if !o.Enabled() {
+		t.Fatal("expected on-demand indexing to be enabled")
+	}
+
+	all := func() []string {
+		return []string{"cold/a", "cold/b", "cold/c", "hot/a"}
+	}
+
+	have, err := o.Filter(all())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"hot/a"}, have); diff != "" {
+		t.Fatalf("unexpected repos before search (-want +have):\n%s", diff)
+	}
+
+	for _, name := range []string{"cold/a", "cold/b", "cold/c"} {
+		now = now.Add(time.Second)
+		if err := o.Enqueue([]string{name}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// cold/a is the least recently searched and evicted since maxRepos is 2.
+	have, err = o.Filter(all())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"cold/b", "cold/c", "hot/a"}, have); diff != "" {
+		t.Fatalf("unexpected repos after search (-want +have):\n%s", diff)
+	}
+
+	// Searching the index of cold/b keeps it from being evicted by cold/d,
+	// while searching hot/a doesn't take up an on-demand slot.
+	now = now.Add(time.Second)
+	if err := o.Touch([]string{"cold/b", "hot/a"}); err != nil {
+		t.Fatal(err)
+	}
+	now = now.Add(time.Second)
+	if err := o.Enqueue([]string{"cold/d"}); err != nil {
+		t.Fatal(err)
+	}
+
+	have, err = o.Filter(append(all(), "cold/d"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff([]string{"cold/b", "hot/a", "cold/d"}, have); diff != "" {
+		t.Fatalf("unexpected repos after indexed search (-want +have):\n%s", diff)
+	}
+}
@@ -0,0 +1,94 @@
+package backend
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// defaultSearchTrafficHalfLife is the half-life of searches if
+// search.index.trafficPriority.halfLifeHours is not set.
+const defaultSearchTrafficHalfLife = 7 * 24 * time.Hour
+
+var searchTrafficRecorded = promauto.NewCounter(prometheus.CounterOpts{
+	Name: "src_search_traffic_repo_searches_total",
+	Help: "Number of repository searches counted to order the indexing queue by search traffic.",
+})
+
+// SearchTrafficTracker counts how often repositories are searched, so that
+// frequently searched repositories are reindexed first.
+//
+// zoekt-sourcegraph-indexserver checks repositories for changes in the order
+// in which they are listed by the frontend. When traffic priority is enabled,
+// the list is ordered by decaying search counts instead. The counts are kept
+// in memory by Record, and periodically taken with Take and added to the
+// database, so that the searches on all frontend replicas are counted.
+type SearchTrafficTracker struct {
+	mu       sync.Mutex
+	searches map[api.RepoID]int
+}
+
+// SearchTraffic is the SearchTrafficTracker used by the frontend.
+var SearchTraffic = &SearchTrafficTracker{}
+
+// Enabled is true if the indexing queue is ordered by search traffic.
+func (t *SearchTrafficTracker) Enabled() bool {
+	c := searchTrafficConfig()
+	return c != nil && c.Enabled
+}
+
+// HalfLife is the duration after which a search counts half as much.
+func (t *SearchTrafficTracker) HalfLife() time.Duration {
+	if c := searchTrafficConfig(); c != nil && c.HalfLifeHours > 0 {
+		return time.Duration(c.HalfLifeHours) * time.Hour
+	}
+	return defaultSearchTrafficHalfLife
+}
+
+// Record counts a search of each of the repositories.
+func (t *SearchTrafficTracker) Record(ids []api.RepoID) {
+	if len(ids) == 0 || !t.Enabled() {
+		return
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if t.searches == nil {
+		t.searches = make(map[api.RepoID]int, len(ids))
+	}
+	for _, id := range ids {
+		t.searches[id]++
+	}
+	searchTrafficRecorded.Add(float64(len(ids)))
+}
+
+// Take returns the searches counted since the last call to Take.
+func (t *SearchTrafficTracker) Take() map[api.RepoID]int {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	searches := t.searches
+	t.searches = nil
+	return searches
+}
+
+// SortBySearchTraffic sorts names by their scores, highest first. Names
+// without a score keep their relative order after the names with a score.
+func SortBySearchTraffic(names []string, scores map[api.RepoName]float64) {
+	if len(scores) == 0 {
+		return
+	}
+	sort.SliceStable(names, func(i, j int) bool {
+		return scores[api.RepoName(names[i])] > scores[api.RepoName(names[j])]
+	})
+}
+
+func searchTrafficConfig() *schema.SearchIndexTrafficPriority {
+	return conf.ExperimentalFeatures().SearchIndexTrafficPriority
+}
@@ -0,0 +1,45 @@
+package backend
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+func TestSearchTrafficTracker(t *testing.T) {
+	var tracker SearchTrafficTracker
+
+	// Searches are not counted when disabled.
+	tracker.Record([]api.RepoID{1})
+	if got := tracker.Take(); len(got) != 0 {
+		t.Fatalf("got %v, want no searches", got)
+	}
+
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		ExperimentalFeatures: &schema.ExperimentalFeatures{
+			SearchIndexTrafficPriority: &schema.SearchIndexTrafficPriority{Enabled: true},
+		},
+	}})
+	defer conf.Mock(nil)
+
+	tracker.Record([]api.RepoID{1, 2})
+	tracker.Record([]api.RepoID{1})
+	if diff := cmp.Diff(map[api.RepoID]int{1: 2, 2: 1}, tracker.Take()); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+	if got := tracker.Take(); len(got) != 0 {
+		t.Errorf("got %v, want no searches after Take", got)
+	}
+}
+
+func TestSortBySearchTraffic(t *testing.T) {
+	names := []string{"a", "b", "c", "d"}
+	SortBySearchTraffic(names, map[api.RepoName]float64{"c": 1, "d": 5})
+	if diff := cmp.Diff([]string{"d", "c", "a", "b"}, names); diff != "" {
+		t.Errorf("mismatch (-want +got):\n%s", diff)
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 	"unicode/utf8"
 
 	otlog "github.com/opentracing/opentracing-go/log"
@@ -86,6 +87,8 @@ func ResolveCommitParameters(ctx context.Context, tp *search.TextParameters) (*s
 	}, nil
 }
 
+// commitParametersToDiffParameters returns the parameters of the git log search for op. It
+// returns nil if there is nothing to search, because no refs match the ref date filters.
 func commitParametersToDiffParameters(ctx context.Context, db dbutil.DB, op *search.CommitParameters) (*search.DiffParameters, error) {
 	args := []string{
 		"--no-prefix",
@@ -103,7 +106,24 @@ func commitParametersToDiffParameters(ctx context.Context, db dbutil.DB, op *sea
 		args = append(args, "--regexp-ignore-case")
 	}
 
-	for _, rev := range op.RepoRevs.Revs {
+	revs := op.RepoRevs.Revs
+	if op.RepoRevs.HasDateFilter() {
+		// git log cannot filter refs by their date, so pass the refs matched by the
+		// ref globs and date filters instead of the globs.
+		revSpecs, err := op.RepoRevs.ExpandedRevSpecs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		if len(revSpecs) == 0 {
+			return nil, nil
+		}
+		sort.Strings(revSpecs)
+		revs = make([]search.RevisionSpecifier, 0, len(revSpecs))
+		for _, revSpec := range revSpecs {
+			revs = append(revs, search.RevisionSpecifier{RevSpec: revSpec})
+		}
+	}
+	for _, rev := range revs {
 		switch {
 		case rev.RevSpec != "":
 			if strings.HasPrefix(rev.RevSpec, "-") {
@@ -124,13 +144,24 @@ func commitParametersToDiffParameters(ctx context.Context, db dbutil.DB, op *sea
 		}
 	}
 
+	// Dates are parsed by us rather than git, so that they mean the same as
+	// when the query was validated.
+	now := time.Now()
 	beforeValues, _ := op.Query.StringValues(query.FieldBefore)
 	for _, s := range beforeValues {
-		args = append(args, "--until="+s)
+		t, err := query.ParseDate(s, now)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--until="+t.Format(time.RFC3339))
 	}
 	afterValues, _ := op.Query.StringValues(query.FieldAfter)
 	for _, s := range afterValues {
-		args = append(args, "--since="+s)
+		t, err := query.ParseDate(s, now)
+		if err != nil {
+			return nil, err
+		}
+		args = append(args, "--since="+t.Format(time.RFC3339))
 	}
 
 	// Helper for adding git log flags --grep, --author, and --committer, which all behave similarly.
@@ -222,7 +253,7 @@ func searchCommitsInRepoStream(ctx context.Context, db dbutil.DB, op search.Comm
 	}()
 
 	diffParameters, err := commitParametersToDiffParameters(ctx, db, &op)
-	if err != nil {
+	if err != nil || diffParameters == nil {
 		return err
 	}
 
@@ -0,0 +1,104 @@
+// Package export converts search results into the rows of exported search
+// results, which are shared by the search export endpoint and search jobs.
+package export
+
+import (
+	"strconv"
+
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// Row is a single row of exported search results. A file match with multiple
+// line matches results in one row per line match.
+type Row struct {
+	Repository string `json:"repository"`
+	Commit     string `json:"commit,omitempty"`
+	Path       string `json:"path,omitempty"`
+	// Line is the 1-based line number of the match. It is 0 if the match is
+	// not a line match.
+	Line  int    `json:"line,omitempty"`
+	Match string `json:"match"`
+}
+
+// Columns are the names of the columns of the CSV records of rows.
+var Columns = []string{"repository", "commit", "path", "line", "match"}
+
+// Record returns the CSV record of r.
+func (r Row) Record() []string {
+	line := ""
+	if r.Line > 0 {
+		line = strconv.Itoa(r.Line)
+	}
+	return []string{r.Repository, r.Commit, r.Path, line, r.Match}
+}
+
+// Less reports whether r is sorted before o, in the order of repository,
+// commit, path and line.
+func (r Row) Less(o Row) bool {
+	if r.Repository != o.Repository {
+		return r.Repository < o.Repository
+	}
+	if r.Commit != o.Commit {
+		return r.Commit < o.Commit
+	}
+	if r.Path != o.Path {
+		return r.Path < o.Path
+	}
+	if r.Line != o.Line {
+		return r.Line < o.Line
+	}
+	return r.Match < o.Match
+}
+
+// Rows converts matches into rows.
+func Rows(matches []result.Match) []Row {
+	var rows []Row
+	for _, match := range matches {
+		switch v := match.(type) {
+		case *result.FileMatch:
+			base := Row{
+				Repository: string(v.Repo.Name),
+				Commit:     string(v.CommitID),
+				Path:       v.Path,
+			}
+			switch {
+			case len(v.Symbols) > 0:
+				for _, sym := range v.Symbols {
+					row := base
+					row.Line = sym.Symbol.Line
+					row.Match = sym.Symbol.Name
+					rows = append(rows, row)
+				}
+			case len(v.LineMatches) > 0:
+				for _, lm := range v.LineMatches {
+					row := base
+					row.Line = int(lm.LineNumber) + 1
+					row.Match = lm.Preview
+					rows = append(rows, row)
+				}
+			default:
+				row := base
+				row.Match = v.Path
+				rows = append(rows, row)
+			}
+		case *result.RepoMatch:
+			rows = append(rows, Row{
+				Repository: string(v.Name),
+				Match:      string(v.Name),
+			})
+		case *result.CommitMatch:
+			match := string(v.Commit.Message)
+			if v.DiffPreview != nil {
+				match = v.DiffPreview.Value
+			} else if v.MessagePreview != nil {
+				match = v.MessagePreview.Value
+			}
+			rows = append(rows, Row{
+				Repository: string(v.Repo.Name),
+				Commit:     string(v.Commit.ID),
+				Match:      match,
+			})
+		}
+	}
+	return rows
+}
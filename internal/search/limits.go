@@ -1,6 +1,7 @@
 package search
 
 import (
+	"fmt"
 	"math"
 	"time"
 
@@ -42,3 +43,29 @@ func SearchLimits(c *conf.Unified) schema.SearchLimits {
 
 	return limits
 }
+
+// MaxUserTimeout returns the maximum value of "timeout:" that users may set in
+// search queries (the search.maxUserTimeout site configuration), or 0 if there
+// is no maximum.
+func MaxUserTimeout(c *conf.Unified) time.Duration {
+	if c.SearchMaxUserTimeout == "" {
+		return 0
+	}
+	d, err := time.ParseDuration(c.SearchMaxUserTimeout)
+	if err != nil || d <= 0 {
+		return 0
+	}
+	return d
+}
+
+func init() {
+	conf.ContributeValidator(func(c conf.Unified) (problems conf.Problems) {
+		if c.SearchMaxUserTimeout == "" {
+			return nil
+		}
+		if d, err := time.ParseDuration(c.SearchMaxUserTimeout); err != nil || d <= 0 {
+			problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("search.maxUserTimeout: invalid duration %q (examples: \"90s\", \"2m\")", c.SearchMaxUserTimeout)))
+		}
+		return problems
+	})
+}
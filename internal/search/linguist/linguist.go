@@ -0,0 +1,176 @@
+// Package linguist classifies files as generated or vendored code, honoring
+// the linguist-generated and linguist-vendored overrides of a repository's
+// .gitattributes file.
+package linguist
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+
+	"github.com/go-enry/go-enry/v2"
+	"github.com/gobwas/glob"
+)
+
+const (
+	attrGenerated = "linguist-generated"
+	attrVendored  = "linguist-vendored"
+)
+
+// state is the state of an attribute for a path, as defined by gitattributes(5).
+type state int
+
+const (
+	unspecified state = iota
+	set
+	unset
+)
+
+type rule struct {
+	globs []glob.Glob
+	// attrs holds the attributes this rule mentions. An attribute that is
+	// mentioned as unspecified (!attr) resets overrides of earlier rules.
+	attrs map[string]state
+}
+
+func (r *rule) match(name string) bool {
+	for _, g := range r.globs {
+		if g.Match(name) {
+			return true
+		}
+	}
+	return false
+}
+
+// Attributes holds the linguist overrides of a .gitattributes file. The zero
+// value has no overrides.
+type Attributes struct {
+	rules []rule
+}
+
+// Parse parses the contents of a .gitattributes file at the root of a
+// repository. Lines that can't be parsed and attributes other than
+// linguist-generated and linguist-vendored are ignored.
+func Parse(data []byte) *Attributes {
+	var a Attributes
+	s := bufio.NewScanner(bytes.NewReader(data))
+	for s.Scan() {
+		fields := strings.Fields(s.Text())
+		if len(fields) < 2 || strings.HasPrefix(fields[0], "#") {
+			continue
+		}
+
+		attrs := make(map[string]state)
+		for _, f := range fields[1:] {
+			name, st := parseAttr(f)
+			if name == attrGenerated || name == attrVendored {
+				attrs[name] = st
+			}
+		}
+		if len(attrs) == 0 {
+			continue
+		}
+
+		globs, ok := compilePattern(fields[0])
+		if !ok {
+			continue
+		}
+		a.rules = append(a.rules, rule{globs: globs, attrs: attrs})
+	}
+	return &a
+}
+
+// parseAttr parses an attribute of a gitattributes line, like attr, -attr,
+// !attr or attr=value.
+func parseAttr(f string) (string, state) {
+	switch {
+	case strings.HasPrefix(f, "-"):
+		return f[1:], unset
+	case strings.HasPrefix(f, "!"):
+		return f[1:], unspecified
+	}
+	if i := strings.IndexByte(f, '='); i >= 0 {
+		switch strings.ToLower(f[i+1:]) {
+		case "false", "0":
+			return f[:i], unset
+		default:
+			return f[:i], set
+		}
+	}
+	return f, set
+}
+
+// compilePattern compiles a gitattributes pattern. Patterns without a slash
+// match the base name of a path, other patterns match the path relative to
+// the root of the repository.
+func compilePattern(pattern string) ([]glob.Glob, bool) {
+	// Quoted patterns, negative patterns and patterns matching directories
+	// are not supported by gitattributes or rarely used with linguist.
+	if strings.HasPrefix(pattern, `"`) || strings.HasPrefix(pattern, "!") || strings.HasSuffix(pattern, "/") {
+		return nil, false
+	}
+
+	var patterns []string
+	switch {
+	case !strings.Contains(pattern, "/"):
+		patterns = []string{"**/" + pattern, pattern}
+	case strings.HasPrefix(pattern, "**/"):
+		patterns = []string{pattern, strings.TrimPrefix(pattern, "**/")}
+	default:
+		patterns = []string{strings.TrimPrefix(pattern, "/")}
+	}
+	if strings.Contains(pattern, "/**/") {
+		// A slash followed by two asterisks and a slash matches zero or
+		// more directories.
+		patterns = append(patterns, strings.TrimPrefix(strings.ReplaceAll(pattern, "/**/", "/"), "/"))
+	}
+
+	globs := make([]glob.Glob, 0, len(patterns))
+	for _, p := range patterns {
+		g, err := glob.Compile(p, '/')
+		if err != nil {
+			return nil, false
+		}
+		globs = append(globs, g)
+	}
+	return globs, true
+}
+
+// lookup returns the state of attr for name. The last rule matching name
+// that mentions attr wins.
+func (a *Attributes) lookup(name, attr string) state {
+	if a == nil {
+		return unspecified
+	}
+	for i := len(a.rules) - 1; i >= 0; i-- {
+		r := &a.rules[i]
+		if st, ok := r.attrs[attr]; ok && r.match(name) {
+			return st
+		}
+	}
+	return unspecified
+}
+
+// IsGenerated returns whether the file at name is generated code. Without an
+// override in .gitattributes, the path heuristics of linguist are used.
+func (a *Attributes) IsGenerated(name string) bool {
+	switch a.lookup(name, attrGenerated) {
+	case set:
+		return true
+	case unset:
+		return false
+	}
+	return enry.IsGenerated(name, nil)
+}
+
+// IsVendored returns whether the file at name is vendored code. Without an
+// override in .gitattributes, the path heuristics of linguist are used.
+func (a *Attributes) IsVendored(name string) bool {
+	switch a.lookup(name, attrVendored) {
+	case set:
+		return true
+	case unset:
+		return false
+	}
+	return enry.IsVendor(name)
+}
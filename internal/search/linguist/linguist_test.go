@@ -0,0 +1,60 @@
+package linguist
+
+import "testing"
+
+func TestAttributes(t *testing.T) {
+	attrs := Parse([]byte(`# comments are ignored
+*.pb.go linguist-generated=true
+/gen/** linguist-generated
+docs/**/*.md linguist-vendored
+third_party/** linguist-vendored
+third_party/ours/** -linguist-vendored
+node_modules/** linguist-vendored=false
+*.min.js linguist-generated
+web/legacy/*.min.js !linguist-generated
+*.txt text eol=lf
+"quoted file" linguist-generated
+`))
+
+	cases := []struct {
+		path      string
+		generated bool
+		vendored  bool
+	}{
+		{path: "main.go"},
+		{path: "api/api.pb.go", generated: true},
+		{path: "api.pb.go", generated: true},
+		{path: "gen/foo/bar.go", generated: true},
+		{path: "src/gen/bar.go"},
+		{path: "docs/guide/intro.md", vendored: true},
+		{path: "docs/intro.md", vendored: true},
+		{path: "third_party/lib/lib.go", vendored: true},
+		{path: "third_party/ours/lib.go"},
+		{path: "node_modules/left-pad/index.js", generated: true},
+		{path: "vendor/github.com/pkg/errors/errors.go", generated: true, vendored: true},
+		{path: "web/app.min.js", generated: true, vendored: true},
+		{path: "web/legacy/old.min.js", vendored: true},
+		{path: "quoted file"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.path, func(t *testing.T) {
+			if have := attrs.IsGenerated(tc.path); have != tc.generated {
+				t.Errorf("IsGenerated: have %v, want %v", have, tc.generated)
+			}
+			if have := attrs.IsVendored(tc.path); have != tc.vendored {
+				t.Errorf("IsVendored: have %v, want %v", have, tc.vendored)
+			}
+		})
+	}
+}
+
+func TestAttributesNil(t *testing.T) {
+	var attrs *Attributes
+	if !attrs.IsVendored("vendor/foo.go") {
+		t.Error("expected vendor/foo.go to be vendored by default")
+	}
+	if attrs.IsGenerated("main.go") {
+		t.Error("expected main.go not to be generated by default")
+	}
+}
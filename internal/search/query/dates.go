@@ -0,0 +1,170 @@
+package query
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+)
+
+// dateLayouts are the layouts of the absolute dates accepted by ParseDate.
+// Dates without a time zone are in the time zone of the reference time.
+var dateLayouts = []string{
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+	"2006-01-02",
+	"2006/01/02",
+	"1/2/2006",
+	"January 2 2006",
+	"January 2, 2006",
+	"Jan 2 2006",
+	"Jan 2, 2006",
+	"2 January 2006",
+	"2 Jan 2006",
+}
+
+// dateUnits maps the units of relative dates like "3 weeks ago" to functions
+// which go back n units from t.
+var dateUnits = map[string]func(t time.Time, n int) time.Time{
+	"second": func(t time.Time, n int) time.Time { return t.Add(-time.Duration(n) * time.Second) },
+	"sec":    func(t time.Time, n int) time.Time { return t.Add(-time.Duration(n) * time.Second) },
+	"minute": func(t time.Time, n int) time.Time { return t.Add(-time.Duration(n) * time.Minute) },
+	"min":    func(t time.Time, n int) time.Time { return t.Add(-time.Duration(n) * time.Minute) },
+	"hour":   func(t time.Time, n int) time.Time { return t.Add(-time.Duration(n) * time.Hour) },
+	"day":    func(t time.Time, n int) time.Time { return t.AddDate(0, 0, -n) },
+	"week":   func(t time.Time, n int) time.Time { return t.AddDate(0, 0, -7*n) },
+	"month":  func(t time.Time, n int) time.Time { return t.AddDate(0, -n, 0) },
+	"year":   func(t time.Time, n int) time.Time { return t.AddDate(-n, 0, 0) },
+}
+
+var weekdays = map[string]time.Weekday{
+	"sunday":    time.Sunday,
+	"monday":    time.Monday,
+	"tuesday":   time.Tuesday,
+	"wednesday": time.Wednesday,
+	"thursday":  time.Thursday,
+	"friday":    time.Friday,
+	"saturday":  time.Saturday,
+}
+
+// isoDurationRegexp matches ISO 8601 durations like "P1Y2M", "P3W" or "PT36H".
+var isoDurationRegexp = regexp.MustCompile(`^p(?:(\d+)y)?(?:(\d+)m)?(?:(\d+)w)?(?:(\d+)d)?(?:t(?:(\d+)h)?(?:(\d+)m)?(?:(\d+)s)?)?$`)
+
+// ParseDate parses the value of a before: or after: filter relative to now.
+// It accepts
+//
+//   - absolute dates, like "2019-11-01", "2019-11-01T15:04:05Z" or "november 1 2019",
+//   - relative dates, like "3 weeks ago", "yesterday" or "last thursday",
+//   - ISO 8601 durations, like "P3W" or "PT36H", which stand for the time that
+//     long ago.
+//
+// "today", "yesterday" and weekdays stand for the start of the day.
+func ParseDate(value string, now time.Time) (time.Time, error) {
+	trimmed := strings.Join(strings.Fields(value), " ")
+	if trimmed == "" {
+		return time.Time{}, errors.New("empty date")
+	}
+
+	// Month names are matched case insensitively.
+	for _, layout := range dateLayouts {
+		if t, err := time.ParseInLocation(layout, trimmed, now.Location()); err == nil {
+			return t, nil
+		}
+	}
+
+	s := strings.ToLower(trimmed)
+
+	if t, ok := parseISODuration(s, now); ok {
+		return t, nil
+	}
+
+	if t, ok := parseRelativeDate(s, now); ok {
+		return t, nil
+	}
+
+	return time.Time{}, errors.Errorf("unrecognized date %q", value)
+}
+
+// parseISODuration parses an ISO 8601 duration and returns the time that long
+// before now.
+func parseISODuration(s string, now time.Time) (time.Time, bool) {
+	m := isoDurationRegexp.FindStringSubmatch(s)
+	if m == nil || s == "p" || strings.HasSuffix(s, "t") {
+		return time.Time{}, false
+	}
+
+	n := make([]int, len(m))
+	for i, v := range m[1:] {
+		if v == "" {
+			continue
+		}
+		var err error
+		if n[i+1], err = strconv.Atoi(v); err != nil {
+			return time.Time{}, false
+		}
+	}
+
+	years, months, weeks, days, hours, minutes, seconds := n[1], n[2], n[3], n[4], n[5], n[6], n[7]
+	t := now.AddDate(-years, -months, -7*weeks-days)
+	return t.Add(-time.Duration(hours)*time.Hour - time.Duration(minutes)*time.Minute - time.Duration(seconds)*time.Second), true
+}
+
+// parseRelativeDate parses dates relative to now like "3 weeks ago",
+// "3.weeks.ago", "yesterday", "last week" and "last thursday".
+func parseRelativeDate(s string, now time.Time) (time.Time, bool) {
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, now.Location())
+
+	// git accepts dots instead of spaces, e.g. "3.weeks.ago".
+	words := strings.Fields(strings.ReplaceAll(s, ".", " "))
+	if len(words) > 1 && words[len(words)-1] == "ago" {
+		words = words[:len(words)-1]
+	}
+
+	switch len(words) {
+	case 1:
+		switch words[0] {
+		case "now":
+			return now, true
+		case "today":
+			return today, true
+		case "yesterday":
+			return today.AddDate(0, 0, -1), true
+		}
+		if day, ok := weekdays[words[0]]; ok {
+			return lastWeekday(today, day), true
+		}
+
+	case 2:
+		n, err := strconv.Atoi(words[0])
+		switch {
+		case words[0] == "last" || words[0] == "a" || words[0] == "an":
+			n = 1
+		case err != nil || n < 0:
+			return time.Time{}, false
+		}
+
+		if day, ok := weekdays[words[1]]; ok && words[0] == "last" {
+			return lastWeekday(today, day), true
+		}
+		unit := strings.TrimSuffix(words[1], "s")
+		if back, ok := dateUnits[unit]; ok {
+			return back(now, n), true
+		}
+	}
+
+	return time.Time{}, false
+}
+
+// lastWeekday returns the start of the last day before today which is the
+// given weekday.
+func lastWeekday(today time.Time, day time.Weekday) time.Time {
+	diff := int(today.Weekday() - day)
+	if diff <= 0 {
+		diff += 7
+	}
+	return today.AddDate(0, 0, -diff)
+}
@@ -0,0 +1,59 @@
+package query
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseDate(t *testing.T) {
+	// A Thursday.
+	now := time.Date(2021, 6, 10, 15, 4, 5, 0, time.UTC)
+
+	cases := []struct {
+		input string
+		want  time.Time
+	}{
+		{input: "2019-11-01", want: time.Date(2019, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{input: "2019-11-01T10:00:00Z", want: time.Date(2019, 11, 1, 10, 0, 0, 0, time.UTC)},
+		{input: "2019-11-01T10:00:00+02:00", want: time.Date(2019, 11, 1, 8, 0, 0, 0, time.UTC)},
+		{input: "2019-11-01 10:30", want: time.Date(2019, 11, 1, 10, 30, 0, 0, time.UTC)},
+		{input: "11/1/2019", want: time.Date(2019, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{input: "november 1 2019", want: time.Date(2019, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{input: "Nov 1, 2019", want: time.Date(2019, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{input: "1 November 2019", want: time.Date(2019, 11, 1, 0, 0, 0, 0, time.UTC)},
+		{input: "now", want: now},
+		{input: "today", want: time.Date(2021, 6, 10, 0, 0, 0, 0, time.UTC)},
+		{input: "yesterday", want: time.Date(2021, 6, 9, 0, 0, 0, 0, time.UTC)},
+		{input: "3 weeks ago", want: time.Date(2021, 5, 20, 15, 4, 5, 0, time.UTC)},
+		{input: "3.weeks.ago", want: time.Date(2021, 5, 20, 15, 4, 5, 0, time.UTC)},
+		{input: "  2 Hours  ago ", want: time.Date(2021, 6, 10, 13, 4, 5, 0, time.UTC)},
+		{input: "1 month", want: time.Date(2021, 5, 10, 15, 4, 5, 0, time.UTC)},
+		{input: "a year ago", want: time.Date(2020, 6, 10, 15, 4, 5, 0, time.UTC)},
+		{input: "last week", want: time.Date(2021, 6, 3, 15, 4, 5, 0, time.UTC)},
+		{input: "last thursday", want: time.Date(2021, 6, 3, 0, 0, 0, 0, time.UTC)},
+		{input: "monday", want: time.Date(2021, 6, 7, 0, 0, 0, 0, time.UTC)},
+		{input: "P2W", want: time.Date(2021, 5, 27, 15, 4, 5, 0, time.UTC)},
+		{input: "P1Y2M3D", want: time.Date(2020, 4, 7, 15, 4, 5, 0, time.UTC)},
+		{input: "PT36H", want: time.Date(2021, 6, 9, 3, 4, 5, 0, time.UTC)},
+		{input: "P1DT30M", want: time.Date(2021, 6, 9, 14, 34, 5, 0, time.UTC)},
+	}
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			got, err := ParseDate(c.input, now)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !got.Equal(c.want) {
+				t.Errorf("got %s, want %s", got, c.want)
+			}
+		})
+	}
+
+	for _, input := range []string{"", "soon", "3 fortnights ago", "-1 days ago", "P", "P1DT", "next thursday", "2019-13-01"} {
+		t.Run(input, func(t *testing.T) {
+			if _, err := ParseDate(input, now); err == nil {
+				t.Errorf("expected an error for %q", input)
+			}
+		})
+	}
+}
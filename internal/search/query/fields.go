@@ -11,10 +11,13 @@ const (
 	FieldFile               = "file"
 	FieldFork               = "fork"
 	FieldArchived           = "archived"
+	FieldGenerated          = "generated"
+	FieldVendored           = "vendored"
 	FieldLang               = "lang"
 	FieldType               = "type"
 	FieldRepoHasFile        = "repohasfile"
 	FieldRepoHasCommitAfter = "repohascommitafter"
+	FieldRepoHasMeta        = "repohasmeta"
 	FieldPatternType        = "patterntype"
 	FieldContent            = "content"
 	FieldVisibility         = "visibility"
@@ -34,8 +37,18 @@ const (
 	FieldTimeout   = "timeout"
 	FieldCombyRule = "rule"
 	FieldSelect    = "select"
+	FieldDedupe    = "dedupe"
+	FieldGroup     = "group"
 )
 
+// DedupeForks is the value of the dedupe: field which groups identical file
+// matches found in forks of the same repository.
+const DedupeForks = "forks"
+
+// GroupRepo is the value of the group: field which groups results by
+// repository.
+const GroupRepo = "repo"
+
 var allFields = map[string]struct{}{
 	FieldCase:               empty,
 	FieldRepo:               empty,
@@ -47,6 +60,8 @@ var allFields = map[string]struct{}{
 	"f":                     empty,
 	FieldFork:               empty,
 	FieldArchived:           empty,
+	FieldGenerated:          empty,
+	FieldVendored:           empty,
 	FieldLang:               empty,
 	"l":                     empty,
 	"language":              empty,
@@ -56,6 +71,7 @@ var allFields = map[string]struct{}{
 	FieldVisibility:         empty,
 	FieldRepoHasFile:        empty,
 	FieldRepoHasCommitAfter: empty,
+	FieldRepoHasMeta:        empty,
 	FieldBefore:             empty,
 	"until":                 empty,
 	FieldAfter:              empty,
@@ -72,6 +88,8 @@ var allFields = map[string]struct{}{
 	FieldRev:                empty,
 	"revision":              empty,
 	FieldSelect:             empty,
+	FieldDedupe:             empty,
+	FieldGroup:              empty,
 }
 
 var aliases = map[string]string{
@@ -15,6 +15,7 @@ const (
 	HeuristicHoisted
 	Structural
 	IsPredicate
+	HeuristicFieldAsPattern
 )
 
 var allLabels = map[labels]string{
@@ -27,6 +28,7 @@ var allLabels = map[labels]string{
 	HeuristicHoisted:          "HeuristicHoisted",
 	Structural:                "Structural",
 	IsPredicate:               "IsPredicate",
+	HeuristicFieldAsPattern:   "HeuristicFieldAsPattern",
 }
 
 func (l *labels) IsSet(label labels) bool {
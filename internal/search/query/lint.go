@@ -0,0 +1,254 @@
+package query
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"unicode"
+)
+
+// LintKind is the kind of problem a LintWarning describes.
+type LintKind string
+
+const (
+	// LintRedundantFilter is a filter that appears more than once.
+	LintRedundantFilter LintKind = "REDUNDANT_FILTER"
+	// LintImpossibleFilters are filters that can't all hold at the same time,
+	// like fork:only fork:no.
+	LintImpossibleFilters LintKind = "IMPOSSIBLE_FILTERS"
+	// LintDeprecatedFilter is a filter that has a newer replacement.
+	LintDeprecatedFilter LintKind = "DEPRECATED_FILTER"
+	// LintLiteralPattern is text of a literal search that looks like a filter
+	// or group, but is searched for as part of the pattern.
+	LintLiteralPattern LintKind = "LITERAL_PATTERN"
+)
+
+// LintWarning describes a part of a query that is most likely not what the
+// user intended.
+type LintWarning struct {
+	Kind    LintKind
+	Message string
+	Range   Range
+	Fixes   []LintFix
+}
+
+// LintFix is a machine-applicable fix for a LintWarning. It replaces the text
+// in Range of the original query with Replacement.
+type LintFix struct {
+	Description string
+	Range       Range
+	Replacement string
+}
+
+// Apply returns the query in with the fix applied.
+func (f LintFix) Apply(in string) string {
+	return in[:f.Range.Start.Column] + f.Replacement + in[f.Range.End.Column:]
+}
+
+// Lint returns warnings about filters in the query that are redundant,
+// contradict each other, or use deprecated syntax. It does not validate the
+// query, so a query without warnings may still be invalid.
+func Lint(in string, searchType SearchType) ([]LintWarning, error) {
+	nodes, err := Parse(in, searchType)
+	if err != nil {
+		return nil, err
+	}
+
+	params := conjunctiveParameters(nodes)
+	sort.SliceStable(params, func(i, j int) bool {
+		return params[i].Annotation.Range.Start.Column < params[j].Annotation.Range.Start.Column
+	})
+
+	var warnings []LintWarning
+	warnings = append(warnings, lintRedundantFilters(in, params)...)
+	warnings = append(warnings, lintImpossibleFilters(in, params)...)
+	warnings = append(warnings, lintDeprecatedFilters(in, params)...)
+	if searchType == SearchTypeLiteral {
+		warnings = append(warnings, lintLiteralPatterns(in, nodes)...)
+	}
+	return warnings, nil
+}
+
+// conjunctiveParameters returns the parameters that must all hold for a
+// result to match, i.e. those that are not nested below an or-expression.
+func conjunctiveParameters(nodes []Node) []Parameter {
+	var params []Parameter
+	for _, node := range nodes {
+		switch n := node.(type) {
+		case Parameter:
+			params = append(params, n)
+		case Operator:
+			if n.Kind != Or {
+				params = append(params, conjunctiveParameters(n.Operands)...)
+			}
+		}
+	}
+	return params
+}
+
+type lintParameterKey struct {
+	field   string
+	value   string
+	negated bool
+}
+
+func lintKey(p Parameter) lintParameterKey {
+	return lintParameterKey{
+		field:   resolveFieldAlias(strings.ToLower(p.Field)),
+		value:   p.Value,
+		negated: p.Negated,
+	}
+}
+
+func lintRedundantFilters(in string, params []Parameter) []LintWarning {
+	var warnings []LintWarning
+	seen := make(map[lintParameterKey]struct{}, len(params))
+	for _, p := range params {
+		key := lintKey(p)
+		if _, ok := seen[key]; !ok {
+			seen[key] = struct{}{}
+			continue
+		}
+		text := rangeText(in, p.Annotation.Range)
+		warnings = append(warnings, LintWarning{
+			Kind:    LintRedundantFilter,
+			Message: fmt.Sprintf("%s appears more than once in the query.", text),
+			Range:   p.Annotation.Range,
+			Fixes:   []LintFix{removeFix(in, p.Annotation.Range, fmt.Sprintf("Remove the duplicate %s", text))},
+		})
+	}
+	return warnings
+}
+
+// singularValueFields are fields where two different values can't both hold.
+var singularValueFields = map[string]struct{}{
+	FieldFork:       empty,
+	FieldArchived:   empty,
+	FieldGenerated:  empty,
+	FieldVendored:   empty,
+	FieldVisibility: empty,
+}
+
+func lintImpossibleFilters(in string, params []Parameter) []LintWarning {
+	var warnings []LintWarning
+	impossible := func(a, b Parameter) {
+		textA, textB := rangeText(in, a.Annotation.Range), rangeText(in, b.Annotation.Range)
+		warnings = append(warnings, LintWarning{
+			Kind:    LintImpossibleFilters,
+			Message: fmt.Sprintf("%s and %s can't both hold, so the query can't match anything.", textA, textB),
+			Range:   b.Annotation.Range,
+			Fixes: []LintFix{
+				removeFix(in, a.Annotation.Range, fmt.Sprintf("Remove %s", textA)),
+				removeFix(in, b.Annotation.Range, fmt.Sprintf("Remove %s", textB)),
+			},
+		})
+	}
+
+	firstValue := make(map[string]Parameter)
+	seen := make(map[lintParameterKey]Parameter, len(params))
+	for _, p := range params {
+		key := lintKey(p)
+		opposite := key
+		opposite.negated = !key.negated
+		if q, ok := seen[opposite]; ok {
+			impossible(q, p)
+		}
+		if _, ok := seen[key]; !ok {
+			seen[key] = p
+		}
+
+		if _, ok := singularValueFields[key.field]; !ok || p.Negated {
+			continue
+		}
+		if q, ok := firstValue[key.field]; !ok {
+			firstValue[key.field] = p
+		} else if !strings.EqualFold(q.Value, p.Value) {
+			impossible(q, p)
+		}
+	}
+	return warnings
+}
+
+// deprecatedFields maps deprecated fields to the repo: predicate that
+// replaces them.
+var deprecatedFields = map[string]string{
+	FieldRepoHasFile:        "contains.file",
+	FieldRepoHasCommitAfter: "contains.commit.after",
+}
+
+func lintDeprecatedFilters(in string, params []Parameter) []LintWarning {
+	var warnings []LintWarning
+	for _, p := range params {
+		field := strings.ToLower(p.Field)
+		predicate, ok := deprecatedFields[field]
+		if !ok {
+			continue
+		}
+
+		w := LintWarning{
+			Kind:    LintDeprecatedFilter,
+			Message: fmt.Sprintf("%s: is deprecated, use %s:%s(...) instead.", field, FieldRepo, predicate),
+			Range:   p.Annotation.Range,
+		}
+		// Values with parentheses can't be safely moved into a predicate,
+		// and predicates can't be negated.
+		if !strings.ContainsAny(p.Value, "()") && !p.Negated {
+			replacement := fmt.Sprintf("%s:%s(%s)", FieldRepo, predicate, p.Value)
+			w.Fixes = []LintFix{{
+				Description: fmt.Sprintf("Replace with %s", replacement),
+				Range:       p.Annotation.Range,
+				Replacement: replacement,
+			}}
+		}
+		warnings = append(warnings, w)
+	}
+	return warnings
+}
+
+// lintLiteralPatterns warns about text of a literal search that the parser
+// searches for literally although it looks like a filter or group: filters
+// without a value, and parentheses that could only be parsed as text.
+func lintLiteralPatterns(in string, nodes []Node) []LintWarning {
+	var warnings []LintWarning
+	VisitPattern(nodes, func(_ string, _ bool, annotation Annotation) {
+		if !annotation.Labels.IsSet(HeuristicFieldAsPattern) {
+			return
+		}
+		text := rangeText(in, annotation.Range)
+		warnings = append(warnings, LintWarning{
+			Kind:    LintLiteralPattern,
+			Message: fmt.Sprintf("%s has no value, so it is searched for as text. Remove the space after the colon to use it as a filter.", text),
+			Range:   annotation.Range,
+		})
+	})
+
+	if _, err := parse(in, SearchTypeLiteral); err != nil {
+		// Parse only succeeded with the literal fallback parser.
+		warnings = append(warnings, LintWarning{
+			Kind:    LintLiteralPattern,
+			Message: "The parentheses in the query don't form groups, so they are searched for as text.",
+			Range:   newRange(0, len(in)),
+		})
+	}
+	return warnings
+}
+
+func rangeText(in string, r Range) string {
+	return in[r.Start.Column:r.End.Column]
+}
+
+// removeFix returns a fix that removes the text in r together with the
+// whitespace separating it from the rest of the query.
+func removeFix(in string, r Range, description string) LintFix {
+	start, end := r.Start.Column, r.End.Column
+	if start > 0 {
+		for start > 0 && unicode.IsSpace(rune(in[start-1])) {
+			start--
+		}
+	} else {
+		for end < len(in) && unicode.IsSpace(rune(in[end])) {
+			end++
+		}
+	}
+	return LintFix{Description: description, Range: newRange(start, end)}
+}
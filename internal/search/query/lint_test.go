@@ -0,0 +1,126 @@
+package query
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestLint(t *testing.T) {
+	type fix struct {
+		Description string
+		Query       string
+	}
+	type warning struct {
+		Kind    LintKind
+		Message string
+		Fixes   []fix
+	}
+
+	cases := []struct {
+		query string
+		want  []warning
+	}{
+		{
+			query: `repo:foo bar`,
+			want:  nil,
+		},
+		{
+			query: `repo:foo bar r:foo`,
+			want: []warning{{
+				Kind:    LintRedundantFilter,
+				Message: "r:foo appears more than once in the query.",
+				Fixes:   []fix{{"Remove the duplicate r:foo", "repo:foo bar"}},
+			}},
+		},
+		{
+			query: `(repo:foo bar) or (repo:foo baz)`,
+			want:  nil,
+		},
+		{
+			query: `fork:only fork:no bar`,
+			want: []warning{{
+				Kind:    LintImpossibleFilters,
+				Message: "fork:only and fork:no can't both hold, so the query can't match anything.",
+				Fixes: []fix{
+					{"Remove fork:only", "fork:no bar"},
+					{"Remove fork:no", "fork:only bar"},
+				},
+			}},
+		},
+		{
+			query: `-file:test bar file:test`,
+			want: []warning{{
+				Kind:    LintImpossibleFilters,
+				Message: "-file:test and file:test can't both hold, so the query can't match anything.",
+				Fixes: []fix{
+					{"Remove -file:test", "bar file:test"},
+					{"Remove file:test", "-file:test bar"},
+				},
+			}},
+		},
+		{
+			query: `repohasfile:README bar`,
+			want: []warning{{
+				Kind:    LintDeprecatedFilter,
+				Message: "repohasfile: is deprecated, use repo:contains.file(...) instead.",
+				Fixes:   []fix{{"Replace with repo:contains.file(README)", "repo:contains.file(README) bar"}},
+			}},
+		},
+		{
+			query: `bar -repohasfile:README`,
+			want: []warning{{
+				Kind:    LintDeprecatedFilter,
+				Message: "repohasfile: is deprecated, use repo:contains.file(...) instead.",
+			}},
+		},
+		{
+			query: `repohascommitafter:"1 week ago" bar`,
+			want: []warning{{
+				Kind:    LintDeprecatedFilter,
+				Message: "repohascommitafter: is deprecated, use repo:contains.commit.after(...) instead.",
+				Fixes:   []fix{{"Replace with repo:contains.commit.after(1 week ago)", "repo:contains.commit.after(1 week ago) bar"}},
+			}},
+		},
+		{
+			query: `repo:foo type: string`,
+			want: []warning{{
+				Kind:    LintLiteralPattern,
+				Message: "type: has no value, so it is searched for as text. Remove the space after the colon to use it as a filter.",
+			}},
+		},
+		{
+			query: `foo (repo:bar baz)`,
+			want: []warning{{
+				Kind:    LintLiteralPattern,
+				Message: "The parentheses in the query don't form groups, so they are searched for as text.",
+			}},
+		},
+		{
+			query: `call(file: x)`,
+			want:  nil,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.query, func(t *testing.T) {
+			warnings, err := Lint(tc.query, SearchTypeLiteral)
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			var have []warning
+			for _, w := range warnings {
+				hw := warning{Kind: w.Kind, Message: w.Message}
+				for _, f := range w.Fixes {
+					hw.Fixes = append(hw.Fixes, fix{f.Description, f.Apply(tc.query)})
+				}
+				have = append(have, hw)
+			}
+
+			if diff := cmp.Diff(tc.want, have); diff != "" {
+				t.Fatalf("unexpected warnings (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -310,6 +310,13 @@ func ScanAnyPattern(buf []byte) (scanned string, count int) {
 // (foo or (bar and baz)) - a valid query with and/or expression groups in the query langugae
 // (repo:foo bar baz)     - a valid query containing a recognized repo: field. Here parentheses are interpreted as a group, not a pattern.
 func ScanBalancedPattern(buf []byte) (scanned string, count int, ok bool) {
+	return scanBalancedPattern(buf, false)
+}
+
+// scanBalancedPattern is ScanBalancedPattern, except that recognized fields
+// without a value, like "type:" in "(type: string)", do not reject the pattern
+// if emptyFieldsAsPatterns is true.
+func scanBalancedPattern(buf []byte, emptyFieldsAsPatterns bool) (scanned string, count int, ok bool) {
 	var advance, balanced int
 	var r rune
 	var result []rune
@@ -323,7 +330,7 @@ func ScanBalancedPattern(buf []byte) (scanned string, count int, ok bool) {
 
 	// looks ahead to see if there are any recognized fields or operators.
 	keepScanning := func() bool {
-		if field, _, _ := ScanField(buf); field != "" {
+		if field, _, advance := ScanField(buf); field != "" && !(emptyFieldsAsPatterns && isEmptyFieldValue(buf[advance:])) {
 			// This "pattern" contains a recognized field, reject it.
 			return false
 		}
@@ -632,6 +639,16 @@ func ScanField(buf []byte) (string, bool, int) {
 	return field, negated, count
 }
 
+// isEmptyFieldValue returns true if buf, which follows a field, starts without
+// a value for the field, as in "type: string" or "(case:)".
+func isEmptyFieldValue(buf []byte) bool {
+	if len(buf) == 0 {
+		return true
+	}
+	r, _ := utf8.DecodeRune(buf)
+	return unicode.IsSpace(r) || r == ')'
+}
+
 // ScanValue scans for a value (e.g., of a parameter, or a string corresponding
 // to a search pattern). Its main function is to determine when to stop scanning
 // a value (e.g., at a parentheses), and which escape sequences to interpret. It
@@ -780,7 +797,7 @@ func (p *parser) TryParseDelimitedPattern() (Pattern, bool) {
 }
 
 func (p *parser) TryScanBalancedPattern(label labels) (Pattern, bool) {
-	if value, advance, ok := ScanBalancedPattern(p.buf[p.pos:]); ok {
+	if value, advance, ok := p.scanBalancedPattern(); ok {
 		pattern := newPattern(value, false, label, newRange(p.pos, p.pos+advance))
 		p.pos += advance
 		return pattern, true
@@ -788,6 +805,29 @@ func (p *parser) TryScanBalancedPattern(label labels) (Pattern, bool) {
 	return Pattern{}, false
 }
 
+// scanBalancedPattern scans a pattern with balanced parentheses at the current
+// position. See ScanBalancedPattern.
+func (p *parser) scanBalancedPattern() (string, int, bool) {
+	return scanBalancedPattern(p.buf[p.pos:], p.leafParser == SearchTypeLiteral)
+}
+
+// TryParseFieldAsPattern parses a recognized field without a value, like
+// "type:" in "type: string", as a literal pattern. In literal searches, such
+// text is much more likely part of the code searched for than a filter.
+func (p *parser) TryParseFieldAsPattern(label labels) (Pattern, bool) {
+	if p.leafParser != SearchTypeLiteral {
+		return Pattern{}, false
+	}
+	field, _, advance := ScanField(p.buf[p.pos:])
+	if field == "" || !isEmptyFieldValue(p.buf[p.pos+advance:]) {
+		return Pattern{}, false
+	}
+	start := p.pos
+	p.pos += advance
+	label.set(HeuristicFieldAsPattern)
+	return newPattern(string(p.buf[start:p.pos]), false, label, newRange(start, p.pos)), true
+}
+
 func newPattern(value string, negated bool, labels labels, range_ Range) Pattern {
 	return Pattern{
 		Value:   value,
@@ -904,7 +944,7 @@ loop:
 		switch {
 		case p.match(LPAREN) && !isSet(p.heuristics, allowDanglingParens):
 			if isSet(p.heuristics, parensAsPatterns) {
-				if value, advance, ok := ScanBalancedPattern(p.buf[p.pos:]); ok {
+				if value, advance, ok := p.scanBalancedPattern(); ok {
 					if label.IsSet(Literal) {
 						label.set(HeuristicParensAsPatterns)
 					}
@@ -924,7 +964,7 @@ loop:
 				return nil, err
 			}
 			nodes = append(nodes, result...)
-		case p.expect(RPAREN) && !isSet(p.heuristics, allowDanglingParens):
+		case !isSet(p.heuristics, allowDanglingParens) && p.expect(RPAREN):
 			if p.balanced <= 0 {
 				return nil, errors.New("unsupported expression. The combination of parentheses in the query have an unclear meaning. Try using the content: filter to quote patterns that contain parentheses")
 			}
@@ -970,6 +1010,10 @@ loop:
 			pattern.Annotation.Range = newRange(start, p.pos)
 			nodes = append(nodes, pattern)
 		default:
+			if pattern, ok := p.TryParseFieldAsPattern(label); ok {
+				nodes = append(nodes, pattern)
+				continue
+			}
 			parameter, ok, err := p.ParseParameter()
 			if err != nil {
 				return nil, err
@@ -1116,8 +1160,43 @@ func (p *parser) tryFallbackParser(in string) ([]Node, error) {
 	return newOperator(nodes, And), nil
 }
 
+// tryLiteralFallbackParser parses a literal search query in which parentheses
+// could not be interpreted as groups by treating them as part of patterns
+// instead. It fails if the result is not a single pattern scoped by filters,
+// since and/or operators without groups are unlikely to be what was meant.
+func tryLiteralFallbackParser(in string) ([]Node, error) {
+	p := &parser{leafParser: SearchTypeLiteral}
+	nodes, err := p.tryFallbackParser(in)
+	if err != nil {
+		return nil, err
+	}
+	_, pattern, err := PartitionSearchPattern(nodes)
+	if err != nil {
+		return nil, err
+	}
+	if pattern != nil && exists([]Node{pattern}, func(node Node) bool {
+		operator, ok := node.(Operator)
+		return ok && (operator.Kind == And || operator.Kind == Or)
+	}) {
+		return nil, errors.New("literal pattern contains and/or operators")
+	}
+	return nodes, nil
+}
+
 // Parse parses a raw input string into a parse tree comprising Nodes.
 func Parse(in string, searchType SearchType) ([]Node, error) {
+	nodes, err := parse(in, searchType)
+	if err != nil && searchType == SearchTypeLiteral {
+		// Parentheses in literal searches are often part of the code
+		// searched for. Rather than failing, search for them literally.
+		if nodes, fallbackErr := tryLiteralFallbackParser(in); fallbackErr == nil {
+			return nodes, nil
+		}
+	}
+	return nodes, err
+}
+
+func parse(in string, searchType SearchType) ([]Node, error) {
 	if strings.TrimSpace(in) == "" {
 		return nil, nil
 	}
@@ -670,7 +670,7 @@ func TestParseAndOrLiteral(t *testing.T) {
 	// For implementation simplicity, behavior preserves whitespace inside parentheses.
 	autogold.Want("repo:foo (lisp    lisp)", `(and "repo:foo" "(lisp    lisp)") (HeuristicParensAsPatterns,Literal)`).Equal(t, test("repo:foo (lisp    lisp)"))
 	autogold.Want("repo:foo main( or (lisp    lisp)", `(and "repo:foo" (or "main(" "(lisp    lisp)")) (HeuristicHoisted,HeuristicParensAsPatterns,Literal)`).Equal(t, test("repo:foo main( or (lisp    lisp)"))
-	autogold.Want("repo:foo )foo(", "ERROR: unsupported expression. The combination of parentheses in the query have an unclear meaning. Try using the content: filter to quote patterns that contain parentheses").Equal(t, test("repo:foo )foo("))
+	autogold.Want("repo:foo )foo(", `(and "repo:foo" ")foo(") (HeuristicDanglingParens,Literal)`).Equal(t, test("repo:foo )foo("))
 	autogold.Want("repo:foo )main( or (lisp    lisp)", "ERROR: unsupported expression. The combination of parentheses in the query have an unclear meaning. Try using the content: filter to quote patterns that contain parentheses").Equal(t, test("repo:foo )main( or (lisp    lisp)"))
 	autogold.Want("repo:foo ) main( or (lisp    lisp)", "ERROR: unsupported expression. The combination of parentheses in the query have an unclear meaning. Try using the content: filter to quote patterns that contain parentheses").Equal(t, test("repo:foo ) main( or (lisp    lisp)"))
 	autogold.Want("repo:foo )))) main( or (lisp    lisp) and )))", "ERROR: unsupported expression. The combination of parentheses in the query have an unclear meaning. Try using the content: filter to quote patterns that contain parentheses").Equal(t, test("repo:foo )))) main( or (lisp    lisp) and )))"))
@@ -689,8 +689,16 @@ func TestParseAndOrLiteral(t *testing.T) {
 
 	// Fringe tests cases at the boundary of heuristics and invalid syntax.
 	autogold.Want(`x()(y or z)`, "ERROR: unsupported expression. The combination of parentheses in the query have an unclear meaning. Try using the content: filter to quote patterns that contain parentheses").Equal(t, test(`x()(y or z)`))
-	autogold.Want(`)(0 )0`, "ERROR: unsupported expression. The combination of parentheses in the query have an unclear meaning. Try using the content: filter to quote patterns that contain parentheses").Equal(t, test(`)(0 )0`))
-	autogold.Want(`((R:)0))0`, "ERROR: unsupported expression. The combination of parentheses in the query have an unclear meaning. Try using the content: filter to quote patterns that contain parentheses").Equal(t, test(`((R:)0))0`))
+	autogold.Want(`)(0 )0`, `(concat ")(0" ")0") (HeuristicDanglingParens,Literal)`).Equal(t, test(`)(0 )0`))
+	autogold.Want(`((R:)0))0`, `"((R:)0))0" (HeuristicDanglingParens,Literal)`).Equal(t, test(`((R:)0))0`))
+
+	// Fields without a value and ambiguous parentheses are searched literally.
+	autogold.Want(`type: string`, `(concat "type:" "string") (HeuristicFieldAsPattern,Literal)`).Equal(t, test(`type: string`))
+	autogold.Want(`switch (case: 1)`, `(concat "switch" "(case: 1)") (HeuristicParensAsPatterns,Literal)`).Equal(t, test(`switch (case: 1)`))
+	autogold.Want(`call(file: x) y`, `(concat "call(file: x)" "y") (Literal)`).Equal(t, test(`call(file: x) y`))
+	autogold.Want(`foo (repo:bar baz)`, `(concat "foo" "(repo:bar" "baz)") (HeuristicDanglingParens,Literal)`).Equal(t, test(`foo (repo:bar baz)`))
+	autogold.Want(`foo) bar`, `(concat "foo)" "bar") (HeuristicDanglingParens,Literal)`).Equal(t, test(`foo) bar`))
+	autogold.Want(`(a or b) c`, "ERROR: i'm having trouble understanding that query. The combination of parentheses is the problem. Try using the content: filter to quote patterns that contain parentheses").Equal(t, test(`(a or b) c`))
 }
 
 func TestScanBalancedPattern(t *testing.T) {
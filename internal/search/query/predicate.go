@@ -31,6 +31,7 @@ var DefaultPredicateRegistry = predicateRegistry{
 		"contains.file":         func() Predicate { return &RepoContainsFilePredicate{} },
 		"contains.content":      func() Predicate { return &RepoContainsContentPredicate{} },
 		"contains.commit.after": func() Predicate { return &RepoContainsCommitAfterPredicate{} },
+		"has.meta":              func() Predicate { return &RepoHasMetaPredicate{} },
 	},
 	FieldFile: {
 		"contains.content": func() Predicate { return &FileContainsContentPredicate{} },
@@ -243,6 +244,55 @@ func (f *RepoContainsCommitAfterPredicate) Plan(parent Basic) (Plan, error) {
 	return ToPlan(Dnf(nodes))
 }
 
+/* repo:has.meta(...) */
+
+type RepoHasMetaPredicate struct {
+	Key   string
+	Value *string
+}
+
+func (f *RepoHasMetaPredicate) ParseParams(params string) error {
+	key, value := ParseRepoHasMeta(params)
+	if key == "" {
+		return errors.Errorf("has.meta argument should be of the form key or key=value")
+	}
+	f.Key, f.Value = key, value
+	return nil
+}
+
+func (f *RepoHasMetaPredicate) Field() string { return FieldRepo }
+func (f *RepoHasMetaPredicate) Name() string  { return "has.meta" }
+func (f *RepoHasMetaPredicate) Plan(parent Basic) (Plan, error) {
+	value := f.Key
+	if f.Value != nil {
+		value += "=" + *f.Value
+	}
+
+	nodes := make([]Node, 0, 3)
+	nodes = append(nodes, Parameter{
+		Field: FieldCount,
+		Value: "99999",
+	}, Parameter{
+		Field: FieldRepoHasMeta,
+		Value: value,
+	})
+
+	nodes = append(nodes, nonPredicateRepos(parent)...)
+	return ToPlan(Dnf(nodes))
+}
+
+// ParseRepoHasMeta parses a repository metadata filter of the form key or
+// key=value. The returned value is nil if only a key is given.
+func ParseRepoHasMeta(s string) (key string, value *string) {
+	parts := strings.SplitN(s, "=", 2)
+	key = strings.TrimSpace(parts[0])
+	if len(parts) == 2 {
+		v := strings.TrimSpace(parts[1])
+		value = &v
+	}
+	return key, value
+}
+
 type FileContainsContentPredicate struct {
 	Pattern string
 }
@@ -55,6 +55,41 @@ func TestRepoContainsPredicate(t *testing.T) {
 	})
 }
 
+func TestRepoHasMetaPredicate(t *testing.T) {
+	strPtr := func(s string) *string { return &s }
+
+	valid := []struct {
+		params   string
+		expected *RepoHasMetaPredicate
+	}{
+		{`team`, &RepoHasMetaPredicate{Key: "team"}},
+		{`team=payments`, &RepoHasMetaPredicate{Key: "team", Value: strPtr("payments")}},
+		{`team = payments`, &RepoHasMetaPredicate{Key: "team", Value: strPtr("payments")}},
+		{`team=`, &RepoHasMetaPredicate{Key: "team", Value: strPtr("")}},
+		{`url=a=b`, &RepoHasMetaPredicate{Key: "url", Value: strPtr("a=b")}},
+	}
+	for _, tc := range valid {
+		t.Run(tc.params, func(t *testing.T) {
+			p := &RepoHasMetaPredicate{}
+			if err := p.ParseParams(tc.params); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+			if !reflect.DeepEqual(tc.expected, p) {
+				t.Fatalf("expected %#v, got %#v", tc.expected, p)
+			}
+		})
+	}
+
+	for _, params := range []string{``, `=payments`} {
+		t.Run(params, func(t *testing.T) {
+			p := &RepoHasMetaPredicate{}
+			if err := p.ParseParams(params); err == nil {
+				t.Fatal("expected error but got none")
+			}
+		})
+	}
+}
+
 func TestParseAsPredicate(t *testing.T) {
 	tests := []struct {
 		input  string
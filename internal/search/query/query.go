@@ -8,6 +8,8 @@ steps that entail parsing, validity checking, transformation, and conditional
 processing logic driven by external options.
 */
 
+import "time"
+
 // A step performs a transformation on nodes, which may fail.
 type step func(nodes []Node) ([]Node, error)
 
@@ -47,6 +49,21 @@ func With(enabled bool, step step) step {
 	return step
 }
 
+// MaxTimeout returns a step that fails with a TimeoutExceededError if the query
+// sets a timeout: value larger than max. Values that are not valid durations
+// are left for validation to report.
+func MaxTimeout(max time.Duration) step {
+	return func(nodes []Node) ([]Node, error) {
+		var err error
+		VisitField(nodes, FieldTimeout, func(value string, _ bool, _ Annotation) {
+			if d, parseErr := time.ParseDuration(value); parseErr == nil && d > max && err == nil {
+				err = &TimeoutExceededError{Timeout: d, Max: max}
+			}
+		})
+		return nodes, err
+	}
+}
+
 // For runs processing steps for a given search type. This includes
 // normalization, substitution for whitespace, and pattern labeling.
 func For(searchType SearchType) step {
@@ -126,14 +143,17 @@ func ToPlan(disjuncts [][]Node) (Plan, error) {
 }
 
 // Pipeline processes zero or more steps to produce a query. The first step must
-// be Init, otherwise this function is a no-op.
+// be Init, otherwise this function is a no-op. File globs are translated after
+// the steps, so that they apply whether or not the steps include Globbing.
 func Pipeline(steps ...step) (Plan, error) {
-	nodes, err := sequence(steps...)(nil)
+	nodes, err := sequence(append(steps, FileGlobs)...)(nil)
 	if err != nil {
 		return nil, err
 	}
 
-	disjuncts := Dnf(nodes)
+	// Resolve unions of repo filters in a single repository resolution
+	// rather than in separate disjuncts.
+	disjuncts := Dnf(substituteOrForRepoUnion(nodes))
 	if err := Validate(disjuncts); err != nil {
 		return nil, err
 	}
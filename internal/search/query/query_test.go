@@ -3,6 +3,7 @@ package query
 import (
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/google/go-cmp/cmp"
 	"github.com/hexops/autogold"
@@ -27,7 +28,7 @@ func TestPipeline_equivalence(t *testing.T) {
 	test := func(input string) string {
 		pipelinePlan, _ := Pipeline(InitLiteral(input))
 		nodes, _ := Run(InitLiteral(input))
-		disjuncts := Dnf(nodes)
+		disjuncts := Dnf(substituteOrForRepoUnion(nodes))
 		plan, _ := ToPlan(disjuncts)
 		manualPlan := MapPlan(plan, ConcatRevFilters)
 		if diff := cmp.Diff(
@@ -49,4 +50,37 @@ func TestPipeline(t *testing.T) {
 	}
 
 	autogold.Want("contains(...) spans newlines", `"repo:contains.file(\nfoo\n)"`).Equal(t, test("repo:contains.file(\nfoo\n)"))
+	autogold.Want("or-expression over repo filters is a single disjunct", `"repo:(?:^github\\.com/org-a/)|(?:^github\\.com/org-b/)" "file:go.mod"`).Equal(t, test(`(repo:^github\.com/org-a/ or repo:^github\.com/org-b/) file:go.mod`))
+}
+
+func TestPipeline_maxTimeout(t *testing.T) {
+	test := func(input string) string {
+		_, err := Pipeline(InitLiteral(input), MaxTimeout(time.Minute))
+		if err == nil {
+			return "ok"
+		}
+		return err.Error()
+	}
+
+	autogold.Want("timeout below maximum", "ok").Equal(t, test("timeout:30s foo"))
+	autogold.Want("timeout at maximum", "ok").Equal(t, test("timeout:1m foo"))
+	autogold.Want("timeout above maximum", "timeout:10m0s exceeds the maximum timeout of 1m0s").Equal(t, test("timeout:10m foo"))
+	autogold.Want("timeout above maximum in or-expression", "timeout:2m0s exceeds the maximum timeout of 1m0s").Equal(t, test("(timeout:2m foo) or bar"))
+	autogold.Want("invalid timeout is left to validation", `invalid value for field 'timeout' (examples: "timeout:2s", "timeout:200ms")`).Equal(t, test("timeout:forever foo"))
+}
+
+func TestPipeline_fileGlobs(t *testing.T) {
+	test := func(input string, steps ...step) string {
+		pipelinePlan, err := Pipeline(append([]step{InitLiteral(input)}, steps...)...)
+		if err != nil {
+			return err.Error()
+		}
+		return planToString(Dnf(pipelinePlan.ToParseTree()))
+	}
+
+	autogold.Want("glob without globbing", `"file:^.*?/[^/]*?\\.test\\.ts$" "foo"`).Equal(t, test("file:**/*.test.ts foo"))
+	autogold.Want("negated glob without globbing", `"-file:^[^/]*?\\.go$" "foo"`).Equal(t, test("-file:*.go foo"))
+	autogold.Want("regexp without globbing", `"file:\\.go$" "foo"`).Equal(t, test(`file:\.go$ foo`))
+	autogold.Want("glob with globbing is translated once", `"file:^.*?/[^/]*?\\.test\\.ts$" "foo"`).Equal(t, test("file:**/*.test.ts foo", Globbing))
+	autogold.Want("bad glob", "invalid glob syntax in field file: syntax error in glob pattern").Equal(t, test("file:**[ foo"))
 }
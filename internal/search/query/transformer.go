@@ -285,6 +285,42 @@ func Globbing(nodes []Node) ([]Node, error) {
 	return nodes, nil
 }
 
+// IsFileGlob returns whether a file: or repohasfile: value is a glob pattern
+// regardless of whether globbing is enabled. It is a glob pattern if it starts
+// with * or contains **, as in *.go or **/*.test.ts, which makes it an invalid
+// regular expression. Valid regular expressions are never treated as globs.
+func IsFileGlob(value string) bool {
+	if !strings.HasPrefix(value, "*") && !strings.Contains(value, "**") {
+		return false
+	}
+	_, err := regexp.Compile(value)
+	return err != nil
+}
+
+// FileGlobs translates the file: and repohasfile: values for which IsFileGlob
+// is true to regular expressions. Values that Globbing has already translated
+// are valid regular expressions and left as they are.
+func FileGlobs(nodes []Node) ([]Node, error) {
+	var globErr *globError
+
+	nodes = MapParameter(nodes, func(field, value string, negated bool, annotation Annotation) Node {
+		if (field == FieldFile || field == FieldRepoHasFile) && IsFileGlob(value) && globErr == nil {
+			translated, err := globToRegex(value)
+			if err != nil {
+				globErr = &globError{field: field, err: err}
+			} else {
+				value = translated
+			}
+		}
+		return Parameter{Field: field, Value: value, Negated: negated, Annotation: annotation}
+	})
+
+	if globErr != nil {
+		return nil, errors.Errorf("invalid glob syntax in field %s: %s", globErr.field, globErr)
+	}
+	return nodes, nil
+}
+
 func ToNodes(parameters []Parameter) []Node {
 	nodes := make([]Node, 0, len(parameters))
 	for _, p := range parameters {
@@ -463,6 +499,54 @@ func substituteOrForRegexp(nodes []Node) []Node {
 	return newNode
 }
 
+// substituteOrForRepoUnion merges or-expressions over repo filters into a
+// single repo filter matching the union of their regular expressions. For
+// example, the query:
+//
+// (repo:a or repo:b) file:c
+// becomes:
+// repo:(?:a)|(?:b) file:c
+//
+// This way repositories are resolved once for the union, rather than once for
+// each disjunct of the query's DNF. Negated repo filters, repo filters
+// specifying revisions, and predicates are left as-is.
+func substituteOrForRepoUnion(nodes []Node) []Node {
+	isUnionable := func(node Node) bool {
+		if parameter, ok := node.(Parameter); ok {
+			return parameter.Field == FieldRepo &&
+				!parameter.Negated &&
+				!parameter.Annotation.Labels.IsSet(IsPredicate) &&
+				!strings.Contains(parameter.Value, "@")
+		}
+		return false
+	}
+	newNode := []Node{}
+	for _, node := range nodes {
+		switch v := node.(type) {
+		case Operator:
+			operands := substituteOrForRepoUnion(v.Operands)
+			if v.Kind != Or {
+				newNode = append(newNode, newOperator(operands, v.Kind)...)
+				continue
+			}
+			repos, rest := partition(operands, isUnionable)
+			if len(repos) < 2 {
+				newNode = append(newNode, newOperator(operands, Or)...)
+				continue
+			}
+			var values []string
+			for _, node := range repos {
+				values = append(values, node.(Parameter).Value)
+			}
+			repo := Parameter{Field: FieldRepo, Value: "(?:" + strings.Join(values, ")|(?:") + ")"}
+			newNode = append(newNode, newOperator(append([]Node{repo}, rest...), Or)...)
+		case Parameter, Pattern:
+			newNode = append(newNode, node)
+		}
+	}
+	return newNode
+}
+
 // fuzzyRegexp interpolates patterns with .*? regular expressions and
 // concatenates them. Invariant: len(patterns) > 0.
 func fuzzyRegexp(patterns []Pattern) Pattern {
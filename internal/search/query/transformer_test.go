@@ -255,6 +255,51 @@ func TestSubstituteOrForRegexp(t *testing.T) {
 	}
 }
 
+func TestSubstituteOrForRepoUnion(t *testing.T) {
+	cases := []struct {
+		input string
+		want  string
+	}{
+		{
+			input: "(repo:a or repo:b) file:c",
+			want:  `(and "repo:(?:a)|(?:b)" "file:c")`,
+		},
+		{
+			input: "(repo:a or (repo:b or repo:c)) foo",
+			want:  `(and "repo:(?:a)|(?:b)|(?:c)" "foo")`,
+		},
+		{
+			input: "repo:a or repo:b or file:c",
+			want:  `(or "repo:(?:a)|(?:b)" "file:c")`,
+		},
+		{
+			input: "(repo:a or -repo:b) foo",
+			want:  `(and (or "repo:a" "-repo:b") "foo")`,
+		},
+		{
+			input: "(repo:a@rev or repo:b) foo",
+			want:  `(and (or "repo:a@rev" "repo:b") "foo")`,
+		},
+		{
+			input: "(repo:a or repo:contains.file(b)) foo",
+			want:  `(and (or "repo:a" "repo:contains.file(b)") "foo")`,
+		},
+		{
+			input: "repo:a repo:b foo",
+			want:  `(and "repo:a" "repo:b" "foo")`,
+		},
+	}
+	for _, c := range cases {
+		t.Run("Map query", func(t *testing.T) {
+			query, _ := Parse(c.input, SearchTypeRegex)
+			got := toString(substituteOrForRepoUnion(query))
+			if diff := cmp.Diff(c.want, got); diff != "" {
+				t.Fatal(diff)
+			}
+		})
+	}
+}
+
 func TestSubstituteConcat(t *testing.T) {
 	cases := []struct {
 		input  string
@@ -973,3 +1018,25 @@ func TestSubstituteCountAll(t *testing.T) {
 	autogold.Want("with integer count", `(and "count:3" "foo")`).Equal(t, test("foo count:3"))
 	autogold.Want("subexpressions", `(or (and "count:3" "foo") (and "count:99999999" "bar"))`).Equal(t, test("(foo count:3) or (bar count:all)"))
 }
+
+func TestIsFileGlob(t *testing.T) {
+	cases := []struct {
+		value string
+		want  bool
+	}{
+		{value: "*.go", want: true},
+		{value: "**/*.test.ts", want: true},
+		{value: "src/**/foo.go", want: true},
+		{value: `\.go$`, want: false},
+		{value: "src/*.go", want: false}, // valid regexp
+		{value: "[**]foo", want: false},  // valid regexp
+		{value: "foo.go", want: false},
+	}
+	for _, c := range cases {
+		t.Run(c.value, func(t *testing.T) {
+			if got := IsFileGlob(c.value); got != c.want {
+				t.Fatalf("IsFileGlob(%q) = %v, want %v", c.value, got, c.want)
+			}
+		})
+	}
+}
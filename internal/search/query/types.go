@@ -23,6 +23,17 @@ func (e *UnsupportedError) Error() string {
 	return e.Msg
 }
 
+// TimeoutExceededError is returned when a query sets a timeout: value larger
+// than the maximum allowed timeout.
+type TimeoutExceededError struct {
+	Timeout time.Duration
+	Max     time.Duration
+}
+
+func (e *TimeoutExceededError) Error() string {
+	return fmt.Sprintf("timeout:%s exceeds the maximum timeout of %s", e.Timeout, e.Max)
+}
+
 type SearchType int
 
 const (
@@ -298,6 +309,14 @@ func (q Q) Fork() *YesNoOnly {
 	return q.yesNoOnlyValue(FieldFork)
 }
 
+func (q Q) Generated() *YesNoOnly {
+	return q.yesNoOnlyValue(FieldGenerated)
+}
+
+func (q Q) Vendored() *YesNoOnly {
+	return q.yesNoOnlyValue(FieldVendored)
+}
+
 func (q Q) yesNoOnlyValue(field string) *YesNoOnly {
 	var res *YesNoOnly
 	VisitField(q, field, func(value string, _ bool, _ Annotation) {
@@ -387,6 +406,8 @@ func (q Q) valueToTypedValue(field, value string, label labels) []*Value {
 	case
 		FieldFork,
 		FieldArchived,
+		FieldGenerated,
+		FieldVendored,
 		FieldLang, "l", "language",
 		FieldType,
 		FieldPatternType,
@@ -398,6 +419,7 @@ func (q Q) valueToTypedValue(field, value string, label labels) []*Value {
 
 	case
 		FieldRepoHasCommitAfter,
+		FieldRepoHasMeta,
 		FieldBefore, "until",
 		FieldAfter, "since":
 		return []*Value{{String: &value}}
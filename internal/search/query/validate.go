@@ -44,6 +44,18 @@ func IsPatternAtom(b Basic) bool {
 // pattern node.
 func IsStreamingCompatible(p Plan) bool {
 	if len(p) == 1 {
+		if v, _ := p[0].ToParseTree().StringValue(FieldDedupe); v != "" {
+			// Deduplicating results requires the full result set to pick
+			// the canonical result.
+			return false
+		}
+		for _, field := range []string{FieldGenerated, FieldVendored} {
+			if v, _ := p[0].ToParseTree().StringValue(field); v != "" && ParseYesNoOnly(v) != Yes {
+				// Generated and vendored files are filtered once the
+				// .gitattributes of the matched repositories are known.
+				return false
+			}
+		}
 		if p[0].Pattern == nil {
 			return true
 		}
@@ -240,6 +252,13 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		return nil
 	}
 
+	isDate := func() error {
+		if _, err := ParseDate(value, time.Now()); err != nil {
+			return errors.Errorf(`invalid date %q for field '%s'. Use an absolute date like "2019-11-01" or "november 1 2019", a relative date like "3 weeks ago", "yesterday" or "last thursday", or an ISO 8601 duration like "P2W"`, value, field)
+		}
+		return nil
+	}
+
 	isLanguage := func() error {
 		_, ok := enry.GetLanguageByAlias(value)
 		if !ok {
@@ -256,6 +275,20 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 		return nil
 	}
 
+	isDedupe := func() error {
+		if value != DedupeForks {
+			return errors.Errorf("invalid value %q for field %q. Valid values are: %s", value, field, DedupeForks)
+		}
+		return nil
+	}
+
+	isGroup := func() error {
+		if value != GroupRepo {
+			return errors.Errorf("invalid value %q for field %q. Valid values are: %s", value, field, GroupRepo)
+		}
+		return nil
+	}
+
 	isUnrecognizedField := func() error {
 		return errors.Errorf("unrecognized field %q", field)
 	}
@@ -308,10 +341,13 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 	case
 		FieldRepoHasCommitAfter:
 		return satisfies(isSingular, isNotNegated)
+	case
+		FieldRepoHasMeta:
+		return satisfies(isNotNegated)
 	case
 		FieldBefore,
 		FieldAfter:
-		return satisfies(isNotNegated)
+		return satisfies(isNotNegated, isDate)
 	case
 		FieldAuthor,
 		FieldCommitter,
@@ -320,7 +356,9 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 	case
 		FieldIndex,
 		FieldFork,
-		FieldArchived:
+		FieldArchived,
+		FieldGenerated,
+		FieldVendored:
 		return satisfies(isSingular, isNotNegated, isYesNoOnly)
 	case
 		FieldCount:
@@ -337,6 +375,12 @@ func validateField(field, value string, negated bool, seen map[string]struct{})
 	case
 		FieldSelect:
 		return satisfies(isSingular, isNotNegated, isValidSelect)
+	case
+		FieldDedupe:
+		return satisfies(isSingular, isNotNegated, isDedupe)
+	case
+		FieldGroup:
+		return satisfies(isSingular, isNotNegated, isGroup)
 	default:
 		return isUnrecognizedField()
 	}
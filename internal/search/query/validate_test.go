@@ -63,6 +63,10 @@ func TestAndOrQuery_Validation(t *testing.T) {
 			want:       "the query contains a negated search pattern. Structural search does not support negated search patterns at the moment",
 			searchType: SearchTypeStructural,
 		},
+		{
+			input: `type:commit after:"next thursday"`,
+			want:  `invalid date "next thursday" for field 'after'. Use an absolute date like "2019-11-01" or "november 1 2019", a relative date like "3 weeks ago", "yesterday" or "last thursday", or an ISO 8601 duration like "P2W"`,
+		},
 		{
 			input: "repo:foo rev:a rev:b",
 			want:  `field "rev" may not be used more than once`,
@@ -99,6 +103,22 @@ func TestAndOrQuery_Validation(t *testing.T) {
 			input: "type:symbol select:symbol.timelime",
 			want:  `invalid field "timelime" on select path "symbol.timelime"`,
 		},
+		{
+			input: "foo dedupe:repos",
+			want:  `invalid value "repos" for field "dedupe". Valid values are: forks`,
+		},
+		{
+			input: "foo group:file",
+			want:  `invalid value "file" for field "group". Valid values are: repo`,
+		},
+		{
+			input: "foo generated:maybe",
+			want:  `invalid value "maybe" for field "generated". Valid values are: yes, only, no`,
+		},
+		{
+			input: "foo -vendored:no",
+			want:  `field "vendored" does not support negation`,
+		},
 		{
 			input:      "nice try type:repo",
 			want:       "this structural search query specifies `type:` and is not supported. Structural search syntax only applies to searching file contents",
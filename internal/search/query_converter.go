@@ -73,6 +73,12 @@ func count(q query.Basic, p Protocol) int {
 		return DefaultMaxSearchResults
 	}
 
+	if q.FindValue(query.FieldGroup) != "" {
+		// The counts of grouped results are only meaningful if they are
+		// computed over more than the first page of results.
+		return DefaultMaxSearchResultsStreaming
+	}
+
 	switch p {
 	case Batch:
 		return DefaultMaxSearchResults
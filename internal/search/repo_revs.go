@@ -5,14 +5,16 @@ import (
 	"reflect"
 	"strings"
 	"sync"
+	"time"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
 	"github.com/sourcegraph/sourcegraph/internal/types"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
-// RevisionSpecifier represents either a revspec or a ref glob. At most one
-// field is set. The default branch is represented by all fields being empty.
+// RevisionSpecifier represents either a revspec, a ref glob or a ref date
+// filter. At most one field is set. The default branch is represented by all
+// fields being empty.
 type RevisionSpecifier struct {
 	// RevSpec is a revision range specifier suitable for passing to git. See
 	// the manpage gitrevisions(7).
@@ -25,9 +27,24 @@ type RevisionSpecifier struct {
 	// ExcludeRefGlob is a glob for references to exclude. See the
 	// documentation for "--exclude" in git-log.
 	ExcludeRefGlob string
+
+	// CreatedAfter and CreatedBefore restrict the refs matched by ref globs to
+	// those created (see git.Ref.CreatorDate) after or before the given date.
+	// If no include ref glob is specified, they apply to all branches and tags.
+	CreatedAfter  time.Time
+	CreatedBefore time.Time
 }
 
+// revisionDateLayout is the layout of the dates of ref date filters.
+const revisionDateLayout = "2006-01-02"
+
 func (r1 RevisionSpecifier) String() string {
+	if !r1.CreatedAfter.IsZero() {
+		return ">" + r1.CreatedAfter.Format(revisionDateLayout)
+	}
+	if !r1.CreatedBefore.IsZero() {
+		return "<" + r1.CreatedBefore.Format(revisionDateLayout)
+	}
 	if r1.ExcludeRefGlob != "" {
 		return "*!" + r1.ExcludeRefGlob
 	}
@@ -49,7 +66,24 @@ func (r1 RevisionSpecifier) Less(r2 RevisionSpecifier) bool {
 	if r1.RefGlob != r2.RefGlob {
 		return r1.RefGlob < r2.RefGlob
 	}
-	return r1.ExcludeRefGlob < r2.ExcludeRefGlob
+	if r1.ExcludeRefGlob != r2.ExcludeRefGlob {
+		return r1.ExcludeRefGlob < r2.ExcludeRefGlob
+	}
+	if !r1.CreatedAfter.Equal(r2.CreatedAfter) {
+		return r1.CreatedAfter.Before(r2.CreatedAfter)
+	}
+	return r1.CreatedBefore.Before(r2.CreatedBefore)
+}
+
+// IsRefPattern returns true if r is a ref glob or a ref date filter, which
+// are resolved against the refs of the repository.
+func (r1 RevisionSpecifier) IsRefPattern() bool {
+	return r1.RefGlob != "" || r1.ExcludeRefGlob != "" || r1.IsDateFilter()
+}
+
+// IsDateFilter returns true if r is a ref date filter.
+func (r1 RevisionSpecifier) IsDateFilter() bool {
+	return !r1.CreatedAfter.IsZero() || !r1.CreatedBefore.IsZero()
 }
 
 // RepositoryRevisions specifies a repository and 0 or more revspecs and ref
@@ -98,8 +132,11 @@ func (r *RepositoryRevisions) Equal(other *RepositoryRevisions) bool {
 //
 // where repo is a repository regex and revs is a ':'-separated list of revspecs
 // and/or ref globs. A ref glob is a revspec prefixed with '*' (which is not a
-// valid revspec or ref itself; see `man git-check-ref-format`). The '@' and revs
-// may be omitted to refer to the default branch.
+// valid revspec or ref itself; see `man git-check-ref-format`), and an exclude
+// ref glob is prefixed with '*!'. A ref date filter is a date in the format
+// YYYY-MM-DD prefixed with '>' or '<', which restricts the refs to those
+// created after or before the date. The '@' and revs may be omitted to refer to
+// the default branch.
 //
 // For example:
 //
@@ -110,6 +147,8 @@ func (r *RepositoryRevisions) Equal(other *RepositoryRevisions) bool {
 // - 'foo@*bar' refers to the 'foo' repo and all refs matching the glob 'bar/*',
 //   because git interprets the ref glob 'bar' as being 'bar/*' (see `man git-log`
 //   section on the --glob flag)
+// - 'foo@*refs/tags/v1.*:*!refs/tags/v1.0.*:>2021-01-01' refers to the 'foo'
+//   repo and all v1 tags created after 2021-01-01, except the v1.0 tags.
 func ParseRepositoryRevisions(repoAndOptionalRev string) (string, []RevisionSpecifier) {
 	i := strings.Index(repoAndOptionalRev, "@")
 	if i == -1 {
@@ -136,6 +175,16 @@ func ParseRepositoryRevisions(repoAndOptionalRev string) (string, []RevisionSpec
 }
 
 func parseRev(spec string) RevisionSpecifier {
+	if strings.HasPrefix(spec, ">") || strings.HasPrefix(spec, "<") {
+		// Anything else is passed to git as a revspec, which reports it as
+		// an unknown revision.
+		if date, err := time.Parse(revisionDateLayout, spec[1:]); err == nil {
+			if spec[0] == '>' {
+				return RevisionSpecifier{CreatedAfter: date}
+			}
+			return RevisionSpecifier{CreatedBefore: date}
+		}
+	}
 	if strings.HasPrefix(spec, "*!") {
 		return RevisionSpecifier{ExcludeRefGlob: spec[2:]}
 	} else if strings.HasPrefix(spec, "*") {
@@ -165,19 +214,29 @@ func (r *RepositoryRevisions) String() string {
 // OnlyExplicit returns true if all revspecs in Revs are explicit.
 func (r *RepositoryRevisions) OnlyExplicit() bool {
 	for _, rev := range r.Revs {
-		if rev.RefGlob != "" || rev.ExcludeRefGlob != "" {
+		if rev.IsRefPattern() {
 			return false
 		}
 	}
 	return true
 }
 
+// HasDateFilter returns true if any of Revs is a ref date filter.
+func (r *RepositoryRevisions) HasDateFilter() bool {
+	for _, rev := range r.Revs {
+		if rev.IsDateFilter() {
+			return true
+		}
+	}
+	return false
+}
+
 // RevSpecs returns a list of all explicitly listed Git revspecs. It does not expand ref globs to
 // their matching revspecs.
 func (r *RepositoryRevisions) RevSpecs() []string {
 	var revspecs []string
 	for _, rev := range r.Revs {
-		if rev.RefGlob == "" && rev.ExcludeRefGlob == "" {
+		if !rev.IsRefPattern() {
 			revspecs = append(revspecs, rev.RevSpec)
 		}
 	}
@@ -209,6 +268,9 @@ func (r *RepositoryRevisions) ExpandedRevSpecs(ctx context.Context) ([]string, e
 // expandedRevSpecs evaluates all of r's ref glob expressions and returns the full, current list of
 // refs matched or resolved by them, plus the explicitly listed Git revspecs. See
 // git.CompileRefGlobs for information on how ref include/exclude globs are handled.
+//
+// Ref date filters further restrict the matched refs. All date filters must hold
+// for a ref to match.
 func expandedRevSpec(ctx context.Context, r *RepositoryRevisions) ([]string, error) {
 	listRefs := r.ListRefs
 	if listRefs == nil {
@@ -216,19 +278,34 @@ func expandedRevSpec(ctx context.Context, r *RepositoryRevisions) ([]string, err
 	}
 
 	var (
-		revSpecs = map[string]struct{}{}
-		globs    []git.RefGlob
+		revSpecs      = map[string]struct{}{}
+		globs         []git.RefGlob
+		hasInclude    bool
+		after, before time.Time
 	)
 	for _, rev := range r.Revs {
 		switch {
 		case rev.RefGlob != "":
 			globs = append(globs, git.RefGlob{Include: rev.RefGlob})
+			hasInclude = true
 		case rev.ExcludeRefGlob != "":
 			globs = append(globs, git.RefGlob{Exclude: rev.ExcludeRefGlob})
+		case !rev.CreatedAfter.IsZero():
+			if rev.CreatedAfter.After(after) {
+				after = rev.CreatedAfter
+			}
+		case !rev.CreatedBefore.IsZero():
+			if before.IsZero() || rev.CreatedBefore.Before(before) {
+				before = rev.CreatedBefore
+			}
 		default:
 			revSpecs[rev.RevSpec] = struct{}{}
 		}
 	}
+	if (!after.IsZero() || !before.IsZero()) && !hasInclude {
+		// Include globs must come first, so that exclude globs apply to them.
+		globs = append([]git.RefGlob{{Include: "refs/heads/"}, {Include: "refs/tags/"}}, globs...)
+	}
 	if len(globs) > 0 {
 		allRefs, err := listRefs(ctx, r.GitserverRepo())
 		if err != nil {
@@ -241,6 +318,12 @@ func expandedRevSpec(ctx context.Context, r *RepositoryRevisions) ([]string, err
 		}
 
 		for _, ref := range allRefs {
+			if !after.IsZero() && !ref.CreatorDate.After(after) {
+				continue
+			}
+			if !before.IsZero() && !ref.CreatorDate.Before(before) {
+				continue
+			}
 			if rg.Match(ref.Name) {
 				revSpecs[strings.TrimPrefix(ref.Name, "refs/heads/")] = struct{}{}
 			}
@@ -1,8 +1,14 @@
 package search
 
 import (
+	"context"
 	"reflect"
+	"sort"
 	"testing"
+	"time"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
 )
 
 func TestParseRepositoryRevisions(t *testing.T) {
@@ -30,6 +36,15 @@ func TestParseRepositoryRevisions(t *testing.T) {
 				{RefGlob: "glob3"},
 			},
 		},
+		"repo@*refs/tags/v1.*:>2021-01-01:<2021-06-01": {
+			repo: "repo",
+			revs: []RevisionSpecifier{
+				{RefGlob: "refs/tags/v1.*"},
+				{CreatedAfter: time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC)},
+				{CreatedBefore: time.Date(2021, 6, 1, 0, 0, 0, 0, time.UTC)},
+			},
+		},
+		"repo@>yesterday": {repo: "repo", revs: []RevisionSpecifier{{RevSpec: ">yesterday"}}},
 	}
 	for input, want := range tests {
 		t.Run(input, func(t *testing.T) {
@@ -43,3 +58,55 @@ func TestParseRepositoryRevisions(t *testing.T) {
 		})
 	}
 }
+
+func TestExpandedRevSpecs(t *testing.T) {
+	date := func(s string) time.Time {
+		d, err := time.Parse("2006-01-02", s)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return d
+	}
+	refs := []git.Ref{
+		{Name: "refs/heads/main", CreatorDate: date("2021-03-01")},
+		{Name: "refs/heads/old", CreatorDate: date("2020-03-01")},
+		{Name: "refs/pull/1/head", CreatorDate: date("2021-03-01")},
+		{Name: "refs/tags/v1.0.0", CreatorDate: date("2020-02-01")},
+		{Name: "refs/tags/v1.0.1", CreatorDate: date("2021-02-01")},
+		{Name: "refs/tags/v1.1.0", CreatorDate: date("2021-04-01")},
+		{Name: "refs/tags/v2.0.0", CreatorDate: date("2021-05-01")},
+	}
+
+	tests := []struct {
+		revs string
+		want []string
+	}{
+		{revs: "*refs/tags/v1.*", want: []string{"refs/tags/v1.0.0", "refs/tags/v1.0.1", "refs/tags/v1.1.0"}},
+		{revs: "*refs/tags/v1.*:*!refs/tags/v1.0.*", want: []string{"refs/tags/v1.1.0"}},
+		{revs: "*refs/tags/v1.*:>2021-01-01", want: []string{"refs/tags/v1.0.1", "refs/tags/v1.1.0"}},
+		{revs: "*refs/tags/v1.*:*!refs/tags/v1.0.*:>2021-01-01", want: []string{"refs/tags/v1.1.0"}},
+		{revs: ">2021-01-01:<2021-04-15", want: []string{"main", "refs/tags/v1.0.1", "refs/tags/v1.1.0"}},
+		{revs: ">2021-01-01:*!refs/tags/*", want: []string{"main"}},
+		{revs: "old:>2021-04-15", want: []string{"old", "refs/tags/v2.0.0"}},
+		{revs: "<2020-01-01", want: []string{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.revs, func(t *testing.T) {
+			_, revs := ParseRepositoryRevisions("repo@" + tt.revs)
+			r := &RepositoryRevisions{
+				Revs: revs,
+				ListRefs: func(context.Context, api.RepoName) ([]git.Ref, error) {
+					return refs, nil
+				},
+			}
+			got, err := r.ExpandedRevSpecs(context.Background())
+			if err != nil {
+				t.Fatal(err)
+			}
+			sort.Strings(got)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Fatalf("got %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
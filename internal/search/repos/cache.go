@@ -0,0 +1,153 @@
+package repos
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/search/searchcontexts"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// cachedResolved is the representation of Resolved in
+// database.SearchReposCache.
+type cachedResolved struct {
+	RepoRevs        []cachedRepoRevs
+	MissingRepoRevs []cachedRepoRevs
+	ExcludedRepos   ExcludedRepos
+	OverLimit       bool
+}
+
+type cachedRepoRevs struct {
+	Repo types.RepoName
+	Revs []search.RevisionSpecifier
+}
+
+func newCachedResolved(r Resolved) *cachedResolved {
+	toCached := func(repoRevs []*search.RepositoryRevisions) []cachedRepoRevs {
+		cached := make([]cachedRepoRevs, 0, len(repoRevs))
+		for _, rr := range repoRevs {
+			cached = append(cached, cachedRepoRevs{Repo: rr.Repo, Revs: rr.Revs})
+		}
+		return cached
+	}
+
+	return &cachedResolved{
+		RepoRevs:        toCached(r.RepoRevs),
+		MissingRepoRevs: toCached(r.MissingRepoRevs),
+		ExcludedRepos:   r.ExcludedRepos,
+		OverLimit:       r.OverLimit,
+	}
+}
+
+func (c *cachedResolved) resolved() Resolved {
+	fromCached := func(cached []cachedRepoRevs) []*search.RepositoryRevisions {
+		if len(cached) == 0 {
+			return nil
+		}
+		repoRevs := make([]*search.RepositoryRevisions, 0, len(cached))
+		for _, rr := range cached {
+			repoRevs = append(repoRevs, &search.RepositoryRevisions{Repo: rr.Repo, Revs: rr.Revs})
+		}
+		return repoRevs
+	}
+
+	return Resolved{
+		RepoRevs:        fromCached(c.RepoRevs),
+		MissingRepoRevs: fromCached(c.MissingRepoRevs),
+		ExcludedRepos:   c.ExcludedRepos,
+		OverLimit:       c.OverLimit,
+	}
+}
+
+// cachedResolve returns the repositories resolved for op, like resolve, but
+// reads and stores them in database.SearchReposCache.
+func (r *Resolver) cachedResolve(ctx context.Context, op search.RepoOptions) (Resolved, error) {
+	key := resolveCacheKey(ctx, op)
+	if key == "" || database.SearchReposCache == nil {
+		return r.resolve(ctx, op)
+	}
+
+	var cached cachedResolved
+	generation, ok, err := database.SearchReposCache.GetJSON(ctx, key, &cached)
+	if err != nil {
+		log15.Warn("reading search repositories cache", "key", key, "error", err)
+	}
+	if ok {
+		return cached.resolved(), nil
+	}
+
+	resolved, err := r.resolve(ctx, op)
+	if err != nil {
+		return resolved, err
+	}
+	if err := database.SearchReposCache.SetJSON(ctx, generation, key, newCachedResolved(resolved)); err != nil {
+		log15.Warn("writing search repositories cache", "key", key, "error", err)
+	}
+	return resolved, nil
+}
+
+// resolveCacheKey returns the key of the repositories resolved for op by the
+// actor in ctx, or the empty string if they are not cached. Only searches of
+// the global search context without revisions are cached: revisions are
+// validated against gitserver and the repositories of other search and version
+// contexts can change without being reported to the cache.
+func resolveCacheKey(ctx context.Context, op search.RepoOptions) string {
+	if !searchcontexts.IsGlobalSearchContextSpec(op.SearchContextSpec) || op.VersionContextName != "" || op.CommitAfter != "" {
+		return ""
+	}
+	for _, filter := range op.RepoFilters {
+		if strings.Contains(filter, "@") {
+			return ""
+		}
+	}
+
+	sorted := func(s []string) []string {
+		s = append([]string{}, s...)
+		sort.Strings(s)
+		return s
+	}
+
+	// The repositories of repo groups are defined in the settings, which are
+	// included in the key so that changes take effect immediately.
+	var groups []byte
+	if len(op.RepoGroupFilters) > 0 && op.UserSettings != nil {
+		var err error
+		if groups, err = json.Marshal(op.UserSettings.SearchRepositoryGroups); err != nil {
+			return ""
+		}
+	}
+
+	a := actor.FromContext(ctx)
+	return fmt.Sprintf("actor:%d:%t:%q:%q:%q:%s:%q:%t:%t:%t:%t:%t:%t:%t:%d:%t:%t:%t:%t",
+		a.UID,
+		a.Internal,
+		sorted(op.RepoFilters),
+		sorted(op.MinusRepoFilters),
+		sorted(op.RepoGroupFilters),
+		groups,
+		sorted(op.HasMeta),
+		op.NoForks,
+		op.OnlyForks,
+		op.NoArchived,
+		op.OnlyArchived,
+		op.OnlyPrivate,
+		op.OnlyPublic,
+		op.Ranked,
+		op.Limit,
+		// The query only determines whether excluded repositories are
+		// counted, and whether indexed repositories are searched.
+		op.Query == nil,
+		op.Query != nil && op.Query.Fork() == nil,
+		op.Query != nil && op.Query.Archived() == nil,
+		query.HasTypeRepo(op.Query),
+	)
+}
@@ -0,0 +1,83 @@
+package repos
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/google/zoekt"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/cache"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	searchbackend "github.com/sourcegraph/sourcegraph/internal/search/backend"
+	"github.com/sourcegraph/sourcegraph/internal/search/query"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestResolveCached(t *testing.T) {
+	orig := envvar.SourcegraphDotComMode()
+	envvar.MockSourcegraphDotComMode(true)
+	defer envvar.MockSourcegraphDotComMode(orig)
+
+	database.SearchReposCache = cache.NewVersioned(cache.NewMemory(), time.Minute)
+	defer func() { database.SearchReposCache = nil }()
+
+	q, err := query.ParseLiteral("foo")
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	calls := 0
+	resolver := &Resolver{
+		Zoekt: &searchbackend.Zoekt{
+			Client:       &searchbackend.FakeSearcher{Repos: []*zoekt.RepoListEntry{{Repository: zoekt.Repository{Name: "foo"}}}},
+			DisableCache: true,
+		},
+		SearchableReposFunc: func(context.Context) ([]types.RepoName, error) {
+			calls++
+			return []types.RepoName{{ID: 1, Name: "foo"}}, nil
+		},
+	}
+
+	resolve := func(ctx context.Context, op search.RepoOptions) {
+		t.Helper()
+		resolved, err := resolver.Resolve(ctx, op)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(resolved.RepoRevs) != 1 || resolved.RepoRevs[0].Repo.Name != api.RepoName("foo") {
+			t.Fatalf("unexpected resolved repositories: %v", resolved.RepoRevs)
+		}
+	}
+
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+	op := search.RepoOptions{Query: q}
+
+	resolve(ctx, op)
+	resolve(ctx, op)
+	if calls != 1 {
+		t.Fatalf("want repositories to be resolved once, resolved %d times", calls)
+	}
+
+	// Other actors don't share the cached repositories.
+	resolve(actor.WithActor(context.Background(), actor.FromUser(2)), op)
+	if calls != 2 {
+		t.Fatalf("want repositories to be resolved for another actor, resolved %d times", calls)
+	}
+
+	database.InvalidateSearchRepos(ctx)
+	resolve(ctx, op)
+	if calls != 3 {
+		t.Fatalf("want repositories to be resolved after invalidation, resolved %d times", calls)
+	}
+
+	// Searches of other search contexts are not cached.
+	op.SearchContextSpec = "@alice"
+	if key := resolveCacheKey(ctx, op); key != "" {
+		t.Fatalf("want search context not to be cached, have key %q", key)
+	}
+}
@@ -51,7 +51,13 @@ type Resolver struct {
 	SearchableReposFunc searchableReposFunc
 }
 
+// Resolve returns the repositories and revisions to search for op. Results
+// are cached in database.SearchReposCache if it is set.
 func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (Resolved, error) {
+	return r.cachedResolve(ctx, op)
+}
+
+func (r *Resolver) resolve(ctx context.Context, op search.RepoOptions) (Resolved, error) {
 	var err error
 	tr, ctx := trace.New(ctx, "resolveRepositories", op.String())
 	defer func() {
@@ -161,8 +167,23 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (Resolved
 			OnlyPrivate:  op.OnlyPrivate,
 		}
 
+		for _, meta := range op.HasMeta {
+			key, value := query.ParseRepoHasMeta(meta)
+			options.HasMeta = append(options.HasMeta, database.RepoMetaFilter{Key: key, Value: value})
+		}
+
 		if searchContext.ID != 0 {
 			options.SearchContextID = searchContext.ID
+		} else if searchContext.NamespaceTeamID != 0 {
+			options.IDs, err = database.OrgTeams(r.DB).ListRepoIDs(ctx, searchContext.NamespaceTeamID)
+			if err != nil {
+				return Resolved{}, err
+			}
+			if len(options.IDs) == 0 {
+				// An empty list of IDs is not a filter, but the team has no
+				// repositories to search.
+				return Resolved{}, nil
+			}
 		} else if searchContext.NamespaceUserID != 0 {
 			options.UserID = searchContext.NamespaceUserID
 			options.IncludeUserPublicRepos = true
@@ -194,6 +215,26 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (Resolved
 		if err != nil {
 			return Resolved{}, err
 		}
+
+		// A search for a single repository by a name it no longer has searches
+		// the repository it was renamed to on its code host.
+		if len(repos) == 0 && len(includePatterns) == 1 {
+			pattern, ok, err := renamedRepoPattern(ctx, r.DB, includePatterns[0])
+			if err != nil {
+				return Resolved{}, err
+			}
+			if ok {
+				tr.LazyPrintf("following rename: %s", pattern)
+				options.IncludePatterns = []string{pattern}
+				repos, err = database.Repos(r.DB).ListRepoNames(ctx, options)
+				if err != nil {
+					return Resolved{}, err
+				}
+				for i := range includePatternRevs {
+					includePatternRevs[i].includePattern = regexp.MustCompile("(?i:" + pattern + ")")
+				}
+			}
+		}
 	}
 	overLimit := len(repos) > limit
 	repoRevs := make([]*search.RepositoryRevisions, 0, len(repos))
@@ -249,7 +290,7 @@ func (r *Resolver) Resolve(ctx context.Context, op search.RepoOptions) (Resolved
 
 		// Check if the repository actually has the revisions that the user specified.
 		for _, rev := range revs {
-			if rev.RefGlob != "" || rev.ExcludeRefGlob != "" {
+			if rev.IsRefPattern() {
 				// Do not validate ref patterns. A ref pattern matching 0 refs is not necessarily
 				// invalid, so it's not clear what validation would even mean.
 				repoRev.Revs = append(repoRev.Revs, rev)
@@ -334,6 +375,28 @@ func ExactlyOneRepo(repoFilters []string) bool {
 	return false
 }
 
+// renamedRepoPattern returns a pattern matching the current name of the
+// repository that was renamed from the single repository name matched by
+// pattern, if there is one.
+func renamedRepoPattern(ctx context.Context, db dbutil.DB, pattern string) (string, bool, error) {
+	if !strings.HasPrefix(pattern, "^") || !strings.HasSuffix(pattern, "$") {
+		return "", false, nil
+	}
+	re, err := regexpsyntax.Parse(strings.TrimSuffix(strings.TrimPrefix(pattern, "^"), "$"), regexpFlags)
+	if err != nil || re.Op != regexpsyntax.OpLiteral {
+		return "", false, nil
+	}
+
+	repo, err := database.Repos(db).GetByPreviousName(ctx, api.RepoName(string(re.Rune)))
+	if err != nil {
+		if errcode.IsNotFound(err) {
+			return "", false, nil
+		}
+		return "", false, err
+	}
+	return "^" + regexp.QuoteMeta(string(repo.Name)) + "$", true, nil
+}
+
 func UnionRegExps(patterns []string) string {
 	if len(patterns) == 0 {
 		return ""
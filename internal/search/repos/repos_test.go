@@ -181,6 +181,63 @@ func TestRevisionValidation(t *testing.T) {
 	}
 }
 
+func TestResolveRenamedRepository(t *testing.T) {
+	git.Mocks.ResolveRevision = func(spec string, opt git.ResolveRevisionOptions) (api.CommitID, error) {
+		return "", nil
+	}
+	database.Mocks.Repos.ListRepoNames = func(ctx context.Context, opts database.ReposListOptions) ([]types.RepoName, error) {
+		if len(opts.IncludePatterns) == 1 && opts.IncludePatterns[0] == `^github\.com/new/name$` {
+			return []types.RepoName{{ID: 1, Name: "github.com/new/name"}}, nil
+		}
+		return nil, nil
+	}
+	database.Mocks.Repos.GetByPreviousName = func(ctx context.Context, name api.RepoName) (*types.Repo, error) {
+		if name == "github.com/old/name" {
+			return &types.Repo{ID: 1, Name: "github.com/new/name"}, nil
+		}
+		return nil, &database.RepoNotFoundErr{Name: name}
+	}
+	defer func() {
+		git.Mocks.ResolveRevision = nil
+		database.Mocks.Repos.ListRepoNames = nil
+		database.Mocks.Repos.GetByPreviousName = nil
+	}()
+
+	tests := []struct {
+		repoFilter string
+		want       []*search.RepositoryRevisions
+	}{
+		{
+			repoFilter: `^github\.com/old/name$@revBar`,
+			want: []*search.RepositoryRevisions{{
+				Repo: types.RepoName{ID: 1, Name: "github.com/new/name"},
+				Revs: []search.RevisionSpecifier{{RevSpec: "revBar"}},
+			}},
+		},
+		{
+			repoFilter: `^github\.com/unknown/name$`,
+			want:       []*search.RepositoryRevisions{},
+		},
+		{
+			repoFilter: `github\.com/old`,
+			want:       []*search.RepositoryRevisions{},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.repoFilter, func(t *testing.T) {
+			op := search.RepoOptions{RepoFilters: []string{tt.repoFilter}}
+			resolved, err := (&Resolver{}).Resolve(context.Background(), op)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff(tt.want, resolved.RepoRevs); diff != "" {
+				t.Error(diff)
+			}
+		})
+	}
+}
+
 // TestSearchRevspecs tests a repository name against a list of
 // repository specs with optional revspecs, and determines whether
 // we get the expected error, list of matching rev specs, or list
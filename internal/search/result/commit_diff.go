@@ -0,0 +1,182 @@
+package result
+
+import (
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// DiffFile is a file in the diff of a commit match.
+type DiffFile struct {
+	// OrigName and NewName are the paths of the file before and after the
+	// commit. They are empty if the file was added (resp. deleted).
+	OrigName string
+	NewName  string
+	Hunks    []DiffHunk
+}
+
+// DiffHunk is a hunk of a DiffFile.
+type DiffHunk struct {
+	OldStart int32
+	OldLines int32
+	NewStart int32
+	NewLines int32
+	Section  string
+	Lines    []DiffLine
+}
+
+type DiffLineKind string
+
+const (
+	DiffLineAdded     DiffLineKind = "ADDED"
+	DiffLineUnchanged DiffLineKind = "UNCHANGED"
+	DiffLineDeleted   DiffLineKind = "DELETED"
+)
+
+// DiffLine is a line of a DiffHunk.
+type DiffLine struct {
+	Kind DiffLineKind
+	// OldLine and NewLine are the 1-indexed line numbers in the old (resp.
+	// new) file. OldLine is 0 for added lines, and NewLine is 0 for deleted
+	// lines.
+	OldLine int32
+	NewLine int32
+	// Value is the line without the leading '+', '-' or ' '.
+	Value string
+	// Highlights are the query matches on the line. Line is the line number
+	// in the file the line belongs to and Character is the 0-indexed offset
+	// into Value.
+	Highlights []HighlightedRange
+}
+
+var hunkHeaderRegexp = regexp.MustCompile(`^@@ -(\d+)(?:,(\d+))? \+(\d+)(?:,(\d+))? @@ ?(.*)$`)
+
+// DiffFiles parses the diff preview of a commit match into files and hunks,
+// so that clients can render it like a file match. It returns nil if the
+// match is not a diff match.
+func (r *CommitMatch) DiffFiles() []DiffFile {
+	if r.DiffPreview == nil {
+		return nil
+	}
+	return parseDiffFiles(*r.DiffPreview)
+}
+
+func parseDiffFiles(preview HighlightedString) []DiffFile {
+	// The highlights of a diff preview are 1-indexed by line and include the
+	// line status in the character offset.
+	highlightsByLine := make(map[int32][]HighlightedRange)
+	for _, h := range preview.Highlights {
+		highlightsByLine[h.Line] = append(highlightsByLine[h.Line], h)
+	}
+
+	var (
+		files            []DiffFile
+		file             *DiffFile
+		hunk             *DiffHunk
+		oldLine, newLine int32
+		oldLeft, newLeft int32
+	)
+	for i, line := range strings.Split(preview.Value, "\n") {
+		if hunk != nil && (oldLeft > 0 || newLeft > 0) {
+			if strings.HasPrefix(line, `\`) {
+				continue // "\ No newline at end of file"
+			}
+
+			l := DiffLine{Kind: DiffLineUnchanged}
+			if line != "" {
+				switch line[0] {
+				case '+':
+					l.Kind = DiffLineAdded
+				case '-':
+					l.Kind = DiffLineDeleted
+				}
+				l.Value = line[1:]
+			}
+
+			lineNumber := newLine
+			switch l.Kind {
+			case DiffLineAdded:
+				l.NewLine = newLine
+				newLine++
+				newLeft--
+			case DiffLineDeleted:
+				l.OldLine = oldLine
+				lineNumber = oldLine
+				oldLine++
+				oldLeft--
+			default:
+				l.OldLine, l.NewLine = oldLine, newLine
+				oldLine++
+				newLine++
+				oldLeft--
+				newLeft--
+			}
+
+			for _, h := range highlightsByLine[int32(i)+1] {
+				l.Highlights = append(l.Highlights, HighlightedRange{
+					Line:      lineNumber,
+					Character: h.Character - 1,
+					Length:    h.Length,
+				})
+			}
+			hunk.Lines = append(hunk.Lines, l)
+			continue
+		}
+
+		switch {
+		case strings.HasPrefix(line, "diff "):
+			files = append(files, DiffFile{})
+			file, hunk = &files[len(files)-1], nil
+
+		case strings.HasPrefix(line, "--- "):
+			if file == nil || len(file.Hunks) > 0 {
+				files = append(files, DiffFile{})
+				file, hunk = &files[len(files)-1], nil
+			}
+			file.OrigName = diffFileName(line[len("--- "):])
+
+		case strings.HasPrefix(line, "+++ ") && file != nil:
+			file.NewName = diffFileName(line[len("+++ "):])
+
+		case strings.HasPrefix(line, "@@ ") && file != nil:
+			m := hunkHeaderRegexp.FindStringSubmatch(line)
+			if m == nil {
+				continue
+			}
+			file.Hunks = append(file.Hunks, DiffHunk{
+				OldStart: parseHunkInt(m[1], 0),
+				OldLines: parseHunkInt(m[2], 1),
+				NewStart: parseHunkInt(m[3], 0),
+				NewLines: parseHunkInt(m[4], 1),
+				Section:  m[5],
+			})
+			hunk = &file.Hunks[len(file.Hunks)-1]
+			oldLine, newLine = hunk.OldStart, hunk.NewStart
+			oldLeft, newLeft = hunk.OldLines, hunk.NewLines
+		}
+	}
+	return files
+}
+
+// diffFileName returns the path of a "---" or "+++" line of a diff, which is
+// empty for /dev/null.
+func diffFileName(s string) string {
+	if i := strings.IndexByte(s, '\t'); i >= 0 {
+		s = s[:i]
+	}
+	if s == "/dev/null" {
+		return ""
+	}
+	return s
+}
+
+func parseHunkInt(s string, defaultValue int32) int32 {
+	if s == "" {
+		return defaultValue
+	}
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil {
+		return defaultValue
+	}
+	return int32(n)
+}
@@ -0,0 +1,67 @@
+package result
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseDiffFiles(t *testing.T) {
+	preview := HighlightedString{
+		Value: `diff --git a.go a.go
+index 1111111..2222222 100644
+--- a.go
++++ a.go
+@@ -3 +3 @@ func main() {
+-	foo()
++	bar(foo)
+diff --git b.go b.go
+new file mode 100644
+index 0000000..3333333
+--- /dev/null
++++ b.go
+@@ -0,0 +1,2 @@
++package b
++var foo = 1
+\ No newline at end of file
+`,
+		Highlights: []HighlightedRange{
+			{Line: 6, Character: 2, Length: 3},
+			{Line: 7, Character: 6, Length: 3},
+			{Line: 15, Character: 5, Length: 3},
+		},
+	}
+
+	want := []DiffFile{
+		{
+			OrigName: "a.go",
+			NewName:  "a.go",
+			Hunks: []DiffHunk{{
+				OldStart: 3, OldLines: 1, NewStart: 3, NewLines: 1,
+				Section: "func main() {",
+				Lines: []DiffLine{
+					{Kind: DiffLineDeleted, OldLine: 3, Value: "\tfoo()", Highlights: []HighlightedRange{{Line: 3, Character: 1, Length: 3}}},
+					{Kind: DiffLineAdded, NewLine: 3, Value: "\tbar(foo)", Highlights: []HighlightedRange{{Line: 3, Character: 5, Length: 3}}},
+				},
+			}},
+		},
+		{
+			NewName: "b.go",
+			Hunks: []DiffHunk{{
+				OldStart: 0, OldLines: 0, NewStart: 1, NewLines: 2,
+				Lines: []DiffLine{
+					{Kind: DiffLineAdded, NewLine: 1, Value: "package b"},
+					{Kind: DiffLineAdded, NewLine: 2, Value: "var foo = 1", Highlights: []HighlightedRange{{Line: 2, Character: 4, Length: 3}}},
+				},
+			}},
+		},
+	}
+
+	if diff := cmp.Diff(want, (&CommitMatch{DiffPreview: &preview}).DiffFiles()); diff != "" {
+		t.Fatalf("unexpected diff files (-want +got):\n%s", diff)
+	}
+
+	if files := (&CommitMatch{}).DiffFiles(); files != nil {
+		t.Fatalf("expected no diff files for a commit match without diff, got %v", files)
+	}
+}
@@ -0,0 +1,91 @@
+package result
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// RepoOrigin describes the repository a repository was forked (or mirrored)
+// from.
+type RepoOrigin struct {
+	// Key identifies the origin repository. Forks of the same repository, and
+	// the repository itself, have the same key.
+	Key string
+	// IsFork is true if the repository is not the origin repository itself.
+	IsFork bool
+}
+
+// DedupeForks groups file matches which are identical (same path and same
+// line matches) and found in repositories with the same origin. Only one
+// match of every group is kept: the match in the origin repository if it is
+// part of the results, otherwise the first match. It is annotated with the
+// number of matches that were grouped into it. Other matches are kept as
+// they are, and the order of the results is preserved.
+func DedupeForks(matches []Match, origin func(types.RepoName) RepoOrigin) []Match {
+	type group struct {
+		index int // index of the kept match in deduped
+		count int
+		fork  bool
+	}
+	groups := make(map[string]*group)
+	deduped := matches[:0:0]
+	for _, m := range matches {
+		fm, ok := m.(*FileMatch)
+		if !ok {
+			deduped = append(deduped, m)
+			continue
+		}
+
+		o := origin(fm.Repo)
+		key := o.Key + "\x00" + fileMatchSignature(fm)
+		g, ok := groups[key]
+		if !ok {
+			groups[key] = &group{index: len(deduped), count: 1, fork: o.IsFork}
+			deduped = append(deduped, fm)
+			continue
+		}
+		g.count++
+		if g.fork && !o.IsFork {
+			// Prefer the match in the origin repository.
+			deduped[g.index] = fm
+			g.fork = false
+		}
+	}
+
+	for _, g := range groups {
+		if g.count > 1 {
+			deduped[g.index].(*FileMatch).DuplicateForks = g.count - 1
+		}
+	}
+	return deduped
+}
+
+// fileMatchSignature returns a string which is equal for file matches with
+// the same path and the same line and symbol matches.
+func fileMatchSignature(fm *FileMatch) string {
+	var b strings.Builder
+	b.WriteString(fm.Path)
+	for _, lm := range fm.LineMatches {
+		b.WriteByte(0)
+		b.WriteString(strconv.Itoa(int(lm.LineNumber)))
+		b.WriteByte(':')
+		b.WriteString(lm.Preview)
+		for _, ol := range lm.OffsetAndLengths {
+			b.WriteByte(' ')
+			b.WriteString(strconv.Itoa(int(ol[0])))
+			b.WriteByte(',')
+			b.WriteString(strconv.Itoa(int(ol[1])))
+		}
+	}
+	for _, sm := range fm.Symbols {
+		b.WriteByte(0)
+		b.WriteString(sm.Symbol.Name)
+		b.WriteByte(':')
+		b.WriteString(sm.Symbol.Kind)
+		b.WriteByte(':')
+		b.WriteString(strconv.Itoa(sm.Symbol.Line))
+	}
+	return b.String()
+}
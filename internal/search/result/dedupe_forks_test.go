@@ -0,0 +1,71 @@
+package result
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestDedupeForks(t *testing.T) {
+	origins := map[api.RepoName]RepoOrigin{
+		"github.com/org/repo":   {Key: "github.com/org/repo"},
+		"github.com/alice/repo": {Key: "github.com/org/repo", IsFork: true},
+		"github.com/bob/repo":   {Key: "github.com/org/repo", IsFork: true},
+		"github.com/org/other":  {Key: "github.com/org/other"},
+	}
+	origin := func(r types.RepoName) RepoOrigin { return origins[r.Name] }
+
+	fileMatch := func(repo api.RepoName, path, preview string) *FileMatch {
+		return &FileMatch{
+			File:        File{Repo: types.RepoName{Name: repo}, Path: path},
+			LineMatches: []*LineMatch{{Preview: preview, LineNumber: 1, OffsetAndLengths: [][2]int32{{0, 3}}}},
+		}
+	}
+	name := func(m Match) string {
+		switch v := m.(type) {
+		case *FileMatch:
+			return string(v.Repo.Name) + "/" + v.Path
+		case *RepoMatch:
+			return string(v.Name)
+		}
+		return ""
+	}
+
+	matches := []Match{
+		fileMatch("github.com/alice/repo", "a.go", "foo"),
+		fileMatch("github.com/org/other", "a.go", "foo"),
+		&RepoMatch{Name: "github.com/bob/repo"},
+		fileMatch("github.com/bob/repo", "a.go", "foo"),
+		fileMatch("github.com/bob/repo", "b.go", "foo"),
+		fileMatch("github.com/org/repo", "a.go", "foo"),
+		fileMatch("github.com/alice/repo", "b.go", "foo changed"),
+	}
+
+	deduped := DedupeForks(matches, origin)
+
+	var have []string
+	duplicates := map[string]int{}
+	for _, m := range deduped {
+		have = append(have, name(m))
+		if fm, ok := m.(*FileMatch); ok && fm.DuplicateForks > 0 {
+			duplicates[name(m)] = fm.DuplicateForks
+		}
+	}
+
+	want := []string{
+		"github.com/org/repo/a.go",
+		"github.com/org/other/a.go",
+		"github.com/bob/repo",
+		"github.com/bob/repo/b.go",
+		"github.com/alice/repo/b.go",
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("unexpected results (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff(map[string]int{"github.com/org/repo/a.go": 2}, duplicates); diff != "" {
+		t.Fatalf("unexpected duplicate forks (-want +got):\n%s", diff)
+	}
+}
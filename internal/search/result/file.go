@@ -47,6 +47,10 @@ type FileMatch struct {
 	Symbols     []*SymbolMatch `json:"-"`
 
 	LimitHit bool
+
+	// DuplicateForks is the number of forks of the repository with an
+	// identical match, which were grouped into this match by dedupe:forks.
+	DuplicateForks int `json:"-"`
 }
 
 func (fm *FileMatch) RepoName() types.RepoName {
@@ -0,0 +1,55 @@
+package result
+
+import (
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// RepoGroup is the results of a search in one repository, as grouped by
+// group:repo.
+type RepoGroup struct {
+	Repo types.RepoName
+
+	// Matches are the first results in the repository, up to the limit per
+	// group.
+	Matches []Match
+
+	// ResultCount is the number of results in the repository, including the
+	// results beyond the limit.
+	ResultCount int
+
+	// MatchCount is the sum of ResultCount() of all results in the
+	// repository, including the results beyond the limit.
+	MatchCount int
+}
+
+// LimitHit returns true if some results of the group were dropped because of
+// the limit per group.
+func (g *RepoGroup) LimitHit() bool {
+	return len(g.Matches) < g.ResultCount
+}
+
+// GroupByRepo groups matches by repository, in the order of the first match
+// of each repository. At most limit matches are kept in each group, but the
+// counts of a group include all its matches. A limit of zero or less keeps
+// all matches.
+func GroupByRepo(matches []Match, limit int) []*RepoGroup {
+	var groups []*RepoGroup
+	byRepo := make(map[api.RepoName]*RepoGroup)
+	for _, m := range matches {
+		repo := m.RepoName()
+		g, ok := byRepo[repo.Name]
+		if !ok {
+			g = &RepoGroup{Repo: repo}
+			byRepo[repo.Name] = g
+			groups = append(groups, g)
+		}
+
+		if limit <= 0 || len(g.Matches) < limit {
+			g.Matches = append(g.Matches, m)
+		}
+		g.ResultCount++
+		g.MatchCount += m.ResultCount()
+	}
+	return groups
+}
@@ -0,0 +1,57 @@
+package result
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+func TestGroupByRepo(t *testing.T) {
+	fileMatch := func(repo api.RepoName, path string, lines int) *FileMatch {
+		fm := &FileMatch{File: File{Repo: types.RepoName{Name: repo}, Path: path}}
+		for i := 0; i < lines; i++ {
+			fm.LineMatches = append(fm.LineMatches, &LineMatch{LineNumber: int32(i), OffsetAndLengths: [][2]int32{{0, 3}}})
+		}
+		return fm
+	}
+
+	matches := []Match{
+		fileMatch("b", "1.go", 2),
+		fileMatch("a", "1.go", 1),
+		fileMatch("b", "2.go", 3),
+		&RepoMatch{Name: "c"},
+		fileMatch("b", "3.go", 1),
+	}
+
+	type group struct {
+		Repo        api.RepoName
+		Keys        []Key
+		ResultCount int
+		MatchCount  int
+		LimitHit    bool
+	}
+	var have []group
+	for _, g := range GroupByRepo(matches, 2) {
+		var keys []Key
+		for _, m := range g.Matches {
+			keys = append(keys, m.Key())
+		}
+		have = append(have, group{g.Repo.Name, keys, g.ResultCount, g.MatchCount, g.LimitHit()})
+	}
+
+	want := []group{
+		{"b", []Key{matches[0].Key(), matches[2].Key()}, 3, 6, true},
+		{"a", []Key{matches[1].Key()}, 1, 1, false},
+		{"c", []Key{matches[3].Key()}, 1, 1, false},
+	}
+	if diff := cmp.Diff(want, have); diff != "" {
+		t.Fatalf("unexpected groups (-want +got):\n%s", diff)
+	}
+
+	if groups := GroupByRepo(matches, 0); len(groups[0].Matches) != 3 {
+		t.Fatalf("want all matches without limit, have %d", len(groups[0].Matches))
+	}
+}
@@ -57,8 +57,11 @@ func SearchRepositories(ctx context.Context, args *search.TextParameters, limit
 		query.FieldCase:               {},
 		query.FieldRepoHasFile:        {},
 		query.FieldRepoHasCommitAfter: {},
+		query.FieldRepoHasMeta:        {},
 		query.FieldPatternType:        {},
 		query.FieldSelect:             {},
+		query.FieldDedupe:             {},
+		query.FieldGroup:              {},
 	}
 	// Don't return repo results if the search contains fields that aren't on the allowlist.
 	// Matching repositories based whether they contain files at a certain path (etc.) is not yet implemented.
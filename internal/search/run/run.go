@@ -17,6 +17,15 @@ type SearchInputs struct {
 
 	// DefaultLimit is the default limit to use if not specified in query.
 	DefaultLimit int
+
+	// Locale is the locale in which alerts are shown to the user, chosen
+	// from the Accept-Language header of the request. It is empty if alerts
+	// should be shown in English.
+	Locale string
+
+	// Exhaustive is true if the search is not subject to the limits and
+	// timeouts of interactive searches.
+	Exhaustive bool
 }
 
 // MaxResults computes the limit for the query.
@@ -21,6 +21,7 @@ import (
 const (
 	GlobalSearchContextName           = "global"
 	searchContextSpecPrefix           = "@"
+	searchContextTeamSeparator        = ":"
 	maxSearchContextNameLength        = 32
 	maxSearchContextDescriptionLength = 1024
 	maxRevisionLength                 = 255
@@ -65,6 +66,8 @@ func ResolveSearchContextSpec(ctx context.Context, db dbutil.DB, searchContextSp
 			NamespaceUserID: namespace.User,
 			NamespaceOrgID:  namespace.Organization,
 		})
+	} else if hasNamespaceName && !hasSearchContextName && strings.Contains(parsedSearchContextSpec.NamespaceName, searchContextTeamSeparator) {
+		return resolveTeamSearchContext(ctx, db, parsedSearchContextSpec.NamespaceName)
 	} else if hasNamespaceName && !hasSearchContextName {
 		namespace, err := database.Namespaces(db).GetByName(ctx, parsedSearchContextSpec.NamespaceName)
 		if err != nil {
@@ -79,6 +82,23 @@ func ResolveSearchContextSpec(ctx context.Context, db dbutil.DB, searchContextSp
 	return database.SearchContexts(db).GetSearchContext(ctx, database.GetSearchContextOptions{Name: parsedSearchContextSpec.SearchContextName})
 }
 
+// resolveTeamSearchContext resolves the auto-defined search context of a team,
+// referenced as @org:team.
+func resolveTeamSearchContext(ctx context.Context, db dbutil.DB, name string) (*types.SearchContext, error) {
+	i := strings.Index(name, searchContextTeamSeparator)
+	orgName, teamName := name[:i], name[i+1:]
+
+	org, err := database.Orgs(db).GetByName(ctx, orgName)
+	if err != nil {
+		return nil, err
+	}
+	team, err := database.OrgTeams(db).GetByName(ctx, org.ID, teamName)
+	if err != nil {
+		return nil, err
+	}
+	return GetTeamSearchContext(org, team), nil
+}
+
 func ValidateSearchContextWriteAccessForCurrentUser(ctx context.Context, db dbutil.DB, namespaceUserID, namespaceOrgID int32, public bool) error {
 	if namespaceUserID != 0 && namespaceOrgID != 0 {
 		return errors.New("namespaceUserID and namespaceOrgID are mutually exclusive")
@@ -296,6 +316,19 @@ func GetUserSearchContext(name string, userID int32) *types.SearchContext {
 	return &types.SearchContext{Name: name, Public: true, Description: "All repositories you've added to Sourcegraph", NamespaceUserID: userID}
 }
 
+// GetTeamSearchContext returns the auto-defined search context of a team. It
+// contains the repositories of the team and its parent teams.
+func GetTeamSearchContext(org *types.Org, team *types.OrgTeam) *types.SearchContext {
+	return &types.SearchContext{
+		Name:             org.Name + searchContextTeamSeparator + team.Name,
+		Public:           true,
+		Description:      "All repositories of the team " + team.Name,
+		NamespaceOrgID:   org.ID,
+		NamespaceOrgName: org.Name,
+		NamespaceTeamID:  team.ID,
+	}
+}
+
 func GetGlobalSearchContext() *types.SearchContext {
 	return &types.SearchContext{Name: GlobalSearchContextName, Public: true, Description: "All repositories on Sourcegraph"}
 }
@@ -108,6 +108,7 @@ func TestConstructingSearchContextSpecs(t *testing.T) {
 		{name: "user namespaced search context", searchContext: &types.SearchContext{ID: 1, Name: "context", NamespaceUserID: 1, NamespaceUserName: "user"}, wantSearchContextSpec: "@user/context"},
 		{name: "org namespaced search context", searchContext: &types.SearchContext{ID: 1, Name: "context", NamespaceOrgID: 1, NamespaceOrgName: "org"}, wantSearchContextSpec: "@org/context"},
 		{name: "instance-level search context", searchContext: &types.SearchContext{ID: 1, Name: "instance-level-context"}, wantSearchContextSpec: "instance-level-context"},
+		{name: "team auto-defined search context", searchContext: GetTeamSearchContext(&types.Org{ID: 1, Name: "org"}, &types.OrgTeam{ID: 1, OrgID: 1, Name: "team"}), wantSearchContextSpec: "@org:team"},
 	}
 
 	for _, tt := range tests {
@@ -0,0 +1,200 @@
+package searchjobs
+
+import (
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/export"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+)
+
+type searchJobStore interface {
+	UpdateProgress(ctx context.Context, id int64, matchCount int) (string, error)
+}
+
+type searchResolver interface {
+	Results(context.Context) (*graphqlbackend.SearchResultsResolver, error)
+}
+
+func defaultNewSearchResolver(ctx context.Context, db dbutil.DB, args *graphqlbackend.SearchArgs) (searchResolver, error) {
+	return graphqlbackend.NewSearchImplementer(ctx, db, args)
+}
+
+// searchJobHandler runs a search job exhaustively and streams its results as
+// gzipped JSON lines of export.Row to the upload store.
+type searchJobHandler struct {
+	store             searchJobStore
+	uploadStore       uploadstore.Store
+	db                dbutil.DB
+	newSearchResolver func(context.Context, dbutil.DB, *graphqlbackend.SearchArgs) (searchResolver, error)
+	progressInterval  time.Duration
+}
+
+func (h *searchJobHandler) Handle(ctx context.Context, record workerutil.Record) (err error) {
+	job := record.(searchJobRecord).SearchJob
+	key := database.SearchJobResultsKey(job.ID)
+
+	tr, searchCtx := trace.New(ctx, "search.RunSearchJob", job.Query)
+	defer func() {
+		tr.SetError(err)
+		tr.Finish()
+	}()
+
+	// 🚨 SECURITY: Search as the user who created the job, so that the results
+	// only contain what the user may access.
+	searchCtx = actor.WithActor(searchCtx, actor.FromUser(job.UserID))
+	searchCtx, cancel := context.WithCancel(searchCtx)
+	defer cancel()
+
+	// Upload the results while they are found, so that the worker never holds
+	// more than a buffer of them in memory.
+	pr, pw := io.Pipe()
+	uploadErr := make(chan error, 1)
+	go func() {
+		_, err := h.uploadStore.Upload(searchCtx, key, pr)
+		// Unblock the search if the upload stopped reading its results.
+		pr.CloseWithError(err)
+		uploadErr <- err
+	}()
+
+	results := &searchJobResults{cancel: cancel, pw: pw}
+	results.zw = gzip.NewWriter(pw)
+	results.enc = json.NewEncoder(results.zw)
+
+	// Report progress until the search is done, and stop the search if the
+	// job was canceled.
+	var canceled bool
+	progressDone := make(chan struct{})
+	stopProgress := make(chan struct{})
+	go func() {
+		defer close(progressDone)
+		ticker := time.NewTicker(h.progressInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-stopProgress:
+				return
+			case <-ticker.C:
+			}
+			state, err := h.store.UpdateProgress(searchCtx, job.ID, results.count())
+			if err != nil {
+				log15.Warn("failed to update search job progress", "id", job.ID, "err", err)
+				continue
+			}
+			if state != "processing" {
+				canceled = true
+				cancel()
+				return
+			}
+		}
+	}()
+
+	search, err := h.newSearchResolver(searchCtx, h.db, &graphqlbackend.SearchArgs{
+		Query:      job.Query,
+		Version:    "V2",
+		Stream:     results,
+		Exhaustive: true,
+	})
+	if err == nil {
+		_, err = search.Results(searchCtx)
+	}
+	close(stopProgress)
+	<-progressDone
+
+	if canceled {
+		results.close(context.Canceled)
+		<-uploadErr
+		h.deleteResults(ctx, job.ID)
+		return nil
+	}
+	if err := results.close(err); err != nil {
+		<-uploadErr
+		return err
+	}
+	if err := <-uploadErr; err != nil {
+		return err
+	}
+
+	tr.LazyPrintf("stored %d results", results.matchCount)
+	state, err := h.store.UpdateProgress(ctx, job.ID, results.matchCount)
+	if err != nil {
+		return err
+	}
+	if state != "processing" {
+		// The job was canceled while its results were uploaded.
+		h.deleteResults(ctx, job.ID)
+	}
+	return nil
+}
+
+// deleteResults deletes the results of a search job that was canceled. The
+// results expire in the upload store if they fail to be deleted.
+func (h *searchJobHandler) deleteResults(ctx context.Context, id int64) {
+	if err := h.uploadStore.Delete(ctx, database.SearchJobResultsKey(id)); err != nil {
+		log15.Warn("failed to delete results of canceled search job", "id", id, "err", err)
+	}
+}
+
+// searchJobResults encodes the results streamed by a search job into the
+// upload of its results.
+type searchJobResults struct {
+	cancel context.CancelFunc
+	pw     *io.PipeWriter
+
+	mu         sync.Mutex
+	zw         *gzip.Writer
+	enc        *json.Encoder
+	matchCount int
+	err        error
+}
+
+func (r *searchJobResults) Send(event streaming.SearchEvent) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err != nil {
+		return
+	}
+
+	for _, row := range export.Rows(event.Results) {
+		if r.err = r.enc.Encode(row); r.err != nil {
+			r.cancel()
+			return
+		}
+		r.matchCount++
+	}
+}
+
+func (r *searchJobResults) count() int {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.matchCount
+}
+
+// close finishes the upload of the encoded results, or aborts it if the search
+// failed with searchErr. It returns the first error that occurred.
+func (r *searchJobResults) close(searchErr error) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.err == nil {
+		r.err = searchErr
+	}
+	if r.err == nil {
+		r.err = r.zw.Close()
+	}
+	// Closing the pipe with a nil error ends the upload successfully.
+	r.pw.CloseWithError(r.err)
+	return r.err
+}
@@ -0,0 +1,168 @@
+package searchjobs
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"errors"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/cmd/frontend/graphqlbackend"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/search/streaming"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore/mocks"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type fakeSearchJobStore struct {
+	mu  sync.Mutex
+	job *types.SearchJob
+}
+
+func (s *fakeSearchJobStore) UpdateProgress(_ context.Context, _ int64, matchCount int) (string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.job.MatchCount = matchCount
+	return s.job.State, nil
+}
+
+func (s *fakeSearchJobStore) setState(state string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.job.State = state
+}
+
+// streamingSearchResolver streams its matches, one per event, and blocks
+// until it is canceled if block is set.
+type streamingSearchResolver struct {
+	stream  streaming.Sender
+	matches []result.Match
+	block   bool
+}
+
+func (r *streamingSearchResolver) Results(ctx context.Context) (*graphqlbackend.SearchResultsResolver, error) {
+	for _, m := range r.matches {
+		r.stream.Send(streaming.SearchEvent{Results: []result.Match{m}})
+	}
+	if r.block {
+		<-ctx.Done()
+		return nil, ctx.Err()
+	}
+	return &graphqlbackend.SearchResultsResolver{
+		UserSettings:  &schema.Settings{},
+		SearchResults: &graphqlbackend.SearchResults{},
+	}, nil
+}
+
+func TestSearchJobHandler(t *testing.T) {
+	matches := []result.Match{
+		&result.FileMatch{
+			File: result.File{
+				Repo:     types.RepoName{ID: 1, Name: "github.com/foo/bar"},
+				CommitID: "deadbeef",
+				Path:     "main.go",
+			},
+			LineMatches: []*result.LineMatch{
+				{Preview: "func main() {", LineNumber: 2},
+				{Preview: `	fmt.Println("a, b")`, LineNumber: 3},
+			},
+		},
+		&result.RepoMatch{ID: 2, Name: api.RepoName("github.com/foo/baz")},
+	}
+
+	newStore := func() *fakeSearchJobStore {
+		return &fakeSearchJobStore{job: &types.SearchJob{ID: 1, UserID: 7, Query: "foo", State: "processing"}}
+	}
+	newHandler := func(store *fakeSearchJobStore, uploadStore *mocks.MockStore, block bool) *searchJobHandler {
+		return &searchJobHandler{
+			store:       store,
+			uploadStore: uploadStore,
+			newSearchResolver: func(ctx context.Context, _ dbutil.DB, args *graphqlbackend.SearchArgs) (searchResolver, error) {
+				if !args.Exhaustive {
+					t.Error("search job is not exhaustive")
+				}
+				if uid := actor.FromContext(ctx).UID; uid != 7 {
+					t.Errorf("search job runs as user %d, want 7", uid)
+				}
+				return &streamingSearchResolver{stream: args.Stream, matches: matches, block: block}, nil
+			},
+			progressInterval: time.Millisecond,
+		}
+	}
+
+	t.Run("stores results", func(t *testing.T) {
+		var uploaded bytes.Buffer
+		uploadStore := mocks.NewMockStore()
+		uploadStore.UploadFunc.SetDefaultHook(func(_ context.Context, key string, r io.Reader) (int64, error) {
+			if want := database.SearchJobResultsKey(1); key != want {
+				t.Errorf("got key %q, want %q", key, want)
+			}
+			return io.Copy(&uploaded, r)
+		})
+
+		store := newStore()
+		if err := newHandler(store, uploadStore, false).Handle(context.Background(), searchJobRecord{SearchJob: store.job}); err != nil {
+			t.Fatal(err)
+		}
+		if got := store.job.MatchCount; got != 3 {
+			t.Errorf("got match count %d, want 3", got)
+		}
+
+		zr, err := gzip.NewReader(&uploaded)
+		if err != nil {
+			t.Fatal(err)
+		}
+		got, err := io.ReadAll(zr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		want := `{"repository":"github.com/foo/bar","commit":"deadbeef","path":"main.go","line":3,"match":"func main() {"}
+{"repository":"github.com/foo/bar","commit":"deadbeef","path":"main.go","line":4,"match":"\tfmt.Println(\"a, b\")"}
+{"repository":"github.com/foo/baz","match":"github.com/foo/baz"}
+`
+		if diff := cmp.Diff(want, string(got)); diff != "" {
+			t.Fatalf("unexpected results (-want +got):\n%s", diff)
+		}
+	})
+
+	t.Run("stops canceled jobs", func(t *testing.T) {
+		uploadStore := mocks.NewMockStore()
+		uploadStore.UploadFunc.SetDefaultHook(func(_ context.Context, _ string, r io.Reader) (int64, error) {
+			return io.Copy(io.Discard, r)
+		})
+
+		store := newStore()
+		go func() {
+			time.Sleep(10 * time.Millisecond)
+			store.setState(database.SearchJobStateCanceled)
+		}()
+		if err := newHandler(store, uploadStore, true).Handle(context.Background(), searchJobRecord{SearchJob: store.job}); err != nil {
+			t.Fatal(err)
+		}
+		if calls := uploadStore.DeleteFunc.History(); len(calls) != 1 || calls[0].Arg1 != database.SearchJobResultsKey(1) {
+			t.Fatalf("unexpected deletes of the results of the canceled search job: %+v", calls)
+		}
+	})
+
+	t.Run("fails jobs whose results fail to upload", func(t *testing.T) {
+		uploadErr := errors.New("bucket unavailable")
+		uploadStore := mocks.NewMockStore()
+		uploadStore.UploadFunc.SetDefaultReturn(0, uploadErr)
+
+		store := newStore()
+		err := newHandler(store, uploadStore, false).Handle(context.Background(), searchJobRecord{SearchJob: store.job})
+		if !errors.Is(err, uploadErr) {
+			t.Fatalf("got error %v, want %v", err, uploadErr)
+		}
+	})
+}
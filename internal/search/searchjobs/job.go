@@ -0,0 +1,187 @@
+// Package searchjobs implements the worker job which runs search jobs
+// exhaustively and stores their results in the upload store.
+package searchjobs
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+	"github.com/keegancsmith/sqlf"
+	"github.com/opentracing/opentracing-go"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/basestore"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/observation"
+	"github.com/sourcegraph/sourcegraph/internal/trace"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/uploadstore"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker"
+	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
+)
+
+// searchJobProgressInterval is how often a running search job records the
+// number of results it found so far, and checks whether it was canceled.
+const searchJobProgressInterval = 5 * time.Second
+
+type config struct {
+	env.BaseConfig
+
+	UploadStoreConfig *uploadstore.Config
+}
+
+var configInst = &config{}
+
+func (c *config) Load() {
+	c.UploadStoreConfig = &uploadstore.Config{}
+	c.UploadStoreConfig.Load()
+}
+
+func (c *config) Validate() error {
+	if err := c.BaseConfig.Validate(); err != nil {
+		return err
+	}
+	return c.UploadStoreConfig.Validate()
+}
+
+// NewSearchJobsJob returns a worker job that runs search jobs, resets stalled
+// search jobs and deletes old search jobs together with their results.
+func NewSearchJobsJob() shared.Job {
+	return &searchJobsJob{}
+}
+
+type searchJobsJob struct{}
+
+func (j *searchJobsJob) Config() []env.Config {
+	return []env.Config{configInst}
+}
+
+func (j *searchJobsJob) Routines(_ context.Context) ([]goroutine.BackgroundRoutine, error) {
+	observationContext := &observation.Context{
+		Logger:     log15.Root(),
+		Tracer:     &trace.Tracer{Tracer: opentracing.GlobalTracer()},
+		Registerer: prometheus.DefaultRegisterer,
+	}
+
+	db, err := shared.InitDatabase()
+	if err != nil {
+		return nil, err
+	}
+
+	uploadStore, err := uploadstore.CreateLazy(context.Background(), configInst.UploadStoreConfig.SearchJobsConfig(database.SearchJobRetention), observationContext)
+	if err != nil {
+		return nil, errors.Wrap(err, "creating upload store")
+	}
+
+	dbHandle := basestore.NewHandleWithDB(db, sql.TxOptions{
+		// Change the isolation level for every transaction created by the worker
+		// so that multiple workers can modify the same rows without conflicts.
+		Isolation: sql.LevelReadCommitted,
+	})
+
+	workerStore := store.New(dbHandle, store.Options{
+		Name:              "search_job_worker_store",
+		TableName:         "search_jobs",
+		Scan:              scanSearchJobRecord,
+		OrderByExpression: sqlf.Sprintf("search_jobs.created_at"),
+		ColumnExpressions: database.SearchJobColumns,
+		StalledMaxAge:     time.Minute,
+		MaxNumResets:      3,
+		MaxNumRetries:     1,
+	})
+
+	handler := &searchJobHandler{
+		store:             database.SearchJobs(db),
+		uploadStore:       uploadStore,
+		db:                db,
+		newSearchResolver: defaultNewSearchResolver,
+		progressInterval:  searchJobProgressInterval,
+	}
+
+	// Pass a fresh context, see docs for shared.Job
+	ctx := context.Background()
+
+	worker := dbworker.NewWorker(ctx, workerStore, handler, workerutil.WorkerOptions{
+		Name:              "search_job_worker",
+		NumHandlers:       1,
+		Interval:          5 * time.Second,
+		HeartbeatInterval: 15 * time.Second,
+		Metrics:           workerutil.NewMetrics(observationContext, "search_job_worker", nil),
+	})
+
+	resetter := dbworker.NewResetter(workerStore, dbworker.ResetterOptions{
+		Name:     "search_job_worker_resetter",
+		Interval: 5 * time.Minute,
+		Metrics: dbworker.ResetterMetrics{
+			RecordResets: promauto.NewCounter(prometheus.CounterOpts{
+				Name: "src_search_job_queue_resets_total",
+				Help: "Total number of search jobs put back into queued state",
+			}),
+			RecordResetFailures: promauto.NewCounter(prometheus.CounterOpts{
+				Name: "src_search_job_queue_max_resets_total",
+				Help: "Total number of search jobs that exceed the max number of resets",
+			}),
+			Errors: promauto.NewCounter(prometheus.CounterOpts{
+				Name: "src_search_job_queue_reset_errors_total",
+				Help: "Total number of errors when running the search job resetter",
+			}),
+		},
+	})
+
+	janitor := goroutine.NewPeriodicGoroutine(ctx, time.Hour, goroutine.NewHandlerWithErrorMessage(
+		"delete old search jobs",
+		func(ctx context.Context) error {
+			return deleteOldSearchJobs(ctx, database.SearchJobs(db), uploadStore, time.Now().Add(-database.SearchJobRetention))
+		},
+	))
+
+	return []goroutine.BackgroundRoutine{worker, resetter, janitor}, nil
+}
+
+// deleteOldSearchJobs deletes the search jobs which finished before the given
+// time, and their results. Results which fail to be deleted expire in the
+// upload store on their own.
+func deleteOldSearchJobs(ctx context.Context, store *database.SearchJobStore, uploadStore uploadstore.Store, before time.Time) error {
+	ids, err := store.DeleteFinishedBefore(ctx, before)
+	if err != nil {
+		return err
+	}
+	for _, id := range ids {
+		if err := uploadStore.Delete(ctx, database.SearchJobResultsKey(id)); err != nil {
+			log15.Warn("failed to delete search job results", "id", id, "err", err)
+		}
+	}
+	return nil
+}
+
+// searchJobRecord is a search job dequeued by the search job worker.
+type searchJobRecord struct {
+	*types.SearchJob
+}
+
+// RecordID implements workerutil.Record.
+func (r searchJobRecord) RecordID() int { return int(r.ID) }
+
+func scanSearchJobRecord(rows *sql.Rows, queryErr error) (_ workerutil.Record, _ bool, err error) {
+	if queryErr != nil {
+		return nil, false, queryErr
+	}
+	defer func() { err = basestore.CloseRows(rows, err) }()
+
+	if !rows.Next() {
+		return nil, false, nil
+	}
+	job, err := database.ScanSearchJob(rows)
+	if err != nil {
+		return nil, false, err
+	}
+	return searchJobRecord{SearchJob: job}, true, nil
+}
@@ -0,0 +1,140 @@
+package streaming
+
+import (
+	"sort"
+
+	"github.com/sourcegraph/sourcegraph/internal/inventory"
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// AggregationDimension is the property of a match by which an Aggregation groups
+// matches.
+type AggregationDimension string
+
+const (
+	AggregateByRepo     AggregationDimension = "REPO"
+	AggregateByAuthor   AggregationDimension = "AUTHOR"
+	AggregateByLanguage AggregationDimension = "LANGUAGE"
+	AggregateByPath     AggregationDimension = "PATH"
+)
+
+// Aggregation counts the matches of a search grouped by a dimension. It is
+// updated with each event of a search, so it can be evaluated at any point of a
+// stream of results. The counts are exact only if the search sent all matching
+// results, which is reported by AggregationResult.IsExact.
+type Aggregation struct {
+	Dimension AggregationDimension
+
+	counts       map[string]int
+	unattributed int
+	partial      bool
+}
+
+// AggregationGroup is the number of matches with the same value of the
+// aggregated dimension.
+type AggregationGroup struct {
+	Label string
+	Count int
+}
+
+// AggregationResult is the computed state of an Aggregation.
+type AggregationResult struct {
+	// Groups are the groups with the highest counts, in descending order of
+	// count.
+	Groups []AggregationGroup
+
+	// OtherGroupCount is the number of groups left out of Groups because of
+	// the limit, and OtherCount is the number of matches in them.
+	OtherGroupCount int
+	OtherCount      int
+
+	// UnattributedCount is the number of matches that have no value for the
+	// dimension, such as repository matches when aggregating by path.
+	UnattributedCount int
+
+	// IsExact is false if the search did not send all matching results, for
+	// example because it hit a limit or repositories timed out.
+	IsExact bool
+}
+
+// Update internal state for the results in event.
+func (a *Aggregation) Update(event SearchEvent) {
+	// Initialize state on first call.
+	if a.counts == nil {
+		a.counts = map[string]int{}
+	}
+
+	if event.Stats.IsLimitHit || event.Stats.Status.Any(search.RepoStatusLimitHit|search.RepoStatusTimedout) {
+		a.partial = true
+	}
+
+	for _, match := range event.Results {
+		if fm, ok := match.(*result.FileMatch); ok && fm.LimitHit {
+			a.partial = true
+		}
+
+		if label, ok := a.label(match); ok {
+			a.counts[label] += match.ResultCount()
+		} else {
+			a.unattributed += match.ResultCount()
+		}
+	}
+}
+
+// label returns the value of the aggregated dimension for the given match.
+func (a *Aggregation) label(match result.Match) (string, bool) {
+	switch a.Dimension {
+	case AggregateByRepo:
+		return string(match.RepoName().Name), true
+
+	case AggregateByAuthor:
+		if cm, ok := match.(*result.CommitMatch); ok {
+			return cm.Commit.Author.Name, true
+		}
+
+	case AggregateByLanguage:
+		if fm, ok := match.(*result.FileMatch); ok {
+			if language, _ := inventory.GetLanguageByFilename(fm.Path); language != "" {
+				return language, true
+			}
+		}
+
+	case AggregateByPath:
+		if fm, ok := match.(*result.FileMatch); ok {
+			return fm.Path, true
+		}
+	}
+
+	return "", false
+}
+
+// Compute returns the limit groups with the highest counts, based on the events
+// passed to Update. Groups with the same count are ordered by label.
+func (a *Aggregation) Compute(limit int) AggregationResult {
+	groups := make([]AggregationGroup, 0, len(a.counts))
+	for label, count := range a.counts {
+		groups = append(groups, AggregationGroup{Label: label, Count: count})
+	}
+	sort.Slice(groups, func(i, j int) bool {
+		if groups[i].Count != groups[j].Count {
+			return groups[i].Count > groups[j].Count
+		}
+		return groups[i].Label < groups[j].Label
+	})
+
+	r := AggregationResult{
+		UnattributedCount: a.unattributed,
+		IsExact:           !a.partial,
+	}
+	if limit >= 0 && len(groups) > limit {
+		for _, g := range groups[limit:] {
+			r.OtherGroupCount++
+			r.OtherCount += g.Count
+		}
+		groups = groups[:limit]
+	}
+	r.Groups = groups
+
+	return r
+}
@@ -0,0 +1,121 @@
+package streaming
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/search"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+	"github.com/sourcegraph/sourcegraph/internal/vcs/git"
+)
+
+func TestAggregation(t *testing.T) {
+	repoA := types.RepoName{ID: 1, Name: "a"}
+	repoB := types.RepoName{ID: 2, Name: "b"}
+
+	fileMatch := func(repo types.RepoName, path string, lines int) *result.FileMatch {
+		fm := &result.FileMatch{File: result.File{Repo: repo, Path: path}}
+		for i := 0; i < lines; i++ {
+			fm.LineMatches = append(fm.LineMatches, &result.LineMatch{OffsetAndLengths: [][2]int32{{0, 1}}})
+		}
+		return fm
+	}
+	commitMatch := func(repo types.RepoName, author string) *result.CommitMatch {
+		return &result.CommitMatch{Repo: repo, Commit: git.Commit{Author: git.Signature{Name: author}}}
+	}
+
+	events := []SearchEvent{
+		{Results: []result.Match{
+			fileMatch(repoA, "main.go", 3),
+			fileMatch(repoA, "README", 1),
+			commitMatch(repoB, "alice"),
+		}},
+		{Results: []result.Match{
+			fileMatch(repoB, "main.go", 2),
+			fileMatch(repoB, "lib.ts", 1),
+			commitMatch(repoB, "bob"),
+			commitMatch(repoA, "alice"),
+			&result.RepoMatch{Name: "b", ID: 2},
+		}},
+	}
+
+	for _, tc := range []struct {
+		dimension AggregationDimension
+		limit     int
+		want      AggregationResult
+	}{
+		{
+			dimension: AggregateByRepo,
+			limit:     10,
+			want: AggregationResult{
+				Groups:  []AggregationGroup{{"b", 6}, {"a", 5}},
+				IsExact: true,
+			},
+		},
+		{
+			dimension: AggregateByAuthor,
+			limit:     10,
+			want: AggregationResult{
+				Groups:            []AggregationGroup{{"alice", 2}, {"bob", 1}},
+				UnattributedCount: 8,
+				IsExact:           true,
+			},
+		},
+		{
+			dimension: AggregateByLanguage,
+			limit:     10,
+			want: AggregationResult{
+				Groups:            []AggregationGroup{{"Go", 5}, {"TypeScript", 1}},
+				UnattributedCount: 5,
+				IsExact:           true,
+			},
+		},
+		{
+			dimension: AggregateByPath,
+			limit:     1,
+			want: AggregationResult{
+				Groups:            []AggregationGroup{{"main.go", 5}},
+				OtherGroupCount:   2,
+				OtherCount:        2,
+				UnattributedCount: 4,
+				IsExact:           true,
+			},
+		},
+	} {
+		t.Run(string(tc.dimension), func(t *testing.T) {
+			a := Aggregation{Dimension: tc.dimension}
+			for _, event := range events {
+				a.Update(event)
+			}
+
+			if diff := cmp.Diff(tc.want, a.Compute(tc.limit)); diff != "" {
+				t.Errorf("unexpected aggregation (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestAggregationPartial(t *testing.T) {
+	for name, event := range map[string]SearchEvent{
+		"limit hit": {Stats: Stats{IsLimitHit: true}},
+		"timed out": {Stats: Stats{Status: search.RepoStatusSingleton(1, search.RepoStatusTimedout)}},
+		"file limit hit": {Results: []result.Match{
+			&result.FileMatch{File: result.File{Path: "main.go"}, LimitHit: true},
+		}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			a := Aggregation{Dimension: AggregateByPath}
+			a.Update(SearchEvent{Results: []result.Match{&result.FileMatch{File: result.File{Path: "a.go"}}}})
+			if !a.Compute(10).IsExact {
+				t.Fatal("want exact aggregation before partial event")
+			}
+
+			a.Update(event)
+			if a.Compute(10).IsExact {
+				t.Error("want inexact aggregation after partial event")
+			}
+		})
+	}
+}
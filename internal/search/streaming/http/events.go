@@ -24,6 +24,10 @@ type EventContentMatch struct {
 	Branches   []string `json:"branches,omitempty"`
 	Version    string   `json:"version,omitempty"`
 
+	// DuplicateForks is the number of forks with an identical match which
+	// were grouped into this match by dedupe:forks.
+	DuplicateForks int `json:"duplicateForks,omitempty"`
+
 	LineMatches []EventLineMatch `json:"lineMatches"`
 }
 
@@ -42,6 +46,8 @@ type EventPathMatch struct {
 	RepoStars  int      `json:"repoStars,omitempty"`
 	Branches   []string `json:"branches,omitempty"`
 	Version    string   `json:"version,omitempty"`
+
+	DuplicateForks int `json:"duplicateForks,omitempty"`
 }
 
 func (e *EventPathMatch) eventMatch() {}
@@ -79,6 +85,8 @@ type EventSymbolMatch struct {
 	Branches   []string `json:"branches,omitempty"`
 	Version    string   `json:"version,omitempty"`
 
+	DuplicateForks int `json:"duplicateForks,omitempty"`
+
 	Symbols []Symbol `json:"symbols"`
 }
 
@@ -127,6 +135,14 @@ type EventAlert struct {
 	Title           string          `json:"title"`
 	Description     string          `json:"description,omitempty"`
 	ProposedQueries []ProposedQuery `json:"proposedQueries"`
+
+	// DismissKey is the key with which the user dismisses the alert in the
+	// search.dismissedAlerts setting. Empty if the alert is not dismissible.
+	DismissKey string `json:"dismissKey,omitempty"`
+
+	// Notices are alerts which are less important than this alert, but also
+	// apply to the search.
+	Notices []EventAlert `json:"notices,omitempty"`
 }
 
 // ProposedQuery is a suggested query to run when we emit an alert.
@@ -0,0 +1,73 @@
+package streaming
+
+import (
+	"context"
+
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+)
+
+// WithSubRepoPermissionsFilter returns a child Stream of parent that removes
+// all matches the actor in ctx is not allowed to view according to
+// sub-repository permissions.
+func WithSubRepoPermissionsFilter(ctx context.Context, parent Sender, checker authz.SubRepoPermissionChecker) Sender {
+	if checker == nil || !checker.Enabled() {
+		return parent
+	}
+
+	return StreamFunc(func(e SearchEvent) {
+		filtered, err := FilterSubRepoPermissions(ctx, checker, e.Results)
+		if err != nil {
+			// 🚨 SECURITY: Fail closed, we don't know which matches are
+			// visible to the actor.
+			log15.Error("streaming.WithSubRepoPermissionsFilter", "error", err)
+			filtered = nil
+		}
+		e.Results = filtered
+		parent.Send(e)
+	})
+}
+
+// FilterSubRepoPermissions returns the subset of matches that the actor in ctx
+// is allowed to view according to sub-repository permissions.
+//
+// File matches are checked against their path. Commit and diff matches are
+// dropped entirely for repositories the actor can only partially view, since
+// they may reference any path of the repository.
+func FilterSubRepoPermissions(ctx context.Context, checker authz.SubRepoPermissionChecker, matches []result.Match) ([]result.Match, error) {
+	if checker == nil || !checker.Enabled() {
+		return matches, nil
+	}
+
+	a := actor.FromContext(ctx)
+	if a.Internal {
+		return matches, nil
+	}
+
+	filtered := matches[:0]
+	for _, m := range matches {
+		switch v := m.(type) {
+		case *result.FileMatch:
+			ok, err := authz.FilterActorPath(ctx, checker, a, v.Repo.Name, v.Path)
+			if err != nil {
+				return nil, err
+			}
+			if !ok {
+				continue
+			}
+		case *result.CommitMatch:
+			restricted, err := checker.EnabledForRepo(ctx, a.UID, v.Repo.Name)
+			if err != nil {
+				return nil, err
+			}
+			if restricted {
+				continue
+			}
+		}
+		filtered = append(filtered, m)
+	}
+	return filtered, nil
+}
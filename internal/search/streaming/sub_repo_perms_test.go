@@ -0,0 +1,68 @@
+package streaming
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+	"github.com/sourcegraph/sourcegraph/internal/search/result"
+	"github.com/sourcegraph/sourcegraph/internal/types"
+)
+
+// restrictedRepoChecker only allows reading paths under "public/" in the
+// repository "restricted".
+type restrictedRepoChecker struct{}
+
+func (restrictedRepoChecker) Permissions(ctx context.Context, userID int32, content authz.RepoContent) (authz.Perms, error) {
+	if content.Repo != "restricted" || strings.HasPrefix(content.Path, "public/") {
+		return authz.Read, nil
+	}
+	return authz.None, nil
+}
+
+func (restrictedRepoChecker) Enabled() bool { return true }
+
+func (restrictedRepoChecker) EnabledForRepo(ctx context.Context, userID int32, repo api.RepoName) (bool, error) {
+	return repo == "restricted", nil
+}
+
+func TestFilterSubRepoPermissions(t *testing.T) {
+	fileMatch := func(repo, path string) *result.FileMatch {
+		return &result.FileMatch{File: result.File{Repo: types.RepoName{Name: api.RepoName(repo)}, Path: path}}
+	}
+	commitMatch := func(repo string) *result.CommitMatch {
+		return &result.CommitMatch{Repo: types.RepoName{Name: api.RepoName(repo)}}
+	}
+
+	matches := []result.Match{
+		fileMatch("restricted", "public/a.go"),
+		fileMatch("restricted", "private/b.go"),
+		fileMatch("other", "private/b.go"),
+		commitMatch("restricted"),
+		commitMatch("other"),
+		&result.RepoMatch{Name: "restricted"},
+	}
+
+	ctx := actor.WithActor(context.Background(), actor.FromUser(1))
+	have, err := FilterSubRepoPermissions(ctx, restrictedRepoChecker{}, append([]result.Match(nil), matches...))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	var keys []result.Key
+	for _, m := range have {
+		keys = append(keys, m.Key())
+	}
+	want := []result.Match{matches[0], matches[2], matches[4], matches[5]}
+	if len(have) != len(want) {
+		t.Fatalf("unexpected matches: %+v", keys)
+	}
+	for i := range want {
+		if have[i] != want[i] {
+			t.Fatalf("unexpected match at %d: %+v", i, keys[i])
+		}
+	}
+}
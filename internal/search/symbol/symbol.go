@@ -11,6 +11,7 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/google/zoekt"
 	zoektquery "github.com/google/zoekt/query"
+	"github.com/inconshreveable/log15"
 	"github.com/neelance/parallel"
 	"github.com/opentracing/opentracing-go/ext"
 	otlog "github.com/opentracing/opentracing-go/log"
@@ -66,6 +67,8 @@ func Search(ctx context.Context, args *search.TextParameters, limit int, stream
 	ctx, stream, cancel := streaming.WithLimit(ctx, stream, limit)
 	defer cancel()
 
+	args, precise := partitionPreciseRepos(ctx, args, repos)
+
 	indexed, err := zoektutil.NewIndexedSearchRequest(ctx, args, zoektutil.SymbolRequest, stream)
 	if err != nil {
 		return err
@@ -88,7 +91,8 @@ func Search(ctx context.Context, args *search.TextParameters, limit int, stream
 		}
 	})
 
-	for _, repoRevs := range indexed.Unindexed {
+	// precise is owned by this function, so it is safe to append to it.
+	for _, repoRevs := range append(precise, indexed.Unindexed...) {
 		repoRevs := repoRevs
 		if ctx.Err() != nil {
 			break
@@ -120,6 +124,41 @@ func Search(ctx context.Context, args *search.TextParameters, limit int, stream
 	return run.Wait()
 }
 
+// partitionPreciseRepos removes the repositories with precise code intelligence data
+// from args and returns them separately. Symbols for these repositories are listed
+// via backend.Symbols instead of zoekt so that precise symbols are used when available.
+func partitionPreciseRepos(ctx context.Context, args *search.TextParameters, repos []*search.RepositoryRevisions) (*search.TextParameters, []*search.RepositoryRevisions) {
+	if backend.PreciseSymbols == nil || len(repos) == 0 {
+		return args, nil
+	}
+
+	repoIDs := make([]api.RepoID, 0, len(repos))
+	for _, repoRevs := range repos {
+		repoIDs = append(repoIDs, repoRevs.Repo.ID)
+	}
+	withPrecise, err := backend.PreciseSymbols.ReposWithPreciseSymbols(ctx, repoIDs)
+	if err != nil {
+		log15.Warn("Failed to determine repositories with precise symbols.", "err", err)
+		return args, nil
+	}
+	if len(withPrecise) == 0 {
+		return args, nil
+	}
+
+	var rest, precise []*search.RepositoryRevisions
+	for _, repoRevs := range repos {
+		if _, ok := withPrecise[repoRevs.Repo.ID]; ok {
+			precise = append(precise, repoRevs)
+		} else {
+			rest = append(rest, repoRevs)
+		}
+	}
+
+	argsCopy := *args
+	argsCopy.RepoPromise = (&search.RepoPromise{}).Resolve(rest)
+	return &argsCopy, precise
+}
+
 func searchInRepo(ctx context.Context, repoRevs *search.RepositoryRevisions, patternInfo *search.TextPatternInfo, limit int) (res []result.Match, err error) {
 	span, ctx := ot.StartSpanFromContext(ctx, "Search symbols in repo")
 	defer func() {
@@ -262,6 +262,7 @@ type RepoOptions struct {
 	NoArchived         bool
 	OnlyArchived       bool
 	CommitAfter        string
+	HasMeta            []string
 	OnlyPrivate        bool
 	OnlyPublic         bool
 	Ranked             bool // Return results ordered by rank
@@ -294,6 +295,9 @@ func (op *RepoOptions) String() string {
 	if op.CommitAfter != "" {
 		_, _ = fmt.Fprintf(&b, " CommitAfter=%q", op.CommitAfter)
 	}
+	if len(op.HasMeta) > 0 {
+		_, _ = fmt.Fprintf(&b, " HasMeta=%v", op.HasMeta)
+	}
 
 	if op.NoForks {
 		b.WriteString(" NoForks")
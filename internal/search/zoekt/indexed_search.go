@@ -65,7 +65,7 @@ func (rb *IndexedRepoRevs) add(reporev *search.RepositoryRevisions, repo *zoekt.
 	}
 
 	if !reporev.OnlyExplicit() {
-		// Contains a RefGlob or ExcludeRefGlob so we can't do indexed
+		// Contains a ref glob or ref date filter so we can't do indexed
 		// search on it.
 		//
 		// TODO we could only process the explicit revs and return the non
@@ -249,10 +249,16 @@ func NewIndexedSearchRequest(ctx context.Context, args *search.TextParameters, t
 	}
 
 	// Only include indexes with symbol information if a symbol request.
+	// Repositories with corrupt shards are searched by searcher until they
+	// are reindexed, since zoekt would only return partial results.
 	var filter func(repo *zoekt.Repository) bool
 	if typ == SymbolRequest {
 		filter = func(repo *zoekt.Repository) bool {
-			return repo.HasSymbols
+			return repo.HasSymbols && !backend.CorruptShards.IsCorrupt(api.RepoID(repo.ID))
+		}
+	} else if backend.CorruptShards.Count() > 0 {
+		filter = func(repo *zoekt.Repository) bool {
+			return !backend.CorruptShards.IsCorrupt(api.RepoID(repo.ID))
 		}
 	}
 
@@ -278,6 +284,16 @@ func NewIndexedSearchRequest(ctx context.Context, args *search.TextParameters, t
 
 	tr.LogFields(log.Int("all_indexed_set.size", len(indexedSet)))
 
+	// Count the searched repositories so that frequently searched ones are
+	// reindexed first.
+	if backend.SearchTraffic.Enabled() {
+		ids := make([]api.RepoID, 0, len(repos))
+		for _, r := range repos {
+			ids = append(ids, r.Repo.ID)
+		}
+		backend.SearchTraffic.Record(ids)
+	}
+
 	// Split based on indexed vs unindexed
 	indexed, searcherRepos := zoektIndexedRepos(indexedSet, repos, filter)
 
@@ -286,6 +302,29 @@ func NewIndexedSearchRequest(ctx context.Context, args *search.TextParameters, t
 		log.Int("searcher_repos.size", len(searcherRepos)),
 	)
 
+	// Queue unindexed repositories for indexing if they are indexed on
+	// demand. Meanwhile they are searched by searcher. Searched indexed
+	// repositories are touched so that the least recently searched
+	// repositories are evicted first.
+	if backend.OnDemand.Enabled() {
+		names := make([]string, 0, len(searcherRepos))
+		for _, r := range searcherRepos {
+			names = append(names, string(r.Repo.Name))
+		}
+		touched := make([]string, 0, len(indexed.repoRevs))
+		for name := range indexed.repoRevs {
+			touched = append(touched, name)
+		}
+		go func() {
+			if err := backend.OnDemand.Touch(touched); err != nil {
+				log15.Warn("failed to touch repositories indexed on demand", "error", err)
+			}
+			if err := backend.OnDemand.Enqueue(names); err != nil {
+				log15.Warn("failed to queue repositories for on-demand indexing", "error", err)
+			}
+		}()
+	}
+
 	// Disable unindexed search
 	if args.PatternInfo.Index == query.Only {
 		searcherRepos = limitUnindexedRepos(searcherRepos, 0, stream)
@@ -550,6 +550,19 @@ func TestQueryToZoektQuery(t *testing.T) {
 			},
 			Query: `foo case:yes f:\.go$ f:\.yaml$ -f:\bvendor\b`,
 		},
+		{
+			Name: "path glob",
+			Type: TextRequest,
+			Pattern: &search.TextPatternInfo{
+				IsRegExp:                     true,
+				IsCaseSensitive:              false,
+				Pattern:                      "foo",
+				IncludePatterns:              []string{`^.*?/[^/]*?\.test\.ts$`}, // file:**/*.test.ts
+				ExcludePattern:               `^[^/]*?\.go$`,                     // -file:*.go
+				PathPatternsAreCaseSensitive: false,
+			},
+			Query: `foo case:no f:^.*?/[^/]*?\.test\.ts$ -f:^[^/]*?\.go$`,
+		},
 		{
 			Name: "path matches only",
 			Type: TextRequest,
@@ -11,11 +11,17 @@ import (
 	"time"
 
 	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/httpcli"
 )
 
 // Client is capable of posting a message to a Slack webhook
 type Client struct {
 	WebhookURL string
+
+	// HTTPClient is used to post messages. If nil, http.DefaultClient is
+	// used.
+	HTTPClient httpcli.Doer
 }
 
 // New creates a new Slack client
@@ -79,7 +85,11 @@ func (c *Client) Post(ctx context.Context, payload *Payload) error {
 	timeoutCtx, cancel := context.WithTimeout(ctx, time.Minute)
 	defer cancel()
 
-	resp, err := http.DefaultClient.Do(req.WithContext(timeoutCtx))
+	cli := c.HTTPClient
+	if cli == nil {
+		cli = http.DefaultClient
+	}
+	resp, err := cli.Do(req.WithContext(timeoutCtx))
 	if err != nil {
 		return errors.Wrap(err, "slack: http request")
 	}
@@ -14,6 +14,7 @@ import (
 	"path/filepath"
 	"strings"
 	"sync"
+	"syscall"
 	"time"
 
 	"github.com/cockroachdb/errors"
@@ -48,6 +49,11 @@ const maxFileSize = 1 << 20 // 1MB; match https://sourcegraph.com/search?q=repo:
 // * We touch files when opening them, so can do LRU based on file
 //   modification times.
 //
+// When the free space on the disk holding the cache drops below
+// MinDiskFreeBytes, the store backs off: it evicts enough archives to restore
+// the headroom, even if the cache is below MaxCacheSizeBytes, and it lowers
+// the number of concurrent fetches in proportion to the remaining headroom.
+//
 // Note: The store fetches tarballs but stores zips. We want to be able to
 // filter which files we cache, so we need a format that supports streaming
 // (tar). We want to be able to support random concurrent access for reading,
@@ -70,6 +76,15 @@ type Store struct {
 	// MaxCacheSizeBytes.
 	MaxCacheSizeBytes int64
 
+	// MinDiskFreeBytes is the amount of free disk space the store tries to
+	// keep available on the disk holding Path. If zero, the free disk space
+	// is not monitored.
+	MinDiskFreeBytes int64
+
+	// diskFreeBytes returns the free disk space of the disk holding Path. It
+	// is overridden in tests.
+	diskFreeBytes func(path string) (int64, error)
+
 	// once protects Start
 	once sync.Once
 
@@ -94,6 +109,9 @@ type FilterFunc func(hdr *tar.Header) bool
 func (s *Store) Start() {
 	s.once.Do(func() {
 		s.fetchLimiter = mutablelimiter.New(15)
+		if s.diskFreeBytes == nil {
+			s.diskFreeBytes = diskFreeBytes
+		}
 		s.cache = &diskcache.Store{
 			Dir:               s.Path,
 			Component:         "store",
@@ -148,9 +166,16 @@ func (s *Store) PrepareZip(ctx context.Context, repo api.RepoName, commit api.Co
 		// TODO: consider adding a cache method that doesn't actually bother opening the file,
 		// since we're just going to close it again immediately.
 		bgctx := opentracing.ContextWithSpan(context.Background(), opentracing.SpanFromContext(ctx))
+		fetched := false
 		f, err := s.cache.Open(bgctx, key, func(ctx context.Context) (io.ReadCloser, error) {
+			fetched = true
 			return s.fetch(ctx, repo, commit, largeFilePatterns)
 		})
+		if fetched {
+			cacheMisses.Inc()
+		} else if err == nil {
+			cacheHits.Inc()
+		}
 		var path string
 		if f != nil {
 			path = f.Path
@@ -344,26 +369,88 @@ func (s *Store) String() string {
 }
 
 // watchAndEvict is a loop which periodically checks the size of the cache and
-// evicts/deletes items if the store gets too large.
+// evicts/deletes items if the store gets too large or the disk runs low on
+// free space.
 func (s *Store) watchAndEvict() {
-	if s.MaxCacheSizeBytes == 0 {
+	if s.MaxCacheSizeBytes == 0 && s.MinDiskFreeBytes == 0 {
 		return
 	}
 
+	var lastCacheSize int64
 	for {
 		time.Sleep(10 * time.Second)
 
-		stats, err := s.cache.Evict(s.MaxCacheSizeBytes)
+		stats, err := s.cache.Evict(s.evictionBudget(lastCacheSize))
 		if err != nil {
 			log.Printf("failed to Evict: %s", err)
 			continue
 		}
+		lastCacheSize = stats.CacheSize
 		cacheSizeBytes.Set(float64(stats.CacheSize))
 		evictions.Add(float64(stats.Evicted))
 	}
 }
 
-// watchConfig updates fetchLimiter as the number of gitservers change.
+// evictionBudget returns the size in bytes the cache should be evicted down
+// to. It is MaxCacheSizeBytes, unless the free disk space is below
+// MinDiskFreeBytes, in which case the budget shrinks the cache by the missing
+// headroom.
+func (s *Store) evictionBudget(cacheSize int64) int64 {
+	budget := s.MaxCacheSizeBytes
+
+	if deficit := s.diskFreeDeficit(); deficit > 0 {
+		pressured := cacheSize - deficit
+		if pressured < 0 {
+			pressured = 0
+		}
+		if budget == 0 || pressured < budget {
+			budget = pressured
+		}
+	}
+
+	return budget
+}
+
+// diskFreeDeficit returns how many bytes of free disk space are missing to
+// reach MinDiskFreeBytes, or 0 if there is enough headroom or the free disk
+// space is not monitored.
+func (s *Store) diskFreeDeficit() int64 {
+	if s.MinDiskFreeBytes == 0 {
+		return 0
+	}
+
+	free, err := s.diskFreeBytes(s.Path)
+	if err != nil {
+		log15.Warn("failed to determine free disk space", "path", s.Path, "error", err)
+		return 0
+	}
+
+	if free >= s.MinDiskFreeBytes {
+		diskPressure.Set(0)
+		return 0
+	}
+	diskPressure.Set(1)
+	return s.MinDiskFreeBytes - free
+}
+
+// fetchLimit returns the number of concurrent fetches to allow given the
+// limit without disk pressure. Under disk pressure the limit is scaled down
+// in proportion to the remaining headroom, but always allows one fetch.
+func (s *Store) fetchLimit(limit int) int {
+	deficit := s.diskFreeDeficit()
+	if deficit == 0 {
+		return limit
+	}
+
+	limit = int(int64(limit) * (s.MinDiskFreeBytes - deficit) / s.MinDiskFreeBytes)
+	if limit < 1 {
+		limit = 1
+	}
+	return limit
+}
+
+// watchConfig updates fetchLimiter as the number of gitservers and the free
+// disk space change.
 func (s *Store) watchConfig() {
 	for {
 		// Allow roughly 10 fetches per gitserver
@@ -371,12 +458,24 @@ func (s *Store) watchConfig() {
 		if limit == 0 {
 			limit = 15
 		}
+		limit = s.fetchLimit(limit)
 		s.fetchLimiter.SetLimit(limit)
+		fetchLimit.Set(float64(limit))
 
 		time.Sleep(10 * time.Second)
 	}
 }
 
+// diskFreeBytes returns the free disk space available to unprivileged users
+// on the disk holding path.
+func diskFreeBytes(path string) (int64, error) {
+	var stat syscall.Statfs_t
+	if err := syscall.Statfs(path, &stat); err != nil {
+		return 0, err
+	}
+	return int64(stat.Bavail * uint64(stat.Bsize)), nil
+}
+
 // ignoreSizeMax determines whether the max size should be ignored. It uses
 // the glob syntax found here: https://golang.org/pkg/path/filepath/#Match.
 func ignoreSizeMax(name string, patterns []string) bool {
@@ -398,6 +497,22 @@ var (
 		Name: "searcher_store_evictions",
 		Help: "The total number of items evicted from the cache.",
 	})
+	cacheHits = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "searcher_store_cache_hits",
+		Help: "The total number of archives served from the on disk cache.",
+	})
+	cacheMisses = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "searcher_store_cache_misses",
+		Help: "The total number of archives that had to be fetched.",
+	})
+	diskPressure = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "searcher_store_disk_pressure",
+		Help: "1 if the free disk space is below the configured minimum, 0 otherwise.",
+	})
+	fetchLimit = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "searcher_store_fetch_limit",
+		Help: "The maximum number of concurrent fetches.",
+	})
 	fetching = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "searcher_store_fetching",
 		Help: "The number of fetches currently running.",
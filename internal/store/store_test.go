@@ -148,6 +148,50 @@ func TestIngoreSizeMax(t *testing.T) {
 	}
 }
 
+func TestDiskPressure(t *testing.T) {
+	var free int64
+	s := &Store{
+		MaxCacheSizeBytes: 1000,
+		MinDiskFreeBytes:  100,
+		diskFreeBytes:     func(string) (int64, error) { return free, nil },
+	}
+
+	tests := []struct {
+		name       string
+		free       int64
+		cacheSize  int64
+		wantBudget int64
+		wantLimit  int
+	}{
+		{name: "enough headroom", free: 500, cacheSize: 800, wantBudget: 1000, wantLimit: 20},
+		{name: "at threshold", free: 100, cacheSize: 800, wantBudget: 1000, wantLimit: 20},
+		{name: "below threshold", free: 60, cacheSize: 800, wantBudget: 760, wantLimit: 12},
+		{name: "below threshold with small cache", free: 60, cacheSize: 30, wantBudget: 0, wantLimit: 12},
+		{name: "disk full", free: 0, cacheSize: 800, wantBudget: 700, wantLimit: 1},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			free = tc.free
+			if have := s.evictionBudget(tc.cacheSize); have != tc.wantBudget {
+				t.Errorf("wrong eviction budget. want=%d, have=%d", tc.wantBudget, have)
+			}
+			if have := s.fetchLimit(20); have != tc.wantLimit {
+				t.Errorf("wrong fetch limit. want=%d, have=%d", tc.wantLimit, have)
+			}
+		})
+	}
+
+	t.Run("not monitored", func(t *testing.T) {
+		s := &Store{MaxCacheSizeBytes: 1000}
+		if have := s.evictionBudget(800); have != 1000 {
+			t.Errorf("wrong eviction budget. want=%d, have=%d", 1000, have)
+		}
+		if have := s.fetchLimit(20); have != 20 {
+			t.Errorf("wrong fetch limit. want=%d, have=%d", 20, have)
+		}
+	})
+}
+
 func tmpStore(t *testing.T) (*Store, func()) {
 	d, err := os.MkdirTemp("", "store_test")
 	if err != nil {
@@ -22,6 +22,7 @@ import (
 	"github.com/sourcegraph/sourcegraph/internal/repotrackutil"
 	"github.com/sourcegraph/sourcegraph/internal/sentry"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
+	"github.com/sourcegraph/sourcegraph/internal/trace/requestid"
 )
 
 type key int
@@ -135,13 +136,24 @@ func HTTPTraceMiddleware(next http.Handler) http.Handler {
 			log15.Error("extracting parent span failed", "error", err)
 		}
 
+		requestID := requestid.FromRequest(r)
+		ctx = requestid.WithID(ctx, requestID)
+		rw.Header().Set(requestid.Header, requestID)
+
 		// start new span
 		span, ctx := ot.StartSpanFromContext(ctx, "", ext.RPCServerOption(wireContext))
 		ext.HTTPUrl.Set(span, r.URL.String())
 		ext.HTTPMethod.Set(span, r.Method)
 		span.SetTag("http.referer", r.Header.Get("referer"))
+		span.SetTag("request_id", requestID)
 		defer span.Finish()
-		rw.Header().Set("X-Trace", SpanURL(span))
+		// Link to the trace if the request is traced, otherwise return the request ID so
+		// that users can still quote it when reporting a failed request.
+		if spanURL := SpanURL(span); !strings.HasPrefix(spanURL, "#") {
+			rw.Header().Set("X-Trace", spanURL)
+		} else {
+			rw.Header().Set("X-Trace", requestID)
+		}
 		ctx = opentracing.ContextWithSpan(ctx, span)
 
 		routeName := "unknown"
@@ -199,6 +211,7 @@ func HTTPTraceMiddleware(next http.Handler) http.Handler {
 			"url", r.URL.String(),
 			"route_name", routeName,
 			"trace", SpanURL(span),
+			"request_id", requestID,
 			"user_agent", r.UserAgent(),
 			"user", userID,
 			"x_forwarded_for", r.Header.Get("X-Forwarded-For"),
@@ -219,6 +232,7 @@ func HTTPTraceMiddleware(next http.Handler) http.Handler {
 				"method":          r.Method,
 				"url":             r.URL.String(),
 				"route_name":      routeName,
+				"request_id":      requestID,
 				"user_agent":      r.UserAgent(),
 				"user":            strconv.FormatInt(int64(userID), 10),
 				"x_forwarded_for": r.Header.Get("X-Forwarded-For"),
@@ -0,0 +1,30 @@
+package trace
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/trace/requestid"
+)
+
+func TestHTTPTraceMiddleware_requestID(t *testing.T) {
+	var got string
+	h := HTTPTraceMiddleware(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got = requestid.FromContext(r.Context())
+	}))
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, httptest.NewRequest("GET", "/", nil))
+
+	if got == "" {
+		t.Fatal("expected request ID in the request context")
+	}
+	if header := rec.Header().Get(requestid.Header); header != got {
+		t.Errorf("got %s header %q, want %q", requestid.Header, header, got)
+	}
+	// Tracing is not enabled, so X-Trace holds the request ID instead of a trace URL.
+	if header := rec.Header().Get("X-Trace"); header != got {
+		t.Errorf("got X-Trace header %q, want %q", header, got)
+	}
+}
@@ -13,6 +13,8 @@ import (
 	"github.com/opentracing-contrib/go-stdlib/nethttp"
 	"github.com/opentracing/opentracing-go"
 	"go.uber.org/atomic"
+
+	"github.com/sourcegraph/sourcegraph/internal/trace/requestid"
 )
 
 type tracePolicy string
@@ -43,6 +45,8 @@ func GetTracePolicy() tracePolicy {
 //
 // - If the HTTP header, X-Sourcegraph-Should-Trace, is set to a truthy value, set the
 //   shouldTraceKey context.Context value to true
+// - Store the request ID from the HTTP header X-Sourcegraph-Request-ID (or a new request ID if
+//   it is not set) in the context.Context, see package requestid.
 // - github.com/opentracing-contrib/go-stdlib/nethttp.Middleware, which creates a new span to track
 //   the request handler from the global tracer.
 func Middleware(h http.Handler, opts ...nethttp.MWOption) http.Handler {
@@ -56,6 +60,9 @@ func MiddlewareWithTracer(tr opentracing.Tracer, h http.Handler, opts ...nethttp
 		nethttp.MWSpanFilter(func(r *http.Request) bool {
 			return ShouldTrace(r.Context())
 		}),
+		nethttp.MWSpanObserver(func(span opentracing.Span, r *http.Request) {
+			span.SetTag("request_id", requestid.FromContext(r.Context()))
+		}),
 	}, opts...)...)
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		var trace bool
@@ -67,7 +74,9 @@ func MiddlewareWithTracer(tr opentracing.Tracer, h http.Handler, opts ...nethttp
 		default:
 			trace = false
 		}
-		nethttpMiddleware.ServeHTTP(w, r.WithContext(WithShouldTrace(r.Context(), trace)))
+		ctx := WithShouldTrace(r.Context(), trace)
+		ctx = requestid.WithID(ctx, requestid.FromRequest(r))
+		nethttpMiddleware.ServeHTTP(w, r.WithContext(ctx))
 	})
 }
 
@@ -92,13 +101,17 @@ func requestWantsTracing(r *http.Request) bool {
 }
 
 // Transport wraps an underlying HTTP RoundTripper, injecting the X-Sourcegraph-Should-Trace header
-// into outgoing requests whenever the shouldTraceKey context value is true.
+// into outgoing requests whenever the shouldTraceKey context value is true. It also propagates the
+// request ID of the context (see package requestid).
 type Transport struct {
 	http.RoundTripper
 }
 
 func (r *Transport) RoundTrip(req *http.Request) (*http.Response, error) {
 	req.Header.Set(traceHeader, strconv.FormatBool(ShouldTrace(req.Context())))
+	if id := requestid.FromContext(req.Context()); id != "" {
+		req.Header.Set(requestid.Header, id)
+	}
 	t := nethttp.Transport{RoundTripper: r.RoundTripper}
 	return t.RoundTrip(req)
 }
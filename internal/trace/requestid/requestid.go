@@ -0,0 +1,68 @@
+// Package requestid assigns an ID to each request so that the log entries,
+// traces and errors it causes can be correlated, even across services. The ID
+// is propagated across API boundaries through a HTTP header
+// (X-Sourcegraph-Request-ID).
+package requestid
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/google/uuid"
+)
+
+// Header is the HTTP header used to propagate the request ID to other
+// services, and to return it to clients.
+const Header = "X-Sourcegraph-Request-ID"
+
+// maxLength is the maximum length of a request ID accepted from a request
+// header.
+const maxLength = 64
+
+type key int
+
+const requestIDKey key = iota
+
+// New returns a new random request ID.
+func New() string {
+	return uuid.New().String()
+}
+
+// FromContext returns the request ID stored in the context, or the empty
+// string if there is none.
+func FromContext(ctx context.Context) string {
+	id, _ := ctx.Value(requestIDKey).(string)
+	return id
+}
+
+// WithID returns a context holding the given request ID.
+func WithID(ctx context.Context, id string) context.Context {
+	return context.WithValue(ctx, requestIDKey, id)
+}
+
+// FromRequest returns the request ID set in the header of r, or a new request
+// ID if the header is missing or does not hold a valid request ID.
+//
+// 🚨 SECURITY: The header may be set by untrusted clients, so only short
+// alphanumeric values (including dashes and underscores) are accepted, in
+// order to prevent log injection.
+func FromRequest(r *http.Request) string {
+	if id := r.Header.Get(Header); valid(id) {
+		return id
+	}
+	return New()
+}
+
+func valid(id string) bool {
+	if id == "" || len(id) > maxLength {
+		return false
+	}
+	for _, c := range id {
+		switch {
+		case 'a' <= c && c <= 'z', 'A' <= c && c <= 'Z', '0' <= c && c <= '9', c == '-', c == '_':
+		default:
+			return false
+		}
+	}
+	return true
+}
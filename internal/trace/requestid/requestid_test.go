@@ -0,0 +1,35 @@
+package requestid
+
+import (
+	"net/http/httptest"
+	"strings"
+	"testing"
+)
+
+func TestFromRequest(t *testing.T) {
+	for _, tc := range []struct {
+		name   string
+		header string
+		keep   bool
+	}{
+		{name: "missing", header: "", keep: false},
+		{name: "valid", header: "0b6c1f0e-7d1a-4d2b_abc", keep: true},
+		{name: "log injection", header: "abc\nlvl=crit msg=spoofed", keep: false},
+		{name: "too long", header: strings.Repeat("a", maxLength+1), keep: false},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			r := httptest.NewRequest("GET", "/", nil)
+			if tc.header != "" {
+				r.Header.Set(Header, tc.header)
+			}
+
+			got := FromRequest(r)
+			if got == "" {
+				t.Fatal("expected a request ID")
+			}
+			if kept := got == tc.header; kept != tc.keep {
+				t.Fatalf("got request ID %q for header %q, want kept=%v", got, tc.header, tc.keep)
+			}
+		})
+	}
+}
@@ -8,6 +8,7 @@ import (
 	"sync/atomic"
 
 	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
 	"github.com/keegancsmith/sqlf"
 	"github.com/opentracing/opentracing-go"
 	"github.com/opentracing/opentracing-go/ext"
@@ -15,6 +16,7 @@ import (
 	nettrace "golang.org/x/net/trace"
 
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
+	"github.com/sourcegraph/sourcegraph/internal/trace/requestid"
 )
 
 var spanURL atomic.Value
@@ -71,6 +73,9 @@ func (t Tracer) New(ctx context.Context, family, title string, tags ...Tag) (*Tr
 		family,
 		tagsOpt{title: title, tags: tags},
 	)
+	if id := requestid.FromContext(ctx); id != "" {
+		span.SetTag("request_id", id)
+	}
 	tr := nettrace.New(family, title)
 	trace := &Trace{span: span, trace: tr, family: family}
 	if parent := TraceFromContext(ctx); parent != nil {
@@ -112,9 +117,22 @@ func CopyContext(ctx context.Context, from context.Context) context.Context {
 	if shouldTrace := ot.ShouldTrace(from); shouldTrace {
 		ctx = ot.WithShouldTrace(ctx, shouldTrace)
 	}
+	if id := requestid.FromContext(from); id != "" {
+		ctx = requestid.WithID(ctx, id)
+	}
 	return ctx
 }
 
+// Logger returns a log15.Logger which includes the request ID of the given
+// context (if any) in every log entry, so that entries can be correlated with
+// the request that caused them.
+func Logger(ctx context.Context) log15.Logger {
+	if id := requestid.FromContext(ctx); id != "" {
+		return log15.Root().New("request_id", id)
+	}
+	return log15.Root()
+}
+
 // Trace is a combined version of golang.org/x/net/trace.Trace and
 // opentracing.Span. Use New to construct one.
 type Trace struct {
@@ -0,0 +1,139 @@
+package txemail
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+
+	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
+	"github.com/jordan-wright/email"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+// registeredTemplates is the built-in template for an email type, along with
+// example template data used to preview site admin overrides.
+type registeredTemplates struct {
+	templates txtypes.Templates
+	example   interface{}
+}
+
+var (
+	registryMu sync.RWMutex
+	registry   = map[string]registeredTemplates{}
+)
+
+// MustRegister validates the built-in templates for an email type and
+// registers them, along with example template data, so that site admins can
+// override (in the email.templates site configuration) and preview them. It
+// returns the templates with their Type set and panics if they are unparsable
+// or if the type is already registered. It is intended to be called in a
+// package-level var declaration.
+func MustRegister(emailType string, example interface{}, input txtypes.Templates) txtypes.Templates {
+	input.Type = emailType
+	MustValidate(input)
+
+	registryMu.Lock()
+	defer registryMu.Unlock()
+	if _, ok := registry[emailType]; ok {
+		panic(fmt.Sprintf("MustRegister: email type %q is already registered", emailType))
+	}
+	registry[emailType] = registeredTemplates{templates: input, example: example}
+	return input
+}
+
+// RegisteredTypes returns the sorted list of email types registered in this
+// process.
+func RegisteredTypes() []string {
+	registryMu.RLock()
+	defer registryMu.RUnlock()
+
+	types := make([]string, 0, len(registry))
+	for emailType := range registry {
+		types = append(types, emailType)
+	}
+	sort.Strings(types)
+	return types
+}
+
+// Preview renders the templates of a registered email type with the given
+// overrides applied, using the example data registered for that type. Unlike
+// Send, it does not fall back to the built-in templates if the overrides are
+// invalid, so that site admins can see the error.
+func Preview(emailType string, custom schema.EmailTemplate) (*email.Email, error) {
+	registryMu.RLock()
+	r, ok := registry[emailType]
+	registryMu.RUnlock()
+	if !ok {
+		return nil, errors.Errorf("unknown email type %q", emailType)
+	}
+
+	parsed, err := ParseTemplate(withOverrides(r.templates, custom))
+	if err != nil {
+		return nil, err
+	}
+
+	var m email.Email
+	if err := renderTemplate(parsed, r.example, &m); err != nil {
+		return nil, err
+	}
+	return &m, nil
+}
+
+// withOverrides returns the templates with the non-empty fields of custom
+// replacing their built-in counterparts.
+func withOverrides(input txtypes.Templates, custom schema.EmailTemplate) txtypes.Templates {
+	if custom.Subject != "" {
+		input.Subject = custom.Subject
+	}
+	if custom.Text != "" {
+		input.Text = custom.Text
+	}
+	if custom.Html != "" {
+		input.HTML = custom.Html
+	}
+	return input
+}
+
+// customTemplates returns the parsed site admin overrides for the type of the
+// given templates. It returns false if there are no overrides or if they are
+// unparsable, in which case the built-in templates should be used.
+func customTemplates(input txtypes.Templates) (*txtypes.ParsedTemplates, bool) {
+	if input.Type == "" {
+		return nil, false
+	}
+	custom, ok := conf.Get().EmailTemplates[input.Type]
+	if !ok {
+		return nil, false
+	}
+
+	parsed, err := ParseTemplate(withOverrides(input, custom))
+	if err != nil {
+		log15.Error("txemail: invalid email template override, using built-in template", "type", input.Type, "error", err)
+		return nil, false
+	}
+	return parsed, true
+}
+
+// brandName returns the brand name configured in the site configuration,
+// defaulting to "Sourcegraph".
+func brandName() string {
+	if branding := conf.Get().Branding; branding != nil && branding.BrandName != "" {
+		return branding.BrandName
+	}
+	return "Sourcegraph"
+}
+
+func init() {
+	conf.ContributeValidator(func(c conf.Unified) (problems conf.Problems) {
+		for emailType, custom := range c.EmailTemplates {
+			if _, err := ParseTemplate(withOverrides(txtypes.Templates{}, custom)); err != nil {
+				problems = append(problems, conf.NewSiteProblem(fmt.Sprintf("email.templates: invalid template for email type %q: %s", emailType, err)))
+			}
+		}
+		return problems
+	})
+}
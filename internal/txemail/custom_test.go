@@ -0,0 +1,91 @@
+package txemail
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/sourcegraph/sourcegraph/internal/conf"
+	"github.com/sourcegraph/sourcegraph/internal/txemail/txtypes"
+	"github.com/sourcegraph/sourcegraph/schema"
+)
+
+type testData struct {
+	A string
+}
+
+var testTemplates = MustRegister("test", testData{A: "example"}, txtypes.Templates{
+	Subject: `{{.A}} subject`,
+	Text:    `{{.A}} text body`,
+	HTML:    `{{.A}} html body`,
+})
+
+func TestRender_customTemplates(t *testing.T) {
+	conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+		Branding: &schema.Branding{BrandName: "Acme"},
+		EmailTemplates: map[string]schema.EmailTemplate{
+			"test": {Subject: `{{brandName}}: custom {{.A}} subject`},
+		},
+	}})
+	defer conf.Mock(nil)
+
+	got, err := render(Message{Template: testTemplates, Data: testData{A: "a"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if diff := cmp.Diff("Acme", got.From); diff != "" {
+		t.Errorf("from mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff("Acme: custom a subject", got.Subject); diff != "" {
+		t.Errorf("subject mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff("a text body", string(got.Text)); diff != "" {
+		t.Errorf("text mismatch (-want +got):\n%s", diff)
+	}
+}
+
+func TestRender_invalidCustomTemplates(t *testing.T) {
+	for name, custom := range map[string]schema.EmailTemplate{
+		"unparsable":   {Subject: `{{.A`},
+		"unrenderable": {Subject: `{{.Missing}}`},
+	} {
+		t.Run(name, func(t *testing.T) {
+			conf.Mock(&conf.Unified{SiteConfiguration: schema.SiteConfiguration{
+				EmailTemplates: map[string]schema.EmailTemplate{"test": custom},
+			}})
+			defer conf.Mock(nil)
+
+			got, err := render(Message{Template: testTemplates, Data: testData{A: "a"}})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if diff := cmp.Diff("a subject", got.Subject); diff != "" {
+				t.Errorf("subject mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
+
+func TestPreview(t *testing.T) {
+	conf.Mock(&conf.Unified{})
+	defer conf.Mock(nil)
+
+	got, err := Preview("test", schema.EmailTemplate{Html: `<b>{{.A}}</b> from {{brandName}}`})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if diff := cmp.Diff("example subject", got.Subject); diff != "" {
+		t.Errorf("subject mismatch (-want +got):\n%s", diff)
+	}
+	if diff := cmp.Diff("<b>example</b> from Sourcegraph", string(got.HTML)); diff != "" {
+		t.Errorf("html mismatch (-want +got):\n%s", diff)
+	}
+
+	if _, err := Preview("test", schema.EmailTemplate{Text: `{{.A`}); err == nil {
+		t.Error("expected error for unparsable template")
+	}
+	if _, err := Preview("unknown", schema.EmailTemplate{}); err == nil {
+		t.Error("expected error for unknown email type")
+	}
+}
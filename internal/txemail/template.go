@@ -82,6 +82,9 @@ func renderTemplate(t *txtypes.ParsedTemplates, data interface{}, m *email.Email
 
 var (
 	textFuncMap = map[string]interface{}{
+		// Returns the brand name configured in the site configuration.
+		"brandName": brandName,
+
 		// Removes HTML tags (which are valid Markdown) from the source, for display in a text-only
 		// setting.
 		"markdownToText": func(markdownSource string) string {
@@ -91,6 +94,9 @@ var (
 	}
 
 	htmlFuncMap = map[string]interface{}{
+		// Returns the brand name configured in the site configuration.
+		"brandName": brandName,
+
 		// Renders Markdown for display in an HTML email.
 		"markdownToSafeHTML": func(markdownSource string) htmltemplate.HTML {
 			unsafeHTML := gfm.Markdown([]byte(markdownSource))
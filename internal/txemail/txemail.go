@@ -11,6 +11,7 @@ import (
 	"strconv"
 
 	"github.com/cockroachdb/errors"
+	"github.com/inconshreveable/log15"
 	"github.com/jordan-wright/email"
 
 	"github.com/sourcegraph/sourcegraph/internal/conf"
@@ -33,7 +34,7 @@ type Message struct {
 func render(message Message) (*email.Email, error) {
 	m := email.Email{
 		To:      message.To,
-		From:    "Sourcegraph",
+		From:    message.FromName,
 		Headers: make(textproto.MIMEHeader),
 	}
 	if message.ReplyTo != nil {
@@ -42,8 +43,8 @@ func render(message Message) (*email.Email, error) {
 	if message.MessageID != nil {
 		m.Headers["Message-ID"] = []string{*message.MessageID}
 	}
-	if message.FromName != "" {
-		m.From = message.FromName
+	if m.From == "" {
+		m.From = brandName()
 	}
 	if len(message.References) > 0 {
 		// jordan-wright/email does not support lists, so we must build it ourself.
@@ -57,6 +58,14 @@ func render(message Message) (*email.Email, error) {
 		m.Headers["References"] = []string{refsList}
 	}
 
+	if custom, ok := customTemplates(message.Template); ok {
+		err := renderTemplate(custom, message.Data, &m)
+		if err == nil {
+			return &m, nil
+		}
+		log15.Error("txemail: failed to render email template override, using built-in template", "type", message.Template.Type, "error", err)
+	}
+
 	parsed, err := ParseTemplate(message.Template)
 	if err != nil {
 		return nil, err
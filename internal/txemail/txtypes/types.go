@@ -19,6 +19,7 @@ type Message struct {
 
 // Templates contains the text and HTML templates for an email.
 type Templates struct {
+	Type    string // email type used to look up site admin overrides (in email.templates)
 	Subject string // text/template subject template
 	Text    string // text/template text body template
 	HTML    string //  html/template HTML body template
@@ -77,6 +77,13 @@ func (e *ExternalService) RedactConfigSecrets() error {
 	if err != nil {
 		return err
 	}
+	switch cfg.(type) {
+	case *schema.GitHubConnection, *schema.GitLabConnection, *schema.BitbucketServerConnection, *schema.BitbucketCloudConnection:
+		newCfg, err = redactFetchCredentials(newCfg)
+		if err != nil {
+			return err
+		}
+	}
 	e.Config = newCfg
 	return nil
 }
@@ -154,10 +161,111 @@ func (e *ExternalService) UnredactConfig(old *ExternalService) error {
 	if err != nil {
 		return err
 	}
+	switch cfg.(type) {
+	case *schema.GitHubConnection, *schema.GitLabConnection, *schema.BitbucketServerConnection, *schema.BitbucketCloudConnection:
+		unredacted, err = unredactFetchCredentials(old.Config, unredacted)
+		if err != nil {
+			return err
+		}
+	}
 	e.Config = unredacted
 	return nil
 }
 
+// fetchCredential holds the fields of an entry of the fetchCredentials of a code
+// host connection that are needed to redact it.
+type fetchCredential struct {
+	Repos    []string `json:"repos"`
+	Username string   `json:"username"`
+	Token    string   `json:"token"`
+	SSHKey   string   `json:"sshKey"`
+}
+
+func parseFetchCredentials(buf string) ([]fetchCredential, error) {
+	var c struct {
+		FetchCredentials []fetchCredential `json:"fetchCredentials"`
+	}
+	if err := unmarshalConfig(buf, &c); err != nil {
+		return nil, err
+	}
+	return c.FetchCredentials, nil
+}
+
+// redactFetchCredentials replaces the token and SSH key of each fetchCredentials
+// entry in buf with RedactedSecret.
+func redactFetchCredentials(buf string) (string, error) {
+	creds, err := parseFetchCredentials(buf)
+	if err != nil {
+		return buf, err
+	}
+	for i, cred := range creds {
+		if cred.Token != "" {
+			if buf, err = jsonc.EditPath(buf, RedactedSecret, "fetchCredentials", i, "token"); err != nil {
+				return buf, err
+			}
+		}
+		if cred.SSHKey != "" {
+			if buf, err = jsonc.EditPath(buf, RedactedSecret, "fetchCredentials", i, "sshKey"); err != nil {
+				return buf, err
+			}
+		}
+	}
+	return buf, nil
+}
+
+// unredactFetchCredentials replaces the redacted secrets of the fetchCredentials
+// entries in new with those of the entry in old that applies to the same repos
+// with the same username, so that entries can be added, removed and reordered.
+// Secrets without such an entry stay redacted and fail validation.
+func unredactFetchCredentials(old, new string) (string, error) {
+	newCreds, err := parseFetchCredentials(new)
+	if err != nil || len(newCreds) == 0 {
+		return new, err
+	}
+	oldCreds, err := parseFetchCredentials(old)
+	if err != nil {
+		return new, err
+	}
+
+	for i, cred := range newCreds {
+		if cred.Token != RedactedSecret && cred.SSHKey != RedactedSecret {
+			continue
+		}
+		prev := matchingFetchCredential(oldCreds, cred, i)
+		if prev == nil {
+			continue
+		}
+		if cred.Token == RedactedSecret {
+			if new, err = jsonc.EditPath(new, prev.Token, "fetchCredentials", i, "token"); err != nil {
+				return new, err
+			}
+		}
+		if cred.SSHKey == RedactedSecret {
+			if new, err = jsonc.EditPath(new, prev.SSHKey, "fetchCredentials", i, "sshKey"); err != nil {
+				return new, err
+			}
+		}
+	}
+	return new, nil
+}
+
+// matchingFetchCredential returns the entry of creds with the same repos and
+// username as cred, preferring the entry at index i, or nil if there is none.
+func matchingFetchCredential(creds []fetchCredential, cred fetchCredential, i int) *fetchCredential {
+	same := func(c fetchCredential) bool {
+		return c.Username == cred.Username && strings.Join(c.Repos, "\n") == strings.Join(cred.Repos, "\n")
+	}
+	if i < len(creds) && same(creds[i]) {
+		return &creds[i]
+	}
+	for j := range creds {
+		if same(creds[j]) {
+			return &creds[j]
+		}
+	}
+	return nil
+}
+
 type jsonStringField struct {
 	path string
 	ptr  *string
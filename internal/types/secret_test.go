@@ -2,8 +2,11 @@ package types
 
 import (
 	"encoding/json"
+	"strings"
 	"testing"
 
+	"github.com/google/go-cmp/cmp"
+
 	"github.com/sourcegraph/sourcegraph/internal/extsvc"
 	"github.com/sourcegraph/sourcegraph/schema"
 )
@@ -202,3 +205,60 @@ func TestRoundTripRedactExternalServiceConfig(t *testing.T) {
 	}
 
 }
+
+func TestRoundTripRedactFetchCredentials(t *testing.T) {
+	old := `{
+		"url": "https://github.com",
+		"token": "discovery",
+		"fetchCredentials": [
+			{"repos": ["^github\\.com/a/"], "token": "a-token"},
+			{"repos": ["^github\\.com/b/"], "sshKey": "b-key"}
+		]
+	}`
+
+	svc := ExternalService{Kind: extsvc.KindGitHub, Config: old}
+	if err := svc.RedactConfigSecrets(); err != nil {
+		t.Fatal(err)
+	}
+	for _, secret := range []string{"discovery", "a-token", "b-key"} {
+		if strings.Contains(svc.Config, secret) {
+			t.Fatalf("redacted config contains secret %q:\n%s", secret, svc.Config)
+		}
+	}
+
+	// The user reorders the entries and adds one.
+	var cfg schema.GitHubConnection
+	if err := json.Unmarshal([]byte(svc.Config), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	cfg.FetchCredentials = []*schema.GitHubFetchCredential{
+		cfg.FetchCredentials[1],
+		{Repos: []string{`^github\.com/c/`}, Token: "c-token"},
+		cfg.FetchCredentials[0],
+	}
+	buf, err := json.Marshal(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	newSvc := ExternalService{Kind: extsvc.KindGitHub, Config: string(buf)}
+	if err := newSvc.UnredactConfig(&ExternalService{Kind: extsvc.KindGitHub, Config: old}); err != nil {
+		t.Fatal(err)
+	}
+
+	cfg = schema.GitHubConnection{}
+	if err := json.Unmarshal([]byte(newSvc.Config), &cfg); err != nil {
+		t.Fatal(err)
+	}
+	want := []*schema.GitHubFetchCredential{
+		{Repos: []string{`^github\.com/b/`}, SshKey: "b-key"},
+		{Repos: []string{`^github\.com/c/`}, Token: "c-token"},
+		{Repos: []string{`^github\.com/a/`}, Token: "a-token"},
+	}
+	if diff := cmp.Diff(want, cfg.FetchCredentials); diff != "" {
+		t.Errorf("unexpected fetch credentials (-want +got):\n%s", diff)
+	}
+	if cfg.Token != "discovery" {
+		t.Errorf("got token %q, want %q", cfg.Token, "discovery")
+	}
+}
@@ -434,7 +434,14 @@ type GitserverRepo struct {
 	LastExternalService int64
 	// The last error that occurred or empty if the last action was successful
 	LastError string
-	UpdatedAt time.Time
+	// Why gitserver did not clone the repo or empty if it wasn't skipped
+	CloneSkipReason string
+	// When gitserver last successfully cloned or fetched the repo, or zero if
+	// that isn't known
+	LastFetched time.Time
+	// The size of the repo on gitserver after it was last fetched
+	RepoSizeBytes int64
+	UpdatedAt     time.Time
 }
 
 // ExternalService is a connection to an external service.
@@ -453,6 +460,15 @@ type ExternalService struct {
 	CloudDefault    bool // Whether this external service is our default public service on Cloud
 }
 
+// ExternalServiceConfigRevision is a revision of the config of an external service.
+type ExternalServiceConfigRevision struct {
+	ID                int64
+	ExternalServiceID int64
+	Config            string
+	AuthorUserID      int32 // zero if the revision was created internally
+	CreatedAt         time.Time
+}
+
 // ExternalServiceSyncJob represents an sync job for an external service
 type ExternalServiceSyncJob struct {
 	ID                int64
@@ -626,6 +642,7 @@ type User struct {
 	CreatedAt             time.Time
 	UpdatedAt             time.Time
 	SiteAdmin             bool
+	SiteAuditor           bool // can view, but not change, site administration data
 	BuiltinAuth           bool
 	Tags                  []string
 	InvalidatedSessionsAt time.Time
@@ -647,6 +664,89 @@ type OrgMembership struct {
 	UpdatedAt time.Time
 }
 
+// OrgTeam is a team of an organization. Teams can be nested, and can be
+// mapped to a team on a code host to sync their members.
+type OrgTeam struct {
+	ID    int64
+	OrgID int32
+	// ParentTeamID is the ID of the parent team, or 0 for a top-level team.
+	ParentTeamID int64
+	Name         string
+	DisplayName  *string
+	// ExternalServiceID and ExternalID identify the team on the code host the
+	// members are synced from. ExternalServiceID is 0 for teams whose members
+	// are managed on Sourcegraph.
+	ExternalServiceID int64
+	ExternalID        string
+	SyncedAt          time.Time
+	CreatedAt         time.Time
+	UpdatedAt         time.Time
+}
+
+// Announcement is a message displayed at the top of all clients to an audience
+// of users, optionally only during a time window.
+type Announcement struct {
+	ID int64
+	// Message is the announcement in Markdown.
+	Message  string
+	Severity AnnouncementSeverity
+	Audience AnnouncementAudience
+	// StartsAt and EndsAt bound the time window in which the announcement is
+	// displayed. A zero StartsAt means immediately and a zero EndsAt means it
+	// does not expire.
+	StartsAt      time.Time
+	EndsAt        time.Time
+	CreatorUserID int32
+	CreatedAt     time.Time
+	UpdatedAt     time.Time
+}
+
+// IsActive returns true if the announcement is displayed at the given time.
+func (a *Announcement) IsActive(now time.Time) bool {
+	return (a.StartsAt.IsZero() || !now.Before(a.StartsAt)) && (a.EndsAt.IsZero() || now.Before(a.EndsAt))
+}
+
+// AnnouncementSeverity is the severity of an announcement, which determines how
+// it is displayed.
+type AnnouncementSeverity string
+
+const (
+	AnnouncementSeverityInfo    AnnouncementSeverity = "INFO"
+	AnnouncementSeverityWarning AnnouncementSeverity = "WARNING"
+	AnnouncementSeverityError   AnnouncementSeverity = "ERROR"
+)
+
+// AnnouncementAudience is the set of users an announcement is displayed to.
+type AnnouncementAudience string
+
+const (
+	// AnnouncementAudienceEveryone includes unauthenticated visitors.
+	AnnouncementAudienceEveryone           AnnouncementAudience = "EVERYONE"
+	AnnouncementAudienceAuthenticatedUsers AnnouncementAudience = "AUTHENTICATED_USERS"
+	AnnouncementAudienceSiteAdmins         AnnouncementAudience = "SITE_ADMINS"
+)
+
+// SearchJob is a search query that runs exhaustively in the background, without
+// the limits and timeouts of interactive searches. Its results are stored for
+// download once it completes.
+type SearchJob struct {
+	ID     int64
+	UserID int32
+	// Query is the query to run, which includes a patternType: filter.
+	Query string
+	// State is the state of the job in the search job worker queue, or
+	// "canceled" if the job was canceled.
+	State          string
+	FailureMessage string
+	// MatchCount is the number of results found so far, or the total number
+	// of results once the job completed.
+	MatchCount int
+	StartedAt  time.Time
+	FinishedAt time.Time
+	CreatedAt  time.Time
+	UpdatedAt  time.Time
+}
+
 type PhabricatorRepo struct {
 	ID       int32
 	Name     api.RepoName
@@ -1156,7 +1256,7 @@ type SearchContext struct {
 	// that helps differentiate between different search contexts.
 	// Example mappings from context spec to context name:
 	// global -> global, @user -> user, @org -> org,
-	// @user/ctx1 -> ctx1, @org/ctx2 -> ctx2.
+	// @user/ctx1 -> ctx1, @org/ctx2 -> ctx2, @org:team -> org:team.
 	Name        string
 	Description string
 	// Public property controls the visibility of the search context. Public search context is available to
@@ -1167,6 +1267,9 @@ type SearchContext struct {
 	Public          bool
 	NamespaceUserID int32 // if non-zero, the owner is this user. NamespaceUserID/NamespaceOrgID are mutually exclusive.
 	NamespaceOrgID  int32 // if non-zero, the owner is this organization. NamespaceUserID/NamespaceOrgID are mutually exclusive.
+	// NamespaceTeamID is non-zero for the auto-defined search context of a team
+	// of the organization NamespaceOrgID, which contains the repositories of the team.
+	NamespaceTeamID int64
 	UpdatedAt       time.Time
 
 	// We cache namespace names to avoid separate database lookups when constructing the search context spec
@@ -0,0 +1,73 @@
+package uploadstore
+
+import (
+	"strings"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/internal/env"
+)
+
+type Config struct {
+	env.BaseConfig
+
+	Backend          string
+	ManageBucket     bool
+	Bucket           string
+	EvictionBucket   string
+	SearchJobsBucket string
+	TTL              time.Duration
+	S3               S3Config
+	GCS              GCSConfig
+}
+
+type loader interface {
+	load(parent *env.BaseConfig)
+}
+
+func (c *Config) Load() {
+	c.Backend = strings.ToLower(c.Get("PRECISE_CODE_INTEL_UPLOAD_BACKEND", "MinIO", "The target file service for code intelligence uploads. S3, GCS, and MinIO are supported."))
+	c.ManageBucket = c.GetBool("PRECISE_CODE_INTEL_UPLOAD_MANAGE_BUCKET", "false", "Whether or not the client should manage the target bucket configuration.")
+	c.Bucket = c.Get("PRECISE_CODE_INTEL_UPLOAD_BUCKET", "lsif-uploads", "The name of the bucket to store LSIF uploads in.")
+	c.EvictionBucket = c.Get("PRECISE_CODE_INTEL_EVICTION_BUCKET", "lsif-evicted-bundles", "The name of the bucket to store code intelligence data evicted from the codeintel database in.")
+	c.SearchJobsBucket = c.Get("SEARCH_JOBS_UPLOAD_BUCKET", "search-jobs", "The name of the bucket to store the results of search jobs in.")
+	c.TTL = c.GetInterval("PRECISE_CODE_INTEL_UPLOAD_TTL", "168h", "The maximum age of an upload before deletion. A zero value disables expiration.")
+
+	if c.Backend == "minio" {
+		// No manual provisioning
+		c.ManageBucket = true
+	}
+
+	loaders := map[string]loader{
+		"s3":    &c.S3,
+		"minio": &c.S3,
+		"gcs":   &c.GCS,
+	}
+
+	config, ok := loaders[c.Backend]
+	if !ok {
+		c.AddError(errors.Errorf("invalid backend %q for PRECISE_CODE_INTEL_UPLOAD_BACKEND: must be S3, GCS, or MinIO", c.Backend))
+		return
+	}
+
+	config.load(&c.BaseConfig)
+}
+
+// EvictionConfig returns a copy of the configuration that targets the bucket storing code
+// intelligence data evicted from the codeintel database. Objects in this bucket do not expire.
+func (c *Config) EvictionConfig() *Config {
+	config := *c
+	config.Bucket = c.EvictionBucket
+	config.TTL = 0
+	return &config
+}
+
+// SearchJobsConfig returns a copy of the configuration that targets the bucket storing the
+// results of search jobs. Objects in this bucket expire after the given TTL.
+func (c *Config) SearchJobsConfig(ttl time.Duration) *Config {
+	config := *c
+	config.Bucket = c.SearchJobsBucket
+	config.TTL = ttl
+	return &config
+}
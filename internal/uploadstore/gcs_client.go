@@ -183,6 +183,11 @@ func (s *gcsStore) update(ctx context.Context, bucket gcsBucketHandle) error {
 }
 
 func (s *gcsStore) lifecycle() storage.Lifecycle {
+	if s.ttl == 0 {
+		// A zero TTL disables object expiration
+		return storage.Lifecycle{}
+	}
+
 	return storage.Lifecycle{
 		Rules: []storage.LifecycleRule{
 			{
@@ -259,6 +259,14 @@ func TestGCSLifecycle(t *testing.T) {
 	}
 }
 
+func TestGCSLifecycleNoExpiration(t *testing.T) {
+	client := newGCSWithClient(nil, "test-bucket", 0, true, GCSConfig{ProjectID: "pid"}, newOperations(&observation.TestContext))
+
+	if lifecycle := client.lifecycle(); len(lifecycle.Rules) != 0 {
+		t.Fatalf("unexpected lifecycle rules")
+	}
+}
+
 func testGCSClient(client gcsAPI, manageBucket bool) Store {
 	return newLazyStore(rawGCSClient(client, manageBucket))
 }
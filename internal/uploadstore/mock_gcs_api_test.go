@@ -12,7 +12,7 @@ import (
 
 // MockGcsAPI is a mock implementation of the gcsAPI interface (from the
 // package
-// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore)
+// github.com/sourcegraph/sourcegraph/internal/uploadstore)
 // used for unit testing.
 type MockGcsAPI struct {
 	// BucketFunc is an instance of a mock function object controlling the
@@ -33,7 +33,7 @@ func NewMockGcsAPI() *MockGcsAPI {
 }
 
 // surrogateMockGcsAPI is a copy of the gcsAPI interface (from the package
-// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore).
+// github.com/sourcegraph/sourcegraph/internal/uploadstore).
 // It is redefined here as it is unexported in the source package.
 type surrogateMockGcsAPI interface {
 	Bucket(string) gcsBucketHandle
@@ -153,7 +153,7 @@ func (c GcsAPIBucketFuncCall) Results() []interface{} {
 
 // MockGcsBucketHandle is a mock implementation of the gcsBucketHandle
 // interface (from the package
-// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore)
+// github.com/sourcegraph/sourcegraph/internal/uploadstore)
 // used for unit testing.
 type MockGcsBucketHandle struct {
 	// AttrsFunc is an instance of a mock function object controlling the
@@ -200,7 +200,7 @@ func NewMockGcsBucketHandle() *MockGcsBucketHandle {
 
 // surrogateMockGcsBucketHandle is a copy of the gcsBucketHandle interface
 // (from the package
-// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore).
+// github.com/sourcegraph/sourcegraph/internal/uploadstore).
 // It is redefined here as it is unexported in the source package.
 type surrogateMockGcsBucketHandle interface {
 	Attrs(context.Context) (*storage.BucketAttrs, error)
@@ -655,7 +655,7 @@ func (c GcsBucketHandleUpdateFuncCall) Results() []interface{} {
 
 // MockGcsComposer is a mock implementation of the gcsComposer interface
 // (from the package
-// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore)
+// github.com/sourcegraph/sourcegraph/internal/uploadstore)
 // used for unit testing.
 type MockGcsComposer struct {
 	// RunFunc is an instance of a mock function object controlling the
@@ -677,7 +677,7 @@ func NewMockGcsComposer() *MockGcsComposer {
 
 // surrogateMockGcsComposer is a copy of the gcsComposer interface (from the
 // package
-// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore).
+// github.com/sourcegraph/sourcegraph/internal/uploadstore).
 // It is redefined here as it is unexported in the source package.
 type surrogateMockGcsComposer interface {
 	Run(context.Context) (*storage.ObjectAttrs, error)
@@ -801,7 +801,7 @@ func (c GcsComposerRunFuncCall) Results() []interface{} {
 
 // MockGcsObjectHandle is a mock implementation of the gcsObjectHandle
 // interface (from the package
-// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore)
+// github.com/sourcegraph/sourcegraph/internal/uploadstore)
 // used for unit testing.
 type MockGcsObjectHandle struct {
 	// ComposerFromFunc is an instance of a mock function object controlling
@@ -848,7 +848,7 @@ func NewMockGcsObjectHandle() *MockGcsObjectHandle {
 
 // surrogateMockGcsObjectHandle is a copy of the gcsObjectHandle interface
 // (from the package
-// github.com/sourcegraph/sourcegraph/enterprise/internal/codeintel/stores/uploadstore).
+// github.com/sourcegraph/sourcegraph/internal/uploadstore).
 // It is redefined here as it is unexported in the source package.
 type surrogateMockGcsObjectHandle interface {
 	ComposerFrom(...gcsObjectHandle) gcsComposer
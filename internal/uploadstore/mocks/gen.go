@@ -0,0 +1,3 @@
+package mocks
+
+//go:generate ../../../dev/mockgen.sh github.com/sourcegraph/sourcegraph/internal/uploadstore -i Store -o mock_store.go
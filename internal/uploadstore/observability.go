@@ -2,6 +2,9 @@ package uploadstore
 
 import (
 	"fmt"
+	"sync"
+
+	"github.com/prometheus/client_golang/prometheus"
 
 	"github.com/sourcegraph/sourcegraph/internal/metrics"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
@@ -37,3 +40,23 @@ func newOperations(observationContext *observation.Context) *operations {
 		delete:  op("Delete"),
 	}
 }
+
+var (
+	operationsMu         sync.Mutex
+	operationsByRegistry = map[prometheus.Registerer]*operations{}
+)
+
+// sharedOperations returns the operations of all stores created with the given observation
+// context's registerer, as the metrics of each operation can only be registered once.
+func sharedOperations(observationContext *observation.Context) *operations {
+	operationsMu.Lock()
+	defer operationsMu.Unlock()
+
+	ops, ok := operationsByRegistry[observationContext.Registerer]
+	if !ok {
+		ops = newOperations(observationContext)
+		operationsByRegistry[observationContext.Registerer] = ops
+	}
+
+	return ops
+}
@@ -292,6 +292,11 @@ func (s *s3Store) create(ctx context.Context) error {
 }
 
 func (s *s3Store) update(ctx context.Context) error {
+	if s.bucketLifecycleConfiguration != nil && len(s.bucketLifecycleConfiguration.Rules) == 0 {
+		// S3 rejects empty lifecycle configurations
+		return nil
+	}
+
 	configureRequest := &s3.PutBucketLifecycleConfigurationInput{
 		Bucket:                 aws.String(s.bucket),
 		LifecycleConfiguration: s.bucketLifecycleConfiguration,
@@ -361,16 +366,22 @@ func isConnectionResetError(err error) bool {
 	return err != nil && strings.Contains(err.Error(), "read: connection reset by peer")
 }
 
+// s3BucketLifecycleConfiguration returns the lifecycle configuration of a managed bucket. A zero
+// TTL disables object expiration.
 func s3BucketLifecycleConfiguration(backend string, ttl time.Duration) *s3types.BucketLifecycleConfiguration {
 	days := int32(ttl / (time.Hour * 24))
 
-	rules := []s3types.LifecycleRule{
-		{
+	var rules []s3types.LifecycleRule
+	if ttl != 0 {
+		rules = append(rules, s3types.LifecycleRule{
 			ID:         aws.String("Expiration Rule"),
 			Status:     s3types.ExpirationStatusEnabled,
 			Filter:     &s3types.LifecycleRuleFilterMemberPrefix{Value: ""},
 			Expiration: &s3types.LifecycleExpiration{Days: days},
-		},
+		})
+	} else {
+		// Incomplete multipart uploads are still aborted when objects do not expire
+		days = 1
 	}
 
 	if backend != "minio" {
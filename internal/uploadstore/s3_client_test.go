@@ -412,3 +412,13 @@ func testS3Client(client s3API, uploader s3Uploader) Store {
 func rawS3Client(client s3API, uploader s3Uploader) *s3Store {
 	return newS3WithClients(client, uploader, "test-bucket", true, nil, newOperations(&observation.TestContext))
 }
+
+func TestS3BucketLifecycleConfigurationNoExpiration(t *testing.T) {
+	if lifecycle := s3BucketLifecycleConfiguration("s3", 0); len(lifecycle.Rules) != 1 || lifecycle.Rules[0].Expiration != nil {
+		t.Fatalf("unexpected lifecycle rules")
+	}
+
+	if lifecycle := s3BucketLifecycleConfiguration("minio", 0); len(lifecycle.Rules) != 0 {
+		t.Fatalf("unexpected lifecycle rules")
+	}
+}
@@ -55,7 +55,7 @@ func create(ctx context.Context, config *Config, observationContext *observation
 		return nil, errors.Errorf("unknown upload store backend '%s'", config.Backend)
 	}
 
-	store, err := newStore(ctx, config, newOperations(observationContext))
+	store, err := newStore(ctx, config, sharedOperations(observationContext))
 	if err != nil {
 		return nil, err
 	}
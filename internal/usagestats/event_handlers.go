@@ -5,7 +5,10 @@ import (
 	"encoding/json"
 	"time"
 
+	"github.com/inconshreveable/log15"
+
 	"github.com/sourcegraph/sourcegraph/cmd/frontend/envvar"
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/database"
 	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
@@ -50,6 +53,28 @@ func LogBackendEvent(db dbutil.DB, userID int32, eventName string, argument json
 	})
 }
 
+// LogActorEvent logs a backend event on behalf of the authenticated actor of the given context,
+// with the feature flags evaluated for the actor. The argument, if not nil, is marshalled as the
+// JSON argument of the event. Events of unauthenticated actors are not logged.
+//
+// The event is written independently of the cancellation of ctx, so callers that do not want
+// to wait for the write may call this in a goroutine.
+func LogActorEvent(ctx context.Context, db dbutil.DB, eventName string, argument interface{}) error {
+	a := actor.FromContext(ctx)
+	if !a.IsAuthenticated() {
+		return nil
+	}
+
+	var jsonArg json.RawMessage
+	if argument != nil {
+		var err error
+		if jsonArg, err = json.Marshal(argument); err != nil {
+			return err
+		}
+	}
+	return LogBackendEvent(db, a.UID, eventName, jsonArg, featureflag.FromContext(ctx), nil)
+}
+
 // LogEvent logs an event.
 func LogEvent(ctx context.Context, db dbutil.DB, args Event) error {
 	if !conf.EventLoggingEnabled() {
@@ -117,19 +142,6 @@ func publishSourcegraphDotComEvent(args Event) error {
 
 // logLocalEvent logs users events.
 func logLocalEvent(ctx context.Context, db dbutil.DB, name, url string, userID int32, userCookieID, source string, argument json.RawMessage, featureFlags featureflag.FlagSet, cohortID *string) error {
-	if name == "SearchResultsQueried" {
-		err := logSiteSearchOccurred()
-		if err != nil {
-			return err
-		}
-	}
-	if name == "findReferences" {
-		err := logSiteFindRefsOccurred()
-		if err != nil {
-			return err
-		}
-	}
-
 	info := &database.Event{
 		Name:            name,
 		URL:             url,
@@ -143,3 +155,34 @@ func logLocalEvent(ctx context.Context, db dbutil.DB, name, url string, userID i
 	}
 	return database.EventLogs(db).Insert(ctx, info)
 }
+
+// FeatureFlagExposureLogger returns a featureflag.ExposureLogger that logs feature flag
+// exposures as FeatureFlagExposed events, with the name and value of the flag as the argument.
+func FeatureFlagExposureLogger(db dbutil.DB) featureflag.ExposureLogger {
+	return func(exposure featureflag.Exposure) {
+		argument, err := json.Marshal(map[string]interface{}{
+			"flag":  exposure.FlagName,
+			"value": exposure.Value,
+		})
+		if err != nil {
+			log15.Warn("Could not marshal feature flag exposure", "error", err)
+			return
+		}
+
+		userCookieID := exposure.AnonymousUID
+		if userCookieID == "" {
+			userCookieID = "backend" // avoid the event_logs table's user existence constraint
+		}
+
+		err = LogEvent(context.Background(), db, Event{
+			EventName:    "FeatureFlagExposed",
+			UserID:       exposure.UserID,
+			UserCookieID: userCookieID,
+			Source:       "BACKEND",
+			Argument:     argument,
+		})
+		if err != nil {
+			log15.Warn("Could not log feature flag exposure", "flag", exposure.FlagName, "error", err)
+		}
+	}
+}
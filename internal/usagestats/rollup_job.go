@@ -0,0 +1,89 @@
+package usagestats
+
+import (
+	"context"
+	"time"
+
+	"github.com/cockroachdb/errors"
+
+	"github.com/sourcegraph/sourcegraph/cmd/worker/shared"
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+)
+
+type rollupConfig struct {
+	env.BaseConfig
+
+	Interval      time.Duration
+	MaxDaysPerRun int
+}
+
+var rollupConfigInst = &rollupConfig{}
+
+func (c *rollupConfig) Load() {
+	c.Interval = c.GetInterval("USAGE_ROLLUP_INTERVAL", "1h", "The frequency with which to aggregate event logs into daily and weekly usage rollups.")
+	c.MaxDaysPerRun = c.GetInt("USAGE_ROLLUP_MAX_DAYS_PER_RUN", "30", "The maximum number of days of event logs to aggregate per run, which bounds the work of backfilling rollups.")
+}
+
+func (c *rollupConfig) Validate() error {
+	if err := c.BaseConfig.Validate(); err != nil {
+		return err
+	}
+	if c.MaxDaysPerRun < 1 {
+		return errors.Errorf("invalid value %d for USAGE_ROLLUP_MAX_DAYS_PER_RUN: must be positive", c.MaxDaysPerRun)
+	}
+	return nil
+}
+
+// NewRollupJob returns a worker job that periodically aggregates event logs into the daily and
+// weekly active user and event rollups read by site admins.
+func NewRollupJob() shared.Job {
+	return &rollupJob{}
+}
+
+type rollupJob struct{}
+
+func (j *rollupJob) Config() []env.Config {
+	return []env.Config{rollupConfigInst}
+}
+
+func (j *rollupJob) Routines(_ context.Context) ([]goroutine.BackgroundRoutine, error) {
+	db, err := shared.InitDatabase()
+	if err != nil {
+		return nil, err
+	}
+	store := database.UsageRollups(db)
+
+	handler := goroutine.NewHandlerWithErrorMessage("aggregate usage rollups", func(ctx context.Context) error {
+		return rollup(ctx, store, timeNow().UTC(), rollupConfigInst.MaxDaysPerRun)
+	})
+
+	return []goroutine.BackgroundRoutine{
+		// Pass a fresh context, see docs for shared.Job
+		goroutine.NewPeriodicGoroutine(context.Background(), rollupConfigInst.Interval, handler),
+	}, nil
+}
+
+// rollup aggregates the events logged since the most recent rollups of each period type, or
+// since the earliest event if there are no rollups yet, up to maxDays days at a time.
+func rollup(ctx context.Context, store *database.UsageRollupStore, now time.Time, maxDays int) error {
+	for _, period := range []database.PeriodType{database.Daily, database.Weekly} {
+		start, ok, err := store.RollupStart(ctx, period)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			continue
+		}
+
+		end := now
+		if limit := start.AddDate(0, 0, maxDays); limit.Before(end) {
+			end = limit
+		}
+		if err := store.Rollup(ctx, period, start, end); err != nil {
+			return errors.Wrapf(err, "aggregating %s usage rollups", period)
+		}
+	}
+	return nil
+}
@@ -145,12 +145,6 @@ func TestUserUsageStatistics_LogPageView(t *testing.T) {
 func TestUserUsageStatistics_LogSearchQuery(t *testing.T) {
 	db := setupForTest(t)
 
-	// Set searchOccurred to true to prevent using redis to log all-time stats during tests.
-	searchOccurred = 1
-	defer func() {
-		searchOccurred = 0
-	}()
-
 	user := types.User{
 		ID: 1,
 	}
@@ -11,6 +11,8 @@ import (
 	"github.com/cockroachdb/errors"
 
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver"
+	"github.com/sourcegraph/sourcegraph/internal/gitserver/protocol"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 )
 
@@ -44,6 +46,51 @@ func BlameFile(ctx context.Context, repo api.RepoName, path string, opt *BlameOp
 	return blameFileCmd(ctx, gitserverCmdFunc(repo), path, opt)
 }
 
+// StreamBlameFile returns Git blame information about a file like BlameFile,
+// but calls onHunk for each hunk as soon as gitserver attributed it to a
+// commit instead of waiting for the whole file to be blamed. The hunks are not
+// in the order of their lines. If onHunk returns an error, the blame is
+// stopped and the error returned.
+func StreamBlameFile(ctx context.Context, repo api.RepoName, path string, opt *BlameOptions, onHunk func(*Hunk) error) error {
+	span, ctx := ot.StartSpanFromContext(ctx, "Git: StreamBlameFile")
+	span.SetTag("repo", repo)
+	span.SetTag("path", path)
+	span.SetTag("opt", opt)
+	defer span.Finish()
+
+	if opt == nil {
+		opt = &BlameOptions{}
+	}
+	if opt.OldestCommit != "" {
+		return errors.Errorf("OldestCommit not implemented")
+	}
+	if err := checkSpecArgSafety(string(opt.NewestCommit)); err != nil {
+		return err
+	}
+
+	return gitserver.DefaultClient.Blame(ctx, &protocol.BlameRequest{
+		Repo:      repo,
+		Commit:    opt.NewestCommit,
+		Path:      filepath.ToSlash(path),
+		StartLine: opt.StartLine,
+		EndLine:   opt.EndLine,
+	}, func(h protocol.BlameHunk) error {
+		return onHunk(&Hunk{
+			StartLine: h.StartLine,
+			EndLine:   h.EndLine,
+			StartByte: h.StartByte,
+			EndByte:   h.EndByte,
+			CommitID:  h.CommitID,
+			Author: Signature{
+				Name:  h.AuthorName,
+				Email: h.AuthorEmail,
+				Date:  h.AuthorDate,
+			},
+			Message: h.Message,
+		})
+	})
+}
+
 func blameFileCmd(ctx context.Context, command cmdFunc, path string, opt *BlameOptions) ([]*Hunk, error) {
 	if opt == nil {
 		opt = &BlameOptions{}
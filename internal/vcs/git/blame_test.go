@@ -3,6 +3,7 @@ package git
 import (
 	"context"
 	"reflect"
+	"sort"
 	"testing"
 	"time"
 
@@ -65,5 +66,20 @@ func TestRepository_BlameFile(t *testing.T) {
 		if !reflect.DeepEqual(hunks, test.wantHunks) {
 			t.Errorf("%s: hunks != wantHunks\n\nhunks ==========\n%s\n\nwantHunks ==========\n%s", label, AsJSON(hunks), AsJSON(test.wantHunks))
 		}
+
+		var streamedHunks []*Hunk
+		err = StreamBlameFile(ctx, test.repo, test.path, test.opt, func(h *Hunk) error {
+			streamedHunks = append(streamedHunks, h)
+			return nil
+		})
+		if err != nil {
+			t.Errorf("%s: StreamBlameFile(%s, %+v): %s", label, test.path, test.opt, err)
+			continue
+		}
+
+		sort.Slice(streamedHunks, func(i, j int) bool { return streamedHunks[i].StartLine < streamedHunks[j].StartLine })
+		if !reflect.DeepEqual(streamedHunks, test.wantHunks) {
+			t.Errorf("%s: streamedHunks != wantHunks\n\nstreamedHunks ==========\n%s\n\nwantHunks ==========\n%s", label, AsJSON(streamedHunks), AsJSON(test.wantHunks))
+		}
 	}
 }
@@ -31,6 +31,9 @@ func ReadFile(ctx context.Context, repo api.RepoName, commit api.CommitID, name
 	}
 
 	name = util.Rel(name)
+	if err := checkSubRepoPermissions(ctx, repo, name); err != nil {
+		return nil, err
+	}
 	b, err := readFileBytes(ctx, repo, commit, name, maxBytes)
 	if err != nil {
 		return nil, err
@@ -50,6 +53,9 @@ func NewFileReader(ctx context.Context, repo api.RepoName, commit api.CommitID,
 	defer span.Finish()
 
 	name = util.Rel(name)
+	if err := checkSubRepoPermissions(ctx, repo, name); err != nil {
+		return nil, err
+	}
 	br, err := newBlobReader(ctx, repo, commit, name)
 	if err != nil {
 		return nil, errors.Wrapf(err, "getting blobReader for %q", name)
@@ -335,8 +335,12 @@ func rawShowSearch(ctx context.Context, repo api.RepoName, opt RawLogDiffSearchO
 	// Even though we've already searched using the query, we need to
 	// search the returned diff again to filter to only matching hunks
 	// and to highlight matches.
+	//
+	// For commit (non-diff) searches the pattern matches the commit message,
+	// so the diff is only used to filter by path and must not be filtered by
+	// the pattern.
 	var query *regexp.Regexp
-	if pattern := opt.Query.Pattern; pattern != "" {
+	if pattern := opt.Query.Pattern; pattern != "" && opt.Diff {
 		if !opt.Query.IsRegExp {
 			pattern = regexp.QuoteMeta(pattern)
 		}
@@ -123,6 +123,26 @@ func TestRepository_RawLogDiffSearch(t *testing.T) {
 			Refs:       []string{"refs/heads/master", "refs/tags/mytag"},
 			SourceRefs: []string{"refs/heads/branch2"},
 		}},
+	}, {
+		// For commit searches the query matches the message, so it must not
+		// be used to filter the hunks of the diff.
+		name: "message-query-with-path",
+		opt: RawLogDiffSearchOptions{
+			Query: TextSearchOptions{Pattern: "^root$", IsRegExp: true},
+			Paths: PathOptions{IncludePatterns: []string{"^f$"}, IsRegExp: true},
+			Args:  []string{"--grep=^root$", "--extended-regexp"},
+		},
+		want: []*LogCommitSearchResult{{
+			Commit: Commit{
+				ID:        "ce72ece27fd5c8180cfbc1c412021d32fd1cda0d",
+				Author:    Signature{Name: "a", Email: "a@a.com", Date: MustParseTime(time.RFC3339, "2006-01-02T15:04:05Z")},
+				Committer: &Signature{Name: "a", Email: "a@a.com", Date: MustParseTime(time.RFC3339, "2006-01-02T15:04:05Z")},
+				Message:   "root",
+			},
+			Refs:       []string{"refs/heads/master", "refs/tags/mytag"},
+			SourceRefs: []string{"refs/heads/branch2"},
+			Diff:       &RawDiff{Raw: "diff --git f f\nnew file mode 100644\nindex 0000000..d8649da\n--- /dev/null\n+++ f\n@@ -0,0 +1,1 @@\n+root\n"},
+		}},
 	}, {
 		name: "path",
 		opt: RawLogDiffSearchOptions{
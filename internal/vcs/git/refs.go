@@ -304,13 +304,51 @@ func (p byteSlices) Swap(i, j int)      { p[i], p[j] = p[j], p[i] }
 func ListRefs(ctx context.Context, repo api.RepoName) ([]Ref, error) {
 	span, ctx := ot.StartSpanFromContext(ctx, "Git: ListRefs")
 	defer span.Finish()
-	return showRef(ctx, repo)
+
+	cmd := gitserver.DefaultClient.Command("git", "for-each-ref", "--format", "%(objectname)%00%(refname)%00%(creatordate:unix)")
+	cmd.Repo = repo
+	out, err := cmd.CombinedOutput(ctx)
+	if err != nil {
+		if vcs.IsRepoNotExist(err) {
+			return nil, err
+		}
+		return nil, errors.WithMessage(err, fmt.Sprintf("git command %v failed (output: %q)", cmd.Args, out))
+	}
+
+	return parseRefs(out)
+}
+
+func parseRefs(in []byte) ([]Ref, error) {
+	in = bytes.TrimSuffix(in, []byte("\n")) // remove trailing newline
+	if len(in) == 0 {
+		return nil, nil // no refs
+	}
+	lines := bytes.Split(in, []byte("\n"))
+	refs := make([]Ref, len(lines))
+	for i, line := range lines {
+		parts := bytes.SplitN(line, []byte("\x00"), 3)
+		if len(parts) != 3 {
+			return nil, errors.Errorf("invalid git for-each-ref output line: %q", line)
+		}
+
+		refs[i] = Ref{Name: string(parts[1]), CommitID: api.CommitID(parts[0])}
+
+		date, err := strconv.ParseInt(string(parts[2]), 10, 64)
+		if err == nil {
+			refs[i].CreatorDate = time.Unix(date, 0).UTC()
+		}
+	}
+	return refs, nil
 }
 
 // Ref describes a Git ref.
 type Ref struct {
 	Name     string // the full name of the ref (e.g., "refs/heads/mybranch")
 	CommitID api.CommitID
+
+	// CreatorDate is the date of the tag object for annotated tags and the committer date
+	// of the commit otherwise. It is only set by ListRefs.
+	CreatorDate time.Time
 }
 
 func showRef(ctx context.Context, repo api.RepoName, args ...string) ([]Ref, error) {
@@ -307,6 +307,32 @@ func TestRepository_ListTags(t *testing.T) {
 	}
 }
 
+func TestRepository_ListRefs(t *testing.T) {
+	t.Parallel()
+
+	dateEnv := "GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2006-01-02T15:04:05Z"
+	gitCommands := []string{
+		dateEnv + " git commit --allow-empty -m foo --author='a <a@a.com>' --date 2006-01-02T15:04:05Z",
+		"git tag t0",
+		"GIT_COMMITTER_NAME=a GIT_COMMITTER_EMAIL=a@a.com GIT_COMMITTER_DATE=2007-01-02T15:04:05Z git tag --annotate -m foo t1",
+	}
+	repo := MakeGitRepository(t, gitCommands...)
+
+	refs, err := ListRefs(context.Background(), repo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []Ref{
+		{Name: "refs/heads/master", CommitID: "ea167fe3d76b1e5fd3ed8ca44cbd2fe3897684f8", CreatorDate: MustParseTime(time.RFC3339, "2006-01-02T15:04:05Z")},
+		{Name: "refs/tags/t0", CommitID: "ea167fe3d76b1e5fd3ed8ca44cbd2fe3897684f8", CreatorDate: MustParseTime(time.RFC3339, "2006-01-02T15:04:05Z")},
+		{Name: "refs/tags/t1", CommitID: "f8a67d70c35188bee5dd8bc4f37d72ef7e8f5b2b", CreatorDate: MustParseTime(time.RFC3339, "2007-01-02T15:04:05Z")},
+	}
+	if diff := cmp.Diff(want, refs); diff != "" {
+		t.Fatalf("unexpected refs (-want +got):\n%s", diff)
+	}
+}
+
 // See https://github.com/sourcegraph/sourcegraph/issues/5453
 func TestRepository_parseTags_WithoutCreatorDate(t *testing.T) {
 	have, err := parseTags([]byte(
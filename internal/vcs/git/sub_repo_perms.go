@@ -0,0 +1,51 @@
+package git
+
+import (
+	"context"
+	"io/fs"
+	"os"
+
+	"github.com/sourcegraph/sourcegraph/internal/actor"
+	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
+)
+
+// checkSubRepoPermissions returns an error that satisfies os.IsNotExist if the
+// actor in ctx is not allowed to read the named path. Unreadable paths are
+// reported as nonexistent to avoid leaking their existence.
+func checkSubRepoPermissions(ctx context.Context, repo api.RepoName, name string) error {
+	ok, err := authz.FilterActorPath(ctx, authz.DefaultSubRepoPermsChecker, actor.FromContext(ctx), repo, name)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return &os.PathError{Op: "open", Path: name, Err: os.ErrNotExist}
+	}
+	return nil
+}
+
+// filterFileInfos returns the subset of fis that the actor in ctx is allowed
+// to read.
+func filterFileInfos(ctx context.Context, repo api.RepoName, fis []fs.FileInfo) ([]fs.FileInfo, error) {
+	checker := authz.DefaultSubRepoPermsChecker
+	if !checker.Enabled() {
+		return fis, nil
+	}
+
+	a := actor.FromContext(ctx)
+	filtered := make([]fs.FileInfo, 0, len(fis))
+	for _, fi := range fis {
+		name := fi.Name()
+		if fi.IsDir() {
+			name += "/"
+		}
+		ok, err := authz.FilterActorPath(ctx, checker, a, repo, name)
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			filtered = append(filtered, fi)
+		}
+	}
+	return filtered, nil
+}
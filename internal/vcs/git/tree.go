@@ -18,7 +18,9 @@ import (
 	"github.com/cockroachdb/errors"
 	"github.com/golang/groupcache/lru"
 
+	"github.com/sourcegraph/sourcegraph/internal/actor"
 	"github.com/sourcegraph/sourcegraph/internal/api"
+	"github.com/sourcegraph/sourcegraph/internal/authz"
 	"github.com/sourcegraph/sourcegraph/internal/gitserver"
 	"github.com/sourcegraph/sourcegraph/internal/trace/ot"
 	"github.com/sourcegraph/sourcegraph/internal/vcs/util"
@@ -51,6 +53,9 @@ func Lstat(ctx context.Context, repo api.RepoName, commit api.CommitID, path str
 	if err != nil {
 		return nil, err
 	}
+	if fis, err = filterFileInfos(ctx, repo, fis); err != nil {
+		return nil, err
+	}
 	if len(fis) == 0 {
 		return nil, &os.PathError{Op: "ls-tree", Path: path, Err: os.ErrNotExist}
 	}
@@ -120,7 +125,11 @@ func ReadDir(ctx context.Context, repo api.RepoName, commit api.CommitID, path s
 		// to list the dir's tree entry in its parent dir).
 		path = filepath.Clean(util.Rel(path)) + "/"
 	}
-	return lsTree(ctx, repo, commit, path, recurse)
+	fis, err := lsTree(ctx, repo, commit, path, recurse)
+	if err != nil {
+		return nil, err
+	}
+	return filterFileInfos(ctx, repo, fis)
 }
 
 // lsTreeRootCache caches the result of running `git ls-tree ...` on a repository's root path
@@ -150,7 +159,8 @@ func LsFiles(ctx context.Context, repo api.RepoName, commit api.CommitID) ([]str
 	if err != nil {
 		return nil, errors.WithMessage(err, fmt.Sprintf("git command %v failed (output: %q)", cmd.Args, out))
 	}
-	return strings.Split(string(out), "\x00"), nil
+	files := strings.Split(string(out), "\x00")
+	return authz.FilterActorPaths(ctx, authz.DefaultSubRepoPermsChecker, actor.FromContext(ctx), repo, files)
 }
 
 // lsTree returns ls of tree at path.
@@ -0,0 +1,155 @@
+// Package skew detects version skew between the running instances of
+// Sourcegraph services. Every service registers its instance and build
+// version in the database on startup, and refuses to start if another running
+// instance is more than one minor version apart.
+package skew
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Masterminds/semver"
+	"github.com/inconshreveable/log15"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+	"github.com/sourcegraph/sourcegraph/internal/database/dbutil"
+	"github.com/sourcegraph/sourcegraph/internal/env"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
+	"github.com/sourcegraph/sourcegraph/internal/hostname"
+	"github.com/sourcegraph/sourcegraph/internal/version"
+)
+
+var disableCheck, _ = strconv.ParseBool(env.Get("SRC_DISABLE_VERSION_SKEW_CHECK", "false", "Only log an error, instead of refusing to start, when services more than one minor version apart are running."))
+
+const (
+	// heartbeatInterval is how often a registered instance reports that it
+	// is still running.
+	heartbeatInterval = time.Minute
+
+	// ActiveWithin is how recently an instance must have reported that it is
+	// running to be considered when checking for skew. Instances that were
+	// stopped are thus ignored after a while.
+	ActiveWithin = 5 * time.Minute
+)
+
+// Error is returned by Register when the version of the registering service
+// is skewed against other running instances.
+type Error struct {
+	Service string
+	Version string
+	Skewed  []*database.ServiceInstance
+}
+
+func (e *Error) Error() string {
+	instances := make([]string, 0, len(e.Skewed))
+	for _, i := range e.Skewed {
+		instances = append(instances, fmt.Sprintf("%s (%s) at %s", i.Service, i.Instance, i.Version))
+	}
+	return fmt.Sprintf(
+		"%s at %s is more than one minor version apart from the running instances %s, please upgrade all services to the same version or set SRC_DISABLE_VERSION_SKEW_CHECK=true to start anyway",
+		e.Service,
+		e.Version,
+		strings.Join(instances, ", "),
+	)
+}
+
+// Register checks the build version of the given service against the other
+// instances running in the deployment, records the instance and keeps
+// reporting that it is running in the background.
+//
+// It returns an *Error if another instance is more than one minor version
+// apart, unless SRC_DISABLE_VERSION_SKEW_CHECK is set. Failures to access the
+// database are only logged, so that a service can start before the frontend
+// migrated the database.
+func Register(ctx context.Context, db dbutil.DB, service string) error {
+	store := database.ServiceInstances(db)
+	instance := hostname.Get()
+	current := version.Version()
+
+	active, err := store.ListActive(ctx, ActiveWithin)
+	if err != nil {
+		log15.Error("Failed to check version skew", "service", service, "error", err)
+		return nil
+	}
+
+	var others []*database.ServiceInstance
+	for _, i := range active {
+		if i.Service != service || i.Instance != instance {
+			others = append(others, i)
+		}
+	}
+
+	if skewed := Skewed(current, others); len(skewed) > 0 {
+		err := &Error{Service: service, Version: current, Skewed: skewed}
+		if !disableCheck {
+			return err
+		}
+		log15.Error("Starting despite version skew", "error", err)
+	}
+
+	if err := store.Start(ctx, service, instance, current); err != nil {
+		log15.Error("Failed to register service instance", "service", service, "error", err)
+		return nil
+	}
+
+	heartbeat := goroutine.NewPeriodicGoroutine(context.Background(), heartbeatInterval, goroutine.NewHandlerWithErrorMessage(
+		"report running service instance",
+		func(ctx context.Context) error {
+			return store.Heartbeat(ctx, service, instance)
+		},
+	))
+	goroutine.Go(heartbeat.Start)
+
+	return nil
+}
+
+// Skewed returns the instances whose version is more than one minor version
+// apart from the given version. Development builds and versions which are not
+// semantic versions, such as insiders builds, are never skewed.
+func Skewed(v string, instances []*database.ServiceInstance) []*database.ServiceInstance {
+	current := parse(v)
+	if current == nil {
+		return nil
+	}
+
+	var skewed []*database.ServiceInstance
+	for _, i := range instances {
+		if other := parse(i.Version); other != nil && isSkewed(current, other) {
+			skewed = append(skewed, i)
+		}
+	}
+	return skewed
+}
+
+func parse(v string) *semver.Version {
+	if version.IsDev(v) {
+		return nil
+	}
+	parsed, err := semver.NewVersion(v)
+	if err != nil {
+		return nil
+	}
+	return parsed
+}
+
+// isSkewed reports whether a and b are more than one minor version apart. As
+// the last minor version of a major release is not known, any minor version is
+// considered to be one minor version apart from the first minor version of the
+// next major release, like in backend.IsValidUpgrade.
+func isSkewed(a, b *semver.Version) bool {
+	if a.Major() > b.Major() {
+		a, b = b, a
+	}
+	switch b.Major() - a.Major() {
+	case 0:
+		d := b.Minor() - a.Minor()
+		return d > 1 || d < -1
+	case 1:
+		return b.Minor() != 0
+	default:
+		return true
+	}
+}
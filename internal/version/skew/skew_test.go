@@ -0,0 +1,32 @@
+package skew
+
+import (
+	"testing"
+
+	"github.com/sourcegraph/sourcegraph/internal/database"
+)
+
+func TestSkewed(t *testing.T) {
+	for _, tc := range []struct {
+		current, other string
+		skewed         bool
+	}{
+		{"3.31.0", "3.31.2", false},
+		{"3.31.0", "3.30.0", false},
+		{"3.31.0", "3.32.1", false},
+		{"3.31.0", "3.29.0", true},
+		{"3.31.0", "3.33.0", true},
+		{"3.36.0", "4.0.0", false},
+		{"4.0.0", "3.12.0", false},
+		{"3.36.0", "4.1.0", true},
+		{"3.31.0", "5.0.0", true},
+		{"3.31.0", "0.0.0+dev", false},
+		{"0.0.0+dev", "3.31.0", false},
+		{"3.31.0", "107950_2021-08-20_abc123", false},
+	} {
+		instances := []*database.ServiceInstance{{Service: "gitserver", Instance: "gitserver-0", Version: tc.other}}
+		if have := len(Skewed(tc.current, instances)) > 0; have != tc.skewed {
+			t.Errorf("Skewed(%q, %q): want %t, have %t", tc.current, tc.other, tc.skewed, have)
+		}
+	}
+}
@@ -155,6 +155,17 @@ type Options struct {
 	// supplied.
 	OrderByExpression *sqlf.Query
 
+	// FairnessKeyExpression is an optional SQL expression identifying the owner of a record, such as
+	// its repository, user or batch change. If supplied, candidate records are interleaved across keys
+	// instead of being selected strictly by `OrderByExpression`: the next record is the first one of
+	// the key with the fewest processing and previously selected records. This prevents a key with a
+	// large number of records from starving all other keys. This expression may use the alias provided
+	// in `ViewName`, if one was supplied.
+	//
+	// Ranking the candidates requires scanning all queued records on each dequeue, so the state column
+	// should be indexed.
+	FairnessKeyExpression *sqlf.Query
+
 	// ColumnExpressions are the target columns provided to the query when selecting a job record. These
 	// expressions may use the alias provided in `ViewName`, if one was supplied.
 	ColumnExpressions []*sqlf.Query
@@ -311,21 +322,51 @@ func (s *store) Dequeue(ctx context.Context, workerHostname string, conditions [
 	now := s.now()
 
 	// Select and "lock" candidate record
-	id, exists, err := basestore.ScanFirstInt(s.Query(ctx, s.formatQuery(
-		selectCandidateQuery,
-		quote(s.options.ViewName),
-		now,
-		int(s.options.RetryAfter/time.Second),
-		now,
-		int(s.options.RetryAfter/time.Second),
-		s.options.MaxNumRetries,
-		makeConditionSuffix(conditions),
-		s.options.OrderByExpression,
-		quote(s.options.TableName),
-		now,
-		now,
-		workerHostname,
-	)))
+	var candidateQuery *sqlf.Query
+	if s.options.FairnessKeyExpression == nil {
+		candidateQuery = s.formatQuery(
+			selectCandidateQuery,
+			quote(s.options.ViewName),
+			now,
+			int(s.options.RetryAfter/time.Second),
+			now,
+			int(s.options.RetryAfter/time.Second),
+			s.options.MaxNumRetries,
+			makeConditionSuffix(conditions),
+			s.options.OrderByExpression,
+			quote(s.options.TableName),
+			now,
+			now,
+			workerHostname,
+		)
+	} else {
+		candidateQuery = s.formatQuery(
+			selectFairCandidateQuery,
+			s.options.FairnessKeyExpression,
+			s.options.OrderByExpression,
+			s.options.OrderByExpression,
+			quote(s.options.ViewName),
+			now,
+			int(s.options.RetryAfter/time.Second),
+			now,
+			int(s.options.RetryAfter/time.Second),
+			s.options.MaxNumRetries,
+			makeConditionSuffix(conditions),
+			quote(s.options.ViewName),
+			now,
+			int(s.options.RetryAfter/time.Second),
+			now,
+			int(s.options.RetryAfter/time.Second),
+			s.options.MaxNumRetries,
+			makeConditionSuffix(conditions),
+			quote(s.options.TableName),
+			now,
+			now,
+			workerHostname,
+		)
+	}
+
+	id, exists, err := basestore.ScanFirstInt(s.Query(ctx, candidateQuery))
 	if err != nil {
 		return nil, false, err
 	}
@@ -384,6 +425,66 @@ WHERE {id} IN (SELECT {id} FROM candidate)
 RETURNING {id}
 `
 
+// selectFairCandidateQuery is selectCandidateQuery with candidates interleaved across the
+// values of the fairness key. Within each key, processing records are ranked first, so that
+// keys with records already being processed are selected last. Ties are broken by the
+// position of the record in the overall order. The ranking is computed in a separate
+// CTE, as window functions cannot be combined with FOR UPDATE.
+const selectFairCandidateQuery = `
+-- source: internal/workerutil/store.go:Dequeue
+WITH ranked AS (
+	SELECT
+		{id} AS ranked_id,
+		ROW_NUMBER() OVER (PARTITION BY %s ORDER BY {state} = 'processing' DESC, %s) AS fairness_rank,
+		ROW_NUMBER() OVER (ORDER BY %s) AS fairness_position
+	FROM %s
+	WHERE
+		(
+			{state} = 'processing' OR
+			(
+				{state} = 'queued' AND
+				({process_after} IS NULL OR {process_after} <= %s)
+			) OR (
+				%s > 0 AND
+				{state} = 'errored' AND
+				%s - {finished_at} > (%s * '1 second'::interval) AND
+				{num_failures} < %s
+			)
+		)
+		%s
+),
+candidate AS (
+	SELECT {id} FROM %s
+	JOIN ranked ON ranked.ranked_id = {id}
+	WHERE
+		(
+			(
+				{state} = 'queued' AND
+				({process_after} IS NULL OR {process_after} <= %s)
+			) OR (
+				%s > 0 AND
+				{state} = 'errored' AND
+				%s - {finished_at} > (%s * '1 second'::interval) AND
+				{num_failures} < %s
+			)
+		)
+		%s
+	ORDER BY ranked.fairness_rank, ranked.fairness_position
+	FOR UPDATE SKIP LOCKED
+	LIMIT 1
+)
+UPDATE %s
+SET
+	{state} = 'processing',
+	{started_at} = %s,
+	{last_heartbeat_at} = %s,
+	{finished_at} = NULL,
+	{failure_message} = NULL,
+	{worker_hostname} = %s
+WHERE {id} IN (SELECT {id} FROM candidate)
+RETURNING {id}
+`
+
 const selectRecordQuery = `
 -- source: internal/workerutil/store.go:Dequeue
 SELECT %s FROM %s WHERE {id} = %s
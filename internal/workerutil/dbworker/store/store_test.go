@@ -149,6 +149,30 @@ func TestStoreDequeueConditions(t *testing.T) {
 	assertDequeueRecordResult(t, 3, record, ok, err)
 }
 
+func TestStoreDequeueFairness(t *testing.T) {
+	db := setupStoreTest(t)
+
+	if _, err := db.ExecContext(context.Background(), `
+		INSERT INTO workerutil_test (id, state, uploaded_at)
+		VALUES
+			(1, 'queued', NOW() - '5 minute'::interval),
+			(2, 'queued', NOW() - '4 minute'::interval),
+			(3, 'queued', NOW() - '3 minute'::interval),
+			(11, 'queued', NOW() - '1 minute'::interval)
+	`); err != nil {
+		t.Fatalf("unexpected error inserting records: %s", err)
+	}
+
+	// Records are owned by the tens digit of their ID
+	options := defaultTestStoreOptions(nil)
+	options.FairnessKeyExpression = sqlf.Sprintf("w.id / 10")
+
+	for _, expectedID := range []int{1, 11, 2, 3} {
+		record, ok, err := testStore(db, options).Dequeue(context.Background(), "test", nil)
+		assertDequeueRecordResult(t, expectedID, record, ok, err)
+	}
+}
+
 func TestStoreDequeueDelay(t *testing.T) {
 	db := setupStoreTest(t)
 
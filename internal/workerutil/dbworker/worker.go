@@ -3,10 +3,17 @@ package dbworker
 import (
 	"context"
 
+	"github.com/sourcegraph/sourcegraph/internal/conf"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil"
 	"github.com/sourcegraph/sourcegraph/internal/workerutil/dbworker/store"
 )
 
 func NewWorker(ctx context.Context, store store.Store, handler workerutil.Handler, options workerutil.WorkerOptions) *workerutil.Worker {
+	if options.Paused == nil {
+		// Handlers of database-backed records write to the database, so they are
+		// paused while the instance is in maintenance mode.
+		options.Paused = conf.MaintenanceModeEnabled
+	}
+
 	return workerutil.NewWorker(ctx, newStoreShim(store), handler, options)
 }
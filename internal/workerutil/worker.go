@@ -10,6 +10,7 @@ import (
 	"github.com/inconshreveable/log15"
 
 	"github.com/sourcegraph/sourcegraph/internal/errcode"
+	"github.com/sourcegraph/sourcegraph/internal/goroutine"
 	"github.com/sourcegraph/sourcegraph/internal/hostname"
 	"github.com/sourcegraph/sourcegraph/internal/observation"
 )
@@ -20,13 +21,17 @@ type Worker struct {
 	handler          Handler
 	options          WorkerOptions
 	clock            glock.Clock
-	handlerSemaphore chan struct{}   // tracks available handler slots
-	ctx              context.Context // root context passed to the handler
-	cancel           func()          // cancels the root context
-	wg               sync.WaitGroup  // tracks active handler routines
-	finished         chan struct{}   // signals that Start has finished
+	handlerSemaphore chan struct{}           // tracks available handler slots
+	ctx              context.Context         // root context passed to the handler
+	cancel           func()                  // cancels the root context
+	wg               sync.WaitGroup          // tracks active handler routines
+	finished         chan struct{}           // signals that Start has finished
+	observers        []goroutine.RunObserver // notified after each handler invocation
 }
 
+var _ goroutine.BackgroundRoutine = &Worker{}
+var _ goroutine.RunObservable = &Worker{}
+
 type WorkerOptions struct {
 	// Name denotes the name of the worker used to distinguish log messages and
 	// emitted metrics. The worker constructor will fail if this field is not
@@ -51,6 +56,11 @@ type WorkerOptions struct {
 	// record is neither pending nor abandoned.
 	HeartbeatInterval time.Duration
 
+	// Paused, if supplied, is invoked before each attempt to dequeue a record. No
+	// records are dequeued while it returns true. Records that are already being
+	// processed are not interrupted.
+	Paused func() bool
+
 	// Metrics configures logging, tracing, and metrics for the work loop.
 	Metrics WorkerMetrics
 }
@@ -121,6 +131,13 @@ loop:
 	w.wg.Wait()
 }
 
+// AddRunObserver registers an observer that is called after each invocation of the handler.
+// Observers may be called concurrently when the worker runs multiple handlers. This method
+// must be called before Start.
+func (w *Worker) AddRunObserver(observer goroutine.RunObserver) {
+	w.observers = append(w.observers, observer)
+}
+
 // Stop will cause the worker loop to exit after the current iteration. This is done by canceling the
 // context passed to the database and the handler functions (which may cause the currently processing
 // unit of work to fail). This method blocks until all handler goroutines have exited.
@@ -133,6 +150,10 @@ func (w *Worker) Stop() {
 // can be dequeued and returns an error only on failure to dequeue a new record - no handler errors
 // will bubble up.
 func (w *Worker) dequeueAndHandle() (dequeued bool, err error) {
+	if w.options.Paused != nil && w.options.Paused() {
+		return false, nil
+	}
+
 	select {
 	// If we block here we are waiting for a handler to exit so that we do not
 	// exceed our configured concurrency limit.
@@ -224,7 +245,11 @@ func (w *Worker) handle(record Record) (err error) {
 	ctx, endOperation := w.options.Metrics.operations.handle.With(w.ctx, &err, observation.Args{})
 	defer endOperation(1, observation.Args{})
 
+	start := w.clock.Now()
 	handleErr := w.handler.Handle(ctx, record)
+	for _, observer := range w.observers {
+		observer(w.clock.Since(start), handleErr)
+	}
 
 	if errcode.IsNonRetryable(handleErr) {
 		if marked, markErr := w.store.MarkFailed(ctx, record.RecordID(), handleErr.Error()); markErr != nil {
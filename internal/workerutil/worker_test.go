@@ -93,6 +93,45 @@ func TestWorkerHandlerFailure(t *testing.T) {
 	}
 }
 
+func TestWorkerRunObserver(t *testing.T) {
+	store := NewMockStore()
+	handler := NewMockHandler()
+	clock := glock.NewMockClock()
+	options := WorkerOptions{
+		Name:           "test",
+		WorkerHostname: "test",
+		NumHandlers:    1,
+		Interval:       time.Second,
+		Metrics:        NewMetrics(&observation.TestContext, "", nil),
+	}
+
+	store.DequeueFunc.PushReturn(TestRecord{ID: 42}, true, nil)
+	store.DequeueFunc.SetDefaultReturn(nil, false, nil)
+	store.MarkErroredFunc.SetDefaultReturn(true, nil)
+	handler.HandleFunc.SetDefaultReturn(errors.Errorf("oops"))
+
+	var (
+		mu   sync.Mutex
+		errs []error
+	)
+	worker := newWorker(context.Background(), store, handler, options, clock)
+	worker.AddRunObserver(func(duration time.Duration, err error) {
+		mu.Lock()
+		errs = append(errs, err)
+		mu.Unlock()
+	})
+	go func() { worker.Start() }()
+	clock.BlockingAdvance(time.Second)
+	worker.Stop()
+
+	if len(errs) != 1 {
+		t.Fatalf("unexpected number of observed runs. want=%d have=%d", 1, len(errs))
+	}
+	if errs[0] == nil || errs[0].Error() != "oops" {
+		t.Errorf("unexpected observed error. want=%q have=%v", "oops", errs[0])
+	}
+}
+
 type nonRetryableTestErr struct{}
 
 func (e nonRetryableTestErr) Error() string      { return "just retry me and see what happens" }
@@ -260,6 +299,35 @@ func TestWorkerBlockingPreDequeueHook(t *testing.T) {
 	}
 }
 
+func TestWorkerPaused(t *testing.T) {
+	store := NewMockStore()
+	handler := NewMockHandler()
+	clock := glock.NewMockClock()
+	options := WorkerOptions{
+		Name:           "test",
+		WorkerHostname: "test",
+		NumHandlers:    1,
+		Interval:       time.Second,
+		Paused:         func() bool { return true },
+		Metrics:        NewMetrics(&observation.TestContext, "", nil),
+	}
+
+	store.DequeueFunc.PushReturn(TestRecord{ID: 42}, true, nil)
+	store.DequeueFunc.SetDefaultReturn(nil, false, nil)
+
+	worker := newWorker(context.Background(), store, handler, options, clock)
+	go func() { worker.Start() }()
+	clock.BlockingAdvance(time.Second)
+	worker.Stop()
+
+	if callCount := len(store.DequeueFunc.History()); callCount != 0 {
+		t.Errorf("unexpected dequeue call count. want=%d have=%d", 0, callCount)
+	}
+	if callCount := len(handler.HandleFunc.History()); callCount != 0 {
+		t.Errorf("unexpected handle call count. want=%d have=%d", 0, callCount)
+	}
+}
+
 func TestWorkerConditionalPreDequeueHook(t *testing.T) {
 	store := NewMockStore()
 	handler := NewMockHandlerWithPreDequeue()
@@ -12,6 +12,12 @@ type IndexJob struct {
 	Indexer     string       `json:"indexer" yaml:"indexer"`
 	IndexerArgs []string     `json:"indexer_args" yaml:"indexer_args"`
 	Outfile     string       `json:"outfile" yaml:"outfile"`
+
+	// Confidence and Reasons explain index jobs inferred from the repository structure, and
+	// are not part of index configuration files. Confidence is between 0 and 1, and Reasons
+	// are human-readable descriptions of the evidence the index job was inferred from.
+	Confidence float64  `json:"-" yaml:"-"`
+	Reasons    []string `json:"-" yaml:"-"`
 }
 
 type DockerStep struct {
@@ -7,6 +7,7 @@ import (
 
 type GitClient interface {
 	FileExists(ctx context.Context, file string) (bool, error)
+	StatPaths(ctx context.Context, paths []string) (map[string]bool, error)
 	RawContents(ctx context.Context, file string) ([]byte, error)
 	ListFiles(ctx context.Context, pattern *regexp.Regexp) ([]string, error)
 }
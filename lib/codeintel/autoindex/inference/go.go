@@ -1,6 +1,7 @@
 package inference
 
 import (
+	"fmt"
 	"path/filepath"
 	"regexp"
 
@@ -28,6 +29,16 @@ func CanIndexGoRepo(gitclient GitClient, paths []string) bool {
 
 const lsifGoImage = "sourcegraph/lsif-go:latest"
 
+const (
+	// goModuleConfidence is the confidence of index jobs inferred from go.mod files, which
+	// fully describe how to fetch the dependencies of a module.
+	goModuleConfidence = 0.9
+
+	// preModuleGoConfidence is the confidence of index jobs inferred from Go files without a
+	// go.mod file, whose dependencies may not be resolvable in GOPATH mode.
+	preModuleGoConfidence = 0.5
+)
+
 func InferGoIndexJobs(gitclient GitClient, paths []string) (indexes []config.IndexJob) {
 	for _, path := range paths {
 		if !isGoModulePath(path) {
@@ -50,29 +61,38 @@ func InferGoIndexJobs(gitclient GitClient, paths []string) (indexes []config.Ind
 			Indexer:     lsifGoImage,
 			IndexerArgs: []string{"lsif-go", "--no-animation"},
 			Outfile:     "",
+			Confidence:  goModuleConfidence,
+			Reasons:     []string{describePath(path)},
 		})
 	}
 	if len(indexes) > 0 {
 		return indexes
 	}
 
+	var goFiles []string
 	for _, path := range paths {
-		if !isPreModuleGoProjectPath(path) {
-			continue
+		if isPreModuleGoProjectPath(path) {
+			goFiles = append(goFiles, path)
 		}
+	}
+	if len(goFiles) == 0 {
+		return nil
+	}
 
-		return []config.IndexJob{
-			{
-				Steps:       nil,
-				Root:        "",
-				Indexer:     lsifGoImage,
-				IndexerArgs: []string{"GO111MODULE=off", "lsif-go", "--no-animation"},
-				Outfile:     "",
+	return []config.IndexJob{
+		{
+			Steps:       nil,
+			Root:        "",
+			Indexer:     lsifGoImage,
+			IndexerArgs: []string{"GO111MODULE=off", "lsif-go", "--no-animation"},
+			Outfile:     "",
+			Confidence:  preModuleGoConfidence,
+			Reasons: []string{
+				"found no go.mod file",
+				fmt.Sprintf("found %d Go files at the repository root, indexing in GOPATH mode", len(goFiles)),
 			},
-		}
+		},
 	}
-
-	return nil
 }
 
 var goSegmentBlockList = append([]string{"vendor"}, segmentBlockList...)
@@ -85,6 +85,8 @@ func TestInferGoIndexJobsGoModRoot(t *testing.T) {
 			Indexer:     lsifGoImage,
 			IndexerArgs: []string{"lsif-go", "--no-animation"},
 			Outfile:     "",
+			Confidence:  goModuleConfidence,
+			Reasons:     []string{"found go.mod at the repository root"},
 		},
 	}
 	if diff := cmp.Diff(expectedIndexJobs, InferGoIndexJobs(NewMockGitClient(), paths)); diff != "" {
@@ -112,6 +114,8 @@ func TestInferGoIndexJobsGoModSubdirs(t *testing.T) {
 			Indexer:     lsifGoImage,
 			IndexerArgs: []string{"lsif-go", "--no-animation"},
 			Outfile:     "",
+			Confidence:  goModuleConfidence,
+			Reasons:     []string{"found go.mod at a"},
 		},
 		{
 			Steps: []config.DockerStep{
@@ -125,6 +129,8 @@ func TestInferGoIndexJobsGoModSubdirs(t *testing.T) {
 			Indexer:     lsifGoImage,
 			IndexerArgs: []string{"lsif-go", "--no-animation"},
 			Outfile:     "",
+			Confidence:  goModuleConfidence,
+			Reasons:     []string{"found go.mod at b"},
 		},
 		{
 			Steps: []config.DockerStep{
@@ -138,6 +144,8 @@ func TestInferGoIndexJobsGoModSubdirs(t *testing.T) {
 			Indexer:     lsifGoImage,
 			IndexerArgs: []string{"lsif-go", "--no-animation"},
 			Outfile:     "",
+			Confidence:  goModuleConfidence,
+			Reasons:     []string{"found go.mod at c"},
 		},
 	}
 	if diff := cmp.Diff(expectedIndexJobs, InferGoIndexJobs(NewMockGitClient(), paths)); diff != "" {
@@ -159,6 +167,11 @@ func TestInferGoIndexJobsNoGoModFile(t *testing.T) {
 			Indexer:     lsifGoImage,
 			IndexerArgs: []string{"GO111MODULE=off", "lsif-go", "--no-animation"},
 			Outfile:     "",
+			Confidence:  preModuleGoConfidence,
+			Reasons: []string{
+				"found no go.mod file",
+				"found 3 Go files at the repository root, indexing in GOPATH mode",
+			},
 		},
 	}
 	if diff := cmp.Diff(expectedIndexJobs, InferGoIndexJobs(NewMockGitClient(), paths)); diff != "" {
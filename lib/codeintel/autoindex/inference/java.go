@@ -15,6 +15,10 @@ func CanIndexJavaRepo(gitserver GitClient, paths []string) bool {
 	return false
 }
 
+// javaConfidence is the confidence of index jobs inferred from lsif-java.json files, which
+// are written for package repositories that lsif-java knows how to build.
+const javaConfidence = 0.9
+
 func InferJavaIndexJobs(gitserver GitClient, paths []string) (indexes []config.IndexJob) {
 	for _, path := range paths {
 		if !isJavaPath(path) {
@@ -25,9 +29,11 @@ func InferJavaIndexJobs(gitserver GitClient, paths []string) (indexes []config.I
 			IndexerArgs: []string{
 				"/coursier launch --contrib --ttl 0 lsif-java -- index",
 			},
-			Outfile: "dump.lsif",
-			Root:    "",
-			Steps:   []config.DockerStep{},
+			Outfile:    "dump.lsif",
+			Root:       "",
+			Steps:      []config.DockerStep{},
+			Confidence: javaConfidence,
+			Reasons:    []string{describePath(path)},
 		})
 	}
 	return indexes
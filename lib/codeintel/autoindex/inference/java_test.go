@@ -52,9 +52,11 @@ func TestInferJavaIndexJobs(t *testing.T) {
 			IndexerArgs: []string{
 				"/coursier launch --contrib --ttl 0 lsif-java -- index",
 			},
-			Outfile: "dump.lsif",
-			Root:    "",
-			Steps:   []config.DockerStep{},
+			Outfile:    "dump.lsif",
+			Root:       "",
+			Steps:      []config.DockerStep{},
+			Confidence: javaConfidence,
+			Reasons:    []string{"found lsif-java.json at the repository root"},
 		},
 	}
 	if diff := cmp.Diff(expectedIndexJobs, InferJavaIndexJobs(NewMockGitClient(), paths)); diff != "" {
@@ -22,6 +22,9 @@ type MockGitClient struct {
 	// RawContentsFunc is an instance of a mock function object controlling
 	// the behavior of the method RawContents.
 	RawContentsFunc *GitClientRawContentsFunc
+	// StatPathsFunc is an instance of a mock function object controlling the
+	// behavior of the method StatPaths.
+	StatPathsFunc *GitClientStatPathsFunc
 }
 
 // NewMockGitClient creates a new mock of the GitClient interface. All
@@ -43,6 +46,11 @@ func NewMockGitClient() *MockGitClient {
 				return nil, nil
 			},
 		},
+		StatPathsFunc: &GitClientStatPathsFunc{
+			defaultHook: func(context.Context, []string) (map[string]bool, error) {
+				return nil, nil
+			},
+		},
 	}
 }
 
@@ -59,6 +67,9 @@ func NewMockGitClientFrom(i GitClient) *MockGitClient {
 		RawContentsFunc: &GitClientRawContentsFunc{
 			defaultHook: i.RawContents,
 		},
+		StatPathsFunc: &GitClientStatPathsFunc{
+			defaultHook: i.StatPaths,
+		},
 	}
 }
 
@@ -386,3 +397,109 @@ func (c GitClientRawContentsFuncCall) Args() []interface{} {
 func (c GitClientRawContentsFuncCall) Results() []interface{} {
 	return []interface{}{c.Result0, c.Result1}
 }
+
+// GitClientStatPathsFunc describes the behavior when the StatPaths method
+// of the parent MockGitClient instance is invoked.
+type GitClientStatPathsFunc struct {
+	defaultHook func(context.Context, []string) (map[string]bool, error)
+	hooks       []func(context.Context, []string) (map[string]bool, error)
+	history     []GitClientStatPathsFuncCall
+	mutex       sync.Mutex
+}
+
+// StatPaths delegates to the next hook function in the queue and stores the
+// parameter and result values of this invocation.
+func (m *MockGitClient) StatPaths(v0 context.Context, v1 []string) (map[string]bool, error) {
+	r0, r1 := m.StatPathsFunc.nextHook()(v0, v1)
+	m.StatPathsFunc.appendCall(GitClientStatPathsFuncCall{v0, v1, r0, r1})
+	return r0, r1
+}
+
+// SetDefaultHook sets function that is called when the StatPaths method of
+// the parent MockGitClient instance is invoked and the hook queue is empty.
+func (f *GitClientStatPathsFunc) SetDefaultHook(hook func(context.Context, []string) (map[string]bool, error)) {
+	f.defaultHook = hook
+}
+
+// PushHook adds a function to the end of hook queue. Each invocation of the
+// StatPaths method of the parent MockGitClient instance invokes the hook at
+// the front of the queue and discards it. After the queue is empty, the
+// default hook function is invoked for any future action.
+func (f *GitClientStatPathsFunc) PushHook(hook func(context.Context, []string) (map[string]bool, error)) {
+	f.mutex.Lock()
+	f.hooks = append(f.hooks, hook)
+	f.mutex.Unlock()
+}
+
+// SetDefaultReturn calls SetDefaultDefaultHook with a function that returns
+// the given values.
+func (f *GitClientStatPathsFunc) SetDefaultReturn(r0 map[string]bool, r1 error) {
+	f.SetDefaultHook(func(context.Context, []string) (map[string]bool, error) {
+		return r0, r1
+	})
+}
+
+// PushReturn calls PushDefaultHook with a function that returns the given
+// values.
+func (f *GitClientStatPathsFunc) PushReturn(r0 map[string]bool, r1 error) {
+	f.PushHook(func(context.Context, []string) (map[string]bool, error) {
+		return r0, r1
+	})
+}
+
+func (f *GitClientStatPathsFunc) nextHook() func(context.Context, []string) (map[string]bool, error) {
+	f.mutex.Lock()
+	defer f.mutex.Unlock()
+
+	if len(f.hooks) == 0 {
+		return f.defaultHook
+	}
+
+	hook := f.hooks[0]
+	f.hooks = f.hooks[1:]
+	return hook
+}
+
+func (f *GitClientStatPathsFunc) appendCall(r0 GitClientStatPathsFuncCall) {
+	f.mutex.Lock()
+	f.history = append(f.history, r0)
+	f.mutex.Unlock()
+}
+
+// History returns a sequence of GitClientStatPathsFuncCall objects
+// describing the invocations of this function.
+func (f *GitClientStatPathsFunc) History() []GitClientStatPathsFuncCall {
+	f.mutex.Lock()
+	history := make([]GitClientStatPathsFuncCall, len(f.history))
+	copy(history, f.history)
+	f.mutex.Unlock()
+
+	return history
+}
+
+// GitClientStatPathsFuncCall is an object that describes an invocation of
+// method StatPaths on an instance of MockGitClient.
+type GitClientStatPathsFuncCall struct {
+	// Arg0 is the value of the 1st argument passed to this method invocation.
+	Arg0 context.Context
+	// Arg1 is the value of the 2nd argument passed to this method invocation.
+	Arg1 []string
+	// Result0 is the value of the 1st result returned from this method
+	// invocation.
+	Result0 map[string]bool
+	// Result1 is the value of the 2nd result returned from this method
+	// invocation.
+	Result1 error
+}
+
+// Args returns an interface slice containing the arguments of this
+// invocation.
+func (c GitClientStatPathsFuncCall) Args() []interface{} {
+	return []interface{}{c.Arg0, c.Arg1}
+}
+
+// Results returns an interface slice containing the results of this
+// invocation.
+func (c GitClientStatPathsFuncCall) Results() []interface{} {
+	return []interface{}{c.Result0, c.Result1}
+}
@@ -1,6 +1,9 @@
 package inference
 
-import "path/filepath"
+import (
+	"fmt"
+	"path/filepath"
+)
 
 // dirWithoutDot returns the directory name of the given path. Unlike filepath.Dir,
 // this function will return an empty string (instead of a `.`) to indicate an empty
@@ -12,6 +15,21 @@ func dirWithoutDot(path string) string {
 	return ""
 }
 
+// describeDir returns a human-readable name of the given directory for the reasons
+// of inferred index jobs.
+func describeDir(dir string) string {
+	if dir == "" || dir == "." {
+		return "the repository root"
+	}
+	return dir
+}
+
+// describePath returns a human-readable location of the given file for the reasons
+// of inferred index jobs.
+func describePath(path string) string {
+	return fmt.Sprintf("found %s at %s", filepath.Base(path), describeDir(dirWithoutDot(path)))
+}
+
 // ancestorDirs returns all ancestor dirnames of the given path. The last element of
 // the returned slice will always be empty (indicating the repository root).
 func ancestorDirs(path string) (ancestors []string) {
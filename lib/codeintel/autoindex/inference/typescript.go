@@ -3,8 +3,10 @@ package inference
 import (
 	"context"
 	"encoding/json"
+	"fmt"
 	"path/filepath"
 	"regexp"
+	"strings"
 
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/autoindex/config"
 )
@@ -34,16 +36,45 @@ func CanIndexTypeScriptRepo(gitclient GitClient, paths []string) bool {
 const lsifTscImage = "sourcegraph/lsif-node:autoindex"
 const nMuslCommand = "N_NODE_MIRROR=https://unofficial-builds.nodejs.org/download/release n --arch x64-musl auto"
 
+const (
+	// typeScriptConfidence is the confidence of index jobs inferred from tsconfig.json files
+	// without package.json files, whose dependencies cannot be installed.
+	typeScriptConfidence = 0.6
+
+	// typeScriptWithDependenciesConfidence is the confidence of index jobs inferred from
+	// tsconfig.json files whose dependencies are installed from package.json files.
+	typeScriptWithDependenciesConfidence = 0.8
+
+	// typeScriptWithNodeVersionConfidence is the confidence of index jobs whose dependencies
+	// are installed with the Node.js version the project requires.
+	typeScriptWithNodeVersionConfidence = 0.9
+)
+
 func InferTypeScriptIndexJobs(gitclient GitClient, paths []string) (indexes []config.IndexJob) {
 	for _, path := range paths {
 		if !canIndexTypeScriptPath(path) {
 			continue
 		}
 
+		reasons := []string{describePath(path)}
+
 		// check first if anywhere along the ancestor path there is a lerna.json
 		isYarn := checkLernaFile(gitclient, path, paths)
+		if lernaDir, ok := lernaRoot(path, paths); ok {
+			packages := "packages"
+			n := countLernaPackages(lernaDir, paths)
+			if n == 1 {
+				packages = "package"
+			}
+			reason := fmt.Sprintf("detected lerna monorepo with %d %s at %s", n, packages, describeDir(lernaDir))
+			if isYarn {
+				reason += ", using yarn"
+			}
+			reasons = append(reasons, reason)
+		}
 
 		var dockerSteps []config.DockerStep
+		var installReasons []string
 		for _, dir := range ancestorDirs(path) {
 			if !contains(paths, filepath.Join(dir, "package.json")) {
 				continue
@@ -52,8 +83,10 @@ func InferTypeScriptIndexJobs(gitclient GitClient, paths []string) (indexes []co
 			var commands []string
 			if isYarn || contains(paths, filepath.Join(dir, "yarn.lock")) {
 				commands = append(commands, "yarn --ignore-engines")
+				installReasons = append(installReasons, fmt.Sprintf("found package.json at %s, installing dependencies with yarn", describeDir(dir)))
 			} else {
 				commands = append(commands, "npm install")
+				installReasons = append(installReasons, fmt.Sprintf("found package.json at %s, installing dependencies with npm", describeDir(dir)))
 			}
 
 			dockerSteps = append(dockerSteps, config.DockerStep{
@@ -63,20 +96,33 @@ func InferTypeScriptIndexJobs(gitclient GitClient, paths []string) (indexes []co
 			})
 		}
 
+		confidence := typeScriptConfidence
+		if len(dockerSteps) > 0 {
+			confidence = typeScriptWithDependenciesConfidence
+		}
+
 		var localSteps []string
-		if checkCanDeriveNodeVersion(gitclient, path, paths) {
+		var nodeVersionReasons []string
+		if nodeVersionPath, ok := checkCanDeriveNodeVersion(gitclient, path, paths); ok {
 			for i, step := range dockerSteps {
 				step.Commands = append([]string{nMuslCommand}, step.Commands...)
 				dockerSteps[i] = step
 			}
 
 			localSteps = append(localSteps, nMuslCommand)
+			nodeVersionReasons = append(nodeVersionReasons, fmt.Sprintf("derived the Node.js version from %s", nodeVersionPath))
+			if len(dockerSteps) > 0 {
+				confidence = typeScriptWithNodeVersionConfidence
+			}
 		}
 
 		n := len(dockerSteps)
 		for i := 0; i < n/2; i++ {
 			dockerSteps[i], dockerSteps[n-i-1] = dockerSteps[n-i-1], dockerSteps[i]
+			installReasons[i], installReasons[n-i-1] = installReasons[n-i-1], installReasons[i]
 		}
+		reasons = append(reasons, installReasons...)
+		reasons = append(reasons, nodeVersionReasons...)
 
 		indexes = append(indexes, config.IndexJob{
 			Steps:       dockerSteps,
@@ -85,6 +131,8 @@ func InferTypeScriptIndexJobs(gitclient GitClient, paths []string) (indexes []co
 			Indexer:     lsifTscImage,
 			IndexerArgs: []string{"lsif-tsc", "-p", "."},
 			Outfile:     "",
+			Confidence:  confidence,
+			Reasons:     reasons,
 		})
 	}
 
@@ -110,7 +158,9 @@ func checkLernaFile(gitclient GitClient, path string, paths []string) (isYarn bo
 	return
 }
 
-func checkCanDeriveNodeVersion(gitclient GitClient, path string, paths []string) bool {
+// checkCanDeriveNodeVersion returns the path of the nearest file from which the Node.js
+// version required by the project at the given path can be derived, if any.
+func checkCanDeriveNodeVersion(gitclient GitClient, path string, paths []string) (string, bool) {
 	for _, dir := range ancestorDirs(path) {
 		packageJSONPath := filepath.Join(dir, "package.json")
 		nvmrcPath := filepath.Join(dir, ".nvmrc")
@@ -118,15 +168,42 @@ func checkCanDeriveNodeVersion(gitclient GitClient, path string, paths []string)
 		nnodeVersionPath := filepath.Join(dir, ".n-node-version")
 
 		// TODO - refactor this
-		if (contains(paths, packageJSONPath) && hasEnginesField(gitclient, packageJSONPath)) ||
-			contains(paths, nvmrcPath) ||
-			contains(paths, nodeVersionPath) ||
-			contains(paths, nnodeVersionPath) {
-			return true
+		if contains(paths, packageJSONPath) && hasEnginesField(gitclient, packageJSONPath) {
+			return packageJSONPath, true
+		}
+		for _, versionPath := range []string{nvmrcPath, nodeVersionPath, nnodeVersionPath} {
+			if contains(paths, versionPath) {
+				return versionPath, true
+			}
 		}
 	}
 
-	return false
+	return "", false
+}
+
+// lernaRoot returns the directory of the nearest lerna.json file among the ancestors of
+// the given path, if any.
+func lernaRoot(path string, paths []string) (string, bool) {
+	for _, dir := range ancestorDirs(path) {
+		if contains(paths, filepath.Join(dir, "lerna.json")) {
+			return dir, true
+		}
+	}
+	return "", false
+}
+
+// countLernaPackages returns the number of package.json files below the lerna monorepo
+// at the given directory, excluding the package.json of the monorepo itself.
+func countLernaPackages(dir string, paths []string) (n int) {
+	for _, path := range paths {
+		if filepath.Base(path) != "package.json" || path == filepath.Join(dir, "package.json") {
+			continue
+		}
+		if dir == "" || strings.HasPrefix(path, dir+"/") {
+			n++
+		}
+	}
+	return n
 }
 
 func hasEnginesField(gitclient GitClient, packageJSONPath string) (hasField bool) {
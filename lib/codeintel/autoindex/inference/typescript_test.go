@@ -78,6 +78,9 @@ func TestInferTypeScriptIndexJobsTsConfigRoot(t *testing.T) {
 			Indexer:     lsifTscImage,
 			IndexerArgs: []string{"lsif-tsc", "-p", "."},
 			Outfile:     "",
+
+			Confidence: typeScriptConfidence,
+			Reasons:    []string{"found tsconfig.json at the repository root"},
 		},
 	}
 	if diff := cmp.Diff(expectedIndexJobs, InferTypeScriptIndexJobs(NewMockGitClient(), paths)); diff != "" {
@@ -99,6 +102,9 @@ func TestInferTypeScriptIndexJobsTsConfigSubdirs(t *testing.T) {
 			Indexer:     lsifTscImage,
 			IndexerArgs: []string{"lsif-tsc", "-p", "."},
 			Outfile:     "",
+
+			Confidence: typeScriptConfidence,
+			Reasons:    []string{"found tsconfig.json at a"},
 		},
 		{
 			Steps:       nil,
@@ -106,6 +112,9 @@ func TestInferTypeScriptIndexJobsTsConfigSubdirs(t *testing.T) {
 			Indexer:     lsifTscImage,
 			IndexerArgs: []string{"lsif-tsc", "-p", "."},
 			Outfile:     "",
+
+			Confidence: typeScriptConfidence,
+			Reasons:    []string{"found tsconfig.json at b"},
 		},
 		{
 			Steps:       nil,
@@ -113,6 +122,9 @@ func TestInferTypeScriptIndexJobsTsConfigSubdirs(t *testing.T) {
 			Indexer:     lsifTscImage,
 			IndexerArgs: []string{"lsif-tsc", "-p", "."},
 			Outfile:     "",
+
+			Confidence: typeScriptConfidence,
+			Reasons:    []string{"found tsconfig.json at c"},
 		},
 	}
 	if diff := cmp.Diff(expectedIndexJobs, InferTypeScriptIndexJobs(NewMockGitClient(), paths)); diff != "" {
@@ -145,6 +157,12 @@ func TestInferTypeScriptIndexJobsInstallSteps(t *testing.T) {
 			Indexer:     lsifTscImage,
 			IndexerArgs: []string{"lsif-tsc", "-p", "."},
 			Outfile:     "",
+
+			Confidence: typeScriptWithDependenciesConfidence,
+			Reasons: []string{
+				"found tsconfig.json at the repository root",
+				"found package.json at the repository root, installing dependencies with npm",
+			},
 		},
 		{
 			Steps: []config.DockerStep{
@@ -158,6 +176,12 @@ func TestInferTypeScriptIndexJobsInstallSteps(t *testing.T) {
 			Indexer:     lsifTscImage,
 			IndexerArgs: []string{"lsif-tsc", "-p", "."},
 			Outfile:     "",
+
+			Confidence: typeScriptWithDependenciesConfidence,
+			Reasons: []string{
+				"found tsconfig.json at foo/baz",
+				"found package.json at the repository root, installing dependencies with npm",
+			},
 		},
 		{
 			Steps: []config.DockerStep{
@@ -176,6 +200,13 @@ func TestInferTypeScriptIndexJobsInstallSteps(t *testing.T) {
 			Indexer:     lsifTscImage,
 			IndexerArgs: []string{"lsif-tsc", "-p", "."},
 			Outfile:     "",
+
+			Confidence: typeScriptWithDependenciesConfidence,
+			Reasons: []string{
+				"found tsconfig.json at foo/bar/baz",
+				"found package.json at the repository root, installing dependencies with npm",
+				"found package.json at foo/bar, installing dependencies with yarn",
+			},
 		},
 		{
 			Steps: []config.DockerStep{
@@ -199,6 +230,14 @@ func TestInferTypeScriptIndexJobsInstallSteps(t *testing.T) {
 			Indexer:     lsifTscImage,
 			IndexerArgs: []string{"lsif-tsc", "-p", "."},
 			Outfile:     "",
+
+			Confidence: typeScriptWithDependenciesConfidence,
+			Reasons: []string{
+				"found tsconfig.json at foo/bar/bonk",
+				"found package.json at the repository root, installing dependencies with npm",
+				"found package.json at foo/bar, installing dependencies with yarn",
+				"found package.json at foo/bar/bonk, installing dependencies with npm",
+			},
 		},
 	}
 	if diff := cmp.Diff(expectedIndexJobs, InferTypeScriptIndexJobs(NewMockGitClient(), paths)); diff != "" {
@@ -262,6 +301,13 @@ func TestInferTypeScriptIndexJobsTscLernaConfig(t *testing.T) {
 				Indexer:     lsifTscImage,
 				IndexerArgs: []string{"lsif-tsc", "-p", "."},
 				Outfile:     "",
+
+				Confidence: typeScriptWithDependenciesConfidence,
+				Reasons: []string{
+					"found tsconfig.json at the repository root",
+					"detected lerna monorepo with 0 packages at the repository root, using yarn",
+					"found package.json at the repository root, installing dependencies with yarn",
+				},
 			},
 		},
 		{
@@ -278,6 +324,13 @@ func TestInferTypeScriptIndexJobsTscLernaConfig(t *testing.T) {
 				Indexer:     lsifTscImage,
 				IndexerArgs: []string{"lsif-tsc", "-p", "."},
 				Outfile:     "",
+
+				Confidence: typeScriptWithDependenciesConfidence,
+				Reasons: []string{
+					"found tsconfig.json at the repository root",
+					"detected lerna monorepo with 0 packages at the repository root",
+					"found package.json at the repository root, installing dependencies with npm",
+				},
 			},
 		},
 		{
@@ -294,6 +347,12 @@ func TestInferTypeScriptIndexJobsTscLernaConfig(t *testing.T) {
 				Indexer:     lsifTscImage,
 				IndexerArgs: []string{"lsif-tsc", "-p", "."},
 				Outfile:     "",
+
+				Confidence: typeScriptWithDependenciesConfidence,
+				Reasons: []string{
+					"found tsconfig.json at the repository root",
+					"found package.json at the repository root, installing dependencies with npm",
+				},
 			},
 		},
 		{
@@ -315,6 +374,14 @@ func TestInferTypeScriptIndexJobsTscLernaConfig(t *testing.T) {
 				Indexer:     lsifTscImage,
 				IndexerArgs: []string{"lsif-tsc", "-p", "."},
 				Outfile:     "",
+
+				Confidence: typeScriptWithDependenciesConfidence,
+				Reasons: []string{
+					"found tsconfig.json at foo/bar",
+					"detected lerna monorepo with 1 package at the repository root, using yarn",
+					"found package.json at the repository root, installing dependencies with yarn",
+					"found package.json at foo, installing dependencies with yarn",
+				},
 			},
 		},
 	}
@@ -362,6 +429,13 @@ func TestInferTypeScriptIndexJobsNodeVersionInferrence(t *testing.T) {
 				Indexer:     lsifTscImage,
 				IndexerArgs: []string{"lsif-tsc", "-p", "."},
 				Outfile:     "",
+
+				Confidence: typeScriptWithNodeVersionConfidence,
+				Reasons: []string{
+					"found tsconfig.json at the repository root",
+					"found package.json at the repository root, installing dependencies with npm",
+					"derived the Node.js version from .nvmrc",
+				},
 			},
 		},
 		{
@@ -380,6 +454,13 @@ func TestInferTypeScriptIndexJobsNodeVersionInferrence(t *testing.T) {
 				Indexer:     lsifTscImage,
 				IndexerArgs: []string{"lsif-tsc", "-p", "."},
 				Outfile:     "",
+
+				Confidence: typeScriptWithNodeVersionConfidence,
+				Reasons: []string{
+					"found tsconfig.json at the repository root",
+					"found package.json at the repository root, installing dependencies with npm",
+					"derived the Node.js version from package.json",
+				},
 			},
 		},
 	}
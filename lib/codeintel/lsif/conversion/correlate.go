@@ -7,6 +7,7 @@ import (
 	"os/exec"
 	"path/filepath"
 	"strings"
+	"time"
 
 	"github.com/cockroachdb/errors"
 	"github.com/inconshreveable/log15"
@@ -23,12 +24,37 @@ import (
 //
 // If getChildren == nil, no pruning of irrelevant data is performed.
 func Correlate(ctx context.Context, r io.Reader, root string, getChildren pathexistence.GetChildrenFunc) (*semantic.GroupedBundleDataChans, error) {
+	return CorrelateWithTimings(ctx, r, root, getChildren, nil)
+}
+
+// CorrelationTimings is the time spent in each phase of a correlation.
+type CorrelationTimings struct {
+	// Parse is the time spent reading and parsing the raw LSIF data.
+	Parse time.Duration
+	// Correlate is the time spent canonicalizing and pruning the parsed data. The grouped
+	// bundle data is produced lazily while its channels are consumed, so grouping is not
+	// included.
+	Correlate time.Duration
+}
+
+// CorrelateWithTimings is like Correlate, but also records the time spent in each phase into
+// timings, if it is not nil.
+func CorrelateWithTimings(ctx context.Context, r io.Reader, root string, getChildren pathexistence.GetChildrenFunc, timings *CorrelationTimings) (*semantic.GroupedBundleDataChans, error) {
+	if timings == nil {
+		timings = &CorrelationTimings{}
+	}
+
 	// Read raw upload stream and return a correlation state
+	start := time.Now()
 	state, err := correlateFromReader(ctx, r, root)
+	timings.Parse = time.Since(start)
 	if err != nil {
 		return nil, err
 	}
 
+	start = time.Now()
+	defer func() { timings.Correlate = time.Since(start) }()
+
 	// Remove duplicate elements, collapse linked elements
 	canonicalize(state)
 
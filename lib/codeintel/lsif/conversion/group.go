@@ -10,6 +10,7 @@ import (
 
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/bloomfilter"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/conversion/datastructures"
+	"github.com/sourcegraph/sourcegraph/lib/codeintel/lsif/protocol"
 	"github.com/sourcegraph/sourcegraph/lib/codeintel/semantic"
 )
 
@@ -80,6 +81,16 @@ func serializeBundleDocuments(ctx context.Context, state *State) chan semantic.K
 	return ch
 }
 
+// definitionTag returns the given tag if it describes a definition. Other tags are
+// not used after conversion and are dropped to keep the serialized document small.
+func definitionTag(tag *protocol.RangeTag) *protocol.RangeTag {
+	if tag == nil || tag.Type != "definition" {
+		return nil
+	}
+
+	return tag
+}
+
 func serializeDocument(state *State, documentID int) semantic.DocumentData {
 	document := semantic.DocumentData{
 		Ranges:             make(map[semantic.ID]semantic.RangeData, state.Contains.SetLen(documentID)),
@@ -123,6 +134,7 @@ func serializeDocument(state *State, documentID int) semantic.DocumentData {
 			HoverResultID:         toID(rangeData.HoverResultID),
 			DocumentationResultID: toID(rangeData.DocumentationResultID),
 			MonikerIDs:            monikerIDs,
+			Tag:                   definitionTag(rangeData.Tag),
 		}
 
 		if rangeData.HoverResultID != 0 {
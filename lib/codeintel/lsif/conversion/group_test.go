@@ -29,6 +29,7 @@ func TestGroupBundleData(t *testing.T) {
 						Start: protocol.Pos{Line: 1, Character: 2},
 						End:   protocol.Pos{Line: 3, Character: 4},
 					},
+					Tag: &protocol.RangeTag{Type: "definition", Text: "foo", Kind: protocol.Function},
 				},
 				DefinitionResultID: 3001,
 				ReferenceResultID:  0,
@@ -39,6 +40,7 @@ func TestGroupBundleData(t *testing.T) {
 						Start: protocol.Pos{Line: 2, Character: 3},
 						End:   protocol.Pos{Line: 4, Character: 5},
 					},
+					Tag: &protocol.RangeTag{Type: "reference", Text: "foo", Kind: protocol.Function},
 				},
 				DefinitionResultID: 0,
 				ReferenceResultID:  3006,
@@ -348,6 +350,7 @@ func TestGroupBundleData(t *testing.T) {
 					ReferenceResultID:  "",
 					HoverResultID:      "",
 					MonikerIDs:         []semantic.ID{"4001", "4002"},
+					Tag:                &protocol.RangeTag{Type: "definition", Text: "foo", Kind: protocol.Function},
 				},
 				"2002": {
 					StartLine:          2,
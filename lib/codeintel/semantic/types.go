@@ -34,6 +34,10 @@ type RangeData struct {
 	HoverResultID         ID   // possibly empty
 	DocumentationResultID ID   // possibly empty
 	MonikerIDs            []ID // possibly empty
+
+	// Tag is the symbol metadata the indexer attached to the range. This is only
+	// retained for ranges tagged as definitions and is nil otherwise.
+	Tag *protocol.RangeTag
 }
 
 // MonikerData represent a unique name (eventually) attached to a range.
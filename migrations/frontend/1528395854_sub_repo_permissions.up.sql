@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS sub_repo_permissions (
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    version integer NOT NULL DEFAULT 1,
+    path_includes text[],
+    path_excludes text[],
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS sub_repo_permissions_repo_id_user_id_version_uindex ON sub_repo_permissions (repo_id, user_id, version);
+CREATE INDEX IF NOT EXISTS sub_repo_perms_user_id ON sub_repo_permissions (user_id);
+
+COMMENT ON TABLE sub_repo_permissions IS 'Responsible for storing permissions at a finer granularity than repo';
+
+COMMIT;
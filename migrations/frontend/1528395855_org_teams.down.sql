@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP TABLE IF EXISTS org_team_repos;
+DROP TABLE IF EXISTS org_team_members;
+DROP TABLE IF EXISTS org_teams;
+
+COMMIT;
@@ -0,0 +1,43 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS org_teams (
+    id bigserial PRIMARY KEY,
+    org_id integer NOT NULL REFERENCES orgs(id) ON DELETE CASCADE,
+    parent_team_id bigint REFERENCES org_teams(id) ON DELETE CASCADE,
+    name citext NOT NULL,
+    display_name text,
+    external_service_id bigint REFERENCES external_services(id) ON DELETE SET NULL,
+    external_id text,
+    synced_at timestamp with time zone,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT org_teams_name_max_length CHECK (char_length(name::text) <= 255),
+    CONSTRAINT org_teams_name_valid_chars CHECK (name ~ '^[a-zA-Z0-9](?:[a-zA-Z0-9]|[-.](?=[a-zA-Z0-9]))*-?$'::citext),
+    CONSTRAINT org_teams_parent_team_id_not_self CHECK (parent_team_id <> id)
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS org_teams_org_id_name ON org_teams (org_id, name);
+CREATE INDEX IF NOT EXISTS org_teams_parent_team_id ON org_teams (parent_team_id);
+
+COMMENT ON TABLE org_teams IS 'Teams of an organization. Teams can be nested with parent_team_id, and mapped to a team on a code host with external_service_id and external_id to sync their members.';
+COMMENT ON COLUMN org_teams.external_id IS 'The team on the code host. For GitHub this is "org/team-slug", for GitLab the full path of the group.';
+
+CREATE TABLE IF NOT EXISTS org_team_members (
+    team_id bigint NOT NULL REFERENCES org_teams(id) ON DELETE CASCADE,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (team_id, user_id)
+);
+
+CREATE INDEX IF NOT EXISTS org_team_members_user_id ON org_team_members (user_id);
+
+CREATE TABLE IF NOT EXISTS org_team_repos (
+    team_id bigint NOT NULL REFERENCES org_teams(id) ON DELETE CASCADE,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (team_id, repo_id)
+);
+
+CREATE INDEX IF NOT EXISTS org_team_repos_repo_id ON org_team_repos (repo_id);
+
+COMMIT;
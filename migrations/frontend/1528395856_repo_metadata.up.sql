@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS repo_metadata (
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    key text NOT NULL,
+    value text NOT NULL DEFAULT '',
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (repo_id, key),
+    CONSTRAINT repo_metadata_key_not_empty CHECK (key <> '')
+);
+
+CREATE INDEX IF NOT EXISTS repo_metadata_key_value ON repo_metadata (key, value);
+
+COMMENT ON TABLE repo_metadata IS 'Custom key/value metadata of repositories, e.g. team:payments or tier:1. Repositories can be filtered by metadata with repo:has.meta(key=value).';
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE batch_spec_executions ADD COLUMN IF NOT EXISTS access_token_id bigint REFERENCES access_tokens(id) ON DELETE SET NULL DEFERRABLE;
+
+COMMENT ON COLUMN batch_spec_executions.access_token_id IS 'The access token created for src-cli to act on behalf of the user while the execution is running. It is deleted when the execution finishes.';
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS gitserver_repos_clone_skip_reason_idx;
+
+ALTER TABLE gitserver_repos DROP COLUMN IF EXISTS clone_skip_reason;
+
+COMMIT;
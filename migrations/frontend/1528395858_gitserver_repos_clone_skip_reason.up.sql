@@ -0,0 +1,9 @@
+BEGIN;
+
+ALTER TABLE gitserver_repos ADD COLUMN IF NOT EXISTS clone_skip_reason text;
+
+CREATE INDEX IF NOT EXISTS gitserver_repos_clone_skip_reason_idx ON gitserver_repos (repo_id) WHERE clone_skip_reason IS NOT NULL;
+
+COMMENT ON COLUMN gitserver_repos.clone_skip_reason IS 'Why gitserver did not clone the repository, e.g. because it is larger than the maxRepoSize of its code host connection. NULL if the repository was not skipped.';
+
+COMMIT;
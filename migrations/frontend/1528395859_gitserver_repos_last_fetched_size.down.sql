@@ -0,0 +1,7 @@
+BEGIN;
+
+ALTER TABLE gitserver_repos
+    DROP COLUMN IF EXISTS last_fetched,
+    DROP COLUMN IF EXISTS repo_size_bytes;
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+ALTER TABLE gitserver_repos
+    ADD COLUMN IF NOT EXISTS last_fetched timestamp with time zone,
+    ADD COLUMN IF NOT EXISTS repo_size_bytes bigint;
+
+COMMENT ON COLUMN gitserver_repos.last_fetched IS 'When gitserver last successfully cloned or fetched the repository. NULL if it was never fetched since this column was added.';
+COMMENT ON COLUMN gitserver_repos.repo_size_bytes IS 'The size of the repository on gitserver after it was last fetched.';
+
+COMMIT;
@@ -0,0 +1,17 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS service_instances (
+    service text NOT NULL,
+    instance text NOT NULL,
+    version text NOT NULL,
+    started_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (service, instance)
+);
+
+COMMENT ON TABLE service_instances IS 'The running instances of each Sourcegraph service and their build version, used to detect version skew between services.';
+COMMENT ON COLUMN service_instances.instance IS 'The hostname of the instance.';
+COMMENT ON COLUMN service_instances.started_at IS 'When the instance last started.';
+COMMENT ON COLUMN service_instances.updated_at IS 'When the instance last reported that it is running. Instances that stopped reporting are ignored.';
+
+COMMIT;
@@ -0,0 +1,24 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS external_service_config_revisions (
+    id bigserial PRIMARY KEY,
+    external_service_id bigint NOT NULL REFERENCES external_services(id) ON DELETE CASCADE DEFERRABLE,
+    config text NOT NULL,
+    encryption_key_id text NOT NULL DEFAULT '',
+    author_user_id integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS external_service_config_revisions_external_service_id_idx ON external_service_config_revisions(external_service_id, id);
+
+COMMENT ON TABLE external_service_config_revisions IS 'Every revision of the config of each external service, so that a bad edit can be reverted.';
+COMMENT ON COLUMN external_service_config_revisions.config IS 'The config of the external service as of this revision, encrypted in the same way as external_services.config.';
+COMMENT ON COLUMN external_service_config_revisions.author_user_id IS 'The user that created the revision, or null if it was created internally.';
+
+-- Record the current config of existing external services as their first revision.
+INSERT INTO external_service_config_revisions (external_service_id, config, encryption_key_id, created_at)
+SELECT id, config, encryption_key_id, updated_at
+FROM external_services
+WHERE deleted_at IS NULL;
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+ALTER TABLE batch_changes DROP COLUMN IF EXISTS version;
+
+COMMIT;
@@ -0,0 +1,7 @@
+BEGIN;
+
+-- The version is incremented on every update of a batch change, so that
+-- concurrent updates based on the same read can be detected.
+ALTER TABLE batch_changes ADD COLUMN IF NOT EXISTS version integer NOT NULL DEFAULT 1;
+
+COMMIT;
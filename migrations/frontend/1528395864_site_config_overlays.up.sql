@@ -0,0 +1,17 @@
+BEGIN;
+
+-- Named site configuration overlays are merged on top of the site
+-- configuration in ascending order of position (and name, for overlays with
+-- the same position).
+CREATE TABLE IF NOT EXISTS site_config_overlays (
+    id serial PRIMARY KEY,
+    name text NOT NULL,
+    position integer NOT NULL DEFAULT 0,
+    contents text NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS site_config_overlays_name_unique ON site_config_overlays(name);
+
+COMMIT;
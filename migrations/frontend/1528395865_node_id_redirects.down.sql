@@ -0,0 +1,8 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trig_redirect_recreated_repo ON repo;
+DROP FUNCTION IF EXISTS redirect_recreated_repo();
+DROP INDEX IF EXISTS repo_deleted_original_name_idx;
+DROP TABLE IF EXISTS node_id_redirects;
+
+COMMIT;
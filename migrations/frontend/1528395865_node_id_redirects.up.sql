@@ -0,0 +1,35 @@
+BEGIN;
+
+-- node_id_redirects maps the IDs of GraphQL nodes that no longer exist to the
+-- nodes that replaced them, so that references to the old ID keep resolving.
+CREATE TABLE IF NOT EXISTS node_id_redirects (
+    kind text NOT NULL,
+    old_id bigint NOT NULL,
+    new_id bigint NOT NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (kind, old_id)
+);
+
+-- Soft-deleted repositories are looked up by their name before deletion when
+-- a repository with that name is created again.
+CREATE INDEX IF NOT EXISTS repo_deleted_original_name_idx ON repo (lower(regexp_replace(name::text, '^DELETED-[0-9.]+-', ''))) WHERE deleted_at IS NOT NULL;
+
+CREATE OR REPLACE FUNCTION redirect_recreated_repo() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    INSERT INTO node_id_redirects (kind, old_id, new_id)
+    SELECT 'Repository', repo.id, NEW.id
+    FROM repo
+    WHERE repo.deleted_at IS NOT NULL
+    AND lower(regexp_replace(repo.name::text, '^DELETED-[0-9.]+-', '')) = lower(NEW.name::text)
+    AND repo.id <> NEW.id
+    ON CONFLICT (kind, old_id) DO UPDATE SET new_id = EXCLUDED.new_id, created_at = now();
+
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER trig_redirect_recreated_repo AFTER INSERT ON repo FOR EACH ROW WHEN (NEW.deleted_at IS NULL) EXECUTE PROCEDURE redirect_recreated_repo();
+
+COMMIT;
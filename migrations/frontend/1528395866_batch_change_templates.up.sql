@@ -0,0 +1,25 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS batch_change_templates (
+  id bigserial PRIMARY KEY,
+  name text NOT NULL,
+  description text NOT NULL DEFAULT '',
+  spec text NOT NULL,
+  parameters jsonb NOT NULL DEFAULT '[]'::jsonb,
+  namespace_user_id integer REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+  namespace_org_id integer REFERENCES orgs(id) ON DELETE CASCADE DEFERRABLE,
+  creator_id integer REFERENCES users(id) ON DELETE SET NULL DEFERRABLE,
+  created_at timestamp with time zone NOT NULL DEFAULT now(),
+  updated_at timestamp with time zone NOT NULL DEFAULT now(),
+
+  CONSTRAINT batch_change_templates_has_1_namespace CHECK ((namespace_user_id IS NULL) <> (namespace_org_id IS NULL)),
+  CONSTRAINT batch_change_templates_name_not_blank CHECK (name <> '')
+);
+
+CREATE UNIQUE INDEX IF NOT EXISTS batch_change_templates_namespace_user_id_name ON batch_change_templates (namespace_user_id, name);
+CREATE UNIQUE INDEX IF NOT EXISTS batch_change_templates_namespace_org_id_name ON batch_change_templates (namespace_org_id, name);
+
+COMMENT ON TABLE batch_change_templates IS 'Batch spec templates which are offered to all users of the instance. A template is rendered into a batch spec by substituting its parameters.';
+COMMENT ON COLUMN batch_change_templates.parameters IS 'The parameters of the template as a JSON array of objects with a name, description and default value.';
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trig_audit_log_users ON users;
+DROP TRIGGER IF EXISTS trig_audit_log_access_tokens ON access_tokens;
+DROP TRIGGER IF EXISTS trig_audit_log_external_services ON external_services;
+DROP TRIGGER IF EXISTS trig_audit_log_user_external_accounts ON user_external_accounts;
+DROP FUNCTION IF EXISTS func_audit_log();
+DROP TABLE IF EXISTS audit_log;
+
+COMMIT;
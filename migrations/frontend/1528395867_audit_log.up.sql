@@ -0,0 +1,90 @@
+BEGIN;
+
+-- audit_log is an append-only record of changes to audit-critical tables. Rows
+-- are written by the trig_audit_log_* triggers below.
+CREATE TABLE IF NOT EXISTS audit_log (
+    id bigserial PRIMARY KEY,
+    table_name text NOT NULL,
+    row_id text NOT NULL,
+    operation text NOT NULL,
+    actor_user_id integer,
+    changed_columns text[] NOT NULL DEFAULT '{}',
+    old_data jsonb,
+    new_data jsonb,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS audit_log_table_name_row_id_idx ON audit_log (table_name, row_id);
+CREATE INDEX IF NOT EXISTS audit_log_actor_user_id_idx ON audit_log (actor_user_id);
+CREATE INDEX IF NOT EXISTS audit_log_created_at_idx ON audit_log (created_at);
+
+COMMENT ON TABLE audit_log IS 'Append-only record of changes to audit-critical tables, written by triggers.';
+COMMENT ON COLUMN audit_log.actor_user_id IS 'The user that made the change, as set in the sourcegraph.audit_actor_id setting of the transaction. NULL for internal actors. Not a foreign key, so that entries outlive the user.';
+COMMENT ON COLUMN audit_log.changed_columns IS 'The columns whose value differs between old_data and new_data.';
+COMMENT ON COLUMN audit_log.old_data IS 'The row before the change, without the redacted columns. NULL for inserts.';
+COMMENT ON COLUMN audit_log.new_data IS 'The row after the change, without the redacted columns. NULL for deletes.';
+
+-- func_audit_log records the change that fired the trigger in audit_log. The
+-- trigger arguments are the names of columns whose values must not be stored.
+-- Redacted columns are still reported in changed_columns.
+CREATE OR REPLACE FUNCTION func_audit_log() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+DECLARE
+    old_row jsonb;
+    new_row jsonb;
+    changed text[];
+    actor integer;
+BEGIN
+    IF TG_OP <> 'INSERT' THEN
+        old_row := to_jsonb(OLD);
+    END IF;
+    IF TG_OP <> 'DELETE' THEN
+        new_row := to_jsonb(NEW);
+    END IF;
+
+    SELECT COALESCE(array_agg(key ORDER BY key), '{}') INTO changed
+    FROM (
+        SELECT key FROM jsonb_object_keys(COALESCE(new_row, old_row)) AS key
+    ) keys
+    WHERE old_row->key IS DISTINCT FROM new_row->key;
+
+    -- Updates that only bump updated_at are not interesting.
+    IF TG_OP = 'UPDATE' AND changed <@ ARRAY['updated_at'] THEN
+        RETURN NULL;
+    END IF;
+
+    actor := NULLIF(NULLIF(current_setting('sourcegraph.audit_actor_id', true), ''), '0')::integer;
+
+    INSERT INTO audit_log (table_name, row_id, operation, actor_user_id, changed_columns, old_data, new_data)
+    VALUES (
+        TG_TABLE_NAME,
+        COALESCE(new_row, old_row)->>'id',
+        TG_OP,
+        actor,
+        changed,
+        old_row - TG_ARGV,
+        new_row - TG_ARGV
+    );
+
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER trig_audit_log_users
+    AFTER INSERT OR DELETE OR UPDATE OF username, display_name, avatar_url, deleted_at, passwd, passwd_reset_code, site_admin, tags, invalidated_sessions_at, billing_customer_id ON users
+    FOR EACH ROW EXECUTE PROCEDURE func_audit_log('passwd', 'passwd_reset_code', 'passwd_reset_time');
+
+CREATE TRIGGER trig_audit_log_access_tokens
+    AFTER INSERT OR DELETE OR UPDATE OF subject_user_id, note, deleted_at, scopes, value_sha256 ON access_tokens
+    FOR EACH ROW EXECUTE PROCEDURE func_audit_log('value_sha256');
+
+CREATE TRIGGER trig_audit_log_external_services
+    AFTER INSERT OR DELETE OR UPDATE OF kind, display_name, config, deleted_at, namespace_user_id, unrestricted, cloud_default ON external_services
+    FOR EACH ROW EXECUTE PROCEDURE func_audit_log('config');
+
+CREATE TRIGGER trig_audit_log_user_external_accounts
+    AFTER INSERT OR DELETE OR UPDATE OF user_id, service_type, service_id, account_id, client_id, auth_data, account_data, deleted_at ON user_external_accounts
+    FOR EACH ROW EXECUTE PROCEDURE func_audit_log('auth_data', 'account_data');
+
+COMMIT;
@@ -0,0 +1,10 @@
+BEGIN;
+
+DROP TRIGGER IF EXISTS trig_audit_log_users ON users;
+CREATE TRIGGER trig_audit_log_users
+    AFTER INSERT OR DELETE OR UPDATE OF username, display_name, avatar_url, deleted_at, passwd, passwd_reset_code, site_admin, tags, invalidated_sessions_at, billing_customer_id ON users
+    FOR EACH ROW EXECUTE PROCEDURE func_audit_log('passwd', 'passwd_reset_code', 'passwd_reset_time');
+
+ALTER TABLE users DROP COLUMN IF EXISTS site_auditor;
+
+COMMIT;
@@ -0,0 +1,13 @@
+BEGIN;
+
+ALTER TABLE users ADD COLUMN IF NOT EXISTS site_auditor boolean DEFAULT false NOT NULL;
+
+COMMENT ON COLUMN users.site_auditor IS 'Whether the user can view, but not change, site administration data such as the site configuration (with secrets redacted), background job status, the audit log and usage statistics.';
+
+-- Record changes to the new role in the audit log.
+DROP TRIGGER IF EXISTS trig_audit_log_users ON users;
+CREATE TRIGGER trig_audit_log_users
+    AFTER INSERT OR DELETE OR UPDATE OF username, display_name, avatar_url, deleted_at, passwd, passwd_reset_code, site_admin, site_auditor, tags, invalidated_sessions_at, billing_customer_id ON users
+    FOR EACH ROW EXECUTE PROCEDURE func_audit_log('passwd', 'passwd_reset_code', 'passwd_reset_time');
+
+COMMIT;
@@ -0,0 +1,12 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS repo_search_frequencies (
+    repo_id integer PRIMARY KEY REFERENCES repo(id) ON DELETE CASCADE,
+    score double precision NOT NULL,
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE repo_search_frequencies IS 'Decaying counts of how often repositories are searched, used to reindex frequently searched repositories first.';
+COMMENT ON COLUMN repo_search_frequencies.score IS 'The number of searches of the repository as of updated_at, where each search counts half as much after each half-life.';
+
+COMMIT;
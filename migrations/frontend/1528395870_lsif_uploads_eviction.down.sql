@@ -0,0 +1,7 @@
+BEGIN;
+
+DROP INDEX IF EXISTS lsif_uploads_evicted_at;
+ALTER TABLE lsif_uploads DROP COLUMN IF EXISTS last_queried_at;
+ALTER TABLE lsif_uploads DROP COLUMN IF EXISTS evicted_at;
+
+COMMIT;
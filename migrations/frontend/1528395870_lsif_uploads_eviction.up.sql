@@ -0,0 +1,11 @@
+BEGIN;
+
+ALTER TABLE lsif_uploads ADD COLUMN IF NOT EXISTS last_queried_at timestamp with time zone;
+ALTER TABLE lsif_uploads ADD COLUMN IF NOT EXISTS evicted_at timestamp with time zone;
+
+CREATE INDEX IF NOT EXISTS lsif_uploads_evicted_at ON lsif_uploads(evicted_at) WHERE evicted_at IS NOT NULL;
+
+COMMENT ON COLUMN lsif_uploads.last_queried_at IS 'The last time (at hourly granularity) code intelligence data from this upload was used to answer a query.';
+COMMENT ON COLUMN lsif_uploads.evicted_at IS 'The time the code intelligence data of this upload was moved from the codeintel database to object storage. Null if the data is in the codeintel database.';
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS schema_migration_progress;
+
+COMMIT;
@@ -0,0 +1,24 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS schema_migration_progress (
+    database text NOT NULL,
+    version bigint NOT NULL,
+    name text NOT NULL,
+    state text NOT NULL,
+    step text NOT NULL,
+    rows_done bigint NOT NULL DEFAULT 0,
+    rows_total bigint,
+    message text NOT NULL DEFAULT '',
+    started_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    finished_at timestamp with time zone,
+    PRIMARY KEY (database, version)
+);
+
+COMMENT ON TABLE schema_migration_progress IS 'The progress of the schema migrations run by the migration runner, shown to site admins.';
+COMMENT ON COLUMN schema_migration_progress.state IS 'One of running, blocked, failed or completed.';
+COMMENT ON COLUMN schema_migration_progress.step IS 'What the migration runner is doing, such as backfilling a column.';
+COMMENT ON COLUMN schema_migration_progress.rows_total IS 'The number of rows processed by the current step, if it processes rows.';
+COMMENT ON COLUMN schema_migration_progress.message IS 'Why the migration is blocked or failed.';
+
+COMMIT;
@@ -0,0 +1,16 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS lsif_upload_processing_traces (
+    upload_id integer PRIMARY KEY REFERENCES lsif_uploads(id) ON DELETE CASCADE,
+    phases jsonb NOT NULL,
+    duration_ms bigint NOT NULL,
+    peak_memory_bytes bigint NOT NULL,
+    recorded_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+COMMENT ON TABLE lsif_upload_processing_traces IS 'A breakdown of the time and memory the precise code intel worker spent on the last processing attempt of an upload.';
+COMMENT ON COLUMN lsif_upload_processing_traces.phases IS 'The phases of processing (e.g., parse, correlate, write, commit) with their start offsets and durations in milliseconds. Phases that were not reached are omitted.';
+COMMENT ON COLUMN lsif_upload_processing_traces.duration_ms IS 'The total time spent processing the upload in milliseconds.';
+COMMENT ON COLUMN lsif_upload_processing_traces.peak_memory_bytes IS 'The highest heap size of the worker process sampled while processing the upload. Uploads processed concurrently contribute to the same heap.';
+
+COMMIT;
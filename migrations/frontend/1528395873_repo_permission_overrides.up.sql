@@ -0,0 +1,24 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS repo_permission_overrides (
+    id serial PRIMARY KEY,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE,
+    permission text NOT NULL DEFAULT 'read',
+    effect text NOT NULL CHECK (effect IN ('grant', 'deny')),
+    reason text NOT NULL DEFAULT '',
+    expires_at timestamp with time zone,
+    created_by_user_id integer REFERENCES users(id) ON DELETE SET NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    UNIQUE (repo_id, user_id, permission)
+);
+
+CREATE INDEX IF NOT EXISTS repo_permission_overrides_user_id ON repo_permission_overrides(user_id);
+
+COMMENT ON TABLE repo_permission_overrides IS 'Repository permissions of users managed by site admins, which take precedence over the permissions synced from code hosts.';
+COMMENT ON COLUMN repo_permission_overrides.effect IS 'Either grant, which gives the user the permission in addition to the synced permissions, or deny, which revokes the permission regardless of the synced permissions.';
+COMMENT ON COLUMN repo_permission_overrides.reason IS 'Why the override exists, e.g. the ticket that requested access.';
+COMMENT ON COLUMN repo_permission_overrides.expires_at IS 'The time after which the override no longer applies. Overrides without an expiry apply until they are deleted.';
+
+COMMIT;
@@ -0,0 +1,6 @@
+BEGIN;
+
+DROP TABLE IF EXISTS usage_event_rollups;
+DROP TABLE IF EXISTS usage_active_user_rollups;
+
+COMMIT;
@@ -0,0 +1,31 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS usage_active_user_rollups (
+    period text NOT NULL CHECK (period IN ('daily', 'weekly')),
+    period_start date NOT NULL,
+    active_users integer NOT NULL,
+    registered_users integer NOT NULL,
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (period, period_start)
+);
+
+CREATE TABLE IF NOT EXISTS usage_event_rollups (
+    period text NOT NULL CHECK (period IN ('daily', 'weekly')),
+    period_start date NOT NULL,
+    event_name text NOT NULL,
+    events bigint NOT NULL,
+    users integer NOT NULL,
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (period, period_start, event_name)
+);
+
+CREATE INDEX IF NOT EXISTS usage_event_rollups_event_name ON usage_event_rollups(event_name);
+
+COMMENT ON TABLE usage_active_user_rollups IS 'Active users per day and week, aggregated from event_logs by the worker.';
+COMMENT ON COLUMN usage_active_user_rollups.period_start IS 'The first day (UTC) of the period. Weeks start on Sunday.';
+COMMENT ON COLUMN usage_active_user_rollups.active_users IS 'The number of registered and anonymous users who logged an event in the period.';
+
+COMMENT ON TABLE usage_event_rollups IS 'Events and unique users per event name, day and week, aggregated from event_logs by the worker.';
+COMMENT ON COLUMN usage_event_rollups.period_start IS 'The first day (UTC) of the period. Weeks start on Sunday.';
+
+COMMIT;
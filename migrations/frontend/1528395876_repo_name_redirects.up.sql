@@ -0,0 +1,32 @@
+BEGIN;
+
+-- repo_name_redirects maps the previous names of renamed repositories to the
+-- repositories, so that references to an old name keep resolving.
+CREATE TABLE IF NOT EXISTS repo_name_redirects (
+    name citext PRIMARY KEY,
+    repo_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    created_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS repo_name_redirects_repo_id_idx ON repo_name_redirects (repo_id);
+
+CREATE OR REPLACE FUNCTION record_repo_name_redirect() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    -- A repository that now has the name owns it again.
+    DELETE FROM repo_name_redirects WHERE name = NEW.name;
+
+    IF TG_OP = 'UPDATE' AND OLD.name <> NEW.name AND OLD.deleted_at IS NULL THEN
+        INSERT INTO repo_name_redirects (name, repo_id)
+        VALUES (OLD.name, NEW.id)
+        ON CONFLICT (name) DO UPDATE SET repo_id = EXCLUDED.repo_id, created_at = now();
+    END IF;
+
+    RETURN NULL;
+END;
+$$;
+
+CREATE TRIGGER trig_record_repo_name_redirect AFTER INSERT OR UPDATE OF name ON repo FOR EACH ROW WHEN (NEW.deleted_at IS NULL) EXECUTE PROCEDURE record_repo_name_redirect();
+
+COMMIT;
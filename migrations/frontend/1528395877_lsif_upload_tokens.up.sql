@@ -0,0 +1,20 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS lsif_upload_tokens (
+    id serial PRIMARY KEY,
+    repository_id integer NOT NULL REFERENCES repo(id) ON DELETE CASCADE,
+    value_sha256 bytea NOT NULL UNIQUE,
+    note text NOT NULL DEFAULT '',
+    creator_user_id integer REFERENCES users(id) ON DELETE SET NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    last_used_at timestamp with time zone,
+    deleted_at timestamp with time zone
+);
+
+CREATE INDEX IF NOT EXISTS lsif_upload_tokens_repository_id ON lsif_upload_tokens (repository_id) WHERE deleted_at IS NULL;
+
+COMMENT ON TABLE lsif_upload_tokens IS 'Tokens that permit uploading LSIF data for a single repository.';
+COMMENT ON COLUMN lsif_upload_tokens.value_sha256 IS 'The SHA-256 hash of the token value. The token value itself is only returned once, on creation.';
+COMMENT ON COLUMN lsif_upload_tokens.last_used_at IS 'The last time the token was used to authorize an upload.';
+
+COMMIT;
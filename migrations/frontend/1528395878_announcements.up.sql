@@ -0,0 +1,26 @@
+BEGIN;
+
+CREATE TABLE IF NOT EXISTS announcements (
+    id bigserial PRIMARY KEY,
+    message text NOT NULL,
+    severity text NOT NULL DEFAULT 'INFO',
+    audience text NOT NULL DEFAULT 'EVERYONE',
+    starts_at timestamp with time zone,
+    ends_at timestamp with time zone,
+    creator_user_id integer REFERENCES users(id) ON DELETE SET NULL,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now(),
+    CONSTRAINT announcements_severity_valid CHECK (severity IN ('INFO', 'WARNING', 'ERROR')),
+    CONSTRAINT announcements_audience_valid CHECK (audience IN ('EVERYONE', 'AUTHENTICATED_USERS', 'SITE_ADMINS')),
+    CONSTRAINT announcements_ends_after_start CHECK (starts_at IS NULL OR ends_at IS NULL OR ends_at > starts_at)
+);
+
+CREATE INDEX IF NOT EXISTS announcements_ends_at ON announcements (ends_at);
+
+COMMENT ON TABLE announcements IS 'Instance-wide announcements displayed at the top of all clients.';
+COMMENT ON COLUMN announcements.message IS 'The Markdown message of the announcement.';
+COMMENT ON COLUMN announcements.audience IS 'The users the announcement is displayed to: EVERYONE (including unauthenticated visitors), AUTHENTICATED_USERS or SITE_ADMINS.';
+COMMENT ON COLUMN announcements.starts_at IS 'The time from which the announcement is displayed. NULL means immediately.';
+COMMENT ON COLUMN announcements.ends_at IS 'The time at which the announcement expires. NULL means it does not expire.';
+
+COMMIT;
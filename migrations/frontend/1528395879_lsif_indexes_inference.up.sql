@@ -0,0 +1,36 @@
+BEGIN;
+
+ALTER TABLE lsif_indexes ADD COLUMN IF NOT EXISTS inference_confidence double precision;
+ALTER TABLE lsif_indexes ADD COLUMN IF NOT EXISTS inference_reasons text[];
+
+COMMENT ON COLUMN lsif_indexes.inference_confidence IS 'The confidence, between 0 and 1, that an index job inferred from the repository structure is correct. Null if the index job was configured explicitly.';
+COMMENT ON COLUMN lsif_indexes.inference_reasons IS 'Human-readable descriptions of the repository structure an index job was inferred from (e.g. ''found go.mod at the repository root''). Null if the index job was configured explicitly.';
+
+CREATE OR REPLACE VIEW lsif_indexes_with_repository_name AS
+ SELECT u.id,
+    u.commit,
+    u.queued_at,
+    u.state,
+    u.failure_message,
+    u.started_at,
+    u.finished_at,
+    u.repository_id,
+    u.process_after,
+    u.num_resets,
+    u.num_failures,
+    u.docker_steps,
+    u.root,
+    u.indexer,
+    u.indexer_args,
+    u.outfile,
+    u.log_contents,
+    u.execution_logs,
+    u.local_steps,
+    r.name AS repository_name,
+    u.inference_confidence,
+    u.inference_reasons
+   FROM (lsif_indexes u
+     JOIN repo r ON ((r.id = u.repository_id)))
+  WHERE (r.deleted_at IS NULL);
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+CREATE OR REPLACE FUNCTION record_repo_name_redirect() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+BEGIN
+    -- A repository that now has the name owns it again.
+    DELETE FROM repo_name_redirects WHERE name = NEW.name;
+
+    IF TG_OP = 'UPDATE' AND OLD.name <> NEW.name AND OLD.deleted_at IS NULL THEN
+        INSERT INTO repo_name_redirects (name, repo_id)
+        VALUES (OLD.name, NEW.id)
+        ON CONFLICT (name) DO UPDATE SET repo_id = EXCLUDED.repo_id, created_at = now();
+    END IF;
+
+    RETURN NULL;
+END;
+$$;
+
+COMMIT;
@@ -0,0 +1,39 @@
+BEGIN;
+
+-- Also record the previous name of a soft-deleted repository that the syncer
+-- restores under a new name, which happens when a repository was renamed on its
+-- code host while it was deleted, e.g. because it moved between the orgs of two
+-- external services. The prefix added by soft_deleted_repository_name is
+-- stripped, and the name is skipped if another repository has taken it since.
+CREATE OR REPLACE FUNCTION record_repo_name_redirect() RETURNS trigger
+    LANGUAGE plpgsql
+    AS $$
+DECLARE
+    old_name citext;
+BEGIN
+    -- A repository that now has the name owns it again.
+    DELETE FROM repo_name_redirects WHERE name = NEW.name;
+
+    IF TG_OP <> 'UPDATE' THEN
+        RETURN NULL;
+    END IF;
+
+    old_name := OLD.name;
+    IF OLD.deleted_at IS NOT NULL THEN
+        old_name := regexp_replace(OLD.name::text, '^DELETED-[0-9.]+-', '');
+        IF EXISTS (SELECT FROM repo WHERE name = old_name AND deleted_at IS NULL) THEN
+            RETURN NULL;
+        END IF;
+    END IF;
+
+    IF old_name <> NEW.name THEN
+        INSERT INTO repo_name_redirects (name, repo_id)
+        VALUES (old_name, NEW.id)
+        ON CONFLICT (name) DO UPDATE SET repo_id = EXCLUDED.repo_id, created_at = now();
+    END IF;
+
+    RETURN NULL;
+END;
+$$;
+
+COMMIT;
@@ -0,0 +1,28 @@
+BEGIN;
+
+-- Search jobs run search queries exhaustively in the background. The state
+-- columns are those of a dbworker queue, with the additional "canceled" state.
+-- The results of completed search jobs are stored in the upload store.
+CREATE TABLE IF NOT EXISTS search_jobs (
+    id bigserial PRIMARY KEY,
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    query text NOT NULL,
+    state text NOT NULL DEFAULT 'queued',
+    failure_message text,
+    started_at timestamp with time zone,
+    finished_at timestamp with time zone,
+    process_after timestamp with time zone,
+    num_resets integer NOT NULL DEFAULT 0,
+    num_failures integer NOT NULL DEFAULT 0,
+    execution_logs json[],
+    worker_hostname text NOT NULL DEFAULT '',
+    last_heartbeat_at timestamp with time zone,
+    match_count integer NOT NULL DEFAULT 0,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    updated_at timestamp with time zone NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS search_jobs_state_idx ON search_jobs (state);
+CREATE INDEX IF NOT EXISTS search_jobs_user_id_created_at_idx ON search_jobs (user_id, created_at);
+
+COMMIT;
@@ -0,0 +1,5 @@
+BEGIN;
+
+DROP TABLE IF EXISTS graphql_idempotency_keys;
+
+COMMIT;
@@ -0,0 +1,20 @@
+BEGIN;
+
+-- Idempotency keys of GraphQL mutations, together with the response that is
+-- replayed when a mutation is retried with the same key. The response is NULL
+-- while the first request with the key is in progress. Requests in progress
+-- renew their reservation of the key with heartbeat_at, so that retries of
+-- long-running mutations don't run them a second time.
+CREATE TABLE IF NOT EXISTS graphql_idempotency_keys (
+    user_id integer NOT NULL REFERENCES users(id) ON DELETE CASCADE DEFERRABLE,
+    key text NOT NULL,
+    request_hash bytea NOT NULL,
+    response bytea,
+    created_at timestamp with time zone NOT NULL DEFAULT now(),
+    heartbeat_at timestamp with time zone NOT NULL DEFAULT now(),
+    PRIMARY KEY (user_id, key)
+);
+
+CREATE INDEX IF NOT EXISTS graphql_idempotency_keys_created_at_idx ON graphql_idempotency_keys (created_at);
+
+COMMIT;
@@ -34,6 +34,46 @@ func Worker() *monitoring.Container {
 					},
 				}, createWorkerActiveJobRows()...),
 			},
+			{
+				Title: "Job runs",
+				Rows: []monitoring.Row{
+					{
+						{
+							Name:        "worker_job_run_99th_percentile_duration",
+							Description: "99th percentile job run duration over 5m",
+							Query:       `histogram_quantile(0.99, sum by (le, job_name)(rate(src_worker_job_run_duration_seconds_bucket{job="worker"}[5m])))`,
+							Panel:       monitoring.Panel().LegendFormat("{{job_name}}").Unit(monitoring.Seconds),
+							NoAlert:     true,
+							Interpretation: `
+								The time spent on a single run of each job, such as one invocation of a periodic
+								routine or the processing of one record by a queue worker.
+							`,
+						},
+						{
+							Name:        "worker_job_run_errors",
+							Description: "job run errors every 5m",
+							Query:       `sum by (job_name)(increase(src_worker_job_run_errors_total{job="worker"}[5m]))`,
+							Panel:       monitoring.Panel().LegendFormat("{{job_name}}"),
+							NoAlert:     true,
+							Interpretation: `
+								The number of runs of each job that returned an error. Refer to the worker logs for details.
+							`,
+						},
+						{
+							Name:        "worker_job_time_since_last_success",
+							Description: "time since the last successful job run",
+							Query:       `time() - max by (job_name)(src_worker_job_last_success_timestamp_seconds{job="worker"})`,
+							Panel:       monitoring.Panel().LegendFormat("{{job_name}}").Unit(monitoring.Seconds),
+							NoAlert:     true,
+							Interpretation: `
+								The time since a run of each job last completed without an error. Queue-based jobs only
+								run when there is work, so a large value is only a concern for periodic jobs or while
+								the queue of the job is not empty.
+							`,
+						},
+					},
+				},
+			},
 			{
 				Title:  "Precise code intelligence commit graph updater",
 				Hidden: true,
@@ -21,6 +21,8 @@ type AWSCodeCommitConnection struct {
 	// See the AWS CodeCommit documentation on Git credentials for CodeCommit: https://docs.aws.amazon.com/IAM/latest/UserGuide/id_credentials_ssh-keys.html#git-credentials-code-commit.
 	// For detailed instructions on how to create the credentials in IAM, see this page: https://docs.aws.amazon.com/codecommit/latest/userguide/setting-up-gc.html
 	GitCredentials AWSCodeCommitGitCredentials `json:"gitCredentials"`
+	// GitLFS description: Whether to fetch the Git LFS objects of repositories when they are cloned or updated. By default, LFS objects are skipped and only the LFS pointer files are available on Sourcegraph. Fetching LFS objects requires git-lfs to be installed on gitserver.
+	GitLFS string `json:"gitLFS,omitempty"`
 	// InitialRepositoryEnablement description: Deprecated and ignored field which will be removed entirely in the next release. AWS CodeCommit repositories can no longer be enabled or disabled explicitly. Configure which repositories should not be mirrored via "exclude" instead.
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
 	// Region description: The AWS region in which to access AWS CodeCommit. See the list of supported regions at https://docs.aws.amazon.com/codecommit/latest/userguide/regions.html#regions-git.
@@ -78,6 +80,14 @@ type AuthAccessTokens struct {
 	Allow string `json:"allow,omitempty"`
 }
 
+// AuthImpersonation description: Allows site admins to view Sourcegraph as another user for debugging, e.g. to troubleshoot repository permissions. Impersonation sessions are short-lived and restricted to read operations. Starting and stopping an impersonation, as well as all GraphQL requests made while impersonating, are recorded in the security event log with both the site admin and the impersonated user.
+type AuthImpersonation struct {
+	// DurationMinutes description: The number of minutes after which an impersonation session expires.
+	DurationMinutes int `json:"durationMinutes,omitempty"`
+	// Enabled description: Allow site admins to impersonate users that are not site admins.
+	Enabled bool `json:"enabled,omitempty"`
+}
+
 // AuthProviderCommon description: Common properties for authentication providers.
 type AuthProviderCommon struct {
 	// DisplayName description: The name to use when displaying this authentication provider in the UI. Defaults to an auto-generated name with the type of authentication provider and other relevant identifiers (such as a hostname).
@@ -137,6 +147,49 @@ func (v *AuthProviders) UnmarshalJSON(data []byte) error {
 	return fmt.Errorf("tagged union type must have a %q property whose value is one of %s", "type", []string{"builtin", "saml", "openidconnect", "http-header", "github", "gitlab"})
 }
 
+// AzureDevOpsConnection description: Configuration for a connection to Azure DevOps.
+type AzureDevOpsConnection struct {
+	// Exclude description: A list of repositories to never mirror from Azure DevOps. Takes precedence over "orgs" and "projects" configuration.
+	//
+	// Supports excluding by name ({"name": "myorg/myproject/myrepo"}) or by ID ({"id": "8a6d3dd1-2bd2-4c5e-8f55-d2a1a1b0c7b3"}).
+	Exclude []*ExcludedAzureDevOpsRepo `json:"exclude,omitempty"`
+	// GitLFS description: Whether to fetch the Git LFS objects of repositories when they are cloned or updated. By default, LFS objects are skipped and only the LFS pointer files are available on Sourcegraph. Fetching LFS objects requires git-lfs to be installed on gitserver.
+	GitLFS string `json:"gitLFS,omitempty"`
+	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on Azure DevOps.
+	//
+	// If "http", Sourcegraph will access Azure DevOps repositories using Git URLs of the form https://dev.azure.com/myorg/myproject/_git/myrepo.
+	//
+	// If "ssh", Sourcegraph will access Azure DevOps repositories using Git URLs of the form git@ssh.dev.azure.com:v3/myorg/myproject/myrepo. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
+	GitURLType string `json:"gitURLType,omitempty"`
+	// Orgs description: An array of organization names identifying Azure DevOps organizations whose repositories should be mirrored on Sourcegraph. All repositories of all projects of the organizations that the token has access to are mirrored.
+	Orgs []string `json:"orgs,omitempty"`
+	// Projects description: An array of projects ("org/project") whose repositories should be mirrored on Sourcegraph.
+	Projects []string `json:"projects,omitempty"`
+	// RateLimit description: Rate limit applied when making background API requests to Azure DevOps.
+	RateLimit *AzureDevOpsRateLimit `json:"rateLimit,omitempty"`
+	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for an Azure DevOps repository.
+	//
+	//  - "{host}" is replaced with the Azure DevOps URL's host (such as dev.azure.com), "{org}" with the organization name, "{project}" with the project name and "{repo}" with the repository name.
+	//
+	// For example, if your Sourcegraph is https://src.example.com, then a repositoryPathPattern of "{host}/{org}/{project}/{repo}" would mean that an Azure DevOps repository at https://dev.azure.com/myorg/myproject/_git/myrepo is available on Sourcegraph at https://src.example.com/dev.azure.com/myorg/myproject/myrepo.
+	//
+	// It is important that the Sourcegraph repository name generated with this pattern be unique to this code host. If different code hosts generate repository names that collide, Sourcegraph's behavior is undefined.
+	RepositoryPathPattern string `json:"repositoryPathPattern,omitempty"`
+	// Token description: A personal access token (PAT) of the user set in the "username" field. The token needs the Code (Read) scope to mirror repositories and the Code (Read & write) scope to publish pull requests with batch changes.
+	Token string `json:"token"`
+	// Url description: URL of Azure DevOps Services, such as https://dev.azure.com. Generally, admin should not modify the value of this option because Azure DevOps Services is a public hosting platform.
+	Url string `json:"url"`
+	// Username description: The username to use when authenticating to Azure DevOps. Also set the corresponding "token" field.
+	Username string `json:"username"`
+}
+
+// AzureDevOpsRateLimit description: Rate limit applied when making background API requests to Azure DevOps.
+type AzureDevOpsRateLimit struct {
+	// Enabled description: true if rate limiting is enabled.
+	Enabled bool `json:"enabled"`
+	// RequestsPerHour description: Requests per hour permitted. This is an average, calculated per second. Internally, the burst limit is set to 500, which implies that for a requests per hour limit as low as 1, users will continue to be able to send a maximum of 500 requests immediately, provided that the complexity cost of each request is 1.
+	RequestsPerHour float64 `json:"requestsPerHour"`
+}
 type BatchChangeRolloutWindow struct {
 	// Days description: Day(s) the window applies to. If omitted, this rule applies to all days of the week.
 	Days []string `json:"days,omitempty"`
@@ -147,11 +200,23 @@ type BatchChangeRolloutWindow struct {
 	// Start description: Window start time. If omitted, no time window is applied to the day(s) that match this rule.
 	Start string `json:"start,omitempty"`
 }
+type BatchChangesExcludedRepository struct {
+	// Glob description: A glob pattern which matches against the name of a repository on Sourcegraph (such as "github.com/myorg/*"). "*" doesn't match "/", use "**" to match any number of path components.
+	Glob string `json:"glob,omitempty"`
+	// Name description: The name of a repository on Sourcegraph (such as "github.com/myorg/myrepo"). The name is matched case-insensitively.
+	Name string `json:"name,omitempty"`
+	// Reason description: Why batch changes may not publish changesets to the matching repositories. It is included in the error shown to users.
+	Reason string `json:"reason,omitempty"`
+	// Regex description: Regular expression which matches against the name of a repository on Sourcegraph (such as "^github\\.com/myorg/frozen-").
+	Regex string `json:"regex,omitempty"`
+}
 
 // BatchSpec description: A batch specification, which describes the batch change and what kinds of changes to make (or what existing changesets to track).
 type BatchSpec struct {
 	// ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 	ChangesetTemplate *ChangesetTemplate `json:"changesetTemplate,omitempty"`
+	// Checks description: Rules that determine which checks (CI builds, commit statuses and check runs) reported by the code host count toward the check state of the changesets created by the batch change. The first rule whose name pattern matches the name of a check applies. Checks that match no rule are required.
+	Checks []*ChangesetCheckRule `json:"checks,omitempty"`
 	// Description description: The description of the batch change.
 	Description string `json:"description,omitempty"`
 	// ImportChangesets description: Import existing changesets on code hosts.
@@ -178,12 +243,18 @@ type BitbucketCloudConnection struct {
 	//
 	// Supports excluding by name ({"name": "myorg/myrepo"}) or by UUID ({"uuid": "{fceb73c7-cef6-4abe-956d-e471281126bd}"}).
 	Exclude []*ExcludedBitbucketCloudRepo `json:"exclude,omitempty"`
+	// FetchCredentials description: Credentials that gitserver uses to clone and fetch repositories instead of the credentials used to discover them, so that the discovery credentials do not need access to the contents of every repository. The first entry whose repos match the repository name and that has a credential for the configured gitURLType applies. Repositories without a matching entry are cloned with the discovery credentials.
+	FetchCredentials []*BitbucketCloudFetchCredential `json:"fetchCredentials,omitempty"`
+	// GitLFS description: Whether to fetch the Git LFS objects of repositories when they are cloned or updated. By default, LFS objects are skipped and only the LFS pointer files are available on Sourcegraph. Fetching LFS objects requires git-lfs to be installed on gitserver.
+	GitLFS string `json:"gitLFS,omitempty"`
 	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this Bitbucket Cloud.
 	//
 	// If "http", Sourcegraph will access Bitbucket Cloud repositories using Git URLs of the form https://bitbucket.org/myteam/myproject.git.
 	//
 	// If "ssh", Sourcegraph will access Bitbucket Cloud repositories using Git URLs of the form git@bitbucket.org:myteam/myproject.git. See the documentation for how to provide SSH private keys and known_hosts: https://docs.sourcegraph.com/admin/repo/auth#repositories-that-need-http-s-or-ssh-authentication.
 	GitURLType string `json:"gitURLType,omitempty"`
+	// MaxRepoSize description: Repositories larger than this size, as reported by Bitbucket Cloud, are not cloned. Skipped repositories are listed by the `repositories(cloneSkipped: true)` GraphQL query. The size is a number followed by a unit (KB, MB or GB). Repositories that are already cloned are not affected.
+	MaxRepoSize string `json:"maxRepoSize,omitempty"`
 	// RateLimit description: Rate limit applied when making background API requests to Bitbucket Cloud.
 	RateLimit *BitbucketCloudRateLimit `json:"rateLimit,omitempty"`
 	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for a Bitbucket Cloud repository.
@@ -201,6 +272,16 @@ type BitbucketCloudConnection struct {
 	// Username description: The username to use when authenticating to the Bitbucket Cloud. Also set the corresponding "appPassword" field.
 	Username string `json:"username"`
 }
+type BitbucketCloudFetchCredential struct {
+	// Repos description: Regular expressions matched against the names of the repositories on Sourcegraph (for example, bitbucket.org/myteam/myrepo) that the credential applies to. If empty, the credential applies to all repositories of this connection.
+	Repos []string `json:"repos,omitempty"`
+	// SshKey description: The PEM-encoded private key of a deploy key without a passphrase, used when gitURLType is "ssh".
+	SshKey string `json:"sshKey,omitempty"`
+	// Token description: A Bitbucket Cloud app password with the repository read permission, used when gitURLType is "http".
+	Token string `json:"token,omitempty"`
+	// Username description: The username to authenticate with. Defaults to the username of the connection.
+	Username string `json:"username,omitempty"`
+}
 
 // BitbucketCloudRateLimit description: Rate limit applied when making background API requests to Bitbucket Cloud.
 type BitbucketCloudRateLimit struct {
@@ -230,6 +311,10 @@ type BitbucketServerConnection struct {
 	Exclude []*ExcludedBitbucketServerRepo `json:"exclude,omitempty"`
 	// ExcludePersonalRepositories description: Whether or not personal repositories should be excluded or not. When true, Sourcegraph will ignore personal repositories it may have access to. See https://docs.sourcegraph.com/integration/bitbucket_server#excluding-personal-repositories for more information.
 	ExcludePersonalRepositories bool `json:"excludePersonalRepositories,omitempty"`
+	// FetchCredentials description: Credentials that gitserver uses to clone and fetch repositories instead of the credentials used to discover them, so that the discovery credentials do not need access to the contents of every repository. The first entry whose repos match the repository name and that has a credential for the configured gitURLType applies. Repositories without a matching entry are cloned with the discovery credentials.
+	FetchCredentials []*BitbucketServerFetchCredential `json:"fetchCredentials,omitempty"`
+	// GitLFS description: Whether to fetch the Git LFS objects of repositories when they are cloned or updated. By default, LFS objects are skipped and only the LFS pointer files are available on Sourcegraph. Fetching LFS objects requires git-lfs to be installed on gitserver.
+	GitLFS string `json:"gitLFS,omitempty"`
 	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this Bitbucket Server instance.
 	//
 	// If "http", Sourcegraph will access Bitbucket Server repositories using Git URLs of the form http(s)://bitbucket.example.com/scm/myproject/myrepo.git (using https: if the Bitbucket Server instance uses HTTPS).
@@ -273,6 +358,16 @@ type BitbucketServerConnection struct {
 	// Webhooks description: DEPRECATED: Switch to "plugin.webhooks"
 	Webhooks *Webhooks `json:"webhooks,omitempty"`
 }
+type BitbucketServerFetchCredential struct {
+	// Repos description: Regular expressions matched against the names of the repositories on Sourcegraph (for example, bitbucket.example.com/MYPROJECT/myrepo) that the credential applies to. If empty, the credential applies to all repositories of this connection.
+	Repos []string `json:"repos,omitempty"`
+	// SshKey description: The PEM-encoded private key of a deploy key without a passphrase, used when gitURLType is "ssh".
+	SshKey string `json:"sshKey,omitempty"`
+	// Token description: A Bitbucket Server personal access token or password with read access to the repositories, used when gitURLType is "http".
+	Token string `json:"token,omitempty"`
+	// Username description: The username to authenticate with. Defaults to the username of the connection.
+	Username string `json:"username,omitempty"`
+}
 
 // BitbucketServerIdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the Bitbucket Server identity to use for a given Sourcegraph user. When 'username' is used, Sourcegraph assumes usernames are identical in Sourcegraph and Bitbucket Server accounts and `auth.enableUsernameChanges` must be set to false for security reasons.
 type BitbucketServerIdentityProvider struct {
@@ -360,6 +455,13 @@ type BuiltinAuthProvider struct {
 	Type        string `json:"type"`
 }
 
+type ChangesetCheckRule struct {
+	// Name description: A glob pattern to match check names against, such as "ci/*" or "*lint*".
+	Name string `json:"name"`
+	// Required description: Whether matching checks are required. Failing or pending informational (not required) checks don't make the check state of a changeset failed or pending.
+	Required *bool `json:"required,omitempty"`
+}
+
 // ChangesetTemplate description: A template describing how to create (and update) changesets with the file changes produced by the command steps.
 type ChangesetTemplate struct {
 	// Body description: The body (description) of the changeset.
@@ -409,6 +511,16 @@ type Dotcom struct {
 	SlackLicenseExpirationWebhook string `json:"slackLicenseExpirationWebhook,omitempty"`
 }
 
+// EmailTemplate description: The subject and body templates of a transactional email.
+type EmailTemplate struct {
+	// Html description: The html/template for the HTML email body.
+	Html string `json:"html,omitempty"`
+	// Subject description: The text/template for the email subject.
+	Subject string `json:"subject,omitempty"`
+	// Text description: The text/template for the plain text email body.
+	Text string `json:"text,omitempty"`
+}
+
 // EncryptionKey description: Config for a key
 type EncryptionKey struct {
 	Cloudkms *CloudKMSEncryptionKey
@@ -463,50 +575,122 @@ type EncryptionKeys struct {
 	UserExternalAccountKey *EncryptionKey `json:"userExternalAccountKey,omitempty"`
 }
 type ExcludedAWSCodeCommitRepo struct {
+	// Glob description: A glob pattern which matches against the name of a repository on Sourcegraph (such as "code.example.com/myorg/*"). "*" doesn't match "/", use "**" to match any number of path components.
+	Glob string `json:"glob,omitempty"`
 	// Id description: The ID of an AWS Code Commit repository (as returned by the AWS API) to exclude from mirroring. Use this to exclude the repository, even if renamed, or to differentiate between repositories with the same name in multiple regions.
 	Id string `json:"id,omitempty"`
 	// Name description: The name of an AWS CodeCommit repository ("repo-name") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
+	// Regex description: Regular expression which matches against the name of a repository on Sourcegraph (such as "code.example.com/myorg/myrepo").
+	Regex string `json:"regex,omitempty"`
+}
+type ExcludedAzureDevOpsRepo struct {
+	// Forks description: If set to true, forks will be excluded.
+	Forks bool `json:"forks,omitempty"`
+	// Glob description: A glob pattern which matches against the name of a repository on Sourcegraph (such as "code.example.com/myorg/*"). "*" doesn't match "/", use "**" to match any number of path components.
+	Glob string `json:"glob,omitempty"`
+	// Id description: The ID of an Azure DevOps repository (as returned by the Azure DevOps API) to exclude from mirroring.
+	Id string `json:"id,omitempty"`
+	// LargerThan description: Repositories larger than this size on the code host will be excluded. The size is a number followed by a unit (KB, MB or GB).
+	LargerThan string `json:"largerThan,omitempty"`
+	// Name description: The name of an Azure DevOps repository ("org/project/repo") to exclude from mirroring.
+	Name string `json:"name,omitempty"`
+	// Pattern description: Regular expression which matches against the name of an Azure DevOps repository ("org/project/repo").
+	Pattern string `json:"pattern,omitempty"`
+	// Regex description: Regular expression which matches against the name of a repository on Sourcegraph (such as "code.example.com/myorg/myrepo").
+	Regex string `json:"regex,omitempty"`
 }
 type ExcludedBitbucketCloudRepo struct {
+	// Forks description: If set to true, forks will be excluded.
+	Forks bool `json:"forks,omitempty"`
+	// Glob description: A glob pattern which matches against the name of a repository on Sourcegraph (such as "code.example.com/myorg/*"). "*" doesn't match "/", use "**" to match any number of path components.
+	Glob string `json:"glob,omitempty"`
+	// LargerThan description: Repositories larger than this size on the code host will be excluded. The size is a number followed by a unit (KB, MB or GB).
+	LargerThan string `json:"largerThan,omitempty"`
 	// Name description: The name of a Bitbucket Cloud repo ("myorg/myrepo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 	// Pattern description: Regular expression which matches against the name of a Bitbucket Cloud repo.
 	Pattern string `json:"pattern,omitempty"`
+	// Regex description: Regular expression which matches against the name of a repository on Sourcegraph (such as "code.example.com/myorg/myrepo").
+	Regex string `json:"regex,omitempty"`
 	// Uuid description: The UUID of a Bitbucket Cloud repo (as returned by the Bitbucket Cloud's API) to exclude from mirroring.
 	Uuid string `json:"uuid,omitempty"`
 }
 type ExcludedBitbucketServerRepo struct {
+	// Archived description: If set to true, archived repositories will be excluded.
+	Archived bool `json:"archived,omitempty"`
+	// Forks description: If set to true, forks will be excluded.
+	Forks bool `json:"forks,omitempty"`
+	// Glob description: A glob pattern which matches against the name of a repository on Sourcegraph (such as "code.example.com/myorg/*"). "*" doesn't match "/", use "**" to match any number of path components.
+	Glob string `json:"glob,omitempty"`
 	// Id description: The ID of a Bitbucket Server repo (as returned by the Bitbucket Server instance's API) to exclude from mirroring.
 	Id int `json:"id,omitempty"`
 	// Name description: The name of a Bitbucket Server repo ("projectKey/repositorySlug") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 	// Pattern description: Regular expression which matches against the name of a Bitbucket Server repo.
 	Pattern string `json:"pattern,omitempty"`
+	// Regex description: Regular expression which matches against the name of a repository on Sourcegraph (such as "code.example.com/myorg/myrepo").
+	Regex string `json:"regex,omitempty"`
 }
 type ExcludedGitHubRepo struct {
 	// Archived description: If set to true, archived repositories will be excluded.
 	Archived bool `json:"archived,omitempty"`
 	// Forks description: If set to true, forks will be excluded.
 	Forks bool `json:"forks,omitempty"`
+	// Glob description: A glob pattern which matches against the name of a repository on Sourcegraph (such as "code.example.com/myorg/*"). "*" doesn't match "/", use "**" to match any number of path components.
+	Glob string `json:"glob,omitempty"`
 	// Id description: The node ID of a GitHub repository (as returned by the GitHub instance's API) to exclude from mirroring. Use this to exclude the repository, even if renamed. Note: This is the GraphQL ID, not the GitHub database ID. eg: "curl https://api.github.com/repos/vuejs/vue | jq .node_id"
 	Id string `json:"id,omitempty"`
+	// LargerThan description: Repositories larger than this size on the code host will be excluded. The size is a number followed by a unit (KB, MB or GB).
+	LargerThan string `json:"largerThan,omitempty"`
 	// Name description: The name of a GitHub repository ("owner/name") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 	// Pattern description: Regular expression which matches against the name of a GitHub repository ("owner/name").
 	Pattern string `json:"pattern,omitempty"`
+	// Regex description: Regular expression which matches against the name of a repository on Sourcegraph (such as "code.example.com/myorg/myrepo").
+	Regex string `json:"regex,omitempty"`
 }
 type ExcludedGitLabProject struct {
+	// Archived description: If set to true, archived repositories will be excluded.
+	Archived bool `json:"archived,omitempty"`
+	// Forks description: If set to true, forks will be excluded.
+	Forks bool `json:"forks,omitempty"`
+	// Glob description: A glob pattern which matches against the name of a repository on Sourcegraph (such as "code.example.com/myorg/*"). "*" doesn't match "/", use "**" to match any number of path components.
+	Glob string `json:"glob,omitempty"`
 	// Id description: The ID of a GitLab project (as returned by the GitLab instance's API) to exclude from mirroring.
 	Id int `json:"id,omitempty"`
 	// Name description: The name of a GitLab project ("group/name") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
+	// Regex description: Regular expression which matches against the name of a repository on Sourcegraph (such as "code.example.com/myorg/myrepo").
+	Regex string `json:"regex,omitempty"`
 }
 type ExcludedGitoliteRepo struct {
+	// Glob description: A glob pattern which matches against the name of a repository on Sourcegraph (such as "code.example.com/myorg/*"). "*" doesn't match "/", use "**" to match any number of path components.
+	Glob string `json:"glob,omitempty"`
 	// Name description: The name of a Gitolite repo ("my-repo") to exclude from mirroring.
 	Name string `json:"name,omitempty"`
 	// Pattern description: Regular expression which matches against the name of a Gitolite repo to exclude from mirroring.
 	Pattern string `json:"pattern,omitempty"`
+	// Regex description: Regular expression which matches against the name of a repository on Sourcegraph (such as "code.example.com/myorg/myrepo").
+	Regex string `json:"regex,omitempty"`
+}
+type ExcludedOtherRepo struct {
+	// Glob description: A glob pattern which matches against the name of a repository on Sourcegraph (such as "code.example.com/myorg/*"). "*" doesn't match "/", use "**" to match any number of path components.
+	Glob string `json:"glob,omitempty"`
+	// Regex description: Regular expression which matches against the name of a repository on Sourcegraph (such as "code.example.com/myorg/myrepo").
+	Regex string `json:"regex,omitempty"`
+}
+type ExcludedPerforceDepot struct {
+	// Glob description: A glob pattern which matches against the name of a repository on Sourcegraph (such as "code.example.com/myorg/*"). "*" doesn't match "/", use "**" to match any number of path components.
+	Glob string `json:"glob,omitempty"`
+	// Regex description: Regular expression which matches against the name of a repository on Sourcegraph (such as "code.example.com/myorg/myrepo").
+	Regex string `json:"regex,omitempty"`
+}
+type ExcludedPhabricatorRepo struct {
+	// Glob description: A glob pattern which matches against the name of a repository on Sourcegraph (such as "code.example.com/myorg/*"). "*" doesn't match "/", use "**" to match any number of path components.
+	Glob string `json:"glob,omitempty"`
+	// Regex description: Regular expression which matches against the name of a repository on Sourcegraph (such as "code.example.com/myorg/myrepo").
+	Regex string `json:"regex,omitempty"`
 }
 
 // ExpandedGitCommitDescription description: The Git commit to create with the changes.
@@ -543,10 +727,16 @@ type ExperimentalFeatures struct {
 	RateLimitAnonymous int `json:"rateLimitAnonymous,omitempty"`
 	// SearchIndexBranches description: A map from repository name to a list of extra revs (branch, ref, tag, commit sha, etc) to index for a repository. We always index the default branch ("HEAD") and revisions in version contexts. This allows specifying additional revisions. Sourcegraph can index up to 64 branches per repository.
 	SearchIndexBranches map[string][]string `json:"search.index.branches,omitempty"`
+	// SearchIndexOnDemand description: Index rarely searched repositories on demand. When enabled, only repositories matching alwaysIndex are continuously indexed. Other repositories are searched with the unindexed search backend and queued for indexing when they are searched. The least recently searched on-demand repositories are removed from the index once more than maxRepos are queued.
+	SearchIndexOnDemand *SearchIndexOnDemand `json:"search.index.onDemand,omitempty"`
+	// SearchIndexTrafficPriority description: Reindex frequently searched repositories first. When enabled, Sourcegraph counts how often each repository is searched, and the indexed search backend checks the most frequently searched repositories for changes before the others instead of in a fixed order. Older searches count less: a search counts half as much after each halfLifeHours.
+	SearchIndexTrafficPriority *SearchIndexTrafficPriority `json:"search.index.trafficPriority,omitempty"`
 	// SearchMultipleRevisionsPerRepository description: DEPRECATED. Always on. Will be removed in 3.19.
 	SearchMultipleRevisionsPerRepository *bool `json:"searchMultipleRevisionsPerRepository,omitempty"`
 	// StructuralSearch description: Enables structural search.
 	StructuralSearch string `json:"structuralSearch,omitempty"`
+	// SubRepoPermissions description: Enables sub-repository permissions, restricting access to paths within a repository according to rules returned by authz providers.
+	SubRepoPermissions *SubRepoPermissions `json:"subRepoPermissions,omitempty"`
 	// TlsExternal description: Global TLS/SSL settings for Sourcegraph to use when communicating with code hosts.
 	TlsExternal *TlsExternal `json:"tls.external,omitempty"`
 	// VersionContexts description: JSON array of version context configuration
@@ -555,10 +745,14 @@ type ExperimentalFeatures struct {
 
 // Extensions description: Configures Sourcegraph extensions.
 type Extensions struct {
-	// AllowRemoteExtensions description: Allow only the explicitly listed remote extensions (by extension ID, such as "alice/myextension") from the remote registry. If not set, all remote extensions may be used from the remote registry. To completely disable the remote registry, set `remoteRegistry` to `false`.
+	// AllowRemoteExtensions description: Allow only the explicitly listed remote extensions (by extension ID, such as "alice/myextension") from the remote registry. If not set, all remote extensions may be used from the remote registry. To completely disable the remote registry, set `remoteRegistry` to `false`. If set, only the listed extensions may also be published to the private extension registry of this instance.
 	//
 	// Only available in Sourcegraph Enterprise.
 	AllowRemoteExtensions []string `json:"allowRemoteExtensions,omitempty"`
+	// BundleSigningKeys description: The Ed25519 public keys (base64-encoded) trusted to sign the bundles of extensions published to the private extension registry of this instance. If set, publishing an extension requires a signature of its bundle made with one of these keys. Publishes are recorded in the security event log.
+	//
+	// Only available in Sourcegraph Enterprise.
+	BundleSigningKeys []string `json:"bundleSigningKeys,omitempty"`
 	// Disabled description: Disable all usage of extensions.
 	Disabled *bool `json:"disabled,omitempty"`
 	// RemoteRegistry description: The remote extension registry URL, or `false` to not use a remote extension registry. If not set, the default remote extension registry URL is used.
@@ -573,6 +767,14 @@ type ExternalIdentity struct {
 	GitlabProvider string `json:"gitlabProvider"`
 	Type           string `json:"type"`
 }
+type ExternalServiceUpdateLimit struct {
+	// ExternalServiceID description: The database ID of the external service (code host connection).
+	ExternalServiceID int `json:"externalServiceID"`
+	// MaxConcurrentUpdates description: The maximum number of concurrent updates for repositories of this external service. 0 means only gitMaxConcurrentClones applies.
+	MaxConcurrentUpdates int `json:"maxConcurrentUpdates,omitempty"`
+	// Weight description: The relative share of updates given to this external service compared to other external services with queued updates.
+	Weight int `json:"weight,omitempty"`
+}
 
 // GitCommitAuthor description: The author of the Git commit.
 type GitCommitAuthor struct {
@@ -612,6 +814,10 @@ type GitHubAuthProvider struct {
 
 // GitHubAuthorization description: If non-null, enforces GitHub repository permissions. This requires that there is an item in the `auth.providers` field of type "github" with the same `url` field as specified in this `GitHubConnection`.
 type GitHubAuthorization struct {
+	// MaxStaleness description: How old the permissions synced from GitHub may get before they are no longer enforced, in which case users lose access to private repositories until their permissions are refreshed. It must not be shorter than `ttl`. The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration). By default, permissions are enforced regardless of their age.
+	MaxStaleness string `json:"maxStaleness,omitempty"`
+	// Ttl description: How long the permissions synced from GitHub are considered fresh. Stale permissions keep being enforced while they are refreshed in the background. The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration). By default, permissions are refreshed continuously, starting with the oldest ones.
+	Ttl string `json:"ttl,omitempty"`
 }
 
 // GitHubConnection description: Configuration for a connection to GitHub or GitHub Enterprise.
@@ -630,6 +836,10 @@ type GitHubConnection struct {
 	//
 	// Note: ID is the GitHub GraphQL ID, not the GitHub database ID. eg: "curl https://api.github.com/repos/vuejs/vue | jq .node_id"
 	Exclude []*ExcludedGitHubRepo `json:"exclude,omitempty"`
+	// FetchCredentials description: Credentials that gitserver uses to clone and fetch repositories instead of the credentials used to discover them, so that the discovery credentials do not need access to the contents of every repository. The first entry whose repos match the repository name and that has a credential for the configured gitURLType applies. Repositories without a matching entry are cloned with the discovery credentials.
+	FetchCredentials []*GitHubFetchCredential `json:"fetchCredentials,omitempty"`
+	// GitLFS description: Whether to fetch the Git LFS objects of repositories when they are cloned or updated. By default, LFS objects are skipped and only the LFS pointer files are available on Sourcegraph. Fetching LFS objects requires git-lfs to be installed on gitserver.
+	GitLFS string `json:"gitLFS,omitempty"`
 	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this GitHub instance.
 	//
 	// If "http", Sourcegraph will access GitHub repositories using Git URLs of the form http(s)://github.com/myteam/myproject.git (using https: if the GitHub instance uses HTTPS).
@@ -638,6 +848,8 @@ type GitHubConnection struct {
 	GitURLType string `json:"gitURLType,omitempty"`
 	// InitialRepositoryEnablement description: Deprecated and ignored field which will be removed entirely in the next release. GitHub repositories can no longer be enabled or disabled explicitly. Configure repositories to be mirrored via "repos", "exclude" and "repositoryQuery" instead.
 	InitialRepositoryEnablement bool `json:"initialRepositoryEnablement,omitempty"`
+	// MaxRepoSize description: Repositories larger than this size, as reported by GitHub, are not cloned. Skipped repositories are listed by the `repositories(cloneSkipped: true)` GraphQL query. The size is a number followed by a unit (KB, MB or GB). Repositories that are already cloned are not affected.
+	MaxRepoSize string `json:"maxRepoSize,omitempty"`
 	// Orgs description: An array of organization names identifying GitHub organizations whose repositories should be mirrored on Sourcegraph.
 	Orgs []string `json:"orgs,omitempty"`
 	// RateLimit description: Rate limit applied when making background API requests to GitHub.
@@ -674,6 +886,14 @@ type GitHubConnection struct {
 	// Webhooks description: An array of configurations defining existing GitHub webhooks that send updates back to Sourcegraph.
 	Webhooks []*GitHubWebhook `json:"webhooks,omitempty"`
 }
+type GitHubFetchCredential struct {
+	// Repos description: Regular expressions matched against the names of the repositories on Sourcegraph (for example, github.com/myorg/myrepo) that the credential applies to. If empty, the credential applies to all repositories of this connection.
+	Repos []string `json:"repos,omitempty"`
+	// SshKey description: The PEM-encoded private key of a deploy key without a passphrase, used when gitURLType is "ssh".
+	SshKey string `json:"sshKey,omitempty"`
+	// Token description: A GitHub personal access token that can read the contents of the repositories, used when gitURLType is "http".
+	Token string `json:"token,omitempty"`
+}
 
 // GitHubRateLimit description: Rate limit applied when making background API requests to GitHub.
 type GitHubRateLimit struct {
@@ -705,6 +925,10 @@ type GitLabAuthProvider struct {
 type GitLabAuthorization struct {
 	// IdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the GitLab identity to use for a given Sourcegraph user.
 	IdentityProvider IdentityProvider `json:"identityProvider"`
+	// MaxStaleness description: How old the permissions synced from GitLab may get before they are no longer enforced, in which case users lose access to private repositories until their permissions are refreshed. It must not be shorter than `ttl`. The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration). By default, permissions are enforced regardless of their age.
+	MaxStaleness string `json:"maxStaleness,omitempty"`
+	// Ttl description: How long the permissions synced from GitLab are considered fresh. Stale permissions keep being enforced while they are refreshed in the background. The string format is that of the Duration type in the Go time package (https://golang.org/pkg/time/#ParseDuration). By default, permissions are refreshed continuously, starting with the oldest ones.
+	Ttl string `json:"ttl,omitempty"`
 }
 
 // GitLabConnection description: Configuration for a connection to GitLab (GitLab.com or GitLab self-managed).
@@ -719,6 +943,10 @@ type GitLabConnection struct {
 	CloudGlobal bool `json:"cloudGlobal,omitempty"`
 	// Exclude description: A list of projects to never mirror from this GitLab instance. Takes precedence over "projects" and "projectQuery" configuration. Supports excluding by name ({"name": "group/name"}) or by ID ({"id": 42}).
 	Exclude []*ExcludedGitLabProject `json:"exclude,omitempty"`
+	// FetchCredentials description: Credentials that gitserver uses to clone and fetch repositories instead of the credentials used to discover them, so that the discovery credentials do not need access to the contents of every repository. The first entry whose repos match the repository name and that has a credential for the configured gitURLType applies. Repositories without a matching entry are cloned with the discovery credentials.
+	FetchCredentials []*GitLabFetchCredential `json:"fetchCredentials,omitempty"`
+	// GitLFS description: Whether to fetch the Git LFS objects of repositories when they are cloned or updated. By default, LFS objects are skipped and only the LFS pointer files are available on Sourcegraph. Fetching LFS objects requires git-lfs to be installed on gitserver.
+	GitLFS string `json:"gitLFS,omitempty"`
 	// GitURLType description: The type of Git URLs to use for cloning and fetching Git repositories on this GitLab instance.
 	//
 	// If "http", Sourcegraph will access GitLab repositories using Git URLs of the form http(s)://gitlab.example.com/myteam/myproject.git (using https: if the GitLab instance uses HTTPS).
@@ -752,6 +980,14 @@ type GitLabConnection struct {
 	// Webhooks description: An array of webhook configurations
 	Webhooks []*GitLabWebhook `json:"webhooks,omitempty"`
 }
+type GitLabFetchCredential struct {
+	// Repos description: Regular expressions matched against the names of the repositories on Sourcegraph (for example, gitlab.example.com/mygroup/myrepo) that the credential applies to. If empty, the credential applies to all repositories of this connection.
+	Repos []string `json:"repos,omitempty"`
+	// SshKey description: The PEM-encoded private key of a deploy key without a passphrase, used when gitURLType is "ssh".
+	SshKey string `json:"sshKey,omitempty"`
+	// Token description: A GitLab personal, project or group access token with the read_repository scope, used when gitURLType is "http".
+	Token string `json:"token,omitempty"`
+}
 type GitLabNameTransformation struct {
 	// Regex description: The regex to match for the occurrences of its replacement.
 	Regex string `json:"regex,omitempty"`
@@ -781,6 +1017,8 @@ type GitLabWebhook struct {
 type GitoliteConnection struct {
 	// Exclude description: A list of repositories to never mirror from this Gitolite instance. Supports excluding by exact name ({"name": "foo"}).
 	Exclude []*ExcludedGitoliteRepo `json:"exclude,omitempty"`
+	// GitLFS description: Whether to fetch the Git LFS objects of repositories when they are cloned or updated. By default, LFS objects are skipped and only the LFS pointer files are available on Sourcegraph. Fetching LFS objects requires git-lfs to be installed on gitserver.
+	GitLFS string `json:"gitLFS,omitempty"`
 	// Host description: Gitolite host that stores the repositories (e.g., git@gitolite.example.com, ssh://git@gitolite.example.com:2222/).
 	Host string `json:"host"`
 	// Phabricator description: Phabricator instance that integrates with this Gitolite instance
@@ -804,6 +1042,18 @@ type HTTPHeaderAuthProvider struct {
 	UsernameHeader string `json:"usernameHeader"`
 }
 
+// HttpCompression description: Compression of HTTP responses, per route. Each route lists the encodings its responses may be compressed with, in order of preference. The first encoding of the list that the client accepts (according to its Accept-Encoding request header) is used, and an empty list disables compression of the route's responses. Routes that are not set compress with brotli or gzip.
+type HttpCompression struct {
+	// Default description: The encodings of the responses of all other routes.
+	Default []string `json:"default,omitempty"`
+	// Graphql description: The encodings of GraphQL API responses (/.api/graphql).
+	Graphql []string `json:"graphql,omitempty"`
+	// Raw description: The encodings of raw file and archive responses (/<repository>/-/raw/<path>). Zip archives are never compressed again.
+	Raw []string `json:"raw,omitempty"`
+	// SearchStream description: The encodings of streaming search results (/.api/search/stream). Events are compressed as they are sent, so results still arrive incrementally.
+	SearchStream []string `json:"searchStream,omitempty"`
+}
+
 // IdentityProvider description: The source of identity to use when computing permissions. This defines how to compute the GitLab identity to use for a given Sourcegraph user.
 type IdentityProvider struct {
 	Oauth    *OAuthIdentity
@@ -916,6 +1166,30 @@ type MountedEncryptionKey struct {
 	Version    string `json:"version,omitempty"`
 }
 
+// NetworkAccessPathRule description: An access rule for requests whose path starts with a prefix.
+type NetworkAccessPathRule struct {
+	// Allow description: IP addresses or CIDR ranges that may access matching paths. If set, requests from all other addresses are rejected.
+	Allow []string `json:"allow,omitempty"`
+	// Deny description: IP addresses or CIDR ranges that may not access matching paths. Takes precedence over allow.
+	Deny []string `json:"deny,omitempty"`
+	// DenyAnonymous description: Rejects requests to matching paths from users who are not signed in. Sign-in pages and static assets are always accessible.
+	DenyAnonymous bool `json:"denyAnonymous,omitempty"`
+	// PathPrefix description: The path prefix the rule applies to, such as "/.api/" or "/site-admin".
+	PathPrefix string `json:"pathPrefix"`
+}
+
+// NetworkAccessPolicy description: Restricts which client IP addresses may access Sourcegraph, and which paths anonymous users may access. Use this when Sourcegraph cannot be placed behind a web application firewall. Health checks (/healthz) are never restricted.
+type NetworkAccessPolicy struct {
+	// Allow description: IP addresses or CIDR ranges that may access Sourcegraph. If set, requests from all other addresses are rejected.
+	Allow []string `json:"allow,omitempty"`
+	// Deny description: IP addresses or CIDR ranges that may not access Sourcegraph. Takes precedence over allow.
+	Deny []string `json:"deny,omitempty"`
+	// PathRules description: Additional rules for requests to specific paths. The first rule whose pathPrefix matches the request path applies, on top of the allow and deny lists above.
+	PathRules []*NetworkAccessPathRule `json:"pathRules,omitempty"`
+	// TrustedProxies description: IP addresses or CIDR ranges of the reverse proxies and load balancers in front of Sourcegraph. For requests from these addresses, the client IP address is read from the X-Forwarded-For header. For all other requests, the address of the connection is used. If Sourcegraph is behind a proxy that is not listed here, all requests appear to come from the proxy.
+	TrustedProxies []string `json:"trustedProxies,omitempty"`
+}
+
 // NoOpEncryptionKey description: This encryption key is a no op, leaving your data in plaintext (not recommended).
 type NoOpEncryptionKey struct {
 	Type string `json:"type"`
@@ -1077,6 +1351,10 @@ type OpenIDConnectAuthProvider struct {
 	// ConfigID description: An identifier that can be used to reference this authentication provider in other parts of the config. For example, in configuration for a code host, you may want to designate this authentication provider as the identity provider for the code host.
 	ConfigID    string `json:"configID,omitempty"`
 	DisplayName string `json:"displayName,omitempty"`
+	// GroupMappings description: Rules that assign organization membership and the site admin role based on the groups of the user in the groupsClaim. They are applied when the user signs in, and re-applied periodically with the groups of the last sign-in. Membership of the organizations listed in any rule is managed by these rules: users are added to and removed from them according to their groups. If any rule sets siteAdmin, the site admin role of users of this provider is managed by the rules as well.
+	GroupMappings []*OpenIDConnectGroupMapping `json:"groupMappings,omitempty"`
+	// GroupsClaim description: The name of the claim of the ID token or userinfo response that contains the groups of the user (example: groups). Required for groupMappings.
+	GroupsClaim string `json:"groupsClaim,omitempty"`
 	// Issuer description: The URL of the OpenID Connect issuer.
 	//
 	// For Google Apps: https://accounts.google.com
@@ -1086,9 +1364,23 @@ type OpenIDConnectAuthProvider struct {
 	Type               string `json:"type"`
 }
 
+// OpenIDConnectGroupMapping description: Assigns organization membership and the site admin role to users that are members of a group of the OpenID Connect provider.
+type OpenIDConnectGroupMapping struct {
+	// Group description: The name of the group, as listed in the groups claim.
+	Group string `json:"group"`
+	// Orgs description: The names of the organizations that members of the group are added to.
+	Orgs []string `json:"orgs,omitempty"`
+	// SiteAdmin description: Whether members of the group are site admins.
+	SiteAdmin bool `json:"siteAdmin,omitempty"`
+}
+
 // OtherExternalServiceConnection description: Configuration for a Connection to Git repositories for which an external service integration isn't yet available.
 type OtherExternalServiceConnection struct {
-	Repos []string `json:"repos"`
+	// Exclude description: A list of repositories to never mirror from this code host, even if listed in "repos". Supports excluding by glob ({"glob": "git.example.com/archive/**"}) or regular expression ({"regex": "-mirror$"}) on the name of the repository on Sourcegraph.
+	Exclude []*ExcludedOtherRepo `json:"exclude,omitempty"`
+	// GitLFS description: Whether to fetch the Git LFS objects of repositories when they are cloned or updated. By default, LFS objects are skipped and only the LFS pointer files are available on Sourcegraph. Fetching LFS objects requires git-lfs to be installed on gitserver.
+	GitLFS string   `json:"gitLFS,omitempty"`
+	Repos  []string `json:"repos"`
 	// RepositoryPathPattern description: The pattern used to generate the corresponding Sourcegraph repository name for the repositories. In the pattern, the variable "{base}" is replaced with the Git clone base URL host and path, and "{repo}" is replaced with the repository path taken from the `repos` field.
 	//
 	// For example, if your Git clone base URL is https://git.example.com/repos and `repos` contains the value "my/repo", then a repositoryPathPattern of "{base}/{repo}" would mean that a repository at https://git.example.com/repos/my/repo is available on Sourcegraph at https://sourcegraph.example.com/git.example.com/repos/my/repo.
@@ -1119,6 +1411,8 @@ type PerforceConnection struct {
 	Authorization *PerforceAuthorization `json:"authorization,omitempty"`
 	// Depots description: Depots can have arbitrary paths, e.g. a path to depot root or a subdirectory.
 	Depots []string `json:"depots,omitempty"`
+	// Exclude description: A list of depots to never mirror from this Perforce Server, even if listed in "depots". Supports excluding by glob ({"glob": "perforce/archive/*"}) or regular expression ({"regex": "-old$"}) on the name of the repository on Sourcegraph.
+	Exclude []*ExcludedPerforceDepot `json:"exclude,omitempty"`
 	// MaxChanges description: Only import at most n changes when possible (git p4 clone --max-changes).
 	MaxChanges float64 `json:"maxChanges,omitempty"`
 	// P4Passwd description: The ticket value for the user (P4PASSWD).
@@ -1163,6 +1457,8 @@ type Phabricator struct {
 
 // PhabricatorConnection description: Configuration for a connection to Phabricator.
 type PhabricatorConnection struct {
+	// Exclude description: A list of repositories to never mirror from this Phabricator instance, even if listed in "repos". Supports excluding by glob ({"glob": "gitolite/archive/*"}) or regular expression ({"regex": "-old$"}) on the name of the repository on Sourcegraph.
+	Exclude []*ExcludedPhabricatorRepo `json:"exclude,omitempty"`
 	// Repos description: The list of repositories available on Phabricator.
 	Repos []*Repos `json:"repos,omitempty"`
 	// Token description: API token for the Phabricator instance.
@@ -1199,10 +1495,19 @@ type Responders struct {
 	Username string `json:"username,omitempty"`
 }
 
+// SAMLAccountLinking description: Rules for linking SAML identities to existing Sourcegraph accounts.
+type SAMLAccountLinking struct {
+	// MatchVerifiedEmail description: Link a SAML identity that is not yet linked to a Sourcegraph account to the account with a verified email address equal to the email address of the SAML identity. If false, such identities are only used to sign up for new accounts (see `allowSignup`) and must otherwise be linked by a signed-in user.
+	MatchVerifiedEmail *bool `json:"matchVerifiedEmail,omitempty"`
+	// OnConflict description: What to do when a signed-in user signs in with a SAML identity that is linked to another Sourcegraph account. With "prompt", the user is asked whether to continue as the account the identity is linked to or to stay signed in as the current user. With "error", the sign-in fails.
+	OnConflict string `json:"onConflict,omitempty"`
+}
+
 // SAMLAuthProvider description: Configures the SAML authentication provider for SSO.
 //
-// Note: if you are using IdP-initiated login, you must have *at most one* SAMLAuthProvider in the `auth.providers` array.
+// Note: if you are using IdP-initiated login with multiple SAMLAuthProviders in the `auth.providers` array, each of them must use a different SAML Identity Provider.
 type SAMLAuthProvider struct {
+	AccountLinking *SAMLAccountLinking `json:"accountLinking,omitempty"`
 	// AllowSignup description: Allows new visitors to sign up for accounts via SAML authentication. If false, users signing in via SAML must have an existing Sourcegraph account, which will be linked to their SAML identity after sign-in.
 	AllowSignup *bool `json:"allowSignup,omitempty"`
 	// ConfigID description: An identifier that can be used to reference this authentication provider in other parts of the config. For example, in configuration for a code host, you may want to designate this authentication provider as the identity provider for the code host.
@@ -1247,6 +1552,34 @@ type SMTPServerConfig struct {
 	Username string `json:"username,omitempty"`
 }
 
+// SearchAlertMessage description: The translated text of a search alert.
+type SearchAlertMessage struct {
+	// Description description: The text/template for the alert description.
+	Description string `json:"description,omitempty"`
+	// ProposedQueries description: Translations of the descriptions of the queries proposed by the alert, keyed by their English description (such as "query with longer timeout").
+	ProposedQueries map[string]string `json:"proposedQueries,omitempty"`
+	// Title description: The text/template for the alert title.
+	Title string `json:"title,omitempty"`
+}
+
+// SearchIndexOnDemand description: Index rarely searched repositories on demand. When enabled, only repositories matching alwaysIndex are continuously indexed. Other repositories are searched with the unindexed search backend and queued for indexing when they are searched. The least recently searched on-demand repositories are removed from the index once more than maxRepos are queued.
+type SearchIndexOnDemand struct {
+	// AlwaysIndex description: Regular expressions matching the names of repositories that are always indexed.
+	AlwaysIndex []string `json:"alwaysIndex,omitempty"`
+	// Enabled description: Enables indexing repositories on demand.
+	Enabled bool `json:"enabled,omitempty"`
+	// MaxRepos description: The maximum number of repositories indexed on demand. When exceeded, the least recently searched repositories are removed from the index.
+	MaxRepos int `json:"maxRepos,omitempty"`
+}
+
+// SearchIndexTrafficPriority description: Reindex frequently searched repositories first. When enabled, Sourcegraph counts how often each repository is searched, and the indexed search backend checks the most frequently searched repositories for changes before the others instead of in a fixed order. Older searches count less: a search counts half as much after each halfLifeHours.
+type SearchIndexTrafficPriority struct {
+	// Enabled description: Enables ordering the indexing queue by search traffic.
+	Enabled bool `json:"enabled,omitempty"`
+	// HalfLifeHours description: The number of hours after which a search counts half as much.
+	HalfLifeHours int `json:"halfLifeHours,omitempty"`
+}
+
 // SearchLimits description: Limits that search applies for number of repositories searched and timeouts.
 type SearchLimits struct {
 	// CommitDiffMaxRepos description: The maximum number of repositories to search across when doing a "type:diff" or "type:commit". The user is prompted to narrow their query if the limit is exceeded. There is a separate limit (commitDiffWithTimeFilterMaxRepos) when "after:" or "before:" is specified because those queries are faster. Defaults to 50.
@@ -1263,9 +1596,9 @@ type SearchSavedQueries struct {
 	Description string `json:"description"`
 	// Key description: Unique key for this query in this file
 	Key string `json:"key"`
-	// Notify description: Notify the owner of this configuration file when new results are available
+	// Notify description: DEPRECATED: Use code monitors with email actions instead. Notify the owner of this configuration file when new results are available
 	Notify bool `json:"notify,omitempty"`
-	// NotifySlack description: Notify Slack via the organization's Slack webhook URL when new results are available
+	// NotifySlack description: DEPRECATED: Use code monitors with Slack webhook actions instead. Notify Slack via the organization's Slack webhook URL when new results are available
 	NotifySlack bool `json:"notifySlack,omitempty"`
 	// Query description: Query string
 	Query string `json:"query"`
@@ -1310,7 +1643,7 @@ type Settings struct {
 	InsightsDisplayLocationDirectory    *bool                       `json:"insights.displayLocation.directory,omitempty"`
 	InsightsDisplayLocationHomepage     *bool                       `json:"insights.displayLocation.homepage,omitempty"`
 	InsightsDisplayLocationInsightsPage *bool                       `json:"insights.displayLocation.insightsPage,omitempty"`
-	// Motd description: DEPRECATED: Use `notices` instead.
+	// Motd description: DEPRECATED: Use announcements (managed by site admins with the `createAnnouncement` GraphQL mutation) or `notices` instead.
 	//
 	// An array (often with just one element) of messages to display at the top of all pages, including for unauthenticated users. Users may dismiss a message (and any message with the same string value will remain dismissed for the user).
 	//
@@ -1332,6 +1665,8 @@ type Settings struct {
 	SearchDefaultCaseSensitive bool `json:"search.defaultCaseSensitive,omitempty"`
 	// SearchDefaultPatternType description: The default pattern type (literal or regexp) that search queries will be intepreted as.
 	SearchDefaultPatternType string `json:"search.defaultPatternType,omitempty"`
+	// SearchDismissedAlerts description: The keys of the onboarding search alerts (such as "no-repositories-configured", "indexing-in-progress" and "default-branch-missing") that the user has dismissed. Dismissed alerts are no longer shown with search results.
+	SearchDismissedAlerts []string `json:"search.dismissedAlerts,omitempty"`
 	// SearchGlobbing description: Enables globbing for supported field values
 	SearchGlobbing *bool `json:"search.globbing,omitempty"`
 	// SearchHideSuggestions description: Disable search suggestions below the search bar when constructing queries. Defaults to false.
@@ -1410,6 +1745,8 @@ type SiteConfiguration struct {
 	AuthAccessTokens *AuthAccessTokens `json:"auth.accessTokens,omitempty"`
 	// AuthEnableUsernameChanges description: Enables users to change their username after account creation. Warning: setting this to be true has security implications if you have enabled (or will at any point in the future enable) repository permissions with an option that relies on username equivalency between Sourcegraph and an external service or authentication provider. Do NOT set this to true if you are using non-built-in authentication OR rely on username equivalency for repository permissions.
 	AuthEnableUsernameChanges bool `json:"auth.enableUsernameChanges,omitempty"`
+	// AuthImpersonation description: Allows site admins to view Sourcegraph as another user for debugging, e.g. to troubleshoot repository permissions. Impersonation sessions are short-lived and restricted to read operations. Starting and stopping an impersonation, as well as all GraphQL requests made while impersonating, are recorded in the security event log with both the site admin and the impersonated user.
+	AuthImpersonation *AuthImpersonation `json:"auth.impersonation,omitempty"`
 	// AuthMinPasswordLength description: The minimum number of Unicode code points that a password must contain.
 	AuthMinPasswordLength int `json:"auth.minPasswordLength,omitempty"`
 	// AuthPasswordResetLinkExpiry description: The duration (in seconds) that a password reset link is considered valid.
@@ -1438,6 +1775,8 @@ type SiteConfiguration struct {
 	AuthzEnforceForSiteAdmins bool `json:"authz.enforceForSiteAdmins,omitempty"`
 	// BatchChangesEnabled description: Enables/disables the Batch Changes feature.
 	BatchChangesEnabled *bool `json:"batchChanges.enabled,omitempty"`
+	// BatchChangesExcludedRepositories description: Repositories that batch changes must never publish changesets to, such as repositories frozen for compliance reasons. Batch specs that would create changesets in a matching repository are rejected when they are created or applied, regardless of the permissions of the user. Existing changesets can still be tracked.
+	BatchChangesExcludedRepositories []*BatchChangesExcludedRepository `json:"batchChanges.excludedRepositories,omitempty"`
 	// BatchChangesRestrictToAdmins description: When enabled, only site admins can create and apply batch changes.
 	BatchChangesRestrictToAdmins *bool `json:"batchChanges.restrictToAdmins,omitempty"`
 	// BatchChangesRolloutWindows description: Specifies specific windows, which can have associated rate limits, to be used when publishing changesets. All days and times are handled in UTC.
@@ -1474,6 +1813,8 @@ type SiteConfiguration struct {
 	EmailAddress string `json:"email.address,omitempty"`
 	// EmailSmtp description: The SMTP server used to send transactional emails (such as email verifications, reset-password emails, and notifications).
 	EmailSmtp *SMTPServerConfig `json:"email.smtp,omitempty"`
+	// EmailTemplates description: Overrides for the subject and body templates of transactional emails, keyed by email type (such as "resetPassword" or "verifyEmail"). Fields that are omitted use the built-in template. Templates use Go template syntax and have access to the same data as the built-in template, plus the `brandName` function.
+	EmailTemplates map[string]EmailTemplate `json:"email.templates,omitempty"`
 	// EncryptionKeys description: Configuration for encryption keys used to encrypt data at rest in the database.
 	EncryptionKeys *EncryptionKeys `json:"encryption.keys,omitempty"`
 	// ExperimentalFeatures description: Experimental features to enable or disable. Features that are now enabled by default are marked as deprecated.
@@ -1486,6 +1827,8 @@ type SiteConfiguration struct {
 	ExternalURL string `json:"externalURL,omitempty"`
 	// GitCloneURLToRepositoryName description: JSON array of configuration that maps from Git clone URL to repository name. Sourcegraph automatically resolves remote clone URLs to their proper code host. However, there may be non-remote clone URLs (e.g., in submodule declarations) that Sourcegraph cannot automatically map to a code host. In this case, use this field to specify the mapping. The mappings are tried in the order they are specified and take precedence over automatic mappings.
 	GitCloneURLToRepositoryName []*CloneURLToRepositoryName `json:"git.cloneURLToRepositoryName,omitempty"`
+	// GitExternalServiceUpdateLimits description: JSON array of per external service limits for the git update scheduler. Repositories waiting to be cloned or updated are queued fairly across the external services they belong to, so that a large initial sync of one code host connection does not starve others. The weight controls the relative share of updates given to an external service and maxConcurrentUpdates caps the number of concurrent updates for it. External services that are not listed have a weight of 1 and are only limited by gitMaxConcurrentClones.
+	GitExternalServiceUpdateLimits []*ExternalServiceUpdateLimit `json:"gitExternalServiceUpdateLimits,omitempty"`
 	// GitMaxCodehostRequestsPerSecond description: Maximum number of remote code host git operations (e.g. clone or ls-remote) to be run per second per gitserver. Default is -1, which is unlimited.
 	GitMaxCodehostRequestsPerSecond *int `json:"gitMaxCodehostRequestsPerSecond,omitempty"`
 	// GitMaxConcurrentClones description: Maximum number of git clone processes that will be run concurrently per gitserver to update repositories. Note: the global git update scheduler respects gitMaxConcurrentClones. However, we allow each gitserver to run upto gitMaxConcurrentClones to allow for urgent fetches. Urgent fetches are used when a user is browsing a PR and we do not have the commit yet.
@@ -1504,6 +1847,8 @@ type SiteConfiguration struct {
 	HtmlHeadBottom string `json:"htmlHeadBottom,omitempty"`
 	// HtmlHeadTop description: HTML to inject at the top of the `<head>` element on each page, for analytics scripts
 	HtmlHeadTop string `json:"htmlHeadTop,omitempty"`
+	// HttpCompression description: Compression of HTTP responses, per route. Each route lists the encodings its responses may be compressed with, in order of preference. The first encoding of the list that the client accepts (according to its Accept-Encoding request header) is used, and an empty list disables compression of the route's responses. Routes that are not set compress with brotli or gzip.
+	HttpCompression *HttpCompression `json:"http.compression,omitempty"`
 	// InsightsHistoricalFrameLength description: (debug) duration of historical insights timeframes, one point per repository will be recorded in each timeframe.
 	InsightsHistoricalFrameLength string `json:"insights.historical.frameLength,omitempty"`
 	// InsightsHistoricalFrames description: (debug) number of historical insights timeframes to populate
@@ -1522,8 +1867,12 @@ type SiteConfiguration struct {
 	Log *Log `json:"log,omitempty"`
 	// LsifEnforceAuth description: Whether or not LSIF uploads will be blocked unless a valid LSIF upload token is provided.
 	LsifEnforceAuth bool `json:"lsifEnforceAuth,omitempty"`
+	// MaintenanceMode description: Pauses background writers, such as repository syncing, batch change reconciliation and code intelligence processing, while keeping the instance available for reads. Jobs that are already running are completed. Use this while performing database maintenance. Maintenance mode can also be enabled with the SRC_MAINTENANCE_MODE environment variable.
+	MaintenanceMode bool `json:"maintenanceMode,omitempty"`
 	// MaxReposToSearch description: DEPRECATED: Configure maxRepos in search.limits. The maximum number of repositories to search across. The user is prompted to narrow their query if exceeded. Any value less than or equal to zero means unlimited.
 	MaxReposToSearch int `json:"maxReposToSearch,omitempty"`
+	// NetworkAccessPolicy description: Restricts which client IP addresses may access Sourcegraph, and which paths anonymous users may access. Use this when Sourcegraph cannot be placed behind a web application firewall. Health checks (/healthz) are never restricted.
+	NetworkAccessPolicy *NetworkAccessPolicy `json:"networkAccessPolicy,omitempty"`
 	// ObservabilityAlerts description: Configure notifications for Sourcegraph's built-in alerts.
 	ObservabilityAlerts []*ObservabilityAlerts `json:"observability.alerts,omitempty"`
 	// ObservabilityLogSlowGraphQLRequests description: (debug) logs all GraphQL requests slower than the specified number of milliseconds.
@@ -1544,6 +1893,10 @@ type SiteConfiguration struct {
 	RepoConcurrentExternalServiceSyncers int `json:"repoConcurrentExternalServiceSyncers,omitempty"`
 	// RepoListUpdateInterval description: Interval (in minutes) for checking code hosts (such as GitHub, Gitolite, etc.) for new repositories.
 	RepoListUpdateInterval int `json:"repoListUpdateInterval,omitempty"`
+	// RepoRestoreWindow description: The duration (in hours) for which repositories that were deleted, for example because they are no longer returned by their code host, can be restored along with their code intelligence data. After the restore window, the code intelligence data of deleted repositories is removed.
+	RepoRestoreWindow int `json:"repoRestoreWindow,omitempty"`
+	// SearchAlertMessages description: Translations of the alerts shown to users when a search returns no or incomplete results, keyed by locale (a BCP 47 language tag such as "de" or "pt-BR") and then by alert type (such as "timed_out" or "no_resolved_repos__generic"). The locale is chosen from the Accept-Language header of the request. Alerts without a translation are shown in English. Titles and descriptions use Go template syntax and have access to the parameters of the alert, such as `{{.Duration}}` for "timed_out".
+	SearchAlertMessages map[string]map[string]SearchAlertMessage `json:"search.alertMessages,omitempty"`
 	// SearchIndexEnabled description: Whether indexed search is enabled. If unset Sourcegraph detects the environment to decide if indexed search is enabled. Indexed search is RAM heavy, and is disabled by default in the single docker image. All other environments will have it enabled by default. The size of all your repository working copies is the amount of additional RAM required.
 	SearchIndexEnabled *bool `json:"search.index.enabled,omitempty"`
 	// SearchIndexSymbolsEnabled description: Whether indexed symbol search is enabled. This is contingent on the indexed search configuration, and is true by default for instances with indexed search enabled. Enabling this will cause every repository to re-index, which is a time consuming (several hours) operation. Additionally, it requires more storage and ram to accommodate the added symbols information in the search index.
@@ -1552,10 +1905,16 @@ type SiteConfiguration struct {
 	SearchLargeFiles []string `json:"search.largeFiles,omitempty"`
 	// SearchLimits description: Limits that search applies for number of repositories searched and timeouts.
 	SearchLimits *SearchLimits `json:"search.limits,omitempty"`
+	// SearchMaxUserTimeout description: The maximum value of "timeout:" that users may set in search queries, as a duration such as "90s" or "2m". Queries with a larger "timeout:" value are rejected with an alert instead of being run, and search alerts never suggest a longer timeout. If unset, "timeout:" values are only capped at search.limits.maxTimeoutSeconds.
+	SearchMaxUserTimeout string `json:"search.maxUserTimeout,omitempty"`
 	// UpdateChannel description: The channel on which to automatically check for Sourcegraph updates.
 	UpdateChannel string `json:"update.channel,omitempty"`
 	// UseJaeger description: DEPRECATED. Use `"observability.tracing": { "sampling": "all" }`, instead. Enables Jaeger tracing.
 	UseJaeger bool `json:"useJaeger,omitempty"`
+	// UserReposMaxCloneSizeBytesPerUser description: The per user maximum total size in bytes of the clones of the repos that can be added by non site admins. Once the limit is reached, no more repos are added for the user. If 0 or unset, there is no limit.
+	UserReposMaxCloneSizeBytesPerUser int `json:"userRepos.maxCloneSizeBytesPerUser,omitempty"`
+	// UserReposMaxExternalServicesPerUser description: The per user maximum number of code host connections that can be added by non site admins. Repositories of code host connections over the limit are not synced. If 0 or unset, there is no limit.
+	UserReposMaxExternalServicesPerUser int `json:"userRepos.maxExternalServicesPerUser,omitempty"`
 	// UserReposMaxPerSite description: The site wide maximum number of repos that can be added by non site admins
 	UserReposMaxPerSite int `json:"userRepos.maxPerSite,omitempty"`
 	// UserReposMaxPerUser description: The per user maximum number of repos that can be added by non site admins
@@ -1578,6 +1937,14 @@ type Step struct {
 	Run string `json:"run"`
 }
 
+// SubRepoPermissions description: Enables sub-repository permissions, restricting access to paths within a repository according to rules returned by authz providers.
+type SubRepoPermissions struct {
+	// Enabled description: Enables sub-repository permission checks.
+	Enabled bool `json:"enabled,omitempty"`
+	// UserCacheTTLSeconds description: The number of seconds a user's sub-repository permissions are cached for before being reloaded from the database.
+	UserCacheTTLSeconds int `json:"userCacheTTLSeconds,omitempty"`
+}
+
 // TlsExternal description: Global TLS/SSL settings for Sourcegraph to use when communicating with code hosts.
 type TlsExternal struct {
 	// Certificates description: TLS certificates to accept. This is only necessary if you are using self-signed certificates or an internal CA. Can be an internal CA certificate or a self-signed certificate. To get the certificate of a webserver run `openssl s_client -connect HOST:443 -showcerts < /dev/null 2> /dev/null | openssl x509 -outform PEM`. To escape the value into a JSON string, you may want to use a tool like https://json-escape-text.now.sh.